@@ -81,6 +81,7 @@ import (
 	migrationproxy "kubevirt.io/kubevirt/pkg/virt-handler/migration-proxy"
 	nodelabeller "kubevirt.io/kubevirt/pkg/virt-handler/node-labeller"
 	nodelabellerutil "kubevirt.io/kubevirt/pkg/virt-handler/node-labeller/util"
+	nodeshutdown "kubevirt.io/kubevirt/pkg/virt-handler/node-shutdown"
 	"kubevirt.io/kubevirt/pkg/virt-handler/rest"
 	"kubevirt.io/kubevirt/pkg/virt-handler/selinux"
 	virt_api "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
@@ -238,7 +239,8 @@ func (app *virtHandlerApp) Run() {
 	broadcaster := record.NewBroadcaster()
 	broadcaster.StartRecordingToSink(&k8coresv1.EventSinkImpl{Interface: app.virtCli.CoreV1().Events(k8sv1.NamespaceAll)})
 	// Scheme is used to create an ObjectReference from an Object (e.g. VirtualMachineInstance) during Event creation
-	recorder := broadcaster.NewRecorder(scheme.Scheme, k8sv1.EventSource{Component: "virt-handler", Host: app.HostOverride})
+	// Identical events are deduplicated to not flood etcd with the events of a single broken VMI
+	recorder := controller.NewDedupingEventRecorder(broadcaster.NewRecorder(scheme.Scheme, k8sv1.EventSource{Component: "virt-handler", Host: app.HostOverride}), controller.DefaultEventDedupWindow)
 
 	// Wire VirtualMachineInstance controller
 	factory := controller.NewKubeInformerFactory(app.virtCli.RestClient(), app.virtCli, nil, app.namespace)
@@ -323,6 +325,16 @@ func (app *virtHandlerApp) Run() {
 		}
 	}
 
+	// VMIs are evacuated on the shutdown of the node, if systemd-logind is reachable
+	var nodeShutdownStatus nodeshutdown.NodeShutdownStatus
+	var nodeShutdownManager *nodeshutdown.NodeShutdownManager
+	if inhibitor, err := nodeshutdown.NewLogindInhibitor(); err != nil {
+		logger.Reason(err).Warning("Not protecting VMIs from the shutdown of the node")
+	} else {
+		nodeShutdownManager = nodeshutdown.NewNodeShutdownManager(inhibitor, app.virtCli, app.clusterConfig, vmiSourceInformer.GetStore(), app.HostOverride)
+		nodeShutdownStatus = nodeShutdownManager
+	}
+
 	vmController := virthandler.NewController(
 		recorder,
 		app.virtCli,
@@ -340,6 +352,7 @@ func (app *virtHandlerApp) Run() {
 		podIsolationDetector,
 		migrationProxy,
 		capabilities,
+		nodeShutdownStatus,
 	)
 
 	promErrCh := make(chan error)
@@ -355,7 +368,7 @@ func (app *virtHandlerApp) Run() {
 		app.VirtShareDir,
 	)
 
-	promdomain.SetupDomainStatsCollector(app.virtCli, app.VirtShareDir, app.HostOverride, app.MaxRequestsInFlight, vmiSourceInformer)
+	promdomain.SetupDomainStatsCollector(app.virtCli, app.VirtShareDir, app.HostOverride, app.MaxRequestsInFlight, vmiSourceInformer, app.clusterConfig)
 	if err := downwardmetrics.RunDownwardMetricsCollector(context.Background(), app.HostOverride, vmiSourceInformer, podIsolationDetector); err != nil {
 		panic(fmt.Errorf("failed to set up the downwardMetrics collector: %v", err))
 	}
@@ -366,6 +379,7 @@ func (app *virtHandlerApp) Run() {
 	// Bootstrapping. From here on the startup order matters
 
 	factory.Start(stop)
+	go recorder.Run(stop)
 	go gracefulShutdownInformer.Run(stop)
 	go domainSharedInformer.Run(stop)
 
@@ -392,6 +406,9 @@ func (app *virtHandlerApp) Run() {
 	cache.WaitForCacheSync(stop, factory.ConfigMap().HasSynced, vmiSourceInformer.HasSynced, factory.CRD().HasSynced)
 
 	go vmController.Run(10, stop)
+	if nodeShutdownManager != nil {
+		go nodeShutdownManager.Run(stop)
+	}
 
 	doneCh := make(chan string)
 	defer close(doneCh)
@@ -484,9 +501,14 @@ func (app *virtHandlerApp) runServer(errCh chan error, consoleHandler *rest.Cons
 	ws.Route(ws.PUT("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/unpause").To(lifecycleHandler.UnpauseHandler))
 	ws.Route(ws.PUT("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/freeze").To(lifecycleHandler.FreezeHandler))
 	ws.Route(ws.PUT("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/unfreeze").To(lifecycleHandler.UnfreezeHandler))
+	ws.Route(ws.PUT("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/memorysnapshot").To(lifecycleHandler.MemorySnapshotHandler))
+	ws.Route(ws.PUT("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/setlinkstate").To(lifecycleHandler.SetLinkStateHandler))
 	ws.Route(ws.GET("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/guestosinfo").To(lifecycleHandler.GetGuestInfo).Produces(restful.MIME_JSON).Consumes(restful.MIME_JSON).Returns(http.StatusOK, "OK", v1.VirtualMachineInstanceGuestAgentInfo{}))
 	ws.Route(ws.GET("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/userlist").To(lifecycleHandler.GetUsers).Produces(restful.MIME_JSON).Consumes(restful.MIME_JSON).Returns(http.StatusOK, "OK", v1.VirtualMachineInstanceGuestOSUserList{}))
 	ws.Route(ws.GET("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/filesystemlist").To(lifecycleHandler.GetFilesystems).Produces(restful.MIME_JSON).Consumes(restful.MIME_JSON).Returns(http.StatusOK, "OK", v1.VirtualMachineInstanceFileSystemList{}))
+	ws.Route(ws.GET("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/usage").To(lifecycleHandler.GetUsage).Produces(restful.MIME_JSON).Consumes(restful.MIME_JSON).Returns(http.StatusOK, "OK", v1.VirtualMachineInstanceUsage{}))
+	// virt-api fetches the screenshot with the JSON accept header of all its requests to virt-handler
+	ws.Route(ws.GET("/v1/namespaces/{namespace}/virtualmachineinstances/{name}/screenshot").To(lifecycleHandler.GetScreenshot).Produces("image/png", restful.MIME_JSON))
 	restful.DefaultContainer.Add(ws)
 	server := &http.Server{
 		Addr:    fmt.Sprintf("%s:%d", app.ServiceListen.BindAddress, app.consoleServerPort),
@@ -549,6 +571,10 @@ func (app *virtHandlerApp) AddFlags() {
 	// This should be deprecated if the API allows for shared resources in the future
 	flag.IntVar(&app.MaxDevices, "max-devices", maxDevices,
 		"Number of devices to register with Kubernetes device plugin framework")
+	// keep the flag name virt-operator used to pass, for virt-handler DaemonSets which were not updated yet
+	flag.IntVar(&app.MaxDevices, "maxDevices", maxDevices,
+		"Number of devices to register with Kubernetes device plugin framework")
+	flag.CommandLine.MarkDeprecated("maxDevices", "use --max-devices instead")
 
 	flag.IntVar(&app.MaxRequestsInFlight, "max-metric-requests", maxRequestsInFlight,
 		"Number of concurrent requests to the metrics endpoint")
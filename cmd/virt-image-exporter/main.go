@@ -0,0 +1,94 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package main
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+
+	"github.com/spf13/pflag"
+
+	"kubevirt.io/client-go/log"
+	containerdisk "kubevirt.io/kubevirt/pkg/container-disk"
+)
+
+const terminationLog = "/dev/termination-log"
+
+func main() {
+	log.InitializeLogging("image-exporter")
+	log.Log.Info("Starting...")
+
+	diskPath := pflag.String("disk", "", "Path to the disk image or block device to export")
+	image := pflag.String("image", "", "Image to push the disk to, referenced by tag")
+	baseImage := pflag.String("base-image", "", "Image to add the disk layer on top of")
+	pushSecret := pflag.String("push-secret", "", "Path to a .dockerconfigjson with the registry credentials")
+
+	pflag.Parse()
+
+	if *diskPath == "" || *image == "" {
+		log.Log.Errorf("Both disk and image flags must be provided")
+		os.Exit(1)
+	}
+
+	var dockerConfig []byte
+	if *pushSecret != "" {
+		var err error
+		dockerConfig, err = ioutil.ReadFile(*pushSecret)
+		if err != nil {
+			log.Log.Reason(err).Error("Failed to read the push secret")
+			os.Exit(1)
+		}
+	}
+
+	disk, err := os.Open(*diskPath)
+	if err != nil {
+		log.Log.Reason(err).Error("Failed to open the disk")
+		os.Exit(1)
+	}
+	defer disk.Close()
+	// Seeking to the end also works for block devices, where stat reports no size
+	size, err := disk.Seek(0, io.SeekEnd)
+	if err != nil {
+		log.Log.Reason(err).Error("Failed to determine the size of the disk")
+		os.Exit(1)
+	}
+
+	pusher, err := containerdisk.NewImagePusher(&http.Client{}, dockerConfig)
+	if err != nil {
+		log.Log.Reason(err).Error("Failed to parse the push secret")
+		os.Exit(1)
+	}
+	digest, err := pusher.PushDisk(disk, size, *image, *baseImage)
+	if err != nil {
+		log.Log.Reason(err).Errorf("Pushing the disk to %s failed", *image)
+		ioutil.WriteFile(terminationLog, []byte(err.Error()), 0644)
+		os.Exit(1)
+	}
+
+	log.Log.Infof("Pushed the disk to %s@%s", *image, digest)
+	if err := ioutil.WriteFile(terminationLog, []byte(digest), 0644); err != nil {
+		log.Log.Reason(err).Error("Failed to write the digest to the termination log")
+		os.Exit(1)
+	}
+
+	log.Log.Info("Exiting...")
+}
@@ -62,6 +62,8 @@ var (
 	SysprepSourceDir = mountBaseDir + "/sysprep"
 	// SecretSourceDir represents a location where Secrets is attached to the pod
 	SecretSourceDir = mountBaseDir + "/secret"
+	// NetworkDiskSecretSourceDir represents a location where the credentials of network disks are attached to the pod
+	NetworkDiskSecretSourceDir = mountBaseDir + "/network-disk-secret"
 	// DownwardAPISourceDir represents a location where downwardapi is attached to the pod
 	DownwardAPISourceDir = mountBaseDir + "/downwardapi"
 	// ServiceAccountSourceDir represents the location where the ServiceAccount token is attached to the pod
@@ -83,6 +85,10 @@ var (
 	ServiceAccountDiskDir = mountBaseDir + "/service-account-disk"
 	// ServiceAccountDiskName represents the name of the ServiceAccount iso image
 	ServiceAccountDiskName = "service-account.iso"
+	// RestoreMemoryDir represents a location where the memory state of a restored snapshot is attached to the pod
+	RestoreMemoryDir = mountBaseDir + "/restore-memory"
+	// RestoreMemoryFile represents the memory state of a restored snapshot
+	RestoreMemoryFile = filepath.Join(RestoreMemoryDir, "disk.img")
 
 	createISOImage      = defaultCreateIsoImage
 	createEmptyISOImage = defaultCreateEmptyIsoImage
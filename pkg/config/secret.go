@@ -31,6 +31,11 @@ func GetSecretSourcePath(volumeName string) string {
 	return filepath.Join(SecretSourceDir, volumeName)
 }
 
+// GetNetworkDiskSecretSourcePath returns a path to the credentials of a network disk mounted on a pod
+func GetNetworkDiskSecretSourcePath(volumeName string) string {
+	return filepath.Join(NetworkDiskSecretSourceDir, volumeName)
+}
+
 // GetSecretDiskPath returns a path to Secret iso image created based on volume name
 func GetSecretDiskPath(volumeName string) string {
 	return filepath.Join(SecretDisksDir, volumeName+".iso")
@@ -0,0 +1,293 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package containerdisk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	dockerHubRegistry     = "docker.io"
+	dockerHubRegistryHost = "registry-1.docker.io"
+
+	digestResolveTimeout = 5 * time.Second
+)
+
+var (
+	digestRegexp     = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+	repositoryRegexp = regexp.MustCompile(`^[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*$`)
+	tagRegexp        = regexp.MustCompile(`^[\w][\w.-]{0,127}$`)
+)
+
+var manifestMediaTypes = []string{
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.oci.image.manifest.v1+json",
+}
+
+// ImageDigestResolver resolves an image referenced by tag to a reference pinned to the digest of its manifest.
+type ImageDigestResolver interface {
+	ResolveImageDigest(ctx context.Context, image string) (string, error)
+}
+
+type registryClient struct {
+	client *http.Client
+}
+
+// NewImageDigestResolver returns a resolver which looks up the manifest digest anonymously with the registry API.
+func NewImageDigestResolver() ImageDigestResolver {
+	return NewImageDigestResolverWithClient(&http.Client{Timeout: digestResolveTimeout})
+}
+
+func NewImageDigestResolverWithClient(client *http.Client) ImageDigestResolver {
+	return &registryClient{client: client}
+}
+
+// ImageDigest returns the digest an image is referenced by, or an empty string if it is referenced by tag.
+func ImageDigest(image string) string {
+	if idx := strings.LastIndex(image, "@"); idx != -1 {
+		return image[idx+1:]
+	}
+	return ""
+}
+
+// IsValidImageDigest checks whether the digest is a sha256 digest as used by image registries.
+func IsValidImageDigest(digest string) bool {
+	return digestRegexp.MatchString(digest)
+}
+
+// ValidateImageReference checks whether the image is a valid reference by tag or by digest
+func ValidateImageReference(image string) error {
+	name := image
+	if digest := ImageDigest(image); digest != "" {
+		if !IsValidImageDigest(digest) {
+			return fmt.Errorf("invalid digest '%s', expected a sha256 digest", digest)
+		}
+		name = image[:strings.LastIndex(image, "@")]
+	}
+	_, repository, tag := parseImageReference(name)
+	if !repositoryRegexp.MatchString(repository) {
+		return fmt.Errorf("invalid repository '%s'", repository)
+	}
+	if !tagRegexp.MatchString(tag) {
+		return fmt.Errorf("invalid tag '%s'", tag)
+	}
+	return nil
+}
+
+// splitImageReference splits an image into the registry host, the repository and the digest or tag
+func splitImageReference(image string) (host string, repository string, reference string) {
+	if digest := ImageDigest(image); digest != "" {
+		host, repository, _ = parseImageReference(image[:strings.LastIndex(image, "@")])
+		return host, repository, digest
+	}
+	return parseImageReference(image)
+}
+
+// parseImageReference splits an image referenced by tag into the registry host, the repository and the tag.
+func parseImageReference(image string) (host string, repository string, tag string) {
+	name := image
+	tag = "latest"
+	if idx := strings.LastIndex(name, ":"); idx != -1 && !strings.Contains(name[idx+1:], "/") {
+		name, tag = name[:idx], name[idx+1:]
+	}
+
+	host = dockerHubRegistry
+	repository = name
+	if idx := strings.Index(name, "/"); idx != -1 {
+		domain := name[:idx]
+		if strings.ContainsAny(domain, ".:") || domain == "localhost" {
+			host, repository = domain, name[idx+1:]
+		}
+	}
+	if host == dockerHubRegistry {
+		host = dockerHubRegistryHost
+		if !strings.Contains(repository, "/") {
+			repository = "library/" + repository
+		}
+	}
+	return host, repository, tag
+}
+
+func (r *registryClient) ResolveImageDigest(ctx context.Context, image string) (string, error) {
+	if ImageDigest(image) != "" {
+		return image, nil
+	}
+
+	host, repository, tag := parseImageReference(image)
+	manifestURL := fmt.Sprintf("https://%s/v2/%s/manifests/%s", host, repository, tag)
+
+	resp, err := r.headManifest(ctx, manifestURL, "")
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		token, err := r.fetchToken(ctx, host, resp.Header.Get("WWW-Authenticate"))
+		if err != nil {
+			return "", fmt.Errorf("failed to authenticate with registry %s: %v", host, err)
+		}
+		resp, err = r.headManifest(ctx, manifestURL, token)
+		if err != nil {
+			return "", err
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to look up the manifest of image %s: registry returned %s", image, resp.Status)
+	}
+
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if !IsValidImageDigest(digest) {
+		return "", fmt.Errorf("registry returned an invalid digest '%s' for image %s", digest, image)
+	}
+	return image + "@" + digest, nil
+}
+
+func (r *registryClient) headManifest(ctx context.Context, manifestURL string, token string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, manifestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return resp, nil
+}
+
+// fetchToken requests an anonymous bearer token as described by the WWW-Authenticate challenge of the registry
+func (r *registryClient) fetchToken(ctx context.Context, host string, challenge string) (string, error) {
+	scheme, params := parseChallenge(challenge)
+	if scheme != "Bearer" {
+		return "", fmt.Errorf("unsupported authentication challenge '%s'", challenge)
+	}
+	return requestToken(ctx, r.client, host, params, nil, nil)
+}
+
+// parseChallenge splits a WWW-Authenticate challenge into its scheme and parameters
+func parseChallenge(challenge string) (string, map[string]string) {
+	params := map[string]string{}
+	parts := strings.SplitN(strings.TrimSpace(challenge), " ", 2)
+	if len(parts) == 2 {
+		for _, param := range strings.Split(parts[1], ",") {
+			kv := strings.SplitN(strings.TrimSpace(param), "=", 2)
+			if len(kv) == 2 {
+				params[kv[0]] = strings.Trim(kv[1], `"`)
+			}
+		}
+	}
+	return parts[0], params
+}
+
+// validateRealm checks that the token realm of a challenge is served with https by the registry host
+// or by a host of the domain of the registry, like auth.docker.io for registry-1.docker.io.
+// Registries must not be able to make KubeVirt components send requests to arbitrary hosts.
+func validateRealm(host string, realm string) (*url.URL, error) {
+	realmURL, err := url.Parse(realm)
+	if err != nil {
+		return nil, fmt.Errorf("invalid realm '%s': %v", realm, err)
+	}
+	if realmURL.Scheme != "https" {
+		return nil, fmt.Errorf("realm '%s' does not use https", realm)
+	}
+
+	registryHostname := host
+	if idx := strings.LastIndex(host, ":"); idx != -1 {
+		registryHostname = host[:idx]
+	}
+	realmHostname := realmURL.Hostname()
+	if realmHostname == registryHostname {
+		return realmURL, nil
+	}
+	if net.ParseIP(registryHostname) == nil {
+		domain := registryHostname
+		if labels := strings.Split(registryHostname, "."); len(labels) > 2 {
+			domain = strings.Join(labels[1:], ".")
+		}
+		if strings.Contains(domain, ".") && (realmHostname == domain || strings.HasSuffix(realmHostname, "."+domain)) {
+			return realmURL, nil
+		}
+	}
+	return nil, fmt.Errorf("realm '%s' is not served by registry %s", realm, host)
+}
+
+// requestToken requests a bearer token from the realm of a challenge. The scopes default to the
+// scope of the challenge, the token is requested anonymously if there are no credentials.
+func requestToken(ctx context.Context, client *http.Client, host string, params map[string]string, scopes []string, credentials *registryCredentials) (string, error) {
+	if params["realm"] == "" {
+		return "", fmt.Errorf("authentication challenge has no realm")
+	}
+	realmURL, err := validateRealm(host, params["realm"])
+	if err != nil {
+		return "", err
+	}
+
+	query := url.Values{}
+	if params["service"] != "" {
+		query.Set("service", params["service"])
+	}
+	if len(scopes) == 0 && params["scope"] != "" {
+		scopes = []string{params["scope"]}
+	}
+	for _, scope := range scopes {
+		query.Add("scope", scope)
+	}
+	realmURL.RawQuery = query.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realmURL.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	if credentials != nil {
+		req.SetBasicAuth(credentials.username, credentials.password)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token endpoint returned %s", resp.Status)
+	}
+
+	token := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", err
+	}
+	if token.Token != "" {
+		return token.Token, nil
+	}
+	return token.AccessToken, nil
+}
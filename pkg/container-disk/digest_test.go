@@ -0,0 +1,161 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package containerdisk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ImageDigest", func() {
+	const digest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+	table.DescribeTable("should parse image references", func(image, expectedHost, expectedRepository, expectedTag string) {
+		host, repository, tag := parseImageReference(image)
+		Expect(host).To(Equal(expectedHost))
+		Expect(repository).To(Equal(expectedRepository))
+		Expect(tag).To(Equal(expectedTag))
+	},
+		table.Entry("of official Docker Hub images", "fedora", "registry-1.docker.io", "library/fedora", "latest"),
+		table.Entry("of Docker Hub images with a tag", "kubevirt/cirros-container-disk-demo:v0.40.0", "registry-1.docker.io", "kubevirt/cirros-container-disk-demo", "v0.40.0"),
+		table.Entry("of images on other registries", "quay.io/kubevirt/fedora:33", "quay.io", "kubevirt/fedora", "33"),
+		table.Entry("of images on registries with a port", "registry:5000/kubevirt/fedora", "registry:5000", "kubevirt/fedora", "latest"),
+		table.Entry("of images on localhost", "localhost/fedora:33", "localhost", "fedora", "33"),
+	)
+
+	It("should extract and validate digests", func() {
+		Expect(ImageDigest("quay.io/kubevirt/fedora:33")).To(BeEmpty())
+		Expect(ImageDigest("quay.io/kubevirt/fedora@" + digest)).To(Equal(digest))
+		Expect(IsValidImageDigest(digest)).To(BeTrue())
+		Expect(IsValidImageDigest("sha256:1234")).To(BeFalse())
+	})
+
+	table.DescribeTable("should validate image references", func(image string, valid bool) {
+		err := ValidateImageReference(image)
+		if valid {
+			Expect(err).ToNot(HaveOccurred())
+		} else {
+			Expect(err).To(HaveOccurred())
+		}
+	},
+		table.Entry("with a tag", "quay.io/kubevirt/fedora:33", true),
+		table.Entry("without a tag", "registry:5000/kubevirt/fedora", true),
+		table.Entry("with a digest", "quay.io/kubevirt/fedora@"+digest, true),
+		table.Entry("with an invalid digest", "quay.io/kubevirt/fedora@sha256:1234", false),
+		table.Entry("with an upper case repository", "quay.io/KubeVirt/fedora:33", false),
+		table.Entry("with an invalid tag", "quay.io/kubevirt/fedora:-33", false),
+	)
+
+	table.DescribeTable("should only accept token realms of the registry", func(host, realm string, valid bool) {
+		_, err := validateRealm(host, realm)
+		if valid {
+			Expect(err).ToNot(HaveOccurred())
+		} else {
+			Expect(err).To(HaveOccurred())
+		}
+	},
+		table.Entry("on the registry host", "quay.io", "https://quay.io/v2/auth", true),
+		table.Entry("on the registry host with a port", "registry:5000", "https://registry:5000/token", true),
+		table.Entry("in the domain of the registry", "registry-1.docker.io", "https://auth.docker.io/token", true),
+		table.Entry("without https", "quay.io", "http://quay.io/v2/auth", false),
+		table.Entry("on another host", "quay.io", "https://example.com/token", false),
+		table.Entry("on a host sharing only the top level domain", "registry.example.com", "https://attacker.com/token", false),
+		table.Entry("on another IP address", "10.0.0.1:5000", "https://10.0.0.2/token", false),
+		table.Entry("on the metadata service", "quay.io", "https://169.254.169.254/latest", false),
+	)
+
+	Context("resolving digests with a registry", func() {
+		var server *httptest.Server
+		var requireToken bool
+		var realmHost string
+
+		BeforeEach(func() {
+			requireToken = false
+			realmHost = ""
+			server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.URL.Path == "/token":
+					Expect(r.URL.Query().Get("scope")).To(Equal("repository:kubevirt/fedora:pull"))
+					fmt.Fprint(w, `{"token": "fake-token"}`)
+				case r.URL.Path == "/v2/kubevirt/fedora/manifests/33":
+					Expect(r.Method).To(Equal(http.MethodHead))
+					if requireToken && r.Header.Get("Authorization") != "Bearer fake-token" {
+						host := r.Host
+						if realmHost != "" {
+							host = realmHost
+						}
+						w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="https://%s/token",service="registry",scope="repository:kubevirt/fedora:pull"`, host))
+						w.WriteHeader(http.StatusUnauthorized)
+						return
+					}
+					w.Header().Set("Docker-Content-Digest", digest)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		image := func(name string) string {
+			return strings.TrimPrefix(server.URL, "https://") + "/" + name
+		}
+
+		It("should pin the image to the digest of its manifest", func() {
+			resolved, err := NewImageDigestResolverWithClient(server.Client()).ResolveImageDigest(context.Background(), image("kubevirt/fedora:33"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resolved).To(Equal(image("kubevirt/fedora:33@" + digest)))
+		})
+
+		It("should request an anonymous token if the registry asks for it", func() {
+			requireToken = true
+			resolved, err := NewImageDigestResolverWithClient(server.Client()).ResolveImageDigest(context.Background(), image("kubevirt/fedora:33"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resolved).To(Equal(image("kubevirt/fedora:33@" + digest)))
+		})
+
+		It("should not request a token from a realm on another host", func() {
+			requireToken = true
+			realmHost = "example.com"
+			_, err := NewImageDigestResolverWithClient(server.Client()).ResolveImageDigest(context.Background(), image("kubevirt/fedora:33"))
+			Expect(err).To(MatchError(ContainSubstring("is not served by registry")))
+		})
+
+		It("should fail if the manifest does not exist", func() {
+			_, err := NewImageDigestResolverWithClient(server.Client()).ResolveImageDigest(context.Background(), image("kubevirt/fedora:34"))
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should keep images which are already pinned", func() {
+			resolved, err := NewImageDigestResolverWithClient(server.Client()).ResolveImageDigest(context.Background(), image("kubevirt/fedora@" + digest))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resolved).To(Equal(image("kubevirt/fedora@" + digest)))
+		})
+	})
+})
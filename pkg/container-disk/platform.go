@@ -0,0 +1,109 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package containerdisk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// ImageArchitectureResolver looks up the architectures an image is available for.
+type ImageArchitectureResolver interface {
+	ResolveImageArchitectures(ctx context.Context, image string) ([]string, error)
+}
+
+// NewImageArchitectureResolver returns a resolver which looks up the image manifest anonymously with the registry API.
+func NewImageArchitectureResolver() ImageArchitectureResolver {
+	return NewImageArchitectureResolverWithClient(&http.Client{Timeout: digestResolveTimeout})
+}
+
+func NewImageArchitectureResolverWithClient(client *http.Client) ImageArchitectureResolver {
+	return &registryClient{client: client}
+}
+
+// ResolveImageArchitectures returns the sorted linux architectures of the manifests of a manifest list,
+// or the architecture of the image config if the image has a single manifest.
+func (r *registryClient) ResolveImageArchitectures(ctx context.Context, image string) ([]string, error) {
+	host, repository, reference := splitImageReference(image)
+	repositoryURL := fmt.Sprintf("https://%s/v2/%s", host, repository)
+
+	data, status, err := r.get(ctx, repositoryURL+"/manifests/"+reference, strings.Join(manifestMediaTypes, ", "))
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("failed to look up the manifest of image %s: registry returned %d", image, status)
+	}
+
+	manifest := &manifestIndex{}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse the manifest of image %s: %v", image, err)
+	}
+
+	if len(manifest.Manifests) > 0 {
+		var architectures []string
+		seen := map[string]bool{}
+		for _, m := range manifest.Manifests {
+			if m.Platform == nil || m.Platform.OS != "linux" || seen[m.Platform.Architecture] {
+				continue
+			}
+			seen[m.Platform.Architecture] = true
+			architectures = append(architectures, m.Platform.Architecture)
+		}
+		sort.Strings(architectures)
+		return architectures, nil
+	}
+
+	if !IsValidImageDigest(manifest.Config.Digest) {
+		return nil, fmt.Errorf("the manifest of image %s has no valid config digest", image)
+	}
+	data, status, err = r.get(ctx, repositoryURL+"/blobs/"+manifest.Config.Digest, "")
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch the config of image %s: registry returned %d", image, status)
+	}
+	config := platform{}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse the config of image %s: %v", image, err)
+	}
+	if config.Architecture == "" {
+		return nil, fmt.Errorf("the config of image %s has no architecture", image)
+	}
+	if config.OS != "" && config.OS != "linux" {
+		return nil, nil
+	}
+	return []string{config.Architecture}, nil
+}
+
+// SupportsArchitecture returns true if the architecture is one of the architectures of an image.
+func SupportsArchitecture(architectures []string, architecture string) bool {
+	for _, a := range architectures {
+		if a == architecture {
+			return true
+		}
+	}
+	return false
+}
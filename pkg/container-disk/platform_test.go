@@ -0,0 +1,86 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package containerdisk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Image architectures", func() {
+	const configDigest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+	var server *httptest.Server
+
+	BeforeEach(func() {
+		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/v2/kubevirt/fedora/manifests/multi-arch":
+				w.Header().Set("Content-Type", mediaTypeOCIIndex)
+				fmt.Fprint(w, `{"schemaVersion": 2, "mediaType": "`+mediaTypeOCIIndex+`", "manifests": [
+					{"digest": "sha256:1", "platform": {"os": "linux", "architecture": "s390x"}},
+					{"digest": "sha256:2", "platform": {"os": "linux", "architecture": "amd64"}},
+					{"digest": "sha256:3", "platform": {"os": "windows", "architecture": "arm64"}},
+					{"digest": "sha256:4", "platform": {"os": "linux", "architecture": "amd64"}}
+				]}`)
+			case "/v2/kubevirt/fedora/manifests/single-arch":
+				w.Header().Set("Content-Type", mediaTypeDockerManifest)
+				fmt.Fprint(w, `{"schemaVersion": 2, "mediaType": "`+mediaTypeDockerManifest+`", "config": {"digest": "`+configDigest+`"}}`)
+			case "/v2/kubevirt/fedora/blobs/" + configDigest:
+				fmt.Fprint(w, `{"os": "linux", "architecture": "arm64"}`)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	image := func(name string) string {
+		return strings.TrimPrefix(server.URL, "https://") + "/" + name
+	}
+
+	It("should return the linux architectures of a manifest list", func() {
+		architectures, err := NewImageArchitectureResolverWithClient(server.Client()).ResolveImageArchitectures(context.Background(), image("kubevirt/fedora:multi-arch"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(architectures).To(Equal([]string{"amd64", "s390x"}))
+		Expect(SupportsArchitecture(architectures, "amd64")).To(BeTrue())
+		Expect(SupportsArchitecture(architectures, "arm64")).To(BeFalse())
+	})
+
+	It("should return the architecture of the config of a single manifest", func() {
+		architectures, err := NewImageArchitectureResolverWithClient(server.Client()).ResolveImageArchitectures(context.Background(), image("kubevirt/fedora:single-arch"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(architectures).To(Equal([]string{"arm64"}))
+	})
+
+	It("should fail if the image does not exist", func() {
+		_, err := NewImageArchitectureResolverWithClient(server.Client()).ResolveImageArchitectures(context.Background(), image("kubevirt/fedora:missing"))
+		Expect(err).To(MatchError(ContainSubstring("registry returned 404")))
+	})
+})
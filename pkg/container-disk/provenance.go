@@ -0,0 +1,265 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package containerdisk
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+const (
+	cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+	cosignSignatureType       = "cosign container image signature"
+
+	// signature manifests and payloads are small, anything larger is not read
+	maxRegistryResponseSize = 4 * 1024 * 1024
+)
+
+// ImageSignatureVerifier verifies that an image referenced by digest carries a cosign signature of one of the keys.
+type ImageSignatureVerifier interface {
+	VerifyImageSignature(image string, keys []crypto.PublicKey) error
+}
+
+// NewImageSignatureVerifier returns a verifier which fetches the signatures anonymously with the registry API.
+func NewImageSignatureVerifier() ImageSignatureVerifier {
+	return NewImageSignatureVerifierWithClient(&http.Client{Timeout: digestResolveTimeout})
+}
+
+func NewImageSignatureVerifierWithClient(client *http.Client) ImageSignatureVerifier {
+	return &registryClient{client: client}
+}
+
+// IsImageFromRegistries returns true if the image comes from one of the registries, which are
+// optionally followed by a repository path. Docker Hub images are matched as "docker.io".
+func IsImageFromRegistries(image string, registries []string) bool {
+	name := imageName(image)
+	for _, registry := range registries {
+		registry = strings.TrimSuffix(registry, "/")
+		if registry == "" {
+			continue
+		}
+		// only match complete path segments, "quay.io/kubevirt" must not allow "quay.io/kubevirtci/image"
+		if strings.HasPrefix(name, registry+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// imageName returns the fully qualified name of an image without its tag and digest
+func imageName(image string) string {
+	if idx := strings.LastIndex(image, "@"); idx != -1 {
+		image = image[:idx]
+	}
+	host, repository, _ := parseImageReference(image)
+	if host == dockerHubRegistryHost {
+		host = dockerHubRegistry
+	}
+	return host + "/" + repository
+}
+
+// ParsePublicKeys parses the PEM encoded public keys of all values, in the order of their keys.
+func ParsePublicKeys(data map[string]string) ([]crypto.PublicKey, error) {
+	var names []string
+	for name := range data {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var keys []crypto.PublicKey
+	for _, name := range names {
+		rest := []byte(data[name])
+		for {
+			var block *pem.Block
+			block, rest = pem.Decode(rest)
+			if block == nil {
+				break
+			}
+			if block.Type != "PUBLIC KEY" {
+				continue
+			}
+			key, err := x509.ParsePKIXPublicKey(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse public key %s: %v", name, err)
+			}
+			switch key.(type) {
+			case *ecdsa.PublicKey, *rsa.PublicKey:
+				keys = append(keys, key)
+			default:
+				return nil, fmt.Errorf("public key %s is no ECDSA or RSA key", name)
+			}
+		}
+	}
+	return keys, nil
+}
+
+type ociManifest struct {
+	Layers []ociDescriptor `json:"layers"`
+}
+
+type ociDescriptor struct {
+	Digest      string            `json:"digest"`
+	Annotations map[string]string `json:"annotations"`
+}
+
+// cosignPayload is the simple signing payload cosign signs
+type cosignPayload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+		Type string `json:"type"`
+	} `json:"critical"`
+}
+
+// VerifyImageSignature looks up the cosign signatures stored next to the image in its repository.
+// The image is trusted if one of them is a signature of one of the keys over the digest of the image.
+func (r *registryClient) VerifyImageSignature(image string, keys []crypto.PublicKey) error {
+	digest := ImageDigest(image)
+	if !IsValidImageDigest(digest) {
+		return fmt.Errorf("image %s is not referenced by a sha256 digest", image)
+	}
+	if len(keys) == 0 {
+		return fmt.Errorf("no public keys to verify the signature of image %s with", image)
+	}
+
+	host, repository, _ := parseImageReference(image[:strings.LastIndex(image, "@")])
+	signatureTag := strings.Replace(digest, ":", "-", 1) + ".sig"
+	manifestURL := fmt.Sprintf("https://%s/v2/%s/manifests/%s", host, repository, signatureTag)
+
+	data, status, err := r.get(context.Background(), manifestURL, strings.Join(manifestMediaTypes, ", "))
+	if err != nil {
+		return err
+	}
+	if status == http.StatusNotFound {
+		return fmt.Errorf("image %s is not signed", image)
+	}
+	if status != http.StatusOK {
+		return fmt.Errorf("failed to look up the signatures of image %s: registry returned %d", image, status)
+	}
+
+	manifest := ociManifest{}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return fmt.Errorf("failed to parse the signatures of image %s: %v", image, err)
+	}
+
+	for _, layer := range manifest.Layers {
+		signature, err := base64.StdEncoding.DecodeString(layer.Annotations[cosignSignatureAnnotation])
+		if err != nil || len(signature) == 0 || !IsValidImageDigest(layer.Digest) {
+			continue
+		}
+
+		payload, status, err := r.get(context.Background(), fmt.Sprintf("https://%s/v2/%s/blobs/%s", host, repository, layer.Digest), "")
+		if err != nil {
+			return err
+		}
+		if status != http.StatusOK {
+			return fmt.Errorf("failed to fetch the signature payload of image %s: registry returned %d", image, status)
+		}
+		hash := sha256.Sum256(payload)
+		if "sha256:"+hex.EncodeToString(hash[:]) != layer.Digest {
+			return fmt.Errorf("the signature payload of image %s does not match its digest", image)
+		}
+
+		if !verifySignature(keys, hash[:], signature) {
+			continue
+		}
+
+		signed := cosignPayload{}
+		if err := json.Unmarshal(payload, &signed); err != nil {
+			continue
+		}
+		if signed.Critical.Type == cosignSignatureType && signed.Critical.Image.DockerManifestDigest == digest {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("image %s has no valid signature of a trusted key", image)
+}
+
+func verifySignature(keys []crypto.PublicKey, hash []byte, signature []byte) bool {
+	for _, key := range keys {
+		switch key := key.(type) {
+		case *ecdsa.PublicKey:
+			if ecdsa.VerifyASN1(key, hash, signature) {
+				return true
+			}
+		case *rsa.PublicKey:
+			if rsa.VerifyPKCS1v15(key, crypto.SHA256, hash, signature) == nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// get fetches a registry API URL and authenticates with an anonymous token if the registry asks for one
+func (r *registryClient) get(ctx context.Context, url string, accept string) ([]byte, int, error) {
+	resp, err := r.doGet(ctx, url, accept, "")
+	if err != nil {
+		return nil, 0, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		resp.Body.Close()
+		token, err := r.fetchToken(ctx, resp.Request.URL.Host, resp.Header.Get("WWW-Authenticate"))
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to authenticate with registry: %v", err)
+		}
+		resp, err = r.doGet(ctx, url, accept, token)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxRegistryResponseSize))
+	if err != nil {
+		return nil, 0, err
+	}
+	return data, resp.StatusCode, nil
+}
+
+func (r *registryClient) doGet(ctx context.Context, url string, accept string, token string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return r.client.Do(req)
+}
@@ -0,0 +1,152 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package containerdisk
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Image provenance", func() {
+	const digest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+	table.DescribeTable("should match images with the allowed registries", func(image string, allowed bool) {
+		registries := []string{"quay.io/kubevirt", "registry:5000", "docker.io/library"}
+		Expect(IsImageFromRegistries(image, registries)).To(Equal(allowed))
+	},
+		table.Entry("with a repository path", "quay.io/kubevirt/fedora:33", true),
+		table.Entry("by digest", "quay.io/kubevirt/fedora@"+digest, true),
+		table.Entry("with a port", "registry:5000/fedora", true),
+		table.Entry("of official Docker Hub images", "fedora", true),
+		table.Entry("not matching partial path segments", "quay.io/kubevirtci/fedora", false),
+		table.Entry("not matching other Docker Hub images", "kubevirt/fedora", false),
+		table.Entry("not matching other registries", "registry.example.com/kubevirt/fedora", false),
+	)
+
+	Context("verifying signatures", func() {
+		var server *httptest.Server
+		var key *ecdsa.PrivateKey
+		var payload []byte
+		var signature []byte
+
+		sign := func(data []byte) []byte {
+			hash := sha256.Sum256(data)
+			signature, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+			Expect(err).ToNot(HaveOccurred())
+			return signature
+		}
+
+		BeforeEach(func() {
+			var err error
+			key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).ToNot(HaveOccurred())
+
+			payload = []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"kubevirt/fedora"},"image":{"docker-manifest-digest":"%s"},"type":"cosign container image signature"},"optional":null}`, digest))
+			signature = sign(payload)
+
+			server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				hash := sha256.Sum256(payload)
+				payloadDigest := "sha256:" + hex.EncodeToString(hash[:])
+				switch r.URL.Path {
+				case "/v2/kubevirt/fedora/manifests/" + strings.Replace(digest, ":", "-", 1) + ".sig":
+					manifest, err := json.Marshal(map[string]interface{}{
+						"layers": []map[string]interface{}{
+							{
+								"digest": payloadDigest,
+								"annotations": map[string]string{
+									"dev.cosignproject.cosign/signature": base64.StdEncoding.EncodeToString(signature),
+								},
+							},
+						},
+					})
+					Expect(err).ToNot(HaveOccurred())
+					w.Write(manifest)
+				case "/v2/kubevirt/fedora/blobs/" + payloadDigest:
+					w.Write(payload)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		image := func(name string) string {
+			return strings.TrimPrefix(server.URL, "https://") + "/" + name
+		}
+
+		verify := func(image string, keys ...crypto.PublicKey) error {
+			return NewImageSignatureVerifierWithClient(server.Client()).VerifyImageSignature(image, keys)
+		}
+
+		It("should accept images signed by a trusted key", func() {
+			Expect(verify(image("kubevirt/fedora@"+digest), &key.PublicKey)).To(Succeed())
+		})
+
+		It("should reject images signed by another key", func() {
+			other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(verify(image("kubevirt/fedora@"+digest), &other.PublicKey)).To(MatchError(ContainSubstring("no valid signature")))
+		})
+
+		It("should reject signatures of another image", func() {
+			payload = []byte(strings.Replace(string(payload), digest, "sha256:"+strings.Repeat("f", 64), 1))
+			signature = sign(payload)
+			Expect(verify(image("kubevirt/fedora@"+digest), &key.PublicKey)).To(MatchError(ContainSubstring("no valid signature")))
+		})
+
+		It("should reject images without signatures", func() {
+			Expect(verify(image("kubevirt/cirros@"+digest), &key.PublicKey)).To(MatchError(ContainSubstring("is not signed")))
+		})
+
+		It("should reject images which are not referenced by digest", func() {
+			Expect(verify(image("kubevirt/fedora:33"), &key.PublicKey)).To(MatchError(ContainSubstring("not referenced by a sha256 digest")))
+		})
+
+		It("should parse the trusted keys from PEM", func() {
+			der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+			Expect(err).ToNot(HaveOccurred())
+			keys, err := ParsePublicKeys(map[string]string{
+				"cosign.pub": string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(keys).To(HaveLen(1))
+			Expect(verify(image("kubevirt/fedora@"+digest), keys...)).To(Succeed())
+		})
+	})
+})
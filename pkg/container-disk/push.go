@@ -0,0 +1,562 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package containerdisk
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"runtime"
+	"strings"
+	"time"
+)
+
+const (
+	// ExportedDiskName is the name of the disk in the layer an exported containerDisk image adds.
+	// The layer hides the disks of the base image, whatever their names are.
+	ExportedDiskName = "disk.img"
+
+	// opaqueWhiteout marks the directory of the layer as opaque, the files of the lower layers in it are hidden
+	opaqueWhiteout = ".wh..wh..opq"
+
+	exportedDiskOwner = 107
+
+	mediaTypeDockerManifest     = "application/vnd.docker.distribution.manifest.v2+json"
+	mediaTypeDockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+	mediaTypeDockerConfig       = "application/vnd.docker.container.image.v1+json"
+	mediaTypeDockerLayer        = "application/vnd.docker.image.rootfs.diff.tar.gzip"
+	mediaTypeOCIManifest        = "application/vnd.oci.image.manifest.v1+json"
+	mediaTypeOCIIndex           = "application/vnd.oci.image.index.v1+json"
+	mediaTypeOCIConfig          = "application/vnd.oci.image.config.v1+json"
+	mediaTypeOCILayer           = "application/vnd.oci.image.layer.v1.tar+gzip"
+
+	maxManifestSize = 4 * 1024 * 1024
+)
+
+type registryCredentials struct {
+	username string
+	password string
+}
+
+type registryRepository struct {
+	host string
+	name string
+}
+
+func (r registryRepository) url(format string, a ...interface{}) string {
+	return fmt.Sprintf("https://%s/v2/%s", r.host, r.name) + fmt.Sprintf(format, a...)
+}
+
+type platform struct {
+	Architecture string `json:"architecture"`
+	OS           string `json:"os"`
+}
+
+type descriptor struct {
+	MediaType string    `json:"mediaType"`
+	Size      int64     `json:"size"`
+	Digest    string    `json:"digest"`
+	Platform  *platform `json:"platform,omitempty"`
+}
+
+type imageManifest struct {
+	SchemaVersion int          `json:"schemaVersion"`
+	MediaType     string       `json:"mediaType,omitempty"`
+	Config        descriptor   `json:"config"`
+	Layers        []descriptor `json:"layers"`
+}
+
+// manifestIndex holds the fields of image manifests and of manifest lists
+type manifestIndex struct {
+	imageManifest
+	Manifests []descriptor `json:"manifests,omitempty"`
+}
+
+// ImagePusher pushes disks as containerDisk images to registries
+type ImagePusher struct {
+	client      *http.Client
+	credentials map[string]registryCredentials
+	// the Authorization header per registry host
+	auth map[string]string
+}
+
+// NewImagePusher returns a pusher which authenticates with the credentials of the docker config, if there
+// are credentials for the registry, and anonymously otherwise.
+func NewImagePusher(client *http.Client, dockerConfigJSON []byte) (*ImagePusher, error) {
+	pusher := &ImagePusher{
+		client:      client,
+		credentials: map[string]registryCredentials{},
+		auth:        map[string]string{},
+	}
+	if len(dockerConfigJSON) == 0 {
+		return pusher, nil
+	}
+
+	config := struct {
+		Auths map[string]struct {
+			Auth     string `json:"auth"`
+			Username string `json:"username"`
+			Password string `json:"password"`
+		} `json:"auths"`
+	}{}
+	if err := json.Unmarshal(dockerConfigJSON, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse the docker config: %v", err)
+	}
+	for server, entry := range config.Auths {
+		credentials := registryCredentials{username: entry.Username, password: entry.Password}
+		if entry.Auth != "" {
+			decoded, err := base64.StdEncoding.DecodeString(entry.Auth)
+			if err != nil {
+				return nil, fmt.Errorf("invalid auth of registry %s: %v", server, err)
+			}
+			parts := strings.SplitN(string(decoded), ":", 2)
+			if len(parts) != 2 {
+				return nil, fmt.Errorf("invalid auth of registry %s: expected username:password", server)
+			}
+			credentials = registryCredentials{username: parts[0], password: parts[1]}
+		}
+		pusher.credentials[registryHost(server)] = credentials
+	}
+	return pusher, nil
+}
+
+// registryHost normalizes the server of a docker config entry to the host the registry API is served on
+func registryHost(server string) string {
+	server = strings.TrimPrefix(strings.TrimPrefix(server, "https://"), "http://")
+	server = strings.SplitN(server, "/", 2)[0]
+	if server == dockerHubRegistry || server == "index.docker.io" {
+		return dockerHubRegistryHost
+	}
+	return server
+}
+
+// PushDisk pushes the disk as containerDisk image on top of the base image, if one is given, and returns the
+// digest of the manifest. Blobs the repository already has are not uploaded again. The layers of the base
+// image are mounted from the base repository if it is on the same registry, and copied otherwise.
+func (p *ImagePusher) PushDisk(disk io.ReaderAt, size int64, image string, baseImage string) (string, error) {
+	if ImageDigest(image) != "" {
+		return "", fmt.Errorf("image %s must be referenced by tag", image)
+	}
+	host, name, tag := parseImageReference(image)
+	target := registryRepository{host: host, name: name}
+
+	var base *registryRepository
+	var baseReference string
+	scopes := map[string][]string{target.host: {"repository:" + target.name + ":pull,push"}}
+	if baseImage != "" {
+		baseHost, baseName, reference := splitImageReference(baseImage)
+		base = &registryRepository{host: baseHost, name: baseName}
+		baseReference = reference
+		scopes[base.host] = append(scopes[base.host], "repository:"+base.name+":pull")
+	}
+	for host, hostScopes := range scopes {
+		if err := p.login(host, hostScopes); err != nil {
+			return "", err
+		}
+	}
+
+	manifest := imageManifest{SchemaVersion: 2, MediaType: mediaTypeDockerManifest}
+	config := map[string]interface{}{
+		"architecture": runtime.GOARCH,
+		"os":           "linux",
+	}
+	if base != nil {
+		baseManifest, err := p.fetchManifest(*base, baseReference)
+		if err != nil {
+			return "", fmt.Errorf("failed to fetch the manifest of base image %s: %v", baseImage, err)
+		}
+		config, err = p.fetchConfig(*base, baseManifest.Config.Digest)
+		if err != nil {
+			return "", fmt.Errorf("failed to fetch the config of base image %s: %v", baseImage, err)
+		}
+		for _, layer := range baseManifest.Layers {
+			if err := p.copyBlob(*base, target, layer); err != nil {
+				return "", fmt.Errorf("failed to copy layer %s of base image %s: %v", layer.Digest, baseImage, err)
+			}
+		}
+		manifest.MediaType = baseManifest.MediaType
+		manifest.Layers = baseManifest.Layers
+	}
+	configMediaType, layerMediaType := mediaTypeDockerConfig, mediaTypeDockerLayer
+	if manifest.MediaType == mediaTypeOCIManifest {
+		configMediaType, layerMediaType = mediaTypeOCIConfig, mediaTypeOCILayer
+	}
+
+	layer, diffID, err := digestLayer(disk, size)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the disk: %v", err)
+	}
+	layer.MediaType = layerMediaType
+	err = p.uploadBlob(target, layer, func(w io.Writer) error {
+		_, err := writeLayer(w, disk, size)
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to upload the disk layer: %v", err)
+	}
+	manifest.Layers = append(manifest.Layers, layer)
+
+	configBytes, err := json.Marshal(appendLayerToConfig(config, diffID))
+	if err != nil {
+		return "", err
+	}
+	manifest.Config = descriptor{MediaType: configMediaType, Size: int64(len(configBytes)), Digest: sha256Digest(configBytes)}
+	err = p.uploadBlob(target, manifest.Config, func(w io.Writer) error {
+		_, err := w.Write(configBytes)
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to upload the image config: %v", err)
+	}
+
+	return p.putManifest(target, tag, &manifest)
+}
+
+// login authenticates with the registry for the scopes, if the registry requires it
+func (p *ImagePusher) login(host string, scopes []string) error {
+	resp, err := p.client.Get(fmt.Sprintf("https://%s/v2/", host))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		return nil
+	}
+
+	var credentials *registryCredentials
+	if c, exists := p.credentials[host]; exists {
+		credentials = &c
+	}
+	scheme, params := parseChallenge(resp.Header.Get("WWW-Authenticate"))
+	switch scheme {
+	case "Basic":
+		if credentials == nil {
+			return fmt.Errorf("registry %s requires credentials", host)
+		}
+		p.auth[host] = "Basic " + base64.StdEncoding.EncodeToString([]byte(credentials.username+":"+credentials.password))
+	case "Bearer":
+		token, err := requestToken(context.Background(), p.client, host, params, scopes, credentials)
+		if err != nil {
+			return fmt.Errorf("failed to authenticate with registry %s: %v", host, err)
+		}
+		p.auth[host] = "Bearer " + token
+	default:
+		return fmt.Errorf("unsupported authentication challenge of registry %s", host)
+	}
+	return nil
+}
+
+func (p *ImagePusher) do(req *http.Request) (*http.Response, error) {
+	if auth := p.auth[req.URL.Host]; auth != "" {
+		req.Header.Set("Authorization", auth)
+	}
+	return p.client.Do(req)
+}
+
+// fetchManifest fetches the image manifest, a manifest list is resolved to the manifest of the platform
+func (p *ImagePusher) fetchManifest(repository registryRepository, reference string) (*imageManifest, error) {
+	req, err := http.NewRequest(http.MethodGet, repository.url("/manifests/%s", reference), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+	resp, err := p.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("registry returned %s", resp.Status)
+	}
+
+	manifest := &manifestIndex{}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxManifestSize)).Decode(manifest); err != nil {
+		return nil, err
+	}
+	if manifest.MediaType == "" {
+		manifest.MediaType = strings.TrimSpace(strings.SplitN(resp.Header.Get("Content-Type"), ";", 2)[0])
+	}
+
+	switch manifest.MediaType {
+	case mediaTypeDockerManifest, mediaTypeOCIManifest:
+		return &manifest.imageManifest, nil
+	case mediaTypeDockerManifestList, mediaTypeOCIIndex:
+		for _, m := range manifest.Manifests {
+			if m.Platform != nil && m.Platform.OS == "linux" && m.Platform.Architecture == runtime.GOARCH {
+				return p.fetchManifest(repository, m.Digest)
+			}
+		}
+		return nil, fmt.Errorf("no manifest for linux/%s", runtime.GOARCH)
+	default:
+		return nil, fmt.Errorf("unsupported manifest media type '%s'", manifest.MediaType)
+	}
+}
+
+func (p *ImagePusher) fetchConfig(repository registryRepository, digest string) (map[string]interface{}, error) {
+	body, err := p.fetchBlob(repository, digest)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	config := map[string]interface{}{}
+	if err := json.NewDecoder(io.LimitReader(body, maxManifestSize)).Decode(&config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func (p *ImagePusher) fetchBlob(repository registryRepository, digest string) (io.ReadCloser, error) {
+	req, err := http.NewRequest(http.MethodGet, repository.url("/blobs/%s", digest), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := p.do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("registry returned %s", resp.Status)
+	}
+	return resp.Body, nil
+}
+
+func (p *ImagePusher) blobExists(repository registryRepository, digest string) (bool, error) {
+	req, err := http.NewRequest(http.MethodHead, repository.url("/blobs/%s", digest), nil)
+	if err != nil {
+		return false, err
+	}
+	resp, err := p.do(req)
+	if err != nil {
+		return false, err
+	}
+	resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("registry returned %s", resp.Status)
+	}
+}
+
+// startUpload starts an upload session and returns its location. If a blob is given to mount from
+// another repository on the registry and the registry mounts it, no session is started.
+func (p *ImagePusher) startUpload(repository registryRepository, mount *descriptor, from *registryRepository) (*url.URL, error) {
+	uploadURL := repository.url("/blobs/uploads/")
+	if mount != nil && from != nil && from.host == repository.host {
+		uploadURL += "?" + url.Values{"mount": {mount.Digest}, "from": {from.name}}.Encode()
+	}
+	req, err := http.NewRequest(http.MethodPost, uploadURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := p.do(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusCreated:
+		return nil, nil
+	case http.StatusAccepted:
+		location, err := url.Parse(resp.Header.Get("Location"))
+		if err != nil {
+			return nil, fmt.Errorf("registry returned an invalid upload location: %v", err)
+		}
+		return req.URL.ResolveReference(location), nil
+	default:
+		return nil, fmt.Errorf("registry returned %s", resp.Status)
+	}
+}
+
+func (p *ImagePusher) putBlob(location *url.URL, blob descriptor, body io.Reader) error {
+	query := location.Query()
+	query.Set("digest", blob.Digest)
+	location.RawQuery = query.Encode()
+
+	req, err := http.NewRequest(http.MethodPut, location.String(), body)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = blob.Size
+	req.Header.Set("Content-Type", "application/octet-stream")
+	resp, err := p.do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("registry returned %s", resp.Status)
+	}
+	return nil
+}
+
+// copyBlob makes the blob of the source repository available in the target repository
+func (p *ImagePusher) copyBlob(source registryRepository, target registryRepository, blob descriptor) error {
+	exists, err := p.blobExists(target, blob.Digest)
+	if err != nil || exists {
+		return err
+	}
+	location, err := p.startUpload(target, &blob, &source)
+	if err != nil || location == nil {
+		return err
+	}
+	body, err := p.fetchBlob(source, blob.Digest)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	return p.putBlob(location, blob, body)
+}
+
+// uploadBlob uploads the blob written by the writer, unless the repository already has it
+func (p *ImagePusher) uploadBlob(repository registryRepository, blob descriptor, write func(w io.Writer) error) error {
+	exists, err := p.blobExists(repository, blob.Digest)
+	if err != nil || exists {
+		return err
+	}
+	location, err := p.startUpload(repository, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	reader, writer := io.Pipe()
+	defer reader.Close()
+	go func() {
+		writer.CloseWithError(write(writer))
+	}()
+	return p.putBlob(location, blob, reader)
+}
+
+func (p *ImagePusher) putManifest(repository registryRepository, tag string, manifest *imageManifest) (string, error) {
+	manifestBytes, err := json.Marshal(manifest)
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequest(http.MethodPut, repository.url("/manifests/%s", tag), bytes.NewReader(manifestBytes))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", manifest.MediaType)
+	resp, err := p.do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("failed to push the manifest: registry returned %s", resp.Status)
+	}
+	return sha256Digest(manifestBytes), nil
+}
+
+// appendLayerToConfig adds the layer to the root filesystem and the history of the image config
+func appendLayerToConfig(config map[string]interface{}, diffID string) map[string]interface{} {
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	rootfs, _ := config["rootfs"].(map[string]interface{})
+	if rootfs == nil {
+		rootfs = map[string]interface{}{"type": "layers"}
+	}
+	diffIDs, _ := rootfs["diff_ids"].([]interface{})
+	rootfs["diff_ids"] = append(diffIDs, diffID)
+	config["rootfs"] = rootfs
+
+	history, _ := config["history"].([]interface{})
+	config["history"] = append(history, map[string]interface{}{
+		"created":    now,
+		"created_by": "kubevirt image export",
+	})
+	config["created"] = now
+	return config
+}
+
+// writeLayer writes the layer with the disk as gzip compressed tar and returns the digest of the tar.
+// The disk directory is opaque, since containerDisks must only have one disk.
+// The layer is reproducible, so it can be written once to compute its digest and once more to upload it.
+func writeLayer(w io.Writer, disk io.ReaderAt, size int64) (string, error) {
+	diffHash := sha256.New()
+	gz, err := gzip.NewWriterLevel(w, gzip.BestSpeed)
+	if err != nil {
+		return "", err
+	}
+	tw := tar.NewWriter(io.MultiWriter(gz, diffHash))
+
+	headers := []*tar.Header{
+		{Typeflag: tar.TypeDir, Name: "disk/", Mode: 0555},
+		{Typeflag: tar.TypeReg, Name: "disk/" + opaqueWhiteout, Mode: 0},
+		{Typeflag: tar.TypeReg, Name: "disk/" + ExportedDiskName, Mode: 0440, Size: size},
+	}
+	for _, header := range headers {
+		header.Uid, header.Gid = exportedDiskOwner, exportedDiskOwner
+		header.ModTime = time.Unix(0, 0)
+		if err := tw.WriteHeader(header); err != nil {
+			return "", err
+		}
+	}
+	if _, err := io.Copy(tw, io.NewSectionReader(disk, 0, size)); err != nil {
+		return "", err
+	}
+	if err := tw.Close(); err != nil {
+		return "", err
+	}
+	if err := gz.Close(); err != nil {
+		return "", err
+	}
+	return "sha256:" + hex.EncodeToString(diffHash.Sum(nil)), nil
+}
+
+// digestLayer returns the descriptor and the digest of the uncompressed layer with the disk
+func digestLayer(disk io.ReaderAt, size int64) (descriptor, string, error) {
+	hash := sha256.New()
+	counter := &countingWriter{}
+	diffID, err := writeLayer(io.MultiWriter(hash, counter), disk, size)
+	if err != nil {
+		return descriptor{}, "", err
+	}
+	return descriptor{Size: counter.n, Digest: "sha256:" + hex.EncodeToString(hash.Sum(nil))}, diffID, nil
+}
+
+type countingWriter struct {
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	c.n += int64(len(p))
+	return len(p), nil
+}
+
+func sha256Digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,257 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package containerdisk
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// fakeRegistry serves the parts of the registry API which are used to push images
+type fakeRegistry struct {
+	lock      sync.Mutex
+	blobs     map[string]map[string][]byte
+	manifests map[string]map[string][]byte
+	uploads   map[string]string
+	uploaded  []string
+	mounted   []string
+}
+
+func newFakeRegistry() *fakeRegistry {
+	return &fakeRegistry{
+		blobs:     map[string]map[string][]byte{},
+		manifests: map[string]map[string][]byte{},
+		uploads:   map[string]string{},
+	}
+}
+
+func (f *fakeRegistry) addBlob(repository string, blob []byte) string {
+	digest := sha256Digest(blob)
+	if f.blobs[repository] == nil {
+		f.blobs[repository] = map[string][]byte{}
+	}
+	f.blobs[repository][digest] = blob
+	return digest
+}
+
+func (f *fakeRegistry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	defer GinkgoRecover()
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if r.Header.Get("Authorization") != "Basic "+base64.StdEncoding.EncodeToString([]byte("user:secret")) {
+		w.Header().Set("WWW-Authenticate", `Basic realm="registry"`)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/v2/")
+	switch {
+	case r.URL.Path == "/v2/":
+	case strings.HasPrefix(r.URL.Path, "/upload/"):
+		Expect(r.Method).To(Equal(http.MethodPut))
+		blob, err := ioutil.ReadAll(r.Body)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sha256Digest(blob)).To(Equal(r.URL.Query().Get("digest")))
+		f.addBlob(f.uploads[r.URL.Path], blob)
+		f.uploaded = append(f.uploaded, sha256Digest(blob))
+		w.WriteHeader(http.StatusCreated)
+	case strings.HasSuffix(path, "/blobs/uploads/"):
+		Expect(r.Method).To(Equal(http.MethodPost))
+		repository := strings.TrimSuffix(path, "/blobs/uploads/")
+		mount, from := r.URL.Query().Get("mount"), r.URL.Query().Get("from")
+		if blob, exists := f.blobs[from][mount]; exists {
+			f.addBlob(repository, blob)
+			f.mounted = append(f.mounted, mount)
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		location := fmt.Sprintf("/upload/%d", len(f.uploads))
+		f.uploads[location] = repository
+		w.Header().Set("Location", location)
+		w.WriteHeader(http.StatusAccepted)
+	case strings.Contains(path, "/blobs/"):
+		parts := strings.SplitN(path, "/blobs/", 2)
+		blob, exists := f.blobs[parts[0]][parts[1]]
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodGet {
+			w.Write(blob)
+		}
+	case strings.Contains(path, "/manifests/"):
+		parts := strings.SplitN(path, "/manifests/", 2)
+		if r.Method == http.MethodPut {
+			Expect(r.Header.Get("Content-Type")).ToNot(BeEmpty())
+			manifest, err := ioutil.ReadAll(r.Body)
+			Expect(err).ToNot(HaveOccurred())
+			if f.manifests[parts[0]] == nil {
+				f.manifests[parts[0]] = map[string][]byte{}
+			}
+			f.manifests[parts[0]][parts[1]] = manifest
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		manifest, exists := f.manifests[parts[0]][parts[1]]
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(manifest)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+var _ = Describe("ImagePusher", func() {
+	const dockerConfig = `{"auths": {"%s": {"auth": "dXNlcjpzZWNyZXQ="}}}`
+
+	var registry *fakeRegistry
+	var server *httptest.Server
+	var pusher *ImagePusher
+	var host string
+	disk := []byte("the content of the disk")
+
+	BeforeEach(func() {
+		registry = newFakeRegistry()
+		server = httptest.NewTLSServer(registry)
+		host = strings.TrimPrefix(server.URL, "https://")
+
+		var err error
+		pusher, err = NewImagePusher(server.Client(), []byte(fmt.Sprintf(dockerConfig, host)))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	pushedManifest := func(repository, tag string) *imageManifest {
+		manifest := &imageManifest{}
+		Expect(json.Unmarshal(registry.manifests[repository][tag], manifest)).To(Succeed())
+		return manifest
+	}
+
+	pushedConfig := func(repository string, manifest *imageManifest) map[string]interface{} {
+		config := map[string]interface{}{}
+		Expect(json.Unmarshal(registry.blobs[repository][manifest.Config.Digest], &config)).To(Succeed())
+		return config
+	}
+
+	It("should push the disk as a containerDisk image", func() {
+		digest, err := pusher.PushDisk(bytes.NewReader(disk), int64(len(disk)), host+"/kubevirt/golden:v1", "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(digest).To(Equal(sha256Digest(registry.manifests["kubevirt/golden"]["v1"])))
+
+		manifest := pushedManifest("kubevirt/golden", "v1")
+		Expect(manifest.MediaType).To(Equal(mediaTypeDockerManifest))
+		Expect(manifest.Config.MediaType).To(Equal(mediaTypeDockerConfig))
+		Expect(manifest.Layers).To(HaveLen(1))
+		Expect(manifest.Layers[0].MediaType).To(Equal(mediaTypeDockerLayer))
+
+		gz, err := gzip.NewReader(bytes.NewReader(registry.blobs["kubevirt/golden"][manifest.Layers[0].Digest]))
+		Expect(err).ToNot(HaveOccurred())
+		layer, err := ioutil.ReadAll(gz)
+		Expect(err).ToNot(HaveOccurred())
+		config := pushedConfig("kubevirt/golden", manifest)
+		Expect(config["rootfs"]).To(HaveKeyWithValue("diff_ids", ConsistOf(sha256Digest(layer))))
+
+		tr := tar.NewReader(bytes.NewReader(layer))
+		header, err := tr.Next()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(header.Name).To(Equal("disk/"))
+		header, err = tr.Next()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(header.Name).To(Equal("disk/.wh..wh..opq"), "the disks of a base image should be hidden")
+		Expect(header.Size).To(BeZero())
+		header, err = tr.Next()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(header.Name).To(Equal("disk/" + ExportedDiskName))
+		Expect(header.Uid).To(Equal(exportedDiskOwner))
+		content, err := ioutil.ReadAll(tr)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(content).To(Equal(disk))
+	})
+
+	It("should mount the layers of the base image and append the disk layer", func() {
+		baseLayer := registry.addBlob("kubevirt/base", []byte("base layer"))
+		baseConfig := registry.addBlob("kubevirt/base", []byte(`{"os": "linux", "rootfs": {"type": "layers", "diff_ids": ["sha256:base"]}}`))
+		baseManifest, err := json.Marshal(&imageManifest{
+			SchemaVersion: 2,
+			MediaType:     mediaTypeOCIManifest,
+			Config:        descriptor{MediaType: mediaTypeOCIConfig, Digest: baseConfig},
+			Layers:        []descriptor{{MediaType: mediaTypeOCILayer, Digest: baseLayer, Size: 10}},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		registry.manifests["kubevirt/base"] = map[string][]byte{"latest": baseManifest}
+
+		_, err = pusher.PushDisk(bytes.NewReader(disk), int64(len(disk)), host+"/kubevirt/golden:v1", host+"/kubevirt/base")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(registry.mounted).To(ConsistOf(baseLayer))
+
+		manifest := pushedManifest("kubevirt/golden", "v1")
+		Expect(manifest.MediaType).To(Equal(mediaTypeOCIManifest))
+		Expect(manifest.Layers).To(HaveLen(2))
+		Expect(manifest.Layers[0].Digest).To(Equal(baseLayer))
+		Expect(manifest.Layers[1].MediaType).To(Equal(mediaTypeOCILayer))
+		Expect(registry.uploaded).To(ConsistOf(manifest.Layers[1].Digest, manifest.Config.Digest))
+
+		config := pushedConfig("kubevirt/golden", manifest)
+		Expect(config["rootfs"]).To(HaveKeyWithValue("diff_ids", HaveLen(2)))
+		Expect(config["history"]).To(HaveLen(1))
+	})
+
+	It("should not upload the disk layer again if the repository has it", func() {
+		_, err := pusher.PushDisk(bytes.NewReader(disk), int64(len(disk)), host+"/kubevirt/golden:v1", "")
+		Expect(err).ToNot(HaveOccurred())
+		layer := pushedManifest("kubevirt/golden", "v1").Layers[0].Digest
+
+		registry.uploaded = nil
+		_, err = pusher.PushDisk(bytes.NewReader(disk), int64(len(disk)), host+"/kubevirt/golden:v2", "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pushedManifest("kubevirt/golden", "v2").Layers[0].Digest).To(Equal(layer))
+		Expect(registry.uploaded).ToNot(ContainElement(layer))
+	})
+
+	It("should fail without credentials for the registry", func() {
+		pusher, err := NewImagePusher(server.Client(), nil)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = pusher.PushDisk(bytes.NewReader(disk), int64(len(disk)), host+"/kubevirt/golden:v1", "")
+		Expect(err).To(MatchError(ContainSubstring("requires credentials")))
+	})
+
+	It("should refuse to push images referenced by digest", func() {
+		_, err := pusher.PushDisk(bytes.NewReader(disk), int64(len(disk)), host+"/kubevirt/golden@"+sha256Digest(disk), "")
+		Expect(err).To(MatchError(ContainSubstring("must be referenced by tag")))
+	})
+})
@@ -20,9 +20,9 @@ package controller
 
 import (
 	k8sv1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/util/conditions"
 )
 
 type VirtualMachineConditionManager struct {
@@ -33,15 +33,7 @@ func NewVirtualMachineConditionManager() *VirtualMachineConditionManager {
 }
 
 func (d *VirtualMachineConditionManager) GetCondition(vm *v1.VirtualMachine, cond v1.VirtualMachineConditionType) *v1.VirtualMachineCondition {
-	if vm == nil {
-		return nil
-	}
-	for _, c := range vm.Status.Conditions {
-		if c.Type == cond {
-			return &c
-		}
-	}
-	return nil
+	return conditions.GetVMCondition(vm, cond)
 }
 
 func (d *VirtualMachineConditionManager) HasCondition(vm *v1.VirtualMachine, cond v1.VirtualMachineConditionType) bool {
@@ -49,69 +41,35 @@ func (d *VirtualMachineConditionManager) HasCondition(vm *v1.VirtualMachine, con
 }
 
 func (d *VirtualMachineConditionManager) RemoveCondition(vm *v1.VirtualMachine, cond v1.VirtualMachineConditionType) {
-	var conds []v1.VirtualMachineCondition
-	for _, c := range vm.Status.Conditions {
-		if c.Type == cond {
-			continue
-		}
-		conds = append(conds, c)
-	}
-	vm.Status.Conditions = conds
+	conditions.RemoveVMCondition(vm, cond)
 }
 
 type VirtualMachineInstanceConditionManager struct {
 }
 
-// UpdateCondition updates the given VirtualMachineCondition, unless it is already set with the same status and reason.
-func (d *VirtualMachineConditionManager) UpdateCondition(vm *v1.VirtualMachine, cond *v1.VirtualMachineCondition) {
-	for i, c := range vm.Status.Conditions {
-		if c.Type != cond.Type {
-			continue
-		}
-
-		if c.Status != cond.Status || c.Reason != cond.Reason {
-			vm.Status.Conditions[i] = *cond
-		}
-
-		return
-	}
-
-	vm.Status.Conditions = append(vm.Status.Conditions, *cond)
+// UpdateCondition adds or updates the given VirtualMachineCondition, see conditions.SetVMCondition.
+func (d *VirtualMachineConditionManager) UpdateCondition(vm *v1.VirtualMachine, cond *v1.VirtualMachineCondition) bool {
+	return conditions.SetVMCondition(vm, *cond)
 }
 
 func (d *VirtualMachineInstanceConditionManager) CheckFailure(vmi *v1.VirtualMachineInstance, syncErr error, reason string) (changed bool) {
 	if syncErr != nil {
+		// Sync errors are retried, don't update the condition on every retry with a different error message
 		if d.HasConditionWithStatusAndReason(vmi, v1.VirtualMachineInstanceSynchronized, k8sv1.ConditionFalse, reason) {
 			return false
 		}
-		if d.HasCondition(vmi, v1.VirtualMachineInstanceSynchronized) {
-			d.RemoveCondition(vmi, v1.VirtualMachineInstanceSynchronized)
-		}
-		vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{
-			Type:               v1.VirtualMachineInstanceSynchronized,
-			Reason:             reason,
-			Message:            syncErr.Error(),
-			LastTransitionTime: metav1.Now(),
-			Status:             k8sv1.ConditionFalse,
+		return conditions.SetVMICondition(vmi, v1.VirtualMachineInstanceCondition{
+			Type:    v1.VirtualMachineInstanceSynchronized,
+			Reason:  reason,
+			Message: syncErr.Error(),
+			Status:  k8sv1.ConditionFalse,
 		})
-		return true
-	} else if d.HasCondition(vmi, v1.VirtualMachineInstanceSynchronized) {
-		d.RemoveCondition(vmi, v1.VirtualMachineInstanceSynchronized)
-		return true
 	}
-	return false
+	return conditions.RemoveVMICondition(vmi, v1.VirtualMachineInstanceSynchronized)
 }
 
 func (d *VirtualMachineInstanceConditionManager) GetCondition(vmi *v1.VirtualMachineInstance, cond v1.VirtualMachineInstanceConditionType) *v1.VirtualMachineInstanceCondition {
-	if vmi == nil {
-		return nil
-	}
-	for _, c := range vmi.Status.Conditions {
-		if c.Type == cond {
-			return &c
-		}
-	}
-	return nil
+	return conditions.GetVMICondition(vmi, cond)
 }
 
 func (d *VirtualMachineInstanceConditionManager) HasCondition(vmi *v1.VirtualMachineInstance, cond v1.VirtualMachineInstanceConditionType) bool {
@@ -129,37 +87,18 @@ func (d *VirtualMachineInstanceConditionManager) HasConditionWithStatusAndReason
 }
 
 func (d *VirtualMachineInstanceConditionManager) RemoveCondition(vmi *v1.VirtualMachineInstance, cond v1.VirtualMachineInstanceConditionType) {
-	var conds []v1.VirtualMachineInstanceCondition
-	for _, c := range vmi.Status.Conditions {
-		if c.Type == cond {
-			continue
-		}
-		conds = append(conds, c)
-	}
-	vmi.Status.Conditions = conds
+	conditions.RemoveVMICondition(vmi, cond)
 }
 
-// UpdateCondition updates the given VirtualMachineInstanceCondition, unless it is already set with the same status and reason.
-func (d *VirtualMachineInstanceConditionManager) UpdateCondition(vmi *v1.VirtualMachineInstance, cond *v1.VirtualMachineInstanceCondition) {
-	for i, c := range vmi.Status.Conditions {
-		if c.Type != cond.Type {
-			continue
-		}
-
-		if c.Status != cond.Status || c.Reason != cond.Reason {
-			vmi.Status.Conditions[i] = *cond
-		}
-
-		return
-	}
-
-	vmi.Status.Conditions = append(vmi.Status.Conditions, *cond)
+// UpdateCondition adds or updates the given VirtualMachineInstanceCondition, see conditions.SetVMICondition.
+func (d *VirtualMachineInstanceConditionManager) UpdateCondition(vmi *v1.VirtualMachineInstance, cond *v1.VirtualMachineInstanceCondition) bool {
+	return conditions.SetVMICondition(vmi, *cond)
 }
 
 // AddPodCondition add pod condition to the VM.
 func (d *VirtualMachineInstanceConditionManager) AddPodCondition(vmi *v1.VirtualMachineInstance, cond *k8sv1.PodCondition) {
 	if !d.HasCondition(vmi, v1.VirtualMachineInstanceConditionType(cond.Type)) {
-		vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{
+		conditions.SetVMICondition(vmi, v1.VirtualMachineInstanceCondition{
 			LastProbeTime:      cond.LastProbeTime,
 			LastTransitionTime: cond.LastTransitionTime,
 			Message:            cond.Message,
@@ -213,36 +152,23 @@ type VirtualMachineInstanceMigrationConditionManager struct {
 }
 
 func (d *VirtualMachineInstanceMigrationConditionManager) HasCondition(migration *v1.VirtualMachineInstanceMigration, cond v1.VirtualMachineInstanceMigrationConditionType) bool {
-	for _, c := range migration.Status.Conditions {
-		if c.Type == cond {
-			return true
-		}
-	}
-	return false
+	return conditions.GetMigrationCondition(migration, cond) != nil
 }
 
 func (d *VirtualMachineInstanceMigrationConditionManager) HasConditionWithStatus(migration *v1.VirtualMachineInstanceMigration, cond v1.VirtualMachineInstanceMigrationConditionType, status k8sv1.ConditionStatus) bool {
-	for _, c := range migration.Status.Conditions {
-		if c.Type == cond {
-			if c.Status == status {
-				return true
-			}
-			return false
-		}
-	}
-	return false
+	c := conditions.GetMigrationCondition(migration, cond)
+	return c != nil && c.Status == status
 }
 
 func (d *VirtualMachineInstanceMigrationConditionManager) RemoveCondition(migration *v1.VirtualMachineInstanceMigration, cond v1.VirtualMachineInstanceMigrationConditionType) {
-	var conds []v1.VirtualMachineInstanceMigrationCondition
-	for _, c := range migration.Status.Conditions {
-		if c.Type == cond {
-			continue
-		}
-		conds = append(conds, c)
-	}
-	migration.Status.Conditions = conds
+	conditions.RemoveMigrationCondition(migration, cond)
 }
+
+// UpdateCondition adds or updates the given VirtualMachineInstanceMigrationCondition, see conditions.SetMigrationCondition.
+func (d *VirtualMachineInstanceMigrationConditionManager) UpdateCondition(migration *v1.VirtualMachineInstanceMigration, cond *v1.VirtualMachineInstanceMigrationCondition) bool {
+	return conditions.SetMigrationCondition(migration, *cond)
+}
+
 func NewVirtualMachineInstanceMigrationConditionManager() *VirtualMachineInstanceMigrationConditionManager {
 	return &VirtualMachineInstanceMigrationConditionManager{}
 }
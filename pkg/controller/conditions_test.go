@@ -23,6 +23,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	v12 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	v1 "kubevirt.io/client-go/api/v1"
 )
@@ -97,9 +98,13 @@ var _ = Describe("VirtualMachineInstance ConditionManager", func() {
 				Status: v12.ConditionTrue,
 			}
 
-			cm.UpdateCondition(vmi, vc2)
+			Expect(cm.UpdateCondition(vmi, vc2)).To(BeTrue())
 			Expect(len(vmi.Status.Conditions)).To(Equal(1))
-			Expect(cm.GetCondition(vmi, vc1.Type)).To(Equal(vc2))
+			cond := cm.GetCondition(vmi, vc1.Type)
+			Expect(cond.Status).To(Equal(vc2.Status))
+			Expect(cond.Reason).To(BeEmpty())
+			Expect(cond.Message).To(BeEmpty())
+			Expect(cond.LastTransitionTime.IsZero()).To(BeFalse())
 		})
 
 		It("should update the condition if the reason has changed", func() {
@@ -115,7 +120,7 @@ var _ = Describe("VirtualMachineInstance ConditionManager", func() {
 			Expect(cm.GetCondition(vmi, vc1.Type)).To(Equal(vc2))
 		})
 
-		It("shouldn't update the condition if both status and reason hasn't changed", func() {
+		It("should update the message but keep the transition time if only the message has changed", func() {
 			vc2 := &v1.VirtualMachineInstanceCondition{
 				Type:    v1.VirtualMachineInstanceReady,
 				Status:  v12.ConditionFalse,
@@ -123,8 +128,16 @@ var _ = Describe("VirtualMachineInstance ConditionManager", func() {
 				Message: "A different message",
 			}
 
-			cm.UpdateCondition(vmi, vc2)
+			Expect(cm.UpdateCondition(vmi, vc2)).To(BeTrue())
 			Expect(len(vmi.Status.Conditions)).To(Equal(1))
+			Expect(cm.GetCondition(vmi, vc1.Type)).To(Equal(vc2))
+		})
+
+		It("shouldn't update the condition if nothing has changed", func() {
+			vc2 := vc1.DeepCopy()
+			vc2.LastProbeTime = metav1.Now()
+
+			Expect(cm.UpdateCondition(vmi, vc2)).To(BeFalse())
 			Expect(cm.GetCondition(vmi, vc1.Type)).To(Equal(vc1))
 		})
 	})
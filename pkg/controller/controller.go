@@ -317,6 +317,19 @@ func SetVMIPhaseTransitionTimestamp(oldVMI *v1.VirtualMachineInstance, newVMI *v
 	}
 }
 
+// GuestAgentFeatureUnavailable checks whether the guest agent of the VMI is known to lack the commands a feature relies on
+func GuestAgentFeatureUnavailable(vmi *v1.VirtualMachineInstance, feature v1.GuestAgentFeature) bool {
+	if vmi.Status.GuestAgent == nil {
+		return false
+	}
+	for _, unavailable := range vmi.Status.GuestAgent.UnavailableFeatures {
+		if unavailable == feature {
+			return true
+		}
+	}
+	return false
+}
+
 func VMIHasHotplugVolumes(vmi *v1.VirtualMachineInstance) bool {
 	for _, volumeStatus := range vmi.Status.VolumeStatus {
 		if volumeStatus.HotplugVolume != nil {
@@ -0,0 +1,167 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package controller
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
+)
+
+// DefaultEventDedupWindow is how long identical events of an object are suppressed after they were recorded
+const DefaultEventDedupWindow = 5 * time.Minute
+
+type eventKey struct {
+	uid       types.UID
+	namespace string
+	name      string
+	eventtype string
+	reason    string
+	message   string
+}
+
+type dedupedEvent struct {
+	// object is the latest object the event was recorded for
+	object      runtime.Object
+	annotations map[string]string
+	recorded    time.Time
+	suppressed  int
+}
+
+// DedupingEventRecorder records identical events of the same object at most once per window, so that a
+// single broken object which fails over and over again can't flood etcd with events. The first occurrence
+// is recorded right away, the latest one is recorded together with the number of suppressed occurrences
+// once the window expired.
+type DedupingEventRecorder struct {
+	recorder record.EventRecorder
+	window   time.Duration
+	clock    clock.Clock
+
+	lock   sync.Mutex
+	events map[eventKey]*dedupedEvent
+}
+
+func NewDedupingEventRecorder(recorder record.EventRecorder, window time.Duration) *DedupingEventRecorder {
+	return &DedupingEventRecorder{
+		recorder: recorder,
+		window:   window,
+		clock:    clock.RealClock{},
+		events:   map[eventKey]*dedupedEvent{},
+	}
+}
+
+// Run records the latest occurrence of the suppressed events whose window expired until stop is closed
+func (r *DedupingEventRecorder) Run(stop <-chan struct{}) {
+	wait.Until(r.flush, r.window, stop)
+}
+
+func (r *DedupingEventRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	r.AnnotatedEventf(object, nil, eventtype, reason, "%s", message)
+}
+
+func (r *DedupingEventRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.AnnotatedEventf(object, nil, eventtype, reason, messageFmt, args...)
+}
+
+func (r *DedupingEventRecorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	message := fmt.Sprintf(messageFmt, args...)
+
+	key, ok := newEventKey(object, eventtype, reason, message)
+	if !ok {
+		r.record(object, annotations, eventtype, reason, message, 0)
+		return
+	}
+
+	r.lock.Lock()
+	now := r.clock.Now()
+	suppressed := 0
+	if event, exists := r.events[key]; exists {
+		if now.Sub(event.recorded) < r.window {
+			event.object = object
+			event.annotations = annotations
+			event.suppressed++
+			r.lock.Unlock()
+			return
+		}
+		suppressed = event.suppressed
+	}
+	r.events[key] = &dedupedEvent{object: object, recorded: now}
+	r.lock.Unlock()
+
+	r.record(object, annotations, eventtype, reason, message, suppressed)
+}
+
+// flush records the latest occurrence of the events which were suppressed in their expired window
+// and forgets about the expired events
+func (r *DedupingEventRecorder) flush() {
+	r.lock.Lock()
+	now := r.clock.Now()
+	var pending []eventKey
+	var pendingEvents []*dedupedEvent
+	for key, event := range r.events {
+		if now.Sub(event.recorded) < r.window {
+			continue
+		}
+		delete(r.events, key)
+		if event.suppressed > 0 {
+			pending = append(pending, key)
+			pendingEvents = append(pendingEvents, event)
+		}
+	}
+	r.lock.Unlock()
+
+	for i, key := range pending {
+		event := pendingEvents[i]
+		r.record(event.object, event.annotations, key.eventtype, key.reason, key.message, event.suppressed)
+	}
+}
+
+func (r *DedupingEventRecorder) record(object runtime.Object, annotations map[string]string, eventtype, reason, message string, suppressed int) {
+	if suppressed > 0 {
+		message = fmt.Sprintf("%s (%d identical events suppressed)", message, suppressed)
+	}
+	if annotations != nil {
+		r.recorder.AnnotatedEventf(object, annotations, eventtype, reason, "%s", message)
+		return
+	}
+	r.recorder.Event(object, eventtype, reason, message)
+}
+
+func newEventKey(object runtime.Object, eventtype, reason, message string) (eventKey, bool) {
+	accessor, err := meta.Accessor(object)
+	if err != nil {
+		return eventKey{}, false
+	}
+	return eventKey{
+		uid:       accessor.GetUID(),
+		namespace: accessor.GetNamespace(),
+		name:      accessor.GetName(),
+		eventtype: eventtype,
+		reason:    reason,
+		message:   message,
+	}, true
+}
@@ -0,0 +1,98 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/client-go/tools/record"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("DedupingEventRecorder", func() {
+	const window = time.Minute
+
+	var fakeRecorder *record.FakeRecorder
+	var fakeClock *clock.FakeClock
+	var recorder *DedupingEventRecorder
+	var vmi *v1.VirtualMachineInstance
+
+	BeforeEach(func() {
+		fakeRecorder = record.NewFakeRecorder(100)
+		fakeClock = clock.NewFakeClock(time.Now())
+		recorder = NewDedupingEventRecorder(fakeRecorder, window)
+		recorder.clock = fakeClock
+
+		vmi = v1.NewMinimalVMI("testvmi")
+		vmi.UID = types.UID("1234")
+	})
+
+	It("should record identical events only once per window", func() {
+		for i := 0; i < 5; i++ {
+			recorder.Eventf(vmi, k8sv1.EventTypeWarning, "SyncFailed", "failed to sync: %s", "boom")
+		}
+		Expect(fakeRecorder.Events).To(Receive(Equal("Warning SyncFailed failed to sync: boom")))
+		Expect(fakeRecorder.Events).ToNot(Receive())
+	})
+
+	It("should not suppress different events of the same object or the same event of different objects", func() {
+		other := v1.NewMinimalVMI("othervmi")
+		other.UID = types.UID("5678")
+
+		recorder.Event(vmi, k8sv1.EventTypeWarning, "SyncFailed", "boom")
+		recorder.Event(vmi, k8sv1.EventTypeNormal, "Started", "started")
+		recorder.Event(other, k8sv1.EventTypeWarning, "SyncFailed", "boom")
+		Expect(fakeRecorder.Events).To(HaveLen(3))
+	})
+
+	It("should record the latest suppressed occurrence with its count once the window expired", func() {
+		for i := 0; i < 4; i++ {
+			recorder.Event(vmi, k8sv1.EventTypeWarning, "SyncFailed", "boom")
+		}
+		Expect(fakeRecorder.Events).To(Receive(Equal("Warning SyncFailed boom")))
+
+		recorder.flush()
+		Expect(fakeRecorder.Events).ToNot(Receive())
+
+		fakeClock.Step(window)
+		recorder.flush()
+		Expect(fakeRecorder.Events).To(Receive(Equal("Warning SyncFailed boom (3 identical events suppressed)")))
+
+		By("recording the next occurrence right away")
+		recorder.Event(vmi, k8sv1.EventTypeWarning, "SyncFailed", "boom")
+		Expect(fakeRecorder.Events).To(Receive(Equal("Warning SyncFailed boom")))
+	})
+
+	It("should add the suppressed count to the first occurrence after the window expired", func() {
+		recorder.Event(vmi, k8sv1.EventTypeWarning, "SyncFailed", "boom")
+		recorder.Event(vmi, k8sv1.EventTypeWarning, "SyncFailed", "boom")
+		Expect(fakeRecorder.Events).To(Receive(Equal("Warning SyncFailed boom")))
+
+		fakeClock.Step(window)
+		recorder.Event(vmi, k8sv1.EventTypeWarning, "SyncFailed", "boom")
+		Expect(fakeRecorder.Events).To(Receive(Equal("Warning SyncFailed boom (1 identical events suppressed)")))
+	})
+})
@@ -98,6 +98,21 @@ type KubeInformerFactory interface {
 	// Watches VirtualMachineInstanceMigration objects
 	VirtualMachineInstanceMigration() cache.SharedIndexInformer
 
+	// Watches VirtualMachineSchedulingHints objects
+	VirtualMachineSchedulingHints() cache.SharedIndexInformer
+
+	// Watches VirtualMachineImageExport objects
+	VirtualMachineImageExport() cache.SharedIndexInformer
+
+	// Watches VirtualMachineBulkOperation objects
+	VirtualMachineBulkOperation() cache.SharedIndexInformer
+
+	// Watches ValidationPolicy objects
+	ValidationPolicy() cache.SharedIndexInformer
+
+	// Watches MaintenanceWindow objects
+	MaintenanceWindow() cache.SharedIndexInformer
+
 	// Watches VirtualMachineSnapshot objects
 	VirtualMachineSnapshot() cache.SharedIndexInformer
 
@@ -122,6 +137,9 @@ type KubeInformerFactory interface {
 	// Watches for PersistentVolumeClaim objects
 	PersistentVolumeClaim() cache.SharedIndexInformer
 
+	// Watches for PersistentVolume objects
+	PersistentVolume() cache.SharedIndexInformer
+
 	// Watches for ControllerRevision objects
 	ControllerRevision() cache.SharedIndexInformer
 
@@ -218,6 +236,9 @@ type KubeInformerFactory interface {
 	// Pod returns an informer for ALL Pods in the system
 	Pod() cache.SharedIndexInformer
 
+	// ActivePod returns an informer for the scheduled Pods which still hold on to the resources of their node
+	ActivePod() cache.SharedIndexInformer
+
 	K8SInformerFactory() informers.SharedInformerFactory
 }
 
@@ -368,6 +389,41 @@ func (f *kubeInformerFactory) VirtualMachineInstanceMigration() cache.SharedInde
 	})
 }
 
+func (f *kubeInformerFactory) VirtualMachineSchedulingHints() cache.SharedIndexInformer {
+	return f.getInformer("vmSchedulingHintsInformer", func() cache.SharedIndexInformer {
+		lw := cache.NewListWatchFromClient(f.restClient, "virtualmachineschedulinghints", k8sv1.NamespaceAll, fields.Everything())
+		return cache.NewSharedIndexInformer(lw, &kubev1.VirtualMachineSchedulingHints{}, f.defaultResync, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	})
+}
+
+func (f *kubeInformerFactory) VirtualMachineImageExport() cache.SharedIndexInformer {
+	return f.getInformer("vmImageExportInformer", func() cache.SharedIndexInformer {
+		lw := cache.NewListWatchFromClient(f.restClient, "virtualmachineimageexports", k8sv1.NamespaceAll, fields.Everything())
+		return cache.NewSharedIndexInformer(lw, &kubev1.VirtualMachineImageExport{}, f.defaultResync, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	})
+}
+
+func (f *kubeInformerFactory) ValidationPolicy() cache.SharedIndexInformer {
+	return f.getInformer("validationPolicyInformer", func() cache.SharedIndexInformer {
+		lw := cache.NewListWatchFromClient(f.restClient, "validationpolicies", k8sv1.NamespaceAll, fields.Everything())
+		return cache.NewSharedIndexInformer(lw, &kubev1.ValidationPolicy{}, f.defaultResync, cache.Indexers{})
+	})
+}
+
+func (f *kubeInformerFactory) MaintenanceWindow() cache.SharedIndexInformer {
+	return f.getInformer("maintenanceWindowInformer", func() cache.SharedIndexInformer {
+		lw := cache.NewListWatchFromClient(f.restClient, "maintenancewindows", k8sv1.NamespaceAll, fields.Everything())
+		return cache.NewSharedIndexInformer(lw, &kubev1.MaintenanceWindow{}, f.defaultResync, cache.Indexers{})
+	})
+}
+
+func (f *kubeInformerFactory) VirtualMachineBulkOperation() cache.SharedIndexInformer {
+	return f.getInformer("vmBulkOperationInformer", func() cache.SharedIndexInformer {
+		lw := cache.NewListWatchFromClient(f.restClient, "virtualmachinebulkoperations", k8sv1.NamespaceAll, fields.Everything())
+		return cache.NewSharedIndexInformer(lw, &kubev1.VirtualMachineBulkOperation{}, f.defaultResync, cache.Indexers{})
+	})
+}
+
 func (f *kubeInformerFactory) KubeVirtPod() cache.SharedIndexInformer {
 	return f.getInformer("kubeVirtPodInformer", func() cache.SharedIndexInformer {
 		// Watch all pods with the kubevirt app label
@@ -523,6 +579,14 @@ func (f *kubeInformerFactory) PersistentVolumeClaim() cache.SharedIndexInformer
 	})
 }
 
+func (f *kubeInformerFactory) PersistentVolume() cache.SharedIndexInformer {
+	return f.getInformer("persistentVolumeInformer", func() cache.SharedIndexInformer {
+		restClient := f.clientSet.CoreV1().RESTClient()
+		lw := cache.NewListWatchFromClient(restClient, "persistentvolumes", k8sv1.NamespaceAll, fields.Everything())
+		return cache.NewSharedIndexInformer(lw, &k8sv1.PersistentVolume{}, f.defaultResync, cache.Indexers{})
+	})
+}
+
 func (f *kubeInformerFactory) LimitRanges() cache.SharedIndexInformer {
 	return f.getInformer("limitrangeInformer", func() cache.SharedIndexInformer {
 		restClient := f.clientSet.CoreV1().RESTClient()
@@ -877,6 +941,26 @@ func (f *kubeInformerFactory) Pod() cache.SharedIndexInformer {
 	})
 }
 
+func (f *kubeInformerFactory) ActivePod() cache.SharedIndexInformer {
+	return f.getInformer("activePodInformer", func() cache.SharedIndexInformer {
+		fieldSelector := fields.AndSelectors(
+			fields.OneTermNotEqualSelector("spec.nodeName", ""),
+			fields.OneTermNotEqualSelector("status.phase", string(k8sv1.PodSucceeded)),
+			fields.OneTermNotEqualSelector("status.phase", string(k8sv1.PodFailed)),
+		)
+		lw := NewListWatchFromClient(f.clientSet.CoreV1().RESTClient(), "pods", k8sv1.NamespaceAll, fieldSelector, labels.Everything())
+		return cache.NewSharedIndexInformer(lw, &k8sv1.Pod{}, f.defaultResync, cache.Indexers{
+			"node": func(obj interface{}) ([]string, error) {
+				pod, ok := obj.(*k8sv1.Pod)
+				if !ok {
+					return nil, unexpectedObjectError
+				}
+				return []string{pod.Spec.NodeName}, nil
+			},
+		})
+	})
+}
+
 // VolumeSnapshotInformer returns an informer for VolumeSnapshots
 func VolumeSnapshotInformer(clientSet kubecli.KubevirtClient, resyncPeriod time.Duration) cache.SharedIndexInformer {
 	restClient := clientSet.KubernetesSnapshotClient().SnapshotV1beta1().RESTClient()
@@ -1,6 +1,7 @@
 package emptydisk
 
 import (
+	"fmt"
 	"os"
 	"os/exec"
 	"path"
@@ -15,7 +16,7 @@ const emptyDiskBaseDir = "/var/run/libvirt/empty-disks/"
 
 type emptyDiskCreator struct {
 	emptyDiskBaseDir string
-	discCreateFunc   func(filePath string, size string) error
+	discCreateFunc   func(filePath string, size string, format v1.DiskImageFormat, preallocation v1.DiskImagePreallocation) error
 }
 
 func (c *emptyDiskCreator) CreateTemporaryDisks(vmi *v1.VirtualMachineInstance) error {
@@ -24,12 +25,12 @@ func (c *emptyDiskCreator) CreateTemporaryDisks(vmi *v1.VirtualMachineInstance)
 		if volume.EmptyDisk != nil {
 			// qemu-img takes the size in bytes or in Kibibytes/Mebibytes/...; lets take bytes
 			size := strconv.FormatInt(volume.EmptyDisk.Capacity.ToDec().ScaledValue(0), 10)
-			file := filePathForVolumeName(c.emptyDiskBaseDir, volume.Name)
+			file := filePathForVolumeName(c.emptyDiskBaseDir, volume.Name, GetImageFormat(volume.EmptyDisk))
 			if err := util.MkdirAllWithNosec(c.emptyDiskBaseDir); err != nil {
 				return err
 			}
 			if _, err := os.Stat(file); os.IsNotExist(err) {
-				if err := c.discCreateFunc(file, size); err != nil {
+				if err := c.discCreateFunc(file, size, GetImageFormat(volume.EmptyDisk), GetPreallocation(volume.EmptyDisk)); err != nil {
 					return err
 				}
 			} else if err != nil {
@@ -44,22 +45,52 @@ func (c *emptyDiskCreator) CreateTemporaryDisks(vmi *v1.VirtualMachineInstance)
 	return nil
 }
 
-func (c *emptyDiskCreator) FilePathForVolumeName(volumeName string) string {
-	return filePathForVolumeName(c.emptyDiskBaseDir, volumeName)
+func (c *emptyDiskCreator) FilePathForVolumeName(volumeName string, format v1.DiskImageFormat) string {
+	return filePathForVolumeName(c.emptyDiskBaseDir, volumeName, format)
 }
 
-func filePathForVolumeName(basedir string, volumeName string) string {
+// The file extension follows the image format, raw images get the .img extension like the disks on PVCs.
+// The format of a volume can't change while the VMI exists, so the path stays stable for running and migrating VMIs.
+func filePathForVolumeName(basedir string, volumeName string, format v1.DiskImageFormat) string {
+	if format == v1.DiskImageFormatRaw {
+		return path.Join(basedir, volumeName+".img")
+	}
 	return path.Join(basedir, volumeName+".qcow2")
 }
 
-func createQCOW(file string, size string) error {
+// GetImageFormat returns the requested on-disk format of the empty disk, defaulting to qcow2
+func GetImageFormat(source *v1.EmptyDiskSource) v1.DiskImageFormat {
+	if source.ImageFormat == "" {
+		return v1.DiskImageFormatQCOW2
+	}
+	return source.ImageFormat
+}
+
+// GetPreallocation returns the requested preallocation mode of the empty disk, defaulting to off
+func GetPreallocation(source *v1.EmptyDiskSource) v1.DiskImagePreallocation {
+	if source.Preallocation == "" {
+		return v1.DiskImagePreallocationOff
+	}
+	return source.Preallocation
+}
+
+func createImage(file string, size string, format v1.DiskImageFormat, preallocation v1.DiskImagePreallocation) error {
+	args := []string{"create", "-f", string(format)}
+	if preallocation != v1.DiskImagePreallocationOff {
+		args = append(args, "-o", "preallocation="+string(preallocation))
+	}
+	args = append(args, file, size)
 	// #nosec No risk for attacket injection. Parameters are predefined strings
-	return exec.Command("qemu-img", "create", "-f", "qcow2", file, size).Run()
+	output, err := exec.Command("qemu-img", args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("qemu-img failed with output '%s': %v", string(output), err)
+	}
+	return nil
 }
 
 func NewEmptyDiskCreator() *emptyDiskCreator {
 	return &emptyDiskCreator{
 		emptyDiskBaseDir: emptyDiskBaseDir,
-		discCreateFunc:   createQCOW,
+		discCreateFunc:   createImage,
 	}
 }
@@ -54,7 +54,7 @@ var _ = Describe("EmptyDisk", func() {
 			AppendEmptyDisk(vmi, "testdisk")
 			err := creator.CreateTemporaryDisks(vmi)
 			Expect(err).ToNot(HaveOccurred())
-			_, err = os.Stat(filePathForVolumeName(emptyDiskBaseDir, "testdisk"))
+			_, err = os.Stat(filePathForVolumeName(emptyDiskBaseDir, "testdisk", v1.DiskImageFormatQCOW2))
 			Expect(err).ToNot(HaveOccurred())
 			_, err = os.Stat(path.Join(emptyDiskBaseDir, "testdisk.qcow2"))
 			Expect(err).ToNot(HaveOccurred())
@@ -64,21 +64,58 @@ var _ = Describe("EmptyDisk", func() {
 			AppendEmptyDisk(vmi, "testdisk")
 			err := creator.CreateTemporaryDisks(vmi)
 			Expect(err).ToNot(HaveOccurred())
-			_, err = os.Stat(filePathForVolumeName(emptyDiskBaseDir, "testdisk"))
+			_, err = os.Stat(filePathForVolumeName(emptyDiskBaseDir, "testdisk", v1.DiskImageFormatQCOW2))
 			Expect(err).ToNot(HaveOccurred())
 			_, err = os.Stat(path.Join(emptyDiskBaseDir, "testdisk.qcow2"))
 			Expect(err).ToNot(HaveOccurred())
 		})
 		It("should generate non-conflicting volume paths per disk", func() {
-			Expect(NewEmptyDiskCreator().FilePathForVolumeName("volume1")).ToNot(Equal(NewEmptyDiskCreator().FilePathForVolumeName("volume2")))
+			Expect(NewEmptyDiskCreator().FilePathForVolumeName("volume1", v1.DiskImageFormatQCOW2)).ToNot(Equal(NewEmptyDiskCreator().FilePathForVolumeName("volume2", v1.DiskImageFormatQCOW2)))
+		})
+		It("should create a sparse qcow2 image by default", func() {
+			var format v1.DiskImageFormat
+			var preallocation v1.DiskImagePreallocation
+			creator.discCreateFunc = func(filePath string, size string, f v1.DiskImageFormat, p v1.DiskImagePreallocation) error {
+				format, preallocation = f, p
+				return fakeCreatorFunc(filePath, size, f, p)
+			}
+			vmi := v1.NewMinimalVMI("testvmi")
+			AppendEmptyDisk(vmi, "testdisk")
+			Expect(creator.CreateTemporaryDisks(vmi)).To(Succeed())
+			Expect(format).To(Equal(v1.DiskImageFormatQCOW2))
+			Expect(preallocation).To(Equal(v1.DiskImagePreallocationOff))
+		})
+		It("should create the image with the requested format and preallocation", func() {
+			var format v1.DiskImageFormat
+			var preallocation v1.DiskImagePreallocation
+			creator.discCreateFunc = func(filePath string, size string, f v1.DiskImageFormat, p v1.DiskImagePreallocation) error {
+				format, preallocation = f, p
+				return fakeCreatorFunc(filePath, size, f, p)
+			}
+			vmi := v1.NewMinimalVMI("testvmi")
+			AppendEmptyDisk(vmi, "testdisk")
+			vmi.Spec.Volumes[0].EmptyDisk.ImageFormat = v1.DiskImageFormatRaw
+			vmi.Spec.Volumes[0].EmptyDisk.Preallocation = v1.DiskImagePreallocationFalloc
+			Expect(creator.CreateTemporaryDisks(vmi)).To(Succeed())
+			Expect(format).To(Equal(v1.DiskImageFormatRaw))
+			Expect(preallocation).To(Equal(v1.DiskImagePreallocationFalloc))
+		})
+		It("should give raw images the .img extension", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			AppendEmptyDisk(vmi, "testdisk")
+			vmi.Spec.Volumes[0].EmptyDisk.ImageFormat = v1.DiskImageFormatRaw
+			Expect(creator.CreateTemporaryDisks(vmi)).To(Succeed())
+			Expect(filePathForVolumeName(emptyDiskBaseDir, "testdisk", v1.DiskImageFormatRaw)).To(Equal(path.Join(emptyDiskBaseDir, "testdisk.img")))
+			_, err := os.Stat(path.Join(emptyDiskBaseDir, "testdisk.img"))
+			Expect(err).ToNot(HaveOccurred())
 		})
 		It("should leave pre-existing disks alone", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 			AppendEmptyDisk(vmi, "testdisk")
-			ioutil.WriteFile(filePathForVolumeName(emptyDiskBaseDir, "testdisk"), []byte("test"), 0777)
+			ioutil.WriteFile(filePathForVolumeName(emptyDiskBaseDir, "testdisk", v1.DiskImageFormatQCOW2), []byte("test"), 0777)
 			err := creator.CreateTemporaryDisks(vmi)
 			Expect(err).ToNot(HaveOccurred())
-			data, err := ioutil.ReadFile(filePathForVolumeName(emptyDiskBaseDir, "testdisk"))
+			data, err := ioutil.ReadFile(filePathForVolumeName(emptyDiskBaseDir, "testdisk", v1.DiskImageFormatQCOW2))
 			Expect(err).ToNot(HaveOccurred())
 			Expect(string(data)).To(Equal("test"))
 		})
@@ -86,7 +123,7 @@ var _ = Describe("EmptyDisk", func() {
 
 })
 
-func fakeCreatorFunc(filePath string, _ string) error {
+func fakeCreatorFunc(filePath string, _ string, _ v1.DiskImageFormat, _ v1.DiskImagePreallocation) error {
 	fmt.Println(filePath)
 	f, err := os.Create(filePath)
 	if err == nil {
@@ -44,7 +44,7 @@ type EphemeralDiskCreatorInterface interface {
 type ephemeralDiskCreator struct {
 	mountBaseDir   string
 	pvcBaseDir     string
-	discCreateFunc func(filePath string, size string) ([]byte, error)
+	discCreateFunc func(backingFile string, imagePath string, format v1.DiskImageFormat, preallocation v1.DiskImagePreallocation) ([]byte, error)
 }
 
 func NewEphemeralDiskCreator(mountBaseDir string) *ephemeralDiskCreator {
@@ -97,7 +97,12 @@ func (c *ephemeralDiskCreator) CreateBackedImageForVolume(volume v1.Volume, back
 		return err
 	}
 
-	output, err := c.discCreateFunc(backingFile, imagePath)
+	format, preallocation := v1.DiskImageFormatQCOW2, v1.DiskImagePreallocationOff
+	if volume.Ephemeral != nil {
+		format, preallocation = GetImageFormat(volume.Ephemeral), GetPreallocation(volume.Ephemeral)
+	}
+
+	output, err := c.discCreateFunc(backingFile, imagePath, format, preallocation)
 
 	// Cleanup of previous images isn't really necessary as they're all on EmptyDir.
 	if err != nil {
@@ -129,15 +134,44 @@ func (c *ephemeralDiskCreator) CreateEphemeralImages(vmi *v1.VirtualMachineInsta
 	return nil
 }
 
-func createBackingDisk(backingFile string, imagePath string) ([]byte, error) {
+// GetImageFormat returns the requested format of the local image, defaulting to a qcow2 overlay
+func GetImageFormat(source *v1.EphemeralVolumeSource) v1.DiskImageFormat {
+	if source.ImageFormat == "" {
+		return v1.DiskImageFormatQCOW2
+	}
+	return source.ImageFormat
+}
+
+// GetPreallocation returns the requested preallocation mode of the local image, defaulting to off
+func GetPreallocation(source *v1.EphemeralVolumeSource) v1.DiskImagePreallocation {
+	if source.Preallocation == "" {
+		return v1.DiskImagePreallocationOff
+	}
+	return source.Preallocation
+}
+
+func createBackingDisk(backingFile string, imagePath string, format v1.DiskImageFormat, preallocation v1.DiskImagePreallocation) ([]byte, error) {
 	// #nosec No risk for attacket injection. Parameters are predefined strings
-	cmd := exec.Command("qemu-img",
-		"create",
-		"-f",
-		"qcow2",
-		"-b",
-		backingFile,
-		imagePath,
-	)
+	cmd := exec.Command("qemu-img", backingDiskArgs(backingFile, imagePath, format, preallocation)...)
 	return cmd.CombinedOutput()
 }
+
+// backingDiskArgs returns the qemu-img arguments to create the local image of the PVC.
+// The disk.img on the PVC is always raw, its format is passed explicitly to not let
+// qemu-img probe the format of guest data.
+func backingDiskArgs(backingFile string, imagePath string, format v1.DiskImageFormat, preallocation v1.DiskImagePreallocation) []string {
+	var args []string
+	if format == v1.DiskImageFormatRaw {
+		// A raw image can't have a backing file, so expand the backing file into a standalone copy
+		args = []string{"convert", "-f", string(v1.DiskImageFormatRaw), "-O", string(format)}
+	} else {
+		args = []string{"create", "-f", string(format), "-b", backingFile, "-F", string(v1.DiskImageFormatRaw)}
+	}
+	if preallocation != v1.DiskImagePreallocationOff {
+		args = append(args, "-o", "preallocation="+string(preallocation))
+	}
+	if format == v1.DiskImageFormatRaw {
+		args = append(args, backingFile)
+	}
+	return append(args, imagePath)
+}
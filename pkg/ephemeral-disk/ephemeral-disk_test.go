@@ -25,6 +25,7 @@ import (
 	"path/filepath"
 
 	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 	k8sv1 "k8s.io/api/core/v1"
 
@@ -135,6 +136,33 @@ var _ = Describe("ContainerDisk", func() {
 				_, err = os.Stat(filepath.Join(creator.mountBaseDir, "fake-disk3", "disk.qcow2"))
 				Expect(err).NotTo(HaveOccurred())
 			})
+			It("Should pass the requested image format and preallocation to qemu-img", func() {
+				var format v1.DiskImageFormat
+				var preallocation v1.DiskImagePreallocation
+				creator.discCreateFunc = func(backingFile string, imagePath string, f v1.DiskImageFormat, p v1.DiskImagePreallocation) ([]byte, error) {
+					format, preallocation = f, p
+					return fakeCreateBackingDisk(backingFile, imagePath, f, p)
+				}
+				vmi := v1.NewMinimalVMI("fake-vmi")
+				AppendEphemeralPVC(vmi, "fake-disk1", "fake-pvc1")
+				vmi.Spec.Volumes[0].Ephemeral.ImageFormat = v1.DiskImageFormatRaw
+				vmi.Spec.Volumes[0].Ephemeral.Preallocation = v1.DiskImagePreallocationFull
+				Expect(creator.CreateEphemeralImages(vmi)).To(Succeed())
+				Expect(format).To(Equal(v1.DiskImageFormatRaw))
+				Expect(preallocation).To(Equal(v1.DiskImagePreallocationFull))
+			})
+			table.DescribeTable("Should pass the format of the PVC disk to qemu-img", func(format v1.DiskImageFormat, preallocation v1.DiskImagePreallocation, expectedArgs ...string) {
+				Expect(backingDiskArgs("/pvc/disk.img", "/local/disk.qcow2", format, preallocation)).To(Equal(expectedArgs))
+			},
+				table.Entry("for a qcow2 overlay", v1.DiskImageFormatQCOW2, v1.DiskImagePreallocationOff,
+					"create", "-f", "qcow2", "-b", "/pvc/disk.img", "-F", "raw", "/local/disk.qcow2"),
+				table.Entry("for a preallocated qcow2 overlay", v1.DiskImageFormatQCOW2, v1.DiskImagePreallocationMetadata,
+					"create", "-f", "qcow2", "-b", "/pvc/disk.img", "-F", "raw", "-o", "preallocation=metadata", "/local/disk.qcow2"),
+				table.Entry("for a raw copy", v1.DiskImageFormatRaw, v1.DiskImagePreallocationOff,
+					"convert", "-f", "raw", "-O", "raw", "/pvc/disk.img", "/local/disk.qcow2"),
+				table.Entry("for a preallocated raw copy", v1.DiskImageFormatRaw, v1.DiskImagePreallocationFull,
+					"convert", "-f", "raw", "-O", "raw", "-o", "preallocation=full", "/pvc/disk.img", "/local/disk.qcow2"),
+			)
 			It("Should create ephemeral images in an idempotent way", func() {
 				vmi := v1.NewMinimalVMI("fake-vmi")
 				AppendEphemeralPVC(vmi, "fake-disk1", "fake-pvc1")
@@ -147,7 +175,7 @@ var _ = Describe("ContainerDisk", func() {
 	})
 })
 
-func fakeCreateBackingDisk(backingFile string, imagePath string) ([]byte, error) {
+func fakeCreateBackingDisk(backingFile string, imagePath string, _ v1.DiskImageFormat, _ v1.DiskImagePreallocation) ([]byte, error) {
 	_, err := os.Stat(backingFile)
 	if os.IsNotExist(err) {
 		return nil, err
@@ -30,6 +30,10 @@ It has these top-level messages:
 	ExecResponse
 	GuestPingRequest
 	GuestPingResponse
+	ScreenshotResponse
+	MemoryUploadRequest
+	MemoryUploadResponse
+	LinkStateRequest
 */
 package v1
 
@@ -640,6 +644,118 @@ func (m *GuestPingResponse) GetResponse() *Response {
 	return nil
 }
 
+type ScreenshotResponse struct {
+	Response   *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	Screenshot []byte    `protobuf:"bytes,2,opt,name=screenshot,proto3" json:"screenshot,omitempty"`
+}
+
+func (m *ScreenshotResponse) Reset()                    { *m = ScreenshotResponse{} }
+func (m *ScreenshotResponse) String() string            { return proto.CompactTextString(m) }
+func (*ScreenshotResponse) ProtoMessage()               {}
+func (*ScreenshotResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+func (m *ScreenshotResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *ScreenshotResponse) GetScreenshot() []byte {
+	if m != nil {
+		return m.Screenshot
+	}
+	return nil
+}
+
+type MemoryUploadRequest struct {
+	Vmi         *VMI   `protobuf:"bytes,1,opt,name=vmi" json:"vmi,omitempty"`
+	Options     []byte `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+	Credentials []byte `protobuf:"bytes,3,opt,name=credentials,proto3" json:"credentials,omitempty"`
+}
+
+func (m *MemoryUploadRequest) Reset()                    { *m = MemoryUploadRequest{} }
+func (m *MemoryUploadRequest) String() string            { return proto.CompactTextString(m) }
+func (*MemoryUploadRequest) ProtoMessage()               {}
+func (*MemoryUploadRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *MemoryUploadRequest) GetVmi() *VMI {
+	if m != nil {
+		return m.Vmi
+	}
+	return nil
+}
+
+func (m *MemoryUploadRequest) GetOptions() []byte {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+func (m *MemoryUploadRequest) GetCredentials() []byte {
+	if m != nil {
+		return m.Credentials
+	}
+	return nil
+}
+
+type MemoryUploadResponse struct {
+	Response            *Response `protobuf:"bytes,1,opt,name=response" json:"response,omitempty"`
+	Status              string    `protobuf:"bytes,2,opt,name=status" json:"status,omitempty"`
+	CredentialsRequired bool      `protobuf:"varint,3,opt,name=credentialsRequired" json:"credentialsRequired,omitempty"`
+}
+
+func (m *MemoryUploadResponse) Reset()                    { *m = MemoryUploadResponse{} }
+func (m *MemoryUploadResponse) String() string            { return proto.CompactTextString(m) }
+func (*MemoryUploadResponse) ProtoMessage()               {}
+func (*MemoryUploadResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+func (m *MemoryUploadResponse) GetResponse() *Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *MemoryUploadResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *MemoryUploadResponse) GetCredentialsRequired() bool {
+	if m != nil {
+		return m.CredentialsRequired
+	}
+	return false
+}
+
+type LinkStateRequest struct {
+	Vmi     *VMI   `protobuf:"bytes,1,opt,name=vmi" json:"vmi,omitempty"`
+	Options []byte `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+}
+
+func (m *LinkStateRequest) Reset()                    { *m = LinkStateRequest{} }
+func (m *LinkStateRequest) String() string            { return proto.CompactTextString(m) }
+func (*LinkStateRequest) ProtoMessage()               {}
+func (*LinkStateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *LinkStateRequest) GetVmi() *VMI {
+	if m != nil {
+		return m.Vmi
+	}
+	return nil
+}
+
+func (m *LinkStateRequest) GetOptions() []byte {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*VMI)(nil), "kubevirt.cmd.v1.VMI")
 	proto.RegisterType((*CPU)(nil), "kubevirt.cmd.v1.CPU")
@@ -663,6 +779,10 @@ func init() {
 	proto.RegisterType((*ExecResponse)(nil), "kubevirt.cmd.v1.ExecResponse")
 	proto.RegisterType((*GuestPingRequest)(nil), "kubevirt.cmd.v1.GuestPingRequest")
 	proto.RegisterType((*GuestPingResponse)(nil), "kubevirt.cmd.v1.GuestPingResponse")
+	proto.RegisterType((*ScreenshotResponse)(nil), "kubevirt.cmd.v1.ScreenshotResponse")
+	proto.RegisterType((*MemoryUploadRequest)(nil), "kubevirt.cmd.v1.MemoryUploadRequest")
+	proto.RegisterType((*MemoryUploadResponse)(nil), "kubevirt.cmd.v1.MemoryUploadResponse")
+	proto.RegisterType((*LinkStateRequest)(nil), "kubevirt.cmd.v1.LinkStateRequest")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -681,6 +801,7 @@ type CmdClient interface {
 	UnpauseVirtualMachine(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error)
 	FreezeVirtualMachine(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error)
 	UnfreezeVirtualMachine(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error)
+	SnapshotVirtualMachineMemory(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error)
 	ShutdownVirtualMachine(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error)
 	KillVirtualMachine(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error)
 	DeleteVirtualMachine(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error)
@@ -697,6 +818,9 @@ type CmdClient interface {
 	Ping(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*Response, error)
 	Exec(ctx context.Context, in *ExecRequest, opts ...grpc.CallOption) (*ExecResponse, error)
 	GuestPing(ctx context.Context, in *GuestPingRequest, opts ...grpc.CallOption) (*GuestPingResponse, error)
+	Screenshot(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*ScreenshotResponse, error)
+	UploadVirtualMachineMemory(ctx context.Context, in *MemoryUploadRequest, opts ...grpc.CallOption) (*MemoryUploadResponse, error)
+	SetVirtualMachineInterfaceLinkState(ctx context.Context, in *LinkStateRequest, opts ...grpc.CallOption) (*Response, error)
 }
 
 type cmdClient struct {
@@ -752,6 +876,15 @@ func (c *cmdClient) UnfreezeVirtualMachine(ctx context.Context, in *VMIRequest,
 	return out, nil
 }
 
+func (c *cmdClient) SnapshotVirtualMachineMemory(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/SnapshotVirtualMachineMemory", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *cmdClient) ShutdownVirtualMachine(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error) {
 	out := new(Response)
 	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/ShutdownVirtualMachine", in, out, c.cc, opts...)
@@ -896,6 +1029,33 @@ func (c *cmdClient) GuestPing(ctx context.Context, in *GuestPingRequest, opts ..
 	return out, nil
 }
 
+func (c *cmdClient) Screenshot(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*ScreenshotResponse, error) {
+	out := new(ScreenshotResponse)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/Screenshot", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) UploadVirtualMachineMemory(ctx context.Context, in *MemoryUploadRequest, opts ...grpc.CallOption) (*MemoryUploadResponse, error) {
+	out := new(MemoryUploadResponse)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/UploadVirtualMachineMemory", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cmdClient) SetVirtualMachineInterfaceLinkState(ctx context.Context, in *LinkStateRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := grpc.Invoke(ctx, "/kubevirt.cmd.v1.Cmd/SetVirtualMachineInterfaceLinkState", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Cmd service
 
 type CmdServer interface {
@@ -904,6 +1064,7 @@ type CmdServer interface {
 	UnpauseVirtualMachine(context.Context, *VMIRequest) (*Response, error)
 	FreezeVirtualMachine(context.Context, *VMIRequest) (*Response, error)
 	UnfreezeVirtualMachine(context.Context, *VMIRequest) (*Response, error)
+	SnapshotVirtualMachineMemory(context.Context, *VMIRequest) (*Response, error)
 	ShutdownVirtualMachine(context.Context, *VMIRequest) (*Response, error)
 	KillVirtualMachine(context.Context, *VMIRequest) (*Response, error)
 	DeleteVirtualMachine(context.Context, *VMIRequest) (*Response, error)
@@ -920,6 +1081,9 @@ type CmdServer interface {
 	Ping(context.Context, *EmptyRequest) (*Response, error)
 	Exec(context.Context, *ExecRequest) (*ExecResponse, error)
 	GuestPing(context.Context, *GuestPingRequest) (*GuestPingResponse, error)
+	Screenshot(context.Context, *VMIRequest) (*ScreenshotResponse, error)
+	UploadVirtualMachineMemory(context.Context, *MemoryUploadRequest) (*MemoryUploadResponse, error)
+	SetVirtualMachineInterfaceLinkState(context.Context, *LinkStateRequest) (*Response, error)
 }
 
 func RegisterCmdServer(s *grpc.Server, srv CmdServer) {
@@ -1016,6 +1180,24 @@ func _Cmd_UnfreezeVirtualMachine_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Cmd_SnapshotVirtualMachineMemory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VMIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).SnapshotVirtualMachineMemory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/SnapshotVirtualMachineMemory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).SnapshotVirtualMachineMemory(ctx, req.(*VMIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Cmd_ShutdownVirtualMachine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(VMIRequest)
 	if err := dec(in); err != nil {
@@ -1304,6 +1486,60 @@ func _Cmd_GuestPing_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Cmd_Screenshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VMIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).Screenshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/Screenshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).Screenshot(ctx, req.(*VMIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_UploadVirtualMachineMemory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MemoryUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).UploadVirtualMachineMemory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/UploadVirtualMachineMemory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).UploadVirtualMachineMemory(ctx, req.(*MemoryUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cmd_SetVirtualMachineInterfaceLinkState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LinkStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CmdServer).SetVirtualMachineInterfaceLinkState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubevirt.cmd.v1.Cmd/SetVirtualMachineInterfaceLinkState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CmdServer).SetVirtualMachineInterfaceLinkState(ctx, req.(*LinkStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Cmd_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "kubevirt.cmd.v1.Cmd",
 	HandlerType: (*CmdServer)(nil),
@@ -1328,6 +1564,10 @@ var _Cmd_serviceDesc = grpc.ServiceDesc{
 			MethodName: "UnfreezeVirtualMachine",
 			Handler:    _Cmd_UnfreezeVirtualMachine_Handler,
 		},
+		{
+			MethodName: "SnapshotVirtualMachineMemory",
+			Handler:    _Cmd_SnapshotVirtualMachineMemory_Handler,
+		},
 		{
 			MethodName: "ShutdownVirtualMachine",
 			Handler:    _Cmd_ShutdownVirtualMachine_Handler,
@@ -1392,6 +1632,18 @@ var _Cmd_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GuestPing",
 			Handler:    _Cmd_GuestPing_Handler,
 		},
+		{
+			MethodName: "Screenshot",
+			Handler:    _Cmd_Screenshot_Handler,
+		},
+		{
+			MethodName: "UploadVirtualMachineMemory",
+			Handler:    _Cmd_UploadVirtualMachineMemory_Handler,
+		},
+		{
+			MethodName: "SetVirtualMachineInterfaceLinkState",
+			Handler:    _Cmd_SetVirtualMachineInterfaceLinkState_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/handler-launcher-com/cmd/v1/cmd.proto",
@@ -1400,79 +1652,89 @@ var _Cmd_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("pkg/handler-launcher-com/cmd/v1/cmd.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1176 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0x5b, 0x6f, 0x1b, 0xc5,
-	0x17, 0x8f, 0x6f, 0xa9, 0x7d, 0x72, 0xf9, 0xb7, 0xd3, 0xb8, 0xff, 0x25, 0x50, 0x1a, 0x46, 0x28,
-	0x4a, 0xa5, 0x36, 0x21, 0x21, 0xe5, 0x81, 0x07, 0x54, 0xe2, 0xa6, 0x51, 0x5b, 0xdc, 0x9a, 0x71,
-	0x12, 0x44, 0x41, 0xaa, 0xa6, 0xbb, 0x93, 0xcd, 0xa8, 0xbb, 0x33, 0x66, 0x67, 0xd6, 0xc4, 0x7d,
-	0x2d, 0x4f, 0x48, 0x7c, 0x3e, 0x5e, 0xf8, 0x30, 0x68, 0x66, 0x77, 0x1d, 0xdb, 0xbb, 0xc6, 0x20,
-	0xfb, 0xc9, 0x73, 0x6e, 0xbf, 0x73, 0x9d, 0x3d, 0x63, 0xb8, 0xdf, 0x7b, 0xe7, 0xef, 0x5d, 0x52,
-	0xe1, 0x05, 0x2c, 0x7a, 0x18, 0xd0, 0x58, 0xb8, 0x97, 0x2c, 0x7a, 0xe8, 0xca, 0x70, 0xcf, 0x0d,
-	0xbd, 0xbd, 0xfe, 0xbe, 0xf9, 0xd9, 0xed, 0x45, 0x52, 0x4b, 0xf4, 0xbf, 0x77, 0xf1, 0x5b, 0xd6,
-	0xe7, 0x91, 0xde, 0x35, 0xbc, 0xfe, 0x3e, 0xbe, 0x07, 0x95, 0xf3, 0xf6, 0x33, 0xe4, 0xc0, 0x8d,
-	0x7e, 0xc8, 0x9f, 0x2b, 0x29, 0x9c, 0xd2, 0x56, 0x69, 0x67, 0x95, 0x64, 0x24, 0xde, 0x87, 0x4a,
-	0xab, 0x73, 0x86, 0xd6, 0xa1, 0xcc, 0x3d, 0x2b, 0x5b, 0x23, 0x65, 0xee, 0xa1, 0x4d, 0xa8, 0x2b,
-	0xfe, 0x36, 0xe0, 0xc2, 0x57, 0x4e, 0x79, 0xab, 0xb2, 0xb3, 0x46, 0x86, 0x34, 0xde, 0x83, 0x1b,
-	0xdd, 0xe4, 0x9c, 0x33, 0xdb, 0x80, 0x5a, 0x9f, 0x06, 0x31, 0x73, 0xca, 0x5b, 0xa5, 0x9d, 0x2a,
-	0x49, 0x08, 0x7c, 0x0c, 0xb5, 0x0e, 0xf5, 0x99, 0x32, 0x62, 0x57, 0xc6, 0x42, 0x5b, 0x8b, 0x2a,
-	0x49, 0x08, 0x84, 0xa0, 0x1a, 0x0b, 0xae, 0xad, 0x4d, 0x83, 0xd8, 0xb3, 0xe1, 0x29, 0xfe, 0x9e,
-	0x39, 0x15, 0x0b, 0x6d, 0xcf, 0xf8, 0x10, 0x96, 0xdb, 0x2c, 0x94, 0xd1, 0x00, 0xdd, 0x81, 0x65,
-	0x1a, 0x8e, 0x00, 0xa5, 0x54, 0x11, 0x12, 0xfe, 0xb3, 0x04, 0xd5, 0x16, 0x0b, 0x82, 0x5c, 0xac,
-	0x7b, 0xb0, 0x1c, 0x5a, 0x38, 0xab, 0xbe, 0x72, 0xf0, 0xff, 0xdd, 0x89, 0xe2, 0xed, 0x26, 0xde,
-	0x48, 0xaa, 0x86, 0x1e, 0x40, 0xad, 0x67, 0xd2, 0x70, 0x2a, 0x5b, 0x95, 0x9d, 0x95, 0x83, 0x3b,
-	0x39, 0x7d, 0x9b, 0x24, 0x49, 0x94, 0xd0, 0x57, 0xd0, 0xf0, 0xb8, 0xd2, 0x54, 0xb8, 0x4c, 0x39,
-	0x55, 0x6b, 0xe1, 0xe4, 0x2c, 0xd2, 0x3a, 0x92, 0x6b, 0x55, 0xb4, 0x03, 0x55, 0xb7, 0x17, 0x2b,
-	0xa7, 0x66, 0x4d, 0x36, 0x72, 0x26, 0xad, 0xce, 0x19, 0xb1, 0x1a, 0xf8, 0x31, 0xd4, 0x4f, 0x65,
-	0x4f, 0x06, 0xd2, 0x1f, 0xa0, 0x43, 0x00, 0x11, 0x87, 0xf4, 0x8d, 0xcb, 0x82, 0x40, 0x39, 0x25,
-	0x6b, 0xdb, 0xcc, 0xdb, 0xb2, 0x20, 0x20, 0x0d, 0xa3, 0x68, 0x4e, 0x0a, 0xff, 0x5e, 0x82, 0xe5,
-	0x6e, 0xfb, 0x88, 0x4b, 0x85, 0x30, 0xac, 0x86, 0x54, 0xc4, 0x17, 0xd4, 0xd5, 0x71, 0xc4, 0x22,
-	0x5b, 0xa7, 0x06, 0x19, 0xe3, 0x99, 0x29, 0xea, 0x45, 0xd2, 0x8b, 0xdd, 0xac, 0xc2, 0x19, 0x69,
-	0xe7, 0x8b, 0x45, 0x8a, 0x4b, 0x61, 0x3b, 0xd6, 0x20, 0x19, 0x89, 0x6e, 0x42, 0x45, 0xbd, 0x8b,
-	0x9d, 0xaa, 0xe5, 0x9a, 0xa3, 0x69, 0xde, 0x05, 0x0d, 0x79, 0x30, 0x70, 0x6a, 0x96, 0x99, 0x52,
-	0xf8, 0x43, 0x19, 0x9a, 0xe7, 0x3c, 0xd2, 0x31, 0x0d, 0xda, 0xd4, 0xbd, 0xe4, 0x82, 0xbd, 0xea,
-	0x69, 0x2e, 0x85, 0x42, 0x2f, 0x60, 0x63, 0x5c, 0x90, 0xc4, 0x6c, 0x63, 0x2c, 0xea, 0x5b, 0x22,
-	0x26, 0x85, 0x46, 0xe8, 0x10, 0x9a, 0x6d, 0x16, 0x1e, 0xd1, 0x20, 0x90, 0x52, 0x74, 0x35, 0xd5,
-	0xaa, 0xc3, 0x22, 0x2e, 0x3d, 0x9b, 0xd2, 0x1a, 0x29, 0x16, 0xa2, 0x2f, 0xe0, 0x76, 0x27, 0x62,
-	0x86, 0xef, 0x52, 0xcd, 0xbc, 0x73, 0x19, 0xc4, 0x61, 0x3a, 0x09, 0x0d, 0x52, 0x24, 0x42, 0x8f,
-	0xa0, 0xae, 0xd3, 0xee, 0xd8, 0xec, 0x57, 0x0e, 0x3e, 0xca, 0x05, 0x9a, 0xb5, 0x8f, 0x0c, 0x55,
-	0x71, 0x1f, 0xe0, 0xbc, 0xfd, 0x8c, 0xb0, 0x5f, 0x62, 0xa6, 0x34, 0xda, 0x86, 0x4a, 0x3f, 0xe4,
-	0x69, 0xa2, 0xf9, 0x59, 0x30, 0x9a, 0x46, 0x01, 0x3d, 0x86, 0x1b, 0x32, 0x29, 0x56, 0x3a, 0xcc,
-	0xdb, 0x79, 0xdd, 0xa2, 0xd2, 0x92, 0xcc, 0x0c, 0x9f, 0xc2, 0xcd, 0x36, 0xf7, 0x23, 0x6a, 0xa8,
-	0xff, 0xea, 0xdd, 0x19, 0xf7, 0xbe, 0x7a, 0x8d, 0xfa, 0xa1, 0x04, 0x2b, 0xc7, 0x57, 0xcc, 0xcd,
-	0x10, 0x3f, 0x05, 0xf0, 0x64, 0x48, 0xb9, 0x78, 0x49, 0x43, 0x96, 0xce, 0xd8, 0x08, 0xc7, 0x20,
-	0xb5, 0x64, 0x18, 0x52, 0xe1, 0x65, 0x13, 0x96, 0x92, 0xe6, 0x6a, 0x7f, 0x1b, 0xf9, 0x59, 0xc5,
-	0xed, 0x19, 0x6d, 0xc3, 0xba, 0xe6, 0x21, 0x93, 0xb1, 0xee, 0x32, 0x57, 0x0a, 0x4f, 0xd9, 0x42,
-	0xd7, 0xc8, 0x04, 0x17, 0xaf, 0xc3, 0xea, 0x71, 0xd8, 0xd3, 0x83, 0x34, 0x0a, 0xfc, 0x0d, 0xd4,
-	0x09, 0x53, 0x3d, 0x29, 0x94, 0xf5, 0xa8, 0x62, 0xd7, 0x65, 0x2a, 0x19, 0xa7, 0x3a, 0xc9, 0x48,
-	0x23, 0x09, 0x99, 0x52, 0xd4, 0x67, 0x59, 0x2c, 0x29, 0x89, 0xdf, 0xc0, 0xfa, 0x13, 0x1b, 0xf3,
-	0x10, 0xe5, 0x11, 0xd4, 0xa3, 0xf4, 0x9c, 0x96, 0x2b, 0xdf, 0xec, 0x4c, 0x99, 0x0c, 0x55, 0xcd,
-	0x55, 0x48, 0x92, 0x4f, 0x3d, 0xa4, 0x14, 0x16, 0x70, 0x3b, 0x71, 0x60, 0x47, 0x70, 0x5e, 0x2f,
-	0x5b, 0xb0, 0xe2, 0x5d, 0xa3, 0xa5, 0xae, 0x46, 0x59, 0xf8, 0x0a, 0x6e, 0x9d, 0x98, 0xca, 0x3c,
-	0x13, 0x17, 0x72, 0x5e, 0x6f, 0x0f, 0xe0, 0x96, 0x3f, 0x89, 0x95, 0xfa, 0xcc, 0x0b, 0xf0, 0x6f,
-	0x25, 0x68, 0x5a, 0xd7, 0x67, 0x8a, 0x45, 0xdf, 0x71, 0xa5, 0xe7, 0x75, 0x7f, 0x08, 0x4d, 0xbf,
-	0x08, 0x2f, 0x0d, 0xa1, 0x58, 0x88, 0xff, 0x28, 0x81, 0x63, 0xc3, 0x78, 0xca, 0x03, 0xa6, 0x06,
-	0x4a, 0xb3, 0x70, 0xee, 0xb2, 0x7f, 0x0d, 0x8e, 0x3f, 0x05, 0x32, 0x0d, 0x66, 0xaa, 0x1c, 0x0f,
-	0x60, 0x35, 0xb9, 0x36, 0xf3, 0x85, 0xb0, 0x09, 0x75, 0x76, 0xc5, 0x75, 0x4b, 0x7a, 0x89, 0xcb,
-	0x1a, 0x19, 0xd2, 0x66, 0xf6, 0x94, 0xf6, 0x5e, 0xc5, 0x3a, 0xfd, 0x62, 0xa7, 0x14, 0x7e, 0x0d,
-	0x37, 0x6d, 0x25, 0x3a, 0x66, 0x2f, 0xfd, 0xcb, 0x6b, 0x9b, 0xbf, 0x88, 0xe5, 0xc2, 0x8b, 0xf8,
-	0x3c, 0x9d, 0xb3, 0x04, 0x7b, 0xae, 0xdc, 0x0e, 0xfe, 0x5a, 0x83, 0x4a, 0x2b, 0xf4, 0xd0, 0x4b,
-	0x40, 0xdd, 0x81, 0x70, 0xc7, 0x3f, 0x6f, 0xe8, 0xe3, 0xc2, 0xaf, 0x55, 0x92, 0xce, 0xe6, 0x74,
-	0x7c, 0xbc, 0x84, 0x5e, 0xc1, 0xed, 0x0e, 0x8d, 0x15, 0x5b, 0x18, 0xe0, 0xf7, 0xd0, 0x3c, 0x13,
-	0xbd, 0x85, 0x42, 0x76, 0x60, 0xe3, 0x69, 0xc4, 0xd8, 0xfb, 0xc5, 0x21, 0x12, 0xb8, 0x73, 0x26,
-	0x2e, 0x16, 0x8e, 0xd9, 0xbd, 0x8c, 0xb5, 0x27, 0x7f, 0x15, 0x0b, 0xc3, 0x7c, 0x09, 0xe8, 0x05,
-	0x0f, 0x82, 0x45, 0x56, 0xf2, 0x09, 0x0b, 0x98, 0x5e, 0x5c, 0xd6, 0x3f, 0x40, 0x33, 0x59, 0xa4,
-	0x93, 0x90, 0x9f, 0xe5, 0xdf, 0x97, 0x13, 0x0b, 0x77, 0xe6, 0x60, 0x9a, 0x41, 0x1f, 0x1a, 0x9d,
-	0xd2, 0xc8, 0x67, 0x7a, 0x8e, 0x48, 0x7f, 0x84, 0xbb, 0x2d, 0xf3, 0xe6, 0x9c, 0xa8, 0xe6, 0xd0,
-	0xc1, 0x9c, 0xad, 0xe7, 0xbe, 0xa0, 0x41, 0x12, 0x64, 0x47, 0x7a, 0xad, 0x80, 0x51, 0x11, 0xf7,
-	0xe6, 0xc0, 0xfc, 0x09, 0xee, 0x3d, 0xe5, 0x82, 0x06, 0x7c, 0x72, 0x44, 0x17, 0x11, 0x70, 0x1b,
-	0x1a, 0x27, 0x4c, 0x27, 0x4b, 0x17, 0xdd, 0xcd, 0x69, 0x8e, 0x3e, 0x1f, 0x36, 0xef, 0xe5, 0xc4,
-	0xe3, 0xaf, 0x01, 0x3b, 0x04, 0xeb, 0x43, 0x38, 0xbb, 0x62, 0x67, 0x61, 0x7e, 0x3e, 0x05, 0x73,
-	0xec, 0x01, 0x80, 0x97, 0x50, 0x17, 0x56, 0x4f, 0x98, 0x1e, 0x2e, 0xeb, 0x59, 0xb0, 0x38, 0x27,
-	0xce, 0xed, 0x79, 0x0b, 0x5a, 0x3f, 0x61, 0x76, 0x29, 0xce, 0x8c, 0x73, 0xbb, 0x18, 0x30, 0xb7,
-	0x50, 0x97, 0xd0, 0xcf, 0xb6, 0x04, 0x23, 0xcb, 0x6d, 0x16, 0xf4, 0xfd, 0x62, 0xe8, 0xa2, 0xf5,
-	0xb8, 0x84, 0x8e, 0xa0, 0x6a, 0x96, 0xc8, 0x2c, 0xcc, 0x7f, 0xec, 0xf9, 0x31, 0x54, 0xcd, 0x92,
-	0x45, 0x9f, 0xe4, 0x31, 0xae, 0x9f, 0xac, 0x9b, 0x77, 0xa7, 0x48, 0x87, 0x30, 0xa7, 0xd0, 0x18,
-	0x2e, 0xb5, 0x82, 0x4b, 0x3e, 0xb9, 0x4c, 0xa7, 0xf5, 0x64, 0x74, 0x27, 0xe2, 0xa5, 0xa3, 0xea,
-	0xeb, 0x72, 0x7f, 0xff, 0xed, 0xb2, 0xfd, 0x5b, 0xff, 0xe5, 0xdf, 0x01, 0x00, 0x00, 0xff, 0xff,
-	0xd8, 0x09, 0x2e, 0x7f, 0x03, 0x10, 0x00, 0x00,
+	// 1344 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0xdf, 0x53, 0x1b, 0xb7,
+	0x13, 0xc7, 0xbf, 0x88, 0xbd, 0x38, 0x7c, 0x89, 0xc0, 0xf9, 0x5e, 0xdd, 0xfc, 0xa0, 0x4a, 0xca,
+	0x90, 0x99, 0x04, 0x0a, 0x25, 0x7d, 0xe8, 0x43, 0x27, 0xc5, 0x21, 0x0c, 0x49, 0x9c, 0xb8, 0x67,
+	0xa0, 0x6d, 0xda, 0x99, 0x8c, 0x72, 0x27, 0x8c, 0x86, 0x3b, 0xc9, 0x3d, 0xe9, 0xdc, 0x38, 0xaf,
+	0xe9, 0x53, 0x67, 0xfa, 0xdc, 0x3f, 0xad, 0x2f, 0xfd, 0x63, 0x3a, 0xd2, 0x9d, 0x0f, 0xdb, 0x77,
+	0xc6, 0xed, 0xd8, 0x4f, 0x68, 0xb5, 0xbb, 0x9f, 0x5d, 0xed, 0xae, 0x74, 0x1f, 0x0c, 0x0f, 0xba,
+	0x17, 0x9d, 0xed, 0x73, 0xc2, 0x5d, 0x8f, 0x06, 0x8f, 0x3c, 0x12, 0x72, 0xe7, 0x9c, 0x06, 0x8f,
+	0x1c, 0xe1, 0x6f, 0x3b, 0xbe, 0xbb, 0xdd, 0xdb, 0xd1, 0x7f, 0xb6, 0xba, 0x81, 0x50, 0x02, 0xfd,
+	0xef, 0x22, 0x7c, 0x47, 0x7b, 0x2c, 0x50, 0x5b, 0x7a, 0xaf, 0xb7, 0x83, 0xef, 0x42, 0xe1, 0xb4,
+	0x79, 0x84, 0x2c, 0xb8, 0xd6, 0xf3, 0xd9, 0x73, 0x29, 0xb8, 0x95, 0x5b, 0xcf, 0x6d, 0x56, 0xed,
+	0x81, 0x88, 0x77, 0xa0, 0xd0, 0x68, 0x9d, 0xa0, 0x65, 0xc8, 0x33, 0xd7, 0xe8, 0xae, 0xdb, 0x79,
+	0xe6, 0xa2, 0x3a, 0x94, 0x25, 0x7b, 0xe7, 0x31, 0xde, 0x91, 0x56, 0x7e, 0xbd, 0xb0, 0x79, 0xdd,
+	0x4e, 0x64, 0xbc, 0x0d, 0xd7, 0xda, 0xd1, 0x3a, 0xe5, 0xb6, 0x06, 0xa5, 0x1e, 0xf1, 0x42, 0x6a,
+	0xe5, 0xd7, 0x73, 0x9b, 0x45, 0x3b, 0x12, 0xf0, 0x01, 0x94, 0x5a, 0xa4, 0x43, 0xa5, 0x56, 0x3b,
+	0x22, 0xe4, 0xca, 0x78, 0x14, 0xed, 0x48, 0x40, 0x08, 0x8a, 0x21, 0x67, 0xca, 0xf8, 0x54, 0x6c,
+	0xb3, 0xd6, 0x7b, 0x92, 0x7d, 0xa0, 0x56, 0xc1, 0x40, 0x9b, 0x35, 0xde, 0x83, 0xc5, 0x26, 0xf5,
+	0x45, 0xd0, 0x47, 0x37, 0x61, 0x91, 0xf8, 0x43, 0x40, 0xb1, 0x94, 0x85, 0x84, 0xff, 0xca, 0x41,
+	0xb1, 0x41, 0x3d, 0x2f, 0x95, 0xeb, 0x36, 0x2c, 0xfa, 0x06, 0xce, 0x98, 0x2f, 0xed, 0xfe, 0x7f,
+	0x6b, 0xac, 0x78, 0x5b, 0x51, 0x34, 0x3b, 0x36, 0x43, 0x0f, 0xa1, 0xd4, 0xd5, 0xc7, 0xb0, 0x0a,
+	0xeb, 0x85, 0xcd, 0xa5, 0xdd, 0x9b, 0x29, 0x7b, 0x73, 0x48, 0x3b, 0x32, 0x42, 0x5f, 0x41, 0xc5,
+	0x65, 0x52, 0x11, 0xee, 0x50, 0x69, 0x15, 0x8d, 0x87, 0x95, 0xf2, 0x88, 0xeb, 0x68, 0x5f, 0x9a,
+	0xa2, 0x4d, 0x28, 0x3a, 0xdd, 0x50, 0x5a, 0x25, 0xe3, 0xb2, 0x96, 0x72, 0x69, 0xb4, 0x4e, 0x6c,
+	0x63, 0x81, 0x9f, 0x40, 0xf9, 0x58, 0x74, 0x85, 0x27, 0x3a, 0x7d, 0xb4, 0x07, 0xc0, 0x43, 0x9f,
+	0xbc, 0x75, 0xa8, 0xe7, 0x49, 0x2b, 0x67, 0x7c, 0x6b, 0x69, 0x5f, 0xea, 0x79, 0x76, 0x45, 0x1b,
+	0xea, 0x95, 0xc4, 0xbf, 0xe7, 0x60, 0xb1, 0xdd, 0xdc, 0x67, 0x42, 0x22, 0x0c, 0x55, 0x9f, 0xf0,
+	0xf0, 0x8c, 0x38, 0x2a, 0x0c, 0x68, 0x60, 0xea, 0x54, 0xb1, 0x47, 0xf6, 0xf4, 0x14, 0x75, 0x03,
+	0xe1, 0x86, 0xce, 0xa0, 0xc2, 0x03, 0x51, 0x6b, 0x7a, 0x34, 0x90, 0x4c, 0x70, 0xd3, 0xb1, 0x8a,
+	0x3d, 0x10, 0xd1, 0x0a, 0x14, 0xe4, 0x45, 0x68, 0x15, 0xcd, 0xae, 0x5e, 0xea, 0xe6, 0x9d, 0x11,
+	0x9f, 0x79, 0x7d, 0xab, 0x64, 0x36, 0x63, 0x09, 0x7f, 0xcc, 0x43, 0xed, 0x94, 0x05, 0x2a, 0x24,
+	0x5e, 0x93, 0x38, 0xe7, 0x8c, 0xd3, 0xd7, 0x5d, 0xc5, 0x04, 0x97, 0xe8, 0x05, 0xac, 0x8d, 0x2a,
+	0xa2, 0x9c, 0xad, 0xdc, 0x84, 0xbe, 0x45, 0x6a, 0x3b, 0xd3, 0x09, 0xed, 0x41, 0xad, 0x49, 0xfd,
+	0x7d, 0xe2, 0x79, 0x42, 0xf0, 0xb6, 0x22, 0x4a, 0xb6, 0x68, 0xc0, 0x84, 0x6b, 0x8e, 0x74, 0xdd,
+	0xce, 0x56, 0xa2, 0x2f, 0x60, 0xb5, 0x15, 0x50, 0xbd, 0xef, 0x10, 0x45, 0xdd, 0x53, 0xe1, 0x85,
+	0x7e, 0x3c, 0x09, 0x15, 0x3b, 0x4b, 0x85, 0x1e, 0x43, 0x59, 0xc5, 0xdd, 0x31, 0xa7, 0x5f, 0xda,
+	0xfd, 0x24, 0x95, 0xe8, 0xa0, 0x7d, 0x76, 0x62, 0x8a, 0x7b, 0x00, 0xa7, 0xcd, 0x23, 0x9b, 0xfe,
+	0x12, 0x52, 0xa9, 0xd0, 0x06, 0x14, 0x7a, 0x3e, 0x8b, 0x0f, 0x9a, 0x9e, 0x05, 0x6d, 0xa9, 0x0d,
+	0xd0, 0x13, 0xb8, 0x26, 0xa2, 0x62, 0xc5, 0xc3, 0xbc, 0x91, 0xb6, 0xcd, 0x2a, 0xad, 0x3d, 0x70,
+	0xc3, 0xc7, 0xb0, 0xd2, 0x64, 0x9d, 0x80, 0x68, 0xe9, 0xbf, 0x46, 0xb7, 0x46, 0xa3, 0x57, 0x2f,
+	0x51, 0x3f, 0xe6, 0x60, 0xe9, 0xe0, 0x3d, 0x75, 0x06, 0x88, 0x77, 0x00, 0x5c, 0xe1, 0x13, 0xc6,
+	0x5f, 0x11, 0x9f, 0xc6, 0x33, 0x36, 0xb4, 0xa3, 0x91, 0x1a, 0xc2, 0xf7, 0x09, 0x77, 0x07, 0x13,
+	0x16, 0x8b, 0xfa, 0x6a, 0x7f, 0x1b, 0x74, 0x06, 0x15, 0x37, 0x6b, 0xb4, 0x01, 0xcb, 0x8a, 0xf9,
+	0x54, 0x84, 0xaa, 0x4d, 0x1d, 0xc1, 0x5d, 0x69, 0x0a, 0x5d, 0xb2, 0xc7, 0x76, 0xf1, 0x32, 0x54,
+	0x0f, 0xfc, 0xae, 0xea, 0xc7, 0x59, 0xe0, 0x6f, 0xa0, 0x6c, 0x53, 0xd9, 0x15, 0x5c, 0x9a, 0x88,
+	0x32, 0x74, 0x1c, 0x2a, 0xa3, 0x71, 0x2a, 0xdb, 0x03, 0x51, 0x6b, 0x7c, 0x2a, 0x25, 0xe9, 0xd0,
+	0x41, 0x2e, 0xb1, 0x88, 0xdf, 0xc2, 0xf2, 0x53, 0x93, 0x73, 0x82, 0xf2, 0x18, 0xca, 0x41, 0xbc,
+	0xb6, 0x72, 0x13, 0x9a, 0x3d, 0x30, 0xb6, 0x13, 0x53, 0x7d, 0x15, 0xa2, 0xc3, 0xc7, 0x11, 0x62,
+	0x09, 0x73, 0x58, 0x8d, 0x02, 0x98, 0x11, 0x9c, 0x35, 0xca, 0x3a, 0x2c, 0xb9, 0x97, 0x68, 0x71,
+	0xa8, 0xe1, 0x2d, 0xfc, 0x1e, 0x6e, 0x1c, 0xea, 0xca, 0x1c, 0xf1, 0x33, 0x31, 0x6b, 0xb4, 0x87,
+	0x70, 0xa3, 0x33, 0x8e, 0x15, 0xc7, 0x4c, 0x2b, 0xf0, 0x6f, 0x39, 0xa8, 0x99, 0xd0, 0x27, 0x92,
+	0x06, 0x2f, 0x99, 0x54, 0xb3, 0x86, 0xdf, 0x83, 0x5a, 0x27, 0x0b, 0x2f, 0x4e, 0x21, 0x5b, 0x89,
+	0xff, 0xc8, 0x81, 0x65, 0xd2, 0x78, 0xc6, 0x3c, 0x2a, 0xfb, 0x52, 0x51, 0x7f, 0xe6, 0xb2, 0x7f,
+	0x0d, 0x56, 0x67, 0x02, 0x64, 0x9c, 0xcc, 0x44, 0x3d, 0xee, 0x43, 0x35, 0xba, 0x36, 0xb3, 0xa5,
+	0x50, 0x87, 0x32, 0x7d, 0xcf, 0x54, 0x43, 0xb8, 0x51, 0xc8, 0x92, 0x9d, 0xc8, 0x7a, 0xf6, 0xa4,
+	0x72, 0x5f, 0x87, 0x2a, 0x7e, 0xb1, 0x63, 0x09, 0xbf, 0x81, 0x15, 0x53, 0x89, 0x96, 0xfe, 0x2e,
+	0xfd, 0xcb, 0x6b, 0x9b, 0xbe, 0x88, 0xf9, 0xcc, 0x8b, 0xf8, 0x1c, 0x6e, 0x0c, 0x61, 0xcf, 0x74,
+	0x36, 0x7c, 0x01, 0xa8, 0xed, 0x04, 0x94, 0x72, 0x79, 0x2e, 0x66, 0x9e, 0x9a, 0x3b, 0x00, 0x32,
+	0x01, 0x8b, 0x1f, 0xb1, 0xa1, 0x1d, 0xdc, 0x87, 0xd5, 0x88, 0x0c, 0x9c, 0x74, 0x3d, 0x41, 0xdc,
+	0xb9, 0x3d, 0x90, 0xfa, 0x6e, 0x3a, 0x01, 0x75, 0x29, 0x57, 0x8c, 0x78, 0xd2, 0xb4, 0xa2, 0x6a,
+	0x0f, 0x6f, 0xe1, 0x3f, 0x73, 0xb0, 0x36, 0x1a, 0x7b, 0xe6, 0x37, 0x47, 0x2a, 0xa2, 0xc2, 0xc1,
+	0x43, 0x10, 0x4b, 0xfa, 0x0b, 0x37, 0x14, 0x56, 0x9f, 0x90, 0x05, 0xd4, 0x35, 0x19, 0x95, 0xed,
+	0x2c, 0x95, 0xfe, 0x64, 0xbc, 0x64, 0xfc, 0x42, 0x3f, 0x21, 0x74, 0x6e, 0x15, 0xd9, 0xfd, 0x7b,
+	0x05, 0x0a, 0x0d, 0xdf, 0x45, 0xaf, 0x00, 0xb5, 0xfb, 0xdc, 0x19, 0xfd, 0x6c, 0xa1, 0x4f, 0x33,
+	0x21, 0xa3, 0xe0, 0xf5, 0xc9, 0xe7, 0xc7, 0x0b, 0xe8, 0x35, 0xac, 0xb6, 0x48, 0x28, 0xe9, 0xdc,
+	0x00, 0xbf, 0x83, 0xda, 0x09, 0xef, 0xce, 0x15, 0xb2, 0x05, 0x6b, 0xcf, 0x02, 0x4a, 0x3f, 0xcc,
+	0x0f, 0xd1, 0x86, 0x9b, 0x27, 0xfc, 0x6c, 0xbe, 0x98, 0x3f, 0xc0, 0xad, 0x36, 0x27, 0x5d, 0x7d,
+	0x31, 0x46, 0x31, 0x63, 0x76, 0x3e, 0x53, 0xb6, 0xed, 0xf3, 0x50, 0xb9, 0xe2, 0x57, 0x3e, 0xb7,
+	0x6c, 0x5f, 0x01, 0x7a, 0xc1, 0x3c, 0x6f, 0x9e, 0x3d, 0x7a, 0x4a, 0x3d, 0xaa, 0xe6, 0x57, 0xcf,
+	0xef, 0xa1, 0x16, 0x51, 0xaf, 0x71, 0xc8, 0xcf, 0x52, 0x5e, 0xe3, 0x14, 0x6d, 0xea, 0xc8, 0xeb,
+	0x2b, 0x94, 0x38, 0x1d, 0x93, 0xa0, 0x43, 0xd5, 0x0c, 0x99, 0xfe, 0x08, 0xb7, 0x1b, 0xfa, 0xbf,
+	0x94, 0xb1, 0x6a, 0x26, 0x01, 0x66, 0x6c, 0x3d, 0xeb, 0x70, 0xe2, 0x45, 0x49, 0xb6, 0x84, 0xdb,
+	0xf0, 0x28, 0xe1, 0x61, 0x77, 0x06, 0xcc, 0x9f, 0xe0, 0xee, 0x33, 0xc6, 0x89, 0xc7, 0x3e, 0xd0,
+	0xf9, 0x27, 0xdc, 0x84, 0xca, 0x21, 0x55, 0x11, 0x4d, 0x43, 0xb7, 0x53, 0x96, 0xc3, 0x84, 0xb3,
+	0x7e, 0x37, 0xa5, 0x1e, 0xe5, 0x8f, 0x66, 0x08, 0x96, 0x13, 0x38, 0x43, 0xca, 0xa6, 0x61, 0xde,
+	0x9f, 0x80, 0x39, 0x42, 0x19, 0xf1, 0x02, 0x6a, 0x43, 0xf5, 0x90, 0xaa, 0x84, 0xde, 0x4d, 0x83,
+	0xc5, 0x29, 0x75, 0x8a, 0x19, 0x1a, 0xd0, 0xf2, 0x21, 0x35, 0x34, 0x6a, 0x6a, 0x9e, 0x1b, 0xd9,
+	0x80, 0x29, 0x0a, 0xb6, 0x80, 0x7e, 0x36, 0x25, 0x18, 0xa2, 0x43, 0xd3, 0xa0, 0x1f, 0x64, 0x43,
+	0x67, 0x11, 0xaa, 0x05, 0xb4, 0x0f, 0x45, 0x4d, 0x3b, 0xa6, 0x61, 0x5e, 0xd9, 0xf3, 0x03, 0x28,
+	0x6a, 0x5a, 0x86, 0x6e, 0xa5, 0x31, 0x2e, 0xff, 0xc9, 0xa9, 0xdf, 0x9e, 0xa0, 0x4d, 0x60, 0x8e,
+	0xa1, 0x92, 0xd0, 0xa0, 0x8c, 0x4b, 0x3e, 0x4e, 0xbf, 0xea, 0xf8, 0x2a, 0x93, 0xa1, 0x87, 0x09,
+	0x2e, 0x09, 0xd1, 0xd5, 0x83, 0x7d, 0x2f, 0xa5, 0x4c, 0x53, 0x29, 0xbc, 0x80, 0x2e, 0xa0, 0x1e,
+	0x71, 0x8e, 0xcc, 0x67, 0xfe, 0xfe, 0x84, 0xdf, 0x4b, 0x46, 0x28, 0x52, 0xfd, 0xf3, 0x29, 0x56,
+	0x49, 0x30, 0x0a, 0xf7, 0xda, 0x74, 0xec, 0x83, 0x72, 0xc4, 0x15, 0x0d, 0xce, 0x88, 0x43, 0x13,
+	0x9e, 0x91, 0x51, 0xae, 0x71, 0x0e, 0x72, 0x65, 0x0b, 0xf7, 0x8b, 0x6f, 0xf2, 0xbd, 0x9d, 0x77,
+	0x8b, 0xe6, 0xe7, 0xb2, 0x2f, 0xff, 0x19, 0x00, 0x63, 0xda, 0x8b, 0xb7, 0x5b, 0x13, 0x00, 0x00,
 }
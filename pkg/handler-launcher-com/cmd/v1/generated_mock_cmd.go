@@ -110,6 +110,22 @@ func (_mr *_MockCmdClientRecorder) UnfreezeVirtualMachine(arg0, arg1 interface{}
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "UnfreezeVirtualMachine", _s...)
 }
 
+func (_m *MockCmdClient) SnapshotVirtualMachineMemory(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error) {
+	_s := []interface{}{ctx, in}
+	for _, _x := range opts {
+		_s = append(_s, _x)
+	}
+	ret := _m.ctrl.Call(_m, "SnapshotVirtualMachineMemory", _s...)
+	ret0, _ := ret[0].(*Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockCmdClientRecorder) SnapshotVirtualMachineMemory(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	_s := append([]interface{}{arg0, arg1}, arg2...)
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SnapshotVirtualMachineMemory", _s...)
+}
+
 func (_m *MockCmdClient) ShutdownVirtualMachine(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*Response, error) {
 	_s := []interface{}{ctx, in}
 	for _, _x := range opts {
@@ -366,6 +382,54 @@ func (_mr *_MockCmdClientRecorder) GuestPing(arg0, arg1 interface{}, arg2 ...int
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "GuestPing", _s...)
 }
 
+func (_m *MockCmdClient) Screenshot(ctx context.Context, in *VMIRequest, opts ...grpc.CallOption) (*ScreenshotResponse, error) {
+	_s := []interface{}{ctx, in}
+	for _, _x := range opts {
+		_s = append(_s, _x)
+	}
+	ret := _m.ctrl.Call(_m, "Screenshot", _s...)
+	ret0, _ := ret[0].(*ScreenshotResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockCmdClientRecorder) Screenshot(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	_s := append([]interface{}{arg0, arg1}, arg2...)
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Screenshot", _s...)
+}
+
+func (_m *MockCmdClient) UploadVirtualMachineMemory(ctx context.Context, in *MemoryUploadRequest, opts ...grpc.CallOption) (*MemoryUploadResponse, error) {
+	_s := []interface{}{ctx, in}
+	for _, _x := range opts {
+		_s = append(_s, _x)
+	}
+	ret := _m.ctrl.Call(_m, "UploadVirtualMachineMemory", _s...)
+	ret0, _ := ret[0].(*MemoryUploadResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockCmdClientRecorder) UploadVirtualMachineMemory(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	_s := append([]interface{}{arg0, arg1}, arg2...)
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "UploadVirtualMachineMemory", _s...)
+}
+
+func (_m *MockCmdClient) SetVirtualMachineInterfaceLinkState(ctx context.Context, in *LinkStateRequest, opts ...grpc.CallOption) (*Response, error) {
+	_s := []interface{}{ctx, in}
+	for _, _x := range opts {
+		_s = append(_s, _x)
+	}
+	ret := _m.ctrl.Call(_m, "SetVirtualMachineInterfaceLinkState", _s...)
+	ret0, _ := ret[0].(*Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockCmdClientRecorder) SetVirtualMachineInterfaceLinkState(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	_s := append([]interface{}{arg0, arg1}, arg2...)
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SetVirtualMachineInterfaceLinkState", _s...)
+}
+
 // Mock of CmdServer interface
 type MockCmdServer struct {
 	ctrl     *gomock.Controller
@@ -442,6 +506,17 @@ func (_mr *_MockCmdServerRecorder) UnfreezeVirtualMachine(arg0, arg1 interface{}
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "UnfreezeVirtualMachine", arg0, arg1)
 }
 
+func (_m *MockCmdServer) SnapshotVirtualMachineMemory(_param0 context.Context, _param1 *VMIRequest) (*Response, error) {
+	ret := _m.ctrl.Call(_m, "SnapshotVirtualMachineMemory", _param0, _param1)
+	ret0, _ := ret[0].(*Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockCmdServerRecorder) SnapshotVirtualMachineMemory(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SnapshotVirtualMachineMemory", arg0, arg1)
+}
+
 func (_m *MockCmdServer) ShutdownVirtualMachine(_param0 context.Context, _param1 *VMIRequest) (*Response, error) {
 	ret := _m.ctrl.Call(_m, "ShutdownVirtualMachine", _param0, _param1)
 	ret0, _ := ret[0].(*Response)
@@ -617,3 +692,36 @@ func (_m *MockCmdServer) GuestPing(_param0 context.Context, _param1 *GuestPingRe
 func (_mr *_MockCmdServerRecorder) GuestPing(arg0, arg1 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "GuestPing", arg0, arg1)
 }
+
+func (_m *MockCmdServer) Screenshot(_param0 context.Context, _param1 *VMIRequest) (*ScreenshotResponse, error) {
+	ret := _m.ctrl.Call(_m, "Screenshot", _param0, _param1)
+	ret0, _ := ret[0].(*ScreenshotResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockCmdServerRecorder) Screenshot(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Screenshot", arg0, arg1)
+}
+
+func (_m *MockCmdServer) UploadVirtualMachineMemory(_param0 context.Context, _param1 *MemoryUploadRequest) (*MemoryUploadResponse, error) {
+	ret := _m.ctrl.Call(_m, "UploadVirtualMachineMemory", _param0, _param1)
+	ret0, _ := ret[0].(*MemoryUploadResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockCmdServerRecorder) UploadVirtualMachineMemory(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "UploadVirtualMachineMemory", arg0, arg1)
+}
+
+func (_m *MockCmdServer) SetVirtualMachineInterfaceLinkState(_param0 context.Context, _param1 *LinkStateRequest) (*Response, error) {
+	ret := _m.ctrl.Call(_m, "SetVirtualMachineInterfaceLinkState", _param0, _param1)
+	ret0, _ := ret[0].(*Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockCmdServerRecorder) SetVirtualMachineInterfaceLinkState(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SetVirtualMachineInterfaceLinkState", arg0, arg1)
+}
@@ -18,4 +18,27 @@
  */
 package v1
 
-const CmdVersion = 1
+// CmdVersion is the latest revision of the cmd protocol. A revision may only add
+// commands or fields to the v1 service, so a virt-launcher keeps serving all
+// revisions down to MinCompatibleCmdVersion and virt-handler keeps managing VMIs
+// whose virt-launcher is one release older than itself.
+const CmdVersion = 6
+
+// MinCompatibleCmdVersion is the oldest revision both sides still support
+const MinCompatibleCmdVersion = 1
+
+// MemorySnapshotCmdVersion is the revision which introduced SnapshotVirtualMachineMemory
+const MemorySnapshotCmdVersion = 2
+
+// ScreenshotCmdVersion is the revision which introduced Screenshot
+const ScreenshotCmdVersion = 3
+
+// MemoryUploadCmdVersion is the revision which introduced UploadVirtualMachineMemory
+const MemoryUploadCmdVersion = 4
+
+// LinkStateCmdVersion is the revision which introduced SetVirtualMachineInterfaceLinkState
+const LinkStateCmdVersion = 5
+
+// MemorySaveCmdVersion is the revision from which UploadVirtualMachineMemory saves the memory to
+// the memory dump volume in the background if the options contain no object storage
+const MemorySaveCmdVersion = 6
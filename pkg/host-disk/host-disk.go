@@ -97,10 +97,11 @@ func ReplacePVCByHostDisk(vmi *v1.VirtualMachineInstance) error {
 			isShared := types.HasSharedAccessMode(volumeStatus.PersistentVolumeClaimInfo.AccessModes)
 			file := getPVCDiskImgPath(vmi.Spec.Volumes[i].Name, "disk.img")
 			volumeSource.HostDisk = &v1.HostDisk{
-				Path:     file,
-				Type:     v1.HostDiskExistsOrCreate,
-				Capacity: volumeStatus.PersistentVolumeClaimInfo.Capacity[k8sv1.ResourceStorage],
-				Shared:   &isShared,
+				Path:          file,
+				Type:          v1.HostDiskExistsOrCreate,
+				Capacity:      volumeStatus.PersistentVolumeClaimInfo.Capacity[k8sv1.ResourceStorage],
+				Shared:        &isShared,
+				Preallocation: volumeSource.PersistentVolumeClaim.Preallocation,
 			}
 			// PersistenVolumeClaim is replaced by HostDisk
 			volumeSource.PersistentVolumeClaim = nil
@@ -132,6 +133,42 @@ func createSparseRaw(fullPath string, size int64) (err error) {
 	return nil
 }
 
+// createPreallocatedRaw creates a raw image with the space for the whole image reserved. With the full
+// preallocation the image is filled with zeroes too, like qemu-img does.
+func createPreallocatedRaw(fullPath string, size int64, preallocation v1.DiskImagePreallocation) (err error) {
+	f, err := os.Create(fullPath)
+	if err != nil {
+		return err
+	}
+	defer util.CloseIOAndCheckErr(f, &err)
+	if err = syscall.Fallocate(int(f.Fd()), 0, 0, size); err != nil {
+		return err
+	}
+	if preallocation != v1.DiskImagePreallocationFull {
+		return nil
+	}
+	zeroes := make([]byte, 1024*1024)
+	for offset := int64(0); offset < size; offset += int64(len(zeroes)) {
+		chunk := zeroes
+		if remaining := size - offset; remaining < int64(len(chunk)) {
+			chunk = chunk[:remaining]
+		}
+		if _, err = f.WriteAt(chunk, offset); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func createRaw(fullPath string, size int64, preallocation v1.DiskImagePreallocation) error {
+	switch preallocation {
+	case v1.DiskImagePreallocationFalloc, v1.DiskImagePreallocationFull:
+		return createPreallocatedRaw(fullPath, size, preallocation)
+	default:
+		return createSparseRaw(fullPath, size)
+	}
+}
+
 func getPVCDiskImgPath(volumeName string, diskName string) string {
 	return path.Join(pvcBaseDir, volumeName, diskName)
 }
@@ -197,7 +234,7 @@ func (hdc *DiskImgCreator) mountHostDiskAndSetOwnership(vmi *v1.VirtualMachineIn
 		return err
 	}
 	if !fileExists {
-		if err := hdc.handleRequestedSizeAndCreateSparseRaw(vmi, diskDir, diskPath, hostDisk); err != nil {
+		if err := hdc.handleRequestedSizeAndCreateRaw(vmi, diskDir, diskPath, hostDisk); err != nil {
 			return err
 		}
 	}
@@ -209,7 +246,7 @@ func (hdc *DiskImgCreator) mountHostDiskAndSetOwnership(vmi *v1.VirtualMachineIn
 	return nil
 }
 
-func (hdc *DiskImgCreator) handleRequestedSizeAndCreateSparseRaw(vmi *v1.VirtualMachineInstance, diskDir string, diskPath string, hostDisk *v1.HostDisk) error {
+func (hdc *DiskImgCreator) handleRequestedSizeAndCreateRaw(vmi *v1.VirtualMachineInstance, diskDir string, diskPath string, hostDisk *v1.HostDisk) error {
 	size, err := hdc.dirBytesAvailableFunc(diskDir, hdc.minimumPVCReserveBytes)
 	availableSize := int64(size)
 	if err != nil {
@@ -222,9 +259,9 @@ func (hdc *DiskImgCreator) handleRequestedSizeAndCreateSparseRaw(vmi *v1.Virtual
 			return err
 		}
 	}
-	err = createSparseRaw(diskPath, requestedSize)
+	err = createRaw(diskPath, requestedSize, hostDisk.Preallocation)
 	if err != nil {
-		log.Log.Reason(err).Errorf("Couldn't create a sparse raw file for disk path: %s, error: %v", diskPath, err)
+		log.Log.Reason(err).Errorf("Couldn't create a raw file for disk path: %s, error: %v", diskPath, err)
 		return err
 	}
 	return nil
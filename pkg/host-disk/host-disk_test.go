@@ -25,6 +25,7 @@ import (
 	"os"
 	"path"
 	"strings"
+	"syscall"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
@@ -172,6 +173,28 @@ var _ = Describe("HostDisk", func() {
 					Expect(err).NotTo(HaveOccurred())
 					Expect(img3.Size()).To(Equal(int64(83886080))) // 80Mi
 				})
+				table.DescribeTable("Should allocate the space of disk.img as requested", func(preallocation v1.DiskImagePreallocation, allocated bool) {
+					vmi := v1.NewMinimalVMI("fake-vmi")
+					addHostDisk(vmi, "volume1", v1.HostDiskExistsOrCreate, "4Mi")
+					vmi.Spec.Volumes[0].HostDisk.Preallocation = preallocation
+
+					Expect(hostDiskCreator.Create(vmi)).To(Succeed())
+
+					img, err := os.Stat(vmi.Spec.Volumes[0].HostDisk.Path)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(img.Size()).To(Equal(int64(4194304))) // 4Mi
+					allocatedBytes := img.Sys().(*syscall.Stat_t).Blocks * 512
+					if allocated {
+						Expect(allocatedBytes).To(BeNumerically(">=", int64(4194304)))
+					} else {
+						Expect(allocatedBytes).To(BeNumerically("<", int64(4194304)))
+					}
+				},
+					table.Entry("sparse by default", v1.DiskImagePreallocation(""), false),
+					table.Entry("sparse when off", v1.DiskImagePreallocationOff, false),
+					table.Entry("reserved with falloc", v1.DiskImagePreallocationFalloc, true),
+					table.Entry("written with full", v1.DiskImagePreallocationFull, true),
+				)
 				It("Should stop creating disk images if there is not enough space and should return err", func() {
 					By("Creating a new minimal vmi")
 					vmi := v1.NewMinimalVMI("fake-vmi")
@@ -448,6 +471,28 @@ var _ = Describe("HostDisk", func() {
 			table.Entry("blockmode", k8sv1.PersistentVolumeBlock, "disk"),
 			table.Entry("filesystem passthrough", k8sv1.PersistentVolumeFilesystem, "filesystem"),
 		)
+
+		It("should pass the preallocation of the PVC to the hostdisk", func() {
+			mode := k8sv1.PersistentVolumeFilesystem
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Volumes = []v1.Volume{{
+				Name: "pvc-volume",
+				VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+						PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "madeup"},
+						Preallocation:                     v1.DiskImagePreallocationFalloc,
+					},
+				},
+			}}
+			vmi.Status.VolumeStatus = []v1.VolumeStatus{{
+				Name:                      "pvc-volume",
+				PersistentVolumeClaimInfo: &v1.PersistentVolumeClaimInfo{VolumeMode: &mode},
+			}}
+
+			Expect(ReplacePVCByHostDisk(vmi)).To(Succeed())
+			Expect(vmi.Spec.Volumes[0].HostDisk).ToNot(BeNil())
+			Expect(vmi.Spec.Volumes[0].HostDisk.Preallocation).To(Equal(v1.DiskImagePreallocationFalloc))
+		})
 	})
 
 })
@@ -0,0 +1,11 @@
+package accounting_test
+
+import (
+	"testing"
+
+	"kubevirt.io/client-go/testutils"
+)
+
+func TestAccounting(t *testing.T) {
+	testutils.KubeVirtTestSuiteSetup(t)
+}
@@ -0,0 +1,57 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package accounting
+
+import (
+	"strings"
+
+	k6tv1 "kubevirt.io/client-go/api/v1"
+)
+
+var (
+	// Formatter used to sanitize label keys into metric labels
+	labelFormatter = strings.NewReplacer(".", "_", "/", "_", "-", "_")
+
+	// Prefix of the metric labels of the accounting labels
+	metricLabelPrefix = "accounting_"
+)
+
+// MetricLabel returns the name of the metric label the VMI label key is attached as.
+func MetricLabel(key string) string {
+	return metricLabelPrefix + labelFormatter.Replace(key)
+}
+
+// MetricLabels returns the names of the metric labels of the VMI label keys, in their order.
+func MetricLabels(keys []string) []string {
+	labels := make([]string, 0, len(keys))
+	for _, key := range keys {
+		labels = append(labels, MetricLabel(key))
+	}
+	return labels
+}
+
+// LabelValues returns the values of the VMI label keys, in their order. Missing labels are empty.
+func LabelValues(vmi *k6tv1.VirtualMachineInstance, keys []string) []string {
+	values := make([]string, 0, len(keys))
+	for _, key := range keys {
+		values = append(values, vmi.Labels[key])
+	}
+	return values
+}
@@ -0,0 +1,230 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package accounting
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+
+	k6tv1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/util/hardware"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+const (
+	// SampleInterval is how often the resource usage of the running VMIs is sampled
+	SampleInterval = time.Minute
+
+	// DefaultReportInterval is the period a usage report covers if none is configured
+	DefaultReportInterval = time.Hour
+
+	webhookTimeout = 30 * time.Second
+)
+
+// UsageReport is the resource usage of all VMIs which ran in a period, as it is posted to the webhook.
+type UsageReport struct {
+	Start time.Time  `json:"start"`
+	End   time.Time  `json:"end"`
+	VMIs  []VMIUsage `json:"vmis"`
+}
+
+// VMIUsage is the resource usage of a VMI. Storage is the capacity of the claims of its volumes.
+type VMIUsage struct {
+	Namespace        string            `json:"namespace"`
+	Name             string            `json:"name"`
+	UID              types.UID         `json:"uid"`
+	Labels           map[string]string `json:"labels,omitempty"`
+	VCPUHours        float64           `json:"vcpuHours"`
+	MemoryByteHours  float64           `json:"memoryByteHours"`
+	StorageByteHours float64           `json:"storageByteHours"`
+}
+
+// UsageReporter samples the resource usage of the running VMIs and posts it to the configured
+// webhook once per report interval. The usage keeps being accumulated until a report was accepted.
+type UsageReporter struct {
+	vmiInformer   cache.SharedIndexInformer
+	pvcInformer   cache.SharedIndexInformer
+	clusterConfig *virtconfig.ClusterConfig
+	client        *http.Client
+
+	start      time.Time
+	lastSample time.Time
+	usage      map[types.UID]*VMIUsage
+}
+
+func NewUsageReporter(vmiInformer cache.SharedIndexInformer, pvcInformer cache.SharedIndexInformer, clusterConfig *virtconfig.ClusterConfig) *UsageReporter {
+	return &UsageReporter{
+		vmiInformer:   vmiInformer,
+		pvcInformer:   pvcInformer,
+		clusterConfig: clusterConfig,
+		client:        &http.Client{Timeout: webhookTimeout},
+		usage:         map[types.UID]*VMIUsage{},
+	}
+}
+
+func (r *UsageReporter) Run(stopCh <-chan struct{}) {
+	log.Log.Info("Starting usage reporter")
+	wait.Until(func() { r.sample(time.Now()) }, SampleInterval, stopCh)
+	log.Log.Info("Stopping usage reporter")
+}
+
+func (r *UsageReporter) sample(now time.Time) {
+	accounting := r.clusterConfig.GetAccountingConfiguration()
+	if accounting == nil || accounting.UsageReport == nil {
+		r.lastSample = time.Time{}
+		return
+	}
+	if r.lastSample.IsZero() {
+		r.reset(now)
+		return
+	}
+
+	hours := now.Sub(r.lastSample).Hours()
+	r.lastSample = now
+	for _, obj := range r.vmiInformer.GetStore().List() {
+		vmi := obj.(*k6tv1.VirtualMachineInstance)
+		if !vmi.IsRunning() {
+			continue
+		}
+		usage, exists := r.usage[vmi.UID]
+		if !exists {
+			usage = &VMIUsage{Namespace: vmi.Namespace, Name: vmi.Name, UID: vmi.UID}
+			r.usage[vmi.UID] = usage
+		}
+		usage.Labels = accountingLabels(vmi, accounting.Labels)
+		usage.VCPUHours += float64(vcpus(vmi)) * hours
+		usage.MemoryByteHours += float64(guestMemory(vmi)) * hours
+		usage.StorageByteHours += float64(r.storage(vmi)) * hours
+	}
+
+	interval := DefaultReportInterval
+	if accounting.UsageReport.Interval != nil {
+		interval = accounting.UsageReport.Interval.Duration
+	}
+	if now.Sub(r.start) < interval {
+		return
+	}
+	if err := r.push(accounting.UsageReport.WebhookURL, r.report(now)); err != nil {
+		log.Log.Reason(err).Errorf("Failed to report the resource usage of VMIs since %s, retrying with the next report", r.start.Format(time.RFC3339))
+		return
+	}
+	r.reset(now)
+}
+
+func (r *UsageReporter) reset(now time.Time) {
+	r.start = now
+	r.lastSample = now
+	r.usage = map[types.UID]*VMIUsage{}
+}
+
+func (r *UsageReporter) report(now time.Time) *UsageReport {
+	report := &UsageReport{Start: r.start, End: now, VMIs: []VMIUsage{}}
+	for _, usage := range r.usage {
+		report.VMIs = append(report.VMIs, *usage)
+	}
+	sort.Slice(report.VMIs, func(i, j int) bool {
+		if report.VMIs[i].Namespace != report.VMIs[j].Namespace {
+			return report.VMIs[i].Namespace < report.VMIs[j].Namespace
+		}
+		return report.VMIs[i].Name < report.VMIs[j].Name
+	})
+	return report
+}
+
+func (r *UsageReporter) push(url string, report *UsageReport) error {
+	body, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	resp, err := r.client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// storage returns the capacity of the claims of the VMI volumes, or their requested size while they are not bound
+func (r *UsageReporter) storage(vmi *k6tv1.VirtualMachineInstance) int64 {
+	var total int64
+	for _, volume := range vmi.Spec.Volumes {
+		var claimName string
+		if volume.PersistentVolumeClaim != nil {
+			claimName = volume.PersistentVolumeClaim.ClaimName
+		} else if volume.DataVolume != nil {
+			claimName = volume.DataVolume.Name
+		} else {
+			continue
+		}
+		obj, exists, err := r.pvcInformer.GetStore().GetByKey(vmi.Namespace + "/" + claimName)
+		if err != nil || !exists {
+			continue
+		}
+		pvc := obj.(*k8sv1.PersistentVolumeClaim)
+		if capacity, ok := pvc.Status.Capacity[k8sv1.ResourceStorage]; ok {
+			total += capacity.Value()
+		} else if request, ok := pvc.Spec.Resources.Requests[k8sv1.ResourceStorage]; ok {
+			total += request.Value()
+		}
+	}
+	return total
+}
+
+// vcpus returns the number of vCPUs of the VMI, which has a single vCPU if no topology is set
+func vcpus(vmi *k6tv1.VirtualMachineInstance) int64 {
+	if vmi.Spec.Domain.CPU != nil {
+		if vcpus := hardware.GetNumberOfVCPUs(vmi.Spec.Domain.CPU); vcpus > 0 {
+			return vcpus
+		}
+	}
+	return 1
+}
+
+func guestMemory(vmi *k6tv1.VirtualMachineInstance) int64 {
+	if vmi.Spec.Domain.Memory != nil && vmi.Spec.Domain.Memory.Guest != nil {
+		return vmi.Spec.Domain.Memory.Guest.Value()
+	}
+	memory := vmi.Spec.Domain.Resources.Requests[k8sv1.ResourceMemory]
+	return memory.Value()
+}
+
+func accountingLabels(vmi *k6tv1.VirtualMachineInstance, keys []string) map[string]string {
+	if len(keys) == 0 {
+		return nil
+	}
+	labels := make(map[string]string, len(keys))
+	for i, value := range LabelValues(vmi, keys) {
+		labels[keys[i]] = value
+	}
+	return labels
+}
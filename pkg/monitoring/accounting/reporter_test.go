@@ -0,0 +1,150 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package accounting
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	k6tv1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+var _ = Describe("Accounting", func() {
+
+	It("should sanitize the metric labels and default missing values to empty", func() {
+		vmi := &k6tv1.VirtualMachineInstance{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"example.com/cost-center": "cc-42"}},
+		}
+		keys := []string{"example.com/cost-center", "tenant"}
+		Expect(MetricLabels(keys)).To(Equal([]string{"accounting_example_com_cost_center", "accounting_tenant"}))
+		Expect(LabelValues(vmi, keys)).To(Equal([]string{"cc-42", ""}))
+	})
+
+	Context("usage reporter", func() {
+		var server *httptest.Server
+		var reports []UsageReport
+		var status int
+		var reporter *UsageReporter
+		var start time.Time
+
+		newReporter := func(webhookURL string) *UsageReporter {
+			config, _, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&k6tv1.KubeVirtConfiguration{
+				AccountingConfiguration: &k6tv1.AccountingConfiguration{
+					Labels: []string{"tenant"},
+					UsageReport: &k6tv1.UsageReportConfiguration{
+						WebhookURL: webhookURL,
+						Interval:   &metav1.Duration{Duration: time.Hour},
+					},
+				},
+			})
+			vmiInformer, _ := testutils.NewFakeInformerFor(&k6tv1.VirtualMachineInstance{})
+			pvcInformer, _ := testutils.NewFakeInformerFor(&k8sv1.PersistentVolumeClaim{})
+
+			vmi := &k6tv1.VirtualMachineInstance{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "testvmi", UID: "1234", Labels: map[string]string{"tenant": "blue"}},
+				Spec: k6tv1.VirtualMachineInstanceSpec{
+					Domain: k6tv1.DomainSpec{
+						CPU: &k6tv1.CPU{Sockets: 2, Cores: 2},
+						Resources: k6tv1.ResourceRequirements{
+							Requests: k8sv1.ResourceList{k8sv1.ResourceMemory: resource.MustParse("1Gi")},
+						},
+					},
+					Volumes: []k6tv1.Volume{
+						{Name: "disk", VolumeSource: k6tv1.VolumeSource{DataVolume: &k6tv1.DataVolumeSource{Name: "testdv"}}},
+						{Name: "cloudinit", VolumeSource: k6tv1.VolumeSource{CloudInitNoCloud: &k6tv1.CloudInitNoCloudSource{}}},
+					},
+				},
+				Status: k6tv1.VirtualMachineInstanceStatus{Phase: k6tv1.Running},
+			}
+			stopped := vmi.DeepCopy()
+			stopped.Name, stopped.UID, stopped.Status.Phase = "stopped", "5678", k6tv1.Succeeded
+			vmiInformer.GetStore().Add(vmi)
+			vmiInformer.GetStore().Add(stopped)
+			pvcInformer.GetStore().Add(&k8sv1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "testdv"},
+				Status: k8sv1.PersistentVolumeClaimStatus{
+					Capacity: k8sv1.ResourceList{k8sv1.ResourceStorage: resource.MustParse("10Gi")},
+				},
+			})
+			return NewUsageReporter(vmiInformer, pvcInformer, config)
+		}
+
+		BeforeEach(func() {
+			reports = nil
+			status = http.StatusOK
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				report := UsageReport{}
+				Expect(json.NewDecoder(r.Body).Decode(&report)).To(Succeed())
+				reports = append(reports, report)
+				w.WriteHeader(status)
+			}))
+			reporter = newReporter(server.URL)
+			start = time.Now()
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("should report the usage of the running VMIs once per interval", func() {
+			reporter.sample(start)
+			reporter.sample(start.Add(30 * time.Minute))
+			Expect(reports).To(BeEmpty())
+
+			reporter.sample(start.Add(time.Hour))
+			Expect(reports).To(HaveLen(1))
+			Expect(reports[0].Start).To(BeTemporally("==", start))
+			Expect(reports[0].End).To(BeTemporally("==", start.Add(time.Hour)))
+			Expect(reports[0].VMIs).To(ConsistOf(VMIUsage{
+				Namespace:        "default",
+				Name:             "testvmi",
+				UID:              "1234",
+				Labels:           map[string]string{"tenant": "blue"},
+				VCPUHours:        4,
+				MemoryByteHours:  1024 * 1024 * 1024,
+				StorageByteHours: 10 * 1024 * 1024 * 1024,
+			}))
+		})
+
+		It("should keep accumulating the usage if the webhook fails", func() {
+			status = http.StatusServiceUnavailable
+			reporter.sample(start)
+			reporter.sample(start.Add(time.Hour))
+			Expect(reports).To(HaveLen(1))
+
+			status = http.StatusOK
+			reporter.sample(start.Add(2 * time.Hour))
+			Expect(reports).To(HaveLen(2))
+			Expect(reports[1].Start).To(BeTemporally("==", start))
+			Expect(reports[1].VMIs).To(HaveLen(1))
+			Expect(reports[1].VMIs[0].VCPUHours).To(BeEquivalentTo(8))
+		})
+	})
+})
@@ -0,0 +1,120 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package admission
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	admissionv1 "k8s.io/api/admission/v1"
+)
+
+const (
+	resultAllowed = "allowed"
+	resultDenied  = "denied"
+	unknownCause  = "Unknown"
+)
+
+var (
+	admissionRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kubevirt_admission_requests_total",
+			Help: "Amount of admission reviews handled by a webhook, broken down by webhook, resource, operation and result",
+		},
+		[]string{"webhook", "resource", "operation", "result"},
+	)
+	admissionDenials = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kubevirt_admission_denials_total",
+			Help: "Amount of causes reported in denied admission reviews, broken down by webhook, resource, operation and cause type",
+		},
+		[]string{"webhook", "resource", "operation", "cause_type"},
+	)
+	admissionDecodeFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kubevirt_admission_decode_failures_total",
+			Help: "Amount of requests to a webhook which could not be decoded as admission review",
+		},
+		[]string{"webhook"},
+	)
+	admissionDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "kubevirt_admission_duration_seconds",
+			Help:    "Time it took a webhook to admit a review, broken down by webhook, resource and operation",
+			Buckets: []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+		},
+		[]string{"webhook", "resource", "operation"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(admissionRequests)
+	prometheus.MustRegister(admissionDenials)
+	prometheus.MustRegister(admissionDecodeFailures)
+	prometheus.MustRegister(admissionDuration)
+}
+
+// ObserveDecodeFailure counts a request to webhook whose body was not a valid admission review
+func ObserveDecodeFailure(webhook string) {
+	admissionDecodeFailures.WithLabelValues(webhook).Inc()
+}
+
+// ObserveAdmission records the result of an admission review handled by webhook and how long it took
+func ObserveAdmission(webhook string, request *admissionv1.AdmissionRequest, response *admissionv1.AdmissionResponse, duration time.Duration) {
+	var resource, operation string
+	if request != nil {
+		resource, operation = request.Resource.Resource, string(request.Operation)
+	}
+
+	admissionDuration.WithLabelValues(webhook, resource, operation).Observe(duration.Seconds())
+
+	if response == nil || response.Allowed {
+		admissionRequests.WithLabelValues(webhook, resource, operation, resultAllowed).Inc()
+		return
+	}
+	admissionRequests.WithLabelValues(webhook, resource, operation, resultDenied).Inc()
+
+	for _, causeType := range causeTypes(response) {
+		admissionDenials.WithLabelValues(webhook, resource, operation, causeType).Inc()
+	}
+}
+
+// causeTypes returns the type of every cause of a denied review, a denial without
+// detailed causes is reported by its reason
+func causeTypes(response *admissionv1.AdmissionResponse) []string {
+	if response.Result == nil {
+		return []string{unknownCause}
+	}
+	if response.Result.Details != nil && len(response.Result.Details.Causes) > 0 {
+		types := make([]string, 0, len(response.Result.Details.Causes))
+		for _, cause := range response.Result.Details.Causes {
+			if cause.Type == "" {
+				types = append(types, unknownCause)
+			} else {
+				types = append(types, string(cause.Type))
+			}
+		}
+		return types
+	}
+	if response.Result.Reason != "" {
+		return []string{string(response.Result.Reason)}
+	}
+	return []string{unknownCause}
+}
@@ -0,0 +1,101 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package admission
+
+import (
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	io_prometheus_client "github.com/prometheus/client_model/go"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Admission metrics", func() {
+	const webhook = "/virtualmachines-validate"
+
+	request := &admissionv1.AdmissionRequest{
+		Resource:  metav1.GroupVersionResource{Group: "kubevirt.io", Version: "v1", Resource: "virtualmachines"},
+		Operation: admissionv1.Create,
+	}
+
+	counterValue := func(vec *prometheus.CounterVec, labels ...string) float64 {
+		dto := &io_prometheus_client.Metric{}
+		Expect(vec.WithLabelValues(labels...).Write(dto)).To(Succeed())
+		return dto.GetCounter().GetValue()
+	}
+
+	It("should count allowed reviews and observe their duration", func() {
+		allowed := counterValue(admissionRequests, webhook, "virtualmachines", "CREATE", "allowed")
+		histogram := admissionDuration.WithLabelValues(webhook, "virtualmachines", "CREATE").(prometheus.Histogram)
+		dto := &io_prometheus_client.Metric{}
+		Expect(histogram.Write(dto)).To(Succeed())
+		observed := dto.GetHistogram().GetSampleCount()
+
+		ObserveAdmission(webhook, request, &admissionv1.AdmissionResponse{Allowed: true}, 20*time.Millisecond)
+
+		Expect(counterValue(admissionRequests, webhook, "virtualmachines", "CREATE", "allowed")).To(Equal(allowed + 1))
+		Expect(histogram.Write(dto)).To(Succeed())
+		Expect(dto.GetHistogram().GetSampleCount()).To(Equal(observed + 1))
+	})
+
+	It("should count denied reviews by the type of their causes", func() {
+		denied := counterValue(admissionRequests, webhook, "virtualmachines", "CREATE", "denied")
+		invalid := counterValue(admissionDenials, webhook, "virtualmachines", "CREATE", string(metav1.CauseTypeFieldValueInvalid))
+		required := counterValue(admissionDenials, webhook, "virtualmachines", "CREATE", string(metav1.CauseTypeFieldValueRequired))
+
+		ObserveAdmission(webhook, request, &admissionv1.AdmissionResponse{
+			Result: &metav1.Status{
+				Reason: metav1.StatusReasonInvalid,
+				Code:   http.StatusUnprocessableEntity,
+				Details: &metav1.StatusDetails{
+					Causes: []metav1.StatusCause{
+						{Type: metav1.CauseTypeFieldValueInvalid},
+						{Type: metav1.CauseTypeFieldValueInvalid},
+						{Type: metav1.CauseTypeFieldValueRequired},
+					},
+				},
+			},
+		}, time.Millisecond)
+
+		Expect(counterValue(admissionRequests, webhook, "virtualmachines", "CREATE", "denied")).To(Equal(denied + 1))
+		Expect(counterValue(admissionDenials, webhook, "virtualmachines", "CREATE", string(metav1.CauseTypeFieldValueInvalid))).To(Equal(invalid + 2))
+		Expect(counterValue(admissionDenials, webhook, "virtualmachines", "CREATE", string(metav1.CauseTypeFieldValueRequired))).To(Equal(required + 1))
+	})
+
+	It("should fall back to the reason for denials without causes", func() {
+		forbidden := counterValue(admissionDenials, webhook, "virtualmachines", "CREATE", string(metav1.StatusReasonForbidden))
+
+		ObserveAdmission(webhook, request, &admissionv1.AdmissionResponse{
+			Result: &metav1.Status{Reason: metav1.StatusReasonForbidden},
+		}, time.Millisecond)
+
+		Expect(counterValue(admissionDenials, webhook, "virtualmachines", "CREATE", string(metav1.StatusReasonForbidden))).To(Equal(forbidden + 1))
+	})
+
+	It("should count decode failures", func() {
+		failures := counterValue(admissionDecodeFailures, webhook)
+		ObserveDecodeFailure(webhook)
+		Expect(counterValue(admissionDecodeFailures, webhook)).To(Equal(failures + 1))
+	})
+})
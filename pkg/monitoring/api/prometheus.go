@@ -1,6 +1,9 @@
 package api
 
 import (
+	"strconv"
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -34,6 +37,14 @@ var (
 		},
 		namespaceAndVMILabels,
 	)
+	subresourceRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "kubevirt_subresource_request_duration_seconds",
+			Help:    "Time it took to serve a subresource request, for streaming subresources the time until the stream was attached, broken down by resource, subresource, method and status code",
+			Buckets: []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 20, 30, 60},
+		},
+		[]string{"resource", "subresource", "method", "code"},
+	)
 )
 
 func init() {
@@ -41,6 +52,7 @@ func init() {
 	prometheus.MustRegister(activeVNCConnections)
 	prometheus.MustRegister(activeConsoleConnections)
 	prometheus.MustRegister(activeUSBRedirConnections)
+	prometheus.MustRegister(subresourceRequestDuration)
 }
 
 type Decrementer interface {
@@ -78,3 +90,14 @@ func NewActiveUSBRedirConnection(namespace, name string) Decrementer {
 	recorder.Inc()
 	return recorder
 }
+
+// ObserveSubresourceRequest records how long a subresource request took. If a traceID is given it is
+// attached as exemplar, so that slow requests can be looked up in the virt-api logs
+func ObserveSubresourceRequest(resource, subresource, method string, code int, duration time.Duration, traceID string) {
+	observer := subresourceRequestDuration.WithLabelValues(resource, subresource, method, strconv.Itoa(code))
+	if exemplarObserver, ok := observer.(prometheus.ExemplarObserver); ok && traceID != "" {
+		exemplarObserver.ObserveWithExemplar(duration.Seconds(), prometheus.Labels{"trace_id": traceID})
+		return
+	}
+	observer.Observe(duration.Seconds())
+}
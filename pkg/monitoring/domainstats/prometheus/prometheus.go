@@ -27,6 +27,7 @@ import (
 
 	"k8s.io/client-go/tools/cache"
 
+	"kubevirt.io/kubevirt/pkg/monitoring/accounting"
 	vms "kubevirt.io/kubevirt/pkg/monitoring/domainstats"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -36,6 +37,7 @@ import (
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
 	"kubevirt.io/client-go/version"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/stats"
 )
@@ -204,6 +206,17 @@ func (metrics *vmiMetrics) updateVcpu(vcpuStats []stats.DomainStatsVcpu) {
 				[]string{stringVcpuIdx},
 			)
 		}
+
+		if vcpu.DelaySet {
+			metrics.pushCustomMetric(
+				"kubevirt_vmi_vcpu_delay_seconds_total",
+				"Amount of time spent by each vcpu waiting for a host CPU, also known as steal time.",
+				prometheus.CounterValue,
+				float64(vcpu.Delay)/1000000000,
+				[]string{"id"},
+				[]string{stringVcpuIdx},
+			)
+		}
 	}
 }
 
@@ -439,16 +452,18 @@ type DomainStatsCollector struct {
 	nodeName      string
 	concCollector *vms.ConcurrentCollector
 	vmiInformer   cache.SharedIndexInformer
+	clusterConfig *virtconfig.ClusterConfig
 }
 
 // aggregates to virt-launcher
-func SetupDomainStatsCollector(virtCli kubecli.KubevirtClient, virtShareDir, nodeName string, MaxRequestsInFlight int, vmiInformer cache.SharedIndexInformer) *DomainStatsCollector {
+func SetupDomainStatsCollector(virtCli kubecli.KubevirtClient, virtShareDir, nodeName string, MaxRequestsInFlight int, vmiInformer cache.SharedIndexInformer, clusterConfig *virtconfig.ClusterConfig) *DomainStatsCollector {
 	log.Log.Infof("Starting domain stats collector: node name=%v", nodeName)
 	co := &DomainStatsCollector{
 		virtShareDir:  virtShareDir,
 		nodeName:      nodeName,
 		concCollector: vms.NewConcurrentCollector(MaxRequestsInFlight),
 		vmiInformer:   vmiInformer,
+		clusterConfig: clusterConfig,
 	}
 
 	prometheus.MustRegister(co)
@@ -475,7 +490,7 @@ func (co *DomainStatsCollector) Collect(ch chan<- prometheus.Metric) {
 		vmis[i] = obj.(*k6tv1.VirtualMachineInstance)
 	}
 
-	scraper := &prometheusScraper{ch: ch}
+	scraper := &prometheusScraper{ch: ch, accountingLabels: co.clusterConfig.GetAccountingLabels()}
 	co.concCollector.Collect(vmis, scraper, PrometheusCollectionTimeout)
 	return
 }
@@ -485,7 +500,8 @@ func NewPrometheusScraper(ch chan<- prometheus.Metric) *prometheusScraper {
 }
 
 type prometheusScraper struct {
-	ch chan<- prometheus.Metric
+	ch               chan<- prometheus.Metric
+	accountingLabels []string
 }
 
 type vmiStatsInfo struct {
@@ -543,6 +559,7 @@ func (ps *prometheusScraper) Report(socketFile string, vmi *k6tv1.VirtualMachine
 	}()
 
 	vmiMetrics := newVmiMetrics(vmi, ps.ch)
+	vmiMetrics.accountingLabels = ps.accountingLabels
 	vmiMetrics.updateMetrics(vmStats)
 }
 
@@ -558,10 +575,11 @@ func Handler(MaxRequestsInFlight int) http.Handler {
 }
 
 type vmiMetrics struct {
-	k8sLabels      []string
-	k8sLabelValues []string
-	vmi            *k6tv1.VirtualMachineInstance
-	ch             chan<- prometheus.Metric
+	k8sLabels        []string
+	k8sLabelValues   []string
+	accountingLabels []string
+	vmi              *k6tv1.VirtualMachineInstance
+	ch               chan<- prometheus.Metric
 }
 
 func (metrics *vmiMetrics) updateMetrics(vmStats *stats.DomainStats) {
@@ -606,6 +624,8 @@ func (metrics *vmiMetrics) updateKubernetesLabels() {
 		metrics.k8sLabels = append(metrics.k8sLabels, labelPrefix+labelFormatter.Replace(label))
 		metrics.k8sLabelValues = append(metrics.k8sLabelValues, val)
 	}
+	metrics.k8sLabels = append(metrics.k8sLabels, accounting.MetricLabels(metrics.accountingLabels)...)
+	metrics.k8sLabelValues = append(metrics.k8sLabelValues, accounting.LabelValues(metrics.vmi, metrics.accountingLabels)...)
 }
 
 func newVmiMetrics(vmi *k6tv1.VirtualMachineInstance, ch chan<- prometheus.Metric) *vmiMetrics {
@@ -975,6 +975,72 @@ var _ = Describe("Prometheus", func() {
 			Expect(result.Desc().String()).To(ContainSubstring("kubevirt_vmi_network_transmit_packets_dropped_total"))
 		})
 
+		It("should use the interface alias for network metrics when the alias is set", func() {
+			ch := make(chan prometheus.Metric, 2)
+			defer close(ch)
+
+			ps := prometheusScraper{ch: ch}
+
+			vmStats := &stats.DomainStats{
+				Cpu:    &stats.DomainStatsCPU{},
+				Memory: &stats.DomainStatsMemory{},
+				Net: []stats.DomainStatsNet{
+					{
+						NameSet:    true,
+						Name:       "vnet0",
+						AliasSet:   true,
+						Alias:      "storage",
+						TxBytesSet: true,
+						TxBytes:    1000,
+					},
+				},
+			}
+
+			vmi := k6tv1.VirtualMachineInstance{}
+			ps.Report("test", &vmi, vmStats)
+
+			<-ch
+			result := <-ch
+			Expect(result).ToNot(BeNil())
+			Expect(result.Desc().String()).To(ContainSubstring("kubevirt_vmi_network_transmit_bytes_total"))
+
+			dto := &io_prometheus_client.Metric{}
+			err := result.Write(dto)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(dto.String()).To(ContainSubstring("name:\"interface\" value:\"storage\""))
+		})
+
+		It("should use the interface name for network metrics when the alias is not set", func() {
+			ch := make(chan prometheus.Metric, 1)
+			defer close(ch)
+
+			ps := prometheusScraper{ch: ch}
+
+			vmStats := &stats.DomainStats{
+				Cpu:    &stats.DomainStatsCPU{},
+				Memory: &stats.DomainStatsMemory{},
+				Net: []stats.DomainStatsNet{
+					{
+						NameSet:   true,
+						Name:      "vnet0",
+						RxPktsSet: true,
+						RxPkts:    1000,
+					},
+				},
+			}
+
+			vmi := k6tv1.VirtualMachineInstance{}
+			ps.Report("test", &vmi, vmStats)
+
+			result := <-ch
+			Expect(result).ToNot(BeNil())
+
+			dto := &io_prometheus_client.Metric{}
+			err := result.Write(dto)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(dto.String()).To(ContainSubstring("name:\"interface\" value:\"vnet0\""))
+		})
+
 		It("should not expose nameless network interface metrics", func() {
 			ch := make(chan prometheus.Metric, 1)
 			defer close(ch)
@@ -1026,6 +1092,41 @@ var _ = Describe("Prometheus", func() {
 			Expect(result.Desc().String()).To(ContainSubstring("kubernetes_vmi_label_kubevirt_io_nodeName"))
 		})
 
+		It("should add the accounting labels", func() {
+			ch := make(chan prometheus.Metric, 1)
+			defer close(ch)
+
+			ps := prometheusScraper{ch: ch, accountingLabels: []string{"example.com/tenant"}}
+
+			vmStats := &stats.DomainStats{
+				Cpu: &stats.DomainStatsCPU{},
+				Memory: &stats.DomainStatsMemory{
+					RSS:    1024,
+					RSSSet: true,
+				},
+			}
+
+			vmi := k6tv1.VirtualMachineInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"example.com/tenant": "blue",
+					},
+				},
+			}
+			ps.Report("test", &vmi, vmStats)
+
+			result := <-ch
+			Expect(result).ToNot(BeNil())
+			dto := &io_prometheus_client.Metric{}
+			result.Write(dto)
+			labels := map[string]string{}
+			for _, label := range dto.Label {
+				labels[label.GetName()] = label.GetValue()
+			}
+			Expect(labels).To(HaveKeyWithValue("accounting_example_com_tenant", "blue"))
+			Expect(labels).To(HaveKeyWithValue("kubernetes_vmi_label_example_com_tenant", "blue"))
+		})
+
 		It("should expose vcpu wait metric", func() {
 			ch := make(chan prometheus.Metric, 1)
 			defer close(ch)
@@ -1052,6 +1153,35 @@ var _ = Describe("Prometheus", func() {
 			Expect(result.Desc().String()).To(ContainSubstring("kubevirt_vmi_vcpu_wait_seconds"))
 		})
 
+		It("should expose vcpu delay metric", func() {
+			ch := make(chan prometheus.Metric, 1)
+			defer close(ch)
+
+			ps := prometheusScraper{ch: ch}
+
+			vmStats := &stats.DomainStats{
+				Cpu:    &stats.DomainStatsCPU{},
+				Memory: &stats.DomainStatsMemory{},
+				Net:    []stats.DomainStatsNet{},
+				Vcpu: []stats.DomainStatsVcpu{
+					{
+						DelaySet: true,
+						Delay:    1500000000,
+					},
+				},
+			}
+
+			vmi := k6tv1.VirtualMachineInstance{}
+			ps.Report("test", &vmi, vmStats)
+
+			result := <-ch
+			Expect(result).ToNot(BeNil())
+			Expect(result.Desc().String()).To(ContainSubstring("kubevirt_vmi_vcpu_delay_seconds_total"))
+			dto := &io_prometheus_client.Metric{}
+			Expect(result.Write(dto)).To(Succeed())
+			Expect(dto.GetCounter().GetValue()).To(BeEquivalentTo(1.5))
+		})
+
 		It("should expose vcpu to cpu pinning metric", func() {
 			ch := make(chan prometheus.Metric, 1)
 			defer close(ch)
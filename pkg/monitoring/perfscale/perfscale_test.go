@@ -23,6 +23,8 @@ import (
 	"time"
 
 	"github.com/onsi/ginkgo/extensions/table"
+	"github.com/prometheus/client_golang/prometheus"
+	io_prometheus_client "github.com/prometheus/client_model/go"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	. "github.com/onsi/ginkgo"
@@ -61,6 +63,80 @@ var _ = Describe("VMI phase transition time histogram", func() {
 		)
 	})
 
+	Context("Boot SLA calculations", func() {
+		newVMI := func(creation time.Time, timestamps map[v1.VirtualMachineInstancePhase]time.Time) *v1.VirtualMachineInstance {
+			vmi := &v1.VirtualMachineInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:         "test-ns",
+					Name:              "testvmi",
+					CreationTimestamp: metav1.NewTime(creation),
+				},
+			}
+			for phase, timestamp := range timestamps {
+				vmi.Status.PhaseTransitionTimestamps = append(vmi.Status.PhaseTransitionTimestamps, v1.VirtualMachineInstancePhaseTransitionTimestamp{
+					Phase:                    phase,
+					PhaseTransitionTimestamp: metav1.NewTime(timestamp),
+				})
+			}
+			return vmi
+		}
+
+		It("should calculate the time to running from the creation", func() {
+			creation := time.Now().Add(-time.Minute)
+			vmi := newVMI(creation, map[v1.VirtualMachineInstancePhase]time.Time{
+				v1.Scheduling: creation.Add(time.Second),
+				v1.Running:    creation.Add(42 * time.Second),
+			})
+
+			diffSeconds, err := getTimeToRunningSeconds(vmi)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(diffSeconds).To(Equal(42.0))
+		})
+
+		It("should calculate the time spent in scheduling", func() {
+			creation := time.Now().Add(-time.Minute)
+			vmi := newVMI(creation, map[v1.VirtualMachineInstancePhase]time.Time{
+				v1.Scheduling: creation.Add(2 * time.Second),
+				v1.Scheduled:  creation.Add(9500 * time.Millisecond),
+			})
+
+			diffSeconds, err := getTimeInSchedulingSeconds(vmi)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(diffSeconds).To(Equal(7.5))
+		})
+
+		It("should fail if phase transition timestamps are missing", func() {
+			vmi := newVMI(time.Now(), map[v1.VirtualMachineInstancePhase]time.Time{
+				v1.Scheduled: time.Now(),
+			})
+
+			_, err := getTimeToRunningSeconds(vmi)
+			Expect(err).To(HaveOccurred())
+			_, err = getTimeInSchedulingSeconds(vmi)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should only observe the transition into the phase", func() {
+			histogram := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "test", Buckets: phaseTransitionTimeBuckets()})
+			creation := time.Now().Add(-time.Minute)
+			vmi := newVMI(creation, map[v1.VirtualMachineInstancePhase]time.Time{
+				v1.Running: creation.Add(10 * time.Second),
+			})
+			vmi.Status.Phase = v1.Running
+			oldVMI := vmi.DeepCopy()
+			oldVMI.Status.Phase = v1.Scheduled
+
+			observeVMIBootSLA(histogram, v1.Running, getTimeToRunningSeconds, oldVMI, vmi)
+			// resync of the running vmi
+			observeVMIBootSLA(histogram, v1.Running, getTimeToRunningSeconds, vmi, vmi)
+
+			dto := &io_prometheus_client.Metric{}
+			Expect(histogram.Write(dto)).To(Succeed())
+			Expect(dto.GetHistogram().GetSampleCount()).To(Equal(uint64(1)))
+			Expect(dto.GetHistogram().GetSampleSum()).To(Equal(10.0))
+		})
+	})
+
 })
 
 func createVMISForPhaseTransitionTime(phase v1.VirtualMachineInstancePhase, oldPhase v1.VirtualMachineInstancePhase, offset float64, hasTransitionTime bool) *v1.VirtualMachineInstance {
@@ -30,4 +30,6 @@ func RegisterPerfScaleMetrics(vmiInformer cache.SharedIndexInformer) {
 	log.Log.Infof("Starting performance and scale metrics")
 	prometheus.MustRegister(newVMIPhaseTransitionTimeHistogramVec(vmiInformer))
 	prometheus.MustRegister(newVMIPhaseTransitionTimeFromCreationHistogramVec(vmiInformer))
+	prometheus.MustRegister(newVMITimeToRunningHistogram(vmiInformer))
+	prometheus.MustRegister(newVMITimeInSchedulingHistogram(vmiInformer))
 }
@@ -0,0 +1,117 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package perfscale
+
+import (
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+)
+
+func getPhaseTransitionTimestamp(vmi *v1.VirtualMachineInstance, phase v1.VirtualMachineInstancePhase) *metav1.Time {
+	for _, transitionTimestamp := range vmi.Status.PhaseTransitionTimestamps {
+		if transitionTimestamp.Phase == phase {
+			return transitionTimestamp.PhaseTransitionTimestamp.DeepCopy()
+		}
+	}
+	return nil
+}
+
+// getTimeToRunningSeconds returns the time it took from the creation of the vmi until it was running
+func getTimeToRunningSeconds(vmi *v1.VirtualMachineInstance) (float64, error) {
+	running := getPhaseTransitionTimestamp(vmi, v1.Running)
+	if running == nil {
+		return 0.0, fmt.Errorf("missing phase transition timestamp for phase %s", v1.Running)
+	}
+
+	diffSeconds := running.Time.Sub(vmi.CreationTimestamp.Time).Seconds()
+	if diffSeconds < 0 {
+		diffSeconds = 0.0
+	}
+	return diffSeconds, nil
+}
+
+// getTimeInSchedulingSeconds returns the time the vmi waited in the Scheduling phase for its pod to be scheduled
+func getTimeInSchedulingSeconds(vmi *v1.VirtualMachineInstance) (float64, error) {
+	scheduling := getPhaseTransitionTimestamp(vmi, v1.Scheduling)
+	scheduled := getPhaseTransitionTimestamp(vmi, v1.Scheduled)
+	if scheduling == nil || scheduled == nil {
+		return 0.0, fmt.Errorf("missing phase transition timestamps for phases %s and %s", v1.Scheduling, v1.Scheduled)
+	}
+
+	diffSeconds := scheduled.Time.Sub(scheduling.Time).Seconds()
+	if diffSeconds < 0 {
+		diffSeconds = 0.0
+	}
+	return diffSeconds, nil
+}
+
+func observeVMIBootSLA(histogram prometheus.Histogram, phase v1.VirtualMachineInstancePhase, getSeconds func(*v1.VirtualMachineInstance) (float64, error), oldVMI *v1.VirtualMachineInstance, newVMI *v1.VirtualMachineInstance) {
+	// only observe the transition into the phase once, not the resyncs afterwards
+	if oldVMI == nil || oldVMI.Status.Phase == newVMI.Status.Phase || newVMI.Status.Phase != phase {
+		return
+	}
+
+	diffSeconds, err := getSeconds(newVMI)
+	if err != nil {
+		log.Log.V(4).Infof("Error encountered during vmi boot sla histogram calculation: %v", err)
+		return
+	}
+	histogram.Observe(diffSeconds)
+}
+
+func newVMITimeToRunningHistogram(informer cache.SharedIndexInformer) prometheus.Histogram {
+	histogram := prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "kubevirt_vmi_time_to_running_seconds",
+			Help:    "Time from the creation of a VirtualMachineInstance until it reached the Running phase.",
+			Buckets: phaseTransitionTimeBuckets(),
+		},
+	)
+
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldVMI, newVMI interface{}) {
+			observeVMIBootSLA(histogram, v1.Running, getTimeToRunningSeconds, oldVMI.(*v1.VirtualMachineInstance), newVMI.(*v1.VirtualMachineInstance))
+		},
+	})
+	return histogram
+}
+
+func newVMITimeInSchedulingHistogram(informer cache.SharedIndexInformer) prometheus.Histogram {
+	histogram := prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "kubevirt_vmi_time_in_scheduling_seconds",
+			Help:    "Time a VirtualMachineInstance spent in the Scheduling phase until its virt-launcher pod was scheduled.",
+			Buckets: phaseTransitionTimeBuckets(),
+		},
+	)
+
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldVMI, newVMI interface{}) {
+			observeVMIBootSLA(histogram, v1.Scheduled, getTimeInSchedulingSeconds, oldVMI.(*v1.VirtualMachineInstance), newVMI.(*v1.VirtualMachineInstance))
+		},
+	})
+	return histogram
+}
@@ -30,6 +30,8 @@ import (
 	k6tv1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/log"
 	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/monitoring/accounting"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
 var (
@@ -46,17 +48,70 @@ var (
 		},
 		nil,
 	)
-
-	vmiEvictionBlockerDesc = prometheus.NewDesc(
-		"kubevirt_vmi_non_evictable",
-		"Indication for a VirtualMachine that its eviction strategy is set to Live Migration but is not migratable.",
-		[]string{
-			"node", "namespace", "name",
-		},
-		nil,
-	)
 )
 
+// vmiDescs are the descriptions of the per-VMI metrics, which carry the accounting labels
+type vmiDescs struct {
+	accountingLabels []string
+
+	evictionBlocker *prometheus.Desc
+	memoryOverhead  *prometheus.Desc
+	cpuOverhead     *prometheus.Desc
+	guestOSInfo     *prometheus.Desc
+	guestPressure   *prometheus.Desc
+}
+
+func newVMIDescs(accountingLabels []string) *vmiDescs {
+	return &vmiDescs{
+		accountingLabels: accountingLabels,
+		evictionBlocker: newVMIDesc(
+			"kubevirt_vmi_non_evictable",
+			"Indication for a VirtualMachine that its eviction strategy is set to Live Migration but is not migratable.",
+			nil,
+			accountingLabels,
+		),
+		memoryOverhead: newVMIDesc(
+			"kubevirt_vmi_memory_overhead_bytes",
+			"Memory which was added to the virt-launcher pod of the VMI on top of the guest memory.",
+			nil,
+			accountingLabels,
+		),
+		cpuOverhead: newVMIDesc(
+			"kubevirt_vmi_cpu_overhead_cores",
+			"CPU which was added to the virt-launcher pod of the VMI on top of the guest CPUs.",
+			nil,
+			accountingLabels,
+		),
+		guestOSInfo: newVMIDesc(
+			"kubevirt_vmi_guest_os_info",
+			"The guest operating system of the VMI as reported by the guest agent.",
+			[]string{
+				"guest_os_id", "guest_os_name", "guest_os_version_id", "guest_os_kernel_release", "guest_os_machine", "guest_os_timezone", "guest_hostname",
+			},
+			accountingLabels,
+		),
+		guestPressure: newVMIDesc(
+			"kubevirt_vmi_guest_pressure",
+			"Indication that the guest is under pressure. Where `resource` is `memory` when little guest memory is available to applications and `cpu` when the vCPUs wait much for a host CPU.",
+			[]string{"resource"},
+			accountingLabels,
+		),
+	}
+}
+
+func newVMIDesc(name string, help string, customLabels []string, accountingLabels []string) *prometheus.Desc {
+	labels := []string{"node", "namespace", "name"} // Common labels
+	labels = append(labels, customLabels...)
+	labels = append(labels, accounting.MetricLabels(accountingLabels)...)
+	return prometheus.NewDesc(name, help, labels, nil)
+}
+
+func (d *vmiDescs) labelValues(vmi *k6tv1.VirtualMachineInstance, customLabelValues ...string) []string {
+	labelValues := []string{vmi.Status.NodeName, vmi.Namespace, vmi.Name}
+	labelValues = append(labelValues, customLabelValues...)
+	return append(labelValues, accounting.LabelValues(vmi, d.accountingLabels)...)
+}
+
 type vmiCountMetric struct {
 	Phase    string
 	OS       string
@@ -66,7 +121,8 @@ type vmiCountMetric struct {
 }
 
 type VMICollector struct {
-	vmiInformer cache.SharedIndexInformer
+	vmiInformer   cache.SharedIndexInformer
+	clusterConfig *virtconfig.ClusterConfig
 }
 
 func (co *VMICollector) Describe(_ chan<- *prometheus.Desc) {
@@ -74,10 +130,11 @@ func (co *VMICollector) Describe(_ chan<- *prometheus.Desc) {
 }
 
 // does VMI informer stuff
-func SetupVMICollector(vmiInformer cache.SharedIndexInformer) {
+func SetupVMICollector(vmiInformer cache.SharedIndexInformer, clusterConfig *virtconfig.ClusterConfig) {
 	log.Log.Infof("Starting vmi collector")
 	co := &VMICollector{
-		vmiInformer: vmiInformer,
+		vmiInformer:   vmiInformer,
+		clusterConfig: clusterConfig,
 	}
 
 	prometheus.MustRegister(co)
@@ -98,7 +155,7 @@ func (co *VMICollector) Collect(ch chan<- prometheus.Metric) {
 	}
 
 	updateVMIsPhase(vmis, ch)
-	updateVMIMetrics(vmis, ch)
+	updateVMIMetrics(vmis, newVMIDescs(co.clusterConfig.GetAccountingLabels()), ch)
 	return
 }
 
@@ -170,17 +227,89 @@ func checkNonEvictableVMAndSetMetric(vmi *k6tv1.VirtualMachineInstance) float64
 	return setVal
 }
 
-func updateVMIMetrics(vmis []*k6tv1.VirtualMachineInstance, ch chan<- prometheus.Metric) {
+func updateVMIMetrics(vmis []*k6tv1.VirtualMachineInstance, descs *vmiDescs, ch chan<- prometheus.Metric) {
 	for _, vmi := range vmis {
-		updateVMIEvictionBlocker(vmi, ch)
+		updateVMIEvictionBlocker(vmi, descs, ch)
+		updateVMIResourceOverhead(vmi, descs, ch)
+		updateVMIGuestOSInfo(vmi, descs, ch)
+		updateVMIGuestPressure(vmi, descs, ch)
+	}
+}
+
+// updateVMIGuestPressure reports the guest pressure conditions, nothing is reported while the guest provides no statistics
+func updateVMIGuestPressure(vmi *k6tv1.VirtualMachineInstance, descs *vmiDescs, ch chan<- prometheus.Metric) {
+	for _, cond := range vmi.Status.Conditions {
+		var resource string
+		switch cond.Type {
+		case k6tv1.VirtualMachineInstanceGuestMemoryPressure:
+			resource = "memory"
+		case k6tv1.VirtualMachineInstanceGuestCPUSteal:
+			resource = "cpu"
+		default:
+			continue
+		}
+		value := 0.0
+		if cond.Status == k8sv1.ConditionTrue {
+			value = 1.0
+		}
+		mv, err := prometheus.NewConstMetric(
+			descs.guestPressure, prometheus.GaugeValue,
+			value,
+			descs.labelValues(vmi, resource)...,
+		)
+		if err == nil {
+			ch <- mv
+		}
+	}
+}
+
+func updateVMIGuestOSInfo(vmi *k6tv1.VirtualMachineInstance, descs *vmiDescs, ch chan<- prometheus.Metric) {
+	osInfo := vmi.Status.GuestOSInfo
+	if osInfo.Name == "" {
+		return
+	}
+	mv, err := prometheus.NewConstMetric(
+		descs.guestOSInfo, prometheus.GaugeValue,
+		1.0,
+		descs.labelValues(vmi, osInfo.ID, osInfo.Name, osInfo.VersionID, osInfo.KernelRelease, osInfo.Machine, osInfo.Timezone, osInfo.Hostname)...,
+	)
+	if err == nil {
+		ch <- mv
+	}
+}
+
+func updateVMIResourceOverhead(vmi *k6tv1.VirtualMachineInstance, descs *vmiDescs, ch chan<- prometheus.Metric) {
+	overhead := vmi.Status.ResourceOverhead
+	if overhead == nil {
+		return
+	}
+	if overhead.Memory != nil {
+		mv, err := prometheus.NewConstMetric(
+			descs.memoryOverhead, prometheus.GaugeValue,
+			float64(overhead.Memory.Value()),
+			descs.labelValues(vmi)...,
+		)
+		if err == nil {
+			ch <- mv
+		}
+	}
+	if overhead.CPU != nil {
+		mv, err := prometheus.NewConstMetric(
+			descs.cpuOverhead, prometheus.GaugeValue,
+			float64(overhead.CPU.MilliValue())/1000,
+			descs.labelValues(vmi)...,
+		)
+		if err == nil {
+			ch <- mv
+		}
 	}
 }
 
-func updateVMIEvictionBlocker(vmi *k6tv1.VirtualMachineInstance, ch chan<- prometheus.Metric) {
+func updateVMIEvictionBlocker(vmi *k6tv1.VirtualMachineInstance, descs *vmiDescs, ch chan<- prometheus.Metric) {
 	mv, err := prometheus.NewConstMetric(
-		vmiEvictionBlockerDesc, prometheus.GaugeValue,
+		descs.evictionBlocker, prometheus.GaugeValue,
 		checkNonEvictableVMAndSetMetric(vmi),
-		vmi.Status.NodeName, vmi.Namespace, vmi.Name,
+		descs.labelValues(vmi)...,
 	)
 	if err != nil {
 		return
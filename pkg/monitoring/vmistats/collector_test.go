@@ -24,6 +24,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	io_prometheus_client "github.com/prometheus/client_model/go"
 	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	. "github.com/onsi/ginkgo"
@@ -47,7 +48,7 @@ var _ = Describe("VMI Stats Collector", func() {
 
 			vmis := createVMISForEviction(evictionPolicy, migrateCondStatus)
 			for _, vmi := range vmis {
-				updateVMIEvictionBlocker(vmi, ch)
+				updateVMIEvictionBlocker(vmi, newVMIDescs(nil), ch)
 			}
 
 			result := <-ch
@@ -66,6 +67,178 @@ var _ = Describe("VMI Stats Collector", func() {
 			table.Entry("VMI Eviction policy is not set and vm migratable status is not known", nil, k8sv1.ConditionUnknown, 0.0),
 		)
 	})
+
+	Context("VMI resource overhead", func() {
+
+		It("should report the memory and CPU overhead", func() {
+			ch := make(chan prometheus.Metric, 2)
+			defer close(ch)
+
+			memory := resource.MustParse("200Mi")
+			cpu := resource.MustParse("1500m")
+			vmi := &k6tv1.VirtualMachineInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test-ns",
+					Name:      "testvmi",
+				},
+				Status: k6tv1.VirtualMachineInstanceStatus{
+					NodeName: "testNode",
+					ResourceOverhead: &k6tv1.VirtualMachineInstanceResourceOverhead{
+						Memory: &memory,
+						CPU:    &cpu,
+					},
+				},
+			}
+			updateVMIResourceOverhead(vmi, newVMIDescs(nil), ch)
+
+			result := <-ch
+			dto := &io_prometheus_client.Metric{}
+			result.Write(dto)
+			Expect(result.Desc().String()).To(ContainSubstring("kubevirt_vmi_memory_overhead_bytes"))
+			Expect(dto.Gauge.GetValue()).To(BeEquivalentTo(200 * 1024 * 1024))
+
+			result = <-ch
+			dto = &io_prometheus_client.Metric{}
+			result.Write(dto)
+			Expect(result.Desc().String()).To(ContainSubstring("kubevirt_vmi_cpu_overhead_cores"))
+			Expect(dto.Gauge.GetValue()).To(BeEquivalentTo(1.5))
+		})
+
+		It("should not report anything without a resource overhead", func() {
+			ch := make(chan prometheus.Metric, 2)
+			defer close(ch)
+
+			updateVMIResourceOverhead(&k6tv1.VirtualMachineInstance{}, newVMIDescs(nil), ch)
+			Expect(ch).To(BeEmpty())
+		})
+	})
+
+	Context("VMI guest pressure", func() {
+
+		It("should report the guest pressure conditions", func() {
+			ch := make(chan prometheus.Metric, 2)
+			defer close(ch)
+
+			vmi := &k6tv1.VirtualMachineInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test-ns",
+					Name:      "testvmi",
+				},
+				Status: k6tv1.VirtualMachineInstanceStatus{
+					NodeName: "testNode",
+					Conditions: []k6tv1.VirtualMachineInstanceCondition{
+						{Type: k6tv1.VirtualMachineInstanceGuestMemoryPressure, Status: k8sv1.ConditionTrue},
+						{Type: k6tv1.VirtualMachineInstanceIsMigratable, Status: k8sv1.ConditionTrue},
+						{Type: k6tv1.VirtualMachineInstanceGuestCPUSteal, Status: k8sv1.ConditionFalse},
+					},
+				},
+			}
+			updateVMIGuestPressure(vmi, newVMIDescs(nil), ch)
+
+			for _, expected := range []struct {
+				resource string
+				value    float64
+			}{{"memory", 1}, {"cpu", 0}} {
+				result := <-ch
+				dto := &io_prometheus_client.Metric{}
+				result.Write(dto)
+				Expect(result.Desc().String()).To(ContainSubstring("kubevirt_vmi_guest_pressure"))
+				Expect(dto.Gauge.GetValue()).To(BeEquivalentTo(expected.value))
+				labels := map[string]string{}
+				for _, label := range dto.Label {
+					labels[label.GetName()] = label.GetValue()
+				}
+				Expect(labels).To(HaveKeyWithValue("resource", expected.resource))
+			}
+		})
+
+		It("should not report anything without guest pressure conditions", func() {
+			ch := make(chan prometheus.Metric, 1)
+			defer close(ch)
+
+			updateVMIGuestPressure(&k6tv1.VirtualMachineInstance{}, newVMIDescs(nil), ch)
+			Expect(ch).To(BeEmpty())
+		})
+	})
+
+	Context("VMI guest OS info", func() {
+
+		It("should report the guest OS as labels", func() {
+			ch := make(chan prometheus.Metric, 1)
+			defer close(ch)
+
+			vmi := &k6tv1.VirtualMachineInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test-ns",
+					Name:      "testvmi",
+				},
+				Status: k6tv1.VirtualMachineInstanceStatus{
+					NodeName: "testNode",
+					GuestOSInfo: k6tv1.VirtualMachineInstanceGuestOSInfo{
+						ID:            "fedora",
+						Name:          "Fedora",
+						VersionID:     "34",
+						KernelRelease: "5.11.12-300.fc34.x86_64",
+						Machine:       "x86_64",
+						Timezone:      "UTC, 0",
+						Hostname:      "testhost",
+					},
+				},
+			}
+			updateVMIGuestOSInfo(vmi, newVMIDescs(nil), ch)
+
+			result := <-ch
+			dto := &io_prometheus_client.Metric{}
+			result.Write(dto)
+			Expect(result.Desc().String()).To(ContainSubstring("kubevirt_vmi_guest_os_info"))
+			Expect(dto.Gauge.GetValue()).To(BeEquivalentTo(1))
+			labels := map[string]string{}
+			for _, label := range dto.Label {
+				labels[label.GetName()] = label.GetValue()
+			}
+			Expect(labels).To(HaveKeyWithValue("guest_os_name", "Fedora"))
+			Expect(labels).To(HaveKeyWithValue("guest_os_kernel_release", "5.11.12-300.fc34.x86_64"))
+			Expect(labels).To(HaveKeyWithValue("guest_os_timezone", "UTC, 0"))
+			Expect(labels).To(HaveKeyWithValue("guest_hostname", "testhost"))
+		})
+
+		It("should not report anything before the guest agent reported the guest OS", func() {
+			ch := make(chan prometheus.Metric, 1)
+			defer close(ch)
+
+			updateVMIGuestOSInfo(&k6tv1.VirtualMachineInstance{}, newVMIDescs(nil), ch)
+			Expect(ch).To(BeEmpty())
+		})
+
+		It("should attach the accounting labels of the VMI", func() {
+			ch := make(chan prometheus.Metric, 1)
+			defer close(ch)
+
+			vmi := &k6tv1.VirtualMachineInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test-ns",
+					Name:      "testvmi",
+					Labels:    map[string]string{"example.com/cost-center": "cc-42"},
+				},
+				Status: k6tv1.VirtualMachineInstanceStatus{
+					NodeName:    "testNode",
+					GuestOSInfo: k6tv1.VirtualMachineInstanceGuestOSInfo{Name: "Fedora"},
+				},
+			}
+			updateVMIGuestOSInfo(vmi, newVMIDescs([]string{"example.com/cost-center", "example.com/tenant"}), ch)
+
+			result := <-ch
+			dto := &io_prometheus_client.Metric{}
+			result.Write(dto)
+			labels := map[string]string{}
+			for _, label := range dto.Label {
+				labels[label.GetName()] = label.GetValue()
+			}
+			Expect(labels).To(HaveKeyWithValue("guest_os_name", "Fedora"))
+			Expect(labels).To(HaveKeyWithValue("accounting_example_com_cost_center", "cc-42"))
+			Expect(labels).To(HaveKeyWithValue("accounting_example_com_tenant", ""))
+		})
+	})
 })
 
 func createVMISForEviction(evictionStrategy *k6tv1.EvictionStrategy, migratableCondStatus k8sv1.ConditionStatus) []*k6tv1.VirtualMachineInstance {
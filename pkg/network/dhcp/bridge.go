@@ -5,6 +5,7 @@ import (
 
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/kubevirt/pkg/network/cache"
+	dhcpserver "kubevirt.io/kubevirt/pkg/network/dhcp/server"
 	netdriver "kubevirt.io/kubevirt/pkg/network/driver"
 	virtnetlink "kubevirt.io/kubevirt/pkg/network/link"
 )
@@ -43,3 +44,16 @@ func (d *BridgeConfigGenerator) Generate() (*cache.DHCPConfig, error) {
 
 	return dhcpConfig, nil
 }
+
+// Lease reads the address and the routes advertised to the VMI from the cached DHCP configuration
+func (d *BridgeConfigGenerator) Lease() (*dhcpserver.Lease, error) {
+	dhcpConfig, err := d.cacheFactory.CacheDHCPConfigForPid(d.launcherPID).Read(d.podInterfaceName)
+	if err != nil {
+		return nil, err
+	}
+	return &dhcpserver.Lease{
+		ClientIP:   dhcpConfig.IP.IP,
+		ClientMask: dhcpConfig.IP.Mask,
+		Routes:     dhcpConfig.Routes,
+	}, nil
+}
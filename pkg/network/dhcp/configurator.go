@@ -27,6 +27,7 @@ import (
 
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/kubevirt/pkg/network/cache"
+	dhcpserver "kubevirt.io/kubevirt/pkg/network/dhcp/server"
 	netdriver "kubevirt.io/kubevirt/pkg/network/driver"
 )
 
@@ -43,6 +44,7 @@ type configurator struct {
 	handler              netdriver.NetworkHandler
 	dhcpStartedDirectory string
 	podInterfaceName     string
+	leaseSource          dhcpserver.LeaseSource
 }
 
 type ConfigGenerator interface {
@@ -50,14 +52,21 @@ type ConfigGenerator interface {
 }
 
 func NewBridgeConfigurator(cacheFactory cache.InterfaceCacheFactory, launcherPID string, advertisingIfaceName string, handler netdriver.NetworkHandler, podInterfaceName string,
-	vmiSpecIfaces []v1.Interface, vmiSpecIface *v1.Interface) *configurator {
-	return &configurator{
+	vmiSpecIfaces []v1.Interface, vmiSpecIface *v1.Interface, vmiSpecNetwork *v1.Network) *configurator {
+	configGenerator := &BridgeConfigGenerator{handler: handler, cacheFactory: cacheFactory, podInterfaceName: podInterfaceName, launcherPID: launcherPID, vmiSpecIfaces: vmiSpecIfaces, vmiSpecIface: vmiSpecIface}
+	configurator := &configurator{
 		podInterfaceName:     podInterfaceName,
 		advertisingIfaceName: advertisingIfaceName,
 		handler:              handler,
 		dhcpStartedDirectory: defaultDHCPStartedDirectory,
-		configGenerator:      &BridgeConfigGenerator{handler: handler, cacheFactory: cacheFactory, podInterfaceName: podInterfaceName, launcherPID: launcherPID, vmiSpecIfaces: vmiSpecIfaces, vmiSpecIface: vmiSpecIface},
+		configGenerator:      configGenerator,
+	}
+	// The IPAM of secondary networks may change while the VMI runs, virt-handler
+	// then updates the cached DHCP configuration which is advertised on renewal
+	if vmiSpecNetwork != nil && vmiSpecNetwork.Multus != nil && !vmiSpecNetwork.Multus.Default {
+		configurator.leaseSource = configGenerator.Lease
 	}
+	return configurator
 }
 
 func NewMasqueradeConfigurator(advertisingIfaceName string, handler netdriver.NetworkHandler, vmiSpecIface *v1.Interface, vmiSpecNetwork *v1.Network, podInterfaceName string) *configurator {
@@ -77,7 +86,7 @@ func (d *configurator) EnsureDHCPServerStarted(podInterfaceName string, dhcpConf
 	dhcpStartedFile := d.getDHCPStartedFilePath(podInterfaceName)
 	_, err := os.Stat(dhcpStartedFile)
 	if os.IsNotExist(err) {
-		if err := d.handler.StartDHCP(&dhcpConfig, d.advertisingIfaceName, dhcpOptions); err != nil {
+		if err := d.handler.StartDHCP(&dhcpConfig, d.advertisingIfaceName, dhcpOptions, d.leaseSource); err != nil {
 			return fmt.Errorf("failed to start DHCP server for interface %s", podInterfaceName)
 		}
 		newFile, err := os.Create(dhcpStartedFile)
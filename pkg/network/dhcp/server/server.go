@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"reflect"
 	"regexp"
 	"strings"
 	"time"
@@ -38,6 +39,7 @@ import (
 
 const (
 	infiniteLease             = 999 * 24 * time.Hour
+	renewableLease            = 1 * time.Hour
 	errorSearchDomainNotValid = "Search domain is not valid"
 	errorSearchDomainTooLong  = "Search domains length exceeded allowable size"
 	errorNTPConfiguration     = "Could not parse NTP server as IPv4 address: %s"
@@ -47,6 +49,16 @@ const (
 // Note this requires that unicode domains be presented in their ASCII format
 var searchDomainValidationRegex = regexp.MustCompile(`^(?:[_a-z0-9](?:[_a-z0-9-]{0,61}[a-z0-9])?\.)*(?:[a-z](?:[a-z0-9-]{0,61}[a-z0-9])?)?$`)
 
+// Lease is the part of the advertised configuration which may change while the server is running
+type Lease struct {
+	ClientIP   net.IP
+	ClientMask net.IPMask
+	Routes     *[]netlink.Route
+}
+
+// LeaseSource returns the lease which is currently advertised to the client
+type LeaseSource func() (*Lease, error)
+
 func SingleClientDHCPServer(
 	clientMAC net.HardwareAddr,
 	clientIP net.IP,
@@ -58,7 +70,8 @@ func SingleClientDHCPServer(
 	routes *[]netlink.Route,
 	searchDomains []string,
 	mtu uint16,
-	customDHCPOptions *v1.DHCPOptions) error {
+	customDHCPOptions *v1.DHCPOptions,
+	leaseSource LeaseSource) error {
 
 	log.Log.Info("Starting SingleClientDHCPServer")
 
@@ -76,10 +89,18 @@ func SingleClientDHCPServer(
 		clientIP:      clientIP,
 		clientMAC:     clientMAC,
 		serverIP:      serverIP.To4(),
-		leaseDuration: infiniteLease,
+		leaseDuration: leaseDuration(customDHCPOptions, leaseSource != nil),
 		options:       options,
 	}
 
+	if leaseSource != nil {
+		handler.leaseSource = leaseSource
+		handler.lease = &Lease{ClientIP: clientIP, ClientMask: clientMask, Routes: routes}
+		handler.prepareOptions = func(lease *Lease) (dhcp.Options, error) {
+			return prepareDHCPOptions(lease.ClientMask, routerIP, dnsIPs, lease.Routes, searchDomains, mtu, hostname, customDHCPOptions)
+		}
+	}
+
 	l, err := NewUDP4FilterListener(serverIface, ":67")
 	if err != nil {
 		return err
@@ -92,6 +113,18 @@ func SingleClientDHCPServer(
 	return nil
 }
 
+// leaseDuration returns the lease time configured for the interface, or the default one.
+// A lease which can change has to be renewed by the client to pick up the changes.
+func leaseDuration(customDHCPOptions *v1.DHCPOptions, renewable bool) time.Duration {
+	if customDHCPOptions != nil && customDHCPOptions.LeaseTime != nil {
+		return customDHCPOptions.LeaseTime.Duration
+	}
+	if renewable {
+		return renewableLease
+	}
+	return infiniteLease
+}
+
 func prepareDHCPOptions(
 	clientMask net.IPMask,
 	routerIP net.IP,
@@ -179,14 +212,17 @@ func prepareDHCPOptions(
 }
 
 type DHCPHandler struct {
-	serverIP      net.IP
-	clientIP      net.IP
-	clientMAC     net.HardwareAddr
-	leaseDuration time.Duration
-	options       dhcp.Options
+	serverIP       net.IP
+	clientIP       net.IP
+	clientMAC      net.HardwareAddr
+	leaseDuration  time.Duration
+	options        dhcp.Options
+	leaseSource    LeaseSource
+	lease          *Lease
+	prepareOptions func(lease *Lease) (dhcp.Options, error)
 }
 
-func (h *DHCPHandler) ServeDHCP(p dhcp.Packet, msgType dhcp.MessageType, _ dhcp.Options) (d dhcp.Packet) {
+func (h *DHCPHandler) ServeDHCP(p dhcp.Packet, msgType dhcp.MessageType, reqOptions dhcp.Options) (d dhcp.Packet) {
 	log.Log.V(4).Info("Serving a new request")
 	if len(h.clientMAC) != 0 {
 		if mac := p.CHAddr(); !bytes.Equal(mac, h.clientMAC) {
@@ -199,11 +235,20 @@ func (h *DHCPHandler) ServeDHCP(p dhcp.Packet, msgType dhcp.MessageType, _ dhcp.
 
 	case dhcp.Discover:
 		log.Log.V(4).Info("The request has message type DISCOVER")
+		h.refreshLease()
 		return dhcp.ReplyPacket(p, dhcp.Offer, h.serverIP, h.clientIP, h.leaseDuration,
 			h.options.SelectOrderOrAll(nil))
 
 	case dhcp.Request:
 		log.Log.V(4).Info("The request has message type REQUEST")
+		h.refreshLease()
+		if h.leaseSource != nil {
+			// Make the client start over if it renews an address it is not given anymore
+			if requestedIP := requestedIPAddress(p, reqOptions); requestedIP != nil && !requestedIP.Equal(h.clientIP) {
+				log.Log.Infof("Rejecting the request for %s, the client is given %s now", requestedIP, h.clientIP)
+				return dhcp.ReplyPacket(p, dhcp.NAK, h.serverIP, nil, 0, nil)
+			}
+		}
 		return dhcp.ReplyPacket(p, dhcp.ACK, h.serverIP, h.clientIP, h.leaseDuration,
 			h.options.SelectOrderOrAll(nil))
 
@@ -214,6 +259,41 @@ func (h *DHCPHandler) ServeDHCP(p dhcp.Packet, msgType dhcp.MessageType, _ dhcp.
 	}
 }
 
+// refreshLease picks up changes of the lease before it is offered or acknowledged
+func (h *DHCPHandler) refreshLease() {
+	if h.leaseSource == nil {
+		return
+	}
+	lease, err := h.leaseSource()
+	if err != nil {
+		log.Log.Reason(err).Warning("Failed to refresh the DHCP lease, advertising the previous one")
+		return
+	}
+	if reflect.DeepEqual(lease, h.lease) {
+		return
+	}
+	options, err := h.prepareOptions(lease)
+	if err != nil {
+		log.Log.Reason(err).Warning("Failed to prepare the DHCP options of the refreshed lease, advertising the previous one")
+		return
+	}
+	log.Log.Infof("Advertising the refreshed DHCP lease with address %s", lease.ClientIP)
+	h.clientIP = lease.ClientIP
+	h.options = options
+	h.lease = lease
+}
+
+// requestedIPAddress returns the address the client asks for, either while renewing or while selecting an offer
+func requestedIPAddress(p dhcp.Packet, reqOptions dhcp.Options) net.IP {
+	if requestedIP := net.IP(reqOptions[dhcp.OptionRequestedIPAddress]); len(requestedIP) == net.IPv4len {
+		return requestedIP
+	}
+	if ciaddr := p.CIAddr(); !ciaddr.Equal(net.IPv4zero) {
+		return ciaddr
+	}
+	return nil
+}
+
 func sortRoutes(routes []netlink.Route) []netlink.Route {
 	// Default route must come last, otherwise it may not get applied
 	// because there is no route to its gateway yet
@@ -21,11 +21,14 @@ package server
 
 import (
 	"net"
+	"time"
 
 	"github.com/krolaw/dhcp4"
 	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 	"github.com/vishvananda/netlink"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	v1 "kubevirt.io/client-go/api/v1"
 )
@@ -282,4 +285,83 @@ var _ = Describe("DHCP Server", func() {
 			})
 		})
 	})
+
+	table.DescribeTable("lease duration", func(customDHCPOptions *v1.DHCPOptions, renewable bool, expected time.Duration) {
+		Expect(leaseDuration(customDHCPOptions, renewable)).To(Equal(expected))
+	},
+		table.Entry("should be infinite by default", nil, false, infiniteLease),
+		table.Entry("should be renewable if the lease can change", nil, true, renewableLease),
+		table.Entry("should be infinite without a configured lease time", &v1.DHCPOptions{BootFileName: "pxelinux.0"}, false, infiniteLease),
+		table.Entry("should be the configured lease time", &v1.DHCPOptions{LeaseTime: &metav1.Duration{Duration: 5 * time.Minute}}, false, 5*time.Minute),
+		table.Entry("should be the configured lease time even if the lease can change", &v1.DHCPOptions{LeaseTime: &metav1.Duration{Duration: 12 * time.Hour}}, true, 12*time.Hour),
+	)
+
+	Context("with a lease source", func() {
+		var (
+			handler   *DHCPHandler
+			lease     *Lease
+			clientMAC net.HardwareAddr
+			oldIP     = net.ParseIP("10.10.0.5").To4()
+			newIP     = net.ParseIP("10.20.0.7").To4()
+		)
+
+		messageType := func(p dhcp4.Packet) dhcp4.MessageType {
+			return dhcp4.MessageType(p.ParseOptions()[dhcp4.OptionDHCPMessageType][0])
+		}
+
+		BeforeEach(func() {
+			clientMAC, _ = net.ParseMAC("02:00:00:00:00:01")
+			lease = &Lease{ClientIP: oldIP, ClientMask: net.CIDRMask(24, 32)}
+			handler = &DHCPHandler{
+				serverIP:      net.ParseIP("169.254.75.10").To4(),
+				clientIP:      oldIP,
+				clientMAC:     clientMAC,
+				leaseDuration: renewableLease,
+				options:       dhcp4.Options{},
+				leaseSource: func() (*Lease, error) {
+					return lease, nil
+				},
+				lease: &Lease{ClientIP: oldIP, ClientMask: net.CIDRMask(24, 32)},
+				prepareOptions: func(lease *Lease) (dhcp4.Options, error) {
+					return dhcp4.Options{dhcp4.OptionSubnetMask: lease.ClientMask}, nil
+				},
+			}
+		})
+
+		It("should acknowledge the renewal of the current address", func() {
+			request := dhcp4.RequestPacket(dhcp4.Request, clientMAC, oldIP, []byte{1, 2, 3, 4}, false, nil)
+			reply := handler.ServeDHCP(request, dhcp4.Request, request.ParseOptions())
+			Expect(messageType(reply)).To(Equal(dhcp4.ACK))
+			Expect(reply.YIAddr().Equal(oldIP)).To(BeTrue())
+		})
+
+		Context("when the lease changes", func() {
+			BeforeEach(func() {
+				lease = &Lease{ClientIP: newIP, ClientMask: net.CIDRMask(16, 32)}
+			})
+
+			It("should offer the new address", func() {
+				discover := dhcp4.RequestPacket(dhcp4.Discover, clientMAC, nil, []byte{1, 2, 3, 4}, false, nil)
+				reply := handler.ServeDHCP(discover, dhcp4.Discover, discover.ParseOptions())
+				Expect(messageType(reply)).To(Equal(dhcp4.Offer))
+				Expect(reply.YIAddr().Equal(newIP)).To(BeTrue())
+				Expect(reply.ParseOptions()[dhcp4.OptionSubnetMask]).To(Equal([]byte{255, 255, 0, 0}))
+			})
+
+			It("should reject the renewal of the previous address", func() {
+				request := dhcp4.RequestPacket(dhcp4.Request, clientMAC, oldIP, []byte{1, 2, 3, 4}, false, nil)
+				reply := handler.ServeDHCP(request, dhcp4.Request, request.ParseOptions())
+				Expect(messageType(reply)).To(Equal(dhcp4.NAK))
+			})
+
+			It("should acknowledge the request of the new address", func() {
+				request := dhcp4.RequestPacket(dhcp4.Request, clientMAC, nil, []byte{1, 2, 3, 4}, false, []dhcp4.Option{
+					{Code: dhcp4.OptionRequestedIPAddress, Value: newIP},
+				})
+				reply := handler.ServeDHCP(request, dhcp4.Request, request.ParseOptions())
+				Expect(messageType(reply)).To(Equal(dhcp4.ACK))
+				Expect(reply.YIAddr().Equal(newIP)).To(BeTrue())
+			})
+		})
+	})
 })
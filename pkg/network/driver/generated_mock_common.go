@@ -12,6 +12,7 @@ import (
 
 	v1 "kubevirt.io/client-go/api/v1"
 	cache "kubevirt.io/kubevirt/pkg/network/cache"
+	server "kubevirt.io/kubevirt/pkg/network/dhcp/server"
 )
 
 // Mock of NetworkHandler interface
@@ -203,14 +204,44 @@ func (_mr *_MockNetworkHandlerRecorder) LinkSetMaster(arg0, arg1 interface{}) *g
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "LinkSetMaster", arg0, arg1)
 }
 
-func (_m *MockNetworkHandler) StartDHCP(nic *cache.DHCPConfig, bridgeInterfaceName string, dhcpOptions *v1.DHCPOptions) error {
-	ret := _m.ctrl.Call(_m, "StartDHCP", nic, bridgeInterfaceName, dhcpOptions)
+func (_m *MockNetworkHandler) QdiscAdd(qdisc netlink.Qdisc) error {
+	ret := _m.ctrl.Call(_m, "QdiscAdd", qdisc)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-func (_mr *_MockNetworkHandlerRecorder) StartDHCP(arg0, arg1, arg2 interface{}) *gomock.Call {
-	return _mr.mock.ctrl.RecordCall(_mr.mock, "StartDHCP", arg0, arg1, arg2)
+func (_mr *_MockNetworkHandlerRecorder) QdiscAdd(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "QdiscAdd", arg0)
+}
+
+func (_m *MockNetworkHandler) QdiscDel(qdisc netlink.Qdisc) error {
+	ret := _m.ctrl.Call(_m, "QdiscDel", qdisc)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockNetworkHandlerRecorder) QdiscDel(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "QdiscDel", arg0)
+}
+
+func (_m *MockNetworkHandler) FilterAdd(filter netlink.Filter) error {
+	ret := _m.ctrl.Call(_m, "FilterAdd", filter)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockNetworkHandlerRecorder) FilterAdd(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "FilterAdd", arg0)
+}
+
+func (_m *MockNetworkHandler) StartDHCP(nic *cache.DHCPConfig, bridgeInterfaceName string, dhcpOptions *v1.DHCPOptions, leaseSource server.LeaseSource) error {
+	ret := _m.ctrl.Call(_m, "StartDHCP", nic, bridgeInterfaceName, dhcpOptions, leaseSource)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockNetworkHandlerRecorder) StartDHCP(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "StartDHCP", arg0, arg1, arg2, arg3)
 }
 
 func (_m *MockNetworkHandler) HasNatIptables(proto iptables.Protocol) bool {
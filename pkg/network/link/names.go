@@ -27,6 +27,11 @@ func GenerateTapDeviceName(podInterfaceName string) string {
 	return "tap" + podInterfaceName[3:]
 }
 
+// GenerateMirrorDeviceName returns the name of the interface the traffic of the pod interface is mirrored to
+func GenerateMirrorDeviceName(podInterfaceName string) string {
+	return "mir" + podInterfaceName[3:]
+}
+
 func GenerateNewBridgedVmiInterfaceName(originalPodInterfaceName string) string {
 	return fmt.Sprintf("%s-nic", originalPodInterfaceName)
 
@@ -38,6 +38,14 @@ var _ = Describe("Common Methods", func() {
 			Expect(virtnetlink.GenerateTapDeviceName("eth123")).To(Equal("tap123"))
 		})
 	})
+	Context("GenerateMirrorDeviceName function", func() {
+		It("Should return the mirror device name of the primary pod interface", func() {
+			Expect(virtnetlink.GenerateMirrorDeviceName("eth0")).To(Equal("mir0"))
+		})
+		It("Should return the mirror device name of a secondary pod interface", func() {
+			Expect(virtnetlink.GenerateMirrorDeviceName("net2")).To(Equal("mir2"))
+		})
+	})
 	Context("GenerateNewBridgedVmiInterfaceName function", func() {
 		It("Should return the new bridge interface name", func() {
 			Expect(virtnetlink.GenerateNewBridgedVmiInterfaceName("eth0")).To(Equal("eth0-nic"))
@@ -0,0 +1,193 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package network
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+	virtnetlink "kubevirt.io/kubevirt/pkg/network/link"
+)
+
+var mirrorProtocols = map[string]uint32{
+	"TCP":  unix.IPPROTO_TCP,
+	"UDP":  unix.IPPROTO_UDP,
+	"ICMP": unix.IPPROTO_ICMP,
+}
+
+// MirrorDeadline returns when the traffic of the interface stops being mirrored, if the mirror
+// duration is limited.
+func MirrorDeadline(vmi *v1.VirtualMachineInstance, iface *v1.Interface) (time.Time, bool) {
+	if iface.Mirror == nil || iface.Mirror.Duration == nil {
+		return time.Time{}, false
+	}
+	return vmi.CreationTimestamp.Add(iface.Mirror.Duration.Duration), true
+}
+
+func isMirrorExpired(vmi *v1.VirtualMachineInstance, iface *v1.Interface, now time.Time) bool {
+	deadline, limited := MirrorDeadline(vmi, iface)
+	return limited && !now.Before(deadline)
+}
+
+// setupMirror clones the traffic of the tap device to the mirror interface with tc. The tap device
+// sees the traffic the guest sends as ingress, and the traffic the guest receives as egress.
+func (l *podNIC) setupMirror() error {
+	tap, err := l.handler.LinkByName(virtnetlink.GenerateTapDeviceName(l.podInterfaceName))
+	if err != nil {
+		return fmt.Errorf("failed to get the tap device of pod interface %s: %v", l.podInterfaceName, err)
+	}
+	target, err := l.mirrorTarget()
+	if err != nil {
+		return err
+	}
+
+	if err := l.handler.QdiscAdd(newClsactQdisc(tap.Attrs().Index)); err != nil {
+		return fmt.Errorf("failed to add the clsact qdisc to %s: %v", tap.Attrs().Name, err)
+	}
+	for _, filter := range newMirrorFilters(l.vmiSpecIface.Mirror.Filter, tap.Attrs().Index, target.Attrs().Index) {
+		if err := l.handler.FilterAdd(filter); err != nil {
+			return fmt.Errorf("failed to mirror the traffic of %s to %s: %v", tap.Attrs().Name, target.Attrs().Name, err)
+		}
+	}
+
+	log.Log.Object(l.vmi).Infof("Mirroring the traffic of interface %s to %s", l.vmiSpecIface.Name, target.Attrs().Name)
+	return nil
+}
+
+// mirrorTarget returns the interface the traffic is mirrored to. It is created by Multus when the
+// traffic is mirrored to a network, otherwise a capture interface is created in the pod.
+func (l *podNIC) mirrorTarget() (netlink.Link, error) {
+	name := virtnetlink.GenerateMirrorDeviceName(l.podInterfaceName)
+	if l.vmiSpecIface.Mirror.Network == "" {
+		dummy := &netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Name: name}}
+		if err := l.handler.LinkAdd(dummy); err != nil && !os.IsExist(err) {
+			return nil, fmt.Errorf("failed to create the capture interface %s: %v", name, err)
+		}
+	}
+
+	target, err := l.handler.LinkByName(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the mirror interface %s: %v", name, err)
+	}
+	if err := l.handler.LinkSetUp(target); err != nil {
+		return nil, fmt.Errorf("failed to bring the mirror interface %s up: %v", name, err)
+	}
+	return target, nil
+}
+
+// RemoveExpiredMirrors stops mirroring the traffic of the interfaces whose mirror duration is over.
+func (n *VMNetworkConfigurator) RemoveExpiredMirrors(now time.Time) error {
+	for i := range n.vmi.Spec.Networks {
+		network := &n.vmi.Spec.Networks[i]
+		iface := findInterfaceByNetworkName(n.vmi, network)
+		if iface == nil || iface.Bridge == nil || !isMirrorExpired(n.vmi, iface, now) {
+			continue
+		}
+		podInterfaceName, err := composePodInterfaceName(n.vmi, network)
+		if err != nil {
+			return err
+		}
+		tap, err := n.handler.LinkByName(virtnetlink.GenerateTapDeviceName(podInterfaceName))
+		if err != nil {
+			return fmt.Errorf("failed to get the tap device of pod interface %s: %v", podInterfaceName, err)
+		}
+		// deleting the qdisc deletes its filters, it is gone if the mirror was removed before
+		err = n.handler.QdiscDel(newClsactQdisc(tap.Attrs().Index))
+		if err != nil && err != syscall.ENOENT && err != syscall.EINVAL {
+			return fmt.Errorf("failed to stop mirroring the traffic of %s: %v", tap.Attrs().Name, err)
+		}
+		log.Log.Object(n.vmi).V(4).Infof("Stopped mirroring the traffic of interface %s", iface.Name)
+	}
+	return nil
+}
+
+func newClsactQdisc(linkIndex int) *netlink.GenericQdisc {
+	return &netlink.GenericQdisc{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: linkIndex,
+			Handle:    netlink.MakeHandle(0xffff, 0),
+			Parent:    netlink.HANDLE_CLSACT,
+		},
+		QdiscType: "clsact",
+	}
+}
+
+// newMirrorFilters matches the filtered traffic with u32 filters. Like the ip sport and dport
+// matches of tc, the port is matched at the offset of a TCP or UDP header without IP options.
+func newMirrorFilters(filter *v1.InterfaceMirrorFilter, tapIndex int, targetIndex int) []netlink.Filter {
+	var parents []uint32
+	direction := v1.InterfaceMirrorBoth
+	if filter != nil && filter.Direction != "" {
+		direction = filter.Direction
+	}
+	if direction != v1.InterfaceMirrorIngress {
+		parents = append(parents, netlink.HANDLE_MIN_INGRESS)
+	}
+	if direction != v1.InterfaceMirrorEgress {
+		parents = append(parents, netlink.HANDLE_MIN_EGRESS)
+	}
+
+	protocol := uint16(unix.ETH_P_ALL)
+	selectors := [][]netlink.TcU32Key{{{}}}
+	if filter != nil && filter.Protocol != "" {
+		protocol = unix.ETH_P_IP
+		protocolKey := netlink.TcU32Key{Mask: 0x00ff0000, Val: mirrorProtocols[filter.Protocol] << 16, Off: 8}
+		selectors = [][]netlink.TcU32Key{{protocolKey}}
+		if filter.Port != 0 {
+			port := uint32(filter.Port)
+			selectors = [][]netlink.TcU32Key{
+				{protocolKey, {Mask: 0xffff0000, Val: port << 16, Off: 20}},
+				{protocolKey, {Mask: 0x0000ffff, Val: port, Off: 20}},
+			}
+		}
+	}
+
+	var filters []netlink.Filter
+	for _, parent := range parents {
+		for _, keys := range selectors {
+			filters = append(filters, &netlink.U32{
+				FilterAttrs: netlink.FilterAttrs{
+					LinkIndex: tapIndex,
+					Parent:    parent,
+					Protocol:  protocol,
+				},
+				Sel: &netlink.TcU32Sel{
+					Flags: netlink.TC_U32_TERMINAL,
+					Keys:  keys,
+				},
+				Actions: []netlink.Action{
+					&netlink.MirredAction{
+						ActionAttrs:  netlink.ActionAttrs{Action: netlink.TC_ACT_PIPE},
+						MirredAction: netlink.TCA_EGRESS_MIRROR,
+						Ifindex:      targetIndex,
+					},
+				},
+			})
+		}
+	}
+	return filters
+}
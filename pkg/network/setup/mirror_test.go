@@ -0,0 +1,127 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package network
+
+import (
+	"syscall"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	netdriver "kubevirt.io/kubevirt/pkg/network/driver"
+)
+
+var _ = Describe("Interface mirror", func() {
+	const (
+		tapIndex    = 5
+		targetIndex = 7
+	)
+	var (
+		ctrl        *gomock.Controller
+		mockNetwork *netdriver.MockNetworkHandler
+		vmi         *v1.VirtualMachineInstance
+		tap         *netlink.Tuntap
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockNetwork = netdriver.NewMockNetworkHandler(ctrl)
+		vmi = newVMIBridgeInterface("testnamespace", "testVmName")
+		vmi.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+		tap = &netlink.Tuntap{LinkAttrs: netlink.LinkAttrs{Name: "tap0", Index: tapIndex}}
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	table.DescribeTable("should mirror the filtered traffic", func(filter *v1.InterfaceMirrorFilter, expectedParents []uint32, expectedProtocol int, expectedKeys [][]netlink.TcU32Key) {
+		filters := newMirrorFilters(filter, tapIndex, targetIndex)
+		Expect(filters).To(HaveLen(len(expectedParents) * len(expectedKeys)))
+		for i, f := range filters {
+			u32 := f.(*netlink.U32)
+			Expect(u32.LinkIndex).To(Equal(tapIndex))
+			Expect(u32.Parent).To(Equal(expectedParents[i/len(expectedKeys)]))
+			Expect(u32.Protocol).To(Equal(uint16(expectedProtocol)))
+			Expect(u32.Sel.Keys).To(Equal(expectedKeys[i%len(expectedKeys)]))
+			Expect(u32.Actions).To(ConsistOf(&netlink.MirredAction{
+				ActionAttrs:  netlink.ActionAttrs{Action: netlink.TC_ACT_PIPE},
+				MirredAction: netlink.TCA_EGRESS_MIRROR,
+				Ifindex:      targetIndex,
+			}))
+		}
+	},
+		table.Entry("of both directions without a filter", nil,
+			[]uint32{netlink.HANDLE_MIN_INGRESS, netlink.HANDLE_MIN_EGRESS}, unix.ETH_P_ALL, [][]netlink.TcU32Key{{{}}}),
+		table.Entry("sent by the guest", &v1.InterfaceMirrorFilter{Direction: v1.InterfaceMirrorEgress},
+			[]uint32{netlink.HANDLE_MIN_INGRESS}, unix.ETH_P_ALL, [][]netlink.TcU32Key{{{}}}),
+		table.Entry("received by the guest with a protocol", &v1.InterfaceMirrorFilter{Direction: v1.InterfaceMirrorIngress, Protocol: "ICMP"},
+			[]uint32{netlink.HANDLE_MIN_EGRESS}, unix.ETH_P_IP, [][]netlink.TcU32Key{{{Mask: 0x00ff0000, Val: 1 << 16, Off: 8}}}),
+		table.Entry("from or to a port", &v1.InterfaceMirrorFilter{Direction: v1.InterfaceMirrorEgress, Protocol: "TCP", Port: 443},
+			[]uint32{netlink.HANDLE_MIN_INGRESS}, unix.ETH_P_IP, [][]netlink.TcU32Key{
+				{{Mask: 0x00ff0000, Val: 6 << 16, Off: 8}, {Mask: 0xffff0000, Val: 443 << 16, Off: 20}},
+				{{Mask: 0x00ff0000, Val: 6 << 16, Off: 8}, {Mask: 0x0000ffff, Val: 443, Off: 20}},
+			}),
+	)
+
+	It("should mirror the traffic into a capture interface", func() {
+		vmi.Spec.Domain.Devices.Interfaces[0].Mirror = &v1.InterfaceMirror{}
+		launcherPID := 1
+		podnic, err := newPodNIC(vmi, &vmi.Spec.Networks[0], mockNetwork, nil, &launcherPID)
+		Expect(err).ToNot(HaveOccurred())
+
+		capture := &netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Name: "mir0", Index: targetIndex}}
+		mockNetwork.EXPECT().LinkByName("tap0").Return(tap, nil)
+		mockNetwork.EXPECT().LinkAdd(&netlink.Dummy{LinkAttrs: netlink.LinkAttrs{Name: "mir0"}}).Return(nil)
+		mockNetwork.EXPECT().LinkByName("mir0").Return(capture, nil)
+		mockNetwork.EXPECT().LinkSetUp(capture).Return(nil)
+		mockNetwork.EXPECT().QdiscAdd(newClsactQdisc(tapIndex)).Return(nil)
+		mockNetwork.EXPECT().FilterAdd(gomock.Any()).Return(nil).Times(2)
+
+		Expect(podnic.setupMirror()).To(Succeed())
+	})
+
+	It("should only stop mirroring the interfaces whose duration is over", func() {
+		vmi.Spec.Domain.Devices.Interfaces[0].Mirror = &v1.InterfaceMirror{Duration: &metav1.Duration{Duration: time.Minute}}
+		vmi.Spec.Domain.Devices.Interfaces = append(vmi.Spec.Domain.Devices.Interfaces, v1.Interface{
+			Name:                   "red",
+			InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}},
+			Mirror:                 &v1.InterfaceMirror{Network: "ids", Duration: &metav1.Duration{Duration: 2 * time.Hour}},
+		})
+		vmi.Spec.Networks = append(vmi.Spec.Networks, v1.Network{
+			Name:          "red",
+			NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "red"}},
+		})
+
+		mockNetwork.EXPECT().LinkByName("tap0").Return(tap, nil)
+		mockNetwork.EXPECT().QdiscDel(newClsactQdisc(tapIndex)).Return(syscall.ENOENT)
+
+		configurator := newVMNetworkConfiguratorWithHandlerAndCache(vmi, mockNetwork, nil)
+		Expect(configurator.RemoveExpiredMirrors(time.Now())).To(Succeed())
+	})
+})
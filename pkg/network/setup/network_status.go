@@ -0,0 +1,158 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package network
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	nettypes "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	"github.com/vishvananda/netlink"
+
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/network/cache"
+)
+
+// ReadNetworkStatus parses the network-status annotation out of the pod annotations
+// file projected by the downward API. It returns nil if the annotation is not set.
+func ReadNetworkStatus(annotationsFile string) ([]nettypes.NetworkStatus, error) {
+	content, err := ioutil.ReadFile(annotationsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	// The downward API writes one annotation per line as key="quoted value"
+	for _, line := range strings.Split(string(content), "\n") {
+		keyValue := strings.SplitN(line, "=", 2)
+		if len(keyValue) != 2 || keyValue[0] != nettypes.NetworkStatusAnnot {
+			continue
+		}
+		value, err := strconv.Unquote(keyValue[1])
+		if err != nil {
+			return nil, fmt.Errorf("failed to unquote the %s annotation: %v", nettypes.NetworkStatusAnnot, err)
+		}
+		var networkStatus []nettypes.NetworkStatus
+		if err := json.Unmarshal([]byte(value), &networkStatus); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal the %s annotation: %v", nettypes.NetworkStatusAnnot, err)
+		}
+		return networkStatus, nil
+	}
+	return nil, nil
+}
+
+// RefreshDHCPConfig updates the cached DHCP configuration of the secondary bridge interfaces
+// whose address changed according to the network status. The DHCP server in virt-launcher
+// advertises the updated configuration when the guest renews its lease.
+func (n *VMNetworkConfigurator) RefreshDHCPConfig(pid int, networkStatus []nettypes.NetworkStatus) error {
+	for i := range n.vmi.Spec.Networks {
+		network := &n.vmi.Spec.Networks[i]
+		if !isSecondaryMultusNetwork(*network) {
+			continue
+		}
+		iface := findInterfaceByNetworkName(n.vmi, network)
+		if iface == nil || iface.Bridge == nil {
+			continue
+		}
+		nic, err := newPodNIC(n.vmi, network, n.handler, n.cacheFactory, &pid)
+		if err != nil {
+			return err
+		}
+		if err := nic.refreshDHCPConfig(networkStatus); err != nil {
+			return fmt.Errorf("failed to refresh the DHCP configuration of nic '%s': %w", nic.podInterfaceName, err)
+		}
+	}
+	return nil
+}
+
+func (l *podNIC) refreshDHCPConfig(networkStatus []nettypes.NetworkStatus) error {
+	// The cached configuration is only final once phase1 is done
+	state, err := l.state()
+	if err != nil || state != cache.PodIfaceNetworkPreparationFinished {
+		return err
+	}
+
+	ip := podInterfaceIPv4(networkStatus, l.podInterfaceName)
+	if ip == nil {
+		return nil
+	}
+
+	dhcpConfigs := l.cacheFactory.CacheDHCPConfigForPid(getPIDString(l.launcherPID))
+	dhcpConfig, err := dhcpConfigs.Read(l.podInterfaceName)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if dhcpConfig.IPAMDisabled || dhcpConfig.IP.IPNet == nil || dhcpConfig.IP.IP.Equal(ip) {
+		return nil
+	}
+
+	log.Log.Object(l.vmi).Infof("Address of pod interface %s changed from %s to %s, updating the advertised DHCP configuration", l.podInterfaceName, dhcpConfig.IP.IP, ip)
+	updateDHCPConfigAddress(dhcpConfig, ip)
+	return dhcpConfigs.Write(l.podInterfaceName, dhcpConfig)
+}
+
+// updateDHCPConfigAddress moves the DHCP configuration to the new address. The network status
+// doesn't report the prefix length or the routes, so the prefix length is kept and only the
+// route to the subnet of the address follows it.
+func updateDHCPConfigAddress(dhcpConfig *cache.DHCPConfig, ip net.IP) {
+	mask := dhcpConfig.IP.Mask
+	oldSubnet := &net.IPNet{IP: dhcpConfig.IP.IP.Mask(mask), Mask: mask}
+	newSubnet := &net.IPNet{IP: ip.Mask(mask), Mask: mask}
+
+	dhcpConfig.IP = netlink.Addr{IPNet: &net.IPNet{IP: ip, Mask: mask}}
+
+	if dhcpConfig.Routes == nil {
+		return
+	}
+	routes := make([]netlink.Route, 0, len(*dhcpConfig.Routes))
+	for _, route := range *dhcpConfig.Routes {
+		if route.Dst != nil && route.Dst.IP.Equal(oldSubnet.IP) && bytes.Equal(route.Dst.Mask, oldSubnet.Mask) {
+			route.Dst = newSubnet
+		}
+		routes = append(routes, route)
+	}
+	dhcpConfig.Routes = &routes
+}
+
+func podInterfaceIPv4(networkStatus []nettypes.NetworkStatus, podInterfaceName string) net.IP {
+	for _, status := range networkStatus {
+		if status.Interface != podInterfaceName {
+			continue
+		}
+		for _, address := range status.IPs {
+			ip := net.ParseIP(address)
+			if ip == nil {
+				ip, _, _ = net.ParseCIDR(address)
+			}
+			if ip != nil && ip.To4() != nil {
+				return ip.To4()
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package network
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	nettypes "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	"github.com/vishvananda/netlink"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	dutils "kubevirt.io/kubevirt/pkg/ephemeral-disk-utils"
+	"kubevirt.io/kubevirt/pkg/network/cache"
+)
+
+var _ = Describe("Network status", func() {
+	var tmpDir string
+
+	BeforeEach(func() {
+		dutils.MockDefaultOwnershipManager()
+		var err error
+		tmpDir, err = ioutil.TempDir("/tmp", "network-status")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	Context("ReadNetworkStatus", func() {
+		It("should parse the network-status annotation from the downward API file", func() {
+			annotations := filepath.Join(tmpDir, "annotations")
+			content := `kubevirt.io/domain="testvmi"
+k8s.v1.cni.cncf.io/network-status="[{\"name\":\"\",\"interface\":\"eth0\",\"ips\":[\"10.244.0.12\"],\"default\":true},{\"name\":\"default/red\",\"interface\":\"net1\",\"ips\":[\"192.168.1.20\"]}]"
+`
+			Expect(ioutil.WriteFile(annotations, []byte(content), 0644)).To(Succeed())
+
+			networkStatus, err := ReadNetworkStatus(annotations)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(networkStatus).To(HaveLen(2))
+			Expect(networkStatus[1].Interface).To(Equal("net1"))
+			Expect(networkStatus[1].IPs).To(ConsistOf("192.168.1.20"))
+		})
+
+		It("should return nothing if the annotation is not set", func() {
+			annotations := filepath.Join(tmpDir, "annotations")
+			Expect(ioutil.WriteFile(annotations, []byte(`kubevirt.io/domain="testvmi"`), 0644)).To(Succeed())
+
+			networkStatus, err := ReadNetworkStatus(annotations)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(networkStatus).To(BeEmpty())
+		})
+	})
+
+	Context("RefreshDHCPConfig", func() {
+		const launcherPID = 1
+		var (
+			vmi          *v1.VirtualMachineInstance
+			cacheFactory cache.InterfaceCacheFactory
+			dhcpConfig   *cache.DHCPConfig
+		)
+
+		BeforeEach(func() {
+			cacheFactory = cache.NewInterfaceCacheFactoryWithBasePath(tmpDir)
+
+			vmi = newVMI("testnamespace", "testVmName")
+			vmi.Spec.Networks = append(vmi.Spec.Networks, v1.Network{
+				Name:          "red",
+				NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "red"}},
+			})
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{
+				*v1.DefaultBridgeNetworkInterface(),
+				{Name: "red", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}},
+			}
+
+			Expect(cacheFactory.CacheForVMI(vmi).Write("red", &cache.PodCacheInterface{State: cache.PodIfaceNetworkPreparationFinished})).To(Succeed())
+
+			addr, err := netlink.ParseAddr("192.168.1.10/24")
+			Expect(err).ToNot(HaveOccurred())
+			_, subnet, _ := net.ParseCIDR("192.168.1.0/24")
+			_, remote, _ := net.ParseCIDR("10.10.0.0/16")
+			dhcpConfig = &cache.DHCPConfig{
+				Name: "net1",
+				IP:   *addr,
+				Routes: &[]netlink.Route{
+					{Dst: subnet},
+					{Dst: remote, Gw: net.ParseIP("192.168.1.1")},
+				},
+			}
+			Expect(cacheFactory.CacheDHCPConfigForPid("1").Write("net1", dhcpConfig)).To(Succeed())
+		})
+
+		readDHCPConfig := func() *cache.DHCPConfig {
+			cachedConfig, err := cacheFactory.CacheDHCPConfigForPid("1").Read("net1")
+			Expect(err).ToNot(HaveOccurred())
+			return cachedConfig
+		}
+
+		It("should move the cached DHCP configuration to the new address", func() {
+			Expect(NewVMNetworkConfigurator(vmi, cacheFactory).RefreshDHCPConfig(launcherPID, []nettypes.NetworkStatus{
+				{Interface: "net1", IPs: []string{"192.168.2.30"}},
+			})).To(Succeed())
+
+			cachedConfig := readDHCPConfig()
+			Expect(cachedConfig.IP.String()).To(Equal("192.168.2.30/24"))
+			Expect(*cachedConfig.Routes).To(HaveLen(2))
+			Expect((*cachedConfig.Routes)[0].Dst.String()).To(Equal("192.168.2.0/24"))
+			Expect((*cachedConfig.Routes)[1].Dst.String()).To(Equal("10.10.0.0/16"))
+		})
+
+		It("should keep the cached DHCP configuration if the address didn't change", func() {
+			Expect(NewVMNetworkConfigurator(vmi, cacheFactory).RefreshDHCPConfig(launcherPID, []nettypes.NetworkStatus{
+				{Interface: "net1", IPs: []string{"192.168.1.10"}},
+			})).To(Succeed())
+
+			Expect(readDHCPConfig().IP.String()).To(Equal("192.168.1.10/24"))
+		})
+
+		It("should not touch the DHCP configuration before phase1 finished", func() {
+			Expect(cacheFactory.CacheForVMI(vmi).Write("red", &cache.PodCacheInterface{State: cache.PodIfaceNetworkPreparationStarted})).To(Succeed())
+
+			Expect(NewVMNetworkConfigurator(vmi, cacheFactory).RefreshDHCPConfig(launcherPID, []nettypes.NetworkStatus{
+				{Interface: "net1", IPs: []string{"192.168.2.30"}},
+			})).To(Succeed())
+
+			Expect(readDHCPConfig().IP.String()).To(Equal("192.168.1.10/24"))
+		})
+	})
+})
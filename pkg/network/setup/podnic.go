@@ -22,6 +22,7 @@ package network
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"kubevirt.io/kubevirt/pkg/network/domainspec"
 
@@ -224,6 +225,13 @@ func (l *podNIC) PlugPhase1() error {
 		return errors.CreateCriticalNetworkError(err)
 	}
 
+	if l.vmiSpecIface.Bridge != nil && l.vmiSpecIface.Mirror != nil && !isMirrorExpired(l.vmi, l.vmiSpecIface, time.Now()) {
+		if err := l.setupMirror(); err != nil {
+			log.Log.Reason(err).Error("failed to mirror the pod interface traffic")
+			return errors.CreateCriticalNetworkError(err)
+		}
+	}
+
 	if err := l.setState(cache.PodIfaceNetworkPreparationFinished); err != nil {
 		log.Log.Reason(err).Error("failed setting state to PodIfaceNetworkPreparationFinished")
 		return errors.CreateCriticalNetworkError(err)
@@ -270,7 +278,8 @@ func (l *podNIC) newDHCPConfigurator() dhcpconfigurator.Configurator {
 			l.handler,
 			l.podInterfaceName,
 			l.vmi.Spec.Domain.Devices.Interfaces,
-			l.vmiSpecIface)
+			l.vmiSpecIface,
+			l.vmiSpecNetwork)
 	} else if l.vmiSpecIface.Masquerade != nil {
 		dhcpConfigurator = dhcpconfigurator.NewMasqueradeConfigurator(
 			generateInPodBridgeInterfaceName(l.podInterfaceName),
@@ -0,0 +1,67 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+// Package conditions sets the status conditions of the KubeVirt API types the same way in all controllers.
+// A condition is either added, or the existing condition of the same type is updated in place, which keeps
+// the order of the conditions stable. LastTransitionTime only changes with the status of the condition and
+// defaults to the current time. ObservedGeneration is set for the types whose status is a subresource, VMIs
+// don't have one and every update of their status increments their generation. An existing condition is left
+// untouched if neither its status, its reason, its message nor its observed generation change, so that
+// setting the same condition on every reconcile doesn't cause status updates.
+package conditions
+
+import (
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// condition holds the fields which all condition types of the KubeVirt API share
+type condition struct {
+	status             k8sv1.ConditionStatus
+	reason             string
+	message            string
+	lastProbeTime      metav1.Time
+	lastTransitionTime metav1.Time
+	observedGeneration int64
+}
+
+// merge returns the condition to store when desired is set on top of existing, which is nil if the
+// object doesn't have a condition of that type yet, and whether the stored condition changes
+func merge(existing *condition, desired condition, now metav1.Time) (condition, bool) {
+	if existing == nil {
+		if desired.lastTransitionTime.IsZero() {
+			desired.lastTransitionTime = now
+		}
+		return desired, true
+	}
+
+	if existing.status == desired.status &&
+		existing.reason == desired.reason &&
+		existing.message == desired.message &&
+		existing.observedGeneration == desired.observedGeneration {
+		return *existing, false
+	}
+
+	if existing.status == desired.status {
+		desired.lastTransitionTime = existing.lastTransitionTime
+	} else if desired.lastTransitionTime.IsZero() {
+		desired.lastTransitionTime = now
+	}
+	return desired, true
+}
@@ -0,0 +1,139 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+package conditions
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("Conditions", func() {
+	past := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+
+	Context("for VMIs", func() {
+		var vmi *v1.VirtualMachineInstance
+
+		BeforeEach(func() {
+			vmi = v1.NewMinimalVMI("testvmi")
+		})
+
+		It("should add a new condition with a transition time", func() {
+			Expect(SetVMICondition(vmi, v1.VirtualMachineInstanceCondition{
+				Type:   v1.VirtualMachineInstanceReady,
+				Status: k8sv1.ConditionTrue,
+			})).To(BeTrue())
+			Expect(vmi.Status.Conditions).To(HaveLen(1))
+			Expect(vmi.Status.Conditions[0].LastTransitionTime.IsZero()).To(BeFalse())
+			Expect(IsVMIConditionTrue(vmi, v1.VirtualMachineInstanceReady)).To(BeTrue())
+		})
+
+		It("should not change a condition which is already set", func() {
+			vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+				{Type: v1.VirtualMachineInstanceReady, Status: k8sv1.ConditionTrue, Reason: "r", LastTransitionTime: past},
+			}
+			Expect(SetVMICondition(vmi, v1.VirtualMachineInstanceCondition{
+				Type:          v1.VirtualMachineInstanceReady,
+				Status:        k8sv1.ConditionTrue,
+				Reason:        "r",
+				LastProbeTime: metav1.Now(),
+			})).To(BeFalse())
+			Expect(vmi.Status.Conditions[0].LastTransitionTime).To(Equal(past))
+			Expect(vmi.Status.Conditions[0].LastProbeTime.IsZero()).To(BeTrue())
+		})
+
+		It("should keep the transition time and the position if only the reason or the message change", func() {
+			vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+				{Type: v1.VirtualMachineInstancePaused, Status: k8sv1.ConditionTrue},
+				{Type: v1.VirtualMachineInstanceReady, Status: k8sv1.ConditionFalse, Reason: "r", LastTransitionTime: past},
+			}
+			Expect(SetVMICondition(vmi, v1.VirtualMachineInstanceCondition{
+				Type:    v1.VirtualMachineInstanceReady,
+				Status:  k8sv1.ConditionFalse,
+				Reason:  "other",
+				Message: "msg",
+			})).To(BeTrue())
+			Expect(vmi.Status.Conditions).To(HaveLen(2))
+			Expect(vmi.Status.Conditions[1].Reason).To(Equal("other"))
+			Expect(vmi.Status.Conditions[1].Message).To(Equal("msg"))
+			Expect(vmi.Status.Conditions[1].LastTransitionTime).To(Equal(past))
+		})
+
+		It("should update the transition time if the status changes", func() {
+			vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+				{Type: v1.VirtualMachineInstanceReady, Status: k8sv1.ConditionFalse, LastTransitionTime: past},
+			}
+			Expect(SetVMICondition(vmi, v1.VirtualMachineInstanceCondition{
+				Type:   v1.VirtualMachineInstanceReady,
+				Status: k8sv1.ConditionTrue,
+			})).To(BeTrue())
+			Expect(vmi.Status.Conditions[0].LastTransitionTime.After(past.Time)).To(BeTrue())
+		})
+
+		It("should remove a condition", func() {
+			vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+				{Type: v1.VirtualMachineInstancePaused, Status: k8sv1.ConditionTrue},
+				{Type: v1.VirtualMachineInstanceReady, Status: k8sv1.ConditionTrue},
+			}
+			Expect(RemoveVMICondition(vmi, v1.VirtualMachineInstancePaused)).To(BeTrue())
+			Expect(RemoveVMICondition(vmi, v1.VirtualMachineInstancePaused)).To(BeFalse())
+			Expect(vmi.Status.Conditions).To(HaveLen(1))
+			Expect(GetVMICondition(vmi, v1.VirtualMachineInstancePaused)).To(BeNil())
+			Expect(GetVMICondition(vmi, v1.VirtualMachineInstanceReady)).ToNot(BeNil())
+		})
+	})
+
+	Context("for VMs", func() {
+		It("should set the observed generation and update it on newer generations", func() {
+			vm := &v1.VirtualMachine{}
+			vm.Generation = 2
+			cond := v1.VirtualMachineCondition{Type: v1.VirtualMachineFailure, Status: k8sv1.ConditionTrue}
+
+			Expect(SetVMCondition(vm, cond)).To(BeTrue())
+			Expect(vm.Status.Conditions[0].ObservedGeneration).To(Equal(int64(2)))
+			transitionTime := vm.Status.Conditions[0].LastTransitionTime
+			Expect(SetVMCondition(vm, cond)).To(BeFalse())
+
+			vm.Generation = 3
+			Expect(SetVMCondition(vm, cond)).To(BeTrue())
+			Expect(vm.Status.Conditions[0].ObservedGeneration).To(Equal(int64(3)))
+			Expect(vm.Status.Conditions[0].LastTransitionTime).To(Equal(transitionTime))
+		})
+	})
+
+	Context("for migrations", func() {
+		It("should set the observed generation", func() {
+			migration := &v1.VirtualMachineInstanceMigration{}
+			migration.Generation = 5
+			Expect(SetMigrationCondition(migration, v1.VirtualMachineInstanceMigrationCondition{
+				Type:   v1.VirtualMachineInstanceMigrationAbortRequested,
+				Status: k8sv1.ConditionTrue,
+			})).To(BeTrue())
+			Expect(IsMigrationConditionTrue(migration, v1.VirtualMachineInstanceMigrationAbortRequested)).To(BeTrue())
+			Expect(migration.Status.Conditions[0].ObservedGeneration).To(Equal(int64(5)))
+			Expect(RemoveMigrationCondition(migration, v1.VirtualMachineInstanceMigrationAbortRequested)).To(BeTrue())
+			Expect(migration.Status.Conditions).To(BeEmpty())
+		})
+	})
+})
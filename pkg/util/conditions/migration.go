@@ -0,0 +1,106 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package conditions
+
+import (
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+// GetMigrationCondition returns a copy of the condition of the given type, or nil if the migration doesn't have it
+func GetMigrationCondition(migration *v1.VirtualMachineInstanceMigration, conditionType v1.VirtualMachineInstanceMigrationConditionType) *v1.VirtualMachineInstanceMigrationCondition {
+	if migration == nil {
+		return nil
+	}
+	for _, c := range migration.Status.Conditions {
+		if c.Type == conditionType {
+			return &c
+		}
+	}
+	return nil
+}
+
+// IsMigrationConditionTrue returns whether the migration has the condition of the given type with status true
+func IsMigrationConditionTrue(migration *v1.VirtualMachineInstanceMigration, conditionType v1.VirtualMachineInstanceMigrationConditionType) bool {
+	c := GetMigrationCondition(migration, conditionType)
+	return c != nil && c.Status == k8sv1.ConditionTrue
+}
+
+// SetMigrationCondition adds the condition to the migration or updates the existing condition of the same type,
+// and returns whether the conditions of the migration changed. The observed generation of the condition
+// is the generation of the migration.
+func SetMigrationCondition(migration *v1.VirtualMachineInstanceMigration, cond v1.VirtualMachineInstanceMigrationCondition) bool {
+	desired := fromMigrationCondition(cond)
+	desired.observedGeneration = migration.Generation
+	for i := range migration.Status.Conditions {
+		if migration.Status.Conditions[i].Type != cond.Type {
+			continue
+		}
+		existing := fromMigrationCondition(migration.Status.Conditions[i])
+		merged, changed := merge(&existing, desired, metav1.Now())
+		if changed {
+			migration.Status.Conditions[i] = toMigrationCondition(cond.Type, merged)
+		}
+		return changed
+	}
+	merged, _ := merge(nil, desired, metav1.Now())
+	migration.Status.Conditions = append(migration.Status.Conditions, toMigrationCondition(cond.Type, merged))
+	return true
+}
+
+// RemoveMigrationCondition removes the condition of the given type from the migration, and returns whether the migration had it
+func RemoveMigrationCondition(migration *v1.VirtualMachineInstanceMigration, conditionType v1.VirtualMachineInstanceMigrationConditionType) bool {
+	var conds []v1.VirtualMachineInstanceMigrationCondition
+	removed := false
+	for _, c := range migration.Status.Conditions {
+		if c.Type == conditionType {
+			removed = true
+			continue
+		}
+		conds = append(conds, c)
+	}
+	migration.Status.Conditions = conds
+	return removed
+}
+
+func fromMigrationCondition(c v1.VirtualMachineInstanceMigrationCondition) condition {
+	return condition{
+		status:             c.Status,
+		reason:             c.Reason,
+		message:            c.Message,
+		lastProbeTime:      c.LastProbeTime,
+		lastTransitionTime: c.LastTransitionTime,
+		observedGeneration: c.ObservedGeneration,
+	}
+}
+
+func toMigrationCondition(conditionType v1.VirtualMachineInstanceMigrationConditionType, c condition) v1.VirtualMachineInstanceMigrationCondition {
+	return v1.VirtualMachineInstanceMigrationCondition{
+		Type:               conditionType,
+		Status:             c.status,
+		Reason:             c.reason,
+		Message:            c.message,
+		LastProbeTime:      c.lastProbeTime,
+		LastTransitionTime: c.lastTransitionTime,
+		ObservedGeneration: c.observedGeneration,
+	}
+}
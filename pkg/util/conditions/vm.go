@@ -0,0 +1,106 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package conditions
+
+import (
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+// GetVMCondition returns a copy of the condition of the given type, or nil if the VM doesn't have it
+func GetVMCondition(vm *v1.VirtualMachine, conditionType v1.VirtualMachineConditionType) *v1.VirtualMachineCondition {
+	if vm == nil {
+		return nil
+	}
+	for _, c := range vm.Status.Conditions {
+		if c.Type == conditionType {
+			return &c
+		}
+	}
+	return nil
+}
+
+// IsVMConditionTrue returns whether the VM has the condition of the given type with status true
+func IsVMConditionTrue(vm *v1.VirtualMachine, conditionType v1.VirtualMachineConditionType) bool {
+	c := GetVMCondition(vm, conditionType)
+	return c != nil && c.Status == k8sv1.ConditionTrue
+}
+
+// SetVMCondition adds the condition to the VM or updates the existing condition of the same type,
+// and returns whether the conditions of the VM changed. The observed generation of the condition
+// is the generation of the VM.
+func SetVMCondition(vm *v1.VirtualMachine, cond v1.VirtualMachineCondition) bool {
+	desired := fromVMCondition(cond)
+	desired.observedGeneration = vm.Generation
+	for i := range vm.Status.Conditions {
+		if vm.Status.Conditions[i].Type != cond.Type {
+			continue
+		}
+		existing := fromVMCondition(vm.Status.Conditions[i])
+		merged, changed := merge(&existing, desired, metav1.Now())
+		if changed {
+			vm.Status.Conditions[i] = toVMCondition(cond.Type, merged)
+		}
+		return changed
+	}
+	merged, _ := merge(nil, desired, metav1.Now())
+	vm.Status.Conditions = append(vm.Status.Conditions, toVMCondition(cond.Type, merged))
+	return true
+}
+
+// RemoveVMCondition removes the condition of the given type from the VM, and returns whether the VM had it
+func RemoveVMCondition(vm *v1.VirtualMachine, conditionType v1.VirtualMachineConditionType) bool {
+	var conds []v1.VirtualMachineCondition
+	removed := false
+	for _, c := range vm.Status.Conditions {
+		if c.Type == conditionType {
+			removed = true
+			continue
+		}
+		conds = append(conds, c)
+	}
+	vm.Status.Conditions = conds
+	return removed
+}
+
+func fromVMCondition(c v1.VirtualMachineCondition) condition {
+	return condition{
+		status:             c.Status,
+		reason:             c.Reason,
+		message:            c.Message,
+		lastProbeTime:      c.LastProbeTime,
+		lastTransitionTime: c.LastTransitionTime,
+		observedGeneration: c.ObservedGeneration,
+	}
+}
+
+func toVMCondition(conditionType v1.VirtualMachineConditionType, c condition) v1.VirtualMachineCondition {
+	return v1.VirtualMachineCondition{
+		Type:               conditionType,
+		Status:             c.status,
+		Reason:             c.reason,
+		Message:            c.message,
+		LastProbeTime:      c.lastProbeTime,
+		LastTransitionTime: c.lastTransitionTime,
+		ObservedGeneration: c.observedGeneration,
+	}
+}
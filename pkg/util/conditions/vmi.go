@@ -0,0 +1,102 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package conditions
+
+import (
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+// GetVMICondition returns a copy of the condition of the given type, or nil if the VMI doesn't have it
+func GetVMICondition(vmi *v1.VirtualMachineInstance, conditionType v1.VirtualMachineInstanceConditionType) *v1.VirtualMachineInstanceCondition {
+	if vmi == nil {
+		return nil
+	}
+	for _, c := range vmi.Status.Conditions {
+		if c.Type == conditionType {
+			return &c
+		}
+	}
+	return nil
+}
+
+// IsVMIConditionTrue returns whether the VMI has the condition of the given type with status true
+func IsVMIConditionTrue(vmi *v1.VirtualMachineInstance, conditionType v1.VirtualMachineInstanceConditionType) bool {
+	c := GetVMICondition(vmi, conditionType)
+	return c != nil && c.Status == k8sv1.ConditionTrue
+}
+
+// SetVMICondition adds the condition to the VMI or updates the existing condition of the same type,
+// and returns whether the conditions of the VMI changed
+func SetVMICondition(vmi *v1.VirtualMachineInstance, cond v1.VirtualMachineInstanceCondition) bool {
+	desired := fromVMICondition(cond)
+	for i := range vmi.Status.Conditions {
+		if vmi.Status.Conditions[i].Type != cond.Type {
+			continue
+		}
+		existing := fromVMICondition(vmi.Status.Conditions[i])
+		merged, changed := merge(&existing, desired, metav1.Now())
+		if changed {
+			vmi.Status.Conditions[i] = toVMICondition(cond.Type, merged)
+		}
+		return changed
+	}
+	merged, _ := merge(nil, desired, metav1.Now())
+	vmi.Status.Conditions = append(vmi.Status.Conditions, toVMICondition(cond.Type, merged))
+	return true
+}
+
+// RemoveVMICondition removes the condition of the given type from the VMI, and returns whether the VMI had it
+func RemoveVMICondition(vmi *v1.VirtualMachineInstance, conditionType v1.VirtualMachineInstanceConditionType) bool {
+	var conds []v1.VirtualMachineInstanceCondition
+	removed := false
+	for _, c := range vmi.Status.Conditions {
+		if c.Type == conditionType {
+			removed = true
+			continue
+		}
+		conds = append(conds, c)
+	}
+	vmi.Status.Conditions = conds
+	return removed
+}
+
+func fromVMICondition(c v1.VirtualMachineInstanceCondition) condition {
+	return condition{
+		status:             c.Status,
+		reason:             c.Reason,
+		message:            c.Message,
+		lastProbeTime:      c.LastProbeTime,
+		lastTransitionTime: c.LastTransitionTime,
+	}
+}
+
+func toVMICondition(conditionType v1.VirtualMachineInstanceConditionType, c condition) v1.VirtualMachineInstanceCondition {
+	return v1.VirtualMachineInstanceCondition{
+		Type:               conditionType,
+		Status:             c.status,
+		Reason:             c.reason,
+		Message:            c.message,
+		LastProbeTime:      c.lastProbeTime,
+		LastTransitionTime: c.lastTransitionTime,
+	}
+}
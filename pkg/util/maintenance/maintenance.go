@@ -0,0 +1,161 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package maintenance
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+)
+
+const maxPeriodDuration = 7 * 24 * time.Hour
+
+func ListWindows(informer cache.SharedIndexInformer) []*v1.MaintenanceWindow {
+	objs := informer.GetStore().List()
+	windows := []*v1.MaintenanceWindow{}
+	for _, obj := range objs {
+		windows = append(windows, obj.(*v1.MaintenanceWindow))
+	}
+	return windows
+}
+
+// Allowed returns true if the action may run against the VMI at the given time.
+// Otherwise it returns the time until the earliest of the windows restricting the
+// action opens, which is zero if none of them has a valid period.
+func Allowed(windows []*v1.MaintenanceWindow, vmi *v1.VirtualMachineInstance, action v1.MaintenanceAction, now time.Time) (bool, time.Duration) {
+	restricted := false
+	var wait time.Duration
+	for _, window := range windows {
+		if !restricts(window, action) || !selects(window, vmi) {
+			continue
+		}
+		restricted = true
+		for _, period := range window.Spec.Periods {
+			open, opensIn, err := periodState(period, now)
+			if err != nil {
+				log.Log.Reason(err).Errorf("Ignoring invalid period of maintenance window %s", window.Name)
+				continue
+			}
+			if open {
+				return true, 0
+			}
+			if wait == 0 || opensIn < wait {
+				wait = opensIn
+			}
+		}
+	}
+	if !restricted {
+		return true, 0
+	}
+	return false, wait
+}
+
+func restricts(window *v1.MaintenanceWindow, action v1.MaintenanceAction) bool {
+	if len(window.Spec.Actions) == 0 {
+		return true
+	}
+	for _, a := range window.Spec.Actions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+func selects(window *v1.MaintenanceWindow, vmi *v1.VirtualMachineInstance) bool {
+	if len(window.Spec.Namespaces) > 0 {
+		found := false
+		for _, namespace := range window.Spec.Namespaces {
+			if namespace == vmi.Namespace {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if window.Spec.Selector == nil {
+		return true
+	}
+	selector, err := metav1.LabelSelectorAsSelector(window.Spec.Selector)
+	if err != nil {
+		log.Log.Reason(err).Errorf("Ignoring maintenance window %s with an invalid selector", window.Name)
+		return false
+	}
+	return selector.Matches(labels.Set(vmi.Labels))
+}
+
+// periodState returns true if the period is open at the given time, otherwise
+// it returns the time until it opens next.
+func periodState(period v1.MaintenancePeriod, now time.Time) (bool, time.Duration, error) {
+	start, err := time.Parse("15:04", period.Start)
+	if err != nil {
+		return false, 0, fmt.Errorf("start %q is not formatted as HH:MM", period.Start)
+	}
+	duration := period.Duration.Duration
+	if duration <= 0 || duration > maxPeriodDuration {
+		return false, 0, fmt.Errorf("duration %s is not between zero and a week", duration)
+	}
+	days := map[time.Weekday]bool{}
+	for _, name := range period.Days {
+		day, err := parseWeekday(name)
+		if err != nil {
+			return false, 0, err
+		}
+		days[day] = true
+	}
+
+	now = now.UTC()
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	offset := time.Duration(start.Hour())*time.Hour + time.Duration(start.Minute())*time.Minute
+	// A period lasts at most a week, so only the periods which began within the
+	// last week can still be open and one of the next week opens next.
+	for i := -7; i <= 7; i++ {
+		day := midnight.AddDate(0, 0, i)
+		if len(days) > 0 && !days[day.Weekday()] {
+			continue
+		}
+		begin := day.Add(offset)
+		if begin.After(now) {
+			return false, begin.Sub(now), nil
+		}
+		if now.Before(begin.Add(duration)) {
+			return true, 0, nil
+		}
+	}
+	return false, 0, fmt.Errorf("period never opens")
+}
+
+func parseWeekday(name string) (time.Weekday, error) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(day.String(), name) {
+			return day, nil
+		}
+	}
+	return 0, fmt.Errorf("%q is not a day of the week", name)
+}
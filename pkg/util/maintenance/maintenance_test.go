@@ -0,0 +1,134 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package maintenance
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("Maintenance windows", func() {
+
+	// 2021-06-05 is a Saturday
+	saturday := func(clock string) time.Time {
+		t, err := time.Parse(time.RFC3339, "2021-06-05T"+clock+":00Z")
+		Expect(err).ToNot(HaveOccurred())
+		return t
+	}
+
+	newVMI := func() *v1.VirtualMachineInstance {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Namespace = "default"
+		vmi.Labels = map[string]string{"tier": "db"}
+		return vmi
+	}
+
+	newWindow := func(periods ...v1.MaintenancePeriod) *v1.MaintenanceWindow {
+		return &v1.MaintenanceWindow{
+			ObjectMeta: metav1.ObjectMeta{Name: "window"},
+			Spec:       v1.MaintenanceWindowSpec{Periods: periods},
+		}
+	}
+
+	weekend := v1.MaintenancePeriod{
+		Days:     []string{"Saturday", "sunday"},
+		Start:    "22:00",
+		Duration: metav1.Duration{Duration: 4 * time.Hour},
+	}
+
+	It("should not restrict VMIs without windows", func() {
+		allowed, wait := Allowed(nil, newVMI(), v1.MaintenanceActionEvacuation, saturday("12:00"))
+		Expect(allowed).To(BeTrue())
+		Expect(wait).To(BeZero())
+	})
+
+	table.DescribeTable("should restrict actions to the periods", func(now time.Time, expectedAllowed bool, expectedWait time.Duration) {
+		allowed, wait := Allowed([]*v1.MaintenanceWindow{newWindow(weekend)}, newVMI(), v1.MaintenanceActionEvacuation, now)
+		Expect(allowed).To(Equal(expectedAllowed))
+		Expect(wait).To(Equal(expectedWait))
+	},
+		table.Entry("before the period", saturday("12:00"), false, 10*time.Hour),
+		table.Entry("at the start of the period", saturday("22:00"), true, time.Duration(0)),
+		table.Entry("during the period", saturday("23:59"), true, time.Duration(0)),
+		table.Entry("during the period on the next day", saturday("22:00").Add(3*time.Hour), true, time.Duration(0)),
+		table.Entry("after the period", saturday("22:00").Add(4*time.Hour), false, 20*time.Hour),
+		table.Entry("after the last period of the week", saturday("22:00").Add(28*time.Hour), false, 5*24*time.Hour+20*time.Hour),
+	)
+
+	It("should allow the action if any period is open", func() {
+		daily := v1.MaintenancePeriod{Start: "11:30", Duration: metav1.Duration{Duration: time.Hour}}
+		allowed, _ := Allowed([]*v1.MaintenanceWindow{newWindow(weekend, daily)}, newVMI(), v1.MaintenanceActionEvacuation, saturday("12:00"))
+		Expect(allowed).To(BeTrue())
+	})
+
+	It("should wait for the earliest window", func() {
+		later := newWindow(v1.MaintenancePeriod{Start: "20:00", Duration: metav1.Duration{Duration: time.Hour}})
+		allowed, wait := Allowed([]*v1.MaintenanceWindow{newWindow(weekend), later}, newVMI(), v1.MaintenanceActionEvacuation, saturday("12:00"))
+		Expect(allowed).To(BeFalse())
+		Expect(wait).To(Equal(8 * time.Hour))
+	})
+
+	table.DescribeTable("should only restrict the selected VMIs", func(modify func(*v1.MaintenanceWindow), expectedAllowed bool) {
+		window := newWindow(weekend)
+		modify(window)
+		allowed, _ := Allowed([]*v1.MaintenanceWindow{window}, newVMI(), v1.MaintenanceActionWorkloadUpdate, saturday("12:00"))
+		Expect(allowed).To(Equal(expectedAllowed))
+	},
+		table.Entry("with everything selected", func(*v1.MaintenanceWindow) {}, false),
+		table.Entry("in a selected namespace", func(w *v1.MaintenanceWindow) {
+			w.Spec.Namespaces = []string{"other", "default"}
+		}, false),
+		table.Entry("in another namespace", func(w *v1.MaintenanceWindow) {
+			w.Spec.Namespaces = []string{"other"}
+		}, true),
+		table.Entry("with matching labels", func(w *v1.MaintenanceWindow) {
+			w.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "db"}}
+		}, false),
+		table.Entry("with other labels", func(w *v1.MaintenanceWindow) {
+			w.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "web"}}
+		}, true),
+		table.Entry("with an invalid selector", func(w *v1.MaintenanceWindow) {
+			w.Spec.Selector = &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tier", Operator: "Unknown"}}}
+		}, true),
+		table.Entry("for the action", func(w *v1.MaintenanceWindow) {
+			w.Spec.Actions = []v1.MaintenanceAction{v1.MaintenanceActionEvacuation, v1.MaintenanceActionWorkloadUpdate}
+		}, false),
+		table.Entry("for other actions", func(w *v1.MaintenanceWindow) {
+			w.Spec.Actions = []v1.MaintenanceAction{v1.MaintenanceActionEvacuation}
+		}, true),
+	)
+
+	table.DescribeTable("should keep restricting the action with invalid periods", func(period v1.MaintenancePeriod) {
+		allowed, wait := Allowed([]*v1.MaintenanceWindow{newWindow(period)}, newVMI(), v1.MaintenanceActionEvacuation, saturday("12:00"))
+		Expect(allowed).To(BeFalse())
+		Expect(wait).To(BeZero())
+	},
+		table.Entry("with an invalid start", v1.MaintenancePeriod{Start: "25:00", Duration: metav1.Duration{Duration: time.Hour}}),
+		table.Entry("without a duration", v1.MaintenancePeriod{Start: "10:00"}),
+		table.Entry("with a duration over a week", v1.MaintenancePeriod{Start: "10:00", Duration: metav1.Duration{Duration: 8 * 24 * time.Hour}}),
+		table.Entry("with an unknown day", v1.MaintenancePeriod{Days: []string{"Caturday"}, Start: "10:00", Duration: metav1.Duration{Duration: time.Hour}}),
+	)
+})
@@ -1,7 +1,13 @@
 package migrations
 
 import (
+	"sort"
+	"strings"
+	"time"
+
+	k8sv1 "k8s.io/api/core/v1"
 	v12 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/cache"
 
 	v1 "kubevirt.io/client-go/api/v1"
@@ -57,3 +63,88 @@ func MigrationFailed(vmi *v1.VirtualMachineInstance) bool {
 
 	return false
 }
+
+const (
+	// the period automated migrations are held back after the first failure, it doubles with every further failure
+	migrationBackoffBase = 20 * time.Second
+	migrationBackoffMax  = 10 * time.Minute
+)
+
+// NextMigrationBackoff returns the backoff of a VMI after the migration with the given UID failed
+func NextMigrationBackoff(backoff *v1.VirtualMachineInstanceMigrationBackoff, migrationUID types.UID, now v12.Time) *v1.VirtualMachineInstanceMigrationBackoff {
+	next := &v1.VirtualMachineInstanceMigrationBackoff{}
+	if backoff != nil {
+		next = backoff.DeepCopy()
+	}
+	next.FailureCount++
+	next.LastFailedMigrationUID = migrationUID
+	next.LastFailureTimestamp = &now
+
+	delay := migrationBackoffMax
+	if next.FailureCount <= 16 {
+		delay = migrationBackoffBase * time.Duration(1<<uint(next.FailureCount-1))
+		if delay > migrationBackoffMax {
+			delay = migrationBackoffMax
+		}
+	}
+	nextAttempt := v12.NewTime(now.Add(delay))
+	next.NextAttemptTimestamp = &nextAttempt
+	return next
+}
+
+// MigrationBackoffRemaining returns how long automated migrations of the VMI are still held back
+func MigrationBackoffRemaining(vmi *v1.VirtualMachineInstance) time.Duration {
+	backoff := vmi.Status.MigrationBackoff
+	if backoff == nil || backoff.NextAttemptTimestamp == nil {
+		return 0
+	}
+	remaining := time.Until(backoff.NextAttemptTimestamp.Time)
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// HostPassthroughPolicy returns the policy which applies to migrations of the VMI,
+// or an empty policy if the VMI does not use the host-passthrough CPU mode
+func HostPassthroughPolicy(vmi *v1.VirtualMachineInstance, config *v1.MigrationConfiguration) v1.HostPassthroughMigrationPolicy {
+	if cpu := vmi.Spec.Domain.CPU; cpu == nil || cpu.Model != v1.CPUModeHostPassthrough {
+		return ""
+	}
+	if config == nil || config.HostPassthroughPolicy == nil {
+		return v1.HostPassthroughMigrationAllow
+	}
+	return *config.HostPassthroughPolicy
+}
+
+// HostCPUNodeSelector returns the node labels a target node needs to run a host-passthrough VMI
+// migrated away from the given node: the same CPU vendor and host CPU model, and all CPU features
+// of the source node, including the ones the host CPU model requires on top of its named model.
+// The node labeller does not expose the exact host CPU, the labels are the closest approximation.
+func HostCPUNodeSelector(node *k8sv1.Node) map[string]string {
+	selector := map[string]string{}
+	for key, value := range node.Labels {
+		switch {
+		case strings.HasPrefix(key, v1.HostModelCPULabel), strings.HasPrefix(key, v1.CPUModelVendorLabel):
+			selector[key] = value
+		case strings.HasPrefix(key, v1.CPUFeatureLabel), strings.HasPrefix(key, v1.HostModelRequiredFeaturesLabel):
+			if value == "true" {
+				selector[key] = value
+			}
+		}
+	}
+	return selector
+}
+
+// MissingHostCPULabels returns the host CPU model and CPU feature labels of the source node the target node lacks.
+// Nothing is reported if the source node was not labelled by the node labeller.
+func MissingHostCPULabels(source *k8sv1.Node, target *k8sv1.Node) []string {
+	var missing []string
+	for key, value := range HostCPUNodeSelector(source) {
+		if target.Labels[key] != value {
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
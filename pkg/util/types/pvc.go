@@ -57,6 +57,24 @@ func HasSharedAccessMode(accessModes []k8sv1.PersistentVolumeAccessMode) bool {
 	return false
 }
 
+// LocalVolumeNode returns the hostname of the node a PersistentVolume is local to. That's the case, if the
+// node affinity of the PersistentVolume only allows a single hostname, like for local volumes.
+func LocalVolumeNode(pv *k8sv1.PersistentVolume) string {
+	if pv.Spec.NodeAffinity == nil || pv.Spec.NodeAffinity.Required == nil {
+		return ""
+	}
+	terms := pv.Spec.NodeAffinity.Required.NodeSelectorTerms
+	if len(terms) != 1 {
+		return ""
+	}
+	for _, requirement := range terms[0].MatchExpressions {
+		if requirement.Key == k8sv1.LabelHostname && requirement.Operator == k8sv1.NodeSelectorOpIn && len(requirement.Values) == 1 {
+			return requirement.Values[0]
+		}
+	}
+	return ""
+}
+
 func IsPreallocated(annotations map[string]string) bool {
 	for a, value := range annotations {
 		if strings.Contains(a, "/storage.preallocation") && value == "true" {
@@ -75,6 +93,8 @@ func PVCNameFromVirtVolume(volume *virtv1.Volume) string {
 		return volume.DataVolume.Name
 	} else if volume.PersistentVolumeClaim != nil {
 		return volume.PersistentVolumeClaim.ClaimName
+	} else if volume.MemoryDump != nil {
+		return volume.MemoryDump.ClaimName
 	}
 	return ""
 }
@@ -98,4 +98,37 @@ var _ = Describe("PVC utils test", func() {
 		})
 	})
 
+	Context("LocalVolumeNode", func() {
+		newPV := func(terms ...kubev1.NodeSelectorTerm) *kubev1.PersistentVolume {
+			return &kubev1.PersistentVolume{
+				Spec: kubev1.PersistentVolumeSpec{
+					NodeAffinity: &kubev1.VolumeNodeAffinity{
+						Required: &kubev1.NodeSelector{NodeSelectorTerms: terms},
+					},
+				},
+			}
+		}
+		hostnameTerm := func(operator kubev1.NodeSelectorOperator, hostnames ...string) kubev1.NodeSelectorTerm {
+			return kubev1.NodeSelectorTerm{
+				MatchExpressions: []kubev1.NodeSelectorRequirement{
+					{Key: kubev1.LabelHostname, Operator: operator, Values: hostnames},
+				},
+			}
+		}
+
+		It("should return the node of a local volume", func() {
+			Expect(LocalVolumeNode(newPV(hostnameTerm(kubev1.NodeSelectorOpIn, "node01")))).To(Equal("node01"))
+		})
+
+		It("should ignore volumes without node affinity", func() {
+			Expect(LocalVolumeNode(&kubev1.PersistentVolume{})).To(BeEmpty())
+		})
+
+		It("should ignore volumes which are accessible from multiple nodes", func() {
+			Expect(LocalVolumeNode(newPV(hostnameTerm(kubev1.NodeSelectorOpIn, "node01", "node02")))).To(BeEmpty())
+			Expect(LocalVolumeNode(newPV(hostnameTerm(kubev1.NodeSelectorOpIn, "node01"), hostnameTerm(kubev1.NodeSelectorOpIn, "node02")))).To(BeEmpty())
+			Expect(LocalVolumeNode(newPV(hostnameTerm(kubev1.NodeSelectorOpNotIn, "node01")))).To(BeEmpty())
+		})
+	})
+
 })
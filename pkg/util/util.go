@@ -13,6 +13,7 @@ const RequestHeaderClientCAFileKey = "requestheader-client-ca-file"
 const VirtShareDir = "/var/run/kubevirt"
 const VirtPrivateDir = "/var/run/kubevirt-private"
 const VirtLibDir = "/var/lib/kubevirt"
+const VirtChannelSocketsDir = "/var/run/kubevirt-channels"
 const KubeletPodsDir = "/var/lib/kubelet/pods"
 const HostRootMount = "/proc/1/root/"
 const CPUManagerOS3Path = HostRootMount + "var/lib/origin/openshift.local.volumes/cpu_manager_state"
@@ -141,3 +142,18 @@ func IsVhostuserVmiSpec(spec *v1.VirtualMachineInstanceSpec) bool {
 	}
 	return false
 }
+
+// Check if a VMI spec connects a bridge interface to a secondary multus network
+func HasSecondaryBridgeInterfaces(spec *v1.VirtualMachineInstanceSpec) bool {
+	for _, network := range spec.Networks {
+		if network.Multus == nil || network.Multus.Default {
+			continue
+		}
+		for _, iface := range spec.Domain.Devices.Interfaces {
+			if iface.Name == network.Name && iface.Bridge != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
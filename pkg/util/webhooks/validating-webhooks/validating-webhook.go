@@ -4,16 +4,20 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	admissionv1 "k8s.io/api/admission/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
+	admissionmetrics "kubevirt.io/kubevirt/pkg/monitoring/admission"
 	"kubevirt.io/kubevirt/pkg/util/webhooks"
 
 	"kubevirt.io/client-go/log"
 )
 
+// Admitter admits the reviews of a validating webhook. Admitters must not have side
+// effects for requests webhooks.IsDryRun reports, see the SideEffects of their webhook.
 type Admitter interface {
 	Admit(*admissionv1.AdmissionReview) *admissionv1.AdmissionResponse
 }
@@ -58,23 +62,15 @@ func NewAdmissionResponse(causes []v1.StatusCause) *admissionv1.AdmissionRespons
 func Serve(resp http.ResponseWriter, req *http.Request, admitter Admitter) {
 	review, err := webhooks.GetAdmissionReview(req)
 	if err != nil {
+		admissionmetrics.ObserveDecodeFailure(req.URL.Path)
 		resp.WriteHeader(http.StatusBadRequest)
 		return
 	}
 
-	response := admissionv1.AdmissionReview{
-		TypeMeta: v1.TypeMeta{
-			// match the request version to be
-			// backwards compatible with v1beta1
-			APIVersion: review.APIVersion,
-			Kind:       "AdmissionReview",
-		},
-	}
+	start := time.Now()
 	reviewResponse := admitter.Admit(review)
-	if reviewResponse != nil {
-		response.Response = reviewResponse
-		response.Response.UID = review.Request.UID
-	}
+	admissionmetrics.ObserveAdmission(req.URL.Path, review.Request, reviewResponse, time.Since(start))
+	response := webhooks.NewAdmissionReviewResponse(review, reviewResponse)
 	// reset the Object and OldObject, they are not needed in admitter response.
 	review.Request.Object = runtime.RawExtension{}
 	review.Request.OldObject = runtime.RawExtension{}
@@ -85,6 +81,7 @@ func Serve(resp http.ResponseWriter, req *http.Request, admitter Admitter) {
 		resp.WriteHeader(http.StatusBadRequest)
 		return
 	}
+	resp.Header().Set("Content-Type", "application/json")
 	if _, err := resp.Write(responseBytes); err != nil {
 		log.Log.Reason(err).Errorf("failed to write webhook response")
 		resp.WriteHeader(http.StatusBadRequest)
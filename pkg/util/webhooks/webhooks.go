@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"mime"
 	"net/http"
 
 	admissionv1 "k8s.io/api/admission/v1"
@@ -16,8 +17,13 @@ import (
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
 )
 
-// GetAdmissionReview
-func GetAdmissionReview(r *http.Request) (*admissionv1.AdmissionReview, error) {
+// admissionReviewV1beta1 is the legacy AdmissionReview version. Its types are identical to
+// admission/v1, reviews of both versions are decoded into admission/v1 and answered in the
+// version they were sent with.
+const admissionReviewV1beta1 = "admission.k8s.io/v1beta1"
+
+// ReadJSONRequestBody returns the body of a review the API server sent, which has to be of the application/json content type
+func ReadJSONRequestBody(r *http.Request) ([]byte, error) {
 	var body []byte
 	if r.Body != nil {
 		if data, err := ioutil.ReadAll(r.Body); err == nil {
@@ -27,13 +33,55 @@ func GetAdmissionReview(r *http.Request) (*admissionv1.AdmissionReview, error) {
 
 	// verify the content type is accurate
 	contentType := r.Header.Get("Content-Type")
-	if contentType != "application/json" {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType != "application/json" {
 		return nil, fmt.Errorf("contentType=%s, expect application/json", contentType)
 	}
+	return body, nil
+}
+
+// GetAdmissionReview decodes an admission/v1 or admission/v1beta1 AdmissionReview
+func GetAdmissionReview(r *http.Request) (*admissionv1.AdmissionReview, error) {
+	body, err := ReadJSONRequestBody(r)
+	if err != nil {
+		return nil, err
+	}
 
 	ar := &admissionv1.AdmissionReview{}
-	err := json.Unmarshal(body, ar)
-	return ar, err
+	if err := json.Unmarshal(body, ar); err != nil {
+		return nil, err
+	}
+	if ar.Kind != "AdmissionReview" ||
+		(ar.APIVersion != admissionv1.SchemeGroupVersion.String() && ar.APIVersion != admissionReviewV1beta1) {
+		return nil, fmt.Errorf("unsupported review %s %s, expect AdmissionReview of %s or %s", ar.APIVersion, ar.Kind, admissionv1.SchemeGroupVersion.String(), admissionReviewV1beta1)
+	}
+	if ar.Request == nil {
+		return nil, fmt.Errorf("AdmissionReview without a request")
+	}
+	return ar, nil
+}
+
+// NewAdmissionReviewResponse wraps the response to a review into an AdmissionReview of the
+// version the review was sent with, the API server rejects answers in any other version.
+func NewAdmissionReviewResponse(review *admissionv1.AdmissionReview, response *admissionv1.AdmissionResponse) *admissionv1.AdmissionReview {
+	reviewResponse := &admissionv1.AdmissionReview{
+		TypeMeta: v1.TypeMeta{
+			APIVersion: review.APIVersion,
+			Kind:       "AdmissionReview",
+		},
+	}
+	if response != nil {
+		reviewResponse.Response = response
+		reviewResponse.Response.UID = review.Request.UID
+	}
+	return reviewResponse
+}
+
+// IsDryRun returns true if the changes of the request are not going to be persisted.
+// Webhooks with side effects are registered with the NoneOnDryRun side effect class
+// and must skip them for such requests, all other webhooks must not have any.
+func IsDryRun(request *admissionv1.AdmissionRequest) bool {
+	return request != nil && request.DryRun != nil && *request.DryRun
 }
 
 // ToAdmissionResponseError
@@ -48,6 +96,19 @@ func ToAdmissionResponseError(err error) *admissionv1.AdmissionResponse {
 	}
 }
 
+// ToAdmissionResponseInternalError reports an error which was not caused by the request, so that the client retries it
+func ToAdmissionResponseInternalError(err error) *admissionv1.AdmissionResponse {
+	log.Log.Reason(err).Error("admission internal error")
+
+	return &admissionv1.AdmissionResponse{
+		Result: &v1.Status{
+			Message: err.Error(),
+			Reason:  v1.StatusReasonInternalError,
+			Code:    http.StatusInternalServerError,
+		},
+	}
+}
+
 func ToAdmissionResponse(causes []v1.StatusCause) *admissionv1.AdmissionResponse {
 	log.Log.Infof("rejected vmi admission")
 
@@ -72,6 +133,16 @@ func ToAdmissionResponse(causes []v1.StatusCause) *admissionv1.AdmissionResponse
 	}
 }
 
+// WithWarnings attaches non-blocking warnings to an admission response. The warnings are shown to the
+// client whether the request is allowed or denied, they can surface soft problems without failing the request.
+func WithWarnings(response *admissionv1.AdmissionResponse, warnings []string) *admissionv1.AdmissionResponse {
+	if response == nil || len(warnings) == 0 {
+		return response
+	}
+	response.Warnings = append(response.Warnings, warnings...)
+	return response
+}
+
 func ValidationErrorsToAdmissionResponse(errs []error) *admissionv1.AdmissionResponse {
 	var causes []v1.StatusCause
 	for _, e := range errs {
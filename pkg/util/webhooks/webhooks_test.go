@@ -0,0 +1,98 @@
+package webhooks_test
+
+import (
+	"bytes"
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"kubevirt.io/kubevirt/pkg/util/webhooks"
+)
+
+var _ = Describe("Admission responses", func() {
+
+	Context("with warnings", func() {
+		It("should attach the warnings to an allowing response", func() {
+			response := webhooks.WithWarnings(&admissionv1.AdmissionResponse{Allowed: true}, []string{"first", "second"})
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Warnings).To(Equal([]string{"first", "second"}))
+		})
+
+		It("should attach the warnings to a denying response", func() {
+			response := webhooks.WithWarnings(webhooks.ToAdmissionResponse([]v1.StatusCause{{Message: "invalid"}}), []string{"warning"})
+			Expect(response.Allowed).To(BeFalse())
+			Expect(response.Result.Message).To(Equal("invalid"))
+			Expect(response.Warnings).To(Equal([]string{"warning"}))
+		})
+
+		It("should keep the warnings which are already on the response", func() {
+			response := webhooks.WithWarnings(&admissionv1.AdmissionResponse{Warnings: []string{"first"}}, []string{"second"})
+			Expect(response.Warnings).To(Equal([]string{"first", "second"}))
+		})
+
+		It("should leave nil responses alone", func() {
+			Expect(webhooks.WithWarnings(nil, []string{"warning"})).To(BeNil())
+		})
+	})
+
+	table.DescribeTable("should detect dry-run requests", func(request *admissionv1.AdmissionRequest, dryRun bool) {
+		Expect(webhooks.IsDryRun(request)).To(Equal(dryRun))
+	},
+		table.Entry("with dryRun set", &admissionv1.AdmissionRequest{DryRun: &[]bool{true}[0]}, true),
+		table.Entry("with dryRun unset", &admissionv1.AdmissionRequest{DryRun: &[]bool{false}[0]}, false),
+		table.Entry("without dryRun", &admissionv1.AdmissionRequest{}, false),
+		table.Entry("without a request", nil, false),
+	)
+
+	Context("with AdmissionReview versions", func() {
+		newRequest := func(contentType, body string) *http.Request {
+			req, err := http.NewRequest(http.MethodPost, "/", bytes.NewBufferString(body))
+			Expect(err).ToNot(HaveOccurred())
+			req.Header.Set("Content-Type", contentType)
+			return req
+		}
+
+		table.DescribeTable("should decode supported reviews", func(contentType, apiVersion string) {
+			req := newRequest(contentType, `{"apiVersion":"`+apiVersion+`","kind":"AdmissionReview","request":{"uid":"1234"}}`)
+			review, err := webhooks.GetAdmissionReview(req)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(review.APIVersion).To(Equal(apiVersion))
+			Expect(review.Request.UID).To(Equal(types.UID("1234")))
+		},
+			table.Entry("of admission/v1", "application/json", "admission.k8s.io/v1"),
+			table.Entry("of admission/v1beta1", "application/json", "admission.k8s.io/v1beta1"),
+			table.Entry("with a charset", "application/json; charset=utf-8", "admission.k8s.io/v1"),
+		)
+
+		table.DescribeTable("should reject", func(contentType, body string) {
+			_, err := webhooks.GetAdmissionReview(newRequest(contentType, body))
+			Expect(err).To(HaveOccurred())
+		},
+			table.Entry("other content types", "application/yaml", `{"apiVersion":"admission.k8s.io/v1","kind":"AdmissionReview","request":{}}`),
+			table.Entry("unknown versions", "application/json", `{"apiVersion":"admission.k8s.io/v2","kind":"AdmissionReview","request":{}}`),
+			table.Entry("other kinds", "application/json", `{"apiVersion":"admission.k8s.io/v1","kind":"Pod","request":{}}`),
+			table.Entry("reviews without a request", "application/json", `{"apiVersion":"admission.k8s.io/v1","kind":"AdmissionReview"}`),
+			table.Entry("invalid json", "application/json", `{"apiVersion":`),
+		)
+
+		table.DescribeTable("should answer in the version of the review", func(apiVersion string) {
+			review := &admissionv1.AdmissionReview{
+				TypeMeta: v1.TypeMeta{APIVersion: apiVersion, Kind: "AdmissionReview"},
+				Request:  &admissionv1.AdmissionRequest{UID: "1234"},
+			}
+			response := webhooks.NewAdmissionReviewResponse(review, &admissionv1.AdmissionResponse{Allowed: true})
+			Expect(response.APIVersion).To(Equal(apiVersion))
+			Expect(response.Kind).To(Equal("AdmissionReview"))
+			Expect(response.Response.UID).To(Equal(types.UID("1234")))
+			Expect(response.Response.Allowed).To(BeTrue())
+		},
+			table.Entry("admission/v1", "admission.k8s.io/v1"),
+			table.Entry("admission/v1beta1", "admission.k8s.io/v1beta1"),
+		)
+	})
+})
@@ -34,11 +34,16 @@ import (
 
 	restful "github.com/emicklei/go-restful"
 	"github.com/go-openapi/spec"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	flag "github.com/spf13/pflag"
+	k8sv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+	k8coresv1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 	certificate2 "k8s.io/client-go/util/certificate"
 	"k8s.io/client-go/util/flowcontrol"
 	aggregatorclient "k8s.io/kube-aggregator/pkg/client/clientset_generated/clientset"
@@ -58,8 +63,10 @@ import (
 	"kubevirt.io/kubevirt/pkg/util"
 	"kubevirt.io/kubevirt/pkg/util/openapi"
 	webhooksutils "kubevirt.io/kubevirt/pkg/util/webhooks"
+	"kubevirt.io/kubevirt/pkg/virt-api/conversion"
 	"kubevirt.io/kubevirt/pkg/virt-api/rest"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
+	backup_webhook "kubevirt.io/kubevirt/pkg/virt-api/webhooks/backup-webhook"
 	mutating_webhook "kubevirt.io/kubevirt/pkg/virt-api/webhooks/mutating-webhook"
 	validating_webhook "kubevirt.io/kubevirt/pkg/virt-api/webhooks/validating-webhook"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
@@ -104,6 +111,12 @@ type virtAPIApp struct {
 	authorizor       rest.VirtApiAuthorizor
 	certsDirectory   string
 	clusterConfig    *virtconfig.ClusterConfig
+	recorder         record.EventRecorder
+
+	nodeInformer      cache.SharedIndexInformer
+	activePodInformer cache.SharedIndexInformer
+	pvcInformer       cache.SharedIndexInformer
+	pvInformer        cache.SharedIndexInformer
 
 	namespace               string
 	host                    string
@@ -161,6 +174,11 @@ func (app *virtAPIApp) Execute() {
 
 	app.authorizor = authorizor
 
+	// Create event recorder
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&k8coresv1.EventSinkImpl{Interface: app.virtCli.CoreV1().Events(k8sv1.NamespaceAll)})
+	app.recorder = broadcaster.NewRecorder(scheme.Scheme, k8sv1.EventSource{Component: "virt-api"})
+
 	app.certsDirectory, err = ioutil.TempDir("", "certsdir")
 	if err != nil {
 		panic(err)
@@ -204,12 +222,16 @@ func (app *virtAPIApp) composeSubresources() {
 	for _, version := range v1.SubresourceGroupVersions {
 		subresourcesvmGVR := schema.GroupVersionResource{Group: version.Group, Version: version.Version, Resource: "virtualmachines"}
 		subresourcesvmiGVR := schema.GroupVersionResource{Group: version.Group, Version: version.Version, Resource: "virtualmachineinstances"}
+		subresourcesmigrationGVR := schema.GroupVersionResource{Group: version.Group, Version: version.Version, Resource: "virtualmachineinstancemigrations"}
+		subresourcesbulkGVR := schema.GroupVersionResource{Group: version.Group, Version: version.Version, Resource: "bulkoperations"}
+		subresourcesfeasibilityGVR := schema.GroupVersionResource{Group: version.Group, Version: version.Version, Resource: "schedulingfeasibility"}
 
 		subws := new(restful.WebService)
 		subws.Doc(fmt.Sprintf("KubeVirt \"%s\" Subresource API.", version.Version))
 		subws.Path(rest.GroupVersionBasePath(version))
 
-		subresourceApp := rest.NewSubresourceAPIApp(app.virtCli, app.consoleServerPort, app.handlerTLSConfiguration, app.clusterConfig)
+		subresourceApp := rest.NewSubresourceAPIApp(app.virtCli, app.consoleServerPort, app.handlerTLSConfiguration, app.clusterConfig, app.recorder, app.authorizor, app.nodeInformer, app.activePodInformer, app.pvcInformer, app.pvInformer)
+		subws.Filter(subresourceApp.RequestAuditFilter())
 
 		restartRouteBuilder := subws.PUT(rest.ResourcePath(subresourcesvmGVR)+rest.SubResourcePath("restart")).
 			To(subresourceApp.RestartVMRequestHandler).
@@ -232,6 +254,54 @@ func (app *virtAPIApp) composeSubresources() {
 			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
 			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
 
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesmigrationGVR)+rest.SubResourcePath("cancel")).
+			To(subresourceApp.CancelMigrationRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation(version.Version+"CancelMigration").
+			Doc("Cancel an in-flight VirtualMachineInstanceMigration.").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
+
+		subws.Route(subws.PUT(rest.ResourceBasePath(subresourcesbulkGVR)).
+			To(subresourceApp.BulkOperationRequestHandler).
+			Reads(v1.BulkOperationOptions{}).
+			Param(rest.NamespaceParam(subws)).
+			Consumes(restful.MIME_JSON).
+			Produces(restful.MIME_JSON).
+			Operation(version.Version+"BulkOperation").
+			Doc("Start, stop or migrate all VirtualMachines matching a label selector.").
+			Writes(v1.BulkOperation{}).
+			Returns(http.StatusAccepted, "Accepted", v1.BulkOperation{}).
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, "").
+			Returns(http.StatusInternalServerError, httpStatusInternalServerError, ""))
+
+		subws.Route(subws.GET(rest.ResourceBasePath(subresourcesbulkGVR)+"/{handle}").
+			To(subresourceApp.BulkOperationProgressRequestHandler).
+			Param(rest.NamespaceParam(subws)).
+			Param(subws.PathParameter("handle", "Handle of the bulk operation").Required(true)).
+			Consumes(restful.MIME_JSON).
+			Produces(restful.MIME_JSON).
+			Operation(version.Version+"BulkOperationProgress").
+			Doc("Get the progress of a bulk operation.").
+			Writes(v1.BulkOperation{}).
+			Returns(http.StatusOK, "OK", v1.BulkOperation{}).
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, "").
+			Returns(http.StatusInternalServerError, httpStatusInternalServerError, ""))
+
+		subws.Route(subws.PUT(rest.ResourceBasePath(subresourcesfeasibilityGVR)).
+			To(subresourceApp.SchedulingFeasibilityRequestHandler).
+			Reads(v1.SchedulingFeasibilityOptions{}).
+			Param(rest.NamespaceParam(subws)).
+			Consumes(restful.MIME_JSON).
+			Produces(restful.MIME_JSON).
+			Operation(version.Version+"SchedulingFeasibility").
+			Doc("Check if a VirtualMachine could currently be scheduled and which constraints the nodes fail to satisfy.").
+			Writes(v1.SchedulingFeasibility{}).
+			Returns(http.StatusOK, "OK", v1.SchedulingFeasibility{}).
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, "").
+			Returns(http.StatusInternalServerError, httpStatusInternalServerError, ""))
+
 		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmGVR)+rest.SubResourcePath("start")).
 			To(subresourceApp.StartVMRequestHandler).
 			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
@@ -269,6 +339,28 @@ func (app *virtAPIApp) composeSubresources() {
 			Returns(http.StatusOK, "OK", "").
 			Returns(http.StatusInternalServerError, httpStatusInternalServerError, ""))
 
+		memorySnapshotRouteBuilder := subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("memorysnapshot")).
+			To(subresourceApp.MemorySnapshotVMIRequestHandler).
+			Reads(v1.MemorySnapshotOptions{}).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation(version.Version+"MemorySnapshot").
+			Doc("Snapshot the memory of a VirtualMachineInstance object to its memory dump volume, or upload it to object storage.").
+			Writes(v1.MemorySnapshotUploadStatus{}).
+			Returns(http.StatusOK, "OK", v1.MemorySnapshotUploadStatus{}).
+			Returns(http.StatusInternalServerError, httpStatusInternalServerError, "")
+		memorySnapshotRouteBuilder.ParameterNamed("body").Required(false)
+		subws.Route(memorySnapshotRouteBuilder)
+
+		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("setlinkstate")).
+			To(subresourceApp.SetLinkStateVMIRequestHandler).
+			Reads(v1.SetLinkStateOptions{}).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Operation(version.Version+"SetLinkState").
+			Doc("Set the link state of an interface of a running VirtualMachineInstance.").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
+			Returns(http.StatusBadRequest, httpStatusBadRequestMessage, ""))
+
 		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("pause")).
 			To(subresourceApp.PauseVMIRequestHandler).
 			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
@@ -388,6 +480,26 @@ func (app *virtAPIApp) composeSubresources() {
 			Writes(v1.VirtualMachineInstanceFileSystemList{}).
 			Returns(http.StatusOK, "OK", v1.VirtualMachineInstanceFileSystemList{}))
 
+		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("usage")).
+			To(subresourceApp.Usage).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Consumes(restful.MIME_JSON).
+			Produces(restful.MIME_JSON).
+			Operation(version.Version+"Usage").
+			Doc("Get the current CPU and memory usage of a VirtualMachineInstance").
+			Writes(v1.VirtualMachineInstanceUsage{}).
+			Returns(http.StatusOK, "OK", v1.VirtualMachineInstanceUsage{}))
+
+		subws.Route(subws.GET(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("screenshot")).
+			To(subresourceApp.ScreenshotRequestHandler).
+			Param(rest.NamespaceParam(subws)).Param(rest.NameParam(subws)).
+			Produces("image/png").
+			Operation(version.Version+"Screenshot").
+			Doc("Get a PNG screenshot of the graphical console of a VirtualMachineInstance").
+			Returns(http.StatusOK, "OK", "").
+			Returns(http.StatusNotFound, httpStatusNotFoundMessage, "").
+			Returns(http.StatusInternalServerError, httpStatusInternalServerError, ""))
+
 		subws.Route(subws.PUT(rest.ResourcePath(subresourcesvmiGVR)+rest.SubResourcePath("addvolume")).
 			To(subresourceApp.VMIAddVolumeRequestHandler).
 			Reads(v1.AddVolumeOptions{}).
@@ -466,6 +578,14 @@ func (app *virtAPIApp) composeSubresources() {
 						Name:       "virtualmachineinstances/unfreeze",
 						Namespaced: true,
 					},
+					{
+						Name:       "virtualmachineinstances/memorysnapshot",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/setlinkstate",
+						Namespaced: true,
+					},
 					{
 						Name:       "virtualmachines/start",
 						Namespaced: true,
@@ -482,6 +602,18 @@ func (app *virtAPIApp) composeSubresources() {
 						Name:       "virtualmachines/migrate",
 						Namespaced: true,
 					},
+					{
+						Name:       "virtualmachineinstancemigrations/cancel",
+						Namespaced: true,
+					},
+					{
+						Name:       "bulkoperations",
+						Namespaced: true,
+					},
+					{
+						Name:       "schedulingfeasibility",
+						Namespaced: true,
+					},
 					{
 						Name:       "virtualmachineinstances/guestosinfo",
 						Namespaced: true,
@@ -494,6 +626,14 @@ func (app *virtAPIApp) composeSubresources() {
 						Name:       "virtualmachineinstances/filesystemlist",
 						Namespaced: true,
 					},
+					{
+						Name:       "virtualmachineinstances/usage",
+						Namespaced: true,
+					},
+					{
+						Name:       "virtualmachineinstances/screenshot",
+						Namespaced: true,
+					},
 					{
 						Name:       "virtualmachineinstances/addvolume",
 						Namespaced: true,
@@ -682,7 +822,7 @@ func (app *virtAPIApp) prepareCertManager() {
 
 func (app *virtAPIApp) registerValidatingWebhooks() {
 	http.HandleFunc(components.VMICreateValidatePath, func(w http.ResponseWriter, r *http.Request) {
-		validating_webhook.ServeVMICreate(w, r, app.clusterConfig)
+		validating_webhook.ServeVMICreate(w, r, app.clusterConfig, app.virtCli)
 	})
 	http.HandleFunc(components.VMIUpdateValidatePath, func(w http.ResponseWriter, r *http.Request) {
 		validating_webhook.ServeVMIUpdate(w, r, app.clusterConfig)
@@ -708,6 +848,9 @@ func (app *virtAPIApp) registerValidatingWebhooks() {
 	http.HandleFunc(components.VMRestoreValidatePath, func(w http.ResponseWriter, r *http.Request) {
 		validating_webhook.ServeVMRestores(w, r, app.clusterConfig, app.virtCli)
 	})
+	http.HandleFunc(components.VMImageExportValidatePath, func(w http.ResponseWriter, r *http.Request) {
+		validating_webhook.ServeVMImageExports(w, r, app.clusterConfig, app.virtCli)
+	})
 	http.HandleFunc(components.StatusValidatePath, func(w http.ResponseWriter, r *http.Request) {
 		validating_webhook.ServeStatusValidation(w, r, app.clusterConfig, app.virtCli)
 	})
@@ -729,6 +872,15 @@ func (app *virtAPIApp) registerMutatingWebhook() {
 	})
 }
 
+func (app *virtAPIApp) registerConversionWebhook() {
+	http.HandleFunc(components.VirtAPIConversionPath, conversion.Serve)
+}
+
+func (app *virtAPIApp) registerBackupWebhooks() {
+	http.HandleFunc(components.VirtAPIBackupItemActionPath, backup_webhook.ServeBackupItemAction)
+	http.HandleFunc(components.VirtAPIRestoreItemActionPath, backup_webhook.ServeRestoreItemAction)
+}
+
 func (app *virtAPIApp) setupTLS(k8sCAManager webhooksutils.ClientCAManager, kubevirtCAManager webhooksutils.ClientCAManager) {
 
 	// A VerifyClientCertIfGiven request means we're not guaranteed
@@ -772,7 +924,11 @@ func (app *virtAPIApp) startTLS(informerFactory controller.KubeInformerFactory)
 
 	app.Compose()
 
-	http.Handle("/metrics", promhttp.Handler())
+	// OpenMetrics is needed to expose the exemplars of slow subresource requests
+	http.Handle("/metrics", promhttp.InstrumentMetricHandler(
+		prometheus.DefaultRegisterer,
+		promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{EnableOpenMetrics: true}),
+	))
 	server := &http.Server{
 		Addr:      fmt.Sprintf("%s:%d", app.BindAddress, app.Port),
 		TLSConfig: app.tlsConfig,
@@ -840,6 +996,8 @@ func (app *virtAPIApp) Run() {
 	// Build webhook subresources
 	app.registerMutatingWebhook()
 	app.registerValidatingWebhooks()
+	app.registerConversionWebhook()
+	app.registerBackupWebhooks()
 
 	// Run informers for webhooks usage
 	webhookInformers := webhooks.GetInformers()
@@ -849,6 +1007,10 @@ func (app *virtAPIApp) Run() {
 	authConfigMapInformer := kubeInformerFactory.ApiAuthConfigMap()
 	kubevirtCAConfigInformer := kubeInformerFactory.KubeVirtCAConfigMap()
 	kubeVirtInformer := kubeInformerFactory.KubeVirt()
+	app.nodeInformer = kubeInformerFactory.KubeVirtNode()
+	app.activePodInformer = kubeInformerFactory.ActivePod()
+	app.pvcInformer = kubeInformerFactory.PersistentVolumeClaim()
+	app.pvInformer = kubeInformerFactory.PersistentVolume()
 
 	// Wire up health check trigger
 	configMapInformer.SetWatchErrorHandler(func(r *cache.Reflector, err error) {
@@ -861,12 +1023,19 @@ func (app *virtAPIApp) Run() {
 	go webhookInformers.VMIInformer.Run(stopChan)
 	go webhookInformers.VMIPresetInformer.Run(stopChan)
 	go webhookInformers.NamespaceLimitsInformer.Run(stopChan)
+	go webhookInformers.NamespaceInformer.Run(stopChan)
 	go webhookInformers.VMRestoreInformer.Run(stopChan)
+	go webhookInformers.ValidationPolicyInformer.Run(stopChan)
+	go webhookInformers.MaintenanceWindowInformer.Run(stopChan)
 	go kubeVirtInformer.Run(stopChan)
 	go configMapInformer.Run(stopChan)
 	go crdInformer.Run(stopChan)
 	go authConfigMapInformer.Run(stopChan)
 	go kubevirtCAConfigInformer.Run(stopChan)
+	go app.nodeInformer.Run(stopChan)
+	go app.activePodInformer.Run(stopChan)
+	go app.pvcInformer.Run(stopChan)
+	go app.pvInformer.Run(stopChan)
 	cache.WaitForCacheSync(stopChan,
 		crdInformer.HasSynced,
 		authConfigMapInformer.HasSynced,
@@ -875,6 +1044,13 @@ func (app *virtAPIApp) Run() {
 		webhookInformers.VMIInformer.HasSynced,
 		webhookInformers.VMIPresetInformer.HasSynced,
 		webhookInformers.NamespaceLimitsInformer.HasSynced,
+		webhookInformers.NamespaceInformer.HasSynced,
+		webhookInformers.ValidationPolicyInformer.HasSynced,
+		webhookInformers.MaintenanceWindowInformer.HasSynced,
+		app.nodeInformer.HasSynced,
+		app.activePodInformer.HasSynced,
+		app.pvcInformer.HasSynced,
+		app.pvInformer.HasSynced,
 		configMapInformer.HasSynced)
 
 	app.clusterConfig = virtconfig.NewClusterConfig(configMapInformer, crdInformer, kubeVirtInformer, app.namespace)
@@ -68,6 +68,8 @@ var _ = Describe("Virt-api", func() {
 		Expect(err).ToNot(HaveOccurred())
 		ctrl = gomock.NewController(GinkgoT())
 		authorizorMock = rest.NewMockVirtApiAuthorizor(ctrl)
+		// the request audit filter looks up the user of every subresource request
+		authorizorMock.EXPECT().GetUserHeaders().Return([]string{"X-Remote-User"}).AnyTimes()
 
 		// Reset go-restful
 		http.DefaultServeMux = new(http.ServeMux)
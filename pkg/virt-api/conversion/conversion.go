@@ -0,0 +1,117 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+// Package conversion converts KubeVirt objects between the API versions in a hub-and-spoke model.
+//
+// virt-api serves Convert to the API server on the conversion endpoint. Once the control plane
+// rolled over, virt-operator switches the CRDs of all kinds with a spoke to the Webhook conversion
+// strategy. The KubeVirt CRD keeps the None strategy, virt-operator has to read it while virt-api
+// is not running yet.
+package conversion
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+// hubVersion is the version every conversion goes through. A spoke version only
+// has to know how to convert from and to the hub, not to every other version.
+var hubVersion = v1.GroupVersion.Version
+
+// convertFunc converts the content of an object in place, the apiVersion is set by Convert
+type convertFunc func(obj *unstructured.Unstructured) error
+
+type spoke struct {
+	toHub   convertFunc
+	fromHub convertFunc
+}
+
+// noConversion converts kinds whose schema does not differ between a spoke and the hub
+func noConversion(*unstructured.Unstructured) error {
+	return nil
+}
+
+// spokes lists per version and kind how objects are converted from and to the hub version
+var spokes = map[string]map[string]spoke{
+	"v1alpha3": {
+		v1.VirtualMachineGroupVersionKind.Kind:                   {toHub: noConversion, fromHub: noConversion},
+		v1.VirtualMachineInstanceGroupVersionKind.Kind:           {toHub: noConversion, fromHub: noConversion},
+		v1.VirtualMachineInstanceReplicaSetGroupVersionKind.Kind: {toHub: noConversion, fromHub: noConversion},
+		v1.VirtualMachineInstancePresetGroupVersionKind.Kind:     {toHub: noConversion, fromHub: noConversion},
+		v1.VirtualMachineInstanceMigrationGroupVersionKind.Kind:  {toHub: noConversion, fromHub: noConversion},
+		v1.VirtualMachineSchedulingHintsGroupVersionKind.Kind:    {toHub: noConversion, fromHub: noConversion},
+		v1.VirtualMachineImageExportGroupVersionKind.Kind:        {toHub: noConversion, fromHub: noConversion},
+		v1.ValidationPolicyGroupVersionKind.Kind:                 {toHub: noConversion, fromHub: noConversion},
+		v1.MaintenanceWindowGroupVersionKind.Kind:                {toHub: noConversion, fromHub: noConversion},
+		v1.VirtualMachineBulkOperationGroupVersionKind.Kind:      {toHub: noConversion, fromHub: noConversion},
+	},
+}
+
+func lookupSpoke(version, kind string) (spoke, error) {
+	if s, ok := spokes[version][kind]; ok {
+		return s, nil
+	}
+	return spoke{}, fmt.Errorf("conversion of %s from or to %s is not supported", kind, version)
+}
+
+// Convert returns a copy of obj converted to the desired API version
+func Convert(obj *unstructured.Unstructured, desiredAPIVersion string) (*unstructured.Unstructured, error) {
+	from, err := schema.ParseGroupVersion(obj.GetAPIVersion())
+	if err != nil {
+		return nil, err
+	}
+	to, err := schema.ParseGroupVersion(desiredAPIVersion)
+	if err != nil {
+		return nil, err
+	}
+	if from.Group != v1.GroupName || to.Group != v1.GroupName {
+		return nil, fmt.Errorf("can not convert %s from %s to %s, only %s is supported", obj.GetKind(), from, to, v1.GroupName)
+	}
+
+	converted := obj.DeepCopy()
+	if from.Version == to.Version {
+		return converted, nil
+	}
+
+	if from.Version != hubVersion {
+		s, err := lookupSpoke(from.Version, obj.GetKind())
+		if err != nil {
+			return nil, err
+		}
+		if err := s.toHub(converted); err != nil {
+			return nil, fmt.Errorf("failed to convert %s from %s to %s: %v", obj.GetKind(), from.Version, hubVersion, err)
+		}
+	}
+	if to.Version != hubVersion {
+		s, err := lookupSpoke(to.Version, obj.GetKind())
+		if err != nil {
+			return nil, err
+		}
+		if err := s.fromHub(converted); err != nil {
+			return nil, fmt.Errorf("failed to convert %s from %s to %s: %v", obj.GetKind(), hubVersion, to.Version, err)
+		}
+	}
+	converted.SetAPIVersion(to.String())
+
+	return converted, nil
+}
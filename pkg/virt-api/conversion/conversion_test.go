@@ -0,0 +1,125 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package conversion_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/virt-api/conversion"
+)
+
+var _ = Describe("Conversion", func() {
+
+	toUnstructured := func(obj runtime.Object, apiVersion string) *unstructured.Unstructured {
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		Expect(err).ToNot(HaveOccurred())
+		u := &unstructured.Unstructured{Object: content}
+		u.SetAPIVersion(apiVersion)
+		return u
+	}
+
+	newVM := func() runtime.Object {
+		vm := kubecli.NewMinimalVM("testvm")
+		vm.Spec.Template = &v1.VirtualMachineInstanceTemplateSpec{
+			Spec: v1.VirtualMachineInstanceSpec{
+				Domain: v1.DomainSpec{
+					Devices: v1.Devices{Disks: []v1.Disk{{Name: "disk0"}}},
+				},
+			},
+		}
+		return vm
+	}
+
+	newVMI := func() runtime.Object {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Status.Phase = v1.Running
+		return vmi
+	}
+
+	newReplicaSet := func() runtime.Object {
+		replicas := int32(2)
+		return &v1.VirtualMachineInstanceReplicaSet{
+			TypeMeta:   metav1.TypeMeta{Kind: "VirtualMachineInstanceReplicaSet"},
+			ObjectMeta: metav1.ObjectMeta{Name: "testrs"},
+			Spec: v1.VirtualMachineInstanceReplicaSetSpec{
+				Replicas: &replicas,
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+			},
+		}
+	}
+
+	newPreset := func() runtime.Object {
+		return v1.NewVirtualMachinePreset("testpreset", metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}})
+	}
+
+	newMigration := func() runtime.Object {
+		return &v1.VirtualMachineInstanceMigration{
+			TypeMeta:   metav1.TypeMeta{Kind: "VirtualMachineInstanceMigration"},
+			ObjectMeta: metav1.ObjectMeta{Name: "testmigration"},
+			Spec:       v1.VirtualMachineInstanceMigrationSpec{VMIName: "testvmi"},
+		}
+	}
+
+	table.DescribeTable("should convert round trip without losing content", func(newObj func() runtime.Object, from, to string) {
+		original := toUnstructured(newObj(), from)
+
+		converted, err := conversion.Convert(original, to)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(converted.GetAPIVersion()).To(Equal(to))
+
+		back, err := conversion.Convert(converted, from)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(back).To(Equal(original))
+	},
+		table.Entry("a VirtualMachine from v1alpha3 to v1", newVM, "kubevirt.io/v1alpha3", "kubevirt.io/v1"),
+		table.Entry("a VirtualMachine from v1 to v1alpha3", newVM, "kubevirt.io/v1", "kubevirt.io/v1alpha3"),
+		table.Entry("a VirtualMachineInstance from v1alpha3 to v1", newVMI, "kubevirt.io/v1alpha3", "kubevirt.io/v1"),
+		table.Entry("a VirtualMachineInstance from v1 to v1alpha3", newVMI, "kubevirt.io/v1", "kubevirt.io/v1alpha3"),
+		table.Entry("a VirtualMachineInstanceReplicaSet from v1alpha3 to v1", newReplicaSet, "kubevirt.io/v1alpha3", "kubevirt.io/v1"),
+		table.Entry("a VirtualMachineInstanceReplicaSet from v1 to v1alpha3", newReplicaSet, "kubevirt.io/v1", "kubevirt.io/v1alpha3"),
+		table.Entry("a VirtualMachineInstancePreset from v1alpha3 to v1", newPreset, "kubevirt.io/v1alpha3", "kubevirt.io/v1"),
+		table.Entry("a VirtualMachineInstancePreset from v1 to v1alpha3", newPreset, "kubevirt.io/v1", "kubevirt.io/v1alpha3"),
+		table.Entry("a VirtualMachineInstanceMigration from v1alpha3 to v1", newMigration, "kubevirt.io/v1alpha3", "kubevirt.io/v1"),
+		table.Entry("a VirtualMachineInstanceMigration from v1 to v1alpha3", newMigration, "kubevirt.io/v1", "kubevirt.io/v1alpha3"),
+	)
+
+	It("should not modify the original object", func() {
+		original := toUnstructured(newVM(), "kubevirt.io/v1alpha3")
+		_, err := conversion.Convert(original, "kubevirt.io/v1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(original.GetAPIVersion()).To(Equal("kubevirt.io/v1alpha3"))
+	})
+
+	table.DescribeTable("should refuse to convert", func(obj *unstructured.Unstructured, to string) {
+		_, err := conversion.Convert(obj, to)
+		Expect(err).To(HaveOccurred())
+	},
+		table.Entry("to another group", toUnstructured(v1.NewMinimalVMI("testvmi"), "kubevirt.io/v1"), "example.com/v1"),
+		table.Entry("to an unknown version", toUnstructured(v1.NewMinimalVMI("testvmi"), "kubevirt.io/v1"), "kubevirt.io/v2"),
+		table.Entry("unknown kinds", toUnstructured(&v1.VirtualMachineInstanceMigration{TypeMeta: metav1.TypeMeta{Kind: "VirtualMachineInstanceUnknown"}}, "kubevirt.io/v1"), "kubevirt.io/v1alpha3"),
+	)
+})
@@ -0,0 +1,117 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package conversion
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"kubevirt.io/client-go/log"
+	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
+)
+
+// conversionReviewV1beta1 is the legacy ConversionReview version. Its types are identical to
+// apiextensions/v1, reviews of both versions are answered in the version they were sent with.
+const conversionReviewV1beta1 = "apiextensions.k8s.io/v1beta1"
+
+func getConversionReview(r *http.Request) (*extv1.ConversionReview, error) {
+	body, err := webhookutils.ReadJSONRequestBody(r)
+	if err != nil {
+		return nil, err
+	}
+
+	review := &extv1.ConversionReview{}
+	if err := json.Unmarshal(body, review); err != nil {
+		return nil, err
+	}
+	if review.Kind != "ConversionReview" ||
+		(review.APIVersion != extv1.SchemeGroupVersion.String() && review.APIVersion != conversionReviewV1beta1) {
+		return nil, fmt.Errorf("unsupported review %s %s, expect ConversionReview of %s or %s", review.APIVersion, review.Kind, extv1.SchemeGroupVersion.String(), conversionReviewV1beta1)
+	}
+	if review.Request == nil {
+		return nil, fmt.Errorf("ConversionReview without a request")
+	}
+	return review, nil
+}
+
+func convertObjects(request *extv1.ConversionRequest) *extv1.ConversionResponse {
+	response := &extv1.ConversionResponse{UID: request.UID}
+
+	for _, raw := range request.Objects {
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(raw.Raw); err != nil {
+			return conversionFailure(response, err)
+		}
+		converted, err := Convert(obj, request.DesiredAPIVersion)
+		if err != nil {
+			return conversionFailure(response, err)
+		}
+		data, err := converted.MarshalJSON()
+		if err != nil {
+			return conversionFailure(response, err)
+		}
+		response.ConvertedObjects = append(response.ConvertedObjects, runtime.RawExtension{Raw: data})
+	}
+
+	response.Result = metav1.Status{Status: metav1.StatusSuccess}
+	return response
+}
+
+func conversionFailure(response *extv1.ConversionResponse, err error) *extv1.ConversionResponse {
+	log.Log.Reason(err).Error("failed to convert objects")
+	response.ConvertedObjects = nil
+	response.Result = metav1.Status{
+		Status:  metav1.StatusFailure,
+		Message: err.Error(),
+	}
+	return response
+}
+
+// Serve answers the ConversionReviews the API server sends for the KubeVirt CRDs with Convert
+func Serve(resp http.ResponseWriter, req *http.Request) {
+	review, err := getConversionReview(req)
+	if err != nil {
+		log.Log.Reason(err).Error("failed to decode conversion review")
+		resp.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	response := extv1.ConversionReview{
+		TypeMeta: review.TypeMeta,
+		Response: convertObjects(review.Request),
+	}
+
+	responseBytes, err := json.Marshal(response)
+	if err != nil {
+		log.Log.Reason(err).Errorf("failed json encode conversion response")
+		resp.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp.Header().Set("Content-Type", "application/json")
+	if _, err := resp.Write(responseBytes); err != nil {
+		log.Log.Reason(err).Errorf("failed to write conversion response")
+	}
+}
@@ -0,0 +1,136 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package conversion_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/virt-api/conversion"
+)
+
+var _ = Describe("Conversion webhook", func() {
+
+	toUnstructured := func(obj runtime.Object, apiVersion string) *unstructured.Unstructured {
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		Expect(err).ToNot(HaveOccurred())
+		u := &unstructured.Unstructured{Object: content}
+		u.SetAPIVersion(apiVersion)
+		return u
+	}
+
+	newVM := func() runtime.Object {
+		vm := kubecli.NewMinimalVM("testvm")
+		vm.Spec.Template = &v1.VirtualMachineInstanceTemplateSpec{
+			Spec: v1.VirtualMachineInstanceSpec{
+				Domain: v1.DomainSpec{
+					Devices: v1.Devices{Disks: []v1.Disk{{Name: "disk0"}}},
+				},
+			},
+		}
+		return vm
+	}
+
+	newVMI := func() runtime.Object {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Status.Phase = v1.Running
+		return vmi
+	}
+
+	serve := func(review interface{}) (*httptest.ResponseRecorder, *extv1.ConversionReview) {
+		body, err := json.Marshal(review)
+		Expect(err).ToNot(HaveOccurred())
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		recorder := httptest.NewRecorder()
+
+		conversion.Serve(recorder, req)
+
+		response := &extv1.ConversionReview{}
+		if recorder.Code == http.StatusOK {
+			Expect(json.Unmarshal(recorder.Body.Bytes(), response)).To(Succeed())
+		}
+		return recorder, response
+	}
+
+	newReview := func(apiVersion string, objects ...*unstructured.Unstructured) *extv1.ConversionReview {
+		review := &extv1.ConversionReview{
+			TypeMeta: metav1.TypeMeta{APIVersion: apiVersion, Kind: "ConversionReview"},
+			Request:  &extv1.ConversionRequest{UID: "1234", DesiredAPIVersion: "kubevirt.io/v1"},
+		}
+		for _, obj := range objects {
+			data, err := obj.MarshalJSON()
+			Expect(err).ToNot(HaveOccurred())
+			review.Request.Objects = append(review.Request.Objects, runtime.RawExtension{Raw: data})
+		}
+		return review
+	}
+
+	table.DescribeTable("should convert all objects", func(apiVersion string) {
+		recorder, response := serve(newReview(apiVersion,
+			toUnstructured(newVM(), "kubevirt.io/v1alpha3"),
+			toUnstructured(newVMI(), "kubevirt.io/v1alpha3"),
+		))
+
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Header().Get("Content-Type")).To(Equal("application/json"))
+		Expect(response.APIVersion).To(Equal(apiVersion))
+		Expect(response.Kind).To(Equal("ConversionReview"))
+		Expect(response.Response.UID).To(BeEquivalentTo("1234"))
+		Expect(response.Response.Result.Status).To(Equal(metav1.StatusSuccess))
+		Expect(response.Response.ConvertedObjects).To(HaveLen(2))
+		for _, raw := range response.Response.ConvertedObjects {
+			obj := &unstructured.Unstructured{}
+			Expect(obj.UnmarshalJSON(raw.Raw)).To(Succeed())
+			Expect(obj.GetAPIVersion()).To(Equal("kubevirt.io/v1"))
+		}
+	},
+		table.Entry("of apiextensions/v1", "apiextensions.k8s.io/v1"),
+		table.Entry("of apiextensions/v1beta1", "apiextensions.k8s.io/v1beta1"),
+	)
+
+	It("should fail the whole review if one object can not be converted", func() {
+		kv := toUnstructured(&v1.KubeVirt{TypeMeta: metav1.TypeMeta{Kind: "KubeVirt"}}, "kubevirt.io/v1alpha3")
+		recorder, response := serve(newReview("apiextensions.k8s.io/v1", toUnstructured(newVM(), "kubevirt.io/v1alpha3"), kv))
+
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(response.Response.UID).To(BeEquivalentTo("1234"))
+		Expect(response.Response.Result.Status).To(Equal(metav1.StatusFailure))
+		Expect(response.Response.Result.Message).To(ContainSubstring("KubeVirt"))
+		Expect(response.Response.ConvertedObjects).To(BeEmpty())
+	})
+
+	It("should reject reviews of unknown versions", func() {
+		recorder, _ := serve(newReview("apiextensions.k8s.io/v2"))
+		Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+	})
+})
@@ -34,6 +34,7 @@ import (
 	restclient "k8s.io/client-go/rest"
 	"k8s.io/client-go/util/flowcontrol"
 
+	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/kubecli"
 )
 
@@ -41,10 +42,15 @@ const (
 	userHeader            = "X-Remote-User"
 	groupHeader           = "X-Remote-Group"
 	userExtraHeaderPrefix = "X-Remote-Extra-"
+
+	bulkOperationsResource        = "bulkoperations"
+	schedulingFeasibilityResource = "schedulingfeasibility"
 )
 
 type VirtApiAuthorizor interface {
 	Authorize(req *restful.Request) (bool, string, error)
+	UserInfo(req *restful.Request) (string, []string, map[string]authorization.ExtraValue, error)
+	AuthorizeList(req *restful.Request, namespace string, resource string) (bool, string, error)
 	AddUserHeaders(header []string)
 	GetUserHeaders() []string
 	AddGroupHeaders(header []string)
@@ -141,6 +147,44 @@ func (a *authorizor) generateAccessReview(req *restful.Request) (*authorization.
 	// URL example
 	// /apis/subresources.kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi/console
 	pathSplit := strings.Split(url.Path, "/")
+	if len(pathSplit) >= 7 && pathSplit[6] == bulkOperationsResource {
+		// Bulk operations act on all VirtualMachines matching a selector.
+		// Requesting one is granted together with the subresources of single
+		// VirtualMachines, virt-controller authorizes every single VirtualMachine
+		// again before it gets touched. The progress is read from the
+		// VirtualMachineBulkOperation recording the operation.
+		if strings.EqualFold(httpRequest.Method, http.MethodPut) {
+			return a.newAccessReview(headers, &authorization.ResourceAttributes{
+				Namespace: pathSplit[5],
+				Verb:      "update",
+				Group:     pathSplit[2],
+				Version:   pathSplit[3],
+				Resource:  bulkOperationsResource,
+			})
+		}
+		if len(pathSplit) < 8 {
+			return nil, fmt.Errorf("unknown api endpoint %s", url.Path)
+		}
+		return a.newAccessReview(headers, &authorization.ResourceAttributes{
+			Namespace: pathSplit[5],
+			Verb:      "get",
+			Group:     v1.GroupName,
+			Version:   pathSplit[3],
+			Resource:  "virtualmachinebulkoperations",
+			Name:      pathSplit[7],
+		})
+	}
+	if len(pathSplit) >= 7 && pathSplit[6] == schedulingFeasibilityResource {
+		// The feasibility check tells if a VirtualMachine could be scheduled
+		// before it gets created, it is granted by the default roles.
+		return a.newAccessReview(headers, &authorization.ResourceAttributes{
+			Namespace: pathSplit[5],
+			Verb:      "update",
+			Group:     pathSplit[2],
+			Version:   pathSplit[3],
+			Resource:  schedulingFeasibilityResource,
+		})
+	}
 	if len(pathSplit) < 9 {
 		return nil, fmt.Errorf("unknown api endpoint %s", url.Path)
 	}
@@ -151,42 +195,51 @@ func (a *authorizor) generateAccessReview(req *restful.Request) (*authorization.
 	resource := pathSplit[6]
 	resourceName := pathSplit[7]
 	subresource := pathSplit[8]
-	userExtras := a.getUserExtras(headers)
 
-	if resource != "virtualmachineinstances" && resource != "virtualmachines" {
+	switch resource {
+	case "virtualmachineinstances", "virtualmachines":
+	case "virtualmachineinstancemigrations":
+		// migrations only offer to cancel them
+		if subresource != "cancel" {
+			return nil, fmt.Errorf("unknown subresource %s of %s", subresource, resource)
+		}
+	default:
 		return nil, fmt.Errorf("unknown resource type %s", resource)
 	}
 
-	userName, err := a.getUserName(headers)
+	verb, err := mapHttpVerbToRbacVerb(httpRequest.Method, resourceName)
 	if err != nil {
 		return nil, err
 	}
 
-	userGroups, err := a.getUserGroups(headers)
+	return a.newAccessReview(headers, &authorization.ResourceAttributes{
+		Namespace:   namespace,
+		Verb:        verb,
+		Group:       group,
+		Version:     version,
+		Resource:    resource,
+		Subresource: subresource,
+		Name:        resourceName,
+	})
+}
+
+func (a *authorizor) newAccessReview(headers http.Header, attributes *authorization.ResourceAttributes) (*authorization.SubjectAccessReview, error) {
+	userName, err := a.getUserName(headers)
 	if err != nil {
 		return nil, err
 	}
 
-	verb, err := mapHttpVerbToRbacVerb(httpRequest.Method, resourceName)
+	userGroups, err := a.getUserGroups(headers)
 	if err != nil {
 		return nil, err
 	}
 
 	r := &authorization.SubjectAccessReview{}
 	r.Spec = authorization.SubjectAccessReviewSpec{
-		User:   userName,
-		Groups: userGroups,
-		Extra:  userExtras,
-	}
-
-	r.Spec.ResourceAttributes = &authorization.ResourceAttributes{
-		Namespace:   namespace,
-		Verb:        verb,
-		Group:       group,
-		Version:     version,
-		Resource:    resource,
-		Subresource: subresource,
-		Name:        resourceName,
+		User:               userName,
+		Groups:             userGroups,
+		Extra:              a.getUserExtras(headers),
+		ResourceAttributes: attributes,
 	}
 
 	return r, nil
@@ -269,6 +322,51 @@ func (a *authorizor) Authorize(req *restful.Request) (bool, string, error) {
 		return false, fmt.Sprintf("%v", err), nil
 	}
 
+	return a.review(r)
+}
+
+// UserInfo returns the name, groups and extra attributes of the user behind
+// req, as passed on by the API server in the request headers.
+func (a *authorizor) UserInfo(req *restful.Request) (string, []string, map[string]authorization.ExtraValue, error) {
+	if req.Request == nil {
+		return "", nil, nil, fmt.Errorf("empty http request")
+	}
+
+	userName, err := a.getUserName(req.Request.Header)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	userGroups, err := a.getUserGroups(req.Request.Header)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	return userName, userGroups, a.getUserExtras(req.Request.Header), nil
+}
+
+// AuthorizeList checks if the user behind req may list the kubevirt.io resource in the namespace.
+// It is used by subresources which act on a selection of objects, before resolving it.
+func (a *authorizor) AuthorizeList(req *restful.Request, namespace string, resource string) (bool, string, error) {
+	if req.Request == nil {
+		return false, "", fmt.Errorf("empty http request")
+	}
+
+	r, err := a.newAccessReview(req.Request.Header, &authorization.ResourceAttributes{
+		Namespace: namespace,
+		Verb:      "list",
+		Group:     v1.GroupName,
+		Version:   v1.ApiLatestVersion,
+		Resource:  resource,
+	})
+	if err != nil {
+		return false, fmt.Sprintf("%v", err), nil
+	}
+
+	return a.review(r)
+}
+
+func (a *authorizor) review(r *authorization.SubjectAccessReview) (bool, string, error) {
 	result, err := a.subjectAccessReview.Create(context.Background(), r, metav1.CreateOptions{})
 	if err != nil {
 		return false, "internal server error", err
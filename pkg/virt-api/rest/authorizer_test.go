@@ -22,6 +22,7 @@ package rest
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
 	"net/http"
 	"net/url"
 
@@ -30,6 +31,7 @@ import (
 	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/ghttp"
+	authorization "k8s.io/api/authorization/v1"
 	authorizationclient "k8s.io/client-go/kubernetes/typed/authorization/v1"
 	"k8s.io/client-go/tools/clientcmd"
 )
@@ -170,9 +172,118 @@ var _ = Describe("Authorizer", func() {
 				table.Entry("random2", "/1/2/3/4/5/6/7/8/9/0/1/2/3/4/5/6/7/8/9"),
 				table.Entry("no subresource provided", "/apis/subresources.kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
 				table.Entry("invalid resource type", "/apis/subresources.kubevirt.io/v1alpha3/namespaces/default/madeupresource/testvmi/console"),
+				table.Entry("invalid migration subresource", "/apis/subresources.kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstancemigrations/testmigration/console"),
 			)
 		})
 
+		Context("Migrations", func() {
+			It("should require to update the cancel subresource to cancel a migration", func() {
+				req.Request.Method = http.MethodPut
+				req.Request.URL.Path = "/apis/subresources.kubevirt.io/v1/namespaces/default/virtualmachineinstancemigrations/testmigration/cancel"
+
+				result, err := app.generateAccessReview(req)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Spec.User).To(Equal("user"))
+				Expect(result.Spec.ResourceAttributes).To(Equal(&authorization.ResourceAttributes{
+					Namespace:   "default",
+					Verb:        "update",
+					Group:       "subresources.kubevirt.io",
+					Version:     "v1",
+					Resource:    "virtualmachineinstancemigrations",
+					Subresource: "cancel",
+					Name:        "testmigration",
+				}))
+			})
+		})
+
+		Context("Bulk operations", func() {
+			It("should require to update bulk operations to request one", func() {
+				req.Request.Method = http.MethodPut
+				req.Request.URL.Path = "/apis/subresources.kubevirt.io/v1/namespaces/default/bulkoperations"
+
+				result, err := app.generateAccessReview(req)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Spec.User).To(Equal("user"))
+				Expect(result.Spec.ResourceAttributes).To(Equal(&authorization.ResourceAttributes{
+					Namespace: "default",
+					Verb:      "update",
+					Group:     "subresources.kubevirt.io",
+					Version:   "v1",
+					Resource:  "bulkoperations",
+				}))
+			})
+
+			It("should require to get the bulk operation to get the progress", func() {
+				req.Request.Method = http.MethodGet
+				req.Request.URL.Path = "/apis/subresources.kubevirt.io/v1/namespaces/default/bulkoperations/abc"
+
+				result, err := app.generateAccessReview(req)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Spec.User).To(Equal("user"))
+				Expect(result.Spec.ResourceAttributes).To(Equal(&authorization.ResourceAttributes{
+					Namespace: "default",
+					Verb:      "get",
+					Group:     "kubevirt.io",
+					Version:   "v1",
+					Resource:  "virtualmachinebulkoperations",
+					Name:      "abc",
+				}))
+			})
+
+			It("should return the user behind the request", func() {
+				user, groups, extras, err := app.UserInfo(req)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(user).To(Equal("user"))
+				Expect(groups).To(Equal([]string{"userGroup"}))
+				Expect(extras).To(Equal(map[string]authorization.ExtraValue{"test": {"userExtraValue"}}))
+			})
+
+			It("should check if the user behind the request may list the VMs", func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/apis/authorization.k8s.io/v1/subjectaccessreviews"),
+						func(w http.ResponseWriter, r *http.Request) {
+							review := &authorization.SubjectAccessReview{}
+							Expect(json.NewDecoder(r.Body).Decode(review)).To(Succeed())
+							Expect(review.Spec.User).To(Equal("user"))
+							Expect(review.Spec.ResourceAttributes).To(Equal(&authorization.ResourceAttributes{
+								Namespace: "default",
+								Verb:      "list",
+								Group:     "kubevirt.io",
+								Version:   "v1",
+								Resource:  "virtualmachines",
+							}))
+							review.Status.Reason = "no RBAC policy matched"
+							ghttp.RespondWithJSONEncoded(http.StatusOK, review)(w, r)
+						},
+					),
+				)
+
+				allowed, reason, err := app.AuthorizeList(req, "default", "virtualmachines")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(allowed).To(BeFalse())
+				Expect(reason).To(Equal("no RBAC policy matched"))
+			})
+		})
+
+		Context("Scheduling feasibility", func() {
+			It("should require to update the scheduling feasibility subresource", func() {
+				req.Request.Method = http.MethodPut
+				req.Request.URL.Path = "/apis/subresources.kubevirt.io/v1/namespaces/default/schedulingfeasibility"
+
+				result, err := app.generateAccessReview(req)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.Spec.User).To(Equal("user"))
+				Expect(result.Spec.ResourceAttributes).To(Equal(&authorization.ResourceAttributes{
+					Namespace: "default",
+					Verb:      "update",
+					Group:     "subresources.kubevirt.io",
+					Version:   "v1",
+					Resource:  "schedulingfeasibility",
+				}))
+			})
+		})
+
 		AfterEach(func() {
 			server.Close()
 		})
@@ -0,0 +1,221 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/emicklei/go-restful"
+	"k8s.io/apimachinery/pkg/api/errors"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+)
+
+func validateBulkOperation(action v1.BulkOperationAction, selector string) error {
+	switch action {
+	case v1.BulkStartAction, v1.BulkStopAction, v1.BulkMigrateAction:
+	default:
+		return fmt.Errorf("unknown action %q, must be one of %s, %s or %s", action, v1.BulkStartAction, v1.BulkStopAction, v1.BulkMigrateAction)
+	}
+	// an empty selector would match every VirtualMachine of the namespace,
+	// which is too easy to request by accident
+	if selector == "" {
+		return fmt.Errorf("a label selector is required")
+	}
+	if _, err := labels.Parse(selector); err != nil {
+		return fmt.Errorf("invalid label selector %q: %v", selector, err)
+	}
+	return nil
+}
+
+// BulkOperationRequestHandler requests to start, stop or migrate all VirtualMachines
+// matching a label selector. The operation is recorded in a VirtualMachineBulkOperation
+// together with the requesting user, virt-controller authorizes the user for every
+// single VirtualMachine and applies the action to it.
+func (app *SubresourceAPIApp) BulkOperationRequestHandler(request *restful.Request, response *restful.Response) {
+	namespace := request.PathParameter("namespace")
+
+	if request.Request.Body == nil {
+		writeError(errors.NewBadRequest("Request with no body, a bulk operation is required"), response)
+		return
+	}
+	opts := &v1.BulkOperationOptions{}
+	if err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(opts); err != nil {
+		writeError(errors.NewBadRequest(fmt.Sprintf("Can not unmarshal Request body to struct, error: %s", err)), response)
+		return
+	}
+	if err := validateBulkOperation(opts.Action, opts.Selector); err != nil {
+		writeError(errors.NewBadRequest(err.Error()), response)
+		return
+	}
+	parallelism := v1.DefaultBulkOperationParallelism
+	if opts.Parallelism != nil {
+		if *opts.Parallelism < 1 {
+			writeError(errors.NewBadRequest("parallelism must be at least 1"), response)
+			return
+		}
+		parallelism = *opts.Parallelism
+	}
+	if parallelism > v1.MaxBulkOperationParallelism {
+		parallelism = v1.MaxBulkOperationParallelism
+	}
+
+	user, groups, extras, err := app.authorizor.UserInfo(request)
+	if err != nil {
+		writeError(errors.NewBadRequest(err.Error()), response)
+		return
+	}
+
+	// The selector is resolved with the service account of virt-api, the user has to be
+	// allowed to list the VirtualMachines, otherwise the result would reveal their names
+	allowed, reason, err := app.authorizor.AuthorizeList(request, namespace, "virtualmachines")
+	if err != nil {
+		writeError(errors.NewInternalError(err), response)
+		return
+	} else if !allowed {
+		writeError(errors.NewForbidden(v1.Resource("virtualmachines"), "", fmt.Errorf("%s", reason)), response)
+		return
+	}
+
+	vms, err := app.virtCli.VirtualMachine(namespace).List(&k8smetav1.ListOptions{LabelSelector: opts.Selector})
+	if err != nil {
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+
+	operation := &v1.VirtualMachineBulkOperation{
+		ObjectMeta: k8smetav1.ObjectMeta{
+			GenerateName: fmt.Sprintf("bulk-%s-", opts.Action),
+		},
+		Spec: v1.VirtualMachineBulkOperationSpec{
+			Action:          opts.Action,
+			Selector:        opts.Selector,
+			Parallelism:     parallelism,
+			VirtualMachines: []string{},
+			User:            user,
+			Groups:          groups,
+		},
+	}
+	for _, vm := range vms.Items {
+		operation.Spec.VirtualMachines = append(operation.Spec.VirtualMachines, vm.Name)
+	}
+	if len(extras) > 0 {
+		operation.Spec.Extra = map[string][]string{}
+		for k, v := range extras {
+			operation.Spec.Extra[k] = v
+		}
+	}
+
+	operation, err = app.virtCli.VirtualMachineBulkOperation(namespace).Create(operation)
+	if err != nil {
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+
+	log.Log.Infof("Bulk operation %s %s on VirtualMachines matching %q in namespace %s accepted for %d VirtualMachines",
+		operation.Name, opts.Action, opts.Selector, namespace, len(operation.Spec.VirtualMachines))
+	response.WriteHeaderAndEntity(http.StatusAccepted, &v1.BulkOperation{
+		Handle:   operation.Name,
+		Action:   opts.Action,
+		Selector: opts.Selector,
+		Total:    int32(len(operation.Spec.VirtualMachines)),
+	})
+}
+
+// BulkOperationProgressRequestHandler computes the progress of a bulk operation from the
+// outcome recorded in the VirtualMachineBulkOperation and the current state of the
+// VirtualMachines and migrations the action was applied to.
+func (app *SubresourceAPIApp) BulkOperationProgressRequestHandler(request *restful.Request, response *restful.Response) {
+	namespace := request.PathParameter("namespace")
+	handle := request.PathParameter("handle")
+
+	operation, err := app.virtCli.VirtualMachineBulkOperation(namespace).Get(handle, &k8smetav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			writeError(errors.NewNotFound(v1.Resource("virtualmachinebulkoperation"), handle), response)
+			return
+		}
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+
+	result := &v1.BulkOperation{
+		Handle:   operation.Name,
+		Action:   operation.Spec.Action,
+		Selector: operation.Spec.Selector,
+		Total:    int32(len(operation.Spec.VirtualMachines)),
+		Failures: operation.Status.Failures,
+	}
+
+	switch operation.Spec.Action {
+	case v1.BulkStartAction, v1.BulkStopAction:
+		applied := map[string]bool{}
+		for _, name := range operation.Status.Applied {
+			applied[name] = true
+		}
+		vms, err := app.virtCli.VirtualMachine(namespace).List(&k8smetav1.ListOptions{LabelSelector: operation.Spec.Selector})
+		if err != nil {
+			writeError(errors.NewInternalError(err), response)
+			return
+		}
+		for _, vm := range vms.Items {
+			if !applied[vm.Name] {
+				continue
+			}
+			if (operation.Spec.Action == v1.BulkStartAction && vm.Status.Ready) || (operation.Spec.Action == v1.BulkStopAction && !vm.Status.Created) {
+				result.Completed++
+			}
+		}
+	case v1.BulkMigrateAction:
+		migrations, err := app.virtCli.VirtualMachineInstanceMigration(namespace).List(&k8smetav1.ListOptions{
+			LabelSelector: fmt.Sprintf("%s=%s", v1.BulkOperationLabel, operation.Name),
+		})
+		if err != nil {
+			writeError(errors.NewInternalError(err), response)
+			return
+		}
+		for _, migration := range migrations.Items {
+			switch migration.Status.Phase {
+			case v1.MigrationSucceeded:
+				result.Completed++
+			case v1.MigrationFailed:
+				result.Failures = append(result.Failures, v1.BulkOperationFailure{
+					Name:    migration.Spec.VMIName,
+					Message: fmt.Sprintf("migration %s failed", migration.Name),
+				})
+			}
+		}
+	}
+	sortBulkOperationFailures(result.Failures)
+
+	response.WriteEntity(result)
+}
+
+func sortBulkOperationFailures(failures []v1.BulkOperationFailure) {
+	sort.Slice(failures, func(i, j int) bool {
+		return failures[i].Name < failures[j].Name
+	})
+}
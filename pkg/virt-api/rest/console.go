@@ -23,6 +23,7 @@ func (app *SubresourceAPIApp) ConsoleRequestHandler(request *restful.Request, re
 			return conn.ConsoleURI(vmi)
 		}),
 	)
+	streamer.auditSession = app.sessionAuditor(request, consoleSession)
 
 	streamer.Handle(request, response)
 }
@@ -43,6 +43,9 @@ func ComposeAPIDefinitions() []*restful.WebService {
 	vmGVR := schema.GroupVersionResource{Group: v1.GroupVersion.Group, Version: v1.GroupVersion.Version, Resource: "virtualmachines"}
 	migrationGVR := schema.GroupVersionResource{Group: v1.GroupVersion.Group, Version: v1.GroupVersion.Version, Resource: "virtualmachineinstancemigrations"}
 	kubeVirtGVR := schema.GroupVersionResource{Group: v1.GroupVersion.Group, Version: v1.GroupVersion.Version, Resource: "kubevirt"}
+	hintsGVR := schema.GroupVersionResource{Group: v1.GroupVersion.Group, Version: v1.GroupVersion.Version, Resource: "virtualmachineschedulinghints"}
+	imageExportGVR := schema.GroupVersionResource{Group: v1.GroupVersion.Group, Version: v1.GroupVersion.Version, Resource: "virtualmachineimageexports"}
+	bulkOperationGVR := schema.GroupVersionResource{Group: v1.GroupVersion.Group, Version: v1.GroupVersion.Version, Resource: "virtualmachinebulkoperations"}
 
 	vmsGVR := snapshotv1.SchemeGroupVersion.WithResource("virtualmachinesnapshots")
 	vmscGVR := snapshotv1.SchemeGroupVersion.WithResource("virtualmachinesnapshotcontents")
@@ -82,6 +85,21 @@ func ComposeAPIDefinitions() []*restful.WebService {
 		panic(err)
 	}
 
+	ws, err = GenericResourceProxy(ws, hintsGVR, &v1.VirtualMachineSchedulingHints{}, v1.VirtualMachineSchedulingHintsGroupVersionKind.Kind, &v1.VirtualMachineSchedulingHintsList{})
+	if err != nil {
+		panic(err)
+	}
+
+	ws, err = GenericResourceProxy(ws, imageExportGVR, &v1.VirtualMachineImageExport{}, v1.VirtualMachineImageExportGroupVersionKind.Kind, &v1.VirtualMachineImageExportList{})
+	if err != nil {
+		panic(err)
+	}
+
+	ws, err = GenericResourceProxy(ws, bulkOperationGVR, &v1.VirtualMachineBulkOperation{}, v1.VirtualMachineBulkOperationGroupVersionKind.Kind, &v1.VirtualMachineBulkOperationList{})
+	if err != nil {
+		panic(err)
+	}
+
 	ws1, err := ResourceProxyAutodiscovery(vmiGVR)
 	if err != nil {
 		panic(err)
@@ -0,0 +1,470 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/emicklei/go-restful"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/util/hardware"
+	typesutil "kubevirt.io/kubevirt/pkg/util/types"
+)
+
+const kvmDevice = "devices.kubevirt.io/kvm"
+
+// schedulingFeasibilityCheck holds everything needed to decide if a single
+// node could run a VirtualMachine. It mirrors the constraints virt-controller
+// puts on the virt-launcher pod, without the overhead of the pod, so a node
+// passing the check is not guaranteed to fit the VirtualMachine.
+type schedulingFeasibilityCheck struct {
+	spec                *v1.VirtualMachineInstanceSpec
+	nodeSelector        map[string]string
+	cpuNodeDiscovery    bool
+	requests            k8sv1.ResourceList
+	volumeNodeSelectors map[string]*k8sv1.NodeSelector
+}
+
+func newSchedulingFeasibilityCheck(spec *v1.VirtualMachineInstanceSpec, clusterNodeSelectors map[string]string, allowEmulation bool, cpuNodeDiscovery bool) *schedulingFeasibilityCheck {
+	nodeSelector := map[string]string{
+		v1.NodeSchedulable: "true",
+	}
+	for k, v := range spec.NodeSelector {
+		nodeSelector[k] = v
+	}
+	for k, v := range clusterNodeSelectors {
+		nodeSelector[k] = v
+	}
+	if spec.Domain.CPU != nil && spec.Domain.CPU.DedicatedCPUPlacement {
+		nodeSelector[v1.CPUManager] = "true"
+	}
+
+	return &schedulingFeasibilityCheck{
+		spec:                spec,
+		nodeSelector:        nodeSelector,
+		cpuNodeDiscovery:    cpuNodeDiscovery,
+		requests:            schedulingRequests(spec, allowEmulation),
+		volumeNodeSelectors: map[string]*k8sv1.NodeSelector{},
+	}
+}
+
+// schedulingRequests returns the resources a node needs to have available to run the VirtualMachine
+func schedulingRequests(spec *v1.VirtualMachineInstanceSpec, allowEmulation bool) k8sv1.ResourceList {
+	requests := k8sv1.ResourceList{}
+
+	if cpu, ok := spec.Domain.Resources.Requests[k8sv1.ResourceCPU]; ok {
+		requests[k8sv1.ResourceCPU] = cpu
+	} else if spec.Domain.CPU != nil && spec.Domain.CPU.DedicatedCPUPlacement {
+		requests[k8sv1.ResourceCPU] = *resource.NewQuantity(hardware.GetNumberOfVCPUs(spec.Domain.CPU), resource.DecimalSI)
+	}
+
+	memory, hasMemory := spec.Domain.Resources.Requests[k8sv1.ResourceMemory]
+	if spec.Domain.Memory != nil && spec.Domain.Memory.Guest != nil {
+		memory = *spec.Domain.Memory.Guest
+		hasMemory = true
+	}
+	if hasMemory {
+		// with hugepages the guest memory is taken from the hugepages of the node
+		if spec.Domain.Memory != nil && spec.Domain.Memory.Hugepages != nil {
+			requests[k8sv1.ResourceName(k8sv1.ResourceHugePagesPrefix+spec.Domain.Memory.Hugepages.PageSize)] = memory
+		} else {
+			requests[k8sv1.ResourceMemory] = memory
+		}
+	}
+
+	if !allowEmulation {
+		requests[kvmDevice] = resource.MustParse("1")
+	}
+
+	addDevice := func(deviceName string) {
+		quantity := requests[k8sv1.ResourceName(deviceName)]
+		quantity.Add(resource.MustParse("1"))
+		requests[k8sv1.ResourceName(deviceName)] = quantity
+	}
+	for _, gpu := range spec.Domain.Devices.GPUs {
+		addDevice(gpu.DeviceName)
+	}
+	for _, hostDevice := range spec.Domain.Devices.HostDevices {
+		addDevice(hostDevice.DeviceName)
+	}
+
+	return requests
+}
+
+// loadVolumeNodeSelectors looks up the PersistentVolumes backing the volumes of the
+// VirtualMachine in the informer caches and remembers the nodes they can be accessed from.
+// Constraints which no node can satisfy, like a missing PersistentVolumeClaim, are returned.
+func (c *schedulingFeasibilityCheck) loadVolumeNodeSelectors(pvcStore cache.Store, pvStore cache.Store, namespace string, dataVolumeTemplates []v1.DataVolumeTemplateSpec) ([]string, error) {
+	var failedConstraints []string
+
+	templates := map[string]bool{}
+	for _, template := range dataVolumeTemplates {
+		templates[template.Name] = true
+	}
+
+	for i := range c.spec.Volumes {
+		volume := &c.spec.Volumes[i]
+		claimName := typesutil.PVCNameFromVirtVolume(volume)
+		if claimName == "" {
+			continue
+		}
+		obj, exists, err := pvcStore.GetByKey(namespace + "/" + claimName)
+		if err != nil {
+			return nil, err
+		} else if !exists {
+			// DataVolumes created from the templates don't exist yet, their
+			// PersistentVolumes are provisioned for the node the VM lands on
+			if volume.DataVolume == nil || !templates[claimName] {
+				failedConstraints = append(failedConstraints, fmt.Sprintf("volume %s: persistentvolumeclaim %s does not exist", volume.Name, claimName))
+			}
+			continue
+		}
+		pvc := obj.(*k8sv1.PersistentVolumeClaim)
+		if pvc.Spec.VolumeName == "" {
+			continue
+		}
+		obj, exists, err = pvStore.GetByKey(pvc.Spec.VolumeName)
+		if err != nil {
+			return nil, err
+		} else if !exists {
+			// the claim is bound to a volume which is not in the cache yet
+			continue
+		}
+		pv := obj.(*k8sv1.PersistentVolume)
+		if pv.Spec.NodeAffinity != nil && pv.Spec.NodeAffinity.Required != nil {
+			c.volumeNodeSelectors[volume.Name] = pv.Spec.NodeAffinity.Required
+		}
+	}
+
+	return failedConstraints, nil
+}
+
+// checkNode returns the constraints of the VirtualMachine, apart from the resources, the node does not satisfy
+func (c *schedulingFeasibilityCheck) checkNode(node *k8sv1.Node) []string {
+	var failedConstraints []string
+
+	if node.Spec.Unschedulable {
+		failedConstraints = append(failedConstraints, "node is cordoned")
+	}
+
+	for _, key := range sortedKeys(c.nodeSelector) {
+		if value, ok := node.Labels[key]; !ok || value != c.nodeSelector[key] {
+			failedConstraints = append(failedConstraints, fmt.Sprintf("node selector %s=%s does not match", key, c.nodeSelector[key]))
+		}
+	}
+
+	if c.spec.Affinity != nil && c.spec.Affinity.NodeAffinity != nil && c.spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil {
+		if !nodeMatchesNodeSelector(node, c.spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution) {
+			failedConstraints = append(failedConstraints, "required node affinity does not match")
+		}
+	}
+
+	for i := range node.Spec.Taints {
+		taint := &node.Spec.Taints[i]
+		// the taint of cordoned nodes is already reported above
+		if taint.Key == k8sv1.TaintNodeUnschedulable || taint.Effect == k8sv1.TaintEffectPreferNoSchedule {
+			continue
+		}
+		if !toleratesTaint(c.spec.Tolerations, taint) {
+			// only the key is reported, the value of the taint may carry details of the node
+			failedConstraints = append(failedConstraints, fmt.Sprintf("taint %s:%s is not tolerated", taint.Key, taint.Effect))
+		}
+	}
+
+	failedConstraints = append(failedConstraints, c.checkCPU(node)...)
+
+	for _, volume := range c.spec.Volumes {
+		if nodeSelector, ok := c.volumeNodeSelectors[volume.Name]; ok && !nodeMatchesNodeSelector(node, nodeSelector) {
+			failedConstraints = append(failedConstraints, fmt.Sprintf("volume %s is not accessible from the node", volume.Name))
+		}
+	}
+
+	return failedConstraints
+}
+
+// checkResources returns the requested resources which exceed the available resources of a node,
+// which are the resources not yet requested by other pods
+func (c *schedulingFeasibilityCheck) checkResources(available k8sv1.ResourceList) []string {
+	var failedConstraints []string
+	for _, name := range sortedResourceNames(c.requests) {
+		requested := c.requests[name]
+		free, ok := available[name]
+		if !ok || free.Cmp(requested) < 0 {
+			failedConstraints = append(failedConstraints, fmt.Sprintf("insufficient %s: requested %s", name, requested.String()))
+		}
+	}
+	return failedConstraints
+}
+
+func (c *schedulingFeasibilityCheck) checkCPU(node *k8sv1.Node) []string {
+	cpu := c.spec.Domain.CPU
+	if !c.cpuNodeDiscovery || cpu == nil {
+		return nil
+	}
+
+	var failedConstraints []string
+	if cpu.Model != "" && cpu.Model != v1.CPUModeHostModel && cpu.Model != v1.CPUModeHostPassthrough {
+		if node.Labels[v1.CPUModelLabel+cpu.Model] != "true" {
+			failedConstraints = append(failedConstraints, fmt.Sprintf("cpu model %s is not supported", cpu.Model))
+		}
+	}
+	for _, feature := range cpu.Features {
+		value, supported := node.Labels[v1.CPUFeatureLabel+feature.Name]
+		switch feature.Policy {
+		case "", "require":
+			if value != "true" {
+				failedConstraints = append(failedConstraints, fmt.Sprintf("required cpu feature %s is not supported", feature.Name))
+			}
+		case "forbid":
+			if supported {
+				failedConstraints = append(failedConstraints, fmt.Sprintf("forbidden cpu feature %s is supported", feature.Name))
+			}
+		}
+	}
+	return failedConstraints
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedResourceNames(resources k8sv1.ResourceList) []k8sv1.ResourceName {
+	names := make([]k8sv1.ResourceName, 0, len(resources))
+	for name := range resources {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return names[i] < names[j]
+	})
+	return names
+}
+
+func toleratesTaint(tolerations []k8sv1.Toleration, taint *k8sv1.Taint) bool {
+	for i := range tolerations {
+		if tolerations[i].ToleratesTaint(taint) {
+			return true
+		}
+	}
+	return false
+}
+
+// nodeMatchesNodeSelector follows the semantics of the scheduler: the terms are
+// ORed, the requirements of a single term are ANDed and empty terms match nothing.
+func nodeMatchesNodeSelector(node *k8sv1.Node, nodeSelector *k8sv1.NodeSelector) bool {
+	for _, term := range nodeSelector.NodeSelectorTerms {
+		if len(term.MatchExpressions) == 0 && len(term.MatchFields) == 0 {
+			continue
+		}
+		if matchesRequirements(node.Labels, term.MatchExpressions) &&
+			matchesRequirements(map[string]string{"metadata.name": node.Name}, term.MatchFields) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesRequirements(values map[string]string, requirements []k8sv1.NodeSelectorRequirement) bool {
+	selector := labels.NewSelector()
+	for _, requirement := range requirements {
+		var op selection.Operator
+		switch requirement.Operator {
+		case k8sv1.NodeSelectorOpIn:
+			op = selection.In
+		case k8sv1.NodeSelectorOpNotIn:
+			op = selection.NotIn
+		case k8sv1.NodeSelectorOpExists:
+			op = selection.Exists
+		case k8sv1.NodeSelectorOpDoesNotExist:
+			op = selection.DoesNotExist
+		case k8sv1.NodeSelectorOpGt:
+			op = selection.GreaterThan
+		case k8sv1.NodeSelectorOpLt:
+			op = selection.LessThan
+		default:
+			return false
+		}
+		r, err := labels.NewRequirement(requirement.Key, op, requirement.Values)
+		if err != nil {
+			return false
+		}
+		selector = selector.Add(*r)
+	}
+	return selector.Matches(labels.Set(values))
+}
+
+// availableResources subtracts the requests of the pods from the allocatable resources of the node
+func availableResources(node *k8sv1.Node, pods []*k8sv1.Pod) k8sv1.ResourceList {
+	available := node.Status.Allocatable.DeepCopy()
+	if available == nil {
+		available = k8sv1.ResourceList{}
+	}
+	for _, pod := range pods {
+		for name, quantity := range podRequests(pod) {
+			subtractResource(available, name, quantity)
+		}
+	}
+	return available
+}
+
+// podRequests returns the resources the scheduler accounts a pod for: the larger of the sum
+// of its containers and its largest init container, plus the overhead of the pod
+func podRequests(pod *k8sv1.Pod) k8sv1.ResourceList {
+	requests := k8sv1.ResourceList{}
+	for i := range pod.Spec.Containers {
+		for name, quantity := range containerRequests(&pod.Spec.Containers[i]) {
+			addResource(requests, name, quantity)
+		}
+	}
+	for i := range pod.Spec.InitContainers {
+		for name, quantity := range containerRequests(&pod.Spec.InitContainers[i]) {
+			if current, ok := requests[name]; !ok || quantity.Cmp(current) > 0 {
+				requests[name] = quantity
+			}
+		}
+	}
+	for name, quantity := range pod.Spec.Overhead {
+		addResource(requests, name, quantity)
+	}
+	return requests
+}
+
+func containerRequests(container *k8sv1.Container) k8sv1.ResourceList {
+	requests := k8sv1.ResourceList{}
+	for name, quantity := range container.Resources.Requests {
+		requests[name] = quantity
+	}
+	for name, quantity := range container.Resources.Limits {
+		// extended resources only have limits
+		if _, ok := requests[name]; !ok {
+			requests[name] = quantity
+		}
+	}
+	return requests
+}
+
+func addResource(resources k8sv1.ResourceList, name k8sv1.ResourceName, quantity resource.Quantity) {
+	current := resources[name]
+	current.Add(quantity)
+	resources[name] = current
+}
+
+func subtractResource(resources k8sv1.ResourceList, name k8sv1.ResourceName, quantity resource.Quantity) {
+	if current, ok := resources[name]; ok {
+		current.Sub(quantity)
+		resources[name] = current
+	}
+}
+
+// SchedulingFeasibilityRequestHandler reports if any node could currently run the
+// VirtualMachine described by the request and which constraints the nodes fail.
+func (app *SubresourceAPIApp) SchedulingFeasibilityRequestHandler(request *restful.Request, response *restful.Response) {
+	namespace := request.PathParameter("namespace")
+
+	if request.Request.Body == nil {
+		writeError(errors.NewBadRequest("Request with no body, a VirtualMachine spec is required"), response)
+		return
+	}
+	opts := &v1.SchedulingFeasibilityOptions{}
+	if err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(opts); err != nil {
+		writeError(errors.NewBadRequest(fmt.Sprintf("Can not unmarshal Request body to struct, error: %s", err)), response)
+		return
+	}
+	if opts.Spec.Template == nil {
+		writeError(errors.NewBadRequest("the VirtualMachine spec has no template"), response)
+		return
+	}
+
+	check := newSchedulingFeasibilityCheck(&opts.Spec.Template.Spec,
+		app.clusterConfig.GetNodeSelectors(),
+		app.clusterConfig.AllowEmulation(),
+		app.clusterConfig.CPUNodeDiscoveryEnabled(),
+	)
+
+	result := &v1.SchedulingFeasibility{}
+	failedConstraints, err := check.loadVolumeNodeSelectors(app.pvcInformer.GetStore(), app.pvInformer.GetStore(), namespace, opts.Spec.DataVolumeTemplates)
+	if err != nil {
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+	result.FailedConstraints = failedConstraints
+
+	// Nodes and pods are served from the informer caches. The response only contains the
+	// distinct constraints which are not satisfied, neither the nodes nor how many exist.
+	feasibleNodes := 0
+	nodeFailures := map[string]bool{}
+	nodes := app.nodeInformer.GetStore().List()
+	for _, obj := range nodes {
+		node := obj.(*k8sv1.Node)
+		failed := check.checkNode(node)
+		// the resources are only compared on nodes which satisfy all other constraints
+		if len(failed) == 0 {
+			pods, err := app.nodePods(node.Name)
+			if err != nil {
+				writeError(errors.NewInternalError(err), response)
+				return
+			}
+			failed = check.checkResources(availableResources(node, pods))
+		}
+		if len(failed) == 0 {
+			feasibleNodes++
+		}
+		for _, constraint := range failed {
+			nodeFailures[constraint] = true
+		}
+	}
+
+	for constraint := range nodeFailures {
+		result.NodeFailures = append(result.NodeFailures, constraint)
+	}
+	sort.Strings(result.NodeFailures)
+	result.Feasible = feasibleNodes > 0 && len(result.FailedConstraints) == 0
+
+	log.Log.V(4).Infof("Scheduling feasibility in namespace %s checked against %d nodes, feasible: %t", namespace, len(nodes), result.Feasible)
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+// nodePods returns the active pods of a node from the informer cache
+func (app *SubresourceAPIApp) nodePods(nodeName string) ([]*k8sv1.Pod, error) {
+	objs, err := app.activePodInformer.GetIndexer().ByIndex("node", nodeName)
+	if err != nil {
+		return nil, err
+	}
+	pods := make([]*k8sv1.Pod, 0, len(objs))
+	for _, obj := range objs {
+		pods = append(pods, obj.(*k8sv1.Pod))
+	}
+	return pods, nil
+}
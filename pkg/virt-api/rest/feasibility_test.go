@@ -0,0 +1,448 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/emicklei/go-restful"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+var _ = Describe("Scheduling feasibility", func() {
+
+	newNode := func(name string, cpu, memory string) *k8sv1.Node {
+		return &k8sv1.Node{
+			ObjectMeta: k8smetav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{v1.NodeSchedulable: "true"},
+			},
+			Status: k8sv1.NodeStatus{
+				Allocatable: k8sv1.ResourceList{
+					k8sv1.ResourceCPU:    resource.MustParse(cpu),
+					k8sv1.ResourceMemory: resource.MustParse(memory),
+					kvmDevice:            resource.MustParse("110"),
+				},
+			},
+		}
+	}
+
+	newPod := func(cpu, memory string) *k8sv1.Pod {
+		return &k8sv1.Pod{
+			Spec: k8sv1.PodSpec{
+				Containers: []k8sv1.Container{{
+					Resources: k8sv1.ResourceRequirements{
+						Requests: k8sv1.ResourceList{
+							k8sv1.ResourceCPU:    resource.MustParse(cpu),
+							k8sv1.ResourceMemory: resource.MustParse(memory),
+						},
+						Limits: k8sv1.ResourceList{
+							kvmDevice: resource.MustParse("1"),
+						},
+					},
+				}},
+			},
+		}
+	}
+
+	newSpec := func(cpu, memory string) *v1.VirtualMachineInstanceSpec {
+		return &v1.VirtualMachineInstanceSpec{
+			Domain: v1.DomainSpec{
+				Resources: v1.ResourceRequirements{
+					Requests: k8sv1.ResourceList{
+						k8sv1.ResourceCPU:    resource.MustParse(cpu),
+						k8sv1.ResourceMemory: resource.MustParse(memory),
+					},
+				},
+			},
+		}
+	}
+
+	Context("checking a node", func() {
+		It("should accept a node satisfying all constraints", func() {
+			check := newSchedulingFeasibilityCheck(newSpec("1", "1Gi"), nil, false, false)
+			Expect(check.checkNode(newNode("node01", "4", "8Gi"))).To(BeEmpty())
+		})
+
+		It("should report cordoned nodes and non tolerated taints", func() {
+			node := newNode("node01", "4", "8Gi")
+			node.Spec.Unschedulable = true
+			node.Spec.Taints = []k8sv1.Taint{
+				{Key: k8sv1.TaintNodeUnschedulable, Effect: k8sv1.TaintEffectNoSchedule},
+				{Key: "dedicated", Value: "db", Effect: k8sv1.TaintEffectNoSchedule},
+				{Key: "preferred", Effect: k8sv1.TaintEffectPreferNoSchedule},
+			}
+			check := newSchedulingFeasibilityCheck(newSpec("1", "1Gi"), nil, false, false)
+			Expect(check.checkNode(node)).To(Equal([]string{
+				"node is cordoned",
+				"taint dedicated:NoSchedule is not tolerated",
+			}))
+		})
+
+		It("should accept tolerated taints", func() {
+			node := newNode("node01", "4", "8Gi")
+			node.Spec.Taints = []k8sv1.Taint{{Key: "dedicated", Value: "db", Effect: k8sv1.TaintEffectNoSchedule}}
+			spec := newSpec("1", "1Gi")
+			spec.Tolerations = []k8sv1.Toleration{{Key: "dedicated", Operator: k8sv1.TolerationOpEqual, Value: "db", Effect: k8sv1.TaintEffectNoSchedule}}
+			check := newSchedulingFeasibilityCheck(spec, nil, false, false)
+			Expect(check.checkNode(node)).To(BeEmpty())
+		})
+
+		It("should report node selectors of the VM and the cluster which do not match", func() {
+			spec := newSpec("1", "1Gi")
+			spec.NodeSelector = map[string]string{"disktype": "ssd"}
+			check := newSchedulingFeasibilityCheck(spec, map[string]string{"zone": "a"}, false, false)
+			Expect(check.checkNode(newNode("node01", "4", "8Gi"))).To(Equal([]string{
+				"node selector disktype=ssd does not match",
+				"node selector zone=a does not match",
+			}))
+		})
+
+		It("should report a required node affinity which does not match", func() {
+			spec := newSpec("1", "1Gi")
+			spec.Affinity = &k8sv1.Affinity{NodeAffinity: &k8sv1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &k8sv1.NodeSelector{
+					NodeSelectorTerms: []k8sv1.NodeSelectorTerm{{
+						MatchFields: []k8sv1.NodeSelectorRequirement{
+							{Key: "metadata.name", Operator: k8sv1.NodeSelectorOpIn, Values: []string{"node02"}},
+						},
+					}},
+				},
+			}}
+			check := newSchedulingFeasibilityCheck(spec, nil, false, false)
+			Expect(check.checkNode(newNode("node01", "4", "8Gi"))).To(Equal([]string{"required node affinity does not match"}))
+			Expect(check.checkNode(newNode("node02", "4", "8Gi"))).To(BeEmpty())
+		})
+
+		It("should report cpu models and features the node does not support", func() {
+			node := newNode("node01", "4", "8Gi")
+			node.Labels[v1.CPUModelLabel+"Haswell"] = "true"
+			node.Labels[v1.CPUFeatureLabel+"vmx"] = "true"
+			spec := newSpec("1", "1Gi")
+			spec.Domain.CPU = &v1.CPU{
+				Model: "Skylake",
+				Features: []v1.CPUFeature{
+					{Name: "avx512", Policy: "require"},
+					{Name: "vmx", Policy: "forbid"},
+				},
+			}
+			check := newSchedulingFeasibilityCheck(spec, nil, false, true)
+			Expect(check.checkNode(node)).To(Equal([]string{
+				"cpu model Skylake is not supported",
+				"required cpu feature avx512 is not supported",
+				"forbidden cpu feature vmx is supported",
+			}))
+
+			check = newSchedulingFeasibilityCheck(spec, nil, false, false)
+			Expect(check.checkNode(node)).To(BeEmpty())
+		})
+
+		It("should report volumes which are not accessible from the node", func() {
+			spec := newSpec("1", "1Gi")
+			spec.Volumes = []v1.Volume{{Name: "disk0"}}
+			check := newSchedulingFeasibilityCheck(spec, nil, false, false)
+			check.volumeNodeSelectors["disk0"] = &k8sv1.NodeSelector{
+				NodeSelectorTerms: []k8sv1.NodeSelectorTerm{{
+					MatchExpressions: []k8sv1.NodeSelectorRequirement{
+						{Key: "kubernetes.io/hostname", Operator: k8sv1.NodeSelectorOpIn, Values: []string{"node02"}},
+					},
+				}},
+			}
+			Expect(check.checkNode(newNode("node01", "4", "8Gi"))).To(Equal([]string{"volume disk0 is not accessible from the node"}))
+		})
+	})
+
+	table.DescribeTable("should match node selectors like the scheduler", func(terms []k8sv1.NodeSelectorTerm, matches bool) {
+		node := newNode("node01", "4", "8Gi")
+		node.Labels["zone"] = "a"
+		Expect(nodeMatchesNodeSelector(node, &k8sv1.NodeSelector{NodeSelectorTerms: terms})).To(Equal(matches))
+	},
+		table.Entry("with no terms", nil, false),
+		table.Entry("with an empty term", []k8sv1.NodeSelectorTerm{{}}, false),
+		table.Entry("with a matching expression", []k8sv1.NodeSelectorTerm{{
+			MatchExpressions: []k8sv1.NodeSelectorRequirement{{Key: "zone", Operator: k8sv1.NodeSelectorOpIn, Values: []string{"a", "b"}}},
+		}}, true),
+		table.Entry("with a non matching expression", []k8sv1.NodeSelectorTerm{{
+			MatchExpressions: []k8sv1.NodeSelectorRequirement{{Key: "zone", Operator: k8sv1.NodeSelectorOpNotIn, Values: []string{"a"}}},
+		}}, false),
+		table.Entry("with requirements of a term which only partially match", []k8sv1.NodeSelectorTerm{{
+			MatchExpressions: []k8sv1.NodeSelectorRequirement{
+				{Key: "zone", Operator: k8sv1.NodeSelectorOpExists},
+				{Key: "gpu", Operator: k8sv1.NodeSelectorOpExists},
+			},
+		}}, false),
+		table.Entry("with one of several terms matching", []k8sv1.NodeSelectorTerm{
+			{MatchExpressions: []k8sv1.NodeSelectorRequirement{{Key: "gpu", Operator: k8sv1.NodeSelectorOpExists}}},
+			{MatchExpressions: []k8sv1.NodeSelectorRequirement{{Key: "gpu", Operator: k8sv1.NodeSelectorOpDoesNotExist}}},
+		}, true),
+	)
+
+	Context("checking the resources of a node", func() {
+		It("should subtract the requests and extended resource limits of the pods", func() {
+			node := newNode("node01", "4", "8Gi")
+			available := availableResources(node, []*k8sv1.Pod{newPod("1", "2Gi"), newPod("500m", "1Gi")})
+			Expect(available.Cpu().String()).To(Equal("2500m"))
+			Expect(available.Memory().String()).To(Equal("5Gi"))
+			kvm := available[kvmDevice]
+			Expect(kvm.String()).To(Equal("108"))
+			Expect(node.Status.Allocatable.Cpu().String()).To(Equal("4"))
+		})
+
+		It("should account for the largest init container and the overhead of the pods", func() {
+			pod := newPod("1", "1Gi")
+			pod.Spec.Containers = append(pod.Spec.Containers, pod.Spec.Containers[0])
+			pod.Spec.InitContainers = []k8sv1.Container{
+				{Resources: k8sv1.ResourceRequirements{Requests: k8sv1.ResourceList{k8sv1.ResourceCPU: resource.MustParse("3")}}},
+				{Resources: k8sv1.ResourceRequirements{Requests: k8sv1.ResourceList{k8sv1.ResourceMemory: resource.MustParse("1Gi")}}},
+			}
+			pod.Spec.Overhead = k8sv1.ResourceList{
+				k8sv1.ResourceCPU:    resource.MustParse("100m"),
+				k8sv1.ResourceMemory: resource.MustParse("512Mi"),
+			}
+
+			available := availableResources(newNode("node01", "4", "8Gi"), []*k8sv1.Pod{pod})
+			Expect(available.Cpu().String()).To(Equal("900m"))
+			Expect(available.Memory().String()).To(Equal("5632Mi"))
+			kvm := available[kvmDevice]
+			Expect(kvm.String()).To(Equal("108"))
+		})
+
+		It("should report requests exceeding the available resources", func() {
+			check := newSchedulingFeasibilityCheck(newSpec("2", "4Gi"), nil, false, false)
+			Expect(check.checkResources(k8sv1.ResourceList{
+				k8sv1.ResourceCPU:    resource.MustParse("1"),
+				k8sv1.ResourceMemory: resource.MustParse("8Gi"),
+			})).To(Equal([]string{
+				"insufficient cpu: requested 2",
+				"insufficient devices.kubevirt.io/kvm: requested 1",
+			}))
+		})
+
+		It("should not require the kvm device with emulation", func() {
+			check := newSchedulingFeasibilityCheck(newSpec("1", "1Gi"), nil, true, false)
+			Expect(check.checkResources(k8sv1.ResourceList{
+				k8sv1.ResourceCPU:    resource.MustParse("1"),
+				k8sv1.ResourceMemory: resource.MustParse("1Gi"),
+			})).To(BeEmpty())
+		})
+
+		It("should request the guest memory from the hugepages of the node", func() {
+			spec := newSpec("1", "1Gi")
+			spec.Domain.Memory = &v1.Memory{Hugepages: &v1.Hugepages{PageSize: "2Mi"}}
+			requests := schedulingRequests(spec, true)
+			Expect(requests).To(HaveKey(k8sv1.ResourceName("hugepages-2Mi")))
+			Expect(requests).ToNot(HaveKey(k8sv1.ResourceMemory))
+		})
+	})
+
+	Context("handling requests", func() {
+		var server *ghttp.Server
+		var request *restful.Request
+		var recorder *httptest.ResponseRecorder
+		var response *restful.Response
+		var app *SubresourceAPIApp
+		var nodeInformer cache.SharedIndexInformer
+		var podInformer cache.SharedIndexInformer
+		var pvcInformer cache.SharedIndexInformer
+		var pvInformer cache.SharedIndexInformer
+
+		kv := &v1.KubeVirt{
+			ObjectMeta: k8smetav1.ObjectMeta{
+				Name:      "kubevirt",
+				Namespace: "kubevirt",
+			},
+			Spec: v1.KubeVirtSpec{
+				Configuration: v1.KubeVirtConfiguration{
+					DeveloperConfiguration: &v1.DeveloperConfiguration{},
+				},
+			},
+			Status: v1.KubeVirtStatus{
+				Phase: v1.KubeVirtPhaseDeploying,
+			},
+		}
+		config, _, _, _ := testutils.NewFakeClusterConfigUsingKV(kv)
+
+		BeforeEach(func() {
+			server = ghttp.NewServer()
+			virtClient, err := kubecli.GetKubevirtClientFromFlags(server.URL(), "")
+			Expect(err).ToNot(HaveOccurred())
+			nodeInformer, _ = testutils.NewFakeInformerFor(&k8sv1.Node{})
+			podInformer, _ = testutils.NewFakeInformerWithIndexersFor(&k8sv1.Pod{}, cache.Indexers{
+				"node": func(obj interface{}) ([]string, error) {
+					return []string{obj.(*k8sv1.Pod).Spec.NodeName}, nil
+				},
+			})
+			pvcInformer, _ = testutils.NewFakeInformerFor(&k8sv1.PersistentVolumeClaim{})
+			pvInformer, _ = testutils.NewFakeInformerFor(&k8sv1.PersistentVolume{})
+			app = &SubresourceAPIApp{
+				virtCli:           virtClient,
+				clusterConfig:     config,
+				nodeInformer:      nodeInformer,
+				activePodInformer: podInformer,
+				pvcInformer:       pvcInformer,
+				pvInformer:        pvInformer,
+			}
+
+			request = restful.NewRequest(&http.Request{})
+			request.PathParameters()["namespace"] = "default"
+			recorder = httptest.NewRecorder()
+			response = restful.NewResponse(recorder)
+			response.SetRequestAccepts(restful.MIME_JSON)
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		newNodePod := func(nodeName, cpu, memory string) *k8sv1.Pod {
+			pod := newPod(cpu, memory)
+			pod.Name = "pod-" + nodeName
+			pod.Spec.NodeName = nodeName
+			return pod
+		}
+
+		setBody := func(opts *v1.SchedulingFeasibilityOptions) {
+			body, err := json.Marshal(opts)
+			Expect(err).ToNot(HaveOccurred())
+			request.Request.Body = ioutil.NopCloser(bytes.NewReader(body))
+		}
+
+		It("should fail without a template", func() {
+			setBody(&v1.SchedulingFeasibilityOptions{})
+
+			app.SchedulingFeasibilityRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+		})
+
+		It("should only compare the resources of nodes satisfying all other constraints", func() {
+			setBody(&v1.SchedulingFeasibilityOptions{Spec: v1.VirtualMachineSpec{
+				Template: &v1.VirtualMachineInstanceTemplateSpec{Spec: *newSpec("2", "2Gi")},
+			}})
+			cordoned := newNode("node01", "4", "8Gi")
+			cordoned.Spec.Unschedulable = true
+			full := newNode("node02", "4", "8Gi")
+			free := newNode("node03", "4", "8Gi")
+
+			for _, node := range []*k8sv1.Node{cordoned, full, free} {
+				Expect(nodeInformer.GetStore().Add(node)).To(Succeed())
+			}
+			for _, pod := range []*k8sv1.Pod{
+				newNodePod("node01", "4", "1Gi"),
+				newNodePod("node02", "3", "1Gi"),
+				newNodePod("node03", "1", "1Gi"),
+			} {
+				Expect(podInformer.GetStore().Add(pod)).To(Succeed())
+			}
+
+			app.SchedulingFeasibilityRequestHandler(request, response)
+
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			Expect(server.ReceivedRequests()).To(BeEmpty())
+			result := &v1.SchedulingFeasibility{}
+			Expect(json.Unmarshal(recorder.Body.Bytes(), result)).To(Succeed())
+			Expect(result.Feasible).To(BeTrue())
+			Expect(result.NodeFailures).To(Equal([]string{
+				"insufficient cpu: requested 2",
+				"node is cordoned",
+			}))
+			Expect(recorder.Body.String()).ToNot(ContainSubstring("node01"))
+		})
+
+		It("should not be feasible if a persistentvolumeclaim is missing", func() {
+			spec := newSpec("1", "1Gi")
+			spec.Volumes = []v1.Volume{{
+				Name: "disk0",
+				VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "missing"}},
+				},
+			}}
+			setBody(&v1.SchedulingFeasibilityOptions{Spec: v1.VirtualMachineSpec{
+				Template: &v1.VirtualMachineInstanceTemplateSpec{Spec: *spec},
+			}})
+
+			Expect(nodeInformer.GetStore().Add(newNode("node01", "4", "8Gi"))).To(Succeed())
+
+			app.SchedulingFeasibilityRequestHandler(request, response)
+
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			result := &v1.SchedulingFeasibility{}
+			Expect(json.Unmarshal(recorder.Body.Bytes(), result)).To(Succeed())
+			Expect(result.Feasible).To(BeFalse())
+			Expect(result.FailedConstraints).To(Equal([]string{"volume disk0: persistentvolumeclaim missing does not exist"}))
+			Expect(result.NodeFailures).To(BeEmpty())
+		})
+
+		It("should look up the node affinity of persistentvolumes in the informer caches", func() {
+			spec := newSpec("1", "1Gi")
+			spec.Volumes = []v1.Volume{{
+				Name: "disk0",
+				VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "local"}},
+				},
+			}}
+			setBody(&v1.SchedulingFeasibilityOptions{Spec: v1.VirtualMachineSpec{
+				Template: &v1.VirtualMachineInstanceTemplateSpec{Spec: *spec},
+			}})
+			Expect(pvcInformer.GetStore().Add(&k8sv1.PersistentVolumeClaim{
+				ObjectMeta: k8smetav1.ObjectMeta{Name: "local", Namespace: "default"},
+				Spec:       k8sv1.PersistentVolumeClaimSpec{VolumeName: "local-pv"},
+			})).To(Succeed())
+			Expect(pvInformer.GetStore().Add(&k8sv1.PersistentVolume{
+				ObjectMeta: k8smetav1.ObjectMeta{Name: "local-pv"},
+				Spec: k8sv1.PersistentVolumeSpec{
+					NodeAffinity: &k8sv1.VolumeNodeAffinity{
+						Required: &k8sv1.NodeSelector{
+							NodeSelectorTerms: []k8sv1.NodeSelectorTerm{{
+								MatchFields: []k8sv1.NodeSelectorRequirement{{Key: "metadata.name", Operator: k8sv1.NodeSelectorOpIn, Values: []string{"node02"}}},
+							}},
+						},
+					},
+				},
+			})).To(Succeed())
+			Expect(nodeInformer.GetStore().Add(newNode("node01", "4", "8Gi"))).To(Succeed())
+
+			app.SchedulingFeasibilityRequestHandler(request, response)
+
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			Expect(server.ReceivedRequests()).To(BeEmpty())
+			result := &v1.SchedulingFeasibility{}
+			Expect(json.Unmarshal(recorder.Body.Bytes(), result)).To(Succeed())
+			Expect(result.Feasible).To(BeFalse())
+			Expect(result.FailedConstraints).To(BeEmpty())
+			Expect(result.NodeFailures).To(Equal([]string{"volume disk0 is not accessible from the node"}))
+		})
+	})
+})
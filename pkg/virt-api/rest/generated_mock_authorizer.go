@@ -6,6 +6,7 @@ package rest
 import (
 	go_restful "github.com/emicklei/go-restful"
 	gomock "github.com/golang/mock/gomock"
+	v1 "k8s.io/api/authorization/v1"
 )
 
 // Mock of VirtApiAuthorizor interface
@@ -41,6 +42,31 @@ func (_mr *_MockVirtApiAuthorizorRecorder) Authorize(arg0 interface{}) *gomock.C
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "Authorize", arg0)
 }
 
+func (_m *MockVirtApiAuthorizor) UserInfo(req *go_restful.Request) (string, []string, map[string]v1.ExtraValue, error) {
+	ret := _m.ctrl.Call(_m, "UserInfo", req)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].([]string)
+	ret2, _ := ret[2].(map[string]v1.ExtraValue)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+func (_mr *_MockVirtApiAuthorizorRecorder) UserInfo(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "UserInfo", arg0)
+}
+
+func (_m *MockVirtApiAuthorizor) AuthorizeList(req *go_restful.Request, namespace string, resource string) (bool, string, error) {
+	ret := _m.ctrl.Call(_m, "AuthorizeList", req, namespace, resource)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+func (_mr *_MockVirtApiAuthorizorRecorder) AuthorizeList(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "AuthorizeList", arg0, arg1, arg2)
+}
+
 func (_m *MockVirtApiAuthorizor) AddUserHeaders(header []string) {
 	_m.ctrl.Call(_m, "AddUserHeaders", header)
 }
@@ -21,6 +21,7 @@ func (app *SubresourceAPIApp) PortForwardRequestHandler(fetcher vmiFetcher) rest
 			validateVMIForPortForward,
 			netDialer(request),
 		)
+		streamer.auditSession = app.sessionAuditor(request, portForwardSession)
 
 		streamer.Handle(request, response)
 	}
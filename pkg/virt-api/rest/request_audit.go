@@ -0,0 +1,182 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	restful "github.com/emicklei/go-restful"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+
+	"kubevirt.io/client-go/log"
+	apimetrics "kubevirt.io/kubevirt/pkg/monitoring/api"
+)
+
+const (
+	requestTraceAttribute = "kubevirt.io/request-trace"
+
+	// slowSubresourceRequestThreshold is the time after which a subresource request, or the attach
+	// of a streaming subresource, is logged with a detailed trace
+	slowSubresourceRequestThreshold = 5 * time.Second
+)
+
+type requestObserver func(resource, subresource, method string, code int, duration time.Duration, traceID string)
+
+type requestTraceStep struct {
+	msg string
+	at  time.Time
+}
+
+// requestTrace collects the steps of a single subresource request. Handlers can mark steps on it
+// and streaming handlers finish it as soon as the stream is attached, since the request itself
+// only returns once the stream is closed.
+type requestTrace struct {
+	id     string
+	start  time.Time
+	steps  []requestTraceStep
+	finish func(trace *requestTrace, code int)
+	done   bool
+}
+
+func newRequestTrace(finish func(trace *requestTrace, code int)) *requestTrace {
+	return &requestTrace{
+		id:     utilrand.String(16),
+		start:  time.Now(),
+		finish: finish,
+	}
+}
+
+// traceFromRequest returns the trace of a request, or nil if the request is not traced.
+// All methods of requestTrace are safe to call on nil.
+func traceFromRequest(request *restful.Request) *requestTrace {
+	if trace, ok := request.Attribute(requestTraceAttribute).(*requestTrace); ok {
+		return trace
+	}
+	return nil
+}
+
+func (t *requestTrace) step(msg string) {
+	if t == nil || t.done {
+		return
+	}
+	t.steps = append(t.steps, requestTraceStep{msg: msg, at: time.Now()})
+}
+
+func (t *requestTrace) attached() {
+	if t == nil {
+		return
+	}
+	t.step("stream attached")
+	t.finished(http.StatusSwitchingProtocols)
+}
+
+func (t *requestTrace) finished(code int) {
+	if t == nil || t.done {
+		return
+	}
+	t.done = true
+	t.finish(t, code)
+}
+
+func (t *requestTrace) duration() time.Duration {
+	if len(t.steps) > 0 {
+		return t.steps[len(t.steps)-1].at.Sub(t.start)
+	}
+	return time.Since(t.start)
+}
+
+func (t *requestTrace) String() string {
+	steps := make([]string, 0, len(t.steps))
+	last := t.start
+	for _, step := range t.steps {
+		steps = append(steps, fmt.Sprintf("%q %dms", step.msg, step.at.Sub(last).Milliseconds()))
+		last = step.at
+	}
+	return strings.Join(steps, ", ")
+}
+
+// newRequestAuditFilter logs the requesting user, the outcome and the latency of every request to
+// a subresource of an object and records the latency with the observer. Requests slower than slowThreshold are logged
+// with all their steps and passed with their trace id to the observer.
+func newRequestAuditFilter(userHeaders func() []string, slowThreshold time.Duration, observe requestObserver) restful.FilterFunction {
+	return func(request *restful.Request, response *restful.Response, chain *restful.FilterChain) {
+		resource, subresource, ok := subresourceFromRoutePath(request.SelectedRoutePath())
+		if !ok {
+			chain.ProcessFilter(request, response)
+			return
+		}
+		method := request.Request.Method
+		user := getSessionUser(request, userHeaders())
+
+		trace := newRequestTrace(func(trace *requestTrace, code int) {
+			duration := trace.duration()
+			logger := log.Log.With(
+				"audit", "true",
+				"resource", resource,
+				"subresource", subresource,
+				"namespace", request.PathParameter(NamespaceParamName),
+				"name", request.PathParameter(NameParamName),
+				"method", method,
+				"user", user,
+				"statusCode", code,
+				"duration", duration.String(),
+			)
+			var traceID string
+			if duration > slowThreshold {
+				traceID = trace.id
+				logger.With("traceID", traceID, "steps", trace.String()).Warning("Slow subresource request")
+			} else {
+				logger.Info("Subresource request served")
+			}
+			observe(resource, subresource, method, code, duration, traceID)
+		})
+		request.SetAttribute(requestTraceAttribute, trace)
+
+		chain.ProcessFilter(request, response)
+		trace.step("request served")
+		trace.finished(response.StatusCode())
+	}
+}
+
+// subresourceFromRoutePath returns the resource and the subresource of a route path like
+// /apis/subresources.kubevirt.io/v1/namespaces/{namespace}/virtualmachineinstances/{name}/console,
+// it fails for routes which are not bound to an object, like healthz or version
+func subresourceFromRoutePath(path string) (resource, subresource string, ok bool) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, segment := range segments {
+		if isNameParamSegment(segment) && i > 0 && i+1 < len(segments) {
+			return segments[i-1], segments[i+1], true
+		}
+	}
+	return "", "", false
+}
+
+// isNameParamSegment matches the name parameter of a route path, with or without a pattern
+func isNameParamSegment(segment string) bool {
+	return segment == "{"+NameParamName+"}" || strings.HasPrefix(segment, "{"+NameParamName+":")
+}
+
+// RequestAuditFilter audits the subresource requests served by the app and traces slow ones
+func (app *SubresourceAPIApp) RequestAuditFilter() restful.FilterFunction {
+	return newRequestAuditFilter(app.userHeaders, slowSubresourceRequestThreshold, apimetrics.ObserveSubresourceRequest)
+}
@@ -0,0 +1,132 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	restful "github.com/emicklei/go-restful"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Request audit", func() {
+	type observation struct {
+		resource, subresource, method string
+		code                          int
+		duration                      time.Duration
+		traceID                       string
+	}
+
+	var (
+		container    *restful.Container
+		observations []observation
+	)
+
+	newContainer := func(slowThreshold time.Duration, handler restful.RouteFunction) {
+		ws := new(restful.WebService)
+		ws.Path("/apis/subresources.kubevirt.io/v1")
+		ws.Filter(newRequestAuditFilter(func() []string { return []string{"X-Remote-User"} }, slowThreshold,
+			func(resource, subresource, method string, code int, duration time.Duration, traceID string) {
+				observations = append(observations, observation{resource, subresource, method, code, duration, traceID})
+			}))
+		ws.Route(ws.GET("/namespaces/{namespace}/virtualmachineinstances/{name}/console").To(handler))
+		ws.Route(ws.GET("/healthz").To(handler))
+		container = restful.NewContainer()
+		container.Add(ws)
+	}
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("X-Remote-User", "alice")
+		recorder := httptest.NewRecorder()
+		container.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	BeforeEach(func() {
+		observations = nil
+	})
+
+	It("should observe the outcome of subresource requests", func() {
+		newContainer(time.Minute, func(request *restful.Request, response *restful.Response) {
+			response.WriteHeader(http.StatusConflict)
+		})
+
+		Expect(serve("/apis/subresources.kubevirt.io/v1/namespaces/default/virtualmachineinstances/testvmi/console").Code).To(Equal(http.StatusConflict))
+		Expect(observations).To(HaveLen(1))
+		Expect(observations[0].resource).To(Equal("virtualmachineinstances"))
+		Expect(observations[0].subresource).To(Equal("console"))
+		Expect(observations[0].method).To(Equal(http.MethodGet))
+		Expect(observations[0].code).To(Equal(http.StatusConflict))
+		Expect(observations[0].traceID).To(BeEmpty())
+	})
+
+	It("should observe streaming requests once they are attached", func() {
+		var observedOnAttach []observation
+		newContainer(time.Minute, func(request *restful.Request, response *restful.Response) {
+			trace := traceFromRequest(request)
+			trace.step("virt-handler dialed")
+			trace.attached()
+			observedOnAttach = append(observedOnAttach, observations...)
+		})
+
+		serve("/apis/subresources.kubevirt.io/v1/namespaces/default/virtualmachineinstances/testvmi/console")
+		Expect(observedOnAttach).To(HaveLen(1))
+		Expect(observedOnAttach[0].code).To(Equal(http.StatusSwitchingProtocols))
+		Expect(observations).To(HaveLen(1))
+	})
+
+	It("should pass the trace id of slow requests", func() {
+		newContainer(0, func(request *restful.Request, response *restful.Response) {
+			traceFromRequest(request).step("VMI fetched and validated")
+		})
+
+		serve("/apis/subresources.kubevirt.io/v1/namespaces/default/virtualmachineinstances/testvmi/console")
+		Expect(observations).To(HaveLen(1))
+		Expect(observations[0].traceID).ToNot(BeEmpty())
+	})
+
+	It("should not audit requests which are not bound to an object", func() {
+		var trace *requestTrace
+		newContainer(time.Minute, func(request *restful.Request, response *restful.Response) {
+			trace = traceFromRequest(request)
+		})
+
+		Expect(serve("/apis/subresources.kubevirt.io/v1/healthz").Code).To(Equal(http.StatusOK))
+		Expect(trace).To(BeNil())
+		Expect(observations).To(BeEmpty())
+	})
+
+	table.DescribeTable("should find the subresource of a route", func(path, expectedResource, expectedSubresource string, expectedOk bool) {
+		resource, subresource, ok := subresourceFromRoutePath(path)
+		Expect(ok).To(Equal(expectedOk))
+		Expect(resource).To(Equal(expectedResource))
+		Expect(subresource).To(Equal(expectedSubresource))
+	},
+		table.Entry("of a VMI", "/apis/subresources.kubevirt.io/v1/namespaces/{namespace}/virtualmachineinstances/{name}/vnc", "virtualmachineinstances", "vnc", true),
+		table.Entry("with patterns in the parameters", `/apis/subresources.kubevirt.io/v1/namespaces/{namespace:[a-z0-9][a-z0-9\-]*}/virtualmachineinstances/{name:[a-z0-9][a-z0-9\-]*}/console`, "virtualmachineinstances", "console", true),
+		table.Entry("with parameters", "/apis/subresources.kubevirt.io/v1/namespaces/{namespace}/virtualmachines/{name}/portforward/{port}", "virtualmachines", "portforward", true),
+		table.Entry("not bound to an object", "/apis/subresources.kubevirt.io/v1/healthz", "", "", false),
+	)
+})
@@ -0,0 +1,97 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"time"
+
+	restful "github.com/emicklei/go-restful"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+)
+
+type accessSessionType string
+
+const (
+	consoleSession     accessSessionType = "console"
+	vncSession         accessSessionType = "vnc"
+	portForwardSession accessSessionType = "portforward"
+	usbRedirSession    accessSessionType = "usbredir"
+)
+
+const (
+	// AccessSessionStartedReason is added in an event when an interactive access session to a VMI is established
+	AccessSessionStartedReason = "AccessSessionStarted"
+	// AccessSessionEndedReason is added in an event when an interactive access session to a VMI is closed
+	AccessSessionEndedReason = "AccessSessionEnded"
+
+	unknownSessionUser = "<unknown>"
+)
+
+// sessionAuditor is called once a streaming session to a VMI is established.
+// The returned function has to be called when the session ends.
+type sessionAuditor func(vmi *v1.VirtualMachineInstance) (sessionEnded func(err error))
+
+// newSessionAuditor records start, stop, requesting user and duration of an interactive
+// access session as events on the VMI and as audit log lines
+func newSessionAuditor(recorder record.EventRecorder, request *restful.Request, userHeaders []string, sessionType accessSessionType) sessionAuditor {
+	user := getSessionUser(request, userHeaders)
+	remoteAddr := request.Request.RemoteAddr
+
+	return func(vmi *v1.VirtualMachineInstance) func(err error) {
+		start := time.Now()
+		logger := log.Log.Object(vmi).With("audit", "true", "session", string(sessionType), "user", user, "remoteAddr", remoteAddr)
+		logger.Info("Access session started")
+		recorder.Eventf(vmi, k8sv1.EventTypeNormal, AccessSessionStartedReason, "User %s started a %s session", user, sessionType)
+
+		return func(err error) {
+			duration := time.Since(start).Round(time.Second)
+			endLogger := logger.With("duration", duration.String())
+			if err != nil {
+				endLogger = endLogger.Reason(err)
+			}
+			endLogger.Info("Access session ended")
+			recorder.Eventf(vmi, k8sv1.EventTypeNormal, AccessSessionEndedReason, "User %s ended a %s session after %s", user, sessionType, duration)
+		}
+	}
+}
+
+func (app *SubresourceAPIApp) sessionAuditor(request *restful.Request, sessionType accessSessionType) sessionAuditor {
+	return newSessionAuditor(app.recorder, request, app.userHeaders(), sessionType)
+}
+
+func (app *SubresourceAPIApp) userHeaders() []string {
+	if app.authorizor != nil {
+		return app.authorizor.GetUserHeaders()
+	}
+	return []string{userHeader}
+}
+
+func getSessionUser(request *restful.Request, userHeaders []string) string {
+	for _, key := range userHeaders {
+		if user := request.Request.Header.Get(key); user != "" {
+			return user
+		}
+	}
+	return unknownSessionUser
+}
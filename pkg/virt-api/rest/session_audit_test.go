@@ -0,0 +1,76 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	restful "github.com/emicklei/go-restful"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("Session audit", func() {
+	var (
+		recorder *record.FakeRecorder
+		request  *restful.Request
+		vmi      *v1.VirtualMachineInstance
+	)
+
+	BeforeEach(func() {
+		recorder = record.NewFakeRecorder(10)
+		httpReq := httptest.NewRequest(http.MethodGet, "/apis/subresources.kubevirt.io/v1/namespaces/default/virtualmachineinstances/testvmi/vnc", nil)
+		request = restful.NewRequest(httpReq)
+		vmi = &v1.VirtualMachineInstance{ObjectMeta: metav1.ObjectMeta{Name: "testvmi", Namespace: "default"}}
+	})
+
+	It("should record start and end of a session with the requesting user", func() {
+		request.Request.Header.Set("X-Remote-User", "alice")
+
+		sessionEnded := newSessionAuditor(recorder, request, []string{"X-Remote-User"}, vncSession)(vmi)
+		Expect(recorder.Events).To(Receive(And(
+			ContainSubstring(AccessSessionStartedReason),
+			ContainSubstring("User alice started a vnc session"),
+		)))
+
+		sessionEnded(nil)
+		Expect(recorder.Events).To(Receive(And(
+			ContainSubstring(AccessSessionEndedReason),
+			ContainSubstring("User alice ended a vnc session after"),
+		)))
+	})
+
+	It("should use the first configured user header which is present", func() {
+		request.Request.Header.Set("X-Other-User", "bob")
+
+		newSessionAuditor(recorder, request, []string{"X-Remote-User", "X-Other-User"}, consoleSession)(vmi)
+		Expect(recorder.Events).To(Receive(ContainSubstring("User bob started a console session")))
+	})
+
+	It("should record sessions of unknown users", func() {
+		newSessionAuditor(recorder, request, []string{"X-Remote-User"}, portForwardSession)(vmi)
+		Expect(recorder.Events).To(Receive(ContainSubstring("User " + unknownSessionUser + " started a portforward session")))
+	})
+})
@@ -26,6 +26,7 @@ type Streamer struct {
 	validateVMI     validator
 	dial            dialer
 	keepAliveClient func(ctx context.Context, conn *websocket.Conn, cancel func())
+	auditSession    sessionAuditor
 
 	streamToClient streamFunc
 	streamToServer streamFunc
@@ -68,22 +69,33 @@ func (s *Streamer) Handle(request *restful.Request, response *restful.Response)
 	namespace := request.PathParameter(NamespaceParamName)
 	name := request.PathParameter(NameParamName)
 
+	trace := traceFromRequest(request)
+
 	vmi, statusErr := s.fetchAndValidateVMI(namespace, name)
 	if statusErr != nil {
 		writeError(statusErr, response)
 		return statusErr
 	}
+	trace.step("VMI fetched and validated")
 
 	serverConn, statusErr := s.dial(vmi)
 	if statusErr != nil {
 		writeError(statusErr, response)
 		return statusErr
 	}
+	trace.step("virt-handler dialed")
 	clientConn, err := clientConnectionUpgrade(request, response)
 	if err != nil {
 		writeError(errors.NewBadRequest(err.Error()), response)
 		return err
 	}
+	trace.attached()
+
+	var result error
+	if s.auditSession != nil {
+		sessionEnded := s.auditSession(vmi)
+		defer func() { sessionEnded(result) }()
+	}
 
 	ctx, cancel := context.WithCancel(request.Request.Context())
 	defer cancel()
@@ -104,10 +116,11 @@ func (s *Streamer) Handle(request *restful.Request, response *restful.Response)
 	cancel()
 	result2 := <-results
 
-	if result1 != nil {
-		return result1
+	result = result1
+	if result == nil {
+		result = result2
 	}
-	return result2
+	return result
 }
 
 func (s *Streamer) fetchAndValidateVMI(namespace, name string) (*v1.VirtualMachineInstance, *errors.StatusError) {
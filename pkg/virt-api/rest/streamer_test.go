@@ -183,6 +183,34 @@ var _ = Describe("Streamer", func() {
 		defer ws.Close()
 		Eventually(call, defaultTestTimeout).Should(Receive())
 	})
+	It("audits the session once the client connection is established", func() {
+		started := make(chan *v1.VirtualMachineInstance, 1)
+		ended := make(chan struct{}, 1)
+		streamer.auditSession = func(vmi *v1.VirtualMachineInstance) func(error) {
+			started <- vmi
+			return func(_ error) {
+				ended <- struct{}{}
+			}
+		}
+		srv, ws, _, err := testWebsocketDial(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			handleErr := streamer.Handle(restful.NewRequest(r), restful.NewResponse(rw))
+			Expect(handleErr).NotTo(HaveOccurred())
+		}))
+		Expect(err).NotTo(HaveOccurred())
+		defer srv.Close()
+		defer ws.Close()
+		Eventually(started, defaultTestTimeout).Should(Receive(Equal(testVMI)))
+		Eventually(ended, defaultTestTimeout).Should(Receive())
+	})
+	It("does not audit the session if the client connection upgrade failed", func() {
+		called := false
+		streamer.auditSession = func(_ *v1.VirtualMachineInstance) func(error) {
+			called = true
+			return func(_ error) {}
+		}
+		Expect(streamer.Handle(req, resp)).To(HaveOccurred())
+		Expect(called).To(BeFalse())
+	})
 	It("does not call keepAliveClient if the client connection upgrade failed", func() {
 		call := make(chan struct{})
 		streamer.keepAliveClient = func(ctx context.Context, conn *websocket.Conn, _ func()) {
@@ -39,6 +39,8 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/json"
 	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 
 	"kubevirt.io/kubevirt/pkg/util/status"
 
@@ -56,9 +58,15 @@ type SubresourceAPIApp struct {
 	credentialsLock         *sync.Mutex
 	statusUpdater           *status.VMStatusUpdater
 	clusterConfig           *virtconfig.ClusterConfig
+	recorder                record.EventRecorder
+	authorizor              VirtApiAuthorizor
+	nodeInformer            cache.SharedIndexInformer
+	activePodInformer       cache.SharedIndexInformer
+	pvcInformer             cache.SharedIndexInformer
+	pvInformer              cache.SharedIndexInformer
 }
 
-func NewSubresourceAPIApp(virtCli kubecli.KubevirtClient, consoleServerPort int, tlsConfiguration *tls.Config, clusterConfig *virtconfig.ClusterConfig) *SubresourceAPIApp {
+func NewSubresourceAPIApp(virtCli kubecli.KubevirtClient, consoleServerPort int, tlsConfiguration *tls.Config, clusterConfig *virtconfig.ClusterConfig, recorder record.EventRecorder, authorizor VirtApiAuthorizor, nodeInformer cache.SharedIndexInformer, activePodInformer cache.SharedIndexInformer, pvcInformer cache.SharedIndexInformer, pvInformer cache.SharedIndexInformer) *SubresourceAPIApp {
 	return &SubresourceAPIApp{
 		virtCli:                 virtCli,
 		consoleServerPort:       consoleServerPort,
@@ -66,6 +74,12 @@ func NewSubresourceAPIApp(virtCli kubecli.KubevirtClient, consoleServerPort int,
 		handlerTLSConfiguration: tlsConfiguration,
 		statusUpdater:           status.NewVMStatusUpdater(virtCli),
 		clusterConfig:           clusterConfig,
+		recorder:                recorder,
+		authorizor:              authorizor,
+		nodeInformer:            nodeInformer,
+		activePodInformer:       activePodInformer,
+		pvcInformer:             pvcInformer,
+		pvInformer:              pvInformer,
 	}
 }
 
@@ -207,6 +221,12 @@ func getRunningJson(vm *v1.VirtualMachine, running bool) string {
 	}
 }
 
+func templateStartsPaused(vm *v1.VirtualMachine) bool {
+	return vm.Spec.Template != nil &&
+		vm.Spec.Template.Spec.StartStrategy != nil &&
+		*vm.Spec.Template.Spec.StartStrategy == v1.StartStrategyPaused
+}
+
 func getUpdateTerminatingSecondsGracePeriod(gracePeriod int64) string {
 	return fmt.Sprintf("{\"spec\":{\"terminationGracePeriodSeconds\": %d }}", gracePeriod)
 }
@@ -256,11 +276,89 @@ func (app *SubresourceAPIApp) MigrateVMRequestHandler(request *restful.Request,
 	response.WriteHeader(http.StatusAccepted)
 }
 
+// CancelMigrationRequestHandler requests the abort of an in-flight migration. The migration
+// object is kept, the migration controller fails it and records why it was aborted.
+func (app *SubresourceAPIApp) CancelMigrationRequestHandler(request *restful.Request, response *restful.Response) {
+	name := request.PathParameter("name")
+	namespace := request.PathParameter("namespace")
+
+	migration, err := app.virtCli.VirtualMachineInstanceMigration(namespace).Get(name, &k8smetav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			writeError(errors.NewNotFound(v1.Resource("virtualmachineinstancemigration"), name), response)
+			return
+		}
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+
+	if migration.IsFinal() {
+		writeError(errors.NewConflict(v1.Resource("virtualmachineinstancemigration"), name, fmt.Errorf("migration has already finished")), response)
+		return
+	}
+
+	for _, c := range migration.Status.Conditions {
+		if c.Type == v1.VirtualMachineInstanceMigrationAbortRequested {
+			// cancellation is already in progress
+			response.WriteHeader(http.StatusAccepted)
+			return
+		}
+	}
+
+	patch, err := generateMigrationCancelPatch(migration)
+	if err != nil {
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+
+	log.Log.Object(migration).V(4).Infof("Patching migration status: %s", patch)
+	if _, err := app.virtCli.VirtualMachineInstanceMigration(namespace).PatchStatus(name, types.JSONPatchType, []byte(patch)); err != nil {
+		log.Log.Object(migration).Reason(err).Error("unable to cancel migration")
+		if errors.IsConflict(err) || errors.IsInvalid(err) {
+			writeError(errors.NewConflict(v1.Resource("virtualmachineinstancemigration"), name, err), response)
+			return
+		}
+		writeError(errors.NewInternalError(fmt.Errorf("unable to cancel migration: %v", err)), response)
+		return
+	}
+
+	response.WriteHeader(http.StatusAccepted)
+}
+
+func generateMigrationCancelPatch(migration *v1.VirtualMachineInstanceMigration) (string, error) {
+	conditions := append([]v1.VirtualMachineInstanceMigrationCondition{}, migration.Status.Conditions...)
+	conditions = append(conditions, v1.VirtualMachineInstanceMigrationCondition{
+		Type:          v1.VirtualMachineInstanceMigrationAbortRequested,
+		Status:        v12.ConditionTrue,
+		LastProbeTime: k8smetav1.Now(),
+		Reason:        v1.MigrationCancelledReason,
+		Message:       "Migration cancellation was requested",
+	})
+
+	oldConditions, err := json.Marshal(migration.Status.Conditions)
+	if err != nil {
+		return "", err
+	}
+	newConditions, err := json.Marshal(conditions)
+	if err != nil {
+		return "", err
+	}
+
+	// guard against a concurrent update of the conditions
+	test := fmt.Sprintf(`{ "op": "test", "path": "/status/conditions", "value": %s }`, string(oldConditions))
+	add := fmt.Sprintf(`{ "op": "add", "path": "/status/conditions", "value": %s }`, string(newConditions))
+	if len(migration.Status.Conditions) == 0 {
+		return fmt.Sprintf("[ %s ]", add), nil
+	}
+	return fmt.Sprintf("[ %s, %s ]", test, add), nil
+}
+
 func (app *SubresourceAPIApp) RestartVMRequestHandler(request *restful.Request, response *restful.Response) {
 	// RunStrategyHalted         -> doesn't make sense
 	// RunStrategyManual         -> send restart request
 	// RunStrategyAlways         -> send restart request
 	// RunStrategyRerunOnFailure -> send restart request
+	// RunStrategyOnce           -> doesn't make sense
 	name := request.PathParameter("name")
 	namespace := request.PathParameter("namespace")
 
@@ -297,8 +395,8 @@ func (app *SubresourceAPIApp) RestartVMRequestHandler(request *restful.Request,
 		writeError(errors.NewInternalError(err), response)
 		return
 	}
-	if runStrategy == v1.RunStrategyHalted {
-		writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("%v does not support manual restart requests", v1.RunStrategyHalted)), response)
+	if runStrategy == v1.RunStrategyHalted || runStrategy == v1.RunStrategyOnce {
+		writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("%v does not support manual restart requests", runStrategy)), response)
 		return
 	}
 
@@ -440,12 +538,12 @@ func (app *SubresourceAPIApp) StartVMRequestHandler(request *restful.Request, re
 	// RunStrategyManual         -> send start request
 	// RunStrategyAlways         -> doesn't make sense
 	// RunStrategyRerunOnFailure -> doesn't make sense
+	// RunStrategyOnce           -> doesn't make sense
 	switch runStrategy {
 	case v1.RunStrategyHalted:
-		pausedStartStrategy := v1.StartStrategyPaused
 		// Send start request if VM should start paused. virt-controller will update RunStrategy upon this request.
 		// No need to send the request if StartStrategy is already set to Paused in VMI Spec.
-		if startPaused && (vm.Spec.Template == nil || vm.Spec.Template.Spec.StartStrategy != &pausedStartStrategy) {
+		if startPaused && !templateStartsPaused(vm) {
 			patchString, err := getChangeRequestJson(vm, v1.VirtualMachineStateChangeRequest{
 				Action: v1.StartRequest,
 				Data:   startChangeRequestData,
@@ -487,8 +585,8 @@ func (app *SubresourceAPIApp) StartVMRequestHandler(request *restful.Request, re
 		}
 		log.Log.Object(vm).V(4).Infof("Patching VM status: %s", bodyString)
 		patchErr = app.statusUpdater.PatchStatus(vm, types.JSONPatchType, []byte(bodyString))
-	case v1.RunStrategyAlways:
-		writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("%v does not support manual start requests", v1.RunStrategyAlways)), response)
+	case v1.RunStrategyAlways, v1.RunStrategyOnce:
+		writeError(errors.NewConflict(v1.Resource("virtualmachine"), name, fmt.Errorf("%v does not support manual start requests", runStrategy)), response)
 		return
 	}
 
@@ -509,6 +607,7 @@ func (app *SubresourceAPIApp) StopVMRequestHandler(request *restful.Request, res
 	// RunStrategyManual         -> send stop request
 	// RunStrategyAlways         -> spec.running = false
 	// RunStrategyRerunOnFailure -> spec.running = false
+	// RunStrategyOnce           -> spec.running = false
 
 	name := request.PathParameter("name")
 	namespace := request.PathParameter("namespace")
@@ -582,7 +681,7 @@ func (app *SubresourceAPIApp) StopVMRequestHandler(request *restful.Request, res
 		}
 		log.Log.Object(vm).V(4).Infof("Patching VM status: %s", bodyString)
 		patchErr = app.statusUpdater.PatchStatus(vm, patchType, []byte(bodyString))
-	case v1.RunStrategyRerunOnFailure, v1.RunStrategyAlways:
+	case v1.RunStrategyRerunOnFailure, v1.RunStrategyAlways, v1.RunStrategyOnce:
 		bodyString := getRunningJson(vm, false)
 		log.Log.Object(vm).V(4).Infof("Patching VM: %s", bodyString)
 		_, patchErr = app.virtCli.VirtualMachine(namespace).Patch(vm.GetName(), patchType, []byte(bodyString))
@@ -649,6 +748,9 @@ func (app *SubresourceAPIApp) FreezeVMIRequestHandler(request *restful.Request,
 		if vmi.Status.Phase != v1.Running {
 			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VM is not running"))
 		}
+		if controller.GuestAgentFeatureUnavailable(vmi, v1.GuestAgentFeatureFSFreeze) {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("The guest agent doesn't support freezing the filesystems"))
+		}
 		return nil
 	}
 
@@ -674,6 +776,118 @@ func (app *SubresourceAPIApp) UnfreezeVMIRequestHandler(request *restful.Request
 
 }
 
+func (app *SubresourceAPIApp) MemorySnapshotVMIRequestHandler(request *restful.Request, response *restful.Response) {
+
+	validate := func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+		if vmi.Status.Phase != v1.Running {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI is not running"))
+		}
+		return nil
+	}
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.MemorySnapshotURI(vmi)
+	}
+
+	opts := &v1.MemorySnapshotOptions{}
+	if request.Request.Body != nil {
+		err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(opts)
+		switch err {
+		case io.EOF, nil:
+			break
+		default:
+			writeError(errors.NewBadRequest(fmt.Sprintf("Can not unmarshal Request body to struct, error: %s", err)), response)
+			return
+		}
+	}
+
+	_, url, conn, statusErr := app.prepareConnection(request, validate, getURL)
+	if statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+
+	body, err := json.Marshal(opts)
+	if err != nil {
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+	resp, err := conn.PutWithBody(url, app.handlerTLSConfiguration, body)
+	if err != nil {
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+
+	uploadStatus := v1.MemorySnapshotUploadStatus{}
+	if err := json.Unmarshal([]byte(resp), &uploadStatus); err != nil {
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+	response.WriteHeaderAndJson(http.StatusOK, uploadStatus, restful.MIME_JSON)
+}
+
+func (app *SubresourceAPIApp) SetLinkStateVMIRequestHandler(request *restful.Request, response *restful.Response) {
+	opts := &v1.SetLinkStateOptions{}
+	if request.Request.Body == nil {
+		writeError(errors.NewBadRequest("Request with no body, link state options are required"), response)
+		return
+	}
+	err := yaml.NewYAMLOrJSONDecoder(request.Request.Body, 1024).Decode(opts)
+	switch err {
+	case io.EOF:
+		writeError(errors.NewBadRequest("Request with no body, link state options are required"), response)
+		return
+	case nil:
+	default:
+		writeError(errors.NewBadRequest(fmt.Sprintf("Can not unmarshal Request body to struct, error: %s", err)), response)
+		return
+	}
+	if opts.InterfaceName == "" {
+		writeError(errors.NewBadRequest("InterfaceName must be set"), response)
+		return
+	}
+	if opts.State != v1.InterfaceLinkStateUp && opts.State != v1.InterfaceLinkStateDown {
+		writeError(errors.NewBadRequest(fmt.Sprintf("State must be %s or %s", v1.InterfaceLinkStateUp, v1.InterfaceLinkStateDown)), response)
+		return
+	}
+
+	validate := func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+		if vmi.Status.Phase != v1.Running {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI is not running"))
+		}
+		for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+			if iface.Name != opts.InterfaceName {
+				continue
+			}
+			// SR-IOV interfaces are passed through as host devices, which have no link state
+			if iface.SRIOV != nil {
+				return errors.NewBadRequest(fmt.Sprintf("the link state of SR-IOV interface %s can not be set", iface.Name))
+			}
+			return nil
+		}
+		return errors.NewBadRequest(fmt.Sprintf("VMI %s has no interface %s", vmi.Name, opts.InterfaceName))
+	}
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.SetLinkStateURI(vmi)
+	}
+
+	_, url, conn, statusErr := app.prepareConnection(request, validate, getURL)
+	if statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+
+	body, err := json.Marshal(opts)
+	if err != nil {
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+	if _, err := conn.PutWithBody(url, app.handlerTLSConfiguration, body); err != nil {
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+	response.WriteHeader(http.StatusOK)
+}
+
 func (app *SubresourceAPIApp) fetchVirtualMachine(name string, namespace string) (*v1.VirtualMachine, *errors.StatusError) {
 
 	vm, err := app.virtCli.VirtualMachine(namespace).Get(name, &k8smetav1.GetOptions{})
@@ -820,6 +1034,73 @@ func (app *SubresourceAPIApp) UserList(request *restful.Request, response *restf
 	response.WriteEntity(userList)
 }
 
+// Usage handles the subresource for providing the current CPU and memory usage of a VMI
+func (app *SubresourceAPIApp) Usage(request *restful.Request, response *restful.Response) {
+	validate := func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+		if vmi == nil || vmi.Status.Phase != v1.Running {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI is not running"))
+		}
+		return nil
+	}
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.UsageURI(vmi)
+	}
+
+	_, url, conn, err := app.prepareConnection(request, validate, getURL)
+	if err != nil {
+		log.Log.Errorf("Cannot prepare connection %s", err.Error())
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	resp, conErr := conn.Get(url, app.handlerTLSConfiguration)
+	if conErr != nil {
+		log.Log.Errorf("Cannot GET request %s", conErr.Error())
+		response.WriteError(http.StatusInternalServerError, conErr)
+		return
+	}
+
+	usage := v1.VirtualMachineInstanceUsage{}
+	if err := json.Unmarshal([]byte(resp), &usage); err != nil {
+		log.Log.Reason(err).Error("error unmarshalling usage response")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	response.WriteEntity(usage)
+}
+
+// ScreenshotRequestHandler handles the subresource for providing a PNG screenshot of the graphical console of a VMI
+func (app *SubresourceAPIApp) ScreenshotRequestHandler(request *restful.Request, response *restful.Response) {
+	validate := func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
+		if vmi.Status.Phase != v1.Running {
+			return errors.NewConflict(v1.Resource("virtualmachineinstance"), vmi.Name, fmt.Errorf("VMI is not running"))
+		}
+		return validateVMIForVNC(vmi)
+	}
+	getURL := func(vmi *v1.VirtualMachineInstance, conn kubecli.VirtHandlerConn) (string, error) {
+		return conn.ScreenshotURI(vmi)
+	}
+
+	_, url, conn, statusErr := app.prepareConnection(request, validate, getURL)
+	if statusErr != nil {
+		writeError(statusErr, response)
+		return
+	}
+
+	screenshot, err := conn.Get(url, app.handlerTLSConfiguration)
+	if err != nil {
+		writeError(errors.NewInternalError(err), response)
+		return
+	}
+
+	response.AddHeader("Content-Type", "image/png")
+	response.WriteHeader(http.StatusOK)
+	if _, err := response.Write([]byte(screenshot)); err != nil {
+		log.Log.Reason(err).Error("Failed to write the screenshot")
+	}
+}
+
 // FilesystemList handles the subresource for providing guest filesystem list
 func (app *SubresourceAPIApp) FilesystemList(request *restful.Request, response *restful.Response) {
 	validate := func(vmi *v1.VirtualMachineInstance) *errors.StatusError {
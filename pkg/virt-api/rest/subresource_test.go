@@ -34,6 +34,7 @@ import (
 	"sync"
 
 	"github.com/emicklei/go-restful"
+	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
@@ -41,6 +42,7 @@ import (
 
 	"kubevirt.io/kubevirt/pkg/util/status"
 
+	authv1 "k8s.io/api/authorization/v1"
 	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -306,6 +308,47 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 
 		})
 
+		Context("Screenshot", func() {
+			It("should fail if the VMI is not running", func(done Done) {
+				request.PathParameters()["name"] = "testvmi"
+				request.PathParameters()["namespace"] = "default"
+
+				vmi := v1.NewMinimalVMI("testvmi")
+				vmi.Status.Phase = v1.Scheduling
+
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+					),
+				)
+				app.ScreenshotRequestHandler(request, response)
+				statusErr := ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+				Expect(statusErr.Error()).To(ContainSubstring("VMI is not running"))
+				close(done)
+			}, 5)
+
+			It("should fail without a graphics device", func(done Done) {
+				request.PathParameters()["name"] = "testvmi"
+				request.PathParameters()["namespace"] = "default"
+
+				flag := false
+				vmi := v1.NewMinimalVMI("testvmi")
+				vmi.Status.Phase = v1.Running
+				vmi.Spec.Domain.Devices.AutoattachGraphicsDevice = &flag
+
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+					),
+				)
+				app.ScreenshotRequestHandler(request, response)
+				ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+				close(done)
+			}, 5)
+		})
+
 		Context("PortForward", func() {
 			It("should fail with no 'name' path param", func(done Done) {
 
@@ -834,6 +877,7 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 			table.Entry("Manual", v1.RunStrategyManual, "VM is not running"),
 			table.Entry("RerunOnFailure", v1.RunStrategyRerunOnFailure, "VM is not running"),
 			table.Entry("Halted", v1.RunStrategyHalted, "Halted does not support manual restart requests"),
+			table.Entry("Once", v1.RunStrategyOnce, "Once does not support manual restart requests"),
 		)
 	})
 
@@ -1169,6 +1213,7 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 			table.Entry("Always without VMI", v1.RunStrategyAlways, v1.VmPhaseUnset, http.StatusNotFound, "Always does not support manual start requests"),
 			table.Entry("Always with VMI in phase Running", v1.RunStrategyAlways, v1.Running, http.StatusOK, "VM is already running"),
 			table.Entry("RerunOnFailure with VMI in phase Failed", v1.RunStrategyRerunOnFailure, v1.Failed, http.StatusOK, "RerunOnFailure does not support starting VM from failed state"),
+			table.Entry("Once with VMI in phase Succeeded", v1.RunStrategyOnce, v1.Succeeded, http.StatusOK, "Once does not support manual start requests"),
 		)
 
 		table.DescribeTable("should not fail on VM with RunStrategy ",
@@ -1351,6 +1396,7 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 			table.Entry("Always", v1.RunStrategyAlways),
 			table.Entry("RerunOnFailure", v1.RunStrategyRerunOnFailure),
 			table.Entry("Manual", v1.RunStrategyManual),
+			table.Entry("Once", v1.RunStrategyOnce),
 		)
 	})
 
@@ -1456,6 +1502,307 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 		})
 	})
 
+	Context("Subresource api - bulk operations", func() {
+		const vmsPath = "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines"
+		const migrationsPath = "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstancemigrations"
+		const operationsPath = "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachinebulkoperations"
+
+		var ctrl *gomock.Controller
+		var authorizor *MockVirtApiAuthorizor
+		var parallelism int32 = 200
+
+		newVM := func(name string) v1.VirtualMachine {
+			vm := newVirtualMachineWithRunStrategy(v1.RunStrategyAlways)
+			vm.Name = name
+			return *vm
+		}
+
+		bulkBody := func(opts *v1.BulkOperationOptions) io.ReadCloser {
+			optsJson, _ := json.Marshal(opts)
+			return &readCloserWrapper{bytes.NewReader(optsJson)}
+		}
+
+		expectBulkOperation := func(operation *v1.VirtualMachineBulkOperation) {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", operationsPath+"/"+operation.Name),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, operation),
+				),
+			)
+		}
+
+		BeforeEach(func() {
+			ctrl = gomock.NewController(GinkgoT())
+			authorizor = NewMockVirtApiAuthorizor(ctrl)
+			app.authorizor = authorizor
+			request.PathParameters()["namespace"] = "default"
+			// The results are written as entities, which requires an accepted content type
+			response.SetRequestAccepts(restful.MIME_JSON)
+		})
+
+		AfterEach(func() {
+			app.authorizor = nil
+			ctrl.Finish()
+		})
+
+		table.DescribeTable("should reject invalid requests", func(opts *v1.BulkOperationOptions, msg string) {
+			request.Request.Body = bulkBody(opts)
+
+			app.BulkOperationRequestHandler(request, response)
+
+			statusErr := ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+			Expect(statusErr.Error()).To(ContainSubstring(msg))
+		},
+			table.Entry("with an unknown action", &v1.BulkOperationOptions{Action: "pause", Selector: "app=db"}, "unknown action"),
+			table.Entry("without a selector", &v1.BulkOperationOptions{Action: v1.BulkStopAction}, "label selector is required"),
+			table.Entry("with an invalid selector", &v1.BulkOperationOptions{Action: v1.BulkStopAction, Selector: "app in (db"}, "invalid label selector"),
+			table.Entry("with zero parallelism", &v1.BulkOperationOptions{Action: v1.BulkStopAction, Selector: "app=db", Parallelism: new(int32)}, "parallelism must be at least 1"),
+		)
+
+		It("should record the operation on the matching VMs for the requesting user", func() {
+			request.Request.Body = bulkBody(&v1.BulkOperationOptions{Action: v1.BulkStopAction, Selector: "app=db", Parallelism: &parallelism})
+
+			var operation *v1.VirtualMachineBulkOperation
+			authorizor.EXPECT().UserInfo(request).Return("alice", []string{"dbas"}, map[string]authv1.ExtraValue{"scopes": {"all"}}, nil)
+			authorizor.EXPECT().AuthorizeList(request, "default", "virtualmachines").Return(true, "", nil)
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", vmsPath, "labelSelector=app%3Ddb"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, v1.VirtualMachineList{Items: []v1.VirtualMachine{newVM("vm1"), newVM("vm2")}}),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", operationsPath),
+					func(w http.ResponseWriter, r *http.Request) {
+						operation = &v1.VirtualMachineBulkOperation{}
+						Expect(json.NewDecoder(r.Body).Decode(operation)).To(Succeed())
+						operation.Name = operation.GenerateName + "abcde"
+					},
+					func(w http.ResponseWriter, r *http.Request) {
+						ghttp.RespondWithJSONEncoded(http.StatusCreated, operation)(w, r)
+					},
+				),
+			)
+
+			app.BulkOperationRequestHandler(request, response)
+
+			Expect(recorder.Code).To(Equal(http.StatusAccepted))
+			Expect(operation.Spec).To(Equal(v1.VirtualMachineBulkOperationSpec{
+				Action:          v1.BulkStopAction,
+				Selector:        "app=db",
+				Parallelism:     v1.MaxBulkOperationParallelism,
+				VirtualMachines: []string{"vm1", "vm2"},
+				User:            "alice",
+				Groups:          []string{"dbas"},
+				Extra:           map[string][]string{"scopes": {"all"}},
+			}), "the parallelism should be clamped")
+			result := &v1.BulkOperation{}
+			Expect(json.Unmarshal(recorder.Body.Bytes(), result)).To(Succeed())
+			Expect(result.Handle).To(Equal("bulk-stop-abcde"))
+			Expect(result.Action).To(Equal(v1.BulkStopAction))
+			Expect(result.Total).To(BeEquivalentTo(2))
+			Expect(result.Completed).To(BeZero())
+			Expect(result.Failures).To(BeEmpty())
+		})
+
+		It("should not resolve the selector if the user may not list the VMs", func() {
+			request.Request.Body = bulkBody(&v1.BulkOperationOptions{Action: v1.BulkStopAction, Selector: "app=db"})
+
+			authorizor.EXPECT().UserInfo(request).Return("alice", []string{"dbas"}, nil, nil)
+			authorizor.EXPECT().AuthorizeList(request, "default", "virtualmachines").Return(false, "no RBAC policy matched", nil)
+
+			app.BulkOperationRequestHandler(request, response)
+
+			statusErr := ExpectStatusErrorWithCode(recorder, http.StatusForbidden)
+			Expect(statusErr.Error()).To(ContainSubstring("no RBAC policy matched"))
+			Expect(server.ReceivedRequests()).To(BeEmpty())
+		})
+
+		It("should report the progress of a bulk stop", func() {
+			request.PathParameters()["handle"] = "bulk-stop-abcde"
+
+			stopped := newVM("vm1")
+			stopping := newVM("vm2")
+			stopping.Status.Created = true
+			expectBulkOperation(&v1.VirtualMachineBulkOperation{
+				ObjectMeta: k8smetav1.ObjectMeta{Name: "bulk-stop-abcde"},
+				Spec: v1.VirtualMachineBulkOperationSpec{
+					Action:          v1.BulkStopAction,
+					Selector:        "app=db",
+					VirtualMachines: []string{"vm1", "vm2", "vm4"},
+				},
+				Status: v1.VirtualMachineBulkOperationStatus{
+					Applied:  []string{"vm1", "vm2"},
+					Failures: []v1.BulkOperationFailure{{Name: "vm4", Message: "forbidden: not allowed"}},
+				},
+			})
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", vmsPath, "labelSelector=app%3Ddb"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, v1.VirtualMachineList{Items: []v1.VirtualMachine{stopped, stopping, newVM("vm3")}}),
+				),
+			)
+
+			app.BulkOperationProgressRequestHandler(request, response)
+
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			result := &v1.BulkOperation{}
+			Expect(json.Unmarshal(recorder.Body.Bytes(), result)).To(Succeed())
+			Expect(result.Total).To(BeEquivalentTo(3))
+			Expect(result.Completed).To(BeEquivalentTo(1), "only the VMs the operation stopped should count")
+			Expect(result.Failures).To(Equal([]v1.BulkOperationFailure{{Name: "vm4", Message: "forbidden: not allowed"}}))
+		})
+
+		It("should report the progress of a bulk migration", func() {
+			request.PathParameters()["handle"] = "bulk-migrate-abcde"
+
+			newMigration := func(vmName string, phase v1.VirtualMachineInstanceMigrationPhase) v1.VirtualMachineInstanceMigration {
+				return v1.VirtualMachineInstanceMigration{
+					ObjectMeta: k8smetav1.ObjectMeta{Name: "migration-" + vmName},
+					Spec:       v1.VirtualMachineInstanceMigrationSpec{VMIName: vmName},
+					Status:     v1.VirtualMachineInstanceMigrationStatus{Phase: phase},
+				}
+			}
+			expectBulkOperation(&v1.VirtualMachineBulkOperation{
+				ObjectMeta: k8smetav1.ObjectMeta{Name: "bulk-migrate-abcde"},
+				Spec: v1.VirtualMachineBulkOperationSpec{
+					Action:          v1.BulkMigrateAction,
+					Selector:        "app=db",
+					VirtualMachines: []string{"vm1", "vm2", "vm3", "vm4"},
+				},
+				Status: v1.VirtualMachineBulkOperationStatus{
+					Applied:  []string{"vm1", "vm2", "vm3"},
+					Failures: []v1.BulkOperationFailure{{Name: "vm4", Message: "VM is not running"}},
+				},
+			})
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", migrationsPath, "labelSelector=kubevirt.io%2Fbulk-operation%3Dbulk-migrate-abcde"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, v1.VirtualMachineInstanceMigrationList{Items: []v1.VirtualMachineInstanceMigration{
+						newMigration("vm1", v1.MigrationSucceeded),
+						newMigration("vm2", v1.MigrationFailed),
+						newMigration("vm3", v1.MigrationRunning),
+					}}),
+				),
+			)
+
+			app.BulkOperationProgressRequestHandler(request, response)
+
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			result := &v1.BulkOperation{}
+			Expect(json.Unmarshal(recorder.Body.Bytes(), result)).To(Succeed())
+			Expect(result.Total).To(BeEquivalentTo(4))
+			Expect(result.Completed).To(BeEquivalentTo(1))
+			Expect(result.Failures).To(Equal([]v1.BulkOperationFailure{
+				{Name: "vm2", Message: "migration migration-vm2 failed"},
+				{Name: "vm4", Message: "VM is not running"},
+			}))
+		})
+
+		It("should fail for an unknown handle", func() {
+			request.PathParameters()["handle"] = "unknown"
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", operationsPath+"/unknown"),
+					ghttp.RespondWithJSONEncoded(http.StatusNotFound, nil),
+				),
+			)
+
+			app.BulkOperationProgressRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusNotFound)
+		})
+	})
+
+	Context("Subresource api - CancelMigrationRequestHandler", func() {
+		const migrationPath = "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstancemigrations/testmigration"
+
+		BeforeEach(func() {
+			request.PathParameters()["name"] = "testmigration"
+			request.PathParameters()["namespace"] = "default"
+		})
+
+		It("should fail if the migration does not exist", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", migrationPath),
+					ghttp.RespondWithJSONEncoded(http.StatusNotFound, nil),
+				),
+			)
+
+			app.CancelMigrationRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusNotFound)
+		})
+
+		It("should fail if the migration has already finished", func() {
+			migration := v1.VirtualMachineInstanceMigration{
+				Status: v1.VirtualMachineInstanceMigrationStatus{Phase: v1.MigrationSucceeded},
+			}
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", migrationPath),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, migration),
+				),
+			)
+
+			app.CancelMigrationRequestHandler(request, response)
+
+			status := ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+			Expect(status.Error()).To(ContainSubstring("migration has already finished"))
+		})
+
+		It("should not patch a migration which is already being cancelled", func() {
+			migration := v1.VirtualMachineInstanceMigration{
+				Status: v1.VirtualMachineInstanceMigrationStatus{
+					Phase: v1.MigrationRunning,
+					Conditions: []v1.VirtualMachineInstanceMigrationCondition{
+						{Type: v1.VirtualMachineInstanceMigrationAbortRequested, Status: k8sv1.ConditionTrue},
+					},
+				},
+			}
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", migrationPath),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, migration),
+				),
+			)
+
+			app.CancelMigrationRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusAccepted))
+		})
+
+		It("should request the abort of an in-flight migration", func() {
+			migration := v1.VirtualMachineInstanceMigration{
+				Status: v1.VirtualMachineInstanceMigrationStatus{Phase: v1.MigrationRunning},
+			}
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", migrationPath),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, migration),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PATCH", migrationPath+"/status"),
+					func(w http.ResponseWriter, r *http.Request) {
+						body, err := ioutil.ReadAll(r.Body)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(string(body)).To(ContainSubstring(`"type":"migrationAbortRequested"`))
+						Expect(string(body)).To(ContainSubstring(`"reason":"Cancelled"`))
+					},
+					ghttp.RespondWithJSONEncoded(http.StatusOK, migration),
+				),
+			)
+
+			app.CancelMigrationRequestHandler(request, response)
+
+			Expect(response.Error()).ToNot(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusAccepted))
+		})
+	})
+
 	Context("Subresource api - Guest OS Info", func() {
 		type subRes func(request *restful.Request, response *restful.Response)
 
@@ -1479,6 +1826,7 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 			table.Entry("for GuestOSInfo", app.GuestOSInfo),
 			table.Entry("for UserList", app.UserList),
 			table.Entry("for Filesystem", app.FilesystemList),
+			table.Entry("for Usage", app.Usage),
 		)
 
 		table.DescribeTable("should fail when the VMI is not running", func(fn subRes) {
@@ -1503,6 +1851,7 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 			table.Entry("for GuestOSInfo", app.GuestOSInfo),
 			table.Entry("for UserList", app.UserList),
 			table.Entry("for FilesystemList", app.FilesystemList),
+			table.Entry("for Usage", app.Usage),
 		)
 
 		table.DescribeTable("should fail when VMI does not have agent connected", func(fn subRes) {
@@ -1710,6 +2059,28 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 			ExpectStatusErrorWithCode(recorder, http.StatusConflict)
 		})
 
+		It("Should fail freezing a VMI whose guest agent can't freeze the filesystems", func() {
+			request.PathParameters()["name"] = "testvmi"
+			request.PathParameters()["namespace"] = "default"
+
+			vmi := v1.NewMinimalVMIWithNS("default", "testvmi")
+			vmi.Status.Phase = v1.Running
+			vmi.Status.GuestAgent = &v1.VirtualMachineInstanceGuestAgentStatus{
+				UnavailableFeatures: []v1.GuestAgentFeature{v1.GuestAgentFeatureFSFreeze},
+			}
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+			)
+
+			app.FreezeVMIRequestHandler(request, response)
+
+			status := ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+			Expect(status.Error()).To(ContainSubstring("doesn't support freezing the filesystems"))
+		})
+
 		It("Should fail unfreezing a not running VMI", func() {
 
 			expectVMI(false, false)
@@ -1734,6 +2105,151 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 		})
 	})
 
+	Context("Memory snapshots", func() {
+		It("Should save the memory of a running VMI to its memory dump volume in the background", func() {
+			backend.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/v1/namespaces/default/virtualmachineinstances/testvmi/memorysnapshot"),
+					ghttp.VerifyBody([]byte("{}")),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, v1.MemorySnapshotUploadStatus{
+						Phase: v1.MemorySnapshotUploadInProgress,
+					}),
+				),
+			)
+			expectVMI(true, false)
+
+			app.MemorySnapshotVMIRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+			status := v1.MemorySnapshotUploadStatus{}
+			Expect(json.Unmarshal(recorder.Body.Bytes(), &status)).To(Succeed())
+			Expect(status.Phase).To(Equal(v1.MemorySnapshotUploadInProgress))
+		})
+
+		It("Should pass object storage uploads on and return their status", func() {
+			opts := &v1.MemorySnapshotOptions{
+				ObjectStorage: &v1.MemorySnapshotObjectStorage{
+					Endpoint: "https://s3.example.com",
+					Bucket:   "snapshots",
+					Key:      "vmsnapshot-memory",
+				},
+			}
+			bytesRepresentation, _ := json.Marshal(opts)
+			request.Request.Body = ioutil.NopCloser(bytes.NewReader(bytesRepresentation))
+
+			backend.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/v1/namespaces/default/virtualmachineinstances/testvmi/memorysnapshot"),
+					ghttp.VerifyJSONRepresenting(opts),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, v1.MemorySnapshotUploadStatus{
+						Phase: v1.MemorySnapshotUploadInProgress,
+					}),
+				),
+			)
+			expectVMI(true, false)
+
+			app.MemorySnapshotVMIRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+			status := v1.MemorySnapshotUploadStatus{}
+			Expect(json.Unmarshal(recorder.Body.Bytes(), &status)).To(Succeed())
+			Expect(status.Phase).To(Equal(v1.MemorySnapshotUploadInProgress))
+		})
+
+		It("Should fail snapshotting the memory of a not running VMI", func() {
+			expectVMI(false, false)
+
+			app.MemorySnapshotVMIRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+		})
+	})
+
+	Context("Setting the link state", func() {
+		expectVMIWithInterface := func() {
+			request.PathParameters()["name"] = "testvmi"
+			request.PathParameters()["namespace"] = "default"
+
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Status.Phase = v1.Running
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{
+				*v1.DefaultBridgeNetworkInterface(),
+				{
+					Name: "sriov",
+					InterfaceBindingMethod: v1.InterfaceBindingMethod{
+						SRIOV: &v1.InterfaceSRIOV{},
+					},
+				},
+			}
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvmi"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vmi),
+				),
+			)
+			expectHandlerPod()
+		}
+
+		setBody := func(opts *v1.SetLinkStateOptions) {
+			bytesRepresentation, _ := json.Marshal(opts)
+			request.Request.Body = ioutil.NopCloser(bytes.NewReader(bytesRepresentation))
+		}
+
+		It("Should pass the link state of an interface on", func() {
+			opts := &v1.SetLinkStateOptions{
+				InterfaceName: "default",
+				State:         v1.InterfaceLinkStateDown,
+			}
+			setBody(opts)
+
+			backend.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/v1/namespaces/default/virtualmachineinstances/testvmi/setlinkstate"),
+					ghttp.VerifyJSONRepresenting(opts),
+					ghttp.RespondWith(http.StatusAccepted, ""),
+				),
+			)
+			expectVMIWithInterface()
+
+			app.SetLinkStateVMIRequestHandler(request, response)
+
+			Expect(response.StatusCode()).To(Equal(http.StatusOK))
+		})
+
+		table.DescribeTable("Should reject invalid options", func(opts *v1.SetLinkStateOptions) {
+			setBody(opts)
+
+			app.SetLinkStateVMIRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+		},
+			table.Entry("without an interface name", &v1.SetLinkStateOptions{State: v1.InterfaceLinkStateUp}),
+			table.Entry("with an unknown state", &v1.SetLinkStateOptions{InterfaceName: "default", State: "dormant"}),
+		)
+
+		table.DescribeTable("Should reject interfaces which have no link state", func(name string) {
+			setBody(&v1.SetLinkStateOptions{InterfaceName: name, State: v1.InterfaceLinkStateDown})
+			expectVMIWithInterface()
+
+			app.SetLinkStateVMIRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusBadRequest)
+		},
+			table.Entry("if the VMI has no such interface", "other"),
+			table.Entry("if the interface is SR-IOV", "sriov"),
+		)
+
+		It("Should fail setting the link state of a not running VMI", func() {
+			setBody(&v1.SetLinkStateOptions{InterfaceName: "default", State: v1.InterfaceLinkStateDown})
+			expectVMI(false, false)
+
+			app.SetLinkStateVMIRequestHandler(request, response)
+
+			ExpectStatusErrorWithCode(recorder, http.StatusConflict)
+		})
+	})
+
 	Context("Pausing", func() {
 		It("Should pause a running, not paused VMI", func() {
 
@@ -1856,6 +2372,49 @@ var _ = Describe("VirtualMachineInstance Subresources", func() {
 			close(done)
 		})
 
+		It("should patch running on start if the template already starts paused", func() {
+			body := map[string]bool{
+				"paused": true,
+			}
+			bytesRepresentation, _ := json.Marshal(body)
+			request.Request.Body = ioutil.NopCloser(bytes.NewReader(bytesRepresentation))
+
+			startStrategy := v1.StartStrategyPaused
+			vm := v1.VirtualMachine{
+				ObjectMeta: k8smetav1.ObjectMeta{
+					Name:      "testvm",
+					Namespace: "default",
+				},
+				Spec: v1.VirtualMachineSpec{
+					Running: &notRunning,
+					Template: &v1.VirtualMachineInstanceTemplateSpec{
+						Spec: v1.VirtualMachineInstanceSpec{StartStrategy: &startStrategy},
+					},
+				},
+			}
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineinstances/testvm"),
+					ghttp.RespondWithJSONEncoded(http.StatusNotFound, nil),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PATCH", "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachines/testvm"),
+					ghttp.VerifyBody([]byte("{\"spec\":{\"running\": true}}")),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, vm),
+				),
+			)
+
+			app.StartVMRequestHandler(request, response)
+
+			Expect(response.Error()).ToNot(HaveOccurred())
+			Expect(response.StatusCode()).To(Equal(http.StatusAccepted))
+		})
+
 		table.DescribeTable("should patch status on start for VM with RunStrategy",
 			func(runStrategy v1.VirtualMachineRunStrategy) {
 				vm := newVirtualMachineWithRunStrategy(runStrategy)
@@ -22,6 +22,7 @@ func (app *SubresourceAPIApp) USBRedirRequestHandler(request *restful.Request, r
 			return conn.USBRedirURI(vmi)
 		}),
 	)
+	streamer.auditSession = app.sessionAuditor(request, usbRedirSession)
 
 	streamer.Handle(request, response)
 }
@@ -23,6 +23,7 @@ func (app *SubresourceAPIApp) VNCRequestHandler(request *restful.Request, respon
 			return conn.VNCURI(vmi)
 		}),
 	)
+	streamer.auditSession = app.sessionAuditor(request, vncSession)
 
 	streamer.Handle(request, response)
 }
@@ -0,0 +1,276 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package backup_webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+)
+
+// The endpoints take and return what Velero passes to and expects from its
+// BackupItemActions and RestoreItemActions, so that a Velero plugin only has
+// to forward the items to virt-api.
+
+const (
+	cdiGroup           = "cdi.kubevirt.io"
+	dataVolumeResource = "datavolumes"
+	pvcResource        = "persistentvolumeclaims"
+	launcherPodValue   = "virt-launcher"
+)
+
+var podGroupKind = schema.GroupKind{Group: k8sv1.GroupName, Kind: "Pod"}
+
+// ResourceIdentifier identifies an object which has to be backed up or restored along with an item
+type ResourceIdentifier struct {
+	Group     string `json:"group"`
+	Resource  string `json:"resource"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+// BackupItemActionRequest holds the item Velero is about to back up
+type BackupItemActionRequest struct {
+	Item *unstructured.Unstructured `json:"item"`
+}
+
+// BackupItemActionResponse holds the item to back up and the objects it depends on
+type BackupItemActionResponse struct {
+	Item            *unstructured.Unstructured `json:"item"`
+	AdditionalItems []ResourceIdentifier       `json:"additionalItems,omitempty"`
+}
+
+// RestoreItemActionRequest holds the item Velero is about to restore
+type RestoreItemActionRequest struct {
+	Item *unstructured.Unstructured `json:"item"`
+}
+
+// RestoreItemActionResponse holds the item to restore, or whether it is skipped
+type RestoreItemActionResponse struct {
+	Item            *unstructured.Unstructured `json:"item,omitempty"`
+	AdditionalItems []ResourceIdentifier       `json:"additionalItems,omitempty"`
+	SkipRestore     bool                       `json:"skipRestore,omitempty"`
+}
+
+// BackupItem returns the volumes and owners a VirtualMachine, VirtualMachineInstance or
+// virt-launcher pod needs to be restored consistently. All other items are passed through.
+func BackupItem(item *unstructured.Unstructured) (*BackupItemActionResponse, error) {
+	response := &BackupItemActionResponse{Item: item}
+
+	switch item.GroupVersionKind().GroupKind() {
+	case v1.VirtualMachineGroupVersionKind.GroupKind():
+		vm := &v1.VirtualMachine{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, vm); err != nil {
+			return nil, err
+		}
+		items := newItemSet()
+		if vm.Spec.Template != nil {
+			items.addVolumes(vm.Namespace, vm.Spec.Template.Spec.Volumes)
+		}
+		for _, template := range vm.Spec.DataVolumeTemplates {
+			items.addDataVolume(vm.Namespace, template.Name)
+		}
+		response.AdditionalItems = items.list
+	case v1.VirtualMachineInstanceGroupVersionKind.GroupKind():
+		vmi := &v1.VirtualMachineInstance{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, vmi); err != nil {
+			return nil, err
+		}
+		items := newItemSet()
+		items.addVolumes(vmi.Namespace, vmi.Spec.Volumes)
+		if owner := metav1.GetControllerOf(vmi); owner != nil && owner.Kind == v1.VirtualMachineGroupVersionKind.Kind {
+			items.add(v1.GroupName, "virtualmachines", vmi.Namespace, owner.Name)
+		}
+		response.AdditionalItems = items.list
+	case podGroupKind:
+		if !isLauncherPod(item) {
+			break
+		}
+		if owner := metav1.GetControllerOf(item); owner != nil && owner.Kind == v1.VirtualMachineInstanceGroupVersionKind.Kind {
+			response.AdditionalItems = []ResourceIdentifier{{
+				Group:     v1.GroupName,
+				Resource:  "virtualmachineinstances",
+				Namespace: item.GetNamespace(),
+				Name:      owner.Name,
+			}}
+		}
+	}
+
+	return response, nil
+}
+
+// RestoreItem skips the objects KubeVirt reconstructs on its own: virt-launcher pods,
+// VirtualMachineInstances of VirtualMachines and migrations which were in flight.
+// The status of the restored VirtualMachines and VirtualMachineInstances is removed,
+// it described the workload on the backed up cluster.
+func RestoreItem(item *unstructured.Unstructured) (*RestoreItemActionResponse, error) {
+	response := &RestoreItemActionResponse{}
+
+	switch item.GroupVersionKind().GroupKind() {
+	case podGroupKind:
+		if isLauncherPod(item) {
+			response.SkipRestore = true
+			return response, nil
+		}
+	case v1.VirtualMachineInstanceMigrationGroupVersionKind.GroupKind():
+		migration := &v1.VirtualMachineInstanceMigration{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, migration); err != nil {
+			return nil, err
+		}
+		if !migration.IsFinal() {
+			response.SkipRestore = true
+			return response, nil
+		}
+	case v1.VirtualMachineInstanceGroupVersionKind.GroupKind():
+		if owner := metav1.GetControllerOf(item); owner != nil && owner.Kind == v1.VirtualMachineGroupVersionKind.Kind {
+			response.SkipRestore = true
+			return response, nil
+		}
+		item = item.DeepCopy()
+		unstructured.RemoveNestedField(item.Object, "status")
+		labels := item.GetLabels()
+		delete(labels, v1.NodeNameLabel)
+		delete(labels, v1.MigrationTargetNodeNameLabel)
+		delete(labels, v1.OutdatedLauncherImageLabel)
+		item.SetLabels(labels)
+	case v1.VirtualMachineGroupVersionKind.GroupKind():
+		item = item.DeepCopy()
+		unstructured.RemoveNestedField(item.Object, "status")
+	}
+
+	response.Item = item
+	return response, nil
+}
+
+func isLauncherPod(item *unstructured.Unstructured) bool {
+	return item.GetLabels()[v1.AppLabel] == launcherPodValue
+}
+
+// itemSet collects additional items in order and without duplicates
+type itemSet struct {
+	list []ResourceIdentifier
+	seen map[ResourceIdentifier]bool
+}
+
+func newItemSet() *itemSet {
+	return &itemSet{seen: map[ResourceIdentifier]bool{}}
+}
+
+func (s *itemSet) add(group, resource, namespace, name string) {
+	id := ResourceIdentifier{Group: group, Resource: resource, Namespace: namespace, Name: name}
+	if s.seen[id] {
+		return
+	}
+	s.seen[id] = true
+	s.list = append(s.list, id)
+}
+
+// addDataVolume adds a DataVolume and the PVC it populates, which has the same name
+func (s *itemSet) addDataVolume(namespace, name string) {
+	s.add(cdiGroup, dataVolumeResource, namespace, name)
+	s.add(k8sv1.GroupName, pvcResource, namespace, name)
+}
+
+func (s *itemSet) addVolumes(namespace string, volumes []v1.Volume) {
+	for _, volume := range volumes {
+		if volume.PersistentVolumeClaim != nil {
+			s.add(k8sv1.GroupName, pvcResource, namespace, volume.PersistentVolumeClaim.ClaimName)
+		} else if volume.DataVolume != nil {
+			s.addDataVolume(namespace, volume.DataVolume.Name)
+		}
+	}
+}
+
+func readRequest(r *http.Request, request interface{}) error {
+	var body []byte
+	if r.Body != nil {
+		if data, err := ioutil.ReadAll(r.Body); err == nil {
+			body = data
+		}
+	}
+
+	contentType := r.Header.Get("Content-Type")
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType != "application/json" {
+		return fmt.Errorf("contentType=%s, expect application/json", contentType)
+	}
+
+	return json.Unmarshal(body, request)
+}
+
+func writeResponse(resp http.ResponseWriter, response interface{}) {
+	responseBytes, err := json.Marshal(response)
+	if err != nil {
+		log.Log.Reason(err).Errorf("failed json encode item action response")
+		resp.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp.Header().Set("Content-Type", "application/json")
+	if _, err := resp.Write(responseBytes); err != nil {
+		log.Log.Reason(err).Errorf("failed to write item action response")
+	}
+}
+
+// ServeBackupItemAction answers the items a Velero BackupItemAction forwards
+func ServeBackupItemAction(resp http.ResponseWriter, req *http.Request) {
+	request := &BackupItemActionRequest{}
+	if err := readRequest(req, request); err != nil || request.Item == nil {
+		log.Log.Reason(err).Error("failed to decode backup item action request")
+		resp.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	response, err := BackupItem(request.Item)
+	if err != nil {
+		log.Log.Reason(err).Errorf("failed to back up %s %s/%s", request.Item.GetKind(), request.Item.GetNamespace(), request.Item.GetName())
+		http.Error(resp, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	writeResponse(resp, response)
+}
+
+// ServeRestoreItemAction answers the items a Velero RestoreItemAction forwards
+func ServeRestoreItemAction(resp http.ResponseWriter, req *http.Request) {
+	request := &RestoreItemActionRequest{}
+	if err := readRequest(req, request); err != nil || request.Item == nil {
+		log.Log.Reason(err).Error("failed to decode restore item action request")
+		resp.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	response, err := RestoreItem(request.Item)
+	if err != nil {
+		log.Log.Reason(err).Errorf("failed to restore %s %s/%s", request.Item.GetKind(), request.Item.GetNamespace(), request.Item.GetName())
+		http.Error(resp, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	writeResponse(resp, response)
+}
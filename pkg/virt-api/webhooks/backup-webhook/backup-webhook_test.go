@@ -0,0 +1,284 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package backup_webhook_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	backup_webhook "kubevirt.io/kubevirt/pkg/virt-api/webhooks/backup-webhook"
+)
+
+var _ = Describe("Backup webhook", func() {
+
+	toUnstructured := func(obj runtime.Object, gvk schema.GroupVersionKind) *unstructured.Unstructured {
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		Expect(err).ToNot(HaveOccurred())
+		u := &unstructured.Unstructured{Object: content}
+		u.SetGroupVersionKind(gvk)
+		return u
+	}
+
+	controlledBy := func(kind, name string) []metav1.OwnerReference {
+		isController := true
+		return []metav1.OwnerReference{{
+			APIVersion: v1.GroupVersion.String(),
+			Kind:       kind,
+			Name:       name,
+			UID:        "1234",
+			Controller: &isController,
+		}}
+	}
+
+	volumes := []v1.Volume{
+		{
+			Name: "pvc",
+			VolumeSource: v1.VolumeSource{
+				PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+					PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "claim"},
+				},
+			},
+		},
+		{
+			Name: "dv",
+			VolumeSource: v1.VolumeSource{
+				DataVolume: &v1.DataVolumeSource{Name: "datavolume"},
+			},
+		},
+		{
+			Name: "containerdisk",
+			VolumeSource: v1.VolumeSource{
+				ContainerDisk: &v1.ContainerDiskSource{Image: "image"},
+			},
+		},
+	}
+
+	volumeItems := []backup_webhook.ResourceIdentifier{
+		{Group: "", Resource: "persistentvolumeclaims", Namespace: "default", Name: "claim"},
+		{Group: "cdi.kubevirt.io", Resource: "datavolumes", Namespace: "default", Name: "datavolume"},
+		{Group: "", Resource: "persistentvolumeclaims", Namespace: "default", Name: "datavolume"},
+	}
+
+	newVMI := func() *v1.VirtualMachineInstance {
+		vmi := v1.NewMinimalVMIWithNS("default", "testvmi")
+		vmi.Labels = map[string]string{
+			"app":            "test",
+			v1.NodeNameLabel: "node01",
+		}
+		vmi.Spec.Volumes = volumes
+		vmi.Status.Phase = v1.Running
+		vmi.Status.NodeName = "node01"
+		return vmi
+	}
+
+	newLauncherPod := func() *k8sv1.Pod {
+		return &k8sv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "virt-launcher-testvmi-abcde",
+				Namespace:       "default",
+				Labels:          map[string]string{v1.AppLabel: "virt-launcher"},
+				OwnerReferences: controlledBy("VirtualMachineInstance", "testvmi"),
+			},
+		}
+	}
+
+	podGVK := k8sv1.SchemeGroupVersion.WithKind("Pod")
+
+	Context("on backup", func() {
+
+		It("should add the volumes of a VM", func() {
+			vm := &v1.VirtualMachine{
+				ObjectMeta: metav1.ObjectMeta{Name: "testvm", Namespace: "default"},
+				Spec: v1.VirtualMachineSpec{
+					Template: &v1.VirtualMachineInstanceTemplateSpec{
+						Spec: v1.VirtualMachineInstanceSpec{Volumes: volumes},
+					},
+					DataVolumeTemplates: []v1.DataVolumeTemplateSpec{
+						{ObjectMeta: metav1.ObjectMeta{Name: "datavolume"}},
+						{ObjectMeta: metav1.ObjectMeta{Name: "unused"}},
+					},
+				},
+			}
+			item := toUnstructured(vm, v1.VirtualMachineGroupVersionKind)
+
+			response, err := backup_webhook.BackupItem(item)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response.Item).To(Equal(item))
+			Expect(response.AdditionalItems).To(Equal(append(volumeItems,
+				backup_webhook.ResourceIdentifier{Group: "cdi.kubevirt.io", Resource: "datavolumes", Namespace: "default", Name: "unused"},
+				backup_webhook.ResourceIdentifier{Group: "", Resource: "persistentvolumeclaims", Namespace: "default", Name: "unused"},
+			)))
+		})
+
+		It("should add the volumes and the VM of a VMI", func() {
+			vmi := newVMI()
+			vmi.OwnerReferences = controlledBy("VirtualMachine", "testvm")
+
+			response, err := backup_webhook.BackupItem(toUnstructured(vmi, v1.VirtualMachineInstanceGroupVersionKind))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response.AdditionalItems).To(Equal(append(volumeItems,
+				backup_webhook.ResourceIdentifier{Group: v1.GroupName, Resource: "virtualmachines", Namespace: "default", Name: "testvm"},
+			)))
+		})
+
+		It("should add the VMI of a virt-launcher pod", func() {
+			response, err := backup_webhook.BackupItem(toUnstructured(newLauncherPod(), podGVK))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response.AdditionalItems).To(ConsistOf(
+				backup_webhook.ResourceIdentifier{Group: v1.GroupName, Resource: "virtualmachineinstances", Namespace: "default", Name: "testvmi"},
+			))
+		})
+
+		It("should pass other items through", func() {
+			pod := newLauncherPod()
+			pod.Labels = nil
+
+			response, err := backup_webhook.BackupItem(toUnstructured(pod, podGVK))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response.AdditionalItems).To(BeEmpty())
+		})
+	})
+
+	Context("on restore", func() {
+
+		table.DescribeTable("should skip items KubeVirt reconstructs", func(item func() *unstructured.Unstructured, skip bool) {
+			response, err := backup_webhook.RestoreItem(item())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response.SkipRestore).To(Equal(skip))
+			if skip {
+				Expect(response.Item).To(BeNil())
+			} else {
+				Expect(response.Item).ToNot(BeNil())
+			}
+		},
+			table.Entry("and skip virt-launcher pods", func() *unstructured.Unstructured {
+				return toUnstructured(newLauncherPod(), podGVK)
+			}, true),
+			table.Entry("and restore other pods", func() *unstructured.Unstructured {
+				pod := newLauncherPod()
+				pod.Labels = nil
+				return toUnstructured(pod, podGVK)
+			}, false),
+			table.Entry("and skip migrations in flight", func() *unstructured.Unstructured {
+				migration := &v1.VirtualMachineInstanceMigration{
+					ObjectMeta: metav1.ObjectMeta{Name: "migration", Namespace: "default"},
+					Status:     v1.VirtualMachineInstanceMigrationStatus{Phase: v1.MigrationRunning},
+				}
+				return toUnstructured(migration, v1.VirtualMachineInstanceMigrationGroupVersionKind)
+			}, true),
+			table.Entry("and restore finished migrations", func() *unstructured.Unstructured {
+				migration := &v1.VirtualMachineInstanceMigration{
+					ObjectMeta: metav1.ObjectMeta{Name: "migration", Namespace: "default"},
+					Status:     v1.VirtualMachineInstanceMigrationStatus{Phase: v1.MigrationSucceeded},
+				}
+				return toUnstructured(migration, v1.VirtualMachineInstanceMigrationGroupVersionKind)
+			}, false),
+			table.Entry("and skip VMIs of VMs", func() *unstructured.Unstructured {
+				vmi := newVMI()
+				vmi.OwnerReferences = controlledBy("VirtualMachine", "testvm")
+				return toUnstructured(vmi, v1.VirtualMachineInstanceGroupVersionKind)
+			}, true),
+			table.Entry("and restore standalone VMIs", func() *unstructured.Unstructured {
+				return toUnstructured(newVMI(), v1.VirtualMachineInstanceGroupVersionKind)
+			}, false),
+		)
+
+		It("should remove the status and node labels of standalone VMIs", func() {
+			item := toUnstructured(newVMI(), v1.VirtualMachineInstanceGroupVersionKind)
+
+			response, err := backup_webhook.RestoreItem(item)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response.Item.Object).ToNot(HaveKey("status"))
+			Expect(response.Item.GetLabels()).To(Equal(map[string]string{"app": "test"}))
+			Expect(item.Object).To(HaveKey("status"), "the original item should stay untouched")
+		})
+
+		It("should remove the status of VMs", func() {
+			running := true
+			vm := &v1.VirtualMachine{
+				ObjectMeta: metav1.ObjectMeta{Name: "testvm", Namespace: "default"},
+				Spec:       v1.VirtualMachineSpec{Running: &running},
+				Status:     v1.VirtualMachineStatus{Created: true, Ready: true},
+			}
+
+			response, err := backup_webhook.RestoreItem(toUnstructured(vm, v1.VirtualMachineGroupVersionKind))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response.Item.Object).ToNot(HaveKey("status"))
+			Expect(response.Item.Object).To(HaveKeyWithValue("spec", HaveKeyWithValue("running", true)))
+		})
+	})
+
+	Context("when serving", func() {
+
+		post := func(handler http.HandlerFunc, contentType string, body interface{}) *httptest.ResponseRecorder {
+			data, err := json.Marshal(body)
+			Expect(err).ToNot(HaveOccurred())
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(data))
+			req.Header.Set("Content-Type", contentType)
+			recorder := httptest.NewRecorder()
+			handler(recorder, req)
+			return recorder
+		}
+
+		It("should answer backup item actions", func() {
+			vmi := newVMI()
+			recorder := post(backup_webhook.ServeBackupItemAction, "application/json",
+				&backup_webhook.BackupItemActionRequest{Item: toUnstructured(vmi, v1.VirtualMachineInstanceGroupVersionKind)})
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+
+			response := &backup_webhook.BackupItemActionResponse{}
+			Expect(json.Unmarshal(recorder.Body.Bytes(), response)).To(Succeed())
+			Expect(response.Item.GetName()).To(Equal(vmi.Name))
+			Expect(response.AdditionalItems).To(Equal(volumeItems))
+		})
+
+		It("should answer restore item actions", func() {
+			recorder := post(backup_webhook.ServeRestoreItemAction, "application/json",
+				&backup_webhook.RestoreItemActionRequest{Item: toUnstructured(newLauncherPod(), podGVK)})
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+
+			response := &backup_webhook.RestoreItemActionResponse{}
+			Expect(json.Unmarshal(recorder.Body.Bytes(), response)).To(Succeed())
+			Expect(response.SkipRestore).To(BeTrue())
+			Expect(response.Item).To(BeNil())
+		})
+
+		table.DescribeTable("should reject invalid requests", func(handler http.HandlerFunc, contentType string, body interface{}) {
+			Expect(post(handler, contentType, body).Code).To(Equal(http.StatusBadRequest))
+		},
+			table.Entry("to back up without an item", backup_webhook.ServeBackupItemAction, "application/json", map[string]string{}),
+			table.Entry("to restore without an item", backup_webhook.ServeRestoreItemAction, "application/json", map[string]string{}),
+			table.Entry("with the wrong content type", backup_webhook.ServeBackupItemAction, "text/plain",
+				map[string]interface{}{"item": map[string]interface{}{"apiVersion": "v1", "kind": "Pod"}}),
+		)
+	})
+})
@@ -22,17 +22,20 @@ package mutating_webhook
 import (
 	"encoding/json"
 	"net/http"
+	"time"
 
 	admissionv1 "k8s.io/api/admission/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	"kubevirt.io/client-go/log"
+	admissionmetrics "kubevirt.io/kubevirt/pkg/monitoring/admission"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks/mutating-webhook/mutators"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
+// mutator patches the objects of the reviews of a mutating webhook. Mutators must not have
+// side effects, their webhooks are registered with the None side effect class.
 type mutator interface {
 	Mutate(*admissionv1.AdmissionReview) *admissionv1.AdmissionResponse
 }
@@ -40,21 +43,15 @@ type mutator interface {
 func serve(resp http.ResponseWriter, req *http.Request, m mutator) {
 	review, err := webhookutils.GetAdmissionReview(req)
 	if err != nil {
+		admissionmetrics.ObserveDecodeFailure(req.URL.Path)
 		resp.WriteHeader(http.StatusBadRequest)
 		return
 	}
 
-	response := admissionv1.AdmissionReview{
-		TypeMeta: metav1.TypeMeta{
-			APIVersion: admissionv1.SchemeGroupVersion.String(),
-			Kind:       "AdmissionReview",
-		},
-	}
+	start := time.Now()
 	reviewResponse := m.Mutate(review)
-	if reviewResponse != nil {
-		response.Response = reviewResponse
-		response.Response.UID = review.Request.UID
-	}
+	admissionmetrics.ObserveAdmission(req.URL.Path, review.Request, reviewResponse, time.Since(start))
+	response := webhookutils.NewAdmissionReviewResponse(review, reviewResponse)
 	// reset the Object and OldObject, they are not needed in a response.
 	review.Request.Object = runtime.RawExtension{}
 	review.Request.OldObject = runtime.RawExtension{}
@@ -65,6 +62,7 @@ func serve(resp http.ResponseWriter, req *http.Request, m mutator) {
 		resp.WriteHeader(http.StatusBadRequest)
 		return
 	}
+	resp.Header().Set("Content-Type", "application/json")
 	if _, err := resp.Write(responseBytes); err != nil {
 		log.Log.Reason(err).Errorf("failed to write webhook response")
 		resp.WriteHeader(http.StatusBadRequest)
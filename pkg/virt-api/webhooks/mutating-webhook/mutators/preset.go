@@ -110,6 +110,11 @@ func checkMergeConflicts(presetSpec *kubev1.DomainSpec, vmiSpec *kubev1.DomainSp
 			errors = append(errors, fmt.Errorf("spec.ioThreadsPolicy: %v != %v", presetSpec.IOThreadsPolicy, vmiSpec.IOThreadsPolicy))
 		}
 	}
+	if presetSpec.GuestOS != nil && vmiSpec.GuestOS != nil {
+		if !reflect.DeepEqual(presetSpec.GuestOS, vmiSpec.GuestOS) {
+			errors = append(errors, fmt.Errorf("spec.guestOS: %v != %v", presetSpec.GuestOS, vmiSpec.GuestOS))
+		}
+	}
 
 	if len(errors) > 0 {
 		return utilerrors.NewAggregate(errors)
@@ -196,6 +201,15 @@ func mergeDomainSpec(presetSpec *kubev1.DomainSpec, vmiSpec *kubev1.DomainSpec)
 			applied = true
 		}
 	}
+	if presetSpec.GuestOS != nil {
+		if vmiSpec.GuestOS == nil {
+			vmiSpec.GuestOS = &kubev1.GuestOS{}
+			presetSpec.GuestOS.DeepCopyInto(vmiSpec.GuestOS)
+		}
+		if reflect.DeepEqual(vmiSpec.GuestOS, presetSpec.GuestOS) {
+			applied = true
+		}
+	}
 
 	return applied, presetConflicts
 }
@@ -260,6 +274,55 @@ func applyPresets(vmi *kubev1.VirtualMachineInstance, presetInformer cache.Share
 	return nil
 }
 
+// applyNamespaceDefaultPreset applies the default preset of the namespace to VMIs which no preset selects
+func applyNamespaceDefaultPreset(vmi *kubev1.VirtualMachineInstance, presetInformer cache.SharedIndexInformer, namespaceInformer cache.SharedIndexInformer) error {
+	if namespaceInformer == nil || isVMIExcluded(vmi) {
+		return nil
+	}
+
+	obj, exists, err := namespaceInformer.GetStore().GetByKey(vmi.Namespace)
+	if err != nil || !exists {
+		return err
+	}
+	presetName := obj.(*k8sv1.Namespace).Annotations[kubev1.DefaultPresetAnnotation]
+	if presetName == "" {
+		return nil
+	}
+
+	presets, err := listPresets(presetInformer, vmi.Namespace)
+	if err != nil {
+		return err
+	}
+	presets, err = filterPresets(presets, vmi)
+	if err != nil {
+		return err
+	}
+	if len(presets) > 0 {
+		return nil
+	}
+
+	obj, exists, err = presetInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", vmi.Namespace, presetName))
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("default VirtualMachineInstancePreset '%s' of namespace '%s' not found", presetName, vmi.Namespace)
+	}
+	preset := obj.(*kubev1.VirtualMachineInstancePreset)
+
+	applied, err := mergeDomainSpec(preset.Spec.Domain, &vmi.Spec.Domain)
+	if !applied {
+		log.Log.Object(vmi).Warningf("Unable to apply default VirtualMachineInstancePreset '%s': %v", preset.Name, err)
+		return nil
+	}
+	if err != nil {
+		log.Log.Object(vmi).Warningf("Some settings were not applied for default VirtualMachineInstancePreset '%s': %v", preset.Name, err)
+	}
+	annotateVMI(vmi, *preset)
+	log.Log.Object(vmi).V(4).Infof("Apply default preset %s", preset.Name)
+	return nil
+}
+
 func annotateVMI(vmi *kubev1.VirtualMachineInstance, preset kubev1.VirtualMachineInstancePreset) {
 	if vmi.Annotations == nil {
 		vmi.Annotations = map[string]string{}
@@ -352,6 +352,23 @@ var _ = Describe("Mutating Webhook Presets", func() {
 			Expect(len(vmi.Annotations)).To(Equal(1), "There should be an annotation indicating presets were applied")
 			Expect(*vmi.Spec.Domain.IOThreadsPolicy).To(Equal(automaticPolicy), "IOThreadsPolicy should not have been changed")
 		})
+
+		It("Should detect guest OS overrides", func() {
+			preset.Spec.Domain.GuestOS = &v1.GuestOS{OSFamily: v1.GuestOSFamilyWindows}
+			vmi.Spec.Domain.GuestOS = &v1.GuestOS{OSFamily: v1.GuestOSFamilyLinux}
+
+			By("showing that an override occurs")
+			err := checkMergeConflicts(preset.Spec.Domain, &vmi.Spec.Domain)
+			Expect(err).To(HaveOccurred())
+
+			By("showing presets are not applied")
+			vmi.Annotations = map[string]string{}
+			presetInformer.GetIndexer().Add(preset)
+			applyPresets(&vmi, presetInformer)
+
+			Expect(vmi.Annotations).To(BeEmpty(), "There should not be annotations if presets weren't applied")
+			Expect(vmi.Spec.Domain.GuestOS.OSFamily).To(Equal(v1.GuestOSFamilyLinux), "Guest OS should not have been overridden")
+		})
 	})
 
 	Context("Conflict detection", func() {
@@ -632,6 +649,17 @@ var _ = Describe("Mutating Webhook Presets", func() {
 			Expect(vmi.Spec.Domain.IOThreadsPolicy).ToNot(BeNil(), "IOThreads policy should have been applied by preset")
 			Expect(*vmi.Spec.Domain.IOThreadsPolicy).To(Equal(ioThreads), "Expected IOThreadsPolicy to be 'shared' (set by preset)")
 		})
+
+		It("Should apply guest OS settings", func() {
+			guestOS := &v1.GuestOS{OSFamily: v1.GuestOSFamilyWindows}
+			preset.Spec.Domain.GuestOS = guestOS
+
+			presetInformer.GetIndexer().Add(preset)
+			applyPresets(&vmi, presetInformer)
+
+			Expect(vmi.Spec.Domain.GuestOS).To(Equal(guestOS))
+			Expect(vmi.Annotations["virtualmachinepreset.kubevirt.io/test-preset"]).To(Equal(fmt.Sprintf("kubevirt.io/%s", v1.ApiLatestVersion)))
+		})
 	})
 
 	Context("Filter Matching", func() {
@@ -684,4 +712,76 @@ var _ = Describe("Mutating Webhook Presets", func() {
 			Expect(err).To(HaveOccurred())
 		})
 	})
+
+	Context("Namespace default preset", func() {
+		var vmi *v1.VirtualMachineInstance
+		var presetInformer cache.SharedIndexInformer
+		var namespaceInformer cache.SharedIndexInformer
+		var namespace *k8sv1.Namespace
+
+		memory := resource.MustParse("2Gi")
+		defaultPresetAnnotation := fmt.Sprintf("virtualmachinepreset.%s/default-size", v1.GroupName)
+
+		newPreset := func(name string, selector k8smetav1.LabelSelector) *v1.VirtualMachineInstancePreset {
+			return &v1.VirtualMachineInstancePreset{
+				ObjectMeta: k8smetav1.ObjectMeta{Name: name, Namespace: "tenant"},
+				Spec: v1.VirtualMachineInstancePresetSpec{
+					Selector: selector,
+					Domain: &v1.DomainSpec{
+						Resources: v1.ResourceRequirements{Requests: k8sv1.ResourceList{k8sv1.ResourceMemory: memory}},
+					},
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			vmi = v1.NewMinimalVMIWithNS("tenant", "testvmi")
+			vmi.Spec.Domain.Resources.Requests = nil
+			presetInformer, _ = testutils.NewFakeInformerFor(&v1.VirtualMachineInstancePreset{})
+			presetInformer.GetIndexer().Add(newPreset("default-size", k8smetav1.LabelSelector{MatchLabels: map[string]string{"size": "default"}}))
+			namespaceInformer, _ = testutils.NewFakeInformerFor(&k8sv1.Namespace{})
+			namespace = &k8sv1.Namespace{ObjectMeta: k8smetav1.ObjectMeta{
+				Name:        "tenant",
+				Annotations: map[string]string{v1.DefaultPresetAnnotation: "default-size"},
+			}}
+			namespaceInformer.GetIndexer().Add(namespace)
+		})
+
+		It("should apply the default preset to a VMI no preset selects", func() {
+			Expect(applyNamespaceDefaultPreset(vmi, presetInformer, namespaceInformer)).To(Succeed())
+			Expect(vmi.Spec.Domain.Resources.Requests.Memory().String()).To(Equal("2Gi"))
+			Expect(vmi.Annotations).To(HaveKey(defaultPresetAnnotation))
+		})
+
+		It("should not apply the default preset if another preset selects the VMI", func() {
+			presetInformer.GetIndexer().Add(newPreset("selected", k8smetav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}}))
+			vmi.Labels = map[string]string{"app": "db"}
+
+			Expect(applyNamespaceDefaultPreset(vmi, presetInformer, namespaceInformer)).To(Succeed())
+			Expect(vmi.Annotations).ToNot(HaveKey(defaultPresetAnnotation))
+		})
+
+		It("should not apply the default preset to an excluded VMI", func() {
+			vmi.Annotations = map[string]string{exclusionMarking: "true"}
+
+			Expect(applyNamespaceDefaultPreset(vmi, presetInformer, namespaceInformer)).To(Succeed())
+			Expect(vmi.Annotations).ToNot(HaveKey(defaultPresetAnnotation))
+		})
+
+		It("should do nothing if the namespace has no default preset", func() {
+			namespace.Annotations = nil
+			namespaceInformer.GetIndexer().Update(namespace)
+
+			Expect(applyNamespaceDefaultPreset(vmi, presetInformer, namespaceInformer)).To(Succeed())
+			Expect(vmi.Annotations).ToNot(HaveKey(defaultPresetAnnotation))
+		})
+
+		It("should fail if the default preset doesn't exist", func() {
+			namespace.Annotations[v1.DefaultPresetAnnotation] = "missing"
+			namespaceInformer.GetIndexer().Update(namespace)
+
+			err := applyNamespaceDefaultPreset(vmi, presetInformer, namespaceInformer)
+			Expect(err).To(MatchError("default VirtualMachineInstancePreset 'missing' of namespace 'tenant' not found"))
+		})
+	})
 })
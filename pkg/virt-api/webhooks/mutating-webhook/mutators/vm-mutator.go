@@ -63,25 +63,14 @@ func (mutator *VMsMutator) Mutate(ar *admissionv1.AdmissionReview) *admissionv1.
 		return emptyValidResponse()
 	}
 
-	// Set VM defaults
+	// Only patch the fields which get defaulted. Replacing the whole spec would re-serialize
+	// the fields the user owns, which GitOps tools then report as drift from their manifests.
 	log.Log.Object(&vm).V(4).Info("Apply defaults")
-	mutator.setDefaultMachineType(&vm)
-
-	var patch []utiltypes.PatchOperation
-	var value interface{}
-	value = vm.Spec
-	patch = append(patch, utiltypes.PatchOperation{
-		Op:    "replace",
-		Path:  "/spec",
-		Value: value,
-	})
-
-	value = vm.ObjectMeta
-	patch = append(patch, utiltypes.PatchOperation{
-		Op:    "replace",
-		Path:  "/metadata",
-		Value: value,
-	})
+	patch := mutator.setDefaultMachineType(&vm)
+
+	if len(patch) == 0 {
+		return emptyValidResponse()
+	}
 
 	patchBytes, err := json.Marshal(patch)
 	if err != nil {
@@ -97,18 +86,28 @@ func (mutator *VMsMutator) Mutate(ar *admissionv1.AdmissionReview) *admissionv1.
 	}
 }
 
-func (mutator *VMsMutator) setDefaultMachineType(vm *v1.VirtualMachine) {
+func (mutator *VMsMutator) setDefaultMachineType(vm *v1.VirtualMachine) []utiltypes.PatchOperation {
 	if vm.Spec.Template == nil {
 		// nothing to do, let's the validating webhook fail later
-		return
+		return nil
 	}
 	machineType := mutator.ClusterConfig.GetMachineType()
 
 	if machine := vm.Spec.Template.Spec.Domain.Machine; machine != nil {
-		if machine.Type == "" {
-			machine.Type = machineType
+		if machine.Type != "" {
+			return nil
 		}
-	} else {
-		vm.Spec.Template.Spec.Domain.Machine = &v1.Machine{Type: machineType}
+		machine.Type = machineType
+		return []utiltypes.PatchOperation{{
+			Op:    "add",
+			Path:  "/spec/template/spec/domain/machine/type",
+			Value: machineType,
+		}}
 	}
+	vm.Spec.Template.Spec.Domain.Machine = &v1.Machine{Type: machineType}
+	return []utiltypes.PatchOperation{{
+		Op:    "add",
+		Path:  "/spec/template/spec/domain/machine",
+		Value: vm.Spec.Template.Spec.Domain.Machine,
+	}}
 }
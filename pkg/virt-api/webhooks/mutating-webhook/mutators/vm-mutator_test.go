@@ -21,6 +21,7 @@ package mutators
 import (
 	"encoding/json"
 
+	jsonpatch "github.com/evanphx/json-patch"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	admissionv1 "k8s.io/api/admission/v1"
@@ -43,7 +44,7 @@ var _ = Describe("VirtualMachine Mutator", func() {
 
 	machineTypeFromConfig := "pc-q35-3.0"
 
-	getVMSpecMetaFromResponse := func() (*v1.VirtualMachineSpec, *k8smetav1.ObjectMeta) {
+	mutate := func() *admissionv1.AdmissionResponse {
 		vmBytes, err := json.Marshal(vm)
 		Expect(err).ToNot(HaveOccurred())
 		By("Creating the test admissions review from the VM")
@@ -58,19 +59,26 @@ var _ = Describe("VirtualMachine Mutator", func() {
 		By("Mutating the VM")
 		resp := mutator.Mutate(ar)
 		Expect(resp.Allowed).To(BeTrue())
+		return resp
+	}
 
-		By("Getting the VM spec from the response")
-		vmSpec := &v1.VirtualMachineSpec{}
-		vmMeta := &k8smetav1.ObjectMeta{}
-		patch := []utiltypes.PatchOperation{
-			{Value: vmSpec},
-			{Value: vmMeta},
-		}
-		err = json.Unmarshal(resp.Patch, &patch)
+	getVMSpecMetaFromResponse := func() (*v1.VirtualMachineSpec, *k8smetav1.ObjectMeta) {
+		vmBytes, err := json.Marshal(vm)
 		Expect(err).ToNot(HaveOccurred())
-		Expect(patch).NotTo(BeEmpty())
+		resp := mutate()
+
+		By("Applying the patch from the response to the VM")
+		patchedBytes := vmBytes
+		if len(resp.Patch) > 0 {
+			patch, err := jsonpatch.DecodePatch(resp.Patch)
+			Expect(err).ToNot(HaveOccurred())
+			patchedBytes, err = patch.Apply(vmBytes)
+			Expect(err).ToNot(HaveOccurred())
+		}
 
-		return vmSpec, vmMeta
+		patchedVM := &v1.VirtualMachine{}
+		Expect(json.Unmarshal(patchedBytes, patchedVM)).To(Succeed())
+		return &patchedVM.Spec, &patchedVM.ObjectMeta
 	}
 
 	BeforeEach(func() {
@@ -118,4 +126,37 @@ var _ = Describe("VirtualMachine Mutator", func() {
 		vmSpec, _ := getVMSpecMetaFromResponse()
 		Expect(vmSpec.Template.Spec.Domain.Machine.Type).To(Equal(vm.Spec.Template.Spec.Domain.Machine.Type))
 	})
+
+	It("should only patch the defaulted fields", func() {
+		vm.Spec.Template.Spec.Domain.Machine = &v1.Machine{}
+		vm.Spec.Template.Spec.Volumes = []v1.Volume{
+			{
+				Name: "unpinned",
+				VolumeSource: v1.VolumeSource{
+					ContainerDisk: &v1.ContainerDiskSource{Image: "quay.io/kubevirt/fedora:33"},
+				},
+			},
+			{
+				Name: "pinned",
+				VolumeSource: v1.VolumeSource{
+					ContainerDisk: &v1.ContainerDiskSource{Image: "quay.io/kubevirt/fedora:33", ImageDigestPolicy: v1.ContainerDiskImageDigestPolicyPin},
+				},
+			},
+		}
+
+		resp := mutate()
+		patch := []utiltypes.PatchOperation{}
+		Expect(json.Unmarshal(resp.Patch, &patch)).To(Succeed())
+		Expect(patch).To(HaveLen(1))
+		Expect(patch[0].Op).To(Equal("add"))
+		Expect(patch[0].Path).To(Equal("/spec/template/spec/domain/machine/type"))
+	})
+
+	It("should not patch the VM if nothing needs to be defaulted", func() {
+		vm.Spec.Template.Spec.Domain.Machine = &v1.Machine{Type: "q35"}
+
+		resp := mutate()
+		Expect(resp.Patch).To(BeEmpty())
+		Expect(resp.PatchType).To(BeNil())
+	})
 })
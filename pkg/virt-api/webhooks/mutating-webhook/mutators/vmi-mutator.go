@@ -68,6 +68,9 @@ func (mutator *VMIsMutator) Mutate(ar *admissionv1.AdmissionReview) *admissionv1
 
 		// Apply presets
 		err = applyPresets(newVMI, informers.VMIPresetInformer)
+		if err == nil {
+			err = applyNamespaceDefaultPreset(newVMI, informers.VMIPresetInformer, informers.NamespaceInformer)
+		}
 		if err != nil {
 			return &admissionv1.AdmissionResponse{
 				Result: &metav1.Status{
@@ -92,6 +95,7 @@ func (mutator *VMIsMutator) Mutate(ar *admissionv1.AdmissionReview) *admissionv1
 			return webhookutils.ToAdmissionResponseError(err)
 		}
 		v1.SetObjectDefaults_VirtualMachineInstance(newVMI)
+		setDefaultInterfaceModels(newVMI)
 
 		// In a future, yet undecided, release either libvirt or QEMU are going to check the hyperv dependencies, so we can get rid of this code.
 		// Until that time, we need to handle the hyperv deps to avoid obscure rejections from QEMU later on
@@ -224,6 +228,24 @@ func (mutator *VMIsMutator) setDefaultNetworkInterface(obj *v1.VirtualMachineIns
 	return nil
 }
 
+// setDefaultInterfaceModels sets the model the interfaces would otherwise implicitly get on the
+// domain, so that the stored VMI reflects it. It runs after the guest OS specific defaults.
+func setDefaultInterfaceModels(vmi *v1.VirtualMachineInstance) {
+	for i := range vmi.Spec.Domain.Devices.Interfaces {
+		iface := &vmi.Spec.Domain.Devices.Interfaces[i]
+		if iface.Model != "" {
+			continue
+		}
+		switch {
+		case iface.Slirp != nil:
+			// Slirp works only with e1000 or rtl8139
+			iface.Model = "e1000"
+		case iface.Bridge != nil, iface.Masquerade != nil, iface.Macvtap != nil:
+			iface.Model = "virtio"
+		}
+	}
+}
+
 func (mutator *VMIsMutator) setDefaultCPUModel(vmi *v1.VirtualMachineInstance) {
 	//if vmi doesn't have cpu topology or cpu model set
 	if vmi.Spec.Domain.CPU == nil || vmi.Spec.Domain.CPU.Model == "" {
@@ -320,6 +342,10 @@ func (mutator *VMIsMutator) setDefaultResourceRequests(vmi *v1.VirtualMachineIns
 				resources.Requests = k8sv1.ResourceList{}
 			}
 			overcommit := mutator.ClusterConfig.GetMemoryOvercommit()
+			// hugepages are reserved for the guest up front, they can't be overcommitted
+			if vmi.Spec.Domain.Memory.Hugepages != nil {
+				overcommit = 100
+			}
 			if overcommit == 100 {
 				resources.Requests[k8sv1.ResourceMemory] = *memory
 			} else {
@@ -470,6 +470,21 @@ var _ = Describe("VirtualMachineInstance Mutator", func() {
 		table.Entry("networks is non-empty", []v1.Interface{}, []v1.Network{{Name: "b"}}),
 	)
 
+	table.DescribeTable("should default the interface model", func(iface v1.Interface, guestOS *v1.GuestOS, expectedModel string) {
+		vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{iface}
+		vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+		vmi.Spec.Domain.GuestOS = guestOS
+		vmiSpec, _ := getVMISpecMetaFromResponse()
+		Expect(vmiSpec.Domain.Devices.Interfaces[0].Model).To(Equal(expectedModel))
+	},
+		table.Entry("to virtio on masquerade", *v1.DefaultMasqueradeNetworkInterface(), nil, "virtio"),
+		table.Entry("to virtio on bridge", *v1.DefaultBridgeNetworkInterface(), nil, "virtio"),
+		table.Entry("to e1000 on slirp", *v1.DefaultSlirpNetworkInterface(), nil, "e1000"),
+		table.Entry("to the guest OS model", *v1.DefaultMasqueradeNetworkInterface(), &v1.GuestOS{OSFamily: v1.GuestOSFamilyWindows, OSVersion: "2019"}, "e1000e"),
+		table.Entry("not if it is set", v1.Interface{Name: "default", Model: "rtl8139", InterfaceBindingMethod: v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}}}, nil, "rtl8139"),
+		table.Entry("not on SR-IOV", v1.Interface{Name: "default", InterfaceBindingMethod: v1.InterfaceBindingMethod{SRIOV: &v1.InterfaceSRIOV{}}}, nil, ""),
+	)
+
 	It("should not override specified properties with defaults on VMI create", func() {
 		testutils.UpdateFakeClusterConfig(configMapInformer, &k8sv1.ConfigMap{
 			Data: map[string]string{
@@ -553,6 +568,26 @@ var _ = Describe("VirtualMachineInstance Mutator", func() {
 		Expect(vmiSpec.Domain.Resources.Requests.Memory().String()).To(Equal("3072M"))
 	})
 
+	It("should not apply memory-overcommit when hugepages and guest-memory are set", func() {
+		// no limits wanted on this test, to not copy the limit to requests
+		namespaceLimitInformer, _ = testutils.NewFakeInformerFor(&k8sv1.LimitRange{})
+		webhooks.SetInformers(
+			&webhooks.Informers{
+				VMIPresetInformer:       presetInformer,
+				NamespaceLimitsInformer: namespaceLimitInformer,
+			},
+		)
+		testutils.UpdateFakeClusterConfig(configMapInformer, &k8sv1.ConfigMap{
+			Data: map[string]string{
+				virtconfig.MemoryOvercommitKey: "150",
+			},
+		})
+		guestMemory := resource.MustParse("3Gi")
+		vmi.Spec.Domain.Memory = &v1.Memory{Guest: &guestMemory, Hugepages: &v1.Hugepages{PageSize: "1Gi"}}
+		vmiSpec, _ := getVMISpecMetaFromResponse()
+		Expect(vmiSpec.Domain.Resources.Requests.Memory().String()).To(Equal("3Gi"))
+	})
+
 	It("should not apply memory overcommit when memory-request and guest-memory are set", func() {
 		vmi.Spec.Domain.Resources.Requests = k8sv1.ResourceList{
 			k8sv1.ResourceMemory: resource.MustParse("512M"),
@@ -81,10 +81,13 @@ var KubeVirtGroupVersionResource = metav1.GroupVersionResource{
 }
 
 type Informers struct {
-	VMIPresetInformer       cache.SharedIndexInformer
-	NamespaceLimitsInformer cache.SharedIndexInformer
-	VMIInformer             cache.SharedIndexInformer
-	VMRestoreInformer       cache.SharedIndexInformer
+	VMIPresetInformer         cache.SharedIndexInformer
+	NamespaceLimitsInformer   cache.SharedIndexInformer
+	NamespaceInformer         cache.SharedIndexInformer
+	VMIInformer               cache.SharedIndexInformer
+	VMRestoreInformer         cache.SharedIndexInformer
+	ValidationPolicyInformer  cache.SharedIndexInformer
+	MaintenanceWindowInformer cache.SharedIndexInformer
 }
 
 // XXX fix this, this is a huge mess. Move informers to Admitter and Mutator structs.
@@ -117,10 +120,13 @@ func newInformers() *Informers {
 	}
 	kubeInformerFactory := controller.NewKubeInformerFactory(kubeClient.RestClient(), kubeClient, nil, namespace)
 	return &Informers{
-		VMIInformer:             kubeInformerFactory.VMI(),
-		VMIPresetInformer:       kubeInformerFactory.VirtualMachinePreset(),
-		NamespaceLimitsInformer: kubeInformerFactory.LimitRanges(),
-		VMRestoreInformer:       kubeInformerFactory.VirtualMachineRestore(),
+		VMIInformer:               kubeInformerFactory.VMI(),
+		VMIPresetInformer:         kubeInformerFactory.VirtualMachinePreset(),
+		NamespaceLimitsInformer:   kubeInformerFactory.LimitRanges(),
+		NamespaceInformer:         kubeInformerFactory.Namespace(),
+		VMRestoreInformer:         kubeInformerFactory.VirtualMachineRestore(),
+		ValidationPolicyInformer:  kubeInformerFactory.ValidationPolicy(),
+		MaintenanceWindowInformer: kubeInformerFactory.MaintenanceWindow(),
 	}
 }
 
@@ -30,11 +30,17 @@ import (
 
 var _ = Describe("Validating Webhook", func() {
 	var vmiInformer cache.SharedIndexInformer
+	var validationPolicyInformer cache.SharedIndexInformer
+	var maintenanceWindowInformer cache.SharedIndexInformer
 
 	BeforeSuite(func() {
 		vmiInformer, _ = testutils.NewFakeInformerFor(&v1.VirtualMachineInstance{})
+		validationPolicyInformer, _ = testutils.NewFakeInformerFor(&v1.ValidationPolicy{})
+		maintenanceWindowInformer, _ = testutils.NewFakeInformerFor(&v1.MaintenanceWindow{})
 		webhooks.SetInformers(&webhooks.Informers{
-			VMIInformer: vmiInformer,
+			VMIInformer:               vmiInformer,
+			ValidationPolicyInformer:  validationPolicyInformer,
+			MaintenanceWindowInformer: maintenanceWindowInformer,
 		})
 	})
 })
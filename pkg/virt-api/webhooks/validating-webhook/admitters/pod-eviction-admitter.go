@@ -4,14 +4,19 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	k8sv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	virtv1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/kubecli"
 
+	"kubevirt.io/kubevirt/pkg/util/maintenance"
+	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
 	validating_webhooks "kubevirt.io/kubevirt/pkg/util/webhooks/validating-webhooks"
+	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
@@ -43,6 +48,12 @@ func (admitter *PodEvictionAdmitter) Admit(ar *admissionv1.AdmissionReview) *adm
 	if err != nil {
 		return denied(fmt.Sprintf("kubevirt failed getting the vmi: %s", err.Error()))
 	}
+	if !vmi.IsMarkedForEviction() {
+		if response := admitter.admitDescheduling(vmi, launcher); response != nil {
+			return response
+		}
+	}
+
 	if !vmi.IsEvictable() {
 		// we don't act on VMIs without an eviction strategy
 		return validating_webhooks.NewPassingAdmissionResponse()
@@ -52,8 +63,7 @@ func (admitter *PodEvictionAdmitter) Admit(ar *admissionv1.AdmissionReview) *adm
 	}
 
 	if !vmi.IsMarkedForEviction() && vmi.Status.NodeName == launcher.Spec.NodeName {
-		dryRun := ar.Request.DryRun != nil && *ar.Request.DryRun == true
-		err := admitter.markVMI(ar, vmi, dryRun)
+		err := admitter.markVMI(ar, vmi, webhookutils.IsDryRun(ar.Request))
 		if err != nil {
 			// As with the previous case, it is up to the user to issue a retry.
 			return denied(fmt.Sprintf("kubevirt failed marking the vmi for eviction: %s", err.Error()))
@@ -65,6 +75,29 @@ func (admitter *PodEvictionAdmitter) Admit(ar *admissionv1.AdmissionReview) *adm
 	return validating_webhooks.NewPassingAdmissionResponse()
 }
 
+// admitDescheduling denies evictions outside of the maintenance windows of the VMI,
+// unless its node is drained. Evacuations are held back by the evacuation controller.
+func (admitter *PodEvictionAdmitter) admitDescheduling(vmi *virtv1.VirtualMachineInstance, launcher *k8sv1.Pod) *admissionv1.AdmissionResponse {
+	windows := maintenance.ListWindows(webhooks.GetInformers().MaintenanceWindowInformer)
+	allowed, wait := maintenance.Allowed(windows, vmi, virtv1.MaintenanceActionDescheduling, time.Now())
+	if allowed {
+		return nil
+	}
+
+	node, err := admitter.VirtClient.CoreV1().Nodes().Get(context.Background(), launcher.Spec.NodeName, metav1.GetOptions{})
+	if err != nil {
+		return denied(fmt.Sprintf("kubevirt failed getting the node of the vmi: %s", err.Error()))
+	}
+	if node.Spec.Unschedulable {
+		return nil
+	}
+
+	if wait > 0 {
+		return denied(fmt.Sprintf("VMI %s may not be descheduled before its next maintenance window opens in %s", vmi.Name, wait.Round(time.Minute)))
+	}
+	return denied(fmt.Sprintf("VMI %s may not be descheduled outside of its maintenance windows", vmi.Name))
+}
+
 func (admitter *PodEvictionAdmitter) markVMI(ar *admissionv1.AdmissionReview, vmi *virtv1.VirtualMachineInstance, dryRun bool) (err error) {
 	vmiCopy := vmi.DeepCopy()
 	vmiCopy.Status.EvacuationNodeName = vmi.Status.NodeName
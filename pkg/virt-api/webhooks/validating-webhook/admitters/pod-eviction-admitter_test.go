@@ -22,6 +22,7 @@ package admitters
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
@@ -37,6 +38,7 @@ import (
 	virtv1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
@@ -264,6 +266,82 @@ var _ = Describe("Pod eviction admitter", func() {
 				table.Entry("and should not mark the VMI when in dry-run mode", true),
 			)
 
+			Context("with a maintenance window restricting descheduling", func() {
+
+				BeforeEach(func() {
+					Expect(webhooks.GetInformers().MaintenanceWindowInformer.GetStore().Add(&virtv1.MaintenanceWindow{
+						ObjectMeta: metav1.ObjectMeta{Name: "window"},
+						Spec: virtv1.MaintenanceWindowSpec{
+							Actions: []virtv1.MaintenanceAction{virtv1.MaintenanceActionDescheduling},
+							Periods: []virtv1.MaintenancePeriod{{
+								Start:    time.Now().UTC().Add(2 * time.Hour).Format("15:04"),
+								Duration: metav1.Duration{Duration: time.Hour},
+							}},
+						},
+					})).To(Succeed())
+				})
+
+				AfterEach(func() {
+					Expect(webhooks.GetInformers().MaintenanceWindowInformer.GetStore().Replace(nil, "")).To(Succeed())
+				})
+
+				table.DescribeTable("Should only allow evictions when the node is drained", func(unschedulable bool) {
+					pod := &k8sv1.Pod{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "testpod",
+							Namespace: testns,
+							Annotations: map[string]string{
+								virtv1.DomainAnnotation: vmi.Name,
+							},
+							Labels: map[string]string{
+								virtv1.AppLabel: "virt-launcher",
+							},
+						},
+						Spec: k8sv1.PodSpec{
+							NodeName: "testnode",
+						},
+					}
+					node := &k8sv1.Node{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "testnode",
+						},
+						Spec: k8sv1.NodeSpec{
+							Unschedulable: unschedulable,
+						},
+					}
+
+					ar := &admissionv1.AdmissionReview{
+						Request: &admissionv1.AdmissionRequest{
+							Name:      pod.Name,
+							Namespace: pod.Namespace,
+						},
+					}
+
+					kubeClient.Fake.PrependReactor("get", "pods", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+						return true, pod, nil
+					})
+					kubeClient.Fake.PrependReactor("get", "nodes", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+						get, ok := action.(testing.GetAction)
+						Expect(ok).To(BeTrue())
+						Expect(get.GetName()).To(Equal(node.Name))
+						return true, node, nil
+					})
+
+					vmiClient.EXPECT().Get(vmi.Name, &metav1.GetOptions{}).Return(vmi, nil)
+
+					resp := podEvictionAdmitter.Admit(ar)
+					Expect(resp.Allowed).To(Equal(unschedulable))
+					if !unschedulable {
+						Expect(resp.Result.Code).To(Equal(int32(http.StatusTooManyRequests)))
+						Expect(resp.Result.Message).To(ContainSubstring("may not be descheduled"))
+					}
+					Expect(kubeClient.Fake.Actions()).To(HaveLen(2))
+				},
+					table.Entry("and deny descheduling outside of the window", false),
+					table.Entry("and allow evictions of a drained node", true),
+				)
+			})
+
 		})
 
 		Context("Not a virt launcher pod", func() {
@@ -20,50 +20,79 @@
 package admitters
 
 import (
+	"context"
+	"crypto"
 	"encoding/base64"
 	"fmt"
+	"math"
 	"net"
+	"path/filepath"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	admissionv1 "k8s.io/api/admission/v1"
 	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/validation"
 	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+	clientutil "kubevirt.io/client-go/util"
+	containerdisk "kubevirt.io/kubevirt/pkg/container-disk"
 	"kubevirt.io/kubevirt/pkg/hooks"
 	"kubevirt.io/kubevirt/pkg/network/link"
 	"kubevirt.io/kubevirt/pkg/util"
 	hwutil "kubevirt.io/kubevirt/pkg/util/hardware"
+	"kubevirt.io/kubevirt/pkg/util/types"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
 const (
-	arrayLenMax = 256
-	maxStrLen   = 256
-
-	// cloudInitNetworkMaxLen and CloudInitUserMaxLen are being limited
-	// to 2K to allow scaling of config as edits will cause entire object
-	// to be distributed to large no of nodes. For larger than 2K, user should
-	// use NetworkDataSecretRef and UserDataSecretRef
-	cloudInitUserMaxLen    = 2048
-	cloudInitNetworkMaxLen = 2048
+	maxStrLen = 256
 
 	// Copied from kubernetes/pkg/apis/core/validation/validation.go
 	maxDNSNameservers     = 3
 	maxDNSSearchPaths     = 6
 	maxDNSSearchListChars = 256
+
+	// maxVCPUs is the largest number of vCPUs a guest can have, neither KVM nor QEMU
+	// are able to start guests with larger CPU topologies
+	maxVCPUs = 1024
+
+	// maxPciSlot is the last of the 32 slots of a PCI bus
+	maxPciSlot = 0x1f
 )
 
+// QEMU option names are identifiers, -global options are addressed as driver.property
+var validQEMUMachineOptionName = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+var validQEMUGlobalName = regexp.MustCompile(`^[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+$`)
+
 var validInterfaceModels = map[string]*struct{}{"e1000": nil, "e1000e": nil, "ne2k_pci": nil, "pcnet": nil, "rtl8139": nil, "virtio": nil}
+var validChannelTarget = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// guestAgentChannelTarget is the target of the channel of the guest agent, which virt-launcher always adds
+const guestAgentChannelTarget = "org.qemu.guest_agent.0"
+
 var validIOThreadsPolicies = []v1.IOThreadsPolicy{v1.IOThreadsPolicyShared, v1.IOThreadsPolicyAuto}
+var validSCSIControllerModels = []v1.SCSIControllerModel{v1.SCSIControllerModelVirtio, v1.SCSIControllerModelLSILogic}
 var validCPUFeaturePolicies = map[string]*struct{}{"": nil, "force": nil, "require": nil, "optional": nil, "disable": nil, "forbid": nil}
 
+// supportedHugepagesSizes are the hugepage sizes of the architectures, which have a fixed set of them
+var supportedHugepagesSizes = map[string][]string{
+	"amd64": {"2Mi", "1Gi"},
+	"arm64": {"2Mi", "1Gi"},
+}
+
 var restriectedVmiLabels = map[string]bool{
 	v1.CreatedByLabel:               true,
 	v1.MigrationJobLabel:            true,
@@ -73,6 +102,9 @@ var restriectedVmiLabels = map[string]bool{
 	v1.InstallStrategyLabel:         true,
 }
 
+// containerDiskArchitectureLookupTimeout bounds all registry lookups of an admission review, the webhooks time out after 10 seconds
+const containerDiskArchitectureLookupTimeout = 3 * time.Second
+
 const (
 	nameOfTypeNotFoundMessagePattern  = "%s '%s' not found."
 	listExceedsLimitMessagePattern    = "%s list exceeds the %d element limit in length"
@@ -80,7 +112,10 @@ const (
 )
 
 type VMICreateAdmitter struct {
-	ClusterConfig *virtconfig.ClusterConfig
+	ClusterConfig        *virtconfig.ClusterConfig
+	VirtClient           kubecli.KubevirtClient
+	SignatureVerifier    containerdisk.ImageSignatureVerifier
+	ArchitectureResolver containerdisk.ImageArchitectureResolver
 }
 
 func (admitter *VMICreateAdmitter) Admit(ar *admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
@@ -96,6 +131,7 @@ func (admitter *VMICreateAdmitter) Admit(ar *admissionv1.AdmissionReview) *admis
 
 	causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("spec"), &vmi.Spec, admitter.ClusterConfig)
 	causes = append(causes, ValidateVirtualMachineInstanceMandatoryFields(k8sfield.NewPath("spec"), &vmi.Spec)...)
+	causes = append(causes, validatePinnedContainerDiskImages(k8sfield.NewPath("spec"), &vmi.Spec)...)
 	causes = append(causes, ValidateVirtualMachineInstanceMetadata(k8sfield.NewPath("metadata"), &vmi.ObjectMeta, admitter.ClusterConfig, accountName)...)
 	// In a future, yet undecided, release either libvirt or QEMU are going to check the hyperv dependencies, so we can get rid of this code.
 	causes = append(causes, webhooks.ValidateVirtualMachineInstanceHypervFeatureDependencies(k8sfield.NewPath("spec"), &vmi.Spec)...)
@@ -103,63 +139,206 @@ func (admitter *VMICreateAdmitter) Admit(ar *admissionv1.AdmissionReview) *admis
 		// Check if there is any unsupported setting if the arch is Arm64
 		causes = append(causes, webhooks.ValidateVirtualMachineInstanceArm64Setting(k8sfield.NewPath("spec"), &vmi.Spec)...)
 	}
+	warnings := CollectVirtualMachineInstanceWarnings(k8sfield.NewPath("spec"), &vmi.Spec)
+	if len(causes) > 0 {
+		return webhookutils.WithWarnings(webhookutils.ToAdmissionResponse(causes), warnings)
+	}
+
+	causes = ValidateVirtualMachineInstancePolicies(k8sfield.NewPath("metadata"), k8sfield.NewPath("spec"), &vmi.ObjectMeta, &vmi.Spec)
+	if len(causes) > 0 {
+		return webhookutils.WithWarnings(webhookutils.ToAdmissionResponse(causes), warnings)
+	}
+
+	// namespaced feature gates are only checked for otherwise valid VMIs, which passed the cluster-wide gate checks
+	causes = validateNamespacedFeatureGates(k8sfield.NewPath("spec"), &vmi.Spec, ar.Request.Namespace, admitter.ClusterConfig)
+	if len(causes) > 0 {
+		return webhookutils.WithWarnings(webhookutils.ToAdmissionResponse(causes), warnings)
+	}
+
+	causes, err = validateSidecarVolumeModes(k8sfield.NewPath("spec"), &vmi.Spec, ar.Request.Namespace, admitter.VirtClient)
+	if err != nil {
+		return webhookutils.ToAdmissionResponseError(err)
+	}
+	if len(causes) > 0 {
+		return webhookutils.WithWarnings(webhookutils.ToAdmissionResponse(causes), warnings)
+	}
+
+	// signatures are only verified for otherwise valid VMIs, it involves requests to the registries
+	causes, err = validateContainerDiskSignatures(k8sfield.NewPath("spec"), &vmi.Spec, admitter.ClusterConfig, admitter.VirtClient, admitter.SignatureVerifier)
+	if err != nil {
+		return webhookutils.ToAdmissionResponseError(err)
+	}
+	if len(causes) > 0 {
+		return webhookutils.WithWarnings(webhookutils.ToAdmissionResponse(causes), warnings)
+	}
+
+	causes = validateContainerDiskArchitectures(k8sfield.NewPath("spec"), &vmi.Spec, admitter.ArchitectureResolver)
 	if len(causes) > 0 {
-		return webhookutils.ToAdmissionResponse(causes)
+		return webhookutils.WithWarnings(webhookutils.ToAdmissionResponse(causes), warnings)
 	}
 
 	reviewResponse := admissionv1.AdmissionResponse{}
 	reviewResponse.Allowed = true
-	return &reviewResponse
+	return webhookutils.WithWarnings(&reviewResponse, warnings)
+}
+
+// CollectVirtualMachineInstanceWarnings returns the soft problems of the VMI spec, they are reported to the
+// client as admission warnings but don't prevent the creation of the VMI
+func CollectVirtualMachineInstanceWarnings(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) []string {
+	var warnings []string
+	for idx, disk := range spec.Domain.Devices.Disks {
+		if disk.Cache == v1.CacheWriteThrough {
+			warnings = append(warnings, fmt.Sprintf("%s uses the %s cache mode, writes can be considerably slower on rotational disks",
+				field.Child("domain", "devices", "disks").Index(idx).Child("cache").String(), v1.CacheWriteThrough))
+		}
+	}
+	return warnings
+}
+
+// ValidateVirtualMachineInstancePolicies checks the VMI against the constraints of the ValidationPolicies in the
+// cluster, they are meant to be evaluated once the VMI passed the built-in checks
+func ValidateVirtualMachineInstancePolicies(metadataField *k8sfield.Path, field *k8sfield.Path, metadata *metav1.ObjectMeta, spec *v1.VirtualMachineInstanceSpec) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	policies := webhooks.GetInformers().ValidationPolicyInformer.GetStore().List()
+	sort.Slice(policies, func(i, j int) bool {
+		return policies[i].(*v1.ValidationPolicy).Name < policies[j].(*v1.ValidationPolicy).Name
+	})
+	for _, obj := range policies {
+		causes = append(causes, validateVirtualMachineInstancePolicy(metadataField, field, metadata, spec, obj.(*v1.ValidationPolicy))...)
+	}
+	return causes
+}
+
+func validateVirtualMachineInstancePolicy(metadataField *k8sfield.Path, field *k8sfield.Path, metadata *metav1.ObjectMeta, spec *v1.VirtualMachineInstanceSpec, policy *v1.ValidationPolicy) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+
+	// the topology was already checked against maxVCPUs, the product can't overflow
+	if policy.Spec.MaxCPUs != nil && spec.Domain.CPU != nil {
+		vCPUs := hwutil.GetNumberOfVCPUs(spec.Domain.CPU)
+		if vCPUs > int64(*policy.Spec.MaxCPUs) {
+			causes = append(causes, metav1.StatusCause{
+				Type: metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s results in %d vCPUs, ValidationPolicy %s allows at most %d",
+					field.Child("domain", "cpu").String(), vCPUs, policy.Name, *policy.Spec.MaxCPUs),
+				Field: field.Child("domain", "cpu").String(),
+			})
+		}
+	}
+
+	if len(policy.Spec.ForbiddenDiskBuses) > 0 {
+		forbiddenBuses := make(map[string]struct{}, len(policy.Spec.ForbiddenDiskBuses))
+		for _, bus := range policy.Spec.ForbiddenDiskBuses {
+			forbiddenBuses[bus] = struct{}{}
+		}
+		for idx, disk := range spec.Domain.Devices.Disks {
+			var busField *k8sfield.Path
+			var bus string
+			diskField := field.Child("domain", "devices", "disks").Index(idx)
+			switch {
+			case disk.Disk != nil:
+				busField, bus = diskField.Child("disk", "bus"), disk.Disk.Bus
+			case disk.LUN != nil:
+				busField, bus = diskField.Child("lun", "bus"), disk.LUN.Bus
+			case disk.CDRom != nil:
+				busField, bus = diskField.Child("cdrom", "bus"), disk.CDRom.Bus
+			default:
+				continue
+			}
+			if _, forbidden := forbiddenBuses[bus]; forbidden {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueNotSupported,
+					Message: fmt.Sprintf("%s '%s' is forbidden by ValidationPolicy %s", busField.String(), bus, policy.Name),
+					Field:   busField.String(),
+				})
+			}
+		}
+	}
+
+	for _, label := range policy.Spec.RequiredLabels {
+		if _, exists := metadata.Labels[label]; !exists {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: fmt.Sprintf("label '%s' is required by ValidationPolicy %s", label, policy.Name),
+				Field:   metadataField.Child("labels").String(),
+			})
+		}
+	}
+
+	if len(policy.Spec.AllowedContainerDiskRegistries) > 0 {
+		for idx, volume := range spec.Volumes {
+			if volume.ContainerDisk == nil {
+				continue
+			}
+			if !containerdisk.IsImageFromRegistries(volume.ContainerDisk.Image, policy.Spec.AllowedContainerDiskRegistries) {
+				imageField := field.Child("volumes").Index(idx).Child("containerDisk", "image")
+				causes = append(causes, metav1.StatusCause{
+					Type: metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("%s '%s' is not from one of the registries %s allowed by ValidationPolicy %s",
+						imageField.String(), volume.ContainerDisk.Image, strings.Join(policy.Spec.AllowedContainerDiskRegistries, ", "), policy.Name),
+					Field: imageField.String(),
+				})
+			}
+		}
+	}
+
+	return causes
 }
 
 func ValidateVirtualMachineInstanceSpec(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) []metav1.StatusCause {
 	var causes []metav1.StatusCause
 	volumeNameMap := make(map[string]*v1.Volume)
 	networkNameMap := make(map[string]*v1.Network)
+	maxListLength := config.GetMaxListLength()
 
-	maxNumberOfDisksExceeded := len(spec.Domain.Devices.Disks) > arrayLenMax
+	maxNumberOfDisksExceeded := len(spec.Domain.Devices.Disks) > maxListLength
 	if maxNumberOfDisksExceeded {
-		return appendNewStatusCauseForNumberOfDisksExceeded(field, causes)
+		return appendNewStatusCauseForNumberOfDisksExceeded(field, causes, maxListLength)
 	}
 
-	maxNumberOfVolumesExceeded := len(spec.Volumes) > arrayLenMax
+	maxNumberOfVolumesExceeded := len(spec.Volumes) > maxListLength
 	if maxNumberOfVolumesExceeded {
-		return appendNewStatusCauseForMaxNumberOfVolumesExceeded(field, causes)
+		return appendNewStatusCauseForMaxNumberOfVolumesExceeded(field, causes, maxListLength)
 	}
 
 	causes = append(causes, validateHostNameNotConformingToDNSLabelRules(field, spec)...)
-	causes = append(causes, validateSubdomainDNSSubdomainRules(field, spec)...)
+	causes = append(causes, validateSubdomainDNSLabelRules(field, spec)...)
 	causes = append(causes, validateMemoryRequestsNegativeOrNull(field, spec)...)
 	causes = append(causes, validateMemoryLimitsNegativeOrNull(field, spec)...)
 	causes = append(causes, validateHugepagesMemoryRequests(field, spec)...)
 	causes = append(causes, validateVhostuserSpec(field, spec)...)
 	causes = append(causes, validateGuestMemoryLimit(field, spec)...)
 	causes = append(causes, validateEmulatedMachine(field, spec, config)...)
+	causes = append(causes, validateMachineQEMUOptions(field, spec, config)...)
 	causes = append(causes, validateFirmwareSerial(field, spec)...)
 	causes = append(causes, validateCPURequestNotNegative(field, spec)...)
 	causes = append(causes, validateCPULimitNotNegative(field, spec)...)
 	causes = append(causes, validateCpuRequestDoesNotExceedLimit(field, spec)...)
+	causes = append(causes, validateResourceRequestsDoNotExceedLimits(field, spec)...)
+	causes = append(causes, validateCPUTopology(field, spec)...)
 	causes = append(causes, validateCpuPinning(field, spec)...)
 	causes = append(causes, validateNUMA(field, spec, config)...)
 	causes = append(causes, validateCPUIsolatorThread(field, spec)...)
 	causes = append(causes, validateCPUFeaturePolicies(field, spec)...)
 	causes = append(causes, validateStartStrategy(field, spec)...)
+	causes = append(causes, validateSchedulingReadinessGates(field.Child("schedulingReadinessGates"), spec.SchedulingReadinessGates)...)
+	causes = append(causes, validateLauncherPodSettings(field, spec, config)...)
+	causes = append(causes, validateSidecars(field.Child("sidecars"), spec, config)...)
 
-	maxNumberOfInterfacesExceeded := len(spec.Domain.Devices.Interfaces) > arrayLenMax
+	maxNumberOfInterfacesExceeded := len(spec.Domain.Devices.Interfaces) > maxListLength
 	if maxNumberOfInterfacesExceeded {
-		return appendStatusCauseForMaxNumberOfInterfacesExceeded(field, causes)
+		return appendStatusCauseForMaxNumberOfInterfacesExceeded(field, causes, maxListLength)
 	}
-	maxNumberOfNetworksExceeded := len(spec.Networks) > arrayLenMax
+	maxNumberOfNetworksExceeded := len(spec.Networks) > maxListLength
 	if maxNumberOfNetworksExceeded {
-		return appendStatusCauseMaxNumberOfNetworksExceeded(field, causes)
+		return appendStatusCauseMaxNumberOfNetworksExceeded(field, causes, maxListLength)
 	}
 	moreThanOnePodInterface := getNumberOfPodInterfaces(spec) > 1
 	if moreThanOnePodInterface {
 		return appendStatusCauseForMoreThanOnePodInterface(field, causes)
 	}
 
-	bootOrderMap, newCauses := validateBootOrder(field, spec, volumeNameMap)
-	causes = append(causes, newCauses...)
+	causes = append(causes, validateDisksMatchVolumes(field, spec, volumeNameMap)...)
+	causes = append(causes, validateBootOrder(field, spec)...)
 	podExists, multusDefaultCount, newCauses := validateNetworks(field, spec, networkNameMap)
 	causes = append(causes, newCauses...)
 
@@ -170,7 +349,7 @@ func ValidateVirtualMachineInstanceSpec(field *k8sfield.Path, spec *v1.VirtualMa
 		causes = appendStatusCauseForPodNetworkDefinedWithMultusDefaultNetworkDefined(field, causes)
 	}
 
-	networkInterfaceMap, vifMQ, isVirtioNicRequested, newCauses, done := validateNetworksMatchInterfaces(field, spec, config, networkNameMap, bootOrderMap)
+	networkInterfaceMap, vifMQ, isVirtioNicRequested, newCauses, done := validateNetworksMatchInterfaces(field, spec, config, networkNameMap)
 	causes = append(causes, newCauses...)
 	if done {
 		return causes
@@ -180,19 +359,22 @@ func ValidateVirtualMachineInstanceSpec(field *k8sfield.Path, spec *v1.VirtualMa
 	causes = append(causes, validateNetworksAssignedToInterfaces(field, spec, networkInterfaceMap)...)
 
 	causes = append(causes, validateInputDevices(field, spec)...)
+	causes = append(causes, validateSerialChannels(field.Child("domain", "devices", "channels"), spec.Domain.Devices.Channels)...)
 	causes = append(causes, validateIOThreadsPolicy(field, spec)...)
+	causes = append(causes, validateSCSIController(field, spec)...)
 	causes = append(causes, validateProbe(field.Child("readinessProbe"), spec.ReadinessProbe)...)
 	causes = append(causes, validateProbe(field.Child("livenessProbe"), spec.LivenessProbe)...)
+	causes = append(causes, validateLivenessProbeSuccessThreshold(field.Child("livenessProbe"), spec.LivenessProbe)...)
 
 	if getNumberOfPodInterfaces(spec) < 1 {
 		causes = appendStatusCauseForProbeNotAllowedWithNoPodNetworkPresent(field.Child("readinessProbe"), spec.ReadinessProbe, causes)
 		causes = appendStatusCauseForProbeNotAllowedWithNoPodNetworkPresent(field.Child("livenessProbe"), spec.LivenessProbe, causes)
 	}
 
-	causes = append(causes, validateDomainSpec(field.Child("domain"), &spec.Domain)...)
+	causes = append(causes, validateDomainSpec(field.Child("domain"), &spec.Domain, maxListLength)...)
 	causes = append(causes, validateVolumes(field.Child("volumes"), spec.Volumes, config)...)
 
-	causes = append(causes, validateAccessCredentials(field.Child("accessCredentials"), spec.AccessCredentials, spec.Volumes)...)
+	causes = append(causes, validateAccessCredentials(field.Child("accessCredentials"), spec.AccessCredentials, spec.Volumes, maxListLength)...)
 
 	if spec.DNSPolicy != "" {
 		causes = append(causes, validateDNSPolicy(&spec.DNSPolicy, field.Child("dnsPolicy"))...)
@@ -202,11 +384,13 @@ func ValidateVirtualMachineInstanceSpec(field *k8sfield.Path, spec *v1.VirtualMa
 	causes = append(causes, validateGPUsWithPassthroughEnabled(field, spec, config)...)
 	causes = append(causes, validateFilesystemsWithVirtIOFSEnabled(field, spec, config)...)
 	causes = append(causes, validateHostDevicesWithPassthroughEnabled(field, spec, config)...)
+	causes = append(causes, validateHostDevicePCIOrder(field, spec)...)
+	causes = append(causes, validateShareableDisks(field, spec)...)
 
 	return causes
 }
 
-func validateNetworksMatchInterfaces(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig, networkNameMap map[string]*v1.Network, bootOrderMap map[uint]bool) (networkInterfaceMap map[string]struct{}, vifMQ *bool, isVirtioNicRequested bool, causes []metav1.StatusCause, done bool) {
+func validateNetworksMatchInterfaces(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig, networkNameMap map[string]*v1.Network) (networkInterfaceMap map[string]struct{}, vifMQ *bool, isVirtioNicRequested bool, causes []metav1.StatusCause, done bool) {
 
 	done = false
 
@@ -224,6 +408,7 @@ func validateNetworksMatchInterfaces(field *k8sfield.Path, spec *v1.VirtualMachi
 
 		networkData, networkExists := networkNameMap[iface.Name]
 
+		causes = append(causes, validateInterfaceBindingMethod(field, iface, idx)...)
 		causes = append(causes, validateInterfaceNetworkBasics(field, networkExists, idx, iface, networkData, config)...)
 
 		causes = append(causes, validateInterfaceNameUnique(field, networkInterfaceMap, iface, idx)...)
@@ -234,8 +419,10 @@ func validateNetworksMatchInterfaces(field *k8sfield.Path, spec *v1.VirtualMachi
 		causes = append(causes, validatePortConfiguration(field, networkExists, networkData, iface, idx, portForwardMap)...)
 		causes = append(causes, validateInterfaceModel(field, iface, idx)...)
 		causes = append(causes, validateMacAddress(field, iface, idx)...)
-		causes = append(causes, validateInterfaceBootOrder(field, iface, idx, bootOrderMap)...)
 		causes = append(causes, validateInterfacePciAddress(field, iface, idx)...)
+		causes = append(causes, validateInterfaceBandwidth(field, iface, idx)...)
+		causes = append(causes, validateInterfaceBandwidthLimit(field, iface, idx)...)
+		causes = append(causes, validateInterfaceMirror(field, iface, idx, config)...)
 
 		newCauses, newDone := validateDHCPExtraOptions(field, iface)
 		causes = append(causes, newCauses...)
@@ -249,10 +436,37 @@ func validateNetworksMatchInterfaces(field *k8sfield.Path, spec *v1.VirtualMachi
 		}
 
 		causes = append(causes, validateDHCPNTPServersAreValidIPv4Addresses(field, iface, idx)...)
+		causes = append(causes, validateDHCPLeaseTime(field, iface, idx)...)
 	}
 	return networkInterfaceMap, vifMQ, isVirtioNicRequested, causes, done
 }
 
+// validateInterfaceBindingMethod checks that at most one binding method is set on the interface, virt-launcher
+// would otherwise pick one of them based on its own precedence
+func validateInterfaceBindingMethod(field *k8sfield.Path, iface v1.Interface, idx int) (causes []metav1.StatusCause) {
+	bindingMethods := 0
+	for _, isSet := range []bool{
+		iface.Bridge != nil,
+		iface.Slirp != nil,
+		iface.Masquerade != nil,
+		iface.SRIOV != nil,
+		iface.Macvtap != nil,
+		iface.Vhostuser != nil,
+	} {
+		if isSet {
+			bindingMethods++
+		}
+	}
+	if bindingMethods > 1 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "should have only one binding method",
+			Field:   field.Child("domain", "devices", "interfaces").Index(idx).String(),
+		})
+	}
+	return causes
+}
+
 func validateInterfaceNetworkBasics(field *k8sfield.Path, networkExists bool, idx int, iface v1.Interface, networkData *v1.Network, config *virtconfig.ClusterConfig) (causes []metav1.StatusCause) {
 	if !networkExists {
 		causes = appendStatusCauseForNetworkNotFound(field, causes, idx, iface)
@@ -305,6 +519,22 @@ func validateDHCPNTPServersAreValidIPv4Addresses(field *k8sfield.Path, iface v1.
 	return causes
 }
 
+// validateDHCPLeaseTime ensures the lease time fits into DHCP option 051, which counts whole seconds in 32 bits
+func validateDHCPLeaseTime(field *k8sfield.Path, iface v1.Interface, idx int) (causes []metav1.StatusCause) {
+	if iface.DHCPOptions == nil || iface.DHCPOptions.LeaseTime == nil {
+		return causes
+	}
+	leaseTime := iface.DHCPOptions.LeaseTime.Duration
+	if leaseTime < time.Second || leaseTime >= math.MaxUint32*time.Second {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("DHCP lease time must be at least 1s and less than %s.", math.MaxUint32*time.Second),
+			Field:   field.Child("domain", "devices", "interfaces").Index(idx).Child("dhcpOptions", "leaseTime").String(),
+		})
+	}
+	return causes
+}
+
 func validateDHCPPrivateOptionsWithinRange(field *k8sfield.Path, DHCPPrivateOption v1.DHCPPrivateOptions) (causes []metav1.StatusCause) {
 	if !(DHCPPrivateOption.Option >= 224 && DHCPPrivateOption.Option <= 254) {
 		causes = append(causes, metav1.StatusCause{
@@ -325,13 +555,54 @@ func appendStatusCauseForDuplicateDHCPOptionFound(field *k8sfield.Path, causes [
 	return causes
 }
 
+func validateInterfaceBandwidth(field *k8sfield.Path, iface v1.Interface, idx int) (causes []metav1.StatusCause) {
+	if iface.Bandwidth != nil && iface.Bandwidth.Sign() <= 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type: metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s '%s': must be greater than 0.",
+				field.Child("domain", "devices", "interfaces").Index(idx).Child("bandwidth").String(), iface.Bandwidth),
+			Field: field.Child("domain", "devices", "interfaces").Index(idx).Child("bandwidth").String(),
+		})
+	}
+	return causes
+}
+
+// validateInterfaceBandwidthLimit checks the traffic shaping of an interface, libvirt can only shape the traffic
+// of the tap devices of the bridge, masquerade and macvtap bindings
+func validateInterfaceBandwidthLimit(field *k8sfield.Path, iface v1.Interface, idx int) (causes []metav1.StatusCause) {
+	if iface.BandwidthLimit == nil {
+		return nil
+	}
+	limitField := field.Child("domain", "devices", "interfaces").Index(idx).Child("bandwidthLimit")
+	if iface.SRIOV != nil || iface.Slirp != nil || iface.Vhostuser != nil {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("%s is not supported for the binding of interface %s", limitField.String(), iface.Name),
+			Field:   limitField.String(),
+		})
+	}
+	causes = append(causes, validateBandwidthRate(limitField.Child("inbound"), iface.BandwidthLimit.Inbound)...)
+	causes = append(causes, validateBandwidthRate(limitField.Child("outbound"), iface.BandwidthLimit.Outbound)...)
+	return causes
+}
+
+func validateBandwidthRate(field *k8sfield.Path, rate *resource.Quantity) (causes []metav1.StatusCause) {
+	if rate != nil && rate.Sign() <= 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s '%s': must be greater than 0.", field.String(), rate),
+			Field:   field.String(),
+		})
+	}
+	return causes
+}
+
 func validateInterfacePciAddress(field *k8sfield.Path, iface v1.Interface, idx int) (causes []metav1.StatusCause) {
 	if iface.PciAddress != "" {
-		_, err := hwutil.ParsePciAddress(iface.PciAddress)
-		if err != nil {
+		if err := validatePciAddress(iface.PciAddress); err != nil {
 			causes = append(causes, metav1.StatusCause{
 				Type:    metav1.CauseTypeFieldValueInvalid,
-				Message: fmt.Sprintf("interface %s has malformed PCI address (%s).", field.Child("domain", "devices", "interfaces").Index(idx).Child("name").String(), iface.PciAddress),
+				Message: fmt.Sprintf("interface %s has malformed PCI address (%s): %v.", field.Child("domain", "devices", "interfaces").Index(idx).Child("name").String(), iface.PciAddress, err),
 				Field:   field.Child("domain", "devices", "interfaces").Index(idx).Child("pciAddress").String(),
 			})
 		}
@@ -339,26 +610,69 @@ func validateInterfacePciAddress(field *k8sfield.Path, iface v1.Interface, idx i
 	return causes
 }
 
-func validateInterfaceBootOrder(field *k8sfield.Path, iface v1.Interface, idx int, bootOrderMap map[uint]bool) (causes []metav1.StatusCause) {
-	if iface.BootOrder != nil {
-		order := *iface.BootOrder
-		// Verify boot order is greater than 0, if provided
-		if order < 1 {
+func validateInterfaceMirror(field *k8sfield.Path, iface v1.Interface, idx int, config *virtconfig.ClusterConfig) (causes []metav1.StatusCause) {
+	if iface.Mirror == nil {
+		return causes
+	}
+	mirrorField := field.Child("domain", "devices", "interfaces").Index(idx).Child("mirror")
+
+	if !config.InterfaceMirroringEnabled() {
+		return append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "InterfaceMirroring feature gate is not enabled",
+			Field:   mirrorField.String(),
+		})
+	}
+	if iface.Bridge == nil {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("interface %s can only be mirrored with the bridge binding.", iface.Name),
+			Field:   mirrorField.String(),
+		})
+	}
+	if iface.Mirror.Duration != nil && iface.Mirror.Duration.Duration <= 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "the mirror duration must be greater than 0.",
+			Field:   mirrorField.Child("duration").String(),
+		})
+	}
+
+	filter := iface.Mirror.Filter
+	if filter == nil {
+		return causes
+	}
+	switch filter.Direction {
+	case "", v1.InterfaceMirrorIngress, v1.InterfaceMirrorEgress, v1.InterfaceMirrorBoth:
+	default:
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("mirror direction %s is not supported, only ingress, egress or both allowed.", filter.Direction),
+			Field:   mirrorField.Child("filter", "direction").String(),
+		})
+	}
+	switch filter.Protocol {
+	case "", "TCP", "UDP", "ICMP":
+	default:
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("mirror protocol %s is not supported, only TCP, UDP or ICMP allowed.", filter.Protocol),
+			Field:   mirrorField.Child("filter", "protocol").String(),
+		})
+	}
+	if filter.Port != 0 {
+		if filter.Port < 0 || filter.Port > 65535 {
 			causes = append(causes, metav1.StatusCause{
 				Type:    metav1.CauseTypeFieldValueInvalid,
-				Message: fmt.Sprintf("%s must have a boot order > 0, if supplied", field.Index(idx).String()),
-				Field:   field.Index(idx).Child("bootOrder").String(),
+				Message: "the mirror port must be in range 0 < x < 65536.",
+				Field:   mirrorField.Child("filter", "port").String(),
+			})
+		} else if filter.Protocol != "TCP" && filter.Protocol != "UDP" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "the mirror port requires the TCP or UDP protocol.",
+				Field:   mirrorField.Child("filter", "port").String(),
 			})
-		} else {
-			// verify that there are no duplicate boot orders
-			if bootOrderMap[order] {
-				causes = append(causes, metav1.StatusCause{
-					Type:    metav1.CauseTypeFieldValueInvalid,
-					Message: fmt.Sprintf("Boot order for %s already set for a different device.", field.Child("domain", "devices", "interfaces").Index(idx).Child("bootOrder").String()),
-					Field:   field.Child("domain", "devices", "interfaces").Index(idx).Child("bootOrder").String(),
-				})
-			}
-			bootOrderMap[order] = true
 		}
 	}
 	return causes
@@ -622,6 +936,47 @@ func validateInputDevices(field *k8sfield.Path, spec *v1.VirtualMachineInstanceS
 	return causes
 }
 
+// validateSerialChannels checks that the names of the channels can name their sockets and that the targets are unique,
+// the guest agent channel is always attached to the guest
+func validateSerialChannels(field *k8sfield.Path, channels []v1.SerialChannel) (causes []metav1.StatusCause) {
+	names := map[string]bool{}
+	targets := map[string]bool{guestAgentChannelTarget: true}
+	for idx, channel := range channels {
+		nameField := field.Index(idx).Child("name").String()
+		if errs := validation.IsDNS1123Label(channel.Name); len(errs) > 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s is invalid: %s", nameField, strings.Join(errs, ", ")),
+				Field:   nameField,
+			})
+		} else if names[channel.Name] {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueDuplicate,
+				Message: fmt.Sprintf("%s must be unique, %q is used by another channel", nameField, channel.Name),
+				Field:   nameField,
+			})
+		}
+		names[channel.Name] = true
+
+		targetField := field.Index(idx).Child("target").String()
+		if !validChannelTarget.MatchString(channel.Target) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s can only contain alphanumeric characters, dots (.), dashes (-) or underscores (_)", targetField),
+				Field:   targetField,
+			})
+		} else if targets[channel.Target] {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueDuplicate,
+				Message: fmt.Sprintf("%s must be unique, %q is used by another channel", targetField, channel.Target),
+				Field:   targetField,
+			})
+		}
+		targets[channel.Target] = true
+	}
+	return causes
+}
+
 func validateIOThreadsPolicy(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
 	if spec.Domain.IOThreadsPolicy != nil {
 		isValidPolicy := func(policy v1.IOThreadsPolicy) bool {
@@ -643,97 +998,476 @@ func validateIOThreadsPolicy(field *k8sfield.Path, spec *v1.VirtualMachineInstan
 	return causes
 }
 
-func validateProbe(field *k8sfield.Path, probe *v1.Probe) (causes []metav1.StatusCause) {
-	if probe == nil {
-		return causes
-	}
-	numHandlers := 0
-
-	if probe.HTTPGet != nil {
-		numHandlers++
-	}
-	if probe.TCPSocket != nil {
-		numHandlers++
-	}
-	if probe.Exec != nil {
-		numHandlers++
-	}
-	if probe.GuestAgentPing != nil {
-		numHandlers++
-	}
-
-	if numHandlers > 1 {
+func validateDiskDiscard(field *k8sfield.Path, disk *v1.Disk) (causes []metav1.StatusCause) {
+	if disk.CDRom != nil || disk.Floppy != nil {
 		causes = append(causes, metav1.StatusCause{
 			Type:    metav1.CauseTypeFieldValueInvalid,
-			Message: fmt.Sprintf("%s must have exactly one probe type set", field),
+			Message: fmt.Sprintf("%s and %s are only supported for disk and lun devices", field.Child("discard").String(), field.Child("detectZeroes").String()),
 			Field:   field.String(),
 		})
 	}
 
-	if numHandlers < 1 {
+	if disk.Discard != "" && disk.Discard != v1.DiscardUnmap && disk.Discard != v1.DiscardIgnore {
 		causes = append(causes, metav1.StatusCause{
-			Type: metav1.CauseTypeFieldValueRequired,
-			Message: fmt.Sprintf("either %s, %s or %s must be set if a %s is specified",
-				field.Child("tcpSocket").String(),
-				field.Child("exec").String(),
-				field.Child("httpGet").String(),
-				field,
-			),
-			Field: field.String(),
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("%s has invalid value '%s', allowed are '%s' or '%s'", field.Child("discard").String(), disk.Discard, v1.DiscardUnmap, v1.DiscardIgnore),
+			Field:   field.Child("discard").String(),
 		})
 	}
 
-	return causes
-}
-
-func appendStatusCauseForProbeNotAllowedWithNoPodNetworkPresent(field *k8sfield.Path, probe *v1.Probe, causes []metav1.StatusCause) []metav1.StatusCause {
-	if probe == nil {
-		return causes
-	}
-
-	if probe.HTTPGet != nil {
-		causes = append(causes, podNetworkRequiredStatusCause(field.Child("httpGet")))
+	switch disk.DetectZeroes {
+	case "", v1.DetectZeroesOff, v1.DetectZeroesOn:
+	case v1.DetectZeroesUnmap:
+		if disk.Discard == v1.DiscardIgnore {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s '%s' requires %s to be '%s'", field.Child("detectZeroes").String(), disk.DetectZeroes, field.Child("discard").String(), v1.DiscardUnmap),
+				Field:   field.Child("detectZeroes").String(),
+			})
+		}
+	default:
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("%s has invalid value '%s', allowed are '%s', '%s' or '%s'", field.Child("detectZeroes").String(), disk.DetectZeroes, v1.DetectZeroesOff, v1.DetectZeroesOn, v1.DetectZeroesUnmap),
+			Field:   field.Child("detectZeroes").String(),
+		})
 	}
 
-	if probe.TCPSocket != nil {
-		causes = append(causes, podNetworkRequiredStatusCause(field.Child("tcpSocket")))
-	}
 	return causes
 }
 
-func podNetworkRequiredStatusCause(field *k8sfield.Path) metav1.StatusCause {
-	return metav1.StatusCause{
-		Type:    metav1.CauseTypeFieldValueInvalid,
-		Message: fmt.Sprintf("%s is only allowed if the Pod Network is attached", field.String()),
-		Field:   field.String(),
-	}
-}
-
-func validateLiveMigration(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) (causes []metav1.StatusCause) {
-	if !config.LiveMigrationEnabled() && spec.EvictionStrategy != nil {
+func validateDiskImageOptions(field *k8sfield.Path, format v1.DiskImageFormat, preallocation v1.DiskImagePreallocation) (causes []metav1.StatusCause) {
+	switch format {
+	case "", v1.DiskImageFormatRaw, v1.DiskImageFormatQCOW2:
+	default:
 		causes = append(causes, metav1.StatusCause{
-			Type:    metav1.CauseTypeFieldValueInvalid,
-			Message: "LiveMigration feature gate is not enabled",
-			Field:   field.Child("evictionStrategy").String(),
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("%s has invalid value '%s', allowed are '%s' or '%s'", field.Child("imageFormat").String(), format, v1.DiskImageFormatRaw, v1.DiskImageFormatQCOW2),
+			Field:   field.Child("imageFormat").String(),
 		})
-	} else if spec.EvictionStrategy != nil {
-		if *spec.EvictionStrategy != v1.EvictionStrategyLiveMigrate {
+	}
+
+	switch preallocation {
+	case "", v1.DiskImagePreallocationOff, v1.DiskImagePreallocationFalloc, v1.DiskImagePreallocationFull:
+	case v1.DiskImagePreallocationMetadata:
+		if format == v1.DiskImageFormatRaw {
 			causes = append(causes, metav1.StatusCause{
 				Type:    metav1.CauseTypeFieldValueInvalid,
-				Message: fmt.Sprintf("%s is set with an unrecognized option: %s", field.Child("evictionStrategy").String(), *spec.EvictionStrategy),
-				Field:   field.Child("evictionStrategy").String(),
+				Message: fmt.Sprintf("%s '%s' is only supported for '%s' images", field.Child("preallocation").String(), preallocation, v1.DiskImageFormatQCOW2),
+				Field:   field.Child("preallocation").String(),
 			})
 		}
-
+	default:
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("%s has invalid value '%s', allowed are '%s', '%s', '%s' or '%s'", field.Child("preallocation").String(), preallocation, v1.DiskImagePreallocationOff, v1.DiskImagePreallocationMetadata, v1.DiskImagePreallocationFalloc, v1.DiskImagePreallocationFull),
+			Field:   field.Child("preallocation").String(),
+		})
 	}
+
 	return causes
 }
 
-func validateGPUsWithPassthroughEnabled(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) (causes []metav1.StatusCause) {
-	if spec.Domain.Devices.GPUs != nil && !config.GPUPassthroughEnabled() {
+func validateContainerDiskImageDigest(field *k8sfield.Path, containerDisk *v1.ContainerDiskSource) (causes []metav1.StatusCause) {
+	digest := containerdisk.ImageDigest(containerDisk.Image)
+	if digest != "" && !containerdisk.IsValidImageDigest(digest) {
 		causes = append(causes, metav1.StatusCause{
 			Type:    metav1.CauseTypeFieldValueInvalid,
-			Message: fmt.Sprintf("GPU feature gate is not enabled in kubevirt-config"),
+			Message: fmt.Sprintf("%s has an invalid digest '%s', expected a sha256 digest", field.Child("image").String(), digest),
+			Field:   field.Child("image").String(),
+		})
+	}
+
+	switch containerDisk.ImageDigestPolicy {
+	case "":
+	case v1.ContainerDiskImageDigestPolicyRequire:
+		if digest == "" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must be referenced by digest when %s is %s", field.Child("image").String(), field.Child("imageDigestPolicy").String(), containerDisk.ImageDigestPolicy),
+				Field:   field.Child("image").String(),
+			})
+		}
+	case v1.ContainerDiskImageDigestPolicyPin:
+		// VirtualMachine templates keep the tag until virt-controller pins them when it starts the VirtualMachine
+	default:
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("%s has invalid value '%s', allowed are '%s' or '%s'", field.Child("imageDigestPolicy").String(), containerDisk.ImageDigestPolicy, v1.ContainerDiskImageDigestPolicyRequire, v1.ContainerDiskImageDigestPolicyPin),
+			Field:   field.Child("imageDigestPolicy").String(),
+		})
+	}
+	return causes
+}
+
+// validatePinnedContainerDiskImages rejects VMIs with images referenced by tag with the Pin policy. Only
+// virt-controller pins images, when it starts a VirtualMachine, so they must be pinned by the creator otherwise.
+func validatePinnedContainerDiskImages(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
+	for idx, volume := range spec.Volumes {
+		if volume.ContainerDisk == nil || volume.ContainerDisk.ImageDigestPolicy != v1.ContainerDiskImageDigestPolicyPin {
+			continue
+		}
+		if containerdisk.ImageDigest(volume.ContainerDisk.Image) == "" {
+			imageField := field.Child("volumes").Index(idx).Child("containerDisk", "image")
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must be referenced by digest, images with the %s policy are only pinned for VirtualMachines", imageField.String(), v1.ContainerDiskImageDigestPolicyPin),
+				Field:   imageField.String(),
+			})
+		}
+	}
+	return causes
+}
+
+func validateContainerDiskRegistry(field *k8sfield.Path, containerDisk *v1.ContainerDiskSource, config *virtconfig.ClusterConfig) (causes []metav1.StatusCause) {
+	policy := config.GetContainerDiskPolicy()
+	if policy == nil || len(policy.AllowedRegistries) == 0 {
+		return nil
+	}
+	if !containerdisk.IsImageFromRegistries(containerDisk.Image, policy.AllowedRegistries) {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s '%s' is not from one of the allowed registries %s", field.Child("image").String(), containerDisk.Image, strings.Join(policy.AllowedRegistries, ", ")),
+			Field:   field.Child("image").String(),
+		})
+	}
+	return causes
+}
+
+// validateContainerDiskSignatures verifies the containerDisk images against the public keys of the
+// ConfigMap in the KubeVirt namespace, if the containerDisk policy requires signed images.
+func validateContainerDiskSignatures(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig, client kubecli.KubevirtClient, verifier containerdisk.ImageSignatureVerifier) ([]metav1.StatusCause, error) {
+	policy := config.GetContainerDiskPolicy()
+	if policy == nil || policy.SignatureVerification == nil {
+		return nil, nil
+	}
+
+	var keys []crypto.PublicKey
+	var causes []metav1.StatusCause
+	for idx, volume := range spec.Volumes {
+		if volume.ContainerDisk == nil {
+			continue
+		}
+		if keys == nil {
+			var err error
+			keys, err = loadContainerDiskPublicKeys(client, policy.SignatureVerification.PublicKeysConfigMap)
+			if err != nil {
+				return nil, err
+			}
+		}
+		imageField := field.Child("volumes").Index(idx).Child("containerDisk", "image")
+		if err := verifier.VerifyImageSignature(volume.ContainerDisk.Image, keys); err != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s failed the signature verification: %v", imageField.String(), err),
+				Field:   imageField.String(),
+			})
+		}
+	}
+	return causes, nil
+}
+
+// validateContainerDiskArchitectures verifies that the containerDisk images are available for the
+// architecture the VMI selects with its node selector or node affinity. The container runtime of the
+// node pulls the manifest of its architecture from multi-arch images. The lookups share a deadline well
+// below the timeout of the webhook. Images whose manifest can't be looked up in time, e.g. because the
+// registry requires credentials, are not rejected.
+func validateContainerDiskArchitectures(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, resolver containerdisk.ImageArchitectureResolver) (causes []metav1.StatusCause) {
+	architecture := selectedArchitecture(spec)
+	if resolver == nil || architecture == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), containerDiskArchitectureLookupTimeout)
+	defer cancel()
+	for idx, volume := range spec.Volumes {
+		if volume.ContainerDisk == nil {
+			continue
+		}
+		if ctx.Err() != nil {
+			log.Log.Warningf("Skipped looking up the architectures of the remaining containerDisk images: %v", ctx.Err())
+			break
+		}
+		architectures, err := resolver.ResolveImageArchitectures(ctx, volume.ContainerDisk.Image)
+		if err != nil {
+			log.Log.Reason(err).Warningf("Failed to look up the architectures of the image of containerDisk %s", volume.Name)
+			continue
+		}
+		if !containerdisk.SupportsArchitecture(architectures, architecture) {
+			imageField := field.Child("volumes").Index(idx).Child("containerDisk", "image")
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s is not available for architecture %s selected by the node selector or node affinity, available are: %v", imageField.String(), architecture, architectures),
+				Field:   imageField.String(),
+			})
+		}
+	}
+	return causes
+}
+
+func loadContainerDiskPublicKeys(client kubecli.KubevirtClient, name string) ([]crypto.PublicKey, error) {
+	namespace, err := clientutil.GetNamespace()
+	if err != nil {
+		return nil, err
+	}
+	configMap, err := client.CoreV1().ConfigMaps(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the public keys to verify containerDisk signatures with: %v", err)
+	}
+	keys, err := containerdisk.ParsePublicKeys(configMap.Data)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("configmap %s/%s contains no public keys to verify containerDisk signatures with", namespace, name)
+	}
+	return keys, nil
+}
+
+func validateNetworkDisk(field *k8sfield.Path, networkDisk *v1.NetworkDiskSource, config *virtconfig.ClusterConfig) (causes []metav1.StatusCause) {
+	if !config.NetworkDisksEnabled() {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "NetworkDisks feature gate is not enabled",
+			Field:   field.String(),
+		})
+	}
+
+	switch networkDisk.Protocol {
+	case v1.NetworkDiskProtocolISCSI, v1.NetworkDiskProtocolRBD:
+		if networkDisk.Name == "" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: fmt.Sprintf("%s is required for the %s protocol", field.Child("name").String(), networkDisk.Protocol),
+				Field:   field.Child("name").String(),
+			})
+		}
+	case v1.NetworkDiskProtocolNBD:
+		if networkDisk.Auth != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s is not supported for the %s protocol", field.Child("auth").String(), networkDisk.Protocol),
+				Field:   field.Child("auth").String(),
+			})
+		}
+	default:
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("%s has invalid value '%s', allowed are '%s', '%s' or '%s'", field.Child("protocol").String(), networkDisk.Protocol, v1.NetworkDiskProtocolISCSI, v1.NetworkDiskProtocolNBD, v1.NetworkDiskProtocolRBD),
+			Field:   field.Child("protocol").String(),
+		})
+	}
+
+	if networkDisk.Host.Name == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: fmt.Sprintf("%s is a required field", field.Child("host", "name").String()),
+			Field:   field.Child("host", "name").String(),
+		})
+	}
+	if port := networkDisk.Host.Port; port != "" {
+		if portNumber, err := strconv.Atoi(port); err != nil || portNumber < 1 || portNumber > 65535 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must be a port number between 1 and 65535", field.Child("host", "port").String()),
+				Field:   field.Child("host", "port").String(),
+			})
+		}
+	}
+
+	if auth := networkDisk.Auth; auth != nil {
+		if auth.Username == "" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: fmt.Sprintf("%s is a required field", field.Child("auth", "username").String()),
+				Field:   field.Child("auth", "username").String(),
+			})
+		}
+		if auth.SecretRef == "" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: fmt.Sprintf("%s is a required field", field.Child("auth", "secretRef").String()),
+				Field:   field.Child("auth", "secretRef").String(),
+			})
+		}
+	}
+	return causes
+}
+
+func validateSCSIController(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
+	scsiController := spec.Domain.Devices.SCSIController
+	if scsiController == nil || scsiController.Model == "" {
+		return causes
+	}
+	for _, model := range validSCSIControllerModels {
+		if scsiController.Model == model {
+			return causes
+		}
+	}
+	causes = append(causes, metav1.StatusCause{
+		Type:    metav1.CauseTypeFieldValueNotSupported,
+		Message: fmt.Sprintf("Invalid SCSI controller model (%s)", scsiController.Model),
+		Field:   field.Child("domain", "devices", "scsiController", "model").String(),
+	})
+	return causes
+}
+
+func validateProbe(field *k8sfield.Path, probe *v1.Probe) (causes []metav1.StatusCause) {
+	if probe == nil {
+		return causes
+	}
+	numHandlers := 0
+
+	if probe.HTTPGet != nil {
+		numHandlers++
+	}
+	if probe.TCPSocket != nil {
+		numHandlers++
+	}
+	if probe.Exec != nil {
+		numHandlers++
+	}
+	if probe.GuestAgentPing != nil {
+		numHandlers++
+	}
+
+	if numHandlers > 1 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must have exactly one probe type set", field),
+			Field:   field.String(),
+		})
+	}
+
+	if numHandlers < 1 {
+		causes = append(causes, metav1.StatusCause{
+			Type: metav1.CauseTypeFieldValueRequired,
+			Message: fmt.Sprintf("either %s, %s or %s must be set if a %s is specified",
+				field.Child("tcpSocket").String(),
+				field.Child("exec").String(),
+				field.Child("httpGet").String(),
+				field,
+			),
+			Field: field.String(),
+		})
+	}
+
+	if probe.HTTPGet != nil {
+		causes = append(causes, validateProbePort(field.Child("httpGet", "port"), probe.HTTPGet.Port)...)
+	}
+	if probe.TCPSocket != nil {
+		causes = append(causes, validateProbePort(field.Child("tcpSocket", "port"), probe.TCPSocket.Port)...)
+	}
+
+	for _, setting := range []struct {
+		name  string
+		value int32
+	}{
+		{"initialDelaySeconds", probe.InitialDelaySeconds},
+		{"timeoutSeconds", probe.TimeoutSeconds},
+		{"periodSeconds", probe.PeriodSeconds},
+		{"successThreshold", probe.SuccessThreshold},
+		{"failureThreshold", probe.FailureThreshold},
+	} {
+		if setting.value < 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must not be negative", field.Child(setting.name).String()),
+				Field:   field.Child(setting.name).String(),
+			})
+		}
+	}
+
+	return causes
+}
+
+// validateProbePort checks that the port of a probe is either a valid port number or a valid port name
+func validateProbePort(field *k8sfield.Path, port intstr.IntOrString) (causes []metav1.StatusCause) {
+	var errs []string
+	if port.Type == intstr.String {
+		errs = validation.IsValidPortName(port.StrVal)
+	} else {
+		errs = validation.IsValidPortNum(port.IntValue())
+	}
+	if len(errs) > 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s is invalid: %s", field.String(), strings.Join(errs, ", ")),
+			Field:   field.String(),
+		})
+	}
+	return causes
+}
+
+// validateLivenessProbeSuccessThreshold rejects liveness probes with a success threshold other than 1,
+// which the pod validation would reject when the virt-launcher pod is created
+func validateLivenessProbeSuccessThreshold(field *k8sfield.Path, probe *v1.Probe) (causes []metav1.StatusCause) {
+	if probe != nil && probe.SuccessThreshold > 1 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must be 1", field.Child("successThreshold").String()),
+			Field:   field.Child("successThreshold").String(),
+		})
+	}
+	return causes
+}
+
+func appendStatusCauseForProbeNotAllowedWithNoPodNetworkPresent(field *k8sfield.Path, probe *v1.Probe, causes []metav1.StatusCause) []metav1.StatusCause {
+	if probe == nil {
+		return causes
+	}
+
+	if probe.HTTPGet != nil {
+		causes = append(causes, podNetworkRequiredStatusCause(field.Child("httpGet")))
+	}
+
+	if probe.TCPSocket != nil {
+		causes = append(causes, podNetworkRequiredStatusCause(field.Child("tcpSocket")))
+	}
+	return causes
+}
+
+func podNetworkRequiredStatusCause(field *k8sfield.Path) metav1.StatusCause {
+	return metav1.StatusCause{
+		Type:    metav1.CauseTypeFieldValueInvalid,
+		Message: fmt.Sprintf("%s is only allowed if the Pod Network is attached", field.String()),
+		Field:   field.String(),
+	}
+}
+
+func validateLiveMigration(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) (causes []metav1.StatusCause) {
+	if !config.LiveMigrationEnabled() && spec.EvictionStrategy != nil {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "LiveMigration feature gate is not enabled",
+			Field:   field.Child("evictionStrategy").String(),
+		})
+	} else if spec.EvictionStrategy != nil {
+		if *spec.EvictionStrategy != v1.EvictionStrategyLiveMigrate {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s is set with an unrecognized option: %s", field.Child("evictionStrategy").String(), *spec.EvictionStrategy),
+				Field:   field.Child("evictionStrategy").String(),
+			})
+		} else if cpu := spec.Domain.CPU; cpu != nil && cpu.Model == v1.CPUModeHostPassthrough {
+			if policy := config.GetMigrationConfiguration().HostPassthroughPolicy; policy != nil && *policy == v1.HostPassthroughMigrationDeny {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("%s can not be %s, migrating VMIs with the %s CPU mode is not allowed in this cluster", field.Child("evictionStrategy").String(), v1.EvictionStrategyLiveMigrate, v1.CPUModeHostPassthrough),
+					Field:   field.Child("evictionStrategy").String(),
+				})
+			}
+		}
+
+	}
+	return causes
+}
+
+func validateGPUsWithPassthroughEnabled(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) (causes []metav1.StatusCause) {
+	if spec.Domain.Devices.GPUs != nil && !config.GPUPassthroughEnabled() {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("GPU feature gate is not enabled in kubevirt-config"),
 			Field:   field.Child("GPUs").String(),
 		})
 	}
@@ -762,6 +1496,123 @@ func validateHostDevicesWithPassthroughEnabled(field *k8sfield.Path, spec *v1.Vi
 	return causes
 }
 
+// validateNamespacedFeatureGates rejects the use of features whose gates are only enabled for other namespaces
+func validateNamespacedFeatureGates(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, namespace string, config *virtconfig.ClusterConfig) (causes []metav1.StatusCause) {
+	checkGate := func(featureGate string, featureField *k8sfield.Path) {
+		if config.IsFeatureGateEnabledForNamespace(featureGate, namespace) {
+			return
+		}
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s feature gate is not enabled for namespace %s", featureGate, namespace),
+			Field:   featureField.String(),
+		})
+	}
+
+	devicesField := field.Child("domain", "devices")
+	if len(spec.Domain.Devices.GPUs) > 0 {
+		checkGate(virtconfig.GPUGate, devicesField.Child("gpus"))
+	}
+	if len(spec.Domain.Devices.HostDevices) > 0 {
+		checkGate(virtconfig.HostDevicesGate, devicesField.Child("hostDevices"))
+	}
+	if len(spec.Domain.Devices.Filesystems) > 0 {
+		checkGate(virtconfig.VirtIOFSGate, devicesField.Child("filesystems"))
+	}
+	for idx, iface := range spec.Domain.Devices.Interfaces {
+		if iface.Macvtap != nil {
+			checkGate(virtconfig.MacvtapGate, devicesField.Child("interfaces").Index(idx).Child("macvtap"))
+		}
+		if iface.Mirror != nil {
+			checkGate(virtconfig.InterfaceMirroringGate, devicesField.Child("interfaces").Index(idx).Child("mirror"))
+		}
+	}
+	for idx, volume := range spec.Volumes {
+		volumeField := field.Child("volumes").Index(idx)
+		switch {
+		case volume.HostDisk != nil:
+			checkGate(virtconfig.HostDiskGate, volumeField.Child("hostDisk"))
+		case volume.NetworkDisk != nil:
+			checkGate(virtconfig.NetworkDisksGate, volumeField.Child("networkDisk"))
+		case volume.DownwardMetrics != nil:
+			checkGate(virtconfig.DownwardMetricsFeatureGate, volumeField.Child("downwardMetrics"))
+		}
+	}
+	return causes
+}
+
+func validateHostDevicePCIOrder(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
+	pciOrderMap := make(map[uint]bool)
+	validateOrder := func(field *k8sfield.Path, order *uint) {
+		if order == nil {
+			return
+		}
+		if *order < 1 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must be > 0, if supplied", field.String()),
+				Field:   field.String(),
+			})
+			return
+		}
+		if pciOrderMap[*order] {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("PCI order for %s already set for a different device.", field.String()),
+				Field:   field.String(),
+			})
+		}
+		pciOrderMap[*order] = true
+	}
+
+	devicesField := field.Child("domain", "devices")
+	for idx, gpu := range spec.Domain.Devices.GPUs {
+		validateOrder(devicesField.Child("gpus").Index(idx).Child("pciOrder"), gpu.PCIOrder)
+	}
+	for idx, hostDevice := range spec.Domain.Devices.HostDevices {
+		validateOrder(devicesField.Child("hostDevices").Index(idx).Child("pciOrder"), hostDevice.PCIOrder)
+	}
+	return causes
+}
+
+// validateShareableDisks makes sure that shareable disks are attached to the guest without a host side cache,
+// and that they are backed by persistent volumes instead of ephemeral ones
+func validateShareableDisks(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
+	volumes := make(map[string]v1.Volume)
+	for _, volume := range spec.Volumes {
+		volumes[volume.Name] = volume
+	}
+
+	for idx, disk := range spec.Domain.Devices.Disks {
+		if disk.Shareable == nil || !*disk.Shareable {
+			continue
+		}
+		diskField := field.Child("domain", "devices", "disks").Index(idx)
+		if disk.CDRom != nil || disk.Floppy != nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s can only be shareable if it is a disk or a lun", diskField.String()),
+				Field:   diskField.Child("shareable").String(),
+			})
+		}
+		if disk.Cache != "" && disk.Cache != v1.CacheNone {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s is shareable, it can only use the %s cache mode", diskField.String(), v1.CacheNone),
+				Field:   diskField.Child("cache").String(),
+			})
+		}
+		if volume, exists := volumes[disk.Name]; exists && volume.PersistentVolumeClaim == nil && volume.DataVolume == nil {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s is shareable, it must be backed by a persistentVolumeClaim or a dataVolume", diskField.String()),
+				Field:   diskField.Child("shareable").String(),
+			})
+		}
+	}
+	return causes
+}
+
 func appendStatusCauseForPodNetworkDefinedWithMultusDefaultNetworkDefined(field *k8sfield.Path, causes []metav1.StatusCause) []metav1.StatusCause {
 	return append(causes, metav1.StatusCause{
 		Type:    metav1.CauseTypeFieldValueInvalid,
@@ -839,9 +1690,47 @@ func validateNetworkHasOnlyOneType(field *k8sfield.Path, cniTypesCount int, caus
 	return causes
 }
 
-func validateBootOrder(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, volumeNameMap map[string]*v1.Volume) (bootOrderMap map[uint]bool, causes []metav1.StatusCause) {
-	// used to validate uniqueness of boot orders among disks and interfaces
-	bootOrderMap = make(map[uint]bool)
+// validateBootOrder verifies that boot orders are unique across disks and interfaces, since
+// libvirt refuses to define a domain with duplicate boot orders.
+// Boot orders of disks are verified to be greater than 0 in validateDisks.
+func validateBootOrder(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
+	bootOrderMap := make(map[uint]bool)
+
+	checkUnique := func(devicePath *k8sfield.Path, bootOrder *uint) {
+		if bootOrder == nil || *bootOrder < 1 {
+			return
+		}
+		if bootOrderMap[*bootOrder] {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("Boot order for %s already set for a different device.", devicePath.Child("bootOrder").String()),
+				Field:   devicePath.Child("bootOrder").String(),
+			})
+		}
+		bootOrderMap[*bootOrder] = true
+	}
+
+	for idx, disk := range spec.Domain.Devices.Disks {
+		checkUnique(field.Child("domain", "devices", "disks").Index(idx), disk.BootOrder)
+	}
+
+	for idx, iface := range spec.Domain.Devices.Interfaces {
+		devicePath := field.Child("domain", "devices", "interfaces").Index(idx)
+		// Verify boot order is greater than 0, if provided
+		if iface.BootOrder != nil && *iface.BootOrder < 1 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must have a boot order > 0, if supplied", devicePath.String()),
+				Field:   devicePath.Child("bootOrder").String(),
+			})
+		}
+		checkUnique(devicePath, iface.BootOrder)
+	}
+
+	return causes
+}
+
+func validateDisksMatchVolumes(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, volumeNameMap map[string]*v1.Volume) (causes []metav1.StatusCause) {
 	// to perform as set of volume / fs names
 	diskAndFilesystemNames := make(map[string]struct{})
 
@@ -889,19 +1778,6 @@ func validateBootOrder(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec
 			}
 		}
 
-		// verify that there are no duplicate boot orders
-		if disk.BootOrder != nil {
-			order := *disk.BootOrder
-			if bootOrderMap[order] {
-				causes = append(causes, metav1.StatusCause{
-					Type:    metav1.CauseTypeFieldValueInvalid,
-					Message: fmt.Sprintf("Boot order for %s already set for a different device.", field.Child("domain", "devices", "disks").Index(idx).Child("bootOrder").String()),
-					Field:   field.Child("domain", "devices", "disks").Index(idx).Child("bootOrder").String(),
-				})
-			}
-			bootOrderMap[order] = true
-		}
-
 		diskAndFilesystemNames[disk.Name] = struct{}{}
 	}
 
@@ -921,7 +1797,7 @@ func validateBootOrder(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec
 
 	}
 
-	return bootOrderMap, causes
+	return causes
 }
 
 func appendStatusCauseForMoreThanOnePodInterface(field *k8sfield.Path, causes []metav1.StatusCause) []metav1.StatusCause {
@@ -932,18 +1808,18 @@ func appendStatusCauseForMoreThanOnePodInterface(field *k8sfield.Path, causes []
 	})
 }
 
-func appendStatusCauseMaxNumberOfNetworksExceeded(field *k8sfield.Path, causes []metav1.StatusCause) []metav1.StatusCause {
+func appendStatusCauseMaxNumberOfNetworksExceeded(field *k8sfield.Path, causes []metav1.StatusCause, maxListLength int) []metav1.StatusCause {
 	return append(causes, metav1.StatusCause{
 		Type:    metav1.CauseTypeFieldValueInvalid,
-		Message: fmt.Sprintf(listExceedsLimitMessagePattern, field.Child("networks").String(), arrayLenMax),
+		Message: fmt.Sprintf(listExceedsLimitMessagePattern, field.Child("networks").String(), maxListLength),
 		Field:   field.Child("networks").String(),
 	})
 }
 
-func appendStatusCauseForMaxNumberOfInterfacesExceeded(field *k8sfield.Path, causes []metav1.StatusCause) []metav1.StatusCause {
+func appendStatusCauseForMaxNumberOfInterfacesExceeded(field *k8sfield.Path, causes []metav1.StatusCause, maxListLength int) []metav1.StatusCause {
 	return append(causes, metav1.StatusCause{
 		Type:    metav1.CauseTypeFieldValueInvalid,
-		Message: fmt.Sprintf(listExceedsLimitMessagePattern, field.Child("domain", "devices", "interfaces").String(), arrayLenMax),
+		Message: fmt.Sprintf(listExceedsLimitMessagePattern, field.Child("domain", "devices", "interfaces").String(), maxListLength),
 		Field:   field.Child("domain", "devices", "interfaces").String(),
 	})
 }
@@ -1103,6 +1979,182 @@ func validateStartStrategy(field *k8sfield.Path, spec *v1.VirtualMachineInstance
 	return causes
 }
 
+// conditions managed by KubeVirt itself can't be used as scheduling readiness gates,
+// they are never set to True before the virt-launcher pod exists
+var reservedSchedulingReadinessGates = map[v1.VirtualMachineInstanceConditionType]bool{
+	v1.VirtualMachineInstanceConditionType(k8sv1.PodScheduled): true,
+	v1.VirtualMachineInstanceProvisioning:                      true,
+	v1.VirtualMachineInstanceReady:                             true,
+	v1.VirtualMachineInstanceSynchronized:                      true,
+	v1.VirtualMachineInstancePaused:                            true,
+	v1.VirtualMachineInstanceAgentConnected:                    true,
+	v1.VirtualMachineInstanceAccessCredentialsSynchronized:     true,
+	v1.VirtualMachineInstanceUnsupportedAgent:                  true,
+	v1.VirtualMachineInstanceAgentDegraded:                     true,
+	v1.VirtualMachineInstanceIsMigratable:                      true,
+	v1.VirtualMachineInstanceGuestMemoryPressure:               true,
+	v1.VirtualMachineInstanceGuestCPUSteal:                     true,
+}
+
+func validateSchedulingReadinessGates(field *k8sfield.Path, gates []v1.SchedulingReadinessGate) (causes []metav1.StatusCause) {
+	seen := map[v1.VirtualMachineInstanceConditionType]bool{}
+	for idx, gate := range gates {
+		conditionField := field.Index(idx).Child("conditionType").String()
+		switch {
+		case gate.ConditionType == "":
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: fmt.Sprintf("%s is a required field", conditionField),
+				Field:   conditionField,
+			})
+		case reservedSchedulingReadinessGates[gate.ConditionType]:
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s refers to condition %s which is managed by KubeVirt", conditionField, gate.ConditionType),
+				Field:   conditionField,
+			})
+		case seen[gate.ConditionType]:
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueDuplicate,
+				Message: fmt.Sprintf("%s has duplicate value %s", conditionField, gate.ConditionType),
+				Field:   conditionField,
+			})
+		}
+		seen[gate.ConditionType] = true
+	}
+	return causes
+}
+
+// reservedSidecarNames are the names and name prefixes of the containers rendered by KubeVirt into the virt-launcher pod
+var reservedSidecarNames = []string{"compute", "hook-sidecar-", "volume", "kernel-boot", "container-disk-binary"}
+
+func validateSidecars(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) (causes []metav1.StatusCause) {
+	if len(spec.Sidecars) == 0 {
+		return causes
+	}
+	if !config.SidecarEnabled() {
+		return append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("sidecar feature gate is not enabled in kubevirt-config, invalid entry %s", field.String()),
+			Field:   field.String(),
+		})
+	}
+
+	shareableVolumes := map[string]bool{}
+	for _, volume := range spec.Volumes {
+		switch {
+		case volume.PersistentVolumeClaim != nil:
+			shareableVolumes[volume.Name] = !volume.PersistentVolumeClaim.Hotpluggable
+		case volume.DataVolume != nil:
+			shareableVolumes[volume.Name] = !volume.DataVolume.Hotpluggable
+		case volume.ConfigMap != nil, volume.Secret != nil, volume.DownwardAPI != nil, volume.DownwardMetrics != nil,
+			volume.CloudInitNoCloud != nil, volume.CloudInitConfigDrive != nil:
+			shareableVolumes[volume.Name] = true
+		default:
+			shareableVolumes[volume.Name] = false
+		}
+	}
+
+	seen := map[string]bool{}
+	for idx, sidecar := range spec.Sidecars {
+		nameField := field.Index(idx).Child("name")
+		if errors := validation.IsDNS1123Label(sidecar.Name); len(errors) != 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s does not conform to the kubernetes DNS_LABEL rules : %s", nameField.String(), strings.Join(errors, ", ")),
+				Field:   nameField.String(),
+			})
+		}
+		for _, reserved := range reservedSidecarNames {
+			if strings.HasPrefix(sidecar.Name, reserved) {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueNotSupported,
+					Message: fmt.Sprintf("%s '%s' collides with the containers reserved by KubeVirt", nameField.String(), sidecar.Name),
+					Field:   nameField.String(),
+				})
+				break
+			}
+		}
+		if seen[sidecar.Name] {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueDuplicate,
+				Message: fmt.Sprintf("%s has duplicate value %s", nameField.String(), sidecar.Name),
+				Field:   nameField.String(),
+			})
+		}
+		seen[sidecar.Name] = true
+
+		if sidecar.Image == "" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: fmt.Sprintf("%s is a required field", field.Index(idx).Child("image").String()),
+				Field:   field.Index(idx).Child("image").String(),
+			})
+		}
+
+		for mountIdx, volumeMount := range sidecar.VolumeMounts {
+			mountField := field.Index(idx).Child("volumeMounts").Index(mountIdx)
+			if shareable, exists := shareableVolumes[volumeMount.Name]; !exists {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf(nameOfTypeNotFoundMessagePattern, mountField.Child("name").String(), volumeMount.Name),
+					Field:   mountField.Child("name").String(),
+				})
+			} else if !shareable {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueNotSupported,
+					Message: fmt.Sprintf("%s refers to volume %s which can not be shared with sidecars", mountField.Child("name").String(), volumeMount.Name),
+					Field:   mountField.Child("name").String(),
+				})
+			}
+			if !filepath.IsAbs(volumeMount.MountPath) {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("%s must be an absolute path", mountField.Child("mountPath").String()),
+					Field:   mountField.Child("mountPath").String(),
+				})
+			}
+		}
+	}
+	return causes
+}
+
+// validateSidecarVolumeModes rejects sidecar volume mounts of PersistentVolumeClaims in block mode, they are
+// attached to virt-launcher pods as devices and can't be mounted. Claims which don't exist yet are not rejected.
+func validateSidecarVolumeModes(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, namespace string, client kubecli.KubevirtClient) ([]metav1.StatusCause, error) {
+	claimNames := map[string]string{}
+	for idx := range spec.Volumes {
+		if claimName := types.PVCNameFromVirtVolume(&spec.Volumes[idx]); claimName != "" {
+			claimNames[spec.Volumes[idx].Name] = claimName
+		}
+	}
+
+	var causes []metav1.StatusCause
+	for idx, sidecar := range spec.Sidecars {
+		for mountIdx, volumeMount := range sidecar.VolumeMounts {
+			claimName, isClaim := claimNames[volumeMount.Name]
+			if !isClaim {
+				continue
+			}
+			pvc, err := client.CoreV1().PersistentVolumeClaims(namespace).Get(context.Background(), claimName, metav1.GetOptions{})
+			if errors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return nil, fmt.Errorf("failed to get PersistentVolumeClaim %s: %v", claimName, err)
+			}
+			if pvc.Spec.VolumeMode != nil && *pvc.Spec.VolumeMode == k8sv1.PersistentVolumeBlock {
+				nameField := field.Child("sidecars").Index(idx).Child("volumeMounts").Index(mountIdx).Child("name")
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueNotSupported,
+					Message: fmt.Sprintf("%s refers to volume %s in block mode which can not be mounted into sidecars", nameField.String(), volumeMount.Name),
+					Field:   nameField.String(),
+				})
+			}
+		}
+	}
+	return causes, nil
+}
+
 func validateMemoryRequestsAndLimits(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
 	if spec.Domain.Resources.Requests.Memory().Value() > 0 && spec.Domain.Resources.Limits.Memory().Value() > 0 && spec.Domain.Resources.Requests.Memory().Value() != spec.Domain.Resources.Limits.Memory().Value() {
 		causes = append(causes, metav1.StatusCause{
@@ -1158,11 +2210,88 @@ func validateCpuRequestDoesNotExceedLimit(field *k8sfield.Path, spec *v1.Virtual
 		spec.Domain.Resources.Requests.Cpu().MilliValue() > spec.Domain.Resources.Limits.Cpu().MilliValue() {
 		causes = append(causes, metav1.StatusCause{
 			Type: metav1.CauseTypeFieldValueInvalid,
-			Message: fmt.Sprintf("%s '%s' is greater than %s '%s'", field.Child("domain", "resources", "requests", "cpu").String(),
-				spec.Domain.Resources.Requests.Cpu(),
-				field.Child("domain", "resources", "limits", "cpu").String(),
-				spec.Domain.Resources.Limits.Cpu()),
-			Field: field.Child("domain", "resources", "requests", "cpu").String(),
+			Message: fmt.Sprintf("%s '%s' is greater than %s '%s'", field.Child("domain", "resources", "requests", "cpu").String(),
+				spec.Domain.Resources.Requests.Cpu(),
+				field.Child("domain", "resources", "limits", "cpu").String(),
+				spec.Domain.Resources.Limits.Cpu()),
+			Field: field.Child("domain", "resources", "requests", "cpu").String(),
+		})
+	}
+	return causes
+}
+
+// validateResourceRequestsDoNotExceedLimits verifies the resources other than CPU and memory, which are
+// validated on their own
+func validateResourceRequestsDoNotExceedLimits(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
+	resources := spec.Domain.Resources
+	names := make([]string, 0, len(resources.Requests)+len(resources.Limits))
+	for name := range resources.Requests {
+		names = append(names, string(name))
+	}
+	for name := range resources.Limits {
+		if _, exists := resources.Requests[name]; !exists {
+			names = append(names, string(name))
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		resourceName := k8sv1.ResourceName(name)
+		if resourceName == k8sv1.ResourceCPU || resourceName == k8sv1.ResourceMemory {
+			continue
+		}
+		request, hasRequest := resources.Requests[resourceName]
+		limit, hasLimit := resources.Limits[resourceName]
+		if hasRequest && request.Sign() < 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf(valueMustBePositiveMessagePattern, field.Child("domain", "resources", "requests").Key(name).String(), request.String()),
+				Field:   field.Child("domain", "resources", "requests").Key(name).String(),
+			})
+		}
+		if hasLimit && limit.Sign() < 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf(valueMustBePositiveMessagePattern, field.Child("domain", "resources", "limits").Key(name).String(), limit.String()),
+				Field:   field.Child("domain", "resources", "limits").Key(name).String(),
+			})
+		} else if hasRequest && hasLimit && request.Cmp(limit) > 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type: metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s '%s' is greater than %s '%s'", field.Child("domain", "resources", "requests").Key(name).String(),
+					request.String(),
+					field.Child("domain", "resources", "limits").Key(name).String(),
+					limit.String()),
+				Field: field.Child("domain", "resources", "requests").Key(name).String(),
+			})
+		}
+	}
+	return causes
+}
+
+func validateCPUTopology(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
+	cpu := spec.Domain.CPU
+	if cpu == nil {
+		return causes
+	}
+	// multiply in 64 bits, the product of the 32 bit fields can overflow
+	vCPUs := uint64(1)
+	for _, count := range []uint32{cpu.Cores, cpu.Sockets, cpu.Threads} {
+		if count != 0 {
+			vCPUs *= uint64(count)
+		}
+	}
+	if vCPUs > maxVCPUs {
+		causes = append(causes, metav1.StatusCause{
+			Type: metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s, %s and %s result in %d vCPUs, which exceeds the maximum of %d",
+				field.Child("domain", "cpu", "cores").String(),
+				field.Child("domain", "cpu", "sockets").String(),
+				field.Child("domain", "cpu", "threads").String(),
+				vCPUs,
+				maxVCPUs,
+			),
+			Field: field.Child("domain", "cpu").String(),
 		})
 	}
 	return causes
@@ -1242,11 +2371,78 @@ func validateEmulatedMachine(field *k8sfield.Path, spec *v1.VirtualMachineInstan
 	return causes
 }
 
+func validateMachineQEMUOptions(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) (causes []metav1.StatusCause) {
+	machine := spec.Domain.Machine
+	if machine == nil {
+		return causes
+	}
+	var allowedMachineOptions, allowedGlobals []string
+	if allowlist := config.GetQEMUOptionsAllowlist(); allowlist != nil {
+		allowedMachineOptions, allowedGlobals = allowlist.MachineOptions, allowlist.Globals
+	}
+	machineField := field.Child("domain", "machine")
+	causes = append(causes, validateQEMUOptions(machineField.Child("options"), machine.Options, validQEMUMachineOptionName, allowedMachineOptions)...)
+	causes = append(causes, validateQEMUOptions(machineField.Child("globals"), machine.Globals, validQEMUGlobalName, allowedGlobals)...)
+	return causes
+}
+
+// validateQEMUOptions makes sure that only allowlisted options are passed to QEMU, and that their names can't
+// smuggle in further options
+func validateQEMUOptions(field *k8sfield.Path, options []v1.QEMUOption, validName *regexp.Regexp, allowed []string) (causes []metav1.StatusCause) {
+	names := make(map[string]struct{}, len(options))
+	for idx, option := range options {
+		nameField := field.Index(idx).Child("name")
+		if !validName.MatchString(option.Name) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s '%s' is not a valid QEMU option name", nameField.String(), option.Name),
+				Field:   nameField.String(),
+			})
+			continue
+		}
+		if _, exists := names[option.Name]; exists {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueDuplicate,
+				Message: fmt.Sprintf("%s '%s' is set more than once", nameField.String(), option.Name),
+				Field:   nameField.String(),
+			})
+			continue
+		}
+		names[option.Name] = struct{}{}
+		if !isQEMUOptionAllowed(option.Name, allowed) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: fmt.Sprintf("%s '%s' is not allowed by the QEMUOptionsAllowlist of the cluster", nameField.String(), option.Name),
+				Field:   nameField.String(),
+			})
+		}
+	}
+	return causes
+}
+
+func isQEMUOptionAllowed(name string, allowed []string) bool {
+	for _, allowedName := range allowed {
+		if name == allowedName {
+			return true
+		}
+	}
+	return false
+}
+
 func validateGuestMemoryLimit(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
 	if spec.Domain.Memory != nil && spec.Domain.Memory.Guest != nil {
 		limits := spec.Domain.Resources.Limits.Memory().Value()
 		guest := spec.Domain.Memory.Guest.Value()
-		if limits < guest && limits != 0 {
+		if guest <= 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type: metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s '%s': must be greater than 0.",
+					field.Child("domain", "memory", "guest").String(),
+					spec.Domain.Memory.Guest,
+				),
+				Field: field.Child("domain", "memory", "guest").String(),
+			})
+		} else if limits < guest && limits != 0 {
 			causes = append(causes, metav1.StatusCause{
 				Type: metav1.CauseTypeFieldValueInvalid,
 				Message: fmt.Sprintf("%s '%s' must be equal to or less than the memory limit %s '%s'",
@@ -1262,46 +2458,155 @@ func validateGuestMemoryLimit(field *k8sfield.Path, spec *v1.VirtualMachineInsta
 	return causes
 }
 
+// selectedArchitecture returns the architecture the VMI selects with its node selector or with the required
+// terms of its node affinity. The terms are ORed, so they only select an architecture if all require the same one.
+func selectedArchitecture(spec *v1.VirtualMachineInstanceSpec) string {
+	if architecture := spec.NodeSelector[k8sv1.LabelArchStable]; architecture != "" {
+		return architecture
+	}
+	if spec.Affinity == nil || spec.Affinity.NodeAffinity == nil || spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return ""
+	}
+
+	architecture := ""
+	for _, term := range spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		termArchitecture := ""
+		for _, expression := range term.MatchExpressions {
+			if expression.Key == k8sv1.LabelArchStable && expression.Operator == k8sv1.NodeSelectorOpIn && len(expression.Values) == 1 {
+				termArchitecture = expression.Values[0]
+			}
+		}
+		if termArchitecture == "" || (architecture != "" && architecture != termArchitecture) {
+			return ""
+		}
+		architecture = termArchitecture
+	}
+	return architecture
+}
+
 func validateHugepagesMemoryRequests(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
-	if spec.Domain.Memory != nil && spec.Domain.Memory.Hugepages != nil {
-		hugepagesSize, err := resource.ParseQuantity(spec.Domain.Memory.Hugepages.PageSize)
-		if err != nil {
+	if spec.Domain.Memory == nil || spec.Domain.Memory.Hugepages == nil {
+		return causes
+	}
+	// the page sizes of the architecture the VMI runs on apply, which is the one of virt-api if the VMI does not select one
+	architecture := selectedArchitecture(spec)
+	if architecture == "" {
+		architecture = webhooks.Arch
+	}
+	pageSizeField := field.Child("domain", "memory", "hugepages", "pageSize")
+	hugepagesSize, err := resource.ParseQuantity(spec.Domain.Memory.Hugepages.PageSize)
+	if err != nil {
+		causes = append(causes, metav1.StatusCause{
+			Type: metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s '%s': %s",
+				pageSizeField.String(),
+				spec.Domain.Memory.Hugepages.PageSize,
+				resource.ErrFormatWrong,
+			),
+			Field: pageSizeField.String(),
+		})
+	} else if hugepagesSize.Value() <= 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type: metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s '%s': must be greater than 0.",
+				pageSizeField.String(),
+				spec.Domain.Memory.Hugepages.PageSize,
+			),
+			Field: pageSizeField.String(),
+		})
+	} else if supported, ok := supportedHugepagesSizes[architecture]; ok && !isSupportedHugepagesSize(hugepagesSize, supported) {
+		causes = append(causes, metav1.StatusCause{
+			Type: metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("%s '%s' is not supported on %s, must be one of: %v",
+				pageSizeField.String(),
+				spec.Domain.Memory.Hugepages.PageSize,
+				architecture,
+				supported,
+			),
+			Field: pageSizeField.String(),
+		})
+	} else {
+		causes = append(causes, validateGuestMemoryHugepages(field, spec, hugepagesSize)...)
+		vmMemory := spec.Domain.Resources.Requests.Memory().Value()
+		if vmMemory < hugepagesSize.Value() {
 			causes = append(causes, metav1.StatusCause{
 				Type: metav1.CauseTypeFieldValueInvalid,
-				Message: fmt.Sprintf("%s '%s': %s",
-					field.Child("domain", "hugepages", "size").String(),
+				Message: fmt.Sprintf("%s '%s' must be equal to or larger than page size %s '%s'",
+					field.Child("domain", "resources", "requests", "memory").String(),
+					spec.Domain.Resources.Requests.Memory(),
+					pageSizeField.String(),
 					spec.Domain.Memory.Hugepages.PageSize,
-					resource.ErrFormatWrong,
 				),
-				Field: field.Child("domain", "hugepages", "size").String(),
+				Field: field.Child("domain", "resources", "requests", "memory").String(),
 			})
-		} else {
-			vmMemory := spec.Domain.Resources.Requests.Memory().Value()
-			if vmMemory < hugepagesSize.Value() {
-				causes = append(causes, metav1.StatusCause{
-					Type: metav1.CauseTypeFieldValueInvalid,
-					Message: fmt.Sprintf("%s '%s' must be equal to or larger than page size %s '%s'",
-						field.Child("domain", "resources", "requests", "memory").String(),
-						spec.Domain.Resources.Requests.Memory(),
-						field.Child("domain", "hugepages", "size").String(),
-						spec.Domain.Memory.Hugepages.PageSize,
-					),
-					Field: field.Child("domain", "resources", "requests", "memory").String(),
-				})
-			} else if vmMemory%hugepagesSize.Value() != 0 {
-				causes = append(causes, metav1.StatusCause{
-					Type: metav1.CauseTypeFieldValueInvalid,
-					Message: fmt.Sprintf("%s '%s' is not a multiple of the page size %s '%s'",
-						field.Child("domain", "resources", "requests", "memory").String(),
-						spec.Domain.Resources.Requests.Memory(),
-						field.Child("domain", "hugepages", "size").String(),
-						spec.Domain.Memory.Hugepages.PageSize,
-					),
-					Field: field.Child("domain", "resources", "requests", "memory").String(),
-				})
-			}
+		} else if vmMemory%hugepagesSize.Value() != 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type: metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s '%s' is not a multiple of the page size %s '%s'",
+					field.Child("domain", "resources", "requests", "memory").String(),
+					spec.Domain.Resources.Requests.Memory(),
+					pageSizeField.String(),
+					spec.Domain.Memory.Hugepages.PageSize,
+				),
+				Field: field.Child("domain", "resources", "requests", "memory").String(),
+			})
+		}
+	}
+
+	// hugepages are reserved for the guest up front, they can't be overcommitted
+	if spec.Domain.Resources.OvercommitGuestOverhead {
+		causes = append(causes, metav1.StatusCause{
+			Type: metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must not be set when %s are used",
+				field.Child("domain", "resources", "overcommitGuestOverhead").String(),
+				field.Child("domain", "memory", "hugepages").String(),
+			),
+			Field: field.Child("domain", "resources", "overcommitGuestOverhead").String(),
+		})
+	}
+	return causes
+}
+
+func isSupportedHugepagesSize(size resource.Quantity, supported []string) bool {
+	for _, s := range supported {
+		if size.Cmp(resource.MustParse(s)) == 0 {
+			return true
 		}
 	}
+	return false
+}
+
+// validateGuestMemoryHugepages verifies that the guest memory can be backed by the requested hugepages, the pod
+// only gets as many hugepages as the memory request allows
+func validateGuestMemoryHugepages(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, hugepagesSize resource.Quantity) (causes []metav1.StatusCause) {
+	if spec.Domain.Memory.Guest == nil || spec.Domain.Memory.Guest.Value() <= 0 {
+		return causes
+	}
+	guest := spec.Domain.Memory.Guest.Value()
+	requests := spec.Domain.Resources.Requests.Memory().Value()
+	if requests > 0 && guest > requests {
+		causes = append(causes, metav1.StatusCause{
+			Type: metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s '%s' must be equal to or less than the memory request %s '%s' when hugepages are used",
+				field.Child("domain", "memory", "guest").String(),
+				spec.Domain.Memory.Guest,
+				field.Child("domain", "resources", "requests", "memory").String(),
+				spec.Domain.Resources.Requests.Memory(),
+			),
+			Field: field.Child("domain", "memory", "guest").String(),
+		})
+	} else if guest%hugepagesSize.Value() != 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type: metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s '%s' is not a multiple of the page size %s '%s'",
+				field.Child("domain", "memory", "guest").String(),
+				spec.Domain.Memory.Guest,
+				field.Child("domain", "memory", "hugepages", "pageSize").String(),
+				spec.Domain.Memory.Hugepages.PageSize,
+			),
+			Field: field.Child("domain", "memory", "guest").String(),
+		})
+	}
 	return causes
 }
 
@@ -1348,13 +2653,15 @@ func validateMemoryRequestsNegativeOrNull(field *k8sfield.Path, spec *v1.Virtual
 	return causes
 }
 
-func validateSubdomainDNSSubdomainRules(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
+// validateSubdomainDNSLabelRules checks the subdomain the same way the pod validation does, it names the headless
+// service the VMI is registered under, so it has to be a single DNS label
+func validateSubdomainDNSLabelRules(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
 	if spec.Subdomain != "" {
-		errors := validation.IsDNS1123Subdomain(spec.Subdomain)
+		errors := validation.IsDNS1123Label(spec.Subdomain)
 		if len(errors) != 0 {
 			causes = append(causes, metav1.StatusCause{
 				Type: metav1.CauseTypeFieldValueInvalid,
-				Message: fmt.Sprintf("%s does not conform to the kubernetes DNS_SUBDOMAIN rules : %s",
+				Message: fmt.Sprintf("%s does not conform to the kubernetes DNS_LABEL rules : %s",
 					field.Child("subdomain").String(), strings.Join(errors, ", ")),
 				Field: field.Child("subdomain").String(),
 			})
@@ -1363,6 +2670,133 @@ func validateSubdomainDNSSubdomainRules(field *k8sfield.Path, spec *v1.VirtualMa
 	return causes
 }
 
+func validateLauncherPodSettings(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec, config *virtconfig.ClusterConfig) (causes []metav1.StatusCause) {
+	if spec.SchedulerName != "" {
+		causes = append(causes, validateDNSSubdomainField(field.Child("schedulerName"), spec.SchedulerName)...)
+	}
+	if spec.RuntimeClassName != nil {
+		causes = append(causes, validateDNSSubdomainField(field.Child("runtimeClassName"), *spec.RuntimeClassName)...)
+	}
+	if spec.LauncherPodMetadata != nil {
+		metadataField := field.Child("launcherPodMetadata")
+		for key, value := range spec.LauncherPodMetadata.Labels {
+			causes = append(causes, validateLauncherPodMetadataKey(metadataField.Child("labels").Key(key), key)...)
+			if errors := validation.IsValidLabelValue(value); len(errors) != 0 {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("%s has an invalid label value: %s", metadataField.Child("labels").Key(key).String(), strings.Join(errors, ", ")),
+					Field:   metadataField.Child("labels").Key(key).String(),
+				})
+			}
+			if !config.IsLauncherPodLabelPropagated(key) {
+				causes = append(causes, notPropagatedLauncherPodMetadataCause(metadataField.Child("labels").Key(key)))
+			}
+		}
+		for key := range spec.LauncherPodMetadata.Annotations {
+			causes = append(causes, validateLauncherPodMetadataKey(metadataField.Child("annotations").Key(key), key)...)
+			if !config.IsLauncherPodAnnotationPropagated(key) {
+				causes = append(causes, notPropagatedLauncherPodMetadataCause(metadataField.Child("annotations").Key(key)))
+			}
+		}
+	}
+	causes = append(causes, validateTopologySpreadConstraints(field.Child("topologySpreadConstraints"), spec.TopologySpreadConstraints)...)
+	return causes
+}
+
+func validateDNSSubdomainField(field *k8sfield.Path, value string) (causes []metav1.StatusCause) {
+	if errors := validation.IsDNS1123Subdomain(value); len(errors) != 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s does not conform to the kubernetes DNS_SUBDOMAIN rules : %s", field.String(), strings.Join(errors, ", ")),
+			Field:   field.String(),
+		})
+	}
+	return causes
+}
+
+func validateLauncherPodMetadataKey(field *k8sfield.Path, key string) (causes []metav1.StatusCause) {
+	if errors := validation.IsQualifiedName(key); len(errors) != 0 {
+		return append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s is not a valid qualified name: %s", field.String(), strings.Join(errors, ", ")),
+			Field:   field.String(),
+		})
+	}
+	if isReservedKubeVirtKey(key) {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("%s is in the reserved kubevirt.io domain", field.String()),
+			Field:   field.String(),
+		})
+	}
+	return causes
+}
+
+func notPropagatedLauncherPodMetadataCause(field *k8sfield.Path) metav1.StatusCause {
+	return metav1.StatusCause{
+		Type:    metav1.CauseTypeFieldValueNotSupported,
+		Message: fmt.Sprintf("%s is not allowed to be propagated to virt-launcher pods by the cluster configuration", field.String()),
+		Field:   field.String(),
+	}
+}
+
+// isReservedKubeVirtKey returns true for keys in the kubevirt.io domain, which are used
+// to identify and configure the virt-launcher pod
+func isReservedKubeVirtKey(key string) bool {
+	domain := key
+	if idx := strings.Index(key, "/"); idx >= 0 {
+		domain = key[:idx]
+	}
+	return domain == v1.GroupName || strings.HasSuffix(domain, "."+v1.GroupName)
+}
+
+func validateTopologySpreadConstraints(field *k8sfield.Path, constraints []k8sv1.TopologySpreadConstraint) (causes []metav1.StatusCause) {
+	existingConstraints := map[string]bool{}
+	for idx, constraint := range constraints {
+		if constraint.MaxSkew <= 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must be greater than zero", field.Index(idx).Child("maxSkew").String()),
+				Field:   field.Index(idx).Child("maxSkew").String(),
+			})
+		}
+		if constraint.TopologyKey == "" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: fmt.Sprintf("%s can not be empty", field.Index(idx).Child("topologyKey").String()),
+				Field:   field.Index(idx).Child("topologyKey").String(),
+			})
+		} else if errors := validation.IsQualifiedName(constraint.TopologyKey); len(errors) != 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s is not a valid qualified name: %s", field.Index(idx).Child("topologyKey").String(), strings.Join(errors, ", ")),
+				Field:   field.Index(idx).Child("topologyKey").String(),
+			})
+		}
+		switch constraint.WhenUnsatisfiable {
+		case k8sv1.DoNotSchedule, k8sv1.ScheduleAnyway:
+		default:
+			validValues := []string{string(k8sv1.DoNotSchedule), string(k8sv1.ScheduleAnyway)}
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: fmt.Sprintf("%s: %s is not supported, valid values: %s", field.Index(idx).Child("whenUnsatisfiable").String(), constraint.WhenUnsatisfiable, validValues),
+				Field:   field.Index(idx).Child("whenUnsatisfiable").String(),
+			})
+		}
+		// the pair of topologyKey and whenUnsatisfiable identifies a constraint
+		pair := fmt.Sprintf("%s/%s", constraint.TopologyKey, constraint.WhenUnsatisfiable)
+		if existingConstraints[pair] {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueDuplicate,
+				Message: fmt.Sprintf("%s duplicates a constraint with the same topologyKey and whenUnsatisfiable", field.Index(idx).String()),
+				Field:   field.Index(idx).String(),
+			})
+		}
+		existingConstraints[pair] = true
+	}
+	return causes
+}
+
 func validateHostNameNotConformingToDNSLabelRules(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
 	if spec.Hostname != "" {
 		errors := validation.IsDNS1123Label(spec.Hostname)
@@ -1382,18 +2816,18 @@ func appendNewStatusCauseForHostNameNotConformingToDNSLabelRules(field *k8sfield
 	})
 }
 
-func appendNewStatusCauseForMaxNumberOfVolumesExceeded(field *k8sfield.Path, causes []metav1.StatusCause) []metav1.StatusCause {
+func appendNewStatusCauseForMaxNumberOfVolumesExceeded(field *k8sfield.Path, causes []metav1.StatusCause, maxListLength int) []metav1.StatusCause {
 	return append(causes, metav1.StatusCause{
 		Type:    metav1.CauseTypeFieldValueInvalid,
-		Message: fmt.Sprintf(listExceedsLimitMessagePattern, field.Child("volumes").String(), arrayLenMax),
+		Message: fmt.Sprintf(listExceedsLimitMessagePattern, field.Child("volumes").String(), maxListLength),
 		Field:   field.Child("volumes").String(),
 	})
 }
 
-func appendNewStatusCauseForNumberOfDisksExceeded(field *k8sfield.Path, causes []metav1.StatusCause) []metav1.StatusCause {
+func appendNewStatusCauseForNumberOfDisksExceeded(field *k8sfield.Path, causes []metav1.StatusCause, maxListLength int) []metav1.StatusCause {
 	return append(causes, metav1.StatusCause{
 		Type:    metav1.CauseTypeFieldValueInvalid,
-		Message: fmt.Sprintf(listExceedsLimitMessagePattern, field.Child("domain", "devices", "disks").String(), arrayLenMax),
+		Message: fmt.Sprintf(listExceedsLimitMessagePattern, field.Child("domain", "devices", "disks").String(), maxListLength),
 		Field:   field.Child("domain", "devices", "disks").String(),
 	})
 }
@@ -1592,12 +3026,21 @@ func validateFirmware(field *k8sfield.Path, firmware *v1.Firmware) []metav1.Stat
 	return causes
 }
 
-func validateDomainSpec(field *k8sfield.Path, spec *v1.DomainSpec) []metav1.StatusCause {
+func validateDomainSpec(field *k8sfield.Path, spec *v1.DomainSpec, maxListLength int) []metav1.StatusCause {
 	var causes []metav1.StatusCause
 
-	causes = append(causes, validateDevices(field.Child("devices"), &spec.Devices)...)
+	causes = append(causes, validateDevices(field.Child("devices"), &spec.Devices, maxListLength)...)
 	causes = append(causes, validateFirmware(field.Child("firmware"), spec.Firmware)...)
 
+	if spec.GuestOS != nil && spec.GuestOS.OSFamily != "" &&
+		spec.GuestOS.OSFamily != v1.GuestOSFamilyLinux && spec.GuestOS.OSFamily != v1.GuestOSFamilyWindows {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("%s is set with an unsupported osFamily %s, must be one of: %s, %s", field.Child("guestOS").String(), spec.GuestOS.OSFamily, v1.GuestOSFamilyLinux, v1.GuestOSFamilyWindows),
+			Field:   field.Child("guestOS", "osFamily").String(),
+		})
+	}
+
 	if spec.Firmware != nil && spec.Firmware.Bootloader != nil && spec.Firmware.Bootloader.EFI != nil &&
 		(spec.Firmware.Bootloader.EFI.SecureBoot == nil || *spec.Firmware.Bootloader.EFI.SecureBoot) &&
 		(spec.Features == nil || spec.Features.SMM == nil || !*spec.Features.SMM.Enabled) {
@@ -1611,13 +3054,13 @@ func validateDomainSpec(field *k8sfield.Path, spec *v1.DomainSpec) []metav1.Stat
 	return causes
 }
 
-func validateAccessCredentials(field *k8sfield.Path, accessCredentials []v1.AccessCredential, volumes []v1.Volume) []metav1.StatusCause {
+func validateAccessCredentials(field *k8sfield.Path, accessCredentials []v1.AccessCredential, volumes []v1.Volume, maxListLength int) []metav1.StatusCause {
 	var causes []metav1.StatusCause
 
-	if len(accessCredentials) > arrayLenMax {
+	if len(accessCredentials) > maxListLength {
 		causes = append(causes, metav1.StatusCause{
 			Type:    metav1.CauseTypeFieldValueInvalid,
-			Message: fmt.Sprintf(listExceedsLimitMessagePattern, field.String(), arrayLenMax),
+			Message: fmt.Sprintf(listExceedsLimitMessagePattern, field.String(), maxListLength),
 			Field:   field.String(),
 		})
 		// We won't process anything over the limit
@@ -1731,10 +3174,10 @@ func validateVolumes(field *k8sfield.Path, volumes []v1.Volume, config *virtconf
 	var causes []metav1.StatusCause
 	nameMap := make(map[string]int)
 
-	if len(volumes) > arrayLenMax {
+	if maxListLength := config.GetMaxListLength(); len(volumes) > maxListLength {
 		causes = append(causes, metav1.StatusCause{
 			Type:    metav1.CauseTypeFieldValueInvalid,
-			Message: fmt.Sprintf(listExceedsLimitMessagePattern, field.String(), arrayLenMax),
+			Message: fmt.Sprintf(listExceedsLimitMessagePattern, field.String(), maxListLength),
 			Field:   field.String(),
 		})
 		// We won't process anything over the limit
@@ -1744,6 +3187,7 @@ func validateVolumes(field *k8sfield.Path, volumes []v1.Volume, config *virtconf
 	// check that we have max 1 instance of below disks
 	serviceAccountVolumeCount := 0
 	downwardMetricVolumeCount := 0
+	memoryDumpVolumeCount := 0
 
 	for idx, volume := range volumes {
 		// verify name is unique
@@ -1819,6 +3263,13 @@ func validateVolumes(field *k8sfield.Path, volumes []v1.Volume, config *virtconf
 			downwardMetricVolumeCount++
 			volumeSourceSetCount++
 		}
+		if volume.NetworkDisk != nil {
+			volumeSourceSetCount++
+		}
+		if volume.MemoryDump != nil {
+			memoryDumpVolumeCount++
+			volumeSourceSetCount++
+		}
 
 		if volumeSourceSetCount != 1 {
 			causes = append(causes, metav1.StatusCause{
@@ -1883,10 +3334,10 @@ func validateVolumes(field *k8sfield.Path, volumes []v1.Volume, config *virtconf
 				})
 			}
 
-			if userDataLen > cloudInitUserMaxLen {
+			if userDataMaxLen := config.GetCloudInitUserDataMaxBytes(); userDataLen > userDataMaxLen {
 				causes = append(causes, metav1.StatusCause{
 					Type:    metav1.CauseTypeFieldValueInvalid,
-					Message: fmt.Sprintf("%s userdata exceeds %d byte limit. Should use UserDataSecretRef for larger data.", field.Index(idx).Child(dataSourceType).String(), cloudInitUserMaxLen),
+					Message: fmt.Sprintf("%s userdata exceeds %d byte limit. Should use UserDataSecretRef for larger data.", field.Index(idx).Child(dataSourceType).String(), userDataMaxLen),
 					Field:   field.Index(idx).Child(dataSourceType).String(),
 				})
 			}
@@ -1919,10 +3370,10 @@ func validateVolumes(field *k8sfield.Path, volumes []v1.Volume, config *virtconf
 				})
 			}
 
-			if networkDataLen > cloudInitNetworkMaxLen {
+			if networkDataMaxLen := config.GetCloudInitNetworkDataMaxBytes(); networkDataLen > networkDataMaxLen {
 				causes = append(causes, metav1.StatusCause{
 					Type:    metav1.CauseTypeFieldValueInvalid,
-					Message: fmt.Sprintf("%s networkdata exceeds %d byte limit. Should use NetworkDataSecretRef for larger data.", field.Index(idx).Child(dataSourceType).String(), cloudInitNetworkMaxLen),
+					Message: fmt.Sprintf("%s networkdata exceeds %d byte limit. Should use NetworkDataSecretRef for larger data.", field.Index(idx).Child(dataSourceType).String(), networkDataMaxLen),
 					Field:   field.Index(idx).Child(dataSourceType).String(),
 				})
 			}
@@ -1979,6 +3430,32 @@ func validateVolumes(field *k8sfield.Path, volumes []v1.Volume, config *virtconf
 			}
 		}
 
+		if volume.NetworkDisk != nil {
+			causes = append(causes, validateNetworkDisk(field.Index(idx).Child("networkDisk"), volume.NetworkDisk, config)...)
+		}
+
+		if volume.ContainerDisk != nil {
+			causes = append(causes, validateContainerDiskImageDigest(field.Index(idx).Child("containerDisk"), volume.ContainerDisk)...)
+			causes = append(causes, validateContainerDiskRegistry(field.Index(idx).Child("containerDisk"), volume.ContainerDisk, config)...)
+		}
+
+		if volume.EmptyDisk != nil {
+			causes = append(causes, validateDiskImageOptions(field.Index(idx).Child("emptyDisk"), volume.EmptyDisk.ImageFormat, volume.EmptyDisk.Preallocation)...)
+		}
+
+		if volume.Ephemeral != nil {
+			causes = append(causes, validateDiskImageOptions(field.Index(idx).Child("ephemeral"), volume.Ephemeral.ImageFormat, volume.Ephemeral.Preallocation)...)
+		}
+
+		// The disk.img on filesystem PVCs and host disks is always raw
+		if volume.PersistentVolumeClaim != nil {
+			causes = append(causes, validateDiskImageOptions(field.Index(idx).Child("persistentVolumeClaim"), v1.DiskImageFormatRaw, volume.PersistentVolumeClaim.Preallocation)...)
+		}
+
+		if volume.HostDisk != nil {
+			causes = append(causes, validateDiskImageOptions(field.Index(idx).Child("hostDisk"), v1.DiskImageFormatRaw, volume.HostDisk.Preallocation)...)
+		}
+
 		if volume.ConfigMap != nil {
 			if volume.ConfigMap.LocalObjectReference.Name == "" {
 				causes = append(causes, metav1.StatusCause{
@@ -2026,12 +3503,64 @@ func validateVolumes(field *k8sfield.Path, volumes []v1.Volume, config *virtconf
 		})
 	}
 
+	if memoryDumpVolumeCount > 1 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must have max one memoryDump volume set", field.String()),
+			Field:   field.String(),
+		})
+	}
+
 	return causes
 }
 
-func validateDevices(field *k8sfield.Path, devices *v1.Devices) []metav1.StatusCause {
+func validateDevices(field *k8sfield.Path, devices *v1.Devices, maxListLength int) []metav1.StatusCause {
 	var causes []metav1.StatusCause
-	causes = append(causes, validateDisks(field.Child("disks"), devices.Disks)...)
+	causes = append(causes, validateDisks(field.Child("disks"), devices.Disks, maxListLength)...)
+	causes = append(causes, validatePciAddressesUnique(field, devices)...)
+	return causes
+}
+
+// validatePciAddress checks the syntax (DDDD:BB:SS.F) of a guest PCI address and that
+// the slot exists, libvirt would otherwise only reject it once virt-launcher defines the domain
+func validatePciAddress(pciAddress string) error {
+	dbsf, err := hwutil.ParsePciAddress(pciAddress)
+	if err != nil {
+		return fmt.Errorf("expected the format DDDD:BB:SS.F")
+	}
+	if slot, _ := strconv.ParseUint(dbsf[2], 16, 8); slot > maxPciSlot {
+		return fmt.Errorf("the slot must not exceed %02x", maxPciSlot)
+	}
+	return nil
+}
+
+// validatePciAddressesUnique rejects disks and interfaces which ask for the same guest PCI address
+func validatePciAddressesUnique(field *k8sfield.Path, devices *v1.Devices) (causes []metav1.StatusCause) {
+	pciAddresses := map[string]string{}
+	checkUnique := func(pciAddress string, pciAddressField *k8sfield.Path) {
+		if pciAddress == "" {
+			return
+		}
+		pciAddress = strings.ToLower(pciAddress)
+		if otherField, exists := pciAddresses[pciAddress]; exists {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueDuplicate,
+				Message: fmt.Sprintf("%s and %s must not have the same PCI address (%s).", pciAddressField.String(), otherField, pciAddress),
+				Field:   pciAddressField.String(),
+			})
+			return
+		}
+		pciAddresses[pciAddress] = pciAddressField.String()
+	}
+
+	for idx, disk := range devices.Disks {
+		if disk.Disk != nil {
+			checkUnique(disk.Disk.PciAddress, field.Child("disks").Index(idx).Child("disk", "pciAddress"))
+		}
+	}
+	for idx, iface := range devices.Interfaces {
+		checkUnique(iface.PciAddress, field.Child("interfaces").Index(idx).Child("pciAddress"))
+	}
 	return causes
 }
 
@@ -2050,14 +3579,14 @@ func getNumberOfPodInterfaces(spec *v1.VirtualMachineInstanceSpec) int {
 	return nPodInterfaces
 }
 
-func validateDisks(field *k8sfield.Path, disks []v1.Disk) []metav1.StatusCause {
+func validateDisks(field *k8sfield.Path, disks []v1.Disk, maxListLength int) []metav1.StatusCause {
 	var causes []metav1.StatusCause
 	nameMap := make(map[string]int)
 
-	if len(disks) > arrayLenMax {
+	if len(disks) > maxListLength {
 		causes = append(causes, metav1.StatusCause{
 			Type:    metav1.CauseTypeFieldValueInvalid,
-			Message: fmt.Sprintf(listExceedsLimitMessagePattern, field.String(), arrayLenMax),
+			Message: fmt.Sprintf(listExceedsLimitMessagePattern, field.String(), maxListLength),
 			Field:   field.String(),
 		})
 		// We won't process anything over the limit
@@ -2118,22 +3647,30 @@ func validateDisks(field *k8sfield.Path, disks []v1.Disk) []metav1.StatusCause {
 			})
 		}
 
+		// A CD-ROM is a read-only medium, it defaults to readonly and can't be made writable
+		if disk.CDRom != nil && disk.CDRom.ReadOnly != nil && !*disk.CDRom.ReadOnly {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must not be set to false for CD-ROM device", field.Index(idx).Child("cdrom", "readonly").String()),
+				Field:   field.Index(idx).Child("cdrom", "readonly").String(),
+			})
+		}
+
 		// Verify pci address
 		if disk.Disk != nil && disk.Disk.PciAddress != "" {
 			if disk.Disk.Bus != "virtio" {
 				causes = append(causes, metav1.StatusCause{
 					Type:    metav1.CauseTypeFieldValueInvalid,
-					Message: fmt.Sprintf("disk %s - setting a PCI address is only possible with bus type virtio.", field.Child("domain", "devices", "disks", "disk").Index(idx).Child("name").String()),
-					Field:   field.Child("domain", "devices", "disks", "disk").Index(idx).Child("pciAddress").String(),
+					Message: fmt.Sprintf("disk %s - setting a PCI address is only possible with bus type virtio.", field.Index(idx).Child("name").String()),
+					Field:   field.Index(idx).Child("disk", "pciAddress").String(),
 				})
 			}
 
-			_, err := hwutil.ParsePciAddress(disk.Disk.PciAddress)
-			if err != nil {
+			if err := validatePciAddress(disk.Disk.PciAddress); err != nil {
 				causes = append(causes, metav1.StatusCause{
 					Type:    metav1.CauseTypeFieldValueInvalid,
-					Message: fmt.Sprintf("disk %s has malformed PCI address (%s).", field.Child("domain", "devices", "disks", "disk").Index(idx).Child("name").String(), disk.Disk.PciAddress),
-					Field:   field.Child("domain", "devices", "disks", "disk").Index(idx).Child("pciAddress").String(),
+					Message: fmt.Sprintf("disk %s has malformed PCI address (%s): %v.", field.Index(idx).Child("name").String(), disk.Disk.PciAddress, err),
+					Field:   field.Index(idx).Child("disk", "pciAddress").String(),
 				})
 			}
 		}
@@ -2156,7 +3693,7 @@ func validateDisks(field *k8sfield.Path, disks []v1.Disk) []metav1.StatusCause {
 					Field:   field.Index(idx).Child(diskType, "bus").String(),
 				})
 			} else {
-				buses := []string{"virtio", "sata", "scsi"}
+				buses := []string{"virtio", "sata", "scsi", "usb"}
 				validBus := false
 				for _, b := range buses {
 					if b == bus {
@@ -2171,6 +3708,15 @@ func validateDisks(field *k8sfield.Path, disks []v1.Disk) []metav1.StatusCause {
 					})
 				}
 
+				// special case. A LUN is passed through as a SCSI device and can't be attached to an usb bus
+				if diskType == "lun" && bus == "usb" {
+					causes = append(causes, metav1.StatusCause{
+						Type:    metav1.CauseTypeFieldValueInvalid,
+						Message: fmt.Sprintf("Bus type %s is invalid for LUN device", bus),
+						Field:   field.Index(idx).Child("lun", "bus").String(),
+					})
+				}
+
 				// special case. virtio is incompatible with CD-ROM for q35 machine types
 				if diskType == "cdrom" && bus == "virtio" {
 					causes = append(causes, metav1.StatusCause{
@@ -2232,6 +3778,10 @@ func validateDisks(field *k8sfield.Path, disks []v1.Disk) []metav1.StatusCause {
 			})
 		}
 
+		if disk.Discard != "" || disk.DetectZeroes != "" {
+			causes = append(causes, validateDiskDiscard(field.Index(idx), &disk)...)
+		}
+
 		// Verify disk and volume name can be a valid container name since disk
 		// name can become a container name which will fail to schedule if invalid
 		errs := validation.IsDNS1123Label(disk.Name)
@@ -2319,9 +3869,36 @@ func validateKernelBoot(field *k8sfield.Path, kernelBoot *v1.KernelBoot) (causes
 		})
 	}
 
+	causes = append(causes, validateKernelBootArtifactPath(field.Child("container", "kernelPath"), container.KernelPath)...)
+	causes = append(causes, validateKernelBootArtifactPath(field.Child("container", "initrdPath"), container.InitrdPath)...)
+
+	// the artifacts are mounted by their file names into the same directory of the virt-launcher pod
+	if container.KernelPath != "" && container.InitrdPath != "" && filepath.Base(container.KernelPath) == filepath.Base(container.InitrdPath) {
+		initrdField := field.Child("container", "initrdPath").String()
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must not have the same file name as the kernel path", initrdField),
+			Field:   initrdField,
+		})
+	}
+
 	return
 }
 
+// Rejects kernel boot artifact paths which could point outside of the container image
+func validateKernelBootArtifactPath(field *k8sfield.Path, artifactPath string) (causes []metav1.StatusCause) {
+	for _, element := range strings.Split(artifactPath, "/") {
+		if element == ".." {
+			return append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must not contain '..'", field.String()),
+				Field:   field.String(),
+			})
+		}
+	}
+	return causes
+}
+
 func validateVhostuserSpec(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
 	if util.IsVhostuserVmiSpec(spec) {
 		if spec.Domain.Memory == nil || spec.Domain.Memory.Hugepages == nil {
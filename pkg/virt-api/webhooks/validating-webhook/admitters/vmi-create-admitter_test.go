@@ -20,15 +20,21 @@
 package admitters
 
 import (
+	"context"
+	"crypto"
 	"encoding/json"
 	"fmt"
+	"math"
+	rt "runtime"
 	"strconv"
 	"strings"
+	"time"
 
 	"kubevirt.io/kubevirt/tools/vms-generator/utils"
 
 	"kubevirt.io/kubevirt/pkg/virt-operator/resource/generate/rbac"
 
+	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
@@ -41,9 +47,12 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/validation"
 	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/utils/pointer"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/kubevirt/pkg/hooks"
 	"kubevirt.io/kubevirt/pkg/testutils"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
@@ -137,6 +146,48 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 		Expect(len(resp.Result.Details.Causes)).To(Equal(1))
 		Expect(resp.Result.Message).To(ContainSubstring("no memory requested"))
 	})
+	It("should warn about disks with the writethrough cache mode without rejecting the VMI", func() {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+			{Name: "disk0", Cache: v1.CacheNone},
+			{Name: "disk1", Cache: v1.CacheWriteThrough},
+		}
+		vmi.Spec.Volumes = []v1.Volume{
+			{Name: "disk0", VolumeSource: v1.VolumeSource{ContainerDisk: &v1.ContainerDiskSource{Image: "fake"}}},
+			{Name: "disk1", VolumeSource: v1.VolumeSource{ContainerDisk: &v1.ContainerDiskSource{Image: "fake"}}},
+		}
+		vmiBytes, _ := json.Marshal(&vmi)
+
+		ar := &admissionv1.AdmissionReview{
+			Request: &admissionv1.AdmissionRequest{
+				Resource: webhooks.VirtualMachineInstanceGroupVersionResource,
+				Object: runtime.RawExtension{
+					Raw: vmiBytes,
+				},
+			},
+		}
+		resp := vmiCreateAdmitter.Admit(ar)
+		Expect(resp.Allowed).To(BeTrue())
+		Expect(resp.Warnings).To(HaveLen(1))
+		Expect(resp.Warnings[0]).To(ContainSubstring("spec.domain.devices.disks[1].cache"))
+	})
+	It("should keep the warnings when the VMI is rejected", func() {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Spec.Domain.Devices.Disks = []v1.Disk{{Name: "testdisk", Cache: v1.CacheWriteThrough}}
+		vmiBytes, _ := json.Marshal(&vmi)
+
+		ar := &admissionv1.AdmissionReview{
+			Request: &admissionv1.AdmissionRequest{
+				Resource: webhooks.VirtualMachineInstanceGroupVersionResource,
+				Object: runtime.RawExtension{
+					Raw: vmiBytes,
+				},
+			},
+		}
+		resp := vmiCreateAdmitter.Admit(ar)
+		Expect(resp.Allowed).To(BeFalse())
+		Expect(resp.Warnings).To(HaveLen(1))
+	})
 
 	Context("tolerations with eviction policies given", func() {
 		var vmi *v1.VirtualMachineInstance
@@ -175,6 +226,29 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(resp).To(HaveLen(1))
 			Expect(resp[0].Message).To(Equal("fake.evictionStrategy is set with an unrecognized option: fantasy"))
 		})
+
+		table.DescribeTable("with the host-passthrough CPU mode", func(migrationPolicy v1.HostPassthroughMigrationPolicy, allowed bool) {
+			kvConfig := kv.DeepCopy()
+			kvConfig.Spec.Configuration.DeveloperConfiguration.FeatureGates = []string{virtconfig.LiveMigrationGate}
+			kvConfig.Spec.Configuration.MigrationConfiguration = &v1.MigrationConfiguration{
+				HostPassthroughPolicy: &migrationPolicy,
+			}
+			testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kvConfig)
+
+			vmi.Spec.EvictionStrategy = &policy
+			vmi.Spec.Domain.CPU = &v1.CPU{Model: v1.CPUModeHostPassthrough}
+			resp := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			if allowed {
+				Expect(resp).To(BeEmpty())
+			} else {
+				Expect(resp).To(HaveLen(1))
+				Expect(resp[0].Field).To(Equal("fake.evictionStrategy"))
+			}
+		},
+			table.Entry("should allow live migration if the cluster allows it", v1.HostPassthroughMigrationAllow, true),
+			table.Entry("should allow live migration to compatible nodes", v1.HostPassthroughMigrationCompatibleNodes, true),
+			table.Entry("should reject live migration if the cluster denies it", v1.HostPassthroughMigrationDeny, false),
+		)
 	})
 
 	Context("with probes given", func() {
@@ -294,6 +368,50 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(resp.Allowed).To(BeFalse())
 			Expect(resp.Result.Message).To(Equal(`spec.readinessProbe.tcpSocket is only allowed if the Pod Network is attached, spec.livenessProbe.httpGet is only allowed if the Pod Network is attached`))
 		})
+		table.DescribeTable("should validate the settings of the probes", func(probe v1.Probe, liveness bool, expectedField string) {
+			vmi := v1.NewMinimalVMI("testvmi")
+			if liveness {
+				vmi.Spec.LivenessProbe = &probe
+			} else {
+				vmi.Spec.ReadinessProbe = &probe
+			}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			if expectedField == "" {
+				Expect(causes).To(BeEmpty())
+			} else {
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal(expectedField))
+			}
+		},
+			table.Entry("accept a named port", v1.Probe{
+				Handler: v1.Handler{TCPSocket: &k8sv1.TCPSocketAction{Port: intstr.FromString("http")}},
+			}, false, ""),
+			table.Entry("reject a port out of range", v1.Probe{
+				Handler: v1.Handler{TCPSocket: &k8sv1.TCPSocketAction{Port: intstr.FromInt(70000)}},
+			}, false, "fake.readinessProbe.tcpSocket.port"),
+			table.Entry("reject an invalid port name", v1.Probe{
+				Handler: v1.Handler{HTTPGet: &k8sv1.HTTPGetAction{Port: intstr.FromString("no_such-port")}},
+			}, false, "fake.readinessProbe.httpGet.port"),
+			table.Entry("reject a negative period", v1.Probe{
+				PeriodSeconds: -1,
+				Handler:       v1.Handler{GuestAgentPing: &v1.GuestAgentPing{}},
+			}, false, "fake.readinessProbe.periodSeconds"),
+			table.Entry("reject a negative timeout", v1.Probe{
+				TimeoutSeconds: -5,
+				Handler:        v1.Handler{Exec: &k8sv1.ExecAction{Command: []string{"true"}}},
+			}, true, "fake.livenessProbe.timeoutSeconds"),
+			table.Entry("accept a success threshold above 1 for readiness probes", v1.Probe{
+				SuccessThreshold: 3,
+				Handler:          v1.Handler{GuestAgentPing: &v1.GuestAgentPing{}},
+			}, false, ""),
+			table.Entry("reject a success threshold above 1 for liveness probes", v1.Probe{
+				SuccessThreshold: 3,
+				Handler:          v1.Handler{GuestAgentPing: &v1.GuestAgentPing{}},
+			}, true, "fake.livenessProbe.successThreshold"),
+		)
 	})
 
 	It("should accept valid vmi spec on create", func() {
@@ -477,6 +595,59 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(causes[0].Message).To(ContainSubstring("fake.domain.machine.type is not supported: test (allowed values:"))
 		})
 
+		table.DescribeTable("should validate QEMU machine options and globals against the allowlist", func(allowlist *v1.QEMUOptionsAllowlist, machine *v1.Machine, expectedFields ...string) {
+			kvConfig := kv.DeepCopy()
+			kvConfig.Spec.Configuration.QEMUOptionsAllowlist = allowlist
+			testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kvConfig)
+
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Machine = machine
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(len(expectedFields)))
+			for i, field := range expectedFields {
+				Expect(causes[i].Field).To(Equal(field))
+			}
+		},
+			table.Entry("accept allowlisted options",
+				&v1.QEMUOptionsAllowlist{MachineOptions: []string{"graphics"}, Globals: []string{"ICH9-LPC.disable_s3"}},
+				&v1.Machine{
+					Options: []v1.QEMUOption{{Name: "graphics", Value: "off"}},
+					Globals: []v1.QEMUOption{{Name: "ICH9-LPC.disable_s3", Value: "1"}},
+				},
+			),
+			table.Entry("reject any option without an allowlist",
+				nil,
+				&v1.Machine{
+					Options: []v1.QEMUOption{{Name: "graphics", Value: "off"}},
+					Globals: []v1.QEMUOption{{Name: "ICH9-LPC.disable_s3", Value: "1"}},
+				},
+				"fake.domain.machine.options[0].name", "fake.domain.machine.globals[0].name",
+			),
+			table.Entry("reject options missing from the allowlist",
+				&v1.QEMUOptionsAllowlist{MachineOptions: []string{"graphics"}},
+				&v1.Machine{
+					Options: []v1.QEMUOption{{Name: "graphics", Value: "off"}, {Name: "usb", Value: "on"}},
+				},
+				"fake.domain.machine.options[1].name",
+			),
+			table.Entry("reject option names carrying further options",
+				&v1.QEMUOptionsAllowlist{MachineOptions: []string{"graphics"}, Globals: []string{"ICH9-LPC.disable_s3"}},
+				&v1.Machine{
+					Options: []v1.QEMUOption{{Name: "graphics=off,usb", Value: "on"}},
+					Globals: []v1.QEMUOption{{Name: "ICH9-LPC", Value: "1"}},
+				},
+				"fake.domain.machine.options[0].name", "fake.domain.machine.globals[0].name",
+			),
+			table.Entry("reject duplicate options",
+				&v1.QEMUOptionsAllowlist{MachineOptions: []string{"graphics"}},
+				&v1.Machine{
+					Options: []v1.QEMUOption{{Name: "graphics", Value: "off"}, {Name: "graphics", Value: "on"}},
+				},
+				"fake.domain.machine.options[1].name",
+			),
+		)
+
 		It("should accept valid hostname", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 			vmi.Spec.Hostname = "test"
@@ -501,18 +672,121 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
 			Expect(len(causes)).To(Equal(0))
 		})
-		It("should reject invalid subdomain name", func() {
+		table.DescribeTable("should reject invalid subdomain name", func(subdomain string) {
 			vmi := v1.NewMinimalVMI("testvmi")
-			vmi.Spec.Subdomain = "bad+domain"
+			vmi.Spec.Subdomain = subdomain
 
 			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
 			Expect(len(causes)).To(Equal(1))
 			Expect(causes[0].Field).To(Equal("fake.subdomain"))
+			Expect(causes[0].Message).To(ContainSubstring("does not conform to the kubernetes DNS_LABEL rules : "))
+		},
+			table.Entry("with an invalid character", "bad+domain"),
+			table.Entry("with more than one label", "sub.domain"),
+			table.Entry("with a label which is too long", strings.Repeat("a", validation.DNS1123LabelMaxLength+1)),
+		)
+		It("should reject a hostname which is too long", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Hostname = strings.Repeat("a", validation.DNS1123LabelMaxLength+1)
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.hostname"))
+		})
+		It("should accept valid launcher pod settings", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			runtimeClassName := "kata"
+			vmi.Spec.SchedulerName = "custom-scheduler"
+			vmi.Spec.RuntimeClassName = &runtimeClassName
+			vmi.Spec.LauncherPodMetadata = &v1.LauncherPodMetadata{
+				Labels:      map[string]string{"sidecar.istio.io/inject": "true"},
+				Annotations: map[string]string{"example.com/annotation": "some value"},
+			}
+			vmi.Spec.TopologySpreadConstraints = []k8sv1.TopologySpreadConstraint{
+				{MaxSkew: 1, TopologyKey: "topology.kubernetes.io/zone", WhenUnsatisfiable: k8sv1.DoNotSchedule},
+				{MaxSkew: 2, TopologyKey: "topology.kubernetes.io/zone", WhenUnsatisfiable: k8sv1.ScheduleAnyway},
+			}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+		})
+		It("should reject launcher pod metadata which is not allowed to be propagated", func() {
+			kvConfig := kv.DeepCopy()
+			kvConfig.Spec.Configuration.LauncherPodMetadataPropagation = &v1.LauncherPodMetadataPropagation{
+				AllowedLabels:      []string{"sidecar.istio.io/inject"},
+				AllowedAnnotations: []string{"example.com/*"},
+			}
+			testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kvConfig)
+
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.LauncherPodMetadata = &v1.LauncherPodMetadata{
+				Labels:      map[string]string{"sidecar.istio.io/inject": "true", "monitoring": "value"},
+				Annotations: map[string]string{"example.com/annotation": "value", "prometheus.io/scrape": "true"},
+			}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(2))
+			Expect([]string{causes[0].Field, causes[1].Field}).To(ConsistOf(
+				"fake.launcherPodMetadata.labels[monitoring]",
+				"fake.launcherPodMetadata.annotations[prometheus.io/scrape]",
+			))
 		})
+		table.DescribeTable("should reject invalid launcher pod settings", func(updateSpec func(spec *v1.VirtualMachineInstanceSpec), field string) {
+			vmi := v1.NewMinimalVMI("testvmi")
+			updateSpec(&vmi.Spec)
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(len(causes)).To(Equal(1))
+			Expect(causes[0].Field).To(Equal(field))
+		},
+			table.Entry("with an invalid scheduler name", func(spec *v1.VirtualMachineInstanceSpec) {
+				spec.SchedulerName = "bad+scheduler"
+			}, "fake.schedulerName"),
+			table.Entry("with an invalid runtime class name", func(spec *v1.VirtualMachineInstanceSpec) {
+				runtimeClassName := "Bad_Runtime"
+				spec.RuntimeClassName = &runtimeClassName
+			}, "fake.runtimeClassName"),
+			table.Entry("with an invalid label key", func(spec *v1.VirtualMachineInstanceSpec) {
+				spec.LauncherPodMetadata = &v1.LauncherPodMetadata{Labels: map[string]string{"bad key": "value"}}
+			}, "fake.launcherPodMetadata.labels[bad key]"),
+			table.Entry("with an invalid label value", func(spec *v1.VirtualMachineInstanceSpec) {
+				spec.LauncherPodMetadata = &v1.LauncherPodMetadata{Labels: map[string]string{"key": "bad value"}}
+			}, "fake.launcherPodMetadata.labels[key]"),
+			table.Entry("with a reserved label", func(spec *v1.VirtualMachineInstanceSpec) {
+				spec.LauncherPodMetadata = &v1.LauncherPodMetadata{Labels: map[string]string{v1.AppLabel: "value"}}
+			}, "fake.launcherPodMetadata.labels[kubevirt.io]"),
+			table.Entry("with a reserved annotation", func(spec *v1.VirtualMachineInstanceSpec) {
+				spec.LauncherPodMetadata = &v1.LauncherPodMetadata{Annotations: map[string]string{v1.DomainAnnotation: "value"}}
+			}, "fake.launcherPodMetadata.annotations[kubevirt.io/domain]"),
+			table.Entry("with a reserved annotation in a kubevirt.io subdomain", func(spec *v1.VirtualMachineInstanceSpec) {
+				spec.LauncherPodMetadata = &v1.LauncherPodMetadata{Annotations: map[string]string{"vm.kubevirt.io/flavor": "value"}}
+			}, "fake.launcherPodMetadata.annotations[vm.kubevirt.io/flavor]"),
+			table.Entry("with a non positive maxSkew", func(spec *v1.VirtualMachineInstanceSpec) {
+				spec.TopologySpreadConstraints = []k8sv1.TopologySpreadConstraint{
+					{MaxSkew: 0, TopologyKey: "zone", WhenUnsatisfiable: k8sv1.DoNotSchedule},
+				}
+			}, "fake.topologySpreadConstraints[0].maxSkew"),
+			table.Entry("with a missing topologyKey", func(spec *v1.VirtualMachineInstanceSpec) {
+				spec.TopologySpreadConstraints = []k8sv1.TopologySpreadConstraint{
+					{MaxSkew: 1, WhenUnsatisfiable: k8sv1.DoNotSchedule},
+				}
+			}, "fake.topologySpreadConstraints[0].topologyKey"),
+			table.Entry("with an unsupported whenUnsatisfiable", func(spec *v1.VirtualMachineInstanceSpec) {
+				spec.TopologySpreadConstraints = []k8sv1.TopologySpreadConstraint{
+					{MaxSkew: 1, TopologyKey: "zone", WhenUnsatisfiable: "Sometimes"},
+				}
+			}, "fake.topologySpreadConstraints[0].whenUnsatisfiable"),
+			table.Entry("with duplicate constraints", func(spec *v1.VirtualMachineInstanceSpec) {
+				spec.TopologySpreadConstraints = []k8sv1.TopologySpreadConstraint{
+					{MaxSkew: 1, TopologyKey: "zone", WhenUnsatisfiable: k8sv1.DoNotSchedule},
+					{MaxSkew: 2, TopologyKey: "zone", WhenUnsatisfiable: k8sv1.DoNotSchedule},
+				}
+			}, "fake.topologySpreadConstraints[1]"),
+		)
 		It("should accept disk and volume lists equal to max element length", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 
-			for i := 0; i < arrayLenMax; i++ {
+			for i := 0; i < virtconfig.DefaultMaxListLength; i++ {
 				diskName := fmt.Sprintf("testdisk%d", i)
 				vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
 					Name: diskName,
@@ -531,7 +805,7 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 		It("should reject disk lists greater than max element length", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 
-			for i := 0; i <= arrayLenMax; i++ {
+			for i := 0; i <= virtconfig.DefaultMaxListLength; i++ {
 				diskName := "testDisk"
 				vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
 					Name: diskName,
@@ -547,7 +821,7 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 		It("should reject volume lists greater than max element length", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 
-			for i := 0; i <= arrayLenMax; i++ {
+			for i := 0; i <= virtconfig.DefaultMaxListLength; i++ {
 				volumeName := "testVolume"
 				vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
 					Name: volumeName,
@@ -563,6 +837,58 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(len(causes)).To(Equal(1))
 			Expect(causes[0].Field).To(Equal("fake.volumes"))
 		})
+		It("should honor the max list length of the KubeVirt CR", func() {
+			maxListLength := virtconfig.DefaultMaxListLength + 1
+			kvConfig := kv.DeepCopy()
+			kvConfig.Spec.Configuration.AdmissionLimits = &v1.AdmissionLimits{MaxListLength: &maxListLength}
+			testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kvConfig)
+
+			vmi := v1.NewMinimalVMI("testvmi")
+			for i := 0; i < maxListLength; i++ {
+				diskName := fmt.Sprintf("testdisk%d", i)
+				vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+					Name: diskName,
+				})
+				vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+					Name: diskName,
+					VolumeSource: v1.VolumeSource{
+						ContainerDisk: testutils.NewFakeContainerDiskSource(),
+					},
+				})
+			}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(BeEmpty())
+
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name: "onetoomany",
+				VolumeSource: v1.VolumeSource{
+					ContainerDisk: testutils.NewFakeContainerDiskSource(),
+				},
+			})
+			causes = ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Message).To(Equal(fmt.Sprintf("fake.volumes list exceeds the %d element limit in length", maxListLength)))
+		})
+		It("should honor the cloud-init user data limit of the KubeVirt CR", func() {
+			userDataMaxBytes := 2 * virtconfig.DefaultCloudInitUserDataMaxBytes
+			kvConfig := kv.DeepCopy()
+			kvConfig.Spec.Configuration.AdmissionLimits = &v1.AdmissionLimits{CloudInitUserDataMaxBytes: &userDataMaxBytes}
+			testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kvConfig)
+
+			volumes := []v1.Volume{{
+				Name: "cloudinit",
+				VolumeSource: v1.VolumeSource{
+					CloudInitNoCloud: &v1.CloudInitNoCloudSource{UserData: strings.Repeat("a", userDataMaxBytes)},
+				},
+			}}
+			Expect(validateVolumes(k8sfield.NewPath("fake"), volumes, config)).To(BeEmpty())
+
+			volumes[0].CloudInitNoCloud.UserData += "a"
+			causes := validateVolumes(k8sfield.NewPath("fake"), volumes, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Message).To(ContainSubstring(fmt.Sprintf("userdata exceeds %d byte limit", userDataMaxBytes)))
+		})
 		It("should reject disk with missing volume", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 
@@ -801,7 +1127,7 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 
 			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
 			Expect(len(causes)).To(Equal(1))
-			Expect(causes[0].Field).To(Equal("fake.domain.hugepages.size"))
+			Expect(causes[0].Field).To(Equal("fake.domain.memory.hugepages.pageSize"))
 		})
 		It("should reject greater hugepages.size than requests.memory", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
@@ -875,7 +1201,7 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 				k8sv1.ResourceMemory: resource.MustParse("65Mi"),
 			}
 			vmi.Spec.Domain.Memory = &v1.Memory{Hugepages: &v1.Hugepages{}}
-			vmi.Spec.Domain.Memory.Hugepages.PageSize = "2Gi"
+			vmi.Spec.Domain.Memory.Hugepages.PageSize = "2Mi"
 
 			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
 			Expect(len(causes)).To(Equal(1))
@@ -897,17 +1223,86 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 
 			vmi.Spec.Domain.Resources.Requests = k8sv1.ResourceList{
-				k8sv1.ResourceMemory: resource.MustParse("64Mi"),
+				k8sv1.ResourceMemory: resource.MustParse("1500Mi"),
 			}
 			vmi.Spec.Domain.Memory = &v1.Memory{Hugepages: &v1.Hugepages{}}
-			vmi.Spec.Domain.Memory.Hugepages.PageSize = "10Mi"
+			vmi.Spec.Domain.Memory.Hugepages.PageSize = "1Gi"
 
 			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
 			Expect(len(causes)).To(Equal(1))
 			Expect(string(causes[0].Type)).To(Equal("FieldValueInvalid"))
 			Expect(causes[0].Field).To(Equal("fake.domain.resources.requests.memory"))
-			Expect(causes[0].Message).To(Equal("fake.domain.resources.requests.memory '64Mi' " +
-				"is not a multiple of the page size fake.domain.hugepages.size '10Mi'"))
+			Expect(causes[0].Message).To(Equal("fake.domain.resources.requests.memory '1500Mi' " +
+				"is not a multiple of the page size fake.domain.memory.hugepages.pageSize '1Gi'"))
+		})
+		table.DescribeTable("should validate the hugepages size", func(pageSize string, valid bool) {
+			vmi := v1.NewMinimalVMI("testvmi")
+
+			vmi.Spec.Domain.Resources.Requests = k8sv1.ResourceList{
+				k8sv1.ResourceMemory: resource.MustParse("2Gi"),
+			}
+			vmi.Spec.Domain.Memory = &v1.Memory{Hugepages: &v1.Hugepages{PageSize: pageSize}}
+
+			webhooks.Arch = "amd64"
+			defer func() { webhooks.Arch = rt.GOARCH }()
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			if valid {
+				Expect(causes).To(BeEmpty())
+			} else {
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Type).To(Equal(metav1.CauseTypeFieldValueNotSupported))
+				Expect(causes[0].Field).To(Equal("fake.domain.memory.hugepages.pageSize"))
+				Expect(causes[0].Message).To(Equal(fmt.Sprintf("fake.domain.memory.hugepages.pageSize '%s' is not supported on amd64, must be one of: [2Mi 1Gi]", pageSize)))
+			}
+		},
+			table.Entry("and accept 2Mi", "2Mi", true),
+			table.Entry("and accept 1Gi", "1Gi", true),
+			table.Entry("and accept 2Mi in another unit", "2048Ki", true),
+			table.Entry("and reject 4Mi", "4Mi", false),
+			table.Entry("and reject 2Gi", "2Gi", false),
+		)
+		table.DescribeTable("should validate the hugepages size of the architecture selected for the VMI", func(nodeSelector map[string]string, affinity *k8sv1.Affinity, valid bool) {
+			vmi := v1.NewMinimalVMI("testvmi")
+
+			vmi.Spec.Domain.Resources.Requests = k8sv1.ResourceList{
+				k8sv1.ResourceMemory: resource.MustParse("2Gi"),
+			}
+			vmi.Spec.Domain.Memory = &v1.Memory{Hugepages: &v1.Hugepages{PageSize: "4Mi"}}
+			vmi.Spec.NodeSelector = nodeSelector
+			vmi.Spec.Affinity = affinity
+
+			// virt-api itself runs on an architecture without a fixed set of page sizes
+			webhooks.Arch = "s390x"
+			defer func() { webhooks.Arch = rt.GOARCH }()
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			if valid {
+				Expect(causes).To(BeEmpty())
+			} else {
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Message).To(Equal("fake.domain.memory.hugepages.pageSize '4Mi' is not supported on amd64, must be one of: [2Mi 1Gi]"))
+			}
+		},
+			table.Entry("and use the architecture of virt-api without a selected one", nil, nil, true),
+			table.Entry("and use the architecture of the node selector",
+				map[string]string{k8sv1.LabelArchStable: "amd64"}, nil, false),
+			table.Entry("and use the architecture required by the node affinity",
+				nil, newArchitectureAffinity("amd64"), false),
+			table.Entry("and use the architecture of virt-api if the node affinity allows several",
+				nil, newArchitectureAffinity("amd64", "s390x"), true),
+		)
+		It("should reject hugepages with an overcommitted guest overhead", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+
+			vmi.Spec.Domain.Resources.Requests = k8sv1.ResourceList{
+				k8sv1.ResourceMemory: resource.MustParse("64Mi"),
+			}
+			vmi.Spec.Domain.Resources.OvercommitGuestOverhead = true
+			vmi.Spec.Domain.Memory = &v1.Memory{Hugepages: &v1.Hugepages{PageSize: "2Mi"}}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.resources.overcommitGuestOverhead"))
+			Expect(causes[0].Message).To(Equal("fake.domain.resources.overcommitGuestOverhead must not be set when fake.domain.memory.hugepages are used"))
 		})
 		It("should allow setting guest memory and hugepages", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
@@ -926,6 +1321,87 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
 			Expect(len(causes)).To(Equal(0))
 		})
+		It("should reject a zero hugepages size", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+
+			vmi.Spec.Domain.Resources.Requests = k8sv1.ResourceList{
+				k8sv1.ResourceMemory: resource.MustParse("64Mi"),
+			}
+			vmi.Spec.Domain.Memory = &v1.Memory{Hugepages: &v1.Hugepages{PageSize: "0"}}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.memory.hugepages.pageSize"))
+		})
+		table.DescribeTable("should validate guest memory backed by hugepages", func(guest string, expectedCauses int) {
+			vmi := v1.NewMinimalVMI("testvmi")
+			guestMemory := resource.MustParse(guest)
+
+			vmi.Spec.Domain.Resources.Requests = k8sv1.ResourceList{
+				k8sv1.ResourceMemory: resource.MustParse("64Mi"),
+			}
+			vmi.Spec.Domain.Memory = &v1.Memory{
+				Hugepages: &v1.Hugepages{PageSize: "2Mi"},
+				Guest:     &guestMemory,
+			}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(expectedCauses))
+			for _, cause := range causes {
+				Expect(cause.Field).To(Equal("fake.domain.memory.guest"))
+			}
+		},
+			table.Entry("and accept a multiple of the page size", "32Mi", 0),
+			table.Entry("and reject more than the memory request", "128Mi", 1),
+			table.Entry("and reject no multiple of the page size", "33Mi", 1),
+		)
+		It("should reject zero guest memory", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			guestMemory := resource.MustParse("0")
+
+			vmi.Spec.Domain.Memory = &v1.Memory{Guest: &guestMemory}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.domain.memory.guest"))
+		})
+		table.DescribeTable("should validate requests and limits of other resources", func(request, limit string, expectedField string) {
+			vmi := v1.NewMinimalVMI("testvmi")
+
+			vmi.Spec.Domain.Resources.Requests = k8sv1.ResourceList{
+				k8sv1.ResourceEphemeralStorage: resource.MustParse(request),
+			}
+			vmi.Spec.Domain.Resources.Limits = k8sv1.ResourceList{
+				k8sv1.ResourceEphemeralStorage: resource.MustParse(limit),
+			}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			if expectedField == "" {
+				Expect(causes).To(BeEmpty())
+			} else {
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal(expectedField))
+			}
+		},
+			table.Entry("and accept a request within the limit", "1Gi", "2Gi", ""),
+			table.Entry("and reject a request exceeding the limit", "2Gi", "1Gi", "fake.domain.resources.requests[ephemeral-storage]"),
+			table.Entry("and reject a negative limit", "1Gi", "-1Gi", "fake.domain.resources.limits[ephemeral-storage]"),
+		)
+		table.DescribeTable("should validate the number of vCPUs of the CPU topology", func(cpu *v1.CPU, expectedCauses int) {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.CPU = cpu
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(expectedCauses))
+			if expectedCauses > 0 {
+				Expect(causes[0].Field).To(Equal("fake.domain.cpu"))
+			}
+		},
+			table.Entry("and accept a regular topology", &v1.CPU{Cores: 4, Sockets: 2, Threads: 2}, 0),
+			table.Entry("and accept the maximum", &v1.CPU{Sockets: maxVCPUs}, 0),
+			table.Entry("and reject too many vCPUs", &v1.CPU{Cores: 64, Sockets: 16, Threads: 2}, 1),
+			table.Entry("and reject topologies overflowing 32 bits", &v1.CPU{Cores: 65536, Sockets: 65536}, 1),
+		)
 		table.DescribeTable("should verify LUN is mapped to PVC volume",
 			func(volume *v1.Volume, expectedErrors int) {
 				vmi := v1.NewMinimalVMI("testvmi")
@@ -968,7 +1444,7 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
 			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
-			for i := 1; i < arrayLenMax; i++ {
+			for i := 1; i < virtconfig.DefaultMaxListLength; i++ {
 				networkName := fmt.Sprintf("default%d", i)
 
 				vmi.Spec.Domain.Devices.Interfaces = append(vmi.Spec.Domain.Devices.Interfaces,
@@ -986,7 +1462,7 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 		It("should reject interface lists greater than max element length", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
-			for i := 0; i < arrayLenMax; i++ {
+			for i := 0; i < virtconfig.DefaultMaxListLength; i++ {
 				networkName := fmt.Sprintf("default%d", i)
 				vmi.Spec.Domain.Devices.Interfaces = append(vmi.Spec.Domain.Devices.Interfaces,
 					v1.Interface{Name: networkName,
@@ -996,12 +1472,12 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
 			Expect(len(causes)).To(Equal(1))
 			Expect(causes[0].Message).To(Equal(fmt.Sprintf("fake.domain.devices.interfaces "+
-				"list exceeds the %d element limit in length", arrayLenMax)))
+				"list exceeds the %d element limit in length", virtconfig.DefaultMaxListLength)))
 		})
 		It("should reject network lists greater than max element length", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
-			for i := 0; i < arrayLenMax; i++ {
+			for i := 0; i < virtconfig.DefaultMaxListLength; i++ {
 				networkName := fmt.Sprintf("default%d", i)
 				vmi.Spec.Networks = append(vmi.Spec.Networks,
 					v1.Network{Name: networkName, NetworkSource: v1.NetworkSource{
@@ -1010,7 +1486,7 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
 			Expect(len(causes)).To(Equal(1))
 			Expect(causes[0].Message).To(Equal(fmt.Sprintf("fake.networks "+
-				"list exceeds the %d element limit in length", arrayLenMax)))
+				"list exceeds the %d element limit in length", virtconfig.DefaultMaxListLength)))
 		})
 		It("should reject disks with the same boot order", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
@@ -1473,6 +1949,72 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(causes[0].Message).To(Equal("The requested MAC address is reserved for the in-pod bridge. Please choose another one."))
 			Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[0].macAddress"))
 		})
+		table.DescribeTable("should validate the serial channels", func(channels []v1.SerialChannel, expectedField string) {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Channels = channels
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			if expectedField == "" {
+				Expect(causes).To(BeEmpty())
+			} else {
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal(expectedField))
+			}
+		},
+			table.Entry("accept channels with unique names and targets", []v1.SerialChannel{
+				{Name: "logs", Target: "org.example.log.0"},
+				{Name: "telemetry", Target: "org.example.telemetry.0"},
+			}, ""),
+			table.Entry("reject a name which can't name a socket", []v1.SerialChannel{
+				{Name: "../logs", Target: "org.example.log.0"},
+			}, "fake.domain.devices.channels[0].name"),
+			table.Entry("reject a duplicate name", []v1.SerialChannel{
+				{Name: "logs", Target: "org.example.log.0"},
+				{Name: "logs", Target: "org.example.log.1"},
+			}, "fake.domain.devices.channels[1].name"),
+			table.Entry("reject an empty target", []v1.SerialChannel{
+				{Name: "logs"},
+			}, "fake.domain.devices.channels[0].target"),
+			table.Entry("reject a duplicate target", []v1.SerialChannel{
+				{Name: "logs", Target: "org.example.log.0"},
+				{Name: "telemetry", Target: "org.example.log.0"},
+			}, "fake.domain.devices.channels[1].target"),
+			table.Entry("reject the target of the guest agent", []v1.SerialChannel{
+				{Name: "agent", Target: "org.qemu.guest_agent.0"},
+			}, "fake.domain.devices.channels[0].target"),
+		)
+		It("should reject an interface with more than one binding method", func() {
+			vmi := v1.NewMinimalVMI("testvm")
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{{
+				Name: "default",
+				InterfaceBindingMethod: v1.InterfaceBindingMethod{
+					Bridge:     &v1.InterfaceBridge{},
+					Masquerade: &v1.InterfaceMasquerade{},
+				},
+			}}
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Message).To(Equal("should have only one binding method"))
+			Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[0]"))
+		})
+		It("should reject a network with more than one network type", func() {
+			vmi := v1.NewMinimalVMI("testvm")
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultMasqueradeNetworkInterface()}
+			vmi.Spec.Networks = []v1.Network{{
+				Name: "default",
+				NetworkSource: v1.NetworkSource{
+					Pod:    &v1.PodNetwork{},
+					Multus: &v1.MultusNetwork{NetworkName: "test"},
+				},
+			}}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Message).To(Equal("should have only one network type"))
+			Expect(causes[0].Field).To(Equal("fake.networks[0]"))
+		})
 		It("should accept a bridge interface on a pod network when it is permitted", func() {
 			vm := v1.NewMinimalVMI("testvm")
 			vm.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
@@ -1612,12 +2154,84 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vm.Spec, config)
 			Expect(causes).To(HaveLen(0))
 		})
-		It("should reject port out of range", func() {
-			enableSlirpInterface()
-			vm := v1.NewMinimalVMI("testvm")
-			vm.Spec.Domain.Devices.Interfaces = []v1.Interface{{
-				Name: "default",
-				InterfaceBindingMethod: v1.InterfaceBindingMethod{
+		Context("with a mirrored interface", func() {
+			newMirroredVMI := func(mirror *v1.InterfaceMirror) *v1.VirtualMachineInstance {
+				vmi := v1.NewMinimalVMI("testvmi")
+				vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{{
+					Name: "default",
+					InterfaceBindingMethod: v1.InterfaceBindingMethod{
+						Bridge: &v1.InterfaceBridge{},
+					},
+					Mirror: mirror,
+				}}
+				vmi.Spec.Networks = []v1.Network{{
+					Name:          "default",
+					NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "test"}},
+				}}
+				return vmi
+			}
+
+			It("should reject the mirror when the feature gate is not enabled", func() {
+				vmi := newMirroredVMI(&v1.InterfaceMirror{})
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[0].mirror"))
+				Expect(causes[0].Message).To(Equal("InterfaceMirroring feature gate is not enabled"))
+			})
+
+			It("should reject the mirror of an interface without the bridge binding", func() {
+				enableFeatureGate(virtconfig.InterfaceMirroringGate)
+				vmi := newMirroredVMI(&v1.InterfaceMirror{})
+				vmi.Spec.Domain.Devices.Interfaces[0].InterfaceBindingMethod = v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}}
+				vmi.Spec.Networks[0].NetworkSource = v1.NetworkSource{Pod: &v1.PodNetwork{}}
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[0].mirror"))
+			})
+
+			table.DescribeTable("should validate the mirror", func(mirror *v1.InterfaceMirror, expectedField string) {
+				enableFeatureGate(virtconfig.InterfaceMirroringGate)
+				vmi := newMirroredVMI(mirror)
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+				if expectedField == "" {
+					Expect(causes).To(BeEmpty())
+				} else {
+					Expect(causes).To(HaveLen(1))
+					Expect(causes[0].Field).To(Equal(expectedField))
+				}
+			},
+				table.Entry("and accept a capture interface", &v1.InterfaceMirror{}, ""),
+				table.Entry("and accept a mirror network with a filter and duration", &v1.InterfaceMirror{
+					Network:  "ids",
+					Filter:   &v1.InterfaceMirrorFilter{Direction: v1.InterfaceMirrorIngress, Protocol: "TCP", Port: 443},
+					Duration: &metav1.Duration{Duration: time.Hour},
+				}, ""),
+				table.Entry("and accept an ICMP filter", &v1.InterfaceMirror{
+					Filter: &v1.InterfaceMirrorFilter{Protocol: "ICMP"},
+				}, ""),
+				table.Entry("and reject an unknown direction", &v1.InterfaceMirror{
+					Filter: &v1.InterfaceMirrorFilter{Direction: "sideways"},
+				}, "fake.domain.devices.interfaces[0].mirror.filter.direction"),
+				table.Entry("and reject an unknown protocol", &v1.InterfaceMirror{
+					Filter: &v1.InterfaceMirrorFilter{Protocol: "SCTP"},
+				}, "fake.domain.devices.interfaces[0].mirror.filter.protocol"),
+				table.Entry("and reject a port out of range", &v1.InterfaceMirror{
+					Filter: &v1.InterfaceMirrorFilter{Protocol: "UDP", Port: 70000},
+				}, "fake.domain.devices.interfaces[0].mirror.filter.port"),
+				table.Entry("and reject a port without TCP or UDP", &v1.InterfaceMirror{
+					Filter: &v1.InterfaceMirrorFilter{Protocol: "ICMP", Port: 80},
+				}, "fake.domain.devices.interfaces[0].mirror.filter.port"),
+				table.Entry("and reject a duration which is not positive", &v1.InterfaceMirror{
+					Duration: &metav1.Duration{},
+				}, "fake.domain.devices.interfaces[0].mirror.duration"),
+			)
+		})
+		It("should reject port out of range", func() {
+			enableSlirpInterface()
+			vm := v1.NewMinimalVMI("testvm")
+			vm.Spec.Domain.Devices.Interfaces = []v1.Interface{{
+				Name: "default",
+				InterfaceBindingMethod: v1.InterfaceBindingMethod{
 					Slirp: &v1.InterfaceSlirp{},
 				},
 				Ports: []v1.Port{{Port: 80000}}}}
@@ -1761,7 +2375,7 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			vmi := v1.NewMinimalVMI("testvm")
 			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
 			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
-			for _, pciAddress := range []string{"0000:80.10.1", "0000:80:80:1.0", "0000:80:11.15"} {
+			for _, pciAddress := range []string{"0000:80.10.1", "0000:80:80:1.0", "0000:80:11.15", "0000:80:20.0"} {
 				vmi.Spec.Domain.Devices.Interfaces[0].PciAddress = pciAddress
 				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
 				Expect(len(causes)).To(Equal(1))
@@ -1769,6 +2383,70 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			}
 		})
 
+		It("should reject disks and interfaces with the same PCI address", func() {
+			vmi := v1.NewMinimalVMI("testvm")
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+			vmi.Spec.Domain.Devices.Interfaces[0].PciAddress = "0000:02:01.0"
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{{
+				Name: "testdisk",
+				DiskDevice: v1.DiskDevice{
+					Disk: &v1.DiskTarget{PciAddress: "0000:02:01.0", Bus: "virtio"},
+				},
+			}}
+			vmi.Spec.Volumes = []v1.Volume{{
+				Name:         "testdisk",
+				VolumeSource: v1.VolumeSource{ContainerDisk: testutils.NewFakeContainerDiskSource()},
+			}}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Type).To(Equal(metav1.CauseTypeFieldValueDuplicate))
+			Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[0].pciAddress"))
+		})
+
+		table.DescribeTable("should validate the bandwidth of interfaces", func(bandwidth string, valid bool) {
+			vmi := v1.NewMinimalVMI("testvm")
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			quantity := resource.MustParse(bandwidth)
+			vmi.Spec.Domain.Devices.Interfaces[0].Bandwidth = &quantity
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			if valid {
+				Expect(causes).To(BeEmpty())
+			} else {
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[0].bandwidth"))
+			}
+		},
+			table.Entry("and accept a positive bandwidth", "10G", true),
+			table.Entry("and reject a zero bandwidth", "0", false),
+			table.Entry("and reject a negative bandwidth", "-1G", false),
+		)
+
+		table.DescribeTable("should validate the bandwidth limit of interfaces", func(iface *v1.Interface, inbound string, invalidField string) {
+			vmi := v1.NewMinimalVMI("testvm")
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*iface}
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			quantity := resource.MustParse(inbound)
+			vmi.Spec.Domain.Devices.Interfaces[0].BandwidthLimit = &v1.InterfaceBandwidthLimit{Inbound: &quantity}
+
+			var fields []string
+			for _, cause := range ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config) {
+				fields = append(fields, cause.Field)
+			}
+			if invalidField == "" {
+				Expect(fields).To(BeEmpty())
+			} else {
+				Expect(fields).To(ContainElement(invalidField))
+			}
+		},
+			table.Entry("and accept a positive limit", v1.DefaultBridgeNetworkInterface(), "100M", ""),
+			table.Entry("and reject a zero limit", v1.DefaultBridgeNetworkInterface(), "0", "fake.domain.devices.interfaces[0].bandwidthLimit.inbound"),
+			table.Entry("and reject a limit on a slirp interface", v1.DefaultSlirpNetworkInterface(), "100M", "fake.domain.devices.interfaces[0].bandwidthLimit"),
+		)
+
 		It("should accept valid NTP servers", func() {
 			vmi := v1.NewMinimalVMI("testvm")
 			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
@@ -1791,6 +2469,28 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(len(causes)).To(Equal(2))
 		})
 
+		table.DescribeTable("should validate the DHCP lease time", func(leaseTime time.Duration, valid bool) {
+			vmi := v1.NewMinimalVMI("testvm")
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+			vmi.Spec.Domain.Devices.Interfaces[0].DHCPOptions = &v1.DHCPOptions{
+				LeaseTime: &metav1.Duration{Duration: leaseTime},
+			}
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			if valid {
+				Expect(causes).To(BeEmpty())
+			} else {
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[0].dhcpOptions.leaseTime"))
+			}
+		},
+			table.Entry("and accept a lease time of hours", 12*time.Hour, true),
+			table.Entry("and accept a lease time of one second", time.Second, true),
+			table.Entry("and reject a lease time below one second", 500*time.Millisecond, false),
+			table.Entry("and reject a negative lease time", -time.Hour, false),
+			table.Entry("and reject a lease time which doesn't fit into the DHCP option", math.MaxUint32*time.Second, false),
+		)
+
 		It("should accept valid DHCPPrivateOptions", func() {
 			vmi := v1.NewMinimalVMI("testvm")
 			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
@@ -1909,6 +2609,21 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(causes[0].Message).To(Equal(fmt.Sprintf("Invalid IOThreadsPolicy (%s)", ioThreadPolicy)))
 		})
 
+		table.DescribeTable("should validate the SCSI controller model", func(model v1.SCSIControllerModel, expectedCauses int) {
+			vmi := v1.NewMinimalVMI("testvm")
+			vmi.Spec.Domain.Devices.SCSIController = &v1.SCSIController{Model: model}
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(expectedCauses))
+			if expectedCauses > 0 {
+				Expect(causes[0].Field).To(Equal("fake.domain.devices.scsiController.model"))
+			}
+		},
+			table.Entry("and accept the default", v1.SCSIControllerModel(""), 0),
+			table.Entry("and accept virtio-scsi", v1.SCSIControllerModelVirtio, 0),
+			table.Entry("and accept lsilogic", v1.SCSIControllerModelLSILogic, 0),
+			table.Entry("and reject unknown models", v1.SCSIControllerModel("buslogic"), 1),
+		)
+
 		It("should reject GPU devices when feature gate is disabled", func() {
 			vmi := v1.NewMinimalVMI("testvm")
 			vmi.Spec.Domain.Devices.GPUs = []v1.GPU{
@@ -2064,6 +2779,94 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
 			Expect(len(causes)).To(Equal(0))
 		})
+		table.DescribeTable("should validate the PCI order of GPUs and host devices",
+			func(gpuOrder, hostDeviceOrder *uint, expectedField string) {
+				kvConfig := kv.DeepCopy()
+				kvConfig.Spec.Configuration.DeveloperConfiguration.FeatureGates = []string{virtconfig.GPUGate, virtconfig.HostDevicesGate}
+				testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kvConfig)
+
+				vmi := v1.NewMinimalVMI("testvm")
+				vmi.Spec.Domain.Devices.GPUs = []v1.GPU{
+					{
+						Name:       "gpu1",
+						DeviceName: "vendor.com/gpu_name",
+						Role:       "render",
+						PCIOrder:   gpuOrder,
+					},
+				}
+				vmi.Spec.Domain.Devices.HostDevices = []v1.HostDevice{
+					{
+						Name:       "hostdev1",
+						DeviceName: "vendor.com/hostdev_name",
+						Role:       "compute",
+						PCIOrder:   hostDeviceOrder,
+					},
+				}
+
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+				if expectedField == "" {
+					Expect(causes).To(BeEmpty())
+					return
+				}
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal(expectedField))
+			},
+			table.Entry("and accept devices without a PCI order", nil, nil, ""),
+			table.Entry("and accept unique PCI orders", uintPtr(1), uintPtr(2), ""),
+			table.Entry("and reject a PCI order of 0", uintPtr(0), uintPtr(2), "fake.domain.devices.gpus[0].pciOrder"),
+			table.Entry("and reject duplicate PCI orders", uintPtr(1), uintPtr(1), "fake.domain.devices.hostDevices[0].pciOrder"),
+		)
+		table.DescribeTable("should validate the guest OS family", func(family v1.GuestOSFamily, expectedCauses int) {
+			vmi := v1.NewMinimalVMI("testvm")
+			vmi.Spec.Domain.GuestOS = &v1.GuestOS{OSFamily: family}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(expectedCauses))
+			if expectedCauses > 0 {
+				Expect(causes[0].Field).To(Equal("fake.domain.guestOS.osFamily"))
+			}
+		},
+			table.Entry("and accept linux", v1.GuestOSFamilyLinux, 0),
+			table.Entry("and accept windows", v1.GuestOSFamilyWindows, 0),
+			table.Entry("and reject unknown families", v1.GuestOSFamily("beos"), 1),
+		)
+		table.DescribeTable("should validate shareable disks",
+			func(diskDevice v1.DiskDevice, cache v1.DriverCache, volumeSource v1.VolumeSource, expectedField string) {
+				vmi := v1.NewMinimalVMI("testvm")
+				vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+					{
+						Name:       "shared",
+						DiskDevice: diskDevice,
+						Cache:      cache,
+						Shareable:  pointer.BoolPtr(true),
+					},
+				}
+				vmi.Spec.Volumes = []v1.Volume{{Name: "shared", VolumeSource: volumeSource}}
+
+				causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+				if expectedField == "" {
+					Expect(causes).To(BeEmpty())
+					return
+				}
+				Expect(causes).To(HaveLen(1))
+				Expect(causes[0].Field).To(Equal(expectedField))
+			},
+			table.Entry("and accept a lun backed by a PVC",
+				v1.DiskDevice{LUN: &v1.LunTarget{}}, v1.DriverCache(""),
+				v1.VolumeSource{PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "shared"}}}, ""),
+			table.Entry("and accept a disk backed by a DataVolume",
+				v1.DiskDevice{Disk: &v1.DiskTarget{}}, v1.CacheNone,
+				v1.VolumeSource{DataVolume: &v1.DataVolumeSource{Name: "shared"}}, ""),
+			table.Entry("and reject a cdrom",
+				v1.DiskDevice{CDRom: &v1.CDRomTarget{}}, v1.DriverCache(""),
+				v1.VolumeSource{DataVolume: &v1.DataVolumeSource{Name: "shared"}}, "fake.domain.devices.disks[0].shareable"),
+			table.Entry("and reject a host side cache",
+				v1.DiskDevice{Disk: &v1.DiskTarget{}}, v1.CacheWriteThrough,
+				v1.VolumeSource{DataVolume: &v1.DataVolumeSource{Name: "shared"}}, "fake.domain.devices.disks[0].cache"),
+			table.Entry("and reject an ephemeral volume",
+				v1.DiskDevice{Disk: &v1.DiskTarget{}}, v1.DriverCache(""),
+				v1.VolumeSource{Ephemeral: &v1.EphemeralVolumeSource{PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "shared"}}}, "fake.domain.devices.disks[0].shareable"),
+		)
 		table.DescribeTable("Should accept valid DNSPolicy and DNSConfig",
 			func(dnsPolicy k8sv1.DNSPolicy, dnsConfig *k8sv1.PodDNSConfig) {
 				vmi := v1.NewMinimalVMI("testvmi")
@@ -2173,6 +2976,101 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(causes[0].Field).To(Equal("fake.startStrategy"))
 			Expect(causes[0].Message).To(Equal("either fake.startStrategy or fake.livenessProbe should be provided.Pausing VMI with LivenessProbe is not supported"))
 		})
+		table.DescribeTable("should validate scheduling readiness gates", func(gates []v1.SchedulingReadinessGate, expectedFields []string) {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.SchedulingReadinessGates = gates
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			var fields []string
+			for _, cause := range causes {
+				fields = append(fields, cause.Field)
+			}
+			Expect(fields).To(Equal(expectedFields))
+		},
+			table.Entry("and accept custom conditions", []v1.SchedulingReadinessGate{
+				{ConditionType: "example.com/IPReserved"},
+				{ConditionType: "example.com/LicenseCheckedOut"},
+			}, nil),
+			table.Entry("and reject an empty condition type", []v1.SchedulingReadinessGate{
+				{ConditionType: ""},
+			}, []string{"fake.schedulingReadinessGates[0].conditionType"}),
+			table.Entry("and reject conditions managed by KubeVirt", []v1.SchedulingReadinessGate{
+				{ConditionType: v1.VirtualMachineInstanceReady},
+				{ConditionType: "PodScheduled"},
+			}, []string{"fake.schedulingReadinessGates[0].conditionType", "fake.schedulingReadinessGates[1].conditionType"}),
+			table.Entry("and reject duplicate conditions", []v1.SchedulingReadinessGate{
+				{ConditionType: "example.com/IPReserved"},
+				{ConditionType: "example.com/IPReserved"},
+			}, []string{"fake.schedulingReadinessGates[1].conditionType"}),
+		)
+		It("should reject sidecars without the sidecar feature gate", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Sidecars = []v1.Sidecar{{Name: "log-shipper", Image: "fake-image"}}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake.sidecars"))
+		})
+		table.DescribeTable("should validate sidecars", func(sidecars []v1.Sidecar, expectedFields []string) {
+			enableFeatureGate(virtconfig.SidecarGate)
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Sidecars = sidecars
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{{Name: "cloudinit"}, {Name: "licenses"}, {Name: "containerdisk"}}
+			vmi.Spec.Volumes = []v1.Volume{
+				{
+					Name: "cloudinit",
+					VolumeSource: v1.VolumeSource{
+						CloudInitNoCloud: &v1.CloudInitNoCloudSource{UserData: "#cloud-config"},
+					},
+				},
+				{
+					Name: "licenses",
+					VolumeSource: v1.VolumeSource{
+						ConfigMap: &v1.ConfigMapVolumeSource{LocalObjectReference: k8sv1.LocalObjectReference{Name: "licenses"}},
+					},
+				},
+				{
+					Name: "containerdisk",
+					VolumeSource: v1.VolumeSource{
+						ContainerDisk: &v1.ContainerDiskSource{Image: "fake-image"},
+					},
+				},
+			}
+
+			causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), &vmi.Spec, config)
+			var fields []string
+			for _, cause := range causes {
+				fields = append(fields, cause.Field)
+			}
+			Expect(fields).To(Equal(expectedFields))
+		},
+			table.Entry("and accept sidecars sharing cloud-init and configMap volumes", []v1.Sidecar{
+				{Name: "log-shipper", Image: "fake-image", VolumeMounts: []v1.SidecarVolumeMount{{Name: "cloudinit", MountPath: "/cloud-init"}}},
+				{Name: "license-daemon", Image: "fake-image", VolumeMounts: []v1.SidecarVolumeMount{{Name: "licenses", MountPath: "/licenses"}}},
+			}, nil),
+			table.Entry("and reject invalid and duplicate names", []v1.Sidecar{
+				{Name: "Log_Shipper", Image: "fake-image"},
+				{Name: "log-shipper", Image: "fake-image"},
+				{Name: "log-shipper", Image: "fake-image"},
+			}, []string{"fake.sidecars[0].name", "fake.sidecars[2].name"}),
+			table.Entry("and reject names reserved by KubeVirt", []v1.Sidecar{
+				{Name: "compute", Image: "fake-image"},
+				{Name: "hook-sidecar-0", Image: "fake-image"},
+				{Name: "volumecontainerdisk", Image: "fake-image"},
+			}, []string{"fake.sidecars[0].name", "fake.sidecars[1].name", "fake.sidecars[2].name"}),
+			table.Entry("and reject a missing image", []v1.Sidecar{
+				{Name: "log-shipper"},
+			}, []string{"fake.sidecars[0].image"}),
+			table.Entry("and reject unknown and not shareable volumes", []v1.Sidecar{
+				{Name: "log-shipper", Image: "fake-image", VolumeMounts: []v1.SidecarVolumeMount{
+					{Name: "unknown", MountPath: "/unknown"},
+					{Name: "containerdisk", MountPath: "/disk"},
+				}},
+			}, []string{"fake.sidecars[0].volumeMounts[0].name", "fake.sidecars[0].volumeMounts[1].name"}),
+			table.Entry("and reject relative mount paths", []v1.Sidecar{
+				{Name: "log-shipper", Image: "fake-image", VolumeMounts: []v1.SidecarVolumeMount{{Name: "licenses", MountPath: "licenses"}}},
+			}, []string{"fake.sidecars[0].volumeMounts[0].mountPath"}),
+		)
 		Context("with kernel boot defined", func() {
 
 			const (
@@ -2222,6 +3120,14 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 				table.Entry("with kernel args, with container that has initrd and kernel defined but without image - should reject",
 					fakeKernelArgs, fakeInitrd, fakeKernel, "", false, false),
 				table.Entry("with kernel args, with container that has nothing defined", "", "", "", "", false, false),
+				table.Entry("with a kernel path pointing outside of the image - should reject",
+					fakeKernelArgs, "", "/boot/../../etc/kernel", fakeImage, false, false),
+				table.Entry("with an initrd path pointing outside of the image - should reject",
+					fakeKernelArgs, "../initrd", fakeKernel, fakeImage, false, false),
+				table.Entry("with a kernel and initrd path having the same file name - should reject",
+					fakeKernelArgs, "/boot/initrd/image", "/boot/kernel/image", fakeImage, false, false),
+				table.Entry("with absolute kernel and initrd paths - should approve",
+					fakeKernelArgs, "/boot/initrd.img", "/boot/vmlinuz..old", fakeImage, false, true),
 			)
 		})
 	})
@@ -2619,7 +3525,7 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 
 				vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, disk)
 
-				causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+				causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks, virtconfig.DefaultMaxListLength)
 				Expect(len(causes)).To(Equal(0))
 
 			},
@@ -2634,6 +3540,29 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			),
 		)
 
+		table.DescribeTable("should validate discard and detectZeroes", func(disk v1.Disk, expectedFields []string) {
+			causes := validateDisks(k8sfield.NewPath("fake"), []v1.Disk{disk}, virtconfig.DefaultMaxListLength)
+			var fields []string
+			for _, cause := range causes {
+				fields = append(fields, cause.Field)
+			}
+			Expect(fields).To(Equal(expectedFields))
+		},
+			table.Entry("and accept discard with zero detection on a disk",
+				v1.Disk{Name: "testdisk", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{}}, Discard: v1.DiscardUnmap, DetectZeroes: v1.DetectZeroesUnmap}, nil),
+			table.Entry("and accept ignoring discards on a lun",
+				v1.Disk{Name: "testdisk", DiskDevice: v1.DiskDevice{LUN: &v1.LunTarget{}}, Discard: v1.DiscardIgnore, DetectZeroes: v1.DetectZeroesOn}, nil),
+			table.Entry("and reject unknown values",
+				v1.Disk{Name: "testdisk", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{}}, Discard: "trim", DetectZeroes: "always"},
+				[]string{"fake[0].discard", "fake[0].detectZeroes"}),
+			table.Entry("and reject zero detection with unmap when discards are ignored",
+				v1.Disk{Name: "testdisk", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{}}, Discard: v1.DiscardIgnore, DetectZeroes: v1.DetectZeroesUnmap},
+				[]string{"fake[0].detectZeroes"}),
+			table.Entry("and reject discard on a cdrom",
+				v1.Disk{Name: "testdisk", DiskDevice: v1.DiskDevice{CDRom: &v1.CDRomTarget{}}, Discard: v1.DiscardUnmap},
+				[]string{"fake[0]"}),
+		)
+
 		It("should reject floppy disks", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 
@@ -2643,7 +3572,7 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 					Floppy: &v1.FloppyTarget{},
 				},
 			})
-			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks, virtconfig.DefaultMaxListLength)
 			Expect(len(causes)).To(Equal(1))
 			Expect(causes[0].Field).To(Equal("fake[0].name"))
 		})
@@ -2662,7 +3591,7 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 				},
 			})
 
-			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks, virtconfig.DefaultMaxListLength)
 			Expect(len(causes)).To(Equal(0))
 		})
 
@@ -2681,7 +3610,7 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 					Disk: &v1.DiskTarget{},
 				},
 			})
-			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks, virtconfig.DefaultMaxListLength)
 			Expect(len(causes)).To(Equal(1))
 			Expect(causes[0].Field).To(Equal("fake[1].name"))
 		})
@@ -2697,9 +3626,9 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 						Bus:        "scsi"},
 				},
 			})
-			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks, virtconfig.DefaultMaxListLength)
 			Expect(len(causes)).To(Equal(1))
-			Expect(causes[0].Field).To(Equal("fake.domain.devices.disks.disk[0].pciAddress"))
+			Expect(causes[0].Field).To(Equal("fake[0].disk.pciAddress"))
 		})
 
 		It("should reject disks malformed PCI addresses ", func() {
@@ -2713,9 +3642,9 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 						Bus:        "virtio"},
 				},
 			})
-			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks, virtconfig.DefaultMaxListLength)
 			Expect(len(causes)).To(Equal(1))
-			Expect(causes[0].Field).To(Equal("fake.domain.devices.disks.disk[0].pciAddress"))
+			Expect(causes[0].Field).To(Equal("fake[0].disk.pciAddress"))
 		})
 
 		It("should reject disk with multiple targets ", func() {
@@ -2735,7 +3664,7 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 				},
 			})
 
-			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks, virtconfig.DefaultMaxListLength)
 			Expect(len(causes)).To(Equal(1))
 			Expect(causes[0].Field).To(Equal("fake[0]"))
 		})
@@ -2763,6 +3692,38 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(causes[0].Message).To(Equal("Bus type virtio is invalid for CD-ROM device"))
 		})
 
+		table.DescribeTable("should accept supported disk buses", func(diskDevice v1.DiskDevice) {
+			disks := []v1.Disk{{Name: "testdisk", DiskDevice: diskDevice}}
+			causes := validateDisks(k8sfield.NewPath("fake"), disks, virtconfig.DefaultMaxListLength)
+			Expect(causes).To(BeEmpty())
+		},
+			table.Entry("disk on virtio", v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "virtio"}}),
+			table.Entry("disk on sata", v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "sata"}}),
+			table.Entry("disk on scsi", v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "scsi"}}),
+			table.Entry("disk on usb", v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "usb"}}),
+			table.Entry("lun on scsi", v1.DiskDevice{LUN: &v1.LunTarget{Bus: "scsi"}}),
+			table.Entry("cdrom on sata", v1.DiskDevice{CDRom: &v1.CDRomTarget{Bus: "sata"}}),
+			table.Entry("cdrom on usb", v1.DiskDevice{CDRom: &v1.CDRomTarget{Bus: "usb"}}),
+			table.Entry("readonly cdrom", v1.DiskDevice{CDRom: &v1.CDRomTarget{Bus: "sata", ReadOnly: pointer.BoolPtr(true)}}),
+		)
+
+		table.DescribeTable("should reject unsupported disk bus and target combinations", func(diskDevice v1.DiskDevice, expectedField, expectedMessage string) {
+			disks := []v1.Disk{{Name: "testdisk", DiskDevice: diskDevice}}
+			causes := validateDisks(k8sfield.NewPath("fake"), disks, virtconfig.DefaultMaxListLength)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal(expectedField))
+			Expect(causes[0].Message).To(Equal(expectedMessage))
+		},
+			table.Entry("disk on ide", v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "ide"}},
+				"fake[0].disk.bus", "IDE bus is not supported"),
+			table.Entry("disk on an unknown bus", v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "fdc"}},
+				"fake[0].disk.bus", "fake[0] is set with an unrecognized bus fdc, must be one of: [virtio sata scsi usb]"),
+			table.Entry("lun on usb", v1.DiskDevice{LUN: &v1.LunTarget{Bus: "usb"}},
+				"fake[0].lun.bus", "Bus type usb is invalid for LUN device"),
+			table.Entry("writable cdrom", v1.DiskDevice{CDRom: &v1.CDRomTarget{Bus: "sata", ReadOnly: pointer.BoolPtr(false)}},
+				"fake[0].cdrom.readonly", "fake[0].cdrom.readonly must not be set to false for CD-ROM device"),
+		)
+
 		It("should accept a boot order greater than '0'", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 			order := uint(1)
@@ -2775,7 +3736,7 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 				},
 			})
 
-			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks, virtconfig.DefaultMaxListLength)
 			Expect(len(causes)).To(Equal(0))
 		})
 
@@ -2791,7 +3752,7 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 				},
 			})
 
-			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks, virtconfig.DefaultMaxListLength)
 			Expect(len(causes)).To(Equal(1))
 			Expect(causes[0].Field).To(Equal("fake[0].bootOrder"))
 		})
@@ -2830,7 +3791,7 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 				},
 			})
 
-			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks, virtconfig.DefaultMaxListLength)
 			Expect(len(causes)).To(Equal(0))
 		})
 
@@ -2854,7 +3815,7 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 				},
 			})
 
-			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks, virtconfig.DefaultMaxListLength)
 			Expect(len(causes)).To(Equal(2))
 			Expect(causes[0].Field).To(Equal("fake[0].disk.bus"))
 			Expect(causes[1].Field).To(Equal("fake[1].lun.bus"))
@@ -2872,7 +3833,7 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 				IO:   "unsupported",
 			})
 
-			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks, virtconfig.DefaultMaxListLength)
 			Expect(len(causes)).To(Equal(1))
 			Expect(causes[0].Field).To(Equal("fake.domain.devices.disks[1].io"))
 		})
@@ -2883,27 +3844,27 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 				Name: "testdisk", Cache: "unspported", DiskDevice: v1.DiskDevice{
 					Disk: &v1.DiskTarget{}}})
 
-			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks, virtconfig.DefaultMaxListLength)
 			Expect(len(causes)).To(Equal(1))
 			Expect(string(causes[0].Type)).To(Equal("FieldValueInvalid"))
 			Expect(causes[0].Field).To(Equal("fake[0].cache"))
 			Expect(causes[0].Message).To(Equal("fake[0].cache has invalid value unspported"))
 		})
 
-		It("should reject disk count > arrayLenMax", func() {
+		It("should reject disk count > the max list length", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
-			for i := 0; i <= arrayLenMax; i++ {
+			for i := 0; i <= virtconfig.DefaultMaxListLength; i++ {
 				name := strconv.Itoa(i)
 				vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
 					Name: "testdisk" + name, DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{}}})
 			}
 
-			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks, virtconfig.DefaultMaxListLength)
 			Expect(len(causes)).To(Equal(1))
 			Expect(string(causes[0].Type)).To(Equal("FieldValueInvalid"))
 			Expect(causes[0].Field).To(Equal("fake"))
 			Expect(causes[0].Message).To(Equal(fmt.Sprintf("fake list exceeds the %d "+
-				"element limit in length", arrayLenMax)))
+				"element limit in length", virtconfig.DefaultMaxListLength)))
 		})
 
 		It("should reject invalid SN characters", func() {
@@ -2920,7 +3881,7 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 				},
 			})
 
-			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks, virtconfig.DefaultMaxListLength)
 			Expect(len(causes)).To(Equal(1))
 			Expect(causes[0].Field).To(Equal("fake[0].serial"))
 		})
@@ -2939,7 +3900,7 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 				},
 			})
 
-			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks, virtconfig.DefaultMaxListLength)
 			Expect(len(causes)).To(Equal(1))
 			Expect(causes[0].Field).To(Equal("fake[0].serial"))
 		})
@@ -2957,7 +3918,7 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 				},
 			})
 
-			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks, virtconfig.DefaultMaxListLength)
 			Expect(len(causes)).To(Equal(0))
 		})
 
@@ -2990,7 +3951,7 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 				},
 			)
 
-			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks, virtconfig.DefaultMaxListLength)
 			Expect(len(causes)).To(Equal(1)) // Only first disk should fail
 			Expect(string(causes[0].Type)).To(Equal("FieldValueNotSupported"))
 			Expect(causes[0].Field).To(ContainSubstring("domain.devices.disks"))
@@ -3013,7 +3974,7 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 					},
 				})
 
-				causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+				causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks, virtconfig.DefaultMaxListLength)
 				Expect(len(causes)).To(Equal(0))
 			},
 				table.Entry("a 512n disk", 512, 512),
@@ -3035,7 +3996,7 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 					},
 				})
 
-				causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+				causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks, virtconfig.DefaultMaxListLength)
 				Expect(len(causes)).To(Equal(2))
 				Expect(causes[0].Field).To(Equal("fake[0].blockSize.custom.logical"))
 				Expect(causes[1].Field).To(Equal("fake[0].blockSize.custom.physical"))
@@ -3058,7 +4019,7 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 					},
 				})
 
-				causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+				causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks, virtconfig.DefaultMaxListLength)
 				Expect(len(causes)).To(Equal(1))
 				Expect(causes[0].Field).To(Equal("fake[0].blockSize.custom.logical"))
 			})
@@ -3076,7 +4037,7 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 					},
 				})
 
-				causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+				causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks, virtconfig.DefaultMaxListLength)
 				Expect(len(causes)).To(Equal(0))
 			})
 
@@ -3097,7 +4058,7 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 					},
 				})
 
-				causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+				causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks, virtconfig.DefaultMaxListLength)
 				Expect(len(causes)).To(Equal(1))
 				Expect(causes[0].Field).To(Equal("fake[0].blockSize"))
 			})
@@ -3119,12 +4080,109 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 					},
 				})
 
-				causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+				causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks, virtconfig.DefaultMaxListLength)
 				Expect(len(causes)).To(Equal(0))
 			})
 		})
 	})
 
+	Context("with namespaced feature gates", func() {
+		enableNamespacedFeatureGate := func(featureGate string, namespaces ...string) {
+			kvConfig := kv.DeepCopy()
+			kvConfig.Spec.Configuration.DeveloperConfiguration.NamespacedFeatureGates = []v1.NamespacedFeatureGate{
+				{Name: featureGate, Namespaces: namespaces},
+			}
+			testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kvConfig)
+		}
+
+		newDownwardMetricsVMIAdmissionReview := func(namespace string) *admissionv1.AdmissionReview {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
+				Name: "metrics",
+				DiskDevice: v1.DiskDevice{
+					Disk: &v1.DiskTarget{},
+				},
+			})
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name: "metrics",
+				VolumeSource: v1.VolumeSource{
+					DownwardMetrics: &v1.DownwardMetricsVolumeSource{},
+				},
+			})
+			vmiBytes, _ := json.Marshal(&vmi)
+			return &admissionv1.AdmissionReview{
+				Request: &admissionv1.AdmissionRequest{
+					Namespace: namespace,
+					Resource:  webhooks.VirtualMachineInstanceGroupVersionResource,
+					Object: runtime.RawExtension{
+						Raw: vmiBytes,
+					},
+				},
+			}
+		}
+
+		It("should accept VMIs using the feature in an allow-listed namespace", func() {
+			enableNamespacedFeatureGate(virtconfig.DownwardMetricsFeatureGate, "ns1", "ns2")
+
+			resp := vmiCreateAdmitter.Admit(newDownwardMetricsVMIAdmissionReview("ns2"))
+			Expect(resp.Allowed).To(BeTrue())
+		})
+
+		It("should reject VMIs using the feature in other namespaces", func() {
+			enableNamespacedFeatureGate(virtconfig.DownwardMetricsFeatureGate, "ns1")
+
+			resp := vmiCreateAdmitter.Admit(newDownwardMetricsVMIAdmissionReview("ns2"))
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.volumes[0].downwardMetrics"))
+			Expect(resp.Result.Details.Causes[0].Message).To(Equal("DownwardMetrics feature gate is not enabled for namespace ns2"))
+		})
+
+		It("should accept VMIs using the feature in any namespace if the gate is enabled cluster-wide", func() {
+			enableFeatureGate(virtconfig.DownwardMetricsFeatureGate)
+
+			resp := vmiCreateAdmitter.Admit(newDownwardMetricsVMIAdmissionReview("ns2"))
+			Expect(resp.Allowed).To(BeTrue())
+		})
+
+		table.DescribeTable("should reject features of gates enabled for other namespaces", func(featureGate string, spec *v1.VirtualMachineInstanceSpec, expectedField string) {
+			enableNamespacedFeatureGate(featureGate, "ns1")
+
+			Expect(validateNamespacedFeatureGates(k8sfield.NewPath("fake"), spec, "ns1", config)).To(BeEmpty())
+
+			causes := validateNamespacedFeatureGates(k8sfield.NewPath("fake"), spec, "ns2", config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal(expectedField))
+		},
+			table.Entry("with GPUs", virtconfig.GPUGate, &v1.VirtualMachineInstanceSpec{
+				Domain: v1.DomainSpec{Devices: v1.Devices{GPUs: []v1.GPU{{Name: "gpu1", DeviceName: "vendor.com/gpu"}}}},
+			}, "fake.domain.devices.gpus"),
+			table.Entry("with host devices", virtconfig.HostDevicesGate, &v1.VirtualMachineInstanceSpec{
+				Domain: v1.DomainSpec{Devices: v1.Devices{HostDevices: []v1.HostDevice{{Name: "dev1", DeviceName: "vendor.com/dev"}}}},
+			}, "fake.domain.devices.hostDevices"),
+			table.Entry("with filesystems", virtconfig.VirtIOFSGate, &v1.VirtualMachineInstanceSpec{
+				Domain: v1.DomainSpec{Devices: v1.Devices{Filesystems: []v1.Filesystem{{Name: "fs1", Virtiofs: &v1.FilesystemVirtiofs{}}}}},
+			}, "fake.domain.devices.filesystems"),
+			table.Entry("with a macvtap interface", virtconfig.MacvtapGate, &v1.VirtualMachineInstanceSpec{
+				Domain: v1.DomainSpec{Devices: v1.Devices{Interfaces: []v1.Interface{
+					*v1.DefaultBridgeNetworkInterface(),
+					{Name: "macvtap", InterfaceBindingMethod: v1.InterfaceBindingMethod{Macvtap: &v1.InterfaceMacvtap{}}},
+				}}},
+			}, "fake.domain.devices.interfaces[1].macvtap"),
+			table.Entry("with a mirrored interface", virtconfig.InterfaceMirroringGate, &v1.VirtualMachineInstanceSpec{
+				Domain: v1.DomainSpec{Devices: v1.Devices{Interfaces: []v1.Interface{
+					{Name: "default", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}, Mirror: &v1.InterfaceMirror{}},
+				}}},
+			}, "fake.domain.devices.interfaces[0].mirror"),
+			table.Entry("with a hostDisk volume", virtconfig.HostDiskGate, &v1.VirtualMachineInstanceSpec{
+				Volumes: []v1.Volume{{Name: "hostdisk", VolumeSource: v1.VolumeSource{HostDisk: &v1.HostDisk{Path: "/disk.img"}}}},
+			}, "fake.volumes[0].hostDisk"),
+			table.Entry("with a networkDisk volume", virtconfig.NetworkDisksGate, &v1.VirtualMachineInstanceSpec{
+				Volumes: []v1.Volume{{Name: "networkdisk", VolumeSource: v1.VolumeSource{NetworkDisk: &v1.NetworkDiskSource{}}}},
+			}, "fake.volumes[0].networkDisk"),
+		)
+	})
+
 	Context("with volume", func() {
 		It("should accept a single downwardmetrics volume", func() {
 			enableFeatureGate(virtconfig.DownwardMetricsFeatureGate)
@@ -3211,6 +4269,209 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(causes).To(BeEmpty())
 		})
 
+		It("should reject network disk volumes if the feature gate is not enabled", func() {
+			disableFeatureGates()
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name: "testNetworkDisk",
+				VolumeSource: v1.VolumeSource{
+					NetworkDisk: &v1.NetworkDiskSource{
+						Protocol: v1.NetworkDiskProtocolNBD,
+						Host:     v1.NetworkDiskHost{Name: "nbd.example.com"},
+					},
+				},
+			})
+
+			causes := validateVolumes(k8sfield.NewPath("fake"), vmi.Spec.Volumes, config)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("fake[0].networkDisk"))
+		})
+
+		table.DescribeTable("should validate network disk volumes", func(networkDisk *v1.NetworkDiskSource, expectedFields []string) {
+			enableFeatureGate(virtconfig.NetworkDisksGate)
+			defer disableFeatureGates()
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name: "testNetworkDisk",
+				VolumeSource: v1.VolumeSource{
+					NetworkDisk: networkDisk,
+				},
+			})
+
+			causes := validateVolumes(k8sfield.NewPath("fake"), vmi.Spec.Volumes, config)
+			var fields []string
+			for _, cause := range causes {
+				fields = append(fields, cause.Field)
+			}
+			Expect(fields).To(Equal(expectedFields))
+		},
+			table.Entry("and accept an iscsi disk with credentials", &v1.NetworkDiskSource{
+				Protocol: v1.NetworkDiskProtocolISCSI,
+				Name:     "iqn.2021-01.io.kubevirt:target/1",
+				Host:     v1.NetworkDiskHost{Name: "storage.example.com", Port: "3260"},
+				Auth:     &v1.NetworkDiskAuth{Username: "user", SecretRef: "chap-secret"},
+			}, nil),
+			table.Entry("and accept an nbd disk without name", &v1.NetworkDiskSource{
+				Protocol: v1.NetworkDiskProtocolNBD,
+				Host:     v1.NetworkDiskHost{Name: "nbd.example.com"},
+			}, nil),
+			table.Entry("and reject an unknown protocol", &v1.NetworkDiskSource{
+				Protocol: "sheepdog",
+				Host:     v1.NetworkDiskHost{Name: "storage.example.com"},
+			}, []string{"fake[0].networkDisk.protocol"}),
+			table.Entry("and reject an rbd disk without name", &v1.NetworkDiskSource{
+				Protocol: v1.NetworkDiskProtocolRBD,
+				Host:     v1.NetworkDiskHost{Name: "monitor.example.com"},
+			}, []string{"fake[0].networkDisk.name"}),
+			table.Entry("and reject credentials for nbd", &v1.NetworkDiskSource{
+				Protocol: v1.NetworkDiskProtocolNBD,
+				Host:     v1.NetworkDiskHost{Name: "nbd.example.com"},
+				Auth:     &v1.NetworkDiskAuth{Username: "user", SecretRef: "secret"},
+			}, []string{"fake[0].networkDisk.auth"}),
+			table.Entry("and reject a missing host and an invalid port", &v1.NetworkDiskSource{
+				Protocol: v1.NetworkDiskProtocolNBD,
+				Host:     v1.NetworkDiskHost{Port: "70000"},
+			}, []string{"fake[0].networkDisk.host.name", "fake[0].networkDisk.host.port"}),
+			table.Entry("and reject incomplete credentials", &v1.NetworkDiskSource{
+				Protocol: v1.NetworkDiskProtocolISCSI,
+				Name:     "iqn.2021-01.io.kubevirt:target/1",
+				Host:     v1.NetworkDiskHost{Name: "storage.example.com"},
+				Auth:     &v1.NetworkDiskAuth{},
+			}, []string{"fake[0].networkDisk.auth.username", "fake[0].networkDisk.auth.secretRef"}),
+		)
+
+		table.DescribeTable("should validate containerDisk image digests", func(containerDisk *v1.ContainerDiskSource, expectedFields []string) {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name: "testContainerDisk",
+				VolumeSource: v1.VolumeSource{
+					ContainerDisk: containerDisk,
+				},
+			})
+
+			causes := validateVolumes(k8sfield.NewPath("fake"), vmi.Spec.Volumes, config)
+			var fields []string
+			for _, cause := range causes {
+				fields = append(fields, cause.Field)
+			}
+			Expect(fields).To(Equal(expectedFields))
+		},
+			table.Entry("and accept an image referenced by tag without digest policy", &v1.ContainerDiskSource{
+				Image: "quay.io/kubevirt/fedora:33",
+			}, nil),
+			table.Entry("and accept a pinned image with the Require policy", &v1.ContainerDiskSource{
+				Image:             "quay.io/kubevirt/fedora@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+				ImageDigestPolicy: v1.ContainerDiskImageDigestPolicyRequire,
+			}, nil),
+			table.Entry("and reject an invalid digest", &v1.ContainerDiskSource{
+				Image: "quay.io/kubevirt/fedora@sha256:1234",
+			}, []string{"fake[0].containerDisk.image"}),
+			table.Entry("and reject an image referenced by tag with the Require policy", &v1.ContainerDiskSource{
+				Image:             "quay.io/kubevirt/fedora:33",
+				ImageDigestPolicy: v1.ContainerDiskImageDigestPolicyRequire,
+			}, []string{"fake[0].containerDisk.image"}),
+			table.Entry("and accept an image referenced by tag with the Pin policy", &v1.ContainerDiskSource{
+				Image:             "quay.io/kubevirt/fedora:33",
+				ImageDigestPolicy: v1.ContainerDiskImageDigestPolicyPin,
+			}, nil),
+			table.Entry("and reject an unknown digest policy", &v1.ContainerDiskSource{
+				Image:             "quay.io/kubevirt/fedora:33",
+				ImageDigestPolicy: "Latest",
+			}, []string{"fake[0].containerDisk.imageDigestPolicy"}),
+		)
+
+		table.DescribeTable("should require VMIs to reference images with the Pin policy by digest", func(image string, expectedFields []string) {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name: "testContainerDisk",
+				VolumeSource: v1.VolumeSource{
+					ContainerDisk: &v1.ContainerDiskSource{Image: image, ImageDigestPolicy: v1.ContainerDiskImageDigestPolicyPin},
+				},
+			})
+
+			causes := validatePinnedContainerDiskImages(k8sfield.NewPath("fake"), &vmi.Spec)
+			var fields []string
+			for _, cause := range causes {
+				fields = append(fields, cause.Field)
+			}
+			Expect(fields).To(Equal(expectedFields))
+		},
+			table.Entry("and accept a pinned image", "quay.io/kubevirt/fedora:33@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef", nil),
+			table.Entry("and reject an image referenced by tag", "quay.io/kubevirt/fedora:33", []string{"fake.volumes[0].containerDisk.image"}),
+		)
+
+		table.DescribeTable("should validate containerDisk images against the allowed registries", func(image string, expectedFields []string) {
+			kvConfig := kv.DeepCopy()
+			kvConfig.Spec.Configuration.ContainerDiskPolicy = &v1.ContainerDiskPolicy{
+				AllowedRegistries: []string{"quay.io/kubevirt", "registry:5000"},
+			}
+			testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kvConfig)
+			defer testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kv)
+
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name: "testContainerDisk",
+				VolumeSource: v1.VolumeSource{
+					ContainerDisk: &v1.ContainerDiskSource{Image: image},
+				},
+			})
+
+			causes := validateVolumes(k8sfield.NewPath("fake"), vmi.Spec.Volumes, config)
+			var fields []string
+			for _, cause := range causes {
+				fields = append(fields, cause.Field)
+			}
+			Expect(fields).To(Equal(expectedFields))
+		},
+			table.Entry("and accept an image of an allowed repository", "quay.io/kubevirt/fedora:33", nil),
+			table.Entry("and accept an image of an allowed registry", "registry:5000/fedora", nil),
+			table.Entry("and reject an image of another repository", "quay.io/kubevirtci/fedora:33", []string{"fake[0].containerDisk.image"}),
+			table.Entry("and reject a Docker Hub image", "fedora", []string{"fake[0].containerDisk.image"}),
+		)
+
+		table.DescribeTable("should validate the image format and preallocation of", func(volumeSource v1.VolumeSource, expectedFields []string) {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name:         "testdisk",
+				VolumeSource: volumeSource,
+			})
+
+			causes := validateVolumes(k8sfield.NewPath("fake"), vmi.Spec.Volumes, config)
+			var fields []string
+			for _, cause := range causes {
+				fields = append(fields, cause.Field)
+			}
+			Expect(fields).To(Equal(expectedFields))
+		},
+			table.Entry("an empty disk with defaults", v1.VolumeSource{
+				EmptyDisk: &v1.EmptyDiskSource{Capacity: resource.MustParse("1Gi")},
+			}, nil),
+			table.Entry("a fully preallocated raw empty disk", v1.VolumeSource{
+				EmptyDisk: &v1.EmptyDiskSource{Capacity: resource.MustParse("1Gi"), ImageFormat: v1.DiskImageFormatRaw, Preallocation: v1.DiskImagePreallocationFull},
+			}, nil),
+			table.Entry("a qcow2 ephemeral volume with metadata preallocation", v1.VolumeSource{
+				Ephemeral: &v1.EphemeralVolumeSource{ImageFormat: v1.DiskImageFormatQCOW2, Preallocation: v1.DiskImagePreallocationMetadata},
+			}, nil),
+			table.Entry("an empty disk with an unknown format and preallocation", v1.VolumeSource{
+				EmptyDisk: &v1.EmptyDiskSource{Capacity: resource.MustParse("1Gi"), ImageFormat: "vmdk", Preallocation: "lazy"},
+			}, []string{"fake[0].emptyDisk.imageFormat", "fake[0].emptyDisk.preallocation"}),
+			table.Entry("a raw ephemeral volume with metadata preallocation", v1.VolumeSource{
+				Ephemeral: &v1.EphemeralVolumeSource{ImageFormat: v1.DiskImageFormatRaw, Preallocation: v1.DiskImagePreallocationMetadata},
+			}, []string{"fake[0].ephemeral.preallocation"}),
+			table.Entry("a PVC with falloc preallocation", v1.VolumeSource{
+				PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+					PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "testclaim"},
+					Preallocation:                     v1.DiskImagePreallocationFalloc,
+				},
+			}, nil),
+			table.Entry("a PVC with metadata preallocation", v1.VolumeSource{
+				PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+					PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "testclaim"},
+					Preallocation:                     v1.DiskImagePreallocationMetadata,
+				},
+			}, []string{"fake[0].persistentVolumeClaim.preallocation"}),
+		)
+
 		It("should accept sysprep volumes", func() {
 			vmi := v1.NewMinimalVMI("fake-vmi")
 			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
@@ -3403,7 +4664,7 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 				},
 			})
 
-			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks)
+			causes := validateDisks(k8sfield.NewPath("fake"), vmi.Spec.Domain.Devices.Disks, virtconfig.DefaultMaxListLength)
 			Expect(len(causes)).To(Equal(1))
 		})
 	})
@@ -3513,8 +4774,354 @@ var _ = Describe("Validating VMICreate Admitter", func() {
 			Expect(len(causes)).To(Equal(1))
 		})
 	})
+
+	Context("with containerDisk signature verification", func() {
+		const signedImage = "quay.io/kubevirt/fedora@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+		var ctrl *gomock.Controller
+		var kubeClient *fake.Clientset
+		var verifier *fakeImageSignatureVerifier
+		var admitter *VMICreateAdmitter
+
+		newVMIWithContainerDisk := func(image string) *v1.VirtualMachineInstance {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{{Name: "containerdisk"}}
+			vmi.Spec.Volumes = []v1.Volume{{
+				Name: "containerdisk",
+				VolumeSource: v1.VolumeSource{
+					ContainerDisk: &v1.ContainerDiskSource{Image: image},
+				},
+			}}
+			return vmi
+		}
+
+		admit := func(vmi *v1.VirtualMachineInstance) *admissionv1.AdmissionResponse {
+			vmiBytes, err := json.Marshal(vmi)
+			Expect(err).ToNot(HaveOccurred())
+			return admitter.Admit(&admissionv1.AdmissionReview{
+				Request: &admissionv1.AdmissionRequest{
+					Resource: webhooks.VirtualMachineInstanceGroupVersionResource,
+					Object:   runtime.RawExtension{Raw: vmiBytes},
+				},
+			})
+		}
+
+		BeforeEach(func() {
+			kvConfig := kv.DeepCopy()
+			kvConfig.Spec.Configuration.ContainerDiskPolicy = &v1.ContainerDiskPolicy{
+				SignatureVerification: &v1.ContainerDiskSignatureVerification{PublicKeysConfigMap: "cosign-keys"},
+			}
+			testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kvConfig)
+
+			ctrl = gomock.NewController(GinkgoT())
+			virtClient := kubecli.NewMockKubevirtClient(ctrl)
+			kubeClient = fake.NewSimpleClientset()
+			virtClient.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+
+			verifier = &fakeImageSignatureVerifier{signed: map[string]bool{signedImage: true}}
+			admitter = &VMICreateAdmitter{ClusterConfig: config, VirtClient: virtClient, SignatureVerifier: verifier}
+		})
+
+		AfterEach(func() {
+			testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kv)
+			ctrl.Finish()
+		})
+
+		createPublicKeys := func() {
+			_, err := kubeClient.CoreV1().ConfigMaps("kubevirt").Create(context.Background(), &k8sv1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "cosign-keys", Namespace: "kubevirt"},
+				Data:       map[string]string{"cosign.pub": testPublicKey},
+			}, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		It("should accept VMIs with signed containerDisks", func() {
+			createPublicKeys()
+			resp := admit(newVMIWithContainerDisk(signedImage))
+			Expect(resp.Allowed).To(BeTrue())
+			Expect(verifier.keys).To(HaveLen(1))
+		})
+
+		It("should reject VMIs with unsigned containerDisks", func() {
+			createPublicKeys()
+			resp := admit(newVMIWithContainerDisk("quay.io/kubevirt/cirros@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"))
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.volumes[0].containerDisk.image"))
+		})
+
+		It("should reject VMIs with containerDisks if the public keys are missing", func() {
+			resp := admit(newVMIWithContainerDisk(signedImage))
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).To(ContainSubstring("failed to get the public keys"))
+		})
+
+		It("should not look up the public keys for VMIs without containerDisks", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			resp := admit(vmi)
+			Expect(resp.Allowed).To(BeTrue())
+			Expect(verifier.keys).To(BeNil())
+		})
+	})
+
+	Context("with sidecars mounting PersistentVolumeClaims", func() {
+		var ctrl *gomock.Controller
+		var kubeClient *fake.Clientset
+		var virtClient *kubecli.MockKubevirtClient
+
+		BeforeEach(func() {
+			ctrl = gomock.NewController(GinkgoT())
+			virtClient = kubecli.NewMockKubevirtClient(ctrl)
+			kubeClient = fake.NewSimpleClientset()
+			virtClient.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+		})
+
+		AfterEach(func() {
+			ctrl.Finish()
+		})
+
+		newVMIWithSidecarMount := func() *v1.VirtualMachineInstance {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Volumes = []v1.Volume{{
+				Name: "data",
+				VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+						PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "data-claim"},
+					},
+				},
+			}}
+			vmi.Spec.Sidecars = []v1.Sidecar{{
+				Name:         "log-shipper",
+				Image:        "fake-image",
+				VolumeMounts: []v1.SidecarVolumeMount{{Name: "data", MountPath: "/data"}},
+			}}
+			return vmi
+		}
+
+		createClaim := func(volumeMode k8sv1.PersistentVolumeMode) {
+			_, err := kubeClient.CoreV1().PersistentVolumeClaims(k8sv1.NamespaceDefault).Create(context.Background(), &k8sv1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "data-claim", Namespace: k8sv1.NamespaceDefault},
+				Spec:       k8sv1.PersistentVolumeClaimSpec{VolumeMode: &volumeMode},
+			}, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		It("should reject mounts of claims in block mode", func() {
+			createClaim(k8sv1.PersistentVolumeBlock)
+			vmi := newVMIWithSidecarMount()
+			causes, err := validateSidecarVolumeModes(k8sfield.NewPath("spec"), &vmi.Spec, k8sv1.NamespaceDefault, virtClient)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("spec.sidecars[0].volumeMounts[0].name"))
+		})
+
+		It("should accept mounts of claims in filesystem mode", func() {
+			createClaim(k8sv1.PersistentVolumeFilesystem)
+			vmi := newVMIWithSidecarMount()
+			causes, err := validateSidecarVolumeModes(k8sfield.NewPath("spec"), &vmi.Spec, k8sv1.NamespaceDefault, virtClient)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(causes).To(BeEmpty())
+		})
+
+		It("should accept mounts of claims which don't exist yet", func() {
+			vmi := newVMIWithSidecarMount()
+			causes, err := validateSidecarVolumeModes(k8sfield.NewPath("spec"), &vmi.Spec, k8sv1.NamespaceDefault, virtClient)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(causes).To(BeEmpty())
+		})
+	})
+
+	Context("with containerDisk architectures", func() {
+		var resolver *fakeImageArchitectureResolver
+
+		newVMIWithContainerDisk := func(image string, architecture string) *v1.VirtualMachineInstance {
+			vmi := v1.NewMinimalVMI("testvmi")
+			if architecture != "" {
+				vmi.Spec.NodeSelector = map[string]string{k8sv1.LabelArchStable: architecture}
+			}
+			vmi.Spec.Volumes = []v1.Volume{{
+				Name: "containerdisk",
+				VolumeSource: v1.VolumeSource{
+					ContainerDisk: &v1.ContainerDiskSource{Image: image},
+				},
+			}}
+			return vmi
+		}
+
+		BeforeEach(func() {
+			resolver = &fakeImageArchitectureResolver{architectures: map[string][]string{
+				"quay.io/kubevirt/fedora":  {"amd64", "arm64"},
+				"quay.io/kubevirt/windows": {"amd64"},
+			}}
+		})
+
+		It("should accept images available for the selected architecture", func() {
+			vmi := newVMIWithContainerDisk("quay.io/kubevirt/fedora", "arm64")
+			Expect(validateContainerDiskArchitectures(k8sfield.NewPath("spec"), &vmi.Spec, resolver)).To(BeEmpty())
+		})
+
+		It("should reject images not available for the selected architecture", func() {
+			vmi := newVMIWithContainerDisk("quay.io/kubevirt/windows", "arm64")
+			causes := validateContainerDiskArchitectures(k8sfield.NewPath("spec"), &vmi.Spec, resolver)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("spec.volumes[0].containerDisk.image"))
+			Expect(causes[0].Message).To(Equal("spec.volumes[0].containerDisk.image is not available for architecture arm64 selected by the node selector or node affinity, available are: [amd64]"))
+		})
+
+		It("should reject images not available for the architecture selected by node affinity", func() {
+			vmi := newVMIWithContainerDisk("quay.io/kubevirt/windows", "")
+			vmi.Spec.Affinity = newArchitectureAffinity("arm64")
+			causes := validateContainerDiskArchitectures(k8sfield.NewPath("spec"), &vmi.Spec, resolver)
+			Expect(causes).To(HaveLen(1))
+			Expect(causes[0].Field).To(Equal("spec.volumes[0].containerDisk.image"))
+		})
+
+		It("should not look up images if the VMI selects no architecture", func() {
+			vmi := newVMIWithContainerDisk("quay.io/kubevirt/windows", "")
+			Expect(validateContainerDiskArchitectures(k8sfield.NewPath("spec"), &vmi.Spec, resolver)).To(BeEmpty())
+			Expect(resolver.lookups).To(BeZero())
+		})
+
+		It("should accept images whose architectures can't be looked up", func() {
+			vmi := newVMIWithContainerDisk("registry.example.com/private/fedora", "arm64")
+			Expect(validateContainerDiskArchitectures(k8sfield.NewPath("spec"), &vmi.Spec, resolver)).To(BeEmpty())
+			Expect(resolver.lookups).To(Equal(1))
+		})
+	})
+
+	Context("with ValidationPolicies", func() {
+		var policyStore cache.Store
+
+		maxCPUs := func(count uint32) *uint32 {
+			return &count
+		}
+
+		addPolicy := func(name string, spec v1.ValidationPolicySpec) {
+			Expect(policyStore.Add(&v1.ValidationPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec:       spec,
+			})).To(Succeed())
+		}
+
+		newVMI := func() *v1.VirtualMachineInstance {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Labels = map[string]string{"team": "virt"}
+			vmi.Spec.Domain.CPU = &v1.CPU{Sockets: 2, Cores: 2}
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+				{Name: "containerdisk", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "virtio"}}},
+				{Name: "cloudinit", DiskDevice: v1.DiskDevice{CDRom: &v1.CDRomTarget{Bus: "sata"}}},
+			}
+			vmi.Spec.Volumes = []v1.Volume{
+				{Name: "containerdisk", VolumeSource: v1.VolumeSource{ContainerDisk: &v1.ContainerDiskSource{Image: "quay.io/kubevirt/cirros"}}},
+				{Name: "cloudinit", VolumeSource: v1.VolumeSource{CloudInitNoCloud: &v1.CloudInitNoCloudSource{UserData: "#cloud-config"}}},
+			}
+			return vmi
+		}
+
+		admit := func(vmi *v1.VirtualMachineInstance) *admissionv1.AdmissionResponse {
+			vmiBytes, err := json.Marshal(vmi)
+			Expect(err).ToNot(HaveOccurred())
+			return vmiCreateAdmitter.Admit(&admissionv1.AdmissionReview{
+				Request: &admissionv1.AdmissionRequest{
+					Resource: webhooks.VirtualMachineInstanceGroupVersionResource,
+					Object:   runtime.RawExtension{Raw: vmiBytes},
+				},
+			})
+		}
+
+		BeforeEach(func() {
+			policyStore = webhooks.GetInformers().ValidationPolicyInformer.GetStore()
+		})
+
+		AfterEach(func() {
+			for _, obj := range policyStore.List() {
+				Expect(policyStore.Delete(obj)).To(Succeed())
+			}
+		})
+
+		It("should accept VMIs satisfying all policies", func() {
+			addPolicy("cpus", v1.ValidationPolicySpec{MaxCPUs: maxCPUs(4)})
+			addPolicy("devices", v1.ValidationPolicySpec{
+				ForbiddenDiskBuses:             []string{"usb"},
+				RequiredLabels:                 []string{"team"},
+				AllowedContainerDiskRegistries: []string{"quay.io/kubevirt"},
+			})
+			resp := admit(newVMI())
+			Expect(resp.Allowed).To(BeTrue())
+		})
+
+		table.DescribeTable("should reject VMIs violating a policy", func(spec v1.ValidationPolicySpec, field string) {
+			addPolicy("policy", spec)
+			resp := admit(newVMI())
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal(field))
+			Expect(resp.Result.Details.Causes[0].Message).To(ContainSubstring("ValidationPolicy policy"))
+		},
+			table.Entry("with too many vCPUs", v1.ValidationPolicySpec{MaxCPUs: maxCPUs(2)}, "spec.domain.cpu"),
+			table.Entry("with a forbidden disk bus", v1.ValidationPolicySpec{ForbiddenDiskBuses: []string{"virtio"}}, "spec.domain.devices.disks[0].disk.bus"),
+			table.Entry("with a forbidden cdrom bus", v1.ValidationPolicySpec{ForbiddenDiskBuses: []string{"sata"}}, "spec.domain.devices.disks[1].cdrom.bus"),
+			table.Entry("with a missing label", v1.ValidationPolicySpec{RequiredLabels: []string{"team", "owner"}}, "metadata.labels"),
+			table.Entry("with a containerDisk from another registry", v1.ValidationPolicySpec{AllowedContainerDiskRegistries: []string{"registry.example.com"}}, "spec.volumes[0].containerDisk.image"),
+		)
+
+		It("should report the violations of every policy", func() {
+			addPolicy("cpus", v1.ValidationPolicySpec{MaxCPUs: maxCPUs(1)})
+			addPolicy("labels", v1.ValidationPolicySpec{RequiredLabels: []string{"owner"}})
+			resp := admit(newVMI())
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(2))
+		})
+
+		It("should not evaluate policies for VMIs failing the built-in checks", func() {
+			addPolicy("labels", v1.ValidationPolicySpec{RequiredLabels: []string{"owner"}})
+			vmi := newVMI()
+			vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{Name: "testdisk"})
+			resp := admit(vmi)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.domain.devices.disks[2].name"))
+		})
+	})
 })
 
+type fakeImageArchitectureResolver struct {
+	architectures map[string][]string
+	lookups       int
+}
+
+func (r *fakeImageArchitectureResolver) ResolveImageArchitectures(ctx context.Context, image string) ([]string, error) {
+	r.lookups++
+	if deadline, ok := ctx.Deadline(); !ok || time.Until(deadline) > containerDiskArchitectureLookupTimeout {
+		return nil, fmt.Errorf("the lookup of image %s is not bounded by the deadline of the admission review", image)
+	}
+	architectures, exists := r.architectures[image]
+	if !exists {
+		return nil, fmt.Errorf("failed to look up the manifest of image %s: registry returned 401", image)
+	}
+	return architectures, nil
+}
+
+// testPublicKey is a P-256 public key in the format "cosign generate-key-pair" writes
+const testPublicKey = `-----BEGIN PUBLIC KEY-----
+MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAEl95A/xLXkcYK2PkS3YCPKZigSAvB
+r2KOjfxpvARIcyAnGXxmgANCtXZrFB24lDaSfcVyvx4UZmlk+PSRPW7Bxw==
+-----END PUBLIC KEY-----
+`
+
+type fakeImageSignatureVerifier struct {
+	signed map[string]bool
+	keys   []crypto.PublicKey
+}
+
+func (v *fakeImageSignatureVerifier) VerifyImageSignature(image string, keys []crypto.PublicKey) error {
+	v.keys = keys
+	if !v.signed[image] {
+		return fmt.Errorf("image %s is not signed", image)
+	}
+	return nil
+}
+
 var _ = Describe("Function getNumberOfPodInterfaces()", func() {
 	config, _, _, _ := testutils.NewFakeClusterConfig(&k8sv1.ConfigMap{})
 
@@ -3631,7 +5238,8 @@ var _ = Describe("Function getNumberOfPodInterfaces()", func() {
 		spec.Domain.Devices.Interfaces = []v1.Interface{iface}
 		causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), spec, config)
 		Expect(causes).To(HaveLen(1))
-		Expect(causes[0].Field).To(Equal("fake[0].bootOrder"))
+		Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[0].bootOrder"))
+		Expect(causes[0].Message).To(Equal("fake.domain.devices.interfaces[0] must have a boot order > 0, if supplied"))
 	})
 	It("should work when different boot orders are given to devices", func() {
 		spec := &v1.VirtualMachineInstanceSpec{}
@@ -3698,7 +5306,24 @@ var _ = Describe("Function getNumberOfPodInterfaces()", func() {
 
 		causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), spec, config)
 		Expect(causes).To(HaveLen(1))
-		Expect(causes[0].Field).To(ContainSubstring("bootOrder"))
+		Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[0].bootOrder"))
+		Expect(causes[0].Message).To(Equal("Boot order for fake.domain.devices.interfaces[0].bootOrder already set for a different device."))
+	})
+	It("should fail when same boot order is given to more than one interface", func() {
+		spec := &v1.VirtualMachineInstanceSpec{}
+		order := uint(3)
+		spec.Networks = []v1.Network{
+			*v1.DefaultPodNetwork(),
+			{Name: "red", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "red"}}},
+		}
+		spec.Domain.Devices.Interfaces = []v1.Interface{
+			{Name: "default", BootOrder: &order, InterfaceBindingMethod: v1.InterfaceBindingMethod{Masquerade: &v1.InterfaceMasquerade{}}},
+			{Name: "red", BootOrder: &order, InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}},
+		}
+
+		causes := ValidateVirtualMachineInstanceSpec(k8sfield.NewPath("fake"), spec, config)
+		Expect(causes).To(HaveLen(1))
+		Expect(causes[0].Field).To(Equal("fake.domain.devices.interfaces[1].bootOrder"))
 	})
 	It("should reject a serial number whose length is greater than 256", func() {
 		spec := &v1.VirtualMachineInstanceSpec{}
@@ -3933,3 +5558,25 @@ var _ = Describe("Function getNumberOfPodInterfaces()", func() {
 	})
 
 })
+
+func uintPtr(u uint) *uint {
+	return &u
+}
+
+// newArchitectureAffinity returns a node affinity with one required term per architecture
+func newArchitectureAffinity(architectures ...string) *k8sv1.Affinity {
+	affinity := &k8sv1.Affinity{NodeAffinity: &k8sv1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &k8sv1.NodeSelector{},
+	}}
+	for _, architecture := range architectures {
+		affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms = append(
+			affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms,
+			k8sv1.NodeSelectorTerm{MatchExpressions: []k8sv1.NodeSelectorRequirement{{
+				Key:      k8sv1.LabelArchStable,
+				Operator: k8sv1.NodeSelectorOpIn,
+				Values:   []string{architecture},
+			}}},
+		)
+	}
+	return affinity
+}
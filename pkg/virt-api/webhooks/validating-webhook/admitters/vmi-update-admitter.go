@@ -53,10 +53,18 @@ func (admitter *VMIUpdateAdmitter) Admit(ar *admissionv1.AdmissionReview) *admis
 	if !reflect.DeepEqual(newVMI.Spec, oldVMI.Spec) {
 		// Only allow the KubeVirt SA to modify the VMI spec, since that means it went through the sub resource.
 		if webhooks.IsKubeVirtServiceAccount(ar.Request.UserInfo.Username) {
+			if causes := validateImmutableFieldsUpdate(k8sfield.NewPath("spec"), &newVMI.Spec, &oldVMI.Spec); len(causes) > 0 {
+				return webhookutils.ToAdmissionResponse(causes)
+			}
 			hotplugResponse := admitHotplug(newVMI.Spec.Volumes, oldVMI.Spec.Volumes, newVMI.Spec.Domain.Devices.Disks, oldVMI.Spec.Domain.Devices.Disks, oldVMI.Status.VolumeStatus, newVMI, admitter.ClusterConfig)
 			if hotplugResponse != nil {
 				return hotplugResponse
 			}
+		} else if onlyBandwidthLimitsChanged(&newVMI.Spec, &oldVMI.Spec) {
+			// virt-launcher applies the bandwidth limits to the running domain, users may change them directly
+			if causes := validateBandwidthLimitsUpdate(k8sfield.NewPath("spec"), &newVMI.Spec); len(causes) > 0 {
+				return webhookutils.ToAdmissionResponse(causes)
+			}
 		} else {
 			return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
 				{
@@ -76,13 +84,71 @@ func (admitter *VMIUpdateAdmitter) Admit(ar *admissionv1.AdmissionReview) *admis
 	return &reviewResponse
 }
 
+// validateImmutableFieldsUpdate rejects changes of the runtime fields which can't change while the VMI exists,
+// not even through the subresources. The disks are verified by admitHotplug.
+func validateImmutableFieldsUpdate(field *k8sfield.Path, newSpec, oldSpec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
+	newDevices := newSpec.Domain.Devices.DeepCopy()
+	oldDevices := oldSpec.Domain.Devices.DeepCopy()
+	newDevices.Disks = nil
+	oldDevices.Disks = nil
+	clearBandwidthLimits(newDevices.Interfaces)
+	clearBandwidthLimits(oldDevices.Interfaces)
+	if !reflect.DeepEqual(newDevices, oldDevices) {
+		causes = append(causes, immutableFieldCause(field.Child("domain", "devices")))
+	}
+	if !reflect.DeepEqual(newSpec.Networks, oldSpec.Networks) {
+		causes = append(causes, immutableFieldCause(field.Child("networks")))
+	}
+	if !reflect.DeepEqual(newSpec.Domain.Firmware, oldSpec.Domain.Firmware) {
+		causes = append(causes, immutableFieldCause(field.Child("domain", "firmware")))
+	}
+	return causes
+}
+
+// onlyBandwidthLimitsChanged tells if the specs differ in nothing but the bandwidth limits of their interfaces
+func onlyBandwidthLimitsChanged(newSpec, oldSpec *v1.VirtualMachineInstanceSpec) bool {
+	newSpec = newSpec.DeepCopy()
+	oldSpec = oldSpec.DeepCopy()
+	clearBandwidthLimits(newSpec.Domain.Devices.Interfaces)
+	clearBandwidthLimits(oldSpec.Domain.Devices.Interfaces)
+	return reflect.DeepEqual(newSpec, oldSpec)
+}
+
+func clearBandwidthLimits(interfaces []v1.Interface) {
+	for i := range interfaces {
+		interfaces[i].BandwidthLimit = nil
+	}
+}
+
+func validateBandwidthLimitsUpdate(field *k8sfield.Path, spec *v1.VirtualMachineInstanceSpec) (causes []metav1.StatusCause) {
+	for idx, iface := range spec.Domain.Devices.Interfaces {
+		causes = append(causes, validateInterfaceBandwidthLimit(field, iface, idx)...)
+	}
+	return causes
+}
+
+func immutableFieldCause(field *k8sfield.Path) metav1.StatusCause {
+	return metav1.StatusCause{
+		Type:    metav1.CauseTypeFieldValueNotSupported,
+		Message: fmt.Sprintf("%s is immutable after the VMI was created", field.String()),
+		Field:   field.String(),
+	}
+}
+
 // admitHotplug compares the old and new volumes and disks, and ensures that they match and are valid.
 func admitHotplug(newVolumes, oldVolumes []v1.Volume, newDisks, oldDisks []v1.Disk, volumeStatuses []v1.VolumeStatus, newVMI *v1.VirtualMachineInstance, config *virtconfig.ClusterConfig) *admissionv1.AdmissionResponse {
-	if len(newVolumes) != len(newDisks) {
+	// memory dump volumes are only mounted into the virt-launcher pod, they have no disk
+	diskVolumeCount := 0
+	for _, volume := range newVolumes {
+		if volume.MemoryDump == nil {
+			diskVolumeCount++
+		}
+	}
+	if diskVolumeCount != len(newDisks) {
 		return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
 			{
 				Type:    metav1.CauseTypeFieldValueInvalid,
-				Message: fmt.Sprintf("number of disks (%d) does not equal the number of volumes (%d)", len(newDisks), len(newVolumes)),
+				Message: fmt.Sprintf("number of disks (%d) does not equal the number of volumes (%d)", len(newDisks), diskVolumeCount),
 			},
 		})
 	}
@@ -124,6 +190,9 @@ func verifyHotplugVolumes(newHotplugVolumeMap, oldHotplugVolumeMap map[string]v1
 					},
 				})
 			}
+			if v.MemoryDump != nil {
+				continue
+			}
 			if _, ok := newDisks[k]; !ok {
 				return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
 					{
@@ -141,8 +210,8 @@ func verifyHotplugVolumes(newHotplugVolumeMap, oldHotplugVolumeMap map[string]v1
 				})
 			}
 		} else {
-			// This is a new volume, ensure that the volume is either DV or PVC
-			if v.DataVolume == nil && v.PersistentVolumeClaim == nil {
+			// This is a new volume, ensure that the volume is either DV, PVC or memory dump
+			if v.DataVolume == nil && v.PersistentVolumeClaim == nil && v.MemoryDump == nil {
 				return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
 					{
 						Type:    metav1.CauseTypeFieldValueInvalid,
@@ -150,6 +219,17 @@ func verifyHotplugVolumes(newHotplugVolumeMap, oldHotplugVolumeMap map[string]v1
 					},
 				})
 			}
+			if v.MemoryDump != nil {
+				if _, ok := newDisks[k]; ok {
+					return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
+						{
+							Type:    metav1.CauseTypeFieldValueInvalid,
+							Message: fmt.Sprintf("memory dump volume %s must not have a disk", k),
+						},
+					})
+				}
+				continue
+			}
 			// Also ensure the matching new disk exists and is of type scsi
 			if _, ok := newDisks[k]; !ok {
 				return webhookutils.ToAdmissionResponse([]metav1.StatusCause{
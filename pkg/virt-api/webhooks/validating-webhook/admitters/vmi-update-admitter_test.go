@@ -30,6 +30,7 @@ import (
 	"github.com/onsi/gomega/types"
 	admissionv1 "k8s.io/api/admission/v1"
 	authv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
@@ -518,4 +519,116 @@ var _ = Describe("Validating VMIUpdate Admitter", func() {
 		table.Entry("Should admit internal sa", "system:serviceaccount:kubevirt:"+rbac.ApiServiceAccountName, BeTrue()),
 		table.Entry("Should reject regular user", "system:serviceaccount:someNamespace:someUser", BeFalse()),
 	)
+
+	table.DescribeTable("should reject changes of immutable fields by internal sa", func(update func(vmi *v1.VirtualMachineInstance), field string) {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+		vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+		vmi.Spec.Domain.Firmware = &v1.Firmware{Serial: "serial"}
+		updateVmi := vmi.DeepCopy()
+		update(updateVmi)
+
+		newVMIBytes, _ := json.Marshal(&updateVmi)
+		oldVMIBytes, _ := json.Marshal(&vmi)
+		ar := &admissionv1.AdmissionReview{
+			Request: &admissionv1.AdmissionRequest{
+				UserInfo: authv1.UserInfo{Username: "system:serviceaccount:kubevirt:" + rbac.ApiServiceAccountName},
+				Resource: webhooks.VirtualMachineInstanceGroupVersionResource,
+				Object: runtime.RawExtension{
+					Raw: newVMIBytes,
+				},
+				OldObject: runtime.RawExtension{
+					Raw: oldVMIBytes,
+				},
+				Operation: admissionv1.Update,
+			},
+		}
+		resp := vmiUpdateAdmitter.Admit(ar)
+		Expect(resp.Allowed).To(BeFalse())
+		Expect(resp.Result.Details.Causes).To(HaveLen(1))
+		Expect(resp.Result.Details.Causes[0].Field).To(Equal(field))
+	},
+		table.Entry("when an interface changes", func(vmi *v1.VirtualMachineInstance) {
+			vmi.Spec.Domain.Devices.Interfaces[0].MacAddress = "02:00:00:00:00:01"
+		}, "spec.domain.devices"),
+		table.Entry("when a device is added", func(vmi *v1.VirtualMachineInstance) {
+			vmi.Spec.Domain.Devices.Watchdog = &v1.Watchdog{Name: "watchdog"}
+		}, "spec.domain.devices"),
+		table.Entry("when a network changes", func(vmi *v1.VirtualMachineInstance) {
+			vmi.Spec.Networks[0].Name = "other"
+		}, "spec.networks"),
+		table.Entry("when the firmware changes", func(vmi *v1.VirtualMachineInstance) {
+			vmi.Spec.Domain.Firmware.Serial = "other"
+		}, "spec.domain.firmware"),
+	)
+
+	table.DescribeTable("should validate bandwidth limit changes of any user", func(update func(vmi *v1.VirtualMachineInstance), expected types.GomegaMatcher, message string) {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+		vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+		updateVmi := vmi.DeepCopy()
+		update(updateVmi)
+
+		newVMIBytes, _ := json.Marshal(&updateVmi)
+		oldVMIBytes, _ := json.Marshal(&vmi)
+		ar := &admissionv1.AdmissionReview{
+			Request: &admissionv1.AdmissionRequest{
+				UserInfo: authv1.UserInfo{Username: "system:serviceaccount:someNamespace:someUser"},
+				Resource: webhooks.VirtualMachineInstanceGroupVersionResource,
+				Object: runtime.RawExtension{
+					Raw: newVMIBytes,
+				},
+				OldObject: runtime.RawExtension{
+					Raw: oldVMIBytes,
+				},
+				Operation: admissionv1.Update,
+			},
+		}
+		resp := vmiUpdateAdmitter.Admit(ar)
+		Expect(resp.Allowed).To(expected)
+		if message != "" {
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Message).To(Equal(message))
+		}
+	},
+		table.Entry("and allow a new limit", func(vmi *v1.VirtualMachineInstance) {
+			inbound := resource.MustParse("100M")
+			vmi.Spec.Domain.Devices.Interfaces[0].BandwidthLimit = &v1.InterfaceBandwidthLimit{Inbound: &inbound}
+		}, BeTrue(), ""),
+		table.Entry("and reject a rate which is not positive", func(vmi *v1.VirtualMachineInstance) {
+			outbound := resource.MustParse("0")
+			vmi.Spec.Domain.Devices.Interfaces[0].BandwidthLimit = &v1.InterfaceBandwidthLimit{Outbound: &outbound}
+		}, BeFalse(), "spec.domain.devices.interfaces[0].bandwidthLimit.outbound '0': must be greater than 0."),
+		table.Entry("and reject other changes along with the limit", func(vmi *v1.VirtualMachineInstance) {
+			inbound := resource.MustParse("100M")
+			vmi.Spec.Domain.Devices.Interfaces[0].BandwidthLimit = &v1.InterfaceBandwidthLimit{Inbound: &inbound}
+			vmi.Spec.Domain.Devices.Interfaces[0].MacAddress = "02:00:00:00:00:01"
+		}, BeFalse(), "update of VMI object is restricted"),
+	)
+
+	It("should allow metadata and status changes", func() {
+		vmi := v1.NewMinimalVMI("testvmi")
+		updateVmi := vmi.DeepCopy()
+		updateVmi.Annotations = map[string]string{"test": "annotation"}
+		updateVmi.Labels = map[string]string{"test": "label"}
+		updateVmi.Status.Phase = v1.Running
+
+		newVMIBytes, _ := json.Marshal(&updateVmi)
+		oldVMIBytes, _ := json.Marshal(&vmi)
+		ar := &admissionv1.AdmissionReview{
+			Request: &admissionv1.AdmissionRequest{
+				UserInfo: authv1.UserInfo{Username: "system:serviceaccount:someNamespace:someUser"},
+				Resource: webhooks.VirtualMachineInstanceGroupVersionResource,
+				Object: runtime.RawExtension{
+					Raw: newVMIBytes,
+				},
+				OldObject: runtime.RawExtension{
+					Raw: oldVMIBytes,
+				},
+				Operation: admissionv1.Update,
+			},
+		}
+		resp := vmiUpdateAdmitter.Admit(ar)
+		Expect(resp.Allowed).To(BeTrue())
+	})
 })
@@ -0,0 +1,159 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package admitters
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	containerdisk "kubevirt.io/kubevirt/pkg/container-disk"
+	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+// VMImageExportAdmitter validates VirtualMachineImageExports
+type VMImageExportAdmitter struct {
+	Config *virtconfig.ClusterConfig
+	Client kubecli.KubevirtClient
+}
+
+// NewVMImageExportAdmitter creates a VMImageExportAdmitter
+func NewVMImageExportAdmitter(config *virtconfig.ClusterConfig, client kubecli.KubevirtClient) *VMImageExportAdmitter {
+	return &VMImageExportAdmitter{
+		Config: config,
+		Client: client,
+	}
+}
+
+// Admit validates an AdmissionReview
+func (admitter *VMImageExportAdmitter) Admit(ar *admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
+	if ar.Request.Resource.Group != v1.GroupName ||
+		ar.Request.Resource.Resource != "virtualmachineimageexports" {
+		return webhookutils.ToAdmissionResponseError(fmt.Errorf("unexpected resource %+v", ar.Request.Resource))
+	}
+
+	if ar.Request.Operation == admissionv1.Create && !admitter.Config.ImageExportEnabled() {
+		return webhookutils.ToAdmissionResponseError(fmt.Errorf("image export feature gate not enabled"))
+	}
+
+	export := &v1.VirtualMachineImageExport{}
+	err := json.Unmarshal(ar.Request.Object.Raw, export)
+	if err != nil {
+		return webhookutils.ToAdmissionResponseError(err)
+	}
+
+	var causes []metav1.StatusCause
+
+	switch ar.Request.Operation {
+	case admissionv1.Create:
+		specField := k8sfield.NewPath("spec")
+
+		if export.Spec.Source == "" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: "source is required",
+				Field:   specField.Child("source").String(),
+			})
+		} else {
+			_, err := admitter.Client.VirtualMachine(ar.Request.Namespace).Get(export.Spec.Source, &metav1.GetOptions{})
+			if errors.IsNotFound(err) {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("VirtualMachine %q does not exist", export.Spec.Source),
+					Field:   specField.Child("source").String(),
+				})
+			} else if err != nil {
+				return webhookutils.ToAdmissionResponseError(err)
+			}
+		}
+
+		causes = append(causes, validateExportImage(specField.Child("image"), export.Spec.Image)...)
+		if export.Spec.BaseImage != "" {
+			if err := containerdisk.ValidateImageReference(export.Spec.BaseImage); err != nil {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: fmt.Sprintf("invalid baseImage %q: %v", export.Spec.BaseImage, err),
+					Field:   specField.Child("baseImage").String(),
+				})
+			}
+		}
+
+	case admissionv1.Update:
+		prevObj := &v1.VirtualMachineImageExport{}
+		err = json.Unmarshal(ar.Request.OldObject.Raw, prevObj)
+		if err != nil {
+			return webhookutils.ToAdmissionResponseError(err)
+		}
+
+		if !reflect.DeepEqual(prevObj.Spec, export.Spec) {
+			causes = []metav1.StatusCause{
+				{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: "spec in immutable after creation",
+					Field:   k8sfield.NewPath("spec").String(),
+				},
+			}
+		}
+	default:
+		return webhookutils.ToAdmissionResponseError(fmt.Errorf("unexpected operation %s", ar.Request.Operation))
+	}
+
+	if len(causes) > 0 {
+		return webhookutils.ToAdmissionResponse(causes)
+	}
+
+	reviewResponse := admissionv1.AdmissionResponse{
+		Allowed: true,
+	}
+	return &reviewResponse
+}
+
+func validateExportImage(field *k8sfield.Path, image string) []metav1.StatusCause {
+	if image == "" {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: "image is required",
+			Field:   field.String(),
+		}}
+	}
+	if containerdisk.ImageDigest(image) != "" {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("image %q must be referenced by tag", image),
+			Field:   field.String(),
+		}}
+	}
+	if err := containerdisk.ValidateImageReference(image); err != nil {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("invalid image %q: %v", image, err),
+			Field:   field.String(),
+		}}
+	}
+	return nil
+}
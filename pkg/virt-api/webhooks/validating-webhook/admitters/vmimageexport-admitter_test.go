@@ -0,0 +1,197 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package admitters
+
+import (
+	"encoding/json"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/testutils"
+	"kubevirt.io/kubevirt/pkg/virt-api/webhooks"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+)
+
+var _ = Describe("Validating VirtualMachineImageExport Admitter", func() {
+	const digest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+	config, configMapInformer, _, _ := testutils.NewFakeClusterConfig(&corev1.ConfigMap{})
+
+	vm := &v1.VirtualMachine{
+		ObjectMeta: metav1.ObjectMeta{Name: "vm", Namespace: "foo"},
+	}
+
+	newExport := func(source, image, baseImage string) *v1.VirtualMachineImageExport {
+		return &v1.VirtualMachineImageExport{
+			Spec: v1.VirtualMachineImageExportSpec{
+				Source:    source,
+				Image:     image,
+				BaseImage: baseImage,
+			},
+		}
+	}
+
+	Context("Without feature gate enabled", func() {
+		It("should reject anything", func() {
+			ar := createImageExportAdmissionReview(newExport("vm", "quay.io/kubevirt/golden:v1", ""))
+			resp := createTestVMImageExportAdmitter(config, nil).Admit(ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).Should(Equal("image export feature gate not enabled"))
+		})
+	})
+
+	Context("With feature gate enabled", func() {
+		BeforeEach(func() {
+			testutils.UpdateFakeClusterConfig(configMapInformer, &corev1.ConfigMap{
+				Data: map[string]string{virtconfig.FeatureGatesKey: virtconfig.ImageExportGate},
+			})
+		})
+
+		AfterEach(func() {
+			testutils.UpdateFakeClusterConfig(configMapInformer, &corev1.ConfigMap{})
+		})
+
+		It("should reject invalid request resource", func() {
+			ar := &admissionv1.AdmissionReview{
+				Request: &admissionv1.AdmissionRequest{
+					Resource: webhooks.VirtualMachineGroupVersionResource,
+				},
+			}
+
+			resp := createTestVMImageExportAdmitter(config, nil).Admit(ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).Should(ContainSubstring("unexpected resource"))
+		})
+
+		It("should accept a valid export", func() {
+			ar := createImageExportAdmissionReview(newExport("vm", "quay.io/kubevirt/golden:v1", "quay.io/kubevirt/base@"+digest))
+			resp := createTestVMImageExportAdmitter(config, vm).Admit(ar)
+			Expect(resp.Allowed).To(BeTrue())
+		})
+
+		It("should reject when VM does not exist", func() {
+			ar := createImageExportAdmissionReview(newExport("vm", "quay.io/kubevirt/golden:v1", ""))
+			resp := createTestVMImageExportAdmitter(config, nil).Admit(ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.source"))
+		})
+
+		table.DescribeTable("should reject invalid images", func(image, baseImage, field string) {
+			ar := createImageExportAdmissionReview(newExport("vm", image, baseImage))
+			resp := createTestVMImageExportAdmitter(config, vm).Admit(ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal(field))
+		},
+			table.Entry("without an image", "", "", "spec.image"),
+			table.Entry("with an image referenced by digest", "quay.io/kubevirt/golden@"+digest, "", "spec.image"),
+			table.Entry("with an invalid image", "quay.io/KubeVirt/golden:v1", "", "spec.image"),
+			table.Entry("with an invalid base image", "quay.io/kubevirt/golden:v1", "quay.io/kubevirt/base:-1", "spec.baseImage"),
+		)
+
+		It("should reject spec update", func() {
+			oldExport := newExport("vm", "quay.io/kubevirt/golden:v1", "")
+			export := newExport("vm", "quay.io/kubevirt/golden:v2", "")
+
+			ar := createImageExportUpdateAdmissionReview(oldExport, export)
+			resp := createTestVMImageExportAdmitter(config, nil).Admit(ar)
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec"))
+		})
+
+		It("should allow status update", func() {
+			oldExport := newExport("vm", "quay.io/kubevirt/golden:v1", "")
+			export := newExport("vm", "quay.io/kubevirt/golden:v1", "")
+			export.Status.Phase = v1.ImageExportSucceeded
+
+			ar := createImageExportUpdateAdmissionReview(oldExport, export)
+			resp := createTestVMImageExportAdmitter(config, nil).Admit(ar)
+			Expect(resp.Allowed).To(BeTrue())
+		})
+	})
+})
+
+func createImageExportAdmissionReview(export *v1.VirtualMachineImageExport) *admissionv1.AdmissionReview {
+	bytes, _ := json.Marshal(export)
+
+	return &admissionv1.AdmissionReview{
+		Request: &admissionv1.AdmissionRequest{
+			Operation: admissionv1.Create,
+			Namespace: "foo",
+			Resource: metav1.GroupVersionResource{
+				Group:    "kubevirt.io",
+				Resource: "virtualmachineimageexports",
+			},
+			Object: runtime.RawExtension{
+				Raw: bytes,
+			},
+		},
+	}
+}
+
+func createImageExportUpdateAdmissionReview(old, current *v1.VirtualMachineImageExport) *admissionv1.AdmissionReview {
+	oldBytes, _ := json.Marshal(old)
+	currentBytes, _ := json.Marshal(current)
+
+	return &admissionv1.AdmissionReview{
+		Request: &admissionv1.AdmissionRequest{
+			Operation: admissionv1.Update,
+			Namespace: "foo",
+			Resource: metav1.GroupVersionResource{
+				Group:    "kubevirt.io",
+				Resource: "virtualmachineimageexports",
+			},
+			Object: runtime.RawExtension{
+				Raw: currentBytes,
+			},
+			OldObject: runtime.RawExtension{
+				Raw: oldBytes,
+			},
+		},
+	}
+}
+
+func createTestVMImageExportAdmitter(config *virtconfig.ClusterConfig, vm *v1.VirtualMachine) *VMImageExportAdmitter {
+	ctrl := gomock.NewController(GinkgoT())
+	virtClient := kubecli.NewMockKubevirtClient(ctrl)
+	vmInterface := kubecli.NewMockVirtualMachineInterface(ctrl)
+	virtClient.EXPECT().VirtualMachine(gomock.Any()).Return(vmInterface).AnyTimes()
+	if vm == nil {
+		err := errors.NewNotFound(schema.GroupResource{Group: "kubevirt.io", Resource: "virtualmachines"}, "foo")
+		vmInterface.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, err).AnyTimes()
+	} else {
+		vmInterface.EXPECT().Get(vm.Name, gomock.Any()).Return(vm, nil).AnyTimes()
+	}
+	return &VMImageExportAdmitter{Config: config, Client: virtClient}
+}
@@ -22,10 +22,13 @@ package admitters
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	k8sv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
 	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
 
 	v1 "kubevirt.io/client-go/api/v1"
@@ -77,6 +80,7 @@ func ValidateVMIRSSpec(field *k8sfield.Path, spec *v1.VirtualMachineInstanceRepl
 		})
 	}
 	causes = append(causes, ValidateVirtualMachineInstanceSpec(field.Child("template", "spec"), &spec.Template.Spec, config)...)
+	causes = append(causes, validatePinnedContainerDiskImages(field.Child("template", "spec"), &spec.Template.Spec)...)
 
 	selector, err := metav1.LabelSelectorAsSelector(spec.Selector)
 	if err != nil {
@@ -93,5 +97,80 @@ func ValidateVMIRSSpec(field *k8sfield.Path, spec *v1.VirtualMachineInstanceRepl
 		})
 	}
 
+	causes = append(causes, validateVMIRSTopologySpread(field.Child("topologySpread"), spec.TopologySpread)...)
+	causes = append(causes, validateVMIRSServiceName(field, spec)...)
+
+	return causes
+}
+
+func validateVMIRSServiceName(field *k8sfield.Path, spec *v1.VirtualMachineInstanceReplicaSetSpec) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+
+	if spec.ServiceName == "" {
+		return causes
+	}
+
+	if errors := validation.IsDNS1123Label(spec.ServiceName); len(errors) != 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s does not conform to the kubernetes DNS_LABEL rules : %s", field.Child("serviceName").String(), strings.Join(errors, ", ")),
+			Field:   field.Child("serviceName").String(),
+		})
+	}
+
+	if spec.Template.Spec.Hostname != "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must not be set together with %s, every replica needs its own hostname", field.Child("template", "spec", "hostname").String(), field.Child("serviceName").String()),
+			Field:   field.Child("template", "spec", "hostname").String(),
+		})
+	}
+
+	if spec.Template.Spec.Subdomain != "" && spec.Template.Spec.Subdomain != spec.ServiceName {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must match %s", field.Child("template", "spec", "subdomain").String(), field.Child("serviceName").String()),
+			Field:   field.Child("template", "spec", "subdomain").String(),
+		})
+	}
+
+	return causes
+}
+
+func validateVMIRSTopologySpread(field *k8sfield.Path, spread *v1.VirtualMachineInstanceReplicaSetTopologySpread) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+
+	if spread == nil {
+		return causes
+	}
+
+	for i, topologyKey := range spread.TopologyKeys {
+		if topologyKey == "" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must not be empty", field.Child("topologyKeys").Index(i).String()),
+				Field:   field.Child("topologyKeys").Index(i).String(),
+			})
+		}
+	}
+
+	if spread.MaxSkew != nil && *spread.MaxSkew < 1 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("%s must be greater than zero", field.Child("maxSkew").String()),
+			Field:   field.Child("maxSkew").String(),
+		})
+	}
+
+	switch spread.WhenUnsatisfiable {
+	case "", k8sv1.DoNotSchedule, k8sv1.ScheduleAnyway:
+	default:
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("%s must be one of %s or %s", field.Child("whenUnsatisfiable").String(), k8sv1.DoNotSchedule, k8sv1.ScheduleAnyway),
+			Field:   field.Child("whenUnsatisfiable").String(),
+		})
+	}
+
 	return causes
 }
@@ -29,6 +29,7 @@ import (
 	k8sv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
 
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/kubevirt/pkg/testutils"
@@ -104,6 +105,46 @@ var _ = Describe("Validating VMIRS Admitter", func() {
 		}, []string{
 			"spec.selector",
 		}),
+		table.Entry("with invalid topology spread", &v1.VirtualMachineInstanceReplicaSet{
+			Spec: v1.VirtualMachineInstanceReplicaSetSpec{
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"match": "this"},
+				},
+				Template: newVirtualMachineBuilder().WithLabel("match", "this").BuildTemplate(),
+				TopologySpread: &v1.VirtualMachineInstanceReplicaSetTopologySpread{
+					TopologyKeys:      []string{k8sv1.LabelTopologyZone, ""},
+					MaxSkew:           pointer.Int32Ptr(0),
+					WhenUnsatisfiable: "Sometimes",
+				},
+			},
+		}, []string{
+			"spec.topologySpread.topologyKeys[1]",
+			"spec.topologySpread.maxSkew",
+			"spec.topologySpread.whenUnsatisfiable",
+		}),
+		table.Entry("with invalid service name", &v1.VirtualMachineInstanceReplicaSet{
+			Spec: v1.VirtualMachineInstanceReplicaSetSpec{
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"match": "this"},
+				},
+				Template:    newVirtualMachineBuilder().WithLabel("match", "this").BuildTemplate(),
+				ServiceName: "invalid.service",
+			},
+		}, []string{
+			"spec.serviceName",
+		}),
+		table.Entry("with hostname and service name", &v1.VirtualMachineInstanceReplicaSet{
+			Spec: v1.VirtualMachineInstanceReplicaSetSpec{
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"match": "this"},
+				},
+				Template:    newVirtualMachineBuilder().WithLabel("match", "this").WithHostname("vm").WithSubdomain("other").BuildTemplate(),
+				ServiceName: "service",
+			},
+		}, []string{
+			"spec.template.spec.hostname",
+			"spec.template.spec.subdomain",
+		}),
 	)
 	It("should accept valid vmi spec", func() {
 		vmirs := &v1.VirtualMachineInstanceReplicaSet{
@@ -142,9 +183,11 @@ var _ = Describe("Validating VMIRS Admitter", func() {
 })
 
 type virtualMachineBuilder struct {
-	disks   []v1.Disk
-	volumes []v1.Volume
-	labels  map[string]string
+	disks     []v1.Disk
+	volumes   []v1.Volume
+	labels    map[string]string
+	hostname  string
+	subdomain string
 }
 
 func (b *virtualMachineBuilder) WithDisk(disk v1.Disk) *virtualMachineBuilder {
@@ -162,12 +205,24 @@ func (b *virtualMachineBuilder) WithVolume(volume v1.Volume) *virtualMachineBuil
 	return b
 }
 
+func (b *virtualMachineBuilder) WithHostname(hostname string) *virtualMachineBuilder {
+	b.hostname = hostname
+	return b
+}
+
+func (b *virtualMachineBuilder) WithSubdomain(subdomain string) *virtualMachineBuilder {
+	b.subdomain = subdomain
+	return b
+}
+
 func (b *virtualMachineBuilder) Build() *v1.VirtualMachineInstance {
 
 	vmi := v1.NewMinimalVMI("testvmi")
 	vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, b.disks...)
 	vmi.Spec.Volumes = append(vmi.Spec.Volumes, b.volumes...)
 	vmi.Labels = b.labels
+	vmi.Spec.Hostname = b.hostname
+	vmi.Spec.Subdomain = b.subdomain
 
 	return vmi
 }
@@ -23,6 +23,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net"
 	"reflect"
 
 	admissionv1 "k8s.io/api/admission/v1"
@@ -116,7 +117,7 @@ func (admitter *VMRestoreAdmitter) Admit(ar *admissionv1.AdmissionReview) *admis
 			}
 		}
 
-		snapshotCauses, err := admitter.validateSnapshot(
+		snapshotCauses, snapshot, err := admitter.validateSnapshot(
 			k8sfield.NewPath("spec", "virtualMachineSnapshotName"),
 			ar.Request.Namespace,
 			vmRestore.Spec.VirtualMachineSnapshotName,
@@ -146,6 +147,20 @@ func (admitter *VMRestoreAdmitter) Admit(ar *admissionv1.AdmissionReview) *admis
 		}
 
 		causes = append(causes, snapshotCauses...)
+		causes = append(causes, validateStorageClassMappings(k8sfield.NewPath("spec", "storageClassMappings"), vmRestore.Spec.StorageClassMappings)...)
+
+		identityField := k8sfield.NewPath("spec", "identity")
+		identityCauses := validateRestoreIdentity(identityField, vmRestore.Spec.Identity)
+		causes = append(causes, identityCauses...)
+
+		if len(identityCauses) == 0 && snapshot != nil &&
+			(vmRestore.Spec.Identity == nil || vmRestore.Spec.Identity.MACAddresses != snapshotv1.IdentityRegenerate) {
+			macCauses, err := admitter.validateMACAddresses(identityField.Child("macAddresses"), snapshot, targetUID)
+			if err != nil {
+				return webhookutils.ToAdmissionResponseError(err)
+			}
+			causes = append(causes, macCauses...)
+		}
 
 	case admissionv1.Update:
 		prevObj := &snapshotv1.VirtualMachineRestore{}
@@ -180,13 +195,8 @@ func (admitter *VMRestoreAdmitter) Admit(ar *admissionv1.AdmissionReview) *admis
 func (admitter *VMRestoreAdmitter) validateCreateVM(field *k8sfield.Path, namespace, name string) ([]metav1.StatusCause, *types.UID, error) {
 	vm, err := admitter.Client.VirtualMachine(namespace).Get(name, &metav1.GetOptions{})
 	if errors.IsNotFound(err) {
-		return []metav1.StatusCause{
-			{
-				Type:    metav1.CauseTypeFieldValueInvalid,
-				Message: fmt.Sprintf("VirtualMachine %q does not exist", name),
-				Field:   field.String(),
-			},
-		}, nil, nil
+		// the restore creates a new VirtualMachine
+		return nil, nil, nil
 	}
 
 	if err != nil {
@@ -212,7 +222,7 @@ func (admitter *VMRestoreAdmitter) validateCreateVM(field *k8sfield.Path, namesp
 	return causes, &vm.UID, nil
 }
 
-func (admitter *VMRestoreAdmitter) validateSnapshot(field *k8sfield.Path, namespace, name string, targetUID *types.UID) ([]metav1.StatusCause, error) {
+func (admitter *VMRestoreAdmitter) validateSnapshot(field *k8sfield.Path, namespace, name string, targetUID *types.UID) ([]metav1.StatusCause, *snapshotv1.VirtualMachineSnapshot, error) {
 	snapshot, err := admitter.Client.VirtualMachineSnapshot(namespace).Get(context.Background(), name, metav1.GetOptions{})
 	if errors.IsNotFound(err) {
 		return []metav1.StatusCause{
@@ -221,11 +231,11 @@ func (admitter *VMRestoreAdmitter) validateSnapshot(field *k8sfield.Path, namesp
 				Message: fmt.Sprintf("VirtualMachineSnapshot %q does not exist", name),
 				Field:   field.String(),
 			},
-		}, nil
+		}, nil, nil
 	}
 
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var causes []metav1.StatusCause
@@ -257,5 +267,122 @@ func (admitter *VMRestoreAdmitter) validateSnapshot(field *k8sfield.Path, namesp
 		causes = append(causes, cause)
 	}
 
+	return causes, snapshot, nil
+}
+
+// validateMACAddresses rejects preserving the MAC addresses of the snapshot
+// if they are used by another VirtualMachine than the restore target
+func (admitter *VMRestoreAdmitter) validateMACAddresses(field *k8sfield.Path, snapshot *snapshotv1.VirtualMachineSnapshot, targetUID *types.UID) ([]metav1.StatusCause, error) {
+	if snapshot.Status == nil || snapshot.Status.VirtualMachineSnapshotContentName == nil {
+		return nil, nil
+	}
+
+	content, err := admitter.Client.VirtualMachineSnapshotContent(snapshot.Namespace).Get(context.Background(), *snapshot.Status.VirtualMachineSnapshotContentName, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	snapshotVM := content.Spec.Source.VirtualMachine
+	if snapshotVM == nil || snapshotVM.Spec.Template == nil {
+		return nil, nil
+	}
+
+	macs := make(map[string]bool)
+	for _, iface := range snapshotVM.Spec.Template.Spec.Domain.Devices.Interfaces {
+		if mac, err := net.ParseMAC(iface.MacAddress); err == nil {
+			macs[mac.String()] = true
+		}
+	}
+
+	if len(macs) == 0 {
+		return nil, nil
+	}
+
+	vms, err := admitter.Client.VirtualMachine(metav1.NamespaceAll).List(&metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var causes []metav1.StatusCause
+	for _, vm := range vms.Items {
+		if (targetUID != nil && vm.UID == *targetUID) || vm.Spec.Template == nil {
+			continue
+		}
+
+		for _, iface := range vm.Spec.Template.Spec.Domain.Devices.Interfaces {
+			mac, err := net.ParseMAC(iface.MacAddress)
+			if err != nil || !macs[mac.String()] {
+				continue
+			}
+
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("MAC address %s is already used by VirtualMachine %s/%s, it has to be regenerated", mac, vm.Namespace, vm.Name),
+				Field:   field.String(),
+			})
+		}
+	}
+
 	return causes, nil
 }
+
+func validateRestoreIdentity(field *k8sfield.Path, identity *snapshotv1.RestoreIdentity) []metav1.StatusCause {
+	if identity == nil {
+		return nil
+	}
+
+	var causes []metav1.StatusCause
+	policies := []struct {
+		name   string
+		policy snapshotv1.IdentityPolicy
+	}{
+		{"macAddresses", identity.MACAddresses},
+		{"firmwareUUID", identity.FirmwareUUID},
+		{"firmwareSerial", identity.FirmwareSerial},
+	}
+
+	for _, p := range policies {
+		switch p.policy {
+		case "", snapshotv1.IdentityPreserve, snapshotv1.IdentityRegenerate:
+		default:
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: fmt.Sprintf("invalid identity policy %q, must be %s or %s", p.policy, snapshotv1.IdentityPreserve, snapshotv1.IdentityRegenerate),
+				Field:   field.Child(p.name).String(),
+			})
+		}
+	}
+
+	return causes
+}
+
+func validateStorageClassMappings(field *k8sfield.Path, mappings []snapshotv1.StorageClassMapping) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	sources := make(map[string]bool)
+
+	for i, m := range mappings {
+		if m.Source == "" || m.Target == "" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: "source and target StorageClass must be set",
+				Field:   field.Index(i).String(),
+			})
+			continue
+		}
+
+		if sources[m.Source] {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueDuplicate,
+				Message: fmt.Sprintf("StorageClass %q is mapped more than once", m.Source),
+				Field:   field.Index(i).Child("source").String(),
+			})
+		}
+		sources[m.Source] = true
+	}
+
+	return causes
+}
@@ -139,7 +139,7 @@ var _ = Describe("Validating VirtualMachineRestore Admitter", func() {
 			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.target.apiGroup"))
 		})
 
-		It("should reject when VM does not exist", func() {
+		It("should accept when VM does not exist", func() {
 			restore := &snapshotv1.VirtualMachineRestore{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "restore",
@@ -157,9 +157,7 @@ var _ = Describe("Validating VirtualMachineRestore Admitter", func() {
 
 			ar := createRestoreAdmissionReview(restore)
 			resp := createTestVMRestoreAdmitter(config, nil, snapshot).Admit(ar)
-			Expect(resp.Allowed).To(BeFalse())
-			Expect(len(resp.Result.Details.Causes)).To(Equal(1))
-			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.target.name"))
+			Expect(resp.Allowed).To(BeTrue())
 		})
 
 		It("should reject when VM and snapshot do not exist", func() {
@@ -181,9 +179,37 @@ var _ = Describe("Validating VirtualMachineRestore Admitter", func() {
 			ar := createRestoreAdmissionReview(restore)
 			resp := createTestVMRestoreAdmitter(config, nil).Admit(ar)
 			Expect(resp.Allowed).To(BeFalse())
+			Expect(len(resp.Result.Details.Causes)).To(Equal(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.virtualMachineSnapshotName"))
+		})
+
+		It("should reject invalid storage class mappings", func() {
+			restore := &snapshotv1.VirtualMachineRestore{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "restore",
+					Namespace: "default",
+				},
+				Spec: snapshotv1.VirtualMachineRestoreSpec{
+					Target: corev1.TypedLocalObjectReference{
+						APIGroup: &apiGroup,
+						Kind:     "VirtualMachine",
+						Name:     vmName,
+					},
+					VirtualMachineSnapshotName: vmSnapshotName,
+					StorageClassMappings: []snapshotv1.StorageClassMapping{
+						{Source: "sc1", Target: "sc2"},
+						{Source: "sc3"},
+						{Source: "sc1", Target: "sc3"},
+					},
+				},
+			}
+
+			ar := createRestoreAdmissionReview(restore)
+			resp := createTestVMRestoreAdmitter(config, nil, snapshot).Admit(ar)
+			Expect(resp.Allowed).To(BeFalse())
 			Expect(len(resp.Result.Details.Causes)).To(Equal(2))
-			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.target.name"))
-			Expect(resp.Result.Details.Causes[1].Field).To(Equal("spec.virtualMachineSnapshotName"))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.storageClassMappings[1]"))
+			Expect(resp.Result.Details.Causes[1].Field).To(Equal("spec.storageClassMappings[2].source"))
 		})
 
 		It("should reject spec update", func() {
@@ -511,6 +537,97 @@ var _ = Describe("Validating VirtualMachineRestore Admitter", func() {
 				resp := createTestVMRestoreAdmitter(config, vm, snapshot).Admit(ar)
 				Expect(resp.Allowed).To(BeTrue())
 			})
+
+			Context("with identity", func() {
+				const mac = "de:ad:00:00:be:af"
+
+				var restore *snapshotv1.VirtualMachineRestore
+				var snapshotWithContent *snapshotv1.VirtualMachineSnapshot
+				var content *snapshotv1.VirtualMachineSnapshotContent
+				var otherVM *v1.VirtualMachine
+
+				BeforeEach(func() {
+					restore = &snapshotv1.VirtualMachineRestore{
+						Spec: snapshotv1.VirtualMachineRestoreSpec{
+							Target: corev1.TypedLocalObjectReference{
+								APIGroup: &apiGroup,
+								Kind:     "VirtualMachine",
+								Name:     "clone",
+							},
+							VirtualMachineSnapshotName: vmSnapshotName,
+						},
+					}
+
+					contentName := "content"
+					snapshotWithContent = snapshot.DeepCopy()
+					snapshotWithContent.Status.VirtualMachineSnapshotContentName = &contentName
+
+					snapshotVM := &v1.VirtualMachine{
+						ObjectMeta: metav1.ObjectMeta{Name: vmName, Namespace: "default"},
+						Spec: v1.VirtualMachineSpec{
+							Template: &v1.VirtualMachineInstanceTemplateSpec{},
+						},
+					}
+					snapshotVM.Spec.Template.Spec.Domain.Devices.Interfaces = []v1.Interface{{Name: "default", MacAddress: mac}}
+					content = &snapshotv1.VirtualMachineSnapshotContent{
+						ObjectMeta: metav1.ObjectMeta{Name: contentName, Namespace: "default"},
+						Spec: snapshotv1.VirtualMachineSnapshotContentSpec{
+							Source: snapshotv1.SourceSpec{VirtualMachine: snapshotVM},
+						},
+					}
+
+					otherVM = &v1.VirtualMachine{
+						ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "other", UID: "other-uid"},
+						Spec: v1.VirtualMachineSpec{
+							Template: &v1.VirtualMachineInstanceTemplateSpec{},
+						},
+					}
+					otherVM.Spec.Template.Spec.Domain.Devices.Interfaces = []v1.Interface{{Name: "default", MacAddress: "DE:AD:00:00:BE:AF"}}
+				})
+
+				It("should reject invalid identity policies", func() {
+					restore.Spec.Identity = &snapshotv1.RestoreIdentity{
+						MACAddresses: snapshotv1.IdentityRegenerate,
+						FirmwareUUID: "Keep",
+					}
+
+					ar := createRestoreAdmissionReview(restore)
+					resp := createTestVMRestoreAdmitter(config, nil, snapshotWithContent, content).Admit(ar)
+					Expect(resp.Allowed).To(BeFalse())
+					Expect(resp.Result.Details.Causes).To(HaveLen(1))
+					Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.identity.firmwareUUID"))
+				})
+
+				It("should reject preserving MAC addresses used by another VM", func() {
+					ar := createRestoreAdmissionReview(restore)
+					resp := createTestVMRestoreAdmitter(config, nil, snapshotWithContent, content, otherVM).Admit(ar)
+					Expect(resp.Allowed).To(BeFalse())
+					Expect(resp.Result.Details.Causes).To(HaveLen(1))
+					Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.identity.macAddresses"))
+					Expect(resp.Result.Details.Causes[0].Message).To(ContainSubstring("other/other"))
+				})
+
+				It("should accept regenerating MAC addresses used by another VM", func() {
+					restore.Spec.Identity = &snapshotv1.RestoreIdentity{
+						MACAddresses: snapshotv1.IdentityRegenerate,
+					}
+
+					ar := createRestoreAdmissionReview(restore)
+					resp := createTestVMRestoreAdmitter(config, nil, snapshotWithContent, content, otherVM).Admit(ar)
+					Expect(resp.Allowed).To(BeTrue())
+				})
+
+				It("should accept preserving MAC addresses only used by the target VM", func() {
+					restore.Spec.Target.Name = vmName
+					vm.UID = vmUID
+					vm.Spec.Running = &f
+					vm.Spec.Template = otherVM.Spec.Template
+
+					ar := createRestoreAdmissionReview(restore)
+					resp := createTestVMRestoreAdmitter(config, vm, snapshotWithContent, content, vm).Admit(ar)
+					Expect(resp.Allowed).To(BeTrue())
+				})
+			})
 		})
 	})
 })
@@ -567,11 +684,24 @@ func createTestVMRestoreAdmitter(
 	ctrl := gomock.NewController(GinkgoT())
 	virtClient := kubecli.NewMockKubevirtClient(ctrl)
 	vmInterface := kubecli.NewMockVirtualMachineInterface(ctrl)
-	kubevirtClient := kubevirtfake.NewSimpleClientset(objs...)
+
+	var vms []v1.VirtualMachine
+	var snapshotObjs []runtime.Object
+	for _, obj := range objs {
+		if other, ok := obj.(*v1.VirtualMachine); ok {
+			vms = append(vms, *other)
+		} else {
+			snapshotObjs = append(snapshotObjs, obj)
+		}
+	}
+	kubevirtClient := kubevirtfake.NewSimpleClientset(snapshotObjs...)
 
 	virtClient.EXPECT().VirtualMachineSnapshot("default").
 		Return(kubevirtClient.SnapshotV1alpha1().VirtualMachineSnapshots("default"))
+	virtClient.EXPECT().VirtualMachineSnapshotContent("default").
+		Return(kubevirtClient.SnapshotV1alpha1().VirtualMachineSnapshotContents("default")).AnyTimes()
 	virtClient.EXPECT().VirtualMachine(gomock.Any()).Return(vmInterface).AnyTimes()
+	vmInterface.EXPECT().List(gomock.Any()).Return(&v1.VirtualMachineList{Items: vms}, nil).AnyTimes()
 
 	restoreInformer, _ := testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineRestore{})
 	webhooks.GetInformers().VMRestoreInformer = restoreInformer
@@ -24,6 +24,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"strings"
 
 	admissionv1 "k8s.io/api/admission/v1"
 	authv1 "k8s.io/api/authorization/v1"
@@ -35,6 +36,7 @@ import (
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/kubecli"
 	cdiclone "kubevirt.io/containerized-data-importer/pkg/clone"
+	containerdisk "kubevirt.io/kubevirt/pkg/container-disk"
 	"kubevirt.io/kubevirt/pkg/controller"
 	migrationutil "kubevirt.io/kubevirt/pkg/util/migrations"
 	typesutil "kubevirt.io/kubevirt/pkg/util/types"
@@ -43,14 +45,16 @@ import (
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
-var validRunStrategies = []v1.VirtualMachineRunStrategy{v1.RunStrategyHalted, v1.RunStrategyManual, v1.RunStrategyAlways, v1.RunStrategyRerunOnFailure}
+var validRunStrategies = []v1.VirtualMachineRunStrategy{v1.RunStrategyHalted, v1.RunStrategyManual, v1.RunStrategyAlways, v1.RunStrategyRerunOnFailure, v1.RunStrategyOnce}
+var validChangeApplyPolicies = []v1.VirtualMachineChangeApplyPolicy{v1.ChangeApplyPolicyManual, v1.ChangeApplyPolicyLive, v1.ChangeApplyPolicyAutomatic}
 
 type CloneAuthFunc func(pvcNamespace, pvcName, saNamespace, saName string) (bool, string, error)
 
 type VMsAdmitter struct {
-	ClusterConfig *virtconfig.ClusterConfig
-	cloneAuthFunc CloneAuthFunc
-	virtClient    kubecli.KubevirtClient
+	ClusterConfig        *virtconfig.ClusterConfig
+	ArchitectureResolver containerdisk.ImageArchitectureResolver
+	cloneAuthFunc        CloneAuthFunc
+	virtClient           kubecli.KubevirtClient
 }
 
 type sarProxy struct {
@@ -92,9 +96,32 @@ func (admitter *VMsAdmitter) Admit(ar *admissionv1.AdmissionReview) *admissionv1
 		return webhookutils.ToAdmissionResponseError(err)
 	}
 
+	var warnings []string
+	if vm.Spec.Template != nil {
+		warnings = CollectVirtualMachineInstanceWarnings(k8sfield.NewPath("spec", "template", "spec"), &vm.Spec.Template.Spec)
+	}
+
 	causes := ValidateVirtualMachineSpec(k8sfield.NewPath("spec"), &vm.Spec, admitter.ClusterConfig, accountName)
 	if len(causes) > 0 {
-		return webhookutils.ToAdmissionResponse(causes)
+		return webhookutils.WithWarnings(webhookutils.ToAdmissionResponse(causes), warnings)
+	}
+
+	// VMIs created from a template violating a ValidationPolicy or a namespaced feature gate would be rejected later on
+	if vm.Spec.Template != nil {
+		causes = ValidateVirtualMachineInstancePolicies(k8sfield.NewPath("spec", "template", "metadata"), k8sfield.NewPath("spec", "template", "spec"), &vm.Spec.Template.ObjectMeta, &vm.Spec.Template.Spec)
+		if len(causes) > 0 {
+			return webhookutils.WithWarnings(webhookutils.ToAdmissionResponse(causes), warnings)
+		}
+
+		causes = validateNamespacedFeatureGates(k8sfield.NewPath("spec", "template", "spec"), &vm.Spec.Template.Spec, ar.Request.Namespace, admitter.ClusterConfig)
+		if len(causes) > 0 {
+			return webhookutils.WithWarnings(webhookutils.ToAdmissionResponse(causes), warnings)
+		}
+	}
+
+	causes = validateFirstBootOrder(&vm)
+	if len(causes) > 0 {
+		return webhookutils.WithWarnings(webhookutils.ToAdmissionResponse(causes), warnings)
 	}
 
 	causes, err = admitter.authorizeVirtualMachineSpec(ar.Request, &vm)
@@ -102,29 +129,63 @@ func (admitter *VMsAdmitter) Admit(ar *admissionv1.AdmissionReview) *admissionv1
 		return webhookutils.ToAdmissionResponseError(err)
 	}
 	if len(causes) > 0 {
-		return webhookutils.ToAdmissionResponse(causes)
+		return webhookutils.WithWarnings(webhookutils.ToAdmissionResponse(causes), warnings)
 	}
 
 	causes, err = admitter.validateVolumeRequests(&vm)
 	if err != nil {
 		return webhookutils.ToAdmissionResponseError(err)
 	} else if len(causes) > 0 {
-		return webhookutils.ToAdmissionResponse(causes)
+		return webhookutils.WithWarnings(webhookutils.ToAdmissionResponse(causes), warnings)
 	}
 
 	causes = validateSnapshotStatus(ar.Request, &vm)
 	if len(causes) > 0 {
-		return webhookutils.ToAdmissionResponse(causes)
+		return webhookutils.WithWarnings(webhookutils.ToAdmissionResponse(causes), warnings)
 	}
 
 	causes = validateRestoreStatus(ar.Request, &vm)
 	if len(causes) > 0 {
-		return webhookutils.ToAdmissionResponse(causes)
+		return webhookutils.WithWarnings(webhookutils.ToAdmissionResponse(causes), warnings)
+	}
+
+	// architectures are only looked up for otherwise valid VMs, it involves requests to the registries
+	if vm.Spec.Template != nil && containerDiskArchitecturesNeedCheck(ar.Request, &vm) {
+		causes = validateContainerDiskArchitectures(k8sfield.NewPath("spec", "template", "spec"), &vm.Spec.Template.Spec, admitter.ArchitectureResolver)
+		if len(causes) > 0 {
+			return webhookutils.WithWarnings(webhookutils.ToAdmissionResponse(causes), warnings)
+		}
 	}
 
 	reviewResponse := admissionv1.AdmissionResponse{}
 	reviewResponse.Allowed = true
-	return &reviewResponse
+	return webhookutils.WithWarnings(&reviewResponse, warnings)
+}
+
+// containerDiskArchitecturesNeedCheck returns false for updates which keep the containerDisk images and the
+// selected architecture of the template, so that updates of VMs don't look up their images again.
+func containerDiskArchitecturesNeedCheck(ar *admissionv1.AdmissionRequest, vm *v1.VirtualMachine) bool {
+	if ar.Operation != admissionv1.Update {
+		return true
+	}
+	oldVM := v1.VirtualMachine{}
+	if err := json.Unmarshal(ar.OldObject.Raw, &oldVM); err != nil || oldVM.Spec.Template == nil {
+		return true
+	}
+	if selectedArchitecture(&oldVM.Spec.Template.Spec) != selectedArchitecture(&vm.Spec.Template.Spec) {
+		return true
+	}
+	return !reflect.DeepEqual(containerDiskImages(&oldVM.Spec.Template.Spec), containerDiskImages(&vm.Spec.Template.Spec))
+}
+
+func containerDiskImages(spec *v1.VirtualMachineInstanceSpec) []string {
+	var images []string
+	for _, volume := range spec.Volumes {
+		if volume.ContainerDisk != nil {
+			images = append(images, volume.ContainerDisk.Image)
+		}
+	}
+	return images
 }
 
 func (admitter *VMsAdmitter) AdmitStatus(ar *admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
@@ -299,6 +360,74 @@ func ValidateVirtualMachineSpec(field *k8sfield.Path, spec *v1.VirtualMachineSpe
 		}
 	}
 
+	if spec.ChangeApplyPolicy != nil {
+		validChangeApplyPolicy := false
+		for _, policy := range validChangeApplyPolicies {
+			if *spec.ChangeApplyPolicy == policy {
+				validChangeApplyPolicy = true
+				break
+			}
+		}
+		if !validChangeApplyPolicy {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("Invalid ChangeApplyPolicy (%s)", *spec.ChangeApplyPolicy),
+				Field:   field.Child("changeApplyPolicy").String(),
+			})
+		}
+	}
+
+	if spec.TTLSecondsAfterFinished != nil {
+		if spec.RunStrategy == nil || *spec.RunStrategy != v1.RunStrategyOnce {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s is only supported with runStrategy %s", field.Child("ttlSecondsAfterFinished").String(), v1.RunStrategyOnce),
+				Field:   field.Child("ttlSecondsAfterFinished").String(),
+			})
+		} else if *spec.TTLSecondsAfterFinished < 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("%s must not be negative", field.Child("ttlSecondsAfterFinished").String()),
+				Field:   field.Child("ttlSecondsAfterFinished").String(),
+			})
+		}
+	}
+
+	return causes
+}
+
+// validateFirstBootOrder checks that the first boot order annotation lists each disk of the VM at most once
+func validateFirstBootOrder(vm *v1.VirtualMachine) []metav1.StatusCause {
+	bootOrder, exists := vm.Annotations[v1.FirstBootOrderAnnotation]
+	if !exists || vm.Spec.Template == nil {
+		return nil
+	}
+
+	field := k8sfield.NewPath("metadata", "annotations").Key(v1.FirstBootOrderAnnotation).String()
+	disks := map[string]bool{}
+	for _, disk := range vm.Spec.Template.Spec.Domain.Devices.Disks {
+		disks[disk.Name] = true
+	}
+
+	var causes []metav1.StatusCause
+	listed := map[string]bool{}
+	for _, name := range strings.Split(bootOrder, ",") {
+		name = strings.TrimSpace(name)
+		if !disks[name] {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("first boot order references the unknown disk %q", name),
+				Field:   field,
+			})
+		} else if listed[name] {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueDuplicate,
+				Message: fmt.Sprintf("first boot order lists the disk %q more than once", name),
+				Field:   field,
+			})
+		}
+		listed[name] = true
+	}
 	return causes
 }
 
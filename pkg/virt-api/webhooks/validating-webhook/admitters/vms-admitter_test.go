@@ -113,6 +113,74 @@ var _ = Describe("Validating VM Admitter", func() {
 		Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.template.spec.domain.devices.disks[0].name"))
 	})
 
+	It("should reject contradicting resources in the VirtualMachineInstance spec", func() {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.Spec.Domain.Resources.Requests = k8sv1.ResourceList{
+			k8sv1.ResourceMemory: resource.MustParse("128Mi"),
+		}
+		vmi.Spec.Domain.Resources.Limits = k8sv1.ResourceList{
+			k8sv1.ResourceMemory: resource.MustParse("64Mi"),
+		}
+		vm := &v1.VirtualMachine{
+			Spec: v1.VirtualMachineSpec{
+				Running: &notRunning,
+				Template: &v1.VirtualMachineInstanceTemplateSpec{
+					Spec: vmi.Spec,
+				},
+			},
+		}
+		vmBytes, _ := json.Marshal(&vm)
+
+		ar := &admissionv1.AdmissionReview{
+			Request: &admissionv1.AdmissionRequest{
+				Resource: webhooks.VirtualMachineGroupVersionResource,
+				Object: runtime.RawExtension{
+					Raw: vmBytes,
+				},
+			},
+		}
+
+		resp := vmsAdmitter.Admit(ar)
+		Expect(resp.Allowed).To(BeFalse())
+		Expect(resp.Result.Details.Causes).To(HaveLen(1))
+		Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.template.spec.domain.resources.requests.memory"))
+	})
+
+	It("should reject templates violating a ValidationPolicy", func() {
+		policyStore := webhooks.GetInformers().ValidationPolicyInformer.GetStore()
+		policy := &v1.ValidationPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "labels"},
+			Spec:       v1.ValidationPolicySpec{RequiredLabels: []string{"owner"}},
+		}
+		Expect(policyStore.Add(policy)).To(Succeed())
+		defer policyStore.Delete(policy)
+
+		vmi := v1.NewMinimalVMI("testvmi")
+		vm := &v1.VirtualMachine{
+			Spec: v1.VirtualMachineSpec{
+				Running: &notRunning,
+				Template: &v1.VirtualMachineInstanceTemplateSpec{
+					Spec: vmi.Spec,
+				},
+			},
+		}
+		vmBytes, _ := json.Marshal(&vm)
+
+		ar := &admissionv1.AdmissionReview{
+			Request: &admissionv1.AdmissionRequest{
+				Resource: webhooks.VirtualMachineGroupVersionResource,
+				Object: runtime.RawExtension{
+					Raw: vmBytes,
+				},
+			},
+		}
+
+		resp := vmsAdmitter.Admit(ar)
+		Expect(resp.Allowed).To(BeFalse())
+		Expect(resp.Result.Details.Causes).To(HaveLen(1))
+		Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.template.metadata.labels"))
+	})
+
 	It("should accept valid vmi spec", func() {
 		vmi := v1.NewMinimalVMI("testvmi")
 		vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
@@ -700,6 +768,77 @@ var _ = Describe("Validating VM Admitter", func() {
 		Expect(resp.Allowed).To(BeTrue())
 	})
 
+	table.DescribeTable("should validate the TTL after a VM with runStrategy Once finished", func(runStrategy v1.VirtualMachineRunStrategy, ttl int32, allowed bool) {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vm := &v1.VirtualMachine{
+			Spec: v1.VirtualMachineSpec{
+				RunStrategy:             &runStrategy,
+				TTLSecondsAfterFinished: &ttl,
+				Template: &v1.VirtualMachineInstanceTemplateSpec{
+					Spec: vmi.Spec,
+				},
+			},
+		}
+		vmBytes, _ := json.Marshal(&vm)
+
+		ar := &admissionv1.AdmissionReview{
+			Request: &admissionv1.AdmissionRequest{
+				Resource: webhooks.VirtualMachineGroupVersionResource,
+				Object: runtime.RawExtension{
+					Raw: vmBytes,
+				},
+			},
+		}
+
+		resp := vmsAdmitter.Admit(ar)
+		Expect(resp.Allowed).To(Equal(allowed))
+		if !allowed {
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.ttlSecondsAfterFinished"))
+		}
+	},
+		table.Entry("accept a TTL", v1.RunStrategyOnce, int32(3600), true),
+		table.Entry("accept no delay", v1.RunStrategyOnce, int32(0), true),
+		table.Entry("reject a negative TTL", v1.RunStrategyOnce, int32(-1), false),
+		table.Entry("reject a TTL with runStrategy Always", v1.RunStrategyAlways, int32(3600), false),
+		table.Entry("reject a TTL with runStrategy RerunOnFailure", v1.RunStrategyRerunOnFailure, int32(3600), false),
+	)
+
+	table.DescribeTable("should validate the change apply policy", func(policy v1.VirtualMachineChangeApplyPolicy, allowed bool) {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vm := &v1.VirtualMachine{
+			Spec: v1.VirtualMachineSpec{
+				Running:           &notRunning,
+				ChangeApplyPolicy: &policy,
+				Template: &v1.VirtualMachineInstanceTemplateSpec{
+					Spec: vmi.Spec,
+				},
+			},
+		}
+		vmBytes, _ := json.Marshal(&vm)
+
+		ar := &admissionv1.AdmissionReview{
+			Request: &admissionv1.AdmissionRequest{
+				Resource: webhooks.VirtualMachineGroupVersionResource,
+				Object: runtime.RawExtension{
+					Raw: vmBytes,
+				},
+			},
+		}
+
+		resp := vmsAdmitter.Admit(ar)
+		Expect(resp.Allowed).To(Equal(allowed))
+		if !allowed {
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.changeApplyPolicy"))
+		}
+	},
+		table.Entry("accept Manual", v1.ChangeApplyPolicyManual, true),
+		table.Entry("accept Live", v1.ChangeApplyPolicyLive, true),
+		table.Entry("accept Automatic", v1.ChangeApplyPolicyAutomatic, true),
+		table.Entry("reject an unknown policy", v1.VirtualMachineChangeApplyPolicy("Sometimes"), false),
+	)
+
 	It("should reject invalid DataVolumeTemplate with no Volume reference in VMI template", func() {
 		vmi := v1.NewMinimalVMI("testvmi")
 		vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{
@@ -751,6 +890,28 @@ var _ = Describe("Validating VM Admitter", func() {
 		Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.dataVolumeTemplate[0]"))
 	})
 
+	table.DescribeTable("should validate the first boot order", func(bootOrder string, expectedCauses int) {
+		vm := &v1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{v1.FirstBootOrderAnnotation: bootOrder},
+			},
+			Spec: v1.VirtualMachineSpec{
+				Template: &v1.VirtualMachineInstanceTemplateSpec{},
+			},
+		}
+		vm.Spec.Template.Spec.Domain.Devices.Disks = []v1.Disk{{Name: "installer"}, {Name: "rootdisk"}}
+
+		causes := validateFirstBootOrder(vm)
+		Expect(causes).To(HaveLen(expectedCauses))
+		for _, cause := range causes {
+			Expect(cause.Field).To(Equal("metadata.annotations[kubevirt.io/first-boot-order]"))
+		}
+	},
+		table.Entry("accept disks of the VM", "installer, rootdisk", 0),
+		table.Entry("reject unknown disks", "installer,cdrom", 1),
+		table.Entry("reject disks listed more than once", "installer,rootdisk,installer", 1),
+	)
+
 	Context("with Volume", func() {
 
 		BeforeEach(func() {
@@ -858,9 +1019,9 @@ var _ = Describe("Validating VM Admitter", func() {
 			Expect(len(causes)).To(Equal(1))
 			Expect(causes[0].Field).To(Equal("fake[1].name"))
 		})
-		It("should reject volume count > arrayLenMax", func() {
+		It("should reject volume count > the max list length", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
-			for i := 0; i <= arrayLenMax; i++ {
+			for i := 0; i <= virtconfig.DefaultMaxListLength; i++ {
 				name := strconv.Itoa(i)
 
 				vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
@@ -875,7 +1036,7 @@ var _ = Describe("Validating VM Admitter", func() {
 			Expect(len(causes)).To(Equal(1))
 			Expect(string(causes[0].Type)).To(Equal("FieldValueInvalid"))
 			Expect(causes[0].Field).To(Equal("fake"))
-			Expect(causes[0].Message).To(Equal(fmt.Sprintf("fake list exceeds the %d element limit in length", arrayLenMax)))
+			Expect(causes[0].Message).To(Equal(fmt.Sprintf("fake list exceeds the %d element limit in length", virtconfig.DefaultMaxListLength)))
 		})
 
 		table.DescribeTable("should verify cloud-init userdata length", func(userDataLen int, expectedErrors int, base64Encode bool) {
@@ -902,11 +1063,11 @@ var _ = Describe("Validating VM Admitter", func() {
 			}
 		},
 			table.Entry("should accept userdata under max limit", 10, 0, false),
-			table.Entry("should accept userdata equal max limit", cloudInitUserMaxLen, 0, false),
-			table.Entry("should reject userdata greater than max limit", cloudInitUserMaxLen+1, 1, false),
+			table.Entry("should accept userdata equal max limit", virtconfig.DefaultCloudInitUserDataMaxBytes, 0, false),
+			table.Entry("should reject userdata greater than max limit", virtconfig.DefaultCloudInitUserDataMaxBytes+1, 1, false),
 			table.Entry("should accept userdata base64 under max limit", 10, 0, true),
-			table.Entry("should accept userdata base64 equal max limit", cloudInitUserMaxLen, 0, true),
-			table.Entry("should reject userdata base64 greater than max limit", cloudInitUserMaxLen+1, 1, true),
+			table.Entry("should accept userdata base64 equal max limit", virtconfig.DefaultCloudInitUserDataMaxBytes, 0, true),
+			table.Entry("should reject userdata base64 greater than max limit", virtconfig.DefaultCloudInitUserDataMaxBytes+1, 1, true),
 		)
 
 		table.DescribeTable("should verify cloud-init networkdata length", func(networkDataLen int, expectedErrors int, base64Encode bool) {
@@ -934,11 +1095,11 @@ var _ = Describe("Validating VM Admitter", func() {
 			}
 		},
 			table.Entry("should accept networkdata under max limit", 10, 0, false),
-			table.Entry("should accept networkdata equal max limit", cloudInitNetworkMaxLen, 0, false),
-			table.Entry("should reject networkdata greater than max limit", cloudInitNetworkMaxLen+1, 1, false),
+			table.Entry("should accept networkdata equal max limit", virtconfig.DefaultCloudInitNetworkDataMaxBytes, 0, false),
+			table.Entry("should reject networkdata greater than max limit", virtconfig.DefaultCloudInitNetworkDataMaxBytes+1, 1, false),
 			table.Entry("should accept networkdata base64 under max limit", 10, 0, true),
-			table.Entry("should accept networkdata base64 equal max limit", cloudInitNetworkMaxLen, 0, true),
-			table.Entry("should reject networkdata base64 greater than max limit", cloudInitNetworkMaxLen+1, 1, true),
+			table.Entry("should accept networkdata base64 equal max limit", virtconfig.DefaultCloudInitNetworkDataMaxBytes, 0, true),
+			table.Entry("should reject networkdata base64 greater than max limit", virtconfig.DefaultCloudInitNetworkDataMaxBytes+1, 1, true),
 		)
 
 		It("should reject cloud-init with invalid base64 userdata", func() {
@@ -1429,6 +1590,84 @@ var _ = Describe("Validating VM Admitter", func() {
 			return true
 		}),
 	)
+
+	Context("with containerDisk architectures", func() {
+		var resolver *fakeImageArchitectureResolver
+
+		newVMWithContainerDisk := func(image string) *v1.VirtualMachine {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.NodeSelector = map[string]string{k8sv1.LabelArchStable: "arm64"}
+			vmi.Spec.Domain.Devices.Disks = []v1.Disk{{Name: "containerdisk"}}
+			vmi.Spec.Volumes = []v1.Volume{{
+				Name: "containerdisk",
+				VolumeSource: v1.VolumeSource{
+					ContainerDisk: &v1.ContainerDiskSource{Image: image},
+				},
+			}}
+			return &v1.VirtualMachine{
+				Spec: v1.VirtualMachineSpec{
+					Running: &notRunning,
+					Template: &v1.VirtualMachineInstanceTemplateSpec{
+						Spec: vmi.Spec,
+					},
+				},
+			}
+		}
+
+		admit := func(operation admissionv1.Operation, oldVM, vm *v1.VirtualMachine) *admissionv1.AdmissionResponse {
+			vmBytes, err := json.Marshal(vm)
+			Expect(err).ToNot(HaveOccurred())
+			ar := &admissionv1.AdmissionReview{
+				Request: &admissionv1.AdmissionRequest{
+					Operation: operation,
+					Resource:  webhooks.VirtualMachineGroupVersionResource,
+					Object:    runtime.RawExtension{Raw: vmBytes},
+				},
+			}
+			if oldVM != nil {
+				oldVMBytes, err := json.Marshal(oldVM)
+				Expect(err).ToNot(HaveOccurred())
+				ar.Request.OldObject = runtime.RawExtension{Raw: oldVMBytes}
+			}
+			return vmsAdmitter.Admit(ar)
+		}
+
+		BeforeEach(func() {
+			resolver = &fakeImageArchitectureResolver{architectures: map[string][]string{
+				"quay.io/kubevirt/fedora":  {"amd64", "arm64"},
+				"quay.io/kubevirt/windows": {"amd64"},
+			}}
+			vmsAdmitter.ArchitectureResolver = resolver
+		})
+
+		It("should reject templates with images not available for the selected architecture", func() {
+			resp := admit(admissionv1.Create, nil, newVMWithContainerDisk("quay.io/kubevirt/windows"))
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Details.Causes).To(HaveLen(1))
+			Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.template.spec.volumes[0].containerDisk.image"))
+		})
+
+		It("should accept templates with images available for the selected architecture", func() {
+			resp := admit(admissionv1.Create, nil, newVMWithContainerDisk("quay.io/kubevirt/fedora"))
+			Expect(resp.Allowed).To(BeTrue())
+			Expect(resolver.lookups).To(Equal(1))
+		})
+
+		It("should not look up the images again on updates which keep them", func() {
+			oldVM := newVMWithContainerDisk("quay.io/kubevirt/fedora")
+			vm := oldVM.DeepCopy()
+			vm.Spec.Running = &[]bool{true}[0]
+			resp := admit(admissionv1.Update, oldVM, vm)
+			Expect(resp.Allowed).To(BeTrue())
+			Expect(resolver.lookups).To(BeZero())
+		})
+
+		It("should look up the images on updates which change them", func() {
+			resp := admit(admissionv1.Update, newVMWithContainerDisk("quay.io/kubevirt/fedora"), newVMWithContainerDisk("quay.io/kubevirt/windows"))
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resolver.lookups).To(Equal(1))
+		})
+	})
 })
 
 func makeCloneAdmitFunc(expectedSourceNamespace, expectedPVCName, expectedTargetNamespace, expectedServiceAccount string) CloneAuthFunc {
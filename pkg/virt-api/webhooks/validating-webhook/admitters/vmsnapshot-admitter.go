@@ -20,11 +20,14 @@
 package admitters
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"reflect"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	authv1 "k8s.io/api/authorization/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sfield "k8s.io/apimachinery/pkg/util/validation/field"
@@ -112,6 +115,16 @@ func (admitter *VMSnapshotAdmitter) Admit(ar *admissionv1.AdmissionReview) *admi
 			}
 		}
 
+		causes = append(causes, validateSnapshotOptions(k8sfield.NewPath("spec"), &vmSnapshot.Spec)...)
+
+		if len(causes) == 0 && vmSnapshot.Spec.Memory != nil && vmSnapshot.Spec.Memory.ObjectStorage != nil {
+			secretField := k8sfield.NewPath("spec", "memory", "objectStorage", "secretRef", "name")
+			causes, err = admitter.validateSecretAccess(secretField, ar.Request, vmSnapshot.Spec.Memory.ObjectStorage.SecretRef.Name)
+			if err != nil {
+				return webhookutils.ToAdmissionResponseError(err)
+			}
+		}
+
 	case admissionv1.Update:
 		prevObj := &snapshotv1.VirtualMachineSnapshot{}
 		err = json.Unmarshal(ar.Request.OldObject.Raw, prevObj)
@@ -160,3 +173,112 @@ func (admitter *VMSnapshotAdmitter) validateCreateVM(field *k8sfield.Path, names
 
 	return []metav1.StatusCause{}, nil
 }
+
+// validateSecretAccess makes sure that the user creating the snapshot may read the Secret
+// with the object storage credentials, since virt-controller reads it on behalf of the user
+func (admitter *VMSnapshotAdmitter) validateSecretAccess(field *k8sfield.Path, request *admissionv1.AdmissionRequest, secretName string) ([]metav1.StatusCause, error) {
+	extra := map[string]authv1.ExtraValue{}
+	for k, v := range request.UserInfo.Extra {
+		extra[k] = authv1.ExtraValue(v)
+	}
+	sar := &authv1.SubjectAccessReview{
+		Spec: authv1.SubjectAccessReviewSpec{
+			User:   request.UserInfo.Username,
+			Groups: request.UserInfo.Groups,
+			UID:    request.UserInfo.UID,
+			Extra:  extra,
+			ResourceAttributes: &authv1.ResourceAttributes{
+				Namespace: request.Namespace,
+				Verb:      "get",
+				Resource:  "secrets",
+				Name:      secretName,
+			},
+		},
+	}
+	result, err := admitter.Client.AuthorizationV1().SubjectAccessReviews().Create(context.Background(), sar, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	if !result.Status.Allowed {
+		return []metav1.StatusCause{
+			{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("user %q is not allowed to get Secret %q", request.UserInfo.Username, secretName),
+				Field:   field.String(),
+			},
+		}, nil
+	}
+
+	return []metav1.StatusCause{}, nil
+}
+
+func validateSnapshotOptions(field *k8sfield.Path, spec *snapshotv1.VirtualMachineSnapshotSpec) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+
+	if spec.QuiescePolicy != nil {
+		switch *spec.QuiescePolicy {
+		case snapshotv1.QuiescePolicyBestEffort, snapshotv1.QuiescePolicyRequired:
+		default:
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("invalid quiescePolicy %q", *spec.QuiescePolicy),
+				Field:   field.Child("quiescePolicy").String(),
+			})
+		}
+	}
+
+	if spec.TTL != nil && spec.TTL.Duration <= 0 {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "ttl must be positive",
+			Field:   field.Child("ttl").String(),
+		})
+	}
+
+	if spec.Memory != nil && spec.Memory.ObjectStorage != nil {
+		causes = append(causes, validateMemoryObjectStorage(field.Child("memory"), spec.Memory)...)
+	}
+
+	return causes
+}
+
+func validateMemoryObjectStorage(field *k8sfield.Path, memory *snapshotv1.MemorySnapshotSpec) []metav1.StatusCause {
+	var causes []metav1.StatusCause
+	storage := memory.ObjectStorage
+	storageField := field.Child("objectStorage")
+
+	if memory.StorageClassName != nil {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "storageClassName and objectStorage are mutually exclusive",
+			Field:   field.Child("storageClassName").String(),
+		})
+	}
+
+	if endpoint, err := url.Parse(storage.Endpoint); err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("endpoint %q is no http or https URL", storage.Endpoint),
+			Field:   storageField.Child("endpoint").String(),
+		})
+	}
+
+	if storage.Bucket == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: "bucket is required",
+			Field:   storageField.Child("bucket").String(),
+		})
+	}
+
+	if storage.SecretRef.Name == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: "secretRef name is required",
+			Field:   storageField.Child("secretRef", "name").String(),
+		})
+	}
+
+	return causes
+}
@@ -21,17 +21,23 @@ package admitters
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/testing"
 
 	v1 "kubevirt.io/client-go/api/v1"
 	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
@@ -269,10 +275,138 @@ var _ = Describe("Validating VirtualMachineSnapshot Admitter", func() {
 				resp := createTestVMSnapshotAdmitter(config, vm).Admit(ar)
 				Expect(resp.Allowed).To(BeTrue())
 			})
+
+			It("should accept valid snapshot options", func() {
+				policy := snapshotv1.QuiescePolicyRequired
+				snapshot := &snapshotv1.VirtualMachineSnapshot{
+					Spec: snapshotv1.VirtualMachineSnapshotSpec{
+						Source: corev1.TypedLocalObjectReference{
+							APIGroup: &apiGroup,
+							Kind:     "VirtualMachine",
+							Name:     vmName,
+						},
+						QuiescePolicy: &policy,
+						Memory:        &snapshotv1.MemorySnapshotSpec{},
+						TTL:           &metav1.Duration{Duration: time.Hour},
+					},
+				}
+
+				t := true
+				vm.Spec.Running = &t
+
+				ar := createSnapshotAdmissionReview(snapshot)
+				resp := createTestVMSnapshotAdmitter(config, vm).Admit(ar)
+				Expect(resp.Allowed).To(BeTrue())
+			})
+
+			table.DescribeTable("should check if the user may get the object storage Secret", func(allowed bool) {
+				snapshot := &snapshotv1.VirtualMachineSnapshot{
+					Spec: snapshotv1.VirtualMachineSnapshotSpec{
+						Source: corev1.TypedLocalObjectReference{
+							APIGroup: &apiGroup,
+							Kind:     "VirtualMachine",
+							Name:     vmName,
+						},
+						Memory: &snapshotv1.MemorySnapshotSpec{ObjectStorage: createMemoryObjectStorage()},
+					},
+				}
+
+				ar := createSnapshotAdmissionReview(snapshot)
+				ar.Request.UserInfo = authenticationv1.UserInfo{Username: "alice", Groups: []string{"dbas"}}
+				admitter := createTestVMSnapshotAdmitter(config, vm)
+				kubeClient := fake.NewSimpleClientset()
+				kubeClient.Fake.PrependReactor("create", "subjectaccessreviews", func(action testing.Action) (bool, runtime.Object, error) {
+					sar := action.(testing.CreateAction).GetObject().(*authv1.SubjectAccessReview)
+					Expect(sar.Spec.User).To(Equal("alice"))
+					Expect(sar.Spec.Groups).To(Equal([]string{"dbas"}))
+					Expect(sar.Spec.ResourceAttributes).To(Equal(&authv1.ResourceAttributes{
+						Namespace: "foo",
+						Verb:      "get",
+						Resource:  "secrets",
+						Name:      "s3-credentials",
+					}))
+					sar.Status.Allowed = allowed
+					return true, sar, nil
+				})
+				admitter.Client.(*kubecli.MockKubevirtClient).EXPECT().AuthorizationV1().Return(kubeClient.AuthorizationV1())
+
+				resp := admitter.Admit(ar)
+				Expect(resp.Allowed).To(Equal(allowed))
+				if !allowed {
+					Expect(resp.Result.Details.Causes).To(HaveLen(1))
+					Expect(resp.Result.Details.Causes[0].Field).To(Equal("spec.memory.objectStorage.secretRef.name"))
+				}
+			},
+				table.Entry("and accept it if the user may", true),
+				table.Entry("and reject it if the user may not", false),
+			)
+
+			table.DescribeTable("should reject invalid snapshot options", func(spec snapshotv1.VirtualMachineSnapshotSpec, field string) {
+				spec.Source = corev1.TypedLocalObjectReference{
+					APIGroup: &apiGroup,
+					Kind:     "VirtualMachine",
+					Name:     vmName,
+				}
+				snapshot := &snapshotv1.VirtualMachineSnapshot{
+					Spec: spec,
+				}
+
+				ar := createSnapshotAdmissionReview(snapshot)
+				resp := createTestVMSnapshotAdmitter(config, vm).Admit(ar)
+				Expect(resp.Allowed).To(BeFalse())
+				Expect(resp.Result.Details.Causes).To(HaveLen(1))
+				Expect(resp.Result.Details.Causes[0].Field).To(Equal(field))
+			},
+				table.Entry("with unknown quiesce policy",
+					snapshotv1.VirtualMachineSnapshotSpec{QuiescePolicy: &[]snapshotv1.QuiescePolicy{"Sometimes"}[0]}, "spec.quiescePolicy"),
+				table.Entry("with zero ttl",
+					snapshotv1.VirtualMachineSnapshotSpec{TTL: &metav1.Duration{}}, "spec.ttl"),
+				table.Entry("with negative ttl",
+					snapshotv1.VirtualMachineSnapshotSpec{TTL: &metav1.Duration{Duration: -time.Minute}}, "spec.ttl"),
+				table.Entry("with storage class and object storage",
+					snapshotv1.VirtualMachineSnapshotSpec{Memory: &snapshotv1.MemorySnapshotSpec{
+						StorageClassName: &[]string{"local"}[0],
+						ObjectStorage:    createMemoryObjectStorage(),
+					}}, "spec.memory.storageClassName"),
+				table.Entry("with object storage endpoint without scheme",
+					snapshotv1.VirtualMachineSnapshotSpec{Memory: &snapshotv1.MemorySnapshotSpec{
+						ObjectStorage: func() *snapshotv1.MemoryObjectStorage {
+							storage := createMemoryObjectStorage()
+							storage.Endpoint = "s3.example.com"
+							return storage
+						}(),
+					}}, "spec.memory.objectStorage.endpoint"),
+				table.Entry("with object storage without bucket",
+					snapshotv1.VirtualMachineSnapshotSpec{Memory: &snapshotv1.MemorySnapshotSpec{
+						ObjectStorage: func() *snapshotv1.MemoryObjectStorage {
+							storage := createMemoryObjectStorage()
+							storage.Bucket = ""
+							return storage
+						}(),
+					}}, "spec.memory.objectStorage.bucket"),
+				table.Entry("with object storage without secret",
+					snapshotv1.VirtualMachineSnapshotSpec{Memory: &snapshotv1.MemorySnapshotSpec{
+						ObjectStorage: func() *snapshotv1.MemoryObjectStorage {
+							storage := createMemoryObjectStorage()
+							storage.SecretRef.Name = ""
+							return storage
+						}(),
+					}}, "spec.memory.objectStorage.secretRef.name"),
+			)
 		})
 	})
 })
 
+func createMemoryObjectStorage() *snapshotv1.MemoryObjectStorage {
+	return &snapshotv1.MemoryObjectStorage{
+		Endpoint: "https://s3.example.com",
+		Bucket:   "snapshots",
+		SecretRef: corev1.LocalObjectReference{
+			Name: "s3-credentials",
+		},
+	}
+}
+
 func createSnapshotAdmissionReview(snapshot *snapshotv1.VirtualMachineSnapshot) *admissionv1.AdmissionReview {
 	bytes, _ := json.Marshal(snapshot)
 
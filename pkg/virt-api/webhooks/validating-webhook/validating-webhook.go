@@ -23,13 +23,24 @@ import (
 	"net/http"
 
 	"kubevirt.io/client-go/kubecli"
+	containerdisk "kubevirt.io/kubevirt/pkg/container-disk"
 	validating_webhooks "kubevirt.io/kubevirt/pkg/util/webhooks/validating-webhooks"
 	"kubevirt.io/kubevirt/pkg/virt-api/webhooks/validating-webhook/admitters"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
-func ServeVMICreate(resp http.ResponseWriter, req *http.Request, clusterConfig *virtconfig.ClusterConfig) {
-	validating_webhooks.Serve(resp, req, &admitters.VMICreateAdmitter{ClusterConfig: clusterConfig})
+var (
+	imageSignatureVerifier    = containerdisk.NewImageSignatureVerifier()
+	imageArchitectureResolver = containerdisk.NewImageArchitectureResolver()
+)
+
+func ServeVMICreate(resp http.ResponseWriter, req *http.Request, clusterConfig *virtconfig.ClusterConfig, virtCli kubecli.KubevirtClient) {
+	validating_webhooks.Serve(resp, req, &admitters.VMICreateAdmitter{
+		ClusterConfig:        clusterConfig,
+		VirtClient:           virtCli,
+		SignatureVerifier:    imageSignatureVerifier,
+		ArchitectureResolver: imageArchitectureResolver,
+	})
 }
 
 func ServeVMIUpdate(resp http.ResponseWriter, req *http.Request, clusterConfig *virtconfig.ClusterConfig) {
@@ -37,7 +48,9 @@ func ServeVMIUpdate(resp http.ResponseWriter, req *http.Request, clusterConfig *
 }
 
 func ServeVMs(resp http.ResponseWriter, req *http.Request, clusterConfig *virtconfig.ClusterConfig, virtCli kubecli.KubevirtClient) {
-	validating_webhooks.Serve(resp, req, admitters.NewVMsAdmitter(clusterConfig, virtCli))
+	admitter := admitters.NewVMsAdmitter(clusterConfig, virtCli)
+	admitter.ArchitectureResolver = imageArchitectureResolver
+	validating_webhooks.Serve(resp, req, admitter)
 }
 
 func ServeVMIRS(resp http.ResponseWriter, req *http.Request, clusterConfig *virtconfig.ClusterConfig) {
@@ -64,6 +77,10 @@ func ServeVMRestores(resp http.ResponseWriter, req *http.Request, clusterConfig
 	validating_webhooks.Serve(resp, req, admitters.NewVMRestoreAdmitter(clusterConfig, virtCli))
 }
 
+func ServeVMImageExports(resp http.ResponseWriter, req *http.Request, clusterConfig *virtconfig.ClusterConfig, virtCli kubecli.KubevirtClient) {
+	validating_webhooks.Serve(resp, req, admitters.NewVMImageExportAdmitter(clusterConfig, virtCli))
+}
+
 func ServeStatusValidation(resp http.ResponseWriter, req *http.Request, clusterConfig *virtconfig.ClusterConfig, virtCli kubecli.KubevirtClient) {
 	validating_webhooks.Serve(resp, req, &admitters.StatusAdmitter{
 		VmsAdmitter: admitters.NewVMsAdmitter(clusterConfig, virtCli),
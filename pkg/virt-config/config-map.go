@@ -184,10 +184,15 @@ func defaultClusterConfig(cpuArch string) *v1.KubeVirtConfiguration {
 	defaultUnsafeMigrationOverride := DefaultUnsafeMigrationOverride
 	progressTimeout := MigrationProgressTimeout
 	completionTimeoutPerGiB := MigrationCompletionTimeoutPerGiB
+	hostPassthroughPolicy := MigrationHostPassthroughPolicy
+	preSwitchoverDrainSeconds := MigrationPreSwitchoverDrainSeconds
 	cpuRequestDefault := resource.MustParse(DefaultCPURequest)
 	nodeSelectorsDefault, _ := parseNodeSelectors(DefaultNodeSelectors)
 	defaultNetworkInterface := DefaultNetworkInterface
 	defaultMemBalloonStatsPeriod := DefaultMemBalloonStatsPeriod
+	cloudInitUserDataMaxBytesDefault := DefaultCloudInitUserDataMaxBytes
+	cloudInitNetworkDataMaxBytesDefault := DefaultCloudInitNetworkDataMaxBytes
+	maxListLengthDefault := DefaultMaxListLength
 	SmbiosDefaultConfig := &v1.SMBiosConfiguration{
 		Family:       SmbiosConfigDefaultFamily,
 		Manufacturer: SmbiosConfigDefaultManufacturer,
@@ -227,6 +232,8 @@ func defaultClusterConfig(cpuArch string) *v1.KubeVirtConfiguration {
 			UnsafeMigrationOverride:           &defaultUnsafeMigrationOverride,
 			AllowAutoConverge:                 &allowAutoConverge,
 			AllowPostCopy:                     &allowPostCopy,
+			HostPassthroughPolicy:             &hostPassthroughPolicy,
+			PreSwitchoverDrainSeconds:         &preSwitchoverDrainSeconds,
 		},
 		MachineType:      DefaultMachineType,
 		CPURequest:       &cpuRequestDefault,
@@ -265,6 +272,11 @@ func defaultClusterConfig(cpuArch string) *v1.KubeVirtConfiguration {
 				Burst: DefaultVirtWebhookClientBurst,
 			}}},
 		},
+		AdmissionLimits: &v1.AdmissionLimits{
+			CloudInitUserDataMaxBytes:    &cloudInitUserDataMaxBytesDefault,
+			CloudInitNetworkDataMaxBytes: &cloudInitNetworkDataMaxBytesDefault,
+			MaxListLength:                &maxListLengthDefault,
+		},
 	}
 }
 
@@ -293,16 +305,18 @@ func (c *ClusterConfig) SetConfigModifiedCallback(cb ConfigModifiedFn) {
 
 // This struct is for backward compatibility and is deprecated, no new fields should be added
 type migrationConfiguration struct {
-	NodeDrainTaintKey                 *string            `json:"nodeDrainTaintKey,omitempty"`
-	ParallelOutboundMigrationsPerNode *uint32            `json:"parallelOutboundMigrationsPerNode,string,omitempty"`
-	ParallelMigrationsPerCluster      *uint32            `json:"parallelMigrationsPerCluster,string,omitempty"`
-	AllowAutoConverge                 *bool              `json:"allowAutoConverge,string,omitempty"`
-	BandwidthPerMigration             *resource.Quantity `json:"bandwidthPerMigration,omitempty"`
-	CompletionTimeoutPerGiB           *int64             `json:"completionTimeoutPerGiB,string,omitempty"`
-	ProgressTimeout                   *int64             `json:"progressTimeout,string,omitempty"`
-	UnsafeMigrationOverride           *bool              `json:"unsafeMigrationOverride,string,omitempty"`
-	AllowPostCopy                     *bool              `json:"allowPostCopy,string,omitempty"`
-	DisableTLS                        *bool              `json:"disableTLS,omitempty"`
+	NodeDrainTaintKey                 *string                            `json:"nodeDrainTaintKey,omitempty"`
+	ParallelOutboundMigrationsPerNode *uint32                            `json:"parallelOutboundMigrationsPerNode,string,omitempty"`
+	ParallelMigrationsPerCluster      *uint32                            `json:"parallelMigrationsPerCluster,string,omitempty"`
+	AllowAutoConverge                 *bool                              `json:"allowAutoConverge,string,omitempty"`
+	BandwidthPerMigration             *resource.Quantity                 `json:"bandwidthPerMigration,omitempty"`
+	CompletionTimeoutPerGiB           *int64                             `json:"completionTimeoutPerGiB,string,omitempty"`
+	ProgressTimeout                   *int64                             `json:"progressTimeout,string,omitempty"`
+	UnsafeMigrationOverride           *bool                              `json:"unsafeMigrationOverride,string,omitempty"`
+	AllowPostCopy                     *bool                              `json:"allowPostCopy,string,omitempty"`
+	DisableTLS                        *bool                              `json:"disableTLS,omitempty"`
+	HostPassthroughPolicy             *v1.HostPassthroughMigrationPolicy `json:"hostPassthroughPolicy,omitempty"`
+	PreSwitchoverDrainSeconds         *int64                             `json:"preSwitchoverDrainSeconds,string,omitempty"`
 }
 
 // setConfigFromConfigMap parses the provided config map and updates the provided config.
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"strings"
 	"sync"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	"github.com/onsi/ginkgo/extensions/table"
@@ -466,6 +467,159 @@ var _ = Describe("ConfigMap", func() {
 			`{"defaultNetworkInterface":"test","permitSlirpInterface":true,"permitBridgeInterfaceOnPodNetwork":false}`),
 	)
 
+	table.DescribeTable("when the additional guest memory overhead ratio", func(ratio *string, expected float64) {
+		clusterConfig, _, _, _ := testutils.NewFakeClusterConfigUsingKV(&v1.KubeVirt{
+			ObjectMeta: metav1.ObjectMeta{
+				ResourceVersion: rand.String(10),
+				Name:            "kubevirt",
+				Namespace:       "kubevirt",
+			},
+			Spec: v1.KubeVirtSpec{
+				Configuration: v1.KubeVirtConfiguration{
+					AdditionalGuestMemoryOverheadRatio: ratio,
+				},
+			},
+			Status: v1.KubeVirtStatus{
+				Phase: v1.KubeVirtPhaseDeploying,
+			},
+		})
+		Expect(clusterConfig.GetAdditionalGuestMemoryOverheadRatio()).To(Equal(expected))
+	},
+		table.Entry("is unset, should return the default", nil, virtconfig.DefaultAdditionalGuestMemoryOverheadRatio),
+		table.Entry("is set, should return the value", pointer.StringPtr("1.5"), 1.5),
+		table.Entry("is lower than 1, should return the default", pointer.StringPtr("0.5"), virtconfig.DefaultAdditionalGuestMemoryOverheadRatio),
+		table.Entry("is invalid, should return the default", pointer.StringPtr("invalid"), virtconfig.DefaultAdditionalGuestMemoryOverheadRatio),
+	)
+
+	table.DescribeTable("when the node shutdown grace period", func(gracePeriod *int64, expected time.Duration) {
+		clusterConfig, _, _, _ := testutils.NewFakeClusterConfigUsingKV(&v1.KubeVirt{
+			ObjectMeta: metav1.ObjectMeta{
+				ResourceVersion: rand.String(10),
+				Name:            "kubevirt",
+				Namespace:       "kubevirt",
+			},
+			Spec: v1.KubeVirtSpec{
+				Configuration: v1.KubeVirtConfiguration{
+					NodeShutdownGracePeriodSeconds: gracePeriod,
+				},
+			},
+			Status: v1.KubeVirtStatus{
+				Phase: v1.KubeVirtPhaseDeploying,
+			},
+		})
+		Expect(clusterConfig.GetNodeShutdownGracePeriod()).To(Equal(expected))
+	},
+		table.Entry("is unset, should not delay the shutdown", nil, time.Duration(0)),
+		table.Entry("is set, should return the value", pointer.Int64Ptr(300), 5*time.Minute),
+		table.Entry("is negative, should not delay the shutdown", pointer.Int64Ptr(-1), time.Duration(0)),
+	)
+
+	table.DescribeTable("when the admission limits", func(limits *v1.AdmissionLimits, userData, networkData, listLength int) {
+		clusterConfig, _, _, _ := testutils.NewFakeClusterConfigUsingKV(&v1.KubeVirt{
+			ObjectMeta: metav1.ObjectMeta{
+				ResourceVersion: rand.String(10),
+				Name:            "kubevirt",
+				Namespace:       "kubevirt",
+			},
+			Spec: v1.KubeVirtSpec{
+				Configuration: v1.KubeVirtConfiguration{
+					AdmissionLimits: limits,
+				},
+			},
+			Status: v1.KubeVirtStatus{
+				Phase: v1.KubeVirtPhaseDeploying,
+			},
+		})
+		Expect(clusterConfig.GetCloudInitUserDataMaxBytes()).To(Equal(userData))
+		Expect(clusterConfig.GetCloudInitNetworkDataMaxBytes()).To(Equal(networkData))
+		Expect(clusterConfig.GetMaxListLength()).To(Equal(listLength))
+	},
+		table.Entry("are unset, should return the defaults", nil,
+			virtconfig.DefaultCloudInitUserDataMaxBytes, virtconfig.DefaultCloudInitNetworkDataMaxBytes, virtconfig.DefaultMaxListLength),
+		table.Entry("are partially set, should keep the defaults of the others", &v1.AdmissionLimits{CloudInitUserDataMaxBytes: intPtr(8192)},
+			8192, virtconfig.DefaultCloudInitNetworkDataMaxBytes, virtconfig.DefaultMaxListLength),
+		table.Entry("are set, should return the values", &v1.AdmissionLimits{CloudInitUserDataMaxBytes: intPtr(8192), CloudInitNetworkDataMaxBytes: intPtr(4096), MaxListLength: intPtr(512)},
+			8192, 4096, 512),
+	)
+
+	table.DescribeTable("when the node bandwidth", func(networkConfig *v1.NetworkConfiguration, expected int) {
+		clusterConfig, _, _, _ := testutils.NewFakeClusterConfigUsingKV(&v1.KubeVirt{
+			ObjectMeta: metav1.ObjectMeta{
+				ResourceVersion: rand.String(10),
+				Name:            "kubevirt",
+				Namespace:       "kubevirt",
+			},
+			Spec: v1.KubeVirtSpec{
+				Configuration: v1.KubeVirtConfiguration{
+					NetworkConfiguration: networkConfig,
+				},
+			},
+			Status: v1.KubeVirtStatus{
+				Phase: v1.KubeVirtPhaseDeploying,
+			},
+		})
+		Expect(clusterConfig.GetNodeBandwidthDevices("node01")).To(Equal(expected))
+	},
+		table.Entry("is unset, should have no devices", nil, 0),
+		table.Entry("is set, should round down to whole devices", &v1.NetworkConfiguration{
+			NodeBandwidth: resource.NewScaledQuantity(2550, resource.Mega),
+		}, 25),
+		table.Entry("is overridden for the node, should use the override", &v1.NetworkConfiguration{
+			NodeBandwidth:          resource.NewScaledQuantity(25, resource.Giga),
+			NodeBandwidthOverrides: map[string]resource.Quantity{"node01": *resource.NewScaledQuantity(10, resource.Giga)},
+		}, 100),
+		table.Entry("is overridden for other nodes, should use the default", &v1.NetworkConfiguration{
+			NodeBandwidth:          resource.NewScaledQuantity(25, resource.Giga),
+			NodeBandwidthOverrides: map[string]resource.Quantity{"node02": *resource.NewScaledQuantity(10, resource.Giga)},
+		}, 250),
+	)
+
+	table.DescribeTable("when launcher pod metadata propagation", func(propagation *v1.LauncherPodMetadataPropagation, key string, labelPropagated, annotationPropagated bool) {
+		clusterConfig, _, _, _ := testutils.NewFakeClusterConfigUsingKV(&v1.KubeVirt{
+			ObjectMeta: metav1.ObjectMeta{
+				ResourceVersion: rand.String(10),
+				Name:            "kubevirt",
+				Namespace:       "kubevirt",
+			},
+			Spec: v1.KubeVirtSpec{
+				Configuration: v1.KubeVirtConfiguration{
+					LauncherPodMetadataPropagation: propagation,
+				},
+			},
+			Status: v1.KubeVirtStatus{
+				Phase: v1.KubeVirtPhaseDeploying,
+			},
+		})
+		Expect(clusterConfig.IsLauncherPodLabelPropagated(key)).To(Equal(labelPropagated))
+		Expect(clusterConfig.IsLauncherPodAnnotationPropagated(key)).To(Equal(annotationPropagated))
+	},
+		table.Entry("is unset, should propagate everything", nil, "example.com/key", true, true),
+		table.Entry("is empty, should propagate nothing", &v1.LauncherPodMetadataPropagation{}, "example.com/key", false, false),
+		table.Entry("allows the exact key, should propagate it",
+			&v1.LauncherPodMetadataPropagation{AllowedLabels: []string{"example.com/key"}}, "example.com/key", true, false),
+		table.Entry("allows a different key, should not propagate it",
+			&v1.LauncherPodMetadataPropagation{AllowedLabels: []string{"example.com/other"}, AllowedAnnotations: []string{"example.com/other"}}, "example.com/key", false, false),
+		table.Entry("allows a matching prefix, should propagate it",
+			&v1.LauncherPodMetadataPropagation{AllowedAnnotations: []string{"example.com/*"}}, "example.com/key", false, true),
+		table.Entry("allows everything, should propagate it",
+			&v1.LauncherPodMetadataPropagation{AllowedLabels: []string{"*"}, AllowedAnnotations: []string{"*"}}, "example.com/key", true, true),
+	)
+
+	table.DescribeTable("when validating a metadata key pattern", func(pattern string, valid bool) {
+		err := virtconfig.ValidateMetadataKeyPattern(pattern)
+		if valid {
+			Expect(err).ToNot(HaveOccurred())
+		} else {
+			Expect(err).To(HaveOccurred())
+		}
+	},
+		table.Entry("with a qualified name, should accept it", "example.com/key", true),
+		table.Entry("with a prefix, should accept it", "example.com/*", true),
+		table.Entry("with a wildcard only, should accept it", "*", true),
+		table.Entry("with a wildcard in the middle, should reject it", "example.*/key", false),
+		table.Entry("with an invalid name, should reject it", "not a key", false),
+	)
+
 	It("should use configmap value over kubevirt configuration", func() {
 		clusterConfig, cminformer, _, _ := testutils.NewFakeClusterConfigUsingKV(&v1.KubeVirt{
 			ObjectMeta: metav1.ObjectMeta{
@@ -518,4 +672,43 @@ var _ = Describe("ConfigMap", func() {
 		table.Entry("LiveMigration is open, SRIOVLiveMigration should be close",
 			virtconfig.LiveMigrationGate, true, false),
 	)
+
+	table.DescribeTable("when a feature gate is enabled for namespaces", func(devConfig *v1.DeveloperConfiguration, featureGate, namespace string, enabled, enabledForNamespace bool) {
+		clusterConfig, _, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+			DeveloperConfiguration: devConfig,
+		})
+
+		Expect(clusterConfig.HostDevicesPassthroughEnabled()).To(Equal(enabled))
+		Expect(clusterConfig.IsFeatureGateEnabledForNamespace(featureGate, namespace)).To(Equal(enabledForNamespace))
+	},
+		table.Entry("should be enabled for all namespaces if the gate is enabled cluster-wide",
+			&v1.DeveloperConfiguration{
+				FeatureGates: []string{virtconfig.HostDevicesGate},
+			}, virtconfig.HostDevicesGate, "ns1", true, true),
+		table.Entry("should be enabled for an allow-listed namespace",
+			&v1.DeveloperConfiguration{
+				NamespacedFeatureGates: []v1.NamespacedFeatureGate{
+					{Name: virtconfig.HostDevicesGate, Namespaces: []string{"ns1", "ns2"}},
+				},
+			}, virtconfig.HostDevicesGate, "ns2", true, true),
+		table.Entry("should not be enabled for other namespaces",
+			&v1.DeveloperConfiguration{
+				NamespacedFeatureGates: []v1.NamespacedFeatureGate{
+					{Name: virtconfig.HostDevicesGate, Namespaces: []string{"ns1"}},
+				},
+			}, virtconfig.HostDevicesGate, "ns2", true, false),
+		table.Entry("should ignore gates which can't be enabled per namespace",
+			&v1.DeveloperConfiguration{
+				NamespacedFeatureGates: []v1.NamespacedFeatureGate{
+					{Name: virtconfig.HostDevicesGate, Namespaces: []string{"ns1"}},
+					{Name: virtconfig.SnapshotGate, Namespaces: []string{"ns1"}},
+				},
+			}, virtconfig.SnapshotGate, "ns1", true, false),
+		table.Entry("should be disabled if no gate is set",
+			&v1.DeveloperConfiguration{}, virtconfig.HostDevicesGate, "ns1", false, false),
+	)
 })
+
+func intPtr(i int) *int {
+	return &i
+}
@@ -42,14 +42,69 @@ const (
 	MacvtapGate                = "Macvtap"
 	DownwardMetricsFeatureGate = "DownwardMetrics"
 	NonRoot                    = "NonRootExperimental"
+	NetworkDisksGate           = "NetworkDisks"
+	InterfaceMirroringGate     = "InterfaceMirroring"
+	ImageExportGate            = "ImageExport"
 )
 
+// namespacedFeatureGates are the feature gates which can be enabled for a set of namespaces only.
+// The validating webhook rejects VMIs and VMs of other namespaces which use the feature.
+var namespacedFeatureGates = map[string]bool{
+	GPUGate:                    true,
+	HostDevicesGate:            true,
+	HostDiskGate:               true,
+	VirtIOFSGate:               true,
+	MacvtapGate:                true,
+	DownwardMetricsFeatureGate: true,
+	NetworkDisksGate:           true,
+	InterfaceMirroringGate:     true,
+}
+
+// IsNamespacedFeatureGateSupported returns whether a feature gate can be enabled for a set of namespaces only
+func IsNamespacedFeatureGateSupported(featureGate string) bool {
+	return namespacedFeatureGates[featureGate]
+}
+
+// isFeatureGateEnabled returns whether a feature gate is enabled for the whole cluster or for some namespaces.
 func (c *ClusterConfig) isFeatureGateEnabled(featureGate string) bool {
-	for _, fg := range c.GetConfig().DeveloperConfiguration.FeatureGates {
+	devConfig := c.GetConfig().DeveloperConfiguration
+	for _, fg := range devConfig.FeatureGates {
 		if fg == featureGate {
 			return true
 		}
 	}
+	if !namespacedFeatureGates[featureGate] {
+		return false
+	}
+	for _, fg := range devConfig.NamespacedFeatureGates {
+		if fg.Name == featureGate {
+			return true
+		}
+	}
+	return false
+}
+
+// IsFeatureGateEnabledForNamespace returns whether the VMIs and VMs of a namespace may use the feature of a gate
+func (c *ClusterConfig) IsFeatureGateEnabledForNamespace(featureGate string, namespace string) bool {
+	devConfig := c.GetConfig().DeveloperConfiguration
+	for _, fg := range devConfig.FeatureGates {
+		if fg == featureGate {
+			return true
+		}
+	}
+	if !namespacedFeatureGates[featureGate] {
+		return false
+	}
+	for _, fg := range devConfig.NamespacedFeatureGates {
+		if fg.Name != featureGate {
+			continue
+		}
+		for _, ns := range fg.Namespaces {
+			if ns == namespace {
+				return true
+			}
+		}
+	}
 	return false
 }
 
@@ -121,3 +176,15 @@ func (config *ClusterConfig) HostDevicesPassthroughEnabled() bool {
 func (config *ClusterConfig) NonRootEnabled() bool {
 	return config.isFeatureGateEnabled(NonRoot)
 }
+
+func (config *ClusterConfig) NetworkDisksEnabled() bool {
+	return config.isFeatureGateEnabled(NetworkDisksGate)
+}
+
+func (config *ClusterConfig) InterfaceMirroringEnabled() bool {
+	return config.isFeatureGateEnabled(InterfaceMirroringGate)
+}
+
+func (config *ClusterConfig) ImageExportEnabled() bool {
+	return config.isFeatureGateEnabled(ImageExportGate)
+}
@@ -0,0 +1,120 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package virtconfig
+
+/*
+ All configuration settings are applied by the KubeVirt components at runtime through the ClusterConfig,
+ except the settings listed here, which are only evaluated when a component starts.
+*/
+
+import (
+	"encoding/json"
+	"sort"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+const (
+	VirtualMachineInstancesPerNodeSetting = "virtualMachineInstancesPerNode"
+)
+
+type settingValueFn func(config *v1.KubeVirtConfiguration) interface{}
+
+// restartRequiredSettings maps the settings which require a restart of the KubeVirt components
+// to a function extracting their value from the configuration
+var restartRequiredSettings = map[string]settingValueFn{
+	// handed over to virt-handler as a command line argument
+	VirtualMachineInstancesPerNodeSetting: func(config *v1.KubeVirtConfiguration) interface{} {
+		return config.VirtualMachineInstancesPerNode
+	},
+}
+
+// runtimeSettings lists the settings which the components apply without a restart. Every setting of the
+// KubeVirtConfiguration is either listed here or in restartRequiredSettings.
+// Settings which end up in the virt-launcher pod or the domain only apply to VMIs started afterwards,
+// running VMIs are not reported as unapplied configuration.
+var runtimeSettings = map[string]bool{
+	// read by the components whenever they are used
+	"cpuModel":                           true,
+	"cpuRequest":                         true,
+	"developerConfiguration":             true,
+	"emulatedMachines":                   true,
+	"imagePullPolicy":                    true,
+	"migrations":                         true,
+	"machineType":                        true,
+	"network":                            true,
+	"ovmfPath":                           true,
+	"selinuxLauncherType":                true,
+	"defaultRuntimeClass":                true,
+	"smbios":                             true,
+	"supportedGuestAgentVersions":        true,
+	"memBalloonStatsPeriod":              true,
+	"imagePullSecrets":                   true,
+	"imageRegistryMirrors":               true,
+	"additionalGuestMemoryOverheadRatio": true,
+	"launcherPodMetadataPropagation":     true,
+	"nodeShutdownGracePeriodSeconds":     true,
+	"auxiliaryThreadsCPURequests":        true,
+	"containerDiskPolicy":                true,
+	"accountingConfiguration":            true,
+	"admissionLimits":                    true,
+	"qemuOptionsAllowlist":               true,
+	// reloaded by virt-handler through config modified callbacks
+	"permittedHostDevices":         true,
+	"mediatedDevicesConfiguration": true,
+	"minCPUModel":                  true,
+	"obsoleteCPUModels":            true,
+	// log verbosity and rate limiters are reloaded by each component through config modified callbacks
+	"apiConfiguration":        true,
+	"webhookConfiguration":    true,
+	"controllerConfiguration": true,
+	"handlerConfiguration":    true,
+}
+
+// GetRestartRequiredSettings returns the json encoded values of all settings of the provided
+// configuration, which are only applied when the KubeVirt components are restarted
+func GetRestartRequiredSettings(config *v1.KubeVirtConfiguration) map[string]string {
+	settings := map[string]string{}
+	for name, value := range restartRequiredSettings {
+		encoded, err := json.Marshal(value(config))
+		if err != nil {
+			continue
+		}
+		settings[name] = string(encoded)
+	}
+	return settings
+}
+
+// GetChangedSettings returns the sorted names of the settings which differ between the applied and the desired settings
+func GetChangedSettings(applied, desired map[string]string) []string {
+	var changed []string
+	for name, value := range desired {
+		if appliedValue, exists := applied[name]; !exists || appliedValue != value {
+			changed = append(changed, name)
+		}
+	}
+	for name := range applied {
+		if _, exists := desired[name]; !exists {
+			changed = append(changed, name)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
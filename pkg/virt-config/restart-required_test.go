@@ -0,0 +1,69 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package virtconfig
+
+import (
+	"reflect"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("Restart required settings", func() {
+
+	intPtr := func(i int) *int {
+		return &i
+	}
+
+	It("should encode unset settings as null", func() {
+		settings := GetRestartRequiredSettings(&v1.KubeVirtConfiguration{})
+		Expect(settings).To(HaveKeyWithValue(VirtualMachineInstancesPerNodeSetting, "null"))
+	})
+
+	It("should encode set settings as json", func() {
+		settings := GetRestartRequiredSettings(&v1.KubeVirtConfiguration{
+			VirtualMachineInstancesPerNode: intPtr(10),
+		})
+		Expect(settings).To(HaveKeyWithValue(VirtualMachineInstancesPerNodeSetting, "10"))
+	})
+
+	It("should know for every setting if it requires a restart", func() {
+		configType := reflect.TypeOf(v1.KubeVirtConfiguration{})
+		for i := 0; i < configType.NumField(); i++ {
+			name := strings.Split(configType.Field(i).Tag.Get("json"), ",")[0]
+			_, restartRequired := restartRequiredSettings[name]
+			Expect(restartRequired).ToNot(Equal(runtimeSettings[name]), "setting %s has to be classified exactly once", name)
+		}
+	})
+
+	table.DescribeTable("should detect changed settings", func(applied, desired map[string]string, expected []string) {
+		Expect(GetChangedSettings(applied, desired)).To(Equal(expected))
+	},
+		table.Entry("with equal settings", map[string]string{"a": "1"}, map[string]string{"a": "1"}, nil),
+		table.Entry("with a changed value", map[string]string{"a": "1", "b": "1"}, map[string]string{"a": "2", "b": "1"}, []string{"a"}),
+		table.Entry("with an added setting", map[string]string{}, map[string]string{"b": "1"}, []string{"b"}),
+		table.Entry("with a removed setting", map[string]string{"b": "1"}, nil, []string{"b"}),
+		table.Entry("with multiple changes in sorted order", map[string]string{"c": "1"}, map[string]string{"a": "1", "b": "2"}, []string{"a", "b", "c"}),
+	)
+})
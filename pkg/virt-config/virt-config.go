@@ -24,8 +24,15 @@ package virtconfig
 */
 
 import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
 	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	v1 "kubevirt.io/client-go/api/v1"
 )
@@ -38,6 +45,8 @@ const (
 	MigrationAllowPostCopy                   bool   = false
 	MigrationProgressTimeout                 int64  = 150
 	MigrationCompletionTimeoutPerGiB         int64  = 800
+	MigrationHostPassthroughPolicy                  = v1.HostPassthroughMigrationAllow
+	MigrationPreSwitchoverDrainSeconds       int64  = 0
 	DefaultAMD64MachineType                         = "q35"
 	DefaultPPC64LEMachineType                       = "pseries"
 	DefaultAARCH64MachineType                       = "virt"
@@ -80,8 +89,20 @@ const (
 	DefaultVirtAPIBurst                   = 10
 	DefaultVirtWebhookClientQPS           = 200
 	DefaultVirtWebhookClientBurst         = 400
+
+	// Default ratio the computed memory overhead of virt-launcher pods is multiplied with
+	DefaultAdditionalGuestMemoryOverheadRatio = 1.0
+
+	// Default size limits VMIs are validated against
+	DefaultCloudInitUserDataMaxBytes    = 2048
+	DefaultCloudInitNetworkDataMaxBytes = 2048
+	DefaultMaxListLength                = 256
 )
 
+// BandwidthDeviceUnit is the bandwidth in bits per second which each device of the
+// bandwidth resource of the nodes stands for
+const BandwidthDeviceUnit = 100 * 1000 * 1000
+
 func IsAMD64(arch string) bool {
 	if arch == "amd64" {
 		return true
@@ -177,6 +198,23 @@ func (c *ClusterConfig) IsBridgeInterfaceOnPodNetworkEnabled() bool {
 	return *c.GetConfig().NetworkConfiguration.PermitBridgeInterfaceOnPodNetwork
 }
 
+// GetNodeBandwidthDevices returns the number of bandwidth devices the node has for VMIs to reserve,
+// the bandwidth is rounded down to whole devices
+func (c *ClusterConfig) GetNodeBandwidthDevices(nodeName string) int {
+	networkConfig := c.GetConfig().NetworkConfiguration
+	if networkConfig == nil {
+		return 0
+	}
+	bandwidth := networkConfig.NodeBandwidth
+	if override, exists := networkConfig.NodeBandwidthOverrides[nodeName]; exists {
+		bandwidth = &override
+	}
+	if bandwidth == nil || bandwidth.Sign() <= 0 {
+		return 0
+	}
+	return int(bandwidth.Value() / BandwidthDeviceUnit)
+}
+
 func (c *ClusterConfig) GetDefaultClusterConfig() *v1.KubeVirtConfiguration {
 	return c.defaultConfig
 }
@@ -209,6 +247,137 @@ func (c *ClusterConfig) GetPermittedHostDevices() *v1.PermittedHostDevices {
 	return c.GetConfig().PermittedHostDevices
 }
 
+func (c *ClusterConfig) GetImagePullSecrets() []k8sv1.LocalObjectReference {
+	return c.GetConfig().ImagePullSecrets
+}
+
+func (c *ClusterConfig) GetImageRegistryMirrors() []v1.ImageRegistryMirror {
+	return c.GetConfig().ImageRegistryMirrors
+}
+
+func (c *ClusterConfig) GetContainerDiskPolicy() *v1.ContainerDiskPolicy {
+	return c.GetConfig().ContainerDiskPolicy
+}
+
+func (c *ClusterConfig) GetQEMUOptionsAllowlist() *v1.QEMUOptionsAllowlist {
+	return c.GetConfig().QEMUOptionsAllowlist
+}
+
+func (c *ClusterConfig) GetAccountingConfiguration() *v1.AccountingConfiguration {
+	return c.GetConfig().AccountingConfiguration
+}
+
+// GetAccountingLabels returns the VM labels which are attached to the per-VMI metrics
+func (c *ClusterConfig) GetAccountingLabels() []string {
+	if accounting := c.GetAccountingConfiguration(); accounting != nil {
+		return accounting.Labels
+	}
+	return nil
+}
+
+// IsLauncherPodLabelPropagated returns true if the given label may be propagated to virt-launcher pods
+func (c *ClusterConfig) IsLauncherPodLabelPropagated(key string) bool {
+	propagation := c.GetConfig().LauncherPodMetadataPropagation
+	return propagation == nil || MatchesMetadataKeyPattern(propagation.AllowedLabels, key)
+}
+
+// IsLauncherPodAnnotationPropagated returns true if the given annotation may be propagated to virt-launcher pods
+func (c *ClusterConfig) IsLauncherPodAnnotationPropagated(key string) bool {
+	propagation := c.GetConfig().LauncherPodMetadataPropagation
+	return propagation == nil || MatchesMetadataKeyPattern(propagation.AllowedAnnotations, key)
+}
+
+// MatchesMetadataKeyPattern returns true if the key equals one of the patterns,
+// or starts with the prefix of a pattern ending in "*"
+func MatchesMetadataKeyPattern(patterns []string, key string) bool {
+	for _, pattern := range patterns {
+		if strings.HasSuffix(pattern, "*") {
+			if strings.HasPrefix(key, strings.TrimSuffix(pattern, "*")) {
+				return true
+			}
+		} else if pattern == key {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateMetadataKeyPattern ensures that the pattern is either a qualified name, or a prefix followed by "*"
+func ValidateMetadataKeyPattern(pattern string) error {
+	prefix := strings.TrimSuffix(pattern, "*")
+	if strings.Contains(prefix, "*") {
+		return fmt.Errorf("metadata key pattern %q may only contain \"*\" as its last character", pattern)
+	}
+	if prefix != pattern {
+		return nil
+	}
+	if errors := validation.IsQualifiedName(pattern); len(errors) != 0 {
+		return fmt.Errorf("metadata key pattern %q is not a valid qualified name: %s", pattern, strings.Join(errors, ", "))
+	}
+	return nil
+}
+
+// GetAdditionalGuestMemoryOverheadRatio returns the ratio the computed memory overhead is multiplied with.
+// Invalid ratios are ignored, since they are rejected by the KubeVirt validating webhook.
+func (c *ClusterConfig) GetAdditionalGuestMemoryOverheadRatio() float64 {
+	ratio, err := ParseAdditionalGuestMemoryOverheadRatio(c.GetConfig().AdditionalGuestMemoryOverheadRatio)
+	if err != nil {
+		return DefaultAdditionalGuestMemoryOverheadRatio
+	}
+	return ratio
+}
+
+// ParseAdditionalGuestMemoryOverheadRatio parses the given ratio and ensures that it doesn't lower the memory overhead
+func ParseAdditionalGuestMemoryOverheadRatio(ratio *string) (float64, error) {
+	if ratio == nil {
+		return DefaultAdditionalGuestMemoryOverheadRatio, nil
+	}
+	value, err := strconv.ParseFloat(*ratio, 64)
+	if err != nil {
+		return 0, fmt.Errorf("additional guest memory overhead ratio %q is not a decimal number", *ratio)
+	}
+	if value < DefaultAdditionalGuestMemoryOverheadRatio || math.IsInf(value, 0) {
+		return 0, fmt.Errorf("additional guest memory overhead ratio %q must be at least %.1f", *ratio, DefaultAdditionalGuestMemoryOverheadRatio)
+	}
+	return value, nil
+}
+
+// GetCloudInitUserDataMaxBytes returns the largest inline cloud-init user data VMIs may have
+func (c *ClusterConfig) GetCloudInitUserDataMaxBytes() int {
+	limits := c.GetConfig().AdmissionLimits
+	if limits == nil || limits.CloudInitUserDataMaxBytes == nil {
+		return DefaultCloudInitUserDataMaxBytes
+	}
+	return *limits.CloudInitUserDataMaxBytes
+}
+
+// GetCloudInitNetworkDataMaxBytes returns the largest inline cloud-init network data VMIs may have
+func (c *ClusterConfig) GetCloudInitNetworkDataMaxBytes() int {
+	limits := c.GetConfig().AdmissionLimits
+	if limits == nil || limits.CloudInitNetworkDataMaxBytes == nil {
+		return DefaultCloudInitNetworkDataMaxBytes
+	}
+	return *limits.CloudInitNetworkDataMaxBytes
+}
+
+// GetMaxListLength returns the largest number of disks, volumes, interfaces, networks and access credentials of VMIs
+func (c *ClusterConfig) GetMaxListLength() int {
+	limits := c.GetConfig().AdmissionLimits
+	if limits == nil || limits.MaxListLength == nil {
+		return DefaultMaxListLength
+	}
+	return *limits.MaxListLength
+}
+
+// GetNodeShutdownGracePeriod returns how long virt-handler delays the shutdown of its node, 0 if it doesn't
+func (c *ClusterConfig) GetNodeShutdownGracePeriod() time.Duration {
+	gracePeriod := c.GetConfig().NodeShutdownGracePeriodSeconds
+	if gracePeriod == nil || *gracePeriod <= 0 {
+		return 0
+	}
+	return time.Duration(*gracePeriod) * time.Second
+}
+
 func (c *ClusterConfig) GetDesiredMDEVTypes(nodeName string) []string {
 	mdevTypesConf := c.GetConfig().MediatedDevicesConfiguration
 	if mdevTypesConf == nil {
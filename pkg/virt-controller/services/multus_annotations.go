@@ -24,9 +24,13 @@ import (
 	"fmt"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/network/link"
 )
 
-const virtioInterfaceType = "virtio"
+const (
+	virtioInterfaceType     = "virtio"
+	primaryPodInterfaceName = "eth0"
+)
 
 type cniArguments struct {
 	InterfaceType string `json:"interface-type,omitempty"`
@@ -64,10 +68,24 @@ func (mnap multusNetworkAnnotationPool) toString() (string, error) {
 func generateMultusCNIAnnotation(vmi *v1.VirtualMachineInstance) (string, error) {
 	multusNetworkAnnotationPool := multusNetworkAnnotationPool{}
 
+	podInterfaceNames := map[string]string{}
 	multusNonDefaultNetworks := filterMultusNonDefaultNetworks(vmi.Spec.Networks)
 	for i, network := range multusNonDefaultNetworks {
+		podInterfaceNames[network.Name] = fmt.Sprintf("net%d", i+1)
 		multusNetworkAnnotationPool.add(
-			newMultusAnnotationData(vmi, network, fmt.Sprintf("net%d", i+1)))
+			newMultusAnnotationData(vmi, network, podInterfaceNames[network.Name]))
+	}
+
+	for _, network := range vmi.Spec.Networks {
+		iface := getIfaceByName(vmi, network.Name)
+		if iface == nil || iface.Mirror == nil || iface.Mirror.Network == "" {
+			continue
+		}
+		podInterfaceName, exists := podInterfaceNames[network.Name]
+		if !exists {
+			podInterfaceName = primaryPodInterfaceName
+		}
+		multusNetworkAnnotationPool.add(newMirrorAnnotationData(vmi, iface.Mirror, podInterfaceName))
 	}
 
 	if !multusNetworkAnnotationPool.isEmpty() {
@@ -110,6 +128,16 @@ func newMultusAnnotationData(vmi *v1.VirtualMachineInstance, network v1.Network,
 	return multusAnnotation
 }
 
+// newMirrorAnnotationData requests the interface the traffic of the pod interface is mirrored to
+func newMirrorAnnotationData(vmi *v1.VirtualMachineInstance, mirror *v1.InterfaceMirror, podInterfaceName string) multusNetworkAnnotation {
+	namespace, networkName := getNamespaceAndNetworkName(vmi, mirror.Network)
+	return multusNetworkAnnotation{
+		InterfaceName: link.GenerateMirrorDeviceName(podInterfaceName),
+		Namespace:     namespace,
+		NetworkName:   networkName,
+	}
+}
+
 func getIfaceByName(vmi *v1.VirtualMachineInstance, name string) *v1.Interface {
 	for i, iface := range vmi.Spec.Domain.Devices.Interfaces {
 		if iface.Name == name {
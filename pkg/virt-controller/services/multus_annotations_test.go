@@ -117,4 +117,44 @@ var _ = Describe("Multus annotations", func() {
 			Expect(multusAnnotationPool.toString()).To(BeIdenticalTo(expectedString))
 		})
 	})
+	Context("a vmi with mirrored interfaces", func() {
+		BeforeEach(func() {
+			vmi.Spec = v1.VirtualMachineInstanceSpec{
+				Domain: v1.DomainSpec{
+					Devices: v1.Devices{
+						Interfaces: []v1.Interface{
+							{
+								Name:                   "default",
+								InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}},
+								Mirror:                 &v1.InterfaceMirror{Network: "other/ids"},
+							},
+							{
+								Name:                   "test1",
+								InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}},
+								Mirror:                 &v1.InterfaceMirror{Network: "ids"},
+							},
+							{
+								Name:                   "test2",
+								InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}},
+								Mirror:                 &v1.InterfaceMirror{},
+							},
+						},
+					},
+				},
+				Networks: []v1.Network{
+					*v1.DefaultPodNetwork(),
+					network,
+					{Name: "test2", NetworkSource: v1.NetworkSource{Multus: &v1.MultusNetwork{NetworkName: "test2"}}},
+				},
+			}
+		})
+
+		It("requests the mirror networks next to the mirrored pod interfaces", func() {
+			expectedString := `[{"interface":"net1","name":"test1","namespace":"namespace1"},` +
+				`{"interface":"net2","name":"test2","namespace":"namespace1"},` +
+				`{"interface":"mir0","name":"ids","namespace":"other"},` +
+				`{"interface":"mir1","name":"ids","namespace":"namespace1"}]`
+			Expect(generateMultusCNIAnnotation(&vmi)).To(Equal(expectedString))
+		})
+	})
 })
@@ -21,7 +21,9 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"math"
 	"math/rand"
 	"path/filepath"
 	"strconv"
@@ -60,6 +62,13 @@ import (
 const KvmDevice = "devices.kubevirt.io/kvm"
 const TunDevice = "devices.kubevirt.io/tun"
 const VhostNetDevice = "devices.kubevirt.io/vhost-net"
+
+// VMIDevice is advertised by virt-handler according to how many VMIs fit on the node, each virt-launcher pod consumes one
+const VMIDevice = "devices.kubevirt.io/vmi"
+
+// BandwidthDevice is advertised by virt-handler according to the network bandwidth of the node, virt-launcher pods
+// consume as many as the bandwidth of the interfaces of their VMI needs
+const BandwidthDevice = "devices.kubevirt.io/bandwidth"
 const VhostuserSocketDir = "/var/lib/cni/usrcni/"
 const PodNetInfoDefault = "/etc/podnetinfo"
 
@@ -71,6 +80,12 @@ const MultusNetworksAnnotation = "k8s.v1.cni.cncf.io/networks"
 
 const qemuTimeoutJitterRange = 120
 
+// cloudInitDataSubDir is the directory within the ephemeral disks volume where virt-launcher generates cloud-init data
+const cloudInitDataSubDir = "cloud-init-data"
+
+// serialChannelsVolumeName is the volume which holds the sockets of the serial channels of the VMI
+const serialChannelsVolumeName = "serial-channels"
+
 const (
 	CAP_NET_BIND_SERVICE = "NET_BIND_SERVICE"
 	CAP_NET_RAW          = "NET_RAW"
@@ -131,6 +146,7 @@ type templateService struct {
 	hotplugDiskDir             string
 	imagePullSecret            string
 	persistentVolumeClaimStore cache.Store
+	namespaceStore             cache.Store
 	virtClient                 kubecli.KubevirtClient
 	clusterConfig              *virtconfig.ClusterConfig
 	launcherSubGid             int64
@@ -372,8 +388,9 @@ func requestResource(resources *k8sv1.ResourceRequirements, resourceName string)
 	}
 }
 
+// GetLauncherImage returns the virt-launcher image on the first matching registry mirror, or the image itself
 func (t *templateService) GetLauncherImage() string {
-	return t.launcherImage
+	return GetMirroredImage(t.launcherImage, t.clusterConfig.GetImageRegistryMirrors())
 }
 
 func (t *templateService) RenderLaunchManifestNoVm(vmi *v1.VirtualMachineInstance) (*k8sv1.Pod, error) {
@@ -550,9 +567,31 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 
 	if util.IsVhostuserVmiSpec(&vmi.Spec) {
 		addVhostuserVolume(&volumeMounts, &volumes)
+	}
+	// virt-handler follows the network-status annotation to update the DHCP
+	// configuration advertised on secondary bridge interfaces
+	if util.IsVhostuserVmiSpec(&vmi.Spec) || util.HasSecondaryBridgeInterfaces(&vmi.Spec) {
 		addPodInfoVolume(&volumeMounts, &volumes)
 	}
 
+	// only the first virt-launcher pod of the VMI resumes from the memory state of a restored snapshot
+	if claimName, exists := vmi.Annotations[v1.RestoreMemoryClaimAnnotation]; exists && vmi.Status.NodeName == "" {
+		volumes = append(volumes, k8sv1.Volume{
+			Name: "restore-memory",
+			VolumeSource: k8sv1.VolumeSource{
+				PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+					ClaimName: claimName,
+					ReadOnly:  true,
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, k8sv1.VolumeMount{
+			Name:      "restore-memory",
+			MountPath: config.RestoreMemoryDir,
+			ReadOnly:  true,
+		})
+	}
+
 	serviceAccountName := ""
 
 	for _, volume := range vmi.Spec.Volumes {
@@ -697,6 +736,23 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 			})
 		}
 
+		if volume.NetworkDisk != nil && volume.NetworkDisk.Auth != nil {
+			// attach the credentials of a network disk to the pod, the disk itself is accessed by QEMU directly
+			volumeMounts = append(volumeMounts, k8sv1.VolumeMount{
+				Name:      volume.Name,
+				MountPath: config.GetNetworkDiskSecretSourcePath(volume.Name),
+				ReadOnly:  true,
+			})
+			volumes = append(volumes, k8sv1.Volume{
+				Name: volume.Name,
+				VolumeSource: k8sv1.VolumeSource{
+					Secret: &k8sv1.SecretVolumeSource{
+						SecretName: volume.NetworkDisk.Auth.SecretRef,
+					},
+				},
+			})
+		}
+
 		if volume.DownwardAPI != nil {
 			// attach a Secret to the pod
 			volumeMounts = append(volumeMounts, k8sv1.VolumeMount{
@@ -888,6 +944,9 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 			Name: t.imagePullSecret,
 		})
 	}
+	for _, secret := range t.clusterConfig.GetImagePullSecrets() {
+		imagePullSecrets = appendUniqueImagePullSecret(imagePullSecrets, secret)
+	}
 
 	// Pad the virt-launcher grace period.
 	// Ideally we want virt-handler to handle tearing down
@@ -898,6 +957,9 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 
 	// Get memory overhead
 	memoryOverhead := getMemoryOverhead(vmi, t.clusterConfig.GetClusterCPUArch())
+	if ratio := t.clusterConfig.GetAdditionalGuestMemoryOverheadRatio(); ratio != virtconfig.DefaultAdditionalGuestMemoryOverheadRatio {
+		memoryOverhead = multiplyMemory(*memoryOverhead, ratio)
+	}
 
 	// Consider CPU and memory requests and limits for pod scheduling
 	resources := k8sv1.ResourceRequirements{}
@@ -907,12 +969,13 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 	resources.Limits = make(k8sv1.ResourceList)
 
 	// Set Default CPUs request
+	cpuAllocationRatio := 0
 	if !vmi.IsCPUDedicated() {
 		vcpus := int64(1)
 		if vmi.Spec.Domain.CPU != nil {
 			vcpus = hardware.GetNumberOfVCPUs(vmi.Spec.Domain.CPU)
 		}
-		cpuAllocationRatio := t.clusterConfig.GetCPUAllocationRatio()
+		cpuAllocationRatio = t.getCPUAllocationRatio(vmi.Namespace)
 		if vcpus != 0 && cpuAllocationRatio > 0 {
 			val := float64(vcpus) / float64(cpuAllocationRatio)
 			vcpusStr := fmt.Sprintf("%g", val)
@@ -1032,6 +1095,19 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 		})
 	}
 
+	if len(vmi.Spec.Domain.Devices.Channels) != 0 {
+		volumes = append(volumes, k8sv1.Volume{
+			Name: serialChannelsVolumeName,
+			VolumeSource: k8sv1.VolumeSource{
+				EmptyDir: &k8sv1.EmptyDirVolumeSource{},
+			},
+		})
+		volumeMounts = append(volumeMounts, k8sv1.VolumeMount{
+			Name:      serialChannelsVolumeName,
+			MountPath: util.VirtChannelSocketsDir,
+		})
+	}
+
 	// Handle CPU pinning
 	if vmi.IsCPUDedicated() {
 		// schedule only on nodes with a running cpu manager
@@ -1063,6 +1139,18 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 		resources.Limits[k8sv1.ResourceMemory] = *resources.Requests.Memory()
 	}
 
+	// Request CPU for the threads which don't run vCPUs, so that they don't take CPU time from the vCPUs
+	auxiliaryCPU := getAuxiliaryThreadsCPU(vmi, t.clusterConfig.GetConfig().AuxiliaryThreadsCPURequests)
+	if total := auxiliaryCPU.total(); !total.IsZero() {
+		cpuRequest := resources.Requests[k8sv1.ResourceCPU]
+		cpuRequest.Add(total)
+		resources.Requests[k8sv1.ResourceCPU] = cpuRequest
+		if cpuLimit, ok := resources.Limits[k8sv1.ResourceCPU]; ok {
+			cpuLimit.Add(total)
+			resources.Limits[k8sv1.ResourceCPU] = cpuLimit
+		}
+	}
+
 	ovmfPath := t.clusterConfig.GetOVMFPath()
 
 	var command []string
@@ -1154,7 +1242,7 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 	// VirtualMachineInstance target container
 	compute := k8sv1.Container{
 		Name:            "compute",
-		Image:           t.launcherImage,
+		Image:           t.GetLauncherImage(),
 		ImagePullPolicy: imagePullPolicy,
 		SecurityContext: &k8sv1.SecurityContext{
 			RunAsUser:  &userId,
@@ -1236,13 +1324,14 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 	// Make sure the compute container is always the first since the mutating webhook shipped with the sriov operator
 	// for adding the requested resources to the pod will add them to the first container of the list
 	containers := []k8sv1.Container{compute}
+	imageRegistryMirrors := t.clusterConfig.GetImageRegistryMirrors()
 	containersDisks := containerdisk.GenerateContainers(vmi, "container-disks", "virt-bin-share-dir")
-	containers = append(containers, containersDisks...)
+	containers = append(containers, applyImageRegistryMirrors(containersDisks, imageRegistryMirrors)...)
 
 	kernelBootContainer := containerdisk.GenerateKernelBootContainer(vmi, "container-disks", "virt-bin-share-dir")
 	if kernelBootContainer != nil {
 		log.Log.Object(vmi).Infof("kernel boot container generated")
-		containers = append(containers, *kernelBootContainer)
+		containers = append(containers, applyImageRegistryMirrors([]k8sv1.Container{*kernelBootContainer}, imageRegistryMirrors)...)
 	}
 
 	volumes = append(volumes,
@@ -1316,7 +1405,16 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 	podLabels := map[string]string{}
 
 	for k, v := range vmi.Labels {
-		podLabels[k] = v
+		if t.clusterConfig.IsLauncherPodLabelPropagated(k) {
+			podLabels[k] = v
+		}
+	}
+	if vmi.Spec.LauncherPodMetadata != nil {
+		for k, v := range vmi.Spec.LauncherPodMetadata.Labels {
+			if t.clusterConfig.IsLauncherPodLabelPropagated(k) {
+				podLabels[k] = v
+			}
+		}
 	}
 	podLabels[v1.AppLabel] = "virt-launcher"
 	podLabels[v1.CreatedByLabel] = string(vmi.UID)
@@ -1355,9 +1453,11 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 		containers = append(containers, sidecar)
 	}
 
+	containers = append(containers, applyImageRegistryMirrors(renderUserSidecars(vmi, volumeMounts, userId, nonRoot), imageRegistryMirrors)...)
+
 	hostName := dns.SanitizeHostname(vmi)
 
-	podAnnotations, err := generatePodAnnotations(vmi)
+	podAnnotations, err := generatePodAnnotations(vmi, t.clusterConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -1365,6 +1465,14 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 		// mark pod as temp - only used for provisioning
 		podAnnotations[v1.EphemeralProvisioningObject] = "true"
 	}
+	resourceOverhead, err := json.Marshal(getResourceOverhead(vmi, memoryOverhead, auxiliaryCPU))
+	if err != nil {
+		return nil, err
+	}
+	podAnnotations[v1.ResourceOverheadAnnotation] = string(resourceOverhead)
+	if cpuAllocationRatio > 0 {
+		podAnnotations[v1.CPUAllocationRatioAnnotation] = strconv.Itoa(cpuAllocationRatio)
+	}
 
 	var initContainers []k8sv1.Container
 
@@ -1399,7 +1507,7 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 		}
 		cpInitContainer := k8sv1.Container{
 			Name:            "container-disk-binary",
-			Image:           t.launcherImage,
+			Image:           t.GetLauncherImage(),
 			ImagePullPolicy: imagePullPolicy,
 			SecurityContext: &k8sv1.SecurityContext{
 				RunAsUser:  &userId,
@@ -1417,7 +1525,7 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 		initContainers = append(initContainers, cpInitContainer)
 
 		// this causes containerDisks to be pre-pulled before virt-launcher starts.
-		initContainers = append(initContainers, containerdisk.GenerateInitContainers(vmi, "container-disks", "virt-bin-share-dir")...)
+		initContainers = append(initContainers, applyImageRegistryMirrors(containerdisk.GenerateInitContainers(vmi, "container-disks", "virt-bin-share-dir"), imageRegistryMirrors)...)
 	}
 
 	// TODO use constants for podLabels
@@ -1462,8 +1570,12 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 		alignPodMultiCategorySecurity(&pod, selinuxType)
 	}
 
-	// If we have a runtime class specified, use it, otherwise don't set a runtimeClassName
+	// If we have a runtime class specified, use it, otherwise don't set a runtimeClassName.
+	// A runtime class requested by the VMI takes precedence over the default one.
 	runtimeClassName := t.clusterConfig.GetDefaultRuntimeClass()
+	if vmi.Spec.RuntimeClassName != nil {
+		runtimeClassName = *vmi.Spec.RuntimeClassName
+	}
 	if runtimeClassName != "" {
 		pod.Spec.RuntimeClassName = &runtimeClassName
 	}
@@ -1484,6 +1596,15 @@ func (t *templateService) renderLaunchManifest(vmi *v1.VirtualMachineInstance, t
 
 	pod.Spec.SchedulerName = vmi.Spec.SchedulerName
 
+	pod.Spec.TopologySpreadConstraints = vmi.Spec.TopologySpreadConstraints
+
+	// the pod is kept out of the endpoints of Services while the connections to the VMI drain before a migration
+	if drainSeconds := t.clusterConfig.GetMigrationConfiguration().PreSwitchoverDrainSeconds; drainSeconds != nil && *drainSeconds > 0 {
+		pod.Spec.ReadinessGates = append(pod.Spec.ReadinessGates, k8sv1.PodReadinessGate{
+			ConditionType: v1.VirtualMachineInstanceNotDraining,
+		})
+	}
+
 	enableServiceLinks := false
 	pod.Spec.EnableServiceLinks = &enableServiceLinks
 
@@ -1556,7 +1677,7 @@ func (t *templateService) RenderHotplugAttachmentPodTemplate(volumes []*v1.Volum
 			Containers: []k8sv1.Container{
 				{
 					Name:    "hotplug-disk",
-					Image:   t.launcherImage,
+					Image:   t.GetLauncherImage(),
 					Command: command,
 					Resources: k8sv1.ResourceRequirements{ //Took the request and limits from containerDisk init container.
 						Limits: map[k8sv1.ResourceName]resource.Quantity{
@@ -1697,7 +1818,7 @@ func (t *templateService) RenderHotplugAttachmentTriggerPodTemplate(volume *v1.V
 			Containers: []k8sv1.Container{
 				{
 					Name:    "hotplug-disk",
-					Image:   t.launcherImage,
+					Image:   t.GetLauncherImage(),
 					Command: command,
 					Resources: k8sv1.ResourceRequirements{ //Took the request and limits from containerDisk init container.
 						Limits: map[k8sv1.ResourceName]resource.Quantity{
@@ -1828,7 +1949,9 @@ func getRequiredCapabilities(vmi *v1.VirtualMachineInstance, config *virtconfig.
 }
 
 func getRequiredResources(vmi *v1.VirtualMachineInstance, allowEmulation bool) k8sv1.ResourceList {
-	res := k8sv1.ResourceList{}
+	res := k8sv1.ResourceList{
+		VMIDevice: resource.MustParse("1"),
+	}
 	if (len(vmi.Spec.Domain.Devices.Interfaces) > 0) ||
 		(vmi.Spec.Domain.Devices.AutoattachPodInterface == nil) ||
 		(*vmi.Spec.Domain.Devices.AutoattachPodInterface == true) {
@@ -1842,9 +1965,134 @@ func getRequiredResources(vmi *v1.VirtualMachineInstance, allowEmulation bool) k
 		res[VhostNetDevice] = resource.MustParse("1")
 
 	}
+	if devices := bandwidthDevices(vmi); devices > 0 {
+		res[BandwidthDevice] = *resource.NewQuantity(devices, resource.DecimalSI)
+	}
 	return res
 }
 
+// bandwidthDevices returns the number of bandwidth devices the interfaces of the VMI need, rounded up
+func bandwidthDevices(vmi *v1.VirtualMachineInstance) int64 {
+	var bandwidth int64
+	for _, iface := range vmi.Spec.Domain.Devices.Interfaces {
+		if iface.Bandwidth != nil && iface.Bandwidth.Sign() > 0 {
+			bandwidth += iface.Bandwidth.Value()
+		}
+	}
+	return (bandwidth + virtconfig.BandwidthDeviceUnit - 1) / virtconfig.BandwidthDeviceUnit
+}
+
+// renderUserSidecars renders the user sidecar containers of the VMI. The given volumeMounts are the mounts of
+// the compute container, sidecars may only share volumes which are mounted there as a filesystem.
+func renderUserSidecars(vmi *v1.VirtualMachineInstance, computeVolumeMounts []k8sv1.VolumeMount, userId int64, nonRoot bool) []k8sv1.Container {
+	if len(vmi.Spec.Sidecars) == 0 {
+		return nil
+	}
+
+	mountedVolumes := map[string]bool{}
+	for _, volumeMount := range computeVolumeMounts {
+		mountedVolumes[volumeMount.Name] = true
+	}
+	cloudInitVolumes := map[string]bool{}
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.CloudInitNoCloud != nil || volume.CloudInitConfigDrive != nil {
+			cloudInitVolumes[volume.Name] = true
+		}
+	}
+
+	privileged := false
+	var containers []k8sv1.Container
+	for _, requestedSidecar := range vmi.Spec.Sidecars {
+		resources := *requestedSidecar.Resources.DeepCopy()
+		// sidecars must not break the QoS class of the pod, requests and limits have to match
+		if vmi.IsCPUDedicated() || vmi.WantsToHaveQOSGuaranteed() {
+			if resources.Limits == nil {
+				resources.Limits = make(k8sv1.ResourceList)
+			}
+			if resources.Requests == nil {
+				resources.Requests = make(k8sv1.ResourceList)
+			}
+			for name, value := range resources.Requests {
+				if _, exists := resources.Limits[name]; !exists {
+					resources.Limits[name] = value
+				}
+			}
+			for name, value := range resources.Limits {
+				resources.Requests[name] = value
+			}
+		}
+
+		var volumeMounts []k8sv1.VolumeMount
+		if mountedVolumes[serialChannelsVolumeName] {
+			// the sockets of the serial channels are shared with all sidecars
+			volumeMounts = append(volumeMounts, k8sv1.VolumeMount{
+				Name:      serialChannelsVolumeName,
+				MountPath: util.VirtChannelSocketsDir,
+			})
+		}
+		for _, requestedMount := range requestedSidecar.VolumeMounts {
+			if cloudInitVolumes[requestedMount.Name] {
+				// cloud-init data is generated by virt-launcher into the ephemeral disks directory
+				volumeMounts = append(volumeMounts, k8sv1.VolumeMount{
+					Name:      "ephemeral-disks",
+					MountPath: requestedMount.MountPath,
+					SubPath:   filepath.Join(cloudInitDataSubDir, vmi.Namespace, vmi.Name),
+					ReadOnly:  true,
+				})
+			} else if mountedVolumes[requestedMount.Name] {
+				volumeMounts = append(volumeMounts, k8sv1.VolumeMount{
+					Name:      requestedMount.Name,
+					MountPath: requestedMount.MountPath,
+					ReadOnly:  true,
+				})
+			}
+		}
+
+		sidecar := k8sv1.Container{
+			Name:            requestedSidecar.Name,
+			Image:           requestedSidecar.Image,
+			ImagePullPolicy: requestedSidecar.ImagePullPolicy,
+			Command:         requestedSidecar.Command,
+			Args:            requestedSidecar.Args,
+			Resources:       resources,
+			SecurityContext: &k8sv1.SecurityContext{
+				RunAsUser:  &userId,
+				Privileged: &privileged,
+			},
+			VolumeMounts: volumeMounts,
+		}
+		if nonRoot {
+			sidecar.SecurityContext.RunAsGroup = &userId
+			sidecar.SecurityContext.RunAsNonRoot = &nonRoot
+		}
+		containers = append(containers, sidecar)
+	}
+	return containers
+}
+
+// applyImageRegistryMirrors redirects the images of the given containers to the first matching mirror
+func applyImageRegistryMirrors(containers []k8sv1.Container, mirrors []v1.ImageRegistryMirror) []k8sv1.Container {
+	for i := range containers {
+		containers[i].Image = GetMirroredImage(containers[i].Image, mirrors)
+	}
+	return containers
+}
+
+// GetMirroredImage returns the image on the first mirror whose source matches it, or the image itself
+func GetMirroredImage(image string, mirrors []v1.ImageRegistryMirror) string {
+	for _, mirror := range mirrors {
+		source := strings.TrimSuffix(mirror.Source, "/")
+		if source == "" {
+			continue
+		}
+		// only match complete path segments, "quay.io/kubevirt" must not redirect "quay.io/kubevirtci/image"
+		if strings.HasPrefix(image, source+"/") || strings.HasPrefix(image, source+":") || strings.HasPrefix(image, source+"@") {
+			return strings.TrimSuffix(mirror.Mirror, "/") + strings.TrimPrefix(image, source)
+		}
+	}
+	return image
+}
+
 func appendUniqueImagePullSecret(secrets []k8sv1.LocalObjectReference, newsecret k8sv1.LocalObjectReference) []k8sv1.LocalObjectReference {
 	for _, oldsecret := range secrets {
 		if oldsecret == newsecret {
@@ -1937,6 +2185,129 @@ func getMemoryOverhead(vmi *v1.VirtualMachineInstance, cpuArch string) *resource
 	return overhead
 }
 
+// getCPUAllocationRatio returns the CPU allocation ratio of the namespace, if it overrides
+// the one of the cluster. Invalid namespace overrides are ignored.
+func (t *templateService) getCPUAllocationRatio(namespace string) int {
+	cpuAllocationRatio := t.clusterConfig.GetCPUAllocationRatio()
+
+	obj, exists, err := t.namespaceStore.GetByKey(namespace)
+	if err != nil || !exists {
+		return cpuAllocationRatio
+	}
+	value, exists := obj.(*k8sv1.Namespace).Annotations[v1.CPUAllocationRatioAnnotation]
+	if !exists {
+		return cpuAllocationRatio
+	}
+	namespaceRatio, err := strconv.Atoi(value)
+	if err != nil || namespaceRatio < 1 {
+		log.Log.Warningf("Ignoring the invalid CPU allocation ratio %q of namespace %s", value, namespace)
+		return cpuAllocationRatio
+	}
+	return namespaceRatio
+}
+
+// multiplyMemory multiplies the given memory quantity and rounds it up to full bytes
+func multiplyMemory(mem resource.Quantity, multiplier float64) *resource.Quantity {
+	return resource.NewQuantity(int64(math.Ceil(float64(mem.Value())*multiplier)), mem.Format)
+}
+
+// getResourceOverhead describes the resources which are added to the compute container
+// on top of the resources requested for the guest
+func getResourceOverhead(vmi *v1.VirtualMachineInstance, memoryOverhead *resource.Quantity, auxiliaryCPU *auxiliaryThreadsCPU) *v1.VirtualMachineInstanceResourceOverhead {
+	cpuOverhead := resource.NewQuantity(0, resource.DecimalSI)
+	if vmi.IsCPUDedicated() && vmi.Spec.Domain.CPU.IsolateEmulatorThread {
+		cpuOverhead = resource.NewQuantity(1, resource.DecimalSI)
+	}
+	overhead := &v1.VirtualMachineInstanceResourceOverhead{
+		Memory: memoryOverhead,
+		CPU:    cpuOverhead,
+	}
+	if auxiliaryCPU != nil {
+		overhead.CPU.Add(auxiliaryCPU.total())
+		overhead.EmulatorCPU = auxiliaryCPU.emulator
+		overhead.IOThreadsCPU = auxiliaryCPU.ioThreads
+		overhead.VirtiofsCPU = auxiliaryCPU.virtiofs
+	}
+	return overhead
+}
+
+// auxiliaryThreadsCPU holds the CPU requested for the threads of a VMI which don't run vCPUs
+type auxiliaryThreadsCPU struct {
+	emulator  *resource.Quantity
+	ioThreads *resource.Quantity
+	virtiofs  *resource.Quantity
+}
+
+func (c *auxiliaryThreadsCPU) total() resource.Quantity {
+	total := resource.NewQuantity(0, resource.DecimalSI)
+	if c == nil {
+		return *total
+	}
+	for _, cpu := range []*resource.Quantity{c.emulator, c.ioThreads, c.virtiofs} {
+		if cpu != nil {
+			total.Add(*cpu)
+		}
+	}
+	return *total
+}
+
+// getAuxiliaryThreadsCPU computes the CPU requested for the emulator thread, the IO threads and the virtiofsd
+// processes of the VMI. VMIs with dedicated CPUs get a full pCPU for these threads with isolateEmulatorThread.
+func getAuxiliaryThreadsCPU(vmi *v1.VirtualMachineInstance, requests *v1.AuxiliaryThreadsCPURequests) *auxiliaryThreadsCPU {
+	if requests == nil || vmi.IsCPUDedicated() {
+		return nil
+	}
+	multiply := func(request *resource.Quantity, count int64) *resource.Quantity {
+		if request == nil || count == 0 {
+			return nil
+		}
+		return resource.NewMilliQuantity(request.MilliValue()*count, resource.DecimalSI)
+	}
+	return &auxiliaryThreadsCPU{
+		emulator:  multiply(requests.Emulator, 1),
+		ioThreads: multiply(requests.IOThread, getIOThreadsCount(vmi)),
+		virtiofs:  multiply(requests.Virtiofs, int64(len(vmi.Spec.Domain.Devices.Filesystems))),
+	}
+}
+
+// getIOThreadsCount returns the number of IO threads which the disks of the VMI use.
+// It follows the IO thread allocation of the domain converter of virt-launcher.
+func getIOThreadsCount(vmi *v1.VirtualMachineInstance) int64 {
+	var dedicatedThreads, sharedThreads int64
+	for _, disk := range vmi.Spec.Domain.Devices.Disks {
+		if disk.DedicatedIOThread != nil && *disk.DedicatedIOThread {
+			dedicatedThreads++
+		} else {
+			sharedThreads++
+		}
+	}
+
+	policy := vmi.Spec.Domain.IOThreadsPolicy
+	if policy == nil && dedicatedThreads == 0 {
+		return 0
+	}
+
+	threadPoolLimit := int64(1)
+	if policy != nil && *policy == v1.IOThreadsPolicyAuto && !(vmi.IsCPUDedicated() && vmi.Spec.Domain.CPU.IsolateEmulatorThread) {
+		numCPUs := int64(1)
+		if cpuRequests, ok := vmi.Spec.Domain.Resources.Requests[k8sv1.ResourceCPU]; ok {
+			numCPUs = cpuRequests.Value()
+		} else if cpuLimit, ok := vmi.Spec.Domain.Resources.Limits[k8sv1.ResourceCPU]; ok {
+			numCPUs = cpuLimit.Value()
+		}
+		threadPoolLimit = numCPUs * 2
+	}
+
+	if dedicatedThreads+sharedThreads > threadPoolLimit {
+		sharedThreads = threadPoolLimit - dedicatedThreads
+		// there is at least one shared thread
+		if sharedThreads < 1 {
+			sharedThreads = 1
+		}
+	}
+	return dedicatedThreads + sharedThreads
+}
+
 // We need to add this overhead due to potential issues when using exec probes.
 // In certain situations depending on things like node size and kernel versions
 // the exec probe can cause a significant memory overhead that results in the pod getting OOM killed.
@@ -2067,6 +2438,7 @@ func NewTemplateService(launcherImage string,
 	hotplugDiskDir string,
 	imagePullSecret string,
 	persistentVolumeClaimCache cache.Store,
+	namespaceCache cache.Store,
 	virtClient kubecli.KubevirtClient,
 	clusterConfig *virtconfig.ClusterConfig,
 	launcherSubGid int64) TemplateService {
@@ -2082,6 +2454,7 @@ func NewTemplateService(launcherImage string,
 		hotplugDiskDir:             hotplugDiskDir,
 		imagePullSecret:            imagePullSecret,
 		persistentVolumeClaimStore: persistentVolumeClaimCache,
+		namespaceStore:             namespaceCache,
 		virtClient:                 virtClient,
 		clusterConfig:              clusterConfig,
 		launcherSubGid:             launcherSubGid,
@@ -2169,12 +2542,21 @@ func generateContainerSecurityContext(selinuxType string, container *k8sv1.Conta
 	container.SecurityContext.SELinuxOptions.Level = "s0"
 }
 
-func generatePodAnnotations(vmi *v1.VirtualMachineInstance) (map[string]string, error) {
+func generatePodAnnotations(vmi *v1.VirtualMachineInstance, clusterConfig *virtconfig.ClusterConfig) (map[string]string, error) {
 	annotationsSet := map[string]string{
 		v1.DomainAnnotation: vmi.GetObjectMeta().GetName(),
 	}
+	if vmi.Spec.LauncherPodMetadata != nil {
+		for k, v := range vmi.Spec.LauncherPodMetadata.Annotations {
+			if clusterConfig.IsLauncherPodAnnotationPropagated(k) {
+				annotationsSet[k] = v
+			}
+		}
+	}
 	for k, v := range filterVMIAnnotationsForPod(vmi.Annotations) {
-		annotationsSet[k] = v
+		if clusterConfig.IsLauncherPodAnnotationPropagated(k) {
+			annotationsSet[k] = v
+		}
 	}
 
 	multusAnnotation, err := generateMultusCNIAnnotation(vmi)
@@ -2192,16 +2574,18 @@ func generatePodAnnotations(vmi *v1.VirtualMachineInstance) (map[string]string,
 	if HaveMasqueradeInterface(vmi.Spec.Domain.Devices.Interfaces) {
 		annotationsSet[ISTIO_KUBEVIRT_ANNOTATION] = "k6t-eth0"
 	}
-	annotationsSet[VELERO_PREBACKUP_HOOK_CONTAINER_ANNOTATION] = "compute"
-	annotationsSet[VELERO_PREBACKUP_HOOK_COMMAND_ANNOTATION] = fmt.Sprintf(
-		"[\"/usr/bin/virt-freezer\", \"--freeze\", \"--name\", \"%s\", \"--namespace\", \"%s\"]",
-		vmi.GetObjectMeta().GetName(),
-		vmi.GetObjectMeta().GetNamespace())
-	annotationsSet[VELERO_POSTBACKUP_HOOK_CONTAINER_ANNOTATION] = "compute"
-	annotationsSet[VELERO_POSTBACKUP_HOOK_COMMAND_ANNOTATION] = fmt.Sprintf(
-		"[\"/usr/bin/virt-freezer\", \"--unfreeze\", \"--name\", \"%s\", \"--namespace\", \"%s\"]",
-		vmi.GetObjectMeta().GetName(),
-		vmi.GetObjectMeta().GetNamespace())
+	if vmi.Annotations[v1.SkipBackupFreezeAnnotation] != "true" {
+		annotationsSet[VELERO_PREBACKUP_HOOK_CONTAINER_ANNOTATION] = "compute"
+		annotationsSet[VELERO_PREBACKUP_HOOK_COMMAND_ANNOTATION] = fmt.Sprintf(
+			"[\"/usr/bin/virt-freezer\", \"--freeze\", \"--name\", \"%s\", \"--namespace\", \"%s\"]",
+			vmi.GetObjectMeta().GetName(),
+			vmi.GetObjectMeta().GetNamespace())
+		annotationsSet[VELERO_POSTBACKUP_HOOK_CONTAINER_ANNOTATION] = "compute"
+		annotationsSet[VELERO_POSTBACKUP_HOOK_COMMAND_ANNOTATION] = fmt.Sprintf(
+			"[\"/usr/bin/virt-freezer\", \"--unfreeze\", \"--name\", \"%s\", \"--namespace\", \"%s\"]",
+			vmi.GetObjectMeta().GetName(),
+			vmi.GetObjectMeta().GetNamespace())
+	}
 
 	return annotationsSet, nil
 }
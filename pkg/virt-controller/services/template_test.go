@@ -20,6 +20,7 @@
 package services
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strconv"
@@ -61,6 +62,7 @@ var _ = Describe("Template", func() {
 	var defaultArch = "amd64"
 
 	pvcCache := cache.NewIndexer(cache.DeletionHandlingMetaNamespaceKeyFunc, nil)
+	namespaceCache := cache.NewIndexer(cache.DeletionHandlingMetaNamespaceKeyFunc, nil)
 	var svc TemplateService
 
 	ctrl := gomock.NewController(GinkgoT())
@@ -107,6 +109,7 @@ var _ = Describe("Template", func() {
 				"/var/run/kubevirt/hotplug-disks",
 				"pull-secret-1",
 				pvcCache,
+				namespaceCache,
 				virtClient,
 				config,
 				qemuGid,
@@ -240,6 +243,10 @@ var _ = Describe("Template", func() {
 				})
 
 				Expect(err).ToNot(HaveOccurred())
+				// the resource overhead and the cpu allocation ratio are covered separately
+				Expect(pod.ObjectMeta.Annotations).To(HaveKey(v1.ResourceOverheadAnnotation))
+				delete(pod.ObjectMeta.Annotations, v1.ResourceOverheadAnnotation)
+				delete(pod.ObjectMeta.Annotations, v1.CPUAllocationRatioAnnotation)
 				Expect(pod.ObjectMeta.Annotations).To(Equal(podExpectedAnnotation))
 			},
 				table.Entry("and don't contain kubectl annotation",
@@ -313,6 +320,15 @@ var _ = Describe("Template", func() {
 						"post.hook.backup.velero.io/command":   "[\"/usr/bin/virt-freezer\", \"--unfreeze\", \"--name\", \"testvmi\", \"--namespace\", \"testns\"]",
 					},
 				),
+				table.Entry("and don't contain the backup hooks if freezing is skipped",
+					map[string]string{
+						"kubevirt.io/skip-backup-freeze": "true",
+					},
+					map[string]string{
+						"kubevirt.io/domain":             "testvmi",
+						"kubevirt.io/skip-backup-freeze": "true",
+					},
+				),
 			)
 
 			table.DescribeTable("should work", func(arch string, ovmfPath string) {
@@ -346,6 +362,8 @@ var _ = Describe("Template", func() {
 					v1.AppLabel:       "virt-launcher",
 					v1.CreatedByLabel: "1234",
 				}))
+				delete(pod.ObjectMeta.Annotations, v1.ResourceOverheadAnnotation)
+				delete(pod.ObjectMeta.Annotations, v1.CPUAllocationRatioAnnotation)
 				Expect(pod.ObjectMeta.Annotations).To(Equal(map[string]string{
 					v1.DomainAnnotation:                    "testvmi",
 					"test":                                 "shouldBeInPod",
@@ -904,6 +922,42 @@ var _ = Describe("Template", func() {
 					"]")
 				Expect(value).To(Equal(expectedIfaces))
 			})
+			It("should expose the pod annotations to follow the network status of secondary bridge interfaces", func() {
+				config, kvInformer, svc = configFactory(defaultArch)
+				vmi := v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "testvmi",
+						Namespace: "default",
+						UID:       "1234",
+					},
+					Spec: v1.VirtualMachineInstanceSpec{
+						Domain: v1.DomainSpec{
+							Devices: v1.Devices{
+								DisableHotplug: true,
+								Interfaces: []v1.Interface{
+									{Name: "test1", InterfaceBindingMethod: v1.InterfaceBindingMethod{Bridge: &v1.InterfaceBridge{}}},
+								},
+							},
+						},
+						Networks: []v1.Network{
+							{Name: "test1",
+								NetworkSource: v1.NetworkSource{
+									Multus: &v1.MultusNetwork{NetworkName: "test1"},
+								}},
+						},
+					},
+				}
+
+				pod, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pod.Spec.Containers[0].VolumeMounts).To(ContainElement(kubev1.VolumeMount{
+					Name:      "podinfo",
+					MountPath: PodNetInfoDefault,
+				}))
+				for _, volume := range pod.Spec.Containers[0].VolumeMounts {
+					Expect(volume.MountPath).ToNot(Equal(VhostuserSocketDir))
+				}
+			})
 			It("should add default multus networks in the multus default-network annotation", func() {
 				config, kvInformer, svc = configFactory(defaultArch)
 				vmi := v1.VirtualMachineInstance{
@@ -1846,6 +1900,64 @@ var _ = Describe("Template", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(pod.Spec.Containers[0].Resources.Requests.Cpu().String()).To(Equal("150m"))
 			})
+			table.DescribeTable("should respect the cpu allocation ratio of the namespace", func(namespaceRatio, expectedCPU, expectedRatio string) {
+				config, kvInformer, svc = configFactory(defaultArch)
+				namespaceCache.Add(&kubev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "overcommitted",
+						Annotations: map[string]string{v1.CPUAllocationRatioAnnotation: namespaceRatio},
+					},
+				})
+				defer namespaceCache.Delete(&kubev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "overcommitted"}})
+
+				vmi := v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "testvmi",
+						Namespace: "overcommitted",
+						UID:       "1234",
+					},
+					Spec: v1.VirtualMachineInstanceSpec{
+						Domain: v1.DomainSpec{
+							Devices: v1.Devices{
+								DisableHotplug: true,
+							},
+							CPU: &v1.CPU{Cores: 4},
+						},
+					},
+				}
+
+				pod, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pod.Spec.Containers[0].Resources.Requests.Cpu().String()).To(Equal(expectedCPU))
+				Expect(pod.Annotations).To(HaveKeyWithValue(v1.CPUAllocationRatioAnnotation, expectedRatio))
+			},
+				table.Entry("with a valid override", "2", "2", "2"),
+				table.Entry("ignoring a non numeric override", "many", "400m", "10"),
+				table.Entry("ignoring a zero override", "0", "400m", "10"),
+			)
+			It("should not report a cpu allocation ratio for dedicated cpus", func() {
+				config, kvInformer, svc = configFactory(defaultArch)
+
+				vmi := v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "testvmi",
+						Namespace: "default",
+						UID:       "1234",
+					},
+					Spec: v1.VirtualMachineInstanceSpec{
+						Domain: v1.DomainSpec{
+							Devices: v1.Devices{
+								DisableHotplug: true,
+							},
+							CPU: &v1.CPU{Cores: 2, DedicatedCPUPlacement: true},
+						},
+					},
+				}
+
+				pod, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pod.Annotations).ToNot(HaveKey(v1.CPUAllocationRatioAnnotation))
+			})
 		})
 
 		Context("with hugepages constraints", func() {
@@ -2242,6 +2354,105 @@ var _ = Describe("Template", func() {
 			})
 		})
 
+		Context("with cluster-wide image settings", func() {
+			newContainerDiskVMI := func(image string) *v1.VirtualMachineInstance {
+				return &v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "testvmi", Namespace: "default", UID: "1234",
+					},
+					Spec: v1.VirtualMachineInstanceSpec{
+						Volumes: []v1.Volume{
+							{
+								Name: "containerdisk1",
+								VolumeSource: v1.VolumeSource{
+									ContainerDisk: &v1.ContainerDiskSource{
+										Image:           image,
+										ImagePullSecret: "pull-secret-2",
+									},
+								},
+							},
+						},
+						Domain: v1.DomainSpec{
+							Devices: v1.Devices{
+								DisableHotplug: true,
+							},
+						},
+					},
+				}
+			}
+
+			It("should add the pull secrets from the KubeVirt configuration", func() {
+				config, kvInformer, svc = configFactory(defaultArch)
+				kvConfig := kv.DeepCopy()
+				kvConfig.Spec.Configuration.ImagePullSecrets = []kubev1.LocalObjectReference{
+					{Name: "cluster-secret"},
+					{Name: "pull-secret-1"},
+				}
+				testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kvConfig)
+
+				pod, err := svc.RenderLaunchManifest(newContainerDiskVMI("my-image-1"))
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(pod.Spec.ImagePullSecrets).To(Equal([]kubev1.LocalObjectReference{
+					{Name: "pull-secret-2"},
+					{Name: "pull-secret-1"},
+					{Name: "cluster-secret"},
+				}))
+			})
+
+			It("should redirect containerDisk images to the registry mirrors", func() {
+				config, kvInformer, svc = configFactory(defaultArch)
+				kvConfig := kv.DeepCopy()
+				kvConfig.Spec.Configuration.ImageRegistryMirrors = []v1.ImageRegistryMirror{
+					{Source: "quay.io/containerdisks", Mirror: "mirror.example.com/containerdisks"},
+				}
+				testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kvConfig)
+
+				pod, err := svc.RenderLaunchManifest(newContainerDiskVMI("quay.io/containerdisks/fedora:33"))
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(pod.Spec.Containers[0].Image).To(Equal("kubevirt/virt-launcher"))
+				Expect(pod.Spec.Containers[1].Image).To(Equal("mirror.example.com/containerdisks/fedora:33"))
+				var initImages []string
+				for _, container := range pod.Spec.InitContainers {
+					initImages = append(initImages, container.Image)
+				}
+				Expect(initImages).To(ContainElement("mirror.example.com/containerdisks/fedora:33"))
+				Expect(initImages).ToNot(ContainElement("quay.io/containerdisks/fedora:33"))
+			})
+
+			It("should redirect the virt-launcher image to the registry mirrors", func() {
+				config, kvInformer, svc = configFactory(defaultArch)
+				kvConfig := kv.DeepCopy()
+				kvConfig.Spec.Configuration.ImageRegistryMirrors = []v1.ImageRegistryMirror{
+					{Source: "kubevirt", Mirror: "mirror.example.com/kubevirt"},
+				}
+				testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kvConfig)
+
+				pod, err := svc.RenderLaunchManifest(newContainerDiskVMI("quay.io/containerdisks/fedora:33"))
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(pod.Spec.Containers[0].Image).To(Equal("mirror.example.com/kubevirt/virt-launcher"))
+				Expect(pod.Spec.InitContainers[0].Name).To(Equal("container-disk-binary"))
+				Expect(pod.Spec.InitContainers[0].Image).To(Equal("mirror.example.com/kubevirt/virt-launcher"))
+				Expect(svc.GetLauncherImage()).To(Equal("mirror.example.com/kubevirt/virt-launcher"))
+			})
+
+			table.DescribeTable("should mirror images", func(image string, expected string) {
+				mirrors := []v1.ImageRegistryMirror{
+					{Source: "quay.io/kubevirt/", Mirror: "mirror.example.com/kubevirt"},
+					{Source: "docker.io", Mirror: "docker-mirror.example.com"},
+				}
+				Expect(GetMirroredImage(image, mirrors)).To(Equal(expected))
+			},
+				table.Entry("with a matching repository", "quay.io/kubevirt/cirros:latest", "mirror.example.com/kubevirt/cirros:latest"),
+				table.Entry("with a matching registry", "docker.io/library/fedora", "docker-mirror.example.com/library/fedora"),
+				table.Entry("with a digest", "quay.io/kubevirt@sha256:abcd", "mirror.example.com/kubevirt@sha256:abcd"),
+				table.Entry("with a partial path segment match", "quay.io/kubevirtci/image", "quay.io/kubevirtci/image"),
+				table.Entry("without a matching mirror", "registry.example.com/image", "registry.example.com/image"),
+			)
+		})
+
 		Context("with sriov interface", func() {
 
 			It("should not run privileged", func() {
@@ -2320,6 +2531,142 @@ var _ = Describe("Template", func() {
 				Expect(pod.Spec.Containers[0].Resources.Requests.Memory().Value()).To(Equal(expectedMemory.Value()))
 				Expect(pod1.Spec.Containers[0].Resources.Requests.Memory().Value()).To(Equal(expectedMemory.Value()))
 			})
+			It("should multiply the memory overhead with the additional overhead ratio", func() {
+				config, kvInformer, svc = configFactory(defaultArch)
+				ratio := "1.5"
+				kvConfig := kv.DeepCopy()
+				kvConfig.Spec.Configuration.AdditionalGuestMemoryOverheadRatio = &ratio
+				testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kvConfig)
+
+				vmi := newVMIWithSriovInterface("testvmi", "1234")
+				vmi.Spec.Domain.Resources = v1.ResourceRequirements{
+					Requests: kubev1.ResourceList{
+						kubev1.ResourceMemory: resource.MustParse("512Mi"),
+					},
+				}
+
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				overhead := getMemoryOverhead(vmi, config.GetClusterCPUArch())
+				expectedMemory := multiplyMemory(*overhead, 1.5)
+				expectedMemory.Add(*vmi.Spec.Domain.Resources.Requests.Memory())
+				Expect(pod.Spec.Containers[0].Resources.Requests.Memory().Value()).To(Equal(expectedMemory.Value()))
+			})
+			It("should annotate the pod with the resource overhead", func() {
+				config, kvInformer, svc = configFactory(defaultArch)
+				vmi := newVMIWithSriovInterface("testvmi", "1234")
+				vmi.Spec.Domain.CPU = &v1.CPU{
+					Cores:                 2,
+					DedicatedCPUPlacement: true,
+					IsolateEmulatorThread: true,
+				}
+				vmi.Spec.Domain.Resources = v1.ResourceRequirements{
+					Requests: kubev1.ResourceList{
+						kubev1.ResourceMemory: resource.MustParse("512Mi"),
+					},
+				}
+
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				overhead := &v1.VirtualMachineInstanceResourceOverhead{}
+				Expect(json.Unmarshal([]byte(pod.Annotations[v1.ResourceOverheadAnnotation]), overhead)).To(Succeed())
+				Expect(overhead.Memory.Value()).To(Equal(getMemoryOverhead(vmi, config.GetClusterCPUArch()).Value()))
+				Expect(overhead.CPU.Value()).To(Equal(int64(1)))
+			})
+			Context("with auxiliary threads CPU requests", func() {
+				var vmi *v1.VirtualMachineInstance
+
+				BeforeEach(func() {
+					config, kvInformer, svc = configFactory(defaultArch)
+					kvConfig := kv.DeepCopy()
+					kvConfig.Spec.Configuration.AuxiliaryThreadsCPURequests = &v1.AuxiliaryThreadsCPURequests{
+						Emulator: resource.NewMilliQuantity(100, resource.DecimalSI),
+						IOThread: resource.NewMilliQuantity(50, resource.DecimalSI),
+						Virtiofs: resource.NewMilliQuantity(200, resource.DecimalSI),
+					}
+					testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kvConfig)
+
+					dedicated := true
+					policy := v1.IOThreadsPolicyShared
+					vmi = newVMIWithSriovInterface("testvmi", "1234")
+					vmi.Spec.Domain.IOThreadsPolicy = &policy
+					vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+						{Name: "shared1"},
+						{Name: "shared2"},
+						{Name: "dedicated", DedicatedIOThread: &dedicated},
+					}
+					vmi.Spec.Domain.Devices.Filesystems = []v1.Filesystem{{Name: "fs", Virtiofs: &v1.FilesystemVirtiofs{}}}
+					vmi.Spec.Domain.Resources = v1.ResourceRequirements{
+						Requests: kubev1.ResourceList{
+							kubev1.ResourceCPU:    resource.MustParse("1"),
+							kubev1.ResourceMemory: resource.MustParse("512Mi"),
+						},
+					}
+				})
+
+				It("should add them to the CPU request and report their breakdown", func() {
+					pod, err := svc.RenderLaunchManifest(vmi)
+					Expect(err).ToNot(HaveOccurred())
+
+					// 100m for the emulator, 2*50m for a shared and a dedicated IO thread, 200m for virtiofsd
+					cpuRequest := pod.Spec.Containers[0].Resources.Requests[kubev1.ResourceCPU]
+					Expect(cpuRequest.MilliValue()).To(Equal(int64(1400)))
+					Expect(pod.Spec.Containers[0].Resources.Limits).ToNot(HaveKey(kubev1.ResourceCPU))
+
+					overhead := &v1.VirtualMachineInstanceResourceOverhead{}
+					Expect(json.Unmarshal([]byte(pod.Annotations[v1.ResourceOverheadAnnotation]), overhead)).To(Succeed())
+					Expect(overhead.CPU.MilliValue()).To(Equal(int64(400)))
+					Expect(overhead.EmulatorCPU.MilliValue()).To(Equal(int64(100)))
+					Expect(overhead.IOThreadsCPU.MilliValue()).To(Equal(int64(100)))
+					Expect(overhead.VirtiofsCPU.MilliValue()).To(Equal(int64(200)))
+				})
+
+				It("should add them to the CPU limit if there is one", func() {
+					vmi.Spec.Domain.Resources.Limits = kubev1.ResourceList{
+						kubev1.ResourceCPU: resource.MustParse("2"),
+					}
+
+					pod, err := svc.RenderLaunchManifest(vmi)
+					Expect(err).ToNot(HaveOccurred())
+
+					cpuLimit := pod.Spec.Containers[0].Resources.Limits[kubev1.ResourceCPU]
+					Expect(cpuLimit.MilliValue()).To(Equal(int64(2400)))
+				})
+
+				It("should not add them to VMIs with dedicated CPUs", func() {
+					vmi.Spec.Domain.CPU = &v1.CPU{Cores: 1, DedicatedCPUPlacement: true}
+
+					pod, err := svc.RenderLaunchManifest(vmi)
+					Expect(err).ToNot(HaveOccurred())
+
+					cpuRequest := pod.Spec.Containers[0].Resources.Requests[kubev1.ResourceCPU]
+					Expect(cpuRequest.MilliValue()).To(Equal(int64(1000)))
+					overhead := &v1.VirtualMachineInstanceResourceOverhead{}
+					Expect(json.Unmarshal([]byte(pod.Annotations[v1.ResourceOverheadAnnotation]), overhead)).To(Succeed())
+					Expect(overhead.EmulatorCPU).To(BeNil())
+				})
+			})
+
+			table.DescribeTable("should count the IO threads of the disks", func(policy *v1.IOThreadsPolicy, dedicatedDisks, sharedDisks int, expectedThreads int64) {
+				vmi := v1.NewMinimalVMI("testvmi")
+				vmi.Spec.Domain.IOThreadsPolicy = policy
+				vmi.Spec.Domain.Resources.Requests = kubev1.ResourceList{kubev1.ResourceCPU: resource.MustParse("2")}
+				dedicated := true
+				for i := 0; i < dedicatedDisks; i++ {
+					vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{Name: fmt.Sprintf("dedicated%d", i), DedicatedIOThread: &dedicated})
+				}
+				for i := 0; i < sharedDisks; i++ {
+					vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks, v1.Disk{Name: fmt.Sprintf("shared%d", i)})
+				}
+				Expect(getIOThreadsCount(vmi)).To(Equal(expectedThreads))
+			},
+				table.Entry("without IO threads", nil, 0, 3, int64(0)),
+				table.Entry("with dedicated IO threads only", nil, 2, 3, int64(3)),
+				table.Entry("with the shared policy", ioThreadsPolicy(v1.IOThreadsPolicyShared), 0, 3, int64(1)),
+				table.Entry("with the auto policy", ioThreadsPolicy(v1.IOThreadsPolicyAuto), 0, 3, int64(3)),
+				table.Entry("with the auto policy limited by the CPUs", ioThreadsPolicy(v1.IOThreadsPolicyAuto), 1, 6, int64(4)),
+			)
 		})
 		Context("with slirp interface", func() {
 			It("Should have empty port list in the pod manifest", func() {
@@ -2419,6 +2766,54 @@ var _ = Describe("Template", func() {
 			})
 		})
 
+		It("should consume the VMI capacity of the node", func() {
+			config, kvInformer, svc = configFactory(defaultArch)
+			vmi := v1.VirtualMachineInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "testvmi", Namespace: "default", UID: "1234",
+				},
+			}
+			pod, err := svc.RenderLaunchManifest(&vmi)
+			Expect(err).ToNot(HaveOccurred())
+
+			vmiDevice, ok := pod.Spec.Containers[0].Resources.Limits[VMIDevice]
+			Expect(ok).To(BeTrue())
+			Expect(int(vmiDevice.Value())).To(Equal(1))
+		})
+
+		It("should reserve the bandwidth of the interfaces in whole bandwidth devices", func() {
+			config, kvInformer, svc = configFactory(defaultArch)
+			vmi := v1.VirtualMachineInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "testvmi", Namespace: "default", UID: "1234",
+				},
+			}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{
+				{Name: "default", Bandwidth: resource.NewScaledQuantity(10, resource.Giga)},
+				{Name: "secondary", Bandwidth: resource.NewScaledQuantity(150, resource.Mega)},
+				{Name: "unreserved"},
+			}
+			pod, err := svc.RenderLaunchManifest(&vmi)
+			Expect(err).ToNot(HaveOccurred())
+
+			bandwidth, ok := pod.Spec.Containers[0].Resources.Limits[BandwidthDevice]
+			Expect(ok).To(BeTrue())
+			Expect(bandwidth.Value()).To(Equal(int64(102)))
+		})
+
+		It("should not reserve bandwidth without interfaces reserving any", func() {
+			config, kvInformer, svc = configFactory(defaultArch)
+			vmi := v1.VirtualMachineInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "testvmi", Namespace: "default", UID: "1234",
+				},
+			}
+			pod, err := svc.RenderLaunchManifest(&vmi)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(pod.Spec.Containers[0].Resources.Limits).ToNot(HaveKey(kubev1.ResourceName(BandwidthDevice)))
+		})
+
 		Context("with pod networking", func() {
 			It("Should require tun device by default", func() {
 				config, kvInformer, svc = configFactory(defaultArch)
@@ -2487,6 +2882,48 @@ var _ = Describe("Template", func() {
 			})
 		})
 
+		Context("with a restored memory state", func() {
+			var vmi *v1.VirtualMachineInstance
+
+			BeforeEach(func() {
+				config, kvInformer, svc = configFactory(defaultArch)
+				vmi = &v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "testvmi", Namespace: "default", UID: "1234",
+						Annotations: map[string]string{v1.RestoreMemoryClaimAnnotation: "memory-pvc"},
+					},
+				}
+			})
+
+			It("should mount the claim of the memory state read-only", func() {
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pod.Spec.Volumes).To(ContainElement(kubev1.Volume{
+					Name: "restore-memory",
+					VolumeSource: kubev1.VolumeSource{
+						PersistentVolumeClaim: &kubev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: "memory-pvc",
+							ReadOnly:  true,
+						},
+					},
+				}))
+				Expect(pod.Spec.Containers[0].VolumeMounts).To(ContainElement(kubev1.VolumeMount{
+					Name:      "restore-memory",
+					MountPath: k6tconfig.RestoreMemoryDir,
+					ReadOnly:  true,
+				}))
+			})
+
+			It("should not mount the claim into the pods of a scheduled VMI", func() {
+				vmi.Status.NodeName = "node01"
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				for _, volume := range pod.Spec.Volumes {
+					Expect(volume.Name).ToNot(Equal("restore-memory"))
+				}
+			})
+		})
+
 		Context("with a downwardMetrics volume source", func() {
 
 			var vmi *v1.VirtualMachineInstance
@@ -2582,6 +3019,105 @@ var _ = Describe("Template", func() {
 			})
 		})
 
+		Context("with user sidecars", func() {
+			newSidecarVMI := func() *v1.VirtualMachineInstance {
+				return &v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "testvmi", Namespace: "default", UID: "1234",
+					},
+					Spec: v1.VirtualMachineInstanceSpec{
+						Domain: v1.DomainSpec{
+							Devices: v1.Devices{
+								DisableHotplug: true,
+							},
+						},
+						Volumes: []v1.Volume{
+							{
+								Name: "licenses",
+								VolumeSource: v1.VolumeSource{
+									ConfigMap: &v1.ConfigMapVolumeSource{
+										LocalObjectReference: kubev1.LocalObjectReference{Name: "licenses"},
+									},
+								},
+							},
+							{
+								Name: "cloudinit",
+								VolumeSource: v1.VolumeSource{
+									CloudInitNoCloud: &v1.CloudInitNoCloudSource{UserData: "#cloud-config"},
+								},
+							},
+						},
+						Sidecars: []v1.Sidecar{
+							{
+								Name:    "license-daemon",
+								Image:   "license-daemon:latest",
+								Command: []string{"/usr/bin/license-daemon"},
+								Resources: kubev1.ResourceRequirements{
+									Requests: kubev1.ResourceList{
+										kubev1.ResourceCPU:    resource.MustParse("100m"),
+										kubev1.ResourceMemory: resource.MustParse("32Mi"),
+									},
+								},
+								VolumeMounts: []v1.SidecarVolumeMount{
+									{Name: "licenses", MountPath: "/etc/licenses"},
+									{Name: "cloudinit", MountPath: "/cloud-init"},
+								},
+							},
+						},
+					},
+				}
+			}
+
+			It("should add the sidecar containers with read-only volume mounts", func() {
+				config, kvInformer, svc = configFactory(defaultArch)
+				pod, err := svc.RenderLaunchManifest(newSidecarVMI())
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(pod.Spec.Containers).To(HaveLen(2))
+				sidecar := pod.Spec.Containers[1]
+				Expect(sidecar.Name).To(Equal("license-daemon"))
+				Expect(sidecar.Image).To(Equal("license-daemon:latest"))
+				Expect(sidecar.Command).To(Equal([]string{"/usr/bin/license-daemon"}))
+				Expect(*sidecar.SecurityContext.Privileged).To(BeFalse())
+				Expect(sidecar.Resources.Requests.Cpu().Cmp(resource.MustParse("100m"))).To(BeZero())
+				Expect(sidecar.Resources.Limits).To(BeEmpty())
+				Expect(sidecar.VolumeMounts).To(ConsistOf(
+					kubev1.VolumeMount{Name: "licenses", MountPath: "/etc/licenses", ReadOnly: true},
+					kubev1.VolumeMount{Name: "ephemeral-disks", MountPath: "/cloud-init", SubPath: "cloud-init-data/default/testvmi", ReadOnly: true},
+				))
+			})
+
+			It("should share the sockets of the serial channels with the sidecar containers", func() {
+				config, kvInformer, svc = configFactory(defaultArch)
+				vmi := newSidecarVMI()
+				vmi.Spec.Domain.Devices.Channels = []v1.SerialChannel{{Name: "logs", Target: "org.example.log.0"}}
+
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(pod.Spec.Volumes).To(ContainElement(kubev1.Volume{
+					Name:         "serial-channels",
+					VolumeSource: kubev1.VolumeSource{EmptyDir: &kubev1.EmptyDirVolumeSource{}},
+				}))
+				channelsMount := kubev1.VolumeMount{Name: "serial-channels", MountPath: "/var/run/kubevirt-channels"}
+				Expect(pod.Spec.Containers[0].VolumeMounts).To(ContainElement(channelsMount))
+				Expect(pod.Spec.Containers[1].VolumeMounts).To(ContainElement(channelsMount))
+			})
+
+			It("should set limits equal to requests for dedicated CPUs", func() {
+				config, kvInformer, svc = configFactory(defaultArch)
+				vmi := newSidecarVMI()
+				vmi.Spec.Domain.CPU = &v1.CPU{Cores: 2, DedicatedCPUPlacement: true}
+
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				sidecar := pod.Spec.Containers[1]
+				Expect(sidecar.Resources.Limits.Cpu().Cmp(resource.MustParse("100m"))).To(BeZero())
+				Expect(sidecar.Resources.Limits.Memory().Cmp(resource.MustParse("32Mi"))).To(BeZero())
+			})
+		})
+
 		Context("with a Sysprep volume source", func() {
 			Context("with a ConfigMap", func() {
 				It("Should add the Sysprep ConfigMap to template", func() {
@@ -2680,6 +3216,69 @@ var _ = Describe("Template", func() {
 				Expect(pod.Spec.Volumes[3].Secret.SecretName).To(Equal("test-secret"))
 			})
 		})
+		Context("with a network disk volume source", func() {
+			newNetworkDiskVMI := func(auth *v1.NetworkDiskAuth) *v1.VirtualMachineInstance {
+				return &v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "testvmi", Namespace: "default", UID: "1234",
+					},
+					Spec: v1.VirtualMachineInstanceSpec{
+						Volumes: []v1.Volume{
+							{
+								Name: "network-volume",
+								VolumeSource: v1.VolumeSource{
+									NetworkDisk: &v1.NetworkDiskSource{
+										Protocol: v1.NetworkDiskProtocolISCSI,
+										Name:     "iqn.2021-01.io.kubevirt:target/1",
+										Host:     v1.NetworkDiskHost{Name: "storage.example.com"},
+										Auth:     auth,
+									},
+								},
+							},
+						},
+						Domain: v1.DomainSpec{
+							Devices: v1.Devices{
+								DisableHotplug: true,
+							},
+						},
+					},
+				}
+			}
+
+			It("should mount the credentials of the disk", func() {
+				config, kvInformer, svc = configFactory(defaultArch)
+				vmi := newNetworkDiskVMI(&v1.NetworkDiskAuth{Username: "user", SecretRef: "chap-secret"})
+
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				var secretVolume *kubev1.Volume
+				for i, volume := range pod.Spec.Volumes {
+					if volume.Name == "network-volume" {
+						secretVolume = &pod.Spec.Volumes[i]
+					}
+				}
+				Expect(secretVolume).ToNot(BeNil())
+				Expect(secretVolume.Secret.SecretName).To(Equal("chap-secret"))
+				Expect(pod.Spec.Containers[0].VolumeMounts).To(ContainElement(kubev1.VolumeMount{
+					Name:      "network-volume",
+					MountPath: "/var/run/kubevirt-private/network-disk-secret/network-volume",
+					ReadOnly:  true,
+				}))
+			})
+
+			It("should not add a volume without credentials", func() {
+				config, kvInformer, svc = configFactory(defaultArch)
+				vmi := newNetworkDiskVMI(nil)
+
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+
+				for _, volume := range pod.Spec.Volumes {
+					Expect(volume.Name).ToNot(Equal("network-volume"))
+				}
+			})
+		})
 		Context("with probes", func() {
 			var vmi *v1.VirtualMachineInstance
 			BeforeEach(func() {
@@ -3051,6 +3650,129 @@ var _ = Describe("Template", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(pod.Spec.RuntimeClassName).To(BeNil())
 			})
+
+			It("Should prefer the runtimeClassName of the VMI", func() {
+				config, kvInformer, svc = configFactory(defaultArch)
+				kvConfig := kv.DeepCopy()
+				kvConfig.Spec.Configuration.DefaultRuntimeClass = "customRuntime"
+				testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kvConfig)
+
+				runtimeClassName := "vmiRuntime"
+				vmi := v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "testvmi",
+						Namespace: "namespace",
+						UID:       "1234",
+					},
+					Spec: v1.VirtualMachineInstanceSpec{
+						RuntimeClassName: &runtimeClassName,
+					},
+				}
+
+				pod, err := svc.RenderLaunchManifest(&vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(*pod.Spec.RuntimeClassName).To(Equal(runtimeClassName))
+			})
+		})
+
+		Context("with launcher pod customizations", func() {
+			var vmi *v1.VirtualMachineInstance
+
+			BeforeEach(func() {
+				config, kvInformer, svc = configFactory(defaultArch)
+				vmi = &v1.VirtualMachineInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "testvmi",
+						Namespace:   "namespace",
+						UID:         "1234",
+						Labels:      map[string]string{"vmi-label": "vmi"},
+						Annotations: map[string]string{"vmi-annotation": "vmi"},
+					},
+				}
+			})
+
+			It("should add the launcher pod labels and annotations", func() {
+				vmi.Spec.LauncherPodMetadata = &v1.LauncherPodMetadata{
+					Labels:      map[string]string{"sidecar.istio.io/inject": "true", v1.AppLabel: "custom"},
+					Annotations: map[string]string{"example.com/annotation": "value", "vmi-annotation": "custom"},
+				}
+
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pod.Labels).To(HaveKeyWithValue("vmi-label", "vmi"))
+				Expect(pod.Labels).To(HaveKeyWithValue("sidecar.istio.io/inject", "true"))
+				Expect(pod.Labels).To(HaveKeyWithValue(v1.AppLabel, "virt-launcher"))
+				Expect(pod.Annotations).To(HaveKeyWithValue("vmi-annotation", "vmi"))
+				Expect(pod.Annotations).To(HaveKeyWithValue("example.com/annotation", "value"))
+			})
+
+			It("should only propagate allowed labels and annotations", func() {
+				kvConfig := kv.DeepCopy()
+				kvConfig.Spec.Configuration.LauncherPodMetadataPropagation = &v1.LauncherPodMetadataPropagation{
+					AllowedLabels:      []string{"sidecar.istio.io/inject"},
+					AllowedAnnotations: []string{"example.com/*"},
+				}
+				testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kvConfig)
+				vmi.Labels["prometheus.kubevirt.io"] = "true"
+				vmi.Spec.LauncherPodMetadata = &v1.LauncherPodMetadata{
+					Labels:      map[string]string{"sidecar.istio.io/inject": "true", "monitoring": "hijacked"},
+					Annotations: map[string]string{"example.com/annotation": "value", "prometheus.io/scrape": "true"},
+				}
+
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pod.Labels).ToNot(HaveKey("vmi-label"))
+				Expect(pod.Labels).ToNot(HaveKey("prometheus.kubevirt.io"))
+				Expect(pod.Labels).ToNot(HaveKey("monitoring"))
+				Expect(pod.Labels).To(HaveKeyWithValue("sidecar.istio.io/inject", "true"))
+				Expect(pod.Labels).To(HaveKeyWithValue(v1.AppLabel, "virt-launcher"))
+				Expect(pod.Labels).To(HaveKeyWithValue(v1.CreatedByLabel, "1234"))
+				Expect(pod.Annotations).ToNot(HaveKey("vmi-annotation"))
+				Expect(pod.Annotations).ToNot(HaveKey("prometheus.io/scrape"))
+				Expect(pod.Annotations).To(HaveKeyWithValue("example.com/annotation", "value"))
+				Expect(pod.Annotations).To(HaveKeyWithValue(v1.DomainAnnotation, "testvmi"))
+			})
+
+			It("should add the topology spread constraints", func() {
+				constraints := []kubev1.TopologySpreadConstraint{
+					{
+						MaxSkew:           1,
+						TopologyKey:       "topology.kubernetes.io/zone",
+						WhenUnsatisfiable: kubev1.DoNotSchedule,
+						LabelSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"vmi-label": "vmi"},
+						},
+					},
+				}
+				vmi.Spec.TopologySpreadConstraints = constraints
+
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pod.Spec.TopologySpreadConstraints).To(Equal(constraints))
+			})
+
+			It("should only add the not draining readiness gate if connections are drained before migrations", func() {
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pod.Spec.ReadinessGates).To(BeEmpty())
+
+				drainSeconds := int64(30)
+				kvConfig := kv.DeepCopy()
+				kvConfig.Spec.Configuration.MigrationConfiguration = &v1.MigrationConfiguration{PreSwitchoverDrainSeconds: &drainSeconds}
+				testutils.UpdateFakeKubeVirtClusterConfig(kvInformer, kvConfig)
+
+				pod, err = svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pod.Spec.ReadinessGates).To(ConsistOf(kubev1.PodReadinessGate{ConditionType: v1.VirtualMachineInstanceNotDraining}))
+			})
+
+			It("should set the scheduler name", func() {
+				vmi.Spec.SchedulerName = "custom-scheduler"
+
+				pod, err := svc.RenderLaunchManifest(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pod.Spec.SchedulerName).To(Equal("custom-scheduler"))
+			})
 		})
 
 		table.DescribeTable("should require NET_BIND_SERVICE", func(interfaceType string) {
@@ -3403,3 +4125,7 @@ func validateAndExtractQemuTimeoutArg(args []string) string {
 
 	return timeoutString
 }
+
+func ioThreadsPolicy(policy v1.IOThreadsPolicy) *v1.IOThreadsPolicy {
+	return &policy
+}
@@ -34,6 +34,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	flag "github.com/spf13/pflag"
 	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes/scheme"
 	k8coresv1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	clientrest "k8s.io/client-go/rest"
@@ -57,6 +58,7 @@ import (
 	containerdisk "kubevirt.io/kubevirt/pkg/container-disk"
 	"kubevirt.io/kubevirt/pkg/controller"
 
+	"kubevirt.io/kubevirt/pkg/monitoring/accounting"
 	"kubevirt.io/kubevirt/pkg/monitoring/perfscale"
 	vmiprom "kubevirt.io/kubevirt/pkg/monitoring/vmistats" // import for prometheus metrics
 	"kubevirt.io/kubevirt/pkg/service"
@@ -141,6 +143,9 @@ type VirtControllerApp struct {
 
 	persistentVolumeClaimCache    cache.Store
 	persistentVolumeClaimInformer cache.SharedIndexInformer
+	persistentVolumeInformer      cache.SharedIndexInformer
+
+	namespaceInformer cache.SharedIndexInformer
 
 	rsController *VMIReplicaSet
 	rsInformer   cache.SharedIndexInformer
@@ -155,6 +160,8 @@ type VirtControllerApp struct {
 	migrationController *MigrationController
 	migrationInformer   cache.SharedIndexInformer
 
+	schedulingHintsInformer cache.SharedIndexInformer
+
 	workloadUpdateController *workloadupdater.WorkloadUpdateController
 
 	snapshotController        *snapshot.VMSnapshotController
@@ -167,6 +174,14 @@ type VirtControllerApp struct {
 
 	crdInformer cache.SharedIndexInformer
 
+	imageExportController *ImageExportController
+	imageExportInformer   cache.SharedIndexInformer
+
+	bulkOperationController *BulkOperationController
+	bulkOperationInformer   cache.SharedIndexInformer
+
+	maintenanceWindowInformer cache.SharedIndexInformer
+
 	LeaderElection leaderelectionconfig.Configuration
 
 	launcherImage              string
@@ -201,6 +216,8 @@ type VirtControllerApp struct {
 	launcherSubGid                    int64
 	snapshotControllerThreads         int
 	restoreControllerThreads          int
+	imageExportControllerThreads      int
+	bulkOperationControllerThreads    int
 	snapshotControllerResyncPeriod    time.Duration
 
 	caConfigMapName          string
@@ -301,6 +318,9 @@ func Execute() {
 
 	app.persistentVolumeClaimInformer = app.informerFactory.PersistentVolumeClaim()
 	app.persistentVolumeClaimCache = app.persistentVolumeClaimInformer.GetStore()
+	app.persistentVolumeInformer = app.informerFactory.PersistentVolume()
+
+	app.namespaceInformer = app.informerFactory.Namespace()
 
 	app.informerFactory.K8SInformerFactory().Policy().V1beta1().PodDisruptionBudgets().Informer()
 
@@ -308,6 +328,8 @@ func Execute() {
 
 	app.migrationInformer = app.informerFactory.VirtualMachineInstanceMigration()
 
+	app.schedulingHintsInformer = app.informerFactory.VirtualMachineSchedulingHints()
+
 	app.controllerRevisionInformer = app.informerFactory.ControllerRevision()
 
 	app.vmSnapshotInformer = app.informerFactory.VirtualMachineSnapshot()
@@ -315,6 +337,9 @@ func Execute() {
 	app.vmRestoreInformer = app.informerFactory.VirtualMachineRestore()
 	app.storageClassInformer = app.informerFactory.StorageClass()
 	app.allPodInformer = app.informerFactory.Pod()
+	app.imageExportInformer = app.informerFactory.VirtualMachineImageExport()
+	app.bulkOperationInformer = app.informerFactory.VirtualMachineBulkOperation()
+	app.maintenanceWindowInformer = app.informerFactory.MaintenanceWindow()
 
 	if app.hasCDI {
 		app.dataVolumeInformer = app.informerFactory.DataVolume()
@@ -335,6 +360,8 @@ func Execute() {
 	app.initSnapshotController()
 	app.initRestoreController()
 	app.initWorkloadUpdaterController()
+	app.initImageExportController()
+	app.initBulkOperationController()
 	go app.Run()
 
 	<-app.reInitChan
@@ -441,7 +468,7 @@ func (vca *VirtControllerApp) onStartedLeading() func(ctx context.Context) {
 			vca.vmControllerThreads, vca.migrationControllerThreads, vca.evacuationControllerThreads,
 			vca.disruptionBudgetControllerThreads)
 
-		vmiprom.SetupVMICollector(vca.vmiInformer)
+		vmiprom.SetupVMICollector(vca.vmiInformer, vca.clusterConfig)
 		perfscale.RegisterPerfScaleMetrics(vca.vmiInformer)
 
 		go vca.evacuationController.Run(vca.evacuationControllerThreads, stop)
@@ -453,10 +480,13 @@ func (vca *VirtControllerApp) onStartedLeading() func(ctx context.Context) {
 		go vca.migrationController.Run(vca.migrationControllerThreads, stop)
 		go vca.snapshotController.Run(vca.snapshotControllerThreads, stop)
 		go vca.restoreController.Run(vca.restoreControllerThreads, stop)
+		go vca.imageExportController.Run(vca.imageExportControllerThreads, stop)
+		go vca.bulkOperationController.Run(vca.bulkOperationControllerThreads, stop)
 		go vca.workloadUpdateController.Run(stop)
 		go vca.nodeTopologyUpdater.Run(vca.nodeTopologyUpdatePeriod, stop)
+		go accounting.NewUsageReporter(vca.vmiInformer, vca.persistentVolumeClaimInformer, vca.clusterConfig).Run(stop)
 
-		cache.WaitForCacheSync(stop, vca.persistentVolumeClaimInformer.HasSynced)
+		cache.WaitForCacheSync(stop, vca.persistentVolumeClaimInformer.HasSynced, vca.namespaceInformer.HasSynced)
 		close(vca.readyChan)
 		leaderGauge.Set(1)
 	}
@@ -465,7 +495,9 @@ func (vca *VirtControllerApp) onStartedLeading() func(ctx context.Context) {
 func (vca *VirtControllerApp) getNewRecorder(namespace string, componentName string) record.EventRecorder {
 	eventBroadcaster := record.NewBroadcaster()
 	eventBroadcaster.StartRecordingToSink(&k8coresv1.EventSinkImpl{Interface: vca.clientSet.CoreV1().Events(namespace)})
-	return eventBroadcaster.NewRecorder(scheme.Scheme, k8sv1.EventSource{Component: componentName})
+	recorder := controller.NewDedupingEventRecorder(eventBroadcaster.NewRecorder(scheme.Scheme, k8sv1.EventSource{Component: componentName}), controller.DefaultEventDedupWindow)
+	go recorder.Run(wait.NeverStop)
+	return recorder
 }
 
 func (vca *VirtControllerApp) initCommon() {
@@ -486,6 +518,7 @@ func (vca *VirtControllerApp) initCommon() {
 		vca.hotplugDiskDir,
 		vca.imagePullSecret,
 		vca.persistentVolumeClaimCache,
+		vca.namespaceInformer.GetStore(),
 		virtClient,
 		vca.clusterConfig,
 		vca.launcherSubGid,
@@ -502,6 +535,8 @@ func (vca *VirtControllerApp) initCommon() {
 		vca.clientSet,
 		vca.dataVolumeInformer,
 		topologyHinter,
+		vca.schedulingHintsInformer,
+		vca.persistentVolumeInformer,
 	)
 
 	recorder := vca.getNewRecorder(k8sv1.NamespaceAll, "node-controller")
@@ -513,6 +548,7 @@ func (vca *VirtControllerApp) initCommon() {
 		vca.migrationInformer,
 		vca.nodeInformer,
 		vca.persistentVolumeClaimInformer,
+		vca.storageClassInformer,
 		vca.vmiRecorder,
 		vca.clientSet,
 		vca.clusterConfig,
@@ -536,7 +572,8 @@ func (vca *VirtControllerApp) initVirtualMachines() {
 		vca.persistentVolumeClaimInformer,
 		vca.controllerRevisionInformer,
 		recorder,
-		vca.clientSet)
+		vca.clientSet,
+		vca.clusterConfig)
 }
 
 func (vca *VirtControllerApp) initDisruptionBudgetController() {
@@ -560,6 +597,7 @@ func (vca *VirtControllerApp) initWorkloadUpdaterController() {
 		vca.kvPodInformer,
 		vca.migrationInformer,
 		vca.kubeVirtInformer,
+		vca.maintenanceWindowInformer,
 		recorder,
 		vca.clientSet,
 		vca.clusterConfig)
@@ -572,6 +610,7 @@ func (vca *VirtControllerApp) initEvacuationController() {
 		vca.migrationInformer,
 		vca.nodeInformer,
 		vca.kvPodInformer,
+		vca.maintenanceWindowInformer,
 		recorder,
 		vca.clientSet,
 		vca.clusterConfig,
@@ -615,6 +654,27 @@ func (vca *VirtControllerApp) initRestoreController() {
 	vca.restoreController.Init()
 }
 
+func (vca *VirtControllerApp) initImageExportController() {
+	recorder := vca.getNewRecorder(k8sv1.NamespaceAll, "imageexport-controller")
+	vca.imageExportController = NewImageExportController(
+		vca.launcherImage,
+		vca.imageExportInformer,
+		vca.vmInformer,
+		vca.vmiInformer,
+		vca.kvPodInformer,
+		vca.persistentVolumeClaimInformer,
+		recorder,
+		vca.clientSet)
+}
+
+func (vca *VirtControllerApp) initBulkOperationController() {
+	recorder := vca.getNewRecorder(k8sv1.NamespaceAll, "bulkoperation-controller")
+	vca.bulkOperationController = NewBulkOperationController(
+		vca.bulkOperationInformer,
+		recorder,
+		vca.clientSet)
+}
+
 func (vca *VirtControllerApp) leaderProbe(_ *restful.Request, response *restful.Response) {
 	res := map[string]interface{}{}
 
@@ -696,6 +756,12 @@ func (vca *VirtControllerApp) AddFlags() {
 	flag.IntVar(&vca.restoreControllerThreads, "restore-controller-threads", defaultControllerThreads,
 		"Number of goroutines to run for restore controller")
 
+	flag.IntVar(&vca.imageExportControllerThreads, "image-export-controller-threads", defaultControllerThreads,
+		"Number of goroutines to run for image export controller")
+
+	flag.IntVar(&vca.bulkOperationControllerThreads, "bulk-operation-controller-threads", defaultControllerThreads,
+		"Number of goroutines to run for bulk operation controller")
+
 	flag.DurationVar(&vca.snapshotControllerResyncPeriod, "snapshot-controller-resync-period", defaultSnapshotControllerResyncPeriod,
 		"Number of goroutines to run for snapshot controller")
 
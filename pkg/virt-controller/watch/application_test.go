@@ -89,6 +89,7 @@ var _ = Describe("Application", func() {
 		pdbInformer, _ := testutils.NewFakeInformerFor(&v1beta1.PodDisruptionBudget{})
 		podInformer, _ := testutils.NewFakeInformerFor(&k8sv1.Pod{})
 		pvcInformer, _ := testutils.NewFakeInformerFor(&k8sv1.PersistentVolumeClaim{})
+		namespaceInformer, _ := testutils.NewFakeInformerFor(&k8sv1.Namespace{})
 		crInformer, _ := testutils.NewFakeInformerFor(&appsv1.ControllerRevision{})
 		dataVolumeInformer, _ := testutils.NewFakeInformerFor(&cdiv1.DataVolume{})
 		rsInformer, _ := testutils.NewFakeInformerFor(&v1.VirtualMachineInstanceReplicaSet{})
@@ -96,16 +97,21 @@ var _ = Describe("Application", func() {
 		crdInformer, _ := testutils.NewFakeInformerFor(&extv1.CustomResourceDefinition{})
 		vmRestoreInformer, _ := testutils.NewFakeInformerFor(&snapshotv1.VirtualMachineRestore{})
 		dvInformer, _ := testutils.NewFakeInformerFor(&cdiv1.DataVolume{})
+		schedulingHintsInformer, _ := testutils.NewFakeInformerFor(&v1.VirtualMachineSchedulingHints{})
+		pvInformer, _ := testutils.NewFakeInformerFor(&k8sv1.PersistentVolume{})
+		imageExportInformer, _ := testutils.NewFakeInformerFor(&v1.VirtualMachineImageExport{})
+		bulkOperationInformer, _ := testutils.NewFakeInformerFor(&v1.VirtualMachineBulkOperation{})
+		maintenanceWindowInformer, _ := testutils.NewFakeInformerFor(&v1.MaintenanceWindow{})
 
 		var qemuGid int64 = 107
 
 		app.vmiInformer = vmiInformer
 		app.nodeTopologyUpdater = topologyUpdater
 		app.informerFactory = controller.NewKubeInformerFactory(nil, nil, nil, "test")
-		app.evacuationController = evacuation.NewEvacuationController(vmiInformer, migrationInformer, nodeInformer, podInformer, recorder, virtClient, config)
+		app.evacuationController = evacuation.NewEvacuationController(vmiInformer, migrationInformer, nodeInformer, podInformer, maintenanceWindowInformer, recorder, virtClient, config)
 		app.disruptionBudgetController = disruptionbudget.NewDisruptionBudgetController(vmiInformer, pdbInformer, podInformer, migrationInformer, recorder, virtClient)
 		app.nodeController = NewNodeController(virtClient, nodeInformer, vmiInformer, recorder)
-		app.vmiController = NewVMIController(services.NewTemplateService("a", 240, "b", "c", "d", "e", "f", "g", pvcInformer.GetStore(), virtClient, config, qemuGid),
+		app.vmiController = NewVMIController(services.NewTemplateService("a", 240, "b", "c", "d", "e", "f", "g", pvcInformer.GetStore(), namespaceInformer.GetStore(), virtClient, config, qemuGid),
 			vmiInformer,
 			vmInformer,
 			podInformer,
@@ -114,15 +120,18 @@ var _ = Describe("Application", func() {
 			virtClient,
 			dataVolumeInformer,
 			topology.NewTopologyHinter(&cache.FakeCustomStore{}, &cache.FakeCustomStore{}, "amd64", nil),
+			schedulingHintsInformer,
+			pvInformer,
 		)
 		app.rsController = NewVMIReplicaSet(vmiInformer, rsInformer, recorder, virtClient, uint(10))
-		app.vmController = NewVMController(vmiInformer, vmInformer, dataVolumeInformer, pvcInformer, crInformer, recorder, virtClient)
-		app.migrationController = NewMigrationController(services.NewTemplateService("a", 240, "b", "c", "d", "e", "f", "g", pvcInformer.GetStore(), virtClient, config, qemuGid),
+		app.vmController = NewVMController(vmiInformer, vmInformer, dataVolumeInformer, pvcInformer, crInformer, recorder, virtClient, config)
+		app.migrationController = NewMigrationController(services.NewTemplateService("a", 240, "b", "c", "d", "e", "f", "g", pvcInformer.GetStore(), namespaceInformer.GetStore(), virtClient, config, qemuGid),
 			vmiInformer,
 			podInformer,
 			migrationInformer,
 			nodeInformer,
 			pvcInformer,
+			storageClassInformer,
 			recorder,
 			virtClient,
 			config,
@@ -155,7 +164,11 @@ var _ = Describe("Application", func() {
 			Recorder:                  recorder,
 		}
 		app.restoreController.Init()
+		app.imageExportController = NewImageExportController("a", imageExportInformer, vmInformer, vmiInformer, podInformer, pvcInformer, recorder, virtClient)
+		app.bulkOperationController = NewBulkOperationController(bulkOperationInformer, recorder, virtClient)
+		app.clusterConfig = config
 		app.persistentVolumeClaimInformer = pvcInformer
+		app.namespaceInformer = namespaceInformer
 		app.nodeInformer = nodeInformer
 
 		app.readyChan = make(chan bool)
@@ -172,6 +185,7 @@ var _ = Describe("Application", func() {
 
 		// for sync
 		go pvcInformer.Run(ctx.Done())
+		go namespaceInformer.Run(ctx.Done())
 		go nodeInformer.Run(ctx.Done())
 		time.Sleep(time.Second)
 
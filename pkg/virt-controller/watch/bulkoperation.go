@@ -0,0 +1,318 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package watch
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	authv1 "k8s.io/api/authorization/v1"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	virtv1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/controller"
+)
+
+const (
+	// FailedBulkOperationReason is added in an event if a bulk operation could not be applied to a VirtualMachine
+	FailedBulkOperationReason = "FailedBulkOperation"
+	// SuccessfulBulkOperationReason is added in an event if a bulk operation was applied to all VirtualMachines
+	SuccessfulBulkOperationReason = "SuccessfulBulkOperation"
+)
+
+// bulkOperationTTLAfterCompletion is how long a completed VirtualMachineBulkOperation is kept, so that
+// the progress of the VirtualMachines it was applied to can still be followed
+const bulkOperationTTLAfterCompletion = 24 * time.Hour
+
+// BulkOperationAuthFunc checks if the user who requested a bulk operation may apply its action to a VirtualMachine
+type BulkOperationAuthFunc func(operation *virtv1.VirtualMachineBulkOperation, vmName string) (bool, string, error)
+
+// BulkOperationController applies the action of VirtualMachineBulkOperations to their VirtualMachines
+// and records the outcome in their status, so that an operation survives restarts of virt-controller
+type BulkOperationController struct {
+	clientset         kubecli.KubevirtClient
+	Queue             workqueue.RateLimitingInterface
+	operationInformer cache.SharedIndexInformer
+	recorder          record.EventRecorder
+	authFunc          BulkOperationAuthFunc
+}
+
+// NewBulkOperationController creates a new instance of the BulkOperationController struct.
+func NewBulkOperationController(operationInformer cache.SharedIndexInformer,
+	recorder record.EventRecorder,
+	clientset kubecli.KubevirtClient) *BulkOperationController {
+
+	c := &BulkOperationController{
+		clientset:         clientset,
+		Queue:             workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "virt-controller-bulkoperation"),
+		operationInformer: operationInformer,
+		recorder:          recorder,
+	}
+	c.authFunc = c.authorize
+
+	c.operationInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueueOperation,
+		DeleteFunc: func(_ interface{}) { /* the created migrations are left alone */ },
+		UpdateFunc: func(_, curr interface{}) { c.enqueueOperation(curr) },
+	})
+
+	return c
+}
+
+func (c *BulkOperationController) enqueueOperation(obj interface{}) {
+	key, err := controller.KeyFunc(obj)
+	if err != nil {
+		log.Log.Reason(err).Error("Failed to extract key from bulk operation.")
+		return
+	}
+	c.Queue.Add(key)
+}
+
+// Run runs the passed in BulkOperationController.
+func (c *BulkOperationController) Run(threadiness int, stopCh <-chan struct{}) {
+	defer controller.HandlePanic()
+	defer c.Queue.ShutDown()
+	log.Log.Info("Starting bulk operation controller.")
+
+	cache.WaitForCacheSync(stopCh, c.operationInformer.HasSynced)
+
+	for i := 0; i < threadiness; i++ {
+		go wait.Until(c.runWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+	log.Log.Info("Stopping bulk operation controller.")
+}
+
+func (c *BulkOperationController) runWorker() {
+	for c.Execute() {
+	}
+}
+
+// Execute runs commands from the controller queue, if there is
+// an error it requeues the command. Returns false if the queue
+// is empty.
+func (c *BulkOperationController) Execute() bool {
+	key, quit := c.Queue.Get()
+	if quit {
+		return false
+	}
+	defer c.Queue.Done(key)
+	err := c.execute(key.(string))
+
+	if err != nil {
+		log.Log.Reason(err).Infof("reenqueuing bulk operation %v", key)
+		c.Queue.AddRateLimited(key)
+	} else {
+		log.Log.V(4).Infof("processed bulk operation %v", key)
+		c.Queue.Forget(key)
+	}
+	return true
+}
+
+// execute applies the action to the next batch of VirtualMachines and records the outcome.
+// Updating the status triggers the next batch, until the action was applied to all of them.
+func (c *BulkOperationController) execute(key string) error {
+	obj, exists, err := c.operationInformer.GetStore().GetByKey(key)
+	if err != nil || !exists {
+		return err
+	}
+	operation := obj.(*virtv1.VirtualMachineBulkOperation)
+	if operation.IsFinal() {
+		return c.deleteExpiredOperation(operation, key)
+	}
+	newOperation := operation.DeepCopy()
+	var retryErr error
+
+	batch := nextBulkOperationBatch(operation)
+	if len(batch) == 0 {
+		now := metav1.Now()
+		newOperation.Status.Phase = virtv1.BulkOperationSucceeded
+		newOperation.Status.CompletionTimestamp = &now
+		c.recorder.Eventf(operation, k8sv1.EventTypeNormal, SuccessfulBulkOperationReason,
+			"Applied %s to %d VirtualMachines, %d failed", operation.Spec.Action, len(operation.Spec.VirtualMachines), len(operation.Status.Failures))
+	} else {
+		failures := make([]string, len(batch))
+		errs := make([]error, len(batch))
+		wg := sync.WaitGroup{}
+		for i := range batch {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				failures[i], errs[i] = c.applyToVM(operation, batch[i])
+			}(i)
+		}
+		wg.Wait()
+
+		for i, name := range batch {
+			switch {
+			case errs[i] != nil:
+				// the VirtualMachine stays in the next batch
+				log.Log.Object(operation).Reason(errs[i]).Errorf("Failed to authorize %s of VirtualMachine %s", operation.Spec.Action, name)
+				retryErr = errs[i]
+			case failures[i] == "":
+				newOperation.Status.Applied = append(newOperation.Status.Applied, name)
+			default:
+				newOperation.Status.Failures = append(newOperation.Status.Failures, virtv1.BulkOperationFailure{Name: name, Message: failures[i]})
+				c.recorder.Eventf(operation, k8sv1.EventTypeWarning, FailedBulkOperationReason,
+					"Failed to %s VirtualMachine %s: %s", operation.Spec.Action, name, failures[i])
+			}
+		}
+		newOperation.Status.Phase = virtv1.BulkOperationRunning
+	}
+
+	if !equality.Semantic.DeepEqual(operation.Status, newOperation.Status) {
+		if _, err := c.clientset.VirtualMachineBulkOperation(operation.Namespace).UpdateStatus(newOperation); err != nil {
+			return err
+		}
+	}
+	return retryErr
+}
+
+// deleteExpiredOperation deletes a completed VirtualMachineBulkOperation once
+// bulkOperationTTLAfterCompletion passed, the created migrations are left alone
+func (c *BulkOperationController) deleteExpiredOperation(operation *virtv1.VirtualMachineBulkOperation, key string) error {
+	if operation.DeletionTimestamp != nil {
+		return nil
+	}
+	completed := operation.CreationTimestamp.Time
+	if operation.Status.CompletionTimestamp != nil {
+		completed = operation.Status.CompletionTimestamp.Time
+	}
+	if timeLeft := time.Until(completed.Add(bulkOperationTTLAfterCompletion)); timeLeft > 0 {
+		c.Queue.AddAfter(key, timeLeft)
+		return nil
+	}
+
+	err := c.clientset.VirtualMachineBulkOperation(operation.Namespace).Delete(operation.Name, &metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{UID: &operation.UID},
+	})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	log.Log.Object(operation).Infof("Deleted the bulk operation %s after it completed", bulkOperationTTLAfterCompletion)
+	return nil
+}
+
+// nextBulkOperationBatch returns the next VirtualMachines the action has to be applied to,
+// at most as many as the parallelism of the operation allows
+func nextBulkOperationBatch(operation *virtv1.VirtualMachineBulkOperation) []string {
+	parallelism := int(operation.Spec.Parallelism)
+	if parallelism < 1 {
+		parallelism = int(virtv1.DefaultBulkOperationParallelism)
+	} else if parallelism > int(virtv1.MaxBulkOperationParallelism) {
+		parallelism = int(virtv1.MaxBulkOperationParallelism)
+	}
+
+	processed := map[string]bool{}
+	for _, name := range operation.Status.Applied {
+		processed[name] = true
+	}
+	for _, failure := range operation.Status.Failures {
+		processed[failure.Name] = true
+	}
+
+	var batch []string
+	for _, name := range operation.Spec.VirtualMachines {
+		if len(batch) == parallelism {
+			break
+		}
+		if !processed[name] {
+			batch = append(batch, name)
+		}
+	}
+	return batch
+}
+
+// applyToVM authorizes the requesting user and applies the action to a single
+// VirtualMachine, it returns a failure message if that did not work out and an
+// error if the user could not be authorized
+func (c *BulkOperationController) applyToVM(operation *virtv1.VirtualMachineBulkOperation, name string) (string, error) {
+	allowed, reason, err := c.authFunc(operation, name)
+	if err != nil {
+		return "", err
+	}
+	if !allowed {
+		return fmt.Sprintf("forbidden: %s", reason), nil
+	}
+
+	switch operation.Spec.Action {
+	case virtv1.BulkStartAction:
+		err = c.clientset.VirtualMachine(operation.Namespace).Start(name, &virtv1.StartOptions{})
+	case virtv1.BulkStopAction:
+		err = c.clientset.VirtualMachine(operation.Namespace).Stop(name)
+	case virtv1.BulkMigrateAction:
+		_, err = c.clientset.VirtualMachineInstanceMigration(operation.Namespace).Create(&virtv1.VirtualMachineInstanceMigration{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "kubevirt-migrate-vm-",
+				Labels:       map[string]string{virtv1.BulkOperationLabel: operation.Name},
+			},
+			Spec: virtv1.VirtualMachineInstanceMigrationSpec{
+				VMIName: name,
+			},
+		})
+	default:
+		return fmt.Sprintf("unknown action %q", operation.Spec.Action), nil
+	}
+	if err != nil {
+		return err.Error(), nil
+	}
+	return "", nil
+}
+
+// authorize checks if the user recorded in the operation may call the subresource of the action on the VirtualMachine
+func (c *BulkOperationController) authorize(operation *virtv1.VirtualMachineBulkOperation, vmName string) (bool, string, error) {
+	extra := map[string]authv1.ExtraValue{}
+	for k, v := range operation.Spec.Extra {
+		extra[k] = v
+	}
+	sar := &authv1.SubjectAccessReview{
+		Spec: authv1.SubjectAccessReviewSpec{
+			User:   operation.Spec.User,
+			Groups: operation.Spec.Groups,
+			Extra:  extra,
+			ResourceAttributes: &authv1.ResourceAttributes{
+				Namespace:   operation.Namespace,
+				Verb:        "update",
+				Group:       virtv1.SubresourceGroupName,
+				Version:     virtv1.ApiLatestVersion,
+				Resource:    "virtualmachines",
+				Subresource: string(operation.Spec.Action),
+				Name:        vmName,
+			},
+		},
+	}
+	result, err := c.clientset.AuthorizationV1().SubjectAccessReviews().Create(context.Background(), sar, metav1.CreateOptions{})
+	if err != nil {
+		return false, "", err
+	}
+	return result.Status.Allowed, result.Status.Reason, nil
+}
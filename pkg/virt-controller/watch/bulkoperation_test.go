@@ -0,0 +1,222 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package watch
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	authv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+
+	virtv1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+var _ = Describe("Bulk operation controller", func() {
+	var operationInformer cache.SharedIndexInformer
+	var operationInterface *kubecli.MockVirtualMachineBulkOperationInterface
+	var vmInterface *kubecli.MockVirtualMachineInterface
+	var migrationInterface *kubecli.MockVirtualMachineInstanceMigrationInterface
+	var kubeClient *fake.Clientset
+	var recorder *record.FakeRecorder
+	var controller *BulkOperationController
+
+	newOperation := func(action virtv1.BulkOperationAction, parallelism int32, vms ...string) *virtv1.VirtualMachineBulkOperation {
+		return &virtv1.VirtualMachineBulkOperation{
+			ObjectMeta: metav1.ObjectMeta{Name: "bulk-" + string(action) + "-abcde", Namespace: metav1.NamespaceDefault},
+			Spec: virtv1.VirtualMachineBulkOperationSpec{
+				Action:          action,
+				Selector:        "app=db",
+				Parallelism:     parallelism,
+				VirtualMachines: vms,
+				User:            "alice",
+				Groups:          []string{"dbas"},
+				Extra:           map[string][]string{"scopes": {"all"}},
+			},
+		}
+	}
+
+	expectStatus := func(status virtv1.VirtualMachineBulkOperationStatus) {
+		operationInterface.EXPECT().UpdateStatus(gomock.Any()).DoAndReturn(func(operation *virtv1.VirtualMachineBulkOperation) (*virtv1.VirtualMachineBulkOperation, error) {
+			Expect(operation.Status).To(Equal(status))
+			return operation, nil
+		})
+	}
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		virtClient := kubecli.NewMockKubevirtClient(ctrl)
+		operationInterface = kubecli.NewMockVirtualMachineBulkOperationInterface(ctrl)
+		vmInterface = kubecli.NewMockVirtualMachineInterface(ctrl)
+		migrationInterface = kubecli.NewMockVirtualMachineInstanceMigrationInterface(ctrl)
+		virtClient.EXPECT().VirtualMachineBulkOperation(metav1.NamespaceDefault).Return(operationInterface).AnyTimes()
+		virtClient.EXPECT().VirtualMachine(metav1.NamespaceDefault).Return(vmInterface).AnyTimes()
+		virtClient.EXPECT().VirtualMachineInstanceMigration(metav1.NamespaceDefault).Return(migrationInterface).AnyTimes()
+		kubeClient = fake.NewSimpleClientset()
+		virtClient.EXPECT().AuthorizationV1().Return(kubeClient.AuthorizationV1()).AnyTimes()
+
+		operationInformer, _ = testutils.NewFakeInformerFor(&virtv1.VirtualMachineBulkOperation{})
+		recorder = record.NewFakeRecorder(100)
+
+		controller = NewBulkOperationController(operationInformer, recorder, virtClient)
+		controller.authFunc = func(_ *virtv1.VirtualMachineBulkOperation, vmName string) (bool, string, error) {
+			return vmName != "forbidden", "not allowed", nil
+		}
+	})
+
+	It("should apply the action to at most parallelism VirtualMachines at once", func() {
+		operationInformer.GetStore().Add(newOperation(virtv1.BulkStopAction, 2, "vm1", "forbidden", "vm3"))
+
+		vmInterface.EXPECT().Stop("vm1").Return(nil)
+		expectStatus(virtv1.VirtualMachineBulkOperationStatus{
+			Phase:    virtv1.BulkOperationRunning,
+			Applied:  []string{"vm1"},
+			Failures: []virtv1.BulkOperationFailure{{Name: "forbidden", Message: "forbidden: not allowed"}},
+		})
+		Expect(controller.execute("default/bulk-stop-abcde")).To(Succeed())
+		Expect(recorder.Events).To(Receive(ContainSubstring(FailedBulkOperationReason)))
+	})
+
+	It("should continue with the VirtualMachines which were not processed yet", func() {
+		operation := newOperation(virtv1.BulkStartAction, 2, "vm1", "vm2", "vm3")
+		operation.Status.Phase = virtv1.BulkOperationRunning
+		operation.Status.Applied = []string{"vm1", "vm2"}
+		operationInformer.GetStore().Add(operation)
+
+		vmInterface.EXPECT().Start("vm3", &virtv1.StartOptions{}).Return(errors.NewConflict(virtv1.Resource("virtualmachine"), "vm3", fmt.Errorf("VM is already running")))
+		expectStatus(virtv1.VirtualMachineBulkOperationStatus{
+			Phase:    virtv1.BulkOperationRunning,
+			Applied:  []string{"vm1", "vm2"},
+			Failures: []virtv1.BulkOperationFailure{{Name: "vm3", Message: `Operation cannot be fulfilled on virtualmachine.kubevirt.io "vm3": VM is already running`}},
+		})
+		Expect(controller.execute("default/bulk-start-abcde")).To(Succeed())
+	})
+
+	It("should label the migrations it creates", func() {
+		operationInformer.GetStore().Add(newOperation(virtv1.BulkMigrateAction, 10, "vm1"))
+
+		migrationInterface.EXPECT().Create(gomock.Any()).DoAndReturn(func(migration *virtv1.VirtualMachineInstanceMigration) (*virtv1.VirtualMachineInstanceMigration, error) {
+			Expect(migration.Labels).To(HaveKeyWithValue(virtv1.BulkOperationLabel, "bulk-migrate-abcde"))
+			Expect(migration.Spec.VMIName).To(Equal("vm1"))
+			return migration, nil
+		})
+		expectStatus(virtv1.VirtualMachineBulkOperationStatus{
+			Phase:   virtv1.BulkOperationRunning,
+			Applied: []string{"vm1"},
+		})
+		Expect(controller.execute("default/bulk-migrate-abcde")).To(Succeed())
+	})
+
+	It("should retry VirtualMachines the user could not be authorized for", func() {
+		operationInformer.GetStore().Add(newOperation(virtv1.BulkStopAction, 10, "vm1", "vm2"))
+		controller.authFunc = func(_ *virtv1.VirtualMachineBulkOperation, vmName string) (bool, string, error) {
+			if vmName == "vm2" {
+				return false, "", fmt.Errorf("timeout")
+			}
+			return true, "", nil
+		}
+
+		vmInterface.EXPECT().Stop("vm1").Return(nil)
+		expectStatus(virtv1.VirtualMachineBulkOperationStatus{
+			Phase:   virtv1.BulkOperationRunning,
+			Applied: []string{"vm1"},
+		})
+		Expect(controller.execute("default/bulk-stop-abcde")).To(HaveOccurred())
+	})
+
+	It("should succeed once all VirtualMachines were processed", func() {
+		operation := newOperation(virtv1.BulkStopAction, 10, "vm1", "vm2")
+		operation.Status.Phase = virtv1.BulkOperationRunning
+		operation.Status.Applied = []string{"vm1"}
+		operation.Status.Failures = []virtv1.BulkOperationFailure{{Name: "vm2", Message: "VM is not running"}}
+		operationInformer.GetStore().Add(operation)
+
+		operationInterface.EXPECT().UpdateStatus(gomock.Any()).DoAndReturn(func(operation *virtv1.VirtualMachineBulkOperation) (*virtv1.VirtualMachineBulkOperation, error) {
+			Expect(operation.Status.Phase).To(Equal(virtv1.BulkOperationSucceeded))
+			Expect(operation.Status.Applied).To(Equal([]string{"vm1"}))
+			Expect(operation.Status.Failures).To(Equal([]virtv1.BulkOperationFailure{{Name: "vm2", Message: "VM is not running"}}))
+			Expect(operation.Status.CompletionTimestamp).ToNot(BeNil())
+			return operation, nil
+		})
+		Expect(controller.execute("default/bulk-stop-abcde")).To(Succeed())
+		Expect(recorder.Events).To(Receive(ContainSubstring(SuccessfulBulkOperationReason)))
+	})
+
+	It("should keep finished operations until they expire", func() {
+		operation := newOperation(virtv1.BulkStopAction, 10, "vm1")
+		operation.Status.Phase = virtv1.BulkOperationSucceeded
+		completed := metav1.NewTime(time.Now().Add(-time.Hour))
+		operation.Status.CompletionTimestamp = &completed
+		operationInformer.GetStore().Add(operation)
+
+		Expect(controller.execute("default/bulk-stop-abcde")).To(Succeed())
+	})
+
+	It("should delete finished operations once they expired", func() {
+		operation := newOperation(virtv1.BulkStopAction, 10, "vm1")
+		operation.UID = "1234"
+		operation.Status.Phase = virtv1.BulkOperationSucceeded
+		completed := metav1.NewTime(time.Now().Add(-bulkOperationTTLAfterCompletion))
+		operation.Status.CompletionTimestamp = &completed
+		operationInformer.GetStore().Add(operation)
+
+		operationInterface.EXPECT().Delete("bulk-stop-abcde", gomock.Any()).DoAndReturn(func(_ string, options *metav1.DeleteOptions) error {
+			Expect(*options.Preconditions.UID).To(Equal(operation.UID))
+			return nil
+		})
+		Expect(controller.execute("default/bulk-stop-abcde")).To(Succeed())
+	})
+
+	It("should authorize the user who requested the operation", func() {
+		var review *authv1.SubjectAccessReview
+		kubeClient.Fake.PrependReactor("create", "subjectaccessreviews", func(action testing.Action) (bool, runtime.Object, error) {
+			review = action.(testing.CreateAction).GetObject().(*authv1.SubjectAccessReview)
+			return true, &authv1.SubjectAccessReview{Status: authv1.SubjectAccessReviewStatus{Allowed: false, Reason: "no RBAC policy matched"}}, nil
+		})
+
+		allowed, reason, err := controller.authorize(newOperation(virtv1.BulkMigrateAction, 10, "vm1"), "vm1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(allowed).To(BeFalse())
+		Expect(reason).To(Equal("no RBAC policy matched"))
+		Expect(review.Spec.User).To(Equal("alice"))
+		Expect(review.Spec.Groups).To(Equal([]string{"dbas"}))
+		Expect(review.Spec.Extra).To(Equal(map[string]authv1.ExtraValue{"scopes": {"all"}}))
+		Expect(review.Spec.ResourceAttributes).To(Equal(&authv1.ResourceAttributes{
+			Namespace:   metav1.NamespaceDefault,
+			Verb:        "update",
+			Group:       virtv1.SubresourceGroupName,
+			Version:     virtv1.ApiLatestVersion,
+			Resource:    "virtualmachines",
+			Subresource: "migrate",
+			Name:        "vm1",
+		}))
+	})
+})
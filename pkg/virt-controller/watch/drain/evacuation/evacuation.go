@@ -15,6 +15,7 @@ import (
 
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 
+	"kubevirt.io/kubevirt/pkg/util/maintenance"
 	migrationutils "kubevirt.io/kubevirt/pkg/util/migrations"
 
 	virtv1 "kubevirt.io/client-go/api/v1"
@@ -31,15 +32,16 @@ const (
 )
 
 type EvacuationController struct {
-	clientset             kubecli.KubevirtClient
-	Queue                 workqueue.RateLimitingInterface
-	vmiInformer           cache.SharedIndexInformer
-	vmiPodInformer        cache.SharedIndexInformer
-	migrationInformer     cache.SharedIndexInformer
-	recorder              record.EventRecorder
-	migrationExpectations *controller.UIDTrackingControllerExpectations
-	nodeInformer          cache.SharedIndexInformer
-	clusterConfig         *virtconfig.ClusterConfig
+	clientset                 kubecli.KubevirtClient
+	Queue                     workqueue.RateLimitingInterface
+	vmiInformer               cache.SharedIndexInformer
+	vmiPodInformer            cache.SharedIndexInformer
+	migrationInformer         cache.SharedIndexInformer
+	maintenanceWindowInformer cache.SharedIndexInformer
+	recorder                  record.EventRecorder
+	migrationExpectations     *controller.UIDTrackingControllerExpectations
+	nodeInformer              cache.SharedIndexInformer
+	clusterConfig             *virtconfig.ClusterConfig
 }
 
 func NewEvacuationController(
@@ -47,21 +49,23 @@ func NewEvacuationController(
 	migrationInformer cache.SharedIndexInformer,
 	nodeInformer cache.SharedIndexInformer,
 	vmiPodInformer cache.SharedIndexInformer,
+	maintenanceWindowInformer cache.SharedIndexInformer,
 	recorder record.EventRecorder,
 	clientset kubecli.KubevirtClient,
 	clusterConfig *virtconfig.ClusterConfig,
 ) *EvacuationController {
 
 	c := &EvacuationController{
-		Queue:                 workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "virt-controller-evacuation"),
-		vmiInformer:           vmiInformer,
-		migrationInformer:     migrationInformer,
-		nodeInformer:          nodeInformer,
-		vmiPodInformer:        vmiPodInformer,
-		recorder:              recorder,
-		clientset:             clientset,
-		migrationExpectations: controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectations()),
-		clusterConfig:         clusterConfig,
+		Queue:                     workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "virt-controller-evacuation"),
+		vmiInformer:               vmiInformer,
+		migrationInformer:         migrationInformer,
+		nodeInformer:              nodeInformer,
+		vmiPodInformer:            vmiPodInformer,
+		maintenanceWindowInformer: maintenanceWindowInformer,
+		recorder:                  recorder,
+		clientset:                 clientset,
+		migrationExpectations:     controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectations()),
+		clusterConfig:             clusterConfig,
 	}
 
 	c.vmiInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -82,6 +86,12 @@ func NewEvacuationController(
 		UpdateFunc: c.updateNode,
 	})
 
+	c.maintenanceWindowInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.addMaintenanceWindow,
+		DeleteFunc: c.deleteMaintenanceWindow,
+		UpdateFunc: c.updateMaintenanceWindow,
+	})
+
 	return c
 }
 
@@ -107,6 +117,25 @@ func (c *EvacuationController) enqueueNode(obj interface{}) {
 	c.Queue.Add(key)
 }
 
+func (c *EvacuationController) addMaintenanceWindow(_ interface{}) {
+	c.enqueueAllNodes()
+}
+
+func (c *EvacuationController) deleteMaintenanceWindow(_ interface{}) {
+	c.enqueueAllNodes()
+}
+
+func (c *EvacuationController) updateMaintenanceWindow(_, _ interface{}) {
+	c.enqueueAllNodes()
+}
+
+// enqueueAllNodes re-evaluates all nodes, since a window can select VMIs on any of them
+func (c *EvacuationController) enqueueAllNodes() {
+	for _, key := range c.nodeInformer.GetStore().ListKeys() {
+		c.Queue.Add(key)
+	}
+}
+
 func (c *EvacuationController) addVirtualMachineInstance(obj interface{}) {
 	c.enqueueVMI(obj)
 }
@@ -264,7 +293,7 @@ func (c *EvacuationController) Run(threadiness int, stopCh <-chan struct{}) {
 	log.Log.Info("Starting evacuation controller.")
 
 	// Wait for cache sync before we start the node controller
-	cache.WaitForCacheSync(stopCh, c.migrationInformer.HasSynced, c.vmiInformer.HasSynced)
+	cache.WaitForCacheSync(stopCh, c.migrationInformer.HasSynced, c.vmiInformer.HasSynced, c.maintenanceWindowInformer.HasSynced)
 
 	// Start the actual work
 	for i := 0; i < threadiness; i++ {
@@ -369,6 +398,18 @@ func (c *EvacuationController) sync(node *k8sv1.Node, vmisOnNode []*virtv1.Virtu
 
 	migrationCandidates, nonMigrateable := c.filterRunningNonMigratingVMIs(vmisToMigrate, activeMigrations)
 
+	// VMIs whose migrations failed repeatedly are retried once their backoff expired
+	migrationCandidates, backoffDelay := filterMigrationBackoff(migrationCandidates)
+	if backoffDelay > 0 {
+		c.Queue.AddAfter(node.Name, backoffDelay)
+	}
+
+	// VMIs selected by maintenance windows are only evacuated while one of the windows is open
+	migrationCandidates, windowDelay := c.filterMaintenanceWindows(migrationCandidates)
+	if windowDelay > 0 {
+		c.Queue.AddAfter(node.Name, windowDelay)
+	}
+
 	// Don't create hundreds of pending migration objects.
 	// This is just best-effort and is *not* intended to not overload the cluster.
 	// It is possible that more migrations than the limit are created because of evacuations on other nodes.
@@ -440,6 +481,41 @@ func (c *EvacuationController) sync(node *k8sv1.Node, vmisOnNode []*virtv1.Virtu
 	return nil
 }
 
+// filterMigrationBackoff drops the VMIs whose migrations are held back after failures and returns
+// the time until the first of them can be migrated again
+func filterMigrationBackoff(vmis []*virtv1.VirtualMachineInstance) (ready []*virtv1.VirtualMachineInstance, delay time.Duration) {
+	for _, vmi := range vmis {
+		remaining := migrationutils.MigrationBackoffRemaining(vmi)
+		if remaining == 0 {
+			ready = append(ready, vmi)
+			continue
+		}
+		if delay == 0 || remaining < delay {
+			delay = remaining
+		}
+	}
+	return ready, delay
+}
+
+// filterMaintenanceWindows drops the VMIs whose maintenance windows are closed and returns
+// the time until the first of them opens
+func (c *EvacuationController) filterMaintenanceWindows(vmis []*virtv1.VirtualMachineInstance) (ready []*virtv1.VirtualMachineInstance, delay time.Duration) {
+	windows := maintenance.ListWindows(c.maintenanceWindowInformer)
+	now := time.Now()
+	for _, vmi := range vmis {
+		allowed, wait := maintenance.Allowed(windows, vmi, virtv1.MaintenanceActionEvacuation, now)
+		if allowed {
+			ready = append(ready, vmi)
+			continue
+		}
+		log.Log.V(4).Object(vmi).Infof("Postponing evacuation until a maintenance window opens")
+		if wait > 0 && (delay == 0 || wait < delay) {
+			delay = wait
+		}
+	}
+	return ready, delay
+}
+
 func hasMigratedOnEviction(vmi *virtv1.VirtualMachineInstance) bool {
 	return vmi.Status.NodeName != vmi.Status.EvacuationNodeName
 }
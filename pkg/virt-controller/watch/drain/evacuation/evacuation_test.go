@@ -19,6 +19,7 @@ import (
 	"kubevirt.io/kubevirt/pkg/virt-controller/watch/drain/evacuation"
 
 	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 )
 
@@ -35,6 +36,7 @@ var _ = Describe("Evacuation", func() {
 	var migrationSource *framework.FakeControllerSource
 	var podInformer cache.SharedIndexInformer
 	var podSource *framework.FakeControllerSource
+	var maintenanceWindowInformer cache.SharedIndexInformer
 	var recorder *record.FakeRecorder
 	var mockQueue *testutils.MockWorkQueue
 	var kubeClient *fake.Clientset
@@ -48,12 +50,14 @@ var _ = Describe("Evacuation", func() {
 		go migrationInformer.Run(stop)
 		go nodeInformer.Run(stop)
 		go podInformer.Run(stop)
+		go maintenanceWindowInformer.Run(stop)
 
 		Expect(cache.WaitForCacheSync(stop,
 			vmiInformer.HasSynced,
 			migrationInformer.HasSynced,
 			nodeInformer.HasSynced,
 			podInformer.HasSynced,
+			maintenanceWindowInformer.HasSynced,
 		)).To(BeTrue())
 	}
 
@@ -78,11 +82,12 @@ var _ = Describe("Evacuation", func() {
 		migrationInformer, migrationSource = testutils.NewFakeInformerFor(&v1.VirtualMachineInstanceMigration{})
 		nodeInformer, nodeSource = testutils.NewFakeInformerFor(&v12.Node{})
 		podInformer, podSource = testutils.NewFakeInformerFor(&v12.Pod{})
+		maintenanceWindowInformer, _ = testutils.NewFakeInformerFor(&v1.MaintenanceWindow{})
 		recorder = record.NewFakeRecorder(100)
 		recorder.IncludeObject = true
 		config, _, _, _ := testutils.NewFakeClusterConfig(&v12.ConfigMap{})
 
-		controller = evacuation.NewEvacuationController(vmiInformer, migrationInformer, nodeInformer, podInformer, recorder, virtClient, config)
+		controller = evacuation.NewEvacuationController(vmiInformer, migrationInformer, nodeInformer, podInformer, maintenanceWindowInformer, recorder, virtClient, config)
 		mockQueue = testutils.NewMockWorkQueue(controller.Queue)
 		controller.Queue = mockQueue
 		migrationFeeder = testutils.NewMigrationFeeder(mockQueue, migrationSource)
@@ -155,6 +160,76 @@ var _ = Describe("Evacuation", func() {
 			testutils.ExpectEvent(recorder, evacuation.SuccessfulCreateVirtualMachineInstanceMigrationReason)
 		})
 
+		It("should not evict the VMI while its migrations are held back", func() {
+			node := newNode("testnode")
+			node.Spec.Taints = append(node.Spec.Taints, *newTaint())
+			addNode(node)
+
+			nextAttempt := v13.NewTime(time.Now().Add(time.Minute))
+			vmi := newVirtualMachine("testvm", node.Name)
+			vmi.Spec.EvictionStrategy = newEvictionStrategy()
+			vmi.Status.MigrationBackoff = &v1.VirtualMachineInstanceMigrationBackoff{
+				FailureCount:         1,
+				NextAttemptTimestamp: &nextAttempt,
+			}
+			vmiFeeder.Add(vmi)
+
+			controller.Execute()
+		})
+
+		It("should evict the VMI once its migration backoff expired", func() {
+			node := newNode("testnode")
+			node.Spec.Taints = append(node.Spec.Taints, *newTaint())
+			addNode(node)
+
+			nextAttempt := v13.NewTime(time.Now().Add(-time.Minute))
+			vmi := newVirtualMachine("testvm", node.Name)
+			vmi.Spec.EvictionStrategy = newEvictionStrategy()
+			vmi.Status.MigrationBackoff = &v1.VirtualMachineInstanceMigrationBackoff{
+				FailureCount:         3,
+				NextAttemptTimestamp: &nextAttempt,
+			}
+			vmiFeeder.Add(vmi)
+
+			migrationInterface.EXPECT().Create(gomock.Any()).Return(&v1.VirtualMachineInstanceMigration{ObjectMeta: v13.ObjectMeta{Name: "something"}}, nil)
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder, evacuation.SuccessfulCreateVirtualMachineInstanceMigrationReason)
+		})
+
+		table.DescribeTable("should only evict the VMI while its maintenance window is open", func(start time.Duration, expectMigration bool) {
+			node := newNode("testnode")
+			node.Spec.Taints = append(node.Spec.Taints, *newTaint())
+			addNode(node)
+
+			Expect(maintenanceWindowInformer.GetStore().Add(&v1.MaintenanceWindow{
+				ObjectMeta: v13.ObjectMeta{Name: "window"},
+				Spec: v1.MaintenanceWindowSpec{
+					Actions: []v1.MaintenanceAction{v1.MaintenanceActionEvacuation},
+					Periods: []v1.MaintenancePeriod{{
+						Start:    time.Now().UTC().Add(start).Format("15:04"),
+						Duration: v13.Duration{Duration: time.Hour},
+					}},
+				},
+			})).To(Succeed())
+
+			vmi := newVirtualMachine("testvm", node.Name)
+			vmi.Spec.EvictionStrategy = newEvictionStrategy()
+			vmiFeeder.Add(vmi)
+
+			if expectMigration {
+				migrationInterface.EXPECT().Create(gomock.Any()).Return(&v1.VirtualMachineInstanceMigration{ObjectMeta: v13.ObjectMeta{Name: "something"}}, nil)
+			}
+
+			controller.Execute()
+			if expectMigration {
+				testutils.ExpectEvent(recorder, evacuation.SuccessfulCreateVirtualMachineInstanceMigrationReason)
+			}
+		},
+			table.Entry("and evict it during the window", -time.Minute, true),
+			table.Entry("and postpone the eviction before the window", 2*time.Hour, false),
+		)
+
 		It("should ignore VMIs which are not migratable", func() {
 			node := newNode("testnode")
 			node1 := newNode("anothernode")
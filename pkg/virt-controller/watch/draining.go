@@ -0,0 +1,109 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package watch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	k8sv1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	virtv1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+)
+
+// hasNotDrainingReadinessGate returns true if the connections to the pod are drained before its VMI is migrated
+func hasNotDrainingReadinessGate(pod *k8sv1.Pod) bool {
+	for _, gate := range pod.Spec.ReadinessGates {
+		if gate.ConditionType == virtv1.VirtualMachineInstanceNotDraining {
+			return true
+		}
+	}
+	return false
+}
+
+func notDrainingCondition(pod *k8sv1.Pod) *k8sv1.PodCondition {
+	for i := range pod.Status.Conditions {
+		if pod.Status.Conditions[i].Type == virtv1.VirtualMachineInstanceNotDraining {
+			return &pod.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// isDrainedByMigration returns true if the connections to the pod are drained for the migration
+func isDrainedByMigration(pod *k8sv1.Pod, migration *virtv1.VirtualMachineInstanceMigration) bool {
+	condition := notDrainingCondition(pod)
+	return condition != nil && condition.Status == k8sv1.ConditionFalse &&
+		condition.Reason == virtv1.MigrationDrainingReason && condition.Message == drainMessage(migration)
+}
+
+// needsNotDrainingCondition returns true if the readiness gate condition of the pod is unset, or if the pod is
+// still drained although no migration of the VMI is in progress anymore. A pod drained after the last migration
+// of the VMI ended waits for the hand-off of the next one, the migration controller restores it if that fails.
+func needsNotDrainingCondition(pod *k8sv1.Pod, vmi *virtv1.VirtualMachineInstance) bool {
+	condition := notDrainingCondition(pod)
+	if condition == nil {
+		return true
+	}
+	if condition.Status == k8sv1.ConditionTrue {
+		return false
+	}
+	migrationState := vmi.Status.MigrationState
+	if migrationState == nil {
+		return condition.Reason != virtv1.MigrationDrainingReason
+	}
+	if !migrationState.Completed && !migrationState.Failed {
+		return false
+	}
+	handingOff := condition.Reason == virtv1.MigrationDrainingReason &&
+		migrationState.EndTimestamp != nil && migrationState.EndTimestamp.Before(&condition.LastTransitionTime)
+	return !handingOff
+}
+
+func drainMessage(migration *virtv1.VirtualMachineInstanceMigration) string {
+	return fmt.Sprintf("Draining connections before migration %s", migration.Name)
+}
+
+// patchNotDrainingCondition sets the condition of the readiness gate on the pod status. The pod is only
+// ready, and part of the endpoints of Services, while the condition is True.
+func patchNotDrainingCondition(clientset kubecli.KubevirtClient, pod *k8sv1.Pod, status k8sv1.ConditionStatus, reason string, message string) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{
+			"conditions": []k8sv1.PodCondition{
+				{
+					Type:               virtv1.VirtualMachineInstanceNotDraining,
+					Status:             status,
+					Reason:             reason,
+					Message:            message,
+					LastTransitionTime: v1.Now(),
+				},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = clientset.CoreV1().Pods(pod.Namespace).Patch(context.Background(), pod.Name, types.StrategicMergePatchType, patch, v1.PatchOptions{}, "status")
+	return err
+}
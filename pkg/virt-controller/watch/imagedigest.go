@@ -0,0 +1,132 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package watch
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	containerdisk "kubevirt.io/kubevirt/pkg/container-disk"
+)
+
+// containerDiskDigestResolveTimeout bounds the registry lookups for pinning the containerDisk images of a VM
+const containerDiskDigestResolveTimeout = 10 * time.Second
+
+type imageDigestLookup struct {
+	image       string
+	pinnedImage string
+	err         error
+	done        bool
+}
+
+// imageDigestPinner resolves the containerDisk images of VMs to their digest in the background, so that the
+// registry lookups don't block the sync loop. The VM is enqueued once a lookup finished.
+type imageDigestPinner struct {
+	resolver containerdisk.ImageDigestResolver
+	enqueue  func(vmKey string)
+
+	lock sync.Mutex
+	// lookups of the containerDisk volumes by VM key and volume name
+	lookups map[string]map[string]*imageDigestLookup
+}
+
+func newImageDigestPinner(resolver containerdisk.ImageDigestResolver, enqueue func(vmKey string)) *imageDigestPinner {
+	return &imageDigestPinner{
+		resolver: resolver,
+		enqueue:  enqueue,
+		lookups:  map[string]map[string]*imageDigestLookup{},
+	}
+}
+
+// pinnedImage returns the image of the volume pinned to its digest, once the digest of the manifest the mirrored
+// image refers to was resolved. It starts the lookup if it isn't running yet. A failed lookup is returned once and
+// started again on the next call.
+func (p *imageDigestPinner) pinnedImage(vmKey, volumeName, image, mirroredImage string) (string, bool, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	lookup := p.lookups[vmKey][volumeName]
+	if lookup != nil && lookup.image == image {
+		if !lookup.done {
+			return "", false, nil
+		}
+		if lookup.err != nil {
+			delete(p.lookups[vmKey], volumeName)
+			return "", false, lookup.err
+		}
+		return lookup.pinnedImage, true, nil
+	}
+
+	lookup = &imageDigestLookup{image: image}
+	if p.lookups[vmKey] == nil {
+		p.lookups[vmKey] = map[string]*imageDigestLookup{}
+	}
+	p.lookups[vmKey][volumeName] = lookup
+	go p.resolve(vmKey, lookup, mirroredImage)
+	return "", false, nil
+}
+
+func (p *imageDigestPinner) resolve(vmKey string, lookup *imageDigestLookup, mirroredImage string) {
+	ctx, cancel := context.WithTimeout(context.Background(), containerDiskDigestResolveTimeout)
+	resolved, err := p.resolver.ResolveImageDigest(ctx, mirroredImage)
+	cancel()
+
+	p.lock.Lock()
+	lookup.done = true
+	lookup.err = err
+	if err == nil {
+		lookup.pinnedImage = lookup.image + "@" + containerdisk.ImageDigest(resolved)
+	}
+	p.lock.Unlock()
+
+	p.enqueue(vmKey)
+}
+
+// resolvedImage returns the pinned image of a successful lookup of the volume
+func (p *imageDigestPinner) resolvedImage(vmKey, volumeName, image string) (string, bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	lookup := p.lookups[vmKey][volumeName]
+	if lookup == nil || lookup.image != image || !lookup.done || lookup.err != nil {
+		return "", false
+	}
+	return lookup.pinnedImage, true
+}
+
+// forgetVolume drops the lookup of the volume once its pinned image is recorded in the status of the VM
+func (p *imageDigestPinner) forgetVolume(vmKey, volumeName string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	delete(p.lookups[vmKey], volumeName)
+	if len(p.lookups[vmKey]) == 0 {
+		delete(p.lookups, vmKey)
+	}
+}
+
+// forget drops all lookups of a deleted VM
+func (p *imageDigestPinner) forget(vmKey string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	delete(p.lookups, vmKey)
+}
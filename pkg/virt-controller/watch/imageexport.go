@@ -0,0 +1,420 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package watch
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	virtv1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+	containerdisk "kubevirt.io/kubevirt/pkg/container-disk"
+	"kubevirt.io/kubevirt/pkg/controller"
+)
+
+const (
+	imageExportPodPrefix     = "virt-image-export-"
+	imageExportDiskDir       = "/var/run/kubevirt-private/export-disk"
+	imageExportDiskDevice    = "/dev/export-disk"
+	imageExportPushSecretDir = "/var/run/kubevirt-private/push-secret"
+
+	// FailedImageExportReason is added in an event if the export of an image failed
+	FailedImageExportReason = "FailedImageExport"
+	// SuccessfulImageExportReason is added in an event if the export of an image succeeded
+	SuccessfulImageExportReason = "SuccessfulImageExport"
+)
+
+// ImageExportController runs pods which push the boot volume of stopped VirtualMachines as containerDisk images
+type ImageExportController struct {
+	clientset      kubecli.KubevirtClient
+	Queue          workqueue.RateLimitingInterface
+	exportInformer cache.SharedIndexInformer
+	vmInformer     cache.SharedIndexInformer
+	vmiInformer    cache.SharedIndexInformer
+	podInformer    cache.SharedIndexInformer
+	pvcInformer    cache.SharedIndexInformer
+	recorder       record.EventRecorder
+	launcherImage  string
+}
+
+// NewImageExportController creates a new instance of the ImageExportController struct.
+func NewImageExportController(launcherImage string,
+	exportInformer cache.SharedIndexInformer,
+	vmInformer cache.SharedIndexInformer,
+	vmiInformer cache.SharedIndexInformer,
+	podInformer cache.SharedIndexInformer,
+	pvcInformer cache.SharedIndexInformer,
+	recorder record.EventRecorder,
+	clientset kubecli.KubevirtClient) *ImageExportController {
+
+	c := &ImageExportController{
+		clientset:      clientset,
+		Queue:          workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "virt-controller-imageexport"),
+		exportInformer: exportInformer,
+		vmInformer:     vmInformer,
+		vmiInformer:    vmiInformer,
+		podInformer:    podInformer,
+		pvcInformer:    pvcInformer,
+		recorder:       recorder,
+		launcherImage:  launcherImage,
+	}
+
+	c.exportInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueueExport,
+		DeleteFunc: func(_ interface{}) { /* the pod is garbage collected */ },
+		UpdateFunc: func(_, curr interface{}) { c.enqueueExport(curr) },
+	})
+
+	c.vmInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueueExportsOfSource,
+		DeleteFunc: c.enqueueExportsOfSource,
+		UpdateFunc: func(_, curr interface{}) { c.enqueueExportsOfSource(curr) },
+	})
+
+	c.vmiInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueueExportsOfSource,
+		DeleteFunc: c.enqueueExportsOfSource,
+		UpdateFunc: func(_, curr interface{}) { c.enqueueExportsOfSource(curr) },
+	})
+
+	c.podInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueueExportOfPod,
+		DeleteFunc: c.enqueueExportOfPod,
+		UpdateFunc: func(_, curr interface{}) { c.enqueueExportOfPod(curr) },
+	})
+
+	return c
+}
+
+func (c *ImageExportController) enqueueExport(obj interface{}) {
+	key, err := controller.KeyFunc(obj)
+	if err != nil {
+		log.Log.Reason(err).Error("Failed to extract key from image export.")
+		return
+	}
+	c.Queue.Add(key)
+}
+
+// enqueueExportsOfSource enqueues the exports of a VirtualMachine, which are affected by the VirtualMachine
+// and by its VirtualMachineInstance
+func (c *ImageExportController) enqueueExportsOfSource(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	source, ok := obj.(metav1.Object)
+	if !ok {
+		return
+	}
+	exports, err := c.exportInformer.GetIndexer().ByIndex(cache.NamespaceIndex, source.GetNamespace())
+	if err != nil {
+		log.Log.Reason(err).Error("Failed to list image exports.")
+		return
+	}
+	for _, obj := range exports {
+		export := obj.(*virtv1.VirtualMachineImageExport)
+		if export.Spec.Source == source.GetName() && !export.IsFinal() {
+			c.enqueueExport(export)
+		}
+	}
+}
+
+func (c *ImageExportController) enqueueExportOfPod(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	pod, ok := obj.(*k8sv1.Pod)
+	if !ok {
+		return
+	}
+	ref := metav1.GetControllerOf(pod)
+	if ref == nil || ref.Kind != virtv1.VirtualMachineImageExportGroupVersionKind.Kind {
+		return
+	}
+	c.Queue.Add(pod.Namespace + "/" + ref.Name)
+}
+
+// Run runs the passed in ImageExportController.
+func (c *ImageExportController) Run(threadiness int, stopCh <-chan struct{}) {
+	defer controller.HandlePanic()
+	defer c.Queue.ShutDown()
+	log.Log.Info("Starting image export controller.")
+
+	cache.WaitForCacheSync(stopCh, c.exportInformer.HasSynced, c.vmInformer.HasSynced, c.vmiInformer.HasSynced, c.podInformer.HasSynced, c.pvcInformer.HasSynced)
+
+	for i := 0; i < threadiness; i++ {
+		go wait.Until(c.runWorker, time.Second, stopCh)
+	}
+
+	<-stopCh
+	log.Log.Info("Stopping image export controller.")
+}
+
+func (c *ImageExportController) runWorker() {
+	for c.Execute() {
+	}
+}
+
+// Execute runs commands from the controller queue, if there is
+// an error it requeues the command. Returns false if the queue
+// is empty.
+func (c *ImageExportController) Execute() bool {
+	key, quit := c.Queue.Get()
+	if quit {
+		return false
+	}
+	defer c.Queue.Done(key)
+	err := c.execute(key.(string))
+
+	if err != nil {
+		log.Log.Reason(err).Infof("reenqueuing image export %v", key)
+		c.Queue.AddRateLimited(key)
+	} else {
+		log.Log.V(4).Infof("processed image export %v", key)
+		c.Queue.Forget(key)
+	}
+	return true
+}
+
+func (c *ImageExportController) execute(key string) error {
+	obj, exists, err := c.exportInformer.GetStore().GetByKey(key)
+	if err != nil || !exists {
+		return err
+	}
+	export := obj.(*virtv1.VirtualMachineImageExport)
+	if export.IsFinal() {
+		return nil
+	}
+	newExport := export.DeepCopy()
+
+	pod, err := c.getExportPod(export)
+	if err != nil {
+		return err
+	}
+
+	vmObj, vmExists, err := c.vmInformer.GetStore().GetByKey(export.Namespace + "/" + export.Spec.Source)
+	if err != nil {
+		return err
+	}
+	_, vmiExists, err := c.vmiInformer.GetStore().GetByKey(export.Namespace + "/" + export.Spec.Source)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case !vmExists:
+		c.failExport(newExport, fmt.Sprintf("VirtualMachine %s does not exist", export.Spec.Source))
+	case vmiExists && pod != nil:
+		// The VirtualMachine was started, the exported disk would be inconsistent
+		if err := c.deleteExportPod(pod); err != nil {
+			return err
+		}
+		c.failExport(newExport, fmt.Sprintf("VirtualMachine %s was started during the export", export.Spec.Source))
+	case vmiExists:
+		newExport.Status.Phase = virtv1.ImageExportPending
+		newExport.Status.Message = fmt.Sprintf("Waiting for VirtualMachine %s to stop", export.Spec.Source)
+	case pod == nil:
+		claimName, err := bootVolumeClaimName(vmObj.(*virtv1.VirtualMachine))
+		if err != nil {
+			c.failExport(newExport, err.Error())
+			break
+		}
+		block, err := c.isBlockClaim(export.Namespace, claimName)
+		if err != nil {
+			return err
+		}
+		_, err = c.clientset.CoreV1().Pods(export.Namespace).Create(context.Background(), c.newExportPod(export, claimName, block), metav1.CreateOptions{})
+		if err != nil && !errors.IsAlreadyExists(err) {
+			c.recorder.Eventf(export, k8sv1.EventTypeWarning, FailedImageExportReason, "Error creating the export pod: %v", err)
+			return err
+		}
+		newExport.Status.Phase = virtv1.ImageExportInProgress
+		newExport.Status.Message = ""
+	case pod.Status.Phase == k8sv1.PodSucceeded:
+		newExport.Status.Phase = virtv1.ImageExportSucceeded
+		newExport.Status.Digest = terminationMessage(pod)
+		newExport.Status.Message = ""
+		c.recorder.Eventf(export, k8sv1.EventTypeNormal, SuccessfulImageExportReason, "Pushed %s@%s", export.Spec.Image, newExport.Status.Digest)
+	case pod.Status.Phase == k8sv1.PodFailed:
+		message := terminationMessage(pod)
+		if message == "" {
+			message = "the export pod failed"
+		}
+		c.failExport(newExport, message)
+	default:
+		newExport.Status.Phase = virtv1.ImageExportInProgress
+	}
+
+	if equality.Semantic.DeepEqual(export.Status, newExport.Status) {
+		return nil
+	}
+	_, err = c.clientset.VirtualMachineImageExport(export.Namespace).UpdateStatus(newExport)
+	return err
+}
+
+func (c *ImageExportController) failExport(export *virtv1.VirtualMachineImageExport, message string) {
+	export.Status.Phase = virtv1.ImageExportFailed
+	export.Status.Message = message
+	c.recorder.Event(export, k8sv1.EventTypeWarning, FailedImageExportReason, message)
+}
+
+func (c *ImageExportController) getExportPod(export *virtv1.VirtualMachineImageExport) (*k8sv1.Pod, error) {
+	obj, exists, err := c.podInformer.GetStore().GetByKey(export.Namespace + "/" + imageExportPodPrefix + export.Name)
+	if err != nil || !exists {
+		return nil, err
+	}
+	pod := obj.(*k8sv1.Pod)
+	if !metav1.IsControlledBy(pod, export) {
+		return nil, fmt.Errorf("pod %s is not controlled by image export %s", pod.Name, export.Name)
+	}
+	return pod, nil
+}
+
+func (c *ImageExportController) deleteExportPod(pod *k8sv1.Pod) error {
+	err := c.clientset.CoreV1().Pods(pod.Namespace).Delete(context.Background(), pod.Name, metav1.DeleteOptions{})
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func (c *ImageExportController) isBlockClaim(namespace, claimName string) (bool, error) {
+	obj, exists, err := c.pvcInformer.GetStore().GetByKey(namespace + "/" + claimName)
+	if err != nil {
+		return false, err
+	}
+	if !exists {
+		return false, fmt.Errorf("PersistentVolumeClaim %s does not exist", claimName)
+	}
+	pvc := obj.(*k8sv1.PersistentVolumeClaim)
+	return pvc.Spec.VolumeMode != nil && *pvc.Spec.VolumeMode == k8sv1.PersistentVolumeBlock, nil
+}
+
+// bootVolumeClaimName returns the claim of the disk the VirtualMachine boots from, which is the disk with the
+// lowest boot order or the first disk if no disk has a boot order
+func bootVolumeClaimName(vm *virtv1.VirtualMachine) (string, error) {
+	if vm.Spec.Template == nil || len(vm.Spec.Template.Spec.Domain.Devices.Disks) == 0 {
+		return "", fmt.Errorf("VirtualMachine %s has no disks", vm.Name)
+	}
+	disks := vm.Spec.Template.Spec.Domain.Devices.Disks
+	bootDisk := disks[0]
+	for _, disk := range disks {
+		if disk.BootOrder != nil && (bootDisk.BootOrder == nil || *disk.BootOrder < *bootDisk.BootOrder) {
+			bootDisk = disk
+		}
+	}
+
+	for _, volume := range vm.Spec.Template.Spec.Volumes {
+		if volume.Name != bootDisk.Name {
+			continue
+		}
+		switch {
+		case volume.PersistentVolumeClaim != nil:
+			return volume.PersistentVolumeClaim.ClaimName, nil
+		case volume.DataVolume != nil:
+			return volume.DataVolume.Name, nil
+		}
+		return "", fmt.Errorf("boot volume %s of VirtualMachine %s is neither a PersistentVolumeClaim nor a DataVolume", volume.Name, vm.Name)
+	}
+	return "", fmt.Errorf("VirtualMachine %s has no volume for disk %s", vm.Name, bootDisk.Name)
+}
+
+func (c *ImageExportController) newExportPod(export *virtv1.VirtualMachineImageExport, claimName string, block bool) *k8sv1.Pod {
+	container := k8sv1.Container{
+		Name:            "image-exporter",
+		Image:           c.launcherImage,
+		ImagePullPolicy: k8sv1.PullIfNotPresent,
+		Command:         []string{"/usr/bin/virt-image-exporter"},
+		Args:            []string{"--image", export.Spec.Image},
+		// The digest or the reason of a failure
+		TerminationMessagePolicy: k8sv1.TerminationMessageReadFile,
+	}
+	if export.Spec.BaseImage != "" {
+		container.Args = append(container.Args, "--base-image", export.Spec.BaseImage)
+	}
+
+	volumes := []k8sv1.Volume{{
+		Name: "disk",
+		VolumeSource: k8sv1.VolumeSource{
+			PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: claimName, ReadOnly: true},
+		},
+	}}
+	if block {
+		container.VolumeDevices = []k8sv1.VolumeDevice{{Name: "disk", DevicePath: imageExportDiskDevice}}
+		container.Args = append(container.Args, "--disk", imageExportDiskDevice)
+	} else {
+		container.VolumeMounts = []k8sv1.VolumeMount{{Name: "disk", MountPath: imageExportDiskDir, ReadOnly: true}}
+		container.Args = append(container.Args, "--disk", filepath.Join(imageExportDiskDir, containerdisk.ExportedDiskName))
+	}
+
+	if export.Spec.PushSecretName != "" {
+		volumes = append(volumes, k8sv1.Volume{
+			Name: "push-secret",
+			VolumeSource: k8sv1.VolumeSource{
+				Secret: &k8sv1.SecretVolumeSource{
+					SecretName: export.Spec.PushSecretName,
+					Items:      []k8sv1.KeyToPath{{Key: k8sv1.DockerConfigJsonKey, Path: k8sv1.DockerConfigJsonKey}},
+				},
+			},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, k8sv1.VolumeMount{Name: "push-secret", MountPath: imageExportPushSecretDir, ReadOnly: true})
+		container.Args = append(container.Args, "--push-secret", filepath.Join(imageExportPushSecretDir, k8sv1.DockerConfigJsonKey))
+	}
+
+	pod := &k8sv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      imageExportPodPrefix + export.Name,
+			Namespace: export.Namespace,
+			Labels: map[string]string{
+				virtv1.AppLabel: "image-exporter",
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(export, virtv1.VirtualMachineImageExportGroupVersionKind),
+			},
+		},
+		Spec: k8sv1.PodSpec{
+			RestartPolicy: k8sv1.RestartPolicyNever,
+			Containers:    []k8sv1.Container{container},
+			Volumes:       volumes,
+		},
+	}
+	return pod
+}
+
+func terminationMessage(pod *k8sv1.Pod) string {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Terminated != nil {
+			return strings.TrimSpace(status.State.Terminated.Message)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,232 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package watch
+
+import (
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+
+	virtv1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+var _ = Describe("Image export controller", func() {
+	const digest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+	var exportInformer, vmInformer, vmiInformer, podInformer, pvcInformer cache.SharedIndexInformer
+	var exportInterface *kubecli.MockVirtualMachineImageExportInterface
+	var kubeClient *fake.Clientset
+	var recorder *record.FakeRecorder
+	var controller *ImageExportController
+
+	newExport := func() *virtv1.VirtualMachineImageExport {
+		return &virtv1.VirtualMachineImageExport{
+			ObjectMeta: metav1.ObjectMeta{Name: "export", Namespace: metav1.NamespaceDefault, UID: "export-uid"},
+			Spec: virtv1.VirtualMachineImageExportSpec{
+				Source:         "vm",
+				Image:          "quay.io/kubevirt/golden:v1",
+				BaseImage:      "quay.io/kubevirt/base:v1",
+				PushSecretName: "push-secret",
+			},
+		}
+	}
+
+	newVM := func() *virtv1.VirtualMachine {
+		bootOrder := uint(1)
+		return &virtv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: "vm", Namespace: metav1.NamespaceDefault},
+			Spec: virtv1.VirtualMachineSpec{
+				Template: &virtv1.VirtualMachineInstanceTemplateSpec{
+					Spec: virtv1.VirtualMachineInstanceSpec{
+						Domain: virtv1.DomainSpec{
+							Devices: virtv1.Devices{
+								Disks: []virtv1.Disk{{Name: "data"}, {Name: "root", BootOrder: &bootOrder}},
+							},
+						},
+						Volumes: []virtv1.Volume{
+							{Name: "data", VolumeSource: virtv1.VolumeSource{PersistentVolumeClaim: &virtv1.PersistentVolumeClaimVolumeSource{PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "data-pvc"}}}},
+							{Name: "root", VolumeSource: virtv1.VolumeSource{DataVolume: &virtv1.DataVolumeSource{Name: "root-dv"}}},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	newPVC := func(name string, mode k8sv1.PersistentVolumeMode) *k8sv1.PersistentVolumeClaim {
+		return &k8sv1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceDefault},
+			Spec:       k8sv1.PersistentVolumeClaimSpec{VolumeMode: &mode},
+		}
+	}
+
+	newPod := func(export *virtv1.VirtualMachineImageExport, phase k8sv1.PodPhase, message string) *k8sv1.Pod {
+		pod := controller.newExportPod(export, "root-dv", false)
+		pod.Status.Phase = phase
+		pod.Status.ContainerStatuses = []k8sv1.ContainerStatus{{
+			State: k8sv1.ContainerState{Terminated: &k8sv1.ContainerStateTerminated{Message: message}},
+		}}
+		return pod
+	}
+
+	expectStatus := func(phase virtv1.VirtualMachineImageExportPhase, digest string) {
+		exportInterface.EXPECT().UpdateStatus(gomock.Any()).DoAndReturn(func(export *virtv1.VirtualMachineImageExport) (*virtv1.VirtualMachineImageExport, error) {
+			Expect(export.Status.Phase).To(Equal(phase))
+			Expect(export.Status.Digest).To(Equal(digest))
+			return export, nil
+		})
+	}
+
+	BeforeEach(func() {
+		ctrl := gomock.NewController(GinkgoT())
+		virtClient := kubecli.NewMockKubevirtClient(ctrl)
+		exportInterface = kubecli.NewMockVirtualMachineImageExportInterface(ctrl)
+		virtClient.EXPECT().VirtualMachineImageExport(metav1.NamespaceDefault).Return(exportInterface).AnyTimes()
+		kubeClient = fake.NewSimpleClientset()
+		virtClient.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+
+		exportInformer, _ = testutils.NewFakeInformerFor(&virtv1.VirtualMachineImageExport{})
+		vmInformer, _ = testutils.NewFakeInformerFor(&virtv1.VirtualMachine{})
+		vmiInformer, _ = testutils.NewFakeInformerFor(&virtv1.VirtualMachineInstance{})
+		podInformer, _ = testutils.NewFakeInformerFor(&k8sv1.Pod{})
+		pvcInformer, _ = testutils.NewFakeInformerFor(&k8sv1.PersistentVolumeClaim{})
+		recorder = record.NewFakeRecorder(100)
+
+		controller = NewImageExportController("virt-launcher", exportInformer, vmInformer, vmiInformer, podInformer, pvcInformer, recorder, virtClient)
+	})
+
+	It("should wait for the VirtualMachine to stop", func() {
+		exportInformer.GetStore().Add(newExport())
+		vmInformer.GetStore().Add(newVM())
+		vmiInformer.GetStore().Add(&virtv1.VirtualMachineInstance{ObjectMeta: metav1.ObjectMeta{Name: "vm", Namespace: metav1.NamespaceDefault}})
+
+		expectStatus(virtv1.ImageExportPending, "")
+		Expect(controller.execute("default/export")).To(Succeed())
+	})
+
+	It("should fail if the VirtualMachine does not exist", func() {
+		exportInformer.GetStore().Add(newExport())
+
+		expectStatus(virtv1.ImageExportFailed, "")
+		Expect(controller.execute("default/export")).To(Succeed())
+		Expect(recorder.Events).To(Receive(ContainSubstring(FailedImageExportReason)))
+	})
+
+	It("should create a pod exporting the boot volume", func() {
+		exportInformer.GetStore().Add(newExport())
+		vmInformer.GetStore().Add(newVM())
+		pvcInformer.GetStore().Add(newPVC("root-dv", k8sv1.PersistentVolumeBlock))
+
+		var created *k8sv1.Pod
+		kubeClient.Fake.PrependReactor("create", "pods", func(action testing.Action) (bool, runtime.Object, error) {
+			created = action.(testing.CreateAction).GetObject().(*k8sv1.Pod)
+			return true, created, nil
+		})
+
+		expectStatus(virtv1.ImageExportInProgress, "")
+		Expect(controller.execute("default/export")).To(Succeed())
+
+		Expect(created).ToNot(BeNil())
+		Expect(created.Name).To(Equal("virt-image-export-export"))
+		Expect(metav1.GetControllerOf(created).Name).To(Equal("export"))
+		Expect(created.Spec.RestartPolicy).To(Equal(k8sv1.RestartPolicyNever))
+		Expect(created.Spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("root-dv"))
+		Expect(created.Spec.Volumes[1].Secret.SecretName).To(Equal("push-secret"))
+		container := created.Spec.Containers[0]
+		Expect(container.VolumeDevices).To(ConsistOf(k8sv1.VolumeDevice{Name: "disk", DevicePath: imageExportDiskDevice}))
+		Expect(container.Args).To(Equal([]string{
+			"--image", "quay.io/kubevirt/golden:v1",
+			"--base-image", "quay.io/kubevirt/base:v1",
+			"--disk", imageExportDiskDevice,
+			"--push-secret", "/var/run/kubevirt-private/push-secret/.dockerconfigjson",
+		}))
+	})
+
+	It("should fail if the boot volume is no PersistentVolumeClaim", func() {
+		vm := newVM()
+		vm.Spec.Template.Spec.Volumes[1].VolumeSource = virtv1.VolumeSource{ContainerDisk: &virtv1.ContainerDiskSource{Image: "base"}}
+		exportInformer.GetStore().Add(newExport())
+		vmInformer.GetStore().Add(vm)
+
+		expectStatus(virtv1.ImageExportFailed, "")
+		Expect(controller.execute("default/export")).To(Succeed())
+	})
+
+	It("should report the digest of the pushed image", func() {
+		export := newExport()
+		exportInformer.GetStore().Add(export)
+		vmInformer.GetStore().Add(newVM())
+		podInformer.GetStore().Add(newPod(export, k8sv1.PodSucceeded, digest))
+
+		expectStatus(virtv1.ImageExportSucceeded, digest)
+		Expect(controller.execute("default/export")).To(Succeed())
+		Expect(recorder.Events).To(Receive(ContainSubstring(SuccessfulImageExportReason)))
+	})
+
+	It("should report the failure of the pod", func() {
+		export := newExport()
+		exportInformer.GetStore().Add(export)
+		vmInformer.GetStore().Add(newVM())
+		podInformer.GetStore().Add(newPod(export, k8sv1.PodFailed, "unauthorized"))
+
+		exportInterface.EXPECT().UpdateStatus(gomock.Any()).DoAndReturn(func(export *virtv1.VirtualMachineImageExport) (*virtv1.VirtualMachineImageExport, error) {
+			Expect(export.Status.Phase).To(Equal(virtv1.ImageExportFailed))
+			Expect(export.Status.Message).To(Equal("unauthorized"))
+			return export, nil
+		})
+		Expect(controller.execute("default/export")).To(Succeed())
+	})
+
+	It("should abort the export if the VirtualMachine starts", func() {
+		export := newExport()
+		export.Status.Phase = virtv1.ImageExportInProgress
+		exportInformer.GetStore().Add(export)
+		vmInformer.GetStore().Add(newVM())
+		vmiInformer.GetStore().Add(&virtv1.VirtualMachineInstance{ObjectMeta: metav1.ObjectMeta{Name: "vm", Namespace: metav1.NamespaceDefault}})
+		podInformer.GetStore().Add(newPod(export, k8sv1.PodRunning, ""))
+
+		deleted := false
+		kubeClient.Fake.PrependReactor("delete", "pods", func(action testing.Action) (bool, runtime.Object, error) {
+			deleted = true
+			return true, nil, nil
+		})
+
+		expectStatus(virtv1.ImageExportFailed, "")
+		Expect(controller.execute("default/export")).To(Succeed())
+		Expect(deleted).To(BeTrue())
+	})
+
+	It("should ignore finished exports", func() {
+		export := newExport()
+		export.Status.Phase = virtv1.ImageExportSucceeded
+		exportInformer.GetStore().Add(export)
+
+		Expect(controller.execute("default/export")).To(Succeed())
+	})
+})
@@ -30,6 +30,7 @@ import (
 
 	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/api/policy/v1beta1"
+	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -50,6 +51,7 @@ import (
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/client-go/log"
 	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/util/conditions"
 	kubevirttypes "kubevirt.io/kubevirt/pkg/util/types"
 	"kubevirt.io/kubevirt/pkg/virt-controller/services"
 )
@@ -59,6 +61,21 @@ const (
 	successfulCreatePodDisruptionBudgetReason = "SuccessfulCreate"
 )
 
+const (
+	// MigrationPVCNotFoundReason is set when a PVC backing a volume of the VMI does not exist
+	MigrationPVCNotFoundReason = "PVCNotFound"
+	// MigrationPVCNotSharedReason is set when a PVC is not RWX and no storage migration was requested
+	MigrationPVCNotSharedReason = "PVCNotShared"
+	// MigrationStorageClassUnreachableReason is set when no other node can reach the storage class of a PVC
+	MigrationStorageClassUnreachableReason = "StorageClassUnreachable"
+	// MigrationBackoffReason is added when automated migrations of a VMI are held back after a failure
+	MigrationBackoffReason = "MigrationBackoff"
+	// MigrationHostPassthroughDeniedReason is set when the cluster does not allow to migrate VMIs with the host-passthrough CPU mode
+	MigrationHostPassthroughDeniedReason = "HostPassthroughDenied"
+	// MigrationCPUIncompatibleReason is set when the target node lacks the host CPU model or CPU features of the source node
+	MigrationCPUIncompatibleReason = "CPUIncompatible"
+)
+
 type MigrationController struct {
 	templateService    services.TemplateService
 	clientset          kubecli.KubevirtClient
@@ -68,6 +85,7 @@ type MigrationController struct {
 	migrationInformer  cache.SharedIndexInformer
 	nodeInformer       cache.SharedIndexInformer
 	pvcInformer        cache.SharedIndexInformer
+	storageClassStore  cache.Store
 	recorder           record.EventRecorder
 	podExpectations    *controller.UIDTrackingControllerExpectations
 	migrationStartLock *sync.Mutex
@@ -81,6 +99,7 @@ func NewMigrationController(templateService services.TemplateService,
 	migrationInformer cache.SharedIndexInformer,
 	nodeInformer cache.SharedIndexInformer,
 	pvcInformer cache.SharedIndexInformer,
+	storageClassInformer cache.SharedIndexInformer,
 	recorder record.EventRecorder,
 	clientset kubecli.KubevirtClient,
 	clusterConfig *virtconfig.ClusterConfig,
@@ -94,6 +113,7 @@ func NewMigrationController(templateService services.TemplateService,
 		migrationInformer:  migrationInformer,
 		nodeInformer:       nodeInformer,
 		pvcInformer:        pvcInformer,
+		storageClassStore:  storageClassInformer.GetStore(),
 		recorder:           recorder,
 		clientset:          clientset,
 		podExpectations:    controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectations()),
@@ -192,6 +212,27 @@ func (c *MigrationController) patchVMI(origVMI, newVMI *virtv1.VirtualMachineIns
 		}
 	}
 
+	if !reflect.DeepEqual(origVMI.Status.MigrationBackoff, newVMI.Status.MigrationBackoff) {
+		newBackoff, err := json.Marshal(newVMI.Status.MigrationBackoff)
+		if err != nil {
+			return err
+		}
+		if origVMI.Status.MigrationBackoff == nil {
+			ops = append(ops, fmt.Sprintf(`{ "op": "add", "path": "/status/migrationBackoff", "value": %s }`, string(newBackoff)))
+		} else {
+			oldBackoff, err := json.Marshal(origVMI.Status.MigrationBackoff)
+			if err != nil {
+				return err
+			}
+			ops = append(ops, fmt.Sprintf(`{ "op": "test", "path": "/status/migrationBackoff", "value": %s }`, string(oldBackoff)))
+			if newVMI.Status.MigrationBackoff == nil {
+				ops = append(ops, `{ "op": "remove", "path": "/status/migrationBackoff" }`)
+			} else {
+				ops = append(ops, fmt.Sprintf(`{ "op": "replace", "path": "/status/migrationBackoff", "value": %s }`, string(newBackoff)))
+			}
+		}
+	}
+
 	if !reflect.DeepEqual(origVMI.Labels, newVMI.Labels) {
 		newLabels, err := json.Marshal(newVMI.Labels)
 		if err != nil {
@@ -385,14 +426,29 @@ func (c *MigrationController) updateStatus(migration *virtv1.VirtualMachineInsta
 		migrationCopy.Status.Phase = virtv1.MigrationFailed
 		c.recorder.Eventf(migration, k8sv1.EventTypeWarning, FailedMigrationReason, "Source node reported migration failed")
 		log.Log.Object(migration).Errorf("VMI %s/%s reported migration failed.", vmi.Namespace, vmi.Name)
+
+		if vmi.Status.MigrationState.AbortStatus == virtv1.MigrationAbortSucceeded {
+			// the abort was either signaled by us or triggered by the timeouts enforced on the source node
+			if vmi.Status.MigrationState.AbortRequested {
+				conditions.SetMigrationCondition(migrationCopy, newMigrationAbortedCondition(virtv1.MigrationCancelledReason, "Migration was cancelled while in progress"))
+			} else {
+				conditions.SetMigrationCondition(migrationCopy, newMigrationAbortedCondition(virtv1.MigrationAbortedByPolicyReason, "Migration exceeded the configured timeouts"))
+			}
+		}
 	} else if migration.DeletionTimestamp != nil && !migration.IsFinal() &&
 		!conditionManager.HasCondition(migration, virtv1.VirtualMachineInstanceMigrationAbortRequested) {
-		condition := virtv1.VirtualMachineInstanceMigrationCondition{
+		conditions.SetMigrationCondition(migrationCopy, virtv1.VirtualMachineInstanceMigrationCondition{
 			Type:          virtv1.VirtualMachineInstanceMigrationAbortRequested,
 			Status:        k8sv1.ConditionTrue,
 			LastProbeTime: v1.Now(),
-		}
-		migrationCopy.Status.Conditions = append(migrationCopy.Status.Conditions, condition)
+		})
+	} else if !sourceMayMigrate(migration) &&
+		conditionManager.HasCondition(migration, virtv1.VirtualMachineInstanceMigrationAbortRequested) {
+		// the source did not start to transfer the VMI yet, so there is nothing to abort there
+		migrationCopy.Status.Phase = virtv1.MigrationFailed
+		conditions.SetMigrationCondition(migrationCopy, newMigrationAbortedCondition(virtv1.MigrationCancelledReason, "Migration was cancelled before it started"))
+		c.recorder.Eventf(migration, k8sv1.EventTypeNormal, SuccessfulAbortMigrationReason, "Migration was cancelled before it started")
+		log.Log.Object(migration).Infof("Migration cancelled in phase %s", migration.Status.Phase)
 	} else if attachmentPodExists && podIsDown(attachmentPod) {
 		migrationCopy.Status.Phase = virtv1.MigrationFailed
 		c.recorder.Eventf(migration, k8sv1.EventTypeWarning, FailedMigrationReason, "Migration failed because target attachment pod shutdown during migration")
@@ -407,7 +463,22 @@ func (c *MigrationController) updateStatus(migration *virtv1.VirtualMachineInsta
 			}
 
 			if canMigrate {
-				migrationCopy.Status.Phase = virtv1.MigrationPending
+				condition, err := c.checkMigrationStorage(vmi)
+				if err != nil {
+					return err
+				}
+				if condition == nil {
+					condition = c.checkHostPassthroughPolicy(vmi)
+				}
+				if condition != nil {
+					// fail right away, the target pod would never be able to start
+					migrationCopy.Status.Phase = virtv1.MigrationFailed
+					conditions.SetMigrationCondition(migrationCopy, *condition)
+					c.recorder.Eventf(migration, k8sv1.EventTypeWarning, FailedMigrationReason, "VMI is not eligible for migration: %s", condition.Message)
+					log.Log.Object(migration).Errorf("Migration failed %s checks: %s", condition.Type, condition.Message)
+				} else {
+					migrationCopy.Status.Phase = virtv1.MigrationPending
+				}
 			} else {
 				// can not migrate because there is an active migration already
 				// in progress for this VMI.
@@ -427,7 +498,17 @@ func (c *MigrationController) updateStatus(migration *virtv1.VirtualMachineInsta
 			}
 		case virtv1.MigrationScheduling:
 			if isPodReady(pod) {
-				if controller.VMIHasHotplugVolumes(vmi) {
+				condition, err := c.checkTargetNodeCPU(vmi, pod)
+				if err != nil {
+					return err
+				}
+				if condition != nil {
+					// fail before the handoff, QEMU would abort the migration on the target
+					migrationCopy.Status.Phase = virtv1.MigrationFailed
+					conditions.SetMigrationCondition(migrationCopy, *condition)
+					c.recorder.Eventf(migration, k8sv1.EventTypeWarning, FailedMigrationReason, "Migration target is not compatible: %s", condition.Message)
+					log.Log.Object(migration).Errorf("Migration failed CPU checks: %s", condition.Message)
+				} else if controller.VMIHasHotplugVolumes(vmi) {
 					if attachmentPodExists && isPodReady(attachmentPod) {
 						log.Log.Object(migration).Infof("Attachment pod %s for vmi %s/%s is ready", attachmentPod.Name, vmi.Namespace, vmi.Name)
 						migrationCopy.Status.Phase = virtv1.MigrationScheduled
@@ -521,6 +602,18 @@ func (c *MigrationController) createTargetPod(migration *virtv1.VirtualMachineIn
 		}
 	}
 
+	// If cpu model is "host passthrough" and only compatible nodes are allowed, require the cpu model and features of the source
+	if migrations.HostPassthroughPolicy(vmi, c.clusterConfig.GetMigrationConfiguration()) == virtv1.HostPassthroughMigrationCompatibleNodes {
+		node, err := c.getNodeForVMI(vmi)
+		if err != nil {
+			return err
+		}
+
+		for key, value := range migrations.HostCPUNodeSelector(node) {
+			templatePod.Spec.NodeSelector[key] = value
+		}
+	}
+
 	key := controller.MigrationKey(migration)
 	c.podExpectations.ExpectCreations(key, 1)
 	pod, err := c.clientset.CoreV1().Pods(vmi.GetNamespace()).Create(context.Background(), templatePod, v1.CreateOptions{})
@@ -597,10 +690,11 @@ func (c *MigrationController) handleTargetPodHandoff(migration *virtv1.VirtualMa
 
 	vmiCopy := vmi.DeepCopy()
 	vmiCopy.Status.MigrationState = &virtv1.VirtualMachineInstanceMigrationState{
-		MigrationUID: migration.UID,
-		TargetNode:   pod.Spec.NodeName,
-		SourceNode:   vmi.Status.NodeName,
-		TargetPod:    pod.Name,
+		MigrationUID:            migration.UID,
+		TargetNode:              pod.Spec.NodeName,
+		SourceNode:              vmi.Status.NodeName,
+		TargetPod:               pod.Name,
+		AbortOnProjectedTimeout: migration.Spec.AbortOnProjectedTimeout,
 	}
 
 	// By setting this label, virt-handler on the target node will receive
@@ -630,6 +724,70 @@ func (c *MigrationController) handleTargetPodHandoff(migration *virtv1.VirtualMa
 	return nil
 }
 
+// drainSourcePod removes the source pod from the endpoints of Services before the VMI is handed off to the
+// migration target, once the target pod joined them. It returns true once the connections to the VMI had the
+// configured time to drain and the switchover may be signalled, the migration is requeued until then.
+func (c *MigrationController) drainSourcePod(key string, migration *virtv1.VirtualMachineInstanceMigration, vmi *virtv1.VirtualMachineInstance, targetPod *k8sv1.Pod) (bool, error) {
+	if vmi.Status.MigrationState != nil && vmi.Status.MigrationState.MigrationUID == migration.UID {
+		// already handed off
+		return true, nil
+	}
+
+	drainSeconds := c.clusterConfig.GetMigrationConfiguration().PreSwitchoverDrainSeconds
+	if drainSeconds == nil || *drainSeconds <= 0 {
+		return true, nil
+	}
+	drainDuration := time.Duration(*drainSeconds) * time.Second
+
+	sourcePod, err := controller.CurrentVMIPod(vmi, c.podInformer)
+	if err != nil {
+		return false, err
+	}
+	// only pods with the readiness gate can be removed from the endpoints
+	if sourcePod == nil || !hasNotDrainingReadinessGate(sourcePod) {
+		return true, nil
+	}
+
+	if !isDrainedByMigration(sourcePod, migration) {
+		if hasNotDrainingReadinessGate(targetPod) {
+			if condition := notDrainingCondition(targetPod); condition == nil || condition.Status != k8sv1.ConditionTrue {
+				if err := patchNotDrainingCondition(c.clientset, targetPod, k8sv1.ConditionTrue, "", ""); err != nil {
+					return false, fmt.Errorf("failed to add pod %s to the endpoints: %v", targetPod.Name, err)
+				}
+			}
+		}
+
+		err = patchNotDrainingCondition(c.clientset, sourcePod, k8sv1.ConditionFalse, virtv1.MigrationDrainingReason, drainMessage(migration))
+		if err != nil {
+			return false, fmt.Errorf("failed to drain the connections to pod %s: %v", sourcePod.Name, err)
+		}
+		log.Log.Object(vmi).Infof("Draining the connections to pod %s for %s before the switchover of migration %s", sourcePod.Name, drainDuration, migration.Name)
+		c.Queue.AddAfter(key, drainDuration)
+		return false, nil
+	}
+
+	drainedAt := notDrainingCondition(sourcePod).LastTransitionTime
+	if remaining := drainedAt.Add(drainDuration).Sub(time.Now()); remaining > 0 {
+		c.Queue.AddAfter(key, remaining)
+		return false, nil
+	}
+	return true, nil
+}
+
+// restoreDrainedSourcePod adds the source pod back to the endpoints of Services if the migration drained it
+func (c *MigrationController) restoreDrainedSourcePod(migration *virtv1.VirtualMachineInstanceMigration, vmi *virtv1.VirtualMachineInstance) error {
+	sourcePod, err := controller.CurrentVMIPod(vmi, c.podInformer)
+	if err != nil || sourcePod == nil || !isDrainedByMigration(sourcePod, migration) {
+		return err
+	}
+
+	if err := patchNotDrainingCondition(c.clientset, sourcePod, k8sv1.ConditionTrue, "", ""); err != nil {
+		return fmt.Errorf("failed to restore the connections to pod %s: %v", sourcePod.Name, err)
+	}
+	log.Log.Object(vmi).Infof("Restored the connections to pod %s after migration %s ended without a switchover", sourcePod.Name, migration.Name)
+	return nil
+}
+
 func (c *MigrationController) handleSignalMigrationAbort(migration *virtv1.VirtualMachineInstanceMigration, vmi *virtv1.VirtualMachineInstance) error {
 
 	vmiCopy := vmi.DeepCopy()
@@ -659,6 +817,53 @@ func (c *MigrationController) handleSignalMigrationAbort(migration *virtv1.Virtu
 	return nil
 }
 
+// migrationBackoffOutdated returns true if the outcome of the given migration, which must be
+// the latest one of the VMI, is not reflected in the migration backoff of the VMI yet.
+func migrationBackoffOutdated(migration *virtv1.VirtualMachineInstanceMigration, vmi *virtv1.VirtualMachineInstance) bool {
+	state := vmi.Status.MigrationState
+	if state == nil || state.MigrationUID != migration.UID || !state.Completed {
+		return false
+	}
+	backoff := vmi.Status.MigrationBackoff
+	if !state.Failed {
+		return backoff != nil
+	}
+	// cancelled migrations don't say anything about the ability of the VMI to migrate
+	if state.AbortRequested {
+		return false
+	}
+	return backoff == nil || backoff.LastFailedMigrationUID != migration.UID
+}
+
+func (c *MigrationController) updateMigrationBackoff(migration *virtv1.VirtualMachineInstanceMigration, vmi *virtv1.VirtualMachineInstance) error {
+	vmiCopy := vmi.DeepCopy()
+	if !vmi.Status.MigrationState.Failed {
+		vmiCopy.Status.MigrationBackoff = nil
+		return c.patchVMI(vmi, vmiCopy)
+	}
+
+	vmiCopy.Status.MigrationBackoff = migrations.NextMigrationBackoff(vmi.Status.MigrationBackoff, migration.UID, v1.Now())
+	if err := c.patchVMI(vmi, vmiCopy); err != nil {
+		return err
+	}
+	backoff := vmiCopy.Status.MigrationBackoff
+	c.recorder.Eventf(vmi, k8sv1.EventTypeWarning, MigrationBackoffReason, "Migration failed %d times in a row, automated migrations are held back until %s",
+		backoff.FailureCount, backoff.NextAttemptTimestamp.UTC().Format(time.RFC3339))
+	return nil
+}
+
+func (c *MigrationController) deleteTargetPod(key string, migration *virtv1.VirtualMachineInstanceMigration, pod *k8sv1.Pod) error {
+	c.podExpectations.ExpectDeletions(key, []string{controller.PodKey(pod)})
+	err := c.clientset.CoreV1().Pods(pod.Namespace).Delete(context.Background(), pod.Name, v1.DeleteOptions{})
+	if err != nil {
+		c.podExpectations.DeletionObserved(key, controller.PodKey(pod))
+		c.recorder.Eventf(migration, k8sv1.EventTypeWarning, FailedDeletePodReason, "Failed to delete migration target pod %s: %v", pod.Name, err)
+		return err
+	}
+	c.recorder.Eventf(migration, k8sv1.EventTypeNormal, SuccessfulDeletePodReason, "Deleted migration target pod %s of the aborted migration", pod.Name)
+	return nil
+}
+
 func (c *MigrationController) handleTargetPodCreation(key string, migration *virtv1.VirtualMachineInstanceMigration, vmi *virtv1.VirtualMachineInstance) error {
 
 	c.migrationStartLock.Lock()
@@ -764,6 +969,32 @@ func (c *MigrationController) sync(key string, migration *virtv1.VirtualMachineI
 		pod = pods[0]
 	}
 
+	conditionManager := controller.NewVirtualMachineInstanceMigrationConditionManager()
+
+	if vmi.DeletionTimestamp == nil && migrationBackoffOutdated(migration, vmi) {
+		return c.updateMigrationBackoff(migration, vmi)
+	}
+
+	// the VMI stays on the source pod of a failed or deleted migration, which serves connections again
+	if migration.Status.Phase != virtv1.MigrationSucceeded && (migration.Status.Phase == virtv1.MigrationFailed || migration.DeletionTimestamp != nil) {
+		if err := c.restoreDrainedSourcePod(migration, vmi); err != nil {
+			return err
+		}
+	}
+
+	// roll back the target pod of an aborted or incompatible migration, the VMI stays on the source
+	if migration.Status.Phase == virtv1.MigrationFailed && podExists && pod.DeletionTimestamp == nil &&
+		(conditionManager.HasCondition(migration, virtv1.VirtualMachineInstanceMigrationAborted) ||
+			conditionManager.HasCondition(migration, virtv1.VirtualMachineInstanceMigrationCPUCheckFailed)) {
+		return c.deleteTargetPod(key, migration, pod)
+	}
+
+	// a migration cancelled before it started is failed by the status update, don't progress it any further
+	if !migration.IsFinal() && !sourceMayMigrate(migration) &&
+		conditionManager.HasCondition(migration, virtv1.VirtualMachineInstanceMigrationAbortRequested) {
+		return nil
+	}
+
 	vmiDeleted := vmi == nil || vmi.DeletionTimestamp != nil
 	migrationFinalizedOnVMI := vmi.Status.MigrationState != nil && vmi.Status.MigrationState.MigrationUID == migration.UID && vmi.Status.MigrationState.EndTimestamp != nil
 
@@ -800,6 +1031,9 @@ func (c *MigrationController) sync(key string, migration *virtv1.VirtualMachineI
 		// once target pod is running, then alert the VMI of the migration by
 		// setting the target and source nodes. This kicks off the preparation stage.
 		if podExists && isPodReady(pod) {
+			if drained, err := c.drainSourcePod(key, migration, vmi, pod); err != nil || !drained {
+				return err
+			}
 			return c.handleTargetPodHandoff(migration, vmi, pod)
 		}
 	case virtv1.MigrationPreparingTarget, virtv1.MigrationTargetReady, virtv1.MigrationFailed:
@@ -812,9 +1046,17 @@ func (c *MigrationController) sync(key string, migration *virtv1.VirtualMachineI
 
 			return c.handleMarkMigrationFailedOnVMI(migration, vmi)
 		}
+		if migration.Status.Phase == virtv1.MigrationTargetReady {
+			// the source starts to transfer the VMI as soon as the target is ready, it has to abort it
+			if (migration.DeletionTimestamp != nil || conditionManager.HasCondition(migration, virtv1.VirtualMachineInstanceMigrationAbortRequested)) &&
+				vmi.Status.MigrationState != nil {
+				return c.handleSignalMigrationAbort(migration, vmi)
+			}
+		}
 	case virtv1.MigrationRunning:
-		// abort the migration if the migration is being deleted.
-		if migration.DeletionTimestamp != nil && vmi.Status.MigrationState != nil {
+		// abort the migration if the migration is being deleted or was cancelled.
+		if (migration.DeletionTimestamp != nil || conditionManager.HasCondition(migration, virtv1.VirtualMachineInstanceMigrationAbortRequested)) &&
+			vmi.Status.MigrationState != nil {
 			return c.handleSignalMigrationAbort(migration, vmi)
 		}
 	}
@@ -1186,3 +1428,185 @@ func prepareNodeSelectorForHostCpuModel(node *k8sv1.Node, pod *k8sv1.Pod) error
 
 	return nil
 }
+
+// sourceMayMigrate returns true once the target is ready, from then on the source may transfer the VMI any time
+// and a cancelled migration has to be aborted by the source
+func sourceMayMigrate(migration *virtv1.VirtualMachineInstanceMigration) bool {
+	return migration.Status.Phase == virtv1.MigrationTargetReady || migration.Status.Phase == virtv1.MigrationRunning
+}
+
+func newMigrationAbortedCondition(reason string, message string) virtv1.VirtualMachineInstanceMigrationCondition {
+	now := v1.Now()
+	return virtv1.VirtualMachineInstanceMigrationCondition{
+		Type:               virtv1.VirtualMachineInstanceMigrationAborted,
+		Status:             k8sv1.ConditionTrue,
+		LastProbeTime:      now,
+		LastTransitionTime: now,
+		Reason:             reason,
+		Message:            message,
+	}
+}
+
+// checkMigrationStorage verifies that all PVC backed volumes of the VMI can follow it to another node.
+// If they can't, a storageCheckFailed condition explaining why is returned.
+func (c *MigrationController) checkMigrationStorage(vmi *virtv1.VirtualMachineInstance) (*virtv1.VirtualMachineInstanceMigrationCondition, error) {
+	storageCheckFailed := func(reason string, message string) *virtv1.VirtualMachineInstanceMigrationCondition {
+		now := v1.Now()
+		return &virtv1.VirtualMachineInstanceMigrationCondition{
+			Type:               virtv1.VirtualMachineInstanceMigrationStorageCheckFailed,
+			Status:             k8sv1.ConditionTrue,
+			LastProbeTime:      now,
+			LastTransitionTime: now,
+			Reason:             reason,
+			Message:            message,
+		}
+	}
+
+	var candidateNodes []*k8sv1.Node
+	candidateNodesListed := false
+
+	for i := range vmi.Spec.Volumes {
+		volume := &vmi.Spec.Volumes[i]
+		claimName := kubevirttypes.PVCNameFromVirtVolume(volume)
+		if claimName == "" {
+			continue
+		}
+
+		pvc, exists, _, err := kubevirttypes.IsPVCBlockFromStore(c.pvcInformer.GetStore(), vmi.Namespace, claimName)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return storageCheckFailed(MigrationPVCNotFoundReason, fmt.Sprintf("PVC %s/%s of volume %s does not exist", vmi.Namespace, claimName, volume.Name)), nil
+		}
+
+		if !kubevirttypes.HasSharedAccessMode(pvc.Status.AccessModes) && vmi.Status.MigrationMethod != virtv1.BlockMigration {
+			return storageCheckFailed(MigrationPVCNotSharedReason, fmt.Sprintf("PVC %s/%s of volume %s is not shared, ReadWriteMany access mode or storage migration is required", vmi.Namespace, claimName, volume.Name)), nil
+		}
+
+		storageClass, err := c.getStorageClass(pvc)
+		if err != nil {
+			return nil, err
+		}
+		if storageClass == nil || len(storageClass.AllowedTopologies) == 0 {
+			continue
+		}
+
+		if !candidateNodesListed {
+			candidateNodes = c.listMigrationCandidateNodes(vmi)
+			candidateNodesListed = true
+		}
+		reachable := false
+		for _, node := range candidateNodes {
+			if nodeMatchesTopologies(node, storageClass.AllowedTopologies) {
+				reachable = true
+				break
+			}
+		}
+		if !reachable {
+			return storageCheckFailed(MigrationStorageClassUnreachableReason, fmt.Sprintf("no schedulable node besides %s can reach storage class %s of PVC %s/%s", vmi.Status.NodeName, storageClass.Name, vmi.Namespace, claimName)), nil
+		}
+	}
+
+	return nil, nil
+}
+
+func newCPUCheckFailedCondition(reason string, message string) *virtv1.VirtualMachineInstanceMigrationCondition {
+	now := v1.Now()
+	return &virtv1.VirtualMachineInstanceMigrationCondition{
+		Type:               virtv1.VirtualMachineInstanceMigrationCPUCheckFailed,
+		Status:             k8sv1.ConditionTrue,
+		LastProbeTime:      now,
+		LastTransitionTime: now,
+		Reason:             reason,
+		Message:            message,
+	}
+}
+
+// checkHostPassthroughPolicy returns a cpuCheckFailed condition if the cluster does not allow to migrate the VMI because of its CPU mode
+func (c *MigrationController) checkHostPassthroughPolicy(vmi *virtv1.VirtualMachineInstance) *virtv1.VirtualMachineInstanceMigrationCondition {
+	if migrations.HostPassthroughPolicy(vmi, c.clusterConfig.GetMigrationConfiguration()) != virtv1.HostPassthroughMigrationDeny {
+		return nil
+	}
+	return newCPUCheckFailedCondition(MigrationHostPassthroughDeniedReason, "migrating VMIs with the host-passthrough CPU mode is not allowed in this cluster")
+}
+
+// checkTargetNodeCPU verifies that the node of the target pod offers the host CPU model and all CPU features
+// of the source node to a host-passthrough VMI. If it doesn't, a cpuCheckFailed condition is returned.
+func (c *MigrationController) checkTargetNodeCPU(vmi *virtv1.VirtualMachineInstance, pod *k8sv1.Pod) (*virtv1.VirtualMachineInstanceMigrationCondition, error) {
+	if migrations.HostPassthroughPolicy(vmi, c.clusterConfig.GetMigrationConfiguration()) == "" {
+		return nil, nil
+	}
+
+	source, err := c.getNodeForVMI(vmi)
+	if err != nil {
+		return nil, err
+	}
+	obj, exists, err := c.nodeInformer.GetStore().GetByKey(pod.Spec.NodeName)
+	if err != nil {
+		return nil, err
+	} else if !exists {
+		return nil, fmt.Errorf("target node \"%s\" of vmi \"%s\" does not exist", pod.Spec.NodeName, vmi.Name)
+	}
+	target := obj.(*k8sv1.Node)
+
+	missing := migrations.MissingHostCPULabels(source, target)
+	if len(missing) == 0 {
+		return nil, nil
+	}
+	return newCPUCheckFailedCondition(MigrationCPUIncompatibleReason, fmt.Sprintf("node %s lacks the host CPU of node %s: %s", target.Name, source.Name, strings.Join(missing, ", "))), nil
+}
+
+func (c *MigrationController) getStorageClass(pvc *k8sv1.PersistentVolumeClaim) (*storagev1.StorageClass, error) {
+	if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName == "" {
+		return nil, nil
+	}
+	obj, exists, err := c.storageClassStore.GetByKey(*pvc.Spec.StorageClassName)
+	if err != nil || !exists {
+		return nil, err
+	}
+	return obj.(*storagev1.StorageClass), nil
+}
+
+// listMigrationCandidateNodes returns the schedulable nodes, besides the current one, which match the node selector of the VMI
+func (c *MigrationController) listMigrationCandidateNodes(vmi *virtv1.VirtualMachineInstance) []*k8sv1.Node {
+	var nodes []*k8sv1.Node
+	nodeSelector := labels.SelectorFromSet(vmi.Spec.NodeSelector)
+	for _, obj := range c.nodeInformer.GetStore().List() {
+		node := obj.(*k8sv1.Node)
+		if node.Name == vmi.Status.NodeName || node.Spec.Unschedulable || node.Labels[virtv1.NodeSchedulable] != "true" {
+			continue
+		}
+		if !nodeSelector.Matches(labels.Set(node.Labels)) {
+			continue
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+func nodeMatchesTopologies(node *k8sv1.Node, topologies []k8sv1.TopologySelectorTerm) bool {
+	for _, term := range topologies {
+		matches := true
+		for _, expression := range term.MatchLabelExpressions {
+			value, exists := node.Labels[expression.Key]
+			if !exists || !hasValue(expression.Values, value) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
+func hasValue(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
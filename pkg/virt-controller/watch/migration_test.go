@@ -29,8 +29,10 @@ import (
 	. "github.com/onsi/ginkgo"
 	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
+	gomegaTypes "github.com/onsi/gomega/types"
 	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/api/policy/v1beta1"
+	storagev1 "k8s.io/api/storage/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -47,6 +49,7 @@ import (
 	"kubevirt.io/client-go/kubecli"
 	"kubevirt.io/kubevirt/pkg/testutils"
 	utiltype "kubevirt.io/kubevirt/pkg/util/types"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	"kubevirt.io/kubevirt/pkg/virt-controller/services"
 )
 
@@ -71,6 +74,8 @@ var _ = Describe("Migration watcher", func() {
 	var kubeClient *fake.Clientset
 	var networkClient *fakenetworkclient.Clientset
 	var pvcInformer cache.SharedIndexInformer
+	var storageClassInformer cache.SharedIndexInformer
+	var configMapInformer cache.SharedIndexInformer
 	var qemuGid int64 = 107
 
 	shouldExpectMigrationFinalizerRemoval := func(migration *v1.VirtualMachineInstanceMigration) {
@@ -193,15 +198,18 @@ var _ = Describe("Migration watcher", func() {
 		nodeInformer, _ = testutils.NewFakeInformerFor(&k8sv1.Node{})
 
 		pvcInformer, _ = testutils.NewFakeInformerFor(&k8sv1.PersistentVolumeClaim{})
-		config, _, _, _ := testutils.NewFakeClusterConfig(&k8sv1.ConfigMap{})
+		storageClassInformer, _ = testutils.NewFakeInformerFor(&storagev1.StorageClass{})
+		var config *virtconfig.ClusterConfig
+		config, configMapInformer, _, _ = testutils.NewFakeClusterConfig(&k8sv1.ConfigMap{})
 
 		controller = NewMigrationController(
-			services.NewTemplateService("a", 240, "b", "c", "d", "e", "f", "g", pvcInformer.GetStore(), virtClient, config, qemuGid),
+			services.NewTemplateService("a", 240, "b", "c", "d", "e", "f", "g", pvcInformer.GetStore(), cache.NewStore(cache.MetaNamespaceKeyFunc), virtClient, config, qemuGid),
 			vmiInformer,
 			podInformer,
 			migrationInformer,
 			nodeInformer,
 			pvcInformer,
+			storageClassInformer,
 			recorder,
 			virtClient,
 			config,
@@ -333,6 +341,430 @@ var _ = Describe("Migration watcher", func() {
 		})
 	})
 
+	Context("Migration storage checks", func() {
+		var vmi *v1.VirtualMachineInstance
+		var migration *v1.VirtualMachineInstanceMigration
+
+		addPVC := func(accessMode k8sv1.PersistentVolumeAccessMode, storageClassName string) {
+			pvc := &k8sv1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "testpvc", Namespace: vmi.Namespace},
+				Spec:       k8sv1.PersistentVolumeClaimSpec{StorageClassName: &storageClassName},
+				Status:     k8sv1.PersistentVolumeClaimStatus{AccessModes: []k8sv1.PersistentVolumeAccessMode{accessMode}},
+			}
+			Expect(pvcInformer.GetStore().Add(pvc)).To(Succeed())
+		}
+
+		addZonalStorageClass := func(zone string) {
+			storageClass := &storagev1.StorageClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "zonal"},
+				AllowedTopologies: []k8sv1.TopologySelectorTerm{{
+					MatchLabelExpressions: []k8sv1.TopologySelectorLabelRequirement{{
+						Key:    k8sv1.LabelZoneFailureDomainStable,
+						Values: []string{zone},
+					}},
+				}},
+			}
+			Expect(storageClassInformer.GetStore().Add(storageClass)).To(Succeed())
+		}
+
+		addSchedulableNode := func(name string, zone string) {
+			addNode(&k8sv1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: name,
+					Labels: map[string]string{
+						v1.NodeSchedulable:                 "true",
+						k8sv1.LabelZoneFailureDomainStable: zone,
+					},
+				},
+			})
+		}
+
+		shouldExpectStorageCheckFailure := func(reason string) {
+			migrationInterface.EXPECT().UpdateStatus(gomock.Any()).DoAndReturn(func(arg interface{}) (interface{}, interface{}) {
+				status := arg.(*v1.VirtualMachineInstanceMigration).Status
+				Expect(status.Phase).To(Equal(v1.MigrationFailed))
+				Expect(status.Conditions).To(HaveLen(1))
+				Expect(status.Conditions[0].Type).To(Equal(v1.VirtualMachineInstanceMigrationStorageCheckFailed))
+				Expect(status.Conditions[0].Status).To(Equal(k8sv1.ConditionTrue))
+				Expect(status.Conditions[0].Reason).To(Equal(reason))
+				return arg, nil
+			})
+		}
+
+		shouldExpectMigrationPendingState := func() {
+			migrationInterface.EXPECT().UpdateStatus(gomock.Any()).DoAndReturn(func(arg interface{}) (interface{}, interface{}) {
+				Expect(arg.(*v1.VirtualMachineInstanceMigration).Status.Phase).To(Equal(v1.MigrationPending))
+				Expect(arg.(*v1.VirtualMachineInstanceMigration).Status.Conditions).To(BeEmpty())
+				return arg, nil
+			})
+		}
+
+		BeforeEach(func() {
+			vmi = newVirtualMachine("testvmi", v1.Running)
+			vmi.Status.NodeName = "node01"
+			vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+				Name: "disk0",
+				VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+						PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "testpvc"},
+					},
+				},
+			})
+			migration = newMigration("testmigration", vmi.Name, v1.MigrationPhaseUnset)
+		})
+
+		It("should fail the migration if a PVC does not exist", func() {
+			addMigration(migration)
+			addVirtualMachineInstance(vmi)
+
+			shouldExpectStorageCheckFailure(MigrationPVCNotFoundReason)
+			controller.Execute()
+			testutils.ExpectEvent(recorder, FailedMigrationReason)
+		})
+
+		It("should fail the migration if a PVC is not shared", func() {
+			addPVC(k8sv1.ReadWriteOnce, "")
+			addMigration(migration)
+			addVirtualMachineInstance(vmi)
+
+			shouldExpectStorageCheckFailure(MigrationPVCNotSharedReason)
+			controller.Execute()
+			testutils.ExpectEvent(recorder, FailedMigrationReason)
+		})
+
+		It("should accept a PVC which is not shared if storage migration is requested", func() {
+			vmi.Status.MigrationMethod = v1.BlockMigration
+			addPVC(k8sv1.ReadWriteOnce, "")
+			addMigration(migration)
+			addVirtualMachineInstance(vmi)
+
+			shouldExpectMigrationPendingState()
+			controller.Execute()
+		})
+
+		It("should fail the migration if no other node can reach the storage class", func() {
+			addPVC(k8sv1.ReadWriteMany, "zonal")
+			addZonalStorageClass("zone-a")
+			addSchedulableNode("node01", "zone-a")
+			addSchedulableNode("node02", "zone-b")
+			addMigration(migration)
+			addVirtualMachineInstance(vmi)
+
+			shouldExpectStorageCheckFailure(MigrationStorageClassUnreachableReason)
+			controller.Execute()
+			testutils.ExpectEvent(recorder, FailedMigrationReason)
+		})
+
+		It("should move the migration to pending if another node can reach the storage class", func() {
+			addPVC(k8sv1.ReadWriteMany, "zonal")
+			addZonalStorageClass("zone-a")
+			addSchedulableNode("node01", "zone-a")
+			addSchedulableNode("node02", "zone-a")
+			addMigration(migration)
+			addVirtualMachineInstance(vmi)
+
+			shouldExpectMigrationPendingState()
+			controller.Execute()
+		})
+	})
+
+	Context("Migration CPU checks", func() {
+		var vmi *v1.VirtualMachineInstance
+
+		setHostPassthroughPolicy := func(policy v1.HostPassthroughMigrationPolicy) {
+			testutils.UpdateFakeClusterConfig(configMapInformer, &k8sv1.ConfigMap{
+				Data: map[string]string{
+					virtconfig.MigrationsConfigKey: fmt.Sprintf(`{"hostPassthroughPolicy": "%s"}`, policy),
+				},
+			})
+		}
+
+		addNodeWithCPU := func(name string, cpuLabels ...string) {
+			node := newNode(name)
+			node.Labels = map[string]string{}
+			for _, label := range cpuLabels {
+				node.Labels[label] = "true"
+			}
+			addNode(node)
+		}
+
+		shouldExpectCPUCheckFailure := func(reason string) {
+			migrationInterface.EXPECT().UpdateStatus(gomock.Any()).DoAndReturn(func(arg interface{}) (interface{}, interface{}) {
+				status := arg.(*v1.VirtualMachineInstanceMigration).Status
+				Expect(status.Phase).To(Equal(v1.MigrationFailed))
+				Expect(status.Conditions).To(HaveLen(1))
+				Expect(status.Conditions[0].Type).To(Equal(v1.VirtualMachineInstanceMigrationCPUCheckFailed))
+				Expect(status.Conditions[0].Status).To(Equal(k8sv1.ConditionTrue))
+				Expect(status.Conditions[0].Reason).To(Equal(reason))
+				return arg, nil
+			})
+		}
+
+		BeforeEach(func() {
+			vmi = newVirtualMachine("testvmi", v1.Running)
+			vmi.Status.NodeName = "node01"
+			vmi.Spec.Domain.CPU = &v1.CPU{Model: v1.CPUModeHostPassthrough}
+		})
+
+		It("should fail the migration if the cluster denies to migrate host-passthrough VMIs", func() {
+			setHostPassthroughPolicy(v1.HostPassthroughMigrationDeny)
+			migration := newMigration("testmigration", vmi.Name, v1.MigrationPhaseUnset)
+			addMigration(migration)
+			addVirtualMachineInstance(vmi)
+
+			shouldExpectCPUCheckFailure(MigrationHostPassthroughDeniedReason)
+			controller.Execute()
+			testutils.ExpectEvent(recorder, FailedMigrationReason)
+		})
+
+		It("should target the target pod to nodes with the CPU of the source node if only compatible nodes are allowed", func() {
+			setHostPassthroughPolicy(v1.HostPassthroughMigrationCompatibleNodes)
+			migration := newMigration("testmigration", vmi.Name, v1.MigrationPending)
+			addNodeWithCPU("node01", v1.HostModelCPULabel+"Skylake", v1.CPUFeatureLabel+"avx512f", "other-label")
+			addMigration(migration)
+			addVirtualMachineInstance(vmi)
+
+			kubeClient.Fake.PrependReactor("create", "pods", func(action testing.Action) (handled bool, obj k8sruntime.Object, err error) {
+				creation, ok := action.(testing.CreateAction)
+				Expect(ok).To(BeTrue())
+				pod := creation.GetObject().(*k8sv1.Pod)
+				Expect(pod.Spec.NodeSelector).To(HaveKeyWithValue(v1.HostModelCPULabel+"Skylake", "true"))
+				Expect(pod.Spec.NodeSelector).To(HaveKeyWithValue(v1.CPUFeatureLabel+"avx512f", "true"))
+				Expect(pod.Spec.NodeSelector).ToNot(HaveKey("other-label"))
+				return true, creation.GetObject(), nil
+			})
+			controller.Execute()
+
+			testutils.ExpectEvent(recorder, SuccessfulCreatePodReason)
+			testutils.ExpectEvent(recorder, successfulCreatePodDisruptionBudgetReason)
+		})
+
+		table.DescribeTable("once the target pod is ready", func(targetCPULabels []string, compatible bool) {
+			migration := newMigration("testmigration", vmi.Name, v1.MigrationScheduling)
+			pod := newTargetPodForVirtualMachine(vmi, migration, k8sv1.PodRunning)
+			pod.Spec.NodeName = "node02"
+			addNodeWithCPU("node01", v1.HostModelCPULabel+"Skylake", v1.CPUFeatureLabel+"avx512f")
+			addNodeWithCPU("node02", targetCPULabels...)
+			addMigration(migration)
+			addVirtualMachineInstance(vmi)
+			podFeeder.Add(pod)
+
+			if compatible {
+				migrationInterface.EXPECT().UpdateStatus(gomock.Any()).DoAndReturn(func(arg interface{}) (interface{}, interface{}) {
+					Expect(arg.(*v1.VirtualMachineInstanceMigration).Status.Phase).To(Equal(v1.MigrationScheduled))
+					return arg, nil
+				})
+				controller.Execute()
+			} else {
+				shouldExpectCPUCheckFailure(MigrationCPUIncompatibleReason)
+				controller.Execute()
+				testutils.ExpectEvent(recorder, FailedMigrationReason)
+			}
+		},
+			table.Entry("should schedule the migration if the target node has the same CPU",
+				[]string{v1.HostModelCPULabel + "Skylake", v1.CPUFeatureLabel + "avx512f", v1.CPUFeatureLabel + "pku"}, true),
+			table.Entry("should fail the migration if the target node has another CPU model",
+				[]string{v1.HostModelCPULabel + "Haswell", v1.CPUFeatureLabel + "avx512f"}, false),
+			table.Entry("should fail the migration if the target node lacks CPU features",
+				[]string{v1.HostModelCPULabel + "Skylake"}, false),
+		)
+
+		It("should delete the target pod of a migration which failed the CPU checks", func() {
+			migration := newMigration("testmigration", vmi.Name, v1.MigrationFailed)
+			migration.Status.Conditions = append(migration.Status.Conditions, v1.VirtualMachineInstanceMigrationCondition{
+				Type:   v1.VirtualMachineInstanceMigrationCPUCheckFailed,
+				Status: k8sv1.ConditionTrue,
+				Reason: MigrationCPUIncompatibleReason,
+			})
+			pod := newTargetPodForVirtualMachine(vmi, migration, k8sv1.PodRunning)
+			pod.Spec.NodeName = "node02"
+			addMigration(migration)
+			addVirtualMachineInstance(vmi)
+			podFeeder.Add(pod)
+
+			kubeClient.Fake.PrependReactor("delete", "pods", func(action testing.Action) (handled bool, obj k8sruntime.Object, err error) {
+				deletion, ok := action.(testing.DeleteAction)
+				Expect(ok).To(BeTrue())
+				Expect(deletion.GetName()).To(Equal(pod.Name))
+				return true, nil, nil
+			})
+			shouldExpectMigrationFinalizerRemoval(migration)
+			controller.Execute()
+			testutils.ExpectEvent(recorder, SuccessfulDeletePodReason)
+		})
+	})
+
+	Context("Migration with connection draining", func() {
+		var (
+			vmi       *v1.VirtualMachineInstance
+			sourcePod *k8sv1.Pod
+		)
+
+		expectNotDrainingConditionPatches := func(pods []*k8sv1.Pod, statuses []k8sv1.ConditionStatus) {
+			patches := 0
+			kubeClient.Fake.PrependReactor("patch", "pods", func(action testing.Action) (handled bool, obj k8sruntime.Object, err error) {
+				patch, ok := action.(testing.PatchAction)
+				Expect(ok).To(BeTrue())
+				Expect(patches).To(BeNumerically("<", len(pods)))
+				Expect(patch.GetName()).To(Equal(pods[patches].Name))
+				Expect(patch.GetSubresource()).To(Equal("status"))
+				Expect(patch.GetPatchType()).To(Equal(types.StrategicMergePatchType))
+				Expect(string(patch.GetPatch())).To(And(
+					ContainSubstring(`"type":"%s"`, v1.VirtualMachineInstanceNotDraining),
+					ContainSubstring(`"status":"%s"`, statuses[patches]),
+				))
+				patches++
+				return true, nil, nil
+			})
+		}
+
+		expectNotDrainingConditionPatch := func(status k8sv1.ConditionStatus) {
+			expectNotDrainingConditionPatches([]*k8sv1.Pod{sourcePod}, []k8sv1.ConditionStatus{status})
+		}
+
+		BeforeEach(func() {
+			testutils.UpdateFakeClusterConfig(configMapInformer, &k8sv1.ConfigMap{
+				Data: map[string]string{
+					virtconfig.MigrationsConfigKey: `{"preSwitchoverDrainSeconds": "30"}`,
+				},
+			})
+			vmi = newVirtualMachine("testvmi", v1.Running)
+			vmi.Status.NodeName = "node02"
+			sourcePod = newSourcePodForVirtualMachine(vmi)
+			sourcePod.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(vmi, v1.VirtualMachineInstanceGroupVersionKind)}
+			sourcePod.Spec.ReadinessGates = []k8sv1.PodReadinessGate{{ConditionType: v1.VirtualMachineInstanceNotDraining}}
+		})
+
+		newReadyTargetPod := func(migration *v1.VirtualMachineInstanceMigration) *k8sv1.Pod {
+			pod := newTargetPodForVirtualMachine(vmi, migration, k8sv1.PodRunning)
+			pod.Spec.NodeName = "node01"
+			pod.Status.ContainerStatuses = []k8sv1.ContainerStatus{{
+				Name: "compute", State: k8sv1.ContainerState{Running: &k8sv1.ContainerStateRunning{}},
+			}}
+			return pod
+		}
+
+		drainSourcePod := func(migration *v1.VirtualMachineInstanceMigration, drainedAt time.Time) {
+			sourcePod.Status.Conditions = []k8sv1.PodCondition{{
+				Type:               v1.VirtualMachineInstanceNotDraining,
+				Status:             k8sv1.ConditionFalse,
+				Reason:             v1.MigrationDrainingReason,
+				Message:            drainMessage(migration),
+				LastTransitionTime: metav1.NewTime(drainedAt),
+			}}
+		}
+
+		handOff := func(migration *v1.VirtualMachineInstanceMigration) {
+			vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{
+				MigrationUID:      migration.UID,
+				SourceNode:        "node02",
+				TargetNode:        "node01",
+				TargetNodeAddress: "10.10.10.10:1234",
+			}
+		}
+
+		It("should add the target pod to the endpoints and drain the source pod before the hand off", func() {
+			migration := newMigration("testmigration", vmi.Name, v1.MigrationScheduled)
+			targetPod := newReadyTargetPod(migration)
+			targetPod.Spec.ReadinessGates = []k8sv1.PodReadinessGate{{ConditionType: v1.VirtualMachineInstanceNotDraining}}
+			addMigration(migration)
+			addVirtualMachineInstance(vmi)
+			podInformer.GetStore().Add(sourcePod)
+			podFeeder.Add(targetPod)
+
+			expectNotDrainingConditionPatches([]*k8sv1.Pod{targetPod, sourcePod}, []k8sv1.ConditionStatus{k8sv1.ConditionTrue, k8sv1.ConditionFalse})
+
+			controller.Execute()
+			Expect(mockQueue.GetAddAfterEnqueueCount()).To(Equal(1))
+		})
+
+		It("should hold back the hand off while the connections to the source pod drain", func() {
+			migration := newMigration("testmigration", vmi.Name, v1.MigrationScheduled)
+			drainSourcePod(migration, time.Now().Add(-10*time.Second))
+			addMigration(migration)
+			addVirtualMachineInstance(vmi)
+			podInformer.GetStore().Add(sourcePod)
+			podFeeder.Add(newReadyTargetPod(migration))
+
+			kubeClient.Fake.PrependReactor("patch", "pods", func(action testing.Action) (handled bool, obj k8sruntime.Object, err error) {
+				Fail("the source pod should not be patched again")
+				return true, nil, nil
+			})
+
+			controller.Execute()
+			Expect(mockQueue.GetAddAfterEnqueueCount()).To(Equal(1))
+		})
+
+		It("should hand off the migration once the connections to the source pod drained", func() {
+			migration := newMigration("testmigration", vmi.Name, v1.MigrationScheduled)
+			drainSourcePod(migration, time.Now().Add(-31*time.Second))
+			targetPod := newReadyTargetPod(migration)
+			addMigration(migration)
+			addVirtualMachineInstance(vmi)
+			podInformer.GetStore().Add(sourcePod)
+			podFeeder.Add(targetPod)
+
+			patch := fmt.Sprintf(`[{ "op": "add", "path": "/status/migrationState", "value": {"targetNode":"node01","targetPod":"%s","sourceNode":"node02","migrationUid":"testmigration"} }, { "op": "test", "path": "/metadata/labels", "value": {} }, { "op": "replace", "path": "/metadata/labels", "value": {"kubevirt.io/migrationTargetNodeName":"node01"} }]`, targetPod.Name)
+			shouldExpectVirtualMachineInstancePatch(vmi, patch)
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder, SuccessfulHandOverPodReason)
+		})
+
+		It("should not drain the connections to the source pod again after the hand off", func() {
+			migration := newMigration("testmigration", vmi.Name, v1.MigrationTargetReady)
+			handOff(migration)
+			drainSourcePod(migration, time.Now())
+			addMigration(migration)
+			addVirtualMachineInstance(vmi)
+			podInformer.GetStore().Add(sourcePod)
+			podFeeder.Add(newReadyTargetPod(migration))
+
+			kubeClient.Fake.PrependReactor("patch", "pods", func(action testing.Action) (handled bool, obj k8sruntime.Object, err error) {
+				Fail("the source pod should not be patched again")
+				return true, nil, nil
+			})
+
+			controller.Execute()
+		})
+
+		It("should restore the connections to the source pod if the migration failed", func() {
+			migration := newMigration("testmigration", vmi.Name, v1.MigrationFailed)
+			drainSourcePod(migration, time.Now())
+			addMigration(migration)
+			addVirtualMachineInstance(vmi)
+			podInformer.GetStore().Add(sourcePod)
+
+			expectNotDrainingConditionPatch(k8sv1.ConditionTrue)
+			shouldExpectMigrationFinalizerRemoval(migration)
+
+			controller.Execute()
+		})
+
+		It("should restore the connections to the source pod if the migration is deleted", func() {
+			migration := newMigration("testmigration", vmi.Name, v1.MigrationTargetReady)
+			migration.DeletionTimestamp = now()
+			handOff(migration)
+			drainSourcePod(migration, time.Now())
+			addMigration(migration)
+			addVirtualMachineInstance(vmi)
+			podInformer.GetStore().Add(sourcePod)
+			podFeeder.Add(newReadyTargetPod(migration))
+
+			expectNotDrainingConditionPatch(k8sv1.ConditionTrue)
+			migrationInterface.EXPECT().UpdateStatus(gomock.Any()).DoAndReturn(func(arg *v1.VirtualMachineInstanceMigration) (*v1.VirtualMachineInstanceMigration, error) {
+				Expect(arg.Status.Conditions).To(HaveLen(1))
+				Expect(arg.Status.Conditions[0].Type).To(Equal(v1.VirtualMachineInstanceMigrationAbortRequested))
+				return arg, nil
+			})
+
+			vmiInterface.EXPECT().Patch(vmi.Name, types.JSONPatchType, gomock.Any()).Return(vmi, nil)
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder, SuccessfulAbortMigrationReason)
+		})
+	})
+
 	Context("Migration object in pending state", func() {
 		It("should create target pod", func() {
 			vmi := newVirtualMachine("testvmi", v1.Running)
@@ -638,9 +1070,12 @@ var _ = Describe("Migration watcher", func() {
 			podFeeder.Add(pod)
 
 			shouldExpectMigrationFailedState(migration)
+			// the failure is recorded in the migration backoff of the VMI
+			vmiInterface.EXPECT().Patch(vmi.Name, types.JSONPatchType, gomock.Any()).Return(vmi, nil)
 
 			controller.Execute()
 
+			testutils.ExpectEvent(recorder, MigrationBackoffReason)
 			testutils.ExpectEvent(recorder, FailedMigrationReason)
 		},
 			table.Entry("in running state", v1.MigrationRunning),
@@ -728,6 +1163,29 @@ var _ = Describe("Migration watcher", func() {
 			testutils.ExpectEvent(recorder, SuccessfulHandOverPodReason)
 		})
 
+		It("should hand the abort on projected timeout of the migration over to virt-handler", func() {
+			vmi := newVirtualMachine("testvmi", v1.Running)
+			vmi.Status.NodeName = "node02"
+			migration := newMigration("testmigration", vmi.Name, v1.MigrationScheduled)
+			migration.Spec.AbortOnProjectedTimeout = true
+
+			pod := newTargetPodForVirtualMachine(vmi, migration, k8sv1.PodRunning)
+			pod.Spec.NodeName = "node01"
+			pod.Status.ContainerStatuses = []k8sv1.ContainerStatus{{
+				Name: "compute", State: k8sv1.ContainerState{Running: &k8sv1.ContainerStateRunning{}},
+			}}
+
+			addMigration(migration)
+			addVirtualMachineInstance(vmi)
+			podFeeder.Add(pod)
+
+			patch := fmt.Sprintf(`[{ "op": "add", "path": "/status/migrationState", "value": {"targetNode":"node01","targetPod":"%s","sourceNode":"node02","migrationUid":"testmigration","abortOnProjectedTimeout":true} }, { "op": "test", "path": "/metadata/labels", "value": {} }, { "op": "replace", "path": "/metadata/labels", "value": {"kubevirt.io/migrationTargetNodeName":"node01"} }]`, pod.Name)
+			shouldExpectVirtualMachineInstancePatch(vmi, patch)
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder, SuccessfulHandOverPodReason)
+		})
+
 		It("should hand pod over to target virt-handler overriding previous state", func() {
 			vmi := newVirtualMachine("testvmi", v1.Running)
 			vmi.Status.NodeName = "node02"
@@ -899,6 +1357,217 @@ var _ = Describe("Migration watcher", func() {
 			controller.Execute()
 			testutils.ExpectEvent(recorder, SuccessfulAbortMigrationReason)
 		})
+		table.DescribeTable("should signal the abort to the source once a migration is cancelled", func(phase v1.VirtualMachineInstanceMigrationPhase) {
+			vmi := newVirtualMachine("testvmi", v1.Running)
+			vmi.Status.NodeName = "node02"
+			migration := newMigration("testmigration", vmi.Name, phase)
+			migration.Status.Conditions = append(migration.Status.Conditions, v1.VirtualMachineInstanceMigrationCondition{
+				Type:          v1.VirtualMachineInstanceMigrationAbortRequested,
+				Status:        k8sv1.ConditionTrue,
+				LastProbeTime: *now(),
+				Reason:        v1.MigrationCancelledReason,
+			})
+			pod := newTargetPodForVirtualMachine(vmi, migration, k8sv1.PodRunning)
+			pod.Spec.NodeName = "node01"
+			vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{
+				MigrationUID:      migration.UID,
+				TargetNode:        "node01",
+				SourceNode:        "node02",
+				TargetNodeAddress: "10.10.10.10:1234",
+			}
+			if phase == v1.MigrationRunning {
+				vmi.Status.MigrationState.StartTimestamp = now()
+			}
+			addMigration(migration)
+			addVirtualMachineInstance(vmi)
+			podFeeder.Add(pod)
+
+			vmiInterface.EXPECT().Patch(vmi.Name, types.JSONPatchType, gomock.Any()).Return(vmi, nil)
+			controller.Execute()
+			testutils.ExpectEvent(recorder, SuccessfulAbortMigrationReason)
+		},
+			table.Entry("in running state", v1.MigrationRunning),
+			table.Entry("in target ready state", v1.MigrationTargetReady),
+		)
+		table.DescribeTable("should fail a migration which is cancelled before the target is ready", func(phase v1.VirtualMachineInstanceMigrationPhase) {
+			vmi := newVirtualMachine("testvmi", v1.Running)
+			migration := newMigration("testmigration", vmi.Name, phase)
+			migration.Status.Conditions = append(migration.Status.Conditions, v1.VirtualMachineInstanceMigrationCondition{
+				Type:          v1.VirtualMachineInstanceMigrationAbortRequested,
+				Status:        k8sv1.ConditionTrue,
+				LastProbeTime: *now(),
+				Reason:        v1.MigrationCancelledReason,
+			})
+			vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{
+				MigrationUID: migration.UID,
+				TargetNode:   "node01",
+				SourceNode:   "node02",
+			}
+			pod := newTargetPodForVirtualMachine(vmi, migration, k8sv1.PodPending)
+			pod.Spec.NodeName = "node01"
+			addMigration(migration)
+			addVirtualMachineInstance(vmi)
+			podFeeder.Add(pod)
+
+			migrationInterface.EXPECT().UpdateStatus(gomock.Any()).DoAndReturn(func(arg *v1.VirtualMachineInstanceMigration) (*v1.VirtualMachineInstanceMigration, error) {
+				Expect(arg.Status.Phase).To(Equal(v1.MigrationFailed))
+				aborted := false
+				for _, condition := range arg.Status.Conditions {
+					if condition.Type == v1.VirtualMachineInstanceMigrationAborted {
+						Expect(condition.Reason).To(Equal(v1.MigrationCancelledReason))
+						aborted = true
+					}
+				}
+				Expect(aborted).To(BeTrue())
+				return arg, nil
+			})
+			controller.Execute()
+			testutils.ExpectEvent(recorder, SuccessfulAbortMigrationReason)
+		},
+			table.Entry("in pending state", v1.MigrationPending),
+			table.Entry("in scheduling state", v1.MigrationScheduling),
+			table.Entry("in preparing target state", v1.MigrationPreparingTarget),
+		)
+		It("should delete the target pod of an aborted migration", func() {
+			vmi := newVirtualMachine("testvmi", v1.Running)
+			vmi.Status.NodeName = "node02"
+			migration := newMigration("testmigration", vmi.Name, v1.MigrationFailed)
+			migration.Status.Conditions = append(migration.Status.Conditions, v1.VirtualMachineInstanceMigrationCondition{
+				Type:          v1.VirtualMachineInstanceMigrationAborted,
+				Status:        k8sv1.ConditionTrue,
+				LastProbeTime: *now(),
+				Reason:        v1.MigrationAbortedByPolicyReason,
+			})
+			pod := newTargetPodForVirtualMachine(vmi, migration, k8sv1.PodRunning)
+			pod.Spec.NodeName = "node01"
+			vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{
+				MigrationUID:   migration.UID,
+				TargetNode:     "node01",
+				SourceNode:     "node02",
+				StartTimestamp: now(),
+				EndTimestamp:   now(),
+				Completed:      true,
+				Failed:         true,
+				AbortStatus:    v1.MigrationAbortSucceeded,
+			}
+			vmi.Status.MigrationBackoff = &v1.VirtualMachineInstanceMigrationBackoff{
+				FailureCount:           1,
+				LastFailedMigrationUID: migration.UID,
+			}
+			addMigration(migration)
+			addVirtualMachineInstance(vmi)
+			podFeeder.Add(pod)
+
+			kubeClient.Fake.PrependReactor("delete", "pods", func(action testing.Action) (handled bool, obj k8sruntime.Object, err error) {
+				deletion, ok := action.(testing.DeleteAction)
+				Expect(ok).To(BeTrue())
+				Expect(deletion.GetName()).To(Equal(pod.Name))
+				return true, nil, nil
+			})
+			shouldExpectMigrationFinalizerRemoval(migration)
+			controller.Execute()
+			testutils.ExpectEvent(recorder, SuccessfulDeletePodReason)
+		})
+		Context("migration backoff", func() {
+			var vmi *v1.VirtualMachineInstance
+			var migration *v1.VirtualMachineInstanceMigration
+
+			BeforeEach(func() {
+				vmi = newVirtualMachine("testvmi", v1.Running)
+				vmi.Status.NodeName = "node02"
+				migration = newMigration("testmigration", vmi.Name, v1.MigrationFailed)
+				vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{
+					MigrationUID:   migration.UID,
+					TargetNode:     "node01",
+					SourceNode:     "node02",
+					StartTimestamp: now(),
+					EndTimestamp:   now(),
+					Completed:      true,
+					Failed:         true,
+				}
+			})
+
+			expectBackoffPatch := func(matcher gomegaTypes.GomegaMatcher) {
+				vmiInterface.EXPECT().Patch(vmi.Name, types.JSONPatchType, gomock.Any()).DoAndReturn(func(name string, _ types.PatchType, patch []byte) (*v1.VirtualMachineInstance, error) {
+					Expect(string(patch)).To(matcher)
+					return vmi, nil
+				})
+			}
+
+			It("should hold back automated migrations after a failure", func() {
+				addMigration(migration)
+				addVirtualMachineInstance(vmi)
+
+				expectBackoffPatch(And(
+					ContainSubstring(`{ "op": "add", "path": "/status/migrationBackoff"`),
+					ContainSubstring(`"failureCount":1`),
+					ContainSubstring(`"lastFailedMigrationUid":"testmigration"`),
+				))
+				shouldExpectMigrationFinalizerRemoval(migration)
+				controller.Execute()
+				testutils.ExpectEvent(recorder, MigrationBackoffReason)
+			})
+
+			It("should extend the backoff on consecutive failures", func() {
+				lastFailure := metav1.NewTime(time.Now().Add(-time.Hour))
+				vmi.Status.MigrationBackoff = &v1.VirtualMachineInstanceMigrationBackoff{
+					FailureCount:           2,
+					LastFailedMigrationUID: "previousmigration",
+					LastFailureTimestamp:   &lastFailure,
+					NextAttemptTimestamp:   &lastFailure,
+				}
+				addMigration(migration)
+				addVirtualMachineInstance(vmi)
+
+				expectBackoffPatch(And(
+					ContainSubstring(`{ "op": "test", "path": "/status/migrationBackoff"`),
+					ContainSubstring(`"failureCount":3`),
+				))
+				shouldExpectMigrationFinalizerRemoval(migration)
+				controller.Execute()
+				testutils.ExpectEvent(recorder, MigrationBackoffReason)
+			})
+
+			It("should not count a failure twice", func() {
+				nextAttempt := metav1.NewTime(time.Now().Add(time.Minute))
+				vmi.Status.MigrationBackoff = &v1.VirtualMachineInstanceMigrationBackoff{
+					FailureCount:           1,
+					LastFailedMigrationUID: migration.UID,
+					NextAttemptTimestamp:   &nextAttempt,
+				}
+				addMigration(migration)
+				addVirtualMachineInstance(vmi)
+
+				shouldExpectMigrationFinalizerRemoval(migration)
+				controller.Execute()
+			})
+
+			It("should not count cancelled migrations", func() {
+				vmi.Status.MigrationState.AbortRequested = true
+				vmi.Status.MigrationState.AbortStatus = v1.MigrationAbortSucceeded
+				addMigration(migration)
+				addVirtualMachineInstance(vmi)
+
+				shouldExpectMigrationFinalizerRemoval(migration)
+				controller.Execute()
+			})
+
+			It("should clear the backoff once a migration succeeded", func() {
+				migration.Status.Phase = v1.MigrationSucceeded
+				vmi.Status.MigrationState.Failed = false
+				nextAttempt := metav1.NewTime(time.Now().Add(time.Minute))
+				vmi.Status.MigrationBackoff = &v1.VirtualMachineInstanceMigrationBackoff{
+					FailureCount:         1,
+					NextAttemptTimestamp: &nextAttempt,
+				}
+				addMigration(migration)
+				addVirtualMachineInstance(vmi)
+
+				expectBackoffPatch(ContainSubstring(`{ "op": "remove", "path": "/status/migrationBackoff" }`))
+				shouldExpectMigrationFinalizerRemoval(migration)
+				controller.Execute()
+			})
+		})
 		table.DescribeTable("should finalize migration on VMI if target pod fails before migration starts", func(phase v1.VirtualMachineInstanceMigrationPhase, hasPod bool, podPhase k8sv1.PodPhase, initializeMigrationState bool) {
 			vmi := newVirtualMachine("testvmi", v1.Running)
 			vmi.Status.NodeName = "node02"
@@ -22,6 +22,9 @@ package watch
 import (
 	"fmt"
 	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -218,9 +221,14 @@ func (c *VMIReplicaSet) execute(key string) error {
 
 	// Scale up or down, if all expected creates and deletes were report by the listener
 	if needsSync && !rs.Spec.Paused && rs.ObjectMeta.DeletionTimestamp == nil {
-		scaleErr = c.scale(rs, activeVmis)
-		if len(finishedVmis) > 0 && scaleErr == nil {
+		if rs.Spec.ServiceName != "" && len(finishedVmis) > 0 {
+			// The names of the replicas are stable, the finished replicas have to be gone before they can be replaced
 			scaleErr = c.cleanFinishedVmis(rs, finishedVmis)
+		} else {
+			scaleErr = c.scale(rs, activeVmis, vmis)
+			if len(finishedVmis) > 0 && scaleErr == nil {
+				scaleErr = c.cleanFinishedVmis(rs, finishedVmis)
+			}
 		}
 	}
 
@@ -236,7 +244,9 @@ func (c *VMIReplicaSet) execute(key string) error {
 	return scaleErr
 }
 
-func (c *VMIReplicaSet) scale(rs *virtv1.VirtualMachineInstanceReplicaSet, vmis []*virtv1.VirtualMachineInstance) error {
+// scale creates or deletes active VMIs until the replica count matches. New replicas never get the
+// ordinal name of a claimed VMI, not even of a terminating one, since that name is still in use
+func (c *VMIReplicaSet) scale(rs *virtv1.VirtualMachineInstanceReplicaSet, vmis []*virtv1.VirtualMachineInstance, claimedVmis []*virtv1.VirtualMachineInstance) error {
 	log.Log.V(4).Object(rs).Info("Scale")
 	diff := c.calcDiff(rs, vmis)
 
@@ -263,6 +273,10 @@ func (c *VMIReplicaSet) scale(rs *virtv1.VirtualMachineInstanceReplicaSet, vmis
 		log.Log.V(4).Object(rs).Info("Delete excess VM's")
 		// We have to delete VMIs, use a very simple selection strategy for now
 		// TODO: Possible deletion order: not yet running VMIs < migrating VMIs < other
+		if rs.Spec.ServiceName != "" {
+			// like a StatefulSet, scale down the replicas with the highest ordinals first
+			vmis = sortByOrdinalDescending(rs, vmis)
+		}
 		deleteCandidates := vmis[0:diff]
 		c.expectations.ExpectDeletions(rsKey, controller.VirtualMachineInstanceKeys(deleteCandidates))
 		for i := 0; i < diff; i++ {
@@ -287,14 +301,26 @@ func (c *VMIReplicaSet) scale(rs *virtv1.VirtualMachineInstanceReplicaSet, vmis
 		// We have to create VMIs
 		c.expectations.ExpectCreations(rsKey, abs(diff))
 		basename := c.getVirtualMachineBaseName(rs)
+		var names []string
+		if rs.Spec.ServiceName != "" {
+			names = freeOrdinalNames(rs, claimedVmis, abs(diff))
+		}
 		for i := diff; i < 0; i++ {
-			go func() {
+			go func(idx int) {
 				defer wg.Done()
 				vmi := virtv1.NewVMIReferenceFromNameWithNS(rs.ObjectMeta.Namespace, "")
 				vmi.ObjectMeta = rs.Spec.Template.ObjectMeta
 				vmi.ObjectMeta.Name = ""
 				vmi.ObjectMeta.GenerateName = basename
 				vmi.Spec = rs.Spec.Template.Spec
+				vmi.Spec.TopologySpreadConstraints = getTopologySpreadConstraints(rs)
+				if rs.Spec.ServiceName != "" {
+					// the hostname defaults to the VMI name, the ordinal name keeps it resolvable
+					// within the service under the same name when the replica gets replaced
+					vmi.ObjectMeta.Name = names[idx]
+					vmi.ObjectMeta.GenerateName = ""
+					vmi.Spec.Subdomain = rs.Spec.ServiceName
+				}
 				// TODO check if vmi labels exist, and when make sure that they match. For now just override them
 				vmi.ObjectMeta.Labels = rs.Spec.Template.ObjectMeta.Labels
 				vmi.ObjectMeta.OwnerReferences = []metav1.OwnerReference{OwnerRef(rs)}
@@ -306,7 +332,7 @@ func (c *VMIReplicaSet) scale(rs *virtv1.VirtualMachineInstanceReplicaSet, vmis
 					return
 				}
 				c.recorder.Eventf(rs, k8score.EventTypeNormal, SuccessfulCreateVirtualMachineReason, "Started the virtual machine by creating the new virtual machine instance %v", vmi.ObjectMeta.Name)
-			}()
+			}(i - diff)
 		}
 	}
 	wg.Wait()
@@ -320,6 +346,91 @@ func (c *VMIReplicaSet) scale(rs *virtv1.VirtualMachineInstanceReplicaSet, vmis
 	return nil
 }
 
+// ordinalName returns the name of the replica with the given ordinal of a replica set with a governing service
+func ordinalName(rs *virtv1.VirtualMachineInstanceReplicaSet, ordinal int) string {
+	return fmt.Sprintf("%s-%d", rs.ObjectMeta.Name, ordinal)
+}
+
+// getOrdinal returns the ordinal of a replica named by ordinalName, or -1 if the name has no ordinal
+func getOrdinal(rs *virtv1.VirtualMachineInstanceReplicaSet, vmi *virtv1.VirtualMachineInstance) int {
+	suffix := strings.TrimPrefix(vmi.ObjectMeta.Name, rs.ObjectMeta.Name+"-")
+	if suffix == vmi.ObjectMeta.Name {
+		return -1
+	}
+	ordinal, err := strconv.Atoi(suffix)
+	if err != nil || ordinal < 0 || ordinalName(rs, ordinal) != vmi.ObjectMeta.Name {
+		return -1
+	}
+	return ordinal
+}
+
+// freeOrdinalNames returns the names of the count lowest ordinals which are not taken by the given replicas
+func freeOrdinalNames(rs *virtv1.VirtualMachineInstanceReplicaSet, vmis []*virtv1.VirtualMachineInstance, count int) []string {
+	taken := map[string]bool{}
+	for _, vmi := range vmis {
+		taken[vmi.ObjectMeta.Name] = true
+	}
+	var names []string
+	for ordinal := 0; len(names) < count; ordinal++ {
+		if name := ordinalName(rs, ordinal); !taken[name] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// sortByOrdinalDescending returns a copy of the replicas, ordered by their ordinals from the highest to the lowest
+func sortByOrdinalDescending(rs *virtv1.VirtualMachineInstanceReplicaSet, vmis []*virtv1.VirtualMachineInstance) []*virtv1.VirtualMachineInstance {
+	sorted := append([]*virtv1.VirtualMachineInstance{}, vmis...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return getOrdinal(rs, sorted[i]) > getOrdinal(rs, sorted[j])
+	})
+	return sorted
+}
+
+// getTopologySpreadConstraints returns the topology spread constraints of the template, extended by
+// the ones requested by the replica set for topology keys not already covered by the template
+func getTopologySpreadConstraints(rs *virtv1.VirtualMachineInstanceReplicaSet) []k8score.TopologySpreadConstraint {
+	constraints := rs.Spec.Template.Spec.TopologySpreadConstraints
+	spread := rs.Spec.TopologySpread
+	if spread == nil {
+		return constraints
+	}
+
+	topologyKeys := spread.TopologyKeys
+	if len(topologyKeys) == 0 {
+		topologyKeys = []string{k8score.LabelTopologyZone}
+	}
+	maxSkew := int32(1)
+	if spread.MaxSkew != nil {
+		maxSkew = *spread.MaxSkew
+	}
+	whenUnsatisfiable := spread.WhenUnsatisfiable
+	if whenUnsatisfiable == "" {
+		whenUnsatisfiable = k8score.ScheduleAnyway
+	}
+
+	covered := map[string]bool{}
+	for _, constraint := range constraints {
+		covered[constraint.TopologyKey] = true
+	}
+
+	// copy the constraints to not modify the template in the cache
+	injected := append([]k8score.TopologySpreadConstraint{}, constraints...)
+	for _, topologyKey := range topologyKeys {
+		if covered[topologyKey] {
+			continue
+		}
+		injected = append(injected, k8score.TopologySpreadConstraint{
+			MaxSkew:           maxSkew,
+			TopologyKey:       topologyKey,
+			WhenUnsatisfiable: whenUnsatisfiable,
+			LabelSelector:     rs.Spec.Selector.DeepCopy(),
+		})
+	}
+	return injected
+}
+
 // filterActiveVMIs takes a list of VMIs and returns all VMIs which are not in a final state, not terminating and not unknown
 func (c *VMIReplicaSet) filterActiveVMIs(vmis []*virtv1.VirtualMachineInstance) []*virtv1.VirtualMachineInstance {
 	return filter(vmis, func(vmi *virtv1.VirtualMachineInstance) bool {
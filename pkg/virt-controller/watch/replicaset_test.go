@@ -2,6 +2,7 @@ package watch
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
@@ -98,6 +99,127 @@ var _ = Describe("Replicaset", func() {
 			testutils.ExpectEvent(recorder, SuccessfulCreateVirtualMachineReason)
 		})
 
+		It("should spread created VMIs across zones if requested", func() {
+			rs, vmi := DefaultReplicaSet(1)
+			rs.Spec.TopologySpread = &v1.VirtualMachineInstanceReplicaSetTopologySpread{}
+
+			addReplicaSet(rs)
+
+			vmiInterface.EXPECT().Create(gomock.Any()).Do(func(arg interface{}) {
+				Expect(arg.(*v1.VirtualMachineInstance).Spec.TopologySpreadConstraints).To(Equal([]k8sv1.TopologySpreadConstraint{
+					{
+						MaxSkew:           1,
+						TopologyKey:       k8sv1.LabelTopologyZone,
+						WhenUnsatisfiable: k8sv1.ScheduleAnyway,
+						LabelSelector:     rs.Spec.Selector,
+					},
+				}))
+			}).Return(vmi, nil)
+
+			controller.Execute()
+
+			testutils.ExpectEvent(recorder, SuccessfulCreateVirtualMachineReason)
+		})
+
+		It("should place created VMIs in the subdomain of the governing service", func() {
+			rs, vmi := DefaultReplicaSet(1)
+			rs.Spec.ServiceName = "cluster"
+
+			addReplicaSet(rs)
+
+			vmiInterface.EXPECT().Create(gomock.Any()).Do(func(arg interface{}) {
+				Expect(arg.(*v1.VirtualMachineInstance).Spec.Subdomain).To(Equal("cluster"))
+				Expect(arg.(*v1.VirtualMachineInstance).Spec.Hostname).To(BeEmpty())
+			}).Return(vmi, nil)
+
+			controller.Execute()
+
+			testutils.ExpectEvent(recorder, SuccessfulCreateVirtualMachineReason)
+		})
+
+		It("should create VMIs with the free lowest ordinal names if there is a governing service", func() {
+			rs, vmi := DefaultReplicaSet(3)
+			rs.Spec.ServiceName = "cluster"
+			vmi.ObjectMeta.Name = "rs-1"
+
+			addReplicaSet(rs)
+			vmiFeeder.Add(vmi)
+
+			var names []string
+			var lock sync.Mutex
+			vmiInterface.EXPECT().Create(gomock.Any()).Times(2).Do(func(arg interface{}) {
+				Expect(arg.(*v1.VirtualMachineInstance).ObjectMeta.GenerateName).To(BeEmpty())
+				lock.Lock()
+				defer lock.Unlock()
+				names = append(names, arg.(*v1.VirtualMachineInstance).ObjectMeta.Name)
+			}).Return(vmi, nil)
+			rsInterface.EXPECT().UpdateStatus(gomock.Any())
+
+			controller.Execute()
+
+			Expect(names).To(ConsistOf("rs-0", "rs-2"))
+			testutils.ExpectEvent(recorder, SuccessfulCreateVirtualMachineReason)
+			testutils.ExpectEvent(recorder, SuccessfulCreateVirtualMachineReason)
+		})
+
+		It("should not reuse the ordinal names of terminating VMIs if there is a governing service", func() {
+			rs, vmi := DefaultReplicaSet(1)
+			rs.Spec.ServiceName = "cluster"
+			vmi.ObjectMeta.Name = "rs-0"
+			vmi.ObjectMeta.DeletionTimestamp = now()
+
+			addReplicaSet(rs)
+			vmiFeeder.Add(vmi)
+
+			vmiInterface.EXPECT().Create(gomock.Any()).Do(func(arg interface{}) {
+				Expect(arg.(*v1.VirtualMachineInstance).ObjectMeta.Name).To(Equal("rs-1"))
+			}).Return(vmi, nil)
+
+			controller.Execute()
+
+			testutils.ExpectEvent(recorder, SuccessfulCreateVirtualMachineReason)
+		})
+
+		It("should delete the VMIs with the highest ordinals first if there is a governing service", func() {
+			rs, vmi := DefaultReplicaSet(1)
+			rs.Spec.ServiceName = "cluster"
+			rs.Status.Replicas = 3
+
+			addReplicaSet(rs)
+			for _, name := range []string{"rs-2", "rs-0", "rs-10"} {
+				replica := vmi.DeepCopy()
+				replica.ObjectMeta.Name = name
+				vmiFeeder.Add(replica)
+			}
+
+			vmiInterface.EXPECT().Delete("rs-10", gomock.Any())
+			vmiInterface.EXPECT().Delete("rs-2", gomock.Any())
+
+			controller.Execute()
+
+			testutils.ExpectEvent(recorder, SuccessfulDeleteVirtualMachineReason)
+			testutils.ExpectEvent(recorder, SuccessfulDeleteVirtualMachineReason)
+		})
+
+		It("should only replace finished VMIs once they are gone if there is a governing service", func() {
+			rs, vmi := DefaultReplicaSet(1)
+			rs.Spec.ServiceName = "cluster"
+			rs.Status.Replicas = 1
+			vmi.ObjectMeta.Name = "rs-0"
+			vmi.Status.Phase = v1.Failed
+
+			addReplicaSet(rs)
+			vmiFeeder.Add(vmi)
+
+			// the failed replica holds the name, no VMI is created until it is gone
+			vmiInterface.EXPECT().Delete("rs-0", gomock.Any()).Return(nil)
+			rsInterface.EXPECT().UpdateStatus(gomock.Any())
+
+			controller.Execute()
+
+			testutils.ExpectEvent(recorder, SuccessfulDeleteVirtualMachineReason)
+		})
+
 		It("should create missing VMIs when it gets unpaused", func() {
 			rs, vmi := DefaultReplicaSet(3)
 			rs.Spec.Paused = false
@@ -702,6 +824,56 @@ var _ = Describe("Replicaset", func() {
 	})
 })
 
+var _ = Describe("Replicaset topology spread", func() {
+
+	int32Ptr := func(i int32) *int32 {
+		return &i
+	}
+
+	templateConstraint := k8sv1.TopologySpreadConstraint{
+		MaxSkew:           2,
+		TopologyKey:       k8sv1.LabelHostname,
+		WhenUnsatisfiable: k8sv1.DoNotSchedule,
+	}
+
+	table.DescribeTable("should inject constraints", func(templateConstraints []k8sv1.TopologySpreadConstraint, spread *v1.VirtualMachineInstanceReplicaSetTopologySpread, expectedKeys []string) {
+		rs, _ := DefaultReplicaSet(1)
+		rs.Spec.Template.Spec.TopologySpreadConstraints = templateConstraints
+		rs.Spec.TopologySpread = spread
+
+		constraints := getTopologySpreadConstraints(rs)
+
+		var keys []string
+		for _, constraint := range constraints {
+			keys = append(keys, constraint.TopologyKey)
+		}
+		Expect(keys).To(Equal(expectedKeys))
+		Expect(rs.Spec.Template.Spec.TopologySpreadConstraints).To(Equal(templateConstraints))
+	},
+		table.Entry("not without topology spread", []k8sv1.TopologySpreadConstraint{templateConstraint}, nil, []string{k8sv1.LabelHostname}),
+		table.Entry("across zones by default", nil, &v1.VirtualMachineInstanceReplicaSetTopologySpread{}, []string{k8sv1.LabelTopologyZone}),
+		table.Entry("for the requested topology keys", nil,
+			&v1.VirtualMachineInstanceReplicaSetTopologySpread{TopologyKeys: []string{k8sv1.LabelHostname, k8sv1.LabelTopologyZone}},
+			[]string{k8sv1.LabelHostname, k8sv1.LabelTopologyZone}),
+		table.Entry("only for topology keys not covered by the template", []k8sv1.TopologySpreadConstraint{templateConstraint},
+			&v1.VirtualMachineInstanceReplicaSetTopologySpread{TopologyKeys: []string{k8sv1.LabelHostname, k8sv1.LabelTopologyZone}},
+			[]string{k8sv1.LabelHostname, k8sv1.LabelTopologyZone}),
+	)
+
+	It("should apply the skew and unsatisfiable action", func() {
+		rs, _ := DefaultReplicaSet(1)
+		rs.Spec.TopologySpread = &v1.VirtualMachineInstanceReplicaSetTopologySpread{
+			MaxSkew:           int32Ptr(3),
+			WhenUnsatisfiable: k8sv1.DoNotSchedule,
+		}
+
+		constraints := getTopologySpreadConstraints(rs)
+		Expect(constraints).To(HaveLen(1))
+		Expect(constraints[0].MaxSkew).To(Equal(int32(3)))
+		Expect(constraints[0].WhenUnsatisfiable).To(Equal(k8sv1.DoNotSchedule))
+	})
+})
+
 func ReplicaSetFromVMI(name string, vmi *v1.VirtualMachineInstance, replicas int32) *v1.VirtualMachineInstanceReplicaSet {
 	s, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{
 		MatchLabels: vmi.ObjectMeta.Labels,
@@ -22,11 +22,13 @@ package snapshot
 import (
 	"context"
 	"fmt"
+	"net"
 	"reflect"
 	"strings"
 	"time"
 
 	vsv1beta1 "github.com/kubernetes-csi/external-snapshotter/v2/pkg/apis/volumesnapshot/v1beta1"
+	"github.com/pborman/uuid"
 	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -35,7 +37,6 @@ import (
 	kubevirtv1 "kubevirt.io/client-go/api/v1"
 	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
 	"kubevirt.io/client-go/log"
-	"kubevirt.io/kubevirt/pkg/controller"
 )
 
 const (
@@ -80,6 +81,25 @@ func restoreDVName(vmRestore *snapshotv1.VirtualMachineRestore, name string) str
 	return restorePVCName(vmRestore, name)
 }
 
+func vmRestoreID(vmRestore *snapshotv1.VirtualMachineRestore) string {
+	return fmt.Sprintf("%s-%s", vmRestore.Name, vmRestore.UID)
+}
+
+func restoreStorageClassName(vmRestore *snapshotv1.VirtualMachineRestore, storageClassName *string) *string {
+	if storageClassName == nil {
+		return nil
+	}
+
+	for _, m := range vmRestore.Spec.StorageClassMappings {
+		if m.Source == *storageClassName {
+			target := m.Target
+			return &target
+		}
+	}
+
+	return storageClassName
+}
+
 func vmRestoreProgressing(vmRestore *snapshotv1.VirtualMachineRestore) bool {
 	return vmRestore.Status == nil || vmRestore.Status.Complete == nil || !*vmRestore.Status.Complete
 }
@@ -114,9 +134,12 @@ func (ctrl *VMRestoreController) updateVMRestore(vmRestoreIn *snapshotv1.Virtual
 	}
 
 	if len(vmRestoreOut.OwnerReferences) == 0 {
+		// a new VirtualMachine only takes ownership once it has been created
 		target.Own(vmRestoreOut)
-		updateRestoreCondition(vmRestoreOut, newProgressingCondition(corev1.ConditionTrue, "Initializing VirtualMachineRestore"))
-		updateRestoreCondition(vmRestoreOut, newReadyCondition(corev1.ConditionFalse, "Initializing VirtualMachineRestore"))
+		if len(vmRestoreOut.Status.Conditions) == 0 {
+			updateRestoreCondition(vmRestoreOut, newProgressingCondition(corev1.ConditionTrue, "Initializing VirtualMachineRestore"))
+			updateRestoreCondition(vmRestoreOut, newReadyCondition(corev1.ConditionFalse, "Initializing VirtualMachineRestore"))
+		}
 	}
 
 	err = target.UpdateRestoreInProgress()
@@ -309,12 +332,18 @@ func (ctrl *VMRestoreController) getBindingMode(pvc *corev1.PersistentVolumeClai
 	return sc.VolumeBindingMode, nil
 }
 
+// UID is empty when the VirtualMachine is created by the restore,
+// as it will never match the snapshot source
 func (t *vmRestoreTarget) UID() types.UID {
+	if t.vm == nil || t.vm.Annotations[lastRestoreAnnotation] == vmRestoreID(t.vmRestore) {
+		return ""
+	}
+
 	return t.vm.UID
 }
 
 func (t *vmRestoreTarget) UpdateDoneRestore() (bool, error) {
-	if t.vm.Status.RestoreInProgress == nil || *t.vm.Status.RestoreInProgress != t.vmRestore.Name {
+	if t.vm == nil || t.vm.Status.RestoreInProgress == nil || *t.vm.Status.RestoreInProgress != t.vmRestore.Name {
 		return false, nil
 	}
 
@@ -325,6 +354,10 @@ func (t *vmRestoreTarget) UpdateDoneRestore() (bool, error) {
 }
 
 func (t *vmRestoreTarget) UpdateRestoreInProgress() error {
+	if t.vm == nil {
+		return nil
+	}
+
 	if t.vm.Status.RestoreInProgress != nil && *t.vm.Status.RestoreInProgress != t.vmRestore.Name {
 		return fmt.Errorf("vm restore %s in progress", *t.vm.Status.RestoreInProgress)
 	}
@@ -345,18 +378,17 @@ func (t *vmRestoreTarget) UpdateRestoreInProgress() error {
 func (t *vmRestoreTarget) Ready() (bool, error) {
 	log.Log.Object(t.vmRestore).V(3).Info("Checking VM ready")
 
-	rs, err := t.vm.RunStrategy()
-	if err != nil {
-		return false, err
-	}
+	vmiKey := cacheKeyFunc(t.vmRestore.Namespace, t.vmRestore.Spec.Target.Name)
 
-	if rs != kubevirtv1.RunStrategyHalted {
-		return false, fmt.Errorf("invalid RunStrategy %q", rs)
-	}
+	if t.vm != nil {
+		rs, err := t.vm.RunStrategy()
+		if err != nil {
+			return false, err
+		}
 
-	vmiKey, err := controller.KeyFunc(t.vm)
-	if err != nil {
-		return false, err
+		if rs != kubevirtv1.RunStrategyHalted {
+			return false, fmt.Errorf("invalid RunStrategy %q", rs)
+		}
 	}
 
 	_, exists, err := t.controller.VMIInformer.GetStore().GetByKey(vmiKey)
@@ -370,10 +402,12 @@ func (t *vmRestoreTarget) Ready() (bool, error) {
 func (t *vmRestoreTarget) Reconcile() (bool, error) {
 	log.Log.Object(t.vmRestore).V(3).Info("Reconciling VM")
 
-	restoreID := fmt.Sprintf("%s-%s", t.vmRestore.Name, t.vmRestore.UID)
+	restoreID := vmRestoreID(t.vmRestore)
 
-	if lastRestoreID, ok := t.vm.Annotations[lastRestoreAnnotation]; ok && lastRestoreID == restoreID {
-		return false, nil
+	if t.vm != nil {
+		if lastRestoreID, ok := t.vm.Annotations[lastRestoreAnnotation]; ok && lastRestoreID == restoreID {
+			return false, nil
+		}
 	}
 
 	content, err := t.controller.getSnapshotContent(t.vmRestore, t.UID())
@@ -449,6 +483,9 @@ func (t *vmRestoreTarget) Reconcile() (bool, error) {
 
 						dv := snapshotVM.Spec.DataVolumeTemplates[templateIndex].DeepCopy()
 						dv.Name = *vr.DataVolumeName
+						if dv.Spec.PVC != nil {
+							dv.Spec.PVC.StorageClassName = restoreStorageClassName(t.vmRestore, dv.Spec.PVC.StorageClassName)
+						}
 						newTemplates[templateIndex] = *dv
 
 						nv := v.DeepCopy()
@@ -479,16 +516,18 @@ func (t *vmRestoreTarget) Reconcile() (bool, error) {
 
 	if updatedStatus {
 		// find DataVolumes that will no longer exist
-		for _, cdv := range t.vm.Spec.DataVolumeTemplates {
-			found := false
-			for _, ndv := range newTemplates {
-				if cdv.Name == ndv.Name {
-					found = true
-					break
+		if t.vm != nil {
+			for _, cdv := range t.vm.Spec.DataVolumeTemplates {
+				found := false
+				for _, ndv := range newTemplates {
+					if cdv.Name == ndv.Name {
+						found = true
+						break
+					}
+				}
+				if !found {
+					deletedDataVolumes = append(deletedDataVolumes, cdv.Name)
 				}
-			}
-			if !found {
-				deletedDataVolumes = append(deletedDataVolumes, cdv.Name)
 			}
 		}
 		t.vmRestore.Status.DeletedDataVolumes = deletedDataVolumes
@@ -496,19 +535,42 @@ func (t *vmRestoreTarget) Reconcile() (bool, error) {
 		return true, nil
 	}
 
-	newVM := t.vm.DeepCopy()
+	var newVM *kubevirtv1.VirtualMachine
+	if t.vm != nil {
+		newVM = t.vm.DeepCopy()
+	} else {
+		newVM = &kubevirtv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      t.vmRestore.Spec.Target.Name,
+				Namespace: t.vmRestore.Namespace,
+				Labels:    snapshotVM.Labels,
+			},
+		}
+	}
 	newVM.Spec = snapshotVM.Spec
 	// update Running state in case snapshot was on online VM
 	running := false
 	newVM.Spec.Running = &running
 	newVM.Spec.DataVolumeTemplates = newTemplates
 	newVM.Spec.Template.Spec.Volumes = newVolumes
+	if err = t.restoreIdentity(&newVM.Spec, newVM.Name != snapshotVM.Name); err != nil {
+		return false, err
+	}
 	if newVM.Annotations == nil {
 		newVM.Annotations = make(map[string]string)
 	}
 	newVM.Annotations[lastRestoreAnnotation] = restoreID
+	if claimName := t.restoreMemoryClaim(content); claimName != "" {
+		newVM.Annotations[kubevirtv1.RestoreMemoryClaimAnnotation] = claimName
+	} else {
+		delete(newVM.Annotations, kubevirtv1.RestoreMemoryClaimAnnotation)
+	}
 
-	_, err = t.controller.Client.VirtualMachine(newVM.Namespace).Update(newVM)
+	if t.vm != nil {
+		_, err = t.controller.Client.VirtualMachine(newVM.Namespace).Update(newVM)
+	} else {
+		_, err = t.controller.Client.VirtualMachine(newVM.Namespace).Create(newVM)
+	}
 	if err != nil {
 		return false, err
 	}
@@ -516,7 +578,72 @@ func (t *vmRestoreTarget) Reconcile() (bool, error) {
 	return true, nil
 }
 
+// restoreMemoryClaim returns the PersistentVolumeClaim holding the memory state of the snapshot, which the
+// VirtualMachine resumes from on its next start. Only a VirtualMachine restored in place with the identity
+// of the snapshot resumes, others boot from the restored volumes.
+func (t *vmRestoreTarget) restoreMemoryClaim(content *snapshotv1.VirtualMachineSnapshotContent) string {
+	memoryBackup := content.Spec.MemoryBackup
+	if t.vm == nil || t.vmRestore.Spec.Identity != nil || memoryBackup == nil ||
+		memoryBackup.PersistentVolumeClaim == nil || !memorySnapshotTaken(content) {
+		return ""
+	}
+
+	return memoryBackup.PersistentVolumeClaim.Name
+}
+
+// restoreIdentity regenerates the explicitly set identifying values of the restored VirtualMachine
+// which the restore does not preserve. A VirtualMachine other than the snapshotted one regenerates
+// them unless they are explicitly preserved, so that both don't share the same identity.
+func (t *vmRestoreTarget) restoreIdentity(spec *kubevirtv1.VirtualMachineSpec, otherVM bool) error {
+	if spec.Template == nil {
+		return nil
+	}
+
+	identity := snapshotv1.RestoreIdentity{}
+	if t.vmRestore.Spec.Identity != nil {
+		identity = *t.vmRestore.Spec.Identity
+	}
+	regenerate := func(policy snapshotv1.IdentityPolicy) bool {
+		return policy == snapshotv1.IdentityRegenerate || (otherVM && policy == "")
+	}
+
+	if regenerate(identity.MACAddresses) {
+		usedMACs := t.controller.usedMACAddresses()
+		interfaces := spec.Template.Spec.Domain.Devices.Interfaces
+		for i := range interfaces {
+			if interfaces[i].MacAddress == "" {
+				continue
+			}
+
+			mac, err := generateMACAddress(usedMACs)
+			if err != nil {
+				return err
+			}
+			interfaces[i].MacAddress = mac
+		}
+	}
+
+	firmware := spec.Template.Spec.Domain.Firmware
+	if firmware == nil {
+		return nil
+	}
+
+	if regenerate(identity.FirmwareUUID) && firmware.UUID != "" {
+		firmware.UUID = types.UID(uuid.NewRandom().String())
+	}
+
+	if regenerate(identity.FirmwareSerial) && firmware.Serial != "" {
+		firmware.Serial = uuid.NewRandom().String()
+	}
+
+	return nil
+}
+
 func (t *vmRestoreTarget) Own(obj metav1.Object) {
+	if t.vm == nil {
+		return
+	}
+
 	b := true
 	obj.SetOwnerReferences([]metav1.OwnerReference{
 		{
@@ -566,7 +693,7 @@ func (ctrl *VMRestoreController) getSnapshotContent(vmRestore *snapshotv1.Virtua
 		return nil, fmt.Errorf("VMSnapshot %s not ready", objKey)
 	}
 
-	if vms.Status.SourceUID == nil || *vms.Status.SourceUID != targetUID {
+	if targetUID != "" && (vms.Status.SourceUID == nil || *vms.Status.SourceUID != targetUID) {
 		return nil, fmt.Errorf("VMSnapshot source and restore target differ")
 	}
 
@@ -592,6 +719,25 @@ func (ctrl *VMRestoreController) getSnapshotContent(vmRestore *snapshotv1.Virtua
 	return vmss, nil
 }
 
+// usedMACAddresses returns the MAC addresses explicitly set on the VirtualMachines of the cluster
+func (ctrl *VMRestoreController) usedMACAddresses() map[string]bool {
+	usedMACs := make(map[string]bool)
+	for _, obj := range ctrl.VMInformer.GetStore().List() {
+		vm := obj.(*kubevirtv1.VirtualMachine)
+		if vm.Spec.Template == nil {
+			continue
+		}
+
+		for _, iface := range vm.Spec.Template.Spec.Domain.Devices.Interfaces {
+			if mac, err := net.ParseMAC(iface.MacAddress); err == nil {
+				usedMACs[mac.String()] = true
+			}
+		}
+	}
+
+	return usedMACs
+}
+
 func (ctrl *VMRestoreController) getVM(namespace, name string) (*kubevirtv1.VirtualMachine, error) {
 	objKey := cacheKeyFunc(namespace, name)
 	obj, exists, err := ctrl.VMInformer.GetStore().GetByKey(objKey)
@@ -600,7 +746,7 @@ func (ctrl *VMRestoreController) getVM(namespace, name string) (*kubevirtv1.Virt
 	}
 
 	if !exists {
-		return nil, fmt.Errorf("VirtualMachine %s/%s does not exist", namespace, name)
+		return nil, nil
 	}
 
 	return obj.(*kubevirtv1.VirtualMachine).DeepCopy(), nil
@@ -654,6 +800,7 @@ func (ctrl *VMRestoreController) createRestorePVC(
 		},
 		Spec: sourcePVC.Spec,
 	}
+	pvc.Spec.StorageClassName = restoreStorageClassName(vmRestore, pvc.Spec.StorageClassName)
 
 	if volumeBackup.VolumeSnapshotName == nil {
 		log.Log.Errorf("VolumeSnapshot name missing %+v", volumeBackup)
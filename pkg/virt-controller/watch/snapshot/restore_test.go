@@ -2,6 +2,7 @@ package snapshot
 
 import (
 	"context"
+	"net"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
@@ -445,6 +446,36 @@ var _ = Describe("Restore controlleer", func() {
 				controller.processVMRestoreWorkItem()
 			})
 
+			It("should create restore PVCs with mapped storage class", func() {
+				r := createRestoreWithOwner()
+				vm := createModifiedVM()
+				sourceStorageClassName := *createSnapshotVM().Spec.DataVolumeTemplates[0].Spec.PVC.StorageClassName
+				r.Spec.StorageClassMappings = []snapshotv1.StorageClassMapping{
+					{Source: sourceStorageClassName, Target: "mapped"},
+				}
+				r.Status = &snapshotv1.VirtualMachineRestoreStatus{
+					Complete: &f,
+					Conditions: []snapshotv1.Condition{
+						newProgressingCondition(corev1.ConditionTrue, "Creating new PVCs"),
+						newReadyCondition(corev1.ConditionFalse, "Waiting for new PVCs"),
+					},
+				}
+				vmSource.Add(vm)
+				addVolumeRestores(r)
+				expectUpdateVMRestoreInProgress(vm)
+				k8sClient.Fake.PrependReactor("create", "persistentvolumeclaims", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					create, ok := action.(testing.CreateAction)
+					Expect(ok).To(BeTrue())
+
+					createObj := create.GetObject().(*corev1.PersistentVolumeClaim)
+					Expect(*createObj.Spec.StorageClassName).To(Equal("mapped"))
+
+					return true, create.GetObject(), nil
+				})
+				addVirtualMachineRestore(r)
+				controller.processVMRestoreWorkItem()
+			})
+
 			It("should wait for bound", func() {
 				r := createRestoreWithOwner()
 				r.Status = &snapshotv1.VirtualMachineRestoreStatus{
@@ -567,6 +598,200 @@ var _ = Describe("Restore controlleer", func() {
 				controller.processVMRestoreWorkItem()
 			})
 
+			It("should let the VM resume from the memory state of the snapshot", func() {
+				r := createRestoreWithOwner()
+				r.Status = &snapshotv1.VirtualMachineRestoreStatus{
+					Complete:           &f,
+					DeletedDataVolumes: getDeletedDataVolumes(createModifiedVM()),
+					Conditions: []snapshotv1.Condition{
+						newProgressingCondition(corev1.ConditionTrue, "Updating target spec"),
+						newReadyCondition(corev1.ConditionFalse, "Waiting for target update"),
+					},
+				}
+				addVolumeRestores(r)
+				for i := range r.Status.Restores {
+					r.Status.Restores[i].DataVolumeName = &r.Status.Restores[i].PersistentVolumeClaimName
+				}
+
+				s := createSnapshot()
+				sc := createVirtualMachineSnapshotContent(s, createSnapshotVM())
+				sc.Spec.MemoryBackup = createMemoryBackup(s)
+				sc.Status = &snapshotv1.VirtualMachineSnapshotContentStatus{
+					CreationTime: timeFunc(),
+					ReadyToUse:   &t,
+					MemorySnapshotStatus: &snapshotv1.MemorySnapshotStatus{
+						CreationTime: timeFunc(),
+						ReadyToUse:   &t,
+					},
+				}
+				vmSnapshotContentSource.Modify(sc)
+
+				vm := createModifiedVM()
+				vm.Status.RestoreInProgress = &vmRestoreName
+				vmSource.Add(vm)
+
+				vmInterface.EXPECT().Update(gomock.Any()).DoAndReturn(func(vm *v1.VirtualMachine) (*v1.VirtualMachine, error) {
+					Expect(vm.Annotations).To(HaveKeyWithValue(v1.RestoreMemoryClaimAnnotation, sc.Spec.MemoryBackup.PersistentVolumeClaim.Name))
+					return vm, nil
+				})
+				for _, pvc := range getRestorePVCs(r) {
+					pvc.Annotations["cdi.kubevirt.io/storage.populatedFor"] = pvc.Name
+					pvc.Status.Phase = corev1.ClaimBound
+					pvcSource.Add(&pvc)
+				}
+				addVirtualMachineRestore(r)
+				controller.processVMRestoreWorkItem()
+			})
+
+			It("should create new VM when target does not exist", func() {
+				r := createRestore()
+				r.Spec.StorageClassMappings = []snapshotv1.StorageClassMapping{
+					{Source: *createSnapshotVM().Spec.DataVolumeTemplates[0].Spec.PVC.StorageClassName, Target: "mapped"},
+				}
+				r.Status = &snapshotv1.VirtualMachineRestoreStatus{
+					Complete: &f,
+					Conditions: []snapshotv1.Condition{
+						newProgressingCondition(corev1.ConditionTrue, "Updating target spec"),
+						newReadyCondition(corev1.ConditionFalse, "Waiting for target update"),
+					},
+				}
+				addVolumeRestores(r)
+				for i := range r.Status.Restores {
+					r.Status.Restores[i].DataVolumeName = &r.Status.Restores[i].PersistentVolumeClaimName
+				}
+				snapshotVM := createSnapshotVM()
+				newVM := &v1.VirtualMachine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        vmName,
+						Namespace:   testNamespace,
+						Labels:      snapshotVM.Labels,
+						Annotations: map[string]string{"restore.kubevirt.io/lastRestoreUID": "restore-uid"},
+					},
+					Spec: snapshotVM.Spec,
+				}
+				newVM.Spec.DataVolumeTemplates[0].Name = "restore-uid-disk1"
+				newVM.Spec.DataVolumeTemplates[0].Spec.PVC.StorageClassName = &r.Spec.StorageClassMappings[0].Target
+				newVM.Spec.Template.Spec.Volumes[0].DataVolume.Name = "restore-uid-disk1"
+				vmInterface.EXPECT().Create(newVM).Return(newVM, nil)
+				for _, pvc := range getRestorePVCs(r) {
+					pvc.Annotations["cdi.kubevirt.io/storage.populatedFor"] = pvc.Name
+					pvc.Status.Phase = corev1.ClaimBound
+					pvcSource.Add(&pvc)
+				}
+				addVirtualMachineRestore(r)
+				controller.processVMRestoreWorkItem()
+			})
+
+			It("should regenerate the identity of the new VM if requested", func() {
+				r := createRestore()
+				r.Spec.Identity = &snapshotv1.RestoreIdentity{
+					MACAddresses:   snapshotv1.IdentityRegenerate,
+					FirmwareUUID:   snapshotv1.IdentityRegenerate,
+					FirmwareSerial: snapshotv1.IdentityPreserve,
+				}
+				r.Status = &snapshotv1.VirtualMachineRestoreStatus{
+					Complete: &f,
+					Conditions: []snapshotv1.Condition{
+						newProgressingCondition(corev1.ConditionTrue, "Updating target spec"),
+						newReadyCondition(corev1.ConditionFalse, "Waiting for target update"),
+					},
+				}
+				addVolumeRestores(r)
+				for i := range r.Status.Restores {
+					r.Status.Restores[i].DataVolumeName = &r.Status.Restores[i].PersistentVolumeClaimName
+				}
+
+				snapshotVM := createSnapshotVM()
+				snapshotVM.Spec.Template.Spec.Domain.Devices.Interfaces = []v1.Interface{
+					{Name: "default", MacAddress: "de:ad:00:00:be:af"},
+					{Name: "secondary"},
+				}
+				snapshotVM.Spec.Template.Spec.Domain.Firmware = &v1.Firmware{
+					UUID:   "5d307ca9-b3ef-428c-8861-06e72d69f223",
+					Serial: "serial",
+				}
+				s := createSnapshot()
+				sc := createVirtualMachineSnapshotContent(s, snapshotVM)
+				sc.Status = &snapshotv1.VirtualMachineSnapshotContentStatus{
+					CreationTime: timeFunc(),
+					ReadyToUse:   &t,
+				}
+				vmSnapshotContentSource.Modify(sc)
+
+				otherVM := createVirtualMachine(testNamespace, "other")
+				otherVM.Spec.Template.Spec.Domain.Devices.Interfaces = []v1.Interface{{Name: "default", MacAddress: "de:ad:00:00:be:af"}}
+				vmSource.Add(otherVM)
+
+				vmInterface.EXPECT().Create(gomock.Any()).DoAndReturn(func(vm *v1.VirtualMachine) (*v1.VirtualMachine, error) {
+					interfaces := vm.Spec.Template.Spec.Domain.Devices.Interfaces
+					Expect(interfaces[0].MacAddress).ToNot(Equal("de:ad:00:00:be:af"))
+					mac, err := net.ParseMAC(interfaces[0].MacAddress)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(mac[0] & 0x03).To(Equal(byte(0x02)))
+					Expect(interfaces[1].MacAddress).To(BeEmpty())
+
+					firmware := vm.Spec.Template.Spec.Domain.Firmware
+					Expect(firmware.UUID).ToNot(BeEmpty())
+					Expect(firmware.UUID).ToNot(Equal(snapshotVM.Spec.Template.Spec.Domain.Firmware.UUID))
+					Expect(firmware.Serial).To(Equal("serial"))
+					return vm, nil
+				})
+				for _, pvc := range getRestorePVCs(r) {
+					pvc.Annotations["cdi.kubevirt.io/storage.populatedFor"] = pvc.Name
+					pvc.Status.Phase = corev1.ClaimBound
+					pvcSource.Add(&pvc)
+				}
+				addVirtualMachineRestore(r)
+				controller.processVMRestoreWorkItem()
+			})
+
+			It("should regenerate the identity of a VM of another name by default", func() {
+				r := createRestore()
+				r.Spec.Target.Name = "restored-vm"
+				r.Status = &snapshotv1.VirtualMachineRestoreStatus{
+					Complete: &f,
+					Conditions: []snapshotv1.Condition{
+						newProgressingCondition(corev1.ConditionTrue, "Updating target spec"),
+						newReadyCondition(corev1.ConditionFalse, "Waiting for target update"),
+					},
+				}
+				addVolumeRestores(r)
+				for i := range r.Status.Restores {
+					r.Status.Restores[i].DataVolumeName = &r.Status.Restores[i].PersistentVolumeClaimName
+				}
+
+				snapshotVM := createSnapshotVM()
+				snapshotVM.Spec.Template.Spec.Domain.Devices.Interfaces = []v1.Interface{{Name: "default", MacAddress: "de:ad:00:00:be:af"}}
+				snapshotVM.Spec.Template.Spec.Domain.Firmware = &v1.Firmware{
+					UUID:   "5d307ca9-b3ef-428c-8861-06e72d69f223",
+					Serial: "serial",
+				}
+				s := createSnapshot()
+				sc := createVirtualMachineSnapshotContent(s, snapshotVM)
+				sc.Status = &snapshotv1.VirtualMachineSnapshotContentStatus{
+					CreationTime: timeFunc(),
+					ReadyToUse:   &t,
+				}
+				vmSnapshotContentSource.Modify(sc)
+
+				vmInterface.EXPECT().Create(gomock.Any()).DoAndReturn(func(vm *v1.VirtualMachine) (*v1.VirtualMachine, error) {
+					Expect(vm.Name).To(Equal("restored-vm"))
+					Expect(vm.Spec.Template.Spec.Domain.Devices.Interfaces[0].MacAddress).ToNot(Equal("de:ad:00:00:be:af"))
+
+					firmware := vm.Spec.Template.Spec.Domain.Firmware
+					Expect(firmware.UUID).ToNot(Equal(snapshotVM.Spec.Template.Spec.Domain.Firmware.UUID))
+					Expect(firmware.Serial).ToNot(Equal("serial"))
+					return vm, nil
+				})
+				for _, pvc := range getRestorePVCs(r) {
+					pvc.Annotations["cdi.kubevirt.io/storage.populatedFor"] = pvc.Name
+					pvc.Status.Phase = corev1.ClaimBound
+					pvcSource.Add(&pvc)
+				}
+				addVirtualMachineRestore(r)
+				controller.processVMRestoreWorkItem()
+			})
+
 			It("should cleanup and complete", func() {
 				r := createRestoreWithOwner()
 				r.Status = &snapshotv1.VirtualMachineRestoreStatus{
@@ -30,6 +30,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	kubevirtv1 "kubevirt.io/client-go/api/v1"
@@ -52,11 +53,30 @@ const (
 
 	volumeSnapshotMissingEvent = "VolumeSnapshotMissing"
 
+	memorySnapshotCreateEvent = "SuccessfulMemorySnapshotCreate"
+
+	quiesceFailedEvent = "QuiesceFailed"
+
+	vmSnapshotExpiredEvent = "VirtualMachineSnapshotExpired"
+
 	vmSnapshotDeadlineExceededError = "snapshot deadline exceeded"
 
+	vmSnapshotQuiesceUnavailableError = "quiesce required but guest agent is not connected"
+
 	snapshotRetryInterval = 5 * time.Second
+
+	memoryDumpVolumeName = "snapshot-memory-dump"
+)
+
+// keys of the object storage credentials in the Secret of the memory backup
+const (
+	objectStorageAccessKeyID     = "accessKeyId"
+	objectStorageSecretAccessKey = "secretAccessKey"
 )
 
+// memoryDumpOverhead is added to the guest memory when sizing the memory dump PVC
+var memoryDumpOverhead = resource.MustParse("100Mi")
+
 func vmSnapshotReady(vmSnapshot *snapshotv1.VirtualMachineSnapshot) bool {
 	return vmSnapshot.Status != nil && vmSnapshot.Status.ReadyToUse != nil && *vmSnapshot.Status.ReadyToUse
 }
@@ -92,6 +112,42 @@ func vmSnapshotDeadlineExceeded(vmSnapshot *snapshotv1.VirtualMachineSnapshot) b
 	return timeUntilDeadline(vmSnapshot) < 0
 }
 
+func vmSnapshotExpired(vmSnapshot *snapshotv1.VirtualMachineSnapshot) bool {
+	if !vmSnapshotSucceeded(vmSnapshot) || vmSnapshot.Spec.TTL == nil || vmSnapshot.Status.CreationTime == nil {
+		return false
+	}
+	return timeUntilExpiry(vmSnapshot) <= 0
+}
+
+func quiesceRequired(vmSnapshot *snapshotv1.VirtualMachineSnapshot) bool {
+	return vmSnapshot.Spec.QuiescePolicy != nil && *vmSnapshot.Spec.QuiescePolicy == snapshotv1.QuiescePolicyRequired
+}
+
+// quiesceUnavailable returns true when the snapshot requires the guest to be
+// frozen but the guest agent of the online source is not connected
+func quiesceUnavailable(vmSnapshot *snapshotv1.VirtualMachineSnapshot, source snapshotSource) (bool, error) {
+	if !quiesceRequired(vmSnapshot) {
+		return false, nil
+	}
+
+	online, err := source.Online()
+	if err != nil || !online {
+		return false, err
+	}
+
+	ga, err := source.GuestAgent()
+	if err != nil {
+		return false, err
+	}
+
+	return !ga, nil
+}
+
+func memorySnapshotTaken(content *snapshotv1.VirtualMachineSnapshotContent) bool {
+	return content.Status != nil && content.Status.MemorySnapshotStatus != nil &&
+		content.Status.MemorySnapshotStatus.ReadyToUse != nil && *content.Status.MemorySnapshotStatus.ReadyToUse
+}
+
 func getVMSnapshotContentName(vmSnapshot *snapshotv1.VirtualMachineSnapshot) string {
 	if vmSnapshot.Status != nil && vmSnapshot.Status.VirtualMachineSnapshotContentName != nil {
 		return *vmSnapshot.Status.VirtualMachineSnapshotContentName
@@ -115,6 +171,10 @@ func (ctrl *VMSnapshotController) updateVMSnapshot(vmSnapshot *snapshotv1.Virtua
 	log.Log.V(3).Infof("Updating VirtualMachineSnapshot %s/%s", vmSnapshot.Namespace, vmSnapshot.Name)
 	var retry time.Duration
 
+	if vmSnapshot.DeletionTimestamp == nil && vmSnapshotExpired(vmSnapshot) {
+		return 0, ctrl.deleteExpiredVMSnapshot(vmSnapshot)
+	}
+
 	source, err := ctrl.getSnapshotSource(vmSnapshot)
 	if err != nil {
 		return 0, err
@@ -132,14 +192,23 @@ func (ctrl *VMSnapshotController) updateVMSnapshot(vmSnapshot *snapshotv1.Virtua
 				// attempt to lock source
 				// if fails will attempt again when source is updated
 				if !source.Locked() {
-					locked, err := source.Lock()
+					unavailable, err := quiesceUnavailable(vmSnapshot, source)
 					if err != nil {
 						return 0, err
 					}
 
-					log.Log.V(3).Infof("Attempt to lock source returned: %t", locked)
+					if unavailable {
+						log.Log.V(3).Infof("Not locking source of %s/%s, %s", vmSnapshot.Namespace, vmSnapshot.Name, vmSnapshotQuiesceUnavailableError)
+					} else {
+						locked, err := source.Lock()
+						if err != nil {
+							return 0, err
+						}
+
+						log.Log.V(3).Infof("Attempt to lock source returned: %t", locked)
 
-					retry = snapshotRetryInterval
+						retry = snapshotRetryInterval
+					}
 				} else {
 					// create content if does not exist
 					if content == nil {
@@ -153,6 +222,12 @@ func (ctrl *VMSnapshotController) updateVMSnapshot(vmSnapshot *snapshotv1.Virtua
 					return 0, err
 				}
 
+				if content != nil && content.Spec.MemoryBackup != nil && content.Spec.MemoryBackup.PersistentVolumeClaim != nil {
+					if err := source.RemoveMemoryDumpVolume(content.Spec.MemoryBackup.VolumeName); err != nil {
+						return 0, err
+					}
+				}
+
 				if _, err := source.Unlock(); err != nil {
 					return 0, err
 				}
@@ -189,12 +264,35 @@ func (ctrl *VMSnapshotController) updateVMSnapshot(vmSnapshot *snapshotv1.Virtua
 	}
 
 	if retry == 0 {
+		if vmSnapshotSucceeded(vmSnapshot) && vmSnapshot.Spec.TTL != nil {
+			return timeUntilExpiry(vmSnapshot), nil
+		}
 		return timeUntilDeadline(vmSnapshot), nil
 	}
 
 	return retry, nil
 }
 
+func (ctrl *VMSnapshotController) deleteExpiredVMSnapshot(vmSnapshot *snapshotv1.VirtualMachineSnapshot) error {
+	log.Log.V(2).Infof("Deleting expired vmsnapshot %s/%s", vmSnapshot.Namespace, vmSnapshot.Name)
+
+	err := ctrl.Client.VirtualMachineSnapshot(vmSnapshot.Namespace).Delete(context.Background(), vmSnapshot.Name, metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	ctrl.Recorder.Eventf(
+		vmSnapshot,
+		corev1.EventTypeNormal,
+		vmSnapshotExpiredEvent,
+		"VirtualMachineSnapshot %s expired after %s",
+		vmSnapshot.Name,
+		vmSnapshot.Spec.TTL.Duration,
+	)
+
+	return nil
+}
+
 func (ctrl *VMSnapshotController) updateVMSnapshotContent(content *snapshotv1.VirtualMachineSnapshotContent) (time.Duration, error) {
 	log.Log.V(3).Infof("Updating VirtualMachineSnapshotContent %s/%s", content.Namespace, content.Name)
 
@@ -213,6 +311,19 @@ func (ctrl *VMSnapshotController) updateVMSnapshotContent(content *snapshotv1.Vi
 	currentlyReady := vmSnapshotContentReady(content)
 	currentlyError := (content.Status != nil && content.Status.Error != nil) || vmSnapshotError(vmSnapshot) != nil
 
+	takeMemorySnapshot := content.Spec.MemoryBackup != nil && !memorySnapshotTaken(content) && !currentlyReady && !currentlyError
+	if takeMemorySnapshot && content.Spec.MemoryBackup.PersistentVolumeClaim != nil {
+		// the memory dump volume has to be mounted before the guest is frozen
+		mounted, err := ctrl.prepareMemoryBackup(vmSnapshot, content)
+		if err != nil {
+			return 0, err
+		}
+
+		if !mounted {
+			return snapshotRetryInterval, nil
+		}
+	}
+
 	for _, volumeBackup := range content.Spec.VolumeBackups {
 		if volumeBackup.VolumeSnapshotName == nil {
 			continue
@@ -247,32 +358,10 @@ func (ctrl *VMSnapshotController) updateVMSnapshotContent(content *snapshotv1.Vi
 			}
 
 			if !didFreeze {
-				source, err := ctrl.getSnapshotSource(vmSnapshot)
-				if err != nil {
+				if err := ctrl.freezeSource(vmSnapshot); err != nil {
 					return 0, err
 				}
 
-				if source == nil {
-					return 0, fmt.Errorf("unable to get snapshot source")
-				}
-
-				frozen, err := source.Frozen()
-				if err != nil {
-					return 0, err
-				}
-
-				if !frozen {
-					err := source.Freeze()
-					if err != nil {
-						return 0, err
-					}
-
-					// assuming that VM is frozen once Freeze() returns
-					// which should be the case
-					// if Freeze() were async, we'd have to return
-					// and only continue when source.Frozen() == true
-				}
-
 				didFreeze = true
 			}
 
@@ -295,6 +384,26 @@ func (ctrl *VMSnapshotController) updateVMSnapshotContent(content *snapshotv1.Vi
 		volumeSnapshotStatus = append(volumeSnapshotStatus, vss)
 	}
 
+	var memorySnapshotStatus *snapshotv1.MemorySnapshotStatus
+	if content.Status != nil {
+		memorySnapshotStatus = content.Status.MemorySnapshotStatus
+	}
+
+	var memorySaved bool
+	if takeMemorySnapshot {
+		// the memory is saved under the same freeze as the volumes
+		if !didFreeze && memorySnapshotStatus == nil {
+			if err := ctrl.freezeSource(vmSnapshot); err != nil {
+				return 0, err
+			}
+		}
+
+		memorySnapshotStatus, memorySaved, err = ctrl.createMemorySnapshot(vmSnapshot, content)
+		if err != nil {
+			return 0, err
+		}
+	}
+
 	ready := true
 	errorMessage := ""
 	contentCpy := content.DeepCopy()
@@ -310,11 +419,11 @@ func (ctrl *VMSnapshotController) updateVMSnapshotContent(content *snapshotv1.Vi
 		ready = false
 		errorMessage = fmt.Sprintf("VolumeSnapshots (%s) skipped because in error state", strings.Join(skippedSnapshots, ","))
 	} else {
-		for _, vss := range volumeSnapshotStatus {
-			if vss.ReadyToUse == nil || !*vss.ReadyToUse {
-				ready = false
-				break
-			}
+		ready = volumeSnapshotsReady(volumeSnapshotStatus)
+
+		if content.Spec.MemoryBackup != nil &&
+			(memorySnapshotStatus == nil || memorySnapshotStatus.ReadyToUse == nil || !*memorySnapshotStatus.ReadyToUse) {
+			ready = false
 		}
 	}
 
@@ -335,6 +444,13 @@ func (ctrl *VMSnapshotController) updateVMSnapshotContent(content *snapshotv1.Vi
 		}
 	}
 
+	// the memory upload continues once the memory was saved, the guest must not stay frozen until it completed
+	if !ready && memorySaved && errorMessage == "" && volumeSnapshotsReady(volumeSnapshotStatus) {
+		if err := ctrl.unfreezeSource(vmSnapshot); err != nil {
+			return 0, err
+		}
+	}
+
 	if errorMessage != "" {
 		contentCpy.Status.Error = &snapshotv1.Error{
 			Time:    currentTime(),
@@ -344,6 +460,7 @@ func (ctrl *VMSnapshotController) updateVMSnapshotContent(content *snapshotv1.Vi
 
 	contentCpy.Status.ReadyToUse = &ready
 	contentCpy.Status.VolumeSnapshotStatus = volumeSnapshotStatus
+	contentCpy.Status.MemorySnapshotStatus = memorySnapshotStatus
 
 	if !reflect.DeepEqual(content, contentCpy) {
 		if _, err := ctrl.Client.VirtualMachineSnapshotContent(contentCpy.Namespace).Update(context.Background(), contentCpy, metav1.UpdateOptions{}); err != nil {
@@ -351,9 +468,38 @@ func (ctrl *VMSnapshotController) updateVMSnapshotContent(content *snapshotv1.Vi
 		}
 	}
 
+	// the memory upload runs in the background, poll it until it completed
+	if takeMemorySnapshot && (memorySnapshotStatus == nil || memorySnapshotStatus.ReadyToUse == nil || !*memorySnapshotStatus.ReadyToUse) {
+		return snapshotRetryInterval, nil
+	}
+
 	return 0, nil
 }
 
+func volumeSnapshotsReady(volumeSnapshotStatus []snapshotv1.VolumeSnapshotStatus) bool {
+	for _, vss := range volumeSnapshotStatus {
+		if vss.ReadyToUse == nil || !*vss.ReadyToUse {
+			return false
+		}
+	}
+	return true
+}
+
+// unfreezeSource thaws the guest of the snapshot source if it is still frozen
+func (ctrl *VMSnapshotController) unfreezeSource(vmSnapshot *snapshotv1.VirtualMachineSnapshot) error {
+	source, err := ctrl.getSnapshotSource(vmSnapshot)
+	if err != nil || source == nil {
+		return err
+	}
+
+	frozen, err := source.Frozen()
+	if err != nil || !frozen {
+		return err
+	}
+
+	return source.Unfreeze()
+}
+
 func (ctrl *VMSnapshotController) createVolumeSnapshot(
 	content *snapshotv1.VirtualMachineSnapshotContent,
 	volumeBackup snapshotv1.VolumeBackup,
@@ -413,6 +559,228 @@ func (ctrl *VMSnapshotController) createVolumeSnapshot(
 	return volumeSnapshot, nil
 }
 
+// prepareMemoryBackup creates the PVC the memory state is saved to and
+// hotplugs it into the source, returns true once the volume is mounted
+func (ctrl *VMSnapshotController) prepareMemoryBackup(
+	vmSnapshot *snapshotv1.VirtualMachineSnapshot,
+	content *snapshotv1.VirtualMachineSnapshotContent,
+) (bool, error) {
+	memoryBackup := content.Spec.MemoryBackup
+
+	_, exists, err := ctrl.PVCInformer.GetStore().GetByKey(cacheKeyFunc(content.Namespace, memoryBackup.PersistentVolumeClaim.Name))
+	if err != nil {
+		return false, err
+	}
+
+	if !exists {
+		if err := ctrl.createMemoryBackupPVC(content); err != nil {
+			return false, err
+		}
+	}
+
+	source, err := ctrl.getSnapshotSource(vmSnapshot)
+	if err != nil {
+		return false, err
+	}
+
+	if source == nil {
+		return false, fmt.Errorf("unable to get snapshot source")
+	}
+
+	return source.AddMemoryDumpVolume(memoryBackup.VolumeName, memoryBackup.PersistentVolumeClaim.Name)
+}
+
+func (ctrl *VMSnapshotController) createMemoryBackupPVC(content *snapshotv1.VirtualMachineSnapshotContent) error {
+	memoryBackup := content.Spec.MemoryBackup
+	log.Log.Infof("Attempting to create memory backup PVC %s", memoryBackup.PersistentVolumeClaim.Name)
+
+	t := true
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: memoryBackup.PersistentVolumeClaim.Name,
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion:         snapshotv1.SchemeGroupVersion.String(),
+					Kind:               "VirtualMachineSnapshotContent",
+					Name:               content.Name,
+					UID:                content.UID,
+					Controller:         &t,
+					BlockOwnerDeletion: &t,
+				},
+			},
+		},
+		Spec: *memoryBackup.PersistentVolumeClaim.Spec.DeepCopy(),
+	}
+
+	_, err := ctrl.Client.CoreV1().PersistentVolumeClaims(content.Namespace).Create(context.Background(), pvc, metav1.CreateOptions{})
+	if err != nil && !errors.IsAlreadyExists(err) {
+		return err
+	}
+
+	return nil
+}
+
+// freezeSource freezes the guest of the source unless it is frozen already, a
+// failed freeze is only an error when the snapshot requires quiesce
+func (ctrl *VMSnapshotController) freezeSource(vmSnapshot *snapshotv1.VirtualMachineSnapshot) error {
+	source, err := ctrl.getSnapshotSource(vmSnapshot)
+	if err != nil {
+		return err
+	}
+
+	if source == nil {
+		return fmt.Errorf("unable to get snapshot source")
+	}
+
+	frozen, err := source.Frozen()
+	if err != nil {
+		return err
+	}
+
+	if !frozen {
+		err := source.Freeze()
+		if err != nil {
+			if quiesceRequired(vmSnapshot) {
+				return err
+			}
+
+			log.Log.Warningf("Failed to freeze source of %s/%s, continuing without quiesce: %v", vmSnapshot.Namespace, vmSnapshot.Name, err)
+			ctrl.Recorder.Eventf(
+				vmSnapshot,
+				corev1.EventTypeWarning,
+				quiesceFailedEvent,
+				"Failed to freeze guest, taking snapshot without quiesce: %v",
+				err,
+			)
+		}
+
+		// assuming that VM is frozen once Freeze() returns
+		// which should be the case
+		// if Freeze() were async, we'd have to return
+		// and only continue when source.Frozen() == true
+	}
+
+	return nil
+}
+
+// createMemorySnapshot starts or polls saving the memory state of the source
+// to the memory dump volume or the object storage of the memory backup, it
+// also returns whether the memory was saved while an upload of it is still in
+// progress. The credentials are only read from the Secret referenced by the
+// snapshot and passed on when an upload has to be started.
+func (ctrl *VMSnapshotController) createMemorySnapshot(
+	vmSnapshot *snapshotv1.VirtualMachineSnapshot,
+	content *snapshotv1.VirtualMachineSnapshotContent,
+) (*snapshotv1.MemorySnapshotStatus, bool, error) {
+	source, err := ctrl.getSnapshotSource(vmSnapshot)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if source == nil {
+		return nil, false, fmt.Errorf("unable to get snapshot source")
+	}
+
+	memoryBackup := content.Spec.MemoryBackup
+	storage := memoryBackup.ObjectStorage
+
+	options := &kubevirtv1.MemorySnapshotOptions{}
+	var target string
+	if storage == nil {
+		target = fmt.Sprintf("PVC %s", memoryBackup.PersistentVolumeClaim.Name)
+	} else {
+		options.ObjectStorage = &kubevirtv1.MemorySnapshotObjectStorage{
+			Endpoint: storage.Endpoint,
+			Region:   storage.Region,
+			Bucket:   storage.Bucket,
+			Key:      memoryBackup.ObjectKey,
+		}
+		target = fmt.Sprintf("%s/%s", storage.Bucket, memoryBackup.ObjectKey)
+	}
+
+	status, err := source.UploadMemory(options)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if status.Phase == kubevirtv1.MemorySnapshotUploadPending && storage != nil {
+		// the Secret is taken from the snapshot, its creator was authorized to read it on admission
+		if vmSnapshot.Spec.Memory == nil || vmSnapshot.Spec.Memory.ObjectStorage == nil {
+			return nil, false, fmt.Errorf("snapshot %s/%s has no object storage", vmSnapshot.Namespace, vmSnapshot.Name)
+		}
+		if err := ctrl.setObjectStorageCredentials(vmSnapshot.Namespace, vmSnapshot.Spec.Memory.ObjectStorage.SecretRef.Name, options.ObjectStorage); err != nil {
+			return nil, false, err
+		}
+		status, err = source.UploadMemory(options)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	switch status.Phase {
+	case kubevirtv1.MemorySnapshotUploadFailed:
+		return nil, false, fmt.Errorf("failed to save memory state to %s: %s", target, status.Message)
+	case kubevirtv1.MemorySnapshotUploadSucceeded:
+		t := true
+		memorySnapshotStatus := &snapshotv1.MemorySnapshotStatus{
+			CreationTime: currentTime(),
+			ReadyToUse:   &t,
+		}
+
+		if storage == nil {
+			ctrl.Recorder.Eventf(
+				content,
+				corev1.EventTypeNormal,
+				memorySnapshotCreateEvent,
+				"Successfully saved memory state to %s",
+				target,
+			)
+			return memorySnapshotStatus, true, nil
+		}
+
+		ctrl.Recorder.Eventf(
+			content,
+			corev1.EventTypeNormal,
+			memorySnapshotCreateEvent,
+			"Successfully uploaded memory state to %s",
+			target,
+		)
+
+		checksum := status.Checksum
+		memorySnapshotStatus.Checksum = &checksum
+		return memorySnapshotStatus, true, nil
+	}
+
+	log.Log.V(3).Infof("Memory state of %s is being saved to %s", content.Name, target)
+
+	f := false
+	return &snapshotv1.MemorySnapshotStatus{
+		ReadyToUse: &f,
+	}, status.MemorySaved, nil
+}
+
+func (ctrl *VMSnapshotController) setObjectStorageCredentials(namespace, secretName string, storage *kubevirtv1.MemorySnapshotObjectStorage) error {
+	secret, err := ctrl.Client.CoreV1().Secrets(namespace).Get(context.Background(), secretName, metav1.GetOptions{})
+	if errors.IsForbidden(err) {
+		return fmt.Errorf("virt-controller has to be granted get access to secret %s in namespace %s: %v", secretName, namespace, err)
+	} else if err != nil {
+		return err
+	}
+
+	accessKeyID, ok := secret.Data[objectStorageAccessKeyID]
+	if !ok {
+		return fmt.Errorf("secret %s has no %s", secretName, objectStorageAccessKeyID)
+	}
+	secretAccessKey, ok := secret.Data[objectStorageSecretAccessKey]
+	if !ok {
+		return fmt.Errorf("secret %s has no %s", secretName, objectStorageSecretAccessKey)
+	}
+
+	storage.AccessKeyID = string(accessKeyID)
+	storage.SecretAccessKey = string(secretAccessKey)
+	return nil
+}
+
 func (ctrl *VMSnapshotController) getSnapshotSource(vmSnapshot *snapshotv1.VirtualMachineSnapshot) (snapshotSource, error) {
 	switch vmSnapshot.Spec.Source.Kind {
 	case "VirtualMachine":
@@ -474,6 +842,15 @@ func (ctrl *VMSnapshotController) createContent(vmSnapshot *snapshotv1.VirtualMa
 	if err != nil {
 		return err
 	}
+
+	var memoryBackup *snapshotv1.MemoryBackup
+	if vmSnapshot.Spec.Memory != nil {
+		memoryBackup, err = getMemoryBackup(vmSnapshot, source)
+		if err != nil {
+			return err
+		}
+	}
+
 	content := &snapshotv1.VirtualMachineSnapshotContent{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:       getVMSnapshotContentName(vmSnapshot),
@@ -484,6 +861,7 @@ func (ctrl *VMSnapshotController) createContent(vmSnapshot *snapshotv1.VirtualMa
 			VirtualMachineSnapshotName: &vmSnapshot.Name,
 			Source:                     sourceSpec,
 			VolumeBackups:              volumeBackups,
+			MemoryBackup:               memoryBackup,
 		},
 	}
 
@@ -503,6 +881,53 @@ func (ctrl *VMSnapshotController) createContent(vmSnapshot *snapshotv1.VirtualMa
 	return nil
 }
 
+// getMemoryBackup returns the memory backup of an online source, the PVC is
+// sized to hold the guest memory
+func getMemoryBackup(vmSnapshot *snapshotv1.VirtualMachineSnapshot, source snapshotSource) (*snapshotv1.MemoryBackup, error) {
+	online, err := source.Online()
+	if err != nil || !online {
+		return nil, err
+	}
+
+	name := fmt.Sprintf("vmsnapshot-%s-memory", vmSnapshot.UID)
+
+	if vmSnapshot.Spec.Memory.ObjectStorage != nil {
+		return &snapshotv1.MemoryBackup{
+			ObjectStorage: vmSnapshot.Spec.Memory.ObjectStorage.DeepCopy(),
+			ObjectKey:     name,
+		}, nil
+	}
+
+	memory, err := source.GuestMemory()
+	if err != nil {
+		return nil, err
+	}
+
+	size := memory.DeepCopy()
+	size.Add(memoryDumpOverhead)
+	volumeMode := corev1.PersistentVolumeFilesystem
+
+	return &snapshotv1.MemoryBackup{
+		VolumeName: memoryDumpVolumeName,
+		PersistentVolumeClaim: &snapshotv1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: vmSnapshot.Namespace,
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: size,
+					},
+				},
+				StorageClassName: vmSnapshot.Spec.Memory.StorageClassName,
+				VolumeMode:       &volumeMode,
+			},
+		},
+	}, nil
+}
+
 func (ctrl *VMSnapshotController) getSnapshotPVC(namespace, volumeName string) (*corev1.PersistentVolumeClaim, error) {
 	obj, exists, err := ctrl.PVCInformer.GetStore().GetByKey(cacheKeyFunc(namespace, volumeName))
 	if err != nil {
@@ -604,6 +1029,27 @@ func (ctrl *VMSnapshotController) updateSnapshotStatus(vmSnapshot *snapshotv1.Vi
 			vmSnapshotCpy.Status.CreationTime = content.Status.CreationTime
 			vmSnapshotCpy.Status.ReadyToUse = content.Status.ReadyToUse
 			vmSnapshotCpy.Status.Error = content.Status.Error
+
+			if memorySnapshotTaken(content) {
+				updateSnapshotCondition(vmSnapshotCpy, newMemorySnapshotCompletedCondition(corev1.ConditionTrue, "Memory state saved"))
+			}
+		}
+	}
+
+	if vmSnapshotProgressing(vmSnapshotCpy) && vmSnapshotCpy.DeletionTimestamp == nil && source != nil && !source.Locked() {
+		unavailable, err := quiesceUnavailable(vmSnapshotCpy, source)
+		if err != nil {
+			return err
+		}
+
+		if unavailable {
+			message := vmSnapshotQuiesceUnavailableError
+			vmSnapshotCpy.Status.Phase = snapshotv1.Failed
+			vmSnapshotCpy.Status.Error = &snapshotv1.Error{
+				Time:    currentTime(),
+				Message: &message,
+			}
+			updateSnapshotCondition(vmSnapshotCpy, newFailureCondition(corev1.ConditionTrue, vmSnapshotQuiesceUnavailableError))
 		}
 	}
 
@@ -640,6 +1086,10 @@ func (ctrl *VMSnapshotController) updateSnapshotStatus(vmSnapshot *snapshotv1.Vi
 				} else {
 					indications = append(indications, snapshotv1.VMSnapshotNoGuestAgentIndication)
 				}
+
+				if vmSnapshotCpy.Spec.Memory != nil {
+					indications = append(indications, snapshotv1.VMSnapshotMemoryIndication)
+				}
 			}
 			vmSnapshotCpy.Status.Indications = indications
 		} else {
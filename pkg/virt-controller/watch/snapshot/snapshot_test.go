@@ -34,6 +34,7 @@ import (
 	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1beta1"
 	"kubevirt.io/kubevirt/pkg/testutils"
 	"kubevirt.io/kubevirt/pkg/util/status"
+	launcherapi "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 )
 
 const (
@@ -49,6 +50,7 @@ var (
 	t                                  = true
 	f                                  = false
 	noFailureDeadline *metav1.Duration = &metav1.Duration{Duration: 0}
+	checksum                           = "abc-1"
 )
 
 var _ = Describe("Snapshot controlleer", func() {
@@ -369,6 +371,7 @@ var _ = Describe("Snapshot controlleer", func() {
 
 			k8sClient = k8sfake.NewSimpleClientset()
 			virtClient.EXPECT().StorageV1().Return(k8sClient.StorageV1()).AnyTimes()
+			virtClient.EXPECT().CoreV1().Return(k8sClient.CoreV1()).AnyTimes()
 			virtClient.EXPECT().AppsV1().Return(k8sClient.AppsV1()).AnyTimes()
 
 			k8sClient.Fake.PrependReactor("*", "*", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
@@ -560,6 +563,16 @@ var _ = Describe("Snapshot controlleer", func() {
 				controller.processVMSnapshotWorkItem()
 			})
 
+			It("should delete VirtualMachineSnapshot when ttl expired", func() {
+				vmSnapshot := createVMSnapshotSuccess()
+				vmSnapshot.Spec.TTL = &metav1.Duration{Duration: time.Nanosecond}
+
+				expectVMSnapshotDelete(vmSnapshotClient, vmSnapshot.Name)
+				addVirtualMachineSnapshot(vmSnapshot)
+				controller.processVMSnapshotWorkItem()
+				testutils.ExpectEvent(recorder, "VirtualMachineSnapshotExpired")
+			})
+
 			It("cleanup when VirtualMachineSnapshot is deleted", func() {
 				vmSnapshot := createVMSnapshotSuccess()
 				vmSnapshot.DeletionTimestamp = timeFunc()
@@ -877,6 +890,34 @@ var _ = Describe("Snapshot controlleer", func() {
 				controller.processVMSnapshotWorkItem()
 			})
 
+			It("should fail when quiesce is required and guest agent not connected", func() {
+				policy := snapshotv1.QuiescePolicyRequired
+				vmSnapshot := createVMSnapshotInProgress()
+				vmSnapshot.Spec.QuiescePolicy = &policy
+				vm := createVM()
+				vm.Spec.Running = &t
+				vmSource.Add(vm)
+				vmiSource.Add(createVMI(vm))
+
+				message := "quiesce required but guest agent is not connected"
+				updatedSnapshot := vmSnapshot.DeepCopy()
+				updatedSnapshot.ResourceVersion = "1"
+				updatedSnapshot.Status.Phase = snapshotv1.Failed
+				updatedSnapshot.Status.Error = &snapshotv1.Error{
+					Time:    timeFunc(),
+					Message: &message,
+				}
+				updatedSnapshot.Status.Conditions = []snapshotv1.Condition{
+					newFailureCondition(corev1.ConditionTrue, message),
+					newProgressingCondition(corev1.ConditionFalse, "In error state"),
+					newReadyCondition(corev1.ConditionFalse, "Error"),
+				}
+				expectVMSnapshotUpdate(vmSnapshotClient, updatedSnapshot)
+
+				addVirtualMachineSnapshot(vmSnapshot)
+				controller.processVMSnapshotWorkItem()
+			})
+
 			It("should not lock source if another snapshot in progress", func() {
 				n := "otherSnapshot"
 				vmSnapshot := createVMSnapshotInProgress()
@@ -1141,6 +1182,370 @@ var _ = Describe("Snapshot controlleer", func() {
 				testutils.ExpectEvent(recorder, "SuccessfulVolumeSnapshotCreate")
 			})
 
+			It("should create memory backup PVC and add memory dump volume", func() {
+				vm := createLockedVM()
+				vmSnapshot := createVMSnapshotInProgress()
+				vmSnapshotContent := createVMSnapshotContent()
+				vmSnapshotContent.UID = contentUID
+				vmSnapshotContent.Spec.MemoryBackup = createMemoryBackup(vmSnapshot)
+				vmSource.Add(vm)
+				vmSnapshotContentSource.Add(vmSnapshotContent)
+
+				vmi := createVMI(vm)
+				vmi.Spec.Volumes = vm.Spec.Template.Spec.Volumes
+				vmiSource.Add(vmi)
+
+				k8sClient.Fake.PrependReactor("create", "persistentvolumeclaims", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					create, ok := action.(testing.CreateAction)
+					Expect(ok).To(BeTrue())
+
+					pvc := create.GetObject().(*corev1.PersistentVolumeClaim)
+					Expect(pvc.Name).To(Equal(vmSnapshotContent.Spec.MemoryBackup.PersistentVolumeClaim.Name))
+					Expect(pvc.Spec).To(Equal(vmSnapshotContent.Spec.MemoryBackup.PersistentVolumeClaim.Spec))
+					Expect(pvc.OwnerReferences[0].UID).To(Equal(vmSnapshotContent.UID))
+
+					return true, create.GetObject(), nil
+				})
+
+				oldVolumes, err := json.Marshal(vmi.Spec.Volumes)
+				Expect(err).ToNot(HaveOccurred())
+				newVolumes, err := json.Marshal(append(vmi.Spec.Volumes, v1.Volume{
+					Name: "snapshot-memory-dump",
+					VolumeSource: v1.VolumeSource{
+						MemoryDump: &v1.MemoryDumpVolumeSource{
+							PersistentVolumeClaimVolumeSource: v1.PersistentVolumeClaimVolumeSource{
+								PersistentVolumeClaimVolumeSource: corev1.PersistentVolumeClaimVolumeSource{
+									ClaimName: vmSnapshotContent.Spec.MemoryBackup.PersistentVolumeClaim.Name,
+								},
+								Hotpluggable: true,
+							},
+						},
+					},
+				}))
+				Expect(err).ToNot(HaveOccurred())
+				patch := fmt.Sprintf(`[{ "op": "test", "path": "/spec/volumes", "value": %s}, { "op": "replace", "path": "/spec/volumes", "value": %s}]`, oldVolumes, newVolumes)
+				vmiInterface.EXPECT().Patch(vm.Name, types.JSONPatchType, []byte(patch)).Return(vmi, nil)
+
+				addVirtualMachineSnapshot(vmSnapshot)
+				controller.processVMSnapshotContentWorkItem()
+			})
+
+			It("should save the memory under the freeze of the volumes once memory dump volume is mounted", func() {
+				vm := createLockedVM()
+				storageClass := createStorageClass()
+				vmSnapshot := createVMSnapshotInProgress()
+				volumeSnapshotClass := &createVolumeSnapshotClasses()[0]
+				pvcs := createPersistentVolumeClaims()
+				vmSnapshotContent := createVMSnapshotContent()
+				vmSnapshotContent.UID = contentUID
+				vmSnapshotContent.Spec.MemoryBackup = createMemoryBackup(vmSnapshot)
+				vmSource.Add(vm)
+				vmSnapshotContentSource.Add(vmSnapshotContent)
+
+				vmi := createVMI(vm)
+				vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+					Name: "snapshot-memory-dump",
+					VolumeSource: v1.VolumeSource{
+						MemoryDump: &v1.MemoryDumpVolumeSource{
+							PersistentVolumeClaimVolumeSource: v1.PersistentVolumeClaimVolumeSource{
+								PersistentVolumeClaimVolumeSource: corev1.PersistentVolumeClaimVolumeSource{
+									ClaimName: vmSnapshotContent.Spec.MemoryBackup.PersistentVolumeClaim.Name,
+								},
+								Hotpluggable: true,
+							},
+						},
+					},
+				})
+				vmi.Status.VolumeStatus = []v1.VolumeStatus{
+					{
+						Name:  "snapshot-memory-dump",
+						Phase: v1.HotplugVolumeMounted,
+					},
+				}
+				vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{
+					Type:          v1.VirtualMachineInstanceAgentConnected,
+					LastProbeTime: metav1.Now(),
+					Status:        corev1.ConditionTrue,
+				})
+				vmiSource.Add(vmi)
+
+				memoryPVC := corev1.PersistentVolumeClaim{
+					ObjectMeta: vmSnapshotContent.Spec.MemoryBackup.PersistentVolumeClaim.ObjectMeta,
+					Spec:       vmSnapshotContent.Spec.MemoryBackup.PersistentVolumeClaim.Spec,
+				}
+				pvcSource.Add(&memoryPVC)
+
+				updatedContent := vmSnapshotContent.DeepCopy()
+				updatedContent.ResourceVersion = "1"
+				updatedContent.Status = &snapshotv1.VirtualMachineSnapshotContentStatus{
+					ReadyToUse: &f,
+					MemorySnapshotStatus: &snapshotv1.MemorySnapshotStatus{
+						CreationTime: timeFunc(),
+						ReadyToUse:   &t,
+					},
+				}
+
+				volumeSnapshots := createVolumeSnapshots(vmSnapshotContent)
+				for i := range volumeSnapshots {
+					vss := snapshotv1.VolumeSnapshotStatus{
+						VolumeSnapshotName: volumeSnapshots[i].Name,
+					}
+					updatedContent.Status.VolumeSnapshotStatus = append(updatedContent.Status.VolumeSnapshotStatus, vss)
+				}
+
+				storageClassSource.Add(storageClass)
+				volumeSnapshotClassSource.Add(volumeSnapshotClass)
+				for i := range pvcs {
+					pvcSource.Add(&pvcs[i])
+				}
+
+				gomock.InOrder(
+					vmiInterface.EXPECT().Freeze(vm.Name).Return(nil),
+					vmiInterface.EXPECT().UploadMemory(vm.Name, &v1.MemorySnapshotOptions{}).Return(&v1.MemorySnapshotUploadStatus{
+						Phase:       v1.MemorySnapshotUploadSucceeded,
+						MemorySaved: true,
+					}, nil),
+				)
+				expectVolumeSnapshotCreates(k8sSnapshotClient, volumeSnapshotClass.Name, vmSnapshotContent)
+				expectVMSnapshotContentUpdate(vmSnapshotClient, updatedContent)
+				addVirtualMachineSnapshot(vmSnapshot)
+				controller.processVMSnapshotContentWorkItem()
+				testutils.ExpectEvent(recorder, "SuccessfulVolumeSnapshotCreate")
+				testutils.ExpectEvent(recorder, "SuccessfulMemorySnapshotCreate")
+			})
+
+			DescribeTable("should upload memory to object storage", func(uploadStatus v1.MemorySnapshotUploadStatus, memorySnapshotStatus *snapshotv1.MemorySnapshotStatus) {
+				vm := createLockedVM()
+				storageClass := createStorageClass()
+				vmSnapshot := createVMSnapshotInProgress()
+				volumeSnapshotClass := &createVolumeSnapshotClasses()[0]
+				pvcs := createPersistentVolumeClaims()
+				vmSnapshotContent := createVMSnapshotContent()
+				vmSnapshotContent.UID = contentUID
+				vmSnapshotContent.Spec.MemoryBackup = createObjectStorageMemoryBackup(vmSnapshot)
+				vmSource.Add(vm)
+				vmSnapshotContentSource.Add(vmSnapshotContent)
+				vmiSource.Add(createVMI(vm))
+
+				updatedContent := vmSnapshotContent.DeepCopy()
+				updatedContent.ResourceVersion = "1"
+				updatedContent.Status = &snapshotv1.VirtualMachineSnapshotContentStatus{
+					ReadyToUse:           &f,
+					MemorySnapshotStatus: memorySnapshotStatus,
+				}
+
+				volumeSnapshots := createVolumeSnapshots(vmSnapshotContent)
+				for i := range volumeSnapshots {
+					vss := snapshotv1.VolumeSnapshotStatus{
+						VolumeSnapshotName: volumeSnapshots[i].Name,
+					}
+					updatedContent.Status.VolumeSnapshotStatus = append(updatedContent.Status.VolumeSnapshotStatus, vss)
+				}
+
+				storageClassSource.Add(storageClass)
+				volumeSnapshotClassSource.Add(volumeSnapshotClass)
+				for i := range pvcs {
+					pvcSource.Add(&pvcs[i])
+				}
+
+				vmiInterface.EXPECT().UploadMemory(vm.Name, &v1.MemorySnapshotOptions{
+					ObjectStorage: &v1.MemorySnapshotObjectStorage{
+						Endpoint: "https://s3.example.com",
+						Bucket:   "snapshots",
+						Key:      vmSnapshotContent.Spec.MemoryBackup.ObjectKey,
+					},
+				}).Return(&uploadStatus, nil)
+				expectVolumeSnapshotCreates(k8sSnapshotClient, volumeSnapshotClass.Name, vmSnapshotContent)
+				expectVMSnapshotContentUpdate(vmSnapshotClient, updatedContent)
+				addVirtualMachineSnapshot(vmSnapshot)
+				controller.processVMSnapshotContentWorkItem()
+				testutils.ExpectEvent(recorder, "SuccessfulVolumeSnapshotCreate")
+				if uploadStatus.Phase == v1.MemorySnapshotUploadSucceeded {
+					testutils.ExpectEvent(recorder, "SuccessfulMemorySnapshotCreate")
+				}
+			},
+				Entry("and wait while it is in progress",
+					v1.MemorySnapshotUploadStatus{Phase: v1.MemorySnapshotUploadInProgress},
+					&snapshotv1.MemorySnapshotStatus{ReadyToUse: &f},
+				),
+				Entry("and record its checksum once it succeeded",
+					v1.MemorySnapshotUploadStatus{Phase: v1.MemorySnapshotUploadSucceeded, Checksum: "abc-1"},
+					&snapshotv1.MemorySnapshotStatus{CreationTime: timeFunc(), ReadyToUse: &t, Checksum: &checksum},
+				),
+			)
+
+			It("should start the upload with the credentials of the Secret once they are required", func() {
+				vm := createLockedVM()
+				storageClass := createStorageClass()
+				vmSnapshot := createVMSnapshotInProgress()
+				volumeSnapshotClass := &createVolumeSnapshotClasses()[0]
+				pvcs := createPersistentVolumeClaims()
+				vmSnapshotContent := createVMSnapshotContent()
+				vmSnapshotContent.UID = contentUID
+				vmSnapshotContent.Spec.MemoryBackup = createObjectStorageMemoryBackup(vmSnapshot)
+				vmSnapshot.Spec.Memory = &snapshotv1.MemorySnapshotSpec{ObjectStorage: vmSnapshotContent.Spec.MemoryBackup.ObjectStorage}
+				vmSource.Add(vm)
+				vmSnapshotContentSource.Add(vmSnapshotContent)
+				vmiSource.Add(createVMI(vm))
+
+				k8sClient.Fake.PrependReactor("get", "secrets", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+					get, ok := action.(testing.GetAction)
+					Expect(ok).To(BeTrue())
+					Expect(get.GetName()).To(Equal("s3-credentials"))
+
+					return true, &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      get.GetName(),
+							Namespace: get.GetNamespace(),
+						},
+						Data: map[string][]byte{
+							"accessKeyId":     []byte("access"),
+							"secretAccessKey": []byte("secret"),
+						},
+					}, nil
+				})
+
+				updatedContent := vmSnapshotContent.DeepCopy()
+				updatedContent.ResourceVersion = "1"
+				updatedContent.Status = &snapshotv1.VirtualMachineSnapshotContentStatus{
+					ReadyToUse:           &f,
+					MemorySnapshotStatus: &snapshotv1.MemorySnapshotStatus{ReadyToUse: &f},
+				}
+
+				volumeSnapshots := createVolumeSnapshots(vmSnapshotContent)
+				for i := range volumeSnapshots {
+					vss := snapshotv1.VolumeSnapshotStatus{
+						VolumeSnapshotName: volumeSnapshots[i].Name,
+					}
+					updatedContent.Status.VolumeSnapshotStatus = append(updatedContent.Status.VolumeSnapshotStatus, vss)
+				}
+
+				storageClassSource.Add(storageClass)
+				volumeSnapshotClassSource.Add(volumeSnapshotClass)
+				for i := range pvcs {
+					pvcSource.Add(&pvcs[i])
+				}
+
+				gomock.InOrder(
+					vmiInterface.EXPECT().UploadMemory(vm.Name, &v1.MemorySnapshotOptions{
+						ObjectStorage: &v1.MemorySnapshotObjectStorage{
+							Endpoint: "https://s3.example.com",
+							Bucket:   "snapshots",
+							Key:      vmSnapshotContent.Spec.MemoryBackup.ObjectKey,
+						},
+					}).Return(&v1.MemorySnapshotUploadStatus{Phase: v1.MemorySnapshotUploadPending}, nil),
+					vmiInterface.EXPECT().UploadMemory(vm.Name, &v1.MemorySnapshotOptions{
+						ObjectStorage: &v1.MemorySnapshotObjectStorage{
+							Endpoint:        "https://s3.example.com",
+							Bucket:          "snapshots",
+							Key:             vmSnapshotContent.Spec.MemoryBackup.ObjectKey,
+							AccessKeyID:     "access",
+							SecretAccessKey: "secret",
+						},
+					}).Return(&v1.MemorySnapshotUploadStatus{Phase: v1.MemorySnapshotUploadInProgress}, nil),
+				)
+				expectVolumeSnapshotCreates(k8sSnapshotClient, volumeSnapshotClass.Name, vmSnapshotContent)
+				expectVMSnapshotContentUpdate(vmSnapshotClient, updatedContent)
+				addVirtualMachineSnapshot(vmSnapshot)
+				controller.processVMSnapshotContentWorkItem()
+				testutils.ExpectEvent(recorder, "SuccessfulVolumeSnapshotCreate")
+			})
+
+			It("should unfreeze the guest once the memory was saved while it is still uploaded", func() {
+				vm := createLockedVM()
+				vmSnapshot := createVMSnapshotInProgress()
+				vmSnapshotContent := createVMSnapshotContent()
+				vmSnapshotContent.UID = contentUID
+				vmSnapshotContent.Spec.MemoryBackup = createObjectStorageMemoryBackup(vmSnapshot)
+				vmSource.Add(vm)
+				vmSnapshotSource.Add(vmSnapshot)
+				vmSnapshotContentSource.Add(vmSnapshotContent)
+
+				vmi := createVMI(vm)
+				vmi.Status.FSFreezeStatus = launcherapi.FSFrozen
+				vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{
+					Type:          v1.VirtualMachineInstanceAgentConnected,
+					LastProbeTime: metav1.Now(),
+					Status:        corev1.ConditionTrue,
+				})
+				vmiSource.Add(vmi)
+
+				updatedContent := vmSnapshotContent.DeepCopy()
+				updatedContent.ResourceVersion = "1"
+				updatedContent.Status = &snapshotv1.VirtualMachineSnapshotContentStatus{
+					ReadyToUse:           &f,
+					MemorySnapshotStatus: &snapshotv1.MemorySnapshotStatus{ReadyToUse: &f},
+				}
+
+				volumeSnapshots := createVolumeSnapshots(vmSnapshotContent)
+				for i := range volumeSnapshots {
+					volumeSnapshots[i].Status.ReadyToUse = &t
+					volumeSnapshots[i].Status.CreationTime = timeFunc()
+					addVolumeSnapshot(&volumeSnapshots[i])
+
+					vss := snapshotv1.VolumeSnapshotStatus{
+						VolumeSnapshotName: volumeSnapshots[i].Name,
+						ReadyToUse:         volumeSnapshots[i].Status.ReadyToUse,
+						CreationTime:       volumeSnapshots[i].Status.CreationTime,
+					}
+					updatedContent.Status.VolumeSnapshotStatus = append(updatedContent.Status.VolumeSnapshotStatus, vss)
+				}
+
+				vmiInterface.EXPECT().UploadMemory(vm.Name, gomock.Any()).Return(&v1.MemorySnapshotUploadStatus{
+					Phase:       v1.MemorySnapshotUploadInProgress,
+					MemorySaved: true,
+				}, nil)
+				vmiInterface.EXPECT().Unfreeze(vm.Name).Return(nil)
+				expectVMSnapshotContentUpdate(vmSnapshotClient, updatedContent)
+				controller.processVMSnapshotContentWorkItem()
+			})
+
+			It("should freeze the guest before saving the memory when the volume snapshots exist already", func() {
+				vm := createLockedVM()
+				vmSnapshot := createVMSnapshotInProgress()
+				vmSnapshotContent := createVMSnapshotContent()
+				vmSnapshotContent.UID = contentUID
+				vmSnapshotContent.Spec.MemoryBackup = createObjectStorageMemoryBackup(vmSnapshot)
+				vmSource.Add(vm)
+				vmSnapshotSource.Add(vmSnapshot)
+				vmSnapshotContentSource.Add(vmSnapshotContent)
+
+				vmi := createVMI(vm)
+				vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{
+					Type:          v1.VirtualMachineInstanceAgentConnected,
+					LastProbeTime: metav1.Now(),
+					Status:        corev1.ConditionTrue,
+				})
+				vmiSource.Add(vmi)
+
+				updatedContent := vmSnapshotContent.DeepCopy()
+				updatedContent.ResourceVersion = "1"
+				updatedContent.Status = &snapshotv1.VirtualMachineSnapshotContentStatus{
+					ReadyToUse:           &f,
+					MemorySnapshotStatus: &snapshotv1.MemorySnapshotStatus{ReadyToUse: &f},
+				}
+
+				volumeSnapshots := createVolumeSnapshots(vmSnapshotContent)
+				for i := range volumeSnapshots {
+					addVolumeSnapshot(&volumeSnapshots[i])
+
+					vss := snapshotv1.VolumeSnapshotStatus{
+						VolumeSnapshotName: volumeSnapshots[i].Name,
+						ReadyToUse:         volumeSnapshots[i].Status.ReadyToUse,
+					}
+					updatedContent.Status.VolumeSnapshotStatus = append(updatedContent.Status.VolumeSnapshotStatus, vss)
+				}
+
+				gomock.InOrder(
+					vmiInterface.EXPECT().Freeze(vm.Name).Return(nil),
+					vmiInterface.EXPECT().UploadMemory(vm.Name, gomock.Any()).Return(&v1.MemorySnapshotUploadStatus{
+						Phase: v1.MemorySnapshotUploadInProgress,
+					}, nil),
+				)
+				expectVMSnapshotContentUpdate(vmSnapshotClient, updatedContent)
+				controller.processVMSnapshotContentWorkItem()
+			})
+
 			It("should freeze vm with online snapshot and guest agent", func() {
 				storageClass := createStorageClass()
 				vmSnapshot := createVMSnapshotInProgress()
@@ -1940,6 +2345,17 @@ func expectVMSnapshotContentDelete(client *kubevirtfake.Clientset, name string)
 	})
 }
 
+func expectVMSnapshotDelete(client *kubevirtfake.Clientset, name string) {
+	client.Fake.PrependReactor("delete", "virtualmachinesnapshots", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+		delete, ok := action.(testing.DeleteAction)
+		Expect(ok).To(BeTrue())
+
+		Expect(delete.GetName()).To(Equal(name))
+
+		return true, nil, nil
+	})
+}
+
 func expectVolumeSnapshotCreates(
 	client *k8ssnapshotfake.Clientset,
 	voluemSnapshotClass string,
@@ -2100,6 +2516,41 @@ func createVirtualMachineSnapshotContent(vmSnapshot *snapshotv1.VirtualMachineSn
 	}
 }
 
+func createMemoryBackup(vmSnapshot *snapshotv1.VirtualMachineSnapshot) *snapshotv1.MemoryBackup {
+	volumeMode := corev1.PersistentVolumeFilesystem
+	return &snapshotv1.MemoryBackup{
+		VolumeName: "snapshot-memory-dump",
+		PersistentVolumeClaim: &snapshotv1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("vmsnapshot-%s-memory", vmSnapshot.UID),
+				Namespace: vmSnapshot.Namespace,
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: resource.MustParse("164M"),
+					},
+				},
+				VolumeMode: &volumeMode,
+			},
+		},
+	}
+}
+
+func createObjectStorageMemoryBackup(vmSnapshot *snapshotv1.VirtualMachineSnapshot) *snapshotv1.MemoryBackup {
+	return &snapshotv1.MemoryBackup{
+		ObjectStorage: &snapshotv1.MemoryObjectStorage{
+			Endpoint: "https://s3.example.com",
+			Bucket:   "snapshots",
+			SecretRef: corev1.LocalObjectReference{
+				Name: "s3-credentials",
+			},
+		},
+		ObjectKey: fmt.Sprintf("vmsnapshot-%s-memory", vmSnapshot.UID),
+	}
+}
+
 func createPVCsForVM(vm *v1.VirtualMachine) []corev1.PersistentVolumeClaim {
 	var pvcs []corev1.PersistentVolumeClaim
 	for i, dv := range vm.Spec.DataVolumeTemplates {
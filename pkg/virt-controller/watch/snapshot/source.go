@@ -25,10 +25,12 @@ import (
 	"reflect"
 	"time"
 
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 
 	kubevirtv1 "kubevirt.io/client-go/api/v1"
 	snapshotv1 "kubevirt.io/client-go/apis/snapshot/v1alpha1"
@@ -51,6 +53,10 @@ type snapshotSource interface {
 	Frozen() (bool, error)
 	Freeze() error
 	Unfreeze() error
+	GuestMemory() (*resource.Quantity, error)
+	AddMemoryDumpVolume(volumeName, claimName string) (bool, error)
+	RemoveMemoryDumpVolume(volumeName string) error
+	UploadMemory(options *kubevirtv1.MemorySnapshotOptions) (*kubevirtv1.MemorySnapshotUploadStatus, error)
 	Spec() (snapshotv1.SourceSpec, error)
 	PersistentVolumeClaims() (map[string]string, error)
 }
@@ -258,6 +264,11 @@ func (s *vmSnapshotSource) GuestAgent() (bool, error) {
 		return false, err
 	}
 
+	if controller.GuestAgentFeatureUnavailable(vmi, kubevirtv1.GuestAgentFeatureFSFreeze) {
+		log.Log.Object(vmi).Warning("Guest agent doesn't support freezing the filesystems, the snapshot won't be consistent")
+		return false, nil
+	}
+
 	return condManager.HasCondition(vmi, kubevirtv1.VirtualMachineInstanceAgentConnected), nil
 }
 
@@ -313,6 +324,133 @@ func (s *vmSnapshotSource) Unfreeze() error {
 	return nil
 }
 
+func (s *vmSnapshotSource) GuestMemory() (*resource.Quantity, error) {
+	vmi, exists, err := s.controller.getVMI(s.vm)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("can't get guest memory, vmi doesn't exist")
+	}
+
+	if vmi.Spec.Domain.Memory != nil && vmi.Spec.Domain.Memory.Guest != nil {
+		return vmi.Spec.Domain.Memory.Guest, nil
+	}
+
+	if memory, ok := vmi.Spec.Domain.Resources.Requests[corev1.ResourceMemory]; ok {
+		return &memory, nil
+	}
+
+	return nil, fmt.Errorf("can't get guest memory of vmi %s", vmi.Name)
+}
+
+// AddMemoryDumpVolume hotplugs the memory dump volume into the vmi and
+// returns true once it is mounted into the virt-launcher pod
+func (s *vmSnapshotSource) AddMemoryDumpVolume(volumeName, claimName string) (bool, error) {
+	if !s.Locked() {
+		return false, fmt.Errorf("attempting to add memory dump volume to unlocked VM")
+	}
+
+	vmi, exists, err := s.controller.getVMI(s.vm)
+	if err != nil {
+		return false, err
+	}
+	if !exists {
+		return false, fmt.Errorf("can't add memory dump volume, vmi doesn't exist")
+	}
+
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.Name != volumeName {
+			continue
+		}
+
+		for _, volumeStatus := range vmi.Status.VolumeStatus {
+			if volumeStatus.Name == volumeName {
+				return volumeStatus.Phase == kubevirtv1.HotplugVolumeMounted, nil
+			}
+		}
+
+		return false, nil
+	}
+
+	log.Log.V(3).Infof("Adding memory dump volume %s to vmi %s", volumeName, vmi.Name)
+
+	volumes := append(vmi.Spec.DeepCopy().Volumes, kubevirtv1.Volume{
+		Name: volumeName,
+		VolumeSource: kubevirtv1.VolumeSource{
+			MemoryDump: &kubevirtv1.MemoryDumpVolumeSource{
+				PersistentVolumeClaimVolumeSource: kubevirtv1.PersistentVolumeClaimVolumeSource{
+					PersistentVolumeClaimVolumeSource: corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: claimName,
+					},
+					Hotpluggable: true,
+				},
+			},
+		},
+	})
+
+	return false, s.patchVMIVolumes(vmi, volumes)
+}
+
+func (s *vmSnapshotSource) RemoveMemoryDumpVolume(volumeName string) error {
+	if !s.Locked() {
+		return nil
+	}
+
+	vmi, exists, err := s.controller.getVMI(s.vm)
+	if err != nil || !exists {
+		return err
+	}
+
+	volumes := []kubevirtv1.Volume{}
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.Name != volumeName {
+			volumes = append(volumes, volume)
+		}
+	}
+
+	if len(volumes) == len(vmi.Spec.Volumes) {
+		return nil
+	}
+
+	log.Log.V(3).Infof("Removing memory dump volume %s from vmi %s", volumeName, vmi.Name)
+
+	return s.patchVMIVolumes(vmi, volumes)
+}
+
+func (s *vmSnapshotSource) patchVMIVolumes(vmi *kubevirtv1.VirtualMachineInstance, volumes []kubevirtv1.Volume) error {
+	oldVolumesJson, err := json.Marshal(vmi.Spec.Volumes)
+	if err != nil {
+		return err
+	}
+
+	newVolumesJson, err := json.Marshal(volumes)
+	if err != nil {
+		return err
+	}
+
+	var ops []string
+	if len(vmi.Spec.Volumes) > 0 {
+		ops = append(ops,
+			fmt.Sprintf(`{ "op": "test", "path": "/spec/volumes", "value": %s}`, string(oldVolumesJson)),
+			fmt.Sprintf(`{ "op": "replace", "path": "/spec/volumes", "value": %s}`, string(newVolumesJson)),
+		)
+	} else {
+		ops = append(ops, fmt.Sprintf(`{ "op": "add", "path": "/spec/volumes", "value": %s}`, string(newVolumesJson)))
+	}
+
+	_, err = s.controller.Client.VirtualMachineInstance(vmi.Namespace).Patch(vmi.Name, types.JSONPatchType, controller.GeneratePatchBytes(ops))
+	return err
+}
+
+func (s *vmSnapshotSource) UploadMemory(options *kubevirtv1.MemorySnapshotOptions) (*kubevirtv1.MemorySnapshotUploadStatus, error) {
+	if !s.Locked() {
+		return nil, fmt.Errorf("attempting to upload memory of unlocked VM")
+	}
+
+	return s.controller.Client.VirtualMachineInstance(s.vm.Namespace).UploadMemory(s.vm.Name, options)
+}
+
 func (s *vmSnapshotSource) PersistentVolumeClaims() (map[string]string, error) {
 	vm := s.vm
 	online, err := s.Online()
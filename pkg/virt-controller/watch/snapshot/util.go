@@ -20,7 +20,9 @@
 package snapshot
 
 import (
+	"crypto/rand"
 	"fmt"
+	"net"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -67,6 +69,15 @@ func newProgressingCondition(status corev1.ConditionStatus, reason string) snaps
 	}
 }
 
+func newMemorySnapshotCompletedCondition(status corev1.ConditionStatus, reason string) snapshotv1.Condition {
+	return snapshotv1.Condition{
+		Type:               snapshotv1.ConditionMemorySnapshotCompleted,
+		Status:             status,
+		Reason:             reason,
+		LastTransitionTime: *currentTime(),
+	}
+}
+
 func newFailureCondition(status corev1.ConditionStatus, reason string) snapshotv1.Condition {
 	return snapshotv1.Condition{
 		Type:               snapshotv1.ConditionFailure,
@@ -183,3 +194,28 @@ func timeUntilDeadline(vmSnapshot *snapshotv1.VirtualMachineSnapshot) time.Durat
 	deadline := vmSnapshot.CreationTimestamp.Add(failureDeadline)
 	return time.Until(deadline)
 }
+
+func timeUntilExpiry(vmSnapshot *snapshotv1.VirtualMachineSnapshot) time.Duration {
+	if vmSnapshot.Spec.TTL == nil || vmSnapshot.Status == nil || vmSnapshot.Status.CreationTime == nil {
+		return 0
+	}
+	expiry := vmSnapshot.Status.CreationTime.Add(vmSnapshot.Spec.TTL.Duration)
+	return time.Until(expiry)
+}
+
+// generateMACAddress returns a random locally administered unicast MAC address
+// which is not in use yet and marks it as used
+func generateMACAddress(usedMACs map[string]bool) (string, error) {
+	for {
+		mac := make(net.HardwareAddr, 6)
+		if _, err := rand.Read(mac); err != nil {
+			return "", err
+		}
+		mac[0] = (mac[0] | 0x02) &^ 0x01
+
+		if !usedMACs[mac.String()] {
+			usedMACs[mac.String()] = true
+			return mac.String(), nil
+		}
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"math"
 	"math/rand"
 	"reflect"
+	"sort"
 	"strings"
 	"time"
 
@@ -35,6 +36,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	authv1 "k8s.io/api/authorization/v1"
 	k8score "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -49,9 +51,13 @@ import (
 	"kubevirt.io/client-go/log"
 	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1beta1"
 	cdiclone "kubevirt.io/containerized-data-importer/pkg/clone"
+	containerdisk "kubevirt.io/kubevirt/pkg/container-disk"
 	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/util/conditions"
 	"kubevirt.io/kubevirt/pkg/util/status"
 	typesutil "kubevirt.io/kubevirt/pkg/util/types"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+	"kubevirt.io/kubevirt/pkg/virt-controller/services"
 )
 
 type CloneAuthFunc func(pvcNamespace, pvcName, saNamespace, saName string) (bool, string, error)
@@ -66,15 +72,36 @@ const (
 	failureDeletingVmiErrFormat           = "Failure attempting to delete VMI: %v"
 )
 
+const (
+	// FailedApplyChangesReason is added in an event if a change of the template can't be applied to the running VMI
+	FailedApplyChangesReason = "FailedApplyChanges"
+	// SuccessfulApplyChangesReason is added in an event if a change of the template is applied to the running VMI
+	SuccessfulApplyChangesReason = "SuccessfulApplyChanges"
+)
+
 const defaultMaxCrashLoopBackoffDelaySeconds = 300
 
+// firstBootVolumesPollInterval is how often a VM waiting on its volumes before the first boot is checked again,
+// changes of volumes which the VM doesn't own don't enqueue it
+const firstBootVolumesPollInterval = 10 * time.Second
+
+// cdiPodPhaseAnnotation is set by the Containerized Data Importer on the claims it populates
+const cdiPodPhaseAnnotation = "cdi.kubevirt.io/storage.pod.phase"
+
+const (
+	// a guest is considered to be crash looping once its VMIs failed this many times within the window
+	crashLoopGuestFailureThreshold = 3
+	crashLoopGuestFailureWindow    = 10 * time.Minute
+)
+
 func NewVMController(vmiInformer cache.SharedIndexInformer,
 	vmInformer cache.SharedIndexInformer,
 	dataVolumeInformer cache.SharedIndexInformer,
 	pvcInformer cache.SharedIndexInformer,
 	crInformer cache.SharedIndexInformer,
 	recorder record.EventRecorder,
-	clientset kubecli.KubevirtClient) *VMController {
+	clientset kubecli.KubevirtClient,
+	clusterConfig *virtconfig.ClusterConfig) *VMController {
 
 	proxy := &sarProxy{client: clientset}
 
@@ -93,7 +120,11 @@ func NewVMController(vmiInformer cache.SharedIndexInformer,
 			return cdiclone.CanServiceAccountClonePVC(proxy, pvcNamespace, pvcName, saNamespace, saName)
 		},
 		statusUpdater: status.NewVMStatusUpdater(clientset),
+		clusterConfig: clusterConfig,
 	}
+	c.digestPinner = newImageDigestPinner(containerdisk.NewImageDigestResolver(), func(vmKey string) {
+		c.Queue.Add(vmKey)
+	})
 
 	c.vmInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.addVirtualMachine,
@@ -137,6 +168,8 @@ type VMController struct {
 	dataVolumeExpectations *controller.UIDTrackingControllerExpectations
 	cloneAuthFunc          CloneAuthFunc
 	statusUpdater          *status.VMStatusUpdater
+	clusterConfig          *virtconfig.ClusterConfig
+	digestPinner           *imageDigestPinner
 }
 
 func (c *VMController) Run(threadiness int, stopCh <-chan struct{}) {
@@ -190,6 +223,7 @@ func (c *VMController) execute(key string) error {
 	if !exists {
 		// nothing we need to do. It should always be possible to re-create this type of controller
 		c.expectations.DeleteExpectations(key)
+		c.digestPinner.forget(key)
 		return nil
 	}
 	vm := obj.(*virtv1.VirtualMachine)
@@ -258,6 +292,27 @@ func (c *VMController) execute(key string) error {
 		}
 	}
 
+	if marked, err := c.markFirstBootCompleted(vm, vmi); marked || err != nil {
+		if err != nil {
+			logger.Reason(err).Error("Marking the first boot as completed failed")
+		}
+		return err
+	}
+
+	if marked, err := c.markMemoryRestored(vm, vmi); marked || err != nil {
+		if err != nil {
+			logger.Reason(err).Error("Marking the memory state as restored failed")
+		}
+		return err
+	}
+
+	if deleted, err := c.deleteExpiredVM(vm, vmi, key); deleted || err != nil {
+		if err != nil {
+			logger.Reason(err).Error("Deleting the finished VirtualMachine failed")
+		}
+		return err
+	}
+
 	dataVolumes, err := c.listDataVolumesForVM(vm)
 	if err != nil {
 		logger.Reason(err).Error("Failed to fetch dataVolumes for namespace from cache.")
@@ -281,6 +336,9 @@ func (c *VMController) execute(key string) error {
 		}
 
 		dataVolumesReady, err := c.handleDataVolumes(vm, dataVolumes)
+		if err == nil && dataVolumesReady && vmi == nil {
+			dataVolumesReady = c.areFirstBootVolumesReady(vm, key)
+		}
 		if err != nil {
 			createErr = err
 		} else if dataVolumesReady || runStrategy == virtv1.RunStrategyHalted {
@@ -296,6 +354,10 @@ func (c *VMController) execute(key string) error {
 
 			createErr = c.handleVolumeRequests(vm, vmi)
 		}
+
+		if c.needsSync(key) && createErr == nil {
+			createErr = c.applyPendingChanges(vm, vmi)
+		}
 	}
 
 	if createErr != nil {
@@ -493,6 +555,192 @@ func (c *VMController) arePVCVolumesReady(vm *virtv1.VirtualMachine) bool {
 	return true
 }
 
+// isFirstBootPending determines whether the VM has first boot annotations which didn't apply yet
+func isFirstBootPending(vm *virtv1.VirtualMachine) bool {
+	if vm.Annotations[virtv1.FirstBootCompletedAnnotation] == "true" {
+		return false
+	}
+	_, hasBootOrder := vm.Annotations[virtv1.FirstBootOrderAnnotation]
+	return hasBootOrder || vm.Annotations[virtv1.FirstBootWaitForVolumesAnnotation] == "true"
+}
+
+// areFirstBootVolumesReady determines whether all volumes of a VM, which waits on them before its first boot,
+// are populated. DataVolumes and claims waiting for their first consumer are regarded as ready,
+// since the VMI is their consumer.
+func (c *VMController) areFirstBootVolumesReady(vm *virtv1.VirtualMachine, key string) bool {
+	if !isFirstBootPending(vm) || vm.Annotations[virtv1.FirstBootWaitForVolumesAnnotation] != "true" {
+		return true
+	}
+
+	for _, volume := range vm.Spec.Template.Spec.Volumes {
+		var ready bool
+		var err error
+		switch {
+		case volume.DataVolume != nil:
+			ready, err = c.isDataVolumePopulated(vm.Namespace, volume.DataVolume.Name)
+		case volume.PersistentVolumeClaim != nil:
+			ready, err = c.isPVCPopulated(vm.Namespace, volume.PersistentVolumeClaim.ClaimName)
+		default:
+			continue
+		}
+		if err != nil {
+			log.Log.Object(vm).Reason(err).Errorf("Error fetching volume %s", volume.Name)
+		}
+		if !ready {
+			log.Log.Object(vm).V(3).Infof("Waiting on volume %s to be populated before the first boot", volume.Name)
+			c.Queue.AddAfter(key, firstBootVolumesPollInterval)
+			return false
+		}
+	}
+	return true
+}
+
+func (c *VMController) isDataVolumePopulated(namespace string, name string) (bool, error) {
+	obj, exists, err := c.dataVolumeInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", namespace, name))
+	if err != nil || !exists {
+		return false, err
+	}
+	dv := obj.(*cdiv1.DataVolume)
+	return dv.Status.Phase == cdiv1.Succeeded || dv.Status.Phase == cdiv1.WaitForFirstConsumer, nil
+}
+
+func (c *VMController) isPVCPopulated(namespace string, name string) (bool, error) {
+	obj, exists, err := c.pvcInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", namespace, name))
+	if err != nil || !exists {
+		return false, err
+	}
+	pvc := obj.(*k8score.PersistentVolumeClaim)
+	phase, populatedByCDI := pvc.Annotations[cdiPodPhaseAnnotation]
+	return !populatedByCDI || phase == string(k8score.PodSucceeded), nil
+}
+
+// markFirstBootCompleted annotates the VM once its first VMI ran, so that the first boot annotations
+// don't apply to later VMIs. It returns true if the VM was patched.
+func (c *VMController) markFirstBootCompleted(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) (bool, error) {
+	if !isFirstBootPending(vm) || !wasVMIInRunningPhase(vmi) {
+		return false, nil
+	}
+
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{"%s":"true"}}}`, virtv1.FirstBootCompletedAnnotation)
+	_, err := c.clientset.VirtualMachine(vm.Namespace).Patch(vm.Name, types.MergePatchType, []byte(patch))
+	if err != nil {
+		return false, err
+	}
+	log.Log.Object(vm).Info("First boot of the VM completed")
+	return true, nil
+}
+
+// markMemoryRestored removes the restore memory annotation from the VM once the VMI which resumed from the
+// memory state ran, so that later VMIs boot. It returns true if the VM was patched.
+func (c *VMController) markMemoryRestored(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) (bool, error) {
+	claimName, exists := vm.Annotations[virtv1.RestoreMemoryClaimAnnotation]
+	if !exists || vmi == nil || vmi.Annotations[virtv1.RestoreMemoryClaimAnnotation] != claimName || !wasVMIInRunningPhase(vmi) {
+		return false, nil
+	}
+
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{"%s":null}}}`, virtv1.RestoreMemoryClaimAnnotation)
+	_, err := c.clientset.VirtualMachine(vm.Namespace).Patch(vm.Name, types.MergePatchType, []byte(patch))
+	if err != nil {
+		return false, err
+	}
+	log.Log.Object(vm).Infof("Resumed the VM from the memory state in %s", claimName)
+	return true, nil
+}
+
+// deleteExpiredVM deletes a VM with runStrategy Once once ttlSecondsAfterFinished passed after its VMI
+// finished. The VMI and the DataVolumes of the dataVolumeTemplates are garbage collected with the VM.
+func (c *VMController) deleteExpiredVM(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance, key string) (bool, error) {
+	if vm.Spec.TTLSecondsAfterFinished == nil || vm.DeletionTimestamp != nil || vmi == nil || !vmi.IsFinal() {
+		return false, nil
+	}
+	if runStrategy, err := vm.RunStrategy(); err != nil || runStrategy != virtv1.RunStrategyOnce {
+		return false, nil
+	}
+
+	ttl := time.Duration(*vm.Spec.TTLSecondsAfterFinished) * time.Second
+	if timeLeft := time.Until(vmiFinishedTimestamp(vmi).Add(ttl)); timeLeft > 0 {
+		c.Queue.AddAfter(key, timeLeft)
+		return false, nil
+	}
+
+	propagationPolicy := v1.DeletePropagationBackground
+	err := c.clientset.VirtualMachine(vm.Namespace).Delete(vm.Name, &v1.DeleteOptions{
+		PropagationPolicy: &propagationPolicy,
+		Preconditions:     &v1.Preconditions{UID: &vm.UID},
+	})
+	if err != nil && !errors.IsNotFound(err) {
+		return false, err
+	}
+	log.Log.Object(vm).Infof("Deleted the VirtualMachine %d seconds after its VMI finished", *vm.Spec.TTLSecondsAfterFinished)
+	return true, nil
+}
+
+// vmiFinishedTimestamp returns when the VMI entered its final phase
+func vmiFinishedTimestamp(vmi *virtv1.VirtualMachineInstance) time.Time {
+	finished := vmi.CreationTimestamp.Time
+	for _, transition := range vmi.Status.PhaseTransitionTimestamps {
+		if transition.Phase == vmi.Status.Phase && transition.PhaseTransitionTimestamp.Time.After(finished) {
+			finished = transition.PhaseTransitionTimestamp.Time
+		}
+	}
+	return finished
+}
+
+// applyFirstBootOrder boots the disks listed by the first boot order annotation in their order,
+// all other disks and interfaces don't boot
+func applyFirstBootOrder(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) {
+	bootOrderAnnotation, exists := vm.Annotations[virtv1.FirstBootOrderAnnotation]
+	if !exists || !isFirstBootPending(vm) {
+		return
+	}
+
+	bootOrders := map[string]uint{}
+	for i, name := range strings.Split(bootOrderAnnotation, ",") {
+		bootOrders[strings.TrimSpace(name)] = uint(i + 1)
+	}
+
+	// The slices are shared with the VM in the cache, only modify copies
+	if len(vmi.Spec.Domain.Devices.Disks) > 0 {
+		disks := make([]virtv1.Disk, len(vmi.Spec.Domain.Devices.Disks))
+		copy(disks, vmi.Spec.Domain.Devices.Disks)
+		for i := range disks {
+			disks[i].BootOrder = nil
+			if bootOrder, exists := bootOrders[disks[i].Name]; exists {
+				disks[i].BootOrder = &bootOrder
+			}
+		}
+		vmi.Spec.Domain.Devices.Disks = disks
+	}
+	if len(vmi.Spec.Domain.Devices.Interfaces) > 0 {
+		interfaces := make([]virtv1.Interface, len(vmi.Spec.Domain.Devices.Interfaces))
+		copy(interfaces, vmi.Spec.Domain.Devices.Interfaces)
+		for i := range interfaces {
+			interfaces[i].BootOrder = nil
+		}
+		vmi.Spec.Domain.Devices.Interfaces = interfaces
+	}
+
+	log.Log.Object(vm).Infof("Overriding the boot order of the first boot with %s", bootOrderAnnotation)
+}
+
+// applyRestoreMemory lets the VMI resume from the memory state of a restored snapshot
+func applyRestoreMemory(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) {
+	claimName, exists := vm.Annotations[virtv1.RestoreMemoryClaimAnnotation]
+	if !exists {
+		return
+	}
+
+	// The annotations are shared with the VM in the cache, only modify a copy
+	annotations := make(map[string]string, len(vmi.Annotations)+1)
+	for key, value := range vmi.Annotations {
+		annotations[key] = value
+	}
+	annotations[virtv1.RestoreMemoryClaimAnnotation] = claimName
+	vmi.Annotations = annotations
+
+	log.Log.Object(vm).Infof("Resuming the VM from the memory state in %s", claimName)
+}
+
 func (c *VMController) handleVolumeRequests(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) error {
 	if len(vm.Status.VolumeRequests) == 0 {
 		return nil
@@ -648,6 +896,16 @@ func (c *VMController) startStop(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualM
 		}
 		return nil
 
+	case virtv1.RunStrategyOnce:
+		// For this RunStrategy, a VMI is started once. It is not restarted when it
+		// finished, regardless if it succeeded or failed, or when it got deleted.
+		if vmi != nil || vm.Status.RunOnceStarted {
+			return nil
+		}
+
+		log.Log.Object(vm).Infof("%s due to runStrategy: %s", startingVmMsg, runStrategy)
+		return c.startVMI(vm)
+
 	case virtv1.RunStrategyManual:
 		// For this RunStrategy, VMI's will be started/stopped/restarted using api endpoints only
 		if vmi != nil {
@@ -753,8 +1011,20 @@ func (c *VMController) startVMI(vm *virtv1.VirtualMachine) error {
 		return nil
 	}
 
+	pinnedImages, pinned, err := c.pinnedContainerDiskImages(vm, vmKey)
+	if err != nil {
+		log.Log.Object(vm).Reason(err).Error("Failed to pin the containerDisk images of the VirtualMachine")
+		c.recorder.Eventf(vm, k8score.EventTypeWarning, FailedCreateVirtualMachineReason, "Error pinning the containerDisk images to their digest: %v", err)
+		return err
+	}
+	if !pinned {
+		log.Log.Object(vm).V(3).Info("Waiting on the containerDisk images to be pinned to their digest")
+		return nil
+	}
+
 	// start it
 	vmi := c.setupVMIFromVM(vm)
+	applyPinnedContainerDiskImages(vmi, pinnedImages)
 	vmRevisionName, err := c.createVMRevision(vm)
 	if err != nil {
 		log.Log.Object(vm).Reason(err).Error(failedCreateCRforVmErrMsg)
@@ -877,7 +1147,82 @@ func startFailureBackoffTimeLeft(vm *virtv1.VirtualMachine) int64 {
 	return 0
 }
 
+// Reports if vmi failed after it hit a running state
+func vmiFailedAfterRunning(vmi *virtv1.VirtualMachineInstance) bool {
+	return vmi != nil && vmi.Status.Phase == virtv1.Failed && wasVMIInRunningPhase(vmi)
+}
+
+// Reports if the run strategy of vm restarts failed VMIs
+func restartsFailedVMIs(vm *virtv1.VirtualMachine) bool {
+	runStrategy, err := vm.RunStrategy()
+	if err != nil {
+		log.Log.Object(vm).Errorf("Error fetching RunStrategy: %v", err)
+		return false
+	}
+	return runStrategy == virtv1.RunStrategyAlways || runStrategy == virtv1.RunStrategyRerunOnFailure
+}
+
+// syncGuestFailures records VMIs which failed after they were running. Once they failed
+// crashLoopGuestFailureThreshold times within crashLoopGuestFailureWindow, the guest is
+// considered to be crash looping and restarting it is backed off like a start failure.
+func syncGuestFailures(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) {
+	if !restartsFailedVMIs(vm) {
+		vm.Status.GuestFailures = nil
+		return
+	}
+	if vm.Status.GuestFailures != nil && vmi != nil && vm.Status.GuestFailures.LastFailedVMIUID == vmi.UID {
+		// already counted this failure
+		return
+	}
+
+	now := time.Now()
+	var failureTimestamps []v1.Time
+	if vm.Status.GuestFailures != nil {
+		for _, timestamp := range vm.Status.GuestFailures.FailureTimestamps {
+			if now.Sub(timestamp.Time) < crashLoopGuestFailureWindow {
+				failureTimestamps = append(failureTimestamps, timestamp)
+			}
+		}
+	}
+
+	if !vmiFailedAfterRunning(vmi) {
+		if len(failureTimestamps) == 0 {
+			vm.Status.GuestFailures = nil
+		} else {
+			vm.Status.GuestFailures.FailureTimestamps = failureTimestamps
+		}
+		return
+	}
+
+	failureTimestamps = append(failureTimestamps, v1.NewTime(now))
+	vm.Status.GuestFailures = &virtv1.VirtualMachineGuestFailures{
+		FailureTimestamps: failureTimestamps,
+		LastFailedVMIUID:  vmi.UID,
+	}
+	if len(failureTimestamps) < crashLoopGuestFailureThreshold {
+		vm.Status.StartFailure = nil
+		return
+	}
+
+	count := len(failureTimestamps) - crashLoopGuestFailureThreshold + 1
+	delaySeconds := calculateStartBackoffTime(count, defaultMaxCrashLoopBackoffDelaySeconds)
+	retryAfter := v1.NewTime(now.Add(time.Duration(int64(delaySeconds)) * time.Second))
+	log.Log.Object(vm).Infof("Guest failed %d times within %s, backing off restarting it for %d seconds", len(failureTimestamps), crashLoopGuestFailureWindow, delaySeconds)
+
+	vm.Status.StartFailure = &virtv1.VirtualMachineStartFailure{
+		LastFailedVMIUID:     vmi.UID,
+		RetryAfterTimestamp:  &retryAfter,
+		ConsecutiveFailCount: count,
+	}
+}
+
 func syncStartFailureStatus(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) {
+	syncGuestFailures(vm, vmi)
+	if vmiFailedAfterRunning(vmi) && vm.Status.GuestFailures != nil && vm.Status.GuestFailures.LastFailedVMIUID == vmi.UID {
+		// the start failure tracking was already updated with the guest failures
+		return
+	}
+
 	if shouldClearStartFailure(vm, vmi) {
 		// if a vmi associated with the vm hits a running phase, then reset the start failure counter
 		vm.Status.StartFailure = nil
@@ -1025,6 +1370,101 @@ func (c *VMController) createVMRevision(vm *virtv1.VirtualMachine) (string, erro
 	return cr.Name, nil
 }
 
+// pinnedContainerDiskImages returns the images of the containerDisks with the Pin digest policy, which are still
+// referenced by tag, pinned to the digest of their manifest by volume name. The images recorded in the status of the
+// VM are reused, so that all later starts boot the same image, the others are resolved in the background on the
+// mirror the launcher pod pulls the image from. It returns false while lookups are still running.
+func (c *VMController) pinnedContainerDiskImages(vm *virtv1.VirtualMachine, vmKey string) (map[string]string, bool, error) {
+	pinnedImages := map[string]string{}
+	pinned := true
+	for _, volume := range vm.Spec.Template.Spec.Volumes {
+		if !needsImageDigestPin(volume) {
+			continue
+		}
+		if image := recordedPinnedImage(vm, volume); image != "" {
+			pinnedImages[volume.Name] = image
+			continue
+		}
+
+		image := volume.ContainerDisk.Image
+		mirroredImage := services.GetMirroredImage(image, c.clusterConfig.GetImageRegistryMirrors())
+		pinnedImage, done, err := c.digestPinner.pinnedImage(vmKey, volume.Name, image, mirroredImage)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to resolve the image of containerDisk %s: %v", volume.Name, err)
+		}
+		if !done {
+			pinned = false
+			continue
+		}
+		pinnedImages[volume.Name] = pinnedImage
+	}
+	return pinnedImages, pinned, nil
+}
+
+func needsImageDigestPin(volume virtv1.Volume) bool {
+	return volume.ContainerDisk != nil &&
+		volume.ContainerDisk.ImageDigestPolicy == virtv1.ContainerDiskImageDigestPolicyPin &&
+		containerdisk.ImageDigest(volume.ContainerDisk.Image) == ""
+}
+
+// recordedPinnedImage returns the pinned image of the volume from the status of the VM, as long as the image of the
+// volume didn't change since it was pinned
+func recordedPinnedImage(vm *virtv1.VirtualMachine, volume virtv1.Volume) string {
+	for _, pinned := range vm.Status.PinnedContainerDiskImages {
+		if pinned.VolumeName == volume.Name && pinned.Image == volume.ContainerDisk.Image {
+			return pinned.PinnedImage
+		}
+	}
+	return ""
+}
+
+// applyPinnedContainerDiskImages replaces the images of the containerDisks of the VMI with their pinned images, the
+// volumes are copied as they are shared with the template of the VM
+func applyPinnedContainerDiskImages(vmi *virtv1.VirtualMachineInstance, pinnedImages map[string]string) {
+	if len(pinnedImages) == 0 {
+		return
+	}
+	volumes := make([]virtv1.Volume, len(vmi.Spec.Volumes))
+	for i, volume := range vmi.Spec.Volumes {
+		volumes[i] = *volume.DeepCopy()
+		if image, ok := pinnedImages[volume.Name]; ok && volumes[i].ContainerDisk != nil {
+			volumes[i].ContainerDisk.Image = image
+		}
+	}
+	vmi.Spec.Volumes = volumes
+}
+
+// syncPinnedContainerDiskImages records the images which were pinned in the background in the status of the VM and
+// drops the ones of volumes which were removed or whose image changed
+func (c *VMController) syncPinnedContainerDiskImages(vm *virtv1.VirtualMachine) {
+	vmKey, err := controller.KeyFunc(vm)
+	if err != nil {
+		return
+	}
+
+	var pinnedImages []virtv1.PinnedContainerDiskImage
+	for _, volume := range vm.Spec.Template.Spec.Volumes {
+		if !needsImageDigestPin(volume) {
+			continue
+		}
+		if image := recordedPinnedImage(vm, volume); image != "" {
+			c.digestPinner.forgetVolume(vmKey, volume.Name)
+			pinnedImages = append(pinnedImages, virtv1.PinnedContainerDiskImage{
+				VolumeName:  volume.Name,
+				Image:       volume.ContainerDisk.Image,
+				PinnedImage: image,
+			})
+		} else if image, ok := c.digestPinner.resolvedImage(vmKey, volume.Name, volume.ContainerDisk.Image); ok {
+			pinnedImages = append(pinnedImages, virtv1.PinnedContainerDiskImage{
+				VolumeName:  volume.Name,
+				Image:       volume.ContainerDisk.Image,
+				PinnedImage: image,
+			})
+		}
+	}
+	vm.Status.PinnedContainerDiskImages = pinnedImages
+}
+
 // setupVMIfromVM creates a VirtualMachineInstance object from one VirtualMachine object.
 func (c *VMController) setupVMIFromVM(vm *virtv1.VirtualMachine) *virtv1.VirtualMachineInstance {
 
@@ -1041,6 +1481,8 @@ func (c *VMController) setupVMIFromVM(vm *virtv1.VirtualMachine) *virtv1.Virtual
 	}
 
 	setupStableFirmwareUUID(vm, vmi)
+	applyFirstBootOrder(vm, vmi)
+	applyRestoreMemory(vm, vmi)
 
 	// TODO check if vmi labels exist, and when make sure that they match. For now just override them
 	vmi.ObjectMeta.Labels = vm.Spec.Template.ObjectMeta.Labels
@@ -1465,6 +1907,12 @@ func (c *VMController) updateStatus(vmOrig *virtv1.VirtualMachine, vmi *virtv1.V
 	}
 	vm.Status.Ready = ready
 
+	// The VMI can't go away before its VM recorded the run, the finalizer
+	// of the VMI is only removed after the status got updated below.
+	if runStrategy, err := vm.RunStrategy(); err == nil {
+		vm.Status.RunOnceStarted = runStrategy == virtv1.RunStrategyOnce && (vm.Status.RunOnceStarted || created)
+	}
+
 	clearChangeRequest := false
 	if len(vm.Status.StateChangeRequests) != 0 {
 		// Only consider one stateChangeRequest at a time. The second and subsequent change
@@ -1553,6 +2001,8 @@ func (c *VMController) updateStatus(vmOrig *virtv1.VirtualMachine, vmi *virtv1.V
 
 	syncStartFailureStatus(vm, vmi)
 
+	c.syncPinnedContainerDiskImages(vm)
+
 	c.syncReadyConditionFromVMI(vm, vmi)
 
 	// Add/Remove Failure condition if necessary
@@ -1567,14 +2017,11 @@ func (c *VMController) updateStatus(vmOrig *virtv1.VirtualMachine, vmi *virtv1.V
 	if vmiCondManager.HasCondition(vmi, virtv1.VirtualMachineInstancePaused) {
 		if !vmCondManager.HasCondition(vm, virtv1.VirtualMachinePaused) {
 			log.Log.Object(vm).V(3).Info("Adding paused condition")
-			now := v1.NewTime(time.Now())
-			vm.Status.Conditions = append(vm.Status.Conditions, virtv1.VirtualMachineCondition{
-				Type:               virtv1.VirtualMachinePaused,
-				Status:             k8score.ConditionTrue,
-				LastProbeTime:      now,
-				LastTransitionTime: now,
-				Reason:             "PausedByUser",
-				Message:            "VMI was paused by user",
+			conditions.SetVMCondition(vm, virtv1.VirtualMachineCondition{
+				Type:    virtv1.VirtualMachinePaused,
+				Status:  k8score.ConditionTrue,
+				Reason:  "PausedByUser",
+				Message: "VMI was paused by user",
 			})
 		}
 	} else if vmCondManager.HasCondition(vm, virtv1.VirtualMachinePaused) {
@@ -1582,6 +2029,12 @@ func (c *VMController) updateStatus(vmOrig *virtv1.VirtualMachine, vmi *virtv1.V
 		vmCondManager.RemoveCondition(vm, virtv1.VirtualMachinePaused)
 	}
 
+	syncDriftConditionFromVMI(vm, vmi)
+
+	if err := c.syncPendingChanges(vm, vmi); err != nil {
+		log.Log.Object(vm).Reason(err).Error("Failed to detect the changes which aren't applied to the VMI")
+	}
+
 	c.setPrintableStatus(vm, vmi)
 
 	// only update if necessary
@@ -1752,25 +2205,20 @@ func (c *VMController) syncReadyConditionFromVMI(vm *virtv1.VirtualMachine, vmi
 	vmiReadyCond := controller.NewVirtualMachineInstanceConditionManager().
 		GetCondition(vmi, virtv1.VirtualMachineInstanceReady)
 
-	now := v1.Now()
 	if vmi == nil {
 		conditionManager.UpdateCondition(vm, &virtv1.VirtualMachineCondition{
-			Type:               virtv1.VirtualMachineReady,
-			Status:             k8score.ConditionFalse,
-			Reason:             "VMINotExists",
-			Message:            "VMI does not exist",
-			LastProbeTime:      now,
-			LastTransitionTime: now,
+			Type:    virtv1.VirtualMachineReady,
+			Status:  k8score.ConditionFalse,
+			Reason:  "VMINotExists",
+			Message: "VMI does not exist",
 		})
 
 	} else if vmiReadyCond == nil {
 		conditionManager.UpdateCondition(vm, &virtv1.VirtualMachineCondition{
-			Type:               virtv1.VirtualMachineReady,
-			Status:             k8score.ConditionFalse,
-			Reason:             "VMIConditionMissing",
-			Message:            "VMI is missing the Ready condition",
-			LastProbeTime:      now,
-			LastTransitionTime: now,
+			Type:    virtv1.VirtualMachineReady,
+			Status:  k8score.ConditionFalse,
+			Reason:  "VMIConditionMissing",
+			Message: "VMI is missing the Ready condition",
 		})
 
 	} else {
@@ -1785,6 +2233,450 @@ func (c *VMController) syncReadyConditionFromVMI(vm *virtv1.VirtualMachine, vmi
 	}
 }
 
+// syncDriftConditionFromVMI copies the drift detected between the running domain and the VMI to the VM
+func syncDriftConditionFromVMI(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) {
+	vmiDriftCond := conditions.GetVMICondition(vmi, virtv1.VirtualMachineInstanceDriftDetected)
+	if vmi == nil || vmi.IsFinal() || vmiDriftCond == nil || vmiDriftCond.Status != k8score.ConditionTrue {
+		if conditions.RemoveVMCondition(vm, virtv1.VirtualMachineDriftDetected) {
+			log.Log.Object(vm).V(3).Info("Removing drift detected condition")
+		}
+		return
+	}
+	conditions.SetVMCondition(vm, virtv1.VirtualMachineCondition{
+		Type:    virtv1.VirtualMachineDriftDetected,
+		Status:  k8score.ConditionTrue,
+		Reason:  vmiDriftCond.Reason,
+		Message: vmiDriftCond.Message,
+	})
+}
+
+// templateChanges are the changes of the VM template which aren't applied to the running VMI yet,
+// grouped by how they can be applied
+type templateChanges struct {
+	// the volumes of the template which can be hotplugged to the VMI
+	hotplugVolumes []*virtv1.AddVolumeOptions
+	// the names of the hotplugged volumes of the VMI which were removed from the template
+	unplugVolumes []string
+	// the paths of the fields which are applied by migrating the VMI
+	migration []string
+	// the paths of the fields which are only applied by restarting the VM
+	restart []string
+}
+
+// pendingChanges returns the changes sorted by their paths
+func (t *templateChanges) pendingChanges() []virtv1.VirtualMachinePendingChange {
+	var changes []virtv1.VirtualMachinePendingChange
+	for _, volume := range t.hotplugVolumes {
+		changes = append(changes, virtv1.VirtualMachinePendingChange{Path: volumeChangePath(volume.Name), ApplyMethod: virtv1.ChangeApplyMethodHotplug})
+	}
+	for _, name := range t.unplugVolumes {
+		changes = append(changes, virtv1.VirtualMachinePendingChange{Path: volumeChangePath(name), ApplyMethod: virtv1.ChangeApplyMethodHotplug})
+	}
+	for _, path := range t.migration {
+		changes = append(changes, virtv1.VirtualMachinePendingChange{Path: path, ApplyMethod: virtv1.ChangeApplyMethodMigration})
+	}
+	for _, path := range t.restart {
+		changes = append(changes, virtv1.VirtualMachinePendingChange{Path: path, ApplyMethod: virtv1.ChangeApplyMethodRestart})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return changes
+}
+
+func volumeChangePath(name string) string {
+	return fmt.Sprintf("spec.template.spec.volumes[name=%s]", name)
+}
+
+func changeApplyPolicy(vm *virtv1.VirtualMachine) virtv1.VirtualMachineChangeApplyPolicy {
+	if vm.Spec.ChangeApplyPolicy == nil {
+		return virtv1.ChangeApplyPolicyManual
+	}
+	return *vm.Spec.ChangeApplyPolicy
+}
+
+// syncPendingChanges reports the changes of the VM template which aren't applied to the running VMI yet. The restart
+// required condition is added as long as any of them is only applied by restarting the VM, with the Automatic change
+// apply policy the restart is requested right away.
+func (c *VMController) syncPendingChanges(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) error {
+	changes, err := c.getTemplateChanges(vm, vmi)
+	if err != nil {
+		return err
+	}
+	vm.Status.PendingChanges = changes.pendingChanges()
+
+	if len(changes.restart) == 0 {
+		if conditions.RemoveVMCondition(vm, virtv1.VirtualMachineRestartRequired) {
+			log.Log.Object(vm).V(3).Info("Removing restart required condition")
+		}
+		return nil
+	}
+	conditions.SetVMCondition(vm, virtv1.VirtualMachineCondition{
+		Type:    virtv1.VirtualMachineRestartRequired,
+		Status:  k8score.ConditionTrue,
+		Reason:  virtv1.VirtualMachineReasonTemplateChanged,
+		Message: fmt.Sprintf("a restart of the VM is required to apply the changes of %s", strings.Join(changes.restart, ", ")),
+	})
+
+	if restartsForChanges(vm, vmi) {
+		log.Log.Object(vm).Infof("Restarting the VM to apply the changes of %s", strings.Join(changes.restart, ", "))
+		uid := vmi.UID
+		vm.Status.StateChangeRequests = append(vm.Status.StateChangeRequests,
+			virtv1.VirtualMachineStateChangeRequest{Action: virtv1.StopRequest, UID: &uid},
+			virtv1.VirtualMachineStateChangeRequest{Action: virtv1.StartRequest})
+	}
+	return nil
+}
+
+// restartsForChanges returns whether the VM is restarted to apply the changes which require a restart. The restart is
+// requested the same way as through the restart API, so only run strategies which support restarts are restarted.
+func restartsForChanges(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) bool {
+	if changeApplyPolicy(vm) != virtv1.ChangeApplyPolicyAutomatic || vmi.DeletionTimestamp != nil || len(vm.Status.StateChangeRequests) != 0 {
+		return false
+	}
+	runStrategy, err := vm.RunStrategy()
+	if err != nil {
+		return false
+	}
+	return runStrategy == virtv1.RunStrategyAlways || runStrategy == virtv1.RunStrategyRerunOnFailure || runStrategy == virtv1.RunStrategyManual
+}
+
+// applyPendingChanges hotplugs and unplugs the changed volumes of the template and migrates the VMI to apply changed
+// scheduling constraints, if the change apply policy of the VM allows it
+func (c *VMController) applyPendingChanges(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) error {
+	policy := changeApplyPolicy(vm)
+	// volume requests are hotplugged by handleVolumeRequests, the template is only in sync with the VMI once they are processed
+	if policy == virtv1.ChangeApplyPolicyManual || vmi == nil || !vmi.IsRunning() || vmi.DeletionTimestamp != nil || len(vm.Status.VolumeRequests) != 0 {
+		return nil
+	}
+
+	changes, err := c.getTemplateChanges(vm, vmi)
+	if err != nil {
+		return err
+	}
+	if policy == virtv1.ChangeApplyPolicyAutomatic && len(changes.restart) != 0 {
+		// the restart applies all changes
+		return nil
+	}
+
+	for _, volume := range changes.hotplugVolumes {
+		if err := c.clientset.VirtualMachineInstance(vmi.Namespace).AddVolume(vmi.Name, volume); err != nil {
+			c.recorder.Eventf(vm, k8score.EventTypeWarning, FailedApplyChangesReason, "Error hotplugging volume %s: %v", volume.Name, err)
+			return err
+		}
+		c.recorder.Eventf(vm, k8score.EventTypeNormal, SuccessfulApplyChangesReason, "Hotplugged volume %s", volume.Name)
+	}
+	for _, name := range changes.unplugVolumes {
+		if err := c.clientset.VirtualMachineInstance(vmi.Namespace).RemoveVolume(vmi.Name, &virtv1.RemoveVolumeOptions{Name: name}); err != nil {
+			c.recorder.Eventf(vm, k8score.EventTypeWarning, FailedApplyChangesReason, "Error unplugging volume %s: %v", name, err)
+			return err
+		}
+		c.recorder.Eventf(vm, k8score.EventTypeNormal, SuccessfulApplyChangesReason, "Unplugged volume %s", name)
+	}
+
+	if len(changes.migration) != 0 && !migrations.IsMigrating(vmi) {
+		return c.migrateForSchedulingChanges(vm, vmi, changes.migration)
+	}
+	return nil
+}
+
+// migrateForSchedulingChanges updates the scheduling constraints of the VMI and migrates it, so that its new pod is
+// scheduled with them. If the migration can't be created, the constraints are applied by the next migration of the VMI.
+func (c *VMController) migrateForSchedulingChanges(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance, paths []string) error {
+	patch, err := schedulingConstraintsPatch(&vm.Spec.Template.Spec, vmi)
+	if err != nil {
+		return err
+	}
+	if _, err := c.clientset.VirtualMachineInstance(vmi.Namespace).Patch(vmi.Name, types.JSONPatchType, patch); err != nil {
+		c.recorder.Eventf(vm, k8score.EventTypeWarning, FailedApplyChangesReason, "Error updating the scheduling constraints of virtual machine instance %s: %v", vmi.Name, err)
+		return err
+	}
+
+	migration, err := c.clientset.VirtualMachineInstanceMigration(vmi.Namespace).Create(&virtv1.VirtualMachineInstanceMigration{
+		ObjectMeta: v1.ObjectMeta{
+			GenerateName: "kubevirt-change-apply-",
+		},
+		Spec: virtv1.VirtualMachineInstanceMigrationSpec{
+			VMIName: vmi.Name,
+		},
+	})
+	if err != nil {
+		c.recorder.Eventf(vm, k8score.EventTypeWarning, FailedApplyChangesReason, "Error creating a migration to apply the changes of %s: %v", strings.Join(paths, ", "), err)
+		return err
+	}
+	c.recorder.Eventf(vm, k8score.EventTypeNormal, SuccessfulApplyChangesReason, "Created migration %s to apply the changes of %s", migration.Name, strings.Join(paths, ", "))
+	return nil
+}
+
+// schedulingConstraintsPatch returns the patch which sets the scheduling constraints of the template on the VMI
+func schedulingConstraintsPatch(template *virtv1.VirtualMachineInstanceSpec, vmi *virtv1.VirtualMachineInstance) ([]byte, error) {
+	var ops []string
+	addOp := func(path string, value interface{}, isEmpty, existed bool) error {
+		if isEmpty {
+			if existed {
+				ops = append(ops, fmt.Sprintf(`{ "op": "remove", "path": "%s" }`, path))
+			}
+			return nil
+		}
+		valueBytes, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		ops = append(ops, fmt.Sprintf(`{ "op": "add", "path": "%s", "value": %s }`, path, string(valueBytes)))
+		return nil
+	}
+
+	if err := addOp("/spec/nodeSelector", template.NodeSelector, len(template.NodeSelector) == 0, vmi.Spec.NodeSelector != nil); err != nil {
+		return nil, err
+	}
+	if err := addOp("/spec/affinity", template.Affinity, template.Affinity == nil, vmi.Spec.Affinity != nil); err != nil {
+		return nil, err
+	}
+	if err := addOp("/spec/tolerations", template.Tolerations, len(template.Tolerations) == 0, vmi.Spec.Tolerations != nil); err != nil {
+		return nil, err
+	}
+	return controller.GeneratePatchBytes(ops), nil
+}
+
+// getTemplateChanges compares the VM template to the running VMI. Volumes and scheduling constraints are compared to
+// the VMI itself, since they can change while it runs, all other fields to the template the VMI was started with.
+func (c *VMController) getTemplateChanges(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance) (*templateChanges, error) {
+	changes := &templateChanges{}
+	if vmi == nil || vmi.IsFinal() || vmi.Status.VirtualMachineRevisionName == "" || vm.Spec.Template == nil {
+		return changes, nil
+	}
+	startedSpec, err := c.getVMRevisionSpec(vmi.Namespace, vmi.Status.VirtualMachineRevisionName)
+	if err != nil {
+		return nil, err
+	}
+	if startedSpec == nil || startedSpec.Template == nil {
+		return changes, nil
+	}
+	current := &vm.Spec.Template.Spec
+
+	liveVolumes := classifyVolumeChanges(current, vmi, changes)
+
+	if changed := schedulingConstraintChanges(current, &vmi.Spec); len(changed) != 0 {
+		if vmi.IsMigratable() {
+			changes.migration = changed
+		} else {
+			changes.restart = changed
+		}
+	}
+
+	changed, err := changedTemplateFields(&startedSpec.Template.Spec, current, vmi, liveVolumes)
+	if err != nil {
+		return nil, err
+	}
+	changes.restart = append(changes.restart, changed...)
+	sort.Strings(changes.restart)
+	return changes, nil
+}
+
+// classifyVolumeChanges adds the volumes of the template which can be hotplugged to the VMI, and the hotplugged volumes
+// of the VMI which were removed from the template, to the changes. It returns the names of these volumes.
+func classifyVolumeChanges(current *virtv1.VirtualMachineInstanceSpec, vmi *virtv1.VirtualMachineInstance, changes *templateChanges) map[string]bool {
+	live := map[string]bool{}
+	vmiVolumes := map[string]bool{}
+	for _, volume := range vmi.Spec.Volumes {
+		vmiVolumes[volume.Name] = true
+	}
+
+	templateVolumes := map[string]bool{}
+	for i, volume := range current.Volumes {
+		templateVolumes[volume.Name] = true
+		if vmiVolumes[volume.Name] {
+			continue
+		}
+		if options := hotplugVolumeOptions(current, &current.Volumes[i]); options != nil {
+			changes.hotplugVolumes = append(changes.hotplugVolumes, options)
+			live[volume.Name] = true
+		}
+	}
+
+	for _, volumeStatus := range vmi.Status.VolumeStatus {
+		if volumeStatus.HotplugVolume != nil && vmiVolumes[volumeStatus.Name] && !templateVolumes[volumeStatus.Name] {
+			changes.unplugVolumes = append(changes.unplugVolumes, volumeStatus.Name)
+			live[volumeStatus.Name] = true
+		}
+	}
+	return live
+}
+
+// hotplugVolumeOptions returns the options to hotplug the volume, or nil if it can't be hotplugged. Only claims and
+// DataVolumes attached as scsi disks can be hotplugged.
+func hotplugVolumeOptions(spec *virtv1.VirtualMachineInstanceSpec, volume *virtv1.Volume) *virtv1.AddVolumeOptions {
+	if volume.PersistentVolumeClaim == nil && volume.DataVolume == nil {
+		return nil
+	}
+	for _, disk := range spec.Domain.Devices.Disks {
+		if disk.Name != volume.Name {
+			continue
+		}
+		if disk.Disk == nil || disk.Disk.Bus != "scsi" {
+			return nil
+		}
+		return &virtv1.AddVolumeOptions{
+			Name: volume.Name,
+			Disk: disk.DeepCopy(),
+			VolumeSource: &virtv1.HotplugVolumeSource{
+				PersistentVolumeClaim: volume.PersistentVolumeClaim,
+				DataVolume:            volume.DataVolume,
+			},
+		}
+	}
+	return nil
+}
+
+// schedulingConstraintChanges returns the paths of the scheduling constraints of the template which differ from the ones
+// of the VMI, a migration applies them by scheduling the VMI to a new pod
+func schedulingConstraintChanges(current, vmiSpec *virtv1.VirtualMachineInstanceSpec) []string {
+	var changed []string
+	if !equality.Semantic.DeepEqual(current.NodeSelector, vmiSpec.NodeSelector) {
+		changed = append(changed, "spec.template.spec.nodeSelector")
+	}
+	if !equality.Semantic.DeepEqual(current.Affinity, vmiSpec.Affinity) {
+		changed = append(changed, "spec.template.spec.affinity")
+	}
+	if !equality.Semantic.DeepEqual(current.Tolerations, vmiSpec.Tolerations) {
+		changed = append(changed, "spec.template.spec.tolerations")
+	}
+	return changed
+}
+
+// getVMRevisionSpec returns the spec of the VM which was stored in the revision when the VMI was started,
+// or nil if the revision doesn't exist (yet)
+func (c *VMController) getVMRevisionSpec(namespace, name string) (*virtv1.VirtualMachineSpec, error) {
+	storeObj, exists, err := c.crInformer.GetStore().GetByKey(namespace + "/" + name)
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	cr, ok := storeObj.(*appsv1.ControllerRevision)
+	if !ok {
+		return nil, fmt.Errorf("unexpected resource %+v", storeObj)
+	}
+
+	vmRevision := &virtv1.VirtualMachine{}
+	if err := json.Unmarshal(cr.Data.Raw, vmRevision); err != nil {
+		return nil, err
+	}
+	return &vmRevision.Spec, nil
+}
+
+// changedTemplateFields returns the sorted paths of the fields which differ between the template the VMI was started
+// with and the current template. Volumes and disks which are, or can be, hotplugged to or unplugged from the VMI are
+// not reported, neither are the scheduling constraints, which are compared to the VMI.
+func changedTemplateFields(started, current *virtv1.VirtualMachineInstanceSpec, vmi *virtv1.VirtualMachineInstance, liveVolumes map[string]bool) ([]string, error) {
+	started = started.DeepCopy()
+	current = current.DeepCopy()
+	hotplugged := hotpluggedVolumeNames(started, current, vmi)
+	for name := range liveVolumes {
+		hotplugged[name] = true
+	}
+	for _, spec := range []*virtv1.VirtualMachineInstanceSpec{started, current} {
+		spec.NodeSelector, spec.Affinity, spec.Tolerations = nil, nil, nil
+	}
+	started.Volumes, started.Domain.Devices.Disks = withoutHotpluggedVolumes(started, hotplugged)
+	current.Volumes, current.Domain.Devices.Disks = withoutHotpluggedVolumes(current, hotplugged)
+
+	startedFields, err := toUnstructuredFields(started)
+	if err != nil {
+		return nil, err
+	}
+	currentFields, err := toUnstructuredFields(current)
+	if err != nil {
+		return nil, err
+	}
+	changed := changedFields("spec.template.spec", startedFields, currentFields)
+	sort.Strings(changed)
+	return changed, nil
+}
+
+// hotpluggedVolumeNames returns the names of the volumes which are, or are being, hotplugged to or unplugged from the VMI
+func hotpluggedVolumeNames(started, current *virtv1.VirtualMachineInstanceSpec, vmi *virtv1.VirtualMachineInstance) map[string]bool {
+	hotplugged := map[string]bool{}
+	for _, volumeStatus := range vmi.Status.VolumeStatus {
+		if volumeStatus.HotplugVolume != nil {
+			hotplugged[volumeStatus.Name] = true
+		}
+	}
+
+	volumeNames := func(volumes []virtv1.Volume) map[string]bool {
+		names := map[string]bool{}
+		for _, volume := range volumes {
+			names[volume.Name] = true
+		}
+		return names
+	}
+	startedNames := volumeNames(started.Volumes)
+	currentNames := volumeNames(current.Volumes)
+	vmiNames := volumeNames(vmi.Spec.Volumes)
+	for name := range currentNames {
+		if !startedNames[name] && vmiNames[name] {
+			hotplugged[name] = true
+		}
+	}
+	for name := range startedNames {
+		if !currentNames[name] && !vmiNames[name] {
+			hotplugged[name] = true
+		}
+	}
+	return hotplugged
+}
+
+func withoutHotpluggedVolumes(spec *virtv1.VirtualMachineInstanceSpec, hotplugged map[string]bool) ([]virtv1.Volume, []virtv1.Disk) {
+	var volumes []virtv1.Volume
+	for _, volume := range spec.Volumes {
+		if !hotplugged[volume.Name] {
+			volumes = append(volumes, volume)
+		}
+	}
+	var disks []virtv1.Disk
+	for _, disk := range spec.Domain.Devices.Disks {
+		if !hotplugged[disk.Name] {
+			disks = append(disks, disk)
+		}
+	}
+	return volumes, disks
+}
+
+func toUnstructuredFields(spec *virtv1.VirtualMachineInstanceSpec) (map[string]interface{}, error) {
+	specBytes, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(specBytes, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// changedFields descends into the objects and returns the paths of the values which differ,
+// lists are compared as a whole
+func changedFields(path string, started, current interface{}) []string {
+	startedObj, startedIsObj := started.(map[string]interface{})
+	currentObj, currentIsObj := current.(map[string]interface{})
+	if !startedIsObj || !currentIsObj {
+		if reflect.DeepEqual(started, current) {
+			return nil
+		}
+		return []string{path}
+	}
+
+	var changed []string
+	for key, value := range startedObj {
+		changed = append(changed, changedFields(path+"."+key, value, currentObj[key])...)
+	}
+	for key := range currentObj {
+		if _, exists := startedObj[key]; !exists {
+			changed = append(changed, path+"."+key)
+		}
+	}
+	return changed
+}
+
 func (c *VMController) processFailure(vm *virtv1.VirtualMachine, vmi *virtv1.VirtualMachineInstance, createErr error) {
 	reason := ""
 	message := ""
@@ -1805,12 +2697,11 @@ func (c *VMController) processFailure(vm *virtv1.VirtualMachine, vmi *virtv1.Vir
 
 		if !vmConditionManager.HasCondition(vm, virtv1.VirtualMachineFailure) {
 			log.Log.Object(vm).Infof("Reason to fail: %s", reason)
-			vm.Status.Conditions = append(vm.Status.Conditions, virtv1.VirtualMachineCondition{
-				Type:               virtv1.VirtualMachineFailure,
-				Reason:             reason,
-				Message:            message,
-				LastTransitionTime: v1.Now(),
-				Status:             k8score.ConditionTrue,
+			conditions.SetVMCondition(vm, virtv1.VirtualMachineCondition{
+				Type:    virtv1.VirtualMachineFailure,
+				Reason:  reason,
+				Message: message,
+				Status:  k8score.ConditionTrue,
 			})
 		}
 
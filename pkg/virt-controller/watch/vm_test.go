@@ -1,6 +1,7 @@
 package watch
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -92,7 +93,8 @@ var _ = Describe("VirtualMachine", func() {
 			recorder = record.NewFakeRecorder(100)
 			recorder.IncludeObject = true
 
-			controller = NewVMController(vmiInformer, vmInformer, dataVolumeInformer, pvcInformer, crInformer, recorder, virtClient)
+			config, _, _, _ := testutils.NewFakeClusterConfig(&k8sv1.ConfigMap{})
+			controller = NewVMController(vmiInformer, vmInformer, dataVolumeInformer, pvcInformer, crInformer, recorder, virtClient, config)
 			// Wrap our workqueue to have a way to detect when we are done processing updates
 			mockQueue = testutils.NewMockWorkQueue(controller.Queue)
 			controller.Queue = mockQueue
@@ -863,6 +865,97 @@ var _ = Describe("VirtualMachine", func() {
 
 			})
 
+			table.DescribeTable("should track guest failures when VMIs fail after hitting running state", func(previousFailures []time.Duration, expectedFailures int, expectBackoff bool) {
+				vm, vmi := DefaultVirtualMachine(true)
+				vmi.UID = "456"
+				vmi.Status.Phase = v1.Failed
+				vmi.Status.PhaseTransitionTimestamps = []v1.VirtualMachineInstancePhaseTransitionTimestamp{
+					{
+						Phase:                    v1.Running,
+						PhaseTransitionTimestamp: metav1.Now(),
+					},
+				}
+
+				if len(previousFailures) > 0 {
+					vm.Status.GuestFailures = &v1.VirtualMachineGuestFailures{LastFailedVMIUID: "123"}
+					for _, ago := range previousFailures {
+						vm.Status.GuestFailures.FailureTimestamps = append(vm.Status.GuestFailures.FailureTimestamps, metav1.NewTime(time.Now().Add(-ago)))
+					}
+				}
+
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				vmiInterface.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
+
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Times(1).Do(func(arg interface{}) {
+					status := arg.(*v1.VirtualMachine).Status
+					Expect(status.GuestFailures).ToNot(BeNil())
+					Expect(status.GuestFailures.LastFailedVMIUID).To(Equal(vmi.UID))
+					Expect(status.GuestFailures.FailureTimestamps).To(HaveLen(expectedFailures))
+					if expectBackoff {
+						Expect(status.StartFailure).ToNot(BeNil())
+						Expect(status.StartFailure.RetryAfterTimestamp).ToNot(BeNil())
+						Expect(status.StartFailure.LastFailedVMIUID).To(Equal(vmi.UID))
+						Expect(status.StartFailure.ConsecutiveFailCount).To(Equal(expectedFailures - crashLoopGuestFailureThreshold + 1))
+						Expect(status.PrintableStatus).To(Equal(v1.VirtualMachineStatusCrashLoopBackOff))
+					} else {
+						Expect(status.StartFailure).To(BeNil())
+					}
+				}).Return(nil, nil)
+
+				shouldExpectVMIFinalizerRemoval(vmi)
+
+				controller.Execute()
+
+				testutils.ExpectEvent(recorder, SuccessfulDeleteVirtualMachineReason)
+			},
+				table.Entry("without previous failures", nil, 1, false),
+				table.Entry("with a previous failure within the window", []time.Duration{time.Minute}, 2, false),
+				table.Entry("with enough previous failures within the window", []time.Duration{2 * time.Minute, time.Minute}, 3, true),
+				table.Entry("with more previous failures within the window", []time.Duration{3 * time.Minute, 2 * time.Minute, time.Minute}, 4, true),
+				table.Entry("with previous failures outside of the window", []time.Duration{crashLoopGuestFailureWindow + time.Minute, crashLoopGuestFailureWindow + 2*time.Minute}, 1, false),
+			)
+
+			It("should keep guest failures when the VMI is running again", func() {
+				vm, vmi := DefaultVirtualMachine(true)
+				vmi.UID = "456"
+				vmi.Status.Phase = v1.Running
+				vmi.Status.PhaseTransitionTimestamps = []v1.VirtualMachineInstancePhaseTransitionTimestamp{
+					{
+						Phase:                    v1.Running,
+						PhaseTransitionTimestamp: metav1.Now(),
+					},
+				}
+
+				vm.Status.GuestFailures = &v1.VirtualMachineGuestFailures{
+					LastFailedVMIUID: "123",
+					FailureTimestamps: []metav1.Time{
+						metav1.NewTime(time.Now().Add(-crashLoopGuestFailureWindow - time.Minute)),
+						metav1.NewTime(time.Now().Add(-time.Minute)),
+					},
+				}
+				vm.Status.StartFailure = &v1.VirtualMachineStartFailure{
+					LastFailedVMIUID:     "123",
+					ConsecutiveFailCount: 1,
+					RetryAfterTimestamp: &metav1.Time{
+						Time: time.Now().Add(-time.Minute),
+					},
+				}
+
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Times(1).Do(func(arg interface{}) {
+					status := arg.(*v1.VirtualMachine).Status
+					Expect(status.StartFailure).To(BeNil())
+					Expect(status.GuestFailures).ToNot(BeNil())
+					Expect(status.GuestFailures.FailureTimestamps).To(HaveLen(1))
+				}).Return(nil, nil)
+
+				controller.Execute()
+			})
+
 			table.DescribeTable("should clear existing start failures when runStrategy is halted or manual", func(runStrategy v1.VirtualMachineRunStrategy) {
 				vm, vmi := DefaultVirtualMachine(true)
 				vmi.UID = "456"
@@ -1179,6 +1272,145 @@ var _ = Describe("VirtualMachine", func() {
 			testutils.ExpectEvent(recorder, SuccessfulCreateVirtualMachineReason)
 		})
 
+		Context("with first boot annotations", func() {
+			bootOrder := func(order uint) *uint {
+				return &order
+			}
+
+			addPVCVolume := func(vm *v1.VirtualMachine, podPhase string) {
+				vm.Spec.Template.Spec.Volumes = append(vm.Spec.Template.Spec.Volumes, v1.Volume{
+					Name: "rootdisk",
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{
+							ClaimName: "rootpvc",
+						}},
+					},
+				})
+				pvc := &k8sv1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "rootpvc",
+						Namespace:   vm.Namespace,
+						Annotations: map[string]string{cdiPodPhaseAnnotation: podPhase},
+					},
+				}
+				Expect(pvcInformer.GetStore().Add(pvc)).To(Succeed())
+			}
+
+			It("should delay the first boot until the claims are populated", func() {
+				vm, _ := DefaultVirtualMachine(true)
+				vm.Annotations[v1.FirstBootWaitForVolumesAnnotation] = "true"
+				addPVCVolume(vm, string(k8sv1.PodRunning))
+				addVirtualMachine(vm)
+
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(nil, nil)
+
+				controller.Execute()
+				Expect(mockQueue.GetAddAfterEnqueueCount()).To(Equal(1))
+			})
+
+			It("should start the first boot once the claims are populated", func() {
+				vm, vmi := DefaultVirtualMachine(true)
+				vm.Annotations[v1.FirstBootWaitForVolumesAnnotation] = "true"
+				addPVCVolume(vm, string(k8sv1.PodSucceeded))
+				addVirtualMachine(vm)
+
+				vmiInterface.EXPECT().Create(gomock.Any()).Return(vmi, nil)
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(nil, nil)
+
+				controller.Execute()
+				testutils.ExpectEvent(recorder, SuccessfulCreateVirtualMachineReason)
+			})
+
+			It("should not wait on the claims once the first boot completed", func() {
+				vm, vmi := DefaultVirtualMachine(true)
+				vm.Annotations[v1.FirstBootWaitForVolumesAnnotation] = "true"
+				vm.Annotations[v1.FirstBootCompletedAnnotation] = "true"
+				addPVCVolume(vm, string(k8sv1.PodRunning))
+				addVirtualMachine(vm)
+
+				vmiInterface.EXPECT().Create(gomock.Any()).Return(vmi, nil)
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(nil, nil)
+
+				controller.Execute()
+				testutils.ExpectEvent(recorder, SuccessfulCreateVirtualMachineReason)
+			})
+
+			table.DescribeTable("should override the boot order", func(completed bool, installerBootOrder, rootDiskBootOrder *uint) {
+				vm, vmi := DefaultVirtualMachine(true)
+				rootDiskOrder := uint(1)
+				vm.Spec.Template.Spec.Domain.Devices.Disks = []v1.Disk{
+					{Name: "rootdisk", BootOrder: &rootDiskOrder},
+					{Name: "installer"},
+				}
+				vm.Annotations[v1.FirstBootOrderAnnotation] = "installer,rootdisk"
+				if completed {
+					vm.Annotations[v1.FirstBootCompletedAnnotation] = "true"
+				}
+				addVirtualMachine(vm)
+
+				vmiInterface.EXPECT().Create(gomock.Any()).Do(func(arg interface{}) {
+					disks := arg.(*v1.VirtualMachineInstance).Spec.Domain.Devices.Disks
+					Expect(disks[0].BootOrder).To(Equal(rootDiskBootOrder))
+					Expect(disks[1].BootOrder).To(Equal(installerBootOrder))
+				}).Return(vmi, nil)
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(nil, nil)
+
+				controller.Execute()
+				testutils.ExpectEvent(recorder, SuccessfulCreateVirtualMachineReason)
+				Expect(*vm.Spec.Template.Spec.Domain.Devices.Disks[0].BootOrder).To(Equal(uint(1)))
+				Expect(vm.Spec.Template.Spec.Domain.Devices.Disks[1].BootOrder).To(BeNil())
+			},
+				table.Entry("of the first boot", false, bootOrder(1), bootOrder(2)),
+				table.Entry("not after the first boot completed", true, nil, bootOrder(1)),
+			)
+
+			It("should mark the first boot as completed once the VMI ran", func() {
+				vm, vmi := DefaultVirtualMachine(true)
+				vm.Annotations[v1.FirstBootOrderAnnotation] = "rootdisk"
+				vmi.Status.PhaseTransitionTimestamps = []v1.VirtualMachineInstancePhaseTransitionTimestamp{
+					{Phase: v1.Running, PhaseTransitionTimestamp: metav1.Now()},
+				}
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				vmInterface.EXPECT().Patch(vm.Name, types.MergePatchType, []byte(`{"metadata":{"annotations":{"kubevirt.io/first-boot-completed":"true"}}}`)).Return(vm, nil)
+
+				controller.Execute()
+			})
+		})
+
+		Context("with a restored memory state", func() {
+			It("should let the VMI resume from the memory state", func() {
+				vm, vmi := DefaultVirtualMachine(true)
+				vm.Annotations[v1.RestoreMemoryClaimAnnotation] = "memory-pvc"
+				addVirtualMachine(vm)
+
+				vmiInterface.EXPECT().Create(gomock.Any()).Do(func(arg interface{}) {
+					Expect(arg.(*v1.VirtualMachineInstance).Annotations).To(HaveKeyWithValue(v1.RestoreMemoryClaimAnnotation, "memory-pvc"))
+				}).Return(vmi, nil)
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(nil, nil)
+
+				controller.Execute()
+				testutils.ExpectEvent(recorder, SuccessfulCreateVirtualMachineReason)
+				Expect(vm.Spec.Template.ObjectMeta.Annotations).ToNot(HaveKey(v1.RestoreMemoryClaimAnnotation))
+			})
+
+			It("should remove the annotation once the VMI resumed from the memory state ran", func() {
+				vm, vmi := DefaultVirtualMachine(true)
+				vm.Annotations[v1.RestoreMemoryClaimAnnotation] = "memory-pvc"
+				vmi.Annotations = map[string]string{v1.RestoreMemoryClaimAnnotation: "memory-pvc"}
+				vmi.Status.PhaseTransitionTimestamps = []v1.VirtualMachineInstancePhaseTransitionTimestamp{
+					{Phase: v1.Running, PhaseTransitionTimestamp: metav1.Now()},
+				}
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				vmInterface.EXPECT().Patch(vm.Name, types.MergePatchType, []byte(`{"metadata":{"annotations":{"kubevirt.io/restore-memory-claim":null}}}`)).Return(vm, nil)
+
+				controller.Execute()
+			})
+		})
+
 		It("should create missing VirtualMachineInstance", func() {
 			vm, vmi := DefaultVirtualMachine(true)
 
@@ -1200,6 +1432,124 @@ var _ = Describe("VirtualMachine", func() {
 			testutils.ExpectEvent(recorder, SuccessfulCreateVirtualMachineReason)
 		})
 
+		Context("with containerDisks with the Pin digest policy", func() {
+			const digest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+			addContainerDisk := func(vm *v1.VirtualMachine, image string, policy v1.ContainerDiskImageDigestPolicy) {
+				vm.Spec.Template.Spec.Volumes = append(vm.Spec.Template.Spec.Volumes, v1.Volume{
+					Name: fmt.Sprintf("disk%d", len(vm.Spec.Template.Spec.Volumes)),
+					VolumeSource: v1.VolumeSource{
+						ContainerDisk: &v1.ContainerDiskSource{Image: image, ImageDigestPolicy: policy},
+					},
+				})
+			}
+
+			BeforeEach(func() {
+				controller.clusterConfig, _, _, _ = testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+					ImageRegistryMirrors: []v1.ImageRegistryMirror{{Source: "quay.io/kubevirt", Mirror: "registry:5000/kubevirt"}},
+				})
+			})
+
+			setDigestResolver := func(resolver fakeDigestResolver) {
+				controller.digestPinner = newImageDigestPinner(resolver, func(vmKey string) {
+					mockQueue.Add(vmKey)
+				})
+			}
+
+			It("should pin the images on the mirror to their digest in the background before creating the VirtualMachineInstance", func() {
+				setDigestResolver(fakeDigestResolver{"registry:5000/kubevirt/fedora:33": digest})
+				vm, vmi := DefaultVirtualMachine(true)
+				addContainerDisk(vm, "quay.io/kubevirt/fedora:33", v1.ContainerDiskImageDigestPolicyPin)
+				addContainerDisk(vm, "quay.io/kubevirt/fedora:34", "")
+				addContainerDisk(vm, "quay.io/kubevirt/fedora@"+digest, v1.ContainerDiskImageDigestPolicyPin)
+				addVirtualMachine(vm)
+
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil)
+				mockQueue.ExpectAdds(1)
+				controller.Execute()
+				mockQueue.Wait()
+
+				vmiInterface.EXPECT().Create(gomock.Any()).Do(func(arg interface{}) {
+					volumes := arg.(*v1.VirtualMachineInstance).Spec.Volumes
+					Expect(volumes[0].ContainerDisk.Image).To(Equal("quay.io/kubevirt/fedora:33@" + digest))
+					Expect(volumes[1].ContainerDisk.Image).To(Equal("quay.io/kubevirt/fedora:34"))
+				}).Return(vmi, nil)
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(obj interface{}) {
+					Expect(obj.(*v1.VirtualMachine).Status.PinnedContainerDiskImages).To(Equal([]v1.PinnedContainerDiskImage{{
+						VolumeName:  "disk0",
+						Image:       "quay.io/kubevirt/fedora:33",
+						PinnedImage: "quay.io/kubevirt/fedora:33@" + digest,
+					}}))
+				}).Return(vm, nil)
+
+				controller.Execute()
+
+				Expect(vm.Spec.Template.Spec.Volumes[0].ContainerDisk.Image).To(Equal("quay.io/kubevirt/fedora:33"))
+				testutils.ExpectEvent(recorder, SuccessfulCreateVirtualMachineReason)
+			})
+
+			It("should start the VirtualMachineInstance with the images pinned in the status", func() {
+				setDigestResolver(fakeDigestResolver{})
+				vm, vmi := DefaultVirtualMachine(true)
+				addContainerDisk(vm, "quay.io/kubevirt/fedora:33", v1.ContainerDiskImageDigestPolicyPin)
+				vm.Status.PinnedContainerDiskImages = []v1.PinnedContainerDiskImage{{
+					VolumeName:  "disk0",
+					Image:       "quay.io/kubevirt/fedora:33",
+					PinnedImage: "quay.io/kubevirt/fedora:33@" + digest,
+				}}
+				addVirtualMachine(vm)
+
+				vmiInterface.EXPECT().Create(gomock.Any()).Do(func(arg interface{}) {
+					Expect(arg.(*v1.VirtualMachineInstance).Spec.Volumes[0].ContainerDisk.Image).To(Equal("quay.io/kubevirt/fedora:33@" + digest))
+				}).Return(vmi, nil)
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil)
+
+				controller.Execute()
+
+				testutils.ExpectEvent(recorder, SuccessfulCreateVirtualMachineReason)
+			})
+
+			It("should drop the pinned image once the image of the containerDisk changed", func() {
+				setDigestResolver(fakeDigestResolver{})
+				vm, _ := DefaultVirtualMachine(false)
+				addContainerDisk(vm, "quay.io/kubevirt/fedora:34", v1.ContainerDiskImageDigestPolicyPin)
+				vm.Status.PinnedContainerDiskImages = []v1.PinnedContainerDiskImage{{
+					VolumeName:  "disk0",
+					Image:       "quay.io/kubevirt/fedora:33",
+					PinnedImage: "quay.io/kubevirt/fedora:33@" + digest,
+				}}
+				addVirtualMachine(vm)
+
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(obj interface{}) {
+					Expect(obj.(*v1.VirtualMachine).Status.PinnedContainerDiskImages).To(BeEmpty())
+				}).Return(vm, nil)
+
+				controller.Execute()
+			})
+
+			It("should not create the VirtualMachineInstance if an image can't be pinned", func() {
+				setDigestResolver(fakeDigestResolver{})
+				vm, _ := DefaultVirtualMachine(true)
+				addContainerDisk(vm, "quay.io/kubevirt/fedora:33", v1.ContainerDiskImageDigestPolicyPin)
+				addVirtualMachine(vm)
+
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil)
+				mockQueue.ExpectAdds(1)
+				controller.Execute()
+				mockQueue.Wait()
+
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(obj interface{}) {
+					cond := virtcontroller.NewVirtualMachineConditionManager().GetCondition(obj.(*v1.VirtualMachine), v1.VirtualMachineFailure)
+					Expect(cond).ToNot(BeNil())
+					Expect(cond.Message).To(ContainSubstring("failed to resolve the image of containerDisk disk0"))
+				}).Return(vm, nil)
+
+				controller.Execute()
+
+				testutils.ExpectEvent(recorder, FailedCreateVirtualMachineReason)
+			})
+		})
+
 		It("should ignore the name of a VirtualMachineInstance templates", func() {
 			vm, vmi := DefaultVirtualMachineWithNames(true, "vmname", "vminame")
 
@@ -1306,6 +1656,103 @@ var _ = Describe("VirtualMachine", func() {
 			controller.Execute()
 		})
 
+		Context("with runStrategy Once", func() {
+			var vm *v1.VirtualMachine
+			var vmi *v1.VirtualMachineInstance
+
+			BeforeEach(func() {
+				runStrategy := v1.RunStrategyOnce
+				vm, vmi = DefaultVirtualMachine(true)
+				vm.Spec.Running = nil
+				vm.Spec.RunStrategy = &runStrategy
+			})
+
+			finishVMI := func(phase v1.VirtualMachineInstancePhase, finished time.Time) {
+				vmi.Status.Phase = phase
+				vmi.Status.PhaseTransitionTimestamps = []v1.VirtualMachineInstancePhaseTransitionTimestamp{
+					{Phase: v1.Running, PhaseTransitionTimestamp: metav1.NewTime(finished.Add(-time.Hour))},
+					{Phase: phase, PhaseTransitionTimestamp: metav1.NewTime(finished)},
+				}
+			}
+
+			It("should start the VMI", func() {
+				addVirtualMachine(vm)
+
+				vmiInterface.EXPECT().Create(gomock.Any()).Return(vmi, nil)
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Times(1).Return(vm, nil)
+
+				controller.Execute()
+
+				testutils.ExpectEvent(recorder, SuccessfulCreateVirtualMachineReason)
+			})
+
+			table.DescribeTable("should not restart the VMI when it finished", func(phase v1.VirtualMachineInstancePhase) {
+				finishVMI(phase, time.Now())
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				shouldExpectVMIFinalizerRemoval(vmi)
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Times(1).Return(vm, nil)
+
+				controller.Execute()
+			},
+				table.Entry("successfully", v1.Succeeded),
+				table.Entry("with a failure", v1.Failed),
+			)
+
+			It("should record that the VMI was started", func() {
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Times(1).Do(func(obj interface{}) {
+					Expect(obj.(*v1.VirtualMachine).Status.RunOnceStarted).To(BeTrue())
+				}).Return(vm, nil)
+
+				controller.Execute()
+			})
+
+			It("should not start the VMI again when it was deleted", func() {
+				vm.Status.RunOnceStarted = true
+				addVirtualMachine(vm)
+
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Times(1).Do(func(obj interface{}) {
+					Expect(obj.(*v1.VirtualMachine).Status.RunOnceStarted).To(BeTrue())
+				}).Return(vm, nil)
+
+				controller.Execute()
+			})
+
+			It("should delete the VM once the TTL after the VMI finished expired", func() {
+				ttl := int32(60)
+				vm.Spec.TTLSecondsAfterFinished = &ttl
+				finishVMI(v1.Succeeded, time.Now().Add(-2*time.Minute))
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				vmInterface.EXPECT().Delete(vm.Name, gomock.Any()).Do(func(name string, options *metav1.DeleteOptions) {
+					Expect(*options.PropagationPolicy).To(Equal(metav1.DeletePropagationBackground))
+					Expect(*options.Preconditions.UID).To(Equal(vm.UID))
+				}).Return(nil)
+
+				controller.Execute()
+			})
+
+			It("should keep the VM until the TTL after the VMI finished expired", func() {
+				ttl := int32(3600)
+				vm.Spec.TTLSecondsAfterFinished = &ttl
+				finishVMI(v1.Failed, time.Now().Add(-2*time.Minute))
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				shouldExpectVMIFinalizerRemoval(vmi)
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Times(1).Return(vm, nil)
+
+				controller.Execute()
+
+				Expect(mockQueue.GetAddAfterEnqueueCount()).To(Equal(1))
+			})
+		})
+
 		It("should ignore non-matching VMIs", func() {
 			vm, vmi := DefaultVirtualMachine(true)
 
@@ -1524,6 +1971,200 @@ var _ = Describe("VirtualMachine", func() {
 			controller.Execute()
 		})
 
+		It("should copy the drift detected condition from the VMI", func() {
+			vm, vmi := DefaultVirtualMachine(true)
+			addVirtualMachine(vm)
+
+			markAsReady(vmi)
+			vmi.Status.Conditions = append(vmi.Status.Conditions, virtv1.VirtualMachineInstanceCondition{
+				Type:    virtv1.VirtualMachineInstanceDriftDetected,
+				Status:  k8sv1.ConditionTrue,
+				Reason:  virtv1.VirtualMachineInstanceReasonDomainDrifted,
+				Message: "the running domain differs from spec.domain.cpu until the VMI is restarted",
+			})
+			vmiFeeder.Add(vmi)
+
+			vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(obj interface{}) {
+				objVM := obj.(*v1.VirtualMachine)
+				cond := virtcontroller.NewVirtualMachineConditionManager().
+					GetCondition(objVM, v1.VirtualMachineDriftDetected)
+				Expect(cond).ToNot(BeNil())
+				Expect(cond.Status).To(Equal(k8sv1.ConditionTrue))
+				Expect(cond.Reason).To(Equal(v1.VirtualMachineInstanceReasonDomainDrifted))
+				Expect(cond.Message).To(Equal("the running domain differs from spec.domain.cpu until the VMI is restarted"))
+			}).Return(vm, nil)
+
+			controller.Execute()
+		})
+
+		It("should remove the drift detected condition once the VMI reports no drift", func() {
+			vm, vmi := DefaultVirtualMachine(true)
+			vm.Status.Conditions = append(vm.Status.Conditions, virtv1.VirtualMachineCondition{
+				Type:   virtv1.VirtualMachineDriftDetected,
+				Status: k8sv1.ConditionTrue,
+			})
+			addVirtualMachine(vm)
+
+			markAsReady(vmi)
+			vmiFeeder.Add(vmi)
+
+			vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(obj interface{}) {
+				objVM := obj.(*v1.VirtualMachine)
+				cond := virtcontroller.NewVirtualMachineConditionManager().
+					GetCondition(objVM, v1.VirtualMachineDriftDetected)
+				Expect(cond).To(BeNil())
+			}).Return(vm, nil)
+
+			controller.Execute()
+		})
+
+		Context("with a template which changed since the VMI was started", func() {
+			var vm *v1.VirtualMachine
+			var vmi *v1.VirtualMachineInstance
+
+			BeforeEach(func() {
+				vm, vmi = DefaultVirtualMachine(true)
+				vm.Generation = 1
+				vmRevision := createVMRevision(vm)
+				Expect(crInformer.GetStore().Add(vmRevision)).To(Succeed())
+				vmi.Status.VirtualMachineRevisionName = vmRevision.Name
+				markAsReady(vmi)
+				vm.Generation = 2
+			})
+
+			expectNoRestartRequiredCondition := func() {
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(obj interface{}) {
+					objVM := obj.(*v1.VirtualMachine)
+					cond := virtcontroller.NewVirtualMachineConditionManager().
+						GetCondition(objVM, v1.VirtualMachineRestartRequired)
+					Expect(cond).To(BeNil())
+				}).Return(vm, nil)
+			}
+
+			It("should add the restart required condition listing the changed fields", func() {
+				vm.Spec.Template.Spec.Domain.CPU = &v1.CPU{Cores: 4}
+				vm.Spec.Template.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(obj interface{}) {
+					objVM := obj.(*v1.VirtualMachine)
+					cond := virtcontroller.NewVirtualMachineConditionManager().
+						GetCondition(objVM, v1.VirtualMachineRestartRequired)
+					Expect(cond).ToNot(BeNil())
+					Expect(cond.Status).To(Equal(k8sv1.ConditionTrue))
+					Expect(cond.Reason).To(Equal(v1.VirtualMachineReasonTemplateChanged))
+					Expect(cond.Message).To(Equal("a restart of the VM is required to apply the changes of " +
+						"spec.template.spec.domain.cpu, spec.template.spec.domain.devices.interfaces"))
+				}).Return(vm, nil)
+
+				controller.Execute()
+			})
+
+			It("should not require a restart for hotplugged volumes", func() {
+				volume := v1.Volume{
+					Name: "hotplug",
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "hotplug"}},
+					},
+				}
+				vm.Spec.Template.Spec.Volumes = append(vm.Spec.Template.Spec.Volumes, volume)
+				vm.Spec.Template.Spec.Domain.Devices.Disks = append(vm.Spec.Template.Spec.Domain.Devices.Disks, v1.Disk{Name: "hotplug"})
+				vmi.Spec.Volumes = append(vmi.Spec.Volumes, volume)
+				vmi.Status.VolumeStatus = append(vmi.Status.VolumeStatus, v1.VolumeStatus{Name: "hotplug", HotplugVolume: &v1.HotplugVolumeStatus{}})
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				expectNoRestartRequiredCondition()
+
+				controller.Execute()
+			})
+
+			It("should remove the restart required condition once the template is reverted", func() {
+				vm.Status.Conditions = append(vm.Status.Conditions, v1.VirtualMachineCondition{
+					Type:   v1.VirtualMachineRestartRequired,
+					Status: k8sv1.ConditionTrue,
+				})
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				expectNoRestartRequiredCondition()
+
+				controller.Execute()
+			})
+
+			Context("with a volume which can be hotplugged", func() {
+				BeforeEach(func() {
+					vm.Spec.Template.Spec.Volumes = append(vm.Spec.Template.Spec.Volumes, v1.Volume{
+						Name: "hotplug",
+						VolumeSource: v1.VolumeSource{
+							PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "hotplug"}},
+						},
+					})
+					vm.Spec.Template.Spec.Domain.Devices.Disks = append(vm.Spec.Template.Spec.Domain.Devices.Disks, v1.Disk{
+						Name: "hotplug",
+						DiskDevice: v1.DiskDevice{
+							Disk: &v1.DiskTarget{Bus: "scsi"},
+						},
+					})
+				})
+
+				It("should report the pending changes with their apply methods", func() {
+					vm.Spec.Template.Spec.Domain.CPU = &v1.CPU{Cores: 4}
+					addVirtualMachine(vm)
+					vmiFeeder.Add(vmi)
+
+					vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(obj interface{}) {
+						objVM := obj.(*v1.VirtualMachine)
+						Expect(objVM.Status.PendingChanges).To(Equal([]v1.VirtualMachinePendingChange{
+							{Path: "spec.template.spec.domain.cpu", ApplyMethod: v1.ChangeApplyMethodRestart},
+							{Path: "spec.template.spec.volumes[name=hotplug]", ApplyMethod: v1.ChangeApplyMethodHotplug},
+						}))
+						cond := virtcontroller.NewVirtualMachineConditionManager().
+							GetCondition(objVM, v1.VirtualMachineRestartRequired)
+						Expect(cond).ToNot(BeNil())
+						Expect(cond.Message).To(Equal("a restart of the VM is required to apply the changes of spec.template.spec.domain.cpu"))
+					}).Return(vm, nil)
+
+					controller.Execute()
+				})
+
+				It("should hotplug the volume with the Live change apply policy", func() {
+					policy := v1.ChangeApplyPolicyLive
+					vm.Spec.ChangeApplyPolicy = &policy
+					addVirtualMachine(vm)
+					vmiFeeder.Add(vmi)
+
+					vmiInterface.EXPECT().AddVolume(vmi.Name, gomock.Any()).Do(func(name string, options *v1.AddVolumeOptions) {
+						Expect(options.Name).To(Equal("hotplug"))
+						Expect(options.VolumeSource.PersistentVolumeClaim.ClaimName).To(Equal("hotplug"))
+					}).Return(nil)
+					vmInterface.EXPECT().UpdateStatus(gomock.Any()).Return(vm, nil)
+
+					controller.Execute()
+					testutils.ExpectEvent(recorder, SuccessfulApplyChangesReason)
+				})
+			})
+
+			It("should restart the VM to apply changes with the Automatic change apply policy", func() {
+				policy := v1.ChangeApplyPolicyAutomatic
+				vm.Spec.ChangeApplyPolicy = &policy
+				vm.Spec.Template.Spec.Domain.CPU = &v1.CPU{Cores: 4}
+				addVirtualMachine(vm)
+				vmiFeeder.Add(vmi)
+
+				vmInterface.EXPECT().UpdateStatus(gomock.Any()).Do(func(obj interface{}) {
+					objVM := obj.(*v1.VirtualMachine)
+					Expect(objVM.Status.StateChangeRequests).To(HaveLen(2))
+					Expect(objVM.Status.StateChangeRequests[0].Action).To(Equal(v1.StopRequest))
+					Expect(*objVM.Status.StateChangeRequests[0].UID).To(Equal(vmi.UID))
+					Expect(objVM.Status.StateChangeRequests[1].Action).To(Equal(v1.StartRequest))
+				}).Return(vm, nil)
+
+				controller.Execute()
+			})
+		})
+
 		It("should back off if a sync error occurs", func() {
 			vm, vmi := DefaultVirtualMachine(false)
 
@@ -2149,9 +2790,10 @@ func VirtualMachineFromVMI(name string, vmi *v1.VirtualMachineInstance, started
 		Status: v1.VirtualMachineStatus{
 			Conditions: []v1.VirtualMachineCondition{
 				{
-					Type:   v1.VirtualMachineReady,
-					Status: k8sv1.ConditionFalse,
-					Reason: "VMINotExists",
+					Type:    v1.VirtualMachineReady,
+					Status:  k8sv1.ConditionFalse,
+					Reason:  "VMINotExists",
+					Message: "VMI does not exist",
 				},
 			},
 		},
@@ -2181,3 +2823,12 @@ func DefaultVirtualMachineWithNames(started bool, vmName string, vmiName string)
 func DefaultVirtualMachine(started bool) (*v1.VirtualMachine, *v1.VirtualMachineInstance) {
 	return DefaultVirtualMachineWithNames(started, "testvmi", "testvmi")
 }
+
+type fakeDigestResolver map[string]string
+
+func (r fakeDigestResolver) ResolveImageDigest(_ context.Context, image string) (string, error) {
+	if digest, exists := r[image]; exists {
+		return image + "@" + digest, nil
+	}
+	return "", fmt.Errorf("image %s not found", image)
+}
@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -44,6 +45,7 @@ import (
 	"kubevirt.io/client-go/log"
 	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1beta1"
 	"kubevirt.io/kubevirt/pkg/controller"
+	"kubevirt.io/kubevirt/pkg/util/conditions"
 	kubevirttypes "kubevirt.io/kubevirt/pkg/util/types"
 	"kubevirt.io/kubevirt/pkg/virt-controller/services"
 )
@@ -98,6 +100,9 @@ const (
 	// FailedPvcNotFoundReason is added in an event
 	// when a PVC for a volume was not found.
 	FailedPvcNotFoundReason = "FailedPvcNotFound"
+	// FailedLocalVolumesReason is added in an event and in a vmi controller condition
+	// when the volumes of a vmi are local to different nodes.
+	FailedLocalVolumesReason = "FailedLocalVolumes"
 	// SuccessfulMigrationReason is added when a migration attempt completes successfully
 	SuccessfulMigrationReason = "SuccessfulMigration"
 	// FailedMigrationReason is added when a migration attempt fails
@@ -117,6 +122,8 @@ const (
 	// ImagePullBackOffReason is set when an error has occured while pulling an image for a containerDisk VM volume,
 	// and that kubelet is backing off before retrying.
 	ImagePullBackOffReason = "ImagePullBackOff"
+	// FailedSetPodConditionReason is set when the readiness gate condition of the virt-launcher pod can't be set
+	FailedSetPodConditionReason = "FailedSetPodCondition"
 )
 
 const failedToRenderLaunchManifestErrFormat = "failed to render launch manifest: %v"
@@ -130,6 +137,8 @@ func NewVMIController(templateService services.TemplateService,
 	clientset kubecli.KubevirtClient,
 	dataVolumeInformer cache.SharedIndexInformer,
 	topologyHinter topology.Hinter,
+	schedulingHintsInformer cache.SharedIndexInformer,
+	pvInformer cache.SharedIndexInformer,
 ) *VMIController {
 
 	c := &VMIController{
@@ -145,6 +154,9 @@ func NewVMIController(templateService services.TemplateService,
 		vmiExpectations:    controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectations()),
 		dataVolumeInformer: dataVolumeInformer,
 		topologyHinter:     topologyHinter,
+
+		schedulingHintsInformer: schedulingHintsInformer,
+		pvInformer:              pvInformer,
 	}
 
 	c.vmiInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -199,6 +211,9 @@ type VMIController struct {
 	podExpectations    *controller.UIDTrackingControllerExpectations
 	vmiExpectations    *controller.UIDTrackingControllerExpectations
 	dataVolumeInformer cache.SharedIndexInformer
+
+	schedulingHintsInformer cache.SharedIndexInformer
+	pvInformer              cache.SharedIndexInformer
 }
 
 func (c *VMIController) Run(threadiness int, stopCh <-chan struct{}) {
@@ -207,7 +222,7 @@ func (c *VMIController) Run(threadiness int, stopCh <-chan struct{}) {
 	log.Log.Info("Starting vmi controller.")
 
 	// Wait for cache sync before we start the pod controller
-	cache.WaitForCacheSync(stopCh, c.vmInformer.HasSynced, c.vmiInformer.HasSynced, c.podInformer.HasSynced, c.dataVolumeInformer.HasSynced)
+	cache.WaitForCacheSync(stopCh, c.vmInformer.HasSynced, c.vmiInformer.HasSynced, c.podInformer.HasSynced, c.dataVolumeInformer.HasSynced, c.schedulingHintsInformer.HasSynced, c.pvInformer.HasSynced)
 
 	// Start the actual work
 	for i := 0; i < threadiness; i++ {
@@ -374,6 +389,34 @@ func (c *VMIController) hasOwnerVM(vmi *virtv1.VirtualMachineInstance) bool {
 	return false
 }
 
+// getResourceOverheadFromPod returns the resource overhead virt-controller added to the pod
+func getResourceOverheadFromPod(pod *k8sv1.Pod) *virtv1.VirtualMachineInstanceResourceOverhead {
+	value, exists := pod.Annotations[virtv1.ResourceOverheadAnnotation]
+	if !exists {
+		return nil
+	}
+	overhead := &virtv1.VirtualMachineInstanceResourceOverhead{}
+	if err := json.Unmarshal([]byte(value), overhead); err != nil {
+		log.Log.Object(pod).Reason(err).Errorf("Failed to parse the resource overhead of pod %s", pod.Name)
+		return nil
+	}
+	return overhead
+}
+
+// getCPUAllocationRatioFromPod returns the CPU allocation ratio virt-controller applied to the pod
+func getCPUAllocationRatioFromPod(pod *k8sv1.Pod) *int {
+	value, exists := pod.Annotations[virtv1.CPUAllocationRatioAnnotation]
+	if !exists {
+		return nil
+	}
+	ratio, err := strconv.Atoi(value)
+	if err != nil {
+		log.Log.Object(pod).Reason(err).Errorf("Failed to parse the CPU allocation ratio of pod %s", pod.Name)
+		return nil
+	}
+	return &ratio
+}
+
 func (c *VMIController) updateStatus(vmi *virtv1.VirtualMachineInstance, pod *k8sv1.Pod, dataVolumes []*cdiv1.DataVolume, syncErr syncError) error {
 
 	hasFailedDataVolume := false
@@ -422,13 +465,10 @@ func (c *VMIController) updateStatus(vmi *virtv1.VirtualMachineInstance, pod *k8
 				}
 			}
 			if hasWffcDataVolume {
-				condition := virtv1.VirtualMachineInstanceCondition{
+				conditions.SetVMICondition(vmiCopy, virtv1.VirtualMachineInstanceCondition{
 					Type:   virtv1.VirtualMachineInstanceProvisioning,
 					Status: k8sv1.ConditionTrue,
-				}
-				if !conditionManager.HasCondition(vmiCopy, condition.Type) {
-					vmiCopy.Status.Conditions = append(vmiCopy.Status.Conditions, condition)
-				}
+				})
 				if tempPodExists {
 					// Add PodScheduled False condition to the VM
 					if cond := conditionManager.GetPodConditionWithStatus(pod, k8sv1.PodScheduled, k8sv1.ConditionFalse); cond != nil {
@@ -442,18 +482,23 @@ func (c *VMIController) updateStatus(vmi *virtv1.VirtualMachineInstance, pod *k8
 					}
 				}
 			}
-			if syncErr != nil && syncErr.Reason() == FailedPvcNotFoundReason {
-				condition := virtv1.VirtualMachineInstanceCondition{
+			if gates := unsatisfiedSchedulingReadinessGates(vmi); len(gates) > 0 {
+				conditions.SetVMICondition(vmiCopy, virtv1.VirtualMachineInstanceCondition{
+					Type:    virtv1.VirtualMachineInstanceConditionType(k8sv1.PodScheduled),
+					Reason:  virtv1.SchedulingGatedReason,
+					Message: fmt.Sprintf("Waiting for scheduling readiness gates: %s", strings.Join(gates, ", ")),
+					Status:  k8sv1.ConditionFalse,
+				})
+			} else if conditionManager.HasConditionWithStatusAndReason(vmiCopy, virtv1.VirtualMachineInstanceConditionType(k8sv1.PodScheduled), k8sv1.ConditionFalse, virtv1.SchedulingGatedReason) {
+				conditionManager.RemoveCondition(vmiCopy, virtv1.VirtualMachineInstanceConditionType(k8sv1.PodScheduled))
+			}
+			if syncErr != nil && (syncErr.Reason() == FailedPvcNotFoundReason || syncErr.Reason() == FailedLocalVolumesReason) {
+				conditions.SetVMICondition(vmiCopy, virtv1.VirtualMachineInstanceCondition{
 					Type:    virtv1.VirtualMachineInstanceConditionType(k8sv1.PodScheduled),
 					Reason:  k8sv1.PodReasonUnschedulable,
 					Message: syncErr.Error(),
 					Status:  k8sv1.ConditionFalse,
-				}
-				cm := controller.NewVirtualMachineInstanceConditionManager()
-				if cm.HasCondition(vmiCopy, condition.Type) {
-					cm.RemoveCondition(vmiCopy, condition.Type)
-				}
-				vmiCopy.Status.Conditions = append(vmiCopy.Status.Conditions, condition)
+				})
 			}
 		}
 	case vmi.IsScheduling():
@@ -469,6 +514,8 @@ func (c *VMIController) updateStatus(vmi *virtv1.VirtualMachineInstance, pod *k8
 			} else {
 				vmiCopy.Status.QOSClass = &pod.Status.QOSClass
 			}
+			vmiCopy.Status.ResourceOverhead = getResourceOverheadFromPod(pod)
+			vmiCopy.Status.CPUAllocationRatio = getCPUAllocationRatioFromPod(pod)
 
 			// Add PodScheduled False condition to the VM
 			if cond := conditionManager.GetPodConditionWithStatus(pod, k8sv1.PodScheduled, k8sv1.ConditionFalse); cond != nil {
@@ -543,6 +590,8 @@ func (c *VMIController) updateStatus(vmi *virtv1.VirtualMachineInstance, pod *k8
 		}
 
 	case vmi.IsRunning():
+		resetMigrationBackoff(vmiCopy)
+
 		if !vmiPodExists {
 			break
 		}
@@ -608,6 +657,15 @@ func (c *VMIController) updateStatus(vmi *virtv1.VirtualMachineInstance, pod *k8
 	return nil
 }
 
+// resetMigrationBackoff clears the migration backoff of the VMI if it was requested by annotating the VMI
+func resetMigrationBackoff(vmi *virtv1.VirtualMachineInstance) {
+	if _, reset := vmi.Annotations[virtv1.ResetMigrationBackoffAnnotation]; !reset {
+		return
+	}
+	delete(vmi.Annotations, virtv1.ResetMigrationBackoffAnnotation)
+	vmi.Status.MigrationBackoff = nil
+}
+
 func preparePatch(oldVMI, newVMI *virtv1.VirtualMachineInstance) ([]byte, error) {
 	var patchOps []string
 
@@ -672,6 +730,30 @@ func preparePatch(oldVMI, newVMI *virtv1.VirtualMachineInstance) ([]byte, error)
 		}
 	}
 
+	if oldVMI.Status.MigrationBackoff != nil && newVMI.Status.MigrationBackoff == nil {
+		oldBackoff, err := json.Marshal(oldVMI.Status.MigrationBackoff)
+		if err != nil {
+			return nil, err
+		}
+
+		patchOps = append(patchOps, fmt.Sprintf(`{ "op": "test", "path": "/status/migrationBackoff", "value": %s }`, string(oldBackoff)))
+		patchOps = append(patchOps, `{ "op": "remove", "path": "/status/migrationBackoff" }`)
+	}
+
+	if !reflect.DeepEqual(oldVMI.Annotations, newVMI.Annotations) {
+		newAnnotationBytes, err := json.Marshal(newVMI.Annotations)
+		if err != nil {
+			return nil, err
+		}
+		oldAnnotationBytes, err := json.Marshal(oldVMI.Annotations)
+		if err != nil {
+			return nil, err
+		}
+
+		patchOps = append(patchOps, fmt.Sprintf(`{ "op": "test", "path": "/metadata/annotations", "value": %s }`, string(oldAnnotationBytes)))
+		patchOps = append(patchOps, fmt.Sprintf(`{ "op": "replace", "path": "/metadata/annotations", "value": %s }`, string(newAnnotationBytes)))
+	}
+
 	if !reflect.DeepEqual(oldVMI.Labels, newVMI.Labels) {
 		newLabelBytes, err := json.Marshal(newVMI.Labels)
 		if err != nil {
@@ -701,35 +783,28 @@ func preparePatch(oldVMI, newVMI *virtv1.VirtualMachineInstance) ([]byte, error)
 func (c *VMIController) syncReadyConditionFromPod(vmi *virtv1.VirtualMachineInstance, pod *k8sv1.Pod) {
 	conditionManager := controller.NewVirtualMachineInstanceConditionManager()
 
-	now := v1.Now()
 	if pod == nil || isTempPod(pod) {
 		conditionManager.UpdateCondition(vmi, &virtv1.VirtualMachineInstanceCondition{
-			Type:               virtv1.VirtualMachineInstanceReady,
-			Status:             k8sv1.ConditionFalse,
-			Reason:             virtv1.PodNotExistsReason,
-			Message:            "virt-launcher pod has not yet been scheduled",
-			LastProbeTime:      now,
-			LastTransitionTime: now,
+			Type:    virtv1.VirtualMachineInstanceReady,
+			Status:  k8sv1.ConditionFalse,
+			Reason:  virtv1.PodNotExistsReason,
+			Message: "virt-launcher pod has not yet been scheduled",
 		})
 
 	} else if isPodDownOrGoingDown(pod) {
 		conditionManager.UpdateCondition(vmi, &virtv1.VirtualMachineInstanceCondition{
-			Type:               virtv1.VirtualMachineInstanceReady,
-			Status:             k8sv1.ConditionFalse,
-			Reason:             virtv1.PodTerminatingReason,
-			Message:            "virt-launcher pod is terminating",
-			LastProbeTime:      now,
-			LastTransitionTime: now,
+			Type:    virtv1.VirtualMachineInstanceReady,
+			Status:  k8sv1.ConditionFalse,
+			Reason:  virtv1.PodTerminatingReason,
+			Message: "virt-launcher pod is terminating",
 		})
 
 	} else if !vmi.IsRunning() {
 		conditionManager.UpdateCondition(vmi, &virtv1.VirtualMachineInstanceCondition{
-			Type:               virtv1.VirtualMachineInstanceReady,
-			Status:             k8sv1.ConditionFalse,
-			Reason:             virtv1.GuestNotRunningReason,
-			Message:            "Guest VM is not reported as running",
-			LastProbeTime:      now,
-			LastTransitionTime: now,
+			Type:    virtv1.VirtualMachineInstanceReady,
+			Status:  k8sv1.ConditionFalse,
+			Reason:  virtv1.GuestNotRunningReason,
+			Message: "Guest VM is not reported as running",
 		})
 
 	} else if podReadyCond := conditionManager.GetPodCondition(pod, k8sv1.PodReady); podReadyCond != nil {
@@ -744,12 +819,10 @@ func (c *VMIController) syncReadyConditionFromPod(vmi *virtv1.VirtualMachineInst
 
 	} else {
 		conditionManager.UpdateCondition(vmi, &virtv1.VirtualMachineInstanceCondition{
-			Type:               virtv1.VirtualMachineInstanceReady,
-			Status:             k8sv1.ConditionFalse,
-			Reason:             virtv1.PodConditionMissingReason,
-			Message:            "virt-launcher pod is missing the Ready condition",
-			LastProbeTime:      now,
-			LastTransitionTime: now,
+			Type:    virtv1.VirtualMachineInstanceReady,
+			Status:  k8sv1.ConditionFalse,
+			Reason:  virtv1.PodConditionMissingReason,
+			Message: "virt-launcher pod is missing the Ready condition",
 		})
 	}
 }
@@ -899,6 +972,11 @@ func (c *VMIController) sync(vmi *virtv1.VirtualMachineInstance, pod *k8sv1.Pod,
 			return nil
 		}
 
+		if gates := unsatisfiedSchedulingReadinessGates(vmi); len(gates) > 0 {
+			log.Log.V(3).Object(vmi).Infof("Delaying pod creation until scheduling readiness gates %s are satisfied", strings.Join(gates, ", "))
+			return nil
+		}
+
 		// ensure that all dataVolumes associated with the VMI are ready before creating the pod
 		if !dataVolumesReady {
 			log.Log.V(3).Object(vmi).Infof("Delaying pod creation while DataVolume populates")
@@ -919,6 +997,15 @@ func (c *VMIController) sync(vmi *virtv1.VirtualMachineInstance, pod *k8sv1.Pod,
 			return &syncErrorImpl{fmt.Errorf(failedToRenderLaunchManifestErrFormat, err), FailedCreatePodReason}
 		}
 
+		if err := c.applySchedulingHints(vmi, templatePod); err != nil {
+			return &syncErrorImpl{fmt.Errorf("failed to apply scheduling hints: %v", err), FailedCreatePodReason}
+		}
+
+		if err := c.applyLocalVolumesPlacement(vmi, templatePod); err != nil {
+			c.recorder.Eventf(vmi, k8sv1.EventTypeWarning, FailedLocalVolumesReason, "Failed to place the pod: %v", err)
+			return &syncErrorImpl{fmt.Errorf("failed to place the pod: %v", err), FailedLocalVolumesReason}
+		}
+
 		vmiKey := controller.VirtualMachineInstanceKey(vmi)
 		c.podExpectations.ExpectCreations(vmiKey, 1)
 		pod, err := c.clientset.CoreV1().Pods(vmi.GetNamespace()).Create(context.Background(), templatePod, v1.CreateOptions{})
@@ -938,6 +1025,14 @@ func (c *VMIController) sync(vmi *virtv1.VirtualMachineInstance, pod *k8sv1.Pod,
 		}
 	}
 
+	// the pod joins the endpoints of Services once the readiness gate is satisfied, it is only unset
+	// again by the migration controller while it drains the connections to the VMI before the switchover
+	if !isTempPod(pod) && pod.DeletionTimestamp == nil && hasNotDrainingReadinessGate(pod) && needsNotDrainingCondition(pod, vmi) {
+		if err := patchNotDrainingCondition(c.clientset, pod, k8sv1.ConditionTrue, "", ""); err != nil {
+			return &syncErrorImpl{fmt.Errorf("failed to set the readiness gate condition of pod %s: %v", pod.Name, err), FailedSetPodConditionReason}
+		}
+	}
+
 	if !isTempPod(pod) && isPodReady(pod) {
 		hotplugVolumes := getHotplugVolumes(vmi, pod)
 		hotplugAttachmentPods, err := controller.AttachmentPods(pod, c.podInformer)
@@ -961,6 +1056,155 @@ func (c *VMIController) sync(vmi *virtv1.VirtualMachineInstance, pod *k8sv1.Pod,
 	return nil
 }
 
+// localVolumeNode returns the hostname of the node the PersistentVolume bound to the PVC is local to, if any
+func (c *VMIController) localVolumeNode(pvc *k8sv1.PersistentVolumeClaim) string {
+	if pvc.Spec.VolumeName == "" {
+		return ""
+	}
+	obj, exists, err := c.pvInformer.GetStore().GetByKey(pvc.Spec.VolumeName)
+	if err != nil || !exists {
+		return ""
+	}
+	return kubevirttypes.LocalVolumeNode(obj.(*k8sv1.PersistentVolume))
+}
+
+// applyLocalVolumesPlacement pins the launcher pod to the node its volumes are local to, so that the
+// pod is not scheduled to a node where the volumes can't be attached. Volumes which are local to
+// different nodes can't be used together.
+func (c *VMIController) applyLocalVolumesPlacement(vmi *virtv1.VirtualMachineInstance, pod *k8sv1.Pod) error {
+	node, nodeClaim := "", ""
+	for i := range vmi.Spec.Volumes {
+		if vmi.Spec.Volumes[i].PersistentVolumeClaim == nil && vmi.Spec.Volumes[i].DataVolume == nil {
+			continue
+		}
+		claimName := kubevirttypes.PVCNameFromVirtVolume(&vmi.Spec.Volumes[i])
+		obj, exists, err := c.pvcInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", vmi.Namespace, claimName))
+		if err != nil {
+			return err
+		} else if !exists {
+			continue
+		}
+		claimNode := c.localVolumeNode(obj.(*k8sv1.PersistentVolumeClaim))
+		if claimNode == "" {
+			continue
+		}
+		if node != "" && node != claimNode {
+			return fmt.Errorf("PVC %s is local to node %s, but PVC %s is local to node %s", nodeClaim, node, claimName, claimNode)
+		}
+		node, nodeClaim = claimNode, claimName
+	}
+	if node == "" {
+		return nil
+	}
+
+	log.Log.Object(vmi).V(3).Infof("Placing the pod on node %s, where PVC %s is local to", node, nodeClaim)
+	requirement := k8sv1.NodeSelectorRequirement{
+		Key:      k8sv1.LabelHostname,
+		Operator: k8sv1.NodeSelectorOpIn,
+		Values:   []string{node},
+	}
+	if pod.Spec.Affinity == nil {
+		pod.Spec.Affinity = &k8sv1.Affinity{}
+	}
+	if pod.Spec.Affinity.NodeAffinity == nil {
+		pod.Spec.Affinity.NodeAffinity = &k8sv1.NodeAffinity{}
+	}
+	nodeAffinity := pod.Spec.Affinity.NodeAffinity
+	if nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &k8sv1.NodeSelector{}
+	}
+	required := nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if len(required.NodeSelectorTerms) == 0 {
+		required.NodeSelectorTerms = []k8sv1.NodeSelectorTerm{{}}
+	}
+	// the terms are ORed, the node has to be required by each of them
+	for i := range required.NodeSelectorTerms {
+		required.NodeSelectorTerms[i].MatchExpressions = append(required.NodeSelectorTerms[i].MatchExpressions, requirement)
+	}
+	return nil
+}
+
+// unsatisfiedSchedulingReadinessGates returns the condition types of all scheduling readiness gates
+// which are not yet set to True on the VMI.
+func unsatisfiedSchedulingReadinessGates(vmi *virtv1.VirtualMachineInstance) []string {
+	var gates []string
+	conditionManager := controller.NewVirtualMachineInstanceConditionManager()
+	for _, gate := range vmi.Spec.SchedulingReadinessGates {
+		if !conditionManager.HasConditionWithStatus(vmi, gate.ConditionType, k8sv1.ConditionTrue) {
+			gates = append(gates, string(gate.ConditionType))
+		}
+	}
+	return gates
+}
+
+// applySchedulingHints folds the VirtualMachineSchedulingHints with the name of the VMI,
+// if there are any, into the affinity of the launcher pod. Preferred nodes become weighted
+// node affinity terms and a spread group becomes a preferred pod anti-affinity on the
+// hostname against all launcher pods of the same group.
+func (c *VMIController) applySchedulingHints(vmi *virtv1.VirtualMachineInstance, pod *k8sv1.Pod) error {
+	obj, exists, err := c.schedulingHintsInformer.GetStore().GetByKey(controller.VirtualMachineInstanceKey(vmi))
+	if err != nil {
+		return err
+	} else if !exists {
+		return nil
+	}
+	hints := obj.(*virtv1.VirtualMachineSchedulingHints)
+
+	if len(hints.Spec.PreferredNodes) == 0 && hints.Spec.SpreadGroup == "" {
+		return nil
+	}
+	if pod.Spec.Affinity == nil {
+		pod.Spec.Affinity = &k8sv1.Affinity{}
+	}
+
+	if len(hints.Spec.PreferredNodes) > 0 {
+		if pod.Spec.Affinity.NodeAffinity == nil {
+			pod.Spec.Affinity.NodeAffinity = &k8sv1.NodeAffinity{}
+		}
+		nodeAffinity := pod.Spec.Affinity.NodeAffinity
+		for _, node := range hints.Spec.PreferredNodes {
+			// kube-apiserver rejects pods with preferred terms outside of this range
+			if node.Weight < 1 || node.Weight > 100 {
+				log.Log.Object(vmi).Warningf("Ignoring scheduling hint for node %s with invalid weight %d", node.NodeName, node.Weight)
+				continue
+			}
+			nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution, k8sv1.PreferredSchedulingTerm{
+				Weight: node.Weight,
+				Preference: k8sv1.NodeSelectorTerm{
+					MatchFields: []k8sv1.NodeSelectorRequirement{
+						{
+							Key:      "metadata.name",
+							Operator: k8sv1.NodeSelectorOpIn,
+							Values:   []string{node.NodeName},
+						},
+					},
+				},
+			})
+		}
+	}
+
+	if hints.Spec.SpreadGroup != "" {
+		if pod.Labels == nil {
+			pod.Labels = map[string]string{}
+		}
+		pod.Labels[virtv1.SchedulingSpreadGroupLabel] = hints.Spec.SpreadGroup
+		if pod.Spec.Affinity.PodAntiAffinity == nil {
+			pod.Spec.Affinity.PodAntiAffinity = &k8sv1.PodAntiAffinity{}
+		}
+		podAntiAffinity := pod.Spec.Affinity.PodAntiAffinity
+		podAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(podAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution, k8sv1.WeightedPodAffinityTerm{
+			Weight: 100,
+			PodAffinityTerm: k8sv1.PodAffinityTerm{
+				LabelSelector: &v1.LabelSelector{
+					MatchLabels: map[string]string{virtv1.SchedulingSpreadGroupLabel: hints.Spec.SpreadGroup},
+				},
+				TopologyKey: k8sv1.LabelHostname,
+			},
+		})
+	}
+	return nil
+}
+
 func (c *VMIController) handleSyncDataVolumes(vmi *virtv1.VirtualMachineInstance, dataVolumes []*cdiv1.DataVolume) (bool, bool, syncError) {
 
 	ready := true
@@ -1414,7 +1658,7 @@ func getHotplugVolumes(vmi *virtv1.VirtualMachineInstance, virtlauncherPod *k8sv
 		podVolumeMap[podVolume.Name] = podVolume
 	}
 	for _, vmiVolume := range vmiVolumes {
-		if _, ok := podVolumeMap[vmiVolume.Name]; !ok && (vmiVolume.DataVolume != nil || vmiVolume.PersistentVolumeClaim != nil) {
+		if _, ok := podVolumeMap[vmiVolume.Name]; !ok && (vmiVolume.DataVolume != nil || vmiVolume.PersistentVolumeClaim != nil || vmiVolume.MemoryDump != nil) {
 			hotplugVolumes = append(hotplugVolumes, vmiVolume.DeepCopy())
 		}
 	}
@@ -1607,12 +1851,7 @@ func (c *VMIController) triggerHotplugPopulation(volume *virtv1.Volume, vmi *vir
 }
 
 func (c *VMIController) volumeReadyToAttachToNode(namespace string, volume virtv1.Volume, dataVolumes []*cdiv1.DataVolume) (bool, bool, error) {
-	name := ""
-	if volume.DataVolume != nil {
-		name = volume.DataVolume.Name
-	} else if volume.PersistentVolumeClaim != nil {
-		name = volume.PersistentVolumeClaim.ClaimName
-	}
+	name := kubevirttypes.PVCNameFromVirtVolume(&volume)
 	wffc := false
 	ready := false
 	// err is always nil
@@ -1863,14 +2102,9 @@ func (c *VMIController) updateVolumeStatus(vmi *virtv1.VirtualMachineInstance, v
 			}
 		}
 
-		if volume.VolumeSource.PersistentVolumeClaim != nil || volume.VolumeSource.DataVolume != nil {
+		if volume.VolumeSource.PersistentVolumeClaim != nil || volume.VolumeSource.DataVolume != nil || volume.VolumeSource.MemoryDump != nil {
 
-			var pvcName string
-			if volume.VolumeSource.PersistentVolumeClaim != nil {
-				pvcName = volume.VolumeSource.PersistentVolumeClaim.ClaimName
-			} else if volume.VolumeSource.DataVolume != nil {
-				pvcName = volume.VolumeSource.DataVolume.Name
-			}
+			pvcName := kubevirttypes.PVCNameFromVirtVolume(&vmi.Spec.Volumes[i])
 
 			pvcInterface, pvcExists, _ := c.pvcInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", vmi.Namespace, pvcName))
 			if pvcExists {
@@ -1880,6 +2114,7 @@ func (c *VMIController) updateVolumeStatus(vmi *virtv1.VirtualMachineInstance, v
 					VolumeMode:   pvc.Spec.VolumeMode,
 					Capacity:     pvc.Status.Capacity,
 					Preallocated: kubevirttypes.IsPreallocated(pvc.ObjectMeta.Annotations),
+					LocalToNode:  c.localVolumeNode(pvc),
 				}
 			}
 		}
@@ -1928,14 +2163,8 @@ func (c *VMIController) findAttachmentPodByVolumeName(volumeName string, attachm
 }
 
 func (c *VMIController) getVolumePhaseMessageReason(volume *virtv1.Volume, namespace string) (virtv1.VolumePhase, string, string) {
-	claimName := ""
-	if volume.DataVolume != nil {
-		// Using fact that PVC name = DV name.
-		claimName = volume.DataVolume.Name
-	}
-	if volume.PersistentVolumeClaim != nil {
-		claimName = volume.PersistentVolumeClaim.ClaimName
-	}
+	// Using fact that PVC name = DV name.
+	claimName := kubevirttypes.PVCNameFromVirtVolume(volume)
 	pvcInterface, pvcExists, _ := c.pvcInformer.GetStore().GetByKey(fmt.Sprintf("%s/%s", namespace, claimName))
 	if !pvcExists {
 		return virtv1.VolumePending, FailedPvcNotFoundReason, "Unable to determine PVC name"
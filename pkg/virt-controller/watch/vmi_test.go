@@ -81,6 +81,8 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 	var dataVolumeSource *framework.FakeControllerSource
 	var dataVolumeInformer cache.SharedIndexInformer
 	var dataVolumeFeeder *testutils.DataVolumeFeeder
+	var schedulingHintsInformer cache.SharedIndexInformer
+	var pvInformer cache.SharedIndexInformer
 	var qemuGid int64 = 107
 	controllerOf := true
 
@@ -206,13 +208,15 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 		vmInformer, vmSource = testutils.NewFakeInformerFor(&v1.VirtualMachine{})
 		podInformer, podSource = testutils.NewFakeInformerFor(&k8sv1.Pod{})
 		dataVolumeInformer, dataVolumeSource = testutils.NewFakeInformerFor(&cdiv1.DataVolume{})
+		schedulingHintsInformer, _ = testutils.NewFakeInformerFor(&v1.VirtualMachineSchedulingHints{})
+		pvInformer, _ = testutils.NewFakeInformerFor(&k8sv1.PersistentVolume{})
 		recorder = record.NewFakeRecorder(100)
 		recorder.IncludeObject = true
 
 		config, _, _, _ := testutils.NewFakeClusterConfig(&k8sv1.ConfigMap{})
 		pvcInformer, _ = testutils.NewFakeInformerFor(&k8sv1.PersistentVolumeClaim{})
 		controller = NewVMIController(
-			services.NewTemplateService("a", 240, "b", "c", "d", "e", "f", "g", pvcInformer.GetStore(), virtClient, config, qemuGid),
+			services.NewTemplateService("a", 240, "b", "c", "d", "e", "f", "g", pvcInformer.GetStore(), cache.NewStore(cache.MetaNamespaceKeyFunc), virtClient, config, qemuGid),
 			vmiInformer,
 			vmInformer,
 			podInformer,
@@ -221,6 +225,8 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 			virtClient,
 			dataVolumeInformer,
 			topology.NewTopologyHinter(&cache.FakeCustomStore{}, &cache.FakeCustomStore{}, "amd64", nil),
+			schedulingHintsInformer,
+			pvInformer,
 		)
 		// Wrap our workqueue to have a way to detect when we are done processing updates
 		mockQueue = testutils.NewMockWorkQueue(controller.Queue)
@@ -358,11 +364,9 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 
 			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
 				Expect(arg.(*v1.VirtualMachineInstance).Status.Phase).To(Equal(v1.Pending))
-				Expect(arg.(*v1.VirtualMachineInstance).Status.Conditions).
-					To(ContainElement(v1.VirtualMachineInstanceCondition{
-						Type:   v1.VirtualMachineInstanceProvisioning,
-						Status: k8sv1.ConditionTrue,
-					}))
+				Expect(kvcontroller.NewVirtualMachineInstanceConditionManager().
+					HasConditionWithStatus(arg.(*v1.VirtualMachineInstance), v1.VirtualMachineInstanceProvisioning, k8sv1.ConditionTrue)).
+					To(BeTrue())
 			}).Return(vmi, nil)
 			kubeClient.Fake.PrependReactor("get", "persistentvolumeclaims", func(action testing.Action) (handled bool, obj k8sruntime.Object, err error) {
 				return true, dvPVC, nil
@@ -594,11 +598,9 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 
 			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
 				Expect(arg.(*v1.VirtualMachineInstance).Status.Phase).To(Equal(v1.Pending))
-				Expect(arg.(*v1.VirtualMachineInstance).Status.Conditions).
-					To(ContainElement(v1.VirtualMachineInstanceCondition{
-						Type:   v1.VirtualMachineInstanceProvisioning,
-						Status: k8sv1.ConditionTrue,
-					}))
+				Expect(kvcontroller.NewVirtualMachineInstanceConditionManager().
+					HasConditionWithStatus(arg.(*v1.VirtualMachineInstance), v1.VirtualMachineInstanceProvisioning, k8sv1.ConditionTrue)).
+					To(BeTrue())
 			}).Return(vmi, nil)
 			controller.Execute()
 		})
@@ -691,6 +693,203 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 
 			testutils.ExpectEvent(recorder, SuccessfulCreatePodReason)
 		})
+		It("should delay pod creation until all scheduling readiness gates are satisfied", func() {
+			vmi := NewPendingVirtualMachine("testvmi")
+			vmi.Spec.SchedulingReadinessGates = []v1.SchedulingReadinessGate{
+				{ConditionType: "example.com/IPReserved"},
+				{ConditionType: "example.com/LicenseCheckedOut"},
+			}
+			vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{
+				Type:   "example.com/IPReserved",
+				Status: k8sv1.ConditionTrue,
+			})
+
+			addVirtualMachine(vmi)
+
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				updated := arg.(*v1.VirtualMachineInstance)
+				Expect(updated.Status.Phase).To(Equal(v1.Pending))
+				cond := kvcontroller.NewVirtualMachineInstanceConditionManager()
+				Expect(cond.HasConditionWithStatusAndReason(updated, v1.VirtualMachineInstanceConditionType(k8sv1.PodScheduled), k8sv1.ConditionFalse, v1.SchedulingGatedReason)).To(BeTrue())
+				Expect(cond.GetCondition(updated, v1.VirtualMachineInstanceConditionType(k8sv1.PodScheduled)).Message).To(ContainSubstring("example.com/LicenseCheckedOut"))
+			}).Return(vmi, nil)
+
+			controller.Execute()
+		})
+
+		It("should create the pod once all scheduling readiness gates are satisfied", func() {
+			vmi := NewPendingVirtualMachine("testvmi")
+			vmi.Spec.SchedulingReadinessGates = []v1.SchedulingReadinessGate{
+				{ConditionType: "example.com/IPReserved"},
+			}
+			vmi.Status.Conditions = append(vmi.Status.Conditions,
+				v1.VirtualMachineInstanceCondition{
+					Type:   "example.com/IPReserved",
+					Status: k8sv1.ConditionTrue,
+				},
+				v1.VirtualMachineInstanceCondition{
+					Type:    v1.VirtualMachineInstanceConditionType(k8sv1.PodScheduled),
+					Status:  k8sv1.ConditionFalse,
+					Reason:  v1.SchedulingGatedReason,
+					Message: "Waiting for scheduling readiness gates: example.com/IPReserved",
+				},
+			)
+
+			addVirtualMachine(vmi)
+
+			shouldExpectPodCreation(vmi.UID)
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				updated := arg.(*v1.VirtualMachineInstance)
+				Expect(kvcontroller.NewVirtualMachineInstanceConditionManager().HasCondition(updated, v1.VirtualMachineInstanceConditionType(k8sv1.PodScheduled))).To(BeFalse())
+			}).Return(vmi, nil)
+
+			controller.Execute()
+
+			testutils.ExpectEvent(recorder, SuccessfulCreatePodReason)
+		})
+
+		It("should fold the scheduling hints into the affinity of the created pod", func() {
+			vmi := NewPendingVirtualMachine("testvmi")
+			hints := &v1.VirtualMachineSchedulingHints{
+				ObjectMeta: metav1.ObjectMeta{Name: vmi.Name, Namespace: vmi.Namespace},
+				Spec: v1.VirtualMachineSchedulingHintsSpec{
+					PreferredNodes: []v1.PreferredNode{
+						{NodeName: "node01", Weight: 80},
+						{NodeName: "node02", Weight: 20},
+					},
+					SpreadGroup: "db",
+				},
+			}
+			Expect(schedulingHintsInformer.GetStore().Add(hints)).To(Succeed())
+
+			addVirtualMachine(vmi)
+
+			kubeClient.Fake.PrependReactor("create", "pods", func(action testing.Action) (handled bool, obj k8sruntime.Object, err error) {
+				pod := action.(testing.CreateAction).GetObject().(*k8sv1.Pod)
+				Expect(pod.Labels).To(HaveKeyWithValue(v1.SchedulingSpreadGroupLabel, "db"))
+
+				nodeTerms := pod.Spec.Affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution
+				Expect(nodeTerms).To(HaveLen(2))
+				Expect(nodeTerms[0].Weight).To(Equal(int32(80)))
+				Expect(nodeTerms[0].Preference.MatchFields[0].Values).To(ConsistOf("node01"))
+				Expect(nodeTerms[1].Weight).To(Equal(int32(20)))
+				Expect(nodeTerms[1].Preference.MatchFields[0].Values).To(ConsistOf("node02"))
+
+				podTerms := pod.Spec.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution
+				Expect(podTerms).To(HaveLen(1))
+				Expect(podTerms[0].PodAffinityTerm.TopologyKey).To(Equal(k8sv1.LabelHostname))
+				Expect(podTerms[0].PodAffinityTerm.LabelSelector.MatchLabels).To(HaveKeyWithValue(v1.SchedulingSpreadGroupLabel, "db"))
+				return true, pod, nil
+			})
+
+			controller.Execute()
+
+			testutils.ExpectEvent(recorder, SuccessfulCreatePodReason)
+		})
+
+		Context("with volumes local to a node", func() {
+			addLocalVolume := func(vmi *v1.VirtualMachineInstance, claimName string, node string) {
+				vmi.Spec.Volumes = append(vmi.Spec.Volumes, v1.Volume{
+					Name: claimName,
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+							PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: claimName},
+						},
+					},
+				})
+				pvc := &k8sv1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{Namespace: vmi.Namespace, Name: claimName},
+					Spec:       k8sv1.PersistentVolumeClaimSpec{VolumeName: "pv-" + claimName},
+					Status:     k8sv1.PersistentVolumeClaimStatus{Phase: k8sv1.ClaimBound},
+				}
+				Expect(pvcInformer.GetIndexer().Add(pvc)).To(Succeed())
+				pv := &k8sv1.PersistentVolume{
+					ObjectMeta: metav1.ObjectMeta{Name: "pv-" + claimName},
+					Spec: k8sv1.PersistentVolumeSpec{
+						NodeAffinity: &k8sv1.VolumeNodeAffinity{
+							Required: &k8sv1.NodeSelector{
+								NodeSelectorTerms: []k8sv1.NodeSelectorTerm{
+									{
+										MatchExpressions: []k8sv1.NodeSelectorRequirement{
+											{Key: k8sv1.LabelHostname, Operator: k8sv1.NodeSelectorOpIn, Values: []string{node}},
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+				Expect(pvInformer.GetIndexer().Add(pv)).To(Succeed())
+			}
+
+			It("should place the pod on the node of the volumes", func() {
+				vmi := NewPendingVirtualMachine("testvmi")
+				addLocalVolume(vmi, "disk1", "node01")
+				addLocalVolume(vmi, "disk2", "node01")
+				vmi.Spec.Affinity = &k8sv1.Affinity{
+					NodeAffinity: &k8sv1.NodeAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: &k8sv1.NodeSelector{
+							NodeSelectorTerms: []k8sv1.NodeSelectorTerm{
+								{MatchExpressions: []k8sv1.NodeSelectorRequirement{{Key: "zone", Operator: k8sv1.NodeSelectorOpIn, Values: []string{"a"}}}},
+								{MatchExpressions: []k8sv1.NodeSelectorRequirement{{Key: "zone", Operator: k8sv1.NodeSelectorOpIn, Values: []string{"b"}}}},
+							},
+						},
+					},
+				}
+				addVirtualMachine(vmi)
+
+				kubeClient.Fake.PrependReactor("create", "pods", func(action testing.Action) (handled bool, obj k8sruntime.Object, err error) {
+					pod := action.(testing.CreateAction).GetObject().(*k8sv1.Pod)
+					terms := pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+					Expect(terms).To(HaveLen(2))
+					for _, term := range terms {
+						Expect(term.MatchExpressions).To(HaveLen(2))
+						Expect(term.MatchExpressions[1]).To(Equal(k8sv1.NodeSelectorRequirement{
+							Key:      k8sv1.LabelHostname,
+							Operator: k8sv1.NodeSelectorOpIn,
+							Values:   []string{"node01"},
+						}))
+					}
+					return true, pod, nil
+				})
+
+				controller.Execute()
+
+				testutils.ExpectEvent(recorder, SuccessfulCreatePodReason)
+			})
+
+			It("should not create a pod if the volumes are local to different nodes", func() {
+				vmi := NewPendingVirtualMachine("testvmi")
+				addLocalVolume(vmi, "disk1", "node01")
+				addLocalVolume(vmi, "disk2", "node02")
+				addVirtualMachine(vmi)
+
+				vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+					Expect(arg.(*v1.VirtualMachineInstance).Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras,
+						Fields{
+							"Type":   Equal(v1.VirtualMachineInstanceConditionType(k8sv1.PodScheduled)),
+							"Status": Equal(k8sv1.ConditionFalse),
+							"Reason": Equal(k8sv1.PodReasonUnschedulable),
+						})))
+				}).Return(vmi, nil)
+
+				controller.Execute()
+
+				testutils.ExpectEvent(recorder, FailedLocalVolumesReason)
+				Expect(kubeClient.Actions()).To(BeEmpty())
+			})
+
+			It("should report the node the volumes are local to", func() {
+				vmi := NewPendingVirtualMachine("testvmi")
+				addLocalVolume(vmi, "disk1", "node01")
+				pod := NewPodForVirtualMachine(vmi, k8sv1.PodRunning)
+
+				Expect(controller.updateVolumeStatus(vmi, pod)).To(Succeed())
+				Expect(vmi.Status.VolumeStatus).To(HaveLen(1))
+				Expect(vmi.Status.VolumeStatus[0].PersistentVolumeClaimInfo.LocalToNode).To(Equal("node01"))
+			})
+		})
+
 		table.DescribeTable("should delete the corresponding Pods on VirtualMachineInstance deletion with vmi", func(phase v1.VirtualMachineInstancePhase) {
 			vmi := NewPendingVirtualMachine("testvmi")
 
@@ -927,12 +1126,10 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 			Expect(controller.Queue.Len()).To(Equal(0))
 			Expect(mockQueue.GetRateLimitedEnqueueCount()).To(Equal(1))
 
-			// make sure that during next iteration we do not add the same condition again
-			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(vmi *v1.VirtualMachineInstance) {
-				expectConditions(vmi)
-			}).Return(vmi, nil)
-
+			// make sure that during next iteration we do not add the same condition again,
+			// the conditions are already up to date and the vmi is not updated at all
 			controller.Execute()
+			testutils.ExpectEvent(recorder, FailedPvcNotFoundReason)
 			Expect(controller.Queue.Len()).To(Equal(0))
 			Expect(mockQueue.GetRateLimitedEnqueueCount()).To(Equal(2))
 		})
@@ -1321,6 +1518,43 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 
 			controller.Execute()
 		})
+		It("should report the resource overhead of the pod", func() {
+			vmi := NewPendingVirtualMachine("testvmi")
+			setReadyCondition(vmi, k8sv1.ConditionFalse, v1.GuestNotRunningReason)
+			vmi.Status.Phase = v1.Scheduling
+			pod := NewPodForVirtualMachine(vmi, k8sv1.PodRunning)
+			pod.Annotations[v1.ResourceOverheadAnnotation] = `{"memory":"200Mi","cpu":"1"}`
+
+			addVirtualMachine(vmi)
+			podFeeder.Add(pod)
+
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				overhead := arg.(*v1.VirtualMachineInstance).Status.ResourceOverhead
+				Expect(overhead).ToNot(BeNil())
+				Expect(overhead.Memory.String()).To(Equal("200Mi"))
+				Expect(overhead.CPU.String()).To(Equal("1"))
+			}).Return(vmi, nil)
+
+			controller.Execute()
+		})
+		It("should report the cpu allocation ratio of the pod", func() {
+			vmi := NewPendingVirtualMachine("testvmi")
+			setReadyCondition(vmi, k8sv1.ConditionFalse, v1.GuestNotRunningReason)
+			vmi.Status.Phase = v1.Scheduling
+			pod := NewPodForVirtualMachine(vmi, k8sv1.PodRunning)
+			pod.Annotations[v1.CPUAllocationRatioAnnotation] = "4"
+
+			addVirtualMachine(vmi)
+			podFeeder.Add(pod)
+
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				cpuAllocationRatio := arg.(*v1.VirtualMachineInstance).Status.CPUAllocationRatio
+				Expect(cpuAllocationRatio).ToNot(BeNil())
+				Expect(*cpuAllocationRatio).To(Equal(4))
+			}).Return(vmi, nil)
+
+			controller.Execute()
+		})
 		It("should update the virtual machine to scheduled if pod is ready, triggered by pod change", func() {
 			vmi := NewPendingVirtualMachine("testvmi")
 			setReadyCondition(vmi, k8sv1.ConditionFalse, v1.GuestNotRunningReason)
@@ -1522,18 +1756,53 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 			controller.Execute()
 		})
 
+		It("should reset the migration backoff if requested and VMI is in running state", func() {
+			vmi := NewPendingVirtualMachine("testvmi")
+			setReadyCondition(vmi, k8sv1.ConditionTrue, "")
+			vmi.Status.Phase = v1.Running
+			vmi.Annotations[v1.ResetMigrationBackoffAnnotation] = ""
+			vmi.Status.MigrationBackoff = &v1.VirtualMachineInstanceMigrationBackoff{
+				FailureCount:           3,
+				LastFailedMigrationUID: "testmigration",
+			}
+			pod := NewPodForVirtualMachine(vmi, k8sv1.PodRunning)
+			pod.Status.Conditions = []k8sv1.PodCondition{{Type: k8sv1.PodReady, Status: k8sv1.ConditionTrue}}
+
+			addVirtualMachine(vmi)
+			addActivePods(vmi, pod.UID, "")
+			podFeeder.Add(pod)
+
+			vmiInterface.EXPECT().Patch(vmi.Name, types.JSONPatchType, gomock.Any()).DoAndReturn(func(_ string, _ interface{}, patchBytes []byte) (*v1.VirtualMachineInstance, error) {
+				patch, err := jsonpatch.DecodePatch(patchBytes)
+				Expect(err).ToNot(HaveOccurred())
+				vmiBytes, err := json.Marshal(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				vmiBytes, err = patch.Apply(vmiBytes)
+				Expect(err).ToNot(HaveOccurred())
+				patchedVMI := &v1.VirtualMachineInstance{}
+				err = json.Unmarshal(vmiBytes, patchedVMI)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(patchedVMI.Status.MigrationBackoff).To(BeNil())
+				Expect(patchedVMI.Annotations).ToNot(HaveKey(v1.ResetMigrationBackoffAnnotation))
+				return patchedVMI, nil
+			})
+
+			controller.Execute()
+		})
+
 		It("should add a ready condition if it is present on the pod and the VMI is in running state", func() {
 			vmi := NewPendingVirtualMachine("testvmi")
 			vmi.Status.Conditions = nil
 			vmi.Status.Phase = v1.Running
 			pod := NewPodForVirtualMachine(vmi, k8sv1.PodRunning)
-			pod.Status.Conditions = []k8sv1.PodCondition{{Type: k8sv1.PodReady, Status: k8sv1.ConditionTrue}}
+			transitionTime := metav1.NewTime(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+			pod.Status.Conditions = []k8sv1.PodCondition{{Type: k8sv1.PodReady, Status: k8sv1.ConditionTrue, LastTransitionTime: transitionTime}}
 
 			addVirtualMachine(vmi)
 			addActivePods(vmi, pod.UID, "")
 			podFeeder.Add(pod)
 
-			patch := `[{ "op": "test", "path": "/status/conditions", "value": null }, { "op": "replace", "path": "/status/conditions", "value": [{"type":"Ready","status":"True","lastProbeTime":null,"lastTransitionTime":null}] }]`
+			patch := `[{ "op": "test", "path": "/status/conditions", "value": null }, { "op": "replace", "path": "/status/conditions", "value": [{"type":"Ready","status":"True","lastProbeTime":null,"lastTransitionTime":"2021-01-01T00:00:00Z"}] }]`
 			vmiInterface.EXPECT().Patch(vmi.Name, types.JSONPatchType, []byte(patch)).Return(vmi, nil)
 
 			controller.Execute()
@@ -1571,6 +1840,77 @@ var _ = Describe("VirtualMachineInstance watcher", func() {
 			controller.Execute()
 		})
 
+		table.DescribeTable("should satisfy the not draining readiness gate of the pod", func(condition *k8sv1.PodCondition, migrationState *v1.VirtualMachineInstanceMigrationState) {
+			vmi := NewPendingVirtualMachine("testvmi")
+			setReadyCondition(vmi, k8sv1.ConditionTrue, "")
+			vmi.Status.Phase = v1.Running
+			vmi.Status.MigrationState = migrationState
+			pod := NewPodForVirtualMachine(vmi, k8sv1.PodRunning)
+			pod.Spec.ReadinessGates = []k8sv1.PodReadinessGate{{ConditionType: v1.VirtualMachineInstanceNotDraining}}
+			pod.Status.Conditions = []k8sv1.PodCondition{{Type: k8sv1.PodReady, Status: k8sv1.ConditionTrue}}
+			if condition != nil {
+				pod.Status.Conditions = append(pod.Status.Conditions, *condition)
+			}
+
+			addVirtualMachine(vmi)
+			addActivePods(vmi, pod.UID, "")
+			podFeeder.Add(pod)
+
+			kubeClient.Fake.PrependReactor("patch", "pods", func(action testing.Action) (handled bool, obj k8sruntime.Object, err error) {
+				patch, ok := action.(testing.PatchAction)
+				Expect(ok).To(BeTrue())
+				Expect(patch.GetName()).To(Equal(pod.Name))
+				Expect(patch.GetSubresource()).To(Equal("status"))
+				Expect(string(patch.GetPatch())).To(And(
+					ContainSubstring(`"type":"%s"`, v1.VirtualMachineInstanceNotDraining),
+					ContainSubstring(`"status":"True"`),
+				))
+				return true, nil, nil
+			})
+
+			controller.Execute()
+		},
+			table.Entry("if the condition is not set", nil, nil),
+			table.Entry("if the pod is still drained after the migration failed",
+				&k8sv1.PodCondition{Type: v1.VirtualMachineInstanceNotDraining, Status: k8sv1.ConditionFalse, Reason: v1.MigrationDrainingReason},
+				&v1.VirtualMachineInstanceMigrationState{MigrationUID: "testmigration", Failed: true},
+			),
+			table.Entry("if the pod is still drained after the migration ended",
+				&k8sv1.PodCondition{Type: v1.VirtualMachineInstanceNotDraining, Status: k8sv1.ConditionFalse, Reason: v1.MigrationDrainingReason, LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Minute))},
+				&v1.VirtualMachineInstanceMigrationState{MigrationUID: "testmigration", Failed: true, EndTimestamp: now()},
+			),
+		)
+
+		table.DescribeTable("should not satisfy the not draining readiness gate of the pod", func(migrationState *v1.VirtualMachineInstanceMigrationState) {
+			vmi := NewPendingVirtualMachine("testvmi")
+			setReadyCondition(vmi, k8sv1.ConditionTrue, "")
+			vmi.Status.Phase = v1.Running
+			vmi.Status.MigrationState = migrationState
+			pod := NewPodForVirtualMachine(vmi, k8sv1.PodRunning)
+			pod.Spec.ReadinessGates = []k8sv1.PodReadinessGate{{ConditionType: v1.VirtualMachineInstanceNotDraining}}
+			pod.Status.Conditions = []k8sv1.PodCondition{
+				{Type: k8sv1.PodReady, Status: k8sv1.ConditionTrue},
+				{Type: v1.VirtualMachineInstanceNotDraining, Status: k8sv1.ConditionFalse, Reason: v1.MigrationDrainingReason, LastTransitionTime: metav1.Now()},
+			}
+
+			addVirtualMachine(vmi)
+			addActivePods(vmi, pod.UID, "")
+			podFeeder.Add(pod)
+
+			kubeClient.Fake.PrependReactor("patch", "pods", func(action testing.Action) (handled bool, obj k8sruntime.Object, err error) {
+				Fail("the drained pod should not be patched")
+				return true, nil, nil
+			})
+
+			controller.Execute()
+		},
+			table.Entry("while it is drained for a migration", &v1.VirtualMachineInstanceMigrationState{MigrationUID: "testmigration"}),
+			table.Entry("while it is drained before the hand off of a migration", nil),
+			table.Entry("while it is drained before the hand off of another migration",
+				&v1.VirtualMachineInstanceMigrationState{MigrationUID: "testmigration", Completed: true, EndTimestamp: &metav1.Time{Time: time.Now().Add(-time.Minute)}},
+			),
+		)
+
 		It("should add active pods to status if VMI is in running state", func() {
 			vmi := NewPendingVirtualMachine("testvmi")
 			setReadyCondition(vmi, k8sv1.ConditionFalse, v1.PodConditionMissingReason)
@@ -2575,12 +2915,21 @@ func NewPendingVirtualMachine(name string) *v1.VirtualMachineInstance {
 	return vmi
 }
 
+// readyConditionMessages are the messages the controller sets along with the reasons of the Ready condition
+var readyConditionMessages = map[string]string{
+	v1.PodNotExistsReason:        "virt-launcher pod has not yet been scheduled",
+	v1.PodTerminatingReason:      "virt-launcher pod is terminating",
+	v1.GuestNotRunningReason:     "Guest VM is not reported as running",
+	v1.PodConditionMissingReason: "virt-launcher pod is missing the Ready condition",
+}
+
 func setReadyCondition(vmi *v1.VirtualMachineInstance, status k8sv1.ConditionStatus, reason string) {
 	kvcontroller.NewVirtualMachineInstanceConditionManager().RemoveCondition(vmi, v1.VirtualMachineInstanceReady)
 	vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{
-		Type:   v1.VirtualMachineInstanceReady,
-		Status: status,
-		Reason: reason,
+		Type:    v1.VirtualMachineInstanceReady,
+		Status:  status,
+		Reason:  reason,
+		Message: readyConditionMessages[reason],
 	})
 }
 func NewPodForVirtualMachine(vmi *v1.VirtualMachineInstance, phase k8sv1.PodPhase) *k8sv1.Pod {
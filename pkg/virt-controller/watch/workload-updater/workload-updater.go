@@ -23,6 +23,7 @@ import (
 
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 
+	"kubevirt.io/kubevirt/pkg/util/maintenance"
 	migrationutils "kubevirt.io/kubevirt/pkg/util/migrations"
 
 	virtv1 "kubevirt.io/client-go/api/v1"
@@ -66,17 +67,18 @@ func init() {
 }
 
 type WorkloadUpdateController struct {
-	clientset             kubecli.KubevirtClient
-	queue                 workqueue.RateLimitingInterface
-	vmiInformer           cache.SharedIndexInformer
-	podInformer           cache.SharedIndexInformer
-	migrationInformer     cache.SharedIndexInformer
-	recorder              record.EventRecorder
-	migrationExpectations *controller.UIDTrackingControllerExpectations
-	kubeVirtInformer      cache.SharedIndexInformer
-	clusterConfig         *virtconfig.ClusterConfig
-	statusUpdater         *status.KVStatusUpdater
-	launcherImage         string
+	clientset                 kubecli.KubevirtClient
+	queue                     workqueue.RateLimitingInterface
+	vmiInformer               cache.SharedIndexInformer
+	podInformer               cache.SharedIndexInformer
+	migrationInformer         cache.SharedIndexInformer
+	recorder                  record.EventRecorder
+	migrationExpectations     *controller.UIDTrackingControllerExpectations
+	kubeVirtInformer          cache.SharedIndexInformer
+	maintenanceWindowInformer cache.SharedIndexInformer
+	clusterConfig             *virtconfig.ClusterConfig
+	statusUpdater             *status.KVStatusUpdater
+	launcherImage             string
 
 	lastDeletionBatch time.Time
 }
@@ -87,6 +89,8 @@ type updateData struct {
 	evictOutdatedVMIs      []*virtv1.VirtualMachineInstance
 
 	numActiveMigrations int
+	numBackoffVMIs      int
+	numWaitingVMIs      int
 }
 
 func NewWorkloadUpdateController(
@@ -95,6 +99,7 @@ func NewWorkloadUpdateController(
 	podInformer cache.SharedIndexInformer,
 	migrationInformer cache.SharedIndexInformer,
 	kubeVirtInformer cache.SharedIndexInformer,
+	maintenanceWindowInformer cache.SharedIndexInformer,
 	recorder record.EventRecorder,
 	clientset kubecli.KubevirtClient,
 	clusterConfig *virtconfig.ClusterConfig,
@@ -106,17 +111,18 @@ func NewWorkloadUpdateController(
 	)
 
 	c := &WorkloadUpdateController{
-		queue:                 workqueue.NewNamedRateLimitingQueue(rl, "virt-controller-workload-update"),
-		vmiInformer:           vmiInformer,
-		podInformer:           podInformer,
-		migrationInformer:     migrationInformer,
-		kubeVirtInformer:      kubeVirtInformer,
-		recorder:              recorder,
-		clientset:             clientset,
-		statusUpdater:         status.NewKubeVirtStatusUpdater(clientset),
-		launcherImage:         launcherImage,
-		migrationExpectations: controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectations()),
-		clusterConfig:         clusterConfig,
+		queue:                     workqueue.NewNamedRateLimitingQueue(rl, "virt-controller-workload-update"),
+		vmiInformer:               vmiInformer,
+		podInformer:               podInformer,
+		migrationInformer:         migrationInformer,
+		kubeVirtInformer:          kubeVirtInformer,
+		maintenanceWindowInformer: maintenanceWindowInformer,
+		recorder:                  recorder,
+		clientset:                 clientset,
+		statusUpdater:             status.NewKubeVirtStatusUpdater(clientset),
+		launcherImage:             launcherImage,
+		migrationExpectations:     controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectations()),
+		clusterConfig:             clusterConfig,
 	}
 
 	c.kubeVirtInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -131,6 +137,12 @@ func NewWorkloadUpdateController(
 		UpdateFunc: c.updateMigration,
 	})
 
+	c.maintenanceWindowInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.addMaintenanceWindow,
+		DeleteFunc: c.deleteMaintenanceWindow,
+		UpdateFunc: c.updateMaintenanceWindow,
+	})
+
 	return c
 }
 
@@ -188,6 +200,27 @@ func (c *WorkloadUpdateController) updateMigration(_, _ interface{}) {
 	c.queue.AddAfter(key, defaultThrottleIntervalSeconds)
 }
 
+func (c *WorkloadUpdateController) addMaintenanceWindow(_ interface{}) {
+	c.enqueueKubeVirtKey()
+}
+
+func (c *WorkloadUpdateController) deleteMaintenanceWindow(_ interface{}) {
+	c.enqueueKubeVirtKey()
+}
+
+func (c *WorkloadUpdateController) updateMaintenanceWindow(_, _ interface{}) {
+	c.enqueueKubeVirtKey()
+}
+
+func (c *WorkloadUpdateController) enqueueKubeVirtKey() {
+	key, err := c.getKubeVirtKey()
+	if key == "" || err != nil {
+		return
+	}
+
+	c.queue.AddAfter(key, defaultThrottleIntervalSeconds)
+}
+
 func (c *WorkloadUpdateController) addKubeVirt(obj interface{}) {
 	c.enqueueKubeVirt(obj)
 }
@@ -225,7 +258,7 @@ func (c *WorkloadUpdateController) Run(stopCh <-chan struct{}) {
 	threadiness := 1
 
 	// Wait for cache sync before we start the controller
-	cache.WaitForCacheSync(stopCh, c.migrationInformer.HasSynced, c.vmiInformer.HasSynced, c.podInformer.HasSynced, c.kubeVirtInformer.HasSynced)
+	cache.WaitForCacheSync(stopCh, c.migrationInformer.HasSynced, c.vmiInformer.HasSynced, c.podInformer.HasSynced, c.kubeVirtInformer.HasSynced, c.maintenanceWindowInformer.HasSynced)
 
 	// Start the actual work
 	for i := 0; i < threadiness; i++ {
@@ -302,6 +335,9 @@ func (c *WorkloadUpdateController) getUpdateData(kv *virtv1.KubeVirt) *updateDat
 
 	data.numActiveMigrations = len(migrations)
 
+	windows := maintenance.ListWindows(c.maintenanceWindowInformer)
+	now := time.Now()
+
 	objs := c.vmiInformer.GetStore().List()
 	for _, obj := range objs {
 		vmi := obj.(*virtv1.VirtualMachineInstance)
@@ -322,6 +358,14 @@ func (c *WorkloadUpdateController) getUpdateData(kv *virtv1.KubeVirt) *updateDat
 			continue
 		} else if exists := lookup[vmi.Namespace+"/"+vmi.Name]; exists {
 			continue
+		} else if migrationutils.MigrationBackoffRemaining(vmi) > 0 {
+			// migrations of this VMI failed repeatedly, try again once the backoff expired
+			data.numBackoffVMIs++
+			continue
+		} else if allowed, _ := maintenance.Allowed(windows, vmi, virtv1.MaintenanceActionWorkloadUpdate, now); !allowed {
+			// the VMI may only be updated while one of its maintenance windows is open
+			data.numWaitingVMIs++
+			continue
 		}
 
 		if automatedMigrationAllowed && vmi.IsMigratable() {
@@ -409,7 +453,7 @@ func (c *WorkloadUpdateController) sync(kv *virtv1.KubeVirt) error {
 	// Rather than enqueing based on VMI activity, we keep periodically poping the loop
 	// until all VMIs are updated. Watching all VMI activity is chatty for this controller
 	// when we don't need to be that efficent in how quickly the updates are being processed.
-	if len(data.evictOutdatedVMIs) != 0 || len(data.migratableOutdatedVMIs) != 0 {
+	if len(data.evictOutdatedVMIs) != 0 || len(data.migratableOutdatedVMIs) != 0 || data.numBackoffVMIs != 0 || data.numWaitingVMIs != 0 {
 		c.queue.AddAfter(key, periodicReEnqueueIntervalSeconds)
 	}
 
@@ -44,6 +44,7 @@ var _ = Describe("Workload Updater", func() {
 	var migrationSource *framework.FakeControllerSource
 	var kubeVirtSource *framework.FakeControllerSource
 	var kubeVirtInformer cache.SharedIndexInformer
+	var maintenanceWindowInformer cache.SharedIndexInformer
 	var recorder *record.FakeRecorder
 	var mockQueue *testutils.MockWorkQueue
 	var kubeClient *fake.Clientset
@@ -58,11 +59,13 @@ var _ = Describe("Workload Updater", func() {
 		go podInformer.Run(stop)
 		go migrationInformer.Run(stop)
 		go kubeVirtInformer.Run(stop)
+		go maintenanceWindowInformer.Run(stop)
 
 		Expect(cache.WaitForCacheSync(stop,
 			vmiInformer.HasSynced,
 			migrationInformer.HasSynced,
 			kubeVirtInformer.HasSynced,
+			maintenanceWindowInformer.HasSynced,
 		)).To(BeTrue())
 	}
 
@@ -109,8 +112,9 @@ var _ = Describe("Workload Updater", func() {
 
 		kubeVirtInformer, _ = testutils.NewFakeInformerFor(&v1.KubeVirt{})
 		kubeVirtInformer, kubeVirtSource = testutils.NewFakeInformerFor(&v1.KubeVirt{})
+		maintenanceWindowInformer, _ = testutils.NewFakeInformerFor(&v1.MaintenanceWindow{})
 
-		controller = NewWorkloadUpdateController(expectedImage, vmiInformer, podInformer, migrationInformer, kubeVirtInformer, recorder, virtClient, config)
+		controller = NewWorkloadUpdateController(expectedImage, vmiInformer, podInformer, migrationInformer, kubeVirtInformer, maintenanceWindowInformer, recorder, virtClient, config)
 		mockQueue = testutils.NewMockWorkQueue(controller.queue)
 		controller.queue = mockQueue
 		migrationFeeder = testutils.NewMigrationFeeder(mockQueue, migrationSource)
@@ -362,6 +366,49 @@ var _ = Describe("Workload Updater", func() {
 			Expect(recorder.Events).To(BeEmpty())
 		})
 
+		It("should not migrate VMIs while their migrations are held back", func() {
+			kv := newKubeVirt(1)
+			kv.Spec.WorkloadUpdateStrategy.WorkloadUpdateMethods = []v1.WorkloadUpdateMethod{v1.WorkloadUpdateMethodLiveMigrate}
+			addKubeVirt(kv)
+
+			vmi := newVirtualMachine("testvm", true, "madeup", vmiSource, podSource)
+			nextAttempt := metav1.NewTime(time.Now().Add(time.Minute))
+			vmi.Status.MigrationBackoff = &v1.VirtualMachineInstanceMigrationBackoff{
+				FailureCount:         1,
+				NextAttemptTimestamp: &nextAttempt,
+			}
+			vmiSource.Modify(vmi)
+
+			// wait for informer to catch up since we aren't watching
+			// for vmis directly
+			time.Sleep(1 * time.Second)
+
+			controller.Execute()
+			Expect(recorder.Events).To(BeEmpty())
+		})
+
+		It("should not update VMIs outside of their maintenance window", func() {
+			Expect(maintenanceWindowInformer.GetStore().Add(&v1.MaintenanceWindow{
+				ObjectMeta: metav1.ObjectMeta{Name: "window"},
+				Spec: v1.MaintenanceWindowSpec{
+					Actions: []v1.MaintenanceAction{v1.MaintenanceActionWorkloadUpdate},
+					Periods: []v1.MaintenancePeriod{{
+						Start:    time.Now().UTC().Add(2 * time.Hour).Format("15:04"),
+						Duration: metav1.Duration{Duration: time.Hour},
+					}},
+				},
+			})).To(Succeed())
+
+			newVirtualMachine("testvm", true, "madeup", vmiSource, podSource)
+			time.Sleep(1 * time.Second)
+			kv := newKubeVirt(1)
+			kv.Spec.WorkloadUpdateStrategy.WorkloadUpdateMethods = []v1.WorkloadUpdateMethod{v1.WorkloadUpdateMethodLiveMigrate, v1.WorkloadUpdateMethodEvict}
+			addKubeVirt(kv)
+
+			controller.Execute()
+			Expect(recorder.Events).To(BeEmpty())
+		})
+
 		It("should respect custom batch deletion count", func() {
 			batchDeletions := 30
 			reasons := []string{}
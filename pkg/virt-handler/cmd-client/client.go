@@ -26,6 +26,7 @@ package cmdclient
 */
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -56,11 +57,18 @@ import (
 )
 
 var (
-	// add older version when supported
+	// keep at least the previous version in order to manage VMIs started by an older virt-launcher
 	// don't use the variable in pkg/handler-launcher-com/cmd/v1/version.go in order to detect version mismatches early
-	supportedCmdVersions = []uint32{1}
+	supportedCmdVersions = []uint32{6, 5, 4, 3, 2, 1}
 	legacyBaseDir        = "/var/run/kubevirt"
 	podsBaseDir          = "/pods"
+
+	// ErrCmdNotSupported is returned for commands the virt-launcher doesn't implement yet
+	ErrCmdNotSupported = errors.New("command not supported by virt-launcher")
+
+	// ErrMemoryUploadCredentialsRequired is returned when a memory upload has to be started
+	// but no object storage credentials were passed
+	ErrMemoryUploadCredentialsRequired = errors.New("object storage credentials are required to start the memory upload")
 )
 
 const StandardLauncherSocketFileName = "launcher-sock"
@@ -74,6 +82,13 @@ type MigrationOptions struct {
 	UnsafeMigration         bool
 	AllowAutoConverge       bool
 	AllowPostCopy           bool
+	AbortOnProjectedTimeout bool
+}
+
+// ObjectStorageCredentials sign the requests of memory uploads to object storage
+type ObjectStorageCredentials struct {
+	AccessKeyID     string `json:"accessKeyId"`
+	SecretAccessKey string `json:"secretAccessKey"`
 }
 
 type LauncherClient interface {
@@ -82,6 +97,9 @@ type LauncherClient interface {
 	UnpauseVirtualMachine(vmi *v1.VirtualMachineInstance) error
 	FreezeVirtualMachine(vmi *v1.VirtualMachineInstance) error
 	UnfreezeVirtualMachine(vmi *v1.VirtualMachineInstance) error
+	SnapshotVirtualMachineMemory(vmi *v1.VirtualMachineInstance) error
+	UploadVirtualMachineMemory(vmi *v1.VirtualMachineInstance, options *v1.MemorySnapshotOptions, credentials *ObjectStorageCredentials) (*v1.MemorySnapshotUploadStatus, error)
+	SetInterfaceLinkState(vmi *v1.VirtualMachineInstance, options *v1.SetLinkStateOptions) error
 	SyncMigrationTarget(vmi *v1.VirtualMachineInstance) error
 	SignalTargetPodCleanup(vmi *v1.VirtualMachineInstance) error
 	ShutdownVirtualMachine(vmi *v1.VirtualMachineInstance) error
@@ -98,12 +116,15 @@ type LauncherClient interface {
 	Exec(string, string, []string, int32) (int, string, error)
 	Ping() error
 	GuestPing(string, int32) error
+	Screenshot(vmi *v1.VirtualMachineInstance) ([]byte, error)
 	Close()
 }
 
 type VirtLauncherClient struct {
 	v1client cmdv1.CmdClient
 	conn     *grpc.ClientConn
+	// version is the cmd version negotiated with the virt-launcher
+	version uint32
 }
 
 const (
@@ -318,19 +339,31 @@ func NewClientWithInfoClient(infoClient info.CmdInfoClient, conn *grpc.ClientCon
 
 	// create cmd client
 	switch version {
-	case 1:
+	case 1, 2, 3, 4, 5, 6:
+		if version < cmdv1.CmdVersion {
+			log.Log.V(3).Infof("virt-launcher supports cmd version %d, commands of newer versions are unavailable until the VMI is restarted or migrated", version)
+		}
 		client := cmdv1.NewCmdClient(conn)
-		return newV1Client(client, conn), nil
+		return newV1Client(client, conn, version), nil
 	default:
 		return nil, fmt.Errorf("cmd client version %v not implemented yet", version)
 	}
 }
 
-func newV1Client(client cmdv1.CmdClient, conn *grpc.ClientConn) LauncherClient {
+func newV1Client(client cmdv1.CmdClient, conn *grpc.ClientConn, version uint32) LauncherClient {
 	return &VirtLauncherClient{
 		v1client: client,
 		conn:     conn,
+		version:  version,
+	}
+}
+
+// requireVersion fails commands which were introduced after the negotiated cmd version
+func (c *VirtLauncherClient) requireVersion(cmdName string, version uint32) error {
+	if c.version < version {
+		return fmt.Errorf("%w: %s requires cmd version %d, virt-launcher supports %d", ErrCmdNotSupported, cmdName, version, c.version)
 	}
+	return nil
 }
 
 func (c *VirtLauncherClient) Close() {
@@ -364,6 +397,8 @@ func (c *VirtLauncherClient) genericSendVMICmd(cmdName string,
 func handleError(err error, cmdName string, response *cmdv1.Response) error {
 	if IsDisconnected(err) {
 		return err
+	} else if status.Code(err) == codes.Unimplemented {
+		return fmt.Errorf("%w: %s", ErrCmdNotSupported, cmdName)
 	} else if err != nil {
 		msg := fmt.Sprintf("unknown error encountered sending command %s: %s", cmdName, err.Error())
 		return fmt.Errorf(msg)
@@ -373,6 +408,11 @@ func handleError(err error, cmdName string, response *cmdv1.Response) error {
 	return nil
 }
 
+// IsCmdNotSupported returns true if the virt-launcher is too old to handle a command
+func IsCmdNotSupported(err error) bool {
+	return errors.Is(err, ErrCmdNotSupported)
+}
+
 func IsDisconnected(err error) bool {
 	if err == nil {
 		return false
@@ -426,6 +466,99 @@ func (c *VirtLauncherClient) UnfreezeVirtualMachine(vmi *v1.VirtualMachineInstan
 	return c.genericSendVMICmd("Unfreeze", c.v1client.UnfreezeVirtualMachine, vmi, &cmdv1.VirtualMachineOptions{})
 }
 
+func (c *VirtLauncherClient) SnapshotVirtualMachineMemory(vmi *v1.VirtualMachineInstance) error {
+	if err := c.requireVersion("SnapshotMemory", cmdv1.MemorySnapshotCmdVersion); err != nil {
+		return err
+	}
+	return c.genericSendVMICmd("SnapshotMemory", c.v1client.SnapshotVirtualMachineMemory, vmi, &cmdv1.VirtualMachineOptions{})
+}
+
+// UploadVirtualMachineMemory starts the upload of the guest memory to object storage, or to the
+// memory dump volume if the options contain no object storage, or reports the status of the upload
+// if it was already started with the same options. The credentials are only needed to start an
+// upload to object storage, ErrMemoryUploadCredentialsRequired is returned if they are nil and the
+// upload has not been started yet.
+func (c *VirtLauncherClient) UploadVirtualMachineMemory(vmi *v1.VirtualMachineInstance, options *v1.MemorySnapshotOptions, credentials *ObjectStorageCredentials) (*v1.MemorySnapshotUploadStatus, error) {
+	version := uint32(cmdv1.MemoryUploadCmdVersion)
+	if options.ObjectStorage == nil {
+		version = cmdv1.MemorySaveCmdVersion
+	}
+	if err := c.requireVersion("UploadMemory", version); err != nil {
+		return nil, err
+	}
+
+	vmiJson, err := json.Marshal(vmi)
+	if err != nil {
+		return nil, err
+	}
+	optionsJson, err := json.Marshal(options)
+	if err != nil {
+		return nil, err
+	}
+	request := &cmdv1.MemoryUploadRequest{
+		Vmi: &cmdv1.VMI{
+			VmiJson: vmiJson,
+		},
+		Options: optionsJson,
+	}
+	if credentials != nil {
+		request.Credentials, err = json.Marshal(credentials)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), longTimeout)
+	defer cancel()
+
+	uploadResponse, err := c.v1client.UploadVirtualMachineMemory(ctx, request)
+	var response *cmdv1.Response
+	if uploadResponse != nil {
+		response = uploadResponse.Response
+	}
+
+	if err = handleError(err, "UploadMemory", response); err != nil {
+		return nil, err
+	}
+	if uploadResponse.CredentialsRequired {
+		return nil, ErrMemoryUploadCredentialsRequired
+	}
+
+	status := &v1.MemorySnapshotUploadStatus{}
+	if err := json.Unmarshal([]byte(uploadResponse.Status), status); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the memory upload status: %v", err)
+	}
+	return status, nil
+}
+
+// SetInterfaceLinkState sets the link state the guest sees on an interface
+func (c *VirtLauncherClient) SetInterfaceLinkState(vmi *v1.VirtualMachineInstance, options *v1.SetLinkStateOptions) error {
+	if err := c.requireVersion("SetLinkState", cmdv1.LinkStateCmdVersion); err != nil {
+		return err
+	}
+
+	vmiJson, err := json.Marshal(vmi)
+	if err != nil {
+		return err
+	}
+	optionsJson, err := json.Marshal(options)
+	if err != nil {
+		return err
+	}
+	request := &cmdv1.LinkStateRequest{
+		Vmi: &cmdv1.VMI{
+			VmiJson: vmiJson,
+		},
+		Options: optionsJson,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+	defer cancel()
+
+	response, err := c.v1client.SetVirtualMachineInterfaceLinkState(ctx, request)
+	return handleError(err, "SetLinkState", response)
+}
+
 func (c *VirtLauncherClient) ShutdownVirtualMachine(vmi *v1.VirtualMachineInstance) error {
 	return c.genericSendVMICmd("Shutdown", c.v1client.ShutdownVirtualMachine, vmi, &cmdv1.VirtualMachineOptions{})
 }
@@ -686,3 +819,34 @@ func (c *VirtLauncherClient) GuestPing(domainName string, timeoutSeconds int32)
 	_, err := c.v1client.GuestPing(ctx, request)
 	return err
 }
+
+// Screenshot returns a PNG of the current framebuffer of the guest
+func (c *VirtLauncherClient) Screenshot(vmi *v1.VirtualMachineInstance) ([]byte, error) {
+	if err := c.requireVersion("Screenshot", cmdv1.ScreenshotCmdVersion); err != nil {
+		return nil, err
+	}
+
+	vmiJson, err := json.Marshal(vmi)
+	if err != nil {
+		return nil, err
+	}
+	request := &cmdv1.VMIRequest{
+		Vmi: &cmdv1.VMI{
+			VmiJson: vmiJson,
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortTimeout)
+	defer cancel()
+
+	screenshotResponse, err := c.v1client.Screenshot(ctx, request)
+	var response *cmdv1.Response
+	if screenshotResponse != nil {
+		response = screenshotResponse.Response
+	}
+
+	if err = handleError(err, "Screenshot", response); err != nil {
+		return nil, err
+	}
+	return screenshotResponse.Screenshot, nil
+}
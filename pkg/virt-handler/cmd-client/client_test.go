@@ -30,9 +30,12 @@ import (
 	. "github.com/onsi/gomega"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"k8s.io/apimachinery/pkg/types"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/info"
 	cmdv1 "kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/v1"
 )
 
@@ -164,7 +167,7 @@ var _ = Describe("Virt remote commands", func() {
 			BeforeEach(func() {
 				ctrl = gomock.NewController(GinkgoT())
 				mockCmdClient = cmdv1.NewMockCmdClient(ctrl)
-				client = newV1Client(mockCmdClient, nil)
+				client = newV1Client(mockCmdClient, nil, cmdv1.CmdVersion)
 			})
 			AfterEach(func() {
 				ctrl.Finish()
@@ -233,6 +236,126 @@ var _ = Describe("Virt remote commands", func() {
 				err := client.GuestPing(testDomainName, testTimeoutSeconds)
 				Expect(err).ToNot(HaveOccurred())
 			})
+			It("should return the screenshot", func() {
+				mockCmdClient.EXPECT().Screenshot(gomock.Any(), gomock.Any()).Return(&cmdv1.ScreenshotResponse{
+					Response:   &cmdv1.Response{Success: true},
+					Screenshot: []byte("png"),
+				}, nil)
+				screenshot, err := client.Screenshot(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(screenshot).To(Equal([]byte("png")))
+			})
+			It("should return screenshot failures of the server", func() {
+				mockCmdClient.EXPECT().Screenshot(gomock.Any(), gomock.Any()).Return(&cmdv1.ScreenshotResponse{
+					Response: &cmdv1.Response{Success: false, Message: "no graphics"},
+				}, nil)
+				_, err := client.Screenshot(vmi)
+				Expect(err).To(MatchError(ContainSubstring("no graphics")))
+			})
+			It("should return the status of the memory upload", func() {
+				mockCmdClient.EXPECT().UploadVirtualMachineMemory(gomock.Any(), gomock.Any()).Return(&cmdv1.MemoryUploadResponse{
+					Response: &cmdv1.Response{Success: true},
+					Status:   `{"phase":"Succeeded","checksum":"abc-1"}`,
+				}, nil)
+				status, err := client.UploadVirtualMachineMemory(vmi, &v1.MemorySnapshotOptions{}, nil)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(status.Phase).To(Equal(v1.MemorySnapshotUploadSucceeded))
+				Expect(status.Checksum).To(Equal("abc-1"))
+			})
+			It("should only send the object storage credentials when passed", func() {
+				mockCmdClient.EXPECT().UploadVirtualMachineMemory(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, request *cmdv1.MemoryUploadRequest) (*cmdv1.MemoryUploadResponse, error) {
+					Expect(request.Credentials).To(BeEmpty())
+					return &cmdv1.MemoryUploadResponse{
+						Response:            &cmdv1.Response{Success: true},
+						CredentialsRequired: true,
+					}, nil
+				})
+				_, err := client.UploadVirtualMachineMemory(vmi, &v1.MemorySnapshotOptions{}, nil)
+				Expect(err).To(MatchError(ErrMemoryUploadCredentialsRequired))
+
+				mockCmdClient.EXPECT().UploadVirtualMachineMemory(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, request *cmdv1.MemoryUploadRequest) (*cmdv1.MemoryUploadResponse, error) {
+					Expect(string(request.Credentials)).To(Equal(`{"accessKeyId":"access","secretAccessKey":"secret"}`))
+					return &cmdv1.MemoryUploadResponse{
+						Response: &cmdv1.Response{Success: true},
+						Status:   `{"phase":"InProgress"}`,
+					}, nil
+				})
+				status, err := client.UploadVirtualMachineMemory(vmi, &v1.MemorySnapshotOptions{}, &ObjectStorageCredentials{AccessKeyID: "access", SecretAccessKey: "secret"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(status.Phase).To(Equal(v1.MemorySnapshotUploadInProgress))
+			})
+			It("should send the link state options", func() {
+				mockCmdClient.EXPECT().SetVirtualMachineInterfaceLinkState(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, request *cmdv1.LinkStateRequest) (*cmdv1.Response, error) {
+					Expect(string(request.Options)).To(Equal(`{"interfaceName":"default","state":"down"}`))
+					return &cmdv1.Response{Success: true}, nil
+				})
+				err := client.SetInterfaceLinkState(vmi, &v1.SetLinkStateOptions{InterfaceName: "default", State: v1.InterfaceLinkStateDown})
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		Context("version negotiation", func() {
+			var (
+				ctrl           *gomock.Controller
+				mockInfoClient *info.MockCmdInfoClient
+			)
+
+			BeforeEach(func() {
+				ctrl = gomock.NewController(GinkgoT())
+				mockInfoClient = info.NewMockCmdInfoClient(ctrl)
+			})
+			AfterEach(func() {
+				ctrl.Finish()
+			})
+
+			It("should pick the highest version supported by both sides", func() {
+				mockInfoClient.EXPECT().Info(gomock.Any(), gomock.Any()).Return(&info.CmdInfoResponse{SupportedCmdVersions: []uint32{1, 2, 3, 4, 5, 6, 7}}, nil)
+
+				client, err := NewClientWithInfoClient(mockInfoClient, nil)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(client.(*VirtLauncherClient).version).To(Equal(uint32(6)))
+			})
+
+			It("should only save the memory without object storage with a virt-launcher supporting it", func() {
+				mockInfoClient.EXPECT().Info(gomock.Any(), gomock.Any()).Return(&info.CmdInfoResponse{SupportedCmdVersions: []uint32{1, 2, 3, 4, 5}}, nil)
+
+				client, err := NewClientWithInfoClient(mockInfoClient, nil)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = client.UploadVirtualMachineMemory(vmi, &v1.MemorySnapshotOptions{}, nil)
+				Expect(IsCmdNotSupported(err)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring("UploadMemory requires cmd version 6"))
+			})
+
+			It("should keep talking to a virt-launcher of the previous version", func() {
+				mockInfoClient.EXPECT().Info(gomock.Any(), gomock.Any()).Return(&info.CmdInfoResponse{SupportedCmdVersions: []uint32{1}}, nil)
+
+				client, err := NewClientWithInfoClient(mockInfoClient, nil)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(client.(*VirtLauncherClient).version).To(Equal(uint32(1)))
+
+				err = client.SnapshotVirtualMachineMemory(vmi)
+				Expect(IsCmdNotSupported(err)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring("SnapshotMemory requires cmd version 2"))
+
+				_, err = client.Screenshot(vmi)
+				Expect(IsCmdNotSupported(err)).To(BeTrue())
+
+				_, err = client.UploadVirtualMachineMemory(vmi, &v1.MemorySnapshotOptions{}, nil)
+				Expect(IsCmdNotSupported(err)).To(BeTrue())
+
+				err = client.SetInterfaceLinkState(vmi, &v1.SetLinkStateOptions{})
+				Expect(IsCmdNotSupported(err)).To(BeTrue())
+			})
+
+			It("should report commands the virt-launcher doesn't implement", func() {
+				mockCmdClient := cmdv1.NewMockCmdClient(ctrl)
+				mockCmdClient.EXPECT().PauseVirtualMachine(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.Unimplemented, "unknown method"))
+
+				err := newV1Client(mockCmdClient, nil, 1).PauseVirtualMachine(vmi)
+				Expect(IsCmdNotSupported(err)).To(BeTrue())
+				Expect(IsDisconnected(err)).To(BeFalse())
+			})
 		})
 	})
 })
@@ -83,6 +83,37 @@ func (_mr *_MockLauncherClientRecorder) UnfreezeVirtualMachine(arg0 interface{})
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "UnfreezeVirtualMachine", arg0)
 }
 
+func (_m *MockLauncherClient) SnapshotVirtualMachineMemory(vmi *v1.VirtualMachineInstance) error {
+	ret := _m.ctrl.Call(_m, "SnapshotVirtualMachineMemory", vmi)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockLauncherClientRecorder) SnapshotVirtualMachineMemory(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SnapshotVirtualMachineMemory", arg0)
+}
+
+func (_m *MockLauncherClient) UploadVirtualMachineMemory(vmi *v1.VirtualMachineInstance, options *v1.MemorySnapshotOptions, credentials *ObjectStorageCredentials) (*v1.MemorySnapshotUploadStatus, error) {
+	ret := _m.ctrl.Call(_m, "UploadVirtualMachineMemory", vmi, options, credentials)
+	ret0, _ := ret[0].(*v1.MemorySnapshotUploadStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockLauncherClientRecorder) UploadVirtualMachineMemory(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "UploadVirtualMachineMemory", arg0, arg1, arg2)
+}
+
+func (_m *MockLauncherClient) SetInterfaceLinkState(vmi *v1.VirtualMachineInstance, options *v1.SetLinkStateOptions) error {
+	ret := _m.ctrl.Call(_m, "SetInterfaceLinkState", vmi, options)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockLauncherClientRecorder) SetInterfaceLinkState(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SetInterfaceLinkState", arg0, arg1)
+}
+
 func (_m *MockLauncherClient) SyncMigrationTarget(vmi *v1.VirtualMachineInstance) error {
 	ret := _m.ctrl.Call(_m, "SyncMigrationTarget", vmi)
 	ret0, _ := ret[0].(error)
@@ -252,6 +283,17 @@ func (_mr *_MockLauncherClientRecorder) GuestPing(arg0, arg1 interface{}) *gomoc
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "GuestPing", arg0, arg1)
 }
 
+func (_m *MockLauncherClient) Screenshot(vmi *v1.VirtualMachineInstance) ([]byte, error) {
+	ret := _m.ctrl.Call(_m, "Screenshot", vmi)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockLauncherClientRecorder) Screenshot(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Screenshot", arg0)
+}
+
 func (_m *MockLauncherClient) Close() {
 	_m.ctrl.Call(_m, "Close")
 }
@@ -0,0 +1,295 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package device_manager
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	"kubevirt.io/client-go/log"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+	pluginapi "kubevirt.io/kubevirt/pkg/virt-handler/device-manager/deviceplugin/v1beta1"
+)
+
+const (
+	// BandwidthDeviceName is the device plugin which advertises the network bandwidth of the node,
+	// each device stands for virtconfig.BandwidthDeviceUnit bits per second
+	BandwidthDeviceName = "bandwidth"
+
+	bandwidthRefreshInterval = 30 * time.Second
+)
+
+// BandwidthDevicePlugin advertises the network bandwidth of the node which is configured in the
+// cluster network configuration as devices. Virt-launcher pods consume the devices according to
+// the bandwidth of the interfaces of their VMI, the scheduler doesn't place more VMIs on the node
+// than its bandwidth allows this way.
+type BandwidthDevicePlugin struct {
+	devs            []*pluginapi.Device
+	server          *grpc.Server
+	socketPath      string
+	stop            chan struct{}
+	done            chan struct{}
+	resourceName    string
+	refreshInterval time.Duration
+	host            string
+	clusterConfig   *virtconfig.ClusterConfig
+	initialized     bool
+	lock            *sync.Mutex
+}
+
+func NewBandwidthDevicePlugin(host string, clusterConfig *virtconfig.ClusterConfig) *BandwidthDevicePlugin {
+	return &BandwidthDevicePlugin{
+		devs:            []*pluginapi.Device{},
+		socketPath:      SocketPath(BandwidthDeviceName),
+		resourceName:    fmt.Sprintf("%s/%s", DeviceNamespace, BandwidthDeviceName),
+		refreshInterval: bandwidthRefreshInterval,
+		host:            host,
+		clusterConfig:   clusterConfig,
+		initialized:     false,
+		lock:            &sync.Mutex{},
+	}
+}
+
+func (dpi *BandwidthDevicePlugin) GetDevicePath() string {
+	return ""
+}
+
+func (dpi *BandwidthDevicePlugin) GetDeviceName() string {
+	return BandwidthDeviceName
+}
+
+// Start starts the device plugin
+func (dpi *BandwidthDevicePlugin) Start(stop chan struct{}) (err error) {
+	logger := log.DefaultLogger()
+	dpi.stop = stop
+	dpi.done = make(chan struct{})
+
+	err = dpi.cleanup()
+	if err != nil {
+		return err
+	}
+
+	sock, err := net.Listen("unix", dpi.socketPath)
+	if err != nil {
+		return fmt.Errorf("error creating GRPC server socket: %v", err)
+	}
+
+	dpi.server = grpc.NewServer([]grpc.ServerOption{}...)
+	defer dpi.Stop()
+
+	dpi.refreshDevices()
+	pluginapi.RegisterDevicePluginServer(dpi.server, dpi)
+	err = dpi.Register()
+	if err != nil {
+		return fmt.Errorf("error registering with device plugin manager: %v", err)
+	}
+
+	errChan := make(chan error, 2)
+
+	go func() {
+		errChan <- dpi.server.Serve(sock)
+	}()
+
+	err = waitForGrpcServer(dpi.socketPath, connectionTimeout)
+	if err != nil {
+		return fmt.Errorf("error starting the GRPC server: %v", err)
+	}
+
+	go func() {
+		errChan <- dpi.healthCheck()
+	}()
+
+	dpi.setInitialized(true)
+	logger.Infof("%s device plugin started", BandwidthDeviceName)
+	err = <-errChan
+
+	return err
+}
+
+// Stop stops the gRPC server
+func (dpi *BandwidthDevicePlugin) Stop() error {
+	defer func() {
+		if !IsChanClosed(dpi.done) {
+			close(dpi.done)
+		}
+	}()
+	dpi.server.Stop()
+	dpi.setInitialized(false)
+	return dpi.cleanup()
+}
+
+// Register registers the device plugin for the given resourceName with Kubelet.
+func (dpi *BandwidthDevicePlugin) Register() error {
+	conn, err := connect(pluginapi.KubeletSocket, connectionTimeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := pluginapi.NewRegistrationClient(conn)
+	reqt := &pluginapi.RegisterRequest{
+		Version:      pluginapi.Version,
+		Endpoint:     path.Base(dpi.socketPath),
+		ResourceName: dpi.resourceName,
+	}
+
+	_, err = client.Register(context.Background(), reqt)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (dpi *BandwidthDevicePlugin) ListAndWatch(_ *pluginapi.Empty, s pluginapi.DevicePlugin_ListAndWatchServer) error {
+	// FIXME: sending an empty list up front should not be needed. This is a workaround for:
+	// https://github.com/kubevirt/kubevirt/issues/1196
+	// This can safely be removed once supported upstream Kubernetes is 1.10.3 or higher.
+	emptyList := []*pluginapi.Device{}
+	s.Send(&pluginapi.ListAndWatchResponse{Devices: emptyList})
+
+	dpi.refreshDevices()
+	s.Send(&pluginapi.ListAndWatchResponse{Devices: dpi.listDevices()})
+
+	ticker := time.NewTicker(dpi.refreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if dpi.refreshDevices() {
+				s.Send(&pluginapi.ListAndWatchResponse{Devices: dpi.listDevices()})
+			}
+		case <-dpi.stop:
+			return nil
+		case <-dpi.done:
+			return nil
+		}
+	}
+}
+
+// Allocate doesn't pass anything to the containers, the devices only account for the bandwidth of the node
+func (dpi *BandwidthDevicePlugin) Allocate(_ context.Context, r *pluginapi.AllocateRequest) (*pluginapi.AllocateResponse, error) {
+	response := pluginapi.AllocateResponse{}
+	for range r.ContainerRequests {
+		response.ContainerResponses = append(response.ContainerResponses, &pluginapi.ContainerAllocateResponse{})
+	}
+	return &response, nil
+}
+
+func (dpi *BandwidthDevicePlugin) cleanup() error {
+	if err := os.Remove(dpi.socketPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (dpi *BandwidthDevicePlugin) GetDevicePluginOptions(_ context.Context, _ *pluginapi.Empty) (*pluginapi.DevicePluginOptions, error) {
+	options := &pluginapi.DevicePluginOptions{
+		PreStartRequired: false,
+	}
+	return options, nil
+}
+
+func (dpi *BandwidthDevicePlugin) PreStartContainer(_ context.Context, _ *pluginapi.PreStartContainerRequest) (*pluginapi.PreStartContainerResponse, error) {
+	res := &pluginapi.PreStartContainerResponse{}
+	return res, nil
+}
+
+// healthCheck returns once the device plugin socket is removed, which happens when the kubelet restarts
+func (dpi *BandwidthDevicePlugin) healthCheck() error {
+	logger := log.DefaultLogger()
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to creating a fsnotify watcher: %v", err)
+	}
+	defer watcher.Close()
+
+	err = watcher.Add(filepath.Dir(dpi.socketPath))
+	if err != nil {
+		return fmt.Errorf("failed to add the device-plugin kubelet path to the watcher: %v", err)
+	}
+	_, err = os.Stat(dpi.socketPath)
+	if err != nil {
+		return fmt.Errorf("failed to stat the device-plugin socket: %v", err)
+	}
+
+	for {
+		select {
+		case <-dpi.stop:
+			return nil
+		case err := <-watcher.Errors:
+			logger.Reason(err).Errorf("error watching the device plugin directory")
+		case event := <-watcher.Events:
+			if event.Name == dpi.socketPath && event.Op == fsnotify.Remove {
+				logger.Infof("device socket file for device %s was removed, kubelet probably restarted.", BandwidthDeviceName)
+				return nil
+			}
+		}
+	}
+}
+
+func (dpi *BandwidthDevicePlugin) GetInitialized() bool {
+	dpi.lock.Lock()
+	defer dpi.lock.Unlock()
+	return dpi.initialized
+}
+
+func (dpi *BandwidthDevicePlugin) setInitialized(initialized bool) {
+	dpi.lock.Lock()
+	dpi.initialized = initialized
+	dpi.lock.Unlock()
+}
+
+func (dpi *BandwidthDevicePlugin) listDevices() []*pluginapi.Device {
+	dpi.lock.Lock()
+	defer dpi.lock.Unlock()
+	return dpi.devs
+}
+
+// refreshDevices advertises the configured bandwidth of the node again, it returns true if it changed
+func (dpi *BandwidthDevicePlugin) refreshDevices() bool {
+	bandwidth := dpi.clusterConfig.GetNodeBandwidthDevices(dpi.host)
+
+	dpi.lock.Lock()
+	defer dpi.lock.Unlock()
+	if bandwidth == len(dpi.devs) {
+		return false
+	}
+	log.DefaultLogger().Infof("Bandwidth of the node changed from %d to %d devices", len(dpi.devs), bandwidth)
+	devs := make([]*pluginapi.Device, 0, bandwidth)
+	for i := 0; i < bandwidth; i++ {
+		devs = append(devs, &pluginapi.Device{
+			ID:     BandwidthDeviceName + strconv.Itoa(i),
+			Health: pluginapi.Healthy,
+		})
+	}
+	dpi.devs = devs
+	return true
+}
@@ -0,0 +1,70 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package device_manager
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"golang.org/x/net/context"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/testutils"
+	pluginapi "kubevirt.io/kubevirt/pkg/virt-handler/device-manager/deviceplugin/v1beta1"
+)
+
+var _ = Describe("Bandwidth", func() {
+
+	newDevicePlugin := func(networkConfig *v1.NetworkConfiguration) *BandwidthDevicePlugin {
+		clusterConfig, _, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+			NetworkConfiguration: networkConfig,
+		})
+		return NewBandwidthDevicePlugin("node01", clusterConfig)
+	}
+
+	It("should advertise no devices without a configured bandwidth", func() {
+		dpi := newDevicePlugin(nil)
+
+		Expect(dpi.refreshDevices()).To(BeFalse())
+		Expect(dpi.listDevices()).To(BeEmpty())
+	})
+
+	It("should advertise the bandwidth of the node", func() {
+		dpi := newDevicePlugin(&v1.NetworkConfiguration{
+			NodeBandwidth:          resource.NewScaledQuantity(10, resource.Giga),
+			NodeBandwidthOverrides: map[string]resource.Quantity{"node01": *resource.NewScaledQuantity(25, resource.Giga)},
+		})
+
+		Expect(dpi.refreshDevices()).To(BeTrue())
+		Expect(dpi.listDevices()).To(HaveLen(250))
+		Expect(dpi.listDevices()[0]).To(Equal(&pluginapi.Device{ID: "bandwidth0", Health: pluginapi.Healthy}))
+		Expect(dpi.refreshDevices()).To(BeFalse())
+	})
+
+	It("should allocate nothing to the containers", func() {
+		dpi := newDevicePlugin(nil)
+
+		response, err := dpi.Allocate(context.Background(), &pluginapi.AllocateRequest{
+			ContainerRequests: []*pluginapi.ContainerAllocateRequest{{DevicesIDs: []string{"bandwidth0"}}},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.ContainerResponses).To(ConsistOf(&pluginapi.ContainerAllocateResponse{}))
+	})
+})
@@ -22,12 +22,18 @@ package device_manager
 import (
 	"math"
 	"os"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/log"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+	pluginapi "kubevirt.io/kubevirt/pkg/virt-handler/device-manager/deviceplugin/v1beta1"
 )
 
 var permanentDevicePluginPaths = map[string]string{
@@ -36,17 +42,27 @@ var permanentDevicePluginPaths = map[string]string{
 	"vhost-net": "/dev/vhost-net",
 }
 
+// pendingRemovalsCheckInterval is how often device plugins of no longer permitted
+// resources are checked for being released by the VMIs on the node
+const pendingRemovalsCheckInterval = 30 * time.Second
+
 type DeviceControllerInterface interface {
 	Initialized() bool
+	// PendingRemovals returns the no longer permitted resources which are still allocated to VMIs
+	PendingRemovals() []string
+	// VMICapacityExhausted reports whether the node runs as many VMIs as it has capacity for
+	VMICapacityExhausted() bool
 }
 
 type DeviceController struct {
 	devicePlugins      map[string]ControlledDevice
 	devicePluginsMutex sync.Mutex
+	pendingRemovals    map[string]struct{}
 	host               string
 	maxDevices         int
 	backoff            []time.Duration
 	virtConfig         *virtconfig.ClusterConfig
+	vmiStore           cache.Store
 	stop               chan struct{}
 	mdevTypesManager   *MDEVTypesManager
 }
@@ -56,7 +72,27 @@ type ControlledDevice struct {
 	stopChan     chan struct{}
 }
 
-func getPermanentHostDevicePlugins(maxDevices int, permissions string) map[string]ControlledDevice {
+// DrainableDevice is a device plugin which can withdraw its devices from new allocations
+type DrainableDevice interface {
+	SetDraining(draining bool)
+}
+
+// devicesForDrainState reports all devices as unhealthy while draining, so that the kubelet
+// doesn't allocate them anymore but keeps the existing allocations
+func devicesForDrainState(devs []*pluginapi.Device, draining bool) []*pluginapi.Device {
+	if !draining {
+		return devs
+	}
+	drained := make([]*pluginapi.Device, 0, len(devs))
+	for _, dev := range devs {
+		drainedDev := *dev
+		drainedDev.Health = pluginapi.Unhealthy
+		drained = append(drained, &drainedDev)
+	}
+	return drained
+}
+
+func getPermanentHostDevicePlugins(host string, maxDevices int, permissions string, clusterConfig *virtconfig.ClusterConfig, vmiStore cache.Store) map[string]ControlledDevice {
 	ret := map[string]ControlledDevice{}
 	for name, path := range permanentDevicePluginPaths {
 		ret[name] = ControlledDevice{
@@ -64,16 +100,31 @@ func getPermanentHostDevicePlugins(maxDevices int, permissions string) map[strin
 			stopChan:     make(chan struct{}),
 		}
 	}
+	ret[VMICapacityDeviceName] = ControlledDevice{
+		devicePlugin: NewVMICapacityDevicePlugin(maxDevices, clusterConfig, vmiStore),
+		stopChan:     make(chan struct{}),
+	}
+	ret[BandwidthDeviceName] = ControlledDevice{
+		devicePlugin: NewBandwidthDevicePlugin(host, clusterConfig),
+		stopChan:     make(chan struct{}),
+	}
 	return ret
 }
 
-func NewDeviceController(host string, maxDevices int, permissions string, clusterConfig *virtconfig.ClusterConfig) *DeviceController {
+func isPermanentDevicePlugin(name string) bool {
+	_, isPermanent := permanentDevicePluginPaths[name]
+	return isPermanent || name == VMICapacityDeviceName || name == BandwidthDeviceName
+}
+
+func NewDeviceController(host string, maxDevices int, permissions string, clusterConfig *virtconfig.ClusterConfig, vmiStore cache.Store) *DeviceController {
 	controller := &DeviceController{
-		devicePlugins:    getPermanentHostDevicePlugins(maxDevices, permissions),
+		devicePlugins:    getPermanentHostDevicePlugins(host, maxDevices, permissions, clusterConfig, vmiStore),
+		pendingRemovals:  map[string]struct{}{},
 		host:             host,
 		maxDevices:       maxDevices,
 		backoff:          []time.Duration{1 * time.Second, 2 * time.Second, 5 * time.Second, 10 * time.Second},
 		virtConfig:       clusterConfig,
+		vmiStore:         vmiStore,
 		mdevTypesManager: NewMDEVTypesManager(),
 	}
 
@@ -121,8 +172,7 @@ func (c *DeviceController) updatePermittedHostDevicePlugins() (map[string]Contro
 	devicePluginsToStop := make(map[string]ControlledDevice)
 	// generate a map of currently started device plugins
 	for resourceName, hostDevDP := range c.devicePlugins {
-		_, isPermanent := permanentDevicePluginPaths[resourceName]
-		if !isPermanent {
+		if !isPermanentDevicePlugin(resourceName) {
 			devicePluginsToStop[resourceName] = hostDevDP
 		}
 	}
@@ -223,8 +273,26 @@ func (c *DeviceController) refreshPermittedDevices() {
 		c.devicePlugins[resourceName] = dev
 		debugDevAdded = append(debugDevAdded, resourceName)
 	}
-	// remove device plugin for now forbidden devices
+	// offer the devices again which were permitted again before they got released
+	for resourceName := range c.pendingRemovals {
+		if _, disabled := disabledDevicePlugins[resourceName]; !disabled {
+			c.devicePlugins[resourceName].devicePlugin.(DrainableDevice).SetDraining(false)
+			delete(c.pendingRemovals, resourceName)
+			debugDevAdded = append(debugDevAdded, resourceName)
+		}
+	}
+	// remove device plugin for now forbidden devices, the ones still allocated to VMIs
+	// are only withdrawn from new allocations until they get released
 	for resourceName, dev := range disabledDevicePlugins {
+		if _, pending := c.pendingRemovals[resourceName]; pending {
+			continue
+		}
+		if drainable, ok := dev.devicePlugin.(DrainableDevice); ok && c.isResourceAllocated(resourceName) {
+			drainable.SetDraining(true)
+			c.pendingRemovals[resourceName] = struct{}{}
+			logger.Infof("device-plugin for %s is kept until its devices are released", resourceName)
+			continue
+		}
 		close(dev.stopChan)
 		delete(c.devicePlugins, resourceName)
 		debugDevRemoved = append(debugDevRemoved, resourceName)
@@ -237,6 +305,73 @@ func (c *DeviceController) refreshPermittedDevices() {
 	logger.Infof("disabled device-plugins for: %v", debugDevRemoved)
 }
 
+// removeReleasedDevicePlugins stops the device plugins pending removal once no VMI uses their devices anymore
+func (c *DeviceController) removeReleasedDevicePlugins() {
+	c.devicePluginsMutex.Lock()
+	defer c.devicePluginsMutex.Unlock()
+
+	for resourceName := range c.pendingRemovals {
+		if c.isResourceAllocated(resourceName) {
+			continue
+		}
+		close(c.devicePlugins[resourceName].stopChan)
+		delete(c.devicePlugins, resourceName)
+		delete(c.pendingRemovals, resourceName)
+		log.DefaultLogger().Infof("disabled device-plugin for released resource %s", resourceName)
+	}
+}
+
+// isResourceAllocated checks if a VMI on the node still has a host device or a GPU of the resource
+func (c *DeviceController) isResourceAllocated(resourceName string) bool {
+	if c.vmiStore == nil {
+		return false
+	}
+	for _, obj := range c.vmiStore.List() {
+		vmi := obj.(*v1.VirtualMachineInstance)
+		if vmi.IsFinal() {
+			continue
+		}
+		for _, hostDev := range vmi.Spec.Domain.Devices.HostDevices {
+			if hostDev.DeviceName == resourceName {
+				return true
+			}
+		}
+		for _, gpu := range vmi.Spec.Domain.Devices.GPUs {
+			if gpu.DeviceName == resourceName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (c *DeviceController) PendingRemovals() []string {
+	c.devicePluginsMutex.Lock()
+	defer c.devicePluginsMutex.Unlock()
+
+	var resourceNames []string
+	for resourceName := range c.pendingRemovals {
+		resourceNames = append(resourceNames, resourceName)
+	}
+	sort.Strings(resourceNames)
+	return resourceNames
+}
+
+func (c *DeviceController) VMICapacityExhausted() bool {
+	c.devicePluginsMutex.Lock()
+	defer c.devicePluginsMutex.Unlock()
+
+	dev, exists := c.devicePlugins[VMICapacityDeviceName]
+	if !exists {
+		return false
+	}
+	capacityPlugin, ok := dev.devicePlugin.(*VMICapacityDevicePlugin)
+	if !ok || !capacityPlugin.GetInitialized() {
+		return false
+	}
+	return capacityPlugin.runningVMIs() >= capacityPlugin.Capacity()
+}
+
 func (c *DeviceController) Run(stop chan struct{}) error {
 	logger := log.DefaultLogger()
 	// start the permanent DevicePlugins
@@ -247,6 +382,8 @@ func (c *DeviceController) Run(stop chan struct{}) error {
 	c.virtConfig.SetConfigModifiedCallback(c.refreshPermittedDevices)
 	c.refreshPermittedDevices()
 
+	go wait.Until(c.removeReleasedDevicePlugins, pendingRemovalsCheckInterval, stop)
+
 	// keep running until stop
 	<-stop
 
@@ -12,13 +12,16 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
 
+	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/kubevirt/pkg/testutils"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
 type FakePlugin struct {
 	Starts     int32
+	Draining   int32
 	devicePath string
 	deviceName string
 	Error      error
@@ -41,6 +44,14 @@ func (fp *FakePlugin) GetInitialized() bool {
 	return true
 }
 
+func (fp *FakePlugin) SetDraining(draining bool) {
+	if draining {
+		atomic.StoreInt32(&fp.Draining, 1)
+	} else {
+		atomic.StoreInt32(&fp.Draining, 0)
+	}
+}
+
 func NewFakePlugin(name string, path string) *FakePlugin {
 	return &FakePlugin{
 		deviceName: name,
@@ -90,7 +101,7 @@ var _ = Describe("Device Controller", func() {
 
 	Context("Basic Tests", func() {
 		It("Should indicate if node has device", func() {
-			deviceController := NewDeviceController(host, 10, "rw", fakeConfigMap)
+			deviceController := NewDeviceController(host, 10, "rw", fakeConfigMap, nil)
 			devicePath := path.Join(workDir, "fake-device")
 			res := deviceController.NodeHasDevice(devicePath)
 			Expect(res).To(BeFalse())
@@ -126,7 +137,7 @@ var _ = Describe("Device Controller", func() {
 		})
 
 		It("should start the device plugin immediately without delays", func() {
-			deviceController := NewDeviceController(host, 10, "rw", fakeConfigMap)
+			deviceController := NewDeviceController(host, 10, "rw", fakeConfigMap, nil)
 			deviceController.backoff = []time.Duration{10 * time.Millisecond, 10 * time.Second}
 			// New device controllers include the permanent device plugins, we don't want those
 			deviceController.devicePlugins = make(map[string]ControlledDevice)
@@ -144,7 +155,7 @@ var _ = Describe("Device Controller", func() {
 		It("should restart the device plugin with delays if it returns errors", func() {
 			plugin2 = NewFakePlugin("fake-device2", devicePath2)
 			plugin2.Error = fmt.Errorf("failing")
-			deviceController := NewDeviceController(host, 10, "rw", fakeConfigMap)
+			deviceController := NewDeviceController(host, 10, "rw", fakeConfigMap, nil)
 			deviceController.backoff = []time.Duration{10 * time.Millisecond, 300 * time.Millisecond}
 			// New device controllers include the permanent device plugins, we don't want those
 			deviceController.devicePlugins = make(map[string]ControlledDevice)
@@ -160,7 +171,7 @@ var _ = Describe("Device Controller", func() {
 		})
 
 		It("Should not block on other plugins", func() {
-			deviceController := NewDeviceController(host, 10, "rw", fakeConfigMap)
+			deviceController := NewDeviceController(host, 10, "rw", fakeConfigMap, nil)
 			// New device controllers include the permanent device plugins, we don't want those
 			deviceController.devicePlugins = make(map[string]ControlledDevice)
 			deviceController.devicePlugins[deviceName1] = ControlledDevice{
@@ -188,7 +199,7 @@ var _ = Describe("Device Controller", func() {
 		It("should remove all device plugins if permittedHostDevices is removed from the CR", func() {
 			emptyConfigMap, _, _, _ := testutils.NewFakeClusterConfig(&k8sv1.ConfigMap{})
 			Expect(emptyConfigMap.GetPermittedHostDevices()).To(BeNil())
-			deviceController := NewDeviceController(host, 10, "rw", emptyConfigMap)
+			deviceController := NewDeviceController(host, 10, "rw", emptyConfigMap, nil)
 			// New device controllers include the permanent device plugins, we don't want those
 			deviceController.devicePlugins = make(map[string]ControlledDevice)
 			deviceController.devicePlugins[deviceName1] = ControlledDevice{
@@ -209,5 +220,43 @@ var _ = Describe("Device Controller", func() {
 				return exists1 || exists2
 			}).Should(BeFalse())
 		})
+
+		It("should keep the device plugins of resources allocated to VMIs until they are released", func() {
+			emptyConfigMap, _, _, _ := testutils.NewFakeClusterConfig(&k8sv1.ConfigMap{})
+			vmiStore := cache.NewStore(cache.DeletionHandlingMetaNamespaceKeyFunc)
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.HostDevices = []v1.HostDevice{{Name: "hostdev", DeviceName: deviceName1}}
+			Expect(vmiStore.Add(vmi)).To(Succeed())
+
+			deviceController := NewDeviceController(host, 10, "rw", emptyConfigMap, vmiStore)
+			// New device controllers include the permanent device plugins, we don't want those
+			deviceController.devicePlugins = make(map[string]ControlledDevice)
+			deviceController.devicePlugins[deviceName1] = ControlledDevice{
+				devicePlugin: plugin1,
+				stopChan:     stop1,
+			}
+			deviceController.devicePlugins[deviceName2] = ControlledDevice{
+				devicePlugin: plugin2,
+				stopChan:     stop2,
+			}
+			deviceController.refreshPermittedDevices()
+
+			By("withdrawing the allocated devices from new allocations")
+			Expect(deviceController.devicePlugins).To(HaveKey(deviceName1))
+			Expect(deviceController.devicePlugins).ToNot(HaveKey(deviceName2))
+			Expect(atomic.LoadInt32(&plugin1.Draining)).To(Equal(int32(1)))
+			Expect(deviceController.PendingRemovals()).To(ConsistOf(deviceName1))
+
+			By("keeping the device plugin while the VMI is running")
+			deviceController.removeReleasedDevicePlugins()
+			Expect(deviceController.devicePlugins).To(HaveKey(deviceName1))
+
+			By("removing the device plugin once the VMI is gone")
+			Expect(vmiStore.Delete(vmi)).To(Succeed())
+			deviceController.removeReleasedDevicePlugins()
+			Expect(deviceController.devicePlugins).ToNot(HaveKey(deviceName1))
+			Expect(deviceController.PendingRemovals()).To(BeEmpty())
+			Expect(stop1).To(BeClosed())
+		})
 	})
 })
@@ -68,6 +68,8 @@ type MediatedDevicePlugin struct {
 	iommuToMDEVMap map[string]string
 	initialized    bool
 	lock           *sync.Mutex
+	draining       bool
+	drainUpdate    chan struct{}
 }
 
 func NewMediatedDevicePlugin(mdevs []*MDEV, resourceName string) *MediatedDevicePlugin {
@@ -92,6 +94,7 @@ func NewMediatedDevicePlugin(mdevs []*MDEV, resourceName string) *MediatedDevice
 		iommuToMDEVMap: iommuToMDEVMap,
 		initialized:    false,
 		lock:           &sync.Mutex{},
+		drainUpdate:    make(chan struct{}, 1),
 	}
 
 	return dpi
@@ -251,7 +254,7 @@ func (dpi *MediatedDevicePlugin) ListAndWatch(_ *pluginapi.Empty, s pluginapi.De
 	emptyList := []*pluginapi.Device{}
 	s.Send(&pluginapi.ListAndWatchResponse{Devices: emptyList})
 
-	s.Send(&pluginapi.ListAndWatchResponse{Devices: dpi.devs})
+	s.Send(&pluginapi.ListAndWatchResponse{Devices: dpi.listDevices()})
 
 	for {
 		select {
@@ -261,14 +264,16 @@ func (dpi *MediatedDevicePlugin) ListAndWatch(_ *pluginapi.Empty, s pluginapi.De
 					dev.Health = pluginapi.Unhealthy
 				}
 			}
-			s.Send(&pluginapi.ListAndWatchResponse{Devices: dpi.devs})
+			s.Send(&pluginapi.ListAndWatchResponse{Devices: dpi.listDevices()})
 		case healthy := <-dpi.healthy:
 			for _, dev := range dpi.devs {
 				if healthy == dev.ID {
 					dev.Health = pluginapi.Healthy
 				}
 			}
-			s.Send(&pluginapi.ListAndWatchResponse{Devices: dpi.devs})
+			s.Send(&pluginapi.ListAndWatchResponse{Devices: dpi.listDevices()})
+		case <-dpi.drainUpdate:
+			s.Send(&pluginapi.ListAndWatchResponse{Devices: dpi.listDevices()})
 		case <-dpi.stop:
 			return nil
 		case <-dpi.done:
@@ -443,3 +448,20 @@ func (dpi *MediatedDevicePlugin) setInitialized(initialized bool) {
 	dpi.initialized = initialized
 	dpi.lock.Unlock()
 }
+
+// SetDraining withdraws the devices from new allocations, devices which are already allocated keep working
+func (dpi *MediatedDevicePlugin) SetDraining(draining bool) {
+	dpi.lock.Lock()
+	dpi.draining = draining
+	dpi.lock.Unlock()
+	select {
+	case dpi.drainUpdate <- struct{}{}:
+	default:
+	}
+}
+
+func (dpi *MediatedDevicePlugin) listDevices() []*pluginapi.Device {
+	dpi.lock.Lock()
+	defer dpi.lock.Unlock()
+	return devicesForDrainState(dpi.devs, dpi.draining)
+}
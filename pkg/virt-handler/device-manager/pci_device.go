@@ -68,6 +68,8 @@ type PCIDevicePlugin struct {
 	iommuToPCIMap map[string]string
 	initialized   bool
 	lock          *sync.Mutex
+	draining      bool
+	drainUpdate   chan struct{}
 }
 
 func NewPCIDevicePlugin(pciDevices []*PCIDevice, resourceName string) *PCIDevicePlugin {
@@ -90,6 +92,7 @@ func NewPCIDevicePlugin(pciDevices []*PCIDevice, resourceName string) *PCIDevice
 		unhealthy:     make(chan string),
 		initialized:   false,
 		lock:          &sync.Mutex{},
+		drainUpdate:   make(chan struct{}, 1),
 	}
 	return dpi
 }
@@ -168,7 +171,7 @@ func (dpi *PCIDevicePlugin) ListAndWatch(_ *pluginapi.Empty, s pluginapi.DeviceP
 	emptyList := []*pluginapi.Device{}
 	s.Send(&pluginapi.ListAndWatchResponse{Devices: emptyList})
 
-	s.Send(&pluginapi.ListAndWatchResponse{Devices: dpi.devs})
+	s.Send(&pluginapi.ListAndWatchResponse{Devices: dpi.listDevices()})
 
 	for {
 		select {
@@ -178,14 +181,16 @@ func (dpi *PCIDevicePlugin) ListAndWatch(_ *pluginapi.Empty, s pluginapi.DeviceP
 					dev.Health = pluginapi.Unhealthy
 				}
 			}
-			s.Send(&pluginapi.ListAndWatchResponse{Devices: dpi.devs})
+			s.Send(&pluginapi.ListAndWatchResponse{Devices: dpi.listDevices()})
 		case healthy := <-dpi.healthy:
 			for _, dev := range dpi.devs {
 				if healthy == dev.ID {
 					dev.Health = pluginapi.Healthy
 				}
 			}
-			s.Send(&pluginapi.ListAndWatchResponse{Devices: dpi.devs})
+			s.Send(&pluginapi.ListAndWatchResponse{Devices: dpi.listDevices()})
+		case <-dpi.drainUpdate:
+			s.Send(&pluginapi.ListAndWatchResponse{Devices: dpi.listDevices()})
 		case <-dpi.stop:
 			return nil
 		case <-dpi.done:
@@ -426,3 +431,20 @@ func (dpi *PCIDevicePlugin) setInitialized(initialized bool) {
 	dpi.initialized = initialized
 	dpi.lock.Unlock()
 }
+
+// SetDraining withdraws the devices from new allocations, devices which are already allocated keep working
+func (dpi *PCIDevicePlugin) SetDraining(draining bool) {
+	dpi.lock.Lock()
+	dpi.draining = draining
+	dpi.lock.Unlock()
+	select {
+	case dpi.drainUpdate <- struct{}{}:
+	default:
+	}
+}
+
+func (dpi *PCIDevicePlugin) listDevices() []*pluginapi.Device {
+	dpi.lock.Lock()
+	defer dpi.lock.Unlock()
+	return devicesForDrainState(dpi.devs, dpi.draining)
+}
@@ -0,0 +1,385 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package device_manager
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/util"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+	pluginapi "kubevirt.io/kubevirt/pkg/virt-handler/device-manager/deviceplugin/v1beta1"
+)
+
+const (
+	// VMICapacityDeviceName is the device plugin which advertises how many VMIs fit on the node,
+	// every virt-launcher pod consumes one of its devices
+	VMICapacityDeviceName = "vmi"
+
+	// vmiMemoryFootprint is the smallest amount of contiguous memory a VMI needs on the node,
+	// including the overhead of virt-launcher and QEMU
+	vmiMemoryFootprint = 256 * 1024 * 1024
+	// contiguousBlockSize is the smallest free memory block which is not considered to be fragmented
+	contiguousBlockSize        = 2 * 1024 * 1024
+	buddyInfoPath              = "/proc/buddyinfo"
+	vmiCapacityRefreshInterval = 1 * time.Minute
+	kvmDevicePath              = "/dev/kvm"
+)
+
+// VMICapacityDevicePlugin advertises the number of VMIs the node can run as devices.
+// The capacity is capped by the maximum number of devices of virt-handler, drops to zero
+// without /dev/kvm unless emulation is allowed, and is limited by the free memory of the
+// node which is not fragmented.
+type VMICapacityDevicePlugin struct {
+	devs            []*pluginapi.Device
+	server          *grpc.Server
+	socketPath      string
+	stop            chan struct{}
+	done            chan struct{}
+	deviceRoot      string
+	resourceName    string
+	buddyInfoPath   string
+	refreshInterval time.Duration
+	maxDevices      int
+	clusterConfig   *virtconfig.ClusterConfig
+	vmiStore        cache.Store
+	initialized     bool
+	lock            *sync.Mutex
+}
+
+func NewVMICapacityDevicePlugin(maxDevices int, clusterConfig *virtconfig.ClusterConfig, vmiStore cache.Store) *VMICapacityDevicePlugin {
+	return &VMICapacityDevicePlugin{
+		devs:            []*pluginapi.Device{},
+		socketPath:      SocketPath(VMICapacityDeviceName),
+		deviceRoot:      util.HostRootMount,
+		resourceName:    fmt.Sprintf("%s/%s", DeviceNamespace, VMICapacityDeviceName),
+		buddyInfoPath:   buddyInfoPath,
+		refreshInterval: vmiCapacityRefreshInterval,
+		maxDevices:      maxDevices,
+		clusterConfig:   clusterConfig,
+		vmiStore:        vmiStore,
+		initialized:     false,
+		lock:            &sync.Mutex{},
+	}
+}
+
+func (dpi *VMICapacityDevicePlugin) GetDevicePath() string {
+	return ""
+}
+
+func (dpi *VMICapacityDevicePlugin) GetDeviceName() string {
+	return VMICapacityDeviceName
+}
+
+// Start starts the device plugin
+func (dpi *VMICapacityDevicePlugin) Start(stop chan struct{}) (err error) {
+	logger := log.DefaultLogger()
+	dpi.stop = stop
+	dpi.done = make(chan struct{})
+
+	err = dpi.cleanup()
+	if err != nil {
+		return err
+	}
+
+	sock, err := net.Listen("unix", dpi.socketPath)
+	if err != nil {
+		return fmt.Errorf("error creating GRPC server socket: %v", err)
+	}
+
+	dpi.server = grpc.NewServer([]grpc.ServerOption{}...)
+	defer dpi.Stop()
+
+	dpi.refreshDevices()
+	pluginapi.RegisterDevicePluginServer(dpi.server, dpi)
+	err = dpi.Register()
+	if err != nil {
+		return fmt.Errorf("error registering with device plugin manager: %v", err)
+	}
+
+	errChan := make(chan error, 2)
+
+	go func() {
+		errChan <- dpi.server.Serve(sock)
+	}()
+
+	err = waitForGrpcServer(dpi.socketPath, connectionTimeout)
+	if err != nil {
+		return fmt.Errorf("error starting the GRPC server: %v", err)
+	}
+
+	go func() {
+		errChan <- dpi.healthCheck()
+	}()
+
+	dpi.setInitialized(true)
+	logger.Infof("%s device plugin started", VMICapacityDeviceName)
+	err = <-errChan
+
+	return err
+}
+
+// Stop stops the gRPC server
+func (dpi *VMICapacityDevicePlugin) Stop() error {
+	defer func() {
+		if !IsChanClosed(dpi.done) {
+			close(dpi.done)
+		}
+	}()
+	dpi.server.Stop()
+	dpi.setInitialized(false)
+	return dpi.cleanup()
+}
+
+// Register registers the device plugin for the given resourceName with Kubelet.
+func (dpi *VMICapacityDevicePlugin) Register() error {
+	conn, err := connect(pluginapi.KubeletSocket, connectionTimeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := pluginapi.NewRegistrationClient(conn)
+	reqt := &pluginapi.RegisterRequest{
+		Version:      pluginapi.Version,
+		Endpoint:     path.Base(dpi.socketPath),
+		ResourceName: dpi.resourceName,
+	}
+
+	_, err = client.Register(context.Background(), reqt)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (dpi *VMICapacityDevicePlugin) ListAndWatch(_ *pluginapi.Empty, s pluginapi.DevicePlugin_ListAndWatchServer) error {
+	// FIXME: sending an empty list up front should not be needed. This is a workaround for:
+	// https://github.com/kubevirt/kubevirt/issues/1196
+	// This can safely be removed once supported upstream Kubernetes is 1.10.3 or higher.
+	emptyList := []*pluginapi.Device{}
+	s.Send(&pluginapi.ListAndWatchResponse{Devices: emptyList})
+
+	dpi.refreshDevices()
+	s.Send(&pluginapi.ListAndWatchResponse{Devices: dpi.listDevices()})
+
+	ticker := time.NewTicker(dpi.refreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if dpi.refreshDevices() {
+				s.Send(&pluginapi.ListAndWatchResponse{Devices: dpi.listDevices()})
+			}
+		case <-dpi.stop:
+			return nil
+		case <-dpi.done:
+			return nil
+		}
+	}
+}
+
+// Allocate doesn't pass anything to the containers, the devices only account for the VMIs of the node
+func (dpi *VMICapacityDevicePlugin) Allocate(_ context.Context, r *pluginapi.AllocateRequest) (*pluginapi.AllocateResponse, error) {
+	response := pluginapi.AllocateResponse{}
+	for range r.ContainerRequests {
+		response.ContainerResponses = append(response.ContainerResponses, &pluginapi.ContainerAllocateResponse{})
+	}
+	return &response, nil
+}
+
+func (dpi *VMICapacityDevicePlugin) cleanup() error {
+	if err := os.Remove(dpi.socketPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (dpi *VMICapacityDevicePlugin) GetDevicePluginOptions(_ context.Context, _ *pluginapi.Empty) (*pluginapi.DevicePluginOptions, error) {
+	options := &pluginapi.DevicePluginOptions{
+		PreStartRequired: false,
+	}
+	return options, nil
+}
+
+func (dpi *VMICapacityDevicePlugin) PreStartContainer(_ context.Context, _ *pluginapi.PreStartContainerRequest) (*pluginapi.PreStartContainerResponse, error) {
+	res := &pluginapi.PreStartContainerResponse{}
+	return res, nil
+}
+
+// healthCheck returns once the device plugin socket is removed, which happens when the kubelet restarts
+func (dpi *VMICapacityDevicePlugin) healthCheck() error {
+	logger := log.DefaultLogger()
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to creating a fsnotify watcher: %v", err)
+	}
+	defer watcher.Close()
+
+	err = watcher.Add(filepath.Dir(dpi.socketPath))
+	if err != nil {
+		return fmt.Errorf("failed to add the device-plugin kubelet path to the watcher: %v", err)
+	}
+	_, err = os.Stat(dpi.socketPath)
+	if err != nil {
+		return fmt.Errorf("failed to stat the device-plugin socket: %v", err)
+	}
+
+	for {
+		select {
+		case <-dpi.stop:
+			return nil
+		case err := <-watcher.Errors:
+			logger.Reason(err).Errorf("error watching the device plugin directory")
+		case event := <-watcher.Events:
+			if event.Name == dpi.socketPath && event.Op == fsnotify.Remove {
+				logger.Infof("device socket file for device %s was removed, kubelet probably restarted.", VMICapacityDeviceName)
+				return nil
+			}
+		}
+	}
+}
+
+func (dpi *VMICapacityDevicePlugin) GetInitialized() bool {
+	dpi.lock.Lock()
+	defer dpi.lock.Unlock()
+	return dpi.initialized
+}
+
+func (dpi *VMICapacityDevicePlugin) setInitialized(initialized bool) {
+	dpi.lock.Lock()
+	dpi.initialized = initialized
+	dpi.lock.Unlock()
+}
+
+func (dpi *VMICapacityDevicePlugin) listDevices() []*pluginapi.Device {
+	dpi.lock.Lock()
+	defer dpi.lock.Unlock()
+	return dpi.devs
+}
+
+// Capacity returns the number of VMIs which were last advertised for the node
+func (dpi *VMICapacityDevicePlugin) Capacity() int {
+	return len(dpi.listDevices())
+}
+
+// refreshDevices derives the capacity of the node again, it returns true if it changed
+func (dpi *VMICapacityDevicePlugin) refreshDevices() bool {
+	capacity := dpi.deriveCapacity()
+
+	dpi.lock.Lock()
+	defer dpi.lock.Unlock()
+	if capacity == len(dpi.devs) {
+		return false
+	}
+	log.DefaultLogger().Infof("Capacity of the node changed from %d to %d VMIs", len(dpi.devs), capacity)
+	devs := make([]*pluginapi.Device, 0, capacity)
+	for i := 0; i < capacity; i++ {
+		devs = append(devs, &pluginapi.Device{
+			ID:     VMICapacityDeviceName + strconv.Itoa(i),
+			Health: pluginapi.Healthy,
+		})
+	}
+	dpi.devs = devs
+	return true
+}
+
+// deriveCapacity returns how many VMIs fit on the node, including the ones which already run on it
+func (dpi *VMICapacityDevicePlugin) deriveCapacity() int {
+	if !dpi.clusterConfig.AllowEmulation() {
+		if _, err := os.Stat(filepath.Join(dpi.deviceRoot, kvmDevicePath)); err != nil {
+			return 0
+		}
+	}
+
+	capacity := dpi.maxDevices
+	freeMemory, err := contiguousFreeMemory(dpi.buddyInfoPath, os.Getpagesize())
+	if err != nil {
+		log.DefaultLogger().Reason(err).Warning("Not limiting the capacity of the node by its free memory")
+		return capacity
+	}
+	if memoryCapacity := dpi.runningVMIs() + int(freeMemory/vmiMemoryFootprint); memoryCapacity < capacity {
+		capacity = memoryCapacity
+	}
+	return capacity
+}
+
+// runningVMIs returns the number of VMIs on the node which didn't finish yet
+func (dpi *VMICapacityDevicePlugin) runningVMIs() int {
+	if dpi.vmiStore == nil {
+		return 0
+	}
+	running := 0
+	for _, obj := range dpi.vmiStore.List() {
+		if vmi := obj.(*v1.VirtualMachineInstance); !vmi.IsFinal() {
+			running++
+		}
+	}
+	return running
+}
+
+// contiguousFreeMemory sums up the free memory blocks of at least contiguousBlockSize from the
+// buddy allocator statistics, smaller blocks are too fragmented to back the memory of VMIs
+func contiguousFreeMemory(buddyInfo string, pageSize int) (int64, error) {
+	file, err := os.Open(buddyInfo)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	var free int64
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		// Node 0, zone   Normal   1031    658    302    124     39     11      4      1      1      0    102
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 || fields[0] != "Node" || fields[2] != "zone" {
+			return 0, fmt.Errorf("invalid line in %s: %q", buddyInfo, scanner.Text())
+		}
+		for order, field := range fields[4:] {
+			blocks, err := strconv.ParseInt(field, 10, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid line in %s: %q", buddyInfo, scanner.Text())
+			}
+			if blockSize := int64(pageSize) << uint(order); blockSize >= contiguousBlockSize {
+				free += blocks * blockSize
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+	return free, nil
+}
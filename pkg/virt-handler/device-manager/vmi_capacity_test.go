@@ -0,0 +1,122 @@
+package device_manager
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"golang.org/x/net/context"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/testutils"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+	pluginapi "kubevirt.io/kubevirt/pkg/virt-handler/device-manager/deviceplugin/v1beta1"
+)
+
+const pageSize = 4096
+
+var _ = Describe("VMI capacity", func() {
+	var workDir string
+	var vmiStore cache.Store
+	var dpi *VMICapacityDevicePlugin
+
+	writeBuddyInfo := func(content string) {
+		Expect(ioutil.WriteFile(dpi.buddyInfoPath, []byte(content), 0644)).To(Succeed())
+	}
+
+	newClusterConfig := func(allowEmulation bool) *virtconfig.ClusterConfig {
+		clusterConfig, _, _, _ := testutils.NewFakeClusterConfigUsingKVConfig(&v1.KubeVirtConfiguration{
+			DeveloperConfiguration: &v1.DeveloperConfiguration{
+				UseEmulation: allowEmulation,
+			},
+		})
+		return clusterConfig
+	}
+
+	BeforeEach(func() {
+		var err error
+		workDir, err = ioutil.TempDir("", "kubevirt-test")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(os.MkdirAll(filepath.Join(workDir, "dev"), 0755)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(workDir, kvmDevicePath), []byte{}, 0644)).To(Succeed())
+
+		vmiStore = cache.NewStore(cache.DeletionHandlingMetaNamespaceKeyFunc)
+		dpi = NewVMICapacityDevicePlugin(10, newClusterConfig(false), vmiStore)
+		dpi.deviceRoot = workDir
+		dpi.buddyInfoPath = filepath.Join(workDir, "buddyinfo")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(workDir)
+	})
+
+	It("should only count free memory blocks of at least 2MiB", func() {
+		writeBuddyInfo("Node 0, zone      DMA      1      1      0      0      2      1      1      0      1      1      3\n" +
+			"Node 0, zone   Normal   1031    658    302    124     39     11      4      1      1      2    100\n")
+
+		free, err := contiguousFreeMemory(dpi.buddyInfoPath, pageSize)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(free).To(Equal(int64((1+2)*2*1024*1024 + (3+100)*4*1024*1024)))
+	})
+
+	It("should reject invalid buddy allocator statistics", func() {
+		writeBuddyInfo("Node 0, zone   Normal   many\n")
+
+		_, err := contiguousFreeMemory(dpi.buddyInfoPath, pageSize)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should be capped by the maximum number of devices", func() {
+		writeBuddyInfo("Node 0, zone   Normal   0   0   0   0   0   0   0   0   0   0   100000\n")
+
+		Expect(dpi.refreshDevices()).To(BeTrue())
+		Expect(dpi.Capacity()).To(Equal(10))
+		Expect(dpi.listDevices()[0]).To(Equal(&pluginapi.Device{ID: "vmi0", Health: pluginapi.Healthy}))
+		Expect(dpi.refreshDevices()).To(BeFalse())
+	})
+
+	It("should be limited by the contiguous free memory and count the running VMIs", func() {
+		// 1GiB in 4MiB blocks, the fragmented memory is ignored
+		writeBuddyInfo("Node 0, zone   Normal   100000   100000   0   0   0   0   0   0   0   0   256\n")
+		vmi := v1.NewMinimalVMI("running")
+		vmi.Status.Phase = v1.Running
+		Expect(vmiStore.Add(vmi)).To(Succeed())
+		vmi = v1.NewMinimalVMI("done")
+		vmi.Status.Phase = v1.Succeeded
+		Expect(vmiStore.Add(vmi)).To(Succeed())
+
+		dpi.refreshDevices()
+		Expect(dpi.Capacity()).To(Equal(5))
+	})
+
+	It("should not be limited by the memory if it can't be determined", func() {
+		dpi.refreshDevices()
+		Expect(dpi.Capacity()).To(Equal(10))
+	})
+
+	It("should be zero without /dev/kvm", func() {
+		Expect(os.Remove(filepath.Join(workDir, kvmDevicePath))).To(Succeed())
+
+		dpi.refreshDevices()
+		Expect(dpi.Capacity()).To(BeZero())
+	})
+
+	It("should not need /dev/kvm if emulation is allowed", func() {
+		Expect(os.Remove(filepath.Join(workDir, kvmDevicePath))).To(Succeed())
+		dpi.clusterConfig = newClusterConfig(true)
+
+		dpi.refreshDevices()
+		Expect(dpi.Capacity()).To(Equal(10))
+	})
+
+	It("should not pass anything to the containers", func() {
+		response, err := dpi.Allocate(context.Background(), &pluginapi.AllocateRequest{
+			ContainerRequests: []*pluginapi.ContainerAllocateRequest{{DevicesIDs: []string{"vmi0"}}},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.ContainerResponses).To(Equal([]*pluginapi.ContainerAllocateResponse{{}}))
+	})
+})
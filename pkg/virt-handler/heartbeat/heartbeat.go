@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"sort"
+	"strings"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -13,29 +15,35 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	utilwait "k8s.io/apimachinery/pkg/util/wait"
 	k8scli "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/cache"
 
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/log"
 	virtutil "kubevirt.io/kubevirt/pkg/util"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	device_manager "kubevirt.io/kubevirt/pkg/virt-handler/device-manager"
+	nodeshutdown "kubevirt.io/kubevirt/pkg/virt-handler/node-shutdown"
 )
 
 type HeartBeat struct {
 	clientset                 k8scli.CoreV1Interface
 	deviceManagerController   device_manager.DeviceControllerInterface
 	clusterConfig             *virtconfig.ClusterConfig
+	vmiStore                  cache.Store
+	nodeShutdown              nodeshutdown.NodeShutdownStatus
 	host                      string
 	cpuManagerPaths           []string
 	devicePluginPollIntervall time.Duration
 	devicePluginWaitTimeout   time.Duration
 }
 
-func NewHeartBeat(clientset k8scli.CoreV1Interface, deviceManager device_manager.DeviceControllerInterface, clusterConfig *virtconfig.ClusterConfig, host string) *HeartBeat {
+func NewHeartBeat(clientset k8scli.CoreV1Interface, deviceManager device_manager.DeviceControllerInterface, clusterConfig *virtconfig.ClusterConfig, vmiStore cache.Store, nodeShutdown nodeshutdown.NodeShutdownStatus, host string) *HeartBeat {
 	return &HeartBeat{
 		clientset:               clientset,
 		deviceManagerController: deviceManager,
 		clusterConfig:           clusterConfig,
+		vmiStore:                vmiStore,
+		nodeShutdown:            nodeShutdown,
 		host:                    host,
 		// This is a temporary workaround until k8s bug #66525 is resolved
 		cpuManagerPaths:           []string{virtutil.CPUManagerPath, virtutil.CPUManagerOS3Path},
@@ -91,6 +99,30 @@ func (h *HeartBeat) do() {
 	if !h.deviceManagerController.Initialized() {
 		kubevirtSchedulable = "false"
 	}
+	if h.nodeShutdown != nil && h.nodeShutdown.ShuttingDown() {
+		kubevirtSchedulable = "false"
+	}
+	// A node which runs as many VMIs as it has capacity for doesn't accept new ones
+	if h.deviceManagerController.VMICapacityExhausted() {
+		kubevirtSchedulable = "false"
+	}
+
+	// In maintenance mode no new VMIs are accepted, the remaining ones are reported until the node is drained
+	blockingVMIs := "null"
+	inMaintenance, err := h.isInMaintenance()
+	if err != nil {
+		log.DefaultLogger().Reason(err).Errorf("Can't determine if node %s is in maintenance", h.host)
+		return
+	}
+	if inMaintenance {
+		kubevirtSchedulable = "false"
+		blockingVMIs = fmt.Sprintf("%q", strings.Join(h.blockingVMIs(), ","))
+	}
+
+	pendingRemovals := "null"
+	if resourceNames := h.deviceManagerController.PendingRemovals(); len(resourceNames) > 0 {
+		pendingRemovals = fmt.Sprintf("%q", strings.Join(resourceNames, ","))
+	}
 
 	var data []byte
 	// Label the node if cpu manager is running on it
@@ -99,10 +131,12 @@ func (h *HeartBeat) do() {
 	if h.clusterConfig.CPUManagerEnabled() {
 		cpuManagerEnabled = h.isCPUManagerEnabled(h.cpuManagerPaths)
 	}
-	data = []byte(fmt.Sprintf(`{"metadata": { "labels": {"%s": "%s", "%s": "%t"}, "annotations": {"%s": %s}}}`,
+	data = []byte(fmt.Sprintf(`{"metadata": { "labels": {"%s": "%s", "%s": "%t"}, "annotations": {"%s": %s, "%s": %s, "%s": %s}}}`,
 		v1.NodeSchedulable, kubevirtSchedulable,
 		v1.CPUManager, cpuManagerEnabled,
 		v1.VirtHandlerHeartbeat, string(now),
+		v1.NodeMaintenanceBlockingVMIsAnnotation, blockingVMIs,
+		v1.NodePendingHostDeviceRemovalsAnnotation, pendingRemovals,
 	))
 	_, err = h.clientset.Nodes().Patch(context.Background(), h.host, types.StrategicMergePatchType, data, metav1.PatchOptions{})
 	if err != nil {
@@ -112,6 +146,28 @@ func (h *HeartBeat) do() {
 	log.DefaultLogger().V(4).Infof("Heartbeat sent")
 }
 
+func (h *HeartBeat) isInMaintenance() (bool, error) {
+	node, err := h.clientset.Nodes().Get(context.Background(), h.host, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	return node.Annotations[v1.NodeMaintenanceAnnotation] == "true", nil
+}
+
+// blockingVMIs returns the VMIs which still run on the node
+func (h *HeartBeat) blockingVMIs() []string {
+	var vmis []string
+	for _, obj := range h.vmiStore.List() {
+		vmi := obj.(*v1.VirtualMachineInstance)
+		if vmi.IsFinal() {
+			continue
+		}
+		vmis = append(vmis, fmt.Sprintf("%s/%s", vmi.Namespace, vmi.Name))
+	}
+	sort.Strings(vmis)
+	return vmis
+}
+
 func (h *HeartBeat) isCPUManagerEnabled(cpuManagerPaths []string) bool {
 	var cpuManagerOptions map[string]interface{}
 	cpuManagerPath, err := detectCPUManagerFile(cpuManagerPaths)
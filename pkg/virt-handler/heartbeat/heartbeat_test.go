@@ -11,6 +11,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
 
 	virtv1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/kubevirt/pkg/testutils"
@@ -27,6 +28,7 @@ var _ = Describe("Heartbeat", func() {
 
 	var node *v1.Node
 	var fakeClient *fake.Clientset
+	var vmiStore cache.Store
 
 	BeforeEach(func() {
 		node = &v1.Node{
@@ -35,10 +37,11 @@ var _ = Describe("Heartbeat", func() {
 			},
 		}
 		fakeClient = fake.NewSimpleClientset(node)
+		vmiStore = cache.NewStore(cache.DeletionHandlingMetaNamespaceKeyFunc)
 	})
 
 	table.DescribeTable("with cpumanager featuregate should set the node to", func(deviceController device_manager.DeviceControllerInterface, cpuManagerPaths []string, schedulable string, cpumanager string) {
-		heartbeat := NewHeartBeat(fakeClient.CoreV1(), deviceController, config(virtconfig.CPUManager), "mynode")
+		heartbeat := NewHeartBeat(fakeClient.CoreV1(), deviceController, config(virtconfig.CPUManager), vmiStore, nil, "mynode")
 		heartbeat.cpuManagerPaths = cpuManagerPaths
 		heartbeat.do()
 		node, err := fakeClient.CoreV1().Nodes().Get(context.Background(), "mynode", metav1.GetOptions{})
@@ -73,7 +76,7 @@ var _ = Describe("Heartbeat", func() {
 	)
 
 	table.DescribeTable("without cpumanager featuregate should set the node to", func(deviceController device_manager.DeviceControllerInterface, schedulable string) {
-		heartbeat := NewHeartBeat(fakeClient.CoreV1(), deviceController, config(), "mynode")
+		heartbeat := NewHeartBeat(fakeClient.CoreV1(), deviceController, config(), vmiStore, nil, "mynode")
 		heartbeat.do()
 		node, err := fakeClient.CoreV1().Nodes().Get(context.Background(), "mynode", metav1.GetOptions{})
 		Expect(err).ToNot(HaveOccurred())
@@ -91,7 +94,7 @@ var _ = Describe("Heartbeat", func() {
 	)
 
 	table.DescribeTable("without deviceplugin and", func(deviceController device_manager.DeviceControllerInterface, initiallySchedulable string, finallySchedulable string) {
-		heartbeat := NewHeartBeat(fakeClient.CoreV1(), deviceController, config(), "mynode")
+		heartbeat := NewHeartBeat(fakeClient.CoreV1(), deviceController, config(), vmiStore, nil, "mynode")
 		heartbeat.devicePluginWaitTimeout = 2 * time.Second
 		heartbeat.devicePluginPollIntervall = 10 * time.Millisecond
 		stopChan := make(chan struct{})
@@ -140,16 +143,104 @@ var _ = Describe("Heartbeat", func() {
 			"true",
 		),
 	)
+
+	It("should report the host device resources pending removal", func() {
+		heartbeat := NewHeartBeat(fakeClient.CoreV1(), &fakeDeviceController{initialized: true, pendingRemovals: []string{"example.org/gpu", "example.org/nic"}}, config(), vmiStore, nil, "mynode")
+		heartbeat.do()
+		node, err := fakeClient.CoreV1().Nodes().Get(context.Background(), "mynode", metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(node.Annotations).To(HaveKeyWithValue(virtv1.NodePendingHostDeviceRemovalsAnnotation, "example.org/gpu,example.org/nic"))
+
+		heartbeat.deviceManagerController = deviceController(true)
+		heartbeat.do()
+		node, err = fakeClient.CoreV1().Nodes().Get(context.Background(), "mynode", metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(node.Annotations).ToNot(HaveKey(virtv1.NodePendingHostDeviceRemovalsAnnotation))
+	})
+
+	It("should set the node to unschedulable while it is shutting down", func() {
+		heartbeat := NewHeartBeat(fakeClient.CoreV1(), deviceController(true), config(), vmiStore, fakeNodeShutdownStatus(true), "mynode")
+		heartbeat.do()
+		node, err := fakeClient.CoreV1().Nodes().Get(context.Background(), "mynode", metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(node.Labels).To(HaveKeyWithValue(virtv1.NodeSchedulable, "false"))
+	})
+
+	It("should set the node to unschedulable while its VMI capacity is exhausted", func() {
+		heartbeat := NewHeartBeat(fakeClient.CoreV1(), &fakeDeviceController{initialized: true, capacityExhausted: true}, config(), vmiStore, nil, "mynode")
+		heartbeat.do()
+		node, err := fakeClient.CoreV1().Nodes().Get(context.Background(), "mynode", metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(node.Labels).To(HaveKeyWithValue(virtv1.NodeSchedulable, "false"))
+	})
+
+	Context("in maintenance mode", func() {
+		newVMI := func(name string, phase virtv1.VirtualMachineInstancePhase) *virtv1.VirtualMachineInstance {
+			vmi := virtv1.NewMinimalVMIWithNS("default", name)
+			vmi.Status.Phase = phase
+			return vmi
+		}
+
+		BeforeEach(func() {
+			node.Annotations = map[string]string{virtv1.NodeMaintenanceAnnotation: "true"}
+			fakeClient = fake.NewSimpleClientset(node)
+			Expect(vmiStore.Add(newVMI("vmi-b", virtv1.Running))).To(Succeed())
+			Expect(vmiStore.Add(newVMI("vmi-a", virtv1.Running))).To(Succeed())
+			Expect(vmiStore.Add(newVMI("vmi-done", virtv1.Succeeded))).To(Succeed())
+		})
+
+		It("should set the node to unschedulable and report the VMIs blocking the drain", func() {
+			heartbeat := NewHeartBeat(fakeClient.CoreV1(), deviceController(true), config(), vmiStore, nil, "mynode")
+			heartbeat.do()
+			node, err := fakeClient.CoreV1().Nodes().Get(context.Background(), "mynode", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(node.Labels).To(HaveKeyWithValue(virtv1.NodeSchedulable, "false"))
+			Expect(node.Annotations).To(HaveKeyWithValue(virtv1.NodeMaintenanceBlockingVMIsAnnotation, "default/vmi-a,default/vmi-b"))
+		})
+
+		It("should make the node schedulable again and drop the report when leaving maintenance", func() {
+			heartbeat := NewHeartBeat(fakeClient.CoreV1(), deviceController(true), config(), vmiStore, nil, "mynode")
+			heartbeat.do()
+
+			node, err := fakeClient.CoreV1().Nodes().Get(context.Background(), "mynode", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			delete(node.Annotations, virtv1.NodeMaintenanceAnnotation)
+			_, err = fakeClient.CoreV1().Nodes().Update(context.Background(), node, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			heartbeat.do()
+			node, err = fakeClient.CoreV1().Nodes().Get(context.Background(), "mynode", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(node.Labels).To(HaveKeyWithValue(virtv1.NodeSchedulable, "true"))
+			Expect(node.Annotations).ToNot(HaveKey(virtv1.NodeMaintenanceBlockingVMIsAnnotation))
+		})
+	})
 })
 
 type fakeDeviceController struct {
-	initialized bool
+	initialized       bool
+	pendingRemovals   []string
+	capacityExhausted bool
 }
 
 func (f *fakeDeviceController) Initialized() bool {
 	return f.initialized
 }
 
+func (f *fakeDeviceController) PendingRemovals() []string {
+	return f.pendingRemovals
+}
+
+func (f *fakeDeviceController) VMICapacityExhausted() bool {
+	return f.capacityExhausted
+}
+
+type fakeNodeShutdownStatus bool
+
+func (f fakeNodeShutdownStatus) ShuttingDown() bool {
+	return bool(f)
+}
+
 func config(featuregates ...string) *virtconfig.ClusterConfig {
 	cfg := &virtv1.KubeVirtConfiguration{
 		DeveloperConfiguration: &virtv1.DeveloperConfiguration{
@@ -177,6 +268,14 @@ func (f *probeCountingDeviceController) Initialized() bool {
 	return f.probes[f.probed-1]
 }
 
+func (f *probeCountingDeviceController) PendingRemovals() []string {
+	return nil
+}
+
+func (f *probeCountingDeviceController) VMICapacityExhausted() bool {
+	return false
+}
+
 func newProbeCountingDeviceController(probes ...probe) device_manager.DeviceControllerInterface {
 	var probeArray []bool
 	for _, p := range probes {
@@ -576,6 +576,12 @@ func (m *volumeMounter) mountFileSystemHotplugVolume(vmi *v1.VirtualMachineInsta
 		if err := m.writePathToMountRecord(targetDisk, vmi, record); err != nil {
 			return err
 		}
+		if isMemoryDumpVolume(vmi, volume) {
+			// The memory state is written by libvirt, provide the file to bind mount on the empty PVC
+			if err := createMemoryDumpFile(filepath.Join(sourcePath, "disk.img")); err != nil {
+				return fmt.Errorf("failed to create memory dump file for %v: %v", volume, err)
+			}
+		}
 		if out, err := mountCommand(filepath.Join(sourcePath, "disk.img"), targetDisk); err != nil {
 			return fmt.Errorf("failed to bindmount hotplug-disk %v: %v : %v", volume, string(out), err)
 		}
@@ -585,6 +591,26 @@ func (m *volumeMounter) mountFileSystemHotplugVolume(vmi *v1.VirtualMachineInsta
 	return nil
 }
 
+func isMemoryDumpVolume(vmi *v1.VirtualMachineInstance, volumeName string) bool {
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.Name == volumeName {
+			return volume.MemoryDump != nil
+		}
+	}
+	return false
+}
+
+func createMemoryDumpFile(path string) error {
+	if _, err := os.Stat(path); err == nil || !os.IsNotExist(err) {
+		return err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	return file.Close()
+}
+
 func (m *volumeMounter) findVirtlauncherUID(vmi *v1.VirtualMachineInstance) (uid types.UID) {
 	cnt := 0
 	for podUID := range vmi.Status.ActivePods {
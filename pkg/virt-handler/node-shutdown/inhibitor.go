@@ -0,0 +1,117 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package nodeshutdown
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	logindService   = "org.freedesktop.login1"
+	logindObject    = dbus.ObjectPath("/org/freedesktop/login1")
+	logindInterface = "org.freedesktop.login1.Manager"
+)
+
+// Inhibitor delays the shutdown of the node
+type Inhibitor interface {
+	// Inhibit takes the inhibitor lock, the shutdown of the node is delayed until it is released
+	Inhibit() error
+	// Release releases the inhibitor lock if it is taken
+	Release() error
+	// WatchShutdown returns a channel which reports true when the shutdown of the node is prepared,
+	// and false when a prepared shutdown got cancelled
+	WatchShutdown() (<-chan bool, error)
+}
+
+// logindInhibitor takes a delay inhibitor lock for shutdowns from systemd-logind over the system D-Bus
+type logindInhibitor struct {
+	lock sync.Mutex
+	conn *dbus.Conn
+	fd   *os.File
+}
+
+func NewLogindInhibitor() (Inhibitor, error) {
+	conn, err := dbus.SystemBus()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the system bus: %v", err)
+	}
+	return &logindInhibitor{conn: conn}, nil
+}
+
+func (l *logindInhibitor) Inhibit() error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.fd != nil {
+		return nil
+	}
+	var fd dbus.UnixFD
+	err := l.conn.Object(logindService, logindObject).Call(logindInterface+".Inhibit", 0,
+		"shutdown", "kubevirt", "Migrating or shutting down the VMIs of the node", "delay").Store(&fd)
+	if err != nil {
+		return fmt.Errorf("failed to take the shutdown inhibitor lock: %v", err)
+	}
+	l.fd = os.NewFile(uintptr(fd), "kubevirt-shutdown-inhibitor")
+	return nil
+}
+
+func (l *logindInhibitor) Release() error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.fd == nil {
+		return nil
+	}
+	err := l.fd.Close()
+	l.fd = nil
+	return err
+}
+
+func (l *logindInhibitor) WatchShutdown() (<-chan bool, error) {
+	err := l.conn.AddMatchSignal(
+		dbus.WithMatchObjectPath(logindObject),
+		dbus.WithMatchInterface(logindInterface),
+		dbus.WithMatchMember("PrepareForShutdown"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch for the shutdown of the node: %v", err)
+	}
+
+	signals := make(chan *dbus.Signal, 1)
+	l.conn.Signal(signals)
+
+	shutdown := make(chan bool, 1)
+	go func() {
+		for signal := range signals {
+			if signal.Name != logindInterface+".PrepareForShutdown" || len(signal.Body) != 1 {
+				continue
+			}
+			if active, ok := signal.Body[0].(bool); ok {
+				shutdown <- active
+			}
+		}
+		close(shutdown)
+	}()
+	return shutdown, nil
+}
@@ -0,0 +1,289 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package nodeshutdown
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/controller"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
+)
+
+const defaultPollInterval = 2 * time.Second
+
+// shutdownTaintValue marks the node drain taint virt-handler added for a shutdown,
+// so that it is only removed again if virt-handler added it
+const shutdownTaintValue = "NodeShutdown"
+
+// NodeShutdownStatus reports whether the node is shutting down
+type NodeShutdownStatus interface {
+	ShuttingDown() bool
+}
+
+// NodeShutdownManager delays the shutdown of the node with an inhibitor lock, to live migrate the VMIs
+// of the node or to shut them down gracefully within the configured grace period. The node is tainted
+// with the node drain taint, the evacuation controller migrates the VMIs away.
+type NodeShutdownManager struct {
+	inhibitor     Inhibitor
+	clientset     kubecli.KubevirtClient
+	clusterConfig *virtconfig.ClusterConfig
+	vmiStore      cache.Store
+	host          string
+	pollInterval  time.Duration
+	shuttingDown  int32
+	shutdownVMI   func(vmi *v1.VirtualMachineInstance) error
+}
+
+func NewNodeShutdownManager(inhibitor Inhibitor, clientset kubecli.KubevirtClient, clusterConfig *virtconfig.ClusterConfig, vmiStore cache.Store, host string) *NodeShutdownManager {
+	return &NodeShutdownManager{
+		inhibitor:     inhibitor,
+		clientset:     clientset,
+		clusterConfig: clusterConfig,
+		vmiStore:      vmiStore,
+		host:          host,
+		pollInterval:  defaultPollInterval,
+		shutdownVMI:   shutdownVMIThroughLauncher,
+	}
+}
+
+func (m *NodeShutdownManager) ShuttingDown() bool {
+	return atomic.LoadInt32(&m.shuttingDown) == 1
+}
+
+func (m *NodeShutdownManager) Run(stop <-chan struct{}) {
+	// the taint of a previous shutdown survives the reboot of the node
+	m.untaintNode()
+
+	shutdown, err := m.inhibitor.WatchShutdown()
+	if err != nil {
+		log.Log.Reason(err).Error("Not protecting VMIs from the shutdown of the node")
+		return
+	}
+	if err := m.inhibitor.Inhibit(); err != nil {
+		log.Log.Reason(err).Error("Not protecting VMIs from the shutdown of the node")
+		return
+	}
+	defer m.release()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case active, ok := <-shutdown:
+			if !ok {
+				return
+			}
+			if active {
+				m.handleShutdown(stop)
+				continue
+			}
+			// The shutdown got cancelled, protect the VMIs from the next one
+			log.Log.Infof("Shutdown of node %s was cancelled", m.host)
+			atomic.StoreInt32(&m.shuttingDown, 0)
+			m.untaintNode()
+			if err := m.inhibitor.Inhibit(); err != nil {
+				log.Log.Reason(err).Error("Not protecting VMIs from the shutdown of the node")
+			}
+		}
+	}
+}
+
+// handleShutdown evacuates the VMIs of the node and releases the inhibitor lock once all of them are gone,
+// or once the grace period expired
+func (m *NodeShutdownManager) handleShutdown(stop <-chan struct{}) {
+	atomic.StoreInt32(&m.shuttingDown, 1)
+	defer m.release()
+	m.markNodeAsUnschedulable()
+
+	gracePeriod := m.clusterConfig.GetNodeShutdownGracePeriod()
+	if gracePeriod == 0 {
+		log.Log.Infof("Node %s is shutting down, no grace period is configured for its VMIs", m.host)
+		return
+	}
+	log.Log.Infof("Node %s is shutting down, evacuating its VMIs within %s", m.host, gracePeriod)
+	m.taintNode()
+
+	deadline := time.After(gracePeriod)
+	ticker := time.NewTicker(m.pollInterval)
+	defer ticker.Stop()
+	shutDown := map[types.UID]bool{}
+	for {
+		if m.shutdownVMIs(shutDown) == 0 {
+			log.Log.Infof("All VMIs left node %s", m.host)
+			return
+		}
+		select {
+		case <-stop:
+			return
+		case <-deadline:
+			log.Log.Warningf("Grace period for the VMIs of node %s expired, continuing with the shutdown", m.host)
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// shutdownVMIs shuts down the VMIs which are not migrated away by the evacuation controller.
+// They are only shut down once per VMI, shutDown keeps track of them. It returns the number of VMIs
+// which still run on the node.
+func (m *NodeShutdownManager) shutdownVMIs(shutDown map[types.UID]bool) int {
+	running := 0
+	for _, obj := range m.vmiStore.List() {
+		vmi := obj.(*v1.VirtualMachineInstance)
+		if vmi.IsFinal() || vmi.Status.NodeName != m.host {
+			continue
+		}
+		running++
+		if vmi.DeletionTimestamp != nil || wantsToMigrate(vmi) || shutDown[vmi.UID] {
+			continue
+		}
+
+		if err := m.shutdownVMI(vmi); err != nil {
+			log.Log.Object(vmi).Reason(err).Error("Failed to shut down the VMI on the shutting down node")
+			continue
+		}
+		shutDown[vmi.UID] = true
+	}
+	return running
+}
+
+func shutdownVMIThroughLauncher(vmi *v1.VirtualMachineInstance) error {
+	socketFile, err := cmdclient.FindSocketOnHost(vmi)
+	if err != nil {
+		return err
+	}
+	client, err := cmdclient.NewClient(socketFile)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	return client.ShutdownVirtualMachine(vmi)
+}
+
+// markNodeAsUnschedulable keeps new VMIs away right away, the heartbeat keeps the node unschedulable
+// while it is shutting down
+func (m *NodeShutdownManager) markNodeAsUnschedulable() {
+	data := []byte(fmt.Sprintf(`{"metadata": { "labels": {"%s": "false"}}}`, v1.NodeSchedulable))
+	_, err := m.clientset.CoreV1().Nodes().Patch(context.Background(), m.host, types.StrategicMergePatchType, data, metav1.PatchOptions{})
+	if err != nil {
+		log.Log.Reason(err).Errorf("Failed to mark node %s as unschedulable", m.host)
+	}
+}
+
+func (m *NodeShutdownManager) shutdownTaint() *k8sv1.Taint {
+	return &k8sv1.Taint{
+		Key:    *m.clusterConfig.GetMigrationConfiguration().NodeDrainTaintKey,
+		Value:  shutdownTaintValue,
+		Effect: k8sv1.TaintEffectNoSchedule,
+	}
+}
+
+// taintNode adds the node drain taint, unless the node is already drained
+func (m *NodeShutdownManager) taintNode() {
+	node, err := m.clientset.CoreV1().Nodes().Get(context.Background(), m.host, metav1.GetOptions{})
+	if err != nil {
+		log.Log.Reason(err).Errorf("Failed to taint node %s for the evacuation of its VMIs", m.host)
+		return
+	}
+
+	taint := m.shutdownTaint()
+	for _, t := range node.Spec.Taints {
+		if t.MatchTaint(taint) {
+			return
+		}
+	}
+
+	taints := append(append([]k8sv1.Taint{}, node.Spec.Taints...), *taint)
+	if err := m.patchTaints(node, taints); err != nil {
+		log.Log.Reason(err).Errorf("Failed to taint node %s for the evacuation of its VMIs", m.host)
+	}
+}
+
+// untaintNode removes the node drain taint again if it was added for a shutdown
+func (m *NodeShutdownManager) untaintNode() {
+	node, err := m.clientset.CoreV1().Nodes().Get(context.Background(), m.host, metav1.GetOptions{})
+	if err != nil {
+		log.Log.Reason(err).Errorf("Failed to remove the shutdown taint of node %s", m.host)
+		return
+	}
+
+	taint := m.shutdownTaint()
+	taints := []k8sv1.Taint{}
+	for _, t := range node.Spec.Taints {
+		if !t.MatchTaint(taint) || t.Value != shutdownTaintValue {
+			taints = append(taints, t)
+		}
+	}
+	if len(taints) == len(node.Spec.Taints) {
+		return
+	}
+
+	if err := m.patchTaints(node, taints); err != nil {
+		log.Log.Reason(err).Errorf("Failed to remove the shutdown taint of node %s", m.host)
+	}
+}
+
+func (m *NodeShutdownManager) patchTaints(node *k8sv1.Node, taints []k8sv1.Taint) error {
+	oldTaintsJson, err := json.Marshal(node.Spec.Taints)
+	if err != nil {
+		return err
+	}
+	newTaintsJson, err := json.Marshal(taints)
+	if err != nil {
+		return err
+	}
+
+	var ops []string
+	if len(node.Spec.Taints) > 0 {
+		ops = append(ops,
+			fmt.Sprintf(`{ "op": "test", "path": "/spec/taints", "value": %s}`, string(oldTaintsJson)),
+			fmt.Sprintf(`{ "op": "replace", "path": "/spec/taints", "value": %s}`, string(newTaintsJson)),
+		)
+	} else {
+		ops = append(ops, fmt.Sprintf(`{ "op": "add", "path": "/spec/taints", "value": %s}`, string(newTaintsJson)))
+	}
+
+	_, err = m.clientset.CoreV1().Nodes().Patch(context.Background(), m.host, types.JSONPatchType, controller.GeneratePatchBytes(ops), metav1.PatchOptions{})
+	return err
+}
+
+func (m *NodeShutdownManager) release() {
+	if err := m.inhibitor.Release(); err != nil {
+		log.Log.Reason(err).Error("Failed to release the shutdown inhibitor lock")
+	}
+}
+
+func wantsToMigrate(vmi *v1.VirtualMachineInstance) bool {
+	return vmi.Spec.EvictionStrategy != nil && *vmi.Spec.EvictionStrategy == v1.EvictionStrategyLiveMigrate &&
+		controller.NewVirtualMachineInstanceConditionManager().HasConditionWithStatus(vmi, v1.VirtualMachineInstanceIsMigratable, k8sv1.ConditionTrue)
+}
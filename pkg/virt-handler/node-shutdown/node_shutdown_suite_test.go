@@ -0,0 +1,11 @@
+package nodeshutdown_test
+
+import (
+	"testing"
+
+	"kubevirt.io/client-go/testutils"
+)
+
+func TestNodeShutdown(t *testing.T) {
+	testutils.KubeVirtTestSuiteSetup(t)
+}
@@ -0,0 +1,243 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package nodeshutdown
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/pointer"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/testutils"
+)
+
+type fakeInhibitor struct {
+	inhibited bool
+	inhibits  int
+	shutdown  chan bool
+}
+
+func (f *fakeInhibitor) Inhibit() error {
+	f.inhibited = true
+	f.inhibits++
+	return nil
+}
+
+func (f *fakeInhibitor) Release() error {
+	f.inhibited = false
+	return nil
+}
+
+func (f *fakeInhibitor) WatchShutdown() (<-chan bool, error) {
+	return f.shutdown, nil
+}
+
+var _ = Describe("Node shutdown", func() {
+	const host = "mynode"
+
+	var ctrl *gomock.Controller
+	var virtClient *kubecli.MockKubevirtClient
+	var kubeClient *fake.Clientset
+	var vmiStore cache.Store
+	var inhibitor *fakeInhibitor
+	var shutDown []string
+
+	getTaints := func() []k8sv1.Taint {
+		node, err := kubeClient.CoreV1().Nodes().Get(context.Background(), host, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		return node.Spec.Taints
+	}
+
+	newManager := func(gracePeriod *int64) *NodeShutdownManager {
+		clusterConfig, _, _, _ := testutils.NewFakeClusterConfigUsingKV(&v1.KubeVirt{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "kubevirt",
+				Namespace: "kubevirt",
+			},
+			Spec: v1.KubeVirtSpec{
+				Configuration: v1.KubeVirtConfiguration{
+					NodeShutdownGracePeriodSeconds: gracePeriod,
+				},
+			},
+			Status: v1.KubeVirtStatus{
+				Phase: v1.KubeVirtPhaseDeploying,
+			},
+		})
+		manager := NewNodeShutdownManager(inhibitor, virtClient, clusterConfig, vmiStore, host)
+		manager.pollInterval = 10 * time.Millisecond
+		manager.shutdownVMI = func(vmi *v1.VirtualMachineInstance) error {
+			shutDown = append(shutDown, vmi.Name)
+			return nil
+		}
+		return manager
+	}
+
+	newVMI := func(name string, migratable bool) *v1.VirtualMachineInstance {
+		vmi := v1.NewMinimalVMIWithNS("default", name)
+		vmi.UID = types.UID(name)
+		vmi.Status.NodeName = host
+		vmi.Status.Phase = v1.Running
+		if migratable {
+			evictionStrategy := v1.EvictionStrategyLiveMigrate
+			vmi.Spec.EvictionStrategy = &evictionStrategy
+			vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+				{Type: v1.VirtualMachineInstanceIsMigratable, Status: k8sv1.ConditionTrue},
+			}
+		}
+		return vmi
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		virtClient = kubecli.NewMockKubevirtClient(ctrl)
+		kubeClient = fake.NewSimpleClientset(&k8sv1.Node{ObjectMeta: metav1.ObjectMeta{Name: host}})
+		virtClient.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+		vmiStore = cache.NewStore(cache.DeletionHandlingMetaNamespaceKeyFunc)
+		inhibitor = &fakeInhibitor{shutdown: make(chan bool)}
+		shutDown = nil
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("should release the lock right away without a grace period", func() {
+		Expect(vmiStore.Add(newVMI("testvmi", false))).To(Succeed())
+		manager := newManager(nil)
+		inhibitor.inhibited = true
+
+		manager.handleShutdown(make(chan struct{}))
+		Expect(inhibitor.inhibited).To(BeFalse())
+		Expect(manager.ShuttingDown()).To(BeTrue())
+
+		node, err := kubeClient.CoreV1().Nodes().Get(context.Background(), host, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(node.Labels).To(HaveKeyWithValue(v1.NodeSchedulable, "false"))
+		Expect(node.Spec.Taints).To(BeEmpty())
+	})
+
+	It("should taint the node for the evacuation, shut down the other VMIs once and release the lock once they are gone", func() {
+		migratable := newVMI("migratable", true)
+		other := newVMI("other", false)
+		Expect(vmiStore.Add(migratable)).To(Succeed())
+		Expect(vmiStore.Add(other)).To(Succeed())
+		manager := newManager(pointer.Int64Ptr(60))
+		inhibitor.inhibited = true
+
+		done := make(chan struct{})
+		go func() {
+			defer GinkgoRecover()
+			manager.handleShutdown(make(chan struct{}))
+			close(done)
+		}()
+
+		Consistently(done, 100*time.Millisecond).ShouldNot(BeClosed())
+		Expect(shutDown).To(Equal([]string{"other"}))
+		Expect(getTaints()).To(ConsistOf(k8sv1.Taint{
+			Key:    "kubevirt.io/drain",
+			Value:  shutdownTaintValue,
+			Effect: k8sv1.TaintEffectNoSchedule,
+		}))
+
+		Expect(vmiStore.Delete(other)).To(Succeed())
+		Expect(vmiStore.Delete(migratable)).To(Succeed())
+		Eventually(done).Should(BeClosed())
+		Expect(inhibitor.inhibited).To(BeFalse())
+	})
+
+	It("should not taint the node again if it is already drained", func() {
+		drainTaint := k8sv1.Taint{Key: "kubevirt.io/drain", Effect: k8sv1.TaintEffectNoSchedule}
+		kubeClient = fake.NewSimpleClientset(&k8sv1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: host},
+			Spec:       k8sv1.NodeSpec{Taints: []k8sv1.Taint{drainTaint}},
+		})
+		virtClient = kubecli.NewMockKubevirtClient(ctrl)
+		virtClient.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+		manager := newManager(pointer.Int64Ptr(60))
+
+		manager.taintNode()
+		Expect(getTaints()).To(ConsistOf(drainTaint))
+		manager.untaintNode()
+		Expect(getTaints()).To(ConsistOf(drainTaint))
+	})
+
+	It("should release the lock once the grace period expired", func() {
+		vmi := newVMI("testvmi", false)
+		vmi.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		Expect(vmiStore.Add(vmi)).To(Succeed())
+		manager := newManager(pointer.Int64Ptr(1))
+		inhibitor.inhibited = true
+
+		manager.handleShutdown(make(chan struct{}))
+		Expect(inhibitor.inhibited).To(BeFalse())
+	})
+
+	It("should take the lock again if the shutdown got cancelled", func() {
+		manager := newManager(nil)
+		stop := make(chan struct{})
+		done := make(chan struct{})
+		go func() {
+			manager.Run(stop)
+			close(done)
+		}()
+
+		inhibitor.shutdown <- true
+		inhibitor.shutdown <- false
+		inhibitor.shutdown <- true
+		Eventually(manager.ShuttingDown).Should(BeTrue())
+		close(stop)
+		Eventually(done).Should(BeClosed())
+		Expect(inhibitor.inhibits).To(Equal(2))
+		Expect(inhibitor.inhibited).To(BeFalse())
+	})
+
+	It("should remove the shutdown taint if the shutdown got cancelled", func() {
+		manager := newManager(pointer.Int64Ptr(60))
+		manager.taintNode()
+		Expect(getTaints()).To(HaveLen(1))
+
+		stop := make(chan struct{})
+		done := make(chan struct{})
+		go func() {
+			manager.Run(stop)
+			close(done)
+		}()
+
+		// the taint of the last shutdown is removed after the reboot
+		Eventually(getTaints).Should(BeEmpty())
+
+		inhibitor.shutdown <- true
+		Eventually(getTaints).Should(HaveLen(1))
+		inhibitor.shutdown <- false
+		Eventually(getTaints).Should(BeEmpty())
+		close(stop)
+		Eventually(done).Should(BeClosed())
+	})
+})
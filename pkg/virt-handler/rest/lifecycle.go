@@ -19,12 +19,17 @@
 package rest
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/emicklei/go-restful"
 
 	"k8s.io/client-go/tools/cache"
 
+	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/log"
 	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
 )
@@ -153,6 +158,126 @@ func (lh *LifecycleHandler) UnfreezeHandler(request *restful.Request, response *
 	response.WriteHeader(http.StatusAccepted)
 }
 
+func (lh *LifecycleHandler) MemorySnapshotHandler(request *restful.Request, response *restful.Response) {
+	vmi, code, err := getVMI(request, lh.vmiInformer)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to retrieve VMI")
+		response.WriteError(code, err)
+		return
+	}
+
+	sockFile, err := cmdclient.FindSocketOnHost(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to detect cmd client")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+	client, err := cmdclient.NewClient(sockFile)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to connect cmd client")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	options := &v1.MemorySnapshotOptions{}
+	if request.Request.Body != nil {
+		defer request.Request.Body.Close()
+		if err := json.NewDecoder(request.Request.Body).Decode(options); err != nil && err != io.EOF {
+			log.Log.Object(vmi).Reason(err).Error("Failed to unmarshal memory snapshot options")
+			response.WriteError(http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	// the credentials are only passed on when the upload has to be started
+	var credentials *cmdclient.ObjectStorageCredentials
+	if options.ObjectStorage != nil {
+		credentials = objectStorageCredentials(options.ObjectStorage)
+	}
+	status, err := client.UploadVirtualMachineMemory(vmi, options, credentials)
+	if errors.Is(err, cmdclient.ErrMemoryUploadCredentialsRequired) {
+		status, err = &v1.MemorySnapshotUploadStatus{Phase: v1.MemorySnapshotUploadPending}, nil
+	}
+	if cmdclient.IsCmdNotSupported(err) && options.ObjectStorage == nil {
+		// older virt-launchers only save the memory to the memory dump volume while the request waits
+		err = client.SnapshotVirtualMachineMemory(vmi)
+		status = &v1.MemorySnapshotUploadStatus{Phase: v1.MemorySnapshotUploadSucceeded, MemorySaved: true}
+	}
+	if cmdclient.IsCmdNotSupported(err) {
+		log.Log.Object(vmi).Reason(err).Error("virt-launcher is too old to snapshot VMI memory")
+		response.WriteError(http.StatusConflict, err)
+		return
+	} else if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to snapshot VMI memory")
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+	response.WriteEntity(status)
+}
+
+// objectStorageCredentials takes the credentials out of the object storage options, it returns nil
+// if none were passed
+func objectStorageCredentials(storage *v1.MemorySnapshotObjectStorage) *cmdclient.ObjectStorageCredentials {
+	if storage.AccessKeyID == "" && storage.SecretAccessKey == "" {
+		return nil
+	}
+
+	credentials := &cmdclient.ObjectStorageCredentials{
+		AccessKeyID:     storage.AccessKeyID,
+		SecretAccessKey: storage.SecretAccessKey,
+	}
+	storage.AccessKeyID = ""
+	storage.SecretAccessKey = ""
+	return credentials
+}
+
+func (lh *LifecycleHandler) SetLinkStateHandler(request *restful.Request, response *restful.Response) {
+	vmi, code, err := getVMI(request, lh.vmiInformer)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to retrieve VMI")
+		response.WriteError(code, err)
+		return
+	}
+
+	options := &v1.SetLinkStateOptions{}
+	if request.Request.Body == nil {
+		response.WriteError(http.StatusBadRequest, fmt.Errorf("link state options are required"))
+		return
+	}
+	defer request.Request.Body.Close()
+	if err := json.NewDecoder(request.Request.Body).Decode(options); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to unmarshal link state options")
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+
+	sockFile, err := cmdclient.FindSocketOnHost(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to detect cmd client")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+	client, err := cmdclient.NewClient(sockFile)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to connect cmd client")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	err = client.SetInterfaceLinkState(vmi, options)
+	if cmdclient.IsCmdNotSupported(err) {
+		log.Log.Object(vmi).Reason(err).Error("virt-launcher is too old to set the link state of VMI interfaces")
+		response.WriteError(http.StatusConflict, err)
+		return
+	} else if err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to set the link state of interface %s", options.InterfaceName)
+		response.WriteError(http.StatusBadRequest, err)
+		return
+	}
+
+	response.WriteHeader(http.StatusAccepted)
+}
+
 func (lh *LifecycleHandler) GetGuestInfo(request *restful.Request, response *restful.Response) {
 	log.Log.Info("Retreiving guestinfo")
 	vmi, code, err := getVMI(request, lh.vmiInformer)
@@ -244,3 +369,73 @@ func (lh *LifecycleHandler) GetFilesystems(request *restful.Request, response *r
 
 	response.WriteEntity(fsList)
 }
+
+func (lh *LifecycleHandler) GetUsage(request *restful.Request, response *restful.Response) {
+	vmi, code, err := getVMI(request, lh.vmiInformer)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to retrieve VMI")
+		response.WriteError(code, err)
+		return
+	}
+
+	sockFile, err := cmdclient.FindSocketOnHost(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to detect cmd client")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+	client, err := cmdclient.NewClient(sockFile)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to connect cmd client")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	usage, err := getUsage(client, usageWindow)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to get usage")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	response.WriteEntity(usage)
+}
+
+func (lh *LifecycleHandler) GetScreenshot(request *restful.Request, response *restful.Response) {
+	vmi, code, err := getVMI(request, lh.vmiInformer)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to retrieve VMI")
+		response.WriteError(code, err)
+		return
+	}
+
+	sockFile, err := cmdclient.FindSocketOnHost(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to detect cmd client")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+	client, err := cmdclient.NewClient(sockFile)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to connect cmd client")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	screenshot, err := client.Screenshot(vmi)
+	if cmdclient.IsCmdNotSupported(err) {
+		log.Log.Object(vmi).Reason(err).Error("virt-launcher is too old to take a screenshot of the VMI")
+		response.WriteError(http.StatusConflict, err)
+		return
+	} else if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to take a screenshot of the VMI")
+		response.WriteError(http.StatusInternalServerError, err)
+		return
+	}
+
+	response.AddHeader("Content-Type", "image/png")
+	response.WriteHeader(http.StatusOK)
+	if _, err := response.Write(screenshot); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to write the screenshot")
+	}
+}
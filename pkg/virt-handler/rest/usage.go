@@ -0,0 +1,90 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"fmt"
+	"time"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/stats"
+)
+
+// usageWindow is the interval between the two domain stats samples the CPU usage is calculated from
+const usageWindow = time.Second
+
+func getUsage(client cmdclient.LauncherClient, window time.Duration) (*v1.VirtualMachineInstanceUsage, error) {
+	first, err := getDomainStats(client)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	time.Sleep(window)
+	second, err := getDomainStats(client)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+
+	return &v1.VirtualMachineInstanceUsage{
+		Timestamp: metav1.NewTime(now),
+		Window:    metav1.Duration{Duration: now.Sub(start)},
+		Usage:     calculateUsage(first, second, now.Sub(start)),
+	}, nil
+}
+
+func getDomainStats(client cmdclient.LauncherClient) (*stats.DomainStats, error) {
+	domainStats, exists, err := client.GetDomainStats()
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("no domain stats available")
+	}
+	return domainStats, nil
+}
+
+// calculateUsage calculates the CPU usage from the CPU time the domain spent between two samples, and the
+// memory usage from the memory the guest reports as used. If the guest doesn't report its memory, e.g. because
+// the balloon driver is not installed, the resident set size of the domain is used instead.
+func calculateUsage(first, second *stats.DomainStats, window time.Duration) k8sv1.ResourceList {
+	usage := k8sv1.ResourceList{}
+
+	if window > 0 && first.Cpu != nil && second.Cpu != nil && first.Cpu.TimeSet && second.Cpu.TimeSet &&
+		second.Cpu.Time >= first.Cpu.Time {
+		nanoCores := float64(second.Cpu.Time-first.Cpu.Time) / window.Seconds()
+		usage[k8sv1.ResourceCPU] = *resource.NewScaledQuantity(int64(nanoCores), resource.Nano)
+	}
+
+	if mem := second.Memory; mem != nil {
+		if mem.AvailableSet && mem.UnusedSet && mem.Available >= mem.Unused {
+			usage[k8sv1.ResourceMemory] = *resource.NewQuantity(int64(mem.Available-mem.Unused)*1024, resource.BinarySI)
+		} else if mem.RSSSet {
+			usage[k8sv1.ResourceMemory] = *resource.NewQuantity(int64(mem.RSS)*1024, resource.BinarySI)
+		}
+	}
+
+	return usage
+}
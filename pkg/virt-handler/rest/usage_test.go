@@ -0,0 +1,94 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package rest
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/stats"
+)
+
+var _ = Describe("VMI usage", func() {
+
+	newStats := func(cpuTime uint64, mem *stats.DomainStatsMemory) *stats.DomainStats {
+		return &stats.DomainStats{
+			Cpu:    &stats.DomainStatsCPU{TimeSet: true, Time: cpuTime},
+			Memory: mem,
+		}
+	}
+
+	table.DescribeTable("should calculate", func(first, second *stats.DomainStats, expectedCPU, expectedMemory string) {
+		usage := calculateUsage(first, second, 2*time.Second)
+		if expectedCPU == "" {
+			Expect(usage).ToNot(HaveKey(k8sv1.ResourceCPU))
+		} else {
+			Expect(usage.Cpu().Equal(resource.MustParse(expectedCPU))).To(BeTrue(), usage.Cpu().String())
+		}
+		if expectedMemory == "" {
+			Expect(usage).ToNot(HaveKey(k8sv1.ResourceMemory))
+		} else {
+			Expect(usage.Memory().Equal(resource.MustParse(expectedMemory))).To(BeTrue(), usage.Memory().String())
+		}
+	},
+		table.Entry("the CPU usage from the CPU time spent in the window",
+			newStats(1000000000, nil), newStats(2500000000, nil), "750m", ""),
+		table.Entry("no CPU usage if the CPU time is not reported",
+			&stats.DomainStats{}, newStats(2500000000, nil), "", ""),
+		table.Entry("the memory usage from the memory the guest reports as used",
+			newStats(0, nil), newStats(0, &stats.DomainStatsMemory{AvailableSet: true, Available: 2097152, UnusedSet: true, Unused: 1048576, RSSSet: true, RSS: 1572864}), "0", "1Gi"),
+		table.Entry("the memory usage from the resident set size without guest memory stats",
+			newStats(0, nil), newStats(0, &stats.DomainStatsMemory{RSSSet: true, RSS: 1572864}), "0", "1536Mi"),
+	)
+
+	It("should sample the domain stats twice", func() {
+		ctrl := gomock.NewController(GinkgoT())
+		defer ctrl.Finish()
+		client := cmdclient.NewMockLauncherClient(ctrl)
+		gomock.InOrder(
+			client.EXPECT().GetDomainStats().Return(newStats(0, nil), true, nil),
+			client.EXPECT().GetDomainStats().Return(newStats(10000000, nil), true, nil),
+		)
+
+		usage, err := getUsage(client, 10*time.Millisecond)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(usage.Window.Duration).To(BeNumerically(">=", 10*time.Millisecond))
+		Expect(usage.Usage).To(HaveKey(k8sv1.ResourceCPU))
+		Expect(usage.Usage.Cpu().Cmp(resource.MustParse("1"))).To(BeNumerically("<=", 0))
+	})
+
+	It("should fail if the domain stats are not available", func() {
+		ctrl := gomock.NewController(GinkgoT())
+		defer ctrl.Finish()
+		client := cmdclient.NewMockLauncherClient(ctrl)
+		client.EXPECT().GetDomainStats().Return(nil, false, fmt.Errorf("no connection"))
+
+		_, err := getUsage(client, 10*time.Millisecond)
+		Expect(err).To(HaveOccurred())
+	})
+})
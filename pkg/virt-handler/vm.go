@@ -31,6 +31,7 @@ import (
 	"reflect"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -68,13 +69,18 @@ import (
 	hostdisk "kubevirt.io/kubevirt/pkg/host-disk"
 	neterrors "kubevirt.io/kubevirt/pkg/network/errors"
 	virtutil "kubevirt.io/kubevirt/pkg/util"
+	"kubevirt.io/kubevirt/pkg/util/conditions"
 	pvctypes "kubevirt.io/kubevirt/pkg/util/types"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
+	"kubevirt.io/kubevirt/pkg/virt-controller/services"
 	virtcache "kubevirt.io/kubevirt/pkg/virt-handler/cache"
 	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
 	"kubevirt.io/kubevirt/pkg/virt-handler/isolation"
 	migrationproxy "kubevirt.io/kubevirt/pkg/virt-handler/migration-proxy"
+	nodeshutdown "kubevirt.io/kubevirt/pkg/virt-handler/node-shutdown"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/device/hostdevice/generic"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/device/hostdevice/gpu"
 	"kubevirt.io/kubevirt/pkg/watchdog"
 )
 
@@ -135,6 +141,17 @@ var PasswordRelatedGuestAgentCommands = []string{
 	"guest-set-user-password",
 }
 
+// guestAgentFeatureCommands maps the features relying on the guest agent to the commands they require
+var guestAgentFeatureCommands = []struct {
+	feature  v1.GuestAgentFeature
+	commands []string
+}{
+	{v1.GuestAgentFeatureExec, []string{"guest-exec", "guest-exec-status"}},
+	{v1.GuestAgentFeatureFSFreeze, []string{"guest-fsfreeze-freeze", "guest-fsfreeze-thaw", "guest-fsfreeze-status"}},
+	{v1.GuestAgentFeatureSSHPublicKeys, SSHRelatedGuestAgentCommands},
+	{v1.GuestAgentFeatureUserPassword, PasswordRelatedGuestAgentCommands},
+}
+
 func NewController(
 	recorder record.EventRecorder,
 	clientset kubecli.KubevirtClient,
@@ -152,6 +169,7 @@ func NewController(
 	podIsolationDetector isolation.PodIsolationDetector,
 	migrationProxy migrationproxy.ProxyManager,
 	capabilities *nodelabellerapi.Capabilities,
+	nodeShutdown nodeshutdown.NodeShutdownStatus,
 ) *VirtualMachineController {
 
 	queue := workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "virt-handler-vm")
@@ -206,6 +224,7 @@ func NewController(
 
 	c.launcherClients = virtcache.LauncherClientInfoByVMI{}
 	c.phase1NetworkSetupCache = virtcache.LauncherPIDByVMI{}
+	c.expiredMirrorsCache = virtcache.LauncherPIDByVMI{}
 	c.podInterfaceCache = virtcache.PodInterfaceByVMIAndName{}
 
 	c.domainNotifyPipes = make(map[string]string)
@@ -222,8 +241,8 @@ func NewController(
 		permissions = "rwm"
 	}
 
-	c.deviceManagerController = device_manager.NewDeviceController(c.host, maxDevices, permissions, clusterConfig)
-	c.heartBeat = heartbeat.NewHeartBeat(clientset.CoreV1(), c.deviceManagerController, clusterConfig, host)
+	c.deviceManagerController = device_manager.NewDeviceController(c.host, maxDevices, permissions, clusterConfig, vmiSourceInformer.GetStore())
+	c.heartBeat = heartbeat.NewHeartBeat(clientset.CoreV1(), c.deviceManagerController, clusterConfig, vmiSourceInformer.GetStore(), nodeShutdown, host)
 
 	return c
 }
@@ -256,6 +275,10 @@ type VirtualMachineController struct {
 	// prevents cycling an unncessary posix thread.
 	phase1NetworkSetupCache virtcache.LauncherPIDByVMI
 
+	// records if all interface mirrors of the launcher pod
+	// expired and were removed from its network namespace.
+	expiredMirrorsCache virtcache.LauncherPIDByVMI
+
 	// key is the file path, value is the contents.
 	// if key exists, then don't read directly from file.
 	podInterfaceCache virtcache.PodInterfaceByVMIAndName
@@ -480,6 +503,7 @@ func (d *VirtualMachineController) clearPodNetworkPhase1(vmi *v1.VirtualMachineI
 		return
 	}
 	d.phase1NetworkSetupCache.Delete(vmi.UID)
+	d.expiredMirrorsCache.Delete(vmi.UID)
 
 	// Clean Pod interface cache from map and files
 	d.podInterfaceCache.DeleteAllForVMI(vmi.UID)
@@ -537,6 +561,79 @@ func (d *VirtualMachineController) setPodNetworkPhase1(vmi *v1.VirtualMachineIns
 	return false, nil
 }
 
+// refreshPodNetworkDHCPConfig follows the network-status annotation of the virt-launcher pod and
+// updates the DHCP configuration advertised on secondary bridge interfaces when their IPAM changes.
+// The annotation is read from the downward API file, which the kubelet updates in place.
+func (d *VirtualMachineController) refreshPodNetworkDHCPConfig(vmi *v1.VirtualMachineInstance) error {
+	if !virtutil.HasSecondaryBridgeInterfaces(&vmi.Spec) {
+		return nil
+	}
+
+	res, err := d.podIsolationDetector.Detect(vmi)
+	if err != nil {
+		return fmt.Errorf("failed to detect isolation for launcher pod: %v", err)
+	}
+
+	networkStatus, err := netsetup.ReadNetworkStatus(filepath.Join(res.MountRoot(), services.PodNetInfoDefault, "annotations"))
+	if os.IsNotExist(err) {
+		// launcher pods created before the annotations were exposed
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return netsetup.NewVMNetworkConfigurator(vmi, d.networkCacheStoreFactory).RefreshDHCPConfig(res.Pid(), networkStatus)
+}
+
+// expirePodNetworkMirrors stops mirroring the traffic of interfaces once their mirror duration is over.
+// The VMI is requeued when the next mirror expires.
+func (d *VirtualMachineController) expirePodNetworkMirrors(vmi *v1.VirtualMachineInstance) error {
+	expired := false
+	var next time.Duration
+	for i := range vmi.Spec.Domain.Devices.Interfaces {
+		deadline, limited := netsetup.MirrorDeadline(vmi, &vmi.Spec.Domain.Devices.Interfaces[i])
+		if !limited {
+			continue
+		}
+		if remaining := time.Until(deadline); remaining > 0 {
+			if next == 0 || remaining < next {
+				next = remaining
+			}
+		} else {
+			expired = true
+		}
+	}
+
+	if next > 0 {
+		d.Queue.AddAfter(controller.VirtualMachineInstanceKey(vmi), next)
+	}
+	if !expired {
+		return nil
+	}
+
+	res, err := d.podIsolationDetector.Detect(vmi)
+	if err != nil {
+		return fmt.Errorf("failed to detect isolation for launcher pod: %v", err)
+	}
+	pid := res.Pid()
+	if cachedPid, exists := d.expiredMirrorsCache.Load(vmi.UID); exists && cachedPid == pid {
+		return nil
+	}
+
+	err = res.DoNetNS(func() error {
+		return netsetup.NewVMNetworkConfigurator(vmi, d.networkCacheStoreFactory).RemoveExpiredMirrors(time.Now())
+	})
+	if err != nil {
+		return err
+	}
+
+	if next == 0 {
+		d.expiredMirrorsCache.Store(vmi.UID, pid)
+	}
+	return nil
+}
+
 func domainMigrated(domain *api.Domain) bool {
 	if domain != nil && domain.Status.Status == api.Shutoff && domain.Status.Reason == api.ReasonMigrated {
 		return true
@@ -698,7 +795,12 @@ func (d *VirtualMachineController) migrationTargetUpdateVMIStatus(vmi *v1.Virtua
 		// record that we've see the domain populated on the target's node
 		log.Log.Object(vmi).Info("The target node received the migrated domain")
 		vmiCopy.Status.MigrationState.TargetNodeDomainDetected = true
-		d.finalizeMigration(vmi)
+
+		// the domain already runs on the target, so a failure to finalize the
+		// migration must not hold back the hand off to this node
+		if err := d.finalizeMigration(vmi); err != nil {
+			d.recorder.Event(vmi, k8sv1.EventTypeWarning, v1.Migrated.String(), fmt.Sprintf("Failed to finalize the migration on the target: %v", err))
+		}
 	}
 
 	if !migrations.IsMigrating(vmi) {
@@ -777,6 +879,10 @@ func (d *VirtualMachineController) updateHotplugVolumeStatus(vmi *v1.VirtualMach
 				volumeStatus.Message = fmt.Sprintf("Volume %s has been mounted in virt-launcher pod", volumeStatus.Name)
 				volumeStatus.Reason = VolumeMountedToPodReason
 			}
+			if volume, ok := specVolumeMap[volumeStatus.Name]; ok && volume.MemoryDump != nil && volumeStatus.Phase == v1.HotplugVolumeMounted {
+				// Memory dump volumes never get attached to the domain, mounted is final
+				needsRefresh = false
+			}
 		} else {
 			// Not mounted, check if the volume is in the spec, if not update status
 			if _, ok := specVolumeMap[volumeStatus.Name]; !ok && canUpdateToUnmounted(volumeStatus.Phase) {
@@ -851,8 +957,17 @@ func (d *VirtualMachineController) updateGuestInfoFromDomain(vmi *v1.VirtualMach
 		vmi.Status.GuestOSInfo.PrettyName = domain.Status.OSInfo.PrettyName
 		vmi.Status.GuestOSInfo.VersionID = domain.Status.OSInfo.VersionId
 		vmi.Status.GuestOSInfo.KernelVersion = domain.Status.OSInfo.KernelVersion
+		vmi.Status.GuestOSInfo.Machine = domain.Status.OSInfo.Machine
 		vmi.Status.GuestOSInfo.ID = domain.Status.OSInfo.Id
 	}
+
+	// the hostname and the timezone are only reported once the guest agent got polled for them
+	if domain.Status.Hostname != "" {
+		vmi.Status.GuestOSInfo.Hostname = domain.Status.Hostname
+	}
+	if domain.Status.Timezone.Zone != "" {
+		vmi.Status.GuestOSInfo.Timezone = fmt.Sprintf("%s, %d", domain.Status.Timezone.Zone, domain.Status.Timezone.Offset)
+	}
 }
 
 func (d *VirtualMachineController) updateInterfacesFromDomain(vmi *v1.VirtualMachineInstance, domain *api.Domain) error {
@@ -900,22 +1015,32 @@ func (d *VirtualMachineController) updateInterfacesFromDomain(vmi *v1.VirtualMac
 		// - domain.Spec - interfaces form the Spec
 		// - domain.Status.Interfaces - interfaces reported by guest agent (empty if Qemu agent not running)
 		newInterfaces := []v1.VirtualMachineInstanceNetworkInterface{}
+		merger := newGuestAgentInterfaceMerger(len(domain.Status.Interfaces) > 0, time.Now())
 
 		existingInterfaceStatusByName := map[string]v1.VirtualMachineInstanceNetworkInterface{}
+		existingInterfaceStatusByMac := map[string]v1.VirtualMachineInstanceNetworkInterface{}
 		for _, existingInterfaceStatus := range vmi.Status.Interfaces {
 			if existingInterfaceStatus.Name != "" {
 				existingInterfaceStatusByName[existingInterfaceStatus.Name] = existingInterfaceStatus
 			}
+			if existingInterfaceStatus.MAC != "" {
+				existingInterfaceStatusByMac[strings.ToLower(existingInterfaceStatus.MAC)] = existingInterfaceStatus
+			}
 		}
 
+		// MACs are compared in lower case, guests and users do not agree on the case
 		domainInterfaceStatusByMac := map[string]api.InterfaceStatus{}
 		for _, domainInterfaceStatus := range domain.Status.Interfaces {
-			domainInterfaceStatusByMac[domainInterfaceStatus.Mac] = domainInterfaceStatus
+			domainInterfaceStatusByMac[strings.ToLower(domainInterfaceStatus.Mac)] = domainInterfaceStatus
 		}
 
 		existingInterfacesSpecByName := map[string]v1.Interface{}
+		existingInterfacesSpecByMac := map[string]v1.Interface{}
 		for _, existingInterfaceSpec := range vmi.Spec.Domain.Devices.Interfaces {
 			existingInterfacesSpecByName[existingInterfaceSpec.Name] = existingInterfaceSpec
+			if existingInterfaceSpec.MacAddress != "" {
+				existingInterfacesSpecByMac[strings.ToLower(existingInterfaceSpec.MacAddress)] = existingInterfaceSpec
+			}
 		}
 		existingNetworksByName := map[string]v1.Network{}
 		for _, existingNetwork := range vmi.Spec.Networks {
@@ -925,28 +1050,29 @@ func (d *VirtualMachineController) updateInterfacesFromDomain(vmi *v1.VirtualMac
 		// Iterate through all domain.Spec interfaces
 		for _, domainInterface := range domain.Spec.Devices.Interfaces {
 			interfaceMAC := domainInterface.MAC.MAC
+			interfaceName := domainInterfaceName(domainInterface, existingInterfacesSpecByMac)
 			var newInterface v1.VirtualMachineInstanceNetworkInterface
 			var isForwardingBindingInterface = false
 
-			if existingInterfacesSpecByName[domainInterface.Alias.GetName()].Masquerade != nil || existingInterfacesSpecByName[domainInterface.Alias.GetName()].Slirp != nil {
+			if existingInterfacesSpecByName[interfaceName].Masquerade != nil || existingInterfacesSpecByName[interfaceName].Slirp != nil {
 				isForwardingBindingInterface = true
 			}
 
-			if existingInterface, exists := existingInterfaceStatusByName[domainInterface.Alias.GetName()]; exists {
+			if existingInterface, exists := existingInterfaceStatusByName[interfaceName]; exists {
 				// Reuse previously calculated interface from vmi.Status.Interfaces, updating the MAC from domain.Spec
 				// Only interfaces defined in domain.Spec are handled here
 				newInterface = existingInterface
 				newInterface.MAC = interfaceMAC
 
 				// If it is a Combination of Masquerade+Pod network, check IP from file cache
-				if existingInterfacesSpecByName[domainInterface.Alias.GetName()].Masquerade != nil && existingNetworksByName[domainInterface.Alias.GetName()].NetworkSource.Pod != nil {
-					iface, err := d.getPodInterfacefromFileCache(vmi, domainInterface.Alias.GetName())
+				if existingInterfacesSpecByName[interfaceName].Masquerade != nil && existingNetworksByName[interfaceName].NetworkSource.Pod != nil {
+					iface, err := d.getPodInterfacefromFileCache(vmi, interfaceName)
 					if err != nil {
 						return err
 					}
 
-					if !reflect.DeepEqual(iface.PodIPs, existingInterfaceStatusByName[domainInterface.Alias.GetName()].IPs) {
-						newInterface.Name = domainInterface.Alias.GetName()
+					if !reflect.DeepEqual(iface.PodIPs, existingInterfaceStatusByName[interfaceName].IPs) {
+						newInterface.Name = interfaceName
 						newInterface.IP = iface.PodIP
 						newInterface.IPs = iface.PodIPs
 					}
@@ -955,44 +1081,164 @@ func (d *VirtualMachineController) updateInterfacesFromDomain(vmi *v1.VirtualMac
 				// If not present in vmi.Status.Interfaces, create a new one based on domain.Spec
 				newInterface = v1.VirtualMachineInstanceNetworkInterface{
 					MAC:  interfaceMAC,
-					Name: domainInterface.Alias.GetName(),
+					Name: interfaceName,
 				}
 			}
 
 			// Update IP info based on information from domain.Status.Interfaces (Qemu guest)
 			// Remove the interface from domainInterfaceStatusByMac to mark it as handled
-			if interfaceStatus, exists := domainInterfaceStatusByMac[interfaceMAC]; exists {
-				newInterface.InterfaceName = interfaceStatus.InterfaceName
-				// Do not update if interface has Masquerede binding
-				// virt-controller should update VMI status interface with Pod IP instead
-				if !isForwardingBindingInterface {
-					newInterface.IP = interfaceStatus.Ip
-					newInterface.IPs = interfaceStatus.IPs
-				}
-				delete(domainInterfaceStatusByMac, interfaceMAC)
+			// Do not update the IPs if interface has Masquerede binding
+			// virt-controller should update VMI status interface with Pod IP instead
+			var reported *api.InterfaceStatus
+			if interfaceStatus, exists := domainInterfaceStatusByMac[strings.ToLower(interfaceMAC)]; exists {
+				reported = &interfaceStatus
+				delete(domainInterfaceStatusByMac, strings.ToLower(interfaceMAC))
 			}
+			merger.merge(&newInterface, reported, isForwardingBindingInterface)
+			// the bandwidth which is applied to the running domain, it lags behind spec changes until virt-launcher synced them
+			newInterface.BandwidthLimit = domainInterface.BandWidth.Limit()
 			newInterfaces = append(newInterfaces, newInterface)
+			delete(existingInterfaceStatusByMac, strings.ToLower(interfaceMAC))
 		}
 
 		// If any of domain.Status.Interfaces were not handled above, it means that the vm contains additional
 		// interfaces not defined in domain.Spec.Devices.Interfaces (most likely added by user on VM or a SRIOV interface)
-		// Add them to vmi.Status.Interfaces
-		setMissingSRIOVInterfacesNames(existingInterfacesSpecByName, domainInterfaceStatusByMac)
-		for interfaceMAC, domainInterfaceStatus := range domainInterfaceStatusByMac {
-			newInterface := v1.VirtualMachineInstanceNetworkInterface{
-				Name:          domainInterfaceStatus.Name,
-				MAC:           interfaceMAC,
-				IP:            domainInterfaceStatus.Ip,
-				IPs:           domainInterfaceStatus.IPs,
-				InterfaceName: domainInterfaceStatus.InterfaceName,
+		// Add them to vmi.Status.Interfaces, in the order the guest agent reported them
+		for _, domainInterfaceStatus := range domain.Status.Interfaces {
+			interfaceMAC := strings.ToLower(domainInterfaceStatus.Mac)
+			if _, unhandled := domainInterfaceStatusByMac[interfaceMAC]; !unhandled {
+				continue
 			}
+			delete(domainInterfaceStatusByMac, interfaceMAC)
+
+			newInterface, exists := existingInterfaceStatusByMac[interfaceMAC]
+			if !exists {
+				newInterface = v1.VirtualMachineInstanceNetworkInterface{MAC: domainInterfaceStatus.Mac}
+			}
+			if spec, exists := existingInterfacesSpecByMac[interfaceMAC]; exists {
+				newInterface.Name = spec.Name
+			} else if domainInterfaceStatus.Name != "" {
+				newInterface.Name = domainInterfaceStatus.Name
+			}
+			merger.merge(&newInterface, &domainInterfaceStatus, false)
 			newInterfaces = append(newInterfaces, newInterface)
+			delete(existingInterfaceStatusByMac, interfaceMAC)
 		}
+
+		// Interfaces the guest agent stopped reporting are kept until the change is due
+		for _, existingInterface := range vmi.Status.Interfaces {
+			if _, unhandled := existingInterfaceStatusByMac[strings.ToLower(existingInterface.MAC)]; !unhandled || existingInterface.LastGuestAgentUpdate == nil {
+				continue
+			}
+			if merger.keepUnreported(existingInterface) {
+				newInterfaces = append(newInterfaces, existingInterface)
+			}
+		}
+
 		vmi.Status.Interfaces = newInterfaces
+		if merger.postponed > 0 {
+			d.Queue.AddAfter(controller.VirtualMachineInstanceKey(vmi), merger.postponed)
+		}
 	}
 	return nil
 }
 
+// domainInterfaceName returns the name of the VMI interface a domain interface belongs to. The converter
+// sets a user defined alias with the name, interfaces without one are matched by their MAC.
+func domainInterfaceName(domainInterface api.Interface, interfaceSpecsByMac map[string]v1.Interface) string {
+	if domainInterface.Alias != nil && domainInterface.Alias.IsUserDefined() {
+		return domainInterface.Alias.GetName()
+	}
+	if domainInterface.MAC != nil {
+		if spec, exists := interfaceSpecsByMac[strings.ToLower(domainInterface.MAC.MAC)]; exists {
+			return spec.Name
+		}
+	}
+	if domainInterface.Alias != nil {
+		return domainInterface.Alias.GetName()
+	}
+	return ""
+}
+
+// guestAgentInterfaceDebounce is the minimal time between two changes of the guest agent data of an
+// interface. Guests renewing their addresses or cycling their links would make the VMI status flap otherwise.
+const guestAgentInterfaceDebounce = 10 * time.Second
+
+// guestAgentInterfaceMerger merges the interfaces the guest agent reports into the VMI status. Changes to the
+// data of an interface within the debounce period of the previous change are postponed.
+type guestAgentInterfaceMerger struct {
+	// whether the guest agent reports interfaces, data of interfaces it does not report is only
+	// stale if it reports others
+	reporting bool
+	now       time.Time
+	// the time after which the first postponed change is due
+	postponed time.Duration
+}
+
+func newGuestAgentInterfaceMerger(reporting bool, now time.Time) *guestAgentInterfaceMerger {
+	return &guestAgentInterfaceMerger{reporting: reporting, now: now}
+}
+
+// debounced returns whether a change of the interface data is postponed
+func (m *guestAgentInterfaceMerger) debounced(iface v1.VirtualMachineInstanceNetworkInterface) bool {
+	if iface.LastGuestAgentUpdate == nil {
+		return false
+	}
+	wait := iface.LastGuestAgentUpdate.Add(guestAgentInterfaceDebounce).Sub(m.now)
+	if wait <= 0 {
+		return false
+	}
+	if m.postponed == 0 || wait < m.postponed {
+		m.postponed = wait
+	}
+	return true
+}
+
+// merge applies the data the guest agent reported for the interface. If the guest agent reports other
+// interfaces but not this one, the data it reported before is removed.
+func (m *guestAgentInterfaceMerger) merge(iface *v1.VirtualMachineInstanceNetworkInterface, reported *api.InterfaceStatus, keepIPs bool) {
+	if reported == nil && (!m.reporting || iface.LastGuestAgentUpdate == nil) {
+		return
+	}
+
+	updated := *iface
+	updated.InterfaceName, updated.IP, updated.IPs = "", "", nil
+	if reported != nil {
+		updated.InterfaceName, updated.IP, updated.IPs = reported.InterfaceName, reported.Ip, reported.IPs
+	}
+	if keepIPs {
+		updated.IP, updated.IPs = iface.IP, iface.IPs
+	}
+
+	if updated.InterfaceName == iface.InterfaceName && updated.IP == iface.IP && equalIPs(updated.IPs, iface.IPs) {
+		return
+	}
+	if m.debounced(*iface) {
+		return
+	}
+	now := metav1.NewTime(m.now)
+	updated.LastGuestAgentUpdate = &now
+	*iface = updated
+}
+
+// keepUnreported returns whether an interface the guest agent reported before, but no VMI or domain interface
+// is known for, is kept in the status
+func (m *guestAgentInterfaceMerger) keepUnreported(iface v1.VirtualMachineInstanceNetworkInterface) bool {
+	return !m.reporting || m.debounced(iface)
+}
+
+func equalIPs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 func (d *VirtualMachineController) updateAccessCredentialConditions(vmi *v1.VirtualMachineInstance, domain *api.Domain, condManager *controller.VirtualMachineInstanceConditionManager) {
 
 	if domain == nil || domain.Spec.Metadata.KubeVirt.AccessCredential == nil {
@@ -1005,23 +1251,12 @@ func (d *VirtualMachineController) updateAccessCredentialConditions(vmi *v1.Virt
 		status = k8sv1.ConditionTrue
 	}
 
-	add := false
-	condition := condManager.GetCondition(vmi, v1.VirtualMachineInstanceAccessCredentialsSynchronized)
-	if condition == nil {
-		add = true
-	} else if condition.Status != status || condition.Message != message {
-		// if not as expected, remove, then add.
-		condManager.RemoveCondition(vmi, v1.VirtualMachineInstanceAccessCredentialsSynchronized)
-		add = true
-	}
-	if add {
-		newCondition := v1.VirtualMachineInstanceCondition{
-			Type:               v1.VirtualMachineInstanceAccessCredentialsSynchronized,
-			LastTransitionTime: metav1.Now(),
-			Status:             status,
-			Message:            message,
-		}
-		vmi.Status.Conditions = append(vmi.Status.Conditions, newCondition)
+	changed := conditions.SetVMICondition(vmi, v1.VirtualMachineInstanceCondition{
+		Type:    v1.VirtualMachineInstanceAccessCredentialsSynchronized,
+		Status:  status,
+		Message: message,
+	})
+	if changed {
 		if status == k8sv1.ConditionTrue {
 			d.recorder.Event(vmi, k8sv1.EventTypeNormal, v1.AccessCredentialsSyncSuccess.String(), message)
 		} else {
@@ -1030,25 +1265,85 @@ func (d *VirtualMachineController) updateAccessCredentialConditions(vmi *v1.Virt
 	}
 }
 
+// updateDriftCondition reports the fields of the VMI whose configuration in the running domain differs from the VMI,
+// virt-launcher lists them in the metadata of the domain
+func (d *VirtualMachineController) updateDriftCondition(vmi *v1.VirtualMachineInstance, domain *api.Domain) {
+	if domain == nil {
+		return
+	}
+
+	drift := domain.Spec.Metadata.KubeVirt.Drift
+	if drift == nil || len(drift.Fields) == 0 {
+		conditions.RemoveVMICondition(vmi, v1.VirtualMachineInstanceDriftDetected)
+		return
+	}
+
+	message := fmt.Sprintf("the running domain differs from %s until the VMI is restarted", strings.Join(drift.Fields, ", "))
+	changed := conditions.SetVMICondition(vmi, v1.VirtualMachineInstanceCondition{
+		Type:    v1.VirtualMachineInstanceDriftDetected,
+		Status:  k8sv1.ConditionTrue,
+		Reason:  v1.VirtualMachineInstanceReasonDomainDrifted,
+		Message: message,
+	})
+	if changed {
+		d.recorder.Event(vmi, k8sv1.EventTypeWarning, v1.VirtualMachineInstanceReasonDomainDrifted, message)
+	}
+}
+
+// updateGuestPressureConditions reports the pressure inside of the guest which virt-launcher sampled from the balloon
+// statistics and the steal time, the conditions are dropped while the guest does not report the statistics
+func updateGuestPressureConditions(vmi *v1.VirtualMachineInstance, domain *api.Domain) {
+	if domain == nil {
+		return
+	}
+	pressure := domain.Status.GuestPressure
+
+	if pressure.MemoryAvailablePercent == nil {
+		conditions.RemoveVMICondition(vmi, v1.VirtualMachineInstanceGuestMemoryPressure)
+	} else if pressure.UnderMemoryPressure() {
+		conditions.SetVMICondition(vmi, v1.VirtualMachineInstanceCondition{
+			Type:    v1.VirtualMachineInstanceGuestMemoryPressure,
+			Status:  k8sv1.ConditionTrue,
+			Reason:  v1.VirtualMachineInstanceReasonLowGuestMemory,
+			Message: fmt.Sprintf("less than %d%% of the guest memory is available to applications", api.GuestMemoryPressurePercent),
+		})
+	} else {
+		conditions.SetVMICondition(vmi, v1.VirtualMachineInstanceCondition{
+			Type:   v1.VirtualMachineInstanceGuestMemoryPressure,
+			Status: k8sv1.ConditionFalse,
+		})
+	}
+
+	if pressure.CPUStealPercent == nil {
+		conditions.RemoveVMICondition(vmi, v1.VirtualMachineInstanceGuestCPUSteal)
+	} else if pressure.UnderCPUSteal() {
+		conditions.SetVMICondition(vmi, v1.VirtualMachineInstanceCondition{
+			Type:    v1.VirtualMachineInstanceGuestCPUSteal,
+			Status:  k8sv1.ConditionTrue,
+			Reason:  v1.VirtualMachineInstanceReasonHighCPUSteal,
+			Message: fmt.Sprintf("the vCPUs wait for a host CPU more than %d%% of the time", api.GuestCPUStealPercent),
+		})
+	} else {
+		conditions.SetVMICondition(vmi, v1.VirtualMachineInstanceCondition{
+			Type:   v1.VirtualMachineInstanceGuestCPUSteal,
+			Status: k8sv1.ConditionFalse,
+		})
+	}
+}
+
 func (d *VirtualMachineController) updateLiveMigrationConditions(vmi *v1.VirtualMachineInstance, condManager *controller.VirtualMachineInstanceConditionManager) {
 
 	// Cacluate whether the VM is migratable
 	liveMigrationCondition, isBlockMigration := d.calculateLiveMigrationCondition(vmi)
 	if !condManager.HasCondition(vmi, v1.VirtualMachineInstanceIsMigratable) {
-		vmi.Status.Conditions = append(vmi.Status.Conditions, *liveMigrationCondition)
 		// Set VMI Migration Method
 		if isBlockMigration {
 			vmi.Status.MigrationMethod = v1.BlockMigration
 		} else {
 			vmi.Status.MigrationMethod = v1.LiveMigration
 		}
-	} else {
-		cond := condManager.GetCondition(vmi, v1.VirtualMachineInstanceIsMigratable)
-		if !reflect.DeepEqual(cond, liveMigrationCondition) {
-			condManager.RemoveCondition(vmi, v1.VirtualMachineInstanceIsMigratable)
-			vmi.Status.Conditions = append(vmi.Status.Conditions, *liveMigrationCondition)
-		}
 	}
+	conditions.SetVMICondition(vmi, *liveMigrationCondition)
 	if vmi.IsEvictable() && liveMigrationCondition.Status == k8sv1.ConditionFalse {
 		d.recorder.Event(vmi, k8sv1.EventTypeWarning, v1.Migrated.String(), "EvictionStrategy is set but vmi is not migratable")
 	}
@@ -1074,12 +1369,11 @@ func (d *VirtualMachineController) updateGuestAgentConditions(vmi *v1.VirtualMac
 
 	switch {
 	case channelConnected && !condManager.HasCondition(vmi, v1.VirtualMachineInstanceAgentConnected):
-		agentCondition := v1.VirtualMachineInstanceCondition{
+		conditions.SetVMICondition(vmi, v1.VirtualMachineInstanceCondition{
 			Type:          v1.VirtualMachineInstanceAgentConnected,
 			LastProbeTime: metav1.Now(),
 			Status:        k8sv1.ConditionTrue,
-		}
-		vmi.Status.Conditions = append(vmi.Status.Conditions, agentCondition)
+		})
 	case !channelConnected:
 		condManager.RemoveCondition(vmi, v1.VirtualMachineInstanceAgentConnected)
 	}
@@ -1096,6 +1390,7 @@ func (d *VirtualMachineController) updateGuestAgentConditions(vmi *v1.VirtualMac
 		}
 
 		var supported = false
+		var reason, message string
 
 		// For current versions, virt-launcher's supported commands will always contain data.
 		// For backwards compatibility: during upgrade from a previous version of KubeVirt,
@@ -1103,29 +1398,87 @@ func (d *VirtualMachineController) updateGuestAgentConditions(vmi *v1.VirtualMac
 		// commands is empty, fall back to previous behavior.
 		if len(guestInfo.SupportedCommands) > 0 {
 			supported = isGuestAgentSupported(vmi, guestInfo.SupportedCommands)
+			reason = v1.VirtualMachineInstanceReasonAgentCommandsMissing
+			message = "The guest agent doesn't support the commands required by KubeVirt"
 		} else {
 			for _, version := range d.clusterConfig.GetSupportedAgentVersions() {
 				supported = supported || regexp.MustCompile(version).MatchString(guestInfo.GAVersion)
 			}
+			reason = v1.VirtualMachineInstanceReasonAgentVersionNotSupported
+			message = fmt.Sprintf("The guest agent version '%s' is not supported", guestInfo.GAVersion)
 		}
 
 		if !supported {
 			if !condManager.HasCondition(vmi, v1.VirtualMachineInstanceUnsupportedAgent) {
-				agentCondition := v1.VirtualMachineInstanceCondition{
+				conditions.SetVMICondition(vmi, v1.VirtualMachineInstanceCondition{
 					Type:          v1.VirtualMachineInstanceUnsupportedAgent,
 					LastProbeTime: metav1.Now(),
 					Status:        k8sv1.ConditionTrue,
-				}
-				vmi.Status.Conditions = append(vmi.Status.Conditions, agentCondition)
+					Reason:        reason,
+					Message:       message,
+				})
 			}
 		} else {
 			condManager.RemoveCondition(vmi, v1.VirtualMachineInstanceUnsupportedAgent)
 		}
 
+		updateGuestAgentStatus(vmi, guestInfo, condManager)
+	} else {
+		condManager.RemoveCondition(vmi, v1.VirtualMachineInstanceAgentDegraded)
 	}
 	return nil
 }
 
+// updateGuestAgentStatus reports the version and the capabilities of the guest agent in the VMI status
+// and sets the degraded condition if features relying on the guest agent can't be used.
+func updateGuestAgentStatus(vmi *v1.VirtualMachineInstance, guestInfo *v1.VirtualMachineInstanceGuestAgentInfo, condManager *controller.VirtualMachineInstanceConditionManager) {
+	// Older virt-launchers don't report the commands, so nothing can be said about the features
+	if guestInfo.GAVersion == "" && len(guestInfo.SupportedCommands) == 0 {
+		return
+	}
+
+	status := &v1.VirtualMachineInstanceGuestAgentStatus{
+		Version: guestInfo.GAVersion,
+	}
+	for _, cmd := range guestInfo.SupportedCommands {
+		if cmd.Enabled {
+			status.SupportedCommands = append(status.SupportedCommands, cmd.Name)
+		}
+	}
+	if len(guestInfo.SupportedCommands) > 0 {
+		status.UnavailableFeatures = unavailableGuestAgentFeatures(guestInfo.SupportedCommands)
+	}
+	vmi.Status.GuestAgent = status
+
+	if len(status.UnavailableFeatures) == 0 {
+		condManager.RemoveCondition(vmi, v1.VirtualMachineInstanceAgentDegraded)
+		return
+	}
+
+	features := make([]string, 0, len(status.UnavailableFeatures))
+	for _, feature := range status.UnavailableFeatures {
+		features = append(features, string(feature))
+	}
+	message := fmt.Sprintf("The guest agent doesn't support the commands required by: %s", strings.Join(features, ", "))
+	conditions.SetVMICondition(vmi, v1.VirtualMachineInstanceCondition{
+		Type:          v1.VirtualMachineInstanceAgentDegraded,
+		LastProbeTime: metav1.Now(),
+		Status:        k8sv1.ConditionTrue,
+		Reason:        v1.VirtualMachineInstanceReasonAgentFeaturesUnavailable,
+		Message:       message,
+	})
+}
+
+func unavailableGuestAgentFeatures(commands []v1.GuestAgentCommandInfo) []v1.GuestAgentFeature {
+	var unavailable []v1.GuestAgentFeature
+	for _, featureCommands := range guestAgentFeatureCommands {
+		if !_guestAgentCommandSubsetSupported(featureCommands.commands, commands) {
+			unavailable = append(unavailable, featureCommands.feature)
+		}
+	}
+	return unavailable
+}
+
 func (d *VirtualMachineController) updatePausedConditions(vmi *v1.VirtualMachineInstance, domain *api.Domain, condManager *controller.VirtualMachineInstanceConditionManager) {
 
 	// Update paused condition in case VMI was paused / unpaused
@@ -1153,6 +1506,56 @@ func (d *VirtualMachineController) updateFSFreezeStatus(vmi *v1.VirtualMachineIn
 
 }
 
+// updateHostDevicesFromDomain reports the guest PCI addresses of the GPUs and host devices of the VMI
+func (d *VirtualMachineController) updateHostDevicesFromDomain(vmi *v1.VirtualMachineInstance, domain *api.Domain) {
+	if domain == nil {
+		return
+	}
+
+	guestPCIAddresses := map[string]string{}
+	for _, hostDevice := range domain.Spec.Devices.HostDevices {
+		if hostDevice.Alias == nil || hostDevice.Address == nil {
+			continue
+		}
+		if address, ok := formatGuestPCIAddress(hostDevice.Address); ok {
+			guestPCIAddresses[hostDevice.Alias.GetName()] = address
+		}
+	}
+
+	var hostDevicesStatus []v1.VirtualMachineInstanceHostDeviceStatus
+	for _, gpuDevice := range vmi.Spec.Domain.Devices.GPUs {
+		hostDevicesStatus = append(hostDevicesStatus, v1.VirtualMachineInstanceHostDeviceStatus{
+			Name:            gpuDevice.Name,
+			Role:            gpuDevice.Role,
+			GuestPCIAddress: guestPCIAddresses[gpu.AliasPrefix+gpuDevice.Name],
+		})
+	}
+	for _, hostDevice := range vmi.Spec.Domain.Devices.HostDevices {
+		hostDevicesStatus = append(hostDevicesStatus, v1.VirtualMachineInstanceHostDeviceStatus{
+			Name:            hostDevice.Name,
+			Role:            hostDevice.Role,
+			GuestPCIAddress: guestPCIAddresses[generic.AliasPrefix+hostDevice.Name],
+		})
+	}
+	vmi.Status.HostDevices = hostDevicesStatus
+}
+
+// formatGuestPCIAddress formats a libvirt PCI address like 0000:05:00.0
+func formatGuestPCIAddress(address *api.Address) (string, bool) {
+	if address.Type != "pci" {
+		return "", false
+	}
+	var fields [4]uint64
+	for i, field := range []string{address.Domain, address.Bus, address.Slot, address.Function} {
+		value, err := strconv.ParseUint(field, 0, 16)
+		if err != nil {
+			return "", false
+		}
+		fields[i] = value
+	}
+	return fmt.Sprintf("%04x:%02x:%02x.%x", fields[0], fields[1], fields[2], fields[3]), true
+}
+
 func IsoGuestVolumePath(vmi *v1.VirtualMachineInstance, volume *v1.Volume) (string, bool) {
 	var volPath string
 
@@ -1243,6 +1646,7 @@ func (d *VirtualMachineController) updateVMIStatus(origVMI *v1.VirtualMachineIns
 	d.updateGuestInfoFromDomain(vmi, domain)
 	d.updateVolumeStatusesFromDomain(vmi, domain)
 	d.updateFSFreezeStatus(vmi, domain)
+	d.updateHostDevicesFromDomain(vmi, domain)
 	err = d.updateInterfacesFromDomain(vmi, domain)
 	if err != nil {
 		return err
@@ -1256,6 +1660,8 @@ func (d *VirtualMachineController) updateVMIStatus(origVMI *v1.VirtualMachineIns
 
 	// Update conditions on VMI Status
 	d.updateAccessCredentialConditions(vmi, domain, condManager)
+	d.updateDriftCondition(vmi, domain)
+	updateGuestPressureConditions(vmi, domain)
 	d.updateLiveMigrationConditions(vmi, condManager)
 	err = d.updateGuestAgentConditions(vmi, domain, condManager)
 	if err != nil {
@@ -1364,25 +1770,19 @@ func calculatePausedCondition(vmi *v1.VirtualMachineInstance, reason api.StateCh
 	switch reason {
 	case api.ReasonPausedUser:
 		log.Log.Object(vmi).V(3).Info("Adding paused condition")
-		now := metav1.NewTime(time.Now())
-		vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{
-			Type:               v1.VirtualMachineInstancePaused,
-			Status:             k8sv1.ConditionTrue,
-			LastProbeTime:      now,
-			LastTransitionTime: now,
-			Reason:             "PausedByUser",
-			Message:            "VMI was paused by user",
+		conditions.SetVMICondition(vmi, v1.VirtualMachineInstanceCondition{
+			Type:    v1.VirtualMachineInstancePaused,
+			Status:  k8sv1.ConditionTrue,
+			Reason:  "PausedByUser",
+			Message: "VMI was paused by user",
 		})
 	case api.ReasonPausedIOError:
 		log.Log.Object(vmi).V(3).Info("Adding paused condition")
-		now := metav1.NewTime(time.Now())
-		vmi.Status.Conditions = append(vmi.Status.Conditions, v1.VirtualMachineInstanceCondition{
-			Type:               v1.VirtualMachineInstancePaused,
-			Status:             k8sv1.ConditionTrue,
-			LastProbeTime:      now,
-			LastTransitionTime: now,
-			Reason:             "PausedIOError",
-			Message:            "VMI was paused, IO error",
+		conditions.SetVMICondition(vmi, v1.VirtualMachineInstanceCondition{
+			Type:    v1.VirtualMachineInstancePaused,
+			Status:  k8sv1.ConditionTrue,
+			Reason:  "PausedIOError",
+			Message: "VMI was paused, IO error",
 		})
 	default:
 		log.Log.Object(vmi).V(3).Infof("Domain is paused for unknown reason, %s", reason)
@@ -1413,10 +1813,18 @@ func (d *VirtualMachineController) calculateLiveMigrationCondition(vmi *v1.Virtu
 		return newNonMigratableCondition(err.Error(), v1.VirtualMachineInstanceReasonCPUModeNotMigratable), isBlockMigration
 	}
 
+	if migrations.HostPassthroughPolicy(vmi, d.clusterConfig.GetMigrationConfiguration()) == v1.HostPassthroughMigrationDeny {
+		return newNonMigratableCondition("VMI uses the host-passthrough CPU mode, which the cluster does not allow to migrate", v1.VirtualMachineInstanceReasonCPUModeNotMigratable), isBlockMigration
+	}
+
 	if util.IsVMIVirtiofsEnabled(vmi) {
 		return newNonMigratableCondition("VMI uses virtiofs", v1.VirtualMachineInstanceReasonVirtIOFSNotMigratable), isBlockMigration
 	}
 
+	if devices := vmi.Spec.Domain.Devices; len(devices.GPUs) > 0 && (devices.DetachGPUsForMigration == nil || !*devices.DetachGPUsForMigration) {
+		return newNonMigratableCondition("VMI uses GPUs, which are not detached for the migration", v1.VirtualMachineInstanceReasonGPUNotMigratable), isBlockMigration
+	}
+
 	return &v1.VirtualMachineInstanceCondition{
 		Type:   v1.VirtualMachineInstanceIsMigratable,
 		Status: k8sv1.ConditionTrue,
@@ -2339,6 +2747,8 @@ func (d *VirtualMachineController) checkVolumesForMigration(vmi *v1.VirtualMachi
 
 			if !ok || volumeStatus.PersistentVolumeClaimInfo == nil {
 				return true, fmt.Errorf("cannot migrate VMI: Unable to determine if PVC %v is shared, live migration requires that all PVCs must be shared (using ReadWriteMany access mode)", claimName)
+			} else if node := volumeStatus.PersistentVolumeClaimInfo.LocalToNode; node != "" {
+				return true, fmt.Errorf("cannot migrate VMI: PVC %v is bound to a volume which is local to node %v", claimName, node)
 			} else if !pvctypes.HasSharedAccessMode(volumeStatus.PersistentVolumeClaimInfo.AccessModes) {
 				return true, fmt.Errorf("cannot migrate VMI: PVC %v is not shared, live migration requires that all PVCs must be shared (using ReadWriteMany access mode)", claimName)
 			}
@@ -2348,6 +2758,9 @@ func (d *VirtualMachineController) checkVolumesForMigration(vmi *v1.VirtualMachi
 			if !shared {
 				return true, fmt.Errorf("cannot migrate VMI with non-shared HostDisk")
 			}
+		} else if volSrc.NetworkDisk != nil {
+			// network disks are accessed by QEMU directly on the source and the destination
+			continue
 		} else {
 			blockMigrate = true
 		}
@@ -2478,6 +2891,7 @@ func (d *VirtualMachineController) vmUpdateHelperMigrationSource(origVMI *v1.Vir
 			UnsafeMigration:         *migrationConfiguration.UnsafeMigrationOverride,
 			AllowAutoConverge:       *migrationConfiguration.AllowAutoConverge,
 			AllowPostCopy:           *migrationConfiguration.AllowPostCopy,
+			AbortOnProjectedTimeout: vmi.Status.MigrationState.AbortOnProjectedTimeout,
 		}
 
 		err = client.MigrateVirtualMachine(vmi, options)
@@ -2682,6 +3096,14 @@ func (d *VirtualMachineController) vmUpdateHelperDefault(origVMI *v1.VirtualMach
 		if err := d.hotplugVolumeMounter.Mount(vmi); err != nil {
 			return err
 		}
+
+		if err := d.refreshPodNetworkDHCPConfig(vmi); err != nil {
+			log.Log.Object(vmi).Reason(err).Warning("failed to refresh the DHCP configuration of secondary networks")
+		}
+
+		if err := d.expirePodNetworkMirrors(vmi); err != nil {
+			log.Log.Object(vmi).Reason(err).Warning("failed to stop mirroring the traffic of interfaces")
+		}
 	}
 
 	smbios := d.clusterConfig.GetSMBIOS()
@@ -2898,18 +3320,6 @@ func isACPIEnabled(vmi *v1.VirtualMachineInstance, domain *api.Domain) bool {
 		domain.Spec.Features.ACPI != nil
 }
 
-func setMissingSRIOVInterfacesNames(interfacesSpecByName map[string]v1.Interface, interfacesStatusByMac map[string]api.InterfaceStatus) {
-	for name, ifaceSpec := range interfacesSpecByName {
-		if ifaceSpec.SRIOV == nil || ifaceSpec.MacAddress == "" {
-			continue
-		}
-		if domainIfaceStatus, exists := interfacesStatusByMac[ifaceSpec.MacAddress]; exists {
-			domainIfaceStatus.Name = name
-			interfacesStatusByMac[ifaceSpec.MacAddress] = domainIfaceStatus
-		}
-	}
-}
-
 func (d *VirtualMachineController) isHostModelMigratable(vmi *v1.VirtualMachineInstance) error {
 	if cpu := vmi.Spec.Domain.CPU; cpu != nil && cpu.Model == v1.CPUModeHostModel {
 		node, err := d.clientset.CoreV1().Nodes().Get(context.Background(), vmi.Status.NodeName, metav1.GetOptions{})
@@ -52,8 +52,10 @@ import (
 	"k8s.io/client-go/tools/cache"
 	framework "k8s.io/client-go/tools/cache/testing"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
 
 	"kubevirt.io/kubevirt/pkg/certificates"
+	virtcontroller "kubevirt.io/kubevirt/pkg/controller"
 	cmdv1 "kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/v1"
 	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	notifyserver "kubevirt.io/kubevirt/pkg/virt-handler/notify-server"
@@ -204,6 +206,7 @@ var _ = Describe("VirtualMachineInstance", func() {
 			mockIsolationDetector,
 			migrationProxy,
 			nil,
+			nil,
 		)
 		controller.hotplugVolumeMounter = mockHotplugVolumeMounter
 		controller.networkCacheStoreFactory = netcache.NewInterfaceCacheFactoryWithBasePath(shareDir)
@@ -823,6 +826,75 @@ var _ = Describe("VirtualMachineInstance", func() {
 			testutils.ExpectEvent(recorder, VMIDefined)
 		})
 
+		It("should report the guest agent and set the degraded condition when features are unavailable", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = vmiTestUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Running
+			vmi = addActivePods(vmi, podTestUUID, host)
+			vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+				{
+					Type:   v1.VirtualMachineInstanceIsMigratable,
+					Status: k8sv1.ConditionTrue,
+				},
+				{
+					Type:          v1.VirtualMachineInstanceAgentConnected,
+					LastProbeTime: metav1.Now(),
+					Status:        k8sv1.ConditionTrue,
+				},
+			}
+			vmi.Status.Interfaces = make([]v1.VirtualMachineInstanceNetworkInterface, 0)
+
+			mockWatchdog.CreateFile(vmi)
+
+			domain := api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+			domain.Status.Status = api.Running
+			domain.Spec.Devices.Channels = []api.Channel{
+				{
+					Type: "unix",
+					Target: &api.ChannelTarget{
+						Name:  "org.qemu.guest_agent.0",
+						State: "connected",
+					},
+				},
+			}
+
+			commands := []v1.GuestAgentCommandInfo{}
+			for _, cmdName := range append(RequiredGuestAgentCommands, SSHRelatedGuestAgentCommands...) {
+				commands = append(commands, v1.GuestAgentCommandInfo{Name: cmdName, Enabled: true})
+			}
+			commands = append(commands, v1.GuestAgentCommandInfo{Name: "guest-set-user-password", Enabled: false})
+
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(domain)
+
+			client.EXPECT().SyncVirtualMachine(vmi, gomock.Any())
+			client.EXPECT().GetGuestInfo().Return(&v1.VirtualMachineInstanceGuestAgentInfo{
+				GAVersion:         "4.1",
+				SupportedCommands: commands,
+			}, nil)
+			vmiInterface.EXPECT().Update(gomock.Any()).DoAndReturn(func(obj interface{}) (*v1.VirtualMachineInstance, error) {
+				vmi := obj.(*v1.VirtualMachineInstance)
+				Expect(vmi.Status.GuestAgent).ToNot(BeNil())
+				Expect(vmi.Status.GuestAgent.Version).To(Equal("4.1"))
+				Expect(vmi.Status.GuestAgent.SupportedCommands).ToNot(ContainElement("guest-set-user-password"))
+				Expect(vmi.Status.GuestAgent.UnavailableFeatures).To(ConsistOf(v1.GuestAgentFeatureFSFreeze, v1.GuestAgentFeatureUserPassword))
+
+				condManager := virtcontroller.NewVirtualMachineInstanceConditionManager()
+				Expect(condManager.HasCondition(vmi, v1.VirtualMachineInstanceUnsupportedAgent)).To(BeFalse())
+				cond := condManager.GetCondition(vmi, v1.VirtualMachineInstanceAgentDegraded)
+				Expect(cond).ToNot(BeNil())
+				Expect(cond.Reason).To(Equal(v1.VirtualMachineInstanceReasonAgentFeaturesUnavailable))
+				Expect(cond.Message).To(ContainSubstring("FSFreeze, UserPassword"))
+				return vmi, nil
+			})
+			mockHotplugVolumeMounter.EXPECT().Unmount(gomock.Any()).Return(nil)
+			mockHotplugVolumeMounter.EXPECT().Mount(gomock.Any()).Return(nil)
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder, VMIDefined)
+		})
+
 		It("should remove guest agent condition when there is no channel connected", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 			vmi.UID = vmiTestUUID
@@ -991,16 +1063,16 @@ var _ = Describe("VirtualMachineInstance", func() {
 
 			vmiCopy := vmi.DeepCopy()
 			vmiCopy.Status.Conditions = []v1.VirtualMachineInstanceCondition{
-				{
-					Type:   v1.VirtualMachineInstanceIsMigratable,
-					Status: k8sv1.ConditionTrue,
-				},
 				{
 					Type:          v1.VirtualMachineInstanceAccessCredentialsSynchronized,
 					LastProbeTime: metav1.Now(),
 					Status:        k8sv1.ConditionFalse,
 					Message:       "some message",
 				},
+				{
+					Type:   v1.VirtualMachineInstanceIsMigratable,
+					Status: k8sv1.ConditionTrue,
+				},
 			}
 
 			vmiFeeder.Add(vmi)
@@ -1015,6 +1087,137 @@ var _ = Describe("VirtualMachineInstance", func() {
 			expectEvent(string(v1.AccessCredentialsSyncFailed), true)
 		})
 
+		It("should add the drift detected condition when the domain drifted", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = vmiTestUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Running
+			vmi = addActivePods(vmi, podTestUUID, host)
+
+			mockWatchdog.CreateFile(vmi)
+
+			domain := api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+			domain.Status.Status = api.Running
+			domain.Spec.Metadata.KubeVirt.Drift = &api.DriftMetadata{
+				Fields: []string{"spec.domain.cpu", "spec.domain.memory"},
+			}
+
+			updatedVMI := vmi.DeepCopy()
+			updatedVMI.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+				{
+					Type:    v1.VirtualMachineInstanceDriftDetected,
+					Status:  k8sv1.ConditionTrue,
+					Reason:  v1.VirtualMachineInstanceReasonDomainDrifted,
+					Message: "the running domain differs from spec.domain.cpu, spec.domain.memory until the VMI is restarted",
+				},
+				{
+					Type:   v1.VirtualMachineInstanceIsMigratable,
+					Status: k8sv1.ConditionTrue,
+				},
+			}
+
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(domain)
+
+			client.EXPECT().SyncVirtualMachine(vmi, gomock.Any())
+			vmiInterface.EXPECT().Update(NewVMICondMatcher(*updatedVMI))
+			mockHotplugVolumeMounter.EXPECT().Unmount(gomock.Any()).Return(nil)
+			mockHotplugVolumeMounter.EXPECT().Mount(gomock.Any()).Return(nil)
+
+			controller.Execute()
+			expectEvent(v1.VirtualMachineInstanceReasonDomainDrifted, true)
+		})
+
+		It("should remove the drift detected condition when the domain no longer drifted", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = vmiTestUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Running
+			vmi = addActivePods(vmi, podTestUUID, host)
+			vmi.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+				{
+					Type:    v1.VirtualMachineInstanceDriftDetected,
+					Status:  k8sv1.ConditionTrue,
+					Reason:  v1.VirtualMachineInstanceReasonDomainDrifted,
+					Message: "the running domain differs from spec.domain.cpu until the VMI is restarted",
+				},
+				{
+					Type:   v1.VirtualMachineInstanceIsMigratable,
+					Status: k8sv1.ConditionTrue,
+				},
+			}
+
+			mockWatchdog.CreateFile(vmi)
+
+			domain := api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+			domain.Status.Status = api.Running
+
+			updatedVMI := vmi.DeepCopy()
+			updatedVMI.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+				{
+					Type:   v1.VirtualMachineInstanceIsMigratable,
+					Status: k8sv1.ConditionTrue,
+				},
+			}
+
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(domain)
+
+			client.EXPECT().SyncVirtualMachine(vmi, gomock.Any())
+			vmiInterface.EXPECT().Update(NewVMICondMatcher(*updatedVMI))
+			mockHotplugVolumeMounter.EXPECT().Unmount(gomock.Any()).Return(nil)
+			mockHotplugVolumeMounter.EXPECT().Mount(gomock.Any()).Return(nil)
+
+			controller.Execute()
+		})
+
+		It("should report the pressure inside of the guest", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = vmiTestUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Running
+			vmi = addActivePods(vmi, podTestUUID, host)
+
+			mockWatchdog.CreateFile(vmi)
+
+			memoryAvailable := uint(5)
+			cpuSteal := uint(2)
+			domain := api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+			domain.Status.Status = api.Running
+			domain.Status.GuestPressure = api.GuestPressure{
+				MemoryAvailablePercent: &memoryAvailable,
+				CPUStealPercent:        &cpuSteal,
+			}
+
+			updatedVMI := vmi.DeepCopy()
+			updatedVMI.Status.Conditions = []v1.VirtualMachineInstanceCondition{
+				{
+					Type:    v1.VirtualMachineInstanceGuestMemoryPressure,
+					Status:  k8sv1.ConditionTrue,
+					Reason:  v1.VirtualMachineInstanceReasonLowGuestMemory,
+					Message: "less than 10% of the guest memory is available to applications",
+				},
+				{
+					Type:   v1.VirtualMachineInstanceGuestCPUSteal,
+					Status: k8sv1.ConditionFalse,
+				},
+				{
+					Type:   v1.VirtualMachineInstanceIsMigratable,
+					Status: k8sv1.ConditionTrue,
+				},
+			}
+
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(domain)
+
+			client.EXPECT().SyncVirtualMachine(vmi, gomock.Any())
+			vmiInterface.EXPECT().Update(NewVMICondMatcher(*updatedVMI))
+			mockHotplugVolumeMounter.EXPECT().Unmount(gomock.Any()).Return(nil)
+			mockHotplugVolumeMounter.EXPECT().Mount(gomock.Any()).Return(nil)
+
+			controller.Execute()
+		})
+
 		It("should add and remove paused condition", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 			vmi.UID = vmiTestUUID
@@ -1836,6 +2039,46 @@ var _ = Describe("VirtualMachineInstance", func() {
 
 			controller.Execute()
 		}, 3)
+
+		It("should acknowledge the migrated domain even when the migration can't be finalized on the target", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = vmiTestUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Running
+			vmi.Labels = make(map[string]string)
+			vmi.Status.NodeName = "othernode"
+			vmi.Labels[v1.MigrationTargetNodeNameLabel] = host
+			pastTime := metav1.NewTime(metav1.Now().Add(time.Duration(-10) * time.Second))
+			vmi.Status.MigrationState = &v1.VirtualMachineInstanceMigrationState{
+				TargetNode:               host,
+				TargetNodeAddress:        "127.0.0.1:12345",
+				SourceNode:               "othernode",
+				MigrationUID:             "123",
+				TargetNodeDomainDetected: false,
+				StartTimestamp:           &pastTime,
+			}
+
+			mockWatchdog.CreateFile(vmi)
+			domain := api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+			domain.Status.Status = api.Running
+
+			domain.Spec.Metadata.KubeVirt.Migration = &api.MigrationMetadata{
+				UID:            "123",
+				StartTimestamp: &pastTime,
+			}
+
+			domainFeeder.Add(domain)
+			vmiFeeder.Add(vmi)
+
+			vmiUpdated := vmi.DeepCopy()
+			vmiUpdated.Status.MigrationState.TargetNodeDomainDetected = true
+			client.EXPECT().Ping().AnyTimes()
+			client.EXPECT().FinalizeVirtualMachineMigration(vmi).Return(fmt.Errorf("failed to hot-plug host-devices"))
+			vmiInterface.EXPECT().Update(vmiUpdated)
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder, "Failed to finalize the migration on the target")
+		}, 3)
 	})
 
 	Context("check if migratable", func() {
@@ -2003,6 +2246,32 @@ var _ = Describe("VirtualMachineInstance", func() {
 			Expect(blockMigrate).To(BeTrue())
 			Expect(err).To(Equal(fmt.Errorf("cannot migrate VMI: PVC testblock is not shared, live migration requires that all PVCs must be shared (using ReadWriteMany access mode)")))
 		})
+		It("should not be allowed to migrate a vmi with a volume local to a node", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Volumes = []v1.Volume{
+				{
+					Name: "myvolume",
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+							PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "local"},
+						},
+					},
+				},
+			}
+			vmi.Status.VolumeStatus = []v1.VolumeStatus{
+				{
+					Name: "myvolume",
+					PersistentVolumeClaimInfo: &v1.PersistentVolumeClaimInfo{
+						AccessModes: []k8sv1.PersistentVolumeAccessMode{k8sv1.ReadWriteMany},
+						LocalToNode: "node01",
+					},
+				},
+			}
+
+			blockMigrate, err := controller.checkVolumesForMigration(vmi)
+			Expect(blockMigrate).To(BeTrue())
+			Expect(err).To(Equal(fmt.Errorf("cannot migrate VMI: PVC local is bound to a volume which is local to node node01")))
+		})
 		It("should be allowed to migrate a mix of shared and non-shared disks", func() {
 
 			vmi := v1.NewMinimalVMI("testvmi")
@@ -2144,6 +2413,25 @@ var _ = Describe("VirtualMachineInstance", func() {
 			Expect(blockMigrate).To(BeFalse())
 			Expect(err).To(BeNil())
 		})
+		It("should be allowed to live-migrate network disks without block migration", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Volumes = []v1.Volume{
+				{
+					Name: "myvolume",
+					VolumeSource: v1.VolumeSource{
+						NetworkDisk: &v1.NetworkDiskSource{
+							Protocol: v1.NetworkDiskProtocolISCSI,
+							Name:     "iqn.2021-01.io.kubevirt:target/1",
+							Host:     v1.NetworkDiskHost{Name: "storage.example.com"},
+						},
+					},
+				},
+			}
+
+			blockMigrate, err := controller.checkVolumesForMigration(vmi)
+			Expect(blockMigrate).To(BeFalse())
+			Expect(err).To(BeNil())
+		})
 		It("should not be allowed to live-migrate shared and non-shared HostDisks ", func() {
 			_true := true
 			_false := false
@@ -2243,6 +2531,23 @@ var _ = Describe("VirtualMachineInstance", func() {
 			Expect(condition.Reason).To(Equal(v1.VirtualMachineInstanceReasonVirtIOFSNotMigratable))
 		})
 
+		table.DescribeTable("with GPUs", func(detachGPUs *bool, expectedStatus k8sv1.ConditionStatus) {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.GPUs = []v1.GPU{{Name: "gpu1", DeviceName: "vendor.com/gpu"}}
+			vmi.Spec.Domain.Devices.DetachGPUsForMigration = detachGPUs
+
+			condition, _ := controller.calculateLiveMigrationCondition(vmi)
+			Expect(condition.Type).To(Equal(v1.VirtualMachineInstanceIsMigratable))
+			Expect(condition.Status).To(Equal(expectedStatus))
+			if expectedStatus == k8sv1.ConditionFalse {
+				Expect(condition.Reason).To(Equal(v1.VirtualMachineInstanceReasonGPUNotMigratable))
+			}
+		},
+			table.Entry("should not be allowed to live-migrate by default", nil, k8sv1.ConditionFalse),
+			table.Entry("should not be allowed to live-migrate if the GPUs are not detached", pointer.BoolPtr(false), k8sv1.ConditionFalse),
+			table.Entry("should be allowed to live-migrate if the GPUs are detached", pointer.BoolPtr(true), k8sv1.ConditionTrue),
+		)
+
 		Context("with network configuration", func() {
 			It("should block migration for bridge binding assigned to the pod network", func() {
 				vmi := v1.NewMinimalVMI("testvmi")
@@ -2663,6 +2968,168 @@ var _ = Describe("VirtualMachineInstance", func() {
 			testutils.ExpectEvent(recorder, VMIStarted)
 		})
 
+		It("should postpone guest agent changes of an interface within the debounce period", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = vmiTestUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Scheduled
+
+			interfaceName := "interface_name"
+			mac := "C0:01:BE:E7:15:G0:0D"
+			lastUpdate := metav1.Now()
+
+			vmi.Status.Interfaces = []v1.VirtualMachineInstanceNetworkInterface{
+				{
+					IP:                   "1.1.1.1",
+					IPs:                  []string{"1.1.1.1"},
+					MAC:                  mac,
+					Name:                 interfaceName,
+					LastGuestAgentUpdate: &lastUpdate,
+				},
+			}
+
+			mockWatchdog.CreateFile(vmi)
+			domain := api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+			domain.Status.Status = api.Running
+
+			domain.Spec.Devices.Interfaces = []api.Interface{
+				{
+					MAC:   &api.MAC{MAC: mac},
+					Alias: api.NewUserDefinedAlias(interfaceName),
+				},
+			}
+			domain.Status.Interfaces = []api.InterfaceStatus{
+				{
+					Name: interfaceName,
+					Mac:  mac,
+					Ip:   "2.2.2.2",
+					IPs:  []string{"2.2.2.2"},
+				},
+			}
+
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(domain)
+
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				Expect(arg.(*v1.VirtualMachineInstance).Status.Interfaces).To(HaveLen(1))
+				Expect(arg.(*v1.VirtualMachineInstance).Status.Interfaces[0].IP).To(Equal("1.1.1.1"))
+				Expect(arg.(*v1.VirtualMachineInstance).Status.Interfaces[0].IPs).To(Equal([]string{"1.1.1.1"}))
+				Expect(arg.(*v1.VirtualMachineInstance).Status.Interfaces[0].LastGuestAgentUpdate).To(Equal(&lastUpdate))
+			}).Return(vmi, nil)
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder, VMIStarted)
+			Expect(mockQueue.GetAddAfterEnqueueCount()).To(BeNumerically(">", 0))
+		})
+
+		It("should remove the guest agent data of interfaces the guest agent stopped reporting", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = vmiTestUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Scheduled
+
+			interfaceName := "interface_name"
+			mac := "C0:01:BE:E7:15:G0:0D"
+			otherMAC := "1C:CE:C0:01:BE:E7"
+			staleMAC := "1C:CE:C0:01:BE:E8"
+			lastUpdate := metav1.NewTime(time.Now().Add(-time.Minute))
+
+			vmi.Status.Interfaces = []v1.VirtualMachineInstanceNetworkInterface{
+				{
+					IP:                   "1.1.1.1",
+					IPs:                  []string{"1.1.1.1"},
+					MAC:                  mac,
+					Name:                 interfaceName,
+					InterfaceName:        "eth0",
+					LastGuestAgentUpdate: &lastUpdate,
+				},
+				{
+					IP:                   "3.3.3.3",
+					MAC:                  staleMAC,
+					InterfaceName:        "eth2",
+					LastGuestAgentUpdate: &lastUpdate,
+				},
+			}
+
+			mockWatchdog.CreateFile(vmi)
+			domain := api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+			domain.Status.Status = api.Running
+
+			domain.Spec.Devices.Interfaces = []api.Interface{
+				{
+					MAC:   &api.MAC{MAC: mac},
+					Alias: api.NewUserDefinedAlias(interfaceName),
+				},
+			}
+			domain.Status.Interfaces = []api.InterfaceStatus{
+				{
+					Mac:           otherMAC,
+					Ip:            "2.2.2.2",
+					IPs:           []string{"2.2.2.2"},
+					InterfaceName: "eth1",
+				},
+			}
+
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(domain)
+
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				interfaces := arg.(*v1.VirtualMachineInstance).Status.Interfaces
+				Expect(interfaces).To(HaveLen(2))
+				Expect(interfaces[0].Name).To(Equal(interfaceName))
+				Expect(interfaces[0].IP).To(BeEmpty())
+				Expect(interfaces[0].IPs).To(BeEmpty())
+				Expect(interfaces[0].InterfaceName).To(BeEmpty())
+				Expect(interfaces[1].MAC).To(Equal(otherMAC))
+				Expect(interfaces[1].IP).To(Equal("2.2.2.2"))
+				Expect(interfaces[1].LastGuestAgentUpdate).ToNot(BeNil())
+			}).Return(vmi, nil)
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder, VMIStarted)
+		})
+
+		It("should match the guest agent data of interfaces by MAC regardless of the case", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = vmiTestUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Scheduled
+
+			interfaceName := "interface_name"
+			mac := "C0:01:BE:E7:15:0D"
+
+			mockWatchdog.CreateFile(vmi)
+			domain := api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+			domain.Status.Status = api.Running
+
+			domain.Spec.Devices.Interfaces = []api.Interface{
+				{
+					MAC:   &api.MAC{MAC: mac},
+					Alias: api.NewUserDefinedAlias(interfaceName),
+				},
+			}
+			domain.Status.Interfaces = []api.InterfaceStatus{
+				{
+					Mac: strings.ToLower(mac),
+					Ip:  "2.2.2.2",
+					IPs: []string{"2.2.2.2"},
+				},
+			}
+
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(domain)
+
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				Expect(arg.(*v1.VirtualMachineInstance).Status.Interfaces).To(HaveLen(1))
+				Expect(arg.(*v1.VirtualMachineInstance).Status.Interfaces[0].Name).To(Equal(interfaceName))
+				Expect(arg.(*v1.VirtualMachineInstance).Status.Interfaces[0].MAC).To(Equal(mac))
+				Expect(arg.(*v1.VirtualMachineInstance).Status.Interfaces[0].IP).To(Equal("2.2.2.2"))
+			}).Return(vmi, nil)
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder, VMIStarted)
+		})
+
 		It("should update Guest OS Information in VMI status", func() {
 			vmi := v1.NewMinimalVMI("testvmi")
 			vmi.UID = vmiTestUUID
@@ -2679,12 +3146,53 @@ var _ = Describe("VirtualMachineInstance", func() {
 			domain.Status.Status = api.Running
 
 			domain.Status.OSInfo = api.GuestOSInfo{Name: guestOSName}
+			domain.Status.Hostname = "testhost"
+			domain.Status.Timezone = api.Timezone{Zone: "CEST", Offset: 7200}
+
+			vmiFeeder.Add(vmi)
+			domainFeeder.Add(domain)
+
+			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
+				guestOSInfo := arg.(*v1.VirtualMachineInstance).Status.GuestOSInfo
+				Expect(guestOSInfo.Name).To(Equal(guestOSName))
+				Expect(guestOSInfo.Hostname).To(Equal("testhost"))
+				Expect(guestOSInfo.Timezone).To(Equal("CEST, 7200"))
+			}).Return(vmi, nil)
+
+			controller.Execute()
+			testutils.ExpectEvent(recorder, VMIStarted)
+		})
+
+		It("should report the guest PCI addresses of GPUs and host devices in VMI status", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.UID = vmiTestUUID
+			vmi.ObjectMeta.ResourceVersion = "1"
+			vmi.Status.Phase = v1.Scheduled
+			vmi.Spec.Domain.Devices.GPUs = []v1.GPU{{Name: "gpu1", DeviceName: "vendor.com/gpu", Role: "render"}}
+			vmi.Spec.Domain.Devices.HostDevices = []v1.HostDevice{{Name: "hostdev1", DeviceName: "vendor.com/hostdev", Role: "compute"}}
+
+			mockWatchdog.CreateFile(vmi)
+			domain := api.NewMinimalDomainWithUUID("testvmi", vmiTestUUID)
+			domain.Status.Status = api.Running
+			domain.Spec.Devices.HostDevices = []api.HostDevice{
+				{
+					Alias:   api.NewUserDefinedAlias("gpu-gpu1"),
+					Address: &api.Address{Type: "pci", Domain: "0x0000", Bus: "0x05", Slot: "0x00", Function: "0x0"},
+				},
+				{
+					Alias:   api.NewUserDefinedAlias("hostdevice-hostdev1"),
+					Address: &api.Address{Type: "pci", Domain: "0x0000", Bus: "0x06", Slot: "0x00", Function: "0x0"},
+				},
+			}
 
 			vmiFeeder.Add(vmi)
 			domainFeeder.Add(domain)
 
 			vmiInterface.EXPECT().Update(gomock.Any()).Do(func(arg interface{}) {
-				Expect(arg.(*v1.VirtualMachineInstance).Status.GuestOSInfo.Name).To(Equal(guestOSName))
+				Expect(arg.(*v1.VirtualMachineInstance).Status.HostDevices).To(Equal([]v1.VirtualMachineInstanceHostDeviceStatus{
+					{Name: "gpu1", Role: "render", GuestPCIAddress: "0000:05:00.0"},
+					{Name: "hostdev1", Role: "compute", GuestPCIAddress: "0000:06:00.0"},
+				}))
 			}).Return(vmi, nil)
 
 			controller.Execute()
@@ -3076,6 +3584,19 @@ var _ = Describe("VirtualMachineInstance", func() {
 			result := isGuestAgentSupported(vmiWithSSH, allCommands)
 			Expect(result).To(BeTrue())
 		})
+
+		It("should report the features the basic commands can't serve as unavailable", func() {
+			Expect(unavailableGuestAgentFeatures(basicCommands)).To(Equal([]v1.GuestAgentFeature{
+				v1.GuestAgentFeatureExec,
+				v1.GuestAgentFeatureFSFreeze,
+				v1.GuestAgentFeatureSSHPublicKeys,
+				v1.GuestAgentFeatureUserPassword,
+			}))
+		})
+
+		It("should report only fsfreeze as unavailable with all commands", func() {
+			Expect(unavailableGuestAgentFeatures(allCommands)).To(Equal([]v1.GuestAgentFeature{v1.GuestAgentFeatureFSFreeze}))
+		})
 	})
 })
 
@@ -29,9 +29,14 @@ import (
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cli"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/converter"
 	domainerrors "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/errors"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/stats"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/util"
 )
 
+// guestPressureInterval is the interval between the samples of the guest pressure, it is the shortest span the
+// steal time is averaged over
+const guestPressureInterval = 15 * time.Second
+
 var (
 	// add older version when supported
 	// don't use the variable in pkg/handler-launcher-com/notify/v1/version.go in order to detect version mismatches early
@@ -220,7 +225,8 @@ func newWatchEventError(err error) watch.Event {
 }
 
 func eventCallback(c cli.Connection, domain *api.Domain, libvirtEvent libvirtEvent, client *Notifier, events chan watch.Event,
-	interfaceStatus []api.InterfaceStatus, osInfo *api.GuestOSInfo, vmi *v1.VirtualMachineInstance, fsFreezeStatus *api.FSFreeze) {
+	interfaceStatus []api.InterfaceStatus, osInfo *api.GuestOSInfo, vmi *v1.VirtualMachineInstance, fsFreezeStatus *api.FSFreeze,
+	hostname *string, timezone *api.Timezone, guestPressure *api.GuestPressure) {
 	d, err := c.LookupDomainByName(util.DomainFromNamespaceName(domain.ObjectMeta.Namespace, domain.ObjectMeta.Name))
 	if err != nil {
 		if !domainerrors.IsNotFound(err) {
@@ -322,10 +328,19 @@ func eventCallback(c cli.Connection, domain *api.Domain, libvirtEvent libvirtEve
 		if osInfo != nil {
 			domain.Status.OSInfo = *osInfo
 		}
+		if hostname != nil {
+			domain.Status.Hostname = *hostname
+		}
+		if timezone != nil {
+			domain.Status.Timezone = *timezone
+		}
 
 		if fsFreezeStatus != nil {
 			domain.Status.FSFreezeStatus = *fsFreezeStatus
 		}
+		if guestPressure != nil {
+			domain.Status.GuestPressure = *guestPressure
+		}
 
 		err := client.SendDomainEvent(watch.Event{Type: watch.Modified, Object: domain})
 		if err != nil {
@@ -334,6 +349,36 @@ func eventCallback(c cli.Connection, domain *api.Domain, libvirtEvent libvirtEve
 	}
 }
 
+// sampleGuestPressure samples the balloon and vCPU statistics of the running domain
+func sampleGuestPressure(domainConn cli.Connection, sampler *stats.PressureSampler) (*api.GuestPressure, error) {
+	domStats, err := domainConn.GetDomainStats(libvirt.DOMAIN_STATS_BALLOON|libvirt.DOMAIN_STATS_VCPU, libvirt.CONNECT_GET_ALL_DOMAINS_STATS_RUNNING)
+	if err != nil {
+		return nil, err
+	}
+	var domStat *stats.DomainStats
+	if len(domStats) > 0 {
+		domStat = domStats[0]
+	}
+	memory, steal := sampler.Sample(domStat, time.Now())
+	return &api.GuestPressure{MemoryAvailablePercent: memory, CPUStealPercent: steal}, nil
+}
+
+type guestPressureState struct {
+	memoryKnown    bool
+	memoryPressure bool
+	stealKnown     bool
+	cpuSteal       bool
+}
+
+func pressureState(pressure api.GuestPressure) guestPressureState {
+	return guestPressureState{
+		memoryKnown:    pressure.MemoryAvailablePercent != nil,
+		memoryPressure: pressure.UnderMemoryPressure(),
+		stealKnown:     pressure.CPUStealPercent != nil,
+		cpuSteal:       pressure.UnderCPUSteal(),
+	}
+}
+
 var updateEvents = updateEventsClosure()
 
 func updateEventsClosure() func(event watch.Event, domain *api.Domain, events chan watch.Event) {
@@ -389,11 +434,16 @@ func (n *Notifier) StartDomainNotifier(
 		var interfaceStatuses []api.InterfaceStatus
 		var guestOsInfo *api.GuestOSInfo
 		var fsFreezeStatus *api.FSFreeze
+		var hostname *string
+		var timezone *api.Timezone
+		var guestPressure *api.GuestPressure
+		pressureSampler := &stats.PressureSampler{}
+		pressureTicker := time.NewTicker(guestPressureInterval)
 		for {
 			select {
 			case event := <-eventChan:
 				domainCache = util.NewDomainFromName(event.Domain, vmi.UID)
-				eventCallback(domainConn, domainCache, event, n, deleteNotificationSent, interfaceStatuses, guestOsInfo, vmi, fsFreezeStatus)
+				eventCallback(domainConn, domainCache, event, n, deleteNotificationSent, interfaceStatuses, guestOsInfo, vmi, fsFreezeStatus, hostname, timezone, guestPressure)
 				log.Log.Infof("Domain name event: %v", domainCache.Spec.Name)
 				if event.AgentEvent != nil {
 					if event.AgentEvent.State == libvirt.CONNECT_DOMAIN_EVENT_AGENT_LIFECYCLE_STATE_CONNECTED {
@@ -406,12 +456,33 @@ func (n *Notifier) StartDomainNotifier(
 				interfaceStatuses = agentUpdate.DomainInfo.Interfaces
 				guestOsInfo = agentUpdate.DomainInfo.OSInfo
 				fsFreezeStatus = agentUpdate.DomainInfo.FSFreezeStatus
+				// the hostname and timezone rarely change, keep them for the next libvirt events
+				if agentUpdate.DomainInfo.Hostname != nil {
+					hostname = agentUpdate.DomainInfo.Hostname
+				}
+				if agentUpdate.DomainInfo.Timezone != nil {
+					timezone = agentUpdate.DomainInfo.Timezone
+				}
 				if interfaceStatuses != nil {
 					interfaceStatuses = agentpoller.MergeAgentStatusesWithDomainData(domainCache.Spec.Devices.Interfaces, interfaceStatuses)
 				}
 
 				eventCallback(domainConn, domainCache, libvirtEvent{}, n, deleteNotificationSent,
-					interfaceStatuses, guestOsInfo, vmi, fsFreezeStatus)
+					interfaceStatuses, guestOsInfo, vmi, fsFreezeStatus, hostname, timezone, guestPressure)
+			case <-pressureTicker.C:
+				pressure, err := sampleGuestPressure(domainConn, pressureSampler)
+				if err != nil {
+					log.Log.Reason(err).V(3).Info("Could not sample the guest pressure.")
+					continue
+				}
+				previous := guestPressure
+				guestPressure = pressure
+				// the latest values are sent along with other updates, only notify when the pressure state changes
+				if domainCache == nil || (previous != nil && pressureState(*previous) == pressureState(*pressure)) {
+					continue
+				}
+				eventCallback(domainConn, domainCache, libvirtEvent{}, n, deleteNotificationSent,
+					interfaceStatuses, guestOsInfo, vmi, fsFreezeStatus, hostname, timezone, guestPressure)
 			case <-reconnectChan:
 				n.SendDomainEvent(newWatchEventError(fmt.Errorf("Libvirt reconnect, domain %s", domainName)))
 			}
@@ -20,6 +20,7 @@
 package accesscredentials
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/xml"
 	"fmt"
@@ -78,7 +79,7 @@ var _ = Describe("AccessCredentials", func() {
 			AllowEmulation: true,
 			SMBios:         &cmdv1.SMBios{},
 		}
-		Expect(converter.Convert_v1_VirtualMachineInstance_To_api_Domain(vmi, domain, c)).To(Succeed())
+		Expect(converter.Convert_v1_VirtualMachineInstance_To_api_Domain(context.Background(), vmi, domain, c)).To(Succeed())
 		api.NewDefaulter(runtime.GOARCH).SetObjectDefaults_Domain(domain)
 
 		return &domain.Spec
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"strings"
 
 	"kubevirt.io/client-go/log"
 
@@ -288,7 +289,8 @@ func convertInterfaceStatusesFromAgentJSON(agentResult []Interface) []api.Interf
 
 		interfaceIP, interfaceIPs := extractIPs(ifc.IPs)
 		interfaceStatuses = append(interfaceStatuses, api.InterfaceStatus{
-			Mac:           ifc.MAC,
+			// Guests report the MAC in lower case, the domain may have it in upper case
+			Mac:           strings.ToLower(ifc.MAC),
 			Ip:            interfaceIP,
 			IPs:           interfaceIPs,
 			InterfaceName: ifc.Name,
@@ -312,5 +314,13 @@ func extractIPs(ipAddresses []IP) (string, []string) {
 	if interfaceIP == "" && len(interfaceIPs) > 0 {
 		interfaceIP = interfaceIPs[0]
 	}
+	// The main IP is always the first item of the IPs
+	for i := range interfaceIPs {
+		if interfaceIPs[i] == interfaceIP {
+			copy(interfaceIPs[1:i+1], interfaceIPs[:i])
+			interfaceIPs[0] = interfaceIP
+			break
+		}
+	}
 	return interfaceIP, interfaceIPs
 }
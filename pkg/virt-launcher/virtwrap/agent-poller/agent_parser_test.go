@@ -150,6 +150,36 @@ var _ = Describe("Qemu agent poller", func() {
 			Expect(interfaceStatuses).To(Equal(expectedStatuses))
 		})
 
+		It("should normalize the MAC and put the main IP first", func() {
+			interfaceStatuses, err := parseInterfaces(`{
+                "return": [
+                    {
+                        "name":"eth0",
+                        "ip-addresses": [
+                            {
+                                "ip-address-type": "ipv6",
+                                "ip-address": "fe80::858:aff:fef4:51",
+                                "prefix": 64
+                            },
+                            {
+                                "ip-address-type": "ipv4",
+                                "ip-address": "10.244.0.81",
+                                "prefix": 24
+                            }
+                        ],
+                        "hardware-address": "0A:58:0A:F4:00:51"
+                    }
+                ]
+            }`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(interfaceStatuses).To(Equal([]api.InterfaceStatus{{
+				Mac:           "0a:58:0a:f4:00:51",
+				Ip:            "10.244.0.81",
+				IPs:           []string{"10.244.0.81", "fe80::858:aff:fef4:51"},
+				InterfaceName: "eth0",
+			}}))
+		})
+
 		It("should merge QEMU info and agent info", func() {
 			interfaceStatuses, err := parseInterfaces(JSONInput)
 			Expect(err).ToNot(HaveOccurred(), "should parse network inferfaces")
@@ -89,6 +89,12 @@ func (s *AsyncAgentStore) Store(key AgentCommand, value interface{}) {
 		case GET_OSINFO:
 			info := value.(api.GuestOSInfo)
 			domainInfo.OSInfo = &info
+		case GET_HOSTNAME:
+			hostname := value.(string)
+			domainInfo.Hostname = &hostname
+		case GET_TIMEZONE:
+			timezone := value.(api.Timezone)
+			domainInfo.Timezone = &timezone
 		case GET_INTERFACES:
 			domainInfo.Interfaces = value.([]api.InterfaceStatus)
 		case GET_FSFREEZE_STATUS:
@@ -368,7 +374,9 @@ func executeAgentCommands(commands []AgentCommand, con cli.Connection, agentStor
 		case GET_INTERFACES:
 			interfaces, err := parseInterfaces(cmdResult)
 			if err != nil {
+				// keep the last reported interfaces, storing none would drop them from the VMI status
 				log.Log.Errorf("Cannot parse guest agent interface %s", err.Error())
+				continue
 			}
 			agentStore.Store(GET_INTERFACES, interfaces)
 		case GET_OSINFO:
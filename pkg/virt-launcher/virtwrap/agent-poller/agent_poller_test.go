@@ -114,6 +114,24 @@ var _ = Describe("Qemu agent poller", func() {
 			Expect(agentStore.AgentUpdated).ToNot(Receive())
 		})
 
+		It("should fire events for a new hostname and timezone", func() {
+			var agentStore = NewAsyncAgentStore()
+			hostname := "testhost"
+			timezone := api.Timezone{Zone: "CEST", Offset: 7200}
+
+			agentStore.Store(GET_HOSTNAME, hostname)
+			agentStore.Store(GET_TIMEZONE, timezone)
+
+			Expect(agentStore.AgentUpdated).To(Receive(Equal(AgentUpdatedEvent{
+				Type:       GET_HOSTNAME,
+				DomainInfo: api.DomainGuestInfo{Hostname: &hostname},
+			})))
+			Expect(agentStore.AgentUpdated).To(Receive(Equal(AgentUpdatedEvent{
+				Type:       GET_TIMEZONE,
+				DomainInfo: api.DomainGuestInfo{Timezone: &timezone},
+			})))
+		})
+
 		It("should report nil slice when no interfaces exists", func() {
 			var agentStore = NewAsyncAgentStore()
 			interfacesStatus := agentStore.GetInterfaceStatus()
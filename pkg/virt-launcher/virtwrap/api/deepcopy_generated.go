@@ -149,6 +149,16 @@ func (in *BackingStoreFormat) DeepCopy() *BackingStoreFormat {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BandWidth) DeepCopyInto(out *BandWidth) {
 	*out = *in
+	if in.Inbound != nil {
+		in, out := &in.Inbound, &out.Inbound
+		*out = new(BandWidthRate)
+		**out = **in
+	}
+	if in.Outbound != nil {
+		in, out := &in.Outbound, &out.Outbound
+		*out = new(BandWidthRate)
+		**out = **in
+	}
 	return
 }
 
@@ -162,6 +172,22 @@ func (in *BandWidth) DeepCopy() *BandWidth {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BandWidthRate) DeepCopyInto(out *BandWidthRate) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BandWidthRate.
+func (in *BandWidthRate) DeepCopy() *BandWidthRate {
+	if in == nil {
+		return nil
+	}
+	out := new(BandWidthRate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BlockIO) DeepCopyInto(out *BlockIO) {
 	*out = *in
@@ -590,6 +616,11 @@ func (in *ControllerDriver) DeepCopyInto(out *ControllerDriver) {
 		*out = new(uint)
 		**out = **in
 	}
+	if in.Queues != nil {
+		in, out := &in.Queues, &out.Queues
+		*out = new(uint)
+		**out = **in
+	}
 	return
 }
 
@@ -603,6 +634,43 @@ func (in *ControllerDriver) DeepCopy() *ControllerDriver {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConversionMetadata) DeepCopyInto(out *ConversionMetadata) {
+	*out = *in
+	if in.Devices != nil {
+		in, out := &in.Devices, &out.Devices
+		*out = make([]ConvertedDeviceMetadata, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConversionMetadata.
+func (in *ConversionMetadata) DeepCopy() *ConversionMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(ConversionMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConvertedDeviceMetadata) DeepCopyInto(out *ConvertedDeviceMetadata) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConvertedDeviceMetadata.
+func (in *ConvertedDeviceMetadata) DeepCopy() *ConvertedDeviceMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(ConvertedDeviceMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Defaulter) DeepCopyInto(out *Defaulter) {
 	*out = *in
@@ -777,6 +845,11 @@ func (in *Disk) DeepCopyInto(out *Disk) {
 		*out = new(BlockIO)
 		**out = **in
 	}
+	if in.Shareable != nil {
+		in, out := &in.Shareable, &out.Shareable
+		*out = new(Shareable)
+		**out = **in
+	}
 	return
 }
 
@@ -949,6 +1022,16 @@ func (in *DomainGuestInfo) DeepCopyInto(out *DomainGuestInfo) {
 		*out = new(GuestOSInfo)
 		**out = **in
 	}
+	if in.Hostname != nil {
+		in, out := &in.Hostname, &out.Hostname
+		*out = new(string)
+		**out = **in
+	}
+	if in.Timezone != nil {
+		in, out := &in.Timezone, &out.Timezone
+		*out = new(Timezone)
+		**out = **in
+	}
 	if in.FSFreezeStatus != nil {
 		in, out := &in.FSFreezeStatus, &out.FSFreezeStatus
 		*out = new(FSFreeze)
@@ -1000,6 +1083,61 @@ func (in *DomainList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainSnapshot) DeepCopyInto(out *DomainSnapshot) {
+	*out = *in
+	out.XMLName = in.XMLName
+	out.Memory = in.Memory
+	if in.Disks != nil {
+		in, out := &in.Disks, &out.Disks
+		*out = make([]DomainSnapshotDisk, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainSnapshot.
+func (in *DomainSnapshot) DeepCopy() *DomainSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainSnapshotDisk) DeepCopyInto(out *DomainSnapshotDisk) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainSnapshotDisk.
+func (in *DomainSnapshotDisk) DeepCopy() *DomainSnapshotDisk {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainSnapshotDisk)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainSnapshotMemory) DeepCopyInto(out *DomainSnapshotMemory) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainSnapshotMemory.
+func (in *DomainSnapshotMemory) DeepCopy() *DomainSnapshotMemory {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainSnapshotMemory)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DomainSpec) DeepCopyInto(out *DomainSpec) {
 	*out = *in
@@ -1083,7 +1221,9 @@ func (in *DomainStatus) DeepCopyInto(out *DomainStatus) {
 		}
 	}
 	out.OSInfo = in.OSInfo
+	out.Timezone = in.Timezone
 	out.FSFreezeStatus = in.FSFreezeStatus
+	in.GuestPressure.DeepCopyInto(&out.GuestPressure)
 	return
 }
 
@@ -1115,6 +1255,27 @@ func (in *DomainSysInfo) DeepCopy() *DomainSysInfo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriftMetadata) DeepCopyInto(out *DriftMetadata) {
+	*out = *in
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftMetadata.
+func (in *DriftMetadata) DeepCopy() *DriftMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(DriftMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Entry) DeepCopyInto(out *Entry) {
 	*out = *in
@@ -1653,6 +1814,32 @@ func (in *GuestOSInfo) DeepCopy() *GuestOSInfo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuestPressure) DeepCopyInto(out *GuestPressure) {
+	*out = *in
+	if in.MemoryAvailablePercent != nil {
+		in, out := &in.MemoryAvailablePercent, &out.MemoryAvailablePercent
+		*out = new(uint)
+		**out = **in
+	}
+	if in.CPUStealPercent != nil {
+		in, out := &in.CPUStealPercent, &out.CPUStealPercent
+		*out = new(uint)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuestPressure.
+func (in *GuestPressure) DeepCopy() *GuestPressure {
+	if in == nil {
+		return nil
+	}
+	out := new(GuestPressure)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostDevice) DeepCopyInto(out *HostDevice) {
 	*out = *in
@@ -1818,7 +2005,7 @@ func (in *Interface) DeepCopyInto(out *Interface) {
 	if in.BandWidth != nil {
 		in, out := &in.BandWidth, &out.BandWidth
 		*out = new(BandWidth)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.BootOrder != nil {
 		in, out := &in.BootOrder, &out.BootOrder
@@ -1970,6 +2157,16 @@ func (in *KubeVirtMetadata) DeepCopyInto(out *KubeVirtMetadata) {
 		*out = new(AccessCredentialMetadata)
 		**out = **in
 	}
+	if in.Conversion != nil {
+		in, out := &in.Conversion, &out.Conversion
+		*out = new(ConversionMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = new(DriftMetadata)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2657,6 +2854,22 @@ func (in *SerialTarget) DeepCopy() *SerialTarget {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Shareable) DeepCopyInto(out *Shareable) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Shareable.
+func (in *Shareable) DeepCopy() *Shareable {
+	if in == nil {
+		return nil
+	}
+	out := new(Shareable)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Stats) DeepCopyInto(out *Stats) {
 	*out = *in
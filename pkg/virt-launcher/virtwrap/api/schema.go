@@ -27,6 +27,7 @@ import (
 
 	kubev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -109,7 +110,10 @@ type DomainStatus struct {
 	Reason         StateChangeReason
 	Interfaces     []InterfaceStatus
 	OSInfo         GuestOSInfo
+	Hostname       string
+	Timezone       Timezone
 	FSFreezeStatus FSFreeze
+	GuestPressure  GuestPressure
 }
 
 type DomainSysInfo struct {
@@ -118,6 +122,32 @@ type DomainSysInfo struct {
 	Timezone Timezone
 }
 
+// GuestPressure is sampled by virt-launcher from the balloon statistics and the steal time of the vCPUs,
+// values which the guest does not report are nil
+type GuestPressure struct {
+	// MemoryAvailablePercent is the share of the guest memory which applications can use without swapping
+	MemoryAvailablePercent *uint
+	// CPUStealPercent is the share of the time in which the vCPUs waited for a host CPU
+	CPUStealPercent *uint
+}
+
+const (
+	// GuestMemoryPressurePercent is the share of available guest memory below which the guest is under memory pressure
+	GuestMemoryPressurePercent = 10
+	// GuestCPUStealPercent is the share of steal time above which the vCPUs are considered starved by the host
+	GuestCPUStealPercent = 10
+)
+
+// UnderMemoryPressure tells if little of the guest memory is left for applications
+func (p GuestPressure) UnderMemoryPressure() bool {
+	return p.MemoryAvailablePercent != nil && *p.MemoryAvailablePercent < GuestMemoryPressurePercent
+}
+
+// UnderCPUSteal tells if the vCPUs spend much of their time waiting for a host CPU
+func (p GuestPressure) UnderCPUSteal() bool {
+	return p.CPUStealPercent != nil && *p.CPUStealPercent > GuestCPUStealPercent
+}
+
 type GuestOSInfo struct {
 	Name          string
 	KernelRelease string
@@ -164,6 +194,8 @@ type User struct {
 type DomainGuestInfo struct {
 	Interfaces     []InterfaceStatus
 	OSInfo         *GuestOSInfo
+	Hostname       *string
+	Timezone       *Timezone
 	FSFreezeStatus *FSFreeze
 }
 
@@ -339,6 +371,25 @@ type KubeVirtMetadata struct {
 	GracePeriod      *GracePeriodMetadata      `xml:"graceperiod,omitempty"`
 	Migration        *MigrationMetadata        `xml:"migration,omitempty"`
 	AccessCredential *AccessCredentialMetadata `xml:"accessCredential,omitempty"`
+	Conversion       *ConversionMetadata       `xml:"conversion,omitempty"`
+	Drift            *DriftMetadata            `xml:"drift,omitempty"`
+}
+
+// ConversionMetadata reports which devices of the VMI were converted into the domain
+type ConversionMetadata struct {
+	Devices []ConvertedDeviceMetadata `xml:"device"`
+}
+
+type ConvertedDeviceMetadata struct {
+	Type   string `xml:"type,attr"`
+	Name   string `xml:"name,attr,omitempty"`
+	Target string `xml:"target,attr,omitempty"`
+	Error  string `xml:"error,omitempty"`
+}
+
+// DriftMetadata lists the fields of the VMI whose configuration in the running domain differs from the VMI
+type DriftMetadata struct {
+	Fields []string `xml:"field"`
 }
 
 type AccessCredentialMetadata struct {
@@ -539,6 +590,7 @@ type Controller struct {
 // BEGIN ControllerDriver
 type ControllerDriver struct {
 	IOThread *uint `xml:"iothread,attr,omitempty"`
+	Queues   *uint `xml:"queues,attr,omitempty"`
 }
 
 // END ControllerDriver
@@ -561,6 +613,7 @@ type Disk struct {
 	Address      *Address      `xml:"address,omitempty"`
 	Model        string        `xml:"model,attr,omitempty"`
 	BlockIO      *BlockIO      `xml:"blockio,omitempty"`
+	Shareable    *Shareable    `xml:"shareable,omitempty"`
 }
 
 type DiskAuth struct {
@@ -576,6 +629,8 @@ type DiskSecret struct {
 
 type ReadOnly struct{}
 
+type Shareable struct{}
+
 type DiskSource struct {
 	Dev           string          `xml:"dev,attr,omitempty"`
 	File          string          `xml:"file,attr,omitempty"`
@@ -592,14 +647,15 @@ type DiskTarget struct {
 }
 
 type DiskDriver struct {
-	Cache       string `xml:"cache,attr,omitempty"`
-	ErrorPolicy string `xml:"error_policy,attr,omitempty"`
-	IO          string `xml:"io,attr,omitempty"`
-	Name        string `xml:"name,attr"`
-	Type        string `xml:"type,attr"`
-	IOThread    *uint  `xml:"iothread,attr,omitempty"`
-	Queues      *uint  `xml:"queues,attr,omitempty"`
-	Discard     string `xml:"discard,attr,omitempty"`
+	Cache        string `xml:"cache,attr,omitempty"`
+	ErrorPolicy  string `xml:"error_policy,attr,omitempty"`
+	IO           string `xml:"io,attr,omitempty"`
+	Name         string `xml:"name,attr"`
+	Type         string `xml:"type,attr"`
+	IOThread     *uint  `xml:"iothread,attr,omitempty"`
+	Queues       *uint  `xml:"queues,attr,omitempty"`
+	Discard      string `xml:"discard,attr,omitempty"`
+	DetectZeroes string `xml:"detect_zeroes,attr,omitempty"`
 }
 
 type DiskSourceHost struct {
@@ -697,6 +753,13 @@ type LinkState struct {
 }
 
 type BandWidth struct {
+	Inbound  *BandWidthRate `xml:"inbound,omitempty"`
+	Outbound *BandWidthRate `xml:"outbound,omitempty"`
+}
+
+type BandWidthRate struct {
+	// Average is the average bit rate in kilobytes per second
+	Average uint `xml:"average,attr"`
 }
 
 type BootOrder struct {
@@ -749,6 +812,53 @@ type Rom struct {
 	Enabled string `xml:"enabled,attr"`
 }
 
+// bitsPerKiB converts the bit rates of the VMI spec into the kilobytes per second of libvirt
+const bitsPerKiB = 8 * 1024
+
+// NewBandWidth translates the bandwidth limit of a VMI interface into the domain bandwidth.
+// Rates are rounded up to whole kilobytes per second, nil is returned when nothing is limited.
+func NewBandWidth(limit *v1.InterfaceBandwidthLimit) *BandWidth {
+	if limit == nil || (limit.Inbound == nil && limit.Outbound == nil) {
+		return nil
+	}
+	return &BandWidth{
+		Inbound:  newBandWidthRate(limit.Inbound),
+		Outbound: newBandWidthRate(limit.Outbound),
+	}
+}
+
+func newBandWidthRate(bitsPerSecond *resource.Quantity) *BandWidthRate {
+	if bitsPerSecond == nil {
+		return nil
+	}
+	return &BandWidthRate{Average: uint((bitsPerSecond.Value() + bitsPerKiB - 1) / bitsPerKiB)}
+}
+
+// Limit translates the domain bandwidth back into the bandwidth limit of a VMI interface
+func (b *BandWidth) Limit() *v1.InterfaceBandwidthLimit {
+	if b == nil || (b.Inbound.average() == 0 && b.Outbound.average() == 0) {
+		return nil
+	}
+	return &v1.InterfaceBandwidthLimit{
+		Inbound:  b.Inbound.bitsPerSecond(),
+		Outbound: b.Outbound.bitsPerSecond(),
+	}
+}
+
+func (r *BandWidthRate) average() uint {
+	if r == nil {
+		return 0
+	}
+	return r.Average
+}
+
+func (r *BandWidthRate) bitsPerSecond() *resource.Quantity {
+	if r.average() == 0 {
+		return nil
+	}
+	return resource.NewQuantity(int64(r.Average)*bitsPerKiB, resource.DecimalSI)
+}
+
 func NewUserDefinedAlias(aliasName string) *Alias {
 	return &Alias{name: aliasName, userDefined: true}
 }
@@ -1002,16 +1112,36 @@ type IOThreads struct {
 type SecretUsage struct {
 	Type   string `xml:"type,attr"`
 	Target string `xml:"target,omitempty"`
+	Name   string `xml:"name,omitempty"`
 }
 
 type SecretSpec struct {
 	XMLName     xml.Name    `xml:"secret"`
 	Ephemeral   string      `xml:"ephemeral,attr"`
 	Private     string      `xml:"private,attr"`
+	UUID        string      `xml:"uuid,omitempty"`
 	Description string      `xml:"description,omitempty"`
 	Usage       SecretUsage `xml:"usage,omitempty"`
 }
 
+// DomainSnapshot is the snapshot of a running domain as described in
+// https://libvirt.org/formatsnapshot.html
+type DomainSnapshot struct {
+	XMLName xml.Name             `xml:"domainsnapshot"`
+	Memory  DomainSnapshotMemory `xml:"memory"`
+	Disks   []DomainSnapshotDisk `xml:"disks>disk"`
+}
+
+type DomainSnapshotMemory struct {
+	Snapshot string `xml:"snapshot,attr"`
+	File     string `xml:"file,attr,omitempty"`
+}
+
+type DomainSnapshotDisk struct {
+	Name     string `xml:"name,attr"`
+	Snapshot string `xml:"snapshot,attr"`
+}
+
 func NewMinimalDomainSpec(vmiName string) *DomainSpec {
 	precond.MustNotBeEmpty(vmiName)
 	domain := &DomainSpec{}
@@ -27,6 +27,9 @@ import (
 	. "github.com/onsi/ginkgo"
 	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	v1 "kubevirt.io/client-go/api/v1"
 )
 
 var exampleXMLwithNoneMemballoon string
@@ -652,3 +655,32 @@ var _ = Describe("JSON marshal of the alias of a domain device", func() {
 		Expect(newAlias.IsUserDefined()).To(BeTrue())
 	})
 })
+
+var _ = Describe("Interface bandwidth", func() {
+	It("should marshal the average rates into xml", func() {
+		bandwidth := &BandWidth{Inbound: &BandWidthRate{Average: 1000}}
+		xmlBytes, err := xml.Marshal(bandwidth)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(xmlBytes)).To(Equal(`<BandWidth><inbound average="1000"></inbound></BandWidth>`))
+	})
+	It("should not limit anything without a limit", func() {
+		Expect(NewBandWidth(nil)).To(BeNil())
+		Expect(NewBandWidth(&v1.InterfaceBandwidthLimit{})).To(BeNil())
+		var bandwidth *BandWidth
+		Expect(bandwidth.Limit()).To(BeNil())
+		Expect((&BandWidth{Outbound: &BandWidthRate{}}).Limit()).To(BeNil())
+	})
+	It("should round bit rates up to kilobytes per second", func() {
+		inbound := resource.MustParse("100M")
+		outbound := resource.MustParse("1")
+		bandwidth := NewBandWidth(&v1.InterfaceBandwidthLimit{Inbound: &inbound, Outbound: &outbound})
+		Expect(bandwidth.Inbound.Average).To(Equal(uint(12208)))
+		Expect(bandwidth.Outbound.Average).To(Equal(uint(1)))
+	})
+	It("should translate the domain bandwidth back into bits per second", func() {
+		limit := (&BandWidth{Inbound: &BandWidthRate{Average: 1000}}).Limit()
+		Expect(limit.Outbound).To(BeNil())
+		Expect(limit.Inbound.Value()).To(Equal(int64(8192000)))
+		Expect(NewBandWidth(limit)).To(Equal(&BandWidth{Inbound: &BandWidthRate{Average: 1000}}))
+	})
+})
@@ -30,10 +30,13 @@ type InfoServer struct{}
 
 func (i InfoServer) Info(context.Context, *info.CmdInfoRequest) (*info.CmdInfoResponse, error) {
 
-	// since this is the first versioned version, we only support the current versions
-	// add older versions as soon as they are supported
+	// revisions only add to the protocol, so all of them down to the oldest compatible one are served
+	var versions []uint32
+	for version := uint32(cmdv1.CmdVersion); version >= cmdv1.MinCompatibleCmdVersion; version-- {
+		versions = append(versions, version)
+	}
 	return &info.CmdInfoResponse{
-		SupportedCmdVersions: []uint32{cmdv1.CmdVersion},
+		SupportedCmdVersions: versions,
 	}, nil
 
 }
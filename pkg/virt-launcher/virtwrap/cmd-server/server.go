@@ -86,6 +86,53 @@ func getMigrationOptionsFromRequest(request *cmdv1.MigrationRequest) (*cmdclient
 	return options, nil
 }
 
+func getMemoryUploadOptionsFromRequest(request *cmdv1.MemoryUploadRequest) (*v1.MemorySnapshotOptions, error) {
+
+	if request.Options == nil {
+		return nil, fmt.Errorf("memory upload options object not present in command server request")
+	}
+
+	var options *v1.MemorySnapshotOptions
+	if err := json.Unmarshal(request.Options, &options); err != nil {
+		return nil, fmt.Errorf("no valid memory upload options object present in command server request: %v", err)
+	}
+	if options == nil {
+		return nil, fmt.Errorf("no memory upload options present in command server request")
+	}
+
+	return options, nil
+}
+
+func getLinkStateOptionsFromRequest(request *cmdv1.LinkStateRequest) (*v1.SetLinkStateOptions, error) {
+
+	if request.Options == nil {
+		return nil, fmt.Errorf("link state options object not present in command server request")
+	}
+
+	var options *v1.SetLinkStateOptions
+	if err := json.Unmarshal(request.Options, &options); err != nil {
+		return nil, fmt.Errorf("no valid link state options object present in command server request: %v", err)
+	}
+	if options == nil {
+		return nil, fmt.Errorf("no link state options present in command server request")
+	}
+
+	return options, nil
+}
+
+func getMemoryUploadCredentialsFromRequest(request *cmdv1.MemoryUploadRequest) (*cmdclient.ObjectStorageCredentials, error) {
+	if len(request.Credentials) == 0 {
+		return nil, nil
+	}
+
+	var credentials *cmdclient.ObjectStorageCredentials
+	if err := json.Unmarshal(request.Credentials, &credentials); err != nil {
+		return nil, fmt.Errorf("no valid object storage credentials present in command server request: %v", err)
+	}
+
+	return credentials, nil
+}
+
 func getErrorMessage(err error) string {
 	if virErr := launcherErrors.FormatLibvirtError(err); virErr != "" {
 		return virErr
@@ -173,14 +220,14 @@ func (l *Launcher) SyncMigrationTarget(_ context.Context, request *cmdv1.VMIRequ
 
 }
 
-func (l *Launcher) SyncVirtualMachine(_ context.Context, request *cmdv1.VMIRequest) (*cmdv1.Response, error) {
+func (l *Launcher) SyncVirtualMachine(ctx context.Context, request *cmdv1.VMIRequest) (*cmdv1.Response, error) {
 
 	vmi, response := getVMIFromRequest(request.Vmi)
 	if !response.Success {
 		return response, nil
 	}
 
-	if _, err := l.domainManager.SyncVMI(vmi, l.allowEmulation, request.Options); err != nil {
+	if _, err := l.domainManager.SyncVMI(ctx, vmi, l.allowEmulation, request.Options); err != nil {
 		log.Log.Object(vmi).Reason(err).Errorf("Failed to sync vmi")
 		response.Success = false
 		response.Message = getErrorMessage(err)
@@ -259,6 +306,91 @@ func (l *Launcher) UnfreezeVirtualMachine(_ context.Context, request *cmdv1.VMIR
 	return response, nil
 }
 
+func (l *Launcher) SnapshotVirtualMachineMemory(_ context.Context, request *cmdv1.VMIRequest) (*cmdv1.Response, error) {
+	vmi, response := getVMIFromRequest(request.Vmi)
+	if !response.Success {
+		return response, nil
+	}
+
+	if err := l.domainManager.SnapshotVMIMemory(vmi); err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to snapshot vmi memory")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return response, nil
+	}
+
+	log.Log.Object(vmi).Info("Snapshotted vmi memory")
+	return response, nil
+}
+
+func (l *Launcher) UploadVirtualMachineMemory(_ context.Context, request *cmdv1.MemoryUploadRequest) (*cmdv1.MemoryUploadResponse, error) {
+	vmi, response := getVMIFromRequest(request.Vmi)
+	resp := &cmdv1.MemoryUploadResponse{
+		Response: response,
+	}
+	if !response.Success {
+		return resp, nil
+	}
+
+	options, err := getMemoryUploadOptionsFromRequest(request)
+	if err != nil {
+		response.Success = false
+		response.Message = err.Error()
+		return resp, nil
+	}
+
+	credentials, err := getMemoryUploadCredentialsFromRequest(request)
+	if err != nil {
+		response.Success = false
+		response.Message = err.Error()
+		return resp, nil
+	}
+
+	status, err := l.domainManager.UploadVMIMemory(vmi, options, credentials)
+	if errors.Is(err, cmdclient.ErrMemoryUploadCredentialsRequired) {
+		resp.CredentialsRequired = true
+		return resp, nil
+	} else if err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to upload vmi memory")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return resp, nil
+	}
+
+	statusJSON, err := json.Marshal(status)
+	if err != nil {
+		response.Success = false
+		response.Message = err.Error()
+		return resp, nil
+	}
+	resp.Status = string(statusJSON)
+	return resp, nil
+}
+
+func (l *Launcher) SetVirtualMachineInterfaceLinkState(_ context.Context, request *cmdv1.LinkStateRequest) (*cmdv1.Response, error) {
+	vmi, response := getVMIFromRequest(request.Vmi)
+	if !response.Success {
+		return response, nil
+	}
+
+	options, err := getLinkStateOptionsFromRequest(request)
+	if err != nil {
+		response.Success = false
+		response.Message = err.Error()
+		return response, nil
+	}
+
+	if err := l.domainManager.SetInterfaceLinkState(vmi, options); err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to set the link state of interface %s", options.InterfaceName)
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return response, nil
+	}
+
+	log.Log.Object(vmi).Infof("Set the link state of interface %s to %s", options.InterfaceName, options.State)
+	return response, nil
+}
+
 func (l *Launcher) KillVirtualMachine(_ context.Context, request *cmdv1.VMIRequest) (*cmdv1.Response, error) {
 
 	vmi, response := getVMIFromRequest(request.Vmi)
@@ -513,6 +645,26 @@ func (l *Launcher) GuestPing(ctx context.Context, request *cmdv1.GuestPingReques
 	return resp, nil
 }
 
+func (l *Launcher) Screenshot(_ context.Context, request *cmdv1.VMIRequest) (*cmdv1.ScreenshotResponse, error) {
+	vmi, response := getVMIFromRequest(request.Vmi)
+	resp := &cmdv1.ScreenshotResponse{
+		Response: response,
+	}
+	if !response.Success {
+		return resp, nil
+	}
+
+	screenshot, err := l.domainManager.ScreenshotVMI(vmi)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Errorf("Failed to take a screenshot of the vmi")
+		response.Success = false
+		response.Message = getErrorMessage(err)
+		return resp, nil
+	}
+	resp.Screenshot = screenshot
+	return resp, nil
+}
+
 func RunServer(socketPath string,
 	domainManager virtwrap.DomainManager,
 	stopChan chan struct{},
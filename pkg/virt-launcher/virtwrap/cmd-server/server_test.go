@@ -85,7 +85,7 @@ var _ = Describe("Virt remote commands", func() {
 		It("should start a vmi", func() {
 			vmi := v1.NewVMIReferenceFromName("testvmi")
 			domain := api.NewMinimalDomain("testvmi")
-			domainManager.EXPECT().SyncVMI(vmi, allowEmulation, &cmdv1.VirtualMachineOptions{}).Return(&domain.Spec, nil)
+			domainManager.EXPECT().SyncVMI(gomock.Any(), vmi, allowEmulation, &cmdv1.VirtualMachineOptions{}).Return(&domain.Spec, nil)
 
 			err := client.SyncVirtualMachine(vmi, &cmdv1.VirtualMachineOptions{})
 			Expect(err).ToNot(HaveOccurred())
@@ -120,6 +120,95 @@ var _ = Describe("Virt remote commands", func() {
 			Expect(err).ToNot(HaveOccurred())
 		})
 
+		It("should take a screenshot of a vmi", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().ScreenshotVMI(vmi).Return([]byte("png"), nil)
+			screenshot, err := client.Screenshot(vmi)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(screenshot).To(Equal([]byte("png")))
+		})
+
+		It("should report screenshot failures", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			domainManager.EXPECT().ScreenshotVMI(vmi).Return(nil, errors.New("no graphics device"))
+			_, err := client.Screenshot(vmi)
+			Expect(err).To(MatchError(ContainSubstring("no graphics device")))
+		})
+
+		It("should report the status of a vmi memory upload", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			options := &v1.MemorySnapshotOptions{
+				ObjectStorage: &v1.MemorySnapshotObjectStorage{
+					Endpoint: "https://minio.local",
+					Bucket:   "memory",
+					Key:      "vmsnapshot-memory",
+				},
+			}
+			domainManager.EXPECT().UploadVMIMemory(vmi, options, nil).Return(&v1.MemorySnapshotUploadStatus{
+				Phase:    v1.MemorySnapshotUploadSucceeded,
+				Checksum: "abc-1",
+			}, nil)
+			status, err := client.UploadVirtualMachineMemory(vmi, options, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(status.Phase).To(Equal(v1.MemorySnapshotUploadSucceeded))
+			Expect(status.Checksum).To(Equal("abc-1"))
+		})
+
+		It("should ask for the object storage credentials to start a vmi memory upload", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			options := &v1.MemorySnapshotOptions{
+				ObjectStorage: &v1.MemorySnapshotObjectStorage{
+					Endpoint: "https://minio.local",
+					Bucket:   "memory",
+					Key:      "vmsnapshot-memory",
+				},
+			}
+			credentials := &cmdclient.ObjectStorageCredentials{AccessKeyID: "access", SecretAccessKey: "secret"}
+			domainManager.EXPECT().UploadVMIMemory(vmi, options, nil).Return(nil, cmdclient.ErrMemoryUploadCredentialsRequired)
+			_, err := client.UploadVirtualMachineMemory(vmi, options, nil)
+			Expect(err).To(MatchError(cmdclient.ErrMemoryUploadCredentialsRequired))
+
+			domainManager.EXPECT().UploadVMIMemory(vmi, options, credentials).Return(&v1.MemorySnapshotUploadStatus{
+				Phase: v1.MemorySnapshotUploadInProgress,
+			}, nil)
+			status, err := client.UploadVirtualMachineMemory(vmi, options, credentials)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(status.Phase).To(Equal(v1.MemorySnapshotUploadInProgress))
+		})
+
+		It("should save the vmi memory to the memory dump volume without object storage", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			options := &v1.MemorySnapshotOptions{}
+			domainManager.EXPECT().UploadVMIMemory(vmi, options, nil).Return(&v1.MemorySnapshotUploadStatus{
+				Phase: v1.MemorySnapshotUploadInProgress,
+			}, nil)
+			status, err := client.UploadVirtualMachineMemory(vmi, options, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(status.Phase).To(Equal(v1.MemorySnapshotUploadInProgress))
+		})
+
+		It("should set the link state of an interface", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			options := &v1.SetLinkStateOptions{
+				InterfaceName: "default",
+				State:         v1.InterfaceLinkStateDown,
+			}
+			domainManager.EXPECT().SetInterfaceLinkState(vmi, options).Return(nil)
+			err := client.SetInterfaceLinkState(vmi, options)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should report link state failures", func() {
+			vmi := v1.NewVMIReferenceFromName("testvmi")
+			options := &v1.SetLinkStateOptions{
+				InterfaceName: "other",
+				State:         v1.InterfaceLinkStateUp,
+			}
+			domainManager.EXPECT().SetInterfaceLinkState(vmi, options).Return(errors.New("interface other not found in the domain"))
+			err := client.SetInterfaceLinkState(vmi, options)
+			Expect(err).To(MatchError(ContainSubstring("interface other not found")))
+		})
+
 		It("should pause a vmi", func() {
 			vmi := v1.NewVMIReferenceFromName("testvmi")
 			domainManager.EXPECT().PauseVMI(vmi)
@@ -374,6 +463,14 @@ var _ = Describe("Virt remote commands", func() {
 		})
 	})
 
+	Describe("Info server", func() {
+		It("should advertise all compatible versions", func() {
+			resp, err := InfoServer{}.Info(context.TODO(), &info.CmdInfoRequest{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.SupportedCmdVersions).To(Equal([]uint32{cmdv1.CmdVersion, cmdv1.LinkStateCmdVersion, cmdv1.MemoryUploadCmdVersion, cmdv1.ScreenshotCmdVersion, cmdv1.MemorySnapshotCmdVersion, cmdv1.MinCompatibleCmdVersion}))
+		})
+	})
+
 	Describe("Version mismatch", func() {
 
 		var err error
@@ -26,6 +26,7 @@ package converter
 */
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -49,6 +50,9 @@ import (
 
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/device"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/device/hostdevice"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/device/hostdevice/generic"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/device/hostdevice/gpu"
 
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/log"
@@ -63,6 +67,7 @@ import (
 	hostdisk "kubevirt.io/kubevirt/pkg/host-disk"
 	"kubevirt.io/kubevirt/pkg/ignition"
 	"kubevirt.io/kubevirt/pkg/util"
+	pvctypes "kubevirt.io/kubevirt/pkg/util/types"
 )
 
 type HostDeviceType string
@@ -119,6 +124,10 @@ type ConverterContext struct {
 	VolumesDiscardIgnore  []string
 	Topology              *cmdv1.Topology
 	PodNetInterfaces      *netutiltype.InterfaceResponse
+	// ResolvConfReader, PodInterfaceNamer and DeviceInfoProvider default to reading from the pod when unset
+	ResolvConfReader   ResolvConfReader
+	PodInterfaceNamer  PodInterfaceNamer
+	DeviceInfoProvider DeviceInfoProvider
 }
 
 func contains(volumes []string, name string) bool {
@@ -222,6 +231,25 @@ func Convert_v1_Disk_To_api_Disk(c *ConverterContext, diskDevice *v1.Disk, disk
 	return nil
 }
 
+// setDiscardAndDetectZeroes applies the discard and detect_zeroes options of the disk on top of the defaults
+// picked by the volume conversion. On thin-provisioned block volumes zero writes are turned into discards,
+// so that the guest releases the space back to the storage system.
+func setDiscardAndDetectZeroes(c *ConverterContext, diskDevice *v1.Disk, disk *api.Disk) {
+	if diskDevice.Disk == nil && diskDevice.LUN == nil {
+		return
+	}
+	if diskDevice.Discard != "" {
+		disk.Driver.Discard = string(diskDevice.Discard)
+	}
+	if diskDevice.DetectZeroes != "" {
+		disk.Driver.DetectZeroes = string(diskDevice.DetectZeroes)
+		return
+	}
+	if disk.Type == "block" && disk.Driver.Discard == string(v1.DiscardUnmap) && !contains(c.VolumesDiscardIgnore, diskDevice.Name) {
+		disk.Driver.DetectZeroes = string(v1.DetectZeroesUnmap)
+	}
+}
+
 type DirectIOChecker interface {
 	CheckBlockDevice(path string) (bool, error)
 	CheckFile(path string) (bool, error)
@@ -356,6 +384,14 @@ func SetDriverCacheMode(disk *api.Disk, directIOChecker DirectIOChecker) error {
 	mode := v1.DriverCache(disk.Driver.Cache)
 	isBlockDev := false
 
+	if disk.Type == "network" {
+		// network disks are accessed by QEMU directly and don't depend on the file system of the pod
+		if mode == "" {
+			disk.Driver.Cache = string(v1.CacheNone)
+		}
+		return nil
+	}
+
 	if disk.Source.File != "" {
 		path = disk.Source.File
 	} else if disk.Source.Dev != "" {
@@ -528,6 +564,22 @@ func Add_Agent_To_api_Channel() (channel api.Channel) {
 	return
 }
 
+// Convert_v1_SerialChannel_To_api_Channel creates a virtio-serial channel, qemu listens on its socket in the
+// shared channel sockets directory
+func Convert_v1_SerialChannel_To_api_Channel(source v1.SerialChannel) api.Channel {
+	return api.Channel{
+		Type: "unix",
+		Source: &api.ChannelSource{
+			Mode: "bind",
+			Path: filepath.Join(util.VirtChannelSocketsDir, source.Name+".sock"),
+		},
+		Target: &api.ChannelTarget{
+			Name: source.Target,
+			Type: "virtio",
+		},
+	}
+}
+
 func Convert_v1_Volume_To_api_Disk(source *v1.Volume, disk *api.Disk, c *ConverterContext, diskIndex int) error {
 
 	if source.ContainerDisk != nil {
@@ -555,7 +607,7 @@ func Convert_v1_Volume_To_api_Disk(source *v1.Volume, disk *api.Disk, c *Convert
 	}
 
 	if source.Ephemeral != nil {
-		return Convert_v1_EphemeralVolumeSource_To_api_Disk(source.Name, disk, c)
+		return Convert_v1_EphemeralVolumeSource_To_api_Disk(source.Name, source.Ephemeral, disk, c)
 	}
 	if source.EmptyDisk != nil {
 		return Convert_v1_EmptyDiskSource_To_api_Disk(source.Name, source.EmptyDisk, disk)
@@ -575,6 +627,9 @@ func Convert_v1_Volume_To_api_Disk(source *v1.Volume, disk *api.Disk, c *Convert
 	if source.DownwardMetrics != nil {
 		return Convert_v1_DownwardMetricSource_To_api_Disk(disk, c)
 	}
+	if source.NetworkDisk != nil {
+		return Convert_v1_NetworkDiskSource_To_api_Disk(source.Name, source.NetworkDisk, disk)
+	}
 
 	return fmt.Errorf("disk %s references an unsupported source", disk.Alias.GetName())
 }
@@ -681,6 +736,43 @@ func Convert_v1_FilesystemVolumeSource_To_api_Disk(volumeName string, disk *api.
 	return nil
 }
 
+// Convert_v1_NetworkDiskSource_To_api_Disk takes a network disk source and builds the domain Disk representation,
+// which lets QEMU access the disk directly over the network
+func Convert_v1_NetworkDiskSource_To_api_Disk(volumeName string, source *v1.NetworkDiskSource, disk *api.Disk) error {
+	disk.Type = "network"
+	disk.Driver.Type = "raw"
+	disk.Driver.ErrorPolicy = "stop"
+	disk.Source.Protocol = string(source.Protocol)
+	disk.Source.Name = source.Name
+	disk.Source.Host = &api.DiskSourceHost{
+		Name: source.Host.Name,
+		Port: source.Host.Port,
+	}
+	if source.Auth != nil {
+		disk.Auth = &api.DiskAuth{
+			Username: source.Auth.Username,
+			Secret: &api.DiskSecret{
+				Type:  GetNetworkDiskSecretType(source.Protocol),
+				Usage: GetNetworkDiskSecretUsage(volumeName),
+			},
+		}
+	}
+	return nil
+}
+
+// GetNetworkDiskSecretType returns the libvirt secret type holding the credentials for the given protocol
+func GetNetworkDiskSecretType(protocol v1.NetworkDiskProtocol) string {
+	if protocol == v1.NetworkDiskProtocolRBD {
+		return "ceph"
+	}
+	return string(protocol)
+}
+
+// GetNetworkDiskSecretUsage returns the usage of the libvirt secret holding the credentials of a network disk
+func GetNetworkDiskSecretUsage(volumeName string) string {
+	return "network-disk-" + volumeName
+}
+
 // Convert_v1_Hotplug_FilesystemVolumeSource_To_api_Disk takes a FS source and builds the KVM Disk representation
 func Convert_v1_Hotplug_FilesystemVolumeSource_To_api_Disk(volumeName string, disk *api.Disk, volumesDiscardIgnore []string) error {
 	disk.Type = "file"
@@ -771,15 +863,15 @@ func Convert_v1_DownwardMetricSource_To_api_Disk(disk *api.Disk, c *ConverterCon
 	return nil
 }
 
-func Convert_v1_EmptyDiskSource_To_api_Disk(volumeName string, _ *v1.EmptyDiskSource, disk *api.Disk) error {
+func Convert_v1_EmptyDiskSource_To_api_Disk(volumeName string, diskSource *v1.EmptyDiskSource, disk *api.Disk) error {
 	if disk.Type == "lun" {
 		return fmt.Errorf("device %s is of type lun. Not compatible with a file based disk", disk.Alias.GetName())
 	}
 
 	disk.Type = "file"
-	disk.Driver.Type = "qcow2"
+	disk.Driver.Type = string(emptydisk.GetImageFormat(diskSource))
 	disk.Driver.Discard = "unmap"
-	disk.Source.File = emptydisk.NewEmptyDiskCreator().FilePathForVolumeName(volumeName)
+	disk.Source.File = emptydisk.NewEmptyDiskCreator().FilePathForVolumeName(volumeName, emptydisk.GetImageFormat(diskSource))
 	disk.Driver.ErrorPolicy = "stop"
 
 	return nil
@@ -808,19 +900,24 @@ func Convert_v1_ContainerDiskSource_To_api_Disk(volumeName string, _ *v1.Contain
 	return nil
 }
 
-func Convert_v1_EphemeralVolumeSource_To_api_Disk(volumeName string, disk *api.Disk, c *ConverterContext) error {
+func Convert_v1_EphemeralVolumeSource_To_api_Disk(volumeName string, diskSource *v1.EphemeralVolumeSource, disk *api.Disk, c *ConverterContext) error {
 	disk.Type = "file"
-	disk.Driver.Type = "qcow2"
+	disk.Driver.Type = string(ephemeraldisk.GetImageFormat(diskSource))
 	disk.Driver.ErrorPolicy = "stop"
-	disk.Driver.Discard = "unmap"
 	disk.Source.File = c.EphemeraldiskCreator.GetFilePath(volumeName)
+	if !contains(c.VolumesDiscardIgnore, volumeName) {
+		disk.Driver.Discard = "unmap"
+	}
+
+	// A raw image is a standalone copy of the PVC and has no backing store
+	if disk.Driver.Type == string(v1.DiskImageFormatRaw) {
+		return nil
+	}
+
 	disk.BackingStore = &api.BackingStore{
 		Format: &api.BackingStoreFormat{},
 		Source: &api.DiskSource{},
 	}
-	if !contains(c.VolumesDiscardIgnore, volumeName) {
-		disk.Driver.Discard = "unmap"
-	}
 
 	backingDisk := &api.Disk{Driver: &api.DiskDriver{}}
 	err := Convert_v1_FilesystemVolumeSource_To_api_Disk(volumeName, backingDisk, c.VolumesDiscardIgnore)
@@ -1063,6 +1160,12 @@ func ConvertV1ToAPIBalloning(source *v1.Devices, ballooning *api.MemBalloon, c *
 	}
 }
 
+// formatQEMUOption renders an option as name=value, commas in the value are
+// doubled so that QEMU does not read them as the start of another option
+func formatQEMUOption(option v1.QEMUOption) string {
+	return fmt.Sprintf("%s=%s", option.Name, strings.ReplaceAll(option.Value, ",", ",,"))
+}
+
 func initializeQEMUCmdAndQEMUArg(domain *api.Domain) {
 	if domain.Spec.QEMUCmd == nil {
 		domain.Spec.QEMUCmd = &api.Commandline{}
@@ -1073,11 +1176,19 @@ func initializeQEMUCmdAndQEMUArg(domain *api.Domain) {
 	}
 }
 
-func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInstance, domain *api.Domain, c *ConverterContext) (err error) {
+// Convert_v1_VirtualMachineInstance_To_api_Domain converts the VMI into the domain. Conversion does not stop at the
+// first device which can't be converted, the returned error aggregates the errors of all failing devices. Which
+// devices got converted is recorded in the KubeVirt metadata of the domain. The conversion is aborted once ctx is done.
+func Convert_v1_VirtualMachineInstance_To_api_Domain(ctx context.Context, vmi *v1.VirtualMachineInstance, domain *api.Domain, c *ConverterContext) (err error) {
 	precond.MustNotBeNil(vmi)
 	precond.MustNotBeNil(domain)
 	precond.MustNotBeNil(c)
 
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	report := newConversionReport()
+
 	domain.Spec.Name = api.VMINamespaceKeyFunc(vmi)
 	domain.ObjectMeta.Name = vmi.ObjectMeta.Name
 	domain.ObjectMeta.Namespace = vmi.ObjectMeta.Namespace
@@ -1124,6 +1235,9 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 
 	newChannel := Add_Agent_To_api_Channel()
 	domain.Spec.Devices.Channels = append(domain.Spec.Devices.Channels, newChannel)
+	for _, channel := range vmi.Spec.Domain.Devices.Channels {
+		domain.Spec.Devices.Channels = append(domain.Spec.Devices.Channels, Convert_v1_SerialChannel_To_api_Channel(channel))
+	}
 
 	domain.Spec.Metadata.KubeVirt.UID = vmi.UID
 	gracePeriodSeconds := v1.DefaultGracePeriodSeconds
@@ -1373,28 +1487,14 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 
 	prefixMap := newDeviceNamer(vmi.Status.VolumeStatus, vmi.Spec.Domain.Devices.Disks)
 	for _, disk := range vmi.Spec.Domain.Devices.Disks {
-		newDisk := api.Disk{}
-
-		err := Convert_v1_Disk_To_api_Disk(c, &disk, &newDisk, prefixMap, numBlkQueues)
-		if err != nil {
+		if err := ctx.Err(); err != nil {
 			return err
 		}
-		volume := volumes[disk.Name]
-		if volume == nil {
-			return fmt.Errorf("No matching volume with name %s found", disk.Name)
-		}
 
-		if _, ok := c.HotplugVolumes[disk.Name]; !ok {
-			err = Convert_v1_Volume_To_api_Disk(volume, &newDisk, c, volumeIndices[disk.Name])
-		} else {
-			err = Convert_v1_Hotplug_Volume_To_api_Disk(volume, &newDisk, c)
-		}
+		newDisk, err := convertDisk(c, &disk, volumes[disk.Name], volumeIndices[disk.Name], prefixMap, numBlkQueues)
 		if err != nil {
-			return err
-		}
-
-		if err := Convert_v1_BlockSize_To_api_BlockIO(&disk, &newDisk); err != nil {
-			return err
+			report.failed(reportDeviceDisk, disk.Name, err)
+			continue
 		}
 
 		if useIOThreads {
@@ -1419,7 +1519,8 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 		hpStatus, hpOk := c.HotplugVolumes[disk.Name]
 		// if len(c.PermanentVolumes) == 0, it means the vmi is not ready yet, add all disks
 		if _, ok := c.PermanentVolumes[disk.Name]; ok || len(c.PermanentVolumes) == 0 || (hpOk && (hpStatus.Phase == v1.HotplugVolumeMounted || hpStatus.Phase == v1.VolumeReady)) {
-			domain.Spec.Devices.Disks = append(domain.Spec.Devices.Disks, newDisk)
+			domain.Spec.Devices.Disks = append(domain.Spec.Devices.Disks, *newDisk)
+			report.converted(reportDeviceDisk, disk.Name, newDisk.Target.Device)
 		}
 	}
 	// Handle virtioFS
@@ -1450,12 +1551,14 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 
 			volume := volumes[fs.Name]
 			if volume == nil {
-				return fmt.Errorf("No matching volume with name %s found", fs.Name)
+				report.failed(reportDeviceFilesystem, fs.Name, fmt.Errorf("No matching volume with name %s found", fs.Name))
+				continue
 			}
 			volDir, _ := filepath.Split(GetFilesystemVolumePath(volume.Name))
 			newFS.Source = &api.FilesystemSource{}
 			newFS.Source.Dir = volDir
 			domain.Spec.Devices.Filesystems = append(domain.Spec.Devices.Filesystems, newFS)
+			report.converted(reportDeviceFilesystem, fs.Name, fs.Name)
 		}
 	}
 
@@ -1463,30 +1566,36 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 		newWatchdog := &api.Watchdog{}
 		err := Convert_v1_Watchdog_To_api_Watchdog(vmi.Spec.Domain.Devices.Watchdog, newWatchdog, c)
 		if err != nil {
-			return err
+			report.failed(reportDeviceWatchdog, vmi.Spec.Domain.Devices.Watchdog.Name, err)
+		} else {
+			domain.Spec.Devices.Watchdog = newWatchdog
+			report.converted(reportDeviceWatchdog, vmi.Spec.Domain.Devices.Watchdog.Name, newWatchdog.Model)
 		}
-		domain.Spec.Devices.Watchdog = newWatchdog
 	}
 
 	if vmi.Spec.Domain.Devices.Rng != nil {
 		newRng := &api.Rng{}
 		err := Convert_v1_Rng_To_api_Rng(vmi.Spec.Domain.Devices.Rng, newRng, c)
 		if err != nil {
-			return err
+			report.failed(reportDeviceRng, "", err)
+		} else {
+			domain.Spec.Devices.Rng = newRng
+			report.converted(reportDeviceRng, "", newRng.Model)
 		}
-		domain.Spec.Devices.Rng = newRng
 	}
 
-	isUSBDevicePresent := false
+	isUSBDevicePresent := hasUSBDisk(vmi)
 	if vmi.Spec.Domain.Devices.Inputs != nil {
 		inputDevices := make([]api.Input, 0)
 		for i := range vmi.Spec.Domain.Devices.Inputs {
 			inputDevice := api.Input{}
 			err := Convert_v1_Input_To_api_InputDevice(&vmi.Spec.Domain.Devices.Inputs[i], &inputDevice)
 			if err != nil {
-				return err
+				report.failed(reportDeviceInput, vmi.Spec.Domain.Devices.Inputs[i].Name, err)
+				continue
 			}
 			inputDevices = append(inputDevices, inputDevice)
+			report.converted(reportDeviceInput, vmi.Spec.Domain.Devices.Inputs[i].Name, inputDevice.Bus)
 			if inputDevice.Bus == "usb" {
 				isUSBDevicePresent = true
 			}
@@ -1496,13 +1605,13 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 
 	isUSBRedirEnabled, err := Convert_v1_Usbredir_To_api_Usbredir(vmi, &domain.Spec.Devices, c)
 	if err != nil {
-		return err
+		report.failed(reportDeviceUsbredir, "", err)
 	}
 
 	domain.Spec.Devices.Ballooning = &api.MemBalloon{}
 	ConvertV1ToAPIBalloning(&vmi.Spec.Domain.Devices, domain.Spec.Devices.Ballooning, c)
 
-	//usb controller is turned on, only when user specify input device with usb bus,
+	//usb controller is turned on, only when user specify input device or disk with usb bus,
 	//otherwise it is turned off
 	//In ppc64le usb devices like mouse / keyboard are set by default,
 	//so we can't disable the controller otherwise we run into the following error:
@@ -1523,11 +1632,19 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 	}
 
 	if needsSCSIControler(vmi) {
-		domain.Spec.Devices.Controllers = append(domain.Spec.Devices.Controllers, api.Controller{
+		scsiController := api.Controller{
 			Type:  "scsi",
 			Index: "0",
 			Model: translateModel(c, "virtio"),
-		})
+		}
+		if getSCSIControllerModel(vmi) == v1.SCSIControllerModelLSILogic {
+			scsiController.Model = string(v1.SCSIControllerModelLSILogic)
+		} else if numBlkQueues != nil {
+			scsiController.Driver = &api.ControllerDriver{
+				Queues: numBlkQueues,
+			}
+		}
+		domain.Spec.Devices.Controllers = append(domain.Spec.Devices.Controllers, scsiController)
 	}
 
 	if vmi.Spec.Domain.Clock != nil {
@@ -1535,16 +1652,17 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 		newClock := &api.Clock{}
 		err := Convert_v1_Clock_To_api_Clock(clock, newClock)
 		if err != nil {
-			return err
+			report.failed(reportDeviceClock, "", err)
+		} else {
+			domain.Spec.Clock = newClock
 		}
-		domain.Spec.Clock = newClock
 	}
 
 	if vmi.Spec.Domain.Features != nil {
 		domain.Spec.Features = &api.Features{}
 		err := Convert_v1_Features_To_api_Features(vmi.Spec.Domain.Features, domain.Spec.Features, c)
 		if err != nil {
-			return err
+			report.failed(reportDeviceFeatures, "", err)
 		}
 	}
 
@@ -1656,6 +1774,7 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 
 	domain.Spec.Devices.HostDevices = append(domain.Spec.Devices.HostDevices, c.GenericHostDevices...)
 	domain.Spec.Devices.HostDevices = append(domain.Spec.Devices.HostDevices, c.GPUHostDevices...)
+	hostdevice.SortByPCIOrder(domain.Spec.Devices.HostDevices, hostDevicePCIOrders(vmi))
 
 	// This is needed to support a legacy approach to device assignment
 	// Append HostDevices to DomXML if GPU is requested
@@ -1724,13 +1843,22 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 		}
 	}
 
-	domainInterfaces, err := createDomainInterfaces(vmi, domain, c, virtioNetProhibited)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	domainInterfaces, err := createDomainInterfaces(vmi, domain, c, virtioNetProhibited, report)
 	if err != nil {
 		return err
 	}
 	domain.Spec.Devices.Interfaces = append(domain.Spec.Devices.Interfaces, domainInterfaces...)
 	domain.Spec.Devices.HostDevices = append(domain.Spec.Devices.HostDevices, c.SRIOVDevices...)
 
+	report.attach(domain)
+	report.log(vmi)
+	if err := report.err(); err != nil {
+		return err
+	}
+
 	// Add Ignition Command Line if present
 	ignitiondata, _ := vmi.Annotations[v1.IgnitionAnnotation]
 	if ignitiondata != "" && strings.Contains(ignitiondata, "ignition") {
@@ -1740,6 +1868,21 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 		domain.Spec.QEMUCmd.QEMUArg = append(domain.Spec.QEMUCmd.QEMUArg, api.Arg{Value: fmt.Sprintf("name=opt/com.coreos/config,file=%s", ignitionpath)})
 	}
 
+	// Add the allowlisted machine and global QEMU options if present
+	if machine := vmi.Spec.Domain.Machine; machine != nil && (len(machine.Options) > 0 || len(machine.Globals) > 0) {
+		initializeQEMUCmdAndQEMUArg(domain)
+		for _, option := range machine.Options {
+			domain.Spec.QEMUCmd.QEMUArg = append(domain.Spec.QEMUCmd.QEMUArg,
+				api.Arg{Value: "-machine"},
+				api.Arg{Value: formatQEMUOption(option)})
+		}
+		for _, global := range machine.Globals {
+			domain.Spec.QEMUCmd.QEMUArg = append(domain.Spec.QEMUCmd.QEMUArg,
+				api.Arg{Value: "-global"},
+				api.Arg{Value: formatQEMUOption(global)})
+		}
+	}
+
 	if val := vmi.Annotations[v1.PlacePCIDevicesOnRootComplex]; val == "true" {
 		if err := PlacePCIDevicesOnRootComplex(&domain.Spec); err != nil {
 			return err
@@ -1760,6 +1903,55 @@ func Convert_v1_VirtualMachineInstance_To_api_Domain(vmi *v1.VirtualMachineInsta
 	return nil
 }
 
+func convertDisk(c *ConverterContext, diskDevice *v1.Disk, volume *v1.Volume, diskIndex int, prefixMap map[string]deviceNamer, numBlkQueues *uint) (*api.Disk, error) {
+	newDisk := &api.Disk{}
+
+	if err := Convert_v1_Disk_To_api_Disk(c, diskDevice, newDisk, prefixMap, numBlkQueues); err != nil {
+		return nil, err
+	}
+	if volume == nil {
+		return nil, fmt.Errorf("No matching volume with name %s found", diskDevice.Name)
+	}
+
+	var err error
+	if _, ok := c.HotplugVolumes[diskDevice.Name]; !ok {
+		err = Convert_v1_Volume_To_api_Disk(volume, newDisk, c, diskIndex)
+	} else {
+		err = Convert_v1_Hotplug_Volume_To_api_Disk(volume, newDisk, c)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := Convert_v1_BlockSize_To_api_BlockIO(diskDevice, newDisk); err != nil {
+		return nil, err
+	}
+
+	setDiscardAndDetectZeroes(c, diskDevice, newDisk)
+	if err := setShareable(c, diskDevice, newDisk); err != nil {
+		return nil, err
+	}
+	return newDisk, nil
+}
+
+// setShareable allows multiple VMIs to attach a shareable disk. Shareable disks are attached without a host side
+// cache, and they have to be backed by block volumes which can be written from multiple nodes.
+func setShareable(c *ConverterContext, diskDevice *v1.Disk, disk *api.Disk) error {
+	if diskDevice.Shareable == nil || !*diskDevice.Shareable {
+		return nil
+	}
+	if disk.Type != "block" {
+		return fmt.Errorf("disk %s can not be shareable, it is not backed by a block volume", diskDevice.Name)
+	}
+	if volumeStatus, exists := c.PermanentVolumes[diskDevice.Name]; exists && volumeStatus.PersistentVolumeClaimInfo != nil &&
+		!pvctypes.HasSharedAccessMode(volumeStatus.PersistentVolumeClaimInfo.AccessModes) {
+		return fmt.Errorf("disk %s can not be shareable, its volume does not have the %s access mode", diskDevice.Name, k8sv1.ReadWriteMany)
+	}
+	disk.Shareable = &api.Shareable{}
+	disk.Driver.Cache = string(v1.CacheNone)
+	return nil
+}
+
 func getVirtualMemory(vmi *v1.VirtualMachineInstance) *resource.Quantity {
 	// In case that guest memory is explicitly set, return it
 	if vmi.Spec.Domain.Memory != nil && vmi.Spec.Domain.Memory.Guest != nil {
@@ -1814,6 +2006,22 @@ func getCPUTopology(vmi *v1.VirtualMachineInstance) *api.CPUTopology {
 	}
 }
 
+// hostDevicePCIOrders maps the aliases of the GPU and host devices of the VMI to their PCI order
+func hostDevicePCIOrders(vmi *v1.VirtualMachineInstance) map[string]uint {
+	pciOrders := map[string]uint{}
+	for _, gpuDevice := range vmi.Spec.Domain.Devices.GPUs {
+		if gpuDevice.PCIOrder != nil {
+			pciOrders[gpu.AliasPrefix+gpuDevice.Name] = *gpuDevice.PCIOrder
+		}
+	}
+	for _, hostDevice := range vmi.Spec.Domain.Devices.HostDevices {
+		if hostDevice.PCIOrder != nil {
+			pciOrders[generic.AliasPrefix+hostDevice.Name] = *hostDevice.PCIOrder
+		}
+	}
+	return pciOrders
+}
+
 func appendDomainEmulatorThreadPin(domain *api.Domain, allocatedCpu uint32) {
 	emulatorThread := api.CPUEmulatorPin{
 		CPUSet: strconv.Itoa(int(allocatedCpu)),
@@ -1932,6 +2140,14 @@ func GetImageInfo(imagePath string) (*containerdisk.DiskInfo, error) {
 	return info, err
 }
 
+func getSCSIControllerModel(vmi *v1.VirtualMachineInstance) v1.SCSIControllerModel {
+	scsiController := vmi.Spec.Domain.Devices.SCSIController
+	if scsiController == nil || scsiController.Model == "" {
+		return v1.SCSIControllerModelVirtio
+	}
+	return scsiController.Model
+}
+
 func needsSCSIControler(vmi *v1.VirtualMachineInstance) bool {
 	for _, disk := range vmi.Spec.Domain.Devices.Disks {
 		if disk.LUN != nil && disk.LUN.Bus == "scsi" {
@@ -1947,11 +2163,23 @@ func needsSCSIControler(vmi *v1.VirtualMachineInstance) bool {
 	return !vmi.Spec.Domain.Devices.DisableHotplug
 }
 
+func hasUSBDisk(vmi *v1.VirtualMachineInstance) bool {
+	for _, disk := range vmi.Spec.Domain.Devices.Disks {
+		if disk.Disk != nil && disk.Disk.Bus == "usb" {
+			return true
+		}
+		if disk.CDRom != nil && disk.CDRom.Bus == "usb" {
+			return true
+		}
+	}
+	return false
+}
+
 func getPrefixFromBus(bus string) string {
 	switch bus {
 	case "virtio":
 		return "vd"
-	case "sata", "scsi":
+	case "sata", "scsi", "usb":
 		return "sd"
 	case "fdc":
 		return "fd"
@@ -20,6 +20,7 @@
 package converter
 
 import (
+	"context"
 	"encoding/xml"
 	"fmt"
 	"io/ioutil"
@@ -639,13 +640,13 @@ var _ = Describe("Converter", func() {
     <disk device="disk" type="block">
       <source dev="/dev/pvc_block_test"></source>
       <target bus="sata" dev="sdg"></target>
-      <driver cache="writethrough" error_policy="stop" name="qemu" type="raw" iothread="1" discard="unmap"></driver>
+      <driver cache="writethrough" error_policy="stop" name="qemu" type="raw" iothread="1" discard="unmap" detect_zeroes="unmap"></driver>
       <alias name="ua-pvc_block_test"></alias>
     </disk>
     <disk device="disk" type="block">
       <source dev="/dev/dv_block_test"></source>
       <target bus="sata" dev="sdh"></target>
-      <driver cache="writethrough" error_policy="stop" name="qemu" type="raw" iothread="1" discard="unmap"></driver>
+      <driver cache="writethrough" error_policy="stop" name="qemu" type="raw" iothread="1" discard="unmap" detect_zeroes="unmap"></driver>
       <alias name="ua-dv_block_test"></alias>
     </disk>
     <disk device="disk" type="file">
@@ -696,6 +697,27 @@ var _ = Describe("Converter", func() {
       <graceperiod>
         <deletionGracePeriodSeconds>5</deletionGracePeriodSeconds>
       </graceperiod>
+      <conversion>
+        <device type="disk" name="myvolume" target="vda"></device>
+        <device type="disk" name="nocloud" target="vdb"></device>
+        <device type="disk" name="cdrom_tray_unspecified" target="sda"></device>
+        <device type="disk" name="cdrom_tray_open" target="sdb"></device>
+        <device type="disk" name="floppy_tray_unspecified" target="fda"></device>
+        <device type="disk" name="floppy_tray_open" target="fdb"></device>
+        <device type="disk" name="should_default_to_disk" target="sdc"></device>
+        <device type="disk" name="ephemeral_pvc" target="sdd"></device>
+        <device type="disk" name="secret_test" target="sde"></device>
+        <device type="disk" name="configmap_test" target="sdf"></device>
+        <device type="disk" name="pvc_block_test" target="sdg"></device>
+        <device type="disk" name="dv_block_test" target="sdh"></device>
+        <device type="disk" name="serviceaccount_test" target="sdi"></device>
+        <device type="disk" name="sysprep" target="sdj"></device>
+        <device type="disk" name="sysprep_secret" target="sdk"></device>
+        <device type="watchdog" name="mywatchdog" target="i6300esb"></device>
+        <device type="rng" target="virtio-non-transitional"></device>
+        <device type="input" name="tablet0" target="virtio"></device>
+        <device type="interface" name="default" target="ethernet"></device>
+      </conversion>
     </kubevirt>
   </metadata>
   <features>
@@ -854,13 +876,13 @@ var _ = Describe("Converter", func() {
     <disk device="disk" type="block">
       <source dev="/dev/pvc_block_test"></source>
       <target bus="sata" dev="sdg"></target>
-      <driver cache="writethrough" error_policy="stop" name="qemu" type="raw" iothread="1" discard="unmap"></driver>
+      <driver cache="writethrough" error_policy="stop" name="qemu" type="raw" iothread="1" discard="unmap" detect_zeroes="unmap"></driver>
       <alias name="ua-pvc_block_test"></alias>
     </disk>
     <disk device="disk" type="block">
       <source dev="/dev/dv_block_test"></source>
       <target bus="sata" dev="sdh"></target>
-      <driver cache="writethrough" error_policy="stop" name="qemu" type="raw" iothread="1" discard="unmap"></driver>
+      <driver cache="writethrough" error_policy="stop" name="qemu" type="raw" iothread="1" discard="unmap" detect_zeroes="unmap"></driver>
       <alias name="ua-dv_block_test"></alias>
     </disk>
     <disk device="disk" type="file">
@@ -911,6 +933,27 @@ var _ = Describe("Converter", func() {
       <graceperiod>
         <deletionGracePeriodSeconds>5</deletionGracePeriodSeconds>
       </graceperiod>
+      <conversion>
+        <device type="disk" name="myvolume" target="vda"></device>
+        <device type="disk" name="nocloud" target="vdb"></device>
+        <device type="disk" name="cdrom_tray_unspecified" target="sda"></device>
+        <device type="disk" name="cdrom_tray_open" target="sdb"></device>
+        <device type="disk" name="floppy_tray_unspecified" target="fda"></device>
+        <device type="disk" name="floppy_tray_open" target="fdb"></device>
+        <device type="disk" name="should_default_to_disk" target="sdc"></device>
+        <device type="disk" name="ephemeral_pvc" target="sdd"></device>
+        <device type="disk" name="secret_test" target="sde"></device>
+        <device type="disk" name="configmap_test" target="sdf"></device>
+        <device type="disk" name="pvc_block_test" target="sdg"></device>
+        <device type="disk" name="dv_block_test" target="sdh"></device>
+        <device type="disk" name="serviceaccount_test" target="sdi"></device>
+        <device type="disk" name="sysprep" target="sdj"></device>
+        <device type="disk" name="sysprep_secret" target="sdk"></device>
+        <device type="watchdog" name="mywatchdog" target="i6300esb"></device>
+        <device type="rng" target="virtio-non-transitional"></device>
+        <device type="input" name="tablet0" target="virtio"></device>
+        <device type="interface" name="default" target="ethernet"></device>
+      </conversion>
     </kubevirt>
   </metadata>
   <features>
@@ -1072,13 +1115,13 @@ var _ = Describe("Converter", func() {
     <disk device="disk" type="block">
       <source dev="/dev/pvc_block_test"></source>
       <target bus="sata" dev="sdg"></target>
-      <driver cache="writethrough" error_policy="stop" name="qemu" type="raw" iothread="1" discard="unmap"></driver>
+      <driver cache="writethrough" error_policy="stop" name="qemu" type="raw" iothread="1" discard="unmap" detect_zeroes="unmap"></driver>
       <alias name="ua-pvc_block_test"></alias>
     </disk>
     <disk device="disk" type="block">
       <source dev="/dev/dv_block_test"></source>
       <target bus="sata" dev="sdh"></target>
-      <driver cache="writethrough" error_policy="stop" name="qemu" type="raw" iothread="1" discard="unmap"></driver>
+      <driver cache="writethrough" error_policy="stop" name="qemu" type="raw" iothread="1" discard="unmap" detect_zeroes="unmap"></driver>
       <alias name="ua-dv_block_test"></alias>
     </disk>
     <disk device="disk" type="file">
@@ -1129,6 +1172,27 @@ var _ = Describe("Converter", func() {
       <graceperiod>
         <deletionGracePeriodSeconds>5</deletionGracePeriodSeconds>
       </graceperiod>
+      <conversion>
+        <device type="disk" name="myvolume" target="vda"></device>
+        <device type="disk" name="nocloud" target="vdb"></device>
+        <device type="disk" name="cdrom_tray_unspecified" target="sda"></device>
+        <device type="disk" name="cdrom_tray_open" target="sdb"></device>
+        <device type="disk" name="floppy_tray_unspecified" target="fda"></device>
+        <device type="disk" name="floppy_tray_open" target="fdb"></device>
+        <device type="disk" name="should_default_to_disk" target="sdc"></device>
+        <device type="disk" name="ephemeral_pvc" target="sdd"></device>
+        <device type="disk" name="secret_test" target="sde"></device>
+        <device type="disk" name="configmap_test" target="sdf"></device>
+        <device type="disk" name="pvc_block_test" target="sdg"></device>
+        <device type="disk" name="dv_block_test" target="sdh"></device>
+        <device type="disk" name="serviceaccount_test" target="sdi"></device>
+        <device type="disk" name="sysprep" target="sdj"></device>
+        <device type="disk" name="sysprep_secret" target="sdk"></device>
+        <device type="watchdog" name="mywatchdog" target="i6300esb"></device>
+        <device type="rng" target="virtio-non-transitional"></device>
+        <device type="input" name="tablet0" target="virtio"></device>
+        <device type="interface" name="default" target="ethernet"></device>
+      </conversion>
     </kubevirt>
   </metadata>
   <features>
@@ -1298,13 +1362,13 @@ var _ = Describe("Converter", func() {
     <disk device="disk" type="block">
       <source dev="/dev/pvc_block_test"></source>
       <target bus="sata" dev="sdg"></target>
-      <driver cache="writethrough" error_policy="stop" name="qemu" type="raw" iothread="1" discard="unmap"></driver>
+      <driver cache="writethrough" error_policy="stop" name="qemu" type="raw" iothread="1" discard="unmap" detect_zeroes="unmap"></driver>
       <alias name="ua-pvc_block_test"></alias>
     </disk>
     <disk device="disk" type="block">
       <source dev="/dev/dv_block_test"></source>
       <target bus="sata" dev="sdh"></target>
-      <driver cache="writethrough" error_policy="stop" name="qemu" type="raw" iothread="1" discard="unmap"></driver>
+      <driver cache="writethrough" error_policy="stop" name="qemu" type="raw" iothread="1" discard="unmap" detect_zeroes="unmap"></driver>
       <alias name="ua-dv_block_test"></alias>
     </disk>
     <disk device="disk" type="file">
@@ -1358,6 +1422,27 @@ var _ = Describe("Converter", func() {
       <graceperiod>
         <deletionGracePeriodSeconds>5</deletionGracePeriodSeconds>
       </graceperiod>
+      <conversion>
+        <device type="disk" name="myvolume" target="vda"></device>
+        <device type="disk" name="nocloud" target="vdb"></device>
+        <device type="disk" name="cdrom_tray_unspecified" target="sda"></device>
+        <device type="disk" name="cdrom_tray_open" target="sdb"></device>
+        <device type="disk" name="floppy_tray_unspecified" target="fda"></device>
+        <device type="disk" name="floppy_tray_open" target="fdb"></device>
+        <device type="disk" name="should_default_to_disk" target="sdc"></device>
+        <device type="disk" name="ephemeral_pvc" target="sdd"></device>
+        <device type="disk" name="secret_test" target="sde"></device>
+        <device type="disk" name="configmap_test" target="sdf"></device>
+        <device type="disk" name="pvc_block_test" target="sdg"></device>
+        <device type="disk" name="dv_block_test" target="sdh"></device>
+        <device type="disk" name="serviceaccount_test" target="sdi"></device>
+        <device type="disk" name="sysprep" target="sdj"></device>
+        <device type="disk" name="sysprep_secret" target="sdk"></device>
+        <device type="watchdog" name="mywatchdog" target="i6300esb"></device>
+        <device type="rng" target="virtio-non-transitional"></device>
+        <device type="input" name="tablet0" target="virtio"></device>
+        <device type="interface" name="default" target="ethernet"></device>
+      </conversion>
     </kubevirt>
   </metadata>
   <features>
@@ -1668,14 +1753,14 @@ var _ = Describe("Converter", func() {
 			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
 			vmi.Spec.Domain.Devices.Disks[0].Disk.PciAddress = "0000:81:01.0"
 			vmi.Spec.Domain.Devices.Disks[0].Disk.Bus = "scsi"
-			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(vmi, &api.Domain{}, c)).ToNot(Succeed())
+			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(context.Background(), vmi, &api.Domain{}, c)).ToNot(Succeed())
 		})
 
 		It("should add a virtio-scsi controller if a scsci disk is present", func() {
 			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
 			vmi.Spec.Domain.Devices.Disks[0].Disk.Bus = "scsi"
 			dom := &api.Domain{}
-			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(vmi, dom, c)).To(Succeed())
+			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(context.Background(), vmi, dom, c)).To(Succeed())
 			Expect(dom.Spec.Devices.Controllers).To(ContainElement(api.Controller{
 				Type:  "scsi",
 				Index: "0",
@@ -1687,7 +1772,7 @@ var _ = Describe("Converter", func() {
 			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
 			vmi.Spec.Domain.Devices.Disks[0].Disk.Bus = "sata"
 			dom := &api.Domain{}
-			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(vmi, dom, c)).To(Succeed())
+			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(context.Background(), vmi, dom, c)).To(Succeed())
 			Expect(dom.Spec.Devices.Controllers).ToNot(ContainElement(api.Controller{
 				Type:  "scsi",
 				Index: "0",
@@ -1723,22 +1808,36 @@ var _ = Describe("Converter", func() {
 			Expect(disabled).To(BeFalse(), "Expect controller not to be disabled")
 		})
 
+		It("should not disable usb controller when usb disk is present", func() {
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			vmi.Spec.Domain.Devices.Inputs = nil
+			vmi.Spec.Domain.Devices.Disks[0].Disk.Bus = "usb"
+			domain := vmiToDomain(vmi, c)
+			Expect(domain.Spec.Devices.Controllers).To(ContainElement(api.Controller{
+				Type:  "usb",
+				Index: "0",
+				Model: "qemu-xhci",
+			}))
+			Expect(domain.Spec.Devices.Disks[0].Target.Bus).To(Equal("usb"))
+			Expect(domain.Spec.Devices.Disks[0].Target.Device).To(HavePrefix("sd"))
+		})
+
 		It("should fail when input device is set to ps2 bus", func() {
 			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
 			vmi.Spec.Domain.Devices.Inputs[0].Bus = "ps2"
-			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(vmi, &api.Domain{}, c)).ToNot(Succeed(), "Expect error")
+			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(context.Background(), vmi, &api.Domain{}, c)).ToNot(Succeed(), "Expect error")
 		})
 
 		It("should fail when input device is set to keyboard type", func() {
 			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
 			vmi.Spec.Domain.Devices.Inputs[0].Type = "keyboard"
-			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(vmi, &api.Domain{}, c)).ToNot(Succeed(), "Expect error")
+			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(context.Background(), vmi, &api.Domain{}, c)).ToNot(Succeed(), "Expect error")
 		})
 
 		It("should succeed when input device is set to usb bus", func() {
 			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
 			vmi.Spec.Domain.Devices.Inputs[0].Bus = "usb"
-			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(vmi, &api.Domain{}, c)).To(Succeed(), "Expect success")
+			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(context.Background(), vmi, &api.Domain{}, c)).To(Succeed(), "Expect success")
 		})
 
 		It("should succeed when input device bus is empty", func() {
@@ -1892,6 +1991,25 @@ var _ = Describe("Converter", func() {
 			Expect(domainSpec.Devices.Rng).ToNot(BeNil())
 		})
 
+		It("should pass machine and global QEMU options on the command line", func() {
+			vmi.Spec.Domain.Machine = &v1.Machine{
+				Type:    "q35",
+				Options: []v1.QEMUOption{{Name: "graphics", Value: "off"}},
+				Globals: []v1.QEMUOption{{Name: "ICH9-LPC.disable_s3", Value: "1"}, {Name: "fw_cfg.name", Value: "a,b"}},
+			}
+			domain := api.Domain{}
+			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(context.Background(), vmi, &domain, c)).To(Succeed())
+			Expect(domain.Spec.QEMUCmd).ToNot(BeNil())
+			Expect(domain.Spec.QEMUCmd.QEMUArg).To(Equal([]api.Arg{
+				{Value: "-machine"},
+				{Value: "graphics=off"},
+				{Value: "-global"},
+				{Value: "ICH9-LPC.disable_s3=1"},
+				{Value: "-global"},
+				{Value: "fw_cfg.name=a,,b"},
+			}))
+		})
+
 		table.DescribeTable("Validate that QEMU SeaBios debug logs are ",
 			func(toDefineVerbosityEnvVariable bool, virtLauncherLogVerbosity int, shouldEnableDebugLogs bool) {
 
@@ -1907,7 +2025,7 @@ var _ = Describe("Converter", func() {
 
 				domain := api.Domain{}
 
-				err = Convert_v1_VirtualMachineInstance_To_api_Domain(vmi, &domain, c)
+				err = Convert_v1_VirtualMachineInstance_To_api_Domain(context.Background(), vmi, &domain, c)
 				Expect(err).To(BeNil())
 
 				if domain.Spec.QEMUCmd == nil || (domain.Spec.QEMUCmd.QEMUArg == nil) {
@@ -1973,7 +2091,7 @@ var _ = Describe("Converter", func() {
 			net.Pod = nil
 			vmi.Spec.Domain.Devices.Interfaces = append(vmi.Spec.Domain.Devices.Interfaces, *iface)
 			vmi.Spec.Networks = append(vmi.Spec.Networks, *net)
-			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(vmi, &api.Domain{}, c)).ToNot(Succeed())
+			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(context.Background(), vmi, &api.Domain{}, c)).ToNot(Succeed())
 		})
 
 		It("should add tcp if protocol not exist", func() {
@@ -2279,7 +2397,7 @@ var _ = Describe("Converter", func() {
 			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{iface1}
 
 			domain := &api.Domain{}
-			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(vmi, domain, c)).To(HaveOccurred(), "conversion should fail because a macvtap interface requires a multus network attachment")
+			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(context.Background(), vmi, domain, c)).To(HaveOccurred(), "conversion should fail because a macvtap interface requires a multus network attachment")
 		})
 		It("creates SRIOV hostdev", func() {
 			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
@@ -2288,9 +2406,32 @@ var _ = Describe("Converter", func() {
 			const identifyDevice = "sriov-test"
 			c.SRIOVDevices = append(c.SRIOVDevices, api.HostDevice{Type: identifyDevice})
 
-			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(vmi, domain, c)).To(Succeed())
+			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(context.Background(), vmi, domain, c)).To(Succeed())
 			Expect(domain.Spec.Devices.HostDevices).To(Equal([]api.HostDevice{{Type: identifyDevice}}))
 		})
+		It("orders GPUs and host devices by their PCI order", func() {
+			v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+			first, second := uint(1), uint(2)
+			vmi.Spec.Domain.Devices.GPUs = []v1.GPU{
+				{Name: "render", DeviceName: "vendor.com/gpu", PCIOrder: &second},
+				{Name: "unordered", DeviceName: "vendor.com/gpu"},
+			}
+			vmi.Spec.Domain.Devices.HostDevices = []v1.HostDevice{
+				{Name: "compute", DeviceName: "vendor.com/hostdev", PCIOrder: &first},
+			}
+			c.GenericHostDevices = []api.HostDevice{{Alias: api.NewUserDefinedAlias("hostdevice-compute")}}
+			c.GPUHostDevices = []api.HostDevice{
+				{Alias: api.NewUserDefinedAlias("gpu-render")},
+				{Alias: api.NewUserDefinedAlias("gpu-unordered")},
+			}
+
+			domain := &api.Domain{}
+			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(context.Background(), vmi, domain, c)).To(Succeed())
+			Expect(domain.Spec.Devices.HostDevices).To(HaveLen(3))
+			Expect(domain.Spec.Devices.HostDevices[0].Alias.GetName()).To(Equal("hostdevice-compute"))
+			Expect(domain.Spec.Devices.HostDevices[1].Alias.GetName()).To(Equal("gpu-render"))
+			Expect(domain.Spec.Devices.HostDevices[2].Alias.GetName()).To(Equal("gpu-unordered"))
+		})
 	})
 
 	Context("graphics and video device", func() {
@@ -2419,6 +2560,29 @@ var _ = Describe("Converter", func() {
 		)
 	})
 
+	Context("serial channels", func() {
+		It("should add a channel with a socket in the channel sockets directory", func() {
+			vmi := v1.NewMinimalVMI("testvmi")
+			vmi.Spec.Domain.Devices.Channels = []v1.SerialChannel{
+				{Name: "logs", Target: "org.example.log.0"},
+			}
+			domain := vmiToDomain(vmi, &ConverterContext{AllowEmulation: true})
+			Expect(domain.Spec.Devices.Channels).To(HaveLen(2))
+			Expect(domain.Spec.Devices.Channels[0].Target.Name).To(Equal("org.qemu.guest_agent.0"))
+			Expect(domain.Spec.Devices.Channels[1]).To(Equal(api.Channel{
+				Type: "unix",
+				Source: &api.ChannelSource{
+					Mode: "bind",
+					Path: "/var/run/kubevirt-channels/logs.sock",
+				},
+				Target: &api.ChannelTarget{
+					Name: "org.example.log.0",
+					Type: "virtio",
+				},
+			}))
+		})
+	})
+
 	Context("IOThreads", func() {
 
 		table.DescribeTable("Should use correct IOThreads policies", func(policy v1.IOThreadsPolicy, cpuCores int, threadCount int, threadIDs []int) {
@@ -2677,6 +2841,51 @@ var _ = Describe("Converter", func() {
 			Expect(*(domain.Spec.Devices.Disks[0].Driver.Queues)).To(Equal(expectedQueues),
 				"expected number of queues to equal number of requested vCPUs")
 		})
+
+		getSCSIController := func(domain *api.Domain) *api.Controller {
+			for i, controller := range domain.Spec.Devices.Controllers {
+				if controller.Type == "scsi" {
+					return &domain.Spec.Devices.Controllers[i]
+				}
+			}
+			return nil
+		}
+
+		It("should assign queues to the virtio-scsi controller", func() {
+			var expectedQueues uint = 2
+			vmi.Spec.Domain.CPU = &v1.CPU{
+				Cores: 2,
+			}
+			vmi.Spec.Domain.Devices.Disks[0].Disk.Bus = "scsi"
+
+			domain := vmiToDomain(vmi, &ConverterContext{AllowEmulation: true, SMBios: &cmdv1.SMBios{}})
+			scsiController := getSCSIController(domain)
+			Expect(scsiController).ToNot(BeNil())
+			Expect(scsiController.Model).To(Equal("virtio-non-transitional"))
+			Expect(*scsiController.Driver.Queues).To(Equal(expectedQueues),
+				"expected number of queues to equal number of requested vCPUs")
+		})
+
+		It("should not assign queues to the virtio-scsi controller if multiQueue is disabled", func() {
+			vmi.Spec.Domain.Devices.BlockMultiQueue = nil
+			vmi.Spec.Domain.Devices.Disks[0].Disk.Bus = "scsi"
+
+			domain := vmiToDomain(vmi, &ConverterContext{AllowEmulation: true, SMBios: &cmdv1.SMBios{}})
+			scsiController := getSCSIController(domain)
+			Expect(scsiController).ToNot(BeNil())
+			Expect(scsiController.Driver).To(BeNil())
+		})
+
+		It("should use the requested SCSI controller model without queues", func() {
+			vmi.Spec.Domain.Devices.Disks[0].Disk.Bus = "scsi"
+			vmi.Spec.Domain.Devices.SCSIController = &v1.SCSIController{Model: v1.SCSIControllerModelLSILogic}
+
+			domain := vmiToDomain(vmi, &ConverterContext{AllowEmulation: true, SMBios: &cmdv1.SMBios{}})
+			scsiController := getSCSIController(domain)
+			Expect(scsiController).ToNot(BeNil())
+			Expect(scsiController.Model).To(Equal("lsilogic"))
+			Expect(scsiController.Driver).To(BeNil())
+		})
 	})
 	Context("Correctly handle iothreads with dedicated cpus", func() {
 		var vmi *v1.VirtualMachineInstance
@@ -3150,31 +3359,31 @@ var _ = Describe("Converter", func() {
 		})
 		It("fails when pod interfaces from annotations is invalid", func() {
 			domain := &api.Domain{}
-			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(vmi, domain, c)).ToNot(Succeed())
+			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(context.Background(), vmi, domain, c)).ToNot(Succeed())
 		})
 		It("fails when pod interfaces is empty", func() {
 			domain := &api.Domain{}
 			c.PodNetInterfaces = &netutiltype.InterfaceResponse{}
-			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(vmi, domain, c)).ToNot(Succeed())
+			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(context.Background(), vmi, domain, c)).ToNot(Succeed())
 		})
 		It("fails when pod interfaces has valid interfaces but without vhostuser", func() {
 			domain := &api.Domain{}
 			c.PodNetInterfaces = &netutiltype.InterfaceResponse{}
 			c.PodNetInterfaces.Interface = append(c.PodNetInterfaces.Interface, podIfaceNormal)
-			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(vmi, domain, c)).ToNot(Succeed())
+			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(context.Background(), vmi, domain, c)).ToNot(Succeed())
 		})
 		It("fails with valid vhostuser interface but no memory", func() {
 			domain := &api.Domain{}
 			c.PodNetInterfaces = &netutiltype.InterfaceResponse{}
 			c.PodNetInterfaces.Interface = append(c.PodNetInterfaces.Interface, podIfaceVhostuser)
-			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(vmi, domain, c)).ToNot(Succeed())
+			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(context.Background(), vmi, domain, c)).ToNot(Succeed())
 		})
 		It("fails with valid vhostuser interface but no hugepages", func() {
 			domain := &api.Domain{}
 			vmi.Spec.Domain.Resources = resourceReq
 			c.PodNetInterfaces = &netutiltype.InterfaceResponse{}
 			c.PodNetInterfaces.Interface = append(c.PodNetInterfaces.Interface, podIfaceVhostuser)
-			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(vmi, domain, c)).ToNot(Succeed())
+			Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(context.Background(), vmi, domain, c)).ToNot(Succeed())
 		})
 		It("creates vhostuser interface", func() {
 			c.PodNetInterfaces = &netutiltype.InterfaceResponse{}
@@ -3386,6 +3595,233 @@ var _ = Describe("SetDriverCacheMode", func() {
 		table.Entry("'writethrough' without direct io", string(v1.CacheWriteThrough), string(v1.CacheWriteThrough), expectCheckFalse),
 		table.Entry("'writethrough' on error", string(v1.CacheWriteThrough), string(v1.CacheWriteThrough), expectCheckError),
 	)
+
+	It("should not check direct io for network disks", func() {
+		disk := &api.Disk{
+			Type:   "network",
+			Driver: &api.DiskDriver{},
+			Source: api.DiskSource{
+				Protocol: "iscsi",
+			},
+		}
+		Expect(SetDriverCacheMode(disk, mockDirectIOChecker)).To(Succeed())
+		Expect(disk.Driver.Cache).To(Equal(string(v1.CacheNone)))
+	})
+})
+
+var _ = Describe("Convert_v1_NetworkDiskSource_To_api_Disk", func() {
+	table.DescribeTable("should convert", func(source *v1.NetworkDiskSource, expectedXML string) {
+		disk := &api.Disk{Driver: &api.DiskDriver{}}
+		Expect(Convert_v1_NetworkDiskSource_To_api_Disk("netdisk", source, disk)).To(Succeed())
+		data, err := xml.MarshalIndent(disk, "", "  ")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).To(Equal(expectedXML))
+	},
+		table.Entry("an iscsi disk with credentials", &v1.NetworkDiskSource{
+			Protocol: v1.NetworkDiskProtocolISCSI,
+			Name:     "iqn.2021-01.io.kubevirt:target/1",
+			Host:     v1.NetworkDiskHost{Name: "storage.example.com", Port: "3260"},
+			Auth:     &v1.NetworkDiskAuth{Username: "user", SecretRef: "chap-secret"},
+		}, `<Disk device="" type="network">
+  <source protocol="iscsi" name="iqn.2021-01.io.kubevirt:target/1">
+    <host name="storage.example.com" port="3260"></host>
+  </source>
+  <target></target>
+  <driver error_policy="stop" name="" type="raw"></driver>
+  <auth username="user">
+    <secret type="iscsi" usage="network-disk-netdisk"></secret>
+  </auth>
+</Disk>`),
+		table.Entry("an rbd disk with credentials", &v1.NetworkDiskSource{
+			Protocol: v1.NetworkDiskProtocolRBD,
+			Name:     "pool/image",
+			Host:     v1.NetworkDiskHost{Name: "monitor.example.com"},
+			Auth:     &v1.NetworkDiskAuth{Username: "admin", SecretRef: "ceph-secret"},
+		}, `<Disk device="" type="network">
+  <source protocol="rbd" name="pool/image">
+    <host name="monitor.example.com"></host>
+  </source>
+  <target></target>
+  <driver error_policy="stop" name="" type="raw"></driver>
+  <auth username="admin">
+    <secret type="ceph" usage="network-disk-netdisk"></secret>
+  </auth>
+</Disk>`),
+		table.Entry("an nbd disk", &v1.NetworkDiskSource{
+			Protocol: v1.NetworkDiskProtocolNBD,
+			Host:     v1.NetworkDiskHost{Name: "nbd.example.com", Port: "10809"},
+		}, `<Disk device="" type="network">
+  <source protocol="nbd">
+    <host name="nbd.example.com" port="10809"></host>
+  </source>
+  <target></target>
+  <driver error_policy="stop" name="" type="raw"></driver>
+</Disk>`),
+	)
+})
+
+var _ = Describe("setDiscardAndDetectZeroes", func() {
+	table.DescribeTable("should set", func(diskDevice *v1.Disk, diskType string, discardIgnore []string, expectedDiscard string, expectedDetectZeroes string) {
+		disk := &api.Disk{Type: diskType, Driver: &api.DiskDriver{}}
+		if !contains(discardIgnore, diskDevice.Name) {
+			disk.Driver.Discard = "unmap"
+		}
+		setDiscardAndDetectZeroes(&ConverterContext{VolumesDiscardIgnore: discardIgnore}, diskDevice, disk)
+		Expect(disk.Driver.Discard).To(Equal(expectedDiscard))
+		Expect(disk.Driver.DetectZeroes).To(Equal(expectedDetectZeroes))
+	},
+		table.Entry("zero detection with unmap on thin block volumes",
+			&v1.Disk{Name: "disk0", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{}}}, "block", nil, "unmap", "unmap"),
+		table.Entry("no zero detection on preallocated block volumes",
+			&v1.Disk{Name: "disk0", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{}}}, "block", []string{"disk0"}, "", ""),
+		table.Entry("no zero detection on file volumes",
+			&v1.Disk{Name: "disk0", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{}}}, "file", nil, "unmap", ""),
+		table.Entry("the requested values",
+			&v1.Disk{Name: "disk0", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{}}, Discard: v1.DiscardIgnore, DetectZeroes: v1.DetectZeroesOn}, "block", nil, "ignore", "on"),
+		table.Entry("no zero detection when discards are ignored",
+			&v1.Disk{Name: "disk0", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{}}, Discard: v1.DiscardIgnore}, "block", nil, "ignore", ""),
+	)
+})
+
+var _ = Describe("setShareable", func() {
+	shareable := true
+	rwx := v1.VolumeStatus{PersistentVolumeClaimInfo: &v1.PersistentVolumeClaimInfo{AccessModes: []k8sv1.PersistentVolumeAccessMode{k8sv1.ReadWriteMany}}}
+	rwo := v1.VolumeStatus{PersistentVolumeClaimInfo: &v1.PersistentVolumeClaimInfo{AccessModes: []k8sv1.PersistentVolumeAccessMode{k8sv1.ReadWriteOnce}}}
+
+	It("should leave disks alone which are not shareable", func() {
+		disk := &api.Disk{Type: "file", Driver: &api.DiskDriver{}}
+		Expect(setShareable(&ConverterContext{}, &v1.Disk{Name: "disk0"}, disk)).To(Succeed())
+		Expect(disk.Shareable).To(BeNil())
+	})
+
+	It("should share ReadWriteMany block volumes without a cache", func() {
+		disk := &api.Disk{Type: "block", Driver: &api.DiskDriver{}}
+		c := &ConverterContext{PermanentVolumes: map[string]v1.VolumeStatus{"disk0": rwx}}
+		Expect(setShareable(c, &v1.Disk{Name: "disk0", Shareable: &shareable}, disk)).To(Succeed())
+		Expect(disk.Shareable).ToNot(BeNil())
+		Expect(disk.Driver.Cache).To(Equal(string(v1.CacheNone)))
+	})
+
+	table.DescribeTable("should reject", func(diskType string, volumeStatus v1.VolumeStatus) {
+		disk := &api.Disk{Type: diskType, Driver: &api.DiskDriver{}}
+		c := &ConverterContext{PermanentVolumes: map[string]v1.VolumeStatus{"disk0": volumeStatus}}
+		Expect(setShareable(c, &v1.Disk{Name: "disk0", Shareable: &shareable}, disk)).ToNot(Succeed())
+		Expect(disk.Shareable).To(BeNil())
+	},
+		table.Entry("file volumes", "file", rwx),
+		table.Entry("volumes without the ReadWriteMany access mode", "block", rwo),
+	)
+})
+
+var _ = Describe("disk image formats", func() {
+	It("should use the requested format for empty disks", func() {
+		disk := &api.Disk{Driver: &api.DiskDriver{}}
+		Expect(Convert_v1_EmptyDiskSource_To_api_Disk("empty", &v1.EmptyDiskSource{ImageFormat: v1.DiskImageFormatRaw}, disk)).To(Succeed())
+		Expect(disk.Driver.Type).To(Equal("raw"))
+		Expect(disk.Source.File).To(Equal("/var/run/libvirt/empty-disks/empty.img"))
+
+		disk = &api.Disk{Driver: &api.DiskDriver{}}
+		Expect(Convert_v1_EmptyDiskSource_To_api_Disk("empty", &v1.EmptyDiskSource{}, disk)).To(Succeed())
+		Expect(disk.Driver.Type).To(Equal("qcow2"))
+		Expect(disk.Source.File).To(Equal("/var/run/libvirt/empty-disks/empty.qcow2"))
+	})
+
+	It("should not enable discard on ephemeral volumes which ignore it", func() {
+		c := &ConverterContext{
+			EphemeraldiskCreator: &fake.MockEphemeralDiskImageCreator{BaseDir: "/var/run/libvirt/kubevirt-ephemeral-disk/"},
+			VolumesDiscardIgnore: []string{"ephemeral"},
+		}
+		disk := &api.Disk{Driver: &api.DiskDriver{}}
+		Expect(Convert_v1_EphemeralVolumeSource_To_api_Disk("ephemeral", &v1.EphemeralVolumeSource{ImageFormat: v1.DiskImageFormatRaw}, disk, c)).To(Succeed())
+		Expect(disk.Driver.Discard).To(BeEmpty())
+	})
+
+	It("should not add a backing store to raw ephemeral volumes", func() {
+		c := &ConverterContext{EphemeraldiskCreator: &fake.MockEphemeralDiskImageCreator{BaseDir: "/var/run/libvirt/kubevirt-ephemeral-disk/"}}
+		disk := &api.Disk{Driver: &api.DiskDriver{}}
+		Expect(Convert_v1_EphemeralVolumeSource_To_api_Disk("ephemeral", &v1.EphemeralVolumeSource{ImageFormat: v1.DiskImageFormatRaw}, disk, c)).To(Succeed())
+		Expect(disk.Driver.Type).To(Equal("raw"))
+		Expect(disk.BackingStore).To(BeNil())
+
+		disk = &api.Disk{Driver: &api.DiskDriver{}}
+		Expect(Convert_v1_EphemeralVolumeSource_To_api_Disk("ephemeral", &v1.EphemeralVolumeSource{}, disk, c)).To(Succeed())
+		Expect(disk.Driver.Type).To(Equal("qcow2"))
+		Expect(disk.BackingStore).ToNot(BeNil())
+	})
+})
+
+var _ = Describe("Conversion report", func() {
+	var vmi *v1.VirtualMachineInstance
+	var c *ConverterContext
+
+	BeforeEach(func() {
+		vmi = v1.NewMinimalVMI("testvmi")
+		v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+		vmi.Spec.Domain.Devices.Disks = []v1.Disk{
+			{
+				Name: "mydisk",
+				DiskDevice: v1.DiskDevice{
+					Disk: &v1.DiskTarget{Bus: "virtio"},
+				},
+			},
+		}
+		vmi.Spec.Volumes = []v1.Volume{
+			{
+				Name: "mydisk",
+				VolumeSource: v1.VolumeSource{
+					HostDisk: &v1.HostDisk{Path: "/var/run/kubevirt-private/vmi-disks/mydisk/disk.img"},
+				},
+			},
+		}
+		c = &ConverterContext{
+			VirtualMachine: vmi,
+			AllowEmulation: true,
+			SMBios:         &cmdv1.SMBios{},
+		}
+	})
+
+	It("should attach the converted devices to the domain metadata", func() {
+		domain := vmiToDomain(vmi, c)
+		Expect(domain.Spec.Metadata.KubeVirt.Conversion).ToNot(BeNil())
+		Expect(domain.Spec.Metadata.KubeVirt.Conversion.Devices).To(ConsistOf(
+			api.ConvertedDeviceMetadata{Type: "disk", Name: "mydisk", Target: "vda"},
+		))
+	})
+
+	It("should report every failing device instead of only the first one", func() {
+		vmi.Spec.Domain.Devices.Disks = append(vmi.Spec.Domain.Devices.Disks,
+			v1.Disk{Name: "missing1", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "virtio"}}},
+			v1.Disk{Name: "missing2", DiskDevice: v1.DiskDevice{Disk: &v1.DiskTarget{Bus: "virtio"}}},
+		)
+		vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*v1.DefaultBridgeNetworkInterface()}
+		vmi.Spec.Domain.Devices.Watchdog = &v1.Watchdog{Name: "mywatchdog"}
+
+		domain := &api.Domain{}
+		err := Convert_v1_VirtualMachineInstance_To_api_Domain(context.Background(), vmi, domain, c)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("disk missing1: No matching volume with name missing1 found"))
+		Expect(err.Error()).To(ContainSubstring("disk missing2: No matching volume with name missing2 found"))
+		Expect(err.Error()).To(ContainSubstring("interface default: failed to find network default"))
+		Expect(err.Error()).To(ContainSubstring("watchdog mywatchdog:"))
+
+		Expect(domain.Spec.Metadata.KubeVirt.Conversion).ToNot(BeNil())
+		failed := []string{}
+		for _, device := range domain.Spec.Metadata.KubeVirt.Conversion.Devices {
+			if device.Error != "" {
+				failed = append(failed, device.Type+"/"+device.Name)
+			}
+		}
+		Expect(failed).To(ConsistOf("disk/missing1", "disk/missing2", "watchdog/mywatchdog", "interface/default"))
+	})
+
+	It("should stop converting once the context is cancelled", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		domain := &api.Domain{}
+		Expect(Convert_v1_VirtualMachineInstance_To_api_Domain(ctx, vmi, domain, c)).To(MatchError(context.Canceled))
+		Expect(domain.Spec.Devices.Disks).To(BeEmpty())
+	})
 })
 
 func diskToDiskXML(disk *v1.Disk) string {
@@ -3406,7 +3842,7 @@ func vmiToDomainXML(vmi *v1.VirtualMachineInstance, c *ConverterContext) string
 
 func vmiToDomain(vmi *v1.VirtualMachineInstance, c *ConverterContext) *api.Domain {
 	domain := &api.Domain{}
-	ExpectWithOffset(1, Convert_v1_VirtualMachineInstance_To_api_Domain(vmi, domain, c)).To(Succeed())
+	ExpectWithOffset(1, Convert_v1_VirtualMachineInstance_To_api_Domain(context.Background(), vmi, domain, c)).To(Succeed())
 	api.NewDefaulter(c.Architecture).SetObjectDefaults_Domain(domain)
 	return domain
 }
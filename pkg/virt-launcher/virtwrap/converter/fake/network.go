@@ -0,0 +1,47 @@
+package fake
+
+import (
+	"fmt"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+// MockResolvConfReader returns a fixed DNS configuration instead of reading /etc/resolv.conf
+type MockResolvConfReader struct {
+	Nameservers   [][]byte
+	SearchDomains []string
+	Err           error
+}
+
+func (m *MockResolvConfReader) ReadResolvConf() ([][]byte, []string, error) {
+	return m.Nameservers, m.SearchDomains, m.Err
+}
+
+// MockPodInterfaceNamer maps VMI interface names to pod interface names
+type MockPodInterfaceNamer struct {
+	Names map[string]string
+}
+
+func (m *MockPodInterfaceNamer) PodInterfaceName(_ *v1.VirtualMachineInstance, ifaceName string) (string, error) {
+	if name, exists := m.Names[ifaceName]; exists {
+		return name, nil
+	}
+	return "", fmt.Errorf("Interface %s not found", ifaceName)
+}
+
+type VhostuserSocket struct {
+	Path string
+	Mode string
+}
+
+// MockDeviceInfoProvider returns the vhostuser sockets of pod interfaces
+type MockDeviceInfoProvider struct {
+	Vhostuser map[string]VhostuserSocket
+}
+
+func (m *MockDeviceInfoProvider) VhostuserInfo(podIfaceName string) (string, string, error) {
+	if socket, exists := m.Vhostuser[podIfaceName]; exists {
+		return socket.Path, socket.Mode, nil
+	}
+	return "", "", fmt.Errorf("Unable to get vhostuser interface info for %s", podIfaceName)
+}
@@ -26,8 +26,6 @@ import (
 	"net"
 	"strings"
 
-	nettypes "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
-
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/log"
 	"kubevirt.io/kubevirt/pkg/util/net/dns"
@@ -39,7 +37,7 @@ import (
 
 const PrimaryPodInterfaceName = "eth0"
 
-func createDomainInterfaces(vmi *v1.VirtualMachineInstance, domain *api.Domain, c *ConverterContext, virtioNetProhibited bool) ([]api.Interface, error) {
+func createDomainInterfaces(vmi *v1.VirtualMachineInstance, domain *api.Domain, c *ConverterContext, virtioNetProhibited bool, report *conversionReport) ([]api.Interface, error) {
 	if err := validateNetworksTypes(vmi.Spec.Networks); err != nil {
 		return nil, err
 	}
@@ -48,115 +46,136 @@ func createDomainInterfaces(vmi *v1.VirtualMachineInstance, domain *api.Domain,
 
 	networks := indexNetworksByName(vmi.Spec.Networks)
 
-	for i, iface := range vmi.Spec.Domain.Devices.Interfaces {
+	for i := range vmi.Spec.Domain.Devices.Interfaces {
+		iface := &vmi.Spec.Domain.Devices.Interfaces[i]
 		net, isExist := networks[iface.Name]
 		if !isExist {
-			return nil, fmt.Errorf("failed to find network %s", iface.Name)
+			report.failed(reportDeviceInterface, iface.Name, fmt.Errorf("failed to find network %s", iface.Name))
+			continue
 		}
 
-		if iface.SRIOV != nil {
+		domainIface, err := createDomainInterface(vmi, domain, c, iface, net, virtioNetProhibited)
+		if err != nil {
+			report.failed(reportDeviceInterface, iface.Name, err)
 			continue
 		}
-
-		ifaceType := getInterfaceType(&vmi.Spec.Domain.Devices.Interfaces[i])
-		domainIface := api.Interface{
-			Model: &api.Model{
-				Type: translateModel(c, ifaceType),
-			},
-			Alias: api.NewUserDefinedAlias(iface.Name),
+		if domainIface == nil {
+			continue
 		}
+		domainInterfaces = append(domainInterfaces, *domainIface)
+		report.converted(reportDeviceInterface, iface.Name, domainIface.Type)
+	}
 
-		// if AllowEmulation unset and at least one NIC model is virtio,
-		// /dev/vhost-net must be present as we should have asked for it.
-		var virtioNetMQRequested bool
-		if mq := vmi.Spec.Domain.Devices.NetworkInterfaceMultiQueue; mq != nil {
-			virtioNetMQRequested = *mq
-		}
-		if ifaceType == "virtio" && virtioNetProhibited {
-			return nil, fmt.Errorf("In-kernel virtio-net device emulation '/dev/vhost-net' not present")
-		} else if ifaceType == "virtio" && virtioNetMQRequested {
-			queueCount := uint(CalculateNetworkQueues(vmi))
-			domainIface.Driver = &api.InterfaceDriver{Name: "vhost", Queues: &queueCount}
-		}
+	return domainInterfaces, nil
+}
 
-		// Add a pciAddress if specified
-		if iface.PciAddress != "" {
-			addr, err := device.NewPciAddressField(iface.PciAddress)
-			if err != nil {
-				return nil, fmt.Errorf("failed to configure interface %s: %v", iface.Name, err)
-			}
-			domainIface.Address = addr
+// createDomainInterface converts a single interface, SR-IOV interfaces are passed as host devices and yield no interface
+func createDomainInterface(vmi *v1.VirtualMachineInstance, domain *api.Domain, c *ConverterContext, iface *v1.Interface, net *v1.Network, virtioNetProhibited bool) (*api.Interface, error) {
+	if iface.SRIOV != nil {
+		return nil, nil
+	}
+
+	ifaceType := getInterfaceType(iface)
+	domainIface := api.Interface{
+		Model: &api.Model{
+			Type: translateModel(c, ifaceType),
+		},
+		Alias: api.NewUserDefinedAlias(iface.Name),
+	}
+
+	// if AllowEmulation unset and at least one NIC model is virtio,
+	// /dev/vhost-net must be present as we should have asked for it.
+	var virtioNetMQRequested bool
+	if mq := vmi.Spec.Domain.Devices.NetworkInterfaceMultiQueue; mq != nil {
+		virtioNetMQRequested = *mq
+	}
+	if ifaceType == "virtio" && virtioNetProhibited {
+		return nil, fmt.Errorf("In-kernel virtio-net device emulation '/dev/vhost-net' not present")
+	} else if ifaceType == "virtio" && virtioNetMQRequested {
+		queueCount := uint(CalculateNetworkQueues(vmi))
+		domainIface.Driver = &api.InterfaceDriver{Name: "vhost", Queues: &queueCount}
+	}
+
+	// Add a pciAddress if specified
+	if iface.PciAddress != "" {
+		addr, err := device.NewPciAddressField(iface.PciAddress)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure interface %s: %v", iface.Name, err)
 		}
+		domainIface.Address = addr
+	}
 
-		if iface.Bridge != nil || iface.Masquerade != nil {
-			// TODO:(ihar) consider abstracting interface type conversion /
-			// detection into drivers
-
-			// use "ethernet" interface type, since we're using pre-configured tap devices
-			// https://libvirt.org/formatdomain.html#elementsNICSEthernet
-			domainIface.Type = "ethernet"
-			if iface.BootOrder != nil {
-				domainIface.BootOrder = &api.BootOrder{Order: *iface.BootOrder}
-			} else {
-				domainIface.Rom = &api.Rom{Enabled: "no"}
-			}
-		} else if iface.Slirp != nil {
-			domainIface.Type = "user"
+	if iface.Bridge != nil || iface.Masquerade != nil {
+		// TODO:(ihar) consider abstracting interface type conversion /
+		// detection into drivers
+
+		// use "ethernet" interface type, since we're using pre-configured tap devices
+		// https://libvirt.org/formatdomain.html#elementsNICSEthernet
+		domainIface.Type = "ethernet"
+		if iface.BootOrder != nil {
+			domainIface.BootOrder = &api.BootOrder{Order: *iface.BootOrder}
+		} else {
+			domainIface.Rom = &api.Rom{Enabled: "no"}
+		}
+	} else if iface.Slirp != nil {
+		domainIface.Type = "user"
 
-			// Create network interface
-			initializeQEMUCmdAndQEMUArg(domain)
+		// Create network interface
+		initializeQEMUCmdAndQEMUArg(domain)
 
-			// TODO: (seba) Need to change this if multiple interface can be connected to the same network
-			// append the ports from all the interfaces connected to the same network
-			err := createSlirpNetwork(iface, *net, domain)
-			if err != nil {
-				return nil, err
-			}
-		} else if iface.Macvtap != nil {
-			if net.Multus == nil {
-				return nil, fmt.Errorf("macvtap interface %s requires Multus meta-cni", iface.Name)
-			}
+		// TODO: (seba) Need to change this if multiple interface can be connected to the same network
+		// append the ports from all the interfaces connected to the same network
+		err := createSlirpNetwork(*iface, *net, domain, c)
+		if err != nil {
+			return nil, err
+		}
+	} else if iface.Macvtap != nil {
+		if net.Multus == nil {
+			return nil, fmt.Errorf("macvtap interface %s requires Multus meta-cni", iface.Name)
+		}
 
-			domainIface.Type = "ethernet"
-			if iface.BootOrder != nil {
-				domainIface.BootOrder = &api.BootOrder{Order: *iface.BootOrder}
-			} else {
-				domainIface.Rom = &api.Rom{Enabled: "no"}
-			}
-		} else if iface.Vhostuser != nil {
-			domainIface.Type = "vhostuser"
-			podInterfaceName, err := getPodInterfaceName(vmi, iface.Name)
-			if err != nil {
-				log.Log.Errorf("Failed to get NIC for vhostuser interface: %s", iface.Name)
-			}
-			vhostPath, vhostMode, err := getVhostuserInfo(podInterfaceName, c)
-			if err != nil {
-				log.Log.Errorf("Failed to get vhostuser interface info: %v", err)
-				return nil, err
-			}
-			vhostPathParts := strings.Split(vhostPath, "/")
-			vhostDevice := vhostPathParts[len(vhostPathParts)-1]
-			if len(vhostPathParts) == 1 {
-				vhostPath = services.VhostuserSocketDir + vhostPath
-			}
-			domainIface.Source = api.InterfaceSource{
-				Type: "unix",
-				Path: vhostPath,
-				Mode: vhostMode,
-			}
-			domainIface.Target = &api.InterfaceTarget{
-				Device: vhostDevice,
-			}
-			var vhostuserQueueSize uint32 = 1024
-			domainIface.Driver = &api.InterfaceDriver{
-				RxQueueSize: &vhostuserQueueSize,
-				TxQueueSize: &vhostuserQueueSize,
-			}
+		domainIface.Type = "ethernet"
+		if iface.BootOrder != nil {
+			domainIface.BootOrder = &api.BootOrder{Order: *iface.BootOrder}
+		} else {
+			domainIface.Rom = &api.Rom{Enabled: "no"}
+		}
+	} else if iface.Vhostuser != nil {
+		domainIface.Type = "vhostuser"
+		podInterfaceName, err := c.podInterfaceNamer().PodInterfaceName(vmi, iface.Name)
+		if err != nil {
+			log.Log.Errorf("Failed to get NIC for vhostuser interface: %s", iface.Name)
+		}
+		vhostPath, vhostMode, err := c.deviceInfoProvider().VhostuserInfo(podInterfaceName)
+		if err != nil {
+			log.Log.Errorf("Failed to get vhostuser interface info: %v", err)
+			return nil, err
+		}
+		vhostPathParts := strings.Split(vhostPath, "/")
+		vhostDevice := vhostPathParts[len(vhostPathParts)-1]
+		if len(vhostPathParts) == 1 {
+			vhostPath = services.VhostuserSocketDir + vhostPath
+		}
+		domainIface.Source = api.InterfaceSource{
+			Type: "unix",
+			Path: vhostPath,
+			Mode: vhostMode,
+		}
+		domainIface.Target = &api.InterfaceTarget{
+			Device: vhostDevice,
+		}
+		var vhostuserQueueSize uint32 = 1024
+		domainIface.Driver = &api.InterfaceDriver{
+			RxQueueSize: &vhostuserQueueSize,
+			TxQueueSize: &vhostuserQueueSize,
 		}
-		domainInterfaces = append(domainInterfaces, domainIface)
 	}
 
-	return domainInterfaces, nil
+	// libvirt shapes the traffic on the tap devices of ethernet interfaces only
+	if domainIface.Type == "ethernet" {
+		domainIface.BandWidth = api.NewBandWidth(iface.BandwidthLimit)
+	}
+	return &domainIface, nil
 }
 
 func getInterfaceType(iface *v1.Interface) string {
@@ -194,7 +213,7 @@ func indexNetworksByName(networks []v1.Network) map[string]*v1.Network {
 	return netsByName
 }
 
-func createSlirpNetwork(iface v1.Interface, network v1.Network, domain *api.Domain) error {
+func createSlirpNetwork(iface v1.Interface, network v1.Network, domain *api.Domain, c *ConverterContext) error {
 	qemuArg := api.Arg{Value: fmt.Sprintf("user,id=%s", iface.Name)}
 
 	err := configVMCIDR(&qemuArg, network)
@@ -202,7 +221,7 @@ func createSlirpNetwork(iface v1.Interface, network v1.Network, domain *api.Doma
 		return err
 	}
 
-	err = configDNSSearchName(&qemuArg)
+	err = configDNSSearchName(&qemuArg, c.resolvConfReader())
 	if err != nil {
 		return err
 	}
@@ -274,8 +293,8 @@ func configVMCIDR(qemuArg *api.Arg, network v1.Network) error {
 	return nil
 }
 
-func configDNSSearchName(qemuArg *api.Arg) error {
-	_, dnsDoms, err := GetResolvConfDetailsFromPod()
+func configDNSSearchName(qemuArg *api.Arg, resolvConfReader ResolvConfReader) error {
+	_, dnsDoms, err := resolvConfReader.ReadResolvConf()
 	if err != nil {
 		return err
 	}
@@ -349,39 +368,3 @@ func findMultusIndex(vmi *v1.VirtualMachineInstance, networkToFind *v1.Network)
 func isSecondaryMultusNetwork(net v1.Network) bool {
 	return net.Multus != nil && !net.Multus.Default
 }
-
-func getPodInterfaceName(vmi *v1.VirtualMachineInstance, ifaceName string) (string, error) {
-	for i, _ := range vmi.Spec.Networks {
-		network := &vmi.Spec.Networks[i]
-		if network.Pod == nil && network.Multus == nil {
-			continue
-		}
-		iface := FindInterfaceByNetworkName(vmi, network)
-		if iface.Name == ifaceName {
-			podIfaceName, err := ComposePodInterfaceName(vmi, network)
-			if err != nil {
-				return "", err
-			}
-			return podIfaceName, nil
-		}
-	}
-	return "", fmt.Errorf("Interface %s not found", ifaceName)
-}
-
-func getVhostuserInfo(ifaceName string, c *ConverterContext) (string, string, error) {
-	if c.PodNetInterfaces == nil {
-		err := fmt.Errorf("PodNetInterfaces cannot be nil for vhostuser interface")
-		return "", "", err
-	}
-	for _, iface := range c.PodNetInterfaces.Interface {
-		if iface.DeviceType == nettypes.DeviceInfoTypeVHostUser {
-			networkNameParts := strings.Split(iface.NetworkStatus.Name, "/")
-			if networkNameParts[len(networkNameParts)-1] == ifaceName {
-				return iface.NetworkStatus.DeviceInfo.VhostUser.Path, iface.NetworkStatus.DeviceInfo.VhostUser.Mode, nil
-			}
-		}
-
-	}
-	err := fmt.Errorf("Unable to get vhostuser interface info for %s", ifaceName)
-	return "", "", err
-}
@@ -0,0 +1,117 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package converter
+
+import (
+	"fmt"
+	"strings"
+
+	nettypes "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	netutiltype "github.com/openshift/app-netutil/pkg/types"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+// ResolvConfReader provides the DNS configuration the guest inherits from the pod
+type ResolvConfReader interface {
+	// ReadResolvConf returns the nameservers and the search domains
+	ReadResolvConf() ([][]byte, []string, error)
+}
+
+// PodInterfaceNamer maps a VMI interface to the name of the interface it is backed by in the pod
+type PodInterfaceNamer interface {
+	PodInterfaceName(vmi *v1.VirtualMachineInstance, ifaceName string) (string, error)
+}
+
+// DeviceInfoProvider looks up the device info the network plugins published for a pod interface
+type DeviceInfoProvider interface {
+	// VhostuserInfo returns the socket path and the socket mode of a vhostuser pod interface
+	VhostuserInfo(podIfaceName string) (string, string, error)
+}
+
+type podResolvConfReader struct{}
+
+func (podResolvConfReader) ReadResolvConf() ([][]byte, []string, error) {
+	return GetResolvConfDetailsFromPod()
+}
+
+type podInterfaceNamer struct{}
+
+func (podInterfaceNamer) PodInterfaceName(vmi *v1.VirtualMachineInstance, ifaceName string) (string, error) {
+	for i := range vmi.Spec.Networks {
+		network := &vmi.Spec.Networks[i]
+		if network.Pod == nil && network.Multus == nil {
+			continue
+		}
+		iface := FindInterfaceByNetworkName(vmi, network)
+		if iface.Name == ifaceName {
+			podIfaceName, err := ComposePodInterfaceName(vmi, network)
+			if err != nil {
+				return "", err
+			}
+			return podIfaceName, nil
+		}
+	}
+	return "", fmt.Errorf("Interface %s not found", ifaceName)
+}
+
+// podNetInterfacesDeviceInfo looks up the device info in the interfaces reported by app-netutil
+type podNetInterfacesDeviceInfo struct {
+	podNetInterfaces *netutiltype.InterfaceResponse
+}
+
+func (p podNetInterfacesDeviceInfo) VhostuserInfo(podIfaceName string) (string, string, error) {
+	if p.podNetInterfaces == nil {
+		err := fmt.Errorf("PodNetInterfaces cannot be nil for vhostuser interface")
+		return "", "", err
+	}
+	for _, iface := range p.podNetInterfaces.Interface {
+		if iface.DeviceType == nettypes.DeviceInfoTypeVHostUser {
+			networkNameParts := strings.Split(iface.NetworkStatus.Name, "/")
+			if networkNameParts[len(networkNameParts)-1] == podIfaceName {
+				return iface.NetworkStatus.DeviceInfo.VhostUser.Path, iface.NetworkStatus.DeviceInfo.VhostUser.Mode, nil
+			}
+		}
+
+	}
+	err := fmt.Errorf("Unable to get vhostuser interface info for %s", podIfaceName)
+	return "", "", err
+}
+
+func (c *ConverterContext) resolvConfReader() ResolvConfReader {
+	if c.ResolvConfReader != nil {
+		return c.ResolvConfReader
+	}
+	return podResolvConfReader{}
+}
+
+func (c *ConverterContext) podInterfaceNamer() PodInterfaceNamer {
+	if c.PodInterfaceNamer != nil {
+		return c.PodInterfaceNamer
+	}
+	return podInterfaceNamer{}
+}
+
+func (c *ConverterContext) deviceInfoProvider() DeviceInfoProvider {
+	if c.DeviceInfoProvider != nil {
+		return c.DeviceInfoProvider
+	}
+	return podNetInterfacesDeviceInfo{podNetInterfaces: c.PodNetInterfaces}
+}
@@ -0,0 +1,151 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package converter
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	cmdv1 "kubevirt.io/kubevirt/pkg/handler-launcher-com/cmd/v1"
+	"kubevirt.io/kubevirt/pkg/virt-controller/services"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/converter/fake"
+)
+
+var _ = Describe("Network sources", func() {
+	var vmi *v1.VirtualMachineInstance
+	var c *ConverterContext
+
+	BeforeEach(func() {
+		vmi = v1.NewMinimalVMI("testvmi")
+		v1.SetObjectDefaults_VirtualMachineInstance(vmi)
+		c = &ConverterContext{
+			VirtualMachine: vmi,
+			AllowEmulation: true,
+			SMBios:         &cmdv1.SMBios{},
+		}
+	})
+
+	Context("with a slirp interface", func() {
+		BeforeEach(func() {
+			iface := v1.DefaultSlirpNetworkInterface()
+			iface.Model = "e1000"
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*iface}
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+		})
+
+		It("should take the search domains from the resolv.conf reader", func() {
+			c.ResolvConfReader = &fake.MockResolvConfReader{SearchDomains: []string{"example.com", "cluster.local"}}
+
+			domain := vmiToDomain(vmi, c)
+			Expect(domain.Spec.QEMUCmd).ToNot(BeNil())
+			Expect(domain.Spec.QEMUCmd.QEMUArg).To(ContainElement(
+				api.Arg{Value: "user,id=default,net=10.0.2.0/24,dnssearch=example.com,dnssearch=cluster.local"},
+			))
+		})
+
+		It("should fail the interface if resolv.conf can't be read", func() {
+			c.ResolvConfReader = &fake.MockResolvConfReader{Err: fmt.Errorf("no resolv.conf")}
+
+			err := Convert_v1_VirtualMachineInstance_To_api_Domain(context.Background(), vmi, &api.Domain{}, c)
+			Expect(err).To(MatchError(ContainSubstring("interface default: no resolv.conf")))
+		})
+	})
+
+	Context("with a vhostuser interface", func() {
+		BeforeEach(func() {
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{
+				{
+					Name: "vhostuser-1",
+					InterfaceBindingMethod: v1.InterfaceBindingMethod{
+						Vhostuser: &v1.InterfaceVhostuser{},
+					},
+				},
+			}
+			vmi.Spec.Networks = []v1.Network{
+				{
+					Name: "vhostuser-1",
+					NetworkSource: v1.NetworkSource{
+						Multus: &v1.MultusNetwork{NetworkName: "userspace-1"},
+					},
+				},
+			}
+			vmi.Spec.Domain.Resources.Requests = k8sv1.ResourceList{
+				k8sv1.ResourceMemory: resource.MustParse("2Gi"),
+			}
+			vmi.Spec.Domain.Memory = &v1.Memory{Hugepages: &v1.Hugepages{}}
+		})
+
+		It("should look up the socket through the pod interface namer and the device info provider", func() {
+			c.PodInterfaceNamer = &fake.MockPodInterfaceNamer{Names: map[string]string{"vhostuser-1": "net5"}}
+			c.DeviceInfoProvider = &fake.MockDeviceInfoProvider{Vhostuser: map[string]fake.VhostuserSocket{
+				"net5": {Path: "a654321_net5", Mode: "client"},
+			}}
+
+			domain := vmiToDomain(vmi, c)
+			Expect(domain.Spec.Devices.Interfaces).To(HaveLen(1))
+			Expect(domain.Spec.Devices.Interfaces[0].Type).To(Equal("vhostuser"))
+			Expect(domain.Spec.Devices.Interfaces[0].Source.Path).To(Equal(services.VhostuserSocketDir + "a654321_net5"))
+			Expect(domain.Spec.Devices.Interfaces[0].Source.Mode).To(Equal("client"))
+			Expect(domain.Spec.Devices.Interfaces[0].Target.Device).To(Equal("a654321_net5"))
+		})
+
+		It("should fail the interface if no device info is published for it", func() {
+			c.DeviceInfoProvider = &fake.MockDeviceInfoProvider{}
+
+			err := Convert_v1_VirtualMachineInstance_To_api_Domain(context.Background(), vmi, &api.Domain{}, c)
+			Expect(err).To(MatchError(ContainSubstring("interface vhostuser-1: Unable to get vhostuser interface info for net1")))
+		})
+	})
+	Context("with a bandwidth limit", func() {
+		BeforeEach(func() {
+			inbound := resource.MustParse("8M")
+			iface := v1.DefaultBridgeNetworkInterface()
+			iface.BandwidthLimit = &v1.InterfaceBandwidthLimit{Inbound: &inbound}
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*iface}
+			vmi.Spec.Networks = []v1.Network{*v1.DefaultPodNetwork()}
+		})
+
+		It("should shape the traffic of the tap device", func() {
+			domain := vmiToDomain(vmi, c)
+			Expect(domain.Spec.Devices.Interfaces).To(HaveLen(1))
+			Expect(domain.Spec.Devices.Interfaces[0].BandWidth).To(Equal(&api.BandWidth{
+				Inbound: &api.BandWidthRate{Average: 977},
+			}))
+		})
+
+		It("should not shape the traffic of slirp interfaces", func() {
+			iface := v1.DefaultSlirpNetworkInterface()
+			iface.BandwidthLimit = vmi.Spec.Domain.Devices.Interfaces[0].BandwidthLimit
+			vmi.Spec.Domain.Devices.Interfaces = []v1.Interface{*iface}
+			c.ResolvConfReader = &fake.MockResolvConfReader{}
+
+			domain := vmiToDomain(vmi, c)
+			Expect(domain.Spec.Devices.Interfaces).To(HaveLen(1))
+			Expect(domain.Spec.Devices.Interfaces[0].BandWidth).To(BeNil())
+		})
+	})
+})
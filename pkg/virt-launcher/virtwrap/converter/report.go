@@ -0,0 +1,98 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package converter
+
+import (
+	"fmt"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+)
+
+const (
+	reportDeviceDisk       = "disk"
+	reportDeviceFilesystem = "filesystem"
+	reportDeviceWatchdog   = "watchdog"
+	reportDeviceRng        = "rng"
+	reportDeviceInput      = "input"
+	reportDeviceUsbredir   = "usbredir"
+	reportDeviceClock      = "clock"
+	reportDeviceFeatures   = "features"
+	reportDeviceInterface  = "interface"
+)
+
+// conversionReport collects the outcome of converting every device of a VMI, so that
+// a single conversion run can report all failing devices instead of only the first one.
+// A report is created per conversion and must not be shared between conversions.
+type conversionReport struct {
+	devices []api.ConvertedDeviceMetadata
+	errs    []error
+}
+
+func newConversionReport() *conversionReport {
+	return &conversionReport{}
+}
+
+// converted records a device which was successfully converted into the domain
+func (r *conversionReport) converted(deviceType, name, target string) {
+	r.devices = append(r.devices, api.ConvertedDeviceMetadata{
+		Type:   deviceType,
+		Name:   name,
+		Target: target,
+	})
+}
+
+// failed records a device which could not be converted
+func (r *conversionReport) failed(deviceType, name string, err error) {
+	r.devices = append(r.devices, api.ConvertedDeviceMetadata{
+		Type:  deviceType,
+		Name:  name,
+		Error: err.Error(),
+	})
+	if name == "" {
+		r.errs = append(r.errs, fmt.Errorf("%s: %v", deviceType, err))
+	} else {
+		r.errs = append(r.errs, fmt.Errorf("%s %s: %v", deviceType, name, err))
+	}
+}
+
+// err returns an aggregate of all device errors, or nil if every device was converted
+func (r *conversionReport) err() error {
+	return utilerrors.NewAggregate(r.errs)
+}
+
+// attach stores the report in the KubeVirt metadata of the domain
+func (r *conversionReport) attach(domain *api.Domain) {
+	domain.Spec.Metadata.KubeVirt.Conversion = &api.ConversionMetadata{
+		Devices: r.devices,
+	}
+}
+
+func (r *conversionReport) log(vmi *v1.VirtualMachineInstance) {
+	logger := log.Log.Object(vmi).With("devices", len(r.devices), "failed", len(r.errs))
+	if len(r.errs) > 0 {
+		logger.Reason(r.err()).Error("Failed to convert devices of the VirtualMachineInstance to domain")
+		return
+	}
+	logger.V(4).Info("Converted devices of the VirtualMachineInstance to domain")
+}
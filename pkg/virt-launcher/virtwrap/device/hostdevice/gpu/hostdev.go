@@ -82,3 +82,18 @@ func validateCreationOfAllDevices(gpus []v1.GPU, hostDevices []api.HostDevice) e
 	}
 	return nil
 }
+
+func FilterHostDevices(domainSpec *api.DomainSpec) []api.HostDevice {
+	return hostdevice.FilterHostDevicesByAlias(domainSpec.Devices.HostDevices, AliasPrefix)
+}
+
+// GetHostDevicesToAttach returns the GPU host-devices of the VMI which are not attached to the domain.
+func GetHostDevicesToAttach(vmi *v1.VirtualMachineInstance, domainSpec *api.DomainSpec) ([]api.HostDevice, error) {
+	gpuDevices, err := CreateHostDevices(vmi.Spec.Domain.Devices.GPUs)
+	if err != nil {
+		return nil, err
+	}
+	currentAttachedGPUHostDevices := FilterHostDevices(domainSpec)
+
+	return hostdevice.DifferenceHostDevicesByAlias(gpuDevices, currentAttachedGPUHostDevices), nil
+}
@@ -93,6 +93,17 @@ var _ = Describe("GPU HostDevice", func() {
 
 		Expect(hostDevices, err).To(Equal([]api.HostDevice{expectHostDevice0, expectHostDevice1}))
 	})
+
+	It("filters the GPU host-devices of a domain", func() {
+		gpuHostDevice := api.HostDevice{Alias: api.NewUserDefinedAlias(gpu.AliasPrefix + gpuName0)}
+		domainSpec := &api.DomainSpec{}
+		domainSpec.Devices.HostDevices = []api.HostDevice{
+			{Alias: api.NewUserDefinedAlias("sriov-net1")},
+			gpuHostDevice,
+		}
+
+		Expect(gpu.FilterHostDevices(domainSpec)).To(Equal([]api.HostDevice{gpuHostDevice}))
+	})
 })
 
 type stubAddressPool struct {
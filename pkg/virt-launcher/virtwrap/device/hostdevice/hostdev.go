@@ -21,6 +21,7 @@ package hostdevice
 
 import (
 	"fmt"
+	"sort"
 
 	"kubevirt.io/client-go/log"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
@@ -109,3 +110,23 @@ func createMDEVHostDevice(hostDeviceData HostDeviceMetaData, mdevUUID string) (*
 	}
 	return domainHostDevice, nil
 }
+
+// SortByPCIOrder sorts the host devices by the PCI order of their alias, guest PCI addresses are assigned
+// in this order. Host devices without a PCI order keep their relative order after the ordered ones.
+func SortByPCIOrder(hostDevices []api.HostDevice, pciOrderByAlias map[string]uint) {
+	pciOrder := func(hostDevice api.HostDevice) (uint, bool) {
+		if hostDevice.Alias == nil {
+			return 0, false
+		}
+		order, exists := pciOrderByAlias[hostDevice.Alias.GetName()]
+		return order, exists
+	}
+	sort.SliceStable(hostDevices, func(i, j int) bool {
+		orderI, orderedI := pciOrder(hostDevices[i])
+		orderJ, orderedJ := pciOrder(hostDevices[j])
+		if orderedI && orderedJ {
+			return orderI < orderJ
+		}
+		return orderedI && !orderedJ
+	})
+}
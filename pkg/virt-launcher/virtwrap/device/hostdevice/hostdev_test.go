@@ -191,6 +191,27 @@ var _ = Describe("HostDevice", func() {
 			Expect(hostDevices, err).To(Equal([]api.HostDevice{expectHostDevice1, expectHostDevice2}))
 		})
 	})
+
+	It("sorts the devices by their PCI order and keeps the unordered ones last", func() {
+		hostDevices := []api.HostDevice{
+			{Alias: newAlias("unordered0")},
+			{Alias: newAlias("second")},
+			{Alias: newAlias("unordered1")},
+			{Alias: newAlias("first")},
+		}
+		hostdevice.SortByPCIOrder(hostDevices, map[string]uint{
+			aliasPrefix + "first":  1,
+			aliasPrefix + "second": 5,
+		})
+
+		var aliases []string
+		for _, hostDevice := range hostDevices {
+			aliases = append(aliases, hostDevice.Alias.GetName())
+		}
+		Expect(aliases).To(Equal([]string{
+			aliasPrefix + "first", aliasPrefix + "second", aliasPrefix + "unordered0", aliasPrefix + "unordered1",
+		}))
+	})
 })
 
 func newAlias(netName string) *api.Alias {
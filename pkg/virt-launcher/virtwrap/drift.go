@@ -0,0 +1,164 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package virtwrap
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/hooks"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cli"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/util"
+)
+
+// recordDomainDrift stores the fields of the VMI whose configuration in the running domain differs from the domain
+// converted from the VMI in the metadata of the domain, virt-handler reports them on the VMI.
+// Only the metadata is updated, the domain itself is not redefined.
+func (l *LibvirtDomainManager) recordDomainDrift(vmi *v1.VirtualMachineInstance, dom cli.VirDomain, live *api.DomainSpec, expected *api.DomainSpec) error {
+	if _, exists := vmi.Annotations[hooks.HookSidecarListAnnotationName]; exists {
+		// Hook sidecars are free to change the domain, it can't be compared to the VMI
+		return nil
+	}
+
+	var drift *api.DriftMetadata
+	if fields := domainDrift(live, expected); len(fields) > 0 {
+		drift = &api.DriftMetadata{Fields: fields}
+	}
+	if reflect.DeepEqual(live.Metadata.KubeVirt.Drift, drift) {
+		return nil
+	}
+
+	// the metadata of the live domain is not updated when a persistent domain is redefined
+	domainSpec, err := l.getDomainSpec(dom)
+	if err != nil {
+		return err
+	}
+	metadata := domainSpec.Metadata.KubeVirt
+	metadata.Drift = drift
+	return util.SetKubeVirtMetadata(dom, &metadata)
+}
+
+// domainDrift returns the sorted paths of the VMI fields whose configuration in the live domain differs from the
+// expected one. Only settings which libvirt reports back the way they were defined are compared, disks which are
+// not attached yet are left to the hotplug handling.
+func domainDrift(live, expected *api.DomainSpec) []string {
+	var drifted []string
+	if alignedMemory(live.Memory) != alignedMemory(expected.Memory) {
+		drifted = append(drifted, "spec.domain.memory")
+	}
+	if vcpus(live.VCPU) != vcpus(expected.VCPU) ||
+		(expected.CPU.Topology != nil && !reflect.DeepEqual(live.CPU.Topology, expected.CPU.Topology)) {
+		drifted = append(drifted, "spec.domain.cpu")
+	}
+
+	liveDisks := map[string]api.Disk{}
+	for _, disk := range live.Devices.Disks {
+		if disk.Alias != nil && disk.Alias.IsUserDefined() {
+			liveDisks[disk.Alias.GetName()] = disk
+		}
+	}
+	for _, disk := range expected.Devices.Disks {
+		if disk.Alias == nil || !disk.Alias.IsUserDefined() {
+			continue
+		}
+		if liveDisk, exists := liveDisks[disk.Alias.GetName()]; exists && diskDrifted(liveDisk, disk) {
+			drifted = append(drifted, fmt.Sprintf("spec.domain.devices.disks[name=%s]", disk.Alias.GetName()))
+		}
+	}
+
+	liveInterfaces := map[string]api.Interface{}
+	for _, iface := range live.Devices.Interfaces {
+		if iface.Alias != nil && iface.Alias.IsUserDefined() {
+			liveInterfaces[iface.Alias.GetName()] = iface
+		}
+	}
+	for _, iface := range expected.Devices.Interfaces {
+		if iface.Alias == nil || !iface.Alias.IsUserDefined() {
+			continue
+		}
+		name := iface.Alias.GetName()
+		if liveIface, exists := liveInterfaces[name]; !exists || interfaceDrifted(liveIface, iface) {
+			drifted = append(drifted, fmt.Sprintf("spec.domain.devices.interfaces[name=%s]", name))
+		}
+		delete(liveInterfaces, name)
+	}
+	for name := range liveInterfaces {
+		drifted = append(drifted, fmt.Sprintf("spec.domain.devices.interfaces[name=%s]", name))
+	}
+
+	sort.Strings(drifted)
+	return drifted
+}
+
+func diskDrifted(live, expected api.Disk) bool {
+	if live.Target.Bus != expected.Target.Bus || (live.ReadOnly == nil) != (expected.ReadOnly == nil) {
+		return true
+	}
+	if expected.Driver == nil {
+		return false
+	}
+	if live.Driver == nil {
+		return true
+	}
+	return (expected.Driver.Cache != "" && live.Driver.Cache != expected.Driver.Cache) ||
+		(expected.Driver.IO != "" && live.Driver.IO != expected.Driver.IO)
+}
+
+func interfaceDrifted(live, expected api.Interface) bool {
+	if expected.Model != nil && (live.Model == nil || live.Model.Type != expected.Model.Type) {
+		return true
+	}
+	return expected.MAC != nil && (live.MAC == nil || !strings.EqualFold(live.MAC.MAC, expected.MAC.MAC))
+}
+
+func vcpus(vcpu *api.VCPU) uint32 {
+	if vcpu == nil {
+		return 0
+	}
+	return vcpu.CPUs
+}
+
+// alignedMemory returns the memory in MiB, rounded up the way libvirt aligns the memory of the domain
+func alignedMemory(memory api.Memory) uint64 {
+	var unit uint64
+	switch memory.Unit {
+	case "b", "bytes":
+		unit = 1
+	case "KB":
+		unit = 1000
+	case "MB":
+		unit = 1000 * 1000
+	case "GB":
+		unit = 1000 * 1000 * 1000
+	case "M", "MiB":
+		unit = 1024 * 1024
+	case "G", "GiB":
+		unit = 1024 * 1024 * 1024
+	default:
+		unit = 1024
+	}
+
+	const mebibyte = 1024 * 1024
+	return (memory.Value*unit + mebibyte - 1) / mebibyte
+}
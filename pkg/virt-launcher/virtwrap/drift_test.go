@@ -0,0 +1,134 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package virtwrap
+
+import (
+	"encoding/xml"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"libvirt.org/go/libvirt"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cli"
+)
+
+var _ = Describe("Domain drift", func() {
+
+	newDomainSpec := func() *api.DomainSpec {
+		return &api.DomainSpec{
+			Memory: api.Memory{Value: 1024 * 1024 * 1024, Unit: "b"},
+			VCPU:   &api.VCPU{Placement: "static", CPUs: 2},
+			CPU:    api.CPU{Topology: &api.CPUTopology{Sockets: 1, Cores: 2, Threads: 1}},
+			Devices: api.Devices{
+				Disks: []api.Disk{{
+					Alias:  api.NewUserDefinedAlias("rootdisk"),
+					Target: api.DiskTarget{Bus: "virtio", Device: "vda"},
+					Driver: &api.DiskDriver{Cache: "none", Name: "qemu", Type: "raw"},
+				}},
+				Interfaces: []api.Interface{{
+					Alias: api.NewUserDefinedAlias("default"),
+					Model: &api.Model{Type: "virtio"},
+				}},
+			},
+		}
+	}
+
+	It("should not report a domain which matches the VMI", func() {
+		live := newDomainSpec()
+		live.Memory = api.Memory{Value: 1024 * 1024, Unit: "KiB"}
+		live.Devices.Interfaces[0].MAC = &api.MAC{MAC: "02:00:00:00:00:01"}
+		Expect(domainDrift(live, newDomainSpec())).To(BeEmpty())
+	})
+
+	It("should not report disks which are not attached yet", func() {
+		expected := newDomainSpec()
+		expected.Devices.Disks = append(expected.Devices.Disks, api.Disk{
+			Alias:  api.NewUserDefinedAlias("hotplug"),
+			Target: api.DiskTarget{Bus: "scsi", Device: "sda"},
+		})
+		Expect(domainDrift(newDomainSpec(), expected)).To(BeEmpty())
+	})
+
+	table.DescribeTable("should report", func(modify func(live *api.DomainSpec), expectedFields ...string) {
+		live := newDomainSpec()
+		modify(live)
+		Expect(domainDrift(live, newDomainSpec())).To(Equal(expectedFields))
+	},
+		table.Entry("changed memory", func(live *api.DomainSpec) {
+			live.Memory = api.Memory{Value: 2, Unit: "GiB"}
+		}, "spec.domain.memory"),
+		table.Entry("changed vCPUs", func(live *api.DomainSpec) {
+			live.VCPU.CPUs = 4
+		}, "spec.domain.cpu"),
+		table.Entry("a changed CPU topology", func(live *api.DomainSpec) {
+			live.CPU.Topology = &api.CPUTopology{Sockets: 2, Cores: 1, Threads: 1}
+		}, "spec.domain.cpu"),
+		table.Entry("a changed disk bus", func(live *api.DomainSpec) {
+			live.Devices.Disks[0].Target.Bus = "sata"
+		}, "spec.domain.devices.disks[name=rootdisk]"),
+		table.Entry("a changed disk cache", func(live *api.DomainSpec) {
+			live.Devices.Disks[0].Driver.Cache = "writeback"
+		}, "spec.domain.devices.disks[name=rootdisk]"),
+		table.Entry("a changed interface model", func(live *api.DomainSpec) {
+			live.Devices.Interfaces[0].Model.Type = "e1000"
+		}, "spec.domain.devices.interfaces[name=default]"),
+		table.Entry("a missing interface", func(live *api.DomainSpec) {
+			live.Devices.Interfaces = nil
+		}, "spec.domain.devices.interfaces[name=default]"),
+		table.Entry("an additional interface", func(live *api.DomainSpec) {
+			live.Devices.Interfaces = append(live.Devices.Interfaces, api.Interface{Alias: api.NewUserDefinedAlias("extra")})
+		}, "spec.domain.devices.interfaces[name=extra]"),
+		table.Entry("several fields sorted", func(live *api.DomainSpec) {
+			live.VCPU.CPUs = 4
+			live.Memory = api.Memory{Value: 2, Unit: "GiB"}
+		}, "spec.domain.cpu", "spec.domain.memory"),
+	)
+
+	It("should record the drift in the metadata without redefining the domain", func() {
+		ctrl := gomock.NewController(GinkgoT())
+		mockDomain := cli.NewMockVirDomain(ctrl)
+		manager := &LibvirtDomainManager{virConn: cli.NewMockConnection(ctrl)}
+
+		live := newDomainSpec()
+		live.VCPU.CPUs = 4
+		liveXML, err := xml.Marshal(live)
+		Expect(err).ToNot(HaveOccurred())
+		mockDomain.EXPECT().GetXMLDesc(libvirt.DomainXMLFlags(0)).Return(string(liveXML), nil)
+		mockDomain.EXPECT().IsPersistent().AnyTimes().Return(true, nil)
+		mockDomain.EXPECT().GetMetadata(libvirt.DOMAIN_METADATA_ELEMENT, "http://kubevirt.io", libvirt.DOMAIN_AFFECT_CONFIG).
+			Return(`<kubevirt><uid>1234</uid></kubevirt>`, nil)
+		mockDomain.EXPECT().SetMetadata(libvirt.DOMAIN_METADATA_ELEMENT, gomock.Any(), "kubevirt", "http://kubevirt.io",
+			libvirt.DOMAIN_AFFECT_CONFIG|libvirt.DOMAIN_AFFECT_LIVE).DoAndReturn(
+			func(_ libvirt.DomainMetadataType, metadataXML, _, _ string, _ libvirt.DomainModificationImpact) error {
+				metadata := &api.KubeVirtMetadata{}
+				Expect(xml.Unmarshal([]byte(metadataXML), metadata)).To(Succeed())
+				Expect(metadata.UID).To(BeEquivalentTo("1234"))
+				Expect(metadata.Drift).To(Equal(&api.DriftMetadata{Fields: []string{"spec.domain.cpu"}}))
+				return nil
+			})
+
+		vmi := v1.NewMinimalVMI("testvmi")
+		Expect(manager.recordDomainDrift(vmi, mockDomain, live, newDomainSpec())).To(Succeed())
+	})
+})
@@ -4,6 +4,8 @@
 package virtwrap
 
 import (
+	context "context"
+
 	gomock "github.com/golang/mock/gomock"
 
 	v1 "kubevirt.io/client-go/api/v1"
@@ -34,15 +36,15 @@ func (_m *MockDomainManager) EXPECT() *_MockDomainManagerRecorder {
 	return _m.recorder
 }
 
-func (_m *MockDomainManager) SyncVMI(_param0 *v1.VirtualMachineInstance, _param1 bool, _param2 *v10.VirtualMachineOptions) (*api.DomainSpec, error) {
-	ret := _m.ctrl.Call(_m, "SyncVMI", _param0, _param1, _param2)
+func (_m *MockDomainManager) SyncVMI(_param0 context.Context, _param1 *v1.VirtualMachineInstance, _param2 bool, _param3 *v10.VirtualMachineOptions) (*api.DomainSpec, error) {
+	ret := _m.ctrl.Call(_m, "SyncVMI", _param0, _param1, _param2, _param3)
 	ret0, _ := ret[0].(*api.DomainSpec)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-func (_mr *_MockDomainManagerRecorder) SyncVMI(arg0, arg1, arg2 interface{}) *gomock.Call {
-	return _mr.mock.ctrl.RecordCall(_mr.mock, "SyncVMI", arg0, arg1, arg2)
+func (_mr *_MockDomainManagerRecorder) SyncVMI(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SyncVMI", arg0, arg1, arg2, arg3)
 }
 
 func (_m *MockDomainManager) PauseVMI(_param0 *v1.VirtualMachineInstance) error {
@@ -85,6 +87,48 @@ func (_mr *_MockDomainManagerRecorder) UnfreezeVMI(arg0 interface{}) *gomock.Cal
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "UnfreezeVMI", arg0)
 }
 
+func (_m *MockDomainManager) SnapshotVMIMemory(_param0 *v1.VirtualMachineInstance) error {
+	ret := _m.ctrl.Call(_m, "SnapshotVMIMemory", _param0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockDomainManagerRecorder) SnapshotVMIMemory(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SnapshotVMIMemory", arg0)
+}
+
+func (_m *MockDomainManager) UploadVMIMemory(_param0 *v1.VirtualMachineInstance, _param1 *v1.MemorySnapshotOptions, _param2 *cmd_client.ObjectStorageCredentials) (*v1.MemorySnapshotUploadStatus, error) {
+	ret := _m.ctrl.Call(_m, "UploadVMIMemory", _param0, _param1, _param2)
+	ret0, _ := ret[0].(*v1.MemorySnapshotUploadStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockDomainManagerRecorder) UploadVMIMemory(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "UploadVMIMemory", arg0, arg1, arg2)
+}
+
+func (_m *MockDomainManager) ScreenshotVMI(_param0 *v1.VirtualMachineInstance) ([]byte, error) {
+	ret := _m.ctrl.Call(_m, "ScreenshotVMI", _param0)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockDomainManagerRecorder) ScreenshotVMI(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "ScreenshotVMI", arg0)
+}
+
+func (_m *MockDomainManager) SetInterfaceLinkState(_param0 *v1.VirtualMachineInstance, _param1 *v1.SetLinkStateOptions) error {
+	ret := _m.ctrl.Call(_m, "SetInterfaceLinkState", _param0, _param1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockDomainManagerRecorder) SetInterfaceLinkState(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SetInterfaceLinkState", arg0, arg1)
+}
+
 func (_m *MockDomainManager) KillVMI(_param0 *v1.VirtualMachineInstance) error {
 	ret := _m.ctrl.Call(_m, "KillVMI", _param0)
 	ret0, _ := ret[0].(error)
@@ -0,0 +1,69 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package virtwrap
+
+import (
+	"libvirt.org/go/libvirt"
+
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cli"
+)
+
+// syncInterfaceBandwidth applies the bandwidth of the expected interfaces to the running domain, the same way
+// virsh domiftune does. An average of zero removes the limit, so limits dropped from the VMI are lifted too.
+func syncInterfaceBandwidth(dom cli.VirDomain, live, expected []api.Interface) error {
+	for _, iface := range expected {
+		if iface.Alias == nil || iface.Type != "ethernet" {
+			continue
+		}
+		liveIface := lookupInterfaceByAlias(live, iface.Alias.GetName())
+		if liveIface == nil || liveIface.MAC == nil {
+			continue
+		}
+
+		params := newInterfaceParameters(iface.BandWidth)
+		if *params == *newInterfaceParameters(liveIface.BandWidth) {
+			continue
+		}
+		log.Log.V(1).Infof("Changing the bandwidth of interface %s", iface.Alias.GetName())
+		if err := dom.SetInterfaceParameters(liveIface.MAC.MAC, params, libvirt.DOMAIN_AFFECT_LIVE); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newInterfaceParameters(bandwidth *api.BandWidth) *libvirt.DomainInterfaceParameters {
+	params := &libvirt.DomainInterfaceParameters{
+		BandwidthInAverageSet:  true,
+		BandwidthOutAverageSet: true,
+	}
+	if bandwidth == nil {
+		return params
+	}
+	if bandwidth.Inbound != nil {
+		params.BandwidthInAverage = bandwidth.Inbound.Average
+	}
+	if bandwidth.Outbound != nil {
+		params.BandwidthOutAverage = bandwidth.Outbound.Average
+	}
+	return params
+}
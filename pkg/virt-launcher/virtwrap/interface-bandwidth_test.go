@@ -0,0 +1,91 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package virtwrap
+
+import (
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"libvirt.org/go/libvirt"
+
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cli"
+)
+
+var _ = Describe("Interface bandwidth", func() {
+	var ctrl *gomock.Controller
+	var mockDomain *cli.MockVirDomain
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockDomain = cli.NewMockVirDomain(ctrl)
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	newInterface := func(bandwidth *api.BandWidth) api.Interface {
+		return api.Interface{
+			Type:      "ethernet",
+			Alias:     api.NewUserDefinedAlias("default"),
+			BandWidth: bandwidth,
+		}
+	}
+
+	newLiveInterface := func(bandwidth *api.BandWidth) api.Interface {
+		iface := newInterface(bandwidth)
+		iface.MAC = &api.MAC{MAC: "02:00:00:00:00:01"}
+		return iface
+	}
+
+	It("should not touch interfaces whose bandwidth is applied", func() {
+		bandwidth := &api.BandWidth{Inbound: &api.BandWidthRate{Average: 1000}}
+		live := []api.Interface{newLiveInterface(bandwidth)}
+		expected := []api.Interface{newInterface(bandwidth)}
+		Expect(syncInterfaceBandwidth(mockDomain, live, expected)).To(Succeed())
+	})
+
+	It("should apply a changed bandwidth to the running domain", func() {
+		live := []api.Interface{newLiveInterface(&api.BandWidth{Inbound: &api.BandWidthRate{Average: 1000}})}
+		expected := []api.Interface{newInterface(&api.BandWidth{Outbound: &api.BandWidthRate{Average: 2000}})}
+		mockDomain.EXPECT().SetInterfaceParameters("02:00:00:00:00:01", &libvirt.DomainInterfaceParameters{
+			BandwidthInAverageSet:  true,
+			BandwidthOutAverageSet: true,
+			BandwidthOutAverage:    2000,
+		}, libvirt.DOMAIN_AFFECT_LIVE).Return(nil)
+		Expect(syncInterfaceBandwidth(mockDomain, live, expected)).To(Succeed())
+	})
+
+	It("should lift a limit which was removed", func() {
+		live := []api.Interface{newLiveInterface(&api.BandWidth{Inbound: &api.BandWidthRate{Average: 1000}})}
+		expected := []api.Interface{newInterface(nil)}
+		mockDomain.EXPECT().SetInterfaceParameters("02:00:00:00:00:01", &libvirt.DomainInterfaceParameters{
+			BandwidthInAverageSet:  true,
+			BandwidthOutAverageSet: true,
+		}, libvirt.DOMAIN_AFFECT_LIVE).Return(nil)
+		Expect(syncInterfaceBandwidth(mockDomain, live, expected)).To(Succeed())
+	})
+
+	It("should skip interfaces which are not in the running domain", func() {
+		expected := []api.Interface{newInterface(&api.BandWidth{Inbound: &api.BandWidthRate{Average: 1000}})}
+		Expect(syncInterfaceBandwidth(mockDomain, nil, expected)).To(Succeed())
+	})
+})
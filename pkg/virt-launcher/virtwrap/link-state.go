@@ -0,0 +1,71 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package virtwrap
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"libvirt.org/go/libvirt"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/util"
+)
+
+// SetInterfaceLinkState changes the link state of an interface of the running domain, the same
+// way virsh domif-setlink does. Only the live domain is updated, so the link state is kept
+// across migrations but not across restarts of the VMI.
+func (l *LibvirtDomainManager) SetInterfaceLinkState(vmi *v1.VirtualMachineInstance, options *v1.SetLinkStateOptions) error {
+	domName := api.VMINamespaceKeyFunc(vmi)
+	dom, err := l.virConn.LookupDomainByName(domName)
+	if err != nil {
+		return err
+	}
+	defer dom.Free()
+
+	domainSpec, err := util.GetDomainSpecWithFlags(dom, 0)
+	if err != nil {
+		return err
+	}
+
+	iface := lookupInterfaceByAlias(domainSpec.Devices.Interfaces, options.InterfaceName)
+	if iface == nil {
+		return fmt.Errorf("interface %s not found in the domain", options.InterfaceName)
+	}
+	iface.LinkState = &api.LinkState{State: string(options.State)}
+
+	// api.Interface carries no element name of its own
+	var ifaceXML strings.Builder
+	if err := xml.NewEncoder(&ifaceXML).EncodeElement(iface, xml.StartElement{Name: xml.Name{Local: "interface"}}); err != nil {
+		return err
+	}
+	return dom.UpdateDeviceFlags(ifaceXML.String(), libvirt.DOMAIN_DEVICE_MODIFY_LIVE)
+}
+
+func lookupInterfaceByAlias(interfaces []api.Interface, name string) *api.Interface {
+	for i, iface := range interfaces {
+		if iface.Alias != nil && iface.Alias.GetName() == name {
+			return &interfaces[i]
+		}
+	}
+	return nil
+}
@@ -40,11 +40,16 @@ import (
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cli"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/converter"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/device/hostdevice"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/device/hostdevice/gpu"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/device/hostdevice/sriov"
 	domainerrors "kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/errors"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/util"
 )
 
+// Seconds a migration has to run before its projected completion time is taken into account
+const projectionGracePeriod int64 = 30
+
 // Only used for testing, migration proxy ports are 'well-known' ports and should not be randomized in production
 var osChosenMigrationProxyPort = false
 
@@ -68,6 +73,7 @@ type migrationMonitor struct {
 	lastProgressUpdate int64
 	progressWatermark  int64
 	remainingData      int64
+	processedData      int64
 
 	progressTimeout          int64
 	acceptableCompletionTime int64
@@ -102,20 +108,25 @@ func generateMigrationFlags(isBlockMigration, isUnsafeMigration, allowAutoConver
 
 }
 
-func hotUnplugHostDevices(virConn cli.Connection, dom cli.VirDomain) error {
+func hotUnplugHostDevices(virConn cli.Connection, dom cli.VirDomain, detachGPUs bool) error {
 	domainSpec, err := util.GetDomainSpecWithFlags(dom, 0)
 	if err != nil {
 		return err
 	}
 
-	eventChan := make(chan interface{}, sriov.MaxConcurrentHotPlugDevicesEvents)
+	hostDevices := sriov.FilterHostDevices(domainSpec)
+	if detachGPUs {
+		hostDevices = append(hostDevices, gpu.FilterHostDevices(domainSpec)...)
+	}
+
+	eventChan := make(chan interface{}, hostdevice.MaxConcurrentHotPlugDevicesEvents)
 	var callback libvirt.DomainEventDeviceRemovedCallback = func(c *libvirt.Connect, d *libvirt.Domain, event *libvirt.DomainEventDeviceRemoved) {
 		eventChan <- event.DevAlias
 	}
 
 	if domainEvent := cli.NewDomainEventDeviceRemoved(virConn, dom, callback, eventChan); domainEvent != nil {
 		const waitForDetachTimeout = 30 * time.Second
-		err := sriov.SafelyDetachHostDevices(domainSpec, domainEvent, dom, waitForDetachTimeout)
+		err := hostdevice.SafelyDetachHostDevices(hostDevices, domainEvent, dom, waitForDetachTimeout)
 		if err != nil {
 			return err
 		}
@@ -123,6 +134,16 @@ func hotUnplugHostDevices(virConn cli.Connection, dom cli.VirDomain) error {
 	return nil
 }
 
+// shouldDetachGPUsForMigration returns whether the GPUs of the VMI are hot-unplugged
+// before a live migration and hot-plugged again on the target.
+// TODO: migrate mdevs whose vendor driver supports migration without detaching them. libvirt
+// only migrates domains with VFIO devices since 8.6, virt-launcher ships 7.0, which refuses
+// it regardless of the migration flags.
+func shouldDetachGPUsForMigration(vmi *v1.VirtualMachineInstance) bool {
+	devices := vmi.Spec.Domain.Devices
+	return len(devices.GPUs) > 0 && devices.DetachGPUsForMigration != nil && *devices.DetachGPUsForMigration
+}
+
 // This returns domain xml without the migration metadata section, as it is only relevant to the source domain
 // Note: Unfortunately we can't just use UnMarshall + Marshall here, as that leads to unwanted XML alterations
 func migratableDomXML(dom cli.VirDomain, vmi *v1.VirtualMachineInstance) (string, error) {
@@ -473,6 +494,35 @@ func (m *migrationMonitor) shouldTriggerTimeout(elapsed int64, domSpec *api.Doma
 	return false
 }
 
+// projectedCompletionTime estimates the total duration of the migration in seconds,
+// based on the average transfer rate so far and the amount of data still remaining.
+// Zero is returned as long as no estimation is possible.
+func projectedCompletionTime(elapsed int64, processedData int64, remainingData int64) int64 {
+	elapsedSeconds := elapsed / int64(time.Second)
+	if elapsedSeconds <= 0 {
+		return 0
+	}
+	rate := processedData / elapsedSeconds
+	if rate <= 0 {
+		return 0
+	}
+	return elapsedSeconds + remainingData/rate
+}
+
+func (m *migrationMonitor) shouldAbortOnProjectedTimeout(elapsed int64) bool {
+	// post copy is the better remedy for a slow migration if it is allowed
+	if !m.options.AbortOnProjectedTimeout || m.options.AllowPostCopy || m.acceptableCompletionTime == 0 {
+		return false
+	}
+
+	// the transfer rate of the first seconds is not representative
+	if elapsed/int64(time.Second) < projectionGracePeriod {
+		return false
+	}
+
+	return projectedCompletionTime(elapsed, m.processedData, m.remainingData) > m.acceptableCompletionTime
+}
+
 func (m *migrationMonitor) shouldTriggerPostCopy(elapsed int64, domSpec *api.DomainSpec) bool {
 	if m.shouldTriggerTimeout(elapsed, domSpec) && m.options.AllowPostCopy {
 
@@ -619,6 +669,21 @@ func (m *migrationMonitor) processInflightMigration(dom cli.VirDomain) *inflight
 		aborted.message = fmt.Sprintf("Live migration is not completed after %d sec and has been aborted", m.acceptableCompletionTime)
 		aborted.abortStatus = v1.MigrationAbortSucceeded
 		return aborted
+	case m.shouldAbortOnProjectedTimeout(elapsed):
+		// abort right away instead of waiting for a migration
+		// which won't make it in time anyway
+		projected := projectedCompletionTime(elapsed, m.processedData, m.remainingData)
+
+		err := dom.AbortJob()
+		if err != nil {
+			logger.Reason(err).Error("failed to abort migration")
+			return nil
+		}
+
+		aborted := &inflightMigrationAborted{}
+		aborted.message = fmt.Sprintf("Live migration is projected to take %d sec, which exceeds the completion timeout of %d sec, and has been aborted", projected, m.acceptableCompletionTime)
+		aborted.abortStatus = v1.MigrationAbortSucceeded
+		return aborted
 	}
 
 	return nil
@@ -682,6 +747,7 @@ func (m *migrationMonitor) startMonitor() {
 			}
 		}
 		m.remainingData = int64(stats.DataRemaining)
+		m.processedData = int64(stats.DataProcessed)
 		switch stats.Type {
 		case libvirt.DOMAIN_JOB_UNBOUNDED:
 			aborted := m.processInflightMigration(dom)
@@ -793,9 +859,8 @@ func generateMigrationParams(dom cli.VirDomain, vmi *v1.VirtualMachineInstance,
 	return params, nil
 }
 
-func (l *LibvirtDomainManager) migrateHelper(vmi *v1.VirtualMachineInstance, options *cmdclient.MigrationOptions) error {
+func (l *LibvirtDomainManager) migrateHelper(vmi *v1.VirtualMachineInstance, options *cmdclient.MigrationOptions) (err error) {
 
-	var err error
 	var params *libvirt.DomainMigrateParameters
 
 	proxies := l.generateMigrationProxies(vmi)
@@ -811,7 +876,12 @@ func (l *LibvirtDomainManager) migrateHelper(vmi *v1.VirtualMachineInstance, opt
 	if err != nil {
 		return fmt.Errorf("failed to retrive domain state")
 	}
-	migrateFlags := generateMigrationFlags(isBlockMigration(vmi), options.UnsafeMigration, options.AllowAutoConverge, options.AllowPostCopy, migratePaused)
+
+	// The target resumes the domain once the GPUs are attached again, it can't tell that the user paused it.
+	detachGPUs := shouldDetachGPUsForMigration(vmi)
+	if detachGPUs && migratePaused {
+		return fmt.Errorf("can't migrate a paused VMI whose GPUs are detached for the migration")
+	}
 
 	// anything that modifies the domain needs to be performed with the domainModifyLock held
 	// The domain params and unHotplug need to be performed in a critical section together.
@@ -819,10 +889,18 @@ func (l *LibvirtDomainManager) migrateHelper(vmi *v1.VirtualMachineInstance, opt
 		l.domainModifyLock.Lock()
 		defer l.domainModifyLock.Unlock()
 
-		if err := prepareDomainForMigration(l.virConn, dom); err != nil {
+		if err := prepareDomainForMigration(l.virConn, dom, detachGPUs); err != nil {
 			return fmt.Errorf("error encountered during preparing domain for migration: %v", err)
 		}
 
+		if detachGPUs {
+			// the guest must not run without its GPUs, it stays paused until the target attached them
+			if err := dom.Suspend(); err != nil {
+				return fmt.Errorf("failed to suspend the domain after detaching its GPUs: %v", err)
+			}
+			migratePaused = true
+		}
+
 		params, err = generateMigrationParams(dom, vmi, options)
 		if err != nil {
 			return fmt.Errorf("error encountered while generating migration parameters: %v", err)
@@ -831,10 +909,19 @@ func (l *LibvirtDomainManager) migrateHelper(vmi *v1.VirtualMachineInstance, opt
 		return nil
 	}
 
+	if detachGPUs {
+		defer func() {
+			if err != nil {
+				l.restoreDetachedGPUs(vmi)
+			}
+		}()
+	}
+
 	err = critSection()
 	if err != nil {
 		return err
 	}
+	migrateFlags := generateMigrationFlags(isBlockMigration(vmi), options.UnsafeMigration, options.AllowAutoConverge, options.AllowPostCopy, migratePaused)
 
 	// establish all connection proxies before starting migration
 	for _, proxy := range proxies {
@@ -861,10 +948,22 @@ func (l *LibvirtDomainManager) migrateHelper(vmi *v1.VirtualMachineInstance, opt
 	return nil
 }
 
+// restoreDetachedGPUs attaches the GPUs, which were detached for a failed migration,
+// to the source domain again and resumes it
+func (l *LibvirtDomainManager) restoreDetachedGPUs(vmi *v1.VirtualMachineInstance) {
+	if err := l.hotPlugHostDevices(vmi); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("failed to hot-plug host-devices after a failed migration")
+		return
+	}
+	if err := l.UnpauseVMI(vmi); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("failed to resume the domain after a failed migration")
+	}
+}
+
 // prepareDomainForMigration perform necessary operation
 // on the source domain just before migration
-func prepareDomainForMigration(virtConn cli.Connection, domain cli.VirDomain) error {
-	return hotUnplugHostDevices(virtConn, domain)
+func prepareDomainForMigration(virtConn cli.Connection, domain cli.VirDomain, detachGPUs bool) error {
+	return hotUnplugHostDevices(virtConn, domain, detachGPUs)
 }
 
 func shouldImmediatelyFailMigration(vmi *v1.VirtualMachineInstance) bool {
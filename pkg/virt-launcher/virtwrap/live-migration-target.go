@@ -20,6 +20,7 @@
 package virtwrap
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"strconv"
@@ -34,14 +35,27 @@ import (
 )
 
 func (l *LibvirtDomainManager) finalizeMigrationTarget(vmi *v1.VirtualMachineInstance) error {
-	if err := l.hotPlugHostDevices(vmi); err != nil {
-		log.Log.Object(vmi).Reason(err).Error("failed to hot-plug host-devices")
+	// a failed hot-plug must not keep the migrated domain paused,
+	// it is reported once the domain runs on the target
+	hotPlugErr := l.hotPlugHostDevices(vmi)
+	if hotPlugErr != nil {
+		log.Log.Object(vmi).Reason(hotPlugErr).Error("failed to hot-plug host-devices")
+	}
+
+	// the source paused the domain to migrate it without its GPUs
+	if shouldDetachGPUsForMigration(vmi) {
+		if err := l.UnpauseVMI(vmi); err != nil {
+			return err
+		}
 	}
 
 	if err := l.setGuestTime(vmi); err != nil {
 		return err
 	}
 
+	if hotPlugErr != nil {
+		return fmt.Errorf("failed to hot-plug host-devices: %v", hotPlugErr)
+	}
 	return nil
 }
 
@@ -63,7 +77,7 @@ func (l *LibvirtDomainManager) prepareMigrationTarget(vmi *v1.VirtualMachineInst
 	}
 
 	domain := &api.Domain{}
-	if err := converter.Convert_v1_VirtualMachineInstance_To_api_Domain(vmi, domain, c); err != nil {
+	if err := converter.Convert_v1_VirtualMachineInstance_To_api_Domain(context.Background(), vmi, domain, c); err != nil {
 		return fmt.Errorf("conversion failed: %v", err)
 	}
 
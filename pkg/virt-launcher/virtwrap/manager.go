@@ -36,6 +36,7 @@ import (
 	"sync"
 	"time"
 
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/device/hostdevice"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/device/hostdevice/generic"
 	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/device/hostdevice/gpu"
 
@@ -90,11 +91,15 @@ type contextStore struct {
 }
 
 type DomainManager interface {
-	SyncVMI(*v1.VirtualMachineInstance, bool, *cmdv1.VirtualMachineOptions) (*api.DomainSpec, error)
+	SyncVMI(context.Context, *v1.VirtualMachineInstance, bool, *cmdv1.VirtualMachineOptions) (*api.DomainSpec, error)
 	PauseVMI(*v1.VirtualMachineInstance) error
 	UnpauseVMI(*v1.VirtualMachineInstance) error
 	FreezeVMI(*v1.VirtualMachineInstance) error
 	UnfreezeVMI(*v1.VirtualMachineInstance) error
+	SnapshotVMIMemory(*v1.VirtualMachineInstance) error
+	UploadVMIMemory(*v1.VirtualMachineInstance, *v1.MemorySnapshotOptions, *cmdclient.ObjectStorageCredentials) (*v1.MemorySnapshotUploadStatus, error)
+	ScreenshotVMI(*v1.VirtualMachineInstance) ([]byte, error)
+	SetInterfaceLinkState(*v1.VirtualMachineInstance, *v1.SetLinkStateOptions) error
 	KillVMI(*v1.VirtualMachineInstance) error
 	DeleteVMI(*v1.VirtualMachineInstance) error
 	SignalShutdownVMI(*v1.VirtualMachineInstance) error
@@ -121,6 +126,9 @@ type LibvirtDomainManager struct {
 	domainModifyLock sync.Mutex
 	// mutex to control access to the guest time context
 	setGuestTimeLock sync.Mutex
+	// mutex to control access to the memory upload
+	memoryUploadLock sync.Mutex
+	memoryUpload     *memoryUpload
 
 	credManager *accesscredentials.AccessCredentialManager
 
@@ -302,7 +310,7 @@ func (l *LibvirtDomainManager) FinalizeVirtualMachineMigration(vmi *v1.VirtualMa
 }
 
 // hotPlugHostDevices attach host-devices to running domain
-// Currently only SRIOV host-devices are supported
+// Currently only SRIOV and GPU host-devices are supported
 func (l *LibvirtDomainManager) hotPlugHostDevices(vmi *v1.VirtualMachineInstance) error {
 	l.domainModifyLock.Lock()
 	defer l.domainModifyLock.Unlock()
@@ -324,7 +332,12 @@ func (l *LibvirtDomainManager) hotPlugHostDevices(vmi *v1.VirtualMachineInstance
 		return err
 	}
 
-	if err := sriov.AttachHostDevices(domain, sriovHostDevices); err != nil {
+	gpuHostDevices, err := gpu.GetHostDevicesToAttach(vmi, domainSpec)
+	if err != nil {
+		return err
+	}
+
+	if err := hostdevice.AttachHostDevices(domain, append(sriovHostDevices, gpuHostDevices...)); err != nil {
 		return err
 	}
 
@@ -531,6 +544,10 @@ func (l *LibvirtDomainManager) preStartHook(vmi *v1.VirtualMachineInstance, doma
 	if err := downwardmetrics.CreateDownwardMetricDisk(vmi); err != nil {
 		return domain, fmt.Errorf("failed to craete downwardMetric disk: %v", err)
 	}
+	// define the secrets of network disks if they exist
+	if err := l.defineNetworkDiskSecrets(vmi); err != nil {
+		return domain, err
+	}
 
 	// set drivers cache mode
 	for i := range domain.Spec.Devices.Disks {
@@ -773,7 +790,7 @@ func (l *LibvirtDomainManager) generateConverterContext(vmi *v1.VirtualMachineIn
 	return c, nil
 }
 
-func (l *LibvirtDomainManager) SyncVMI(vmi *v1.VirtualMachineInstance, allowEmulation bool, options *cmdv1.VirtualMachineOptions) (*api.DomainSpec, error) {
+func (l *LibvirtDomainManager) SyncVMI(ctx context.Context, vmi *v1.VirtualMachineInstance, allowEmulation bool, options *cmdv1.VirtualMachineOptions) (*api.DomainSpec, error) {
 	l.domainModifyLock.Lock()
 	defer l.domainModifyLock.Unlock()
 
@@ -787,8 +804,8 @@ func (l *LibvirtDomainManager) SyncVMI(vmi *v1.VirtualMachineInstance, allowEmul
 		return nil, err
 	}
 
-	if err := converter.Convert_v1_VirtualMachineInstance_To_api_Domain(vmi, domain, c); err != nil {
-		logger.Error("Conversion failed.")
+	if err := converter.Convert_v1_VirtualMachineInstance_To_api_Domain(ctx, vmi, domain, c); err != nil {
+		logger.Reason(err).Error("Conversion failed.")
 		return nil, err
 	}
 
@@ -829,14 +846,22 @@ func (l *LibvirtDomainManager) SyncVMI(vmi *v1.VirtualMachineInstance, allowEmul
 		if err != nil {
 			return nil, err
 		}
-		createFlags := getDomainCreateFlags(vmi)
-		err = dom.CreateWithFlags(createFlags)
-		if err != nil {
-			logger.Reason(err).
-				Errorf("Failed to start VirtualMachineInstance with flags %v.", createFlags)
-			return nil, err
+		if _, exists := vmi.Annotations[v1.RestoreMemoryClaimAnnotation]; exists {
+			if err := l.restoreDomainMemory(vmi, dom); err != nil {
+				logger.Reason(err).Error("Failed to resume VirtualMachineInstance from the restored memory state.")
+				return nil, err
+			}
+			logger.Info("Domain resumed from the restored memory state.")
+		} else {
+			createFlags := getDomainCreateFlags(vmi)
+			err = dom.CreateWithFlags(createFlags)
+			if err != nil {
+				logger.Reason(err).
+					Errorf("Failed to start VirtualMachineInstance with flags %v.", createFlags)
+				return nil, err
+			}
+			logger.Info("Domain started.")
 		}
-		logger.Info("Domain started.")
 		if vmi.ShouldStartPaused() {
 			l.paused.add(vmi.UID)
 		}
@@ -900,7 +925,18 @@ func (l *LibvirtDomainManager) SyncVMI(vmi *v1.VirtualMachineInstance, allowEmul
 		}
 	}
 
-	// TODO: check if VirtualMachineInstance Spec and Domain Spec are equal or if we have to sync
+	if vmi.IsRunning() {
+		if err := syncInterfaceBandwidth(dom, oldSpec.Devices.Interfaces, domain.Spec.Devices.Interfaces); err != nil {
+			logger.Reason(err).Error("changing the bandwidth of the interfaces failed")
+			return nil, err
+		}
+
+		if err := l.recordDomainDrift(vmi, dom, &oldSpec, &domain.Spec); err != nil {
+			// the drift is only reported, the domain keeps running as it is
+			logger.Reason(err).Warning("recording the drift of the domain failed")
+		}
+	}
+
 	return &oldSpec, nil
 }
 
@@ -1161,6 +1197,71 @@ func (l *LibvirtDomainManager) UnfreezeVMI(vmi *v1.VirtualMachineInstance) error
 	return nil
 }
 
+// SnapshotVMIMemory saves the memory state of the running domain into the
+// image file of the hotplugged memory dump volume, leaving the disks untouched.
+func (l *LibvirtDomainManager) SnapshotVMIMemory(vmi *v1.VirtualMachineInstance) error {
+	memoryDumpVolume, err := getMemoryDumpVolume(vmi)
+	if err != nil {
+		return err
+	}
+
+	domName := api.VMINamespaceKeyFunc(vmi)
+	dom, err := l.virConn.LookupDomainByName(domName)
+	if err != nil {
+		return err
+	}
+	defer dom.Free()
+
+	domainSpec, err := l.getDomainSpec(dom)
+	if err != nil {
+		return err
+	}
+
+	if err := saveMemory(dom, domainSpec, converter.GetHotplugFilesystemVolumePath(memoryDumpVolume)); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to snapshot vmi memory")
+		return err
+	}
+	log.Log.Object(vmi).Infof("Saved memory of vmi to %s", memoryDumpVolume)
+	return nil
+}
+
+func getMemoryDumpVolume(vmi *v1.VirtualMachineInstance) (string, error) {
+	for _, volume := range vmi.Spec.Volumes {
+		if volume.MemoryDump != nil {
+			return volume.Name, nil
+		}
+	}
+	return "", fmt.Errorf("vmi %s has no memory dump volume", vmi.Name)
+}
+
+// saveMemory saves the memory state of the running domain into the file with an external
+// snapshot which leaves the disks untouched, it returns once the file was written
+func saveMemory(dom cli.VirDomain, domainSpec *api.DomainSpec, memoryFile string) error {
+	snapshotXML, err := memorySnapshotXML(domainSpec, memoryFile)
+	if err != nil {
+		return err
+	}
+	snapshot, err := dom.CreateSnapshotXML(snapshotXML, libvirt.DOMAIN_SNAPSHOT_CREATE_LIVE|libvirt.DOMAIN_SNAPSHOT_CREATE_NO_METADATA)
+	if snapshot != nil {
+		snapshot.Free()
+	}
+	return err
+}
+
+func memorySnapshotXML(domainSpec *api.DomainSpec, memoryFile string) (string, error) {
+	snapshot := api.DomainSnapshot{
+		Memory: api.DomainSnapshotMemory{Snapshot: "external", File: memoryFile},
+	}
+	for _, disk := range domainSpec.Devices.Disks {
+		snapshot.Disks = append(snapshot.Disks, api.DomainSnapshotDisk{Name: disk.Target.Device, Snapshot: "no"})
+	}
+	snapshotXML, err := xml.Marshal(snapshot)
+	if err != nil {
+		return "", err
+	}
+	return string(snapshotXML), nil
+}
+
 func (l *LibvirtDomainManager) MarkGracefulShutdownVMI(vmi *v1.VirtualMachineInstance) error {
 	l.domainModifyLock.Lock()
 	defer l.domainModifyLock.Unlock()
@@ -1531,6 +1632,21 @@ func isDomainPaused(dom cli.VirDomain) (bool, error) {
 		util.ConvReason(status, reason) == api.ReasonPausedUser, nil
 }
 
+// restoreDomainMemory starts the defined domain from the memory state of a restored snapshot, which was saved
+// in the format of libvirt save images
+func (l *LibvirtDomainManager) restoreDomainMemory(vmi *v1.VirtualMachineInstance, dom cli.VirDomain) error {
+	domainXML, err := dom.GetXMLDesc(libvirt.DOMAIN_XML_SECURE)
+	if err != nil {
+		return err
+	}
+
+	flags := libvirt.DOMAIN_SAVE_RUNNING
+	if vmi.ShouldStartPaused() {
+		flags = libvirt.DOMAIN_SAVE_PAUSED
+	}
+	return l.virConn.DomainRestoreFlags(config.RestoreMemoryFile, domainXML, flags)
+}
+
 func getDomainCreateFlags(vmi *v1.VirtualMachineInstance) libvirt.DomainCreateFlags {
 	flags := libvirt.DOMAIN_NONE
 
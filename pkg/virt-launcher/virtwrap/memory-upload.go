@@ -0,0 +1,406 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package virtwrap
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+	kutil "kubevirt.io/kubevirt/pkg/util"
+	cmdclient "kubevirt.io/kubevirt/pkg/virt-handler/cmd-client"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cli"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/converter"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/objectstorage"
+)
+
+const (
+	memoryUploadMinPartSize = 8 * 1024 * 1024
+	// object stores accept at most 10000 parts, the headroom covers the device state in the save image
+	memoryUploadMaxParts = 9000
+
+	memoryImagePollInterval = 100 * time.Millisecond
+)
+
+var memoryUploadImage = filepath.Join(kutil.VirtPrivateDir, "memory-upload.img")
+
+type memoryUpload struct {
+	target string
+
+	lock   sync.Mutex
+	status v1.MemorySnapshotUploadStatus
+}
+
+// partUploader is the part of a multipart upload the memory stream needs
+type partUploader interface {
+	UploadPart(number int, data []byte) (objectstorage.Part, error)
+	Complete(parts []objectstorage.Part) error
+}
+
+type memoryStreamResult struct {
+	checksum string
+	err      error
+}
+
+// memorySave tracks libvirt writing the save image, err is only set once done is closed
+type memorySave struct {
+	done chan struct{}
+	err  error
+}
+
+func newMemorySave() *memorySave {
+	return &memorySave{done: make(chan struct{})}
+}
+
+func (s *memorySave) finish(err error) {
+	s.err = err
+	close(s.done)
+}
+
+func (s *memorySave) finished() bool {
+	select {
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (u *memoryUpload) getStatus() *v1.MemorySnapshotUploadStatus {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	status := u.status
+	return &status
+}
+
+func (u *memoryUpload) setStatus(status v1.MemorySnapshotUploadStatus) {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	u.status = status
+}
+
+func (u *memoryUpload) setMemorySaved() {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	u.status.MemorySaved = true
+}
+
+// UploadVMIMemory saves the memory state of the running domain in the background. Without object
+// storage in the options it is saved to the memory dump volume of the vmi, otherwise into a file
+// which is uploaded to an object of an S3 compatible object storage while libvirt is still writing
+// it. Callers poll the status by repeating the call with the same options, the credentials are only
+// needed to start an upload.
+// A failed save is forgotten once its status was reported, so that the next call starts over.
+func (l *LibvirtDomainManager) UploadVMIMemory(vmi *v1.VirtualMachineInstance, options *v1.MemorySnapshotOptions, credentials *cmdclient.ObjectStorageCredentials) (*v1.MemorySnapshotUploadStatus, error) {
+	storage := options.ObjectStorage
+	var target, memoryDumpVolume string
+	if storage != nil {
+		target = fmt.Sprintf("%s/%s/%s", storage.Endpoint, storage.Bucket, storage.Key)
+	} else {
+		volume, err := getMemoryDumpVolume(vmi)
+		if err != nil {
+			return nil, err
+		}
+		memoryDumpVolume = volume
+		target = "volume/" + volume
+	}
+
+	l.memoryUploadLock.Lock()
+	defer l.memoryUploadLock.Unlock()
+
+	if upload := l.memoryUpload; upload != nil {
+		status := upload.getStatus()
+		if upload.target == target {
+			if status.Phase == v1.MemorySnapshotUploadFailed {
+				l.memoryUpload = nil
+			}
+			return status, nil
+		}
+		if status.Phase == v1.MemorySnapshotUploadInProgress {
+			return nil, fmt.Errorf("the memory of vmi %s is already being saved to %s", vmi.Name, upload.target)
+		}
+	}
+
+	if storage != nil && credentials == nil {
+		return nil, cmdclient.ErrMemoryUploadCredentialsRequired
+	}
+
+	domName := api.VMINamespaceKeyFunc(vmi)
+	dom, err := l.virConn.LookupDomainByName(domName)
+	if err != nil {
+		return nil, err
+	}
+	domainSpec, err := l.getDomainSpec(dom)
+	if err != nil {
+		dom.Free()
+		return nil, err
+	}
+
+	if storage == nil {
+		upload := &memoryUpload{
+			target: target,
+			status: v1.MemorySnapshotUploadStatus{Phase: v1.MemorySnapshotUploadInProgress},
+		}
+		l.memoryUpload = upload
+		go runMemorySave(vmi, dom, domainSpec, upload, converter.GetHotplugFilesystemVolumePath(memoryDumpVolume))
+
+		log.Log.Object(vmi).Infof("Started to save the vmi memory to %s", memoryDumpVolume)
+		return upload.getStatus(), nil
+	}
+
+	// libvirt refuses to overwrite the memory file of an external snapshot
+	if err := os.Remove(memoryUploadImage); err != nil && !os.IsNotExist(err) {
+		dom.Free()
+		return nil, err
+	}
+
+	client, err := objectstorage.NewClient(storage.Endpoint, storage.Region, credentials.AccessKeyID, credentials.SecretAccessKey)
+	if err != nil {
+		dom.Free()
+		return nil, err
+	}
+	multipartUpload, err := client.CreateMultipartUpload(storage.Bucket, storage.Key)
+	if err != nil {
+		dom.Free()
+		return nil, err
+	}
+
+	upload := &memoryUpload{
+		target: target,
+		status: v1.MemorySnapshotUploadStatus{Phase: v1.MemorySnapshotUploadInProgress},
+	}
+	l.memoryUpload = upload
+	go runMemoryUpload(vmi, dom, domainSpec, upload, multipartUpload, memoryUploadPartSize(domainSpec.Memory))
+
+	log.Log.Object(vmi).Infof("Started the upload of the vmi memory to %s", target)
+	return upload.getStatus(), nil
+}
+
+func runMemorySave(vmi *v1.VirtualMachineInstance, dom cli.VirDomain, domainSpec *api.DomainSpec, upload *memoryUpload, memoryFile string) {
+	defer dom.Free()
+
+	if err := saveMemory(dom, domainSpec, memoryFile); err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to save vmi memory")
+		upload.setStatus(v1.MemorySnapshotUploadStatus{
+			Phase:   v1.MemorySnapshotUploadFailed,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	log.Log.Object(vmi).Infof("Saved memory of vmi to %s", upload.target)
+	upload.setStatus(v1.MemorySnapshotUploadStatus{
+		Phase:       v1.MemorySnapshotUploadSucceeded,
+		MemorySaved: true,
+	})
+}
+
+func runMemoryUpload(vmi *v1.VirtualMachineInstance, dom cli.VirDomain, domainSpec *api.DomainSpec, upload *memoryUpload, multipartUpload *objectstorage.MultipartUpload, partSize int) {
+	defer dom.Free()
+	defer os.Remove(memoryUploadImage)
+
+	save := newMemorySave()
+	result := make(chan memoryStreamResult, 1)
+	go func() {
+		checksum, err := streamMemoryImage(memoryUploadImage, multipartUpload, partSize, save)
+		result <- memoryStreamResult{checksum: checksum, err: err}
+	}()
+
+	// the snapshot returns once libvirt finished writing the save image
+	err := saveMemory(dom, domainSpec, memoryUploadImage)
+	if err == nil {
+		// the guest can be thawed while the last parts are uploaded
+		upload.setMemorySaved()
+	}
+	save.finish(err)
+	res := <-result
+
+	if res.err != nil {
+		log.Log.Object(vmi).Reason(res.err).Error("Failed to upload vmi memory")
+		if err := multipartUpload.Abort(); err != nil {
+			log.Log.Object(vmi).Reason(err).Warning("Failed to abort the upload of the vmi memory")
+		}
+		upload.setStatus(v1.MemorySnapshotUploadStatus{
+			Phase:   v1.MemorySnapshotUploadFailed,
+			Message: res.err.Error(),
+		})
+		return
+	}
+
+	log.Log.Object(vmi).Infof("Uploaded memory of vmi to %s", upload.target)
+	upload.setStatus(v1.MemorySnapshotUploadStatus{
+		Phase:       v1.MemorySnapshotUploadSucceeded,
+		MemorySaved: true,
+		Checksum:    res.checksum,
+	})
+}
+
+// streamMemoryImage uploads the save image while libvirt writes it. Every part but the first is
+// uploaded as soon as it is complete and then punched out of the file to keep the disk usage
+// down. libvirt rewrites the header at the start of the image once the memory was saved, so the
+// first part is read and uploaded last.
+func streamMemoryImage(path string, upload partUploader, partSize int, save *memorySave) (string, error) {
+	image, err := openMemoryImage(path, save)
+	if err != nil {
+		return "", err
+	}
+	defer image.Close()
+
+	var digests [][]byte
+	var parts []objectstorage.Part
+	data := make([]byte, partSize)
+	offset := int64(partSize)
+	for number := 2; ; number++ {
+		n, err := readMemoryImage(image, data, offset, save)
+		if err != nil {
+			return "", err
+		}
+		if n == 0 {
+			break
+		}
+		part, err := upload.UploadPart(number, data[:n])
+		if err != nil {
+			return "", err
+		}
+		digest := sha256.Sum256(data[:n])
+		parts = append(parts, part)
+		digests = append(digests, digest[:])
+
+		// a file system without hole punching only costs disk space
+		unix.Fallocate(int(image.Fd()), unix.FALLOC_FL_PUNCH_HOLE|unix.FALLOC_FL_KEEP_SIZE, offset, int64(n))
+		offset += int64(n)
+		if n < partSize {
+			break
+		}
+	}
+
+	first := make([]byte, partSize)
+	n, err := image.ReadAt(first, 0)
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	if n == 0 {
+		return "", fmt.Errorf("the save image is empty")
+	}
+	part, err := upload.UploadPart(1, first[:n])
+	if err != nil {
+		return "", err
+	}
+	digest := sha256.Sum256(first[:n])
+	parts = append([]objectstorage.Part{part}, parts...)
+	digests = append([][]byte{digest[:]}, digests...)
+
+	if err := upload.Complete(parts); err != nil {
+		return "", err
+	}
+	return memoryChecksum(digests), nil
+}
+
+// openMemoryImage waits for libvirt to create the save image
+func openMemoryImage(path string, save *memorySave) (*os.File, error) {
+	for {
+		finished := save.finished()
+		image, err := os.Open(path)
+		if err == nil {
+			return image, nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+		if finished {
+			if save.err != nil {
+				return nil, save.err
+			}
+			return nil, fmt.Errorf("the save image was not written")
+		}
+		time.Sleep(memoryImagePollInterval)
+	}
+}
+
+// readMemoryImage reads the part at offset once it is complete or libvirt finished the save image.
+// It returns less than a full part only for the end of a completely saved image.
+func readMemoryImage(image *os.File, data []byte, offset int64, save *memorySave) (int, error) {
+	n := 0
+	for {
+		// checked before reading, so that nothing written before the save finished is missed
+		finished := save.finished()
+		read, err := image.ReadAt(data[n:], offset+int64(n))
+		n += read
+		if err != nil && err != io.EOF {
+			return 0, err
+		}
+		if finished && save.err != nil {
+			return 0, save.err
+		}
+		if n == len(data) || finished {
+			return n, nil
+		}
+		time.Sleep(memoryImagePollInterval)
+	}
+}
+
+// memoryChecksum is the SHA-256 of the concatenated SHA-256 digests of all parts followed by the
+// number of parts. It is not the ETag of the object, which object stores compose from MD5 digests.
+func memoryChecksum(digests [][]byte) string {
+	hash := sha256.New()
+	for _, digest := range digests {
+		hash.Write(digest)
+	}
+	return fmt.Sprintf("%s-%d", hex.EncodeToString(hash.Sum(nil)), len(digests))
+}
+
+// memoryUploadPartSize scales the part size with the guest memory to stay within the part limit
+func memoryUploadPartSize(memory api.Memory) int {
+	var unit uint64
+	switch memory.Unit {
+	case "b", "bytes":
+		unit = 1
+	case "KB":
+		unit = 1000
+	case "MB":
+		unit = 1000 * 1000
+	case "GB":
+		unit = 1000 * 1000 * 1000
+	case "M", "MiB":
+		unit = 1024 * 1024
+	case "G", "GiB":
+		unit = 1024 * 1024 * 1024
+	default:
+		unit = 1024
+	}
+
+	const mebibyte = 1024 * 1024
+	partSize := memory.Value * unit / memoryUploadMaxParts
+	partSize = (partSize + mebibyte - 1) / mebibyte * mebibyte
+	if partSize < memoryUploadMinPartSize {
+		return memoryUploadMinPartSize
+	}
+	return int(partSize)
+}
@@ -0,0 +1,167 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package virtwrap
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/objectstorage"
+)
+
+type fakePartUploader struct {
+	parts  map[int][]byte
+	object []byte
+}
+
+func (u *fakePartUploader) UploadPart(number int, data []byte) (objectstorage.Part, error) {
+	u.parts[number] = append([]byte{}, data...)
+	return objectstorage.Part{Number: number, ETag: fmt.Sprintf("etag-%d", number)}, nil
+}
+
+func (u *fakePartUploader) Complete(parts []objectstorage.Part) error {
+	for i, part := range parts {
+		if part.Number != i+1 {
+			return fmt.Errorf("part %d is out of order", part.Number)
+		}
+		u.object = append(u.object, u.parts[part.Number]...)
+	}
+	return nil
+}
+
+var _ = Describe("Memory upload", func() {
+	var dir string
+	var image string
+	var uploader *fakePartUploader
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "memory-upload")
+		Expect(err).ToNot(HaveOccurred())
+		image = filepath.Join(dir, "memory.img")
+		uploader = &fakePartUploader{parts: map[int][]byte{}}
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	// writeSaveImage writes the image in chunks and rewrites its header at the end, like libvirt does
+	writeSaveImage := func(data []byte, header []byte, save *memorySave) {
+		defer GinkgoRecover()
+		f, err := os.OpenFile(image, os.O_CREATE|os.O_WRONLY, 0600)
+		Expect(err).ToNot(HaveOccurred())
+		for len(data) > 0 {
+			chunk := 20
+			if chunk > len(data) {
+				chunk = len(data)
+			}
+			_, err = f.Write(data[:chunk])
+			Expect(err).ToNot(HaveOccurred())
+			data = data[chunk:]
+		}
+		_, err = f.WriteAt(header, 0)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(f.Close()).To(Succeed())
+		save.finish(nil)
+	}
+
+	table.DescribeTable("should upload the save image with the rewritten header", func(size int) {
+		data := append([]byte("partial-header:"), bytes.Repeat([]byte("m"), size)...)
+		save := newMemorySave()
+		go writeSaveImage(data, []byte("complete-header"), save)
+
+		checksum, err := streamMemoryImage(image, uploader, 32, save)
+		Expect(err).ToNot(HaveOccurred())
+
+		expected := append([]byte("complete-header"), data[15:]...)
+		Expect(uploader.object).To(Equal(expected))
+
+		var numbers []int
+		var digests [][]byte
+		for number := range uploader.parts {
+			numbers = append(numbers, number)
+		}
+		sort.Ints(numbers)
+		for _, number := range numbers {
+			digest := sha256.Sum256(uploader.parts[number])
+			digests = append(digests, digest[:])
+		}
+		Expect(checksum).To(Equal(memoryChecksum(digests)))
+		Expect(checksum).To(HaveSuffix(fmt.Sprintf("-%d", len(numbers))))
+	},
+		table.Entry("within a single part", 10),
+		table.Entry("spanning several parts", 100),
+		table.Entry("filling the parts exactly", 49),
+	)
+
+	It("should not complete the upload before the memory was saved", func() {
+		Expect(os.WriteFile(image, bytes.Repeat([]byte("m"), 100), 0600)).To(Succeed())
+		save := newMemorySave()
+		result := make(chan memoryStreamResult, 1)
+		go func() {
+			checksum, err := streamMemoryImage(image, uploader, 32, save)
+			result <- memoryStreamResult{checksum: checksum, err: err}
+		}()
+		Consistently(result, 0.5).ShouldNot(Receive())
+
+		save.finish(nil)
+		var res memoryStreamResult
+		Eventually(result, 5).Should(Receive(&res))
+		Expect(res.err).ToNot(HaveOccurred())
+		Expect(uploader.object).To(Equal(bytes.Repeat([]byte("m"), 100)))
+	})
+
+	It("should give up when the snapshot failed", func() {
+		Expect(os.WriteFile(image, bytes.Repeat([]byte("m"), 40), 0600)).To(Succeed())
+		save := newMemorySave()
+		save.finish(fmt.Errorf("snapshot failed"))
+
+		_, err := streamMemoryImage(image, uploader, 32, save)
+		Expect(err).To(MatchError("snapshot failed"))
+		Expect(uploader.object).To(BeEmpty())
+	})
+
+	It("should give up when the snapshot failed before the save image was created", func() {
+		save := newMemorySave()
+		save.finish(fmt.Errorf("snapshot failed"))
+
+		_, err := streamMemoryImage(image, uploader, 32, save)
+		Expect(err).To(MatchError("snapshot failed"))
+		Expect(uploader.parts).To(BeEmpty())
+	})
+
+	table.DescribeTable("should scale the part size with the guest memory", func(memory api.Memory, expected int) {
+		Expect(memoryUploadPartSize(memory)).To(Equal(expected))
+	},
+		table.Entry("with the minimum for small guests", api.Memory{Value: 1024 * 1024, Unit: "KiB"}, 8*1024*1024),
+		table.Entry("rounded up to MiB for large guests", api.Memory{Value: 512, Unit: "GiB"}, 59*1024*1024),
+		table.Entry("in bytes", api.Memory{Value: 90000 * 1024 * 1024, Unit: "b"}, 10*1024*1024),
+	)
+})
@@ -0,0 +1,99 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package virtwrap
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/config"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/converter"
+)
+
+const networkDiskPasswordKey = "password"
+
+var networkDiskSecretUUIDns = uuid.MustParse("8a1e6e52-3c1f-4b9e-9d4e-6f2b0c7a5d31")
+
+// defineNetworkDiskSecrets defines the libvirt secrets holding the credentials of the network disks,
+// which are referenced by the disks of the domain. The secrets get a stable UUID, so that they are
+// updated instead of conflicting if the domain has to be defined again.
+func (l *LibvirtDomainManager) defineNetworkDiskSecrets(vmi *v1.VirtualMachineInstance) error {
+	for _, volume := range vmi.Spec.Volumes {
+		networkDisk := volume.NetworkDisk
+		if networkDisk == nil || networkDisk.Auth == nil {
+			continue
+		}
+
+		value, err := readNetworkDiskSecretValue(volume.Name, networkDisk.Protocol)
+		if err != nil {
+			return err
+		}
+
+		usage := api.SecretUsage{
+			Type: converter.GetNetworkDiskSecretType(networkDisk.Protocol),
+		}
+		if networkDisk.Protocol == v1.NetworkDiskProtocolRBD {
+			usage.Name = converter.GetNetworkDiskSecretUsage(volume.Name)
+		} else {
+			usage.Target = converter.GetNetworkDiskSecretUsage(volume.Name)
+		}
+		secretSpec := api.SecretSpec{
+			Ephemeral:   "yes",
+			Private:     "yes",
+			UUID:        uuid.NewSHA1(networkDiskSecretUUIDns, []byte(string(vmi.UID)+"/"+volume.Name)).String(),
+			Description: fmt.Sprintf("credentials of network disk %s", volume.Name),
+			Usage:       usage,
+		}
+		secretXML, err := xml.Marshal(secretSpec)
+		if err != nil {
+			return err
+		}
+		if err := l.virConn.SecretDefineXMLWithValue(string(secretXML), value); err != nil {
+			return fmt.Errorf("failed to define the secret of network disk %s: %v", volume.Name, err)
+		}
+	}
+	return nil
+}
+
+// readNetworkDiskSecretValue reads the credentials of a network disk from the mounted secret.
+// Ceph expects the raw cephx key, which is stored base64 encoded.
+func readNetworkDiskSecretValue(volumeName string, protocol v1.NetworkDiskProtocol) ([]byte, error) {
+	content, err := ioutil.ReadFile(filepath.Join(config.GetNetworkDiskSecretSourcePath(volumeName), networkDiskPasswordKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the credentials of network disk %s: %v", volumeName, err)
+	}
+	password := strings.TrimSuffix(string(content), "\n")
+	if protocol != v1.NetworkDiskProtocolRBD {
+		return []byte(password), nil
+	}
+	key, err := base64.StdEncoding.DecodeString(password)
+	if err != nil {
+		return nil, fmt.Errorf("the cephx key of network disk %s is not base64 encoded: %v", volumeName, err)
+	}
+	return key, nil
+}
@@ -0,0 +1,141 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package virtwrap
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/config"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/cli"
+)
+
+var _ = Describe("Network disk secrets", func() {
+	var ctrl *gomock.Controller
+	var mockConn *cli.MockConnection
+	var manager *LibvirtDomainManager
+	var secretSourceDir string
+	var originalSecretSourceDir string
+
+	writePassword := func(volumeName, password string) {
+		dir := filepath.Join(secretSourceDir, volumeName)
+		Expect(os.MkdirAll(dir, 0755)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(dir, networkDiskPasswordKey), []byte(password), 0600)).To(Succeed())
+	}
+
+	newVMI := func(protocol v1.NetworkDiskProtocol, auth *v1.NetworkDiskAuth) *v1.VirtualMachineInstance {
+		vmi := v1.NewMinimalVMI("testvmi")
+		vmi.UID = "1234"
+		vmi.Spec.Volumes = []v1.Volume{{
+			Name: "netdisk",
+			VolumeSource: v1.VolumeSource{
+				NetworkDisk: &v1.NetworkDiskSource{
+					Protocol: protocol,
+					Name:     "target/1",
+					Host:     v1.NetworkDiskHost{Name: "storage.example.com"},
+					Auth:     auth,
+				},
+			},
+		}}
+		return vmi
+	}
+
+	BeforeEach(func() {
+		var err error
+		secretSourceDir, err = ioutil.TempDir("", "network-disk-secrets")
+		Expect(err).ToNot(HaveOccurred())
+		originalSecretSourceDir = config.NetworkDiskSecretSourceDir
+		config.NetworkDiskSecretSourceDir = secretSourceDir
+
+		ctrl = gomock.NewController(GinkgoT())
+		mockConn = cli.NewMockConnection(ctrl)
+		manager = &LibvirtDomainManager{virConn: mockConn}
+	})
+
+	AfterEach(func() {
+		config.NetworkDiskSecretSourceDir = originalSecretSourceDir
+		os.RemoveAll(secretSourceDir)
+		ctrl.Finish()
+	})
+
+	It("should define an iscsi secret with the password", func() {
+		writePassword("netdisk", "secret-password\n")
+		vmi := newVMI(v1.NetworkDiskProtocolISCSI, &v1.NetworkDiskAuth{Username: "user", SecretRef: "chap"})
+
+		mockConn.EXPECT().SecretDefineXMLWithValue(gomock.Any(), []byte("secret-password")).DoAndReturn(func(secretXML string, _ []byte) error {
+			secretSpec := api.SecretSpec{}
+			Expect(xml.Unmarshal([]byte(secretXML), &secretSpec)).To(Succeed())
+			Expect(secretSpec.Ephemeral).To(Equal("yes"))
+			Expect(secretSpec.Private).To(Equal("yes"))
+			Expect(secretSpec.UUID).ToNot(BeEmpty())
+			Expect(secretSpec.Usage).To(Equal(api.SecretUsage{Type: "iscsi", Target: "network-disk-netdisk"}))
+			return nil
+		})
+
+		Expect(manager.defineNetworkDiskSecrets(vmi)).To(Succeed())
+	})
+
+	It("should define a ceph secret with the decoded key", func() {
+		writePassword("netdisk", "a2V5")
+		vmi := newVMI(v1.NetworkDiskProtocolRBD, &v1.NetworkDiskAuth{Username: "admin", SecretRef: "ceph"})
+
+		mockConn.EXPECT().SecretDefineXMLWithValue(gomock.Any(), []byte("key")).DoAndReturn(func(secretXML string, _ []byte) error {
+			secretSpec := api.SecretSpec{}
+			Expect(xml.Unmarshal([]byte(secretXML), &secretSpec)).To(Succeed())
+			Expect(secretSpec.Usage).To(Equal(api.SecretUsage{Type: "ceph", Name: "network-disk-netdisk"}))
+			return nil
+		})
+
+		Expect(manager.defineNetworkDiskSecrets(vmi)).To(Succeed())
+	})
+
+	It("should use a stable secret UUID", func() {
+		writePassword("netdisk", "secret-password")
+		vmi := newVMI(v1.NetworkDiskProtocolISCSI, &v1.NetworkDiskAuth{Username: "user", SecretRef: "chap"})
+
+		var secretXMLs []string
+		mockConn.EXPECT().SecretDefineXMLWithValue(gomock.Any(), gomock.Any()).Times(2).DoAndReturn(func(secretXML string, _ []byte) error {
+			secretXMLs = append(secretXMLs, secretXML)
+			return nil
+		})
+
+		Expect(manager.defineNetworkDiskSecrets(vmi)).To(Succeed())
+		Expect(manager.defineNetworkDiskSecrets(vmi)).To(Succeed())
+		Expect(secretXMLs[0]).To(Equal(secretXMLs[1]))
+	})
+
+	It("should not define secrets for disks without credentials", func() {
+		vmi := newVMI(v1.NetworkDiskProtocolNBD, nil)
+		Expect(manager.defineNetworkDiskSecrets(vmi)).To(Succeed())
+	})
+
+	It("should fail if the credentials are not mounted", func() {
+		vmi := newVMI(v1.NetworkDiskProtocolISCSI, &v1.NetworkDiskAuth{Username: "user", SecretRef: "chap"})
+		Expect(manager.defineNetworkDiskSecrets(vmi)).ToNot(Succeed())
+	})
+})
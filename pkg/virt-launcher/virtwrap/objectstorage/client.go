@@ -0,0 +1,327 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+// Package objectstorage implements the multipart upload of objects to S3 compatible
+// object storage. Requests are path-style and signed with AWS signature version 4.
+package objectstorage
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultRegion = "us-east-1"
+	service       = "s3"
+	algorithm     = "AWS4-HMAC-SHA256"
+
+	amzDateFormat   = "20060102T150405Z"
+	scopeDateFormat = "20060102"
+
+	requestTimeout = 5 * time.Minute
+)
+
+// Client talks to a single S3 compatible endpoint
+type Client struct {
+	endpoint        *url.URL
+	region          string
+	accessKeyID     string
+	secretAccessKey string
+	httpClient      *http.Client
+	now             func() time.Time
+}
+
+// Part is an uploaded part of a multipart upload
+type Part struct {
+	Number int
+	ETag   string
+}
+
+// MultipartUpload is a multipart upload which was started with CreateMultipartUpload
+type MultipartUpload struct {
+	client   *Client
+	bucket   string
+	key      string
+	uploadID string
+}
+
+type errorResponse struct {
+	XMLName xml.Name `xml:"Error"`
+	Code    string   `xml:"Code"`
+	Message string   `xml:"Message"`
+}
+
+type initiateMultipartUploadResult struct {
+	UploadID string `xml:"UploadId"`
+}
+
+type completeMultipartUpload struct {
+	XMLName xml.Name       `xml:"CompleteMultipartUpload"`
+	Parts   []completePart `xml:"Part"`
+}
+
+type completePart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+// NewClient creates a client for the given http or https endpoint. The region
+// defaults to us-east-1, which most S3 compatible stores accept.
+func NewClient(endpoint, region, accessKeyID, secretAccessKey string) (*Client, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid object storage endpoint %q: %v", endpoint, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid object storage endpoint %q: an http or https URL is required", endpoint)
+	}
+	if region == "" {
+		region = defaultRegion
+	}
+	return &Client{
+		endpoint:        u,
+		region:          region,
+		accessKeyID:     accessKeyID,
+		secretAccessKey: secretAccessKey,
+		httpClient:      &http.Client{Timeout: requestTimeout},
+		now:             time.Now,
+	}, nil
+}
+
+// CreateMultipartUpload starts a multipart upload of the object key in bucket
+func (c *Client) CreateMultipartUpload(bucket, key string) (*MultipartUpload, error) {
+	body, err := c.do(http.MethodPost, bucket, key, url.Values{"uploads": []string{""}}, nil)
+	if err != nil {
+		return nil, err
+	}
+	result := initiateMultipartUploadResult{}
+	if err := xml.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("invalid response to the creation of the multipart upload: %v", err)
+	}
+	if result.UploadID == "" {
+		return nil, fmt.Errorf("no upload id returned for the multipart upload of %s/%s", bucket, key)
+	}
+	return &MultipartUpload{
+		client:   c,
+		bucket:   bucket,
+		key:      key,
+		uploadID: result.UploadID,
+	}, nil
+}
+
+// UploadPart uploads data as the part with the given number, starting at 1
+func (u *MultipartUpload) UploadPart(number int, data []byte) (Part, error) {
+	query := url.Values{
+		"partNumber": []string{strconv.Itoa(number)},
+		"uploadId":   []string{u.uploadID},
+	}
+	resp, err := u.client.request(http.MethodPut, u.bucket, u.key, query, data)
+	if err != nil {
+		return Part{}, err
+	}
+	defer resp.Body.Close()
+	if _, err := checkResponse(resp); err != nil {
+		return Part{}, fmt.Errorf("failed to upload part %d: %v", number, err)
+	}
+	return Part{Number: number, ETag: resp.Header.Get("ETag")}, nil
+}
+
+// Complete assembles the uploaded parts into the object
+func (u *MultipartUpload) Complete(parts []Part) error {
+	sorted := make([]Part, len(parts))
+	copy(sorted, parts)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Number < sorted[j].Number })
+
+	request := completeMultipartUpload{}
+	for _, part := range sorted {
+		request.Parts = append(request.Parts, completePart{PartNumber: part.Number, ETag: part.ETag})
+	}
+	data, err := xml.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	// the completion may fail after the response header was sent, the error is then in the body
+	body, err := u.client.do(http.MethodPost, u.bucket, u.key, url.Values{"uploadId": []string{u.uploadID}}, data)
+	if err != nil {
+		return fmt.Errorf("failed to complete the multipart upload: %v", err)
+	}
+	if err := parseError(body); err != nil {
+		return fmt.Errorf("failed to complete the multipart upload: %v", err)
+	}
+	return nil
+}
+
+// Abort discards the multipart upload and all its uploaded parts
+func (u *MultipartUpload) Abort() error {
+	if _, err := u.client.do(http.MethodDelete, u.bucket, u.key, url.Values{"uploadId": []string{u.uploadID}}, nil); err != nil {
+		return fmt.Errorf("failed to abort the multipart upload: %v", err)
+	}
+	return nil
+}
+
+func (c *Client) do(method, bucket, key string, query url.Values, payload []byte) ([]byte, error) {
+	resp, err := c.request(method, bucket, key, query, payload)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return checkResponse(resp)
+}
+
+func (c *Client) request(method, bucket, key string, query url.Values, payload []byte) (*http.Response, error) {
+	path := strings.TrimSuffix(c.endpoint.EscapedPath(), "/") + "/" + escapePath(bucket) + "/" + escapePath(key)
+	rawQuery := canonicalQuery(query)
+	target := c.endpoint.Scheme + "://" + c.endpoint.Host + path
+	if rawQuery != "" {
+		target += "?" + rawQuery
+	}
+
+	req, err := http.NewRequest(method, target, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	c.sign(req, path, rawQuery, payload)
+	return c.httpClient.Do(req)
+}
+
+// sign adds the AWS signature version 4 headers to the request
+func (c *Client) sign(req *http.Request, path, rawQuery string, payload []byte) {
+	now := c.now().UTC()
+	amzDate := now.Format(amzDateFormat)
+	payloadHash := sha256Hex(payload)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := "host:" + req.URL.Host + "\n" +
+		"x-amz-content-sha256:" + payloadHash + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		rawQuery,
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{now.Format(scopeDateFormat), c.region, service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{
+		algorithm,
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+c.secretAccessKey), now.Format(scopeDateFormat))
+	key = hmacSHA256(key, c.region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		algorithm, c.accessKeyID, scope, signedHeaders, signature))
+}
+
+func checkResponse(resp *http.Response) ([]byte, error) {
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return body, nil
+	}
+	if err := parseError(body); err != nil {
+		return nil, fmt.Errorf("%s: %v", resp.Status, err)
+	}
+	return nil, fmt.Errorf("unexpected response status %s", resp.Status)
+}
+
+func parseError(body []byte) error {
+	errResp := errorResponse{}
+	if err := xml.Unmarshal(body, &errResp); err != nil || errResp.Code == "" {
+		return nil
+	}
+	return fmt.Errorf("%s: %s", errResp.Code, errResp.Message)
+}
+
+// canonicalQuery encodes the query sorted by key, as the signature requires
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var pairs []string
+	for _, k := range keys {
+		for _, v := range query[k] {
+			pairs = append(pairs, escape(k)+"="+escape(v))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+func escapePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i := range segments {
+		segments[i] = escape(segments[i])
+	}
+	return strings.Join(segments, "/")
+}
+
+// escape percent-encodes everything except the unreserved characters of RFC 3986
+func escape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,174 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package objectstorage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+// fakeS3 keeps the parts of a single multipart upload in memory
+type fakeS3 struct {
+	lock      sync.Mutex
+	requests  []*http.Request
+	parts     map[int][]byte
+	object    []byte
+	aborted   bool
+	failParts bool
+}
+
+func (s *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.requests = append(s.requests, r)
+
+	body, _ := ioutil.ReadAll(r.Body)
+	sum := sha256.Sum256(body)
+	if r.Header.Get("X-Amz-Content-Sha256") != hex.EncodeToString(sum[:]) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, "<Error><Code>XAmzContentSHA256Mismatch</Code><Message>checksum mismatch</Message></Error>")
+		return
+	}
+
+	query := r.URL.Query()
+	switch {
+	case r.Method == http.MethodPost && len(query["uploads"]) > 0:
+		s.parts = map[int][]byte{}
+		fmt.Fprint(w, "<InitiateMultipartUploadResult><UploadId>upload-1</UploadId></InitiateMultipartUploadResult>")
+	case r.Method == http.MethodPut && query.Get("uploadId") == "upload-1":
+		if s.failParts {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, "<Error><Code>AccessDenied</Code><Message>Access Denied</Message></Error>")
+			return
+		}
+		number, _ := strconv.Atoi(query.Get("partNumber"))
+		s.parts[number] = body
+		w.Header().Set("ETag", fmt.Sprintf("\"etag-%d\"", number))
+	case r.Method == http.MethodPost && query.Get("uploadId") == "upload-1":
+		request := completeMultipartUpload{}
+		Expect(xml.Unmarshal(body, &request)).To(Succeed())
+		for _, part := range request.Parts {
+			Expect(part.ETag).To(Equal(fmt.Sprintf("\"etag-%d\"", part.PartNumber)))
+			s.object = append(s.object, s.parts[part.PartNumber]...)
+		}
+		fmt.Fprint(w, "<CompleteMultipartUploadResult></CompleteMultipartUploadResult>")
+	case r.Method == http.MethodDelete && query.Get("uploadId") == "upload-1":
+		s.aborted = true
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+var _ = Describe("Object storage client", func() {
+	var server *httptest.Server
+	var store *fakeS3
+	var client *Client
+
+	BeforeEach(func() {
+		store = &fakeS3{}
+		server = httptest.NewServer(store)
+		var err error
+		client, err = NewClient(server.URL, "", "access", "secret")
+		Expect(err).ToNot(HaveOccurred())
+		client.now = func() time.Time {
+			return time.Date(2021, 7, 1, 12, 0, 0, 0, time.UTC)
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should assemble an object from the uploaded parts", func() {
+		upload, err := client.CreateMultipartUpload("memory", "dumps/vm 1")
+		Expect(err).ToNot(HaveOccurred())
+
+		second, err := upload.UploadPart(2, []byte("world"))
+		Expect(err).ToNot(HaveOccurred())
+		first, err := upload.UploadPart(1, []byte("hello "))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(upload.Complete([]Part{second, first})).To(Succeed())
+
+		Expect(string(store.object)).To(Equal("hello world"))
+		for _, r := range store.requests {
+			Expect(r.URL.EscapedPath()).To(Equal("/memory/dumps/vm%201"))
+			Expect(r.Header.Get("X-Amz-Date")).To(Equal("20210701T120000Z"))
+			Expect(r.Header.Get("Authorization")).To(HavePrefix(
+				"AWS4-HMAC-SHA256 Credential=access/20210701/us-east-1/s3/aws4_request, SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature="))
+		}
+	})
+
+	It("should report the error returned by the object storage", func() {
+		store.failParts = true
+		upload, err := client.CreateMultipartUpload("memory", "dump")
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = upload.UploadPart(1, []byte("data"))
+		Expect(err).To(MatchError(ContainSubstring("AccessDenied: Access Denied")))
+
+		Expect(upload.Abort()).To(Succeed())
+		Expect(store.aborted).To(BeTrue())
+	})
+
+	It("should sign requests with the secret access key", func() {
+		other, err := NewClient(server.URL, "", "access", "other")
+		Expect(err).ToNot(HaveOccurred())
+		other.now = client.now
+
+		signature := func(c *Client) string {
+			req, err := http.NewRequest(http.MethodPut, server.URL+"/memory/dump", nil)
+			Expect(err).ToNot(HaveOccurred())
+			c.sign(req, "/memory/dump", "", nil)
+			return req.Header.Get("Authorization")
+		}
+		Expect(signature(client)).To(Equal(signature(client)))
+		Expect(signature(client)).ToNot(Equal(signature(other)))
+	})
+
+	table.DescribeTable("should reject invalid endpoints", func(endpoint string) {
+		_, err := NewClient(endpoint, "", "access", "secret")
+		Expect(err).To(HaveOccurred())
+	},
+		table.Entry("without scheme", "minio.local:9000"),
+		table.Entry("with unsupported scheme", "ftp://minio.local"),
+		table.Entry("without host", "https://"),
+	)
+
+	table.DescribeTable("should encode the canonical query", func(query url.Values, expected string) {
+		Expect(canonicalQuery(query)).To(Equal(expected))
+	},
+		table.Entry("with an empty value", url.Values{"uploads": []string{""}}, "uploads="),
+		table.Entry("sorted by key", url.Values{"uploadId": []string{"a/b"}, "partNumber": []string{"3"}}, "partNumber=3&uploadId=a%2Fb"),
+	)
+})
@@ -0,0 +1,11 @@
+package objectstorage_test
+
+import (
+	"testing"
+
+	"kubevirt.io/client-go/testutils"
+)
+
+func TestObjectStorage(t *testing.T) {
+	testutils.KubeVirtTestSuiteSetup(t)
+}
@@ -0,0 +1,132 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package virtwrap
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"io/ioutil"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/virt-launcher/virtwrap/api"
+)
+
+const (
+	mimeTypePPM = "image/x-portable-pixmap"
+	mimeTypePNG = "image/png"
+)
+
+// ScreenshotVMI captures the framebuffer of the first screen of the domain and returns it as PNG
+func (l *LibvirtDomainManager) ScreenshotVMI(vmi *v1.VirtualMachineInstance) ([]byte, error) {
+	domName := api.VMINamespaceKeyFunc(vmi)
+	dom, err := l.virConn.LookupDomainByName(domName)
+	if err != nil {
+		return nil, err
+	}
+	defer dom.Free()
+
+	stream, err := l.virConn.NewStream(0)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close()
+
+	mimeType, err := dom.Screenshot(stream.UnderlyingStream(), 0, 0)
+	if err != nil {
+		log.Log.Object(vmi).Reason(err).Error("Failed to take a screenshot of the vmi")
+		return nil, err
+	}
+	data, err := ioutil.ReadAll(stream)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the screenshot: %v", err)
+	}
+
+	return screenshotToPNG(mimeType, data)
+}
+
+// screenshotToPNG converts the screen dump of the hypervisor to PNG. QEMU dumps the screen as binary PPM.
+func screenshotToPNG(mimeType string, data []byte) ([]byte, error) {
+	switch mimeType {
+	case mimeTypePNG:
+		return data, nil
+	case mimeTypePPM:
+		img, err := decodePPM(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, img); err != nil {
+			return nil, fmt.Errorf("failed to encode the screenshot: %v", err)
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unsupported screenshot format %q", mimeType)
+	}
+}
+
+// decodePPM decodes a binary PPM (P6) image with 8 bits per channel
+func decodePPM(r io.Reader) (image.Image, error) {
+	reader := bufio.NewReader(r)
+
+	var magic string
+	var width, height, maxVal int
+	if _, err := fmt.Fscan(reader, &magic, &width, &height, &maxVal); err != nil {
+		return nil, fmt.Errorf("invalid PPM header: %v", err)
+	}
+	if magic != "P6" {
+		return nil, fmt.Errorf("unsupported PPM format %q", magic)
+	}
+	if width <= 0 || height <= 0 || maxVal <= 0 || maxVal > 255 {
+		return nil, fmt.Errorf("unsupported PPM dimensions %dx%d with max value %d", width, height, maxVal)
+	}
+	// a single whitespace separates the header from the pixels
+	if _, err := reader.ReadByte(); err != nil {
+		return nil, fmt.Errorf("invalid PPM header: %v", err)
+	}
+
+	pixels := make([]byte, width*height*3)
+	if _, err := io.ReadFull(reader, pixels); err != nil {
+		return nil, fmt.Errorf("truncated PPM image: %v", err)
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for i := 0; i < width*height; i++ {
+		img.SetNRGBA(i%width, i/width, color.NRGBA{
+			R: scaleChannel(pixels[i*3], maxVal),
+			G: scaleChannel(pixels[i*3+1], maxVal),
+			B: scaleChannel(pixels[i*3+2], maxVal),
+			A: 255,
+		})
+	}
+	return img, nil
+}
+
+func scaleChannel(value byte, maxVal int) uint8 {
+	if maxVal == 255 {
+		return value
+	}
+	return uint8(int(value) * 255 / maxVal)
+}
@@ -0,0 +1,63 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package virtwrap
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Screenshot", func() {
+
+	It("should convert a PPM screen dump to PNG", func() {
+		ppm := append([]byte("P6\n2 1\n255\n"), 255, 0, 0, 0, 0, 255)
+
+		data, err := screenshotToPNG(mimeTypePPM, ppm)
+		Expect(err).ToNot(HaveOccurred())
+
+		img, err := png.Decode(bytes.NewReader(data))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(img.Bounds().Dx()).To(Equal(2))
+		Expect(img.Bounds().Dy()).To(Equal(1))
+		Expect(color.NRGBAModel.Convert(img.At(0, 0))).To(Equal(color.NRGBA{R: 255, A: 255}))
+		Expect(color.NRGBAModel.Convert(img.At(1, 0))).To(Equal(color.NRGBA{B: 255, A: 255}))
+	})
+
+	It("should pass PNG screen dumps through", func() {
+		data, err := screenshotToPNG(mimeTypePNG, []byte("png"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(data).To(Equal([]byte("png")))
+	})
+
+	table.DescribeTable("should reject", func(mimeType string, data []byte, expectedErr string) {
+		_, err := screenshotToPNG(mimeType, data)
+		Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+	},
+		table.Entry("unknown formats", "image/bmp", []byte{}, "unsupported screenshot format"),
+		table.Entry("ASCII PPM images", mimeTypePPM, []byte("P3\n1 1\n255\n0 0 0\n"), "unsupported PPM format"),
+		table.Entry("16 bit PPM images", mimeTypePPM, []byte("P6\n1 1\n65535\n"), "unsupported PPM dimensions"),
+		table.Entry("truncated PPM images", mimeTypePPM, []byte("P6\n2 2\n255\n\x00\x00\x00"), "truncated PPM image"),
+	)
+})
@@ -0,0 +1,74 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ */
+
+package stats
+
+import "time"
+
+// PressureSampler derives the pressure inside of the guest from the balloon statistics and from the
+// steal time between consecutive samples of the vCPU statistics.
+type PressureSampler struct {
+	lastDelay  uint64
+	lastVcpus  int
+	lastSample time.Time
+}
+
+// Sample returns the share of the guest memory which applications can still use without swapping and the
+// share of the time since the previous sample in which the vCPUs waited for a host CPU, both in percent.
+// Values the statistics don't provide are nil, the steal time is known from the second sample on.
+func (s *PressureSampler) Sample(domStats *DomainStats, now time.Time) (memoryAvailablePercent *uint, cpuStealPercent *uint) {
+	if domStats == nil {
+		*s = PressureSampler{}
+		return nil, nil
+	}
+	return memoryAvailable(domStats.Memory), s.cpuSteal(domStats.Vcpu, now)
+}
+
+func memoryAvailable(mem *DomainStatsMemory) *uint {
+	if mem == nil || !mem.UsableSet || !mem.AvailableSet || mem.Available == 0 {
+		return nil
+	}
+	return percent(mem.Usable, mem.Available)
+}
+
+func (s *PressureSampler) cpuSteal(vcpus []DomainStatsVcpu, now time.Time) *uint {
+	var delay uint64
+	count := 0
+	for _, vcpu := range vcpus {
+		if vcpu.DelaySet {
+			delay += vcpu.Delay
+			count++
+		}
+	}
+
+	last := *s
+	*s = PressureSampler{lastDelay: delay, lastVcpus: count, lastSample: now}
+	// the vCPUs were hotplugged or the counters reset, start over
+	if count == 0 || last.lastSample.IsZero() || last.lastVcpus != count || delay < last.lastDelay || !now.After(last.lastSample) {
+		return nil
+	}
+	return percent(delay-last.lastDelay, uint64(now.Sub(last.lastSample).Nanoseconds())*uint64(count))
+}
+
+func percent(value, total uint64) *uint {
+	p := uint(100)
+	if value < total {
+		p = uint(value * 100 / total)
+	}
+	return &p
+}
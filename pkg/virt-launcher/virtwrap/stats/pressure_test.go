@@ -0,0 +1,88 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ */
+
+package stats
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Guest pressure", func() {
+	var sampler *PressureSampler
+	start := time.Unix(1600000000, 0)
+
+	newStats := func(delays ...uint64) *DomainStats {
+		domStats := &DomainStats{
+			Memory: &DomainStatsMemory{
+				AvailableSet: true,
+				Available:    4 * 1024 * 1024,
+				UsableSet:    true,
+				Usable:       1024 * 1024,
+			},
+		}
+		for _, delay := range delays {
+			domStats.Vcpu = append(domStats.Vcpu, DomainStatsVcpu{DelaySet: true, Delay: delay})
+		}
+		return domStats
+	}
+
+	BeforeEach(func() {
+		sampler = &PressureSampler{}
+	})
+
+	It("should report the share of the usable guest memory", func() {
+		memory, _ := sampler.Sample(newStats(), start)
+		Expect(memory).ToNot(BeNil())
+		Expect(*memory).To(Equal(uint(25)))
+	})
+
+	It("should not report the memory without balloon statistics", func() {
+		domStats := newStats()
+		domStats.Memory.UsableSet = false
+		memory, _ := sampler.Sample(domStats, start)
+		Expect(memory).To(BeNil())
+	})
+
+	It("should report the steal time from the second sample on", func() {
+		_, steal := sampler.Sample(newStats(0, 0), start)
+		Expect(steal).To(BeNil())
+
+		// 2 vCPUs waited 1s in total during 10s
+		_, steal = sampler.Sample(newStats(uint64(time.Second), 0), start.Add(5*time.Second))
+		Expect(steal).ToNot(BeNil())
+		Expect(*steal).To(Equal(uint(10)))
+	})
+
+	It("should start over when the number of vCPUs changes", func() {
+		sampler.Sample(newStats(0), start)
+		_, steal := sampler.Sample(newStats(0, 0), start.Add(5*time.Second))
+		Expect(steal).To(BeNil())
+	})
+
+	It("should forget the previous sample when the domain is gone", func() {
+		sampler.Sample(newStats(0), start)
+		memory, steal := sampler.Sample(nil, start.Add(5*time.Second))
+		Expect(memory).To(BeNil())
+		Expect(steal).To(BeNil())
+		_, steal = sampler.Sample(newStats(0), start.Add(10*time.Second))
+		Expect(steal).To(BeNil())
+	})
+})
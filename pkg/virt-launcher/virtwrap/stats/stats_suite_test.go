@@ -0,0 +1,11 @@
+package stats_test
+
+import (
+	"testing"
+
+	"kubevirt.io/client-go/testutils"
+)
+
+func TestStats(t *testing.T) {
+	testutils.KubeVirtTestSuiteSetup(t)
+}
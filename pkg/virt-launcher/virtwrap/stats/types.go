@@ -86,6 +86,9 @@ type DomainStatsVcpu struct {
 	Time     uint64
 	WaitSet  bool
 	Wait     uint64
+	// the time the vCPU waited for a host CPU, also known as steal time
+	DelaySet bool
+	Delay    uint64
 }
 
 type DomainStatsNet struct {
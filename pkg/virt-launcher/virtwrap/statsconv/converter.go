@@ -122,6 +122,8 @@ func Convert_libvirt_DomainStatsVcpu_To_stats_DomainStatsVcpu(in []libvirt.Domai
 			Time:     inItem.Time,
 			WaitSet:  inItem.WaitSet,
 			Wait:     inItem.Wait,
+			DelaySet: inItem.DelaySet,
+			Delay:    inItem.Delay,
 		})
 	}
 	return ret
@@ -152,7 +154,10 @@ func Convert_libvirt_DomainStatsNet_To_stats_DomainStatsNet(in []libvirt.DomainS
 		}
 
 		if inItem.NameSet {
-			netStat.Alias, netStat.AliasSet = devAliasMap[inItem.Name]
+			if alias := devAliasMap[inItem.Name]; alias != "" {
+				netStat.Alias = alias
+				netStat.AliasSet = true
+			}
 		}
 
 		ret = append(ret, netStat)
@@ -111,6 +111,30 @@ var _ = Describe("StatsConverter", func() {
 			}
 			Expect(equal).To(BeTrue())
 		})
+
+		It("should use the interface aliases of the network stats", func() {
+			in := &libvirt.DomainStats{
+				Net: []libvirt.DomainStatsNet{
+					{NameSet: true, Name: "vnet0", RxBytesSet: true, RxBytes: 1024},
+					{NameSet: true, Name: "vnet1", RxBytesSet: true, RxBytes: 2048},
+				},
+			}
+			inMem := []libvirt.DomainMemoryStat{}
+			devAliasMap := map[string]string{"vnet0": "default"}
+			out := stats.DomainStats{}
+			mockDomainIdent.EXPECT().GetName().Return("testName", nil)
+			mockDomainIdent.EXPECT().GetUUIDString().Return("testUUID", nil)
+			ident := DomainIdentifier(mockDomainIdent)
+
+			err := Convert_libvirt_DomainStats_to_stats_DomainStats(ident, in, inMem, nil, devAliasMap, &out)
+
+			Expect(err).To(BeNil())
+			Expect(out.Net).To(HaveLen(2))
+			Expect(out.Net[0].AliasSet).To(BeTrue())
+			Expect(out.Net[0].Alias).To(Equal("default"))
+			Expect(out.Net[1].AliasSet).To(BeFalse())
+			Expect(out.Net[1].Alias).To(BeEmpty())
+		})
 	})
 })
 
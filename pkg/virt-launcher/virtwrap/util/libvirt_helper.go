@@ -176,6 +176,26 @@ func GetDomainSpecWithRuntimeInfo(dom cli.VirDomain) (*api.DomainSpec, error) {
 	return activeSpec, nil
 }
 
+// SetKubeVirtMetadata replaces the KubeVirt metadata of a running domain without redefining it.
+// Persistent domains get the metadata in their configuration too, which is where it is read from.
+func SetKubeVirtMetadata(dom cli.VirDomain, metadata *api.KubeVirtMetadata) error {
+	metadataXML, err := xml.Marshal(struct {
+		*api.KubeVirtMetadata
+		XMLName xml.Name `xml:"kubevirt"`
+	}{KubeVirtMetadata: metadata})
+	if err != nil {
+		return err
+	}
+
+	domainModificationImpactFlag, err := getDomainModificationImpactFlag(dom)
+	if err != nil {
+		return err
+	}
+
+	return dom.SetMetadata(libvirt.DOMAIN_METADATA_ELEMENT, string(metadataXML), "kubevirt", "http://kubevirt.io",
+		domainModificationImpactFlag|libvirt.DOMAIN_AFFECT_LIVE)
+}
+
 // GetDomainSpec return the domain XML without runtime information.
 // The result XML is merged from inactive XML and migratable XML.
 func GetDomainSpec(status libvirt.DomainState, dom cli.VirDomain) (*api.DomainSpec, error) {
@@ -990,6 +990,13 @@ func (c *KubeVirtController) syncInstallation(kv *v1.KubeVirt) error {
 	// Record the version we're targeting to install
 	config.SetTargetDeploymentConfig(kv)
 
+	// Record the settings which are only applied once the new deployment is rolled out
+	if unapplied, err := operatorutil.GetUnappliedConfiguration(kv); err != nil {
+		logger.Reason(err).Error("Failed to determine the unapplied configuration")
+	} else {
+		kv.Status.UnappliedConfiguration = unapplied
+	}
+
 	if kv.Status.Phase == "" {
 		kv.Status.Phase = v1.KubeVirtPhaseDeploying
 	}
@@ -1042,6 +1049,12 @@ func (c *KubeVirtController) syncInstallation(kv *v1.KubeVirt) error {
 	if synced {
 		// record the version that has been completely installed
 		config.SetObservedDeploymentConfig(kv)
+		kv.Status.UnappliedConfiguration = nil
+		// the rollout state is kept, so that reconciles of the installed deployment don't start another canary rollout
+		if kv.Status.HandlerRollout != nil && kv.Status.HandlerRollout.Phase == v1.HandlerRolloutPhaseProceeding {
+			kv.Status.HandlerRollout.Phase = v1.HandlerRolloutPhaseCompleted
+			kv.Status.HandlerRollout.Message = ""
+		}
 
 		// update conditions
 		util.UpdateConditionsCreated(kv)
@@ -78,9 +78,9 @@ const (
 
 	NAMESPACE = "kubevirt-test"
 
-	resourceCount = 53
-	patchCount    = 34
-	updateCount   = 20
+	resourceCount = 62
+	patchCount    = 39
+	updateCount   = 24
 )
 
 type KubeVirtTestData struct {
@@ -1088,13 +1088,17 @@ func (k *KubeVirtTestData) addAll(config *util.KubeVirtDeploymentConfig, kv *v1.
 		components.NewVirtualMachineInstanceCrd, components.NewPresetCrd, components.NewReplicaSetCrd,
 		components.NewVirtualMachineCrd, components.NewVirtualMachineInstanceMigrationCrd,
 		components.NewVirtualMachineSnapshotCrd, components.NewVirtualMachineSnapshotContentCrd,
-		components.NewVirtualMachineRestoreCrd,
+		components.NewVirtualMachineRestoreCrd, components.NewSchedulingHintsCrd,
+		components.NewVirtualMachineImageExportCrd, components.NewValidationPolicyCrd,
+		components.NewMaintenanceWindowCrd, components.NewVirtualMachineBulkOperationCrd,
 	}
+	var crds []*extv1.CustomResourceDefinition
 	for _, f := range functions {
 		crd, err := f()
 		if err != nil {
 			panic(fmt.Errorf("This should not happen, %v", err))
 		}
+		crds = append(crds, crd)
 		all = append(all, crd)
 	}
 	// cr
@@ -1129,6 +1133,28 @@ func (k *KubeVirtTestData) addAll(config *util.KubeVirtDeploymentConfig, kv *v1.
 	caConfigMap.Data = map[string]string{components.CABundleKey: string(caBundle)}
 	all = append(all, caConfigMap)
 
+	// the CRDs serving more than one version were switched to the conversion webhook of virt-api
+	conversionPath := components.VirtAPIConversionPath
+	for _, crd := range crds {
+		if crd.Spec.Group != v1.GroupName || len(crd.Spec.Versions) < 2 {
+			continue
+		}
+		crd.Spec.Conversion = &extv1.CustomResourceConversion{
+			Strategy: extv1.WebhookConverter,
+			Webhook: &extv1.WebhookConversion{
+				ClientConfig: &extv1.WebhookClientConfig{
+					Service: &extv1.ServiceReference{
+						Namespace: NAMESPACE,
+						Name:      components.VirtApiServiceName,
+						Path:      &conversionPath,
+					},
+					CABundle: caBundle,
+				},
+				ConversionReviewVersions: []string{"v1", "v1beta1"},
+			},
+		}
+	}
+
 	// webhooks and apiservice
 	validatingWebhook := components.NewVirtAPIValidatingWebhookConfiguration(config.GetNamespace())
 	for i := range validatingWebhook.Webhooks {
@@ -2054,11 +2080,11 @@ var _ = Describe("KubeVirt Operator", func() {
 			Expect(kvTestData.totalAdds).To(Equal(resourceCount - expectedUncreatedResources + expectedTemporaryResources))
 
 			Expect(len(kvTestData.controller.stores.ServiceAccountCache.List())).To(Equal(3))
-			Expect(len(kvTestData.controller.stores.ClusterRoleCache.List())).To(Equal(7))
+			Expect(len(kvTestData.controller.stores.ClusterRoleCache.List())).To(Equal(11))
 			Expect(len(kvTestData.controller.stores.ClusterRoleBindingCache.List())).To(Equal(5))
 			Expect(len(kvTestData.controller.stores.RoleCache.List())).To(Equal(3))
 			Expect(len(kvTestData.controller.stores.RoleBindingCache.List())).To(Equal(3))
-			Expect(len(kvTestData.controller.stores.CrdCache.List())).To(Equal(8))
+			Expect(len(kvTestData.controller.stores.CrdCache.List())).To(Equal(13))
 			Expect(len(kvTestData.controller.stores.ServiceCache.List())).To(Equal(3))
 			Expect(len(kvTestData.controller.stores.DeploymentCache.List())).To(Equal(1))
 			Expect(len(kvTestData.controller.stores.DaemonSetCache.List())).To(Equal(0))
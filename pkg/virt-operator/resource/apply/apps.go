@@ -26,7 +26,7 @@ func (r *Reconciler) syncDeployment(deployment *appsv1.Deployment) error {
 
 	injectOperatorMetadata(kv, &deployment.ObjectMeta, imageTag, imageRegistry, id, true)
 	injectOperatorMetadata(kv, &deployment.Spec.Template.ObjectMeta, imageTag, imageRegistry, id, false)
-	injectPlacementMetadata(kv.Spec.Infra, &deployment.Spec.Template.Spec)
+	InjectPlacementMetadata(kv.Spec.Infra, &deployment.Spec.Template.Spec)
 
 	obj, exists, _ := r.stores.DeploymentCache.Get(deployment)
 	if !exists {
@@ -88,7 +88,7 @@ func (r *Reconciler) syncDaemonSet(daemonSet *appsv1.DaemonSet) error {
 
 	injectOperatorMetadata(kv, &daemonSet.ObjectMeta, imageTag, imageRegistry, id, true)
 	injectOperatorMetadata(kv, &daemonSet.Spec.Template.ObjectMeta, imageTag, imageRegistry, id, false)
-	injectPlacementMetadata(kv.Spec.Workloads, &daemonSet.Spec.Template.Spec)
+	InjectPlacementMetadata(kv.Spec.Workloads, &daemonSet.Spec.Template.Spec)
 
 	if daemonSet.GetName() == "virt-handler" {
 		setMaxDevices(r.kv, daemonSet)
@@ -116,8 +116,9 @@ func (r *Reconciler) syncDaemonSet(daemonSet *appsv1.DaemonSet) error {
 	expectedGeneration := GetExpectedGeneration(daemonSet, kv.Status.Generations)
 
 	resourcemerge.EnsureObjectMeta(modified, &existingCopy.ObjectMeta, daemonSet.ObjectMeta)
-	// there was no change to metadata, the generation was right
-	if !*modified && existingCopy.ObjectMeta.Generation == expectedGeneration {
+	// there was no change to metadata and update strategy, the generation was right
+	if !*modified && existingCopy.ObjectMeta.Generation == expectedGeneration &&
+		existingCopy.Spec.UpdateStrategy.Type == daemonSet.Spec.UpdateStrategy.Type {
 		log.Log.V(4).Infof("daemonset %v is up-to-date", daemonSet.GetName())
 		return nil
 	}
@@ -151,7 +152,7 @@ func setMaxDevices(kv *v1.KubeVirt, vh *appsv1.DaemonSet) {
 	}
 
 	vh.Spec.Template.Spec.Containers[0].Command = append(vh.Spec.Template.Spec.Containers[0].Command,
-		"--maxDevices",
+		"--max-devices",
 		fmt.Sprintf("%d", *kv.Spec.Configuration.VirtualMachineInstancesPerNode))
 }
 
@@ -0,0 +1,297 @@
+package apply
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/virt-operator/resource/generate/components"
+	"kubevirt.io/kubevirt/pkg/virt-operator/util"
+)
+
+const defaultCanaryValidationTimeout = 10 * time.Minute
+
+func isHandlerCanaryRollout(kv *v1.KubeVirt, daemonSet *appsv1.DaemonSet) bool {
+	return daemonSet.GetName() == components.VirtHandlerName &&
+		kv.Spec.HandlerUpdateStrategy != nil &&
+		kv.Spec.HandlerUpdateStrategy.Canary != nil
+}
+
+// syncHandlerDaemonSetWithCanaries rolls out virt-handler to the canary nodes first.
+// While the canaries are validated, the DaemonSet uses the OnDelete update strategy and only
+// the outdated pods on the canary nodes are deleted. Once all canaries are healthy, the DaemonSet
+// falls back to its rolling update strategy. If the canaries don't get healthy in time, the
+// rollout is paused.
+func (r *Reconciler) syncHandlerDaemonSetWithCanaries(daemonSet *appsv1.DaemonSet) error {
+	kv := r.kv
+	_, _, id := getTargetVersionRegistryID(kv)
+
+	status := kv.Status.HandlerRollout
+	if status == nil || status.TargetDeploymentID != id {
+		now := metav1.Now()
+		status = &v1.KubeVirtHandlerRolloutStatus{
+			Phase:              v1.HandlerRolloutPhaseCanary,
+			TargetDeploymentID: id,
+			StartTime:          &now,
+		}
+		kv.Status.HandlerRollout = status
+		log.Log.Object(kv).Infof("Starting canary rollout of daemonset %v", daemonSet.GetName())
+	}
+
+	if status.Phase == v1.HandlerRolloutPhaseProceeding || status.Phase == v1.HandlerRolloutPhaseCompleted {
+		return r.syncDaemonSet(daemonSet)
+	}
+
+	onDeleteDaemonSet := daemonSet.DeepCopy()
+	onDeleteDaemonSet.Spec.UpdateStrategy = appsv1.DaemonSetUpdateStrategy{
+		Type: appsv1.OnDeleteDaemonSetStrategyType,
+	}
+	if err := r.syncDaemonSet(onDeleteDaemonSet); err != nil {
+		return err
+	}
+
+	if status.Phase == v1.HandlerRolloutPhasePaused {
+		log.Log.Object(kv).V(2).Infof("Canary rollout of daemonset %v is paused: %s", daemonSet.GetName(), status.Message)
+		return nil
+	}
+
+	if len(status.CanaryNodes) == 0 {
+		canaryNodes, err := r.selectCanaryNodes()
+		if err != nil {
+			return err
+		}
+		if len(canaryNodes) == 0 {
+			r.pauseCanaryRollout(daemonSet, "no canary nodes found")
+			return nil
+		}
+		status.CanaryNodes = canaryNodes
+	}
+
+	// only delete the outdated pods once the DaemonSet controller observed the new template,
+	// otherwise the pods could get recreated with the old one
+	if !isDaemonSetOnDeleteObserved(onDeleteDaemonSet, id, r.stores) {
+		return nil
+	}
+
+	pods := r.getHandlerPodsByNode()
+	if err := r.updateCanaryPods(pods, status.CanaryNodes); err != nil {
+		return err
+	}
+
+	reason, err := r.getCanaryNodesUnhealthyReason(pods, status.CanaryNodes)
+	if err != nil {
+		return err
+	}
+	if reason == "" {
+		log.Log.Object(kv).Infof("Canaries of daemonset %v are healthy, proceeding with the rollout", daemonSet.GetName())
+		status.Phase = v1.HandlerRolloutPhaseProceeding
+		status.Message = ""
+		return r.syncDaemonSet(daemonSet)
+	}
+
+	if time.Since(status.StartTime.Time) > getCanaryValidationTimeout(kv) {
+		r.pauseCanaryRollout(daemonSet, reason)
+		return nil
+	}
+
+	log.Log.Object(kv).V(2).Infof("Waiting on canaries of daemonset %v: %s", daemonSet.GetName(), reason)
+	status.Message = reason
+	return nil
+}
+
+func (r *Reconciler) pauseCanaryRollout(daemonSet *appsv1.DaemonSet, reason string) {
+	log.Log.Object(r.kv).Warningf("Pausing canary rollout of daemonset %v: %s", daemonSet.GetName(), reason)
+	r.kv.Status.HandlerRollout.Phase = v1.HandlerRolloutPhasePaused
+	r.kv.Status.HandlerRollout.Message = reason
+}
+
+func getCanaryValidationTimeout(kv *v1.KubeVirt) time.Duration {
+	timeout := kv.Spec.HandlerUpdateStrategy.Canary.ValidationTimeout
+	if timeout == nil {
+		return defaultCanaryValidationTimeout
+	}
+	return timeout.Duration
+}
+
+// selectCanaryNodes returns the sorted names of the nodes running virt-handler, which are
+// selected by the canary node selector. Without a node selector a single node is selected.
+func (r *Reconciler) selectCanaryNodes() ([]string, error) {
+	var handlerNodes []string
+	for nodeName := range r.getHandlerPodsByNode() {
+		handlerNodes = append(handlerNodes, nodeName)
+	}
+	sort.Strings(handlerNodes)
+
+	nodeSelector := r.kv.Spec.HandlerUpdateStrategy.Canary.NodeSelector
+	if len(nodeSelector) == 0 {
+		if len(handlerNodes) == 0 {
+			return nil, nil
+		}
+		return handlerNodes[:1], nil
+	}
+
+	nodes, err := r.clientset.CoreV1().Nodes().List(context.Background(), metav1.ListOptions{
+		LabelSelector: labels.Set(nodeSelector).String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list canary nodes: %v", err)
+	}
+	selected := map[string]bool{}
+	for _, node := range nodes.Items {
+		selected[node.Name] = true
+	}
+
+	var canaryNodes []string
+	for _, nodeName := range handlerNodes {
+		if selected[nodeName] {
+			canaryNodes = append(canaryNodes, nodeName)
+		}
+	}
+	return canaryNodes, nil
+}
+
+func (r *Reconciler) getHandlerPodsByNode() map[string][]*k8sv1.Pod {
+	pods := map[string][]*k8sv1.Pod{}
+	for _, obj := range r.stores.InfrastructurePodCache.List() {
+		pod, ok := obj.(*k8sv1.Pod)
+		if !ok || pod.Spec.NodeName == "" || pod.Labels[v1.AppLabel] != components.VirtHandlerName {
+			continue
+		}
+		pods[pod.Spec.NodeName] = append(pods[pod.Spec.NodeName], pod)
+	}
+	return pods
+}
+
+func isDaemonSetOnDeleteObserved(daemonSet *appsv1.DaemonSet, id string, stores util.Stores) bool {
+	obj, exists, _ := stores.DaemonSetCache.Get(daemonSet)
+	if !exists {
+		return false
+	}
+	cachedDaemonSet := obj.(*appsv1.DaemonSet)
+	return cachedDaemonSet.Spec.UpdateStrategy.Type == appsv1.OnDeleteDaemonSetStrategyType &&
+		cachedDaemonSet.Annotations[v1.InstallStrategyIdentifierAnnotation] == id &&
+		cachedDaemonSet.Status.ObservedGeneration >= cachedDaemonSet.Generation
+}
+
+// updateCanaryPods deletes the outdated virt-handler pods on the canary nodes,
+// so that the DaemonSet controller recreates them with the new template
+func (r *Reconciler) updateCanaryPods(pods map[string][]*k8sv1.Pod, canaryNodes []string) error {
+	for _, nodeName := range canaryNodes {
+		for _, pod := range pods[nodeName] {
+			if pod.DeletionTimestamp != nil || util.PodIsUpToDate(pod, r.kv) {
+				continue
+			}
+			err := r.clientset.CoreV1().Pods(pod.Namespace).Delete(context.Background(), pod.Name, metav1.DeleteOptions{})
+			if err != nil && !errors.IsNotFound(err) {
+				return fmt.Errorf("unable to delete outdated canary pod %s: %v", pod.Name, err)
+			}
+			log.Log.Object(pod).Infof("Deleted outdated virt-handler pod on canary node %s", nodeName)
+		}
+	}
+	return nil
+}
+
+// getCanaryNodesUnhealthyReason returns why the canaries are not healthy yet, or an empty string
+// if they are. A canary is healthy when the updated virt-handler pod is ready, the device plugins
+// are registered, virt-handler sent a heartbeat since the pod started and the VMIs on the node
+// did not fail or become unready since then.
+func (r *Reconciler) getCanaryNodesUnhealthyReason(pods map[string][]*k8sv1.Pod, canaryNodes []string) (string, error) {
+	for _, nodeName := range canaryNodes {
+		var canaryPod *k8sv1.Pod
+		for _, pod := range pods[nodeName] {
+			if pod.DeletionTimestamp == nil && util.PodIsUpToDate(pod, r.kv) {
+				canaryPod = pod
+			}
+		}
+		if canaryPod == nil {
+			return fmt.Sprintf("virt-handler on node %s is not updated yet", nodeName), nil
+		}
+		if !util.PodIsReady(canaryPod) {
+			return fmt.Sprintf("virt-handler on node %s is not ready", nodeName), nil
+		}
+
+		node, err := r.clientset.CoreV1().Nodes().Get(context.Background(), nodeName, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			return fmt.Sprintf("node %s does not exist", nodeName), nil
+		} else if err != nil {
+			return "", fmt.Errorf("unable to get canary node %s: %v", nodeName, err)
+		}
+		if node.Labels[v1.NodeSchedulable] != "true" {
+			return fmt.Sprintf("device plugins on node %s are not healthy", nodeName), nil
+		}
+		if !hasHeartbeatSince(node, canaryPod.Status.StartTime) {
+			return fmt.Sprintf("virt-handler on node %s did not send a heartbeat yet", nodeName), nil
+		}
+		reason, err := r.getCanaryVMIsUnhealthyReason(nodeName, canaryPod.Status.StartTime)
+		if err != nil || reason != "" {
+			return reason, err
+		}
+	}
+	return "", nil
+}
+
+// getCanaryVMIsUnhealthyReason returns why the VMIs on a canary node are not healthy, or an empty string
+// if they are. VMIs which failed or became unready before the updated virt-handler started are ignored,
+// they don't tell anything about the update.
+func (r *Reconciler) getCanaryVMIsUnhealthyReason(nodeName string, since *metav1.Time) (string, error) {
+	vmis, err := r.clientset.VirtualMachineInstance(metav1.NamespaceAll).List(&metav1.ListOptions{
+		LabelSelector: labels.Set{v1.NodeNameLabel: nodeName}.String(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to list the VMIs on canary node %s: %v", nodeName, err)
+	}
+	for i := range vmis.Items {
+		vmi := &vmis.Items[i]
+		switch vmi.Status.Phase {
+		case v1.Failed:
+			if hasPhaseTransitionSince(vmi, v1.Failed, since) {
+				return fmt.Sprintf("VMI %s/%s failed on node %s after virt-handler was updated", vmi.Namespace, vmi.Name, nodeName), nil
+			}
+		case v1.Running:
+			if isVMIUnreadySince(vmi, since) {
+				return fmt.Sprintf("VMI %s/%s on node %s is not ready since virt-handler was updated", vmi.Namespace, vmi.Name, nodeName), nil
+			}
+		}
+	}
+	return "", nil
+}
+
+func hasPhaseTransitionSince(vmi *v1.VirtualMachineInstance, phase v1.VirtualMachineInstancePhase, since *metav1.Time) bool {
+	for _, transition := range vmi.Status.PhaseTransitionTimestamps {
+		if transition.Phase == phase && !transition.PhaseTransitionTimestamp.Before(since) {
+			return true
+		}
+	}
+	return false
+}
+
+func isVMIUnreadySince(vmi *v1.VirtualMachineInstance, since *metav1.Time) bool {
+	for _, condition := range vmi.Status.Conditions {
+		if condition.Type == v1.VirtualMachineInstanceReady {
+			return condition.Status != k8sv1.ConditionTrue && !condition.LastTransitionTime.Before(since)
+		}
+	}
+	return false
+}
+
+func hasHeartbeatSince(node *k8sv1.Node, since *metav1.Time) bool {
+	value, exists := node.Annotations[v1.VirtHandlerHeartbeat]
+	if !exists || since == nil {
+		return false
+	}
+	heartbeat := metav1.Time{}
+	if err := json.Unmarshal([]byte(value), &heartbeat); err != nil {
+		return false
+	}
+	return !heartbeat.Before(since)
+}
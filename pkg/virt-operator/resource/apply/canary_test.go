@@ -0,0 +1,372 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package apply
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/controller"
+	utiltypes "kubevirt.io/kubevirt/pkg/util/types"
+	"kubevirt.io/kubevirt/pkg/virt-operator/resource/generate/components"
+	"kubevirt.io/kubevirt/pkg/virt-operator/util"
+)
+
+var _ = Describe("virt-handler canary rollout", func() {
+
+	const (
+		oldID = "old"
+		newID = "new"
+	)
+
+	var ctrl *gomock.Controller
+	var kubeClient *fake.Clientset
+	var kv *v1.KubeVirt
+	var stores util.Stores
+	var mockDSCacheStore *MockStore
+	var daemonSet *appsv1.DaemonSet
+	var r *Reconciler
+	var patchedStrategies []appsv1.DaemonSetUpdateStrategyType
+	var deletedPods []string
+	var vmis []v1.VirtualMachineInstance
+
+	newNode := func(name string, nodeLabels map[string]string, heartbeat time.Time) *corev1.Node {
+		now, err := json.Marshal(metav1.NewTime(heartbeat))
+		Expect(err).ToNot(HaveOccurred())
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Labels:      nodeLabels,
+				Annotations: map[string]string{v1.VirtHandlerHeartbeat: string(now)},
+			},
+		}
+	}
+
+	newHandlerPod := func(nodeName, id string, ready bool) *corev1.Pod {
+		startTime := metav1.NewTime(time.Now().Add(-time.Minute))
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: Namespace,
+				Name:      "virt-handler-" + nodeName + "-" + id,
+				Labels:    map[string]string{v1.AppLabel: components.VirtHandlerName},
+				Annotations: map[string]string{
+					v1.InstallStrategyVersionAnnotation:    Version,
+					v1.InstallStrategyRegistryAnnotation:   Registry,
+					v1.InstallStrategyIdentifierAnnotation: id,
+				},
+			},
+			Spec: corev1.PodSpec{NodeName: nodeName},
+			Status: corev1.PodStatus{
+				Phase:             corev1.PodRunning,
+				StartTime:         &startTime,
+				ContainerStatuses: []corev1.ContainerStatus{{Ready: ready}},
+			},
+		}
+	}
+
+	// cachedDaemonSet simulates the DaemonSet controller having observed the patched DaemonSet
+	cachedDaemonSet := func(strategy appsv1.DaemonSetUpdateStrategyType, id string) *appsv1.DaemonSet {
+		ds := daemonSet.DeepCopy()
+		injectOperatorMetadata(kv, &ds.ObjectMeta, Version, Registry, id, true)
+		ds.Spec.UpdateStrategy.Type = strategy
+		ds.Generation = 1
+		ds.Status.ObservedGeneration = 1
+		SetGeneration(&kv.Status.Generations, ds)
+		return ds
+	}
+
+	addPods := func(pods ...*corev1.Pod) {
+		for _, pod := range pods {
+			Expect(stores.InfrastructurePodCache.Add(pod)).To(Succeed())
+		}
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		patchedStrategies = nil
+		deletedPods = nil
+		vmis = nil
+
+		schedulable := map[string]string{v1.NodeSchedulable: "true"}
+		kubeClient = fake.NewSimpleClientset(
+			newNode("node01", schedulable, time.Now()),
+			newNode("node02", map[string]string{v1.NodeSchedulable: "true", "canary": "true"}, time.Now()),
+			newNode("node03", map[string]string{v1.NodeSchedulable: "false", "canary": "true"}, time.Now()),
+		)
+		kubeClient.Fake.PrependReactor("patch", "daemonsets", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+			patches := []utiltypes.PatchOperation{}
+			Expect(json.Unmarshal(action.(testing.PatchAction).GetPatch(), &patches)).To(Succeed())
+			for _, patch := range patches {
+				if patch.Path == "/spec" {
+					spec := &appsv1.DaemonSetSpec{}
+					data, err := json.Marshal(patch.Value)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(json.Unmarshal(data, spec)).To(Succeed())
+					patchedStrategies = append(patchedStrategies, spec.UpdateStrategy.Type)
+				}
+			}
+			return true, &appsv1.DaemonSet{}, nil
+		})
+		kubeClient.Fake.PrependReactor("delete", "pods", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+			deletedPods = append(deletedPods, action.(testing.DeleteAction).GetName())
+			return true, nil, nil
+		})
+
+		clientset := kubecli.NewMockKubevirtClient(ctrl)
+		clientset.EXPECT().AppsV1().Return(kubeClient.AppsV1()).AnyTimes()
+		clientset.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+		vmiInterface := kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+		clientset.EXPECT().VirtualMachineInstance(metav1.NamespaceAll).Return(vmiInterface).AnyTimes()
+		vmiInterface.EXPECT().List(gomock.Any()).DoAndReturn(func(opts *metav1.ListOptions) (*v1.VirtualMachineInstanceList, error) {
+			list := &v1.VirtualMachineInstanceList{}
+			for _, vmi := range vmis {
+				if opts.LabelSelector == v1.NodeNameLabel+"="+vmi.Labels[v1.NodeNameLabel] {
+					list.Items = append(list.Items, vmi)
+				}
+			}
+			return list, nil
+		}).AnyTimes()
+
+		stores = util.Stores{}
+		mockDSCacheStore = &MockStore{}
+		stores.DaemonSetCache = mockDSCacheStore
+		stores.InfrastructurePodCache = cache.NewStore(cache.MetaNamespaceKeyFunc)
+
+		expectations := &util.Expectations{}
+		expectations.DaemonSet = controller.NewUIDTrackingControllerExpectations(controller.NewControllerExpectationsWithName("DaemonSet"))
+
+		kv = &v1.KubeVirt{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: Namespace,
+			},
+			Spec: v1.KubeVirtSpec{
+				HandlerUpdateStrategy: &v1.KubeVirtHandlerUpdateStrategy{
+					Canary: &v1.KubeVirtHandlerCanaryStrategy{},
+				},
+			},
+			Status: v1.KubeVirtStatus{
+				TargetKubeVirtVersion:  Version,
+				TargetKubeVirtRegistry: Registry,
+				TargetDeploymentID:     newID,
+			},
+		}
+
+		var err error
+		daemonSet, err = components.NewHandlerDaemonSet(Namespace, Registry, "", Version, "", "", "", corev1.PullIfNotPresent, "verbosity", map[string]string{})
+		Expect(err).ToNot(HaveOccurred())
+
+		r = &Reconciler{
+			clientset:    clientset,
+			kv:           kv,
+			expectations: expectations,
+			stores:       stores,
+		}
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("should only be used for virt-handler with a canary strategy", func() {
+		Expect(isHandlerCanaryRollout(kv, daemonSet)).To(BeTrue())
+
+		kv.Spec.HandlerUpdateStrategy.Canary = nil
+		Expect(isHandlerCanaryRollout(kv, daemonSet)).To(BeFalse())
+
+		kv.Spec.HandlerUpdateStrategy = nil
+		Expect(isHandlerCanaryRollout(kv, daemonSet)).To(BeFalse())
+	})
+
+	It("should switch the DaemonSet to OnDelete and wait until the change is observed", func() {
+		mockDSCacheStore.get = cachedDaemonSet(appsv1.RollingUpdateDaemonSetStrategyType, oldID)
+		addPods(newHandlerPod("node01", oldID, true), newHandlerPod("node02", oldID, true))
+
+		Expect(r.syncHandlerDaemonSetWithCanaries(daemonSet)).To(Succeed())
+
+		Expect(patchedStrategies).To(Equal([]appsv1.DaemonSetUpdateStrategyType{appsv1.OnDeleteDaemonSetStrategyType}))
+		Expect(deletedPods).To(BeEmpty())
+		Expect(kv.Status.HandlerRollout.Phase).To(Equal(v1.HandlerRolloutPhaseCanary))
+		Expect(kv.Status.HandlerRollout.TargetDeploymentID).To(Equal(newID))
+		Expect(kv.Status.HandlerRollout.CanaryNodes).To(Equal([]string{"node01"}))
+	})
+
+	It("should only delete outdated pods on the canary nodes", func() {
+		mockDSCacheStore.get = cachedDaemonSet(appsv1.OnDeleteDaemonSetStrategyType, newID)
+		addPods(newHandlerPod("node01", oldID, true), newHandlerPod("node02", oldID, true))
+
+		Expect(r.syncHandlerDaemonSetWithCanaries(daemonSet)).To(Succeed())
+
+		Expect(patchedStrategies).To(BeEmpty())
+		Expect(deletedPods).To(Equal([]string{"virt-handler-node01-old"}))
+		Expect(kv.Status.HandlerRollout.Phase).To(Equal(v1.HandlerRolloutPhaseCanary))
+		Expect(kv.Status.HandlerRollout.Message).To(ContainSubstring("not updated yet"))
+	})
+
+	It("should select the canary nodes with the node selector", func() {
+		kv.Spec.HandlerUpdateStrategy.Canary.NodeSelector = map[string]string{"canary": "true"}
+		mockDSCacheStore.get = cachedDaemonSet(appsv1.OnDeleteDaemonSetStrategyType, newID)
+		addPods(newHandlerPod("node01", oldID, true), newHandlerPod("node02", oldID, true), newHandlerPod("node03", oldID, true))
+
+		Expect(r.syncHandlerDaemonSetWithCanaries(daemonSet)).To(Succeed())
+
+		Expect(kv.Status.HandlerRollout.CanaryNodes).To(Equal([]string{"node02", "node03"}))
+		Expect(deletedPods).To(ConsistOf("virt-handler-node02-old", "virt-handler-node03-old"))
+	})
+
+	It("should proceed with the rollout once the canaries are healthy", func() {
+		mockDSCacheStore.get = cachedDaemonSet(appsv1.OnDeleteDaemonSetStrategyType, newID)
+		addPods(newHandlerPod("node01", newID, true), newHandlerPod("node02", oldID, true))
+
+		Expect(r.syncHandlerDaemonSetWithCanaries(daemonSet)).To(Succeed())
+
+		Expect(deletedPods).To(BeEmpty())
+		Expect(patchedStrategies).To(Equal([]appsv1.DaemonSetUpdateStrategyType{appsv1.RollingUpdateDaemonSetStrategyType}))
+		Expect(kv.Status.HandlerRollout.Phase).To(Equal(v1.HandlerRolloutPhaseProceeding))
+	})
+
+	newVMI := func(nodeName string, phase v1.VirtualMachineInstancePhase, ready bool, transitionTime time.Time) v1.VirtualMachineInstance {
+		readyStatus := corev1.ConditionFalse
+		if ready {
+			readyStatus = corev1.ConditionTrue
+		}
+		return v1.VirtualMachineInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "vmi-" + nodeName,
+				Labels:    map[string]string{v1.NodeNameLabel: nodeName},
+			},
+			Status: v1.VirtualMachineInstanceStatus{
+				Phase: phase,
+				PhaseTransitionTimestamps: []v1.VirtualMachineInstancePhaseTransitionTimestamp{
+					{Phase: phase, PhaseTransitionTimestamp: metav1.NewTime(transitionTime)},
+				},
+				Conditions: []v1.VirtualMachineInstanceCondition{
+					{Type: v1.VirtualMachineInstanceReady, Status: readyStatus, LastTransitionTime: metav1.NewTime(transitionTime)},
+				},
+			},
+		}
+	}
+
+	table.DescribeTable("should judge the canaries by the VMIs on their nodes", func(vmi v1.VirtualMachineInstance, expectedPhase v1.KubeVirtHandlerRolloutPhase, expectedMessage string) {
+		vmis = []v1.VirtualMachineInstance{vmi}
+		mockDSCacheStore.get = cachedDaemonSet(appsv1.OnDeleteDaemonSetStrategyType, newID)
+		addPods(newHandlerPod("node01", newID, true), newHandlerPod("node02", oldID, true))
+
+		Expect(r.syncHandlerDaemonSetWithCanaries(daemonSet)).To(Succeed())
+
+		Expect(kv.Status.HandlerRollout.Phase).To(Equal(expectedPhase))
+		Expect(kv.Status.HandlerRollout.Message).To(ContainSubstring(expectedMessage))
+	},
+		table.Entry("and wait if a VMI failed after the update", newVMI("node01", v1.Failed, false, time.Now()), v1.HandlerRolloutPhaseCanary, "VMI default/vmi-node01 failed"),
+		table.Entry("and wait if a VMI became unready after the update", newVMI("node01", v1.Running, false, time.Now()), v1.HandlerRolloutPhaseCanary, "VMI default/vmi-node01 on node node01 is not ready"),
+		table.Entry("and proceed if a VMI failed before the update", newVMI("node01", v1.Failed, false, time.Now().Add(-time.Hour)), v1.HandlerRolloutPhaseProceeding, ""),
+		table.Entry("and proceed if a VMI was unready before the update", newVMI("node01", v1.Running, false, time.Now().Add(-time.Hour)), v1.HandlerRolloutPhaseProceeding, ""),
+		table.Entry("and proceed if a VMI is ready", newVMI("node01", v1.Running, true, time.Now()), v1.HandlerRolloutPhaseProceeding, ""),
+		table.Entry("and ignore VMIs on other nodes", newVMI("node02", v1.Failed, false, time.Now()), v1.HandlerRolloutPhaseProceeding, ""),
+	)
+
+	It("should wait for a heartbeat of the updated canaries", func() {
+		Expect(kubeClient.Tracker().Update(corev1.SchemeGroupVersion.WithResource("nodes"),
+			newNode("node01", map[string]string{v1.NodeSchedulable: "true"}, time.Now().Add(-time.Hour)), "")).To(Succeed())
+		mockDSCacheStore.get = cachedDaemonSet(appsv1.OnDeleteDaemonSetStrategyType, newID)
+		addPods(newHandlerPod("node01", newID, true))
+
+		Expect(r.syncHandlerDaemonSetWithCanaries(daemonSet)).To(Succeed())
+
+		Expect(kv.Status.HandlerRollout.Phase).To(Equal(v1.HandlerRolloutPhaseCanary))
+		Expect(kv.Status.HandlerRollout.Message).To(ContainSubstring("heartbeat"))
+	})
+
+	It("should pause the rollout if the canaries do not get healthy in time", func() {
+		kv.Spec.HandlerUpdateStrategy.Canary.NodeSelector = map[string]string{"canary": "true"}
+		startTime := metav1.NewTime(time.Now().Add(-time.Hour))
+		kv.Status.HandlerRollout = &v1.KubeVirtHandlerRolloutStatus{
+			Phase:              v1.HandlerRolloutPhaseCanary,
+			TargetDeploymentID: newID,
+			StartTime:          &startTime,
+		}
+		mockDSCacheStore.get = cachedDaemonSet(appsv1.OnDeleteDaemonSetStrategyType, newID)
+		addPods(newHandlerPod("node02", newID, true), newHandlerPod("node03", newID, true))
+
+		Expect(r.syncHandlerDaemonSetWithCanaries(daemonSet)).To(Succeed())
+
+		Expect(kv.Status.HandlerRollout.Phase).To(Equal(v1.HandlerRolloutPhasePaused))
+		Expect(kv.Status.HandlerRollout.Message).To(ContainSubstring("device plugins on node node03"))
+
+		// a paused rollout is not continued, even if the canaries get healthy
+		Expect(r.syncHandlerDaemonSetWithCanaries(daemonSet)).To(Succeed())
+		Expect(kv.Status.HandlerRollout.Phase).To(Equal(v1.HandlerRolloutPhasePaused))
+		Expect(patchedStrategies).To(BeEmpty())
+	})
+
+	It("should pause the rollout if no canary node is found", func() {
+		kv.Spec.HandlerUpdateStrategy.Canary.NodeSelector = map[string]string{"canary": "none"}
+		mockDSCacheStore.get = cachedDaemonSet(appsv1.OnDeleteDaemonSetStrategyType, newID)
+		addPods(newHandlerPod("node01", oldID, true))
+
+		Expect(r.syncHandlerDaemonSetWithCanaries(daemonSet)).To(Succeed())
+
+		Expect(kv.Status.HandlerRollout.Phase).To(Equal(v1.HandlerRolloutPhasePaused))
+		Expect(deletedPods).To(BeEmpty())
+	})
+
+	It("should keep the DaemonSet rolling once the rollout of the deployment completed", func() {
+		kv.Status.HandlerRollout = &v1.KubeVirtHandlerRolloutStatus{
+			Phase:              v1.HandlerRolloutPhaseCompleted,
+			TargetDeploymentID: newID,
+		}
+		mockDSCacheStore.get = cachedDaemonSet(appsv1.OnDeleteDaemonSetStrategyType, newID)
+		addPods(newHandlerPod("node01", newID, true), newHandlerPod("node02", newID, true))
+
+		Expect(r.syncHandlerDaemonSetWithCanaries(daemonSet)).To(Succeed())
+
+		Expect(kv.Status.HandlerRollout.Phase).To(Equal(v1.HandlerRolloutPhaseCompleted))
+		Expect(patchedStrategies).To(Equal([]appsv1.DaemonSetUpdateStrategyType{appsv1.RollingUpdateDaemonSetStrategyType}))
+		Expect(deletedPods).To(BeEmpty())
+	})
+
+	It("should start a new canary rollout for a new deployment", func() {
+		kv.Status.HandlerRollout = &v1.KubeVirtHandlerRolloutStatus{
+			Phase:              v1.HandlerRolloutPhasePaused,
+			TargetDeploymentID: oldID,
+		}
+		mockDSCacheStore.get = cachedDaemonSet(appsv1.OnDeleteDaemonSetStrategyType, oldID)
+		addPods(newHandlerPod("node01", oldID, true))
+
+		Expect(r.syncHandlerDaemonSetWithCanaries(daemonSet)).To(Succeed())
+
+		Expect(kv.Status.HandlerRollout.Phase).To(Equal(v1.HandlerRolloutPhaseCanary))
+		Expect(kv.Status.HandlerRollout.TargetDeploymentID).To(Equal(newID))
+	})
+})
@@ -29,6 +29,7 @@ import (
 
 	k8sv1 "kubevirt.io/client-go/api/v1"
 	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/kubevirt/pkg/virt-operator/resource/generate/components"
 )
 
 var _ = Describe("Certificates", func() {
@@ -115,4 +116,24 @@ var _ = Describe("Certificates", func() {
 		})
 	})
 
+	Context("rotation", func() {
+		It("should rotate a valid certificate only when forced by annotation", func() {
+			secret := components.NewCACertSecret("test")
+			Expect(components.PopulateSecretWithCertificate(secret, nil, week)).To(Succeed())
+			Expect(certificationNeedsRotation(secret, week, nil, oneDay, nil)).To(BeFalse())
+
+			secret.Annotations[v1.ForceCertificateRotationAnnotation] = ""
+			Expect(certificationNeedsRotation(secret, week, nil, oneDay, nil)).To(BeTrue())
+		})
+
+		It("should drop the forced rotation annotation when patching the secret", func() {
+			secret := components.NewCACertSecret("test")
+			Expect(components.PopulateSecretWithCertificate(secret, nil, week)).To(Succeed())
+
+			ops, err := createSecretPatch(secret)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ops).To(ContainElement(ContainSubstring(`"path": "/metadata/annotations"`)))
+			Expect(ops).ToNot(ContainElement(ContainSubstring(v1.ForceCertificateRotationAnnotation)))
+		})
+	})
 })
@@ -0,0 +1,158 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package apply
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/virt-operator/resource/generate/components"
+)
+
+var commonTemplatesSelector = fmt.Sprintf("%s,%s=%s", v1.CommonTemplateLabel, v1.ManagedByLabel, v1.ManagedByLabelOperatorValue)
+
+// syncCommonTemplates deploys the common templates to the namespace requested in the KubeVirt CR and
+// removes them from the namespace they were deployed to before, if it changed or they are not requested anymore.
+// The example VirtualMachines are not started or stopped by the operator.
+func (r *Reconciler) syncCommonTemplates() error {
+	kv := r.kv
+
+	namespace := ""
+	if kv.Spec.CommonTemplates != nil {
+		namespace = kv.Spec.CommonTemplates.Namespace
+	}
+
+	if kv.Status.CommonTemplatesNamespace != "" && kv.Status.CommonTemplatesNamespace != namespace {
+		if err := r.deleteCommonTemplates(kv.Status.CommonTemplatesNamespace); err != nil {
+			return err
+		}
+		kv.Status.CommonTemplatesNamespace = ""
+	}
+
+	if namespace == "" {
+		return nil
+	}
+
+	version, imageRegistry, id := getTargetVersionRegistryID(kv)
+
+	for _, preset := range components.NewCommonTemplatePresets(namespace) {
+		if err := r.syncCommonTemplatePreset(preset, version, imageRegistry, id); err != nil {
+			return err
+		}
+	}
+	for _, vm := range components.NewCommonTemplateVirtualMachines(namespace, imageRegistry, version) {
+		if err := r.syncCommonTemplateVirtualMachine(vm, version, imageRegistry, id); err != nil {
+			return err
+		}
+	}
+
+	kv.Status.CommonTemplatesNamespace = namespace
+	return nil
+}
+
+func (r *Reconciler) syncCommonTemplatePreset(preset *v1.VirtualMachineInstancePreset, version, imageRegistry, id string) error {
+	injectOperatorMetadata(r.kv, &preset.ObjectMeta, version, imageRegistry, id, false)
+
+	client := r.clientset.VirtualMachineInstancePreset(preset.Namespace)
+	existing, err := client.Get(preset.Name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		if _, err := client.Create(preset); err != nil {
+			return fmt.Errorf("unable to create common template preset %+v: %v", preset, err)
+		}
+		log.Log.V(2).Infof("common template preset %v created", preset.GetName())
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if objectMatchesVersion(&existing.ObjectMeta, version, imageRegistry, id, r.kv.GetGeneration()) {
+		log.Log.V(4).Infof("common template preset %v is up-to-date", preset.GetName())
+		return nil
+	}
+
+	preset.ResourceVersion = existing.ResourceVersion
+	if _, err := client.Update(preset); err != nil {
+		return fmt.Errorf("unable to update common template preset %+v: %v", preset, err)
+	}
+	log.Log.V(2).Infof("common template preset %v updated", preset.GetName())
+	return nil
+}
+
+func (r *Reconciler) syncCommonTemplateVirtualMachine(vm *v1.VirtualMachine, version, imageRegistry, id string) error {
+	injectOperatorMetadata(r.kv, &vm.ObjectMeta, version, imageRegistry, id, false)
+
+	client := r.clientset.VirtualMachine(vm.Namespace)
+	existing, err := client.Get(vm.Name, &metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		if _, err := client.Create(vm); err != nil {
+			return fmt.Errorf("unable to create common template vm %+v: %v", vm, err)
+		}
+		log.Log.V(2).Infof("common template vm %v created", vm.GetName())
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if objectMatchesVersion(&existing.ObjectMeta, version, imageRegistry, id, r.kv.GetGeneration()) {
+		log.Log.V(4).Infof("common template vm %v is up-to-date", vm.GetName())
+		return nil
+	}
+
+	// users may have started the example, keep it running
+	vm.Spec.Running = existing.Spec.Running
+	vm.Spec.RunStrategy = existing.Spec.RunStrategy
+	vm.ResourceVersion = existing.ResourceVersion
+	if _, err := client.Update(vm); err != nil {
+		return fmt.Errorf("unable to update common template vm %+v: %v", vm, err)
+	}
+	log.Log.V(2).Infof("common template vm %v updated", vm.GetName())
+	return nil
+}
+
+func (r *Reconciler) deleteCommonTemplates(namespace string) error {
+	vms, err := r.clientset.VirtualMachine(namespace).List(&metav1.ListOptions{LabelSelector: commonTemplatesSelector})
+	if err != nil {
+		return err
+	}
+	for _, vm := range vms.Items {
+		err := r.clientset.VirtualMachine(namespace).Delete(vm.Name, &metav1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("unable to delete common template vm %s/%s: %v", namespace, vm.Name, err)
+		}
+		log.Log.V(2).Infof("common template vm %s/%s deleted", namespace, vm.Name)
+	}
+
+	presets, err := r.clientset.VirtualMachineInstancePreset(namespace).List(metav1.ListOptions{LabelSelector: commonTemplatesSelector})
+	if err != nil {
+		return err
+	}
+	for _, preset := range presets.Items {
+		err := r.clientset.VirtualMachineInstancePreset(namespace).Delete(preset.Name, &metav1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("unable to delete common template preset %s/%s: %v", namespace, preset.Name, err)
+		}
+		log.Log.V(2).Infof("common template preset %s/%s deleted", namespace, preset.Name)
+	}
+	return nil
+}
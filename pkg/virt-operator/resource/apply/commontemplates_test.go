@@ -0,0 +1,161 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package apply
+
+import (
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/virt-operator/resource/generate/components"
+)
+
+var _ = Describe("Common templates", func() {
+
+	const templatesNamespace = "templates"
+
+	var ctrl *gomock.Controller
+	var presetInterface *kubecli.MockVirtualMachineInstancePresetInterface
+	var vmInterface *kubecli.MockVirtualMachineInterface
+	var kv *v1.KubeVirt
+	var r *Reconciler
+
+	notFound := func(resource string) error {
+		return errors.NewNotFound(schema.GroupResource{Group: v1.GroupVersion.Group, Resource: resource}, "")
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		presetInterface = kubecli.NewMockVirtualMachineInstancePresetInterface(ctrl)
+		vmInterface = kubecli.NewMockVirtualMachineInterface(ctrl)
+		clientset := kubecli.NewMockKubevirtClient(ctrl)
+		clientset.EXPECT().VirtualMachineInstancePreset(templatesNamespace).Return(presetInterface).AnyTimes()
+		clientset.EXPECT().VirtualMachine(templatesNamespace).Return(vmInterface).AnyTimes()
+
+		kv = &v1.KubeVirt{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: Namespace,
+			},
+			Spec: v1.KubeVirtSpec{
+				CommonTemplates: &v1.KubeVirtCommonTemplates{Namespace: templatesNamespace},
+			},
+		}
+		kv.Status.TargetKubeVirtRegistry = Registry
+		kv.Status.TargetKubeVirtVersion = Version
+		kv.Status.TargetDeploymentID = "id"
+
+		r = &Reconciler{
+			kv:        kv,
+			clientset: clientset,
+		}
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("should create the presets and the example VMs", func() {
+		presetInterface.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, notFound("virtualmachineinstancepresets")).Times(3)
+		vmInterface.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, notFound("virtualmachines")).Times(3)
+
+		var presets []*v1.VirtualMachineInstancePreset
+		presetInterface.EXPECT().Create(gomock.Any()).DoAndReturn(func(preset *v1.VirtualMachineInstancePreset) (*v1.VirtualMachineInstancePreset, error) {
+			presets = append(presets, preset)
+			return preset, nil
+		}).Times(3)
+		var vms []*v1.VirtualMachine
+		vmInterface.EXPECT().Create(gomock.Any()).DoAndReturn(func(vm *v1.VirtualMachine) (*v1.VirtualMachine, error) {
+			vms = append(vms, vm)
+			return vm, nil
+		}).Times(3)
+
+		Expect(r.syncCommonTemplates()).To(Succeed())
+		Expect(kv.Status.CommonTemplatesNamespace).To(Equal(templatesNamespace))
+
+		for _, preset := range presets {
+			Expect(preset.Labels).To(HaveKeyWithValue(v1.ManagedByLabel, v1.ManagedByLabelOperatorValue))
+			Expect(preset.Annotations).To(HaveKeyWithValue(v1.InstallStrategyVersionAnnotation, Version))
+		}
+		Expect(presets[2].Name).To(Equal(components.CommonTemplateWindows))
+		Expect(presets[2].Spec.Domain.GuestOS.OSFamily).To(Equal(v1.GuestOSFamilyWindows))
+		Expect(presets[2].Spec.Domain.Features.Hyperv).ToNot(BeNil())
+
+		Expect(vms[0].Spec.Template.ObjectMeta.Labels).To(HaveKeyWithValue(v1.CommonTemplateLabel, components.CommonTemplateFedora))
+		Expect(vms[0].Spec.Template.Spec.Volumes[0].ContainerDisk.Image).To(Equal(Registry + "/fedora-cloud-container-disk-demo:" + Version))
+		Expect(*vms[0].Spec.Running).To(BeFalse())
+	})
+
+	It("should only update outdated templates and keep the run state of the example VMs", func() {
+		presetInterface.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(func(name string, _ metav1.GetOptions) (*v1.VirtualMachineInstancePreset, error) {
+			for _, preset := range components.NewCommonTemplatePresets(templatesNamespace) {
+				if preset.Name == name {
+					injectOperatorMetadata(kv, &preset.ObjectMeta, Version, Registry, "id", false)
+					return preset, nil
+				}
+			}
+			return nil, notFound("virtualmachineinstancepresets")
+		}).Times(3)
+
+		running := true
+		vmInterface.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(func(name string, _ *metav1.GetOptions) (*v1.VirtualMachine, error) {
+			vm := &v1.VirtualMachine{ObjectMeta: metav1.ObjectMeta{Name: name, ResourceVersion: "1"}}
+			vm.Spec.Running = &running
+			injectOperatorMetadata(kv, &vm.ObjectMeta, Version, Registry, "old", false)
+			return vm, nil
+		}).Times(3)
+		vmInterface.EXPECT().Update(gomock.Any()).DoAndReturn(func(vm *v1.VirtualMachine) (*v1.VirtualMachine, error) {
+			Expect(vm.Annotations).To(HaveKeyWithValue(v1.InstallStrategyIdentifierAnnotation, "id"))
+			Expect(vm.ResourceVersion).To(Equal("1"))
+			Expect(*vm.Spec.Running).To(BeTrue())
+			return vm, nil
+		}).Times(3)
+
+		Expect(r.syncCommonTemplates()).To(Succeed())
+	})
+
+	It("should delete the templates once they are not requested anymore", func() {
+		kv.Spec.CommonTemplates = nil
+		kv.Status.CommonTemplatesNamespace = templatesNamespace
+
+		vmInterface.EXPECT().List(&metav1.ListOptions{LabelSelector: commonTemplatesSelector}).Return(&v1.VirtualMachineList{
+			Items: []v1.VirtualMachine{{ObjectMeta: metav1.ObjectMeta{Name: "example-fedora"}}},
+		}, nil)
+		vmInterface.EXPECT().Delete("example-fedora", gomock.Any()).Return(nil)
+		presetInterface.EXPECT().List(metav1.ListOptions{LabelSelector: commonTemplatesSelector}).Return(&v1.VirtualMachineInstancePresetList{
+			Items: []v1.VirtualMachineInstancePreset{{ObjectMeta: metav1.ObjectMeta{Name: components.CommonTemplateFedora}}},
+		}, nil)
+		presetInterface.EXPECT().Delete(components.CommonTemplateFedora, gomock.Any()).Return(notFound("virtualmachineinstancepresets"))
+
+		Expect(r.syncCommonTemplates()).To(Succeed())
+		Expect(kv.Status.CommonTemplatesNamespace).To(BeEmpty())
+	})
+
+	It("should do nothing without common templates", func() {
+		kv.Spec.CommonTemplates = nil
+
+		Expect(r.syncCommonTemplates()).To(Succeed())
+		Expect(kv.Status.CommonTemplatesNamespace).To(BeEmpty())
+	})
+})
@@ -19,6 +19,7 @@ import (
 	typedv1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/util/workqueue"
 
+	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/log"
 	"kubevirt.io/kubevirt/pkg/certificates/triple/cert"
 	"kubevirt.io/kubevirt/pkg/controller"
@@ -162,6 +163,11 @@ func (r *Reconciler) getSecret(secret *corev1.Secret) (*corev1.Secret, bool, err
 }
 
 func certificationNeedsRotation(secret *corev1.Secret, duration *metav1.Duration, ca *tls.Certificate, renewBefore *metav1.Duration, caRenewBefore *metav1.Duration) bool {
+	if _, forced := secret.Annotations[v1.ForceCertificateRotationAnnotation]; forced {
+		log.DefaultLogger().Infof("Rotation of the certificate in secret %s was requested, will rotate it.", secret.Name)
+		return true
+	}
+
 	crt, err := components.LoadCertificates(secret)
 	if err != nil {
 		log.DefaultLogger().Reason(err).Infof("Failed to load certificate from secret %s, will rotate it.", secret.Name)
@@ -4,15 +4,19 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"reflect"
 
 	"github.com/openshift/library-go/pkg/operator/resource/resourcemerge"
 
+	corev1 "k8s.io/api/core/v1"
 	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
+	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/log"
+	"kubevirt.io/kubevirt/pkg/virt-operator/resource/generate/components"
 )
 
 func getSubresourcesForVersion(crd *extv1.CustomResourceDefinition, version string) *extv1.CustomResourceSubresources {
@@ -44,6 +48,51 @@ func needsSubresourceStatusDisable(crdTargetVersion *extv1.CustomResourceDefinit
 		(crdTargetVersion.Subresources != nil && crdTargetVersion.Subresources.Status != nil)
 }
 
+// usesConversionWebhook returns true for the CRDs whose versions get converted by virt-api. The KubeVirt
+// CRD keeps the None strategy, virt-operator has to read the KubeVirt CR while virt-api is not running.
+func usesConversionWebhook(crd *extv1.CustomResourceDefinition) bool {
+	return crd.Spec.Group == v1.GroupName && len(crd.Spec.Versions) > 1 &&
+		crd.Spec.Names.Kind != v1.KubeVirtGroupVersionKind.Kind
+}
+
+func newConversionWebhook(namespace string, caBundle []byte) *extv1.CustomResourceConversion {
+	path := components.VirtAPIConversionPath
+	return &extv1.CustomResourceConversion{
+		Strategy: extv1.WebhookConverter,
+		Webhook: &extv1.WebhookConversion{
+			ClientConfig: &extv1.WebhookClientConfig{
+				Service: &extv1.ServiceReference{
+					Namespace: namespace,
+					Name:      components.VirtApiServiceName,
+					Path:      &path,
+				},
+				CABundle: caBundle,
+			},
+			ConversionReviewVersions: []string{"v1", "v1beta1"},
+		},
+	}
+}
+
+func needsConversionWebhookUpdate(cachedCrd *extv1.CustomResourceDefinition, caBundle []byte) bool {
+	conversion := cachedCrd.Spec.Conversion
+	return conversion == nil || conversion.Strategy != extv1.WebhookConverter ||
+		conversion.Webhook == nil || conversion.Webhook.ClientConfig == nil ||
+		!reflect.DeepEqual(conversion.Webhook.ClientConfig.CABundle, caBundle)
+}
+
+// getKubeVirtCABundle returns the bundle of the CA which signs the certificate of virt-api
+func (r *Reconciler) getKubeVirtCABundle() []byte {
+	configMap := findRequiredCAConfigMap(r.targetStrategy.ConfigMaps())
+	if configMap == nil {
+		return nil
+	}
+	obj, exists, _ := r.stores.ConfigMapCache.Get(configMap)
+	if !exists {
+		return nil
+	}
+	return []byte(obj.(*corev1.ConfigMap).Data[components.CABundleKey])
+}
+
 func patchCRD(client clientset.Interface, crd *extv1.CustomResourceDefinition, ops []string) (*extv1.CustomResourceDefinition, error) {
 	name := crd.GetName()
 	newSpec, err := json.Marshal(crd.Spec)
@@ -112,6 +161,8 @@ func (r *Reconciler) createOrUpdateCrd(crd *extv1.CustomResourceDefinition) erro
 			crd.Spec.Versions[i].Subresources.Status = nil
 		}
 	}
+	// virt-api serves the conversion only once the control plane rolled over
+	crd.Spec.Conversion = cachedCrd.Spec.Conversion
 	// Add Labels and Annotations Patches
 	var ops []string
 	labelAnnotationPatch, err := createLabelsAndAnnotationsPatch(&crd.ObjectMeta)
@@ -129,8 +180,9 @@ func (r *Reconciler) createOrUpdateCrd(crd *extv1.CustomResourceDefinition) erro
 }
 
 func (r *Reconciler) rolloutNonCompatibleCRDChanges() error {
+	caBundle := r.getKubeVirtCABundle()
 	for _, crd := range r.targetStrategy.CRDs() {
-		err := r.rolloutNonCompatibleCRDChange(crd)
+		err := r.rolloutNonCompatibleCRDChange(crd, caBundle)
 		if err != nil {
 			return err
 		}
@@ -139,7 +191,7 @@ func (r *Reconciler) rolloutNonCompatibleCRDChanges() error {
 	return nil
 }
 
-func (r *Reconciler) rolloutNonCompatibleCRDChange(crd *extv1.CustomResourceDefinition) error {
+func (r *Reconciler) rolloutNonCompatibleCRDChange(crd *extv1.CustomResourceDefinition, caBundle []byte) error {
 	client := r.clientset.ExtensionsClient()
 	version, imageRegistry, id := getTargetVersionRegistryID(r.kv)
 	var cachedCrd *extv1.CustomResourceDefinition
@@ -153,11 +205,18 @@ func (r *Reconciler) rolloutNonCompatibleCRDChange(crd *extv1.CustomResourceDefi
 	cachedCrd = obj.(*extv1.CustomResourceDefinition)
 	injectOperatorMetadata(r.kv, &crd.ObjectMeta, version, imageRegistry, id, true)
 	if objectMatchesVersion(&cachedCrd.ObjectMeta, version, imageRegistry, id, r.kv.GetGeneration()) {
+		// switch to the conversion webhook of virt-api, or update the CA bundle it is called with
+		crd.Spec.Conversion = cachedCrd.Spec.Conversion
+		conversionChanged := false
+		if usesConversionWebhook(crd) && len(caBundle) > 0 && needsConversionWebhookUpdate(cachedCrd, caBundle) {
+			crd.Spec.Conversion = newConversionWebhook(r.kv.Namespace, caBundle)
+			conversionChanged = true
+		}
 		// Patch if in the deployed version the subresource is not enabled
-		if !needsSubresourceStatusEnable(crd, cachedCrd) {
+		if !needsSubresourceStatusEnable(crd, cachedCrd) && !conversionChanged {
 			return nil
 		}
-		// enable the status subresources now, in case that they were disabled before
+		// enable the status subresources and the conversion webhook now, in case that they were disabled before
 		if _, err := patchCRD(client, crd, []string{}); err != nil {
 			return err
 		}
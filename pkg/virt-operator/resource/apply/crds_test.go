@@ -18,6 +18,7 @@ import (
 
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/virt-operator/resource/generate/components"
 	"kubevirt.io/kubevirt/pkg/virt-operator/util"
 )
 
@@ -167,4 +168,121 @@ var _ = Describe("Apply CRDs", func() {
 
 		Expect(r.rolloutNonCompatibleCRDChanges()).To(Succeed())
 	})
+	Context("conversion webhook", func() {
+		newCrd := func(kind string) *extv1.CustomResourceDefinition {
+			return &extv1.CustomResourceDefinition{
+				TypeMeta: v12.TypeMeta{
+					APIVersion: extv1.SchemeGroupVersion.String(),
+					Kind:       "CustomResourceDefinition",
+				},
+				ObjectMeta: v12.ObjectMeta{
+					Name: "test",
+				},
+				Spec: extv1.CustomResourceDefinitionSpec{
+					Group: v1.GroupName,
+					Names: extv1.CustomResourceDefinitionNames{Kind: kind},
+					Versions: []extv1.CustomResourceDefinitionVersion{
+						{Name: "v1", Served: true, Storage: true},
+						{Name: "v1alpha3", Served: true},
+					},
+					Conversion: &extv1.CustomResourceConversion{Strategy: extv1.NoneConverter},
+				},
+			}
+		}
+
+		// the CRD was already updated to the target version before the control-plane rollover
+		newUpdatedCrd := func(r *Reconciler, kind string) *extv1.CustomResourceDefinition {
+			crd := newCrd(kind)
+			version, imageRegistry, id := getTargetVersionRegistryID(r.kv)
+			injectOperatorMetadata(r.kv, &crd.ObjectMeta, version, imageRegistry, id, true)
+			return crd
+		}
+
+		var patched bool
+		expectPatchedConversion := func(cachedCrd *extv1.CustomResourceDefinition, validate func(*extv1.CustomResourceConversion)) {
+			patched = false
+			extClient.Fake.PrependReactor("patch", "customresourcedefinitions", func(action testing.Action) (handled bool, ret runtime.Object, err error) {
+				a := action.(testing.PatchActionImpl)
+				patch, err := jsonpatch.DecodePatch(a.Patch)
+				Expect(err).ToNot(HaveOccurred())
+				obj, err := json.Marshal(cachedCrd)
+				Expect(err).To(BeNil())
+				obj, err = patch.Apply(obj)
+				Expect(err).To(BeNil())
+				crd := &extv1.CustomResourceDefinition{}
+				Expect(json.Unmarshal(obj, crd)).To(Succeed())
+				validate(crd.Spec.Conversion)
+				patched = true
+				return true, crd, nil
+			})
+		}
+
+		It("should not switch to the conversion webhook before the control-plane rollover", func() {
+			crd := newCrd("VirtualMachine")
+			crd.Spec.Conversion = nil
+			targetStrategy := loadTargetStrategy(crd, config, stores)
+
+			cachedCrd := newCrd("VirtualMachine")
+			stores.CrdCache.Add(cachedCrd)
+			expectPatchedConversion(cachedCrd, func(conversion *extv1.CustomResourceConversion) {
+				Expect(conversion.Strategy).To(Equal(extv1.NoneConverter))
+			})
+
+			r := &Reconciler{
+				kv:             kv,
+				targetStrategy: targetStrategy,
+				stores:         stores,
+				clientset:      clientset,
+				expectations:   expectations,
+			}
+
+			Expect(r.createOrUpdateCrds()).To(Succeed())
+			Expect(patched).To(BeTrue())
+		})
+
+		It("should switch to the conversion webhook of virt-api after the control-plane rollover", func() {
+			crd := newCrd("VirtualMachine")
+			crd.Spec.Conversion = nil
+			targetStrategy := loadTargetStrategy(crd, config, stores)
+
+			r := &Reconciler{
+				kv:             &v1.KubeVirt{ObjectMeta: v12.ObjectMeta{Namespace: Namespace}},
+				targetStrategy: targetStrategy,
+				stores:         stores,
+				clientset:      clientset,
+				expectations:   expectations,
+			}
+			cachedCrd := newUpdatedCrd(r, "VirtualMachine")
+			stores.CrdCache.Add(cachedCrd)
+			expectPatchedConversion(cachedCrd, func(conversion *extv1.CustomResourceConversion) {
+				Expect(conversion.Strategy).To(Equal(extv1.WebhookConverter))
+				Expect(conversion.Webhook.ConversionReviewVersions).To(ConsistOf("v1", "v1beta1"))
+				Expect(conversion.Webhook.ClientConfig.CABundle).To(Equal([]byte("ca-bundle")))
+				Expect(conversion.Webhook.ClientConfig.Service.Namespace).To(Equal(Namespace))
+				Expect(conversion.Webhook.ClientConfig.Service.Name).To(Equal(components.VirtApiServiceName))
+				Expect(*conversion.Webhook.ClientConfig.Service.Path).To(Equal(components.VirtAPIConversionPath))
+			})
+
+			Expect(r.rolloutNonCompatibleCRDChange(targetStrategy.CRDs()[0], []byte("ca-bundle"))).To(Succeed())
+			Expect(patched).To(BeTrue())
+		})
+
+		It("should keep the None conversion of the KubeVirt CRD after the control-plane rollover", func() {
+			crd := newCrd("KubeVirt")
+			crd.Spec.Conversion = nil
+			targetStrategy := loadTargetStrategy(crd, config, stores)
+
+			r := &Reconciler{
+				kv:             kv,
+				targetStrategy: targetStrategy,
+				stores:         stores,
+				clientset:      clientset,
+				expectations:   expectations,
+			}
+			stores.CrdCache.Add(newUpdatedCrd(r, "KubeVirt"))
+
+			// any call to the API server fails the test
+			Expect(r.rolloutNonCompatibleCRDChange(targetStrategy.CRDs()[0], []byte("ca-bundle"))).To(Succeed())
+		})
+	})
 })
@@ -112,8 +112,8 @@ const (
 	kubernetesOSLinux = "linux"
 )
 
-// Merge all Tolerations, Affinity and NodeSelectos from NodePlacement into pod spec
-func injectPlacementMetadata(componentConfig *v1.ComponentConfig, podSpec *corev1.PodSpec) {
+// InjectPlacementMetadata merges all Tolerations, Affinity and NodeSelectos from NodePlacement into pod spec
+func InjectPlacementMetadata(componentConfig *v1.ComponentConfig, podSpec *corev1.PodSpec) {
 	if podSpec == nil {
 		podSpec = &corev1.PodSpec{}
 	}
@@ -605,6 +605,13 @@ func (r *Reconciler) Sync(queue workqueue.RateLimitingInterface) (bool, error) {
 		return false, err
 	}
 
+	// -------- COMMON TEMPLATES --------
+	// the templates are validated by virt-api and can only be created once it rolled over
+	err = r.syncCommonTemplates()
+	if err != nil {
+		return false, err
+	}
+
 	return true, nil
 }
 
@@ -3,6 +3,7 @@ package apply
 func (r *Reconciler) updateKubeVirtSystem(daemonSetsRolledOver, controllerDeploymentsRolledOver bool) (bool, error) {
 	// UPDATE PATH IS
 	// 1. daemonsets - ensures all compute nodes are updated to handle new features
+	//    (virt-handler is rolled out to canary nodes first, if requested)
 	// 2. wait for daemonsets to roll over
 	// 3. controllers - ensures control plane is ready for new features
 	// 4. wait for controllers to roll over
@@ -10,7 +11,12 @@ func (r *Reconciler) updateKubeVirtSystem(daemonSetsRolledOver, controllerDeploy
 
 	// create/update Daemonsets
 	for _, daemonSet := range r.targetStrategy.DaemonSets() {
-		err := r.syncDaemonSet(daemonSet)
+		var err error
+		if isHandlerCanaryRollout(r.kv, daemonSet) {
+			err = r.syncHandlerDaemonSetWithCanaries(daemonSet)
+		} else {
+			err = r.syncDaemonSet(daemonSet)
+		}
 		if err != nil {
 			return false, err
 		}
@@ -0,0 +1,179 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package components
+
+import (
+	"fmt"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+const (
+	CommonTemplateFedora  = "common-fedora"
+	CommonTemplateUbuntu  = "common-ubuntu"
+	CommonTemplateWindows = "common-windows"
+)
+
+// NewCommonTemplatePresets returns the presets of the common templates. Each preset selects
+// the VirtualMachineInstances labeled with its common template.
+func NewCommonTemplatePresets(namespace string) []*v1.VirtualMachineInstancePreset {
+	linux := &v1.GuestOS{OSFamily: v1.GuestOSFamilyLinux}
+	windows := &v1.GuestOS{OSFamily: v1.GuestOSFamilyWindows, OSVersion: "2019"}
+
+	enabled := true
+	disabled := false
+	spinlocks := uint32(8191)
+
+	return []*v1.VirtualMachineInstancePreset{
+		newCommonTemplatePreset(namespace, CommonTemplateFedora, v1.DomainSpec{
+			GuestOS: linux,
+		}),
+		newCommonTemplatePreset(namespace, CommonTemplateUbuntu, v1.DomainSpec{
+			GuestOS: linux,
+		}),
+		newCommonTemplatePreset(namespace, CommonTemplateWindows, v1.DomainSpec{
+			GuestOS: windows,
+			Features: &v1.Features{
+				ACPI: v1.FeatureState{Enabled: &enabled},
+				APIC: &v1.FeatureAPIC{Enabled: &enabled},
+				Hyperv: &v1.FeatureHyperv{
+					Relaxed:   &v1.FeatureState{Enabled: &enabled},
+					VAPIC:     &v1.FeatureState{Enabled: &enabled},
+					Spinlocks: &v1.FeatureSpinlocks{Enabled: &enabled, Retries: &spinlocks},
+				},
+			},
+			Clock: &v1.Clock{
+				ClockOffset: v1.ClockOffset{UTC: &v1.ClockOffsetUTC{}},
+				Timer: &v1.Timer{
+					HPET:   &v1.HPETTimer{Enabled: &disabled},
+					PIT:    &v1.PITTimer{TickPolicy: v1.PITTickPolicyDelay},
+					RTC:    &v1.RTCTimer{TickPolicy: v1.RTCTickPolicyCatchup},
+					Hyperv: &v1.HypervTimer{},
+				},
+			},
+		}),
+	}
+}
+
+func newCommonTemplatePreset(namespace string, name string, domain v1.DomainSpec) *v1.VirtualMachineInstancePreset {
+	return &v1.VirtualMachineInstancePreset{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1.GroupVersion.String(),
+			Kind:       "VirtualMachineInstancePreset",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			Labels: map[string]string{
+				v1.CommonTemplateLabel: name,
+			},
+		},
+		Spec: v1.VirtualMachineInstancePresetSpec{
+			Selector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					v1.CommonTemplateLabel: name,
+				},
+			},
+			Domain: &domain,
+		},
+	}
+}
+
+// NewCommonTemplateVirtualMachines returns a stopped example VirtualMachine for each common template.
+// The devices are picked by the guest OS hint of the presets. The Fedora example boots from the container
+// disk demo image of the given registry, the other examples expect their root disk to be provided as
+// PersistentVolumeClaim.
+func NewCommonTemplateVirtualMachines(namespace string, imageRegistry string, imageTag string) []*v1.VirtualMachine {
+	return []*v1.VirtualMachine{
+		newCommonTemplateVirtualMachine(namespace, "example-fedora", CommonTemplateFedora, "1Gi", v1.VolumeSource{
+			ContainerDisk: &v1.ContainerDiskSource{
+				Image: fmt.Sprintf("%s/fedora-cloud-container-disk-demo:%s", imageRegistry, imageTag),
+			},
+		}),
+		newCommonTemplateVirtualMachine(namespace, "example-ubuntu", CommonTemplateUbuntu, "1Gi", v1.VolumeSource{
+			PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+				PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "ubuntu-rootdisk"},
+			},
+		}),
+		newCommonTemplateVirtualMachine(namespace, "example-windows", CommonTemplateWindows, "4Gi", v1.VolumeSource{
+			PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+				PersistentVolumeClaimVolumeSource: k8sv1.PersistentVolumeClaimVolumeSource{ClaimName: "windows-rootdisk"},
+			},
+		}),
+	}
+}
+
+func newCommonTemplateVirtualMachine(namespace string, name string, template string, memory string, rootDisk v1.VolumeSource) *v1.VirtualMachine {
+	running := false
+	return &v1.VirtualMachine{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1.GroupVersion.String(),
+			Kind:       "VirtualMachine",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			Labels: map[string]string{
+				v1.CommonTemplateLabel: template,
+			},
+		},
+		Spec: v1.VirtualMachineSpec{
+			Running: &running,
+			Template: &v1.VirtualMachineInstanceTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						v1.CommonTemplateLabel: template,
+					},
+				},
+				Spec: v1.VirtualMachineInstanceSpec{
+					Domain: v1.DomainSpec{
+						Resources: v1.ResourceRequirements{
+							Requests: k8sv1.ResourceList{
+								k8sv1.ResourceMemory: resource.MustParse(memory),
+							},
+						},
+						Devices: v1.Devices{
+							Disks: []v1.Disk{
+								{
+									Name: "rootdisk",
+									DiskDevice: v1.DiskDevice{
+										Disk: &v1.DiskTarget{},
+									},
+								},
+							},
+							Interfaces: []v1.Interface{*v1.DefaultMasqueradeNetworkInterface()},
+						},
+					},
+					Networks: []v1.Network{*v1.DefaultPodNetwork()},
+					Volumes: []v1.Volume{
+						{
+							Name:         "rootdisk",
+							VolumeSource: rootDisk,
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -51,6 +51,11 @@ var (
 	VIRTUALMACHINEINSTANCEREPLICASET = "virtualmachineinstancereplicasets." + virtv1.VirtualMachineInstanceReplicaSetGroupVersionKind.Group
 	VIRTUALMACHINEINSTANCEMIGRATION  = "virtualmachineinstancemigrations." + virtv1.VirtualMachineInstanceMigrationGroupVersionKind.Group
 	KUBEVIRT                         = "kubevirts." + virtv1.KubeVirtGroupVersionKind.Group
+	VIRTUALMACHINESCHEDULINGHINTS    = "virtualmachineschedulinghints." + virtv1.VirtualMachineSchedulingHintsGroupVersionKind.Group
+	VIRTUALMACHINEIMAGEEXPORT        = "virtualmachineimageexports." + virtv1.VirtualMachineImageExportGroupVersionKind.Group
+	VIRTUALMACHINEBULKOPERATION      = "virtualmachinebulkoperations." + virtv1.VirtualMachineBulkOperationGroupVersionKind.Group
+	VALIDATIONPOLICY                 = "validationpolicies." + virtv1.ValidationPolicyGroupVersionKind.Group
+	MAINTENANCEWINDOW                = "maintenancewindows." + virtv1.MaintenanceWindowGroupVersionKind.Group
 	VIRTUALMACHINESNAPSHOT           = "virtualmachinesnapshots." + snapshotv1.SchemeGroupVersion.Group
 	VIRTUALMACHINESNAPSHOTCONTENT    = "virtualmachinesnapshotcontents." + snapshotv1.SchemeGroupVersion.Group
 	PreserveUnknownFieldsFalse       = false
@@ -237,6 +242,32 @@ func NewPresetCrd() (*extv1.CustomResourceDefinition, error) {
 	return crd, nil
 }
 
+func NewSchedulingHintsCrd() (*extv1.CustomResourceDefinition, error) {
+	crd := newBlankCrd()
+
+	crd.ObjectMeta.Name = VIRTUALMACHINESCHEDULINGHINTS
+	crd.Spec = extv1.CustomResourceDefinitionSpec{
+		Group:    virtv1.VirtualMachineSchedulingHintsGroupVersionKind.Group,
+		Versions: newCRDVersions(),
+		Scope:    "Namespaced",
+
+		Names: extv1.CustomResourceDefinitionNames{
+			Plural:     "virtualmachineschedulinghints",
+			Singular:   "virtualmachineschedulinghints",
+			Kind:       virtv1.VirtualMachineSchedulingHintsGroupVersionKind.Kind,
+			ShortNames: []string{"vmhints"},
+			Categories: []string{
+				"all",
+			},
+		},
+	}
+
+	if err := patchValidationForAllVersions(crd); err != nil {
+		return nil, err
+	}
+	return crd, nil
+}
+
 func NewReplicaSetCrd() (*extv1.CustomResourceDefinition, error) {
 	crd := newBlankCrd()
 	labelSelector := ".status.labelSelector"
@@ -315,6 +346,133 @@ func NewVirtualMachineInstanceMigrationCrd() (*extv1.CustomResourceDefinition, e
 	return crd, nil
 }
 
+func NewVirtualMachineImageExportCrd() (*extv1.CustomResourceDefinition, error) {
+	crd := newBlankCrd()
+
+	crd.ObjectMeta.Name = VIRTUALMACHINEIMAGEEXPORT
+	crd.Spec = extv1.CustomResourceDefinitionSpec{
+		Group:    virtv1.VirtualMachineImageExportGroupVersionKind.Group,
+		Versions: newCRDVersions(),
+		Scope:    "Namespaced",
+
+		Names: extv1.CustomResourceDefinitionNames{
+			Plural:     "virtualmachineimageexports",
+			Singular:   "virtualmachineimageexport",
+			Kind:       virtv1.VirtualMachineImageExportGroupVersionKind.Kind,
+			ShortNames: []string{"vmimageexport", "vmimageexports"},
+			Categories: []string{
+				"all",
+			},
+		},
+	}
+	err := addFieldsToAllVersions(crd, []extv1.CustomResourceColumnDefinition{
+		{Name: "Source", Type: "string", JSONPath: ".spec.source"},
+		{Name: "Image", Type: "string", JSONPath: ".spec.image"},
+		{Name: "Phase", Type: "string", JSONPath: ".status.phase"},
+		{Name: "Age", Type: "date", JSONPath: creationTimestampJSONPath},
+	}, &extv1.CustomResourceSubresources{
+		Status: &extv1.CustomResourceSubresourceStatus{},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = patchValidationForAllVersions(crd); err != nil {
+		return nil, err
+	}
+	return crd, nil
+}
+
+func NewVirtualMachineBulkOperationCrd() (*extv1.CustomResourceDefinition, error) {
+	crd := newBlankCrd()
+
+	crd.ObjectMeta.Name = VIRTUALMACHINEBULKOPERATION
+	crd.Spec = extv1.CustomResourceDefinitionSpec{
+		Group:    virtv1.VirtualMachineBulkOperationGroupVersionKind.Group,
+		Versions: newCRDVersions(),
+		Scope:    "Namespaced",
+
+		Names: extv1.CustomResourceDefinitionNames{
+			Plural:     "virtualmachinebulkoperations",
+			Singular:   "virtualmachinebulkoperation",
+			Kind:       virtv1.VirtualMachineBulkOperationGroupVersionKind.Kind,
+			ShortNames: []string{"vmbulkoperation", "vmbulkoperations"},
+			Categories: []string{
+				"all",
+			},
+		},
+	}
+	err := addFieldsToAllVersions(crd, []extv1.CustomResourceColumnDefinition{
+		{Name: "Action", Type: "string", JSONPath: ".spec.action"},
+		{Name: "Selector", Type: "string", JSONPath: ".spec.selector"},
+		{Name: "Phase", Type: "string", JSONPath: ".status.phase"},
+		{Name: "Age", Type: "date", JSONPath: creationTimestampJSONPath},
+	}, &extv1.CustomResourceSubresources{
+		Status: &extv1.CustomResourceSubresourceStatus{},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = patchValidationForAllVersions(crd); err != nil {
+		return nil, err
+	}
+	return crd, nil
+}
+
+func NewValidationPolicyCrd() (*extv1.CustomResourceDefinition, error) {
+	crd := newBlankCrd()
+
+	crd.ObjectMeta.Name = VALIDATIONPOLICY
+	crd.Spec = extv1.CustomResourceDefinitionSpec{
+		Group:    virtv1.ValidationPolicyGroupVersionKind.Group,
+		Versions: newCRDVersions(),
+		Scope:    "Cluster",
+
+		Names: extv1.CustomResourceDefinitionNames{
+			Plural:     "validationpolicies",
+			Singular:   "validationpolicy",
+			Kind:       virtv1.ValidationPolicyGroupVersionKind.Kind,
+			ShortNames: []string{"vpolicy", "vpolicies"},
+		},
+	}
+
+	if err := patchValidationForAllVersions(crd); err != nil {
+		return nil, err
+	}
+	return crd, nil
+}
+
+func NewMaintenanceWindowCrd() (*extv1.CustomResourceDefinition, error) {
+	crd := newBlankCrd()
+
+	crd.ObjectMeta.Name = MAINTENANCEWINDOW
+	crd.Spec = extv1.CustomResourceDefinitionSpec{
+		Group:    virtv1.MaintenanceWindowGroupVersionKind.Group,
+		Versions: newCRDVersions(),
+		Scope:    "Cluster",
+
+		Names: extv1.CustomResourceDefinitionNames{
+			Plural:     "maintenancewindows",
+			Singular:   "maintenancewindow",
+			Kind:       virtv1.MaintenanceWindowGroupVersionKind.Kind,
+			ShortNames: []string{"mw", "mws"},
+		},
+	}
+	err := addFieldsToAllVersions(crd, []extv1.CustomResourceColumnDefinition{
+		{Name: "Actions", Type: "string", JSONPath: ".spec.actions"},
+		{Name: "Age", Type: "date", JSONPath: creationTimestampJSONPath},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = patchValidationForAllVersions(crd); err != nil {
+		return nil, err
+	}
+	return crd, nil
+}
+
 // Used by manifest generation
 // If you change something here, you probably need to change the CSV manifest too,
 // see /manifests/release/kubevirt.VERSION.csv.yaml.in
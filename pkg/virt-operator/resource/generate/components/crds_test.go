@@ -25,6 +25,11 @@ var _ = Describe("CRDs", func() {
 		table.Entry("for KV", NewKubeVirtCrd),
 		table.Entry("for VMSNAPSHOT", NewVirtualMachineSnapshotCrd),
 		table.Entry("for VMSNAPSHOTCONTENT", NewVirtualMachineSnapshotContentCrd),
+		table.Entry("for VMSCHEDULINGHINTS", NewSchedulingHintsCrd),
+		table.Entry("for VMIMAGEEXPORT", NewVirtualMachineImageExportCrd),
+		table.Entry("for VALIDATIONPOLICY", NewValidationPolicyCrd),
+		table.Entry("for MAINTENANCEWINDOW", NewMaintenanceWindowCrd),
+		table.Entry("for VMBULKOPERATION", NewVirtualMachineBulkOperationCrd),
 	)
 
 	It("DataVolumeTemplates should have nullable a XPreserveUnknownFields on metadata", func() {
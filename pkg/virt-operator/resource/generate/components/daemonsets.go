@@ -212,6 +212,7 @@ func NewHandlerDaemonSet(namespace string, repository string, imagePrefix string
 		{"kubelet-pods-shortened", "/var/lib/kubelet/pods", "/pods", nil},
 		{"kubelet-pods", "/var/lib/kubelet/pods", "/var/lib/kubelet/pods", &bidi},
 		{"node-labeller", "/var/lib/kubevirt-node-labeller", "/var/lib/kubevirt-node-labeller", nil},
+		{"system-dbus", "/run/dbus", "/run/dbus", nil},
 	}
 
 	for _, volume := range volumes {
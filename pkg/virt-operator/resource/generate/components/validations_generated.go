@@ -510,9 +510,78 @@ var CRDsValidation map[string]string = map[string]string{
                   type: object
               type: object
           type: object
+        commonTemplates:
+          description: CommonTemplates deploys curated VirtualMachineInstancePresets
+            and example VirtualMachines for common operating systems to a namespace.
+            They are versioned with KubeVirt and removed once the common templates
+            are removed again.
+          properties:
+            namespace:
+              description: Namespace the common templates are deployed to, it has
+                to exist
+              type: string
+          required:
+          - namespace
+          type: object
         configuration:
           description: holds kubevirt configurations. same as the virt-configMap
           properties:
+            accountingConfiguration:
+              description: AccountingConfiguration attaches labels of VMIs to their
+                metrics and reports the resource usage of VMIs periodically, to charge
+                tenants back for it.
+              properties:
+                labels:
+                  description: Labels are the label keys of VMIs which are attached
+                    to all per-VMI metrics, as metric labels named accounting_ followed
+                    by the sanitized key, and to the usage reports. VMIs without one
+                    of the labels have an empty value for it.
+                  items:
+                    type: string
+                  type: array
+                  x-kubernetes-list-type: atomic
+                usageReport:
+                  description: UsageReport enables periodic reports of the vCPU, memory
+                    and storage usage of all VMIs.
+                  properties:
+                    interval:
+                      description: Interval is the period each usage report covers.
+                        Defaults to 1h.
+                      type: string
+                    webhookURL:
+                      description: WebhookURL is the URL the usage reports are posted
+                        to as JSON.
+                      type: string
+                  required:
+                  - webhookURL
+                  type: object
+              type: object
+            additionalGuestMemoryOverheadRatio:
+              description: AdditionalGuestMemoryOverheadRatio is multiplied with the
+                computed memory overhead of virt-launcher pods, to add a safety margin.
+                It must be a decimal number of at least 1.0. Defaults to 1.0.
+              type: string
+            admissionLimits:
+              description: AdmissionLimits are the size limits VMIs and VMs are validated
+                against on creation. They can be raised to allow for example large
+                inline cloud-init user data.
+              properties:
+                cloudInitNetworkDataMaxBytes:
+                  description: CloudInitNetworkDataMaxBytes is the largest inline
+                    cloud-init network data in bytes, larger network data has to be
+                    passed with a NetworkDataSecretRef. Defaults to 2048.
+                  type: integer
+                cloudInitUserDataMaxBytes:
+                  description: CloudInitUserDataMaxBytes is the largest inline cloud-init
+                    user data in bytes, larger user data has to be passed with a UserDataSecretRef.
+                    Defaults to 2048.
+                  type: integer
+                maxListLength:
+                  description: MaxListLength is the largest number of disks, volumes,
+                    interfaces, networks and access credentials a VMI can have. Defaults
+                    to 256.
+                  type: integer
+              type: object
             apiConfiguration:
               description: ReloadableComponentConfiguration holds all generic k8s
                 configuration options which can be reloaded by components without
@@ -544,6 +613,66 @@ var CRDsValidation map[string]string = map[string]string{
                       type: object
                   type: object
               type: object
+            auxiliaryThreadsCPURequests:
+              description: AuxiliaryThreadsCPURequests adds CPU requests for the threads
+                of VMIs which don't run vCPUs to their virt-launcher pods, so that
+                these threads don't take CPU time from the vCPUs. Nothing is added
+                if unset. VMIs with dedicated CPUs are not affected.
+              properties:
+                emulator:
+                  anyOf:
+                  - type: integer
+                  - type: string
+                  description: Emulator is requested once per VMI, for the emulator
+                    thread of QEMU.
+                  pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                  x-kubernetes-int-or-string: true
+                ioThread:
+                  anyOf:
+                  - type: integer
+                  - type: string
+                  description: IOThread is requested for each IO thread of a VMI.
+                  pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                  x-kubernetes-int-or-string: true
+                virtiofs:
+                  anyOf:
+                  - type: integer
+                  - type: string
+                  description: Virtiofs is requested for each filesystem of a VMI,
+                    for its virtiofsd process.
+                  pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                  x-kubernetes-int-or-string: true
+              type: object
+            containerDiskPolicy:
+              description: ContainerDiskPolicy restricts the images containerDisks
+                may use. VMIs and VMs whose containerDisks violate the policy are
+                rejected on creation. Nothing is restricted if unset.
+              properties:
+                allowedRegistries:
+                  description: AllowedRegistries are the registries, optionally followed
+                    by a repository path, which containerDisk images have to come
+                    from, for example "quay.io" or "quay.io/kubevirt". Images of Docker
+                    Hub are matched as "docker.io". All registries are allowed if
+                    empty.
+                  items:
+                    type: string
+                  type: array
+                  x-kubernetes-list-type: atomic
+                signatureVerification:
+                  description: SignatureVerification requires containerDisk images
+                    to carry a cosign signature of one of the given public keys. The
+                    images have to be referenced by digest, or be pinned to their
+                    digest with the Pin imageDigestPolicy.
+                  properties:
+                    publicKeysConfigMap:
+                      description: PublicKeysConfigMap is the name of a ConfigMap
+                        in the namespace of KubeVirt, whose entries hold PEM encoded
+                        ECDSA or RSA public keys.
+                      type: string
+                  required:
+                  - publicKeysConfigMap
+                  type: object
+              type: object
             controllerConfiguration:
               description: ReloadableComponentConfiguration holds all generic k8s
                 configuration options which can be reloaded by components without
@@ -637,6 +766,29 @@ var CRDsValidation map[string]string = map[string]string{
                 minimumReservePVCBytes:
                   format: int64
                   type: integer
+                namespacedFeatureGates:
+                  description: NamespacedFeatureGates enables feature gates only for
+                    the VMIs and VMs of the listed namespaces.
+                  items:
+                    description: NamespacedFeatureGate enables a feature gate for
+                      a set of namespaces
+                    properties:
+                      name:
+                        description: Name of the feature gate
+                        type: string
+                      namespaces:
+                        description: Namespaces in which VMIs and VMs are allowed
+                          to use the feature
+                        items:
+                          type: string
+                        type: array
+                        x-kubernetes-list-type: atomic
+                    required:
+                    - name
+                    - namespaces
+                    type: object
+                  type: array
+                  x-kubernetes-list-type: atomic
                 nodeSelectors:
                   additionalProperties:
                     type: string
@@ -688,6 +840,65 @@ var CRDsValidation map[string]string = map[string]string{
               description: PullPolicy describes a policy for if/when to pull a container
                 image
               type: string
+            imagePullSecrets:
+              description: ImagePullSecrets are added to all virt-launcher pods, to
+                pull the virt-launcher, containerDisk and kernel boot images. The
+                secrets must exist in the namespace of the VMI.
+              items:
+                description: LocalObjectReference contains enough information to let
+                  you locate the referenced object inside the same namespace.
+                properties:
+                  name:
+                    description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                      TODO: Add other useful fields. apiVersion, kind, uid?'
+                    type: string
+                type: object
+              type: array
+              x-kubernetes-list-type: atomic
+            imageRegistryMirrors:
+              description: ImageRegistryMirrors redirect the virt-launcher, containerDisk,
+                kernel boot and hook sidecar images to mirror registries. The first
+                mirror whose source matches an image is used.
+              items:
+                description: ImageRegistryMirror redirects images of a registry or
+                  repository to a mirror
+                properties:
+                  mirror:
+                    description: Mirror replaces the source in the image references,
+                      for example "registry.local:5000/kubevirt"
+                    type: string
+                  source:
+                    description: Source is the registry, optionally followed by a
+                      repository path, of the images to redirect, for example "quay.io"
+                      or "quay.io/kubevirt"
+                    type: string
+                required:
+                - mirror
+                - source
+                type: object
+              type: array
+              x-kubernetes-list-type: atomic
+            launcherPodMetadataPropagation:
+              description: LauncherPodMetadataPropagation restricts which labels and
+                annotations of VMIs, and of their launcher pod metadata, are propagated
+                to virt-launcher pods. If unset, all of them are propagated.
+              properties:
+                allowedAnnotations:
+                  description: AllowedAnnotations are the annotation keys which are
+                    propagated to virt-launcher pods. If empty, no annotations are
+                    propagated.
+                  items:
+                    type: string
+                  type: array
+                  x-kubernetes-list-type: atomic
+                allowedLabels:
+                  description: AllowedLabels are the label keys which are propagated
+                    to virt-launcher pods. If empty, no labels are propagated.
+                  items:
+                    type: string
+                  type: array
+                  x-kubernetes-list-type: atomic
+              type: object
             machineType:
               type: string
             mediatedDevicesConfiguration:
@@ -721,6 +932,11 @@ var CRDsValidation map[string]string = map[string]string{
                   type: integer
                 disableTLS:
                   type: boolean
+                hostPassthroughPolicy:
+                  description: HostPassthroughPolicy decides whether and where VMIs
+                    with the host-passthrough CPU mode are migrated. One of Allow,
+                    CompatibleNodes or Deny. Defaults to Allow.
+                  type: string
                 nodeDrainTaintKey:
                   type: string
                 parallelMigrationsPerCluster:
@@ -729,6 +945,18 @@ var CRDsValidation map[string]string = map[string]string{
                 parallelOutboundMigrationsPerNode:
                   format: int32
                   type: integer
+                preSwitchoverDrainSeconds:
+                  description: PreSwitchoverDrainSeconds drains the connections to
+                    a VMI before the switchover of its migration if positive. Once
+                    the migration target pod runs it joins the endpoints of Services,
+                    and the virt-launcher pod of the source is reported as not ready,
+                    which removes it from them. The VMI is only handed off to the
+                    target after the connections drained for PreSwitchoverDrainSeconds,
+                    the source pod is reported as ready again if the migration fails.
+                    Only applies to VMIs started while it is set. Defaults to 0, which
+                    disables draining.
+                  format: int64
+                  type: integer
                 progressTimeout:
                   format: int64
                   type: integer
@@ -742,11 +970,40 @@ var CRDsValidation map[string]string = map[string]string{
               properties:
                 defaultNetworkInterface:
                   type: string
+                nodeBandwidth:
+                  anyOf:
+                  - type: integer
+                  - type: string
+                  description: NodeBandwidth is the network bandwidth of every node,
+                    in bits per second, which VMIs can reserve with the bandwidth
+                    of their interfaces. Nodes without a bandwidth don't run VMIs
+                    reserving any.
+                  pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                  x-kubernetes-int-or-string: true
+                nodeBandwidthOverrides:
+                  additionalProperties:
+                    anyOf:
+                    - type: integer
+                    - type: string
+                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                    x-kubernetes-int-or-string: true
+                  description: NodeBandwidthOverrides overrides the NodeBandwidth
+                    of individual nodes, keyed by the node name.
+                  type: object
                 permitBridgeInterfaceOnPodNetwork:
                   type: boolean
                 permitSlirpInterface:
                   type: boolean
               type: object
+            nodeShutdownGracePeriodSeconds:
+              description: NodeShutdownGracePeriodSeconds is how long virt-handler
+                delays the shutdown or reboot of a node, to live migrate its VMIs
+                or to shut them down gracefully. virt-handler takes a systemd-logind
+                inhibitor lock for that, the delay is capped by InhibitDelayMaxSec
+                of systemd-logind. The shutdown of the node is not delayed if unset
+                or 0.
+              format: int64
+              type: integer
             obsoleteCPUModels:
               additionalProperties:
                 type: boolean
@@ -801,6 +1058,26 @@ var CRDsValidation map[string]string = map[string]string{
                   type: array
                   x-kubernetes-list-type: atomic
               type: object
+            qemuOptionsAllowlist:
+              description: QEMUOptionsAllowlist holds the QEMU -machine and -global
+                options VMIs may set. VMIs setting other options are rejected. No
+                options can be set if unset.
+              properties:
+                globals:
+                  description: Globals are the driver.property names of the -global
+                    options VMIs may set.
+                  items:
+                    type: string
+                  type: array
+                  x-kubernetes-list-type: atomic
+                machineOptions:
+                  description: MachineOptions are the names of the -machine options
+                    VMIs may set.
+                  items:
+                    type: string
+                  type: array
+                  x-kubernetes-list-type: atomic
+              type: object
             selinuxLauncherType:
               type: string
             smbios:
@@ -895,6 +1172,30 @@ var CRDsValidation map[string]string = map[string]string{
               type: array
               x-kubernetes-list-type: atomic
           type: object
+        handlerUpdateStrategy:
+          description: HandlerUpdateStrategy defines how virt-handler is rolled out
+            on updates
+          properties:
+            canary:
+              description: Canary rolls out virt-handler to a set of canary nodes
+                first. The rollout to the remaining nodes only proceeds once virt-handler
+                is healthy on all canary nodes. If the canaries do not become healthy
+                in time, the rollout is paused. A paused rollout can be resumed by
+                removing the canary strategy.
+              properties:
+                nodeSelector:
+                  additionalProperties:
+                    type: string
+                  description: NodeSelector selects the canary nodes among the nodes
+                    running virt-handler. If empty, a single node is used as canary.
+                  type: object
+                validationTimeout:
+                  description: "ValidationTimeout is the time the updated virt-handler
+                    pods on the canary nodes have to become healthy before the rollout
+                    is paused. \n Defaults to 10 minutes"
+                  type: string
+              type: object
+          type: object
         imagePullPolicy:
           description: The ImagePullPolicy to use.
           type: string
@@ -2285,6 +2586,10 @@ var CRDsValidation map[string]string = map[string]string{
       description: KubeVirtStatus represents information pertaining to a KubeVirt
         deployment.
       properties:
+        commonTemplatesNamespace:
+          description: CommonTemplatesNamespace is the namespace the common templates
+            are deployed to
+          type: string
         conditions:
           items:
             description: KubeVirtCondition represents a condition of a KubeVirt deployment
@@ -2344,6 +2649,31 @@ var CRDsValidation map[string]string = map[string]string{
             type: object
           type: array
           x-kubernetes-list-type: atomic
+        handlerRollout:
+          description: HandlerRollout reports the progress of a virt-handler canary
+            rollout
+          properties:
+            canaryNodes:
+              description: CanaryNodes are the nodes virt-handler is rolled out to
+                first
+              items:
+                type: string
+              type: array
+              x-kubernetes-list-type: set
+            message:
+              type: string
+            phase:
+              type: string
+            startTime:
+              description: StartTime is the time the canary rollout started
+              format: date-time
+              nullable: true
+              type: string
+            targetDeploymentID:
+              description: TargetDeploymentID is the deployment the rollout belongs
+                to
+              type: string
+          type: object
         observedDeploymentConfig:
           type: string
         observedDeploymentID:
@@ -2368,16 +2698,23 @@ var CRDsValidation map[string]string = map[string]string{
           type: string
         targetKubeVirtVersion:
           type: string
+        unappliedConfiguration:
+          description: UnappliedConfiguration lists the configuration settings which
+            only take effect after a restart of the KubeVirt components, and which
+            are not rolled out yet
+          items:
+            type: string
+          type: array
+          x-kubernetes-list-type: set
       type: object
   required:
   - spec
   type: object
 `,
-	"virtualmachine": `openAPIV3Schema:
-  description: VirtualMachine handles the VirtualMachines that are not running or
-    are in a stopped state The VirtualMachine contains the template to create the
-    VirtualMachineInstance. It also mirrors the running state of the created VirtualMachineInstance
-    in its status.
+	"maintenancewindow": `openAPIV3Schema:
+  description: MaintenanceWindow restricts automated actions which disrupt the selected
+    VirtualMachineInstances to recurring periods of time. VirtualMachineInstances
+    which are not selected by any window are not restricted.
   properties:
     apiVersion:
       description: 'APIVersion defines the versioned schema of this representation
@@ -2392,93 +2729,260 @@ var CRDsValidation map[string]string = map[string]string{
     metadata:
       type: object
     spec:
-      description: Spec contains the specification of VirtualMachineInstance created
+      description: Spec selects the restricted VirtualMachineInstances and contains
+        the periods in which the actions may run.
       properties:
-        dataVolumeTemplates:
-          description: dataVolumeTemplates is a list of dataVolumes that the VirtualMachineInstance
-            template can reference. DataVolumes in this list are dynamically created
-            for the VirtualMachine and are tied to the VirtualMachine's life-cycle.
+        actions:
+          description: Actions lists the automated actions which only run during the
+            periods of the window. All actions are restricted if empty.
           items:
-            nullable: true
+            type: string
+          type: array
+          x-kubernetes-list-type: set
+        namespaces:
+          description: Namespaces lists the namespaces of the selected VirtualMachineInstances.
+            All namespaces are selected if empty.
+          items:
+            type: string
+          type: array
+          x-kubernetes-list-type: set
+        periods:
+          description: Periods lists the recurring periods in which the actions may
+            run.
+          items:
+            description: MaintenancePeriod is a period of time which recurs on days
+              of the week. All times are in UTC.
             properties:
-              apiVersion:
-                description: 'APIVersion defines the versioned schema of this representation
-                  of an object. Servers should convert recognized schemas to the latest
-                  internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+              days:
+                description: Days lists the days of the week, like Saturday, the period
+                  starts on. The period starts every day if empty.
+                items:
+                  type: string
+                type: array
+                x-kubernetes-list-type: set
+              duration:
+                description: Duration is the length of the period, at most a week.
                 type: string
-              kind:
-                description: 'Kind is a string value representing the REST resource
-                  this object represents. Servers may infer this from the endpoint
-                  the client submits requests to. Cannot be updated. In CamelCase.
-                  More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+              start:
+                description: Start is the time of day, formatted as HH:MM, the period
+                  starts at.
                 type: string
-              metadata:
-                nullable: true
-                type: object
-                x-kubernetes-preserve-unknown-fields: true
-              spec:
-                description: DataVolumeSpec contains the DataVolume specification.
+            required:
+            - duration
+            - start
+            type: object
+          type: array
+          x-kubernetes-list-type: atomic
+        selector:
+          description: Selector selects the VirtualMachineInstances by their labels.
+            All VirtualMachineInstances in the namespaces are selected if not set.
+          properties:
+            matchExpressions:
+              description: matchExpressions is a list of label selector requirements.
+                The requirements are ANDed.
+              items:
+                description: A label selector requirement is a selector that contains
+                  values, a key, and an operator that relates the key and values.
                 properties:
-                  checkpoints:
-                    description: Checkpoints is a list of DataVolumeCheckpoints, representing
-                      stages in a multistage import.
-                    items:
-                      description: DataVolumeCheckpoint defines a stage in a warm
-                        migration.
-                      properties:
-                        current:
-                          description: Current is the identifier of the snapshot created
-                            for this checkpoint.
-                          type: string
-                        previous:
-                          description: Previous is the identifier of the snapshot
-                            from the previous checkpoint.
-                          type: string
-                      required:
-                      - current
-                      - previous
-                      type: object
-                    type: array
-                  contentType:
-                    description: 'DataVolumeContentType options: "kubevirt", "archive"'
-                    enum:
-                    - kubevirt
-                    - archive
+                  key:
+                    description: key is the label key that the selector applies to.
                     type: string
-                  finalCheckpoint:
-                    description: FinalCheckpoint indicates whether the current DataVolumeCheckpoint
-                      is the final checkpoint.
-                    type: boolean
-                  preallocation:
-                    description: Preallocation controls whether storage for DataVolumes
-                      should be allocated in advance.
-                    type: boolean
-                  priorityClassName:
-                    description: PriorityClassName for Importer, Cloner and Uploader
-                      pod
+                  operator:
+                    description: operator represents a key's relationship to a set
+                      of values. Valid operators are In, NotIn, Exists and DoesNotExist.
                     type: string
-                  pvc:
-                    description: PVC is the PVC specification
-                    properties:
-                      accessModes:
-                        description: 'AccessModes contains the desired access modes
-                          the volume should have. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#access-modes-1'
-                        items:
-                          type: string
-                        type: array
-                      dataSource:
-                        description: 'This field can be used to specify either: *
-                          An existing VolumeSnapshot object (snapshot.storage.k8s.io/VolumeSnapshot)
-                          * An existing PVC (PersistentVolumeClaim) * An existing
-                          custom resource that implements data population (Alpha)
-                          In order to use custom resource types that implement data
-                          population, the AnyVolumeDataSource feature gate must be
-                          enabled. If the provisioner or an external controller can
-                          support the specified data source, it will create a new
-                          volume based on the contents of the specified data source.'
-                        properties:
-                          apiGroup:
-                            description: APIGroup is the group for the resource being
+                  values:
+                    description: values is an array of string values. If the operator
+                      is In or NotIn, the values array must be non-empty. If the operator
+                      is Exists or DoesNotExist, the values array must be empty. This
+                      array is replaced during a strategic merge patch.
+                    items:
+                      type: string
+                    type: array
+                required:
+                - key
+                - operator
+                type: object
+              type: array
+            matchLabels:
+              additionalProperties:
+                type: string
+              description: matchLabels is a map of {key,value} pairs. A single {key,value}
+                in the matchLabels map is equivalent to an element of matchExpressions,
+                whose key field is "key", the operator is "In", and the values array
+                contains only "value". The requirements are ANDed.
+              type: object
+          type: object
+      required:
+      - periods
+      type: object
+  required:
+  - spec
+  type: object
+`,
+	"validationpolicy": `openAPIV3Schema:
+  description: ValidationPolicy declares additional constraints on VirtualMachineInstances
+    which the validating webhook enforces after the built-in checks. Policies are
+    cluster scoped, a VirtualMachineInstance has to satisfy all of them.
+  properties:
+    apiVersion:
+      description: 'APIVersion defines the versioned schema of this representation
+        of an object. Servers should convert recognized schemas to the latest internal
+        value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+      type: string
+    kind:
+      description: 'Kind is a string value representing the REST resource this object
+        represents. Servers may infer this from the endpoint the client submits requests
+        to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+      type: string
+    metadata:
+      type: object
+    spec:
+      description: Spec contains the constraints of the policy.
+      properties:
+        allowedContainerDiskRegistries:
+          description: AllowedContainerDiskRegistries lists the registries, optionally
+            followed by a repository path, containerDisk images may be pulled from.
+            Any registry is allowed if empty.
+          items:
+            type: string
+          type: array
+          x-kubernetes-list-type: set
+        forbiddenDiskBuses:
+          description: ForbiddenDiskBuses lists the buses which disks, LUNs and CD-ROMs
+            must not use.
+          items:
+            type: string
+          type: array
+          x-kubernetes-list-type: set
+        maxCPUs:
+          description: MaxCPUs is the maximum number of vCPUs, counted as sockets*cores*threads,
+            of a VirtualMachineInstance.
+          format: int32
+          type: integer
+        requiredLabels:
+          description: RequiredLabels lists the label keys every VirtualMachineInstance
+            must have.
+          items:
+            type: string
+          type: array
+          x-kubernetes-list-type: set
+      type: object
+  required:
+  - spec
+  type: object
+`,
+	"virtualmachine": `openAPIV3Schema:
+  description: VirtualMachine handles the VirtualMachines that are not running or
+    are in a stopped state The VirtualMachine contains the template to create the
+    VirtualMachineInstance. It also mirrors the running state of the created VirtualMachineInstance
+    in its status.
+  properties:
+    apiVersion:
+      description: 'APIVersion defines the versioned schema of this representation
+        of an object. Servers should convert recognized schemas to the latest internal
+        value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+      type: string
+    kind:
+      description: 'Kind is a string value representing the REST resource this object
+        represents. Servers may infer this from the endpoint the client submits requests
+        to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+      type: string
+    metadata:
+      type: object
+    spec:
+      description: Spec contains the specification of VirtualMachineInstance created
+      properties:
+        changeApplyPolicy:
+          description: ChangeApplyPolicy defines how changes of the template are applied
+            to the running VirtualMachineInstance. Defaults to Manual, which only
+            reports the pending changes in the status.
+          type: string
+        dataVolumeTemplates:
+          description: dataVolumeTemplates is a list of dataVolumes that the VirtualMachineInstance
+            template can reference. DataVolumes in this list are dynamically created
+            for the VirtualMachine and are tied to the VirtualMachine's life-cycle.
+          items:
+            nullable: true
+            properties:
+              apiVersion:
+                description: 'APIVersion defines the versioned schema of this representation
+                  of an object. Servers should convert recognized schemas to the latest
+                  internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+                type: string
+              kind:
+                description: 'Kind is a string value representing the REST resource
+                  this object represents. Servers may infer this from the endpoint
+                  the client submits requests to. Cannot be updated. In CamelCase.
+                  More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+                type: string
+              metadata:
+                nullable: true
+                type: object
+                x-kubernetes-preserve-unknown-fields: true
+              spec:
+                description: DataVolumeSpec contains the DataVolume specification.
+                properties:
+                  checkpoints:
+                    description: Checkpoints is a list of DataVolumeCheckpoints, representing
+                      stages in a multistage import.
+                    items:
+                      description: DataVolumeCheckpoint defines a stage in a warm
+                        migration.
+                      properties:
+                        current:
+                          description: Current is the identifier of the snapshot created
+                            for this checkpoint.
+                          type: string
+                        previous:
+                          description: Previous is the identifier of the snapshot
+                            from the previous checkpoint.
+                          type: string
+                      required:
+                      - current
+                      - previous
+                      type: object
+                    type: array
+                  contentType:
+                    description: 'DataVolumeContentType options: "kubevirt", "archive"'
+                    enum:
+                    - kubevirt
+                    - archive
+                    type: string
+                  finalCheckpoint:
+                    description: FinalCheckpoint indicates whether the current DataVolumeCheckpoint
+                      is the final checkpoint.
+                    type: boolean
+                  preallocation:
+                    description: Preallocation controls whether storage for DataVolumes
+                      should be allocated in advance.
+                    type: boolean
+                  priorityClassName:
+                    description: PriorityClassName for Importer, Cloner and Uploader
+                      pod
+                    type: string
+                  pvc:
+                    description: PVC is the PVC specification
+                    properties:
+                      accessModes:
+                        description: 'AccessModes contains the desired access modes
+                          the volume should have. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#access-modes-1'
+                        items:
+                          type: string
+                        type: array
+                      dataSource:
+                        description: 'This field can be used to specify either: *
+                          An existing VolumeSnapshot object (snapshot.storage.k8s.io/VolumeSnapshot)
+                          * An existing PVC (PersistentVolumeClaim) * An existing
+                          custom resource that implements data population (Alpha)
+                          In order to use custom resource types that implement data
+                          population, the AnyVolumeDataSource feature gate must be
+                          enabled. If the provisioner or an external controller can
+                          support the specified data source, it will create a new
+                          volume based on the contents of the specified data source.'
+                        properties:
+                          apiGroup:
+                            description: APIGroup is the group for the resource being
                               referenced. If APIGroup is not specified, the specified
                               Kind must be in the core API group. For any other third-party
                               types, APIGroup is required.
@@ -3844,10 +4348,43 @@ var CRDsValidation map[string]string = map[string]string{
                           description: Whether or not to enable virtio multi-queue
                             for block devices. Defaults to false.
                           type: boolean
+                        channels:
+                          description: Channels are additional virtio-serial channels
+                            of the vmi. Each channel is backed by a unix socket in
+                            the virt-launcher pod, which is shared with the sidecars
+                            of the vmi.
+                          items:
+                            description: SerialChannel is a virtio-serial channel
+                              between the guest and the virt-launcher pod. The socket
+                              of the channel is created at <channel socket directory>/<name>.sock,
+                              where sidecars can connect to it to exchange data with
+                              the guest without networking, e.g. to collect logs.
+                            properties:
+                              name:
+                                description: Name of the channel, unique within the
+                                  vmi. It names the socket of the channel.
+                                type: string
+                              target:
+                                description: Target is the name of the virtio-serial
+                                  port in the guest, e.g. org.example.log.0.
+                                type: string
+                            required:
+                            - name
+                            - target
+                            type: object
+                          type: array
+                          x-kubernetes-list-type: atomic
                         clientPassthrough:
                           description: To configure and access client devices such
                             as redirecting USB
                           type: object
+                        detachGPUsForMigration:
+                          description: Whether to detach the GPUs for a live migration.
+                            The GPUs are hot-unplugged and the guest is paused until
+                            the GPUs of the target are hot-plugged. The guest has
+                            to support the hot-unplug of its GPUs. Without it, VMIs
+                            with GPUs are not live migratable.
+                          type: boolean
                         disableHotplug:
                           description: DisableHotplug disabled the ability to hotplug
                             disks.
@@ -3919,6 +4456,18 @@ var CRDsValidation map[string]string = map[string]string{
                                   should have an exclusive IO Thread. Enabling this
                                   implies useIOThreads = true. Defaults to false.
                                 type: boolean
+                              detectZeroes:
+                                description: 'DetectZeroes specifies whether QEMU
+                                  detects writes of zeroes and optimizes them. Supported
+                                  values are: off, on, unmap. Defaults to unmap on
+                                  thin-provisioned block volumes with discard enabled.'
+                                type: string
+                              discard:
+                                description: 'Discard specifies whether discard requests
+                                  from the guest are passed to the storage. Supported
+                                  values are: unmap, ignore. Defaults to unmap unless
+                                  the volume is preallocated.'
+                                type: string
                               disk:
                                 description: Attach a volume as a disk to the vmi.
                                 properties:
@@ -3972,6 +4521,15 @@ var CRDsValidation map[string]string = map[string]string{
                                 description: Serial provides the ability to specify
                                   a serial number for the disk device.
                                 type: string
+                              shareable:
+                                description: Shareable indicates whether the disk
+                                  can be shared between multiple VMIs, e.g. for clustered
+                                  file systems. A shareable disk must be backed by
+                                  a block PersistentVolumeClaim with the ReadWriteMany
+                                  access mode, it is attached without a host side
+                                  cache and without an ephemeral overlay. Defaults
+                                  to false.
+                                type: boolean
                               tag:
                                 description: If specified, disk address and its tag
                                   will be provided to the guest via config drive metadata
@@ -4007,6 +4565,20 @@ var CRDsValidation map[string]string = map[string]string{
                                 description: Name of the GPU device as exposed by
                                   a device plugin
                                 type: string
+                              pciOrder:
+                                description: PCIOrder is an integer value > 0, used
+                                  to determine the order of the guest PCI addresses
+                                  of the GPUs and host devices. Lower values get lower
+                                  addresses, devices without a PCI order are placed
+                                  after the ordered ones. Each GPU or host device
+                                  that has a PCI order must have a unique value.
+                                type: integer
+                              role:
+                                description: Role labels the device for the guest,
+                                  e.g. render or compute. It is reported together
+                                  with the guest PCI address of the device in the
+                                  VMI status.
+                                type: string
                             required:
                             - deviceName
                             - name
@@ -4023,6 +4595,20 @@ var CRDsValidation map[string]string = map[string]string{
                                 type: string
                               name:
                                 type: string
+                              pciOrder:
+                                description: PCIOrder is an integer value > 0, used
+                                  to determine the order of the guest PCI addresses
+                                  of the GPUs and host devices. Lower values get lower
+                                  addresses, devices without a PCI order are placed
+                                  after the ordered ones. Each GPU or host device
+                                  that has a PCI order must have a unique value.
+                                type: integer
+                              role:
+                                description: Role labels the device for the guest,
+                                  e.g. render or compute. It is reported together
+                                  with the guest PCI address of the device in the
+                                  VMI status.
+                                type: string
                             required:
                             - deviceName
                             - name
@@ -4054,6 +4640,43 @@ var CRDsValidation map[string]string = map[string]string{
                             are added to the vmi.
                           items:
                             properties:
+                              bandwidth:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: Bandwidth is the throughput the interface
+                                  is expected to need, in bits per second, for example
+                                  10G. The VMI reserves it from the node bandwidth
+                                  of the cluster network configuration, nodes without
+                                  enough unreserved bandwidth are not considered for
+                                  scheduling.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                              bandwidthLimit:
+                                description: BandwidthLimit shapes the traffic of
+                                  the interface. It can be changed while the VMI is
+                                  running, virt-launcher applies the new limits to
+                                  the running domain. Not supported on interfaces
+                                  with the SR-IOV, slirp or vhostuser binding.
+                                properties:
+                                  inbound:
+                                    anyOf:
+                                    - type: integer
+                                    - type: string
+                                    description: Inbound is the throughput the guest
+                                      can receive, in bits per second, for example
+                                      100M.
+                                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                    x-kubernetes-int-or-string: true
+                                  outbound:
+                                    anyOf:
+                                    - type: integer
+                                    - type: string
+                                    description: Outbound is the throughput the guest
+                                      can send, in bits per second, for example 100M.
+                                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                    x-kubernetes-int-or-string: true
+                                type: object
                               bootOrder:
                                 description: BootOrder is an integer value > 0, used
                                   to determine ordering of boot devices. Lower values
@@ -4071,6 +4694,13 @@ var CRDsValidation map[string]string = map[string]string{
                                     description: If specified will pass option 67
                                       to interface's DHCP server
                                     type: string
+                                  leaseTime:
+                                    description: If specified will pass the lease
+                                      time to the VM via DHCP option 051. Defaults
+                                      to an hourly renewed lease if the address of
+                                      the interface can change, to an infinite lease
+                                      otherwise.
+                                    type: string
                                   ntpServers:
                                     description: If specified will pass the configured
                                       NTP server to the VM via DHCP option 042.
@@ -4180,6 +4810,16 @@ var CRDsValidation map[string]string = map[string]string{
                           description: Whether to have random number generator from
                             host
                           type: object
+                        scsiController:
+                          description: SCSIController configures the controller of
+                            the disks with the scsi bus. If blockMultiQueue is enabled,
+                            a virtio-scsi controller gets one queue per vCPU.
+                          properties:
+                            model:
+                              description: 'Model of the SCSI controller. One of:
+                                virtio-scsi, lsilogic. Defaults to virtio-scsi.'
+                              type: string
+                          type: object
                         useVirtioTransitional:
                           description: Fall back to legacy virtio 0.9 support if virtio
                             bus is selected on devices. This is helpful for old machines
@@ -4500,6 +5140,21 @@ var CRDsValidation map[string]string = map[string]string{
                             a random generated uid.
                           type: string
                       type: object
+                    guestOS:
+                      description: GuestOS describes the operating system of the guest.
+                        It is a hint used to pick the defaults of the disk bus, the
+                        network interface model, the tablet and the Hyper-V features,
+                        if they are not set.
+                      properties:
+                        osFamily:
+                          description: 'OSFamily of the guest operating system. One
+                            of: linux, windows'
+                          type: string
+                        osVersion:
+                          description: OSVersion of the guest operating system, e.g.
+                            2008 or 10 for windows.
+                          type: string
+                      type: object
                     ioThreadsPolicy:
                       description: 'Controls whether or not disks will share IOThreads.
                         Omitting IOThreadsPolicy disables use of IOThreads. One of:
@@ -4508,6 +5163,45 @@ var CRDsValidation map[string]string = map[string]string{
                     machine:
                       description: Machine type.
                       properties:
+                        globals:
+                          description: Globals are QEMU -global options, which set
+                            a property of all devices of a driver. Their names have
+                            the form driver.property. Only the globals allowed in
+                            the QEMUOptionsAllowlist of the KubeVirt configuration
+                            are accepted.
+                          items:
+                            properties:
+                              name:
+                                description: Name of the option.
+                                type: string
+                              value:
+                                description: Value the option is set to.
+                                type: string
+                            required:
+                            - name
+                            - value
+                            type: object
+                          type: array
+                          x-kubernetes-list-type: atomic
+                        options:
+                          description: Options are additional QEMU -machine options,
+                            for hardware quirks of particular guests. Only the options
+                            allowed in the QEMUOptionsAllowlist of the KubeVirt configuration
+                            are accepted.
+                          items:
+                            properties:
+                              name:
+                                description: Name of the option.
+                                type: string
+                              value:
+                                description: Value the option is set to.
+                                type: string
+                            required:
+                            - name
+                            - value
+                            type: object
+                          type: array
+                          x-kubernetes-list-type: atomic
                         type:
                           description: QEMU machine type is the actual chipset of
                             the VirtualMachineInstance.
@@ -4582,6 +5276,23 @@ var CRDsValidation map[string]string = map[string]string{
                     the hostname will be set to the name of the vmi, if dhcp or cloud-init
                     is configured properly.
                   type: string
+                launcherPodMetadata:
+                  description: LauncherPodMetadata contains additional labels and
+                    annotations for the virt-launcher pod.
+                  properties:
+                    annotations:
+                      additionalProperties:
+                        type: string
+                      description: Annotations which are added to the virt-launcher
+                        pod. Annotations in the kubevirt.io domain are reserved.
+                      type: object
+                    labels:
+                      additionalProperties:
+                        type: string
+                      description: Labels which are added to the virt-launcher pod.
+                        Labels in the kubevirt.io domain are reserved.
+                      type: object
+                  type: object
                 livenessProbe:
                   description: 'Periodic probe of VirtualMachineInstance liveness.
                     VirtualmachineInstances will be stopped if the probe fails. Cannot
@@ -4886,11 +5597,119 @@ var CRDsValidation map[string]string = map[string]string{
                       format: int32
                       type: integer
                   type: object
+                runtimeClassName:
+                  description: If specified, the virt-launcher pod will be run with
+                    the referenced RuntimeClass. If not specified, the default runtime
+                    class of the KubeVirt configuration is used.
+                  type: string
                 schedulerName:
                   description: If specified, the VMI will be dispatched by specified
                     scheduler. If not specified, the VMI will be dispatched by default
                     scheduler.
                   type: string
+                schedulingReadinessGates:
+                  description: SchedulingReadinessGates lists conditions which have
+                    to be set to True on the VirtualMachineInstance, usually by third-party
+                    controllers, before the virt-launcher pod is created.
+                  items:
+                    description: SchedulingReadinessGate refers to a condition on
+                      the VirtualMachineInstance which gates the creation of its pod.
+                    properties:
+                      conditionType:
+                        description: ConditionType refers to a condition in the VirtualMachineInstance's
+                          condition list with matching type.
+                        type: string
+                    required:
+                    - conditionType
+                    type: object
+                  type: array
+                  x-kubernetes-list-type: atomic
+                sidecars:
+                  description: Sidecars are user containers which are added to the
+                    virt-launcher pod. They share the lifecycle of the VirtualMachineInstance.
+                  items:
+                    description: Sidecar is a user container which runs next to the
+                      virtual machine in the virt-launcher pod, e.g. a log shipper
+                      or a license daemon.
+                    properties:
+                      args:
+                        description: Arguments to the entrypoint. The CMD of the image
+                          is used if this is not provided.
+                        items:
+                          type: string
+                        type: array
+                      command:
+                        description: Entrypoint array. The ENTRYPOINT of the image
+                          is used if this is not provided.
+                        items:
+                          type: string
+                        type: array
+                      image:
+                        description: Image of the sidecar container.
+                        type: string
+                      imagePullPolicy:
+                        description: Image pull policy of the sidecar container.
+                        type: string
+                      name:
+                        description: Name of the sidecar container, unique within
+                          the VirtualMachineInstance.
+                        type: string
+                      resources:
+                        description: Resources are the cpu and memory requests and
+                          limits of the sidecar container. They are accounted for
+                          on top of the resources of the virtual machine.
+                        properties:
+                          limits:
+                            additionalProperties:
+                              anyOf:
+                              - type: integer
+                              - type: string
+                              pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                              x-kubernetes-int-or-string: true
+                            description: 'Limits describes the maximum amount of compute
+                              resources allowed. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/'
+                            type: object
+                          requests:
+                            additionalProperties:
+                              anyOf:
+                              - type: integer
+                              - type: string
+                              pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                              x-kubernetes-int-or-string: true
+                            description: 'Requests describes the minimum amount of
+                              compute resources required. If Requests is omitted for
+                              a container, it defaults to Limits if that is explicitly
+                              specified, otherwise to an implementation-defined value.
+                              More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/'
+                            type: object
+                        type: object
+                      volumeMounts:
+                        description: VolumeMounts share volumes of the VirtualMachineInstance
+                          read-only with the sidecar container.
+                        items:
+                          description: SidecarVolumeMount mounts a volume of the VirtualMachineInstance
+                            into a sidecar container.
+                          properties:
+                            mountPath:
+                              description: MountPath is the path within the sidecar
+                                container at which the volume is mounted.
+                              type: string
+                            name:
+                              description: Name of the VirtualMachineInstance volume.
+                              type: string
+                          required:
+                          - mountPath
+                          - name
+                          type: object
+                        type: array
+                    required:
+                    - image
+                    - name
+                    type: object
+                  type: array
+                  x-kubernetes-list-map-keys:
+                  - name
+                  x-kubernetes-list-type: map
                 startStrategy:
                   description: StartStrategy can be set to "Paused" if Virtual Machine
                     should be started in paused state.
@@ -4948,6 +5767,113 @@ var CRDsValidation map[string]string = map[string]string{
                         type: string
                     type: object
                   type: array
+                topologySpreadConstraints:
+                  description: TopologySpreadConstraints describes how the virt-launcher
+                    pod ought to spread across topology domains. All topologySpreadConstraints
+                    are ANDed.
+                  items:
+                    description: TopologySpreadConstraint specifies how to spread
+                      matching pods among the given topology.
+                    properties:
+                      labelSelector:
+                        description: LabelSelector is used to find matching pods.
+                          Pods that match this label selector are counted to determine
+                          the number of pods in their corresponding topology domain.
+                        properties:
+                          matchExpressions:
+                            description: matchExpressions is a list of label selector
+                              requirements. The requirements are ANDed.
+                            items:
+                              description: A label selector requirement is a selector
+                                that contains values, a key, and an operator that
+                                relates the key and values.
+                              properties:
+                                key:
+                                  description: key is the label key that the selector
+                                    applies to.
+                                  type: string
+                                operator:
+                                  description: operator represents a key's relationship
+                                    to a set of values. Valid operators are In, NotIn,
+                                    Exists and DoesNotExist.
+                                  type: string
+                                values:
+                                  description: values is an array of string values.
+                                    If the operator is In or NotIn, the values array
+                                    must be non-empty. If the operator is Exists or
+                                    DoesNotExist, the values array must be empty.
+                                    This array is replaced during a strategic merge
+                                    patch.
+                                  items:
+                                    type: string
+                                  type: array
+                              required:
+                              - key
+                              - operator
+                              type: object
+                            type: array
+                          matchLabels:
+                            additionalProperties:
+                              type: string
+                            description: matchLabels is a map of {key,value} pairs.
+                              A single {key,value} in the matchLabels map is equivalent
+                              to an element of matchExpressions, whose key field is
+                              "key", the operator is "In", and the values array contains
+                              only "value". The requirements are ANDed.
+                            type: object
+                        type: object
+                      maxSkew:
+                        description: 'MaxSkew describes the degree to which pods may
+                          be unevenly distributed. When ` + "`" + `whenUnsatisfiable=DoNotSchedule` + "`" + `,
+                          it is the maximum permitted difference between the number
+                          of matching pods in the target topology and the global minimum.
+                          For example, in a 3-zone cluster, MaxSkew is set to 1, and
+                          pods with the same labelSelector spread as 1/1/0: | zone1
+                          | zone2 | zone3 | |   P   |   P   |       | - if MaxSkew
+                          is 1, incoming pod can only be scheduled to zone3 to become
+                          1/1/1; scheduling it onto zone1(zone2) would make the ActualSkew(2-0)
+                          on zone1(zone2) violate MaxSkew(1). - if MaxSkew is 2, incoming
+                          pod can be scheduled onto any zone. When ` + "`" + `whenUnsatisfiable=ScheduleAnyway` + "`" + `,
+                          it is used to give higher precedence to topologies that
+                          satisfy it. It''s a required field. Default value is 1 and
+                          0 is not allowed.'
+                        format: int32
+                        type: integer
+                      topologyKey:
+                        description: TopologyKey is the key of node labels. Nodes
+                          that have a label with this key and identical values are
+                          considered to be in the same topology. We consider each
+                          <key, value> as a "bucket", and try to put balanced number
+                          of pods into each bucket. It's a required field.
+                        type: string
+                      whenUnsatisfiable:
+                        description: 'WhenUnsatisfiable indicates how to deal with
+                          a pod if it doesn''t satisfy the spread constraint. - DoNotSchedule
+                          (default) tells the scheduler not to schedule it. - ScheduleAnyway
+                          tells the scheduler to schedule the pod in any location,
+                          but giving higher precedence to topologies that would help
+                          reduce the skew. A constraint is considered "Unsatisfiable"
+                          for an incoming pod if and only if every possible node assigment
+                          for that pod would violate "MaxSkew" on some topology. For
+                          example, in a 3-zone cluster, MaxSkew is set to 1, and pods
+                          with the same labelSelector spread as 3/1/1: | zone1 | zone2
+                          | zone3 | | P P P |   P   |   P   | If WhenUnsatisfiable
+                          is set to DoNotSchedule, incoming pod can only be scheduled
+                          to zone2(zone3) to become 3/2/1(3/1/2) as ActualSkew(2-1)
+                          on zone2(zone3) satisfies MaxSkew(1). In other words, the
+                          cluster can still be imbalanced, but scheduler won''t make
+                          it *more* imbalanced. It''s a required field.'
+                        type: string
+                    required:
+                    - maxSkew
+                    - topologyKey
+                    - whenUnsatisfiable
+                    type: object
+                  type: array
+                  x-kubernetes-list-map-keys:
+                  - topologyKey
+                  - whenUnsatisfiable
+                  x-kubernetes-list-type: map
                 volumes:
                   description: List of volumes that can be mounted by disks belonging
                     to the vmi.
@@ -5067,6 +5993,17 @@ var CRDsValidation map[string]string = map[string]string{
                             description: Image is the name of the image with the embedded
                               disk.
                             type: string
+                          imageDigestPolicy:
+                            description: ImageDigestPolicy controls whether the image
+                              has to be referenced by digest. With Require, images
+                              have to be referenced by digest. With Pin, virt-controller
+                              resolves images referenced by tag to their digest when
+                              it starts the VirtualMachine and records them in its
+                              status, so that restarts of the VirtualMachine always
+                              boot the same image. VirtualMachineInstances which are
+                              not created by a VirtualMachine must reference such
+                              images by digest.
+                            type: string
                           imagePullPolicy:
                             description: 'Image pull policy. One of Always, Never,
                               IfNotPresent. Defaults to Always if :latest tag is specified,
@@ -5198,6 +6135,14 @@ var CRDsValidation map[string]string = map[string]string{
                             description: Capacity of the sparse disk.
                             pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
                             x-kubernetes-int-or-string: true
+                          imageFormat:
+                            description: ImageFormat is the on-disk format of the
+                              created image. Defaults to qcow2.
+                            type: string
+                          preallocation:
+                            description: Preallocation defines how space for the created
+                              image is allocated. Defaults to off.
+                            type: string
                         required:
                         - capacity
                         type: object
@@ -5206,6 +6151,13 @@ var CRDsValidation map[string]string = map[string]string{
                           specified source and provides copy-on-write image on top
                           of it.
                         properties:
+                          imageFormat:
+                            description: ImageFormat is the format of the local image
+                              the PVC is exposed through. With qcow2 a copy-on-write
+                              overlay on top of the PVC is created, with raw the PVC
+                              content is converted into a standalone local copy. Defaults
+                              to qcow2.
+                            type: string
                           persistentVolumeClaim:
                             description: 'PersistentVolumeClaimVolumeSource represents
                               a reference to a PersistentVolumeClaim in the same namespace.
@@ -5223,6 +6175,10 @@ var CRDsValidation map[string]string = map[string]string{
                             required:
                             - claimName
                             type: object
+                          preallocation:
+                            description: Preallocation defines how space for the local
+                              image is allocated. Defaults to off.
+                            type: string
                         type: object
                       hostDisk:
                         description: HostDisk represents a disk created on the cluster
@@ -5239,6 +6195,11 @@ var CRDsValidation map[string]string = map[string]string{
                             description: The path to HostDisk image located on the
                               cluster
                             type: string
+                          preallocation:
+                            description: Preallocation defines how space for the raw
+                              disk.img is allocated when it gets created. Only 'off',
+                              'falloc' and 'full' are supported. Defaults to off.
+                            type: string
                           shared:
                             description: Shared indicate whether the path is shared
                               between nodes
@@ -5251,10 +6212,89 @@ var CRDsValidation map[string]string = map[string]string{
                         - path
                         - type
                         type: object
+                      memoryDump:
+                        description: MemoryDump represents a PersistentVolumeClaim
+                          the memory state of the vmi is saved to. It is hotplugged
+                          into the virt-launcher pod without being attached to the
+                          vmi as a disk.
+                        properties:
+                          claimName:
+                            description: 'ClaimName is the name of a PersistentVolumeClaim
+                              in the same namespace as the pod using this volume.
+                              More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
+                            type: string
+                          hotpluggable:
+                            description: Hotpluggable indicates whether the volume
+                              can be hotplugged and hotunplugged.
+                            type: boolean
+                          preallocation:
+                            description: Preallocation defines how space for the raw
+                              disk.img is allocated when it gets created on an empty
+                              filesystem PVC. Only 'off', 'falloc' and 'full' are
+                              supported. Defaults to off.
+                            type: string
+                          readOnly:
+                            description: Will force the ReadOnly setting in VolumeMounts.
+                              Default false.
+                            type: boolean
+                        required:
+                        - claimName
+                        type: object
                       name:
                         description: 'Volume''s name. Must be a DNS_LABEL and unique
                           within the vmi. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names'
                         type: string
+                      networkDisk:
+                        description: NetworkDisk represents a disk which is accessed
+                          by QEMU directly over the network (iSCSI, NBD or RBD), without
+                          attaching it to the node or mounting it into the virt-launcher
+                          pod.
+                        properties:
+                          auth:
+                            description: Auth holds the credentials to access the
+                              disk. Only supported for iscsi and rbd.
+                            properties:
+                              secretRef:
+                                description: SecretRef is the name of a secret in
+                                  the namespace of the vmi, which holds the CHAP password
+                                  (iscsi) or the cephx key (rbd) in its "password"
+                                  key.
+                                type: string
+                              username:
+                                description: Username to authenticate with.
+                                type: string
+                            required:
+                            - secretRef
+                            - username
+                            type: object
+                          host:
+                            description: Host serving the disk.
+                            properties:
+                              name:
+                                description: Name is the hostname or IP address of
+                                  the host.
+                                type: string
+                              port:
+                                description: Port of the host. Defaults to the default
+                                  port of the protocol.
+                                type: string
+                            required:
+                            - name
+                            type: object
+                          name:
+                            description: Name of the disk on the storage system. For
+                              iscsi it is the target IQN and LUN (<iqn>/<lun>), for
+                              rbd the pool and image (<pool>/<image>) and for nbd
+                              the optional export name.
+                            type: string
+                          protocol:
+                            description: 'Protocol used to access the disk. One of:
+                              iscsi, nbd, rbd.'
+                            type: string
+                        required:
+                        - host
+                        - protocol
+                        type: object
                       persistentVolumeClaim:
                         description: 'PersistentVolumeClaimVolumeSource represents
                           a reference to a PersistentVolumeClaim in the same namespace.
@@ -5269,6 +6309,12 @@ var CRDsValidation map[string]string = map[string]string{
                             description: Hotpluggable indicates whether the volume
                               can be hotplugged and hotunplugged.
                             type: boolean
+                          preallocation:
+                            description: Preallocation defines how space for the raw
+                              disk.img is allocated when it gets created on an empty
+                              filesystem PVC. Only 'off', 'falloc' and 'full' are
+                              supported. Defaults to off.
+                            type: string
                           readOnly:
                             description: Will force the ReadOnly setting in VolumeMounts.
                               Default false.
@@ -5339,6 +6385,13 @@ var CRDsValidation map[string]string = map[string]string{
               - domain
               type: object
           type: object
+        ttlSecondsAfterFinished:
+          description: TTLSecondsAfterFinished limits the lifetime of a VirtualMachine
+            with runStrategy Once. The VirtualMachine, including the DataVolumes of
+            its dataVolumeTemplates, is deleted the given number of seconds after
+            its VirtualMachineInstance finished. It is kept if unset.
+          format: int32
+          type: integer
       required:
       - template
       type: object
@@ -5361,6 +6414,11 @@ var CRDsValidation map[string]string = map[string]string{
                 type: string
               message:
                 type: string
+              observedGeneration:
+                description: ObservedGeneration is the metadata.generation of the
+                  object the condition was set for
+                format: int64
+                type: integer
               reason:
                 type: string
               status:
@@ -5376,6 +6434,70 @@ var CRDsValidation map[string]string = map[string]string{
           description: Created indicates if the virtual machine is created in the
             cluster
           type: boolean
+        guestFailures:
+          description: GuestFailures tracks recent failures of VMIs which were running,
+            for the purposes of detecting guest crash loops
+          nullable: true
+          properties:
+            failureTimestamps:
+              description: FailureTimestamps are the times at which the VMIs failed,
+                within the crash loop detection window
+              items:
+                format: date-time
+                type: string
+              type: array
+              x-kubernetes-list-type: atomic
+            lastFailedVMIUID:
+              description: LastFailedVMIUID is the UID of the last VMI which was counted
+                as failed
+              type: string
+          type: object
+        pendingChanges:
+          description: PendingChanges are the changes of the template which aren't
+            applied to the running VirtualMachineInstance yet
+          items:
+            description: VirtualMachinePendingChange is a change of the template which
+              isn't applied to the running VirtualMachineInstance yet
+            properties:
+              applyMethod:
+                description: ApplyMethod is how the change can be applied to the running
+                  VirtualMachineInstance
+                type: string
+              path:
+                description: Path is the path of the changed field of the VirtualMachine
+                type: string
+            required:
+            - applyMethod
+            - path
+            type: object
+          type: array
+          x-kubernetes-list-type: atomic
+        pinnedContainerDiskImages:
+          description: PinnedContainerDiskImages are the images of the containerDisks
+            with the Pin digest policy, resolved to their digest when the VirtualMachine
+            was started. Later starts boot the same images until the image of the
+            containerDisk in the template changes.
+          items:
+            description: PinnedContainerDiskImage is the image of a containerDisk
+              pinned to its digest
+            properties:
+              image:
+                description: Image is the image of the containerDisk in the template
+                type: string
+              pinnedImage:
+                description: PinnedImage is the image referenced by the digest it
+                  was resolved to
+                type: string
+              volumeName:
+                description: VolumeName is the name of the containerDisk volume
+                type: string
+            required:
+            - image
+            - pinnedImage
+            - volumeName
+            type: object
+          type: array
+          x-kubernetes-list-type: atomic
         printableStatus:
           description: PrintableStatus is a human readable, high-level representation
             of the status of the virtual machine
@@ -5387,6 +6509,11 @@ var CRDsValidation map[string]string = map[string]string{
           description: RestoreInProgress is the name of the VirtualMachineRestore
             currently executing
           type: string
+        runOnceStarted:
+          description: RunOnceStarted is set once the VirtualMachineInstance of
+            a VirtualMachine with runStrategy Once was created. The
+            VirtualMachineInstance is not started again, even if it gets deleted.
+          type: boolean
         snapshotInProgress:
           description: SnapshotInProgress is the name of the VirtualMachineSnapshot
             currently executing
@@ -5485,7 +6612,7 @@ var CRDsValidation map[string]string = map[string]string{
                         properties:
                           bus:
                             description: 'Bus indicates the type of disk device to
-                              emulate. supported values: virtio, sata, scsi.'
+                              emulate. supported values: virtio, sata, scsi, usb.'
                             type: string
                           readonly:
                             description: ReadOnly. Defaults to true.
@@ -5501,12 +6628,23 @@ var CRDsValidation map[string]string = map[string]string{
                           have an exclusive IO Thread. Enabling this implies useIOThreads
                           = true. Defaults to false.
                         type: boolean
+                      detectZeroes:
+                        description: 'DetectZeroes specifies whether QEMU detects
+                          writes of zeroes and optimizes them. Supported values are:
+                          off, on, unmap. Defaults to unmap on thin-provisioned block
+                          volumes with discard enabled.'
+                        type: string
+                      discard:
+                        description: 'Discard specifies whether discard requests from
+                          the guest are passed to the storage. Supported values are:
+                          unmap, ignore. Defaults to unmap unless the volume is preallocated.'
+                        type: string
                       disk:
                         description: Attach a volume as a disk to the vmi.
                         properties:
                           bus:
                             description: 'Bus indicates the type of disk device to
-                              emulate. supported values: virtio, sata, scsi.'
+                              emulate. supported values: virtio, sata, scsi, usb.'
                             type: string
                           pciAddress:
                             description: 'If specified, the virtual disk will be placed
@@ -5551,6 +6689,14 @@ var CRDsValidation map[string]string = map[string]string{
                         description: Serial provides the ability to specify a serial
                           number for the disk device.
                         type: string
+                      shareable:
+                        description: Shareable indicates whether the disk can be shared
+                          between multiple VMIs, e.g. for clustered file systems.
+                          A shareable disk must be backed by a block PersistentVolumeClaim
+                          with the ReadWriteMany access mode, it is attached without
+                          a host side cache and without an ephemeral overlay. Defaults
+                          to false.
+                        type: boolean
                       tag:
                         description: If specified, disk address and its tag will be
                           provided to the guest via config drive metadata
@@ -5597,6 +6743,12 @@ var CRDsValidation map[string]string = map[string]string{
                             description: Hotpluggable indicates whether the volume
                               can be hotplugged and hotunplugged.
                             type: boolean
+                          preallocation:
+                            description: Preallocation defines how space for the raw
+                              disk.img is allocated when it gets created on an empty
+                              filesystem PVC. Only 'off', 'falloc' and 'full' are
+                              supported. Defaults to off.
+                            type: string
                           readOnly:
                             description: Will force the ReadOnly setting in VolumeMounts.
                               Default false.
@@ -5625,25 +6777,186 @@ var CRDsValidation map[string]string = map[string]string{
             type: object
           type: array
           x-kubernetes-list-type: atomic
-        volumeSnapshotStatuses:
-          description: VolumeSnapshotStatuses indicates a list of statuses whether
-            snapshotting is supported by each volume.
+        volumeSnapshotStatuses:
+          description: VolumeSnapshotStatuses indicates a list of statuses whether
+            snapshotting is supported by each volume.
+          items:
+            properties:
+              enabled:
+                description: True if the volume supports snapshotting
+                type: boolean
+              name:
+                description: Volume name
+                type: string
+              reason:
+                description: Empty if snapshotting is enabled, contains reason otherwise
+                type: string
+            required:
+            - enabled
+            - name
+            type: object
+          type: array
+      type: object
+  required:
+  - spec
+  type: object
+`,
+	"virtualmachinebulkoperation": `openAPIV3Schema:
+  description: VirtualMachineBulkOperation records a bulk operation requested through
+    the bulkoperations subresource. virt-controller applies the action to the VirtualMachines
+    and records the outcome in the status.
+  properties:
+    apiVersion:
+      description: 'APIVersion defines the versioned schema of this representation
+        of an object. Servers should convert recognized schemas to the latest internal
+        value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+      type: string
+    kind:
+      description: 'Kind is a string value representing the REST resource this object
+        represents. Servers may infer this from the endpoint the client submits requests
+        to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+      type: string
+    metadata:
+      type: object
+    spec:
+      properties:
+        action:
+          description: Action to apply to every VirtualMachine, one of start, stop
+            or migrate
+          type: string
+        extra:
+          additionalProperties:
+            items:
+              type: string
+            type: array
+          description: Extra information about the user who requested the operation
+          type: object
+        groups:
+          description: Groups of the user who requested the operation
+          items:
+            type: string
+          type: array
+          x-kubernetes-list-type: atomic
+        parallelism:
+          description: Parallelism is the number of VirtualMachines processed at the
+            same time
+          format: int32
+          type: integer
+        selector:
+          description: Selector is the label selector which matched the VirtualMachines
+          type: string
+        user:
+          description: User who requested the operation. The action is only applied
+            to the VirtualMachines the user is allowed to apply it to.
+          type: string
+        virtualMachines:
+          description: VirtualMachines are the names of the VirtualMachines matching
+            the selector when the operation was requested
+          items:
+            type: string
+          type: array
+          x-kubernetes-list-type: atomic
+      required:
+      - action
+      - parallelism
+      - selector
+      - user
+      - virtualMachines
+      type: object
+    status:
+      properties:
+        applied:
+          description: Applied lists the VirtualMachines the action was applied to
+          items:
+            type: string
+          type: array
+          x-kubernetes-list-type: atomic
+        completionTimestamp:
+          description: CompletionTimestamp is the time the action was applied to
+            all VirtualMachines. The operation is deleted a while after it completed.
+          format: date-time
+          nullable: true
+          type: string
+        failures:
+          description: Failures lists the VirtualMachines the action could not be
+            applied to
           items:
+            description: BulkOperationFailure describes why a bulk operation failed
+              for a single VirtualMachine
             properties:
-              enabled:
-                description: True if the volume supports snapshotting
-                type: boolean
-              name:
-                description: Volume name
+              message:
+                description: Message describing the failure
                 type: string
-              reason:
-                description: Empty if snapshotting is enabled, contains reason otherwise
+              name:
+                description: Name of the VirtualMachine
                 type: string
             required:
-            - enabled
+            - message
             - name
             type: object
           type: array
+          x-kubernetes-list-type: atomic
+        phase:
+          description: VirtualMachineBulkOperationPhase is the current phase of a
+            VirtualMachineBulkOperation.
+          type: string
+      type: object
+  required:
+  - spec
+  type: object
+`,
+	"virtualmachineimageexport": `openAPIV3Schema:
+  description: VirtualMachineImageExport publishes the boot volume of a stopped VirtualMachine
+    as a containerDisk image to a registry.
+  properties:
+    apiVersion:
+      description: 'APIVersion defines the versioned schema of this representation
+        of an object. Servers should convert recognized schemas to the latest internal
+        value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+      type: string
+    kind:
+      description: 'Kind is a string value representing the REST resource this object
+        represents. Servers may infer this from the endpoint the client submits requests
+        to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+      type: string
+    metadata:
+      type: object
+    spec:
+      properties:
+        baseImage:
+          description: BaseImage is the image the layer with the disk is put on top
+            of. Its layers are mounted from the base repository instead of being uploaded
+            again, if the registry supports it. Defaults to an image with only the
+            disk layer.
+          type: string
+        image:
+          description: Image is the reference by tag the containerDisk image is pushed
+            to.
+          type: string
+        pushSecretName:
+          description: PushSecretName is the name of a kubernetes.io/dockerconfigjson
+            Secret in the namespace of the export with the credentials for the registries.
+          type: string
+        source:
+          description: The name of the VirtualMachine whose boot volume is exported.
+            The VirtualMachine must exist in the namespace of the export and must
+            be stopped.
+          type: string
+      required:
+      - image
+      - source
+      type: object
+    status:
+      properties:
+        digest:
+          description: Digest is the digest of the manifest of the pushed image.
+          type: string
+        message:
+          description: Message is a human readable reason of a failed export.
+          type: string
+        phase:
+          description: VirtualMachineImageExportPhase is the current phase of a VirtualMachineImageExport.
+          type: string
       type: object
   required:
   - spec
@@ -6566,10 +7879,41 @@ var CRDsValidation map[string]string = map[string]string{
                   description: Whether or not to enable virtio multi-queue for block
                     devices. Defaults to false.
                   type: boolean
+                channels:
+                  description: Channels are additional virtio-serial channels of the
+                    vmi. Each channel is backed by a unix socket in the virt-launcher
+                    pod, which is shared with the sidecars of the vmi.
+                  items:
+                    description: SerialChannel is a virtio-serial channel between
+                      the guest and the virt-launcher pod. The socket of the channel
+                      is created at <channel socket directory>/<name>.sock, where
+                      sidecars can connect to it to exchange data with the guest without
+                      networking, e.g. to collect logs.
+                    properties:
+                      name:
+                        description: Name of the channel, unique within the vmi. It
+                          names the socket of the channel.
+                        type: string
+                      target:
+                        description: Target is the name of the virtio-serial port
+                          in the guest, e.g. org.example.log.0.
+                        type: string
+                    required:
+                    - name
+                    - target
+                    type: object
+                  type: array
+                  x-kubernetes-list-type: atomic
                 clientPassthrough:
                   description: To configure and access client devices such as redirecting
                     USB
                   type: object
+                detachGPUsForMigration:
+                  description: Whether to detach the GPUs for a live migration. The
+                    GPUs are hot-unplugged and the guest is paused until the GPUs
+                    of the target are hot-plugged. The guest has to support the hot-unplug
+                    of its GPUs. Without it, VMIs with GPUs are not live migratable.
+                  type: boolean
                 disableHotplug:
                   description: DisableHotplug disabled the ability to hotplug disks.
                   type: boolean
@@ -6620,7 +7964,7 @@ var CRDsValidation map[string]string = map[string]string{
                         properties:
                           bus:
                             description: 'Bus indicates the type of disk device to
-                              emulate. supported values: virtio, sata, scsi.'
+                              emulate. supported values: virtio, sata, scsi, usb.'
                             type: string
                           readonly:
                             description: ReadOnly. Defaults to true.
@@ -6636,12 +7980,23 @@ var CRDsValidation map[string]string = map[string]string{
                           have an exclusive IO Thread. Enabling this implies useIOThreads
                           = true. Defaults to false.
                         type: boolean
+                      detectZeroes:
+                        description: 'DetectZeroes specifies whether QEMU detects
+                          writes of zeroes and optimizes them. Supported values are:
+                          off, on, unmap. Defaults to unmap on thin-provisioned block
+                          volumes with discard enabled.'
+                        type: string
+                      discard:
+                        description: 'Discard specifies whether discard requests from
+                          the guest are passed to the storage. Supported values are:
+                          unmap, ignore. Defaults to unmap unless the volume is preallocated.'
+                        type: string
                       disk:
                         description: Attach a volume as a disk to the vmi.
                         properties:
                           bus:
                             description: 'Bus indicates the type of disk device to
-                              emulate. supported values: virtio, sata, scsi.'
+                              emulate. supported values: virtio, sata, scsi, usb.'
                             type: string
                           pciAddress:
                             description: 'If specified, the virtual disk will be placed
@@ -6686,6 +8041,14 @@ var CRDsValidation map[string]string = map[string]string{
                         description: Serial provides the ability to specify a serial
                           number for the disk device.
                         type: string
+                      shareable:
+                        description: Shareable indicates whether the disk can be shared
+                          between multiple VMIs, e.g. for clustered file systems.
+                          A shareable disk must be backed by a block PersistentVolumeClaim
+                          with the ReadWriteMany access mode, it is attached without
+                          a host side cache and without an ephemeral overlay. Defaults
+                          to false.
+                        type: boolean
                       tag:
                         description: If specified, disk address and its tag will be
                           provided to the guest via config drive metadata
@@ -6721,6 +8084,18 @@ var CRDsValidation map[string]string = map[string]string{
                         description: Name of the GPU device as exposed by a device
                           plugin
                         type: string
+                      pciOrder:
+                        description: PCIOrder is an integer value > 0, used to determine
+                          the order of the guest PCI addresses of the GPUs and host
+                          devices. Lower values get lower addresses, devices without
+                          a PCI order are placed after the ordered ones. Each GPU
+                          or host device that has a PCI order must have a unique value.
+                        type: integer
+                      role:
+                        description: Role labels the device for the guest, e.g. render
+                          or compute. It is reported together with the guest PCI address
+                          of the device in the VMI status.
+                        type: string
                     required:
                     - deviceName
                     - name
@@ -6737,6 +8112,18 @@ var CRDsValidation map[string]string = map[string]string{
                         type: string
                       name:
                         type: string
+                      pciOrder:
+                        description: PCIOrder is an integer value > 0, used to determine
+                          the order of the guest PCI addresses of the GPUs and host
+                          devices. Lower values get lower addresses, devices without
+                          a PCI order are placed after the ordered ones. Each GPU
+                          or host device that has a PCI order must have a unique value.
+                        type: integer
+                      role:
+                        description: Role labels the device for the guest, e.g. render
+                          or compute. It is reported together with the guest PCI address
+                          of the device in the VMI status.
+                        type: string
                     required:
                     - deviceName
                     - name
@@ -6768,6 +8155,40 @@ var CRDsValidation map[string]string = map[string]string{
                     to the vmi.
                   items:
                     properties:
+                      bandwidth:
+                        anyOf:
+                        - type: integer
+                        - type: string
+                        description: Bandwidth is the throughput the interface is
+                          expected to need, in bits per second, for example 10G. The
+                          VMI reserves it from the node bandwidth of the cluster network
+                          configuration, nodes without enough unreserved bandwidth
+                          are not considered for scheduling.
+                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                        x-kubernetes-int-or-string: true
+                      bandwidthLimit:
+                        description: BandwidthLimit shapes the traffic of the interface.
+                          It can be changed while the VMI is running, virt-launcher
+                          applies the new limits to the running domain. Not supported
+                          on interfaces with the SR-IOV, slirp or vhostuser binding.
+                        properties:
+                          inbound:
+                            anyOf:
+                            - type: integer
+                            - type: string
+                            description: Inbound is the throughput the guest can receive,
+                              in bits per second, for example 100M.
+                            pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                            x-kubernetes-int-or-string: true
+                          outbound:
+                            anyOf:
+                            - type: integer
+                            - type: string
+                            description: Outbound is the throughput the guest can
+                              send, in bits per second, for example 100M.
+                            pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                            x-kubernetes-int-or-string: true
+                        type: object
                       bootOrder:
                         description: BootOrder is an integer value > 0, used to determine
                           ordering of boot devices. Lower values take precedence.
@@ -6784,6 +8205,12 @@ var CRDsValidation map[string]string = map[string]string{
                             description: If specified will pass option 67 to interface's
                               DHCP server
                             type: string
+                          leaseTime:
+                            description: If specified will pass the lease time to
+                              the VM via DHCP option 051. Defaults to an hourly renewed
+                              lease if the address of the interface can change, to
+                              an infinite lease otherwise.
+                            type: string
                           ntpServers:
                             description: If specified will pass the configured NTP
                               server to the VM via DHCP option 042.
@@ -6888,6 +8315,16 @@ var CRDsValidation map[string]string = map[string]string{
                 rng:
                   description: Whether to have random number generator from host
                   type: object
+                scsiController:
+                  description: SCSIController configures the controller of the disks
+                    with the scsi bus. If blockMultiQueue is enabled, a virtio-scsi
+                    controller gets one queue per vCPU.
+                  properties:
+                    model:
+                      description: 'Model of the SCSI controller. One of: virtio-scsi,
+                        lsilogic. Defaults to virtio-scsi.'
+                      type: string
+                  type: object
                 useVirtioTransitional:
                   description: Fall back to legacy virtio 0.9 support if virtio bus
                     is selected on devices. This is helpful for old machines like
@@ -7180,6 +8617,20 @@ var CRDsValidation map[string]string = map[string]string{
                     generated uid.
                   type: string
               type: object
+            guestOS:
+              description: GuestOS describes the operating system of the guest. It
+                is a hint used to pick the defaults of the disk bus, the network interface
+                model, the tablet and the Hyper-V features, if they are not set.
+              properties:
+                osFamily:
+                  description: 'OSFamily of the guest operating system. One of: linux,
+                    windows'
+                  type: string
+                osVersion:
+                  description: OSVersion of the guest operating system, e.g. 2008
+                    or 10 for windows.
+                  type: string
+              type: object
             ioThreadsPolicy:
               description: 'Controls whether or not disks will share IOThreads. Omitting
                 IOThreadsPolicy disables use of IOThreads. One of: shared, auto'
@@ -7187,6 +8638,43 @@ var CRDsValidation map[string]string = map[string]string{
             machine:
               description: Machine type.
               properties:
+                globals:
+                  description: Globals are QEMU -global options, which set a property
+                    of all devices of a driver. Their names have the form driver.property.
+                    Only the globals allowed in the QEMUOptionsAllowlist of the KubeVirt
+                    configuration are accepted.
+                  items:
+                    properties:
+                      name:
+                        description: Name of the option.
+                        type: string
+                      value:
+                        description: Value the option is set to.
+                        type: string
+                    required:
+                    - name
+                    - value
+                    type: object
+                  type: array
+                  x-kubernetes-list-type: atomic
+                options:
+                  description: Options are additional QEMU -machine options, for hardware
+                    quirks of particular guests. Only the options allowed in the QEMUOptionsAllowlist
+                    of the KubeVirt configuration are accepted.
+                  items:
+                    properties:
+                      name:
+                        description: Name of the option.
+                        type: string
+                      value:
+                        description: Value the option is set to.
+                        type: string
+                    required:
+                    - name
+                    - value
+                    type: object
+                  type: array
+                  x-kubernetes-list-type: atomic
                 type:
                   description: QEMU machine type is the actual chipset of the VirtualMachineInstance.
                   type: string
@@ -7257,6 +8745,23 @@ var CRDsValidation map[string]string = map[string]string{
             will be set to the name of the vmi, if dhcp or cloud-init is configured
             properly.
           type: string
+        launcherPodMetadata:
+          description: LauncherPodMetadata contains additional labels and annotations
+            for the virt-launcher pod.
+          properties:
+            annotations:
+              additionalProperties:
+                type: string
+              description: Annotations which are added to the virt-launcher pod. Annotations
+                in the kubevirt.io domain are reserved.
+              type: object
+            labels:
+              additionalProperties:
+                type: string
+              description: Labels which are added to the virt-launcher pod. Labels
+                in the kubevirt.io domain are reserved.
+              type: object
+          type: object
         livenessProbe:
           description: 'Periodic probe of VirtualMachineInstance liveness. VirtualmachineInstances
             will be stopped if the probe fails. Cannot be updated. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes'
@@ -7545,10 +9050,115 @@ var CRDsValidation map[string]string = map[string]string{
               format: int32
               type: integer
           type: object
+        runtimeClassName:
+          description: If specified, the virt-launcher pod will be run with the referenced
+            RuntimeClass. If not specified, the default runtime class of the KubeVirt
+            configuration is used.
+          type: string
         schedulerName:
           description: If specified, the VMI will be dispatched by specified scheduler.
             If not specified, the VMI will be dispatched by default scheduler.
           type: string
+        schedulingReadinessGates:
+          description: SchedulingReadinessGates lists conditions which have to be
+            set to True on the VirtualMachineInstance, usually by third-party controllers,
+            before the virt-launcher pod is created.
+          items:
+            description: SchedulingReadinessGate refers to a condition on the VirtualMachineInstance
+              which gates the creation of its pod.
+            properties:
+              conditionType:
+                description: ConditionType refers to a condition in the VirtualMachineInstance's
+                  condition list with matching type.
+                type: string
+            required:
+            - conditionType
+            type: object
+          type: array
+          x-kubernetes-list-type: atomic
+        sidecars:
+          description: Sidecars are user containers which are added to the virt-launcher
+            pod. They share the lifecycle of the VirtualMachineInstance.
+          items:
+            description: Sidecar is a user container which runs next to the virtual
+              machine in the virt-launcher pod, e.g. a log shipper or a license daemon.
+            properties:
+              args:
+                description: Arguments to the entrypoint. The CMD of the image is
+                  used if this is not provided.
+                items:
+                  type: string
+                type: array
+              command:
+                description: Entrypoint array. The ENTRYPOINT of the image is used
+                  if this is not provided.
+                items:
+                  type: string
+                type: array
+              image:
+                description: Image of the sidecar container.
+                type: string
+              imagePullPolicy:
+                description: Image pull policy of the sidecar container.
+                type: string
+              name:
+                description: Name of the sidecar container, unique within the VirtualMachineInstance.
+                type: string
+              resources:
+                description: Resources are the cpu and memory requests and limits
+                  of the sidecar container. They are accounted for on top of the resources
+                  of the virtual machine.
+                properties:
+                  limits:
+                    additionalProperties:
+                      anyOf:
+                      - type: integer
+                      - type: string
+                      pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                      x-kubernetes-int-or-string: true
+                    description: 'Limits describes the maximum amount of compute resources
+                      allowed. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/'
+                    type: object
+                  requests:
+                    additionalProperties:
+                      anyOf:
+                      - type: integer
+                      - type: string
+                      pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                      x-kubernetes-int-or-string: true
+                    description: 'Requests describes the minimum amount of compute
+                      resources required. If Requests is omitted for a container,
+                      it defaults to Limits if that is explicitly specified, otherwise
+                      to an implementation-defined value. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/'
+                    type: object
+                type: object
+              volumeMounts:
+                description: VolumeMounts share volumes of the VirtualMachineInstance
+                  read-only with the sidecar container.
+                items:
+                  description: SidecarVolumeMount mounts a volume of the VirtualMachineInstance
+                    into a sidecar container.
+                  properties:
+                    mountPath:
+                      description: MountPath is the path within the sidecar container
+                        at which the volume is mounted.
+                      type: string
+                    name:
+                      description: Name of the VirtualMachineInstance volume.
+                      type: string
+                  required:
+                  - mountPath
+                  - name
+                  type: object
+                type: array
+            required:
+            - image
+            - name
+            type: object
+          type: array
+          x-kubernetes-list-map-keys:
+          - name
+          x-kubernetes-list-type: map
         startStrategy:
           description: StartStrategy can be set to "Paused" if Virtual Machine should
             be started in paused state.
@@ -7603,6 +9213,109 @@ var CRDsValidation map[string]string = map[string]string{
                 type: string
             type: object
           type: array
+        topologySpreadConstraints:
+          description: TopologySpreadConstraints describes how the virt-launcher pod
+            ought to spread across topology domains. All topologySpreadConstraints
+            are ANDed.
+          items:
+            description: TopologySpreadConstraint specifies how to spread matching
+              pods among the given topology.
+            properties:
+              labelSelector:
+                description: LabelSelector is used to find matching pods. Pods that
+                  match this label selector are counted to determine the number of
+                  pods in their corresponding topology domain.
+                properties:
+                  matchExpressions:
+                    description: matchExpressions is a list of label selector requirements.
+                      The requirements are ANDed.
+                    items:
+                      description: A label selector requirement is a selector that
+                        contains values, a key, and an operator that relates the key
+                        and values.
+                      properties:
+                        key:
+                          description: key is the label key that the selector applies
+                            to.
+                          type: string
+                        operator:
+                          description: operator represents a key's relationship to
+                            a set of values. Valid operators are In, NotIn, Exists
+                            and DoesNotExist.
+                          type: string
+                        values:
+                          description: values is an array of string values. If the
+                            operator is In or NotIn, the values array must be non-empty.
+                            If the operator is Exists or DoesNotExist, the values
+                            array must be empty. This array is replaced during a strategic
+                            merge patch.
+                          items:
+                            type: string
+                          type: array
+                      required:
+                      - key
+                      - operator
+                      type: object
+                    type: array
+                  matchLabels:
+                    additionalProperties:
+                      type: string
+                    description: matchLabels is a map of {key,value} pairs. A single
+                      {key,value} in the matchLabels map is equivalent to an element
+                      of matchExpressions, whose key field is "key", the operator
+                      is "In", and the values array contains only "value". The requirements
+                      are ANDed.
+                    type: object
+                type: object
+              maxSkew:
+                description: 'MaxSkew describes the degree to which pods may be unevenly
+                  distributed. When ` + "`" + `whenUnsatisfiable=DoNotSchedule` + "`" + `, it is the maximum
+                  permitted difference between the number of matching pods in the
+                  target topology and the global minimum. For example, in a 3-zone
+                  cluster, MaxSkew is set to 1, and pods with the same labelSelector
+                  spread as 1/1/0: | zone1 | zone2 | zone3 | |   P   |   P   |       |
+                  - if MaxSkew is 1, incoming pod can only be scheduled to zone3 to
+                  become 1/1/1; scheduling it onto zone1(zone2) would make the ActualSkew(2-0)
+                  on zone1(zone2) violate MaxSkew(1). - if MaxSkew is 2, incoming
+                  pod can be scheduled onto any zone. When ` + "`" + `whenUnsatisfiable=ScheduleAnyway` + "`" + `,
+                  it is used to give higher precedence to topologies that satisfy
+                  it. It''s a required field. Default value is 1 and 0 is not allowed.'
+                format: int32
+                type: integer
+              topologyKey:
+                description: TopologyKey is the key of node labels. Nodes that have
+                  a label with this key and identical values are considered to be
+                  in the same topology. We consider each <key, value> as a "bucket",
+                  and try to put balanced number of pods into each bucket. It's a
+                  required field.
+                type: string
+              whenUnsatisfiable:
+                description: 'WhenUnsatisfiable indicates how to deal with a pod if
+                  it doesn''t satisfy the spread constraint. - DoNotSchedule (default)
+                  tells the scheduler not to schedule it. - ScheduleAnyway tells the
+                  scheduler to schedule the pod in any location, but giving higher
+                  precedence to topologies that would help reduce the skew. A constraint
+                  is considered "Unsatisfiable" for an incoming pod if and only if
+                  every possible node assigment for that pod would violate "MaxSkew"
+                  on some topology. For example, in a 3-zone cluster, MaxSkew is set
+                  to 1, and pods with the same labelSelector spread as 3/1/1: | zone1
+                  | zone2 | zone3 | | P P P |   P   |   P   | If WhenUnsatisfiable
+                  is set to DoNotSchedule, incoming pod can only be scheduled to zone2(zone3)
+                  to become 3/2/1(3/1/2) as ActualSkew(2-1) on zone2(zone3) satisfies
+                  MaxSkew(1). In other words, the cluster can still be imbalanced,
+                  but scheduler won''t make it *more* imbalanced. It''s a required
+                  field.'
+                type: string
+            required:
+            - maxSkew
+            - topologyKey
+            - whenUnsatisfiable
+            type: object
+          type: array
+          x-kubernetes-list-map-keys:
+          - topologyKey
+          - whenUnsatisfiable
+          x-kubernetes-list-type: map
         volumes:
           description: List of volumes that can be mounted by disks belonging to the
             vmi.
@@ -7716,6 +9429,16 @@ var CRDsValidation map[string]string = map[string]string{
                     description: Image is the name of the image with the embedded
                       disk.
                     type: string
+                  imageDigestPolicy:
+                    description: ImageDigestPolicy controls whether the image has
+                      to be referenced by digest. With Require, images have to be
+                      referenced by digest. With Pin, virt-controller resolves images
+                      referenced by tag to their digest when it starts the VirtualMachine
+                      and records them in its status, so that restarts of the VirtualMachine
+                      always boot the same image. VirtualMachineInstances which are
+                      not created by a VirtualMachine must reference such images by
+                      digest.
+                    type: string
                   imagePullPolicy:
                     description: 'Image pull policy. One of Always, Never, IfNotPresent.
                       Defaults to Always if :latest tag is specified, or IfNotPresent
@@ -7840,6 +9563,14 @@ var CRDsValidation map[string]string = map[string]string{
                     description: Capacity of the sparse disk.
                     pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
                     x-kubernetes-int-or-string: true
+                  imageFormat:
+                    description: ImageFormat is the on-disk format of the created
+                      image. Defaults to qcow2.
+                    type: string
+                  preallocation:
+                    description: Preallocation defines how space for the created image
+                      is allocated. Defaults to off.
+                    type: string
                 required:
                 - capacity
                 type: object
@@ -7847,6 +9578,12 @@ var CRDsValidation map[string]string = map[string]string{
                 description: Ephemeral is a special volume source that "wraps" specified
                   source and provides copy-on-write image on top of it.
                 properties:
+                  imageFormat:
+                    description: ImageFormat is the format of the local image the
+                      PVC is exposed through. With qcow2 a copy-on-write overlay on
+                      top of the PVC is created, with raw the PVC content is converted
+                      into a standalone local copy. Defaults to qcow2.
+                    type: string
                   persistentVolumeClaim:
                     description: 'PersistentVolumeClaimVolumeSource represents a reference
                       to a PersistentVolumeClaim in the same namespace. Directly attached
@@ -7864,6 +9601,10 @@ var CRDsValidation map[string]string = map[string]string{
                     required:
                     - claimName
                     type: object
+                  preallocation:
+                    description: Preallocation defines how space for the local image
+                      is allocated. Defaults to off.
+                    type: string
                 type: object
               hostDisk:
                 description: HostDisk represents a disk created on the cluster level
@@ -7878,6 +9619,11 @@ var CRDsValidation map[string]string = map[string]string{
                   path:
                     description: The path to HostDisk image located on the cluster
                     type: string
+                  preallocation:
+                    description: Preallocation defines how space for the raw disk.img
+                      is allocated when it gets created. Only 'off', 'falloc' and
+                      'full' are supported. Defaults to off.
+                    type: string
                   shared:
                     description: Shared indicate whether the path is shared between
                       nodes
@@ -7890,10 +9636,83 @@ var CRDsValidation map[string]string = map[string]string{
                 - path
                 - type
                 type: object
+              memoryDump:
+                description: MemoryDump represents a PersistentVolumeClaim the memory
+                  state of the vmi is saved to. It is hotplugged into the virt-launcher
+                  pod without being attached to the vmi as a disk.
+                properties:
+                  claimName:
+                    description: 'ClaimName is the name of a PersistentVolumeClaim
+                      in the same namespace as the pod using this volume. More info:
+                      https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
+                    type: string
+                  hotpluggable:
+                    description: Hotpluggable indicates whether the volume can be
+                      hotplugged and hotunplugged.
+                    type: boolean
+                  preallocation:
+                    description: Preallocation defines how space for the raw disk.img
+                      is allocated when it gets created on an empty filesystem PVC.
+                      Only 'off', 'falloc' and 'full' are supported. Defaults to off.
+                    type: string
+                  readOnly:
+                    description: Will force the ReadOnly setting in VolumeMounts.
+                      Default false.
+                    type: boolean
+                required:
+                - claimName
+                type: object
               name:
                 description: 'Volume''s name. Must be a DNS_LABEL and unique within
                   the vmi. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names'
                 type: string
+              networkDisk:
+                description: NetworkDisk represents a disk which is accessed by QEMU
+                  directly over the network (iSCSI, NBD or RBD), without attaching
+                  it to the node or mounting it into the virt-launcher pod.
+                properties:
+                  auth:
+                    description: Auth holds the credentials to access the disk. Only
+                      supported for iscsi and rbd.
+                    properties:
+                      secretRef:
+                        description: SecretRef is the name of a secret in the namespace
+                          of the vmi, which holds the CHAP password (iscsi) or the
+                          cephx key (rbd) in its "password" key.
+                        type: string
+                      username:
+                        description: Username to authenticate with.
+                        type: string
+                    required:
+                    - secretRef
+                    - username
+                    type: object
+                  host:
+                    description: Host serving the disk.
+                    properties:
+                      name:
+                        description: Name is the hostname or IP address of the host.
+                        type: string
+                      port:
+                        description: Port of the host. Defaults to the default port
+                          of the protocol.
+                        type: string
+                    required:
+                    - name
+                    type: object
+                  name:
+                    description: Name of the disk on the storage system. For iscsi
+                      it is the target IQN and LUN (<iqn>/<lun>), for rbd the pool
+                      and image (<pool>/<image>) and for nbd the optional export name.
+                    type: string
+                  protocol:
+                    description: 'Protocol used to access the disk. One of: iscsi,
+                      nbd, rbd.'
+                    type: string
+                required:
+                - host
+                - protocol
+                type: object
               persistentVolumeClaim:
                 description: 'PersistentVolumeClaimVolumeSource represents a reference
                   to a PersistentVolumeClaim in the same namespace. Directly attached
@@ -7908,6 +9727,11 @@ var CRDsValidation map[string]string = map[string]string{
                     description: Hotpluggable indicates whether the volume can be
                       hotplugged and hotunplugged.
                     type: boolean
+                  preallocation:
+                    description: Preallocation defines how space for the raw disk.img
+                      is allocated when it gets created on an empty filesystem PVC.
+                      Only 'off', 'falloc' and 'full' are supported. Defaults to off.
+                    type: string
                   readOnly:
                     description: Will force the ReadOnly setting in VolumeMounts.
                       Default false.
@@ -8010,6 +9834,12 @@ var CRDsValidation map[string]string = map[string]string{
             - type
             type: object
           type: array
+        cpuAllocationRatio:
+          description: CPUAllocationRatio is the effective ratio of vCPUs to the CPU
+            requested by the virt-launcher pod. It is not set if the VMI uses dedicated
+            CPUs.
+          format: int64
+          type: integer
         evacuationNodeName:
           description: EvacuationNodeName is used to track the eviction process of
             a VMI. It stores the name of the node that we want to evacuate. It is
@@ -8020,9 +9850,33 @@ var CRDsValidation map[string]string = map[string]string{
           description: FSFreezeStatus is the state of the fs of the guest it can be
             either frozen or thawed
           type: string
+        guestAgent:
+          description: Version and capabilities of the guest agent
+          properties:
+            supportedCommands:
+              description: SupportedCommands lists the commands which are enabled
+                in the guest agent
+              items:
+                type: string
+              type: array
+              x-kubernetes-list-type: set
+            unavailableFeatures:
+              description: UnavailableFeatures lists the features which can't be
+                used because the guest agent lacks commands they rely on
+              items:
+                type: string
+              type: array
+              x-kubernetes-list-type: set
+            version:
+              description: Version of the guest agent
+              type: string
+          type: object
         guestOSInfo:
           description: Guest OS Information
           properties:
+            hostname:
+              description: Hostname of the Guest OS
+              type: string
             id:
               description: Guest OS Id
               type: string
@@ -8041,6 +9895,10 @@ var CRDsValidation map[string]string = map[string]string{
             prettyName:
               description: Guest OS Pretty Name
               type: string
+            timezone:
+              description: Timezone of the Guest OS, the zone name and the offset
+                to UTC in seconds
+              type: string
             version:
               description: Guest OS Version
               type: string
@@ -8048,10 +9906,54 @@ var CRDsValidation map[string]string = map[string]string{
               description: Version ID of the Guest OS
               type: string
           type: object
+        hostDevices:
+          description: HostDevices reports where the GPUs and host devices of the
+            VMI are visible in the guest
+          items:
+            description: VirtualMachineInstanceHostDeviceStatus reports where a GPU
+              or host device is visible in the guest
+            properties:
+              guestPCIAddress:
+                description: GuestPCIAddress is the PCI address of the device in the
+                  guest, e.g. 0000:05:00.0
+                type: string
+              name:
+                description: Name of the GPU or host device in the VMI spec
+                type: string
+              role:
+                description: Role of the device as given in the VMI spec
+                type: string
+            required:
+            - name
+            type: object
+          type: array
+          x-kubernetes-list-type: atomic
         interfaces:
           description: Interfaces represent the details of available network interfaces.
           items:
             properties:
+              bandwidthLimit:
+                description: BandwidthLimit is the limit applied to the interface
+                  of the running domain. It differs from the limit of the spec until
+                  virt-launcher applied a change of it.
+                properties:
+                  inbound:
+                    anyOf:
+                    - type: integer
+                    - type: string
+                    description: Inbound is the throughput the guest can receive,
+                      in bits per second, for example 100M.
+                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                    x-kubernetes-int-or-string: true
+                  outbound:
+                    anyOf:
+                    - type: integer
+                    - type: string
+                    description: Outbound is the throughput the guest can send, in
+                      bits per second, for example 100M.
+                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                    x-kubernetes-int-or-string: true
+                type: object
               interfaceName:
                 description: The interface name inside the Virtual Machine
                 type: string
@@ -8064,6 +9966,13 @@ var CRDsValidation map[string]string = map[string]string{
                 items:
                   type: string
                 type: array
+              lastGuestAgentUpdate:
+                description: Time the guest agent data of the interface was last changed.
+                  Rapid changes of the data are debounced, data the guest agent stops
+                  reporting is removed.
+                format: date-time
+                nullable: true
+                type: string
               mac:
                 description: Hardware address of a Virtual Machine interface
                 type: string
@@ -8078,6 +9987,29 @@ var CRDsValidation map[string]string = map[string]string{
           description: LauncherContainerImageVersion indicates what container image
             is currently active for the vmi.
           type: string
+        migrationBackoff:
+          description: MigrationBackoff tracks consecutive failed migrations of the
+            vmi. Migrations created by KubeVirt itself, e.g. on evacuation, are held
+            back until the backoff expired.
+          properties:
+            failureCount:
+              description: The number of consecutive failed migrations
+              format: int32
+              type: integer
+            lastFailedMigrationUid:
+              description: The UID of the last failed migration
+              type: string
+            lastFailureTimestamp:
+              description: The time the last migration failed
+              format: date-time
+              nullable: true
+              type: string
+            nextAttemptTimestamp:
+              description: Automated migrations are not created before this time
+              format: date-time
+              nullable: true
+              type: string
+          type: object
         migrationMethod:
           description: 'Represents the method using which the vmi can be migrated:
             live migration or block migration'
@@ -8085,6 +10017,10 @@ var CRDsValidation map[string]string = map[string]string{
         migrationState:
           description: Represents the status of a live migration
           properties:
+            abortOnProjectedTimeout:
+              description: Indicates that the source aborts the migration once its
+                projected completion time exceeds the completion timeout
+              type: boolean
             abortRequested:
               description: Indicates that the migration has been requested to abort
               type: boolean
@@ -8178,6 +10114,51 @@ var CRDsValidation map[string]string = map[string]string{
           description: A brief CamelCase message indicating details about why the
             VMI is in this state. e.g. 'NodeUnresponsive'
           type: string
+        resourceOverhead:
+          description: ResourceOverhead is the overhead which was added to the resources
+            of the virt-launcher pod
+          properties:
+            cpu:
+              anyOf:
+              - type: integer
+              - type: string
+              description: CPU needed by the hypervisor, e.g. for an isolated emulator
+                thread
+              pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+              x-kubernetes-int-or-string: true
+            emulatorCPU:
+              anyOf:
+              - type: integer
+              - type: string
+              description: EmulatorCPU is the part of CPU requested for the emulator
+                thread
+              pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+              x-kubernetes-int-or-string: true
+            ioThreadsCPU:
+              anyOf:
+              - type: integer
+              - type: string
+              description: IOThreadsCPU is the part of CPU requested for the IO threads
+              pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+              x-kubernetes-int-or-string: true
+            memory:
+              anyOf:
+              - type: integer
+              - type: string
+              description: Memory needed by the hypervisor, e.g. for pagetables, virtio
+                rings, iothreads and video RAM. It includes the additional guest memory
+                overhead ratio of the KubeVirt configuration.
+              pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+              x-kubernetes-int-or-string: true
+            virtiofsCPU:
+              anyOf:
+              - type: integer
+              - type: string
+              description: VirtiofsCPU is the part of CPU requested for the virtiofsd
+                processes
+              pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+              x-kubernetes-int-or-string: true
+          type: object
         topologyHints:
           properties:
             tscFrequency:
@@ -8235,6 +10216,11 @@ var CRDsValidation map[string]string = map[string]string{
                     description: Capacity represents the capacity set on the corresponding
                       PVC spec
                     type: object
+                  localToNode:
+                    description: LocalToNode is the hostname of the node the bound
+                      PersistentVolume is local to. VirtualMachineInstances using
+                      it are placed on this node and can not be live migrated.
+                    type: string
                   preallocated:
                     description: Preallocated indicates if the PVC's storage is preallocated
                       or not
@@ -8289,6 +10275,12 @@ var CRDsValidation map[string]string = map[string]string{
       type: object
     spec:
       properties:
+        abortOnProjectedTimeout:
+          description: AbortOnProjectedTimeout aborts the migration as soon as its
+            projected completion time, based on the observed transfer rate, exceeds
+            the completion timeout instead of waiting for the timeout to expire. Ignored
+            when post copy is allowed.
+          type: boolean
         vmiName:
           description: The name of the VMI to perform the migration on. VMI must exist
             in the migration objects namespace
@@ -8311,6 +10303,11 @@ var CRDsValidation map[string]string = map[string]string{
                 type: string
               message:
                 type: string
+              observedGeneration:
+                description: ObservedGeneration is the metadata.generation of the
+                  object the condition was set for
+                format: int64
+                type: integer
               reason:
                 type: string
               status:
@@ -8551,10 +10548,41 @@ var CRDsValidation map[string]string = map[string]string{
                   description: Whether or not to enable virtio multi-queue for block
                     devices. Defaults to false.
                   type: boolean
+                channels:
+                  description: Channels are additional virtio-serial channels of the
+                    vmi. Each channel is backed by a unix socket in the virt-launcher
+                    pod, which is shared with the sidecars of the vmi.
+                  items:
+                    description: SerialChannel is a virtio-serial channel between
+                      the guest and the virt-launcher pod. The socket of the channel
+                      is created at <channel socket directory>/<name>.sock, where
+                      sidecars can connect to it to exchange data with the guest without
+                      networking, e.g. to collect logs.
+                    properties:
+                      name:
+                        description: Name of the channel, unique within the vmi. It
+                          names the socket of the channel.
+                        type: string
+                      target:
+                        description: Target is the name of the virtio-serial port
+                          in the guest, e.g. org.example.log.0.
+                        type: string
+                    required:
+                    - name
+                    - target
+                    type: object
+                  type: array
+                  x-kubernetes-list-type: atomic
                 clientPassthrough:
                   description: To configure and access client devices such as redirecting
                     USB
                   type: object
+                detachGPUsForMigration:
+                  description: Whether to detach the GPUs for a live migration. The
+                    GPUs are hot-unplugged and the guest is paused until the GPUs
+                    of the target are hot-plugged. The guest has to support the hot-unplug
+                    of its GPUs. Without it, VMIs with GPUs are not live migratable.
+                  type: boolean
                 disableHotplug:
                   description: DisableHotplug disabled the ability to hotplug disks.
                   type: boolean
@@ -8605,7 +10633,7 @@ var CRDsValidation map[string]string = map[string]string{
                         properties:
                           bus:
                             description: 'Bus indicates the type of disk device to
-                              emulate. supported values: virtio, sata, scsi.'
+                              emulate. supported values: virtio, sata, scsi, usb.'
                             type: string
                           readonly:
                             description: ReadOnly. Defaults to true.
@@ -8621,12 +10649,23 @@ var CRDsValidation map[string]string = map[string]string{
                           have an exclusive IO Thread. Enabling this implies useIOThreads
                           = true. Defaults to false.
                         type: boolean
+                      detectZeroes:
+                        description: 'DetectZeroes specifies whether QEMU detects
+                          writes of zeroes and optimizes them. Supported values are:
+                          off, on, unmap. Defaults to unmap on thin-provisioned block
+                          volumes with discard enabled.'
+                        type: string
+                      discard:
+                        description: 'Discard specifies whether discard requests from
+                          the guest are passed to the storage. Supported values are:
+                          unmap, ignore. Defaults to unmap unless the volume is preallocated.'
+                        type: string
                       disk:
                         description: Attach a volume as a disk to the vmi.
                         properties:
                           bus:
                             description: 'Bus indicates the type of disk device to
-                              emulate. supported values: virtio, sata, scsi.'
+                              emulate. supported values: virtio, sata, scsi, usb.'
                             type: string
                           pciAddress:
                             description: 'If specified, the virtual disk will be placed
@@ -8671,6 +10710,14 @@ var CRDsValidation map[string]string = map[string]string{
                         description: Serial provides the ability to specify a serial
                           number for the disk device.
                         type: string
+                      shareable:
+                        description: Shareable indicates whether the disk can be shared
+                          between multiple VMIs, e.g. for clustered file systems.
+                          A shareable disk must be backed by a block PersistentVolumeClaim
+                          with the ReadWriteMany access mode, it is attached without
+                          a host side cache and without an ephemeral overlay. Defaults
+                          to false.
+                        type: boolean
                       tag:
                         description: If specified, disk address and its tag will be
                           provided to the guest via config drive metadata
@@ -8706,6 +10753,18 @@ var CRDsValidation map[string]string = map[string]string{
                         description: Name of the GPU device as exposed by a device
                           plugin
                         type: string
+                      pciOrder:
+                        description: PCIOrder is an integer value > 0, used to determine
+                          the order of the guest PCI addresses of the GPUs and host
+                          devices. Lower values get lower addresses, devices without
+                          a PCI order are placed after the ordered ones. Each GPU
+                          or host device that has a PCI order must have a unique value.
+                        type: integer
+                      role:
+                        description: Role labels the device for the guest, e.g. render
+                          or compute. It is reported together with the guest PCI address
+                          of the device in the VMI status.
+                        type: string
                     required:
                     - deviceName
                     - name
@@ -8722,6 +10781,18 @@ var CRDsValidation map[string]string = map[string]string{
                         type: string
                       name:
                         type: string
+                      pciOrder:
+                        description: PCIOrder is an integer value > 0, used to determine
+                          the order of the guest PCI addresses of the GPUs and host
+                          devices. Lower values get lower addresses, devices without
+                          a PCI order are placed after the ordered ones. Each GPU
+                          or host device that has a PCI order must have a unique value.
+                        type: integer
+                      role:
+                        description: Role labels the device for the guest, e.g. render
+                          or compute. It is reported together with the guest PCI address
+                          of the device in the VMI status.
+                        type: string
                     required:
                     - deviceName
                     - name
@@ -8753,6 +10824,40 @@ var CRDsValidation map[string]string = map[string]string{
                     to the vmi.
                   items:
                     properties:
+                      bandwidth:
+                        anyOf:
+                        - type: integer
+                        - type: string
+                        description: Bandwidth is the throughput the interface is
+                          expected to need, in bits per second, for example 10G. The
+                          VMI reserves it from the node bandwidth of the cluster network
+                          configuration, nodes without enough unreserved bandwidth
+                          are not considered for scheduling.
+                        pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                        x-kubernetes-int-or-string: true
+                      bandwidthLimit:
+                        description: BandwidthLimit shapes the traffic of the interface.
+                          It can be changed while the VMI is running, virt-launcher
+                          applies the new limits to the running domain. Not supported
+                          on interfaces with the SR-IOV, slirp or vhostuser binding.
+                        properties:
+                          inbound:
+                            anyOf:
+                            - type: integer
+                            - type: string
+                            description: Inbound is the throughput the guest can receive,
+                              in bits per second, for example 100M.
+                            pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                            x-kubernetes-int-or-string: true
+                          outbound:
+                            anyOf:
+                            - type: integer
+                            - type: string
+                            description: Outbound is the throughput the guest can
+                              send, in bits per second, for example 100M.
+                            pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                            x-kubernetes-int-or-string: true
+                        type: object
                       bootOrder:
                         description: BootOrder is an integer value > 0, used to determine
                           ordering of boot devices. Lower values take precedence.
@@ -8769,6 +10874,12 @@ var CRDsValidation map[string]string = map[string]string{
                             description: If specified will pass option 67 to interface's
                               DHCP server
                             type: string
+                          leaseTime:
+                            description: If specified will pass the lease time to
+                              the VM via DHCP option 051. Defaults to an hourly renewed
+                              lease if the address of the interface can change, to
+                              an infinite lease otherwise.
+                            type: string
                           ntpServers:
                             description: If specified will pass the configured NTP
                               server to the VM via DHCP option 042.
@@ -8873,6 +10984,16 @@ var CRDsValidation map[string]string = map[string]string{
                 rng:
                   description: Whether to have random number generator from host
                   type: object
+                scsiController:
+                  description: SCSIController configures the controller of the disks
+                    with the scsi bus. If blockMultiQueue is enabled, a virtio-scsi
+                    controller gets one queue per vCPU.
+                  properties:
+                    model:
+                      description: 'Model of the SCSI controller. One of: virtio-scsi,
+                        lsilogic. Defaults to virtio-scsi.'
+                      type: string
+                  type: object
                 useVirtioTransitional:
                   description: Fall back to legacy virtio 0.9 support if virtio bus
                     is selected on devices. This is helpful for old machines like
@@ -9165,6 +11286,20 @@ var CRDsValidation map[string]string = map[string]string{
                     generated uid.
                   type: string
               type: object
+            guestOS:
+              description: GuestOS describes the operating system of the guest. It
+                is a hint used to pick the defaults of the disk bus, the network interface
+                model, the tablet and the Hyper-V features, if they are not set.
+              properties:
+                osFamily:
+                  description: 'OSFamily of the guest operating system. One of: linux,
+                    windows'
+                  type: string
+                osVersion:
+                  description: OSVersion of the guest operating system, e.g. 2008
+                    or 10 for windows.
+                  type: string
+              type: object
             ioThreadsPolicy:
               description: 'Controls whether or not disks will share IOThreads. Omitting
                 IOThreadsPolicy disables use of IOThreads. One of: shared, auto'
@@ -9172,6 +11307,43 @@ var CRDsValidation map[string]string = map[string]string{
             machine:
               description: Machine type.
               properties:
+                globals:
+                  description: Globals are QEMU -global options, which set a property
+                    of all devices of a driver. Their names have the form driver.property.
+                    Only the globals allowed in the QEMUOptionsAllowlist of the KubeVirt
+                    configuration are accepted.
+                  items:
+                    properties:
+                      name:
+                        description: Name of the option.
+                        type: string
+                      value:
+                        description: Value the option is set to.
+                        type: string
+                    required:
+                    - name
+                    - value
+                    type: object
+                  type: array
+                  x-kubernetes-list-type: atomic
+                options:
+                  description: Options are additional QEMU -machine options, for hardware
+                    quirks of particular guests. Only the options allowed in the QEMUOptionsAllowlist
+                    of the KubeVirt configuration are accepted.
+                  items:
+                    properties:
+                      name:
+                        description: Name of the option.
+                        type: string
+                      value:
+                        description: Value the option is set to.
+                        type: string
+                    required:
+                    - name
+                    - value
+                    type: object
+                  type: array
+                  x-kubernetes-list-type: atomic
                 type:
                   description: QEMU machine type is the actual chipset of the VirtualMachineInstance.
                   type: string
@@ -9345,6 +11517,12 @@ var CRDsValidation map[string]string = map[string]string{
                 contains only "value". The requirements are ANDed.
               type: object
           type: object
+        serviceName:
+          description: ServiceName is the name of the headless service governing the
+            replicas. The replicas get the stable names <replicaset>-<ordinal> and
+            are reachable by the DNS names <replicaset>-<ordinal>.<serviceName>.<namespace>.svc,
+            the service must exist and select the replicas.
+          type: string
         template:
           description: Template describes the pods that will be created.
           properties:
@@ -10316,10 +12494,43 @@ var CRDsValidation map[string]string = map[string]string{
                           description: Whether or not to enable virtio multi-queue
                             for block devices. Defaults to false.
                           type: boolean
+                        channels:
+                          description: Channels are additional virtio-serial channels
+                            of the vmi. Each channel is backed by a unix socket in
+                            the virt-launcher pod, which is shared with the sidecars
+                            of the vmi.
+                          items:
+                            description: SerialChannel is a virtio-serial channel
+                              between the guest and the virt-launcher pod. The socket
+                              of the channel is created at <channel socket directory>/<name>.sock,
+                              where sidecars can connect to it to exchange data with
+                              the guest without networking, e.g. to collect logs.
+                            properties:
+                              name:
+                                description: Name of the channel, unique within the
+                                  vmi. It names the socket of the channel.
+                                type: string
+                              target:
+                                description: Target is the name of the virtio-serial
+                                  port in the guest, e.g. org.example.log.0.
+                                type: string
+                            required:
+                            - name
+                            - target
+                            type: object
+                          type: array
+                          x-kubernetes-list-type: atomic
                         clientPassthrough:
                           description: To configure and access client devices such
                             as redirecting USB
                           type: object
+                        detachGPUsForMigration:
+                          description: Whether to detach the GPUs for a live migration.
+                            The GPUs are hot-unplugged and the guest is paused until
+                            the GPUs of the target are hot-plugged. The guest has
+                            to support the hot-unplug of its GPUs. Without it, VMIs
+                            with GPUs are not live migratable.
+                          type: boolean
                         disableHotplug:
                           description: DisableHotplug disabled the ability to hotplug
                             disks.
@@ -10391,6 +12602,18 @@ var CRDsValidation map[string]string = map[string]string{
                                   should have an exclusive IO Thread. Enabling this
                                   implies useIOThreads = true. Defaults to false.
                                 type: boolean
+                              detectZeroes:
+                                description: 'DetectZeroes specifies whether QEMU
+                                  detects writes of zeroes and optimizes them. Supported
+                                  values are: off, on, unmap. Defaults to unmap on
+                                  thin-provisioned block volumes with discard enabled.'
+                                type: string
+                              discard:
+                                description: 'Discard specifies whether discard requests
+                                  from the guest are passed to the storage. Supported
+                                  values are: unmap, ignore. Defaults to unmap unless
+                                  the volume is preallocated.'
+                                type: string
                               disk:
                                 description: Attach a volume as a disk to the vmi.
                                 properties:
@@ -10444,6 +12667,15 @@ var CRDsValidation map[string]string = map[string]string{
                                 description: Serial provides the ability to specify
                                   a serial number for the disk device.
                                 type: string
+                              shareable:
+                                description: Shareable indicates whether the disk
+                                  can be shared between multiple VMIs, e.g. for clustered
+                                  file systems. A shareable disk must be backed by
+                                  a block PersistentVolumeClaim with the ReadWriteMany
+                                  access mode, it is attached without a host side
+                                  cache and without an ephemeral overlay. Defaults
+                                  to false.
+                                type: boolean
                               tag:
                                 description: If specified, disk address and its tag
                                   will be provided to the guest via config drive metadata
@@ -10479,6 +12711,20 @@ var CRDsValidation map[string]string = map[string]string{
                                 description: Name of the GPU device as exposed by
                                   a device plugin
                                 type: string
+                              pciOrder:
+                                description: PCIOrder is an integer value > 0, used
+                                  to determine the order of the guest PCI addresses
+                                  of the GPUs and host devices. Lower values get lower
+                                  addresses, devices without a PCI order are placed
+                                  after the ordered ones. Each GPU or host device
+                                  that has a PCI order must have a unique value.
+                                type: integer
+                              role:
+                                description: Role labels the device for the guest,
+                                  e.g. render or compute. It is reported together
+                                  with the guest PCI address of the device in the
+                                  VMI status.
+                                type: string
                             required:
                             - deviceName
                             - name
@@ -10495,6 +12741,20 @@ var CRDsValidation map[string]string = map[string]string{
                                 type: string
                               name:
                                 type: string
+                              pciOrder:
+                                description: PCIOrder is an integer value > 0, used
+                                  to determine the order of the guest PCI addresses
+                                  of the GPUs and host devices. Lower values get lower
+                                  addresses, devices without a PCI order are placed
+                                  after the ordered ones. Each GPU or host device
+                                  that has a PCI order must have a unique value.
+                                type: integer
+                              role:
+                                description: Role labels the device for the guest,
+                                  e.g. render or compute. It is reported together
+                                  with the guest PCI address of the device in the
+                                  VMI status.
+                                type: string
                             required:
                             - deviceName
                             - name
@@ -10526,6 +12786,43 @@ var CRDsValidation map[string]string = map[string]string{
                             are added to the vmi.
                           items:
                             properties:
+                              bandwidth:
+                                anyOf:
+                                - type: integer
+                                - type: string
+                                description: Bandwidth is the throughput the interface
+                                  is expected to need, in bits per second, for example
+                                  10G. The VMI reserves it from the node bandwidth
+                                  of the cluster network configuration, nodes without
+                                  enough unreserved bandwidth are not considered for
+                                  scheduling.
+                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                x-kubernetes-int-or-string: true
+                              bandwidthLimit:
+                                description: BandwidthLimit shapes the traffic of
+                                  the interface. It can be changed while the VMI is
+                                  running, virt-launcher applies the new limits to
+                                  the running domain. Not supported on interfaces
+                                  with the SR-IOV, slirp or vhostuser binding.
+                                properties:
+                                  inbound:
+                                    anyOf:
+                                    - type: integer
+                                    - type: string
+                                    description: Inbound is the throughput the guest
+                                      can receive, in bits per second, for example
+                                      100M.
+                                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                    x-kubernetes-int-or-string: true
+                                  outbound:
+                                    anyOf:
+                                    - type: integer
+                                    - type: string
+                                    description: Outbound is the throughput the guest
+                                      can send, in bits per second, for example 100M.
+                                    pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                    x-kubernetes-int-or-string: true
+                                type: object
                               bootOrder:
                                 description: BootOrder is an integer value > 0, used
                                   to determine ordering of boot devices. Lower values
@@ -10543,6 +12840,13 @@ var CRDsValidation map[string]string = map[string]string{
                                     description: If specified will pass option 67
                                       to interface's DHCP server
                                     type: string
+                                  leaseTime:
+                                    description: If specified will pass the lease
+                                      time to the VM via DHCP option 051. Defaults
+                                      to an hourly renewed lease if the address of
+                                      the interface can change, to an infinite lease
+                                      otherwise.
+                                    type: string
                                   ntpServers:
                                     description: If specified will pass the configured
                                       NTP server to the VM via DHCP option 042.
@@ -10652,6 +12956,16 @@ var CRDsValidation map[string]string = map[string]string{
                           description: Whether to have random number generator from
                             host
                           type: object
+                        scsiController:
+                          description: SCSIController configures the controller of
+                            the disks with the scsi bus. If blockMultiQueue is enabled,
+                            a virtio-scsi controller gets one queue per vCPU.
+                          properties:
+                            model:
+                              description: 'Model of the SCSI controller. One of:
+                                virtio-scsi, lsilogic. Defaults to virtio-scsi.'
+                              type: string
+                          type: object
                         useVirtioTransitional:
                           description: Fall back to legacy virtio 0.9 support if virtio
                             bus is selected on devices. This is helpful for old machines
@@ -10972,6 +13286,21 @@ var CRDsValidation map[string]string = map[string]string{
                             a random generated uid.
                           type: string
                       type: object
+                    guestOS:
+                      description: GuestOS describes the operating system of the guest.
+                        It is a hint used to pick the defaults of the disk bus, the
+                        network interface model, the tablet and the Hyper-V features,
+                        if they are not set.
+                      properties:
+                        osFamily:
+                          description: 'OSFamily of the guest operating system. One
+                            of: linux, windows'
+                          type: string
+                        osVersion:
+                          description: OSVersion of the guest operating system, e.g.
+                            2008 or 10 for windows.
+                          type: string
+                      type: object
                     ioThreadsPolicy:
                       description: 'Controls whether or not disks will share IOThreads.
                         Omitting IOThreadsPolicy disables use of IOThreads. One of:
@@ -10980,6 +13309,45 @@ var CRDsValidation map[string]string = map[string]string{
                     machine:
                       description: Machine type.
                       properties:
+                        globals:
+                          description: Globals are QEMU -global options, which set
+                            a property of all devices of a driver. Their names have
+                            the form driver.property. Only the globals allowed in
+                            the QEMUOptionsAllowlist of the KubeVirt configuration
+                            are accepted.
+                          items:
+                            properties:
+                              name:
+                                description: Name of the option.
+                                type: string
+                              value:
+                                description: Value the option is set to.
+                                type: string
+                            required:
+                            - name
+                            - value
+                            type: object
+                          type: array
+                          x-kubernetes-list-type: atomic
+                        options:
+                          description: Options are additional QEMU -machine options,
+                            for hardware quirks of particular guests. Only the options
+                            allowed in the QEMUOptionsAllowlist of the KubeVirt configuration
+                            are accepted.
+                          items:
+                            properties:
+                              name:
+                                description: Name of the option.
+                                type: string
+                              value:
+                                description: Value the option is set to.
+                                type: string
+                            required:
+                            - name
+                            - value
+                            type: object
+                          type: array
+                          x-kubernetes-list-type: atomic
                         type:
                           description: QEMU machine type is the actual chipset of
                             the VirtualMachineInstance.
@@ -11054,6 +13422,23 @@ var CRDsValidation map[string]string = map[string]string{
                     the hostname will be set to the name of the vmi, if dhcp or cloud-init
                     is configured properly.
                   type: string
+                launcherPodMetadata:
+                  description: LauncherPodMetadata contains additional labels and
+                    annotations for the virt-launcher pod.
+                  properties:
+                    annotations:
+                      additionalProperties:
+                        type: string
+                      description: Annotations which are added to the virt-launcher
+                        pod. Annotations in the kubevirt.io domain are reserved.
+                      type: object
+                    labels:
+                      additionalProperties:
+                        type: string
+                      description: Labels which are added to the virt-launcher pod.
+                        Labels in the kubevirt.io domain are reserved.
+                      type: object
+                  type: object
                 livenessProbe:
                   description: 'Periodic probe of VirtualMachineInstance liveness.
                     VirtualmachineInstances will be stopped if the probe fails. Cannot
@@ -11358,11 +13743,119 @@ var CRDsValidation map[string]string = map[string]string{
                       format: int32
                       type: integer
                   type: object
+                runtimeClassName:
+                  description: If specified, the virt-launcher pod will be run with
+                    the referenced RuntimeClass. If not specified, the default runtime
+                    class of the KubeVirt configuration is used.
+                  type: string
                 schedulerName:
                   description: If specified, the VMI will be dispatched by specified
                     scheduler. If not specified, the VMI will be dispatched by default
                     scheduler.
                   type: string
+                schedulingReadinessGates:
+                  description: SchedulingReadinessGates lists conditions which have
+                    to be set to True on the VirtualMachineInstance, usually by third-party
+                    controllers, before the virt-launcher pod is created.
+                  items:
+                    description: SchedulingReadinessGate refers to a condition on
+                      the VirtualMachineInstance which gates the creation of its pod.
+                    properties:
+                      conditionType:
+                        description: ConditionType refers to a condition in the VirtualMachineInstance's
+                          condition list with matching type.
+                        type: string
+                    required:
+                    - conditionType
+                    type: object
+                  type: array
+                  x-kubernetes-list-type: atomic
+                sidecars:
+                  description: Sidecars are user containers which are added to the
+                    virt-launcher pod. They share the lifecycle of the VirtualMachineInstance.
+                  items:
+                    description: Sidecar is a user container which runs next to the
+                      virtual machine in the virt-launcher pod, e.g. a log shipper
+                      or a license daemon.
+                    properties:
+                      args:
+                        description: Arguments to the entrypoint. The CMD of the image
+                          is used if this is not provided.
+                        items:
+                          type: string
+                        type: array
+                      command:
+                        description: Entrypoint array. The ENTRYPOINT of the image
+                          is used if this is not provided.
+                        items:
+                          type: string
+                        type: array
+                      image:
+                        description: Image of the sidecar container.
+                        type: string
+                      imagePullPolicy:
+                        description: Image pull policy of the sidecar container.
+                        type: string
+                      name:
+                        description: Name of the sidecar container, unique within
+                          the VirtualMachineInstance.
+                        type: string
+                      resources:
+                        description: Resources are the cpu and memory requests and
+                          limits of the sidecar container. They are accounted for
+                          on top of the resources of the virtual machine.
+                        properties:
+                          limits:
+                            additionalProperties:
+                              anyOf:
+                              - type: integer
+                              - type: string
+                              pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                              x-kubernetes-int-or-string: true
+                            description: 'Limits describes the maximum amount of compute
+                              resources allowed. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/'
+                            type: object
+                          requests:
+                            additionalProperties:
+                              anyOf:
+                              - type: integer
+                              - type: string
+                              pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                              x-kubernetes-int-or-string: true
+                            description: 'Requests describes the minimum amount of
+                              compute resources required. If Requests is omitted for
+                              a container, it defaults to Limits if that is explicitly
+                              specified, otherwise to an implementation-defined value.
+                              More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/'
+                            type: object
+                        type: object
+                      volumeMounts:
+                        description: VolumeMounts share volumes of the VirtualMachineInstance
+                          read-only with the sidecar container.
+                        items:
+                          description: SidecarVolumeMount mounts a volume of the VirtualMachineInstance
+                            into a sidecar container.
+                          properties:
+                            mountPath:
+                              description: MountPath is the path within the sidecar
+                                container at which the volume is mounted.
+                              type: string
+                            name:
+                              description: Name of the VirtualMachineInstance volume.
+                              type: string
+                          required:
+                          - mountPath
+                          - name
+                          type: object
+                        type: array
+                    required:
+                    - image
+                    - name
+                    type: object
+                  type: array
+                  x-kubernetes-list-map-keys:
+                  - name
+                  x-kubernetes-list-type: map
                 startStrategy:
                   description: StartStrategy can be set to "Paused" if Virtual Machine
                     should be started in paused state.
@@ -11420,6 +13913,113 @@ var CRDsValidation map[string]string = map[string]string{
                         type: string
                     type: object
                   type: array
+                topologySpreadConstraints:
+                  description: TopologySpreadConstraints describes how the virt-launcher
+                    pod ought to spread across topology domains. All topologySpreadConstraints
+                    are ANDed.
+                  items:
+                    description: TopologySpreadConstraint specifies how to spread
+                      matching pods among the given topology.
+                    properties:
+                      labelSelector:
+                        description: LabelSelector is used to find matching pods.
+                          Pods that match this label selector are counted to determine
+                          the number of pods in their corresponding topology domain.
+                        properties:
+                          matchExpressions:
+                            description: matchExpressions is a list of label selector
+                              requirements. The requirements are ANDed.
+                            items:
+                              description: A label selector requirement is a selector
+                                that contains values, a key, and an operator that
+                                relates the key and values.
+                              properties:
+                                key:
+                                  description: key is the label key that the selector
+                                    applies to.
+                                  type: string
+                                operator:
+                                  description: operator represents a key's relationship
+                                    to a set of values. Valid operators are In, NotIn,
+                                    Exists and DoesNotExist.
+                                  type: string
+                                values:
+                                  description: values is an array of string values.
+                                    If the operator is In or NotIn, the values array
+                                    must be non-empty. If the operator is Exists or
+                                    DoesNotExist, the values array must be empty.
+                                    This array is replaced during a strategic merge
+                                    patch.
+                                  items:
+                                    type: string
+                                  type: array
+                              required:
+                              - key
+                              - operator
+                              type: object
+                            type: array
+                          matchLabels:
+                            additionalProperties:
+                              type: string
+                            description: matchLabels is a map of {key,value} pairs.
+                              A single {key,value} in the matchLabels map is equivalent
+                              to an element of matchExpressions, whose key field is
+                              "key", the operator is "In", and the values array contains
+                              only "value". The requirements are ANDed.
+                            type: object
+                        type: object
+                      maxSkew:
+                        description: 'MaxSkew describes the degree to which pods may
+                          be unevenly distributed. When ` + "`" + `whenUnsatisfiable=DoNotSchedule` + "`" + `,
+                          it is the maximum permitted difference between the number
+                          of matching pods in the target topology and the global minimum.
+                          For example, in a 3-zone cluster, MaxSkew is set to 1, and
+                          pods with the same labelSelector spread as 1/1/0: | zone1
+                          | zone2 | zone3 | |   P   |   P   |       | - if MaxSkew
+                          is 1, incoming pod can only be scheduled to zone3 to become
+                          1/1/1; scheduling it onto zone1(zone2) would make the ActualSkew(2-0)
+                          on zone1(zone2) violate MaxSkew(1). - if MaxSkew is 2, incoming
+                          pod can be scheduled onto any zone. When ` + "`" + `whenUnsatisfiable=ScheduleAnyway` + "`" + `,
+                          it is used to give higher precedence to topologies that
+                          satisfy it. It''s a required field. Default value is 1 and
+                          0 is not allowed.'
+                        format: int32
+                        type: integer
+                      topologyKey:
+                        description: TopologyKey is the key of node labels. Nodes
+                          that have a label with this key and identical values are
+                          considered to be in the same topology. We consider each
+                          <key, value> as a "bucket", and try to put balanced number
+                          of pods into each bucket. It's a required field.
+                        type: string
+                      whenUnsatisfiable:
+                        description: 'WhenUnsatisfiable indicates how to deal with
+                          a pod if it doesn''t satisfy the spread constraint. - DoNotSchedule
+                          (default) tells the scheduler not to schedule it. - ScheduleAnyway
+                          tells the scheduler to schedule the pod in any location,
+                          but giving higher precedence to topologies that would help
+                          reduce the skew. A constraint is considered "Unsatisfiable"
+                          for an incoming pod if and only if every possible node assigment
+                          for that pod would violate "MaxSkew" on some topology. For
+                          example, in a 3-zone cluster, MaxSkew is set to 1, and pods
+                          with the same labelSelector spread as 3/1/1: | zone1 | zone2
+                          | zone3 | | P P P |   P   |   P   | If WhenUnsatisfiable
+                          is set to DoNotSchedule, incoming pod can only be scheduled
+                          to zone2(zone3) to become 3/2/1(3/1/2) as ActualSkew(2-1)
+                          on zone2(zone3) satisfies MaxSkew(1). In other words, the
+                          cluster can still be imbalanced, but scheduler won''t make
+                          it *more* imbalanced. It''s a required field.'
+                        type: string
+                    required:
+                    - maxSkew
+                    - topologyKey
+                    - whenUnsatisfiable
+                    type: object
+                  type: array
+                  x-kubernetes-list-map-keys:
+                  - topologyKey
+                  - whenUnsatisfiable
+                  x-kubernetes-list-type: map
                 volumes:
                   description: List of volumes that can be mounted by disks belonging
                     to the vmi.
@@ -11539,6 +14139,17 @@ var CRDsValidation map[string]string = map[string]string{
                             description: Image is the name of the image with the embedded
                               disk.
                             type: string
+                          imageDigestPolicy:
+                            description: ImageDigestPolicy controls whether the image
+                              has to be referenced by digest. With Require, images
+                              have to be referenced by digest. With Pin, virt-controller
+                              resolves images referenced by tag to their digest when
+                              it starts the VirtualMachine and records them in its
+                              status, so that restarts of the VirtualMachine always
+                              boot the same image. VirtualMachineInstances which are
+                              not created by a VirtualMachine must reference such
+                              images by digest.
+                            type: string
                           imagePullPolicy:
                             description: 'Image pull policy. One of Always, Never,
                               IfNotPresent. Defaults to Always if :latest tag is specified,
@@ -11670,6 +14281,14 @@ var CRDsValidation map[string]string = map[string]string{
                             description: Capacity of the sparse disk.
                             pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
                             x-kubernetes-int-or-string: true
+                          imageFormat:
+                            description: ImageFormat is the on-disk format of the
+                              created image. Defaults to qcow2.
+                            type: string
+                          preallocation:
+                            description: Preallocation defines how space for the created
+                              image is allocated. Defaults to off.
+                            type: string
                         required:
                         - capacity
                         type: object
@@ -11678,6 +14297,13 @@ var CRDsValidation map[string]string = map[string]string{
                           specified source and provides copy-on-write image on top
                           of it.
                         properties:
+                          imageFormat:
+                            description: ImageFormat is the format of the local image
+                              the PVC is exposed through. With qcow2 a copy-on-write
+                              overlay on top of the PVC is created, with raw the PVC
+                              content is converted into a standalone local copy. Defaults
+                              to qcow2.
+                            type: string
                           persistentVolumeClaim:
                             description: 'PersistentVolumeClaimVolumeSource represents
                               a reference to a PersistentVolumeClaim in the same namespace.
@@ -11695,6 +14321,10 @@ var CRDsValidation map[string]string = map[string]string{
                             required:
                             - claimName
                             type: object
+                          preallocation:
+                            description: Preallocation defines how space for the local
+                              image is allocated. Defaults to off.
+                            type: string
                         type: object
                       hostDisk:
                         description: HostDisk represents a disk created on the cluster
@@ -11711,6 +14341,11 @@ var CRDsValidation map[string]string = map[string]string{
                             description: The path to HostDisk image located on the
                               cluster
                             type: string
+                          preallocation:
+                            description: Preallocation defines how space for the raw
+                              disk.img is allocated when it gets created. Only 'off',
+                              'falloc' and 'full' are supported. Defaults to off.
+                            type: string
                           shared:
                             description: Shared indicate whether the path is shared
                               between nodes
@@ -11723,10 +14358,89 @@ var CRDsValidation map[string]string = map[string]string{
                         - path
                         - type
                         type: object
+                      memoryDump:
+                        description: MemoryDump represents a PersistentVolumeClaim
+                          the memory state of the vmi is saved to. It is hotplugged
+                          into the virt-launcher pod without being attached to the
+                          vmi as a disk.
+                        properties:
+                          claimName:
+                            description: 'ClaimName is the name of a PersistentVolumeClaim
+                              in the same namespace as the pod using this volume.
+                              More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
+                            type: string
+                          hotpluggable:
+                            description: Hotpluggable indicates whether the volume
+                              can be hotplugged and hotunplugged.
+                            type: boolean
+                          preallocation:
+                            description: Preallocation defines how space for the raw
+                              disk.img is allocated when it gets created on an empty
+                              filesystem PVC. Only 'off', 'falloc' and 'full' are
+                              supported. Defaults to off.
+                            type: string
+                          readOnly:
+                            description: Will force the ReadOnly setting in VolumeMounts.
+                              Default false.
+                            type: boolean
+                        required:
+                        - claimName
+                        type: object
                       name:
                         description: 'Volume''s name. Must be a DNS_LABEL and unique
                           within the vmi. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names'
                         type: string
+                      networkDisk:
+                        description: NetworkDisk represents a disk which is accessed
+                          by QEMU directly over the network (iSCSI, NBD or RBD), without
+                          attaching it to the node or mounting it into the virt-launcher
+                          pod.
+                        properties:
+                          auth:
+                            description: Auth holds the credentials to access the
+                              disk. Only supported for iscsi and rbd.
+                            properties:
+                              secretRef:
+                                description: SecretRef is the name of a secret in
+                                  the namespace of the vmi, which holds the CHAP password
+                                  (iscsi) or the cephx key (rbd) in its "password"
+                                  key.
+                                type: string
+                              username:
+                                description: Username to authenticate with.
+                                type: string
+                            required:
+                            - secretRef
+                            - username
+                            type: object
+                          host:
+                            description: Host serving the disk.
+                            properties:
+                              name:
+                                description: Name is the hostname or IP address of
+                                  the host.
+                                type: string
+                              port:
+                                description: Port of the host. Defaults to the default
+                                  port of the protocol.
+                                type: string
+                            required:
+                            - name
+                            type: object
+                          name:
+                            description: Name of the disk on the storage system. For
+                              iscsi it is the target IQN and LUN (<iqn>/<lun>), for
+                              rbd the pool and image (<pool>/<image>) and for nbd
+                              the optional export name.
+                            type: string
+                          protocol:
+                            description: 'Protocol used to access the disk. One of:
+                              iscsi, nbd, rbd.'
+                            type: string
+                        required:
+                        - host
+                        - protocol
+                        type: object
                       persistentVolumeClaim:
                         description: 'PersistentVolumeClaimVolumeSource represents
                           a reference to a PersistentVolumeClaim in the same namespace.
@@ -11741,6 +14455,12 @@ var CRDsValidation map[string]string = map[string]string{
                             description: Hotpluggable indicates whether the volume
                               can be hotplugged and hotunplugged.
                             type: boolean
+                          preallocation:
+                            description: Preallocation defines how space for the raw
+                              disk.img is allocated when it gets created on an empty
+                              filesystem PVC. Only 'off', 'falloc' and 'full' are
+                              supported. Defaults to off.
+                            type: string
                           readOnly:
                             description: Will force the ReadOnly setting in VolumeMounts.
                               Default false.
@@ -11811,6 +14531,29 @@ var CRDsValidation map[string]string = map[string]string{
               - domain
               type: object
           type: object
+        topologySpread:
+          description: TopologySpread injects topology spread constraints into the
+            created VirtualMachineInstances, to spread the replicas across the given
+            topology domains.
+          properties:
+            maxSkew:
+              description: MaxSkew is the maximum permitted difference of replicas
+                between two topology domains. Defaults to 1.
+              format: int32
+              type: integer
+            topologyKeys:
+              description: TopologyKeys are the node label keys the replicas are spread
+                across. Defaults to topology.kubernetes.io/zone.
+              items:
+                type: string
+              type: array
+              x-kubernetes-list-type: set
+            whenUnsatisfiable:
+              description: WhenUnsatisfiable indicates how to deal with a replica
+                if it doesn't satisfy the spread constraint. One of DoNotSchedule
+                or ScheduleAnyway. Defaults to ScheduleAnyway.
+              type: string
+          type: object
       required:
       - selector
       - template
@@ -11880,8 +14623,38 @@ var CRDsValidation map[string]string = map[string]string{
     spec:
       description: VirtualMachineRestoreSpec is the spec for a VirtualMachineRestoreresource
       properties:
+        identity:
+          description: Identity defines which identifying values of the snapshotted
+            VirtualMachine are preserved and which are regenerated, e.g. when the
+            snapshot is restored into a new VirtualMachine
+          properties:
+            firmwareSerial:
+              description: FirmwareSerial is the SMBIOS serial number of the firmware
+              type: string
+            firmwareUUID:
+              description: FirmwareUUID is the SMBIOS UUID of the firmware
+              type: string
+            macAddresses:
+              description: MACAddresses of the interfaces
+              type: string
+          type: object
+        storageClassMappings:
+          items:
+            description: StorageClassMapping restores volumes of the source StorageClass
+              to the target StorageClass
+            properties:
+              source:
+                type: string
+              target:
+                type: string
+            required:
+            - source
+            - target
+            type: object
+          type: array
         target:
-          description: initially only VirtualMachine type supported
+          description: initially only VirtualMachine type supported a VirtualMachine
+            that does not exist is created from the snapshot
           properties:
             apiGroup:
               description: APIGroup is the group for the resource being referenced.
@@ -11964,6 +14737,54 @@ var CRDsValidation map[string]string = map[string]string{
   required:
   - spec
   type: object
+`,
+	"virtualmachineschedulinghints": `openAPIV3Schema:
+  description: VirtualMachineSchedulingHints carries placement hints written by an
+    external placement engine for the VirtualMachineInstance with the same name and
+    namespace. The hints are folded into the affinity of the launcher pod when it
+    is created, already running pods are not affected.
+  properties:
+    apiVersion:
+      description: 'APIVersion defines the versioned schema of this representation
+        of an object. Servers should convert recognized schemas to the latest internal
+        value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+      type: string
+    kind:
+      description: 'Kind is a string value representing the REST resource this object
+        represents. Servers may infer this from the endpoint the client submits requests
+        to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+      type: string
+    metadata:
+      type: object
+    spec:
+      description: Spec contains the scheduling hints.
+      properties:
+        preferredNodes:
+          description: PreferredNodes is a weighted list of nodes the VirtualMachineInstance
+            should preferably be scheduled to.
+          items:
+            properties:
+              nodeName:
+                description: NodeName is the name of the preferred node.
+                type: string
+              weight:
+                description: Weight associated with the node, in the range 1-100.
+                format: int32
+                type: integer
+            required:
+            - nodeName
+            - weight
+            type: object
+          type: array
+          x-kubernetes-list-type: atomic
+        spreadGroup:
+          description: SpreadGroup places the launcher pod in a group whose members
+            are preferably scheduled to different nodes.
+          type: string
+      type: object
+  required:
+  - spec
+  type: object
 `,
 	"virtualmachinesnapshot": `openAPIV3Schema:
   description: VirtualMachineSnapshot defines the operation of snapshotting a VM
@@ -11993,6 +14814,49 @@ var CRDsValidation map[string]string = map[string]string{
             snapshot to take. In case we pass this deadline we mark this snapshot
             as failed. Defaults to DefaultFailureDeadline - 5min
           type: string
+        memory:
+          description: Memory requests the memory state of an online vm to be saved
+            alongside the volume snapshots.
+          properties:
+            objectStorage:
+              description: ObjectStorage streams the memory state to an S3 compatible
+                bucket instead of saving it to a PersistentVolumeClaim.
+              properties:
+                bucket:
+                  description: Bucket the memory state is uploaded to
+                  type: string
+                endpoint:
+                  description: Endpoint is the URL of the S3 compatible service
+                  type: string
+                region:
+                  description: Region of the bucket, defaults to us-east-1
+                  type: string
+                secretRef:
+                  description: SecretRef references a Secret in the namespace of the
+                    snapshot with the accessKeyId and secretAccessKey of the bucket.
+                    The user creating the snapshot has to be allowed to get the Secret,
+                    and virt-controller has to be granted get access to it, e.g. through
+                    a RoleBinding in the namespace of the snapshot
+                  properties:
+                    name:
+                      description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                        TODO: Add other useful fields. apiVersion, kind, uid?'
+                      type: string
+                  type: object
+              required:
+              - endpoint
+              - bucket
+              - secretRef
+              type: object
+            storageClassName:
+              description: StorageClassName of the PersistentVolumeClaim the memory
+                state is saved to. Defaults to the default storage class of the cluster
+              type: string
+          type: object
+        quiescePolicy:
+          description: QuiescePolicy defines whether freezing the guest file systems
+            of an online vm is required for the snapshot to succeed. Defaults to BestEffort
+          type: string
         source:
           description: TypedLocalObjectReference contains enough information to let
             you locate the typed referenced object inside the same namespace.
@@ -12012,6 +14876,10 @@ var CRDsValidation map[string]string = map[string]string{
           - kind
           - name
           type: object
+        ttl:
+          description: TTL is the time a succeeded snapshot is kept before it is deleted
+            automatically. Snapshots without a TTL never expire.
+          type: string
       required:
       - source
       type: object
@@ -12101,6 +14969,176 @@ var CRDsValidation map[string]string = map[string]string{
       description: VirtualMachineSnapshotContentSpec is the spec for a VirtualMachineSnapshotContent
         resource
       properties:
+        memoryBackup:
+          description: MemoryBackup contains the data needed to locate the saved memory
+            state of a vm
+          properties:
+            objectKey:
+              description: ObjectKey is the key of the object in the bucket of ObjectStorage
+              type: string
+            objectStorage:
+              description: MemoryObjectStorage is an S3 compatible bucket the memory
+                state is uploaded to
+              properties:
+                bucket:
+                  description: Bucket the memory state is uploaded to
+                  type: string
+                endpoint:
+                  description: Endpoint is the URL of the S3 compatible service
+                  type: string
+                region:
+                  description: Region of the bucket, defaults to us-east-1
+                  type: string
+                secretRef:
+                  description: SecretRef references a Secret in the namespace of the
+                    snapshot with the accessKeyId and secretAccessKey of the bucket.
+                    The user creating the snapshot has to be allowed to get the Secret,
+                    and virt-controller has to be granted get access to it, e.g. through
+                    a RoleBinding in the namespace of the snapshot
+                  properties:
+                    name:
+                      description: 'Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+                        TODO: Add other useful fields. apiVersion, kind, uid?'
+                      type: string
+                  type: object
+              required:
+              - endpoint
+              - bucket
+              - secretRef
+              type: object
+            persistentVolumeClaim:
+              properties:
+                metadata:
+                  description: 'Standard object''s metadata. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata'
+                  type: object
+                  x-kubernetes-preserve-unknown-fields: true
+                spec:
+                  description: 'Spec defines the desired characteristics of a volume
+                    requested by a pod author. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
+                  properties:
+                    accessModes:
+                      description: 'AccessModes contains the desired access modes
+                        the volume should have. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#access-modes-1'
+                      items:
+                        type: string
+                      type: array
+                    dataSource:
+                      description: 'This field can be used to specify either: * An
+                        existing VolumeSnapshot object (snapshot.storage.k8s.io/VolumeSnapshot)
+                        * An existing PVC (PersistentVolumeClaim) * An existing custom
+                        resource that implements data population (Alpha) In order
+                        to use custom resource types that implement data population,
+                        the AnyVolumeDataSource feature gate must be enabled. If the
+                        provisioner or an external controller can support the specified
+                        data source, it will create a new volume based on the contents
+                        of the specified data source.'
+                      properties:
+                        apiGroup:
+                          description: APIGroup is the group for the resource being
+                            referenced. If APIGroup is not specified, the specified
+                            Kind must be in the core API group. For any other third-party
+                            types, APIGroup is required.
+                          type: string
+                        kind:
+                          description: Kind is the type of resource being referenced
+                          type: string
+                        name:
+                          description: Name is the name of resource being referenced
+                          type: string
+                      required:
+                      - kind
+                      - name
+                      type: object
+                    resources:
+                      description: 'Resources represents the minimum resources the
+                        volume should have. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#resources'
+                      properties:
+                        limits:
+                          additionalProperties:
+                            anyOf:
+                            - type: integer
+                            - type: string
+                            pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                            x-kubernetes-int-or-string: true
+                          description: 'Limits describes the maximum amount of compute
+                            resources allowed. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/'
+                          type: object
+                        requests:
+                          additionalProperties:
+                            anyOf:
+                            - type: integer
+                            - type: string
+                            pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                            x-kubernetes-int-or-string: true
+                          description: 'Requests describes the minimum amount of compute
+                            resources required. If Requests is omitted for a container,
+                            it defaults to Limits if that is explicitly specified,
+                            otherwise to an implementation-defined value. More info:
+                            https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/'
+                          type: object
+                      type: object
+                    selector:
+                      description: A label query over volumes to consider for binding.
+                      properties:
+                        matchExpressions:
+                          description: matchExpressions is a list of label selector
+                            requirements. The requirements are ANDed.
+                          items:
+                            description: A label selector requirement is a selector
+                              that contains values, a key, and an operator that relates
+                              the key and values.
+                            properties:
+                              key:
+                                description: key is the label key that the selector
+                                  applies to.
+                                type: string
+                              operator:
+                                description: operator represents a key's relationship
+                                  to a set of values. Valid operators are In, NotIn,
+                                  Exists and DoesNotExist.
+                                type: string
+                              values:
+                                description: values is an array of string values.
+                                  If the operator is In or NotIn, the values array
+                                  must be non-empty. If the operator is Exists or
+                                  DoesNotExist, the values array must be empty. This
+                                  array is replaced during a strategic merge patch.
+                                items:
+                                  type: string
+                                type: array
+                            required:
+                            - key
+                            - operator
+                            type: object
+                          type: array
+                        matchLabels:
+                          additionalProperties:
+                            type: string
+                          description: matchLabels is a map of {key,value} pairs.
+                            A single {key,value} in the matchLabels map is equivalent
+                            to an element of matchExpressions, whose key field is
+                            "key", the operator is "In", and the values array contains
+                            only "value". The requirements are ANDed.
+                          type: object
+                      type: object
+                    storageClassName:
+                      description: 'Name of the StorageClass required by the claim.
+                        More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#class-1'
+                      type: string
+                    volumeMode:
+                      description: volumeMode defines what type of volume is required
+                        by the claim. Value of Filesystem is implied when not included
+                        in claim spec.
+                      type: string
+                    volumeName:
+                      description: VolumeName is the binding reference to the PersistentVolume
+                        backing this claim.
+                      type: string
+                  type: object
+              type: object
+            volumeName:
+              type: string
+          type: object
         source:
           description: SourceSpec contains the appropriate spec for the resource being
             snapshotted
@@ -12129,6 +15167,11 @@ var CRDsValidation map[string]string = map[string]string{
                   description: Spec contains the specification of VirtualMachineInstance
                     created
                   properties:
+                    changeApplyPolicy:
+                      description: ChangeApplyPolicy defines how changes of the template
+                        are applied to the running VirtualMachineInstance. Defaults
+                        to Manual, which only reports the pending changes in the status.
+                      type: string
                     dataVolumeTemplates:
                       description: dataVolumeTemplates is a list of dataVolumes that
                         the VirtualMachineInstance template can reference. DataVolumes
@@ -13760,10 +16803,48 @@ var CRDsValidation map[string]string = map[string]string{
                                         multi-queue for block devices. Defaults to
                                         false.
                                       type: boolean
+                                    channels:
+                                      description: Channels are additional virtio-serial
+                                        channels of the vmi. Each channel is backed
+                                        by a unix socket in the virt-launcher pod,
+                                        which is shared with the sidecars of the vmi.
+                                      items:
+                                        description: SerialChannel is a virtio-serial
+                                          channel between the guest and the virt-launcher
+                                          pod. The socket of the channel is created
+                                          at <channel socket directory>/<name>.sock,
+                                          where sidecars can connect to it to exchange
+                                          data with the guest without networking,
+                                          e.g. to collect logs.
+                                        properties:
+                                          name:
+                                            description: Name of the channel, unique
+                                              within the vmi. It names the socket
+                                              of the channel.
+                                            type: string
+                                          target:
+                                            description: Target is the name of the
+                                              virtio-serial port in the guest, e.g.
+                                              org.example.log.0.
+                                            type: string
+                                        required:
+                                        - name
+                                        - target
+                                        type: object
+                                      type: array
+                                      x-kubernetes-list-type: atomic
                                     clientPassthrough:
                                       description: To configure and access client
                                         devices such as redirecting USB
                                       type: object
+                                    detachGPUsForMigration:
+                                      description: Whether to detach the GPUs for
+                                        a live migration. The GPUs are hot-unplugged
+                                        and the guest is paused until the GPUs of
+                                        the target are hot-plugged. The guest has
+                                        to support the hot-unplug of its GPUs. Without
+                                        it, VMIs with GPUs are not live migratable.
+                                      type: boolean
                                     disableHotplug:
                                       description: DisableHotplug disabled the ability
                                         to hotplug disks.
@@ -13844,6 +16925,20 @@ var CRDsValidation map[string]string = map[string]string{
                                               Thread. Enabling this implies useIOThreads
                                               = true. Defaults to false.
                                             type: boolean
+                                          detectZeroes:
+                                            description: 'DetectZeroes specifies whether
+                                              QEMU detects writes of zeroes and optimizes
+                                              them. Supported values are: off, on,
+                                              unmap. Defaults to unmap on thin-provisioned
+                                              block volumes with discard enabled.'
+                                            type: string
+                                          discard:
+                                            description: 'Discard specifies whether
+                                              discard requests from the guest are
+                                              passed to the storage. Supported values
+                                              are: unmap, ignore. Defaults to unmap
+                                              unless the volume is preallocated.'
+                                            type: string
                                           disk:
                                             description: Attach a volume as a disk
                                               to the vmi.
@@ -13906,6 +17001,16 @@ var CRDsValidation map[string]string = map[string]string{
                                               to specify a serial number for the disk
                                               device.
                                             type: string
+                                          shareable:
+                                            description: Shareable indicates whether
+                                              the disk can be shared between multiple
+                                              VMIs, e.g. for clustered file systems.
+                                              A shareable disk must be backed by a
+                                              block PersistentVolumeClaim with the
+                                              ReadWriteMany access mode, it is attached
+                                              without a host side cache and without
+                                              an ephemeral overlay. Defaults to false.
+                                            type: boolean
                                           tag:
                                             description: If specified, disk address
                                               and its tag will be provided to the
@@ -13943,6 +17048,23 @@ var CRDsValidation map[string]string = map[string]string{
                                             description: Name of the GPU device as
                                               exposed by a device plugin
                                             type: string
+                                          pciOrder:
+                                            description: PCIOrder is an integer value
+                                              > 0, used to determine the order of
+                                              the guest PCI addresses of the GPUs
+                                              and host devices. Lower values get lower
+                                              addresses, devices without a PCI order
+                                              are placed after the ordered ones. Each
+                                              GPU or host device that has a PCI order
+                                              must have a unique value.
+                                            type: integer
+                                          role:
+                                            description: Role labels the device for
+                                              the guest, e.g. render or compute. It
+                                              is reported together with the guest
+                                              PCI address of the device in the VMI
+                                              status.
+                                            type: string
                                         required:
                                         - deviceName
                                         - name
@@ -13961,6 +17083,23 @@ var CRDsValidation map[string]string = map[string]string{
                                             type: string
                                           name:
                                             type: string
+                                          pciOrder:
+                                            description: PCIOrder is an integer value
+                                              > 0, used to determine the order of
+                                              the guest PCI addresses of the GPUs
+                                              and host devices. Lower values get lower
+                                              addresses, devices without a PCI order
+                                              are placed after the ordered ones. Each
+                                              GPU or host device that has a PCI order
+                                              must have a unique value.
+                                            type: integer
+                                          role:
+                                            description: Role labels the device for
+                                              the guest, e.g. render or compute. It
+                                              is reported together with the guest
+                                              PCI address of the device in the VMI
+                                              status.
+                                            type: string
                                         required:
                                         - deviceName
                                         - name
@@ -13993,6 +17132,47 @@ var CRDsValidation map[string]string = map[string]string{
                                         which are added to the vmi.
                                       items:
                                         properties:
+                                          bandwidth:
+                                            anyOf:
+                                            - type: integer
+                                            - type: string
+                                            description: Bandwidth is the throughput
+                                              the interface is expected to need, in
+                                              bits per second, for example 10G. The
+                                              VMI reserves it from the node bandwidth
+                                              of the cluster network configuration,
+                                              nodes without enough unreserved bandwidth
+                                              are not considered for scheduling.
+                                            pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                            x-kubernetes-int-or-string: true
+                                          bandwidthLimit:
+                                            description: BandwidthLimit shapes the
+                                              traffic of the interface. It can be
+                                              changed while the VMI is running, virt-launcher
+                                              applies the new limits to the running
+                                              domain. Not supported on interfaces
+                                              with the SR-IOV, slirp or vhostuser
+                                              binding.
+                                            properties:
+                                              inbound:
+                                                anyOf:
+                                                - type: integer
+                                                - type: string
+                                                description: Inbound is the throughput
+                                                  the guest can receive, in bits per
+                                                  second, for example 100M.
+                                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                x-kubernetes-int-or-string: true
+                                              outbound:
+                                                anyOf:
+                                                - type: integer
+                                                - type: string
+                                                description: Outbound is the throughput
+                                                  the guest can send, in bits per
+                                                  second, for example 100M.
+                                                pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                                x-kubernetes-int-or-string: true
+                                            type: object
                                           bootOrder:
                                             description: BootOrder is an integer value
                                               > 0, used to determine ordering of boot
@@ -14012,6 +17192,14 @@ var CRDsValidation map[string]string = map[string]string{
                                                 description: If specified will pass
                                                   option 67 to interface's DHCP server
                                                 type: string
+                                              leaseTime:
+                                                description: If specified will pass
+                                                  the lease time to the VM via DHCP
+                                                  option 051. Defaults to an hourly
+                                                  renewed lease if the address of
+                                                  the interface can change, to an
+                                                  infinite lease otherwise.
+                                                type: string
                                               ntpServers:
                                                 description: If specified will pass
                                                   the configured NTP server to the
@@ -14132,6 +17320,18 @@ var CRDsValidation map[string]string = map[string]string{
                                       description: Whether to have random number generator
                                         from host
                                       type: object
+                                    scsiController:
+                                      description: SCSIController configures the controller
+                                        of the disks with the scsi bus. If blockMultiQueue
+                                        is enabled, a virtio-scsi controller gets
+                                        one queue per vCPU.
+                                      properties:
+                                        model:
+                                          description: 'Model of the SCSI controller.
+                                            One of: virtio-scsi, lsilogic. Defaults
+                                            to virtio-scsi.'
+                                          type: string
+                                      type: object
                                     useVirtioTransitional:
                                       description: Fall back to legacy virtio 0.9
                                         support if virtio bus is selected on devices.
@@ -14477,6 +17677,22 @@ var CRDsValidation map[string]string = map[string]string{
                                         Defaults to a random generated uid.
                                       type: string
                                   type: object
+                                guestOS:
+                                  description: GuestOS describes the operating system
+                                    of the guest. It is a hint used to pick the defaults
+                                    of the disk bus, the network interface model,
+                                    the tablet and the Hyper-V features, if they are
+                                    not set.
+                                  properties:
+                                    osFamily:
+                                      description: 'OSFamily of the guest operating
+                                        system. One of: linux, windows'
+                                      type: string
+                                    osVersion:
+                                      description: OSVersion of the guest operating
+                                        system, e.g. 2008 or 10 for windows.
+                                      type: string
+                                  type: object
                                 ioThreadsPolicy:
                                   description: 'Controls whether or not disks will
                                     share IOThreads. Omitting IOThreadsPolicy disables
@@ -14485,6 +17701,45 @@ var CRDsValidation map[string]string = map[string]string{
                                 machine:
                                   description: Machine type.
                                   properties:
+                                    globals:
+                                      description: Globals are QEMU -global options,
+                                        which set a property of all devices of a driver.
+                                        Their names have the form driver.property.
+                                        Only the globals allowed in the QEMUOptionsAllowlist
+                                        of the KubeVirt configuration are accepted.
+                                      items:
+                                        properties:
+                                          name:
+                                            description: Name of the option.
+                                            type: string
+                                          value:
+                                            description: Value the option is set to.
+                                            type: string
+                                        required:
+                                        - name
+                                        - value
+                                        type: object
+                                      type: array
+                                      x-kubernetes-list-type: atomic
+                                    options:
+                                      description: Options are additional QEMU -machine
+                                        options, for hardware quirks of particular
+                                        guests. Only the options allowed in the QEMUOptionsAllowlist
+                                        of the KubeVirt configuration are accepted.
+                                      items:
+                                        properties:
+                                          name:
+                                            description: Name of the option.
+                                            type: string
+                                          value:
+                                            description: Value the option is set to.
+                                            type: string
+                                        required:
+                                        - name
+                                        - value
+                                        type: object
+                                      type: array
+                                      x-kubernetes-list-type: atomic
                                     type:
                                       description: QEMU machine type is the actual
                                         chipset of the VirtualMachineInstance.
@@ -14566,6 +17821,24 @@ var CRDsValidation map[string]string = map[string]string{
                                 specified, the hostname will be set to the name of
                                 the vmi, if dhcp or cloud-init is configured properly.
                               type: string
+                            launcherPodMetadata:
+                              description: LauncherPodMetadata contains additional
+                                labels and annotations for the virt-launcher pod.
+                              properties:
+                                annotations:
+                                  additionalProperties:
+                                    type: string
+                                  description: Annotations which are added to the
+                                    virt-launcher pod. Annotations in the kubevirt.io
+                                    domain are reserved.
+                                  type: object
+                                labels:
+                                  additionalProperties:
+                                    type: string
+                                  description: Labels which are added to the virt-launcher
+                                    pod. Labels in the kubevirt.io domain are reserved.
+                                  type: object
+                              type: object
                             livenessProbe:
                               description: 'Periodic probe of VirtualMachineInstance
                                 liveness. VirtualmachineInstances will be stopped
@@ -14892,11 +18165,132 @@ var CRDsValidation map[string]string = map[string]string{
                                   format: int32
                                   type: integer
                               type: object
+                            runtimeClassName:
+                              description: If specified, the virt-launcher pod will
+                                be run with the referenced RuntimeClass. If not specified,
+                                the default runtime class of the KubeVirt configuration
+                                is used.
+                              type: string
                             schedulerName:
                               description: If specified, the VMI will be dispatched
                                 by specified scheduler. If not specified, the VMI
                                 will be dispatched by default scheduler.
                               type: string
+                            schedulingReadinessGates:
+                              description: SchedulingReadinessGates lists conditions
+                                which have to be set to True on the VirtualMachineInstance,
+                                usually by third-party controllers, before the virt-launcher
+                                pod is created.
+                              items:
+                                description: SchedulingReadinessGate refers to a condition
+                                  on the VirtualMachineInstance which gates the creation
+                                  of its pod.
+                                properties:
+                                  conditionType:
+                                    description: ConditionType refers to a condition
+                                      in the VirtualMachineInstance's condition list
+                                      with matching type.
+                                    type: string
+                                required:
+                                - conditionType
+                                type: object
+                              type: array
+                              x-kubernetes-list-type: atomic
+                            sidecars:
+                              description: Sidecars are user containers which are
+                                added to the virt-launcher pod. They share the lifecycle
+                                of the VirtualMachineInstance.
+                              items:
+                                description: Sidecar is a user container which runs
+                                  next to the virtual machine in the virt-launcher
+                                  pod, e.g. a log shipper or a license daemon.
+                                properties:
+                                  args:
+                                    description: Arguments to the entrypoint. The
+                                      CMD of the image is used if this is not provided.
+                                    items:
+                                      type: string
+                                    type: array
+                                  command:
+                                    description: Entrypoint array. The ENTRYPOINT
+                                      of the image is used if this is not provided.
+                                    items:
+                                      type: string
+                                    type: array
+                                  image:
+                                    description: Image of the sidecar container.
+                                    type: string
+                                  imagePullPolicy:
+                                    description: Image pull policy of the sidecar
+                                      container.
+                                    type: string
+                                  name:
+                                    description: Name of the sidecar container, unique
+                                      within the VirtualMachineInstance.
+                                    type: string
+                                  resources:
+                                    description: Resources are the cpu and memory
+                                      requests and limits of the sidecar container.
+                                      They are accounted for on top of the resources
+                                      of the virtual machine.
+                                    properties:
+                                      limits:
+                                        additionalProperties:
+                                          anyOf:
+                                          - type: integer
+                                          - type: string
+                                          pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                          x-kubernetes-int-or-string: true
+                                        description: 'Limits describes the maximum
+                                          amount of compute resources allowed. More
+                                          info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/'
+                                        type: object
+                                      requests:
+                                        additionalProperties:
+                                          anyOf:
+                                          - type: integer
+                                          - type: string
+                                          pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
+                                          x-kubernetes-int-or-string: true
+                                        description: 'Requests describes the minimum
+                                          amount of compute resources required. If
+                                          Requests is omitted for a container, it
+                                          defaults to Limits if that is explicitly
+                                          specified, otherwise to an implementation-defined
+                                          value. More info: https://kubernetes.io/docs/concepts/configuration/manage-compute-resources-container/'
+                                        type: object
+                                    type: object
+                                  volumeMounts:
+                                    description: VolumeMounts share volumes of the
+                                      VirtualMachineInstance read-only with the sidecar
+                                      container.
+                                    items:
+                                      description: SidecarVolumeMount mounts a volume
+                                        of the VirtualMachineInstance into a sidecar
+                                        container.
+                                      properties:
+                                        mountPath:
+                                          description: MountPath is the path within
+                                            the sidecar container at which the volume
+                                            is mounted.
+                                          type: string
+                                        name:
+                                          description: Name of the VirtualMachineInstance
+                                            volume.
+                                          type: string
+                                      required:
+                                      - mountPath
+                                      - name
+                                      type: object
+                                    type: array
+                                required:
+                                - image
+                                - name
+                                type: object
+                              type: array
+                              x-kubernetes-list-map-keys:
+                              - name
+                              x-kubernetes-list-type: map
                             startStrategy:
                               description: StartStrategy can be set to "Paused" if
                                 Virtual Machine should be started in paused state.
@@ -14960,6 +18354,128 @@ var CRDsValidation map[string]string = map[string]string{
                                     type: string
                                 type: object
                               type: array
+                            topologySpreadConstraints:
+                              description: TopologySpreadConstraints describes how
+                                the virt-launcher pod ought to spread across topology
+                                domains. All topologySpreadConstraints are ANDed.
+                              items:
+                                description: TopologySpreadConstraint specifies how
+                                  to spread matching pods among the given topology.
+                                properties:
+                                  labelSelector:
+                                    description: LabelSelector is used to find matching
+                                      pods. Pods that match this label selector are
+                                      counted to determine the number of pods in their
+                                      corresponding topology domain.
+                                    properties:
+                                      matchExpressions:
+                                        description: matchExpressions is a list of
+                                          label selector requirements. The requirements
+                                          are ANDed.
+                                        items:
+                                          description: A label selector requirement
+                                            is a selector that contains values, a
+                                            key, and an operator that relates the
+                                            key and values.
+                                          properties:
+                                            key:
+                                              description: key is the label key that
+                                                the selector applies to.
+                                              type: string
+                                            operator:
+                                              description: operator represents a key's
+                                                relationship to a set of values. Valid
+                                                operators are In, NotIn, Exists and
+                                                DoesNotExist.
+                                              type: string
+                                            values:
+                                              description: values is an array of string
+                                                values. If the operator is In or NotIn,
+                                                the values array must be non-empty.
+                                                If the operator is Exists or DoesNotExist,
+                                                the values array must be empty. This
+                                                array is replaced during a strategic
+                                                merge patch.
+                                              items:
+                                                type: string
+                                              type: array
+                                          required:
+                                          - key
+                                          - operator
+                                          type: object
+                                        type: array
+                                      matchLabels:
+                                        additionalProperties:
+                                          type: string
+                                        description: matchLabels is a map of {key,value}
+                                          pairs. A single {key,value} in the matchLabels
+                                          map is equivalent to an element of matchExpressions,
+                                          whose key field is "key", the operator is
+                                          "In", and the values array contains only
+                                          "value". The requirements are ANDed.
+                                        type: object
+                                    type: object
+                                  maxSkew:
+                                    description: 'MaxSkew describes the degree to
+                                      which pods may be unevenly distributed. When
+                                      ` + "`" + `whenUnsatisfiable=DoNotSchedule` + "`" + `, it is the
+                                      maximum permitted difference between the number
+                                      of matching pods in the target topology and
+                                      the global minimum. For example, in a 3-zone
+                                      cluster, MaxSkew is set to 1, and pods with
+                                      the same labelSelector spread as 1/1/0: | zone1
+                                      | zone2 | zone3 | |   P   |   P   |       |
+                                      - if MaxSkew is 1, incoming pod can only be
+                                      scheduled to zone3 to become 1/1/1; scheduling
+                                      it onto zone1(zone2) would make the ActualSkew(2-0)
+                                      on zone1(zone2) violate MaxSkew(1). - if MaxSkew
+                                      is 2, incoming pod can be scheduled onto any
+                                      zone. When ` + "`" + `whenUnsatisfiable=ScheduleAnyway` + "`" + `,
+                                      it is used to give higher precedence to topologies
+                                      that satisfy it. It''s a required field. Default
+                                      value is 1 and 0 is not allowed.'
+                                    format: int32
+                                    type: integer
+                                  topologyKey:
+                                    description: TopologyKey is the key of node labels.
+                                      Nodes that have a label with this key and identical
+                                      values are considered to be in the same topology.
+                                      We consider each <key, value> as a "bucket",
+                                      and try to put balanced number of pods into
+                                      each bucket. It's a required field.
+                                    type: string
+                                  whenUnsatisfiable:
+                                    description: 'WhenUnsatisfiable indicates how
+                                      to deal with a pod if it doesn''t satisfy the
+                                      spread constraint. - DoNotSchedule (default)
+                                      tells the scheduler not to schedule it. - ScheduleAnyway
+                                      tells the scheduler to schedule the pod in any
+                                      location, but giving higher precedence to topologies
+                                      that would help reduce the skew. A constraint
+                                      is considered "Unsatisfiable" for an incoming
+                                      pod if and only if every possible node assigment
+                                      for that pod would violate "MaxSkew" on some
+                                      topology. For example, in a 3-zone cluster,
+                                      MaxSkew is set to 1, and pods with the same
+                                      labelSelector spread as 3/1/1: | zone1 | zone2
+                                      | zone3 | | P P P |   P   |   P   | If WhenUnsatisfiable
+                                      is set to DoNotSchedule, incoming pod can only
+                                      be scheduled to zone2(zone3) to become 3/2/1(3/1/2)
+                                      as ActualSkew(2-1) on zone2(zone3) satisfies
+                                      MaxSkew(1). In other words, the cluster can
+                                      still be imbalanced, but scheduler won''t make
+                                      it *more* imbalanced. It''s a required field.'
+                                    type: string
+                                required:
+                                - maxSkew
+                                - topologyKey
+                                - whenUnsatisfiable
+                                type: object
+                              type: array
+                              x-kubernetes-list-map-keys:
+                              - topologyKey
+                              - whenUnsatisfiable
+                              x-kubernetes-list-type: map
                             volumes:
                               description: List of volumes that can be mounted by
                                 disks belonging to the vmi.
@@ -15097,6 +18613,19 @@ var CRDsValidation map[string]string = map[string]string{
                                         description: Image is the name of the image
                                           with the embedded disk.
                                         type: string
+                                      imageDigestPolicy:
+                                        description: ImageDigestPolicy controls whether
+                                          the image has to be referenced by digest.
+                                          With Require, images have to be referenced
+                                          by digest. With Pin, virt-controller resolves
+                                          images referenced by tag to their digest
+                                          when it starts the VirtualMachine and records
+                                          them in its status, so that restarts of
+                                          the VirtualMachine always boot the same
+                                          image. VirtualMachineInstances which are
+                                          not created by a VirtualMachine must reference
+                                          such images by digest.
+                                        type: string
                                       imagePullPolicy:
                                         description: 'Image pull policy. One of Always,
                                           Never, IfNotPresent. Defaults to Always
@@ -15243,6 +18772,15 @@ var CRDsValidation map[string]string = map[string]string{
                                         description: Capacity of the sparse disk.
                                         pattern: ^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$
                                         x-kubernetes-int-or-string: true
+                                      imageFormat:
+                                        description: ImageFormat is the on-disk format
+                                          of the created image. Defaults to qcow2.
+                                        type: string
+                                      preallocation:
+                                        description: Preallocation defines how space
+                                          for the created image is allocated. Defaults
+                                          to off.
+                                        type: string
                                     required:
                                     - capacity
                                     type: object
@@ -15251,6 +18789,14 @@ var CRDsValidation map[string]string = map[string]string{
                                       that "wraps" specified source and provides copy-on-write
                                       image on top of it.
                                     properties:
+                                      imageFormat:
+                                        description: ImageFormat is the format of
+                                          the local image the PVC is exposed through.
+                                          With qcow2 a copy-on-write overlay on top
+                                          of the PVC is created, with raw the PVC
+                                          content is converted into a standalone local
+                                          copy. Defaults to qcow2.
+                                        type: string
                                       persistentVolumeClaim:
                                         description: 'PersistentVolumeClaimVolumeSource
                                           represents a reference to a PersistentVolumeClaim
@@ -15270,6 +18816,11 @@ var CRDsValidation map[string]string = map[string]string{
                                         required:
                                         - claimName
                                         type: object
+                                      preallocation:
+                                        description: Preallocation defines how space
+                                          for the local image is allocated. Defaults
+                                          to off.
+                                        type: string
                                     type: object
                                   hostDisk:
                                     description: HostDisk represents a disk created
@@ -15286,6 +18837,12 @@ var CRDsValidation map[string]string = map[string]string{
                                         description: The path to HostDisk image located
                                           on the cluster
                                         type: string
+                                      preallocation:
+                                        description: Preallocation defines how space
+                                          for the raw disk.img is allocated when it
+                                          gets created. Only 'off', 'falloc' and 'full'
+                                          are supported. Defaults to off.
+                                        type: string
                                       shared:
                                         description: Shared indicate whether the path
                                           is shared between nodes
@@ -15299,10 +18856,95 @@ var CRDsValidation map[string]string = map[string]string{
                                     - path
                                     - type
                                     type: object
+                                  memoryDump:
+                                    description: MemoryDump represents a PersistentVolumeClaim
+                                      the memory state of the vmi is saved to. It
+                                      is hotplugged into the virt-launcher pod without
+                                      being attached to the vmi as a disk.
+                                    properties:
+                                      claimName:
+                                        description: 'ClaimName is the name of a PersistentVolumeClaim
+                                          in the same namespace as the pod using this
+                                          volume. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims'
+                                        type: string
+                                      hotpluggable:
+                                        description: Hotpluggable indicates whether
+                                          the volume can be hotplugged and hotunplugged.
+                                        type: boolean
+                                      preallocation:
+                                        description: Preallocation defines how space
+                                          for the raw disk.img is allocated when it
+                                          gets created on an empty filesystem PVC.
+                                          Only 'off', 'falloc' and 'full' are supported.
+                                          Defaults to off.
+                                        type: string
+                                      readOnly:
+                                        description: Will force the ReadOnly setting
+                                          in VolumeMounts. Default false.
+                                        type: boolean
+                                    required:
+                                    - claimName
+                                    type: object
                                   name:
                                     description: 'Volume''s name. Must be a DNS_LABEL
                                       and unique within the vmi. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names'
                                     type: string
+                                  networkDisk:
+                                    description: NetworkDisk represents a disk which
+                                      is accessed by QEMU directly over the network
+                                      (iSCSI, NBD or RBD), without attaching it to
+                                      the node or mounting it into the virt-launcher
+                                      pod.
+                                    properties:
+                                      auth:
+                                        description: Auth holds the credentials to
+                                          access the disk. Only supported for iscsi
+                                          and rbd.
+                                        properties:
+                                          secretRef:
+                                            description: SecretRef is the name of
+                                              a secret in the namespace of the vmi,
+                                              which holds the CHAP password (iscsi)
+                                              or the cephx key (rbd) in its "password"
+                                              key.
+                                            type: string
+                                          username:
+                                            description: Username to authenticate
+                                              with.
+                                            type: string
+                                        required:
+                                        - secretRef
+                                        - username
+                                        type: object
+                                      host:
+                                        description: Host serving the disk.
+                                        properties:
+                                          name:
+                                            description: Name is the hostname or IP
+                                              address of the host.
+                                            type: string
+                                          port:
+                                            description: Port of the host. Defaults
+                                              to the default port of the protocol.
+                                            type: string
+                                        required:
+                                        - name
+                                        type: object
+                                      name:
+                                        description: Name of the disk on the storage
+                                          system. For iscsi it is the target IQN and
+                                          LUN (<iqn>/<lun>), for rbd the pool and
+                                          image (<pool>/<image>) and for nbd the optional
+                                          export name.
+                                        type: string
+                                      protocol:
+                                        description: 'Protocol used to access the
+                                          disk. One of: iscsi, nbd, rbd.'
+                                        type: string
+                                    required:
+                                    - host
+                                    - protocol
+                                    type: object
                                   persistentVolumeClaim:
                                     description: 'PersistentVolumeClaimVolumeSource
                                       represents a reference to a PersistentVolumeClaim
@@ -15318,6 +18960,13 @@ var CRDsValidation map[string]string = map[string]string{
                                         description: Hotpluggable indicates whether
                                           the volume can be hotplugged and hotunplugged.
                                         type: boolean
+                                      preallocation:
+                                        description: Preallocation defines how space
+                                          for the raw disk.img is allocated when it
+                                          gets created on an empty filesystem PVC.
+                                          Only 'off', 'falloc' and 'full' are supported.
+                                          Defaults to off.
+                                        type: string
                                       readOnly:
                                         description: Will force the ReadOnly setting
                                           in VolumeMounts. Default false.
@@ -15396,6 +19045,14 @@ var CRDsValidation map[string]string = map[string]string{
                           - domain
                           type: object
                       type: object
+                    ttlSecondsAfterFinished:
+                      description: TTLSecondsAfterFinished limits the lifetime of
+                        a VirtualMachine with runStrategy Once. The VirtualMachine,
+                        including the DataVolumes of its dataVolumeTemplates, is deleted
+                        the given number of seconds after its VirtualMachineInstance
+                        finished. It is kept if unset.
+                      format: int32
+                      type: integer
                   required:
                   - template
                   type: object
@@ -15420,6 +19077,11 @@ var CRDsValidation map[string]string = map[string]string{
                             type: string
                           message:
                             type: string
+                          observedGeneration:
+                            description: ObservedGeneration is the metadata.generation
+                              of the object the condition was set for
+                            format: int64
+                            type: integer
                           reason:
                             type: string
                           status:
@@ -15435,6 +19097,76 @@ var CRDsValidation map[string]string = map[string]string{
                       description: Created indicates if the virtual machine is created
                         in the cluster
                       type: boolean
+                    guestFailures:
+                      description: GuestFailures tracks recent failures of VMIs which
+                        were running, for the purposes of detecting guest crash loops
+                      nullable: true
+                      properties:
+                        failureTimestamps:
+                          description: FailureTimestamps are the times at which the
+                            VMIs failed, within the crash loop detection window
+                          items:
+                            format: date-time
+                            type: string
+                          type: array
+                          x-kubernetes-list-type: atomic
+                        lastFailedVMIUID:
+                          description: LastFailedVMIUID is the UID of the last VMI
+                            which was counted as failed
+                          type: string
+                      type: object
+                    pendingChanges:
+                      description: PendingChanges are the changes of the template
+                        which aren't applied to the running VirtualMachineInstance
+                        yet
+                      items:
+                        description: VirtualMachinePendingChange is a change of the
+                          template which isn't applied to the running VirtualMachineInstance
+                          yet
+                        properties:
+                          applyMethod:
+                            description: ApplyMethod is how the change can be applied
+                              to the running VirtualMachineInstance
+                            type: string
+                          path:
+                            description: Path is the path of the changed field of
+                              the VirtualMachine
+                            type: string
+                        required:
+                        - applyMethod
+                        - path
+                        type: object
+                      type: array
+                      x-kubernetes-list-type: atomic
+                    pinnedContainerDiskImages:
+                      description: PinnedContainerDiskImages are the images of the
+                        containerDisks with the Pin digest policy, resolved to their
+                        digest when the VirtualMachine was started. Later starts boot
+                        the same images until the image of the containerDisk in the
+                        template changes.
+                      items:
+                        description: PinnedContainerDiskImage is the image of a containerDisk
+                          pinned to its digest
+                        properties:
+                          image:
+                            description: Image is the image of the containerDisk in
+                              the template
+                            type: string
+                          pinnedImage:
+                            description: PinnedImage is the image referenced by the
+                              digest it was resolved to
+                            type: string
+                          volumeName:
+                            description: VolumeName is the name of the containerDisk
+                              volume
+                            type: string
+                        required:
+                        - image
+                        - pinnedImage
+                        - volumeName
+                        type: object
+                      type: array
+                      x-kubernetes-list-type: atomic
                     printableStatus:
                       description: PrintableStatus is a human readable, high-level
                         representation of the status of the virtual machine
@@ -15447,6 +19179,12 @@ var CRDsValidation map[string]string = map[string]string{
                       description: RestoreInProgress is the name of the VirtualMachineRestore
                         currently executing
                       type: string
+                    runOnceStarted:
+                      description: RunOnceStarted is set once the
+                        VirtualMachineInstance of a VirtualMachine with
+                        runStrategy Once was created. The VirtualMachineInstance
+                        is not started again, even if it gets deleted.
+                      type: boolean
                     snapshotInProgress:
                       description: SnapshotInProgress is the name of the VirtualMachineSnapshot
                         currently executing
@@ -15573,6 +19311,19 @@ var CRDsValidation map[string]string = map[string]string{
                                       this implies useIOThreads = true. Defaults to
                                       false.
                                     type: boolean
+                                  detectZeroes:
+                                    description: 'DetectZeroes specifies whether QEMU
+                                      detects writes of zeroes and optimizes them.
+                                      Supported values are: off, on, unmap. Defaults
+                                      to unmap on thin-provisioned block volumes with
+                                      discard enabled.'
+                                    type: string
+                                  discard:
+                                    description: 'Discard specifies whether discard
+                                      requests from the guest are passed to the storage.
+                                      Supported values are: unmap, ignore. Defaults
+                                      to unmap unless the volume is preallocated.'
+                                    type: string
                                   disk:
                                     description: Attach a volume as a disk to the
                                       vmi.
@@ -15629,6 +19380,15 @@ var CRDsValidation map[string]string = map[string]string{
                                     description: Serial provides the ability to specify
                                       a serial number for the disk device.
                                     type: string
+                                  shareable:
+                                    description: Shareable indicates whether the disk
+                                      can be shared between multiple VMIs, e.g. for
+                                      clustered file systems. A shareable disk must
+                                      be backed by a block PersistentVolumeClaim with
+                                      the ReadWriteMany access mode, it is attached
+                                      without a host side cache and without an ephemeral
+                                      overlay. Defaults to false.
+                                    type: boolean
                                   tag:
                                     description: If specified, disk address and its
                                       tag will be provided to the guest via config
@@ -15678,6 +19438,13 @@ var CRDsValidation map[string]string = map[string]string{
                                         description: Hotpluggable indicates whether
                                           the volume can be hotplugged and hotunplugged.
                                         type: boolean
+                                      preallocation:
+                                        description: Preallocation defines how space
+                                          for the raw disk.img is allocated when it
+                                          gets created on an empty filesystem PVC.
+                                          Only 'off', 'falloc' and 'full' are supported.
+                                          Defaults to off.
+                                        type: string
                                       readOnly:
                                         description: Will force the ReadOnly setting
                                           in VolumeMounts. Default false.
@@ -15897,6 +19664,29 @@ var CRDsValidation map[string]string = map[string]string{
               format: date-time
               type: string
           type: object
+        memorySnapshotStatus:
+          description: MemorySnapshotStatus is the status of the saved memory state
+            of a vm
+          properties:
+            checksum:
+              description: Checksum of the memory state uploaded to object storage
+              type: string
+            creationTime:
+              format: date-time
+              nullable: true
+              type: string
+            error:
+              description: Error is the last error encountered during the snapshot/restore
+              properties:
+                message:
+                  type: string
+                time:
+                  format: date-time
+                  type: string
+              type: object
+            readyToUse:
+              type: boolean
+          type: object
         readyToUse:
           type: boolean
         volumeSnapshotStatus:
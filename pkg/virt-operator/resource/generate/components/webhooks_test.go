@@ -40,6 +40,22 @@ var _ = Describe("Webhooks", func() {
 		}
 	})
 
+	It("should declare all webhooks free of side effects, except for the eviction validator on dry-run", func() {
+		var webhooks []v1.ValidatingWebhook
+		webhooks = append(webhooks, NewOpertorValidatingWebhookConfiguration("testnamespace").Webhooks...)
+		webhooks = append(webhooks, NewVirtAPIValidatingWebhookConfiguration("testnamespace").Webhooks...)
+		for _, webhook := range webhooks {
+			if webhook.Name == "virt-launcher-eviction-interceptor.kubevirt.io" {
+				Expect(*webhook.SideEffects).To(Equal(v1.SideEffectClassNoneOnDryRun))
+			} else {
+				Expect(*webhook.SideEffects).To(Equal(v1.SideEffectClassNone), webhook.Name)
+			}
+		}
+		for _, webhook := range NewVirtAPIMutatingWebhookConfiguration("testnamespace").Webhooks {
+			Expect(*webhook.SideEffects).To(Equal(v1.SideEffectClassNone), webhook.Name)
+		}
+	})
+
 	It("should make all virt-api validating webhook required, except for the eviction validator", func() {
 		configuration := NewVirtAPIValidatingWebhookConfiguration("testnamespace")
 		for _, webhook := range configuration.Webhooks {
@@ -374,7 +374,9 @@ func GenerateCurrentInstallStrategy(config *operatorutil.KubeVirtDeploymentConfi
 		components.NewVirtualMachineInstanceCrd, components.NewPresetCrd, components.NewReplicaSetCrd,
 		components.NewVirtualMachineCrd, components.NewVirtualMachineInstanceMigrationCrd,
 		components.NewVirtualMachineSnapshotCrd, components.NewVirtualMachineSnapshotContentCrd,
-		components.NewVirtualMachineRestoreCrd,
+		components.NewVirtualMachineRestoreCrd, components.NewSchedulingHintsCrd,
+		components.NewVirtualMachineImageExportCrd, components.NewValidationPolicyCrd,
+		components.NewMaintenanceWindowCrd, components.NewVirtualMachineBulkOperationCrd,
 	}
 	for _, f := range functions {
 		crd, err := f()
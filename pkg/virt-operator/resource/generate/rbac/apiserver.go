@@ -77,7 +77,30 @@ func newApiServerClusterRole() *rbacv1.ClusterRole {
 					"pods",
 				},
 				Verbs: []string{
-					"get", "list", "delete", "patch",
+					"get", "list", "watch", "delete", "patch",
+				},
+			},
+			{
+				APIGroups: []string{
+					"",
+				},
+				Resources: []string{
+					"nodes",
+				},
+				Verbs: []string{
+					"get", "list", "watch",
+				},
+			},
+			{
+				APIGroups: []string{
+					"",
+				},
+				Resources: []string{
+					"persistentvolumeclaims",
+					"persistentvolumes",
+				},
+				Verbs: []string{
+					"get", "list", "watch",
 				},
 			},
 			{
@@ -98,6 +121,7 @@ func newApiServerClusterRole() *rbacv1.ClusterRole {
 				},
 				Resources: []string{
 					"virtualmachines/status",
+					"virtualmachineinstancemigrations/status",
 				},
 				Verbs: []string{
 					"patch",
@@ -114,6 +138,17 @@ func newApiServerClusterRole() *rbacv1.ClusterRole {
 					"create", "get", "list", "watch", "patch",
 				},
 			},
+			{
+				APIGroups: []string{
+					"kubevirt.io",
+				},
+				Resources: []string{
+					"virtualmachinebulkoperations",
+				},
+				Verbs: []string{
+					"create", "get",
+				},
+			},
 			{
 				APIGroups: []string{
 					"kubevirt.io",
@@ -125,6 +160,18 @@ func newApiServerClusterRole() *rbacv1.ClusterRole {
 					"watch", "list",
 				},
 			},
+			{
+				APIGroups: []string{
+					"kubevirt.io",
+				},
+				Resources: []string{
+					"validationpolicies",
+					"maintenancewindows",
+				},
+				Verbs: []string{
+					"watch", "list",
+				},
+			},
 			{
 				APIGroups: []string{
 					"",
@@ -136,6 +183,17 @@ func newApiServerClusterRole() *rbacv1.ClusterRole {
 					"get", "list", "watch",
 				},
 			},
+			{
+				APIGroups: []string{
+					"",
+				},
+				Resources: []string{
+					"events",
+				},
+				Verbs: []string{
+					"create", "patch",
+				},
+			},
 			{
 				APIGroups: []string{
 					"",
@@ -147,6 +205,17 @@ func newApiServerClusterRole() *rbacv1.ClusterRole {
 					"watch", "list",
 				},
 			},
+			{
+				APIGroups: []string{
+					"",
+				},
+				Resources: []string{
+					"namespaces",
+				},
+				Verbs: []string{
+					"watch", "list",
+				},
+			},
 			{
 				APIGroups: []string{
 					"apiextensions.k8s.io",
@@ -179,6 +248,7 @@ func newApiServerClusterRole() *rbacv1.ClusterRole {
 				},
 				Resources: []string{
 					"virtualmachinesnapshots",
+					"virtualmachinesnapshotcontents",
 					"virtualmachinerestores",
 				},
 				Verbs: []string{
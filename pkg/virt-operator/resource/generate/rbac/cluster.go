@@ -33,9 +33,26 @@ func GetAllCluster() []runtime.Object {
 		newAdminClusterRole(),
 		newEditClusterRole(),
 		newViewClusterRole(),
+		newConsoleClusterRole(),
+		newInfoClusterRole(),
+		newVMIOperatorClusterRole(),
+		newVMOperatorClusterRole(),
 	}
 }
 
+const (
+	// ConsoleClusterRoleName grants interactive access (console, VNC, port-forward and USB redirection) to VMIs only
+	ConsoleClusterRoleName = "kubevirt.io:console"
+	// InfoClusterRoleName grants read access to the guest agent information of VMIs only
+	InfoClusterRoleName = "kubevirt.io:guestinfo"
+	// VMIOperatorClusterRoleName grants access to the VMI operations (pause, freeze, hotplug) only
+	VMIOperatorClusterRoleName = "kubevirt.io:vmi-operator"
+	// VMOperatorClusterRoleName grants access to the VM lifecycle operations (start, stop, restart, migrate, hotplug) only
+	VMOperatorClusterRoleName = "kubevirt.io:vm-operator"
+
+	subresourcesGroup = "subresources.kubevirt.io"
+)
+
 func newDefaultClusterRole() *rbacv1.ClusterRole {
 	return &rbacv1.ClusterRole{
 		TypeMeta: metav1.TypeMeta{
@@ -117,50 +134,11 @@ func newAdminClusterRole() *rbacv1.ClusterRole {
 			},
 		},
 		Rules: []rbacv1.PolicyRule{
-			{
-				APIGroups: []string{
-					"subresources.kubevirt.io",
-				},
-				Resources: []string{
-					"virtualmachineinstances/console",
-					"virtualmachineinstances/vnc",
-					"virtualmachineinstances/guestosinfo",
-					"virtualmachineinstances/filesystemlist",
-					"virtualmachineinstances/userlist",
-				},
-				Verbs: []string{
-					"get",
-				},
-			},
-			{
-				APIGroups: []string{
-					"subresources.kubevirt.io",
-				},
-				Resources: []string{
-					"virtualmachineinstances/pause",
-					"virtualmachineinstances/unpause",
-					"virtualmachineinstances/addvolume",
-					"virtualmachineinstances/removevolume",
-					"virtualmachineinstances/freeze",
-					"virtualmachineinstances/unfreeze",
-				},
-				Verbs: []string{
-					"update",
-				},
-			},
-			{
-				APIGroups: []string{
-					"subresources.kubevirt.io",
-				},
-				Resources: []string{
-					"virtualmachines/start",
-					"virtualmachines/stop",
-					"virtualmachines/restart",
-				},
-				Verbs: []string{
-					"update",
-				},
-			},
+			newSubresourceAccessRule(),
+			newSubresourceInfoRule(),
+			newSubresourceVMIOperationsRule(),
+			newSubresourceVMOperationsRule(),
+			newSubresourceSchedulingFeasibilityRule(),
 			{
 				APIGroups: []string{
 					"kubevirt.io",
@@ -171,11 +149,14 @@ func newAdminClusterRole() *rbacv1.ClusterRole {
 					"virtualmachineinstancepresets",
 					"virtualmachineinstancereplicasets",
 					"virtualmachineinstancemigrations",
+					"virtualmachineschedulinghints",
+					"virtualmachineimageexports",
 				},
 				Verbs: []string{
 					"get", "delete", "create", "update", "patch", "list", "watch", "deletecollection",
 				},
 			},
+			newBulkOperationsRule("get", "delete", "list", "watch", "deletecollection"),
 			{
 				APIGroups: []string{
 					"snapshot.kubevirt.io",
@@ -207,50 +188,11 @@ func newEditClusterRole() *rbacv1.ClusterRole {
 			},
 		},
 		Rules: []rbacv1.PolicyRule{
-			{
-				APIGroups: []string{
-					"subresources.kubevirt.io",
-				},
-				Resources: []string{
-					"virtualmachineinstances/console",
-					"virtualmachineinstances/vnc",
-					"virtualmachineinstances/guestosinfo",
-					"virtualmachineinstances/filesystemlist",
-					"virtualmachineinstances/userlist",
-				},
-				Verbs: []string{
-					"get",
-				},
-			},
-			{
-				APIGroups: []string{
-					"subresources.kubevirt.io",
-				},
-				Resources: []string{
-					"virtualmachineinstances/pause",
-					"virtualmachineinstances/unpause",
-					"virtualmachineinstances/addvolume",
-					"virtualmachineinstances/removevolume",
-					"virtualmachineinstances/freeze",
-					"virtualmachineinstances/unfreeze",
-				},
-				Verbs: []string{
-					"update",
-				},
-			},
-			{
-				APIGroups: []string{
-					"subresources.kubevirt.io",
-				},
-				Resources: []string{
-					"virtualmachines/start",
-					"virtualmachines/stop",
-					"virtualmachines/restart",
-				},
-				Verbs: []string{
-					"update",
-				},
-			},
+			newSubresourceAccessRule(),
+			newSubresourceInfoRule(),
+			newSubresourceVMIOperationsRule(),
+			newSubresourceVMOperationsRule(),
+			newSubresourceSchedulingFeasibilityRule(),
 			{
 				APIGroups: []string{
 					"kubevirt.io",
@@ -261,11 +203,14 @@ func newEditClusterRole() *rbacv1.ClusterRole {
 					"virtualmachineinstancepresets",
 					"virtualmachineinstancereplicasets",
 					"virtualmachineinstancemigrations",
+					"virtualmachineschedulinghints",
+					"virtualmachineimageexports",
 				},
 				Verbs: []string{
 					"get", "delete", "create", "update", "patch", "list", "watch",
 				},
 			},
+			newBulkOperationsRule("get", "delete", "list", "watch"),
 			{
 				APIGroups: []string{
 					"snapshot.kubevirt.io",
@@ -308,19 +253,7 @@ func newViewClusterRole() *rbacv1.ClusterRole {
 			},
 		},
 		Rules: []rbacv1.PolicyRule{
-			{
-				APIGroups: []string{
-					"subresources.kubevirt.io",
-				},
-				Resources: []string{
-					"virtualmachineinstances/guestosinfo",
-					"virtualmachineinstances/filesystemlist",
-					"virtualmachineinstances/userlist",
-				},
-				Verbs: []string{
-					"get",
-				},
-			},
+			newSubresourceInfoRule(),
 			{
 				APIGroups: []string{
 					"kubevirt.io",
@@ -331,11 +264,14 @@ func newViewClusterRole() *rbacv1.ClusterRole {
 					"virtualmachineinstancepresets",
 					"virtualmachineinstancereplicasets",
 					"virtualmachineinstancemigrations",
+					"virtualmachineschedulinghints",
+					"virtualmachineimageexports",
 				},
 				Verbs: []string{
 					"get", "list", "watch",
 				},
 			},
+			newBulkOperationsRule("get", "list", "watch"),
 			{
 				APIGroups: []string{
 					"snapshot.kubevirt.io",
@@ -352,3 +288,173 @@ func newViewClusterRole() *rbacv1.ClusterRole {
 		},
 	}
 }
+
+func newSubresourceAccessRule() rbacv1.PolicyRule {
+	return rbacv1.PolicyRule{
+		APIGroups: []string{
+			subresourcesGroup,
+		},
+		Resources: []string{
+			"virtualmachineinstances/console",
+			"virtualmachineinstances/vnc",
+			"virtualmachineinstances/screenshot",
+		},
+		Verbs: []string{
+			"get",
+		},
+	}
+}
+
+// newSubresourceForwardingRule grants port-forward and USB redirection, which are not part of the default roles
+func newSubresourceForwardingRule() rbacv1.PolicyRule {
+	return rbacv1.PolicyRule{
+		APIGroups: []string{
+			subresourcesGroup,
+		},
+		Resources: []string{
+			"virtualmachineinstances/portforward",
+			"virtualmachineinstances/usbredir",
+			"virtualmachines/portforward",
+		},
+		Verbs: []string{
+			"get",
+		},
+	}
+}
+
+func newSubresourceInfoRule() rbacv1.PolicyRule {
+	return rbacv1.PolicyRule{
+		APIGroups: []string{
+			subresourcesGroup,
+		},
+		Resources: []string{
+			"virtualmachineinstances/guestosinfo",
+			"virtualmachineinstances/filesystemlist",
+			"virtualmachineinstances/userlist",
+			"virtualmachineinstances/usage",
+		},
+		Verbs: []string{
+			"get",
+		},
+	}
+}
+
+func newSubresourceVMIOperationsRule() rbacv1.PolicyRule {
+	return rbacv1.PolicyRule{
+		APIGroups: []string{
+			subresourcesGroup,
+		},
+		Resources: []string{
+			"virtualmachineinstances/pause",
+			"virtualmachineinstances/unpause",
+			"virtualmachineinstances/addvolume",
+			"virtualmachineinstances/removevolume",
+			"virtualmachineinstances/freeze",
+			"virtualmachineinstances/unfreeze",
+			"virtualmachineinstances/setlinkstate",
+		},
+		Verbs: []string{
+			"update",
+		},
+	}
+}
+
+func newSubresourceVMOperationsRule() rbacv1.PolicyRule {
+	return rbacv1.PolicyRule{
+		APIGroups: []string{
+			subresourcesGroup,
+		},
+		Resources: []string{
+			"virtualmachines/start",
+			"virtualmachines/stop",
+			"virtualmachines/restart",
+			"virtualmachineinstancemigrations/cancel",
+			"bulkoperations",
+		},
+		Verbs: []string{
+			"update",
+		},
+	}
+}
+
+// newBulkOperationsRule grants access to the VirtualMachineBulkOperations recording the bulk operations.
+// They are only created by virt-api, which records the requesting user in them, users must not create them.
+func newBulkOperationsRule(verbs ...string) rbacv1.PolicyRule {
+	return rbacv1.PolicyRule{
+		APIGroups: []string{
+			"kubevirt.io",
+		},
+		Resources: []string{
+			"virtualmachinebulkoperations",
+		},
+		Verbs: verbs,
+	}
+}
+
+// newSubresourceVMMigrateAndHotplugRule grants to migrate VMs and to hotplug volumes into them, which is not part
+// of the default roles
+func newSubresourceVMMigrateAndHotplugRule() rbacv1.PolicyRule {
+	return rbacv1.PolicyRule{
+		APIGroups: []string{
+			subresourcesGroup,
+		},
+		Resources: []string{
+			"virtualmachines/migrate",
+			"virtualmachines/addvolume",
+			"virtualmachines/removevolume",
+		},
+		Verbs: []string{
+			"update",
+		},
+	}
+}
+
+// newSubresourceSchedulingFeasibilityRule grants to check if a VirtualMachine could currently be scheduled,
+// it is part of the admin and edit roles only, since the check is meant for users who create VirtualMachines
+func newSubresourceSchedulingFeasibilityRule() rbacv1.PolicyRule {
+	return rbacv1.PolicyRule{
+		APIGroups: []string{
+			subresourcesGroup,
+		},
+		Resources: []string{
+			"schedulingfeasibility",
+		},
+		Verbs: []string{
+			"update",
+		},
+	}
+}
+
+// newSubresourceClusterRole creates a ClusterRole which is not aggregated into the default roles,
+// so that access to single groups of subresources can be granted on its own
+func newSubresourceClusterRole(name string, rules ...rbacv1.PolicyRule) *rbacv1.ClusterRole {
+	return &rbacv1.ClusterRole{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "rbac.authorization.k8s.io/v1",
+			Kind:       "ClusterRole",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				virtv1.AppLabel: "",
+			},
+		},
+		Rules: rules,
+	}
+}
+
+func newConsoleClusterRole() *rbacv1.ClusterRole {
+	return newSubresourceClusterRole(ConsoleClusterRoleName, newSubresourceAccessRule(), newSubresourceForwardingRule())
+}
+
+func newInfoClusterRole() *rbacv1.ClusterRole {
+	return newSubresourceClusterRole(InfoClusterRoleName, newSubresourceInfoRule())
+}
+
+func newVMIOperatorClusterRole() *rbacv1.ClusterRole {
+	return newSubresourceClusterRole(VMIOperatorClusterRoleName, newSubresourceVMIOperationsRule())
+}
+
+func newVMOperatorClusterRole() *rbacv1.ClusterRole {
+	return newSubresourceClusterRole(VMOperatorClusterRoleName, newSubresourceVMOperationsRule(), newSubresourceVMMigrateAndHotplugRule())
+}
@@ -0,0 +1,109 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ */
+
+package rbac
+
+import (
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+var _ = Describe("Cluster roles", func() {
+
+	getClusterRole := func(name string) *rbacv1.ClusterRole {
+		for _, obj := range GetAllCluster() {
+			if role, ok := obj.(*rbacv1.ClusterRole); ok && role.Name == name {
+				return role
+			}
+		}
+		return nil
+	}
+
+	allows := func(role *rbacv1.ClusterRole, resource, verb string) bool {
+		for _, rule := range role.Rules {
+			for _, r := range rule.Resources {
+				if r != resource {
+					continue
+				}
+				for _, v := range rule.Verbs {
+					if v == verb {
+						return true
+					}
+				}
+			}
+		}
+		return false
+	}
+
+	table.DescribeTable("should grant subresource access", func(roleName, resource, verb string, expected bool) {
+		role := getClusterRole(roleName)
+		Expect(role).ToNot(BeNil())
+		Expect(allows(role, resource, verb)).To(Equal(expected))
+	},
+		table.Entry("admin to the console", "kubevirt.io:admin", "virtualmachineinstances/console", "get", true),
+		table.Entry("admin to start", "kubevirt.io:admin", "virtualmachines/start", "update", true),
+		table.Entry("edit to pause", "kubevirt.io:edit", "virtualmachineinstances/pause", "update", true),
+		table.Entry("not admin to migrate", "kubevirt.io:admin", "virtualmachines/migrate", "update", false),
+		table.Entry("not edit to port-forward", "kubevirt.io:edit", "virtualmachineinstances/portforward", "get", false),
+		table.Entry("not edit to VM volume hotplug", "kubevirt.io:edit", "virtualmachines/addvolume", "update", false),
+		table.Entry("view to the guest os info", "kubevirt.io:view", "virtualmachineinstances/guestosinfo", "get", true),
+		table.Entry("view to the usage", "kubevirt.io:view", "virtualmachineinstances/usage", "get", true),
+		table.Entry("not view to the console", "kubevirt.io:view", "virtualmachineinstances/console", "get", false),
+		table.Entry("not view to the screenshot", "kubevirt.io:view", "virtualmachineinstances/screenshot", "get", false),
+		table.Entry("not view to pause", "kubevirt.io:view", "virtualmachineinstances/pause", "update", false),
+		table.Entry("edit to set the link state", "kubevirt.io:edit", "virtualmachineinstances/setlinkstate", "update", true),
+		table.Entry("not view to set the link state", "kubevirt.io:view", "virtualmachineinstances/setlinkstate", "update", false),
+		table.Entry("console to the console", ConsoleClusterRoleName, "virtualmachineinstances/console", "get", true),
+		table.Entry("console to VNC", ConsoleClusterRoleName, "virtualmachineinstances/vnc", "get", true),
+		table.Entry("console to the screenshot", ConsoleClusterRoleName, "virtualmachineinstances/screenshot", "get", true),
+		table.Entry("console to port-forward", ConsoleClusterRoleName, "virtualmachineinstances/portforward", "get", true),
+		table.Entry("console to USB redirection", ConsoleClusterRoleName, "virtualmachineinstances/usbredir", "get", true),
+		table.Entry("not console to pause", ConsoleClusterRoleName, "virtualmachineinstances/pause", "update", false),
+		table.Entry("not console to migrate", ConsoleClusterRoleName, "virtualmachines/migrate", "update", false),
+		table.Entry("guest info to the user list", InfoClusterRoleName, "virtualmachineinstances/userlist", "get", true),
+		table.Entry("not guest info to the console", InfoClusterRoleName, "virtualmachineinstances/console", "get", false),
+		table.Entry("vmi operator to pause", VMIOperatorClusterRoleName, "virtualmachineinstances/pause", "update", true),
+		table.Entry("not vmi operator to the console", VMIOperatorClusterRoleName, "virtualmachineinstances/console", "get", false),
+		table.Entry("vm operator to migrate", VMOperatorClusterRoleName, "virtualmachines/migrate", "update", true),
+		table.Entry("vm operator to start", VMOperatorClusterRoleName, "virtualmachines/start", "update", true),
+		table.Entry("vm operator to VM volume hotplug", VMOperatorClusterRoleName, "virtualmachines/addvolume", "update", true),
+		table.Entry("edit to cancel migrations", "kubevirt.io:edit", "virtualmachineinstancemigrations/cancel", "update", true),
+		table.Entry("not view to cancel migrations", "kubevirt.io:view", "virtualmachineinstancemigrations/cancel", "update", false),
+		table.Entry("admin to bulk operations", "kubevirt.io:admin", "bulkoperations", "update", true),
+		table.Entry("edit to bulk operations", "kubevirt.io:edit", "bulkoperations", "update", true),
+		table.Entry("not view to bulk operations", "kubevirt.io:view", "bulkoperations", "update", false),
+		table.Entry("edit to read bulk operations", "kubevirt.io:edit", "virtualmachinebulkoperations", "get", true),
+		table.Entry("view to read bulk operations", "kubevirt.io:view", "virtualmachinebulkoperations", "get", true),
+		table.Entry("not admin to create bulk operations", "kubevirt.io:admin", "virtualmachinebulkoperations", "create", false),
+		table.Entry("not edit to create bulk operations", "kubevirt.io:edit", "virtualmachinebulkoperations", "create", false),
+		table.Entry("admin to scheduling feasibility checks", "kubevirt.io:admin", "schedulingfeasibility", "update", true),
+		table.Entry("edit to scheduling feasibility checks", "kubevirt.io:edit", "schedulingfeasibility", "update", true),
+		table.Entry("not view to scheduling feasibility checks", "kubevirt.io:view", "schedulingfeasibility", "update", false),
+		table.Entry("not vm operator to the console", VMOperatorClusterRoleName, "virtualmachineinstances/console", "get", false),
+	)
+
+	It("should not aggregate the granular roles into the default roles", func() {
+		for _, name := range []string{ConsoleClusterRoleName, InfoClusterRoleName, VMIOperatorClusterRoleName, VMOperatorClusterRoleName} {
+			role := getClusterRole(name)
+			Expect(role).ToNot(BeNil())
+			Expect(role.Labels).ToNot(HaveKey(HavePrefix("rbac.authorization.k8s.io/aggregate-to-")))
+		}
+	})
+})
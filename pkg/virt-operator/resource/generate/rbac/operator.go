@@ -339,6 +339,8 @@ func NewOperatorClusterRole() *rbacv1.ClusterRole {
 					"virtualmachineinstances/removevolume",
 					"virtualmachineinstances/freeze",
 					"virtualmachineinstances/unfreeze",
+					"virtualmachineinstances/memorysnapshot",
+					"virtualmachineinstances/setlinkstate",
 				},
 				Verbs: []string{
 					"update",
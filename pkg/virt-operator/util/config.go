@@ -35,6 +35,7 @@ import (
 
 	v1 "kubevirt.io/client-go/api/v1"
 	clientutil "kubevirt.io/client-go/util"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
 const (
@@ -65,6 +66,9 @@ const (
 	// lookup key in AdditionalProperties
 	AdditionalPropertiesWorkloadUpdatesEnabled = "WorkloadUpdatesEnabled"
 
+	// lookup key prefix in AdditionalProperties for configuration settings which require a restart of the components
+	AdditionalPropertiesConfigurationPrefix = "Configuration."
+
 	// account to use if one is not explicitly named
 	DefaultMonitorAccount = "prometheus-k8s"
 
@@ -149,6 +153,13 @@ func GetTargetConfigFromKV(kv *v1.KubeVirt) *KubeVirtDeploymentConfig {
 	if len(kv.Spec.WorkloadUpdateStrategy.WorkloadUpdateMethods) > 0 {
 		additionalProperties[AdditionalPropertiesWorkloadUpdatesEnabled] = ""
 	}
+	// settings which are only evaluated on start of the components are part of the deployment config,
+	// so that changing them results in a new deployment
+	for name, value := range virtconfig.GetRestartRequiredSettings(&kv.Spec.Configuration) {
+		if value != "null" {
+			additionalProperties[AdditionalPropertiesConfigurationPrefix+name] = value
+		}
+	}
 	// don't use status.target* here, as that is always set, but we need to know if it was set by the spec and with that
 	// overriding shasums from env vars
 	return getConfig(kv.Spec.ImageRegistry,
@@ -397,6 +408,33 @@ func (c *KubeVirtDeploymentConfig) SetObservedDeploymentConfig(kv *v1.KubeVirt)
 	return err
 }
 
+// GetUnappliedConfiguration returns the names of the configuration settings which require a restart of the
+// components, and which differ between the targeted and the completely rolled out deployment
+func GetUnappliedConfiguration(kv *v1.KubeVirt) ([]string, error) {
+	if kv.Status.ObservedDeploymentConfig == "" || kv.Status.TargetDeploymentConfig == "" {
+		return nil, nil
+	}
+	observed := &KubeVirtDeploymentConfig{}
+	if err := json.Unmarshal([]byte(kv.Status.ObservedDeploymentConfig), observed); err != nil {
+		return nil, fmt.Errorf("unable to parse observed deployment config: %v", err)
+	}
+	target := &KubeVirtDeploymentConfig{}
+	if err := json.Unmarshal([]byte(kv.Status.TargetDeploymentConfig), target); err != nil {
+		return nil, fmt.Errorf("unable to parse target deployment config: %v", err)
+	}
+	return virtconfig.GetChangedSettings(observed.getConfigurationSettings(), target.getConfigurationSettings()), nil
+}
+
+func (c *KubeVirtDeploymentConfig) getConfigurationSettings() map[string]string {
+	settings := map[string]string{}
+	for key, value := range c.AdditionalProperties {
+		if strings.HasPrefix(key, AdditionalPropertiesConfigurationPrefix) {
+			settings[strings.TrimPrefix(key, AdditionalPropertiesConfigurationPrefix)] = value
+		}
+	}
+	return settings
+}
+
 func (c *KubeVirtDeploymentConfig) GetImagePullPolicy() k8sv1.PullPolicy {
 	p := c.AdditionalProperties[AdditionalPropertiesNamePullPolicy]
 	if p != "" {
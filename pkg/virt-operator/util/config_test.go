@@ -29,6 +29,9 @@ import (
 
 	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/rand"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
 var _ = Describe("Operator Config", func() {
@@ -290,4 +293,67 @@ var _ = Describe("Operator Config", func() {
 		)
 	})
 
+	Context("Restart required configuration", func() {
+
+		intPtr := func(i int) *int {
+			return &i
+		}
+
+		newKubeVirt := func(vmisPerNode *int) *v1.KubeVirt {
+			return &v1.KubeVirt{
+				Spec: v1.KubeVirtSpec{
+					Configuration: v1.KubeVirtConfiguration{
+						VirtualMachineInstancesPerNode: vmisPerNode,
+					},
+				},
+			}
+		}
+
+		getDeploymentConfig := func(vmisPerNode *int) string {
+			config, err := GetTargetConfigFromKV(newKubeVirt(vmisPerNode)).GetJson()
+			Expect(err).ToNot(HaveOccurred())
+			return config
+		}
+
+		It("should not add unset settings to the deployment config", func() {
+			config := GetTargetConfigFromKV(newKubeVirt(nil))
+			Expect(config.AdditionalProperties).ToNot(HaveKey(AdditionalPropertiesConfigurationPrefix + virtconfig.VirtualMachineInstancesPerNodeSetting))
+		})
+
+		It("should change the deployment ID when a setting changes", func() {
+			config := GetTargetConfigFromKV(newKubeVirt(intPtr(10)))
+			Expect(config.AdditionalProperties).To(HaveKeyWithValue(AdditionalPropertiesConfigurationPrefix+virtconfig.VirtualMachineInstancesPerNodeSetting, "10"))
+			Expect(config.GetDeploymentID()).ToNot(Equal(GetTargetConfigFromKV(newKubeVirt(nil)).GetDeploymentID()))
+		})
+
+		table.DescribeTable("should report unapplied settings", func(observed, target *int, expected []string) {
+			kv := newKubeVirt(target)
+			kv.Status.ObservedDeploymentConfig = getDeploymentConfig(observed)
+			kv.Status.TargetDeploymentConfig = getDeploymentConfig(target)
+			unapplied, err := GetUnappliedConfiguration(kv)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(unapplied).To(Equal(expected))
+		},
+			table.Entry("with nothing when both are unset", nil, nil, nil),
+			table.Entry("with nothing when both are equal", intPtr(10), intPtr(10), nil),
+			table.Entry("with the setting when it was added", nil, intPtr(10), []string{virtconfig.VirtualMachineInstancesPerNodeSetting}),
+			table.Entry("with the setting when it was changed", intPtr(5), intPtr(10), []string{virtconfig.VirtualMachineInstancesPerNodeSetting}),
+			table.Entry("with the setting when it was removed", intPtr(5), nil, []string{virtconfig.VirtualMachineInstancesPerNodeSetting}),
+		)
+
+		It("should report nothing without an observed deployment", func() {
+			kv := newKubeVirt(intPtr(10))
+			kv.Status.TargetDeploymentConfig = getDeploymentConfig(intPtr(10))
+			Expect(GetUnappliedConfiguration(kv)).To(BeEmpty())
+		})
+
+		It("should fail on an unparsable deployment config", func() {
+			kv := newKubeVirt(nil)
+			kv.Status.ObservedDeploymentConfig = "{"
+			kv.Status.TargetDeploymentConfig = getDeploymentConfig(nil)
+			_, err := GetUnappliedConfiguration(kv)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
 })
@@ -20,16 +20,27 @@
 package webhooks
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"strings"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	v1 "kubevirt.io/client-go/api/v1"
 	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/monitoring/accounting"
 	webhookutils "kubevirt.io/kubevirt/pkg/util/webhooks"
 	validating_webhooks "kubevirt.io/kubevirt/pkg/util/webhooks/validating-webhooks"
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 	"kubevirt.io/kubevirt/pkg/virt-operator/resource/apply"
 )
 
@@ -47,7 +58,7 @@ func NewKubeVirtUpdateAdmitter(client kubecli.KubevirtClient) *KubeVirtUpdateAdm
 
 func (admitter *KubeVirtUpdateAdmitter) Admit(ar *admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
 	// Get new and old KubeVirt from admission response
-	newKV, _, err := getAdmissionReviewKubeVirt(ar)
+	newKV, oldKV, err := getAdmissionReviewKubeVirt(ar)
 	if err != nil {
 		return webhookutils.ToAdmissionResponseError(err)
 	}
@@ -60,6 +71,31 @@ func (admitter *KubeVirtUpdateAdmitter) Admit(ar *admissionv1.AdmissionReview) *
 
 	results = append(results, validateCustomizeComponents(newKV.Spec.CustomizeComponents)...)
 	results = append(results, validateCertificates(newKV.Spec.CertificateRotationStrategy.SelfSigned)...)
+	results = append(results, validateGuestMemoryOverheadRatio(newKV.Spec.Configuration.AdditionalGuestMemoryOverheadRatio)...)
+	results = append(results, validateCPUAllocationRatio(newKV.Spec.Configuration.DeveloperConfiguration)...)
+	results = append(results, validateNamespacedFeatureGates(newKV.Spec.Configuration.DeveloperConfiguration)...)
+	results = append(results, validateHostPassthroughPolicy(newKV.Spec.Configuration.MigrationConfiguration)...)
+	results = append(results, validateLauncherPodMetadataPropagation(newKV.Spec.Configuration.LauncherPodMetadataPropagation)...)
+	results = append(results, validateNodeShutdownGracePeriod(newKV.Spec.Configuration.NodeShutdownGracePeriodSeconds)...)
+	results = append(results, validateAuxiliaryThreadsCPURequests(newKV.Spec.Configuration.AuxiliaryThreadsCPURequests)...)
+	results = append(results, validateContainerDiskPolicy(newKV.Spec.Configuration.ContainerDiskPolicy)...)
+	results = append(results, validateAccountingConfiguration(newKV.Spec.Configuration.AccountingConfiguration)...)
+
+	// the placement is only validated on changes, since the validation requires a dry-run request per component type
+	if oldKV == nil || !equality.Semantic.DeepEqual(newKV.Spec.Infra, oldKV.Spec.Infra) {
+		causes, err := validateInfraPlacement(newKV.Namespace, newKV.Spec.Infra, admitter.Client)
+		if err != nil {
+			return webhookutils.ToAdmissionResponseInternalError(err)
+		}
+		results = append(results, causes...)
+	}
+	if oldKV == nil || !equality.Semantic.DeepEqual(newKV.Spec.Workloads, oldKV.Spec.Workloads) {
+		causes, err := validateWorkloadPlacement(newKV.Namespace, newKV.Spec.Workloads, admitter.Client)
+		if err != nil {
+			return webhookutils.ToAdmissionResponseInternalError(err)
+		}
+		results = append(results, causes...)
+	}
 
 	return validating_webhooks.NewAdmissionResponse(results)
 }
@@ -162,3 +198,270 @@ func validateCertificates(certConfig *v1.KubeVirtSelfSignConfiguration) []metav1
 
 	return statuses
 }
+
+func validateGuestMemoryOverheadRatio(ratio *string) []metav1.StatusCause {
+	if _, err := virtconfig.ParseAdditionalGuestMemoryOverheadRatio(ratio); err != nil {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: err.Error(),
+			Field:   "spec.configuration.additionalGuestMemoryOverheadRatio",
+		}}
+	}
+	return nil
+}
+
+func validateNodeShutdownGracePeriod(gracePeriod *int64) []metav1.StatusCause {
+	if gracePeriod != nil && *gracePeriod < 0 {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("node shutdown grace period %d must not be negative", *gracePeriod),
+			Field:   "spec.configuration.nodeShutdownGracePeriodSeconds",
+		}}
+	}
+	return nil
+}
+
+func validateAuxiliaryThreadsCPURequests(requests *v1.AuxiliaryThreadsCPURequests) (causes []metav1.StatusCause) {
+	if requests == nil {
+		return nil
+	}
+	validateRequest := func(field string, request *resource.Quantity) {
+		if request != nil && request.Sign() < 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("auxiliary thread CPU request %s must not be negative", request.String()),
+				Field:   fmt.Sprintf("spec.configuration.auxiliaryThreadsCPURequests.%s", field),
+			})
+		}
+	}
+	validateRequest("emulator", requests.Emulator)
+	validateRequest("ioThread", requests.IOThread)
+	validateRequest("virtiofs", requests.Virtiofs)
+	return causes
+}
+
+func validateContainerDiskPolicy(policy *v1.ContainerDiskPolicy) (causes []metav1.StatusCause) {
+	if policy == nil {
+		return nil
+	}
+	for idx, registry := range policy.AllowedRegistries {
+		if strings.TrimSuffix(registry, "/") == "" {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: "allowed containerDisk registries must not be empty",
+				Field:   fmt.Sprintf("spec.configuration.containerDiskPolicy.allowedRegistries[%d]", idx),
+			})
+		}
+	}
+	if policy.SignatureVerification != nil && policy.SignatureVerification.PublicKeysConfigMap == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: "a ConfigMap with the public keys is required to verify containerDisk signatures",
+			Field:   "spec.configuration.containerDiskPolicy.signatureVerification.publicKeysConfigMap",
+		})
+	}
+	return causes
+}
+
+func validateAccountingConfiguration(accountingConfig *v1.AccountingConfiguration) (causes []metav1.StatusCause) {
+	if accountingConfig == nil {
+		return nil
+	}
+	metricLabels := map[string]string{}
+	for idx, key := range accountingConfig.Labels {
+		field := fmt.Sprintf("spec.configuration.accountingConfiguration.labels[%d]", idx)
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueInvalid,
+				Message: fmt.Sprintf("accounting label %q is no valid label key: %s", key, strings.Join(errs, ", ")),
+				Field:   field,
+			})
+			continue
+		}
+		// different keys like "a.b" and "a_b" end up as the same metric label
+		metricLabel := accounting.MetricLabel(key)
+		if other, exists := metricLabels[metricLabel]; exists {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueDuplicate,
+				Message: fmt.Sprintf("accounting labels %q and %q are both attached to metrics as %s", other, key, metricLabel),
+				Field:   field,
+			})
+			continue
+		}
+		metricLabels[metricLabel] = key
+	}
+
+	report := accountingConfig.UsageReport
+	if report == nil {
+		return causes
+	}
+	if webhookURL, err := url.Parse(report.WebhookURL); err != nil || (webhookURL.Scheme != "http" && webhookURL.Scheme != "https") || webhookURL.Host == "" {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("usage report webhook URL %q must be an http or https URL", report.WebhookURL),
+			Field:   "spec.configuration.accountingConfiguration.usageReport.webhookURL",
+		})
+	}
+	if report.Interval != nil && report.Interval.Duration < accounting.SampleInterval {
+		causes = append(causes, metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("usage report interval %s must be at least %s", report.Interval.Duration, accounting.SampleInterval),
+			Field:   "spec.configuration.accountingConfiguration.usageReport.interval",
+		})
+	}
+	return causes
+}
+
+func validateLauncherPodMetadataPropagation(propagation *v1.LauncherPodMetadataPropagation) (causes []metav1.StatusCause) {
+	if propagation == nil {
+		return nil
+	}
+	validatePatterns := func(field string, patterns []string) {
+		for idx, pattern := range patterns {
+			if err := virtconfig.ValidateMetadataKeyPattern(pattern); err != nil {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldValueInvalid,
+					Message: err.Error(),
+					Field:   fmt.Sprintf("spec.configuration.launcherPodMetadataPropagation.%s[%d]", field, idx),
+				})
+			}
+		}
+	}
+	validatePatterns("allowedLabels", propagation.AllowedLabels)
+	validatePatterns("allowedAnnotations", propagation.AllowedAnnotations)
+	return causes
+}
+
+func validateCPUAllocationRatio(developerConfig *v1.DeveloperConfiguration) []metav1.StatusCause {
+	if developerConfig != nil && developerConfig.CPUAllocationRatio < 0 {
+		return []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: fmt.Sprintf("CPU allocation ratio %d must not be negative", developerConfig.CPUAllocationRatio),
+			Field:   "spec.configuration.developerConfiguration.cpuAllocationRatio",
+		}}
+	}
+	return nil
+}
+
+func validateHostPassthroughPolicy(migrationConfig *v1.MigrationConfiguration) []metav1.StatusCause {
+	if migrationConfig == nil || migrationConfig.HostPassthroughPolicy == nil {
+		return nil
+	}
+	switch policy := *migrationConfig.HostPassthroughPolicy; policy {
+	case v1.HostPassthroughMigrationAllow, v1.HostPassthroughMigrationCompatibleNodes, v1.HostPassthroughMigrationDeny:
+		return nil
+	default:
+		return []metav1.StatusCause{{
+			Type: metav1.CauseTypeFieldValueNotSupported,
+			Message: fmt.Sprintf("host-passthrough migration policy %q is not supported, must be one of %s, %s or %s", policy,
+				v1.HostPassthroughMigrationAllow, v1.HostPassthroughMigrationCompatibleNodes, v1.HostPassthroughMigrationDeny),
+			Field: "spec.configuration.migrations.hostPassthroughPolicy",
+		}}
+	}
+}
+
+func validateNamespacedFeatureGates(developerConfig *v1.DeveloperConfiguration) (causes []metav1.StatusCause) {
+	if developerConfig == nil {
+		return nil
+	}
+	for idx, featureGate := range developerConfig.NamespacedFeatureGates {
+		field := fmt.Sprintf("spec.configuration.developerConfiguration.namespacedFeatureGates[%d]", idx)
+		if !virtconfig.IsNamespacedFeatureGateSupported(featureGate.Name) {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueNotSupported,
+				Message: fmt.Sprintf("feature gate %s can't be enabled for namespaces", featureGate.Name),
+				Field:   field + ".name",
+			})
+		}
+		if len(featureGate.Namespaces) == 0 {
+			causes = append(causes, metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldValueRequired,
+				Message: fmt.Sprintf("feature gate %s must be enabled for at least one namespace", featureGate.Name),
+				Field:   field + ".namespaces",
+			})
+		}
+	}
+	return causes
+}
+
+const placementValidationName = "kubevirt-placement-validation"
+
+func newPlacementValidationPodTemplate(componentConfig *v1.ComponentConfig) corev1.PodTemplateSpec {
+	template := corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{v1.AppLabel: placementValidationName},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:  placementValidationName,
+					Image: placementValidationName,
+				},
+			},
+		},
+	}
+	apply.InjectPlacementMetadata(componentConfig, &template.Spec)
+	return template
+}
+
+// validateInfraPlacement lets the API server validate the infra placement by dry-run creating a Deployment with it
+func validateInfraPlacement(namespace string, componentConfig *v1.ComponentConfig, client kubecli.KubevirtClient) ([]metav1.StatusCause, error) {
+	if componentConfig == nil || componentConfig.NodePlacement == nil {
+		return nil, nil
+	}
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      placementValidationName,
+			Namespace: namespace,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{v1.AppLabel: placementValidationName},
+			},
+			Template: newPlacementValidationPodTemplate(componentConfig),
+		},
+	}
+
+	_, err := client.AppsV1().Deployments(namespace).Create(context.Background(), deployment, metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}})
+	return placementCauses(err, "infra", "spec.infra.nodePlacement")
+}
+
+// validateWorkloadPlacement lets the API server validate the workloads placement by dry-run creating a DaemonSet with it
+func validateWorkloadPlacement(namespace string, componentConfig *v1.ComponentConfig, client kubecli.KubevirtClient) ([]metav1.StatusCause, error) {
+	if componentConfig == nil || componentConfig.NodePlacement == nil {
+		return nil, nil
+	}
+
+	daemonSet := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      placementValidationName,
+			Namespace: namespace,
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{v1.AppLabel: placementValidationName},
+			},
+			Template: newPlacementValidationPodTemplate(componentConfig),
+		},
+	}
+
+	_, err := client.AppsV1().DaemonSets(namespace).Create(context.Background(), daemonSet, metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}})
+	return placementCauses(err, "workloads", "spec.workloads.nodePlacement")
+}
+
+// placementCauses rejects the placement only if the API server refused the dry-run request for it, any other error
+// is returned so that the update is retried
+func placementCauses(err error, component string, field string) ([]metav1.StatusCause, error) {
+	if err == nil {
+		return nil, nil
+	}
+	if !errors.IsInvalid(err) && !errors.IsForbidden(err) {
+		return nil, fmt.Errorf("failed to validate the %s placement: %v", component, err)
+	}
+	return []metav1.StatusCause{{
+		Type:    metav1.CauseTypeFieldValueInvalid,
+		Message: fmt.Sprintf("invalid %s placement: %v", component, err),
+		Field:   field,
+	}}, nil
+}
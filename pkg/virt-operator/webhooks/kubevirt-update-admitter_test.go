@@ -20,11 +20,31 @@
 package webhooks
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/testing"
+	"k8s.io/utils/pointer"
 
 	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+
+	virtconfig "kubevirt.io/kubevirt/pkg/virt-config"
 )
 
 var _ = Describe("Validating KubeVirtUpdate Admitter", func() {
@@ -64,4 +84,267 @@ var _ = Describe("Validating KubeVirtUpdate Admitter", func() {
 			},
 		}, 0),
 	)
+
+	table.DescribeTable("test validateGuestMemoryOverheadRatio", func(ratio *string, expectedCauses int) {
+		causes := validateGuestMemoryOverheadRatio(ratio)
+		Expect(causes).To(HaveLen(expectedCauses))
+	},
+		table.Entry("unset ratio accepted", nil, 0),
+		table.Entry("valid ratio accepted", pointer.StringPtr("1.25"), 0),
+		table.Entry("ratio lower than 1 rejected", pointer.StringPtr("0.9"), 1),
+		table.Entry("non numeric ratio rejected", pointer.StringPtr("double"), 1),
+	)
+
+	table.DescribeTable("test validateLauncherPodMetadataPropagation", func(propagation *v1.LauncherPodMetadataPropagation, expectedCauses int) {
+		causes := validateLauncherPodMetadataPropagation(propagation)
+		Expect(causes).To(HaveLen(expectedCauses))
+	},
+		table.Entry("unset propagation accepted", nil, 0),
+		table.Entry("valid keys and prefixes accepted", &v1.LauncherPodMetadataPropagation{
+			AllowedLabels:      []string{"example.com/team", "app.example.com/*"},
+			AllowedAnnotations: []string{"*"},
+		}, 0),
+		table.Entry("invalid patterns rejected", &v1.LauncherPodMetadataPropagation{
+			AllowedLabels:      []string{"example.com/*/team"},
+			AllowedAnnotations: []string{"not a key"},
+		}, 2),
+	)
+
+	table.DescribeTable("test validateNodeShutdownGracePeriod", func(gracePeriod *int64, expectedCauses int) {
+		causes := validateNodeShutdownGracePeriod(gracePeriod)
+		Expect(causes).To(HaveLen(expectedCauses))
+	},
+		table.Entry("unset grace period accepted", nil, 0),
+		table.Entry("positive grace period accepted", pointer.Int64Ptr(300), 0),
+		table.Entry("negative grace period rejected", pointer.Int64Ptr(-1), 1),
+	)
+
+	table.DescribeTable("test validateAuxiliaryThreadsCPURequests", func(requests *v1.AuxiliaryThreadsCPURequests, expectedCauses int) {
+		causes := validateAuxiliaryThreadsCPURequests(requests)
+		Expect(causes).To(HaveLen(expectedCauses))
+	},
+		table.Entry("unset requests accepted", nil, 0),
+		table.Entry("positive requests accepted", &v1.AuxiliaryThreadsCPURequests{
+			Emulator: resource.NewMilliQuantity(100, resource.DecimalSI),
+			IOThread: resource.NewMilliQuantity(50, resource.DecimalSI),
+		}, 0),
+		table.Entry("negative requests rejected", &v1.AuxiliaryThreadsCPURequests{
+			IOThread: resource.NewMilliQuantity(-50, resource.DecimalSI),
+			Virtiofs: resource.NewMilliQuantity(-100, resource.DecimalSI),
+		}, 2),
+	)
+
+	table.DescribeTable("test validateContainerDiskPolicy", func(policy *v1.ContainerDiskPolicy, expectedCauses int) {
+		causes := validateContainerDiskPolicy(policy)
+		Expect(causes).To(HaveLen(expectedCauses))
+	},
+		table.Entry("unset policy accepted", nil, 0),
+		table.Entry("allowed registries accepted", &v1.ContainerDiskPolicy{
+			AllowedRegistries: []string{"quay.io/kubevirt", "registry:5000"},
+		}, 0),
+		table.Entry("empty registries rejected", &v1.ContainerDiskPolicy{
+			AllowedRegistries: []string{"quay.io/kubevirt", "", "/"},
+		}, 2),
+		table.Entry("signature verification with public keys accepted", &v1.ContainerDiskPolicy{
+			SignatureVerification: &v1.ContainerDiskSignatureVerification{PublicKeysConfigMap: "cosign-keys"},
+		}, 0),
+		table.Entry("signature verification without public keys rejected", &v1.ContainerDiskPolicy{
+			SignatureVerification: &v1.ContainerDiskSignatureVerification{},
+		}, 1),
+	)
+
+	table.DescribeTable("test validateAccountingConfiguration", func(accountingConfig *v1.AccountingConfiguration, expectedCauses int) {
+		causes := validateAccountingConfiguration(accountingConfig)
+		Expect(causes).To(HaveLen(expectedCauses))
+	},
+		table.Entry("unset configuration accepted", nil, 0),
+		table.Entry("label keys accepted", &v1.AccountingConfiguration{
+			Labels: []string{"tenant", "example.com/cost-center"},
+		}, 0),
+		table.Entry("invalid label keys rejected", &v1.AccountingConfiguration{
+			Labels: []string{"", "cost center"},
+		}, 2),
+		table.Entry("label keys with the same metric label rejected", &v1.AccountingConfiguration{
+			Labels: []string{"example.com/tenant", "example-com/tenant"},
+		}, 1),
+		table.Entry("usage report accepted", &v1.AccountingConfiguration{
+			UsageReport: &v1.UsageReportConfiguration{
+				WebhookURL: "https://billing.example.com/usage",
+				Interval:   &metav1.Duration{Duration: 15 * time.Minute},
+			},
+		}, 0),
+		table.Entry("usage report without an http URL rejected", &v1.AccountingConfiguration{
+			UsageReport: &v1.UsageReportConfiguration{WebhookURL: "billing.example.com/usage"},
+		}, 1),
+		table.Entry("usage report interval shorter than a minute rejected", &v1.AccountingConfiguration{
+			UsageReport: &v1.UsageReportConfiguration{
+				WebhookURL: "http://billing.example.com/usage",
+				Interval:   &metav1.Duration{Duration: 30 * time.Second},
+			},
+		}, 1),
+	)
+
+	table.DescribeTable("test validateCPUAllocationRatio", func(developerConfig *v1.DeveloperConfiguration, expectedCauses int) {
+		causes := validateCPUAllocationRatio(developerConfig)
+		Expect(causes).To(HaveLen(expectedCauses))
+	},
+		table.Entry("unset developer configuration accepted", nil, 0),
+		table.Entry("unset ratio accepted", &v1.DeveloperConfiguration{}, 0),
+		table.Entry("positive ratio accepted", &v1.DeveloperConfiguration{CPUAllocationRatio: 5}, 0),
+		table.Entry("negative ratio rejected", &v1.DeveloperConfiguration{CPUAllocationRatio: -1}, 1),
+	)
+
+	table.DescribeTable("test validateHostPassthroughPolicy", func(migrationConfig *v1.MigrationConfiguration, expectedCauses int) {
+		causes := validateHostPassthroughPolicy(migrationConfig)
+		Expect(causes).To(HaveLen(expectedCauses))
+	},
+		table.Entry("unset migration configuration accepted", nil, 0),
+		table.Entry("unset policy accepted", &v1.MigrationConfiguration{}, 0),
+		table.Entry("Allow accepted", &v1.MigrationConfiguration{HostPassthroughPolicy: hostPassthroughPolicy(v1.HostPassthroughMigrationAllow)}, 0),
+		table.Entry("CompatibleNodes accepted", &v1.MigrationConfiguration{HostPassthroughPolicy: hostPassthroughPolicy(v1.HostPassthroughMigrationCompatibleNodes)}, 0),
+		table.Entry("Deny accepted", &v1.MigrationConfiguration{HostPassthroughPolicy: hostPassthroughPolicy(v1.HostPassthroughMigrationDeny)}, 0),
+		table.Entry("unknown policy rejected", &v1.MigrationConfiguration{HostPassthroughPolicy: hostPassthroughPolicy("deny")}, 1),
+	)
+
+	table.DescribeTable("test validateNamespacedFeatureGates", func(developerConfig *v1.DeveloperConfiguration, expectedCauses int) {
+		causes := validateNamespacedFeatureGates(developerConfig)
+		Expect(causes).To(HaveLen(expectedCauses))
+	},
+		table.Entry("unset developer configuration accepted", nil, 0),
+		table.Entry("supported gate with namespaces accepted", &v1.DeveloperConfiguration{
+			NamespacedFeatureGates: []v1.NamespacedFeatureGate{{Name: virtconfig.HostDevicesGate, Namespaces: []string{"ns1"}}},
+		}, 0),
+		table.Entry("unsupported gate rejected", &v1.DeveloperConfiguration{
+			NamespacedFeatureGates: []v1.NamespacedFeatureGate{{Name: virtconfig.SnapshotGate, Namespaces: []string{"ns1"}}},
+		}, 1),
+		table.Entry("gate without namespaces rejected", &v1.DeveloperConfiguration{
+			NamespacedFeatureGates: []v1.NamespacedFeatureGate{{Name: virtconfig.MacvtapGate}},
+		}, 1),
+	)
+
+	Context("with placement changes", func() {
+
+		var ctrl *gomock.Controller
+		var kubeClient *fake.Clientset
+		var admitter *KubeVirtUpdateAdmitter
+		var dryRunCreates []string
+		var createErr error
+
+		newAdmissionReview := func(newKV, oldKV *v1.KubeVirt) *admissionv1.AdmissionReview {
+			newBytes, err := json.Marshal(newKV)
+			Expect(err).ToNot(HaveOccurred())
+			oldBytes, err := json.Marshal(oldKV)
+			Expect(err).ToNot(HaveOccurred())
+			return &admissionv1.AdmissionReview{
+				Request: &admissionv1.AdmissionRequest{
+					Operation: admissionv1.Update,
+					Resource:  KubeVirtGroupVersionResource,
+					Object:    runtime.RawExtension{Raw: newBytes},
+					OldObject: runtime.RawExtension{Raw: oldBytes},
+				},
+			}
+		}
+
+		newKubeVirt := func() *v1.KubeVirt {
+			return &v1.KubeVirt{
+				ObjectMeta: metav1.ObjectMeta{Name: "kubevirt", Namespace: "kubevirt"},
+				Spec: v1.KubeVirtSpec{
+					ImagePullPolicy: corev1.PullIfNotPresent,
+				},
+			}
+		}
+
+		placement := &v1.ComponentConfig{
+			NodePlacement: &v1.NodePlacement{
+				NodeSelector: map[string]string{"infra": "true"},
+				Tolerations: []corev1.Toleration{
+					{Key: "infra", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+				},
+			},
+		}
+
+		BeforeEach(func() {
+			ctrl = gomock.NewController(GinkgoT())
+			dryRunCreates = nil
+			createErr = nil
+
+			kubeClient = fake.NewSimpleClientset()
+			kubeClient.Fake.PrependReactor("create", "*", func(action testing.Action) (handled bool, obj runtime.Object, err error) {
+				create := action.(testing.CreateAction)
+				Expect(create.GetNamespace()).To(Equal("kubevirt"))
+				dryRunCreates = append(dryRunCreates, create.GetResource().Resource)
+				if createErr != nil {
+					return true, nil, createErr
+				}
+				return true, create.GetObject(), nil
+			})
+
+			client := kubecli.NewMockKubevirtClient(ctrl)
+			client.EXPECT().AppsV1().Return(kubeClient.AppsV1()).AnyTimes()
+			admitter = NewKubeVirtUpdateAdmitter(client)
+		})
+
+		AfterEach(func() {
+			ctrl.Finish()
+		})
+
+		It("should validate a changed infra placement with a Deployment", func() {
+			newKV := newKubeVirt()
+			newKV.Spec.Infra = placement
+
+			response := admitter.Admit(newAdmissionReview(newKV, newKubeVirt()))
+			Expect(response.Allowed).To(BeTrue())
+			Expect(dryRunCreates).To(Equal([]string{"deployments"}))
+		})
+
+		It("should validate a changed workloads placement with a DaemonSet", func() {
+			newKV := newKubeVirt()
+			newKV.Spec.Workloads = placement
+
+			response := admitter.Admit(newAdmissionReview(newKV, newKubeVirt()))
+			Expect(response.Allowed).To(BeTrue())
+			Expect(dryRunCreates).To(Equal([]string{"daemonsets"}))
+		})
+
+		It("should not validate an unchanged placement", func() {
+			newKV := newKubeVirt()
+			newKV.Spec.Infra = placement
+			newKV.Spec.Workloads = placement
+
+			response := admitter.Admit(newAdmissionReview(newKV, newKV.DeepCopy()))
+			Expect(response.Allowed).To(BeTrue())
+			Expect(dryRunCreates).To(BeEmpty())
+		})
+
+		It("should reject a placement the API server does not accept", func() {
+			createErr = errors.NewInvalid(schema.GroupKind{Group: "apps", Kind: "Deployment"}, "kubevirt-placement-validation", field.ErrorList{
+				field.Invalid(field.NewPath("spec", "template", "spec", "tolerations"), "infra", "invalid toleration"),
+			})
+			newKV := newKubeVirt()
+			newKV.Spec.Infra = placement
+			newKV.Spec.Workloads = placement
+
+			response := admitter.Admit(newAdmissionReview(newKV, newKubeVirt()))
+			Expect(response.Allowed).To(BeFalse())
+			Expect(response.Result.Details.Causes).To(HaveLen(2))
+			Expect(response.Result.Details.Causes[0].Field).To(Equal("spec.infra.nodePlacement"))
+			Expect(response.Result.Details.Causes[1].Field).To(Equal("spec.workloads.nodePlacement"))
+		})
+
+		It("should fail with an internal error if the placement could not be validated", func() {
+			createErr = fmt.Errorf("connection refused")
+			newKV := newKubeVirt()
+			newKV.Spec.Infra = placement
+
+			response := admitter.Admit(newAdmissionReview(newKV, newKubeVirt()))
+			Expect(response.Allowed).To(BeFalse())
+			Expect(response.Result.Code).To(Equal(int32(http.StatusInternalServerError)))
+			Expect(response.Result.Reason).To(Equal(metav1.StatusReasonInternalError))
+			Expect(response.Result.Details).To(BeNil())
+		})
+	})
 })
+
+func hostPassthroughPolicy(policy v1.HostPassthroughMigrationPolicy) *v1.HostPassthroughMigrationPolicy {
+	return &policy
+}
@@ -0,0 +1,134 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package maintenance
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+
+	kubevirtV1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	COMMAND_MAINTENANCE = "maintenance"
+	ACTION_ENTER        = "enter"
+	ACTION_EXIT         = "exit"
+	ACTION_STATUS       = "status"
+)
+
+func NewCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "maintenance enter|exit|status (NODE)",
+		Short: "Manage the maintenance mode of virt-handler on a node",
+		Long: `Puts the virt-handler of a node into maintenance mode, takes it out of maintenance mode or shows its status.
+In maintenance mode no new virtual machine instances are scheduled to the node, while the ones already running on it keep being managed.
+The status lists the virtual machine instances which still block a full drain of the node.
+First argument is the action, second argument is the name of the node.`,
+		Args:    templates.ExactArgs(COMMAND_MAINTENANCE, 2),
+		Example: usage(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := Command{clientConfig: clientConfig}
+			return c.Run(cmd, args)
+		},
+	}
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func usage() string {
+	usage := "  # Put the virt-handler of node 'mynode' into maintenance mode:\n"
+	usage += "  {{ProgramName}} maintenance enter mynode\n\n"
+	usage += "  # Show the virtual machine instances which still block draining node 'mynode':\n"
+	usage += "  {{ProgramName}} maintenance status mynode\n\n"
+	usage += "  # Take the virt-handler of node 'mynode' out of maintenance mode:\n"
+	usage += "  {{ProgramName}} maintenance exit mynode"
+	return usage
+}
+
+type Command struct {
+	clientConfig clientcmd.ClientConfig
+}
+
+func (c *Command) Run(cmd *cobra.Command, args []string) error {
+	action := strings.ToLower(args[0])
+	nodeName := args[1]
+
+	virtClient, err := kubecli.GetKubevirtClientFromClientConfig(c.clientConfig)
+	if err != nil {
+		return fmt.Errorf("Cannot obtain KubeVirt client: %v", err)
+	}
+
+	switch action {
+	case ACTION_ENTER:
+		if err := patchMaintenance(virtClient, nodeName, `"true"`); err != nil {
+			return fmt.Errorf("Error putting node %s into maintenance mode: %v", nodeName, err)
+		}
+		cmd.Printf("Node %s was put into maintenance mode\n", nodeName)
+	case ACTION_EXIT:
+		if err := patchMaintenance(virtClient, nodeName, "null"); err != nil {
+			return fmt.Errorf("Error taking node %s out of maintenance mode: %v", nodeName, err)
+		}
+		cmd.Printf("Node %s was taken out of maintenance mode\n", nodeName)
+	case ACTION_STATUS:
+		node, err := virtClient.CoreV1().Nodes().Get(context.Background(), nodeName, v1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("Error getting node %s: %v", nodeName, err)
+		}
+		printStatus(cmd, node.Name, node.Annotations)
+	default:
+		return fmt.Errorf("Unknown action %s, must be one of %s, %s or %s", action, ACTION_ENTER, ACTION_EXIT, ACTION_STATUS)
+	}
+	return nil
+}
+
+func patchMaintenance(virtClient kubecli.KubevirtClient, nodeName string, value string) error {
+	data := []byte(fmt.Sprintf(`{"metadata": {"annotations": {"%s": %s}}}`, kubevirtV1.NodeMaintenanceAnnotation, value))
+	_, err := virtClient.CoreV1().Nodes().Patch(context.Background(), nodeName, types.StrategicMergePatchType, data, v1.PatchOptions{})
+	return err
+}
+
+func printStatus(cmd *cobra.Command, nodeName string, annotations map[string]string) {
+	if annotations[kubevirtV1.NodeMaintenanceAnnotation] != "true" {
+		cmd.Printf("Node %s is not in maintenance mode\n", nodeName)
+		return
+	}
+	blockingVMIs, reported := annotations[kubevirtV1.NodeMaintenanceBlockingVMIsAnnotation]
+	switch {
+	case !reported:
+		cmd.Printf("Node %s is in maintenance mode, waiting for virt-handler to report the remaining VMIs\n", nodeName)
+	case blockingVMIs == "":
+		cmd.Printf("Node %s is in maintenance mode and has no VMIs left\n", nodeName)
+	default:
+		vmis := strings.Split(blockingVMIs, ",")
+		cmd.Printf("Node %s is in maintenance mode, %d VMI(s) block the drain:\n", nodeName, len(vmis))
+		for _, vmi := range vmis {
+			cmd.Printf("  %s\n", vmi)
+		}
+	}
+}
@@ -0,0 +1,11 @@
+package maintenance_test
+
+import (
+	"testing"
+
+	"kubevirt.io/client-go/testutils"
+)
+
+func TestMaintenance(t *testing.T) {
+	testutils.KubeVirtTestSuiteSetup(t)
+}
@@ -0,0 +1,120 @@
+package maintenance_test
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	k8sv1 "k8s.io/api/core/v1"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakek8sclient "k8s.io/client-go/kubernetes/fake"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/virtctl/maintenance"
+	"kubevirt.io/kubevirt/tests"
+)
+
+var _ = Describe("Maintenance", func() {
+
+	const nodeName = "testnode"
+	var ctrl *gomock.Controller
+	var kubeClient *fakek8sclient.Clientset
+	var node *k8sv1.Node
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		node = &k8sv1.Node{ObjectMeta: k8smetav1.ObjectMeta{Name: nodeName}}
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	expectNodes := func() {
+		kubeClient = fakek8sclient.NewSimpleClientset(node)
+		kubecli.MockKubevirtClientInstance.EXPECT().CoreV1().Return(kubeClient.CoreV1()).AnyTimes()
+	}
+
+	getNode := func() *k8sv1.Node {
+		node, err := kubeClient.CoreV1().Nodes().Get(context.Background(), nodeName, k8smetav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		return node
+	}
+
+	runCommand := func(args ...string) (string, error) {
+		out := &bytes.Buffer{}
+		cmd := tests.NewVirtctlCommand(append([]string{maintenance.COMMAND_MAINTENANCE}, args...)...)
+		cmd.SetOut(out)
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	Context("With missing input parameters", func() {
+		It("should fail", func() {
+			cmd := tests.NewRepeatableVirtctlCommand(maintenance.COMMAND_MAINTENANCE, maintenance.ACTION_ENTER)
+			Expect(cmd()).To(HaveOccurred())
+		})
+	})
+
+	It("should fail on an unknown action", func() {
+		_, err := runCommand("start", nodeName)
+		Expect(err).To(MatchError(ContainSubstring("Unknown action start")))
+	})
+
+	It("should put the node into maintenance mode", func() {
+		expectNodes()
+
+		_, err := runCommand(maintenance.ACTION_ENTER, nodeName)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(getNode().Annotations).To(HaveKeyWithValue(v1.NodeMaintenanceAnnotation, "true"))
+	})
+
+	It("should take the node out of maintenance mode", func() {
+		node.Annotations = map[string]string{v1.NodeMaintenanceAnnotation: "true"}
+		expectNodes()
+
+		_, err := runCommand(maintenance.ACTION_EXIT, nodeName)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(getNode().Annotations).ToNot(HaveKey(v1.NodeMaintenanceAnnotation))
+	})
+
+	It("should list the VMIs blocking the drain", func() {
+		node.Annotations = map[string]string{
+			v1.NodeMaintenanceAnnotation:             "true",
+			v1.NodeMaintenanceBlockingVMIsAnnotation: "default/vmi-a,default/vmi-b",
+		}
+		expectNodes()
+
+		out, err := runCommand(maintenance.ACTION_STATUS, nodeName)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(out).To(ContainSubstring("2 VMI(s) block the drain"))
+		Expect(out).To(ContainSubstring("default/vmi-a"))
+		Expect(out).To(ContainSubstring("default/vmi-b"))
+	})
+
+	It("should report a drained node", func() {
+		node.Annotations = map[string]string{
+			v1.NodeMaintenanceAnnotation:             "true",
+			v1.NodeMaintenanceBlockingVMIsAnnotation: "",
+		}
+		expectNodes()
+
+		out, err := runCommand(maintenance.ACTION_STATUS, nodeName)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(out).To(ContainSubstring("has no VMIs left"))
+	})
+
+	It("should report a node which is not in maintenance mode", func() {
+		expectNodes()
+
+		out, err := runCommand(maintenance.ACTION_STATUS, nodeName)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(out).To(ContainSubstring("is not in maintenance mode"))
+	})
+})
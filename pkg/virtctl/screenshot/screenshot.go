@@ -0,0 +1,99 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package screenshot
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/client-go/tools/clientcmd"
+
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const COMMAND_SCREENSHOT = "screenshot"
+
+var outputFile string
+
+func NewScreenshotCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "screenshot (VMI)",
+		Short: "Take a screenshot of the graphical console of a virtual machine instance",
+		Long: `Captures the current framebuffer of the graphical console of a virtual machine instance as PNG,
+e.g. to see whether the guest is stuck in the boot loader or waits at a login prompt.`,
+		Args:    templates.ExactArgs("screenshot", 1),
+		Example: usage(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := Command{clientConfig: clientConfig}
+			return c.Run(cmd, args)
+		},
+	}
+	cmd.Flags().StringVarP(&outputFile, "file", "f", "", "File to write the PNG to, defaults to <VMI>.png. Use - to write to stdout.")
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func usage() string {
+	usage := "  # Save a screenshot of virtual machine instance 'testvmi' to testvmi.png:\n"
+	usage += "  {{ProgramName}} screenshot testvmi\n\n"
+	usage += "  # Save a screenshot of virtual machine instance 'testvmi' to /tmp/console.png:\n"
+	usage += "  {{ProgramName}} screenshot testvmi --file=/tmp/console.png"
+	return usage
+}
+
+type Command struct {
+	clientConfig clientcmd.ClientConfig
+}
+
+func (c *Command) Run(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	namespace, _, err := c.clientConfig.Namespace()
+	if err != nil {
+		return err
+	}
+
+	virtClient, err := kubecli.GetKubevirtClientFromClientConfig(c.clientConfig)
+	if err != nil {
+		return fmt.Errorf("Cannot obtain KubeVirt client: %v", err)
+	}
+
+	screenshot, err := virtClient.VirtualMachineInstance(namespace).Screenshot(name)
+	if err != nil {
+		return fmt.Errorf("Error taking a screenshot of VirtualMachineInstance %s: %v", name, err)
+	}
+
+	if outputFile == "-" {
+		_, err := cmd.OutOrStdout().Write(screenshot)
+		return err
+	}
+
+	file := outputFile
+	if file == "" {
+		file = name + ".png"
+	}
+	if err := ioutil.WriteFile(file, screenshot, 0644); err != nil {
+		return fmt.Errorf("Error writing the screenshot to %s: %v", file, err)
+	}
+	cmd.Printf("Screenshot of VirtualMachineInstance %s written to %s\n", name, file)
+	return nil
+}
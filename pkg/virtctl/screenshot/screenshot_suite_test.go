@@ -0,0 +1,11 @@
+package screenshot_test
+
+import (
+	"testing"
+
+	"kubevirt.io/client-go/testutils"
+)
+
+func TestScreenshot(t *testing.T) {
+	testutils.KubeVirtTestSuiteSetup(t)
+}
@@ -0,0 +1,80 @@
+package screenshot_test
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	k8sv1 "k8s.io/api/core/v1"
+
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/virtctl/screenshot"
+	"kubevirt.io/kubevirt/tests"
+)
+
+var _ = Describe("Screenshot", func() {
+
+	var ctrl *gomock.Controller
+	var vmiInterface *kubecli.MockVirtualMachineInstanceInterface
+	var tmpDir string
+
+	runCommand := func(args ...string) (string, error) {
+		out := &bytes.Buffer{}
+		cmd := tests.NewVirtctlCommand(append([]string{screenshot.COMMAND_SCREENSHOT}, args...)...)
+		cmd.SetOut(out)
+		cmd.SetErr(out)
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8sv1.NamespaceDefault).Return(vmiInterface).AnyTimes()
+
+		var err error
+		tmpDir, err = ioutil.TempDir("", "screenshot")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+		os.RemoveAll(tmpDir)
+	})
+
+	It("should write the screenshot to the given file", func() {
+		vmiInterface.EXPECT().Screenshot("testvmi").Return([]byte("png"), nil)
+		file := filepath.Join(tmpDir, "console.png")
+
+		out, err := runCommand("testvmi", "--file", file)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(out).To(ContainSubstring("written to " + file))
+
+		data, err := ioutil.ReadFile(file)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(data).To(Equal([]byte("png")))
+	})
+
+	It("should write the screenshot to stdout", func() {
+		vmiInterface.EXPECT().Screenshot("testvmi").Return([]byte("png"), nil)
+
+		out, err := runCommand("testvmi", "--file", "-")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(out).To(Equal("png"))
+	})
+
+	It("should fail if the screenshot is not available", func() {
+		vmiInterface.EXPECT().Screenshot("testvmi").Return(nil, fmt.Errorf("VMI is not running"))
+
+		_, err := runCommand("testvmi", "--file", filepath.Join(tmpDir, "console.png"))
+		Expect(err).To(MatchError(ContainSubstring("VMI is not running")))
+	})
+})
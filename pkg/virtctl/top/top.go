@@ -0,0 +1,120 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package top
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/virtctl/templates"
+)
+
+const (
+	COMMAND_TOP = "top"
+	COMMAND_VMI = "vmi"
+)
+
+func NewTopCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "top",
+		Short: "Display the CPU and memory usage of virtual machine instances",
+	}
+	cmd.AddCommand(newTopVMICommand(clientConfig))
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func newTopVMICommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vmi [VMI]",
+		Short: "Display the CPU and memory usage of virtual machine instances",
+		Long: `Displays the current CPU usage in cores and the memory usage in bytes of a virtual machine instance,
+or of all running virtual machine instances of the namespace if no name is given.`,
+		Args:    cobra.MaximumNArgs(1),
+		Example: usage(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := Command{clientConfig: clientConfig}
+			return c.Run(cmd, args)
+		},
+	}
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func usage() string {
+	usage := "  # Show the usage of all running virtual machine instances of the namespace:\n"
+	usage += "  {{ProgramName}} top vmi\n\n"
+	usage += "  # Show the usage of virtual machine instance 'testvmi':\n"
+	usage += "  {{ProgramName}} top vmi testvmi"
+	return usage
+}
+
+type Command struct {
+	clientConfig clientcmd.ClientConfig
+}
+
+func (c *Command) Run(cmd *cobra.Command, args []string) error {
+	namespace, _, err := c.clientConfig.Namespace()
+	if err != nil {
+		return err
+	}
+
+	virtClient, err := kubecli.GetKubevirtClientFromClientConfig(c.clientConfig)
+	if err != nil {
+		return fmt.Errorf("Cannot obtain KubeVirt client: %v", err)
+	}
+
+	var names []string
+	if len(args) == 1 {
+		names = append(names, args[0])
+	} else {
+		vmis, err := virtClient.VirtualMachineInstance(namespace).List(&k8smetav1.ListOptions{})
+		if err != nil {
+			return fmt.Errorf("Error listing VirtualMachineInstances: %v", err)
+		}
+		for _, vmi := range vmis.Items {
+			if vmi.Status.Phase == v1.Running {
+				names = append(names, vmi.Name)
+			}
+		}
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NAME\tCPU(cores)\tMEMORY(bytes)")
+	for _, name := range names {
+		usage, err := virtClient.VirtualMachineInstance(namespace).Usage(name)
+		if err != nil {
+			if len(args) == 1 {
+				return fmt.Errorf("Error getting the usage of VirtualMachineInstance %s: %v", name, err)
+			}
+			cmd.PrintErrf("Error getting the usage of VirtualMachineInstance %s: %v\n", name, err)
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%dm\t%dMi\n", name, usage.Usage.Cpu().MilliValue(), usage.Usage.Memory().Value()/(1024*1024))
+	}
+	return w.Flush()
+}
@@ -0,0 +1,93 @@
+package top_test
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	v1 "kubevirt.io/client-go/api/v1"
+	"kubevirt.io/client-go/kubecli"
+	"kubevirt.io/kubevirt/pkg/virtctl/top"
+	"kubevirt.io/kubevirt/tests"
+)
+
+var _ = Describe("Top", func() {
+
+	var ctrl *gomock.Controller
+	var vmiInterface *kubecli.MockVirtualMachineInstanceInterface
+
+	newUsage := func(cpu, memory string) *v1.VirtualMachineInstanceUsage {
+		return &v1.VirtualMachineInstanceUsage{
+			Usage: k8sv1.ResourceList{
+				k8sv1.ResourceCPU:    resource.MustParse(cpu),
+				k8sv1.ResourceMemory: resource.MustParse(memory),
+			},
+		}
+	}
+
+	newVMI := func(name string, phase v1.VirtualMachineInstancePhase) v1.VirtualMachineInstance {
+		vmi := v1.NewMinimalVMI(name)
+		vmi.Status.Phase = phase
+		return *vmi
+	}
+
+	runCommand := func(args ...string) (string, error) {
+		out := &bytes.Buffer{}
+		cmd := tests.NewVirtctlCommand(append([]string{top.COMMAND_TOP, top.COMMAND_VMI}, args...)...)
+		cmd.SetOut(out)
+		cmd.SetErr(out)
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		kubecli.GetKubevirtClientFromClientConfig = kubecli.GetMockKubevirtClientFromClientConfig
+		kubecli.MockKubevirtClientInstance = kubecli.NewMockKubevirtClient(ctrl)
+		vmiInterface = kubecli.NewMockVirtualMachineInstanceInterface(ctrl)
+		kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstance(k8sv1.NamespaceDefault).Return(vmiInterface).AnyTimes()
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("should show the usage of a single VMI", func() {
+		vmiInterface.EXPECT().Usage("testvmi").Return(newUsage("1500m", "1Gi"), nil)
+
+		out, err := runCommand("testvmi")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(out).To(MatchRegexp(`NAME\s+CPU\(cores\)\s+MEMORY\(bytes\)\ntestvmi\s+1500m\s+1024Mi\n`))
+	})
+
+	It("should fail if the usage of a single VMI is not available", func() {
+		vmiInterface.EXPECT().Usage("testvmi").Return(nil, fmt.Errorf("VMI is not running"))
+
+		_, err := runCommand("testvmi")
+		Expect(err).To(MatchError(ContainSubstring("VMI is not running")))
+	})
+
+	It("should show the usage of all running VMIs of the namespace", func() {
+		vmiInterface.EXPECT().List(gomock.Any()).Return(&v1.VirtualMachineInstanceList{
+			Items: []v1.VirtualMachineInstance{
+				newVMI("vmi-a", v1.Running),
+				newVMI("vmi-b", v1.Scheduling),
+				newVMI("vmi-c", v1.Running),
+			},
+		}, nil)
+		vmiInterface.EXPECT().Usage("vmi-a").Return(newUsage("250m", "512Mi"), nil)
+		vmiInterface.EXPECT().Usage("vmi-c").Return(nil, fmt.Errorf("no domain stats available"))
+
+		out, err := runCommand()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(out).To(ContainSubstring("vmi-a"))
+		Expect(out).ToNot(ContainSubstring("vmi-b"))
+		Expect(out).To(ContainSubstring("Error getting the usage of VirtualMachineInstance vmi-c"))
+	})
+})
@@ -38,15 +38,17 @@ import (
 )
 
 const (
-	COMMAND_START        = "start"
-	COMMAND_STOP         = "stop"
-	COMMAND_RESTART      = "restart"
-	COMMAND_MIGRATE      = "migrate"
-	COMMAND_GUESTOSINFO  = "guestosinfo"
-	COMMAND_USERLIST     = "userlist"
-	COMMAND_FSLIST       = "fslist"
-	COMMAND_ADDVOLUME    = "addvolume"
-	COMMAND_REMOVEVOLUME = "removevolume"
+	COMMAND_START          = "start"
+	COMMAND_STOP           = "stop"
+	COMMAND_RESTART        = "restart"
+	COMMAND_MIGRATE        = "migrate"
+	COMMAND_MIGRATE_CANCEL = "migrate-cancel"
+	COMMAND_GUESTOSINFO    = "guestosinfo"
+	COMMAND_USERLIST       = "userlist"
+	COMMAND_FSLIST         = "fslist"
+	COMMAND_ADDVOLUME      = "addvolume"
+	COMMAND_REMOVEVOLUME   = "removevolume"
+	COMMAND_BULK_PROGRESS  = "bulk-progress"
 
 	volumeNameArg         = "volume-name"
 	notDefinedGracePeriod = -1
@@ -59,20 +61,23 @@ var (
 	serial       string
 	persist      bool
 	startPaused  bool
+	selector     string
+	parallelism  int32
 )
 
 func NewStartCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "start (VM)",
 		Short:   "Start a virtual machine.",
-		Example: usage(COMMAND_START),
-		Args:    templates.ExactArgs("start", 1),
+		Example: usageWithSelector(COMMAND_START),
+		Args:    exactArgsOrSelector("start"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			c := Command{command: COMMAND_START, clientConfig: clientConfig}
 			return c.Run(args)
 		},
 	}
 	cmd.Flags().BoolVar(&startPaused, "paused", false, "--paused=false: If set to true, start virtual machine in paused state")
+	addSelectorFlags(cmd)
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	return cmd
 }
@@ -81,8 +86,8 @@ func NewStopCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "stop (VM)",
 		Short:   "Stop a virtual machine.",
-		Example: usage(COMMAND_STOP),
-		Args:    templates.ExactArgs("stop", 1),
+		Example: usageWithSelector(COMMAND_STOP),
+		Args:    exactArgsOrSelector("stop"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			c := Command{command: COMMAND_STOP, clientConfig: clientConfig}
 			return c.Run(args)
@@ -91,6 +96,7 @@ func NewStopCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
 
 	cmd.Flags().BoolVar(&forceRestart, "force", forceRestart, "--force=false: Only used when grace-period=0. If true, immediately remove VMI pod from API and bypass graceful deletion. Note that immediate deletion of some resources may result in inconsistency or data loss and requires confirmation.")
 	cmd.Flags().IntVar(&gracePeriod, "grace-period", gracePeriod, "--grace-period=-1: Period of time in seconds given to the VMI to terminate gracefully. Can only be set to 0 when --force is true (force deletion). Currently only setting 0 is supported.")
+	addSelectorFlags(cmd)
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	return cmd
 }
@@ -116,13 +122,59 @@ func NewMigrateCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "migrate (VM)",
 		Short:   "Migrate a virtual machine.",
-		Example: usage(COMMAND_MIGRATE),
-		Args:    templates.ExactArgs("migrate", 1),
+		Example: usageWithSelector(COMMAND_MIGRATE),
+		Args:    exactArgsOrSelector("migrate"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			c := Command{command: COMMAND_MIGRATE, clientConfig: clientConfig}
 			return c.Run(args)
 		},
 	}
+	addSelectorFlags(cmd)
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func NewBulkProgressCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "bulk-progress (HANDLE)",
+		Short:   "Show the progress of starting, stopping or migrating virtual machines by label selector.",
+		Example: usageBulkProgress(),
+		Args:    templates.ExactArgs("bulk-progress", 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := Command{command: COMMAND_BULK_PROGRESS, clientConfig: clientConfig}
+			return c.Run(args)
+		},
+	}
+	cmd.SetUsageTemplate(templates.UsageTemplate())
+	return cmd
+}
+
+func addSelectorFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&selector, "selector", "l", "", "Apply to all virtual machines matching the label selector instead of a single one, e.g. -l app=db")
+	cmd.Flags().Int32Var(&parallelism, "parallelism", 0, "Number of virtual machines processed at the same time when --selector is used. Defaults to 10, at most 50.")
+}
+
+// exactArgsOrSelector expects the name of a virtual machine, unless they are selected by label
+func exactArgsOrSelector(nameOfCommand string) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if selector != "" {
+			return templates.ExactArgs(nameOfCommand, 0)(cmd, args)
+		}
+		return templates.ExactArgs(nameOfCommand, 1)(cmd, args)
+	}
+}
+
+func NewMigrateCancelCommand(clientConfig clientcmd.ClientConfig) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "migrate-cancel (VM)",
+		Short:   "Cancel the migration of a virtual machine.",
+		Example: usageMigrateCancel(),
+		Args:    templates.ExactArgs("migrate-cancel", 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := Command{command: COMMAND_MIGRATE_CANCEL, clientConfig: clientConfig}
+			return c.Run(args)
+		},
+	}
 	cmd.SetUsageTemplate(templates.UsageTemplate())
 	return cmd
 }
@@ -254,6 +306,25 @@ func usage(cmd string) string {
 	return usage
 }
 
+func usageWithSelector(cmd string) string {
+	usage := usage(cmd)
+	usage += fmt.Sprintf("\n\n  # %s all virtual machines labeled app=db, 5 at a time:\n", strings.Title(cmd))
+	usage += fmt.Sprintf("  {{ProgramName}} %s -l app=db --parallelism 5", cmd)
+	return usage
+}
+
+func usageBulkProgress() string {
+	usage := `  # Show the progress of a bulk operation, using the handle printed when it was requested:
+  {{ProgramName}} bulk-progress eyJhY3Rpb24iOiJzdG9wIn0`
+	return usage
+}
+
+func usageMigrateCancel() string {
+	usage := `  # Cancel the in-flight migration of a virtual machine called 'myvm':
+  {{ProgramName}} migrate-cancel myvm`
+	return usage
+}
+
 func usageAddVolume() string {
 	usage := `  #Dynamically attach a volume to a running VM.
   {{ProgramName}} addvolume fedora-dv --volume-name=example-dv
@@ -326,14 +397,62 @@ func removeVolume(vmiName, volumeName, namespace string, virtClient kubecli.Kube
 	return nil
 }
 
+func cancelMigration(vmiName, namespace string, virtClient kubecli.KubevirtClient) error {
+	migrations, err := virtClient.VirtualMachineInstanceMigration(namespace).List(&metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", v1.MigrationSelectorLabel, vmiName),
+	})
+	if err != nil {
+		return fmt.Errorf("Error listing migrations of VirtualMachine %s, %v", vmiName, err)
+	}
+	for _, migration := range migrations.Items {
+		if migration.Spec.VMIName != vmiName || migration.IsFinal() {
+			continue
+		}
+		err = virtClient.VirtualMachineInstanceMigration(namespace).Cancel(migration.Name)
+		if err != nil {
+			return fmt.Errorf("Error cancelling migration %s of VirtualMachine %s, %v", migration.Name, vmiName, err)
+		}
+		fmt.Printf("Migration %s of VM %s was scheduled to be cancelled\n", migration.Name, vmiName)
+		return nil
+	}
+	return fmt.Errorf("No in-flight migration found for VirtualMachine %s", vmiName)
+}
+
+func requestBulkOperation(action v1.BulkOperationAction, namespace string, virtClient kubecli.KubevirtClient) error {
+	opts := &v1.BulkOperationOptions{
+		Action:   action,
+		Selector: selector,
+	}
+	if parallelism != 0 {
+		opts.Parallelism = &parallelism
+	}
+	bulkOperation, err := virtClient.VirtualMachine(namespace).BulkOperation(opts)
+	if err != nil {
+		return fmt.Errorf("Error requesting %s of VirtualMachines matching %q, %v", action, selector, err)
+	}
+	fmt.Printf("%d VMs matching %q are scheduled to %s\n", bulkOperation.Total, selector, action)
+	fmt.Printf("Use '%s %s' to follow the progress\n", COMMAND_BULK_PROGRESS, bulkOperation.Handle)
+	return nil
+}
+
+func printBulkOperationProgress(handle, namespace string, virtClient kubecli.KubevirtClient) error {
+	bulkOperation, err := virtClient.VirtualMachine(namespace).BulkOperationProgress(handle)
+	if err != nil {
+		return fmt.Errorf("Error getting the progress of bulk operation %s, %v", handle, err)
+	}
+	fmt.Printf("%s of VMs matching %q: %d of %d VMs completed\n", strings.Title(string(bulkOperation.Action)), bulkOperation.Selector, bulkOperation.Completed, bulkOperation.Total)
+	for _, failure := range bulkOperation.Failures {
+		fmt.Printf("VM %s failed: %s\n", failure.Name, failure.Message)
+	}
+	return nil
+}
+
 func gracePeriodIsSet(period int) bool {
 	return period != notDefinedGracePeriod
 }
 
 func (o *Command) Run(args []string) error {
 
-	vmiName := args[0]
-
 	namespace, _, err := o.clientConfig.Namespace()
 	if err != nil {
 		return err
@@ -344,6 +463,25 @@ func (o *Command) Run(args []string) error {
 		return fmt.Errorf("Cannot obtain KubeVirt client: %v", err)
 	}
 
+	if selector != "" {
+		switch o.command {
+		case COMMAND_START:
+			if startPaused {
+				return fmt.Errorf("Can not start paused with --selector")
+			}
+			return requestBulkOperation(v1.BulkStartAction, namespace, virtClient)
+		case COMMAND_STOP:
+			if forceRestart || gracePeriodIsSet(gracePeriod) {
+				return fmt.Errorf("Can not force stop with --selector")
+			}
+			return requestBulkOperation(v1.BulkStopAction, namespace, virtClient)
+		case COMMAND_MIGRATE:
+			return requestBulkOperation(v1.BulkMigrateAction, namespace, virtClient)
+		}
+	}
+
+	vmiName := args[0]
+
 	switch o.command {
 	case COMMAND_START:
 		err = virtClient.VirtualMachine(namespace).Start(vmiName, &v1.StartOptions{Paused: startPaused})
@@ -393,6 +531,8 @@ func (o *Command) Run(args []string) error {
 		if err != nil {
 			return fmt.Errorf("Error migrating VirtualMachine %v", err)
 		}
+	case COMMAND_MIGRATE_CANCEL:
+		return cancelMigration(vmiName, namespace, virtClient)
 	case COMMAND_GUESTOSINFO:
 		guestosinfo, err := virtClient.VirtualMachineInstance(namespace).GuestOsInfo(vmiName)
 		if err != nil {
@@ -436,6 +576,8 @@ func (o *Command) Run(args []string) error {
 		return addVolume(args[0], volumeName, namespace, virtClient)
 	case COMMAND_REMOVEVOLUME:
 		return removeVolume(args[0], volumeName, namespace, virtClient)
+	case COMMAND_BULK_PROGRESS:
+		return printBulkOperationProgress(args[0], namespace, virtClient)
 	}
 
 	fmt.Printf("VM %s was scheduled to %s\n", vmiName, o.command)
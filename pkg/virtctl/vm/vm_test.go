@@ -167,6 +167,92 @@ var _ = Describe("VirtualMachine", func() {
 		})
 	})
 
+	Context("with --selector flag", func() {
+		var parallelismFive int32 = 5
+
+		table.DescribeTable("should request a bulk operation", func(command string, action v1.BulkOperationAction, extraArgs []string, expectedParallelism *int32) {
+			bulkOperation := &v1.BulkOperation{Handle: "abc", Action: action, Selector: "app=db", Total: 2}
+
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface).Times(1)
+			vmInterface.EXPECT().BulkOperation(&v1.BulkOperationOptions{
+				Action:      action,
+				Selector:    "app=db",
+				Parallelism: expectedParallelism,
+			}).Return(bulkOperation, nil).Times(1)
+
+			cmd := tests.NewVirtctlCommand(append([]string{command, "--selector", "app=db"}, extraArgs...)...)
+			Expect(cmd.Execute()).To(Succeed())
+		},
+			table.Entry("to start", "start", v1.BulkStartAction, nil, nil),
+			table.Entry("to stop", "stop", v1.BulkStopAction, nil, nil),
+			table.Entry("to migrate", "migrate", v1.BulkMigrateAction, nil, nil),
+			table.Entry("to migrate with parallelism", "migrate", v1.BulkMigrateAction, []string{"--parallelism", "5"}, &parallelismFive),
+		)
+
+		It("should fail if a VM name is given as well", func() {
+			cmd := tests.NewRepeatableVirtctlCommand("stop", vmName, "-l", "app=db")
+			Expect(cmd()).To(HaveOccurred())
+		})
+
+		It("should fail to start paused", func() {
+			cmd := tests.NewRepeatableVirtctlCommand("start", "-l", "app=db", "--paused")
+			Expect(cmd()).To(HaveOccurred())
+		})
+
+		It("should show the progress of a bulk operation", func() {
+			bulkOperation := &v1.BulkOperation{Handle: "abc", Action: v1.BulkStopAction, Selector: "app=db", Total: 2, Completed: 1}
+
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachine(k8smetav1.NamespaceDefault).Return(vmInterface).Times(1)
+			vmInterface.EXPECT().BulkOperationProgress("abc").Return(bulkOperation, nil).Times(1)
+
+			cmd := tests.NewVirtctlCommand("bulk-progress", "abc")
+			Expect(cmd.Execute()).To(Succeed())
+		})
+	})
+
+	Context("with migrate-cancel VM cmd", func() {
+		var migrationInterface *kubecli.MockVirtualMachineInstanceMigrationInterface
+
+		newMigration := func(name string, phase v1.VirtualMachineInstanceMigrationPhase) v1.VirtualMachineInstanceMigration {
+			return v1.VirtualMachineInstanceMigration{
+				ObjectMeta: k8smetav1.ObjectMeta{Name: name, Namespace: k8smetav1.NamespaceDefault},
+				Spec:       v1.VirtualMachineInstanceMigrationSpec{VMIName: vmName},
+				Status:     v1.VirtualMachineInstanceMigrationStatus{Phase: phase},
+			}
+		}
+
+		BeforeEach(func() {
+			migrationInterface = kubecli.NewMockVirtualMachineInstanceMigrationInterface(ctrl)
+			kubecli.MockKubevirtClientInstance.EXPECT().VirtualMachineInstanceMigration(k8smetav1.NamespaceDefault).Return(migrationInterface).AnyTimes()
+		})
+
+		It("should cancel the in-flight migration of the vm", func() {
+			migrations := &v1.VirtualMachineInstanceMigrationList{
+				Items: []v1.VirtualMachineInstanceMigration{
+					newMigration("old", v1.MigrationSucceeded),
+					newMigration("current", v1.MigrationRunning),
+				},
+			}
+			migrationInterface.EXPECT().List(gomock.Any()).Return(migrations, nil).Times(1)
+			migrationInterface.EXPECT().Cancel("current").Return(nil).Times(1)
+
+			cmd := tests.NewVirtctlCommand("migrate-cancel", vmName)
+			Expect(cmd.Execute()).To(BeNil())
+		})
+
+		It("should fail if the vm has no in-flight migration", func() {
+			migrations := &v1.VirtualMachineInstanceMigrationList{
+				Items: []v1.VirtualMachineInstanceMigration{
+					newMigration("old", v1.MigrationFailed),
+				},
+			}
+			migrationInterface.EXPECT().List(gomock.Any()).Return(migrations, nil).Times(1)
+
+			cmd := tests.NewVirtctlCommand("migrate-cancel", vmName)
+			Expect(cmd.Execute()).To(HaveOccurred())
+		})
+	})
+
 	Context("with restart VM cmd", func() {
 		It("should restart vm", func() {
 			vm := kubecli.NewMinimalVM(vmName)
@@ -22,6 +22,7 @@ package v1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	resource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	types "k8s.io/apimachinery/pkg/types"
@@ -69,6 +70,32 @@ func (in *AccessCredentialSecretSource) DeepCopy() *AccessCredentialSecretSource
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccountingConfiguration) DeepCopyInto(out *AccountingConfiguration) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UsageReport != nil {
+		in, out := &in.UsageReport, &out.UsageReport
+		*out = new(UsageReportConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountingConfiguration.
+func (in *AccountingConfiguration) DeepCopy() *AccountingConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(AccountingConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AddVolumeOptions) DeepCopyInto(out *AddVolumeOptions) {
 	*out = *in
@@ -95,6 +122,37 @@ func (in *AddVolumeOptions) DeepCopy() *AddVolumeOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdmissionLimits) DeepCopyInto(out *AdmissionLimits) {
+	*out = *in
+	if in.CloudInitUserDataMaxBytes != nil {
+		in, out := &in.CloudInitUserDataMaxBytes, &out.CloudInitUserDataMaxBytes
+		*out = new(int)
+		**out = **in
+	}
+	if in.CloudInitNetworkDataMaxBytes != nil {
+		in, out := &in.CloudInitNetworkDataMaxBytes, &out.CloudInitNetworkDataMaxBytes
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxListLength != nil {
+		in, out := &in.MaxListLength, &out.MaxListLength
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionLimits.
+func (in *AdmissionLimits) DeepCopy() *AdmissionLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(AdmissionLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AuthorizedKeysFile) DeepCopyInto(out *AuthorizedKeysFile) {
 	*out = *in
@@ -111,6 +169,37 @@ func (in *AuthorizedKeysFile) DeepCopy() *AuthorizedKeysFile {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuxiliaryThreadsCPURequests) DeepCopyInto(out *AuxiliaryThreadsCPURequests) {
+	*out = *in
+	if in.Emulator != nil {
+		in, out := &in.Emulator, &out.Emulator
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.IOThread != nil {
+		in, out := &in.IOThread, &out.IOThread
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Virtiofs != nil {
+		in, out := &in.Virtiofs, &out.Virtiofs
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuxiliaryThreadsCPURequests.
+func (in *AuxiliaryThreadsCPURequests) DeepCopy() *AuxiliaryThreadsCPURequests {
+	if in == nil {
+		return nil
+	}
+	out := new(AuxiliaryThreadsCPURequests)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BIOS) DeepCopyInto(out *BIOS) {
 	*out = *in
@@ -184,6 +273,74 @@ func (in *Bootloader) DeepCopy() *Bootloader {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BulkOperation) DeepCopyInto(out *BulkOperation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.Failures != nil {
+		in, out := &in.Failures, &out.Failures
+		*out = make([]BulkOperationFailure, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BulkOperation.
+func (in *BulkOperation) DeepCopy() *BulkOperation {
+	if in == nil {
+		return nil
+	}
+	out := new(BulkOperation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BulkOperation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BulkOperationFailure) DeepCopyInto(out *BulkOperationFailure) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BulkOperationFailure.
+func (in *BulkOperationFailure) DeepCopy() *BulkOperationFailure {
+	if in == nil {
+		return nil
+	}
+	out := new(BulkOperationFailure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BulkOperationOptions) DeepCopyInto(out *BulkOperationOptions) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.Parallelism != nil {
+		in, out := &in.Parallelism, &out.Parallelism
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BulkOperationOptions.
+func (in *BulkOperationOptions) DeepCopy() *BulkOperationOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(BulkOperationOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CDRomTarget) DeepCopyInto(out *CDRomTarget) {
 	*out = *in
@@ -485,6 +642,48 @@ func (in *ConfigMapVolumeSource) DeepCopy() *ConfigMapVolumeSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerDiskPolicy) DeepCopyInto(out *ContainerDiskPolicy) {
+	*out = *in
+	if in.AllowedRegistries != nil {
+		in, out := &in.AllowedRegistries, &out.AllowedRegistries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SignatureVerification != nil {
+		in, out := &in.SignatureVerification, &out.SignatureVerification
+		*out = new(ContainerDiskSignatureVerification)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerDiskPolicy.
+func (in *ContainerDiskPolicy) DeepCopy() *ContainerDiskPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerDiskPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerDiskSignatureVerification) DeepCopyInto(out *ContainerDiskSignatureVerification) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerDiskSignatureVerification.
+func (in *ContainerDiskSignatureVerification) DeepCopy() *ContainerDiskSignatureVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerDiskSignatureVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContainerDiskSource) DeepCopyInto(out *ContainerDiskSource) {
 	*out = *in
@@ -572,6 +771,11 @@ func (in *DHCPOptions) DeepCopyInto(out *DHCPOptions) {
 		*out = make([]DHCPPrivateOptions, len(*in))
 		copy(*out, *in)
 	}
+	if in.LeaseTime != nil {
+		in, out := &in.LeaseTime, &out.LeaseTime
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -687,6 +891,13 @@ func (in *DeveloperConfiguration) DeepCopyInto(out *DeveloperConfiguration) {
 		*out = new(LogVerbosity)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NamespacedFeatureGates != nil {
+		in, out := &in.NamespacedFeatureGates, &out.NamespacedFeatureGates
+		*out = make([]NamespacedFeatureGate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -762,6 +973,11 @@ func (in *Devices) DeepCopyInto(out *Devices) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.SCSIController != nil {
+		in, out := &in.SCSIController, &out.SCSIController
+		*out = new(SCSIController)
+		**out = **in
+	}
 	if in.NetworkInterfaceMultiQueue != nil {
 		in, out := &in.NetworkInterfaceMultiQueue, &out.NetworkInterfaceMultiQueue
 		*out = new(bool)
@@ -770,7 +986,14 @@ func (in *Devices) DeepCopyInto(out *Devices) {
 	if in.GPUs != nil {
 		in, out := &in.GPUs, &out.GPUs
 		*out = make([]GPU, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DetachGPUsForMigration != nil {
+		in, out := &in.DetachGPUsForMigration, &out.DetachGPUsForMigration
+		*out = new(bool)
+		**out = **in
 	}
 	if in.Filesystems != nil {
 		in, out := &in.Filesystems, &out.Filesystems
@@ -782,13 +1005,20 @@ func (in *Devices) DeepCopyInto(out *Devices) {
 	if in.HostDevices != nil {
 		in, out := &in.HostDevices, &out.HostDevices
 		*out = make([]HostDevice, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.ClientPassthrough != nil {
 		in, out := &in.ClientPassthrough, &out.ClientPassthrough
 		*out = new(ClientPassthroughDevices)
 		**out = **in
 	}
+	if in.Channels != nil {
+		in, out := &in.Channels, &out.Channels
+		*out = make([]SerialChannel, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -821,6 +1051,11 @@ func (in *Disk) DeepCopyInto(out *Disk) {
 		*out = new(BlockSize)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Shareable != nil {
+		in, out := &in.Shareable, &out.Shareable
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -924,7 +1159,7 @@ func (in *DomainSpec) DeepCopyInto(out *DomainSpec) {
 	if in.Machine != nil {
 		in, out := &in.Machine, &out.Machine
 		*out = new(Machine)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Firmware != nil {
 		in, out := &in.Firmware, &out.Firmware
@@ -952,6 +1187,11 @@ func (in *DomainSpec) DeepCopyInto(out *DomainSpec) {
 		*out = new(Chassis)
 		**out = **in
 	}
+	if in.GuestOS != nil {
+		in, out := &in.GuestOS, &out.GuestOS
+		*out = new(GuestOS)
+		**out = **in
+	}
 	return
 }
 
@@ -1415,6 +1655,11 @@ func (in *FloppyTarget) DeepCopy() *FloppyTarget {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GPU) DeepCopyInto(out *GPU) {
 	*out = *in
+	if in.PCIOrder != nil {
+		in, out := &in.PCIOrder, &out.PCIOrder
+		*out = new(uint)
+		**out = **in
+	}
 	return
 }
 
@@ -1476,6 +1721,22 @@ func (in *GuestAgentPing) DeepCopy() *GuestAgentPing {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuestOS) DeepCopyInto(out *GuestOS) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuestOS.
+func (in *GuestOS) DeepCopy() *GuestOS {
+	if in == nil {
+		return nil
+	}
+	out := new(GuestOS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HPETTimer) DeepCopyInto(out *HPETTimer) {
 	*out = *in
@@ -1536,6 +1797,11 @@ func (in *Handler) DeepCopy() *Handler {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostDevice) DeepCopyInto(out *HostDevice) {
 	*out = *in
+	if in.PCIOrder != nil {
+		in, out := &in.PCIOrder, &out.PCIOrder
+		*out = new(uint)
+		**out = **in
+	}
 	return
 }
 
@@ -1666,6 +1932,22 @@ func (in *I6300ESBWatchdog) DeepCopy() *I6300ESBWatchdog {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageRegistryMirror) DeepCopyInto(out *ImageRegistryMirror) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageRegistryMirror.
+func (in *ImageRegistryMirror) DeepCopy() *ImageRegistryMirror {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageRegistryMirror)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Input) DeepCopyInto(out *Input) {
 	*out = *in
@@ -1701,6 +1983,21 @@ func (in *Interface) DeepCopyInto(out *Interface) {
 		*out = new(DHCPOptions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Mirror != nil {
+		in, out := &in.Mirror, &out.Mirror
+		*out = new(InterfaceMirror)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Bandwidth != nil {
+		in, out := &in.Bandwidth, &out.Bandwidth
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.BandwidthLimit != nil {
+		in, out := &in.BandwidthLimit, &out.BandwidthLimit
+		*out = new(InterfaceBandwidthLimit)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1714,6 +2011,32 @@ func (in *Interface) DeepCopy() *Interface {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterfaceBandwidthLimit) DeepCopyInto(out *InterfaceBandwidthLimit) {
+	*out = *in
+	if in.Inbound != nil {
+		in, out := &in.Inbound, &out.Inbound
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Outbound != nil {
+		in, out := &in.Outbound, &out.Outbound
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceBandwidthLimit.
+func (in *InterfaceBandwidthLimit) DeepCopy() *InterfaceBandwidthLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(InterfaceBandwidthLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InterfaceBindingMethod) DeepCopyInto(out *InterfaceBindingMethod) {
 	*out = *in
@@ -1808,6 +2131,48 @@ func (in *InterfaceMasquerade) DeepCopy() *InterfaceMasquerade {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterfaceMirror) DeepCopyInto(out *InterfaceMirror) {
+	*out = *in
+	if in.Filter != nil {
+		in, out := &in.Filter, &out.Filter
+		*out = new(InterfaceMirrorFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceMirror.
+func (in *InterfaceMirror) DeepCopy() *InterfaceMirror {
+	if in == nil {
+		return nil
+	}
+	out := new(InterfaceMirror)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterfaceMirrorFilter) DeepCopyInto(out *InterfaceMirrorFilter) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceMirrorFilter.
+func (in *InterfaceMirrorFilter) DeepCopy() *InterfaceMirrorFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(InterfaceMirrorFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InterfaceSRIOV) DeepCopyInto(out *InterfaceSRIOV) {
 	*out = *in
@@ -1964,7 +2329,23 @@ func (in *KubeVirtCertificateRotateStrategy) DeepCopy() *KubeVirtCertificateRota
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeVirtCondition) DeepCopyInto(out *KubeVirtCondition) {
+func (in *KubeVirtCommonTemplates) DeepCopyInto(out *KubeVirtCommonTemplates) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeVirtCommonTemplates.
+func (in *KubeVirtCommonTemplates) DeepCopy() *KubeVirtCommonTemplates {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeVirtCommonTemplates)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeVirtCondition) DeepCopyInto(out *KubeVirtCondition) {
 	*out = *in
 	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
 	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
@@ -2066,6 +2447,56 @@ func (in *KubeVirtConfiguration) DeepCopyInto(out *KubeVirtConfiguration) {
 		*out = new(ReloadableComponentConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.ImageRegistryMirrors != nil {
+		in, out := &in.ImageRegistryMirrors, &out.ImageRegistryMirrors
+		*out = make([]ImageRegistryMirror, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdditionalGuestMemoryOverheadRatio != nil {
+		in, out := &in.AdditionalGuestMemoryOverheadRatio, &out.AdditionalGuestMemoryOverheadRatio
+		*out = new(string)
+		**out = **in
+	}
+	if in.LauncherPodMetadataPropagation != nil {
+		in, out := &in.LauncherPodMetadataPropagation, &out.LauncherPodMetadataPropagation
+		*out = new(LauncherPodMetadataPropagation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeShutdownGracePeriodSeconds != nil {
+		in, out := &in.NodeShutdownGracePeriodSeconds, &out.NodeShutdownGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.AuxiliaryThreadsCPURequests != nil {
+		in, out := &in.AuxiliaryThreadsCPURequests, &out.AuxiliaryThreadsCPURequests
+		*out = new(AuxiliaryThreadsCPURequests)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ContainerDiskPolicy != nil {
+		in, out := &in.ContainerDiskPolicy, &out.ContainerDiskPolicy
+		*out = new(ContainerDiskPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AccountingConfiguration != nil {
+		in, out := &in.AccountingConfiguration, &out.AccountingConfiguration
+		*out = new(AccountingConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdmissionLimits != nil {
+		in, out := &in.AdmissionLimits, &out.AdmissionLimits
+		*out = new(AdmissionLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.QEMUOptionsAllowlist != nil {
+		in, out := &in.QEMUOptionsAllowlist, &out.QEMUOptionsAllowlist
+		*out = new(QEMUOptionsAllowlist)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2079,6 +2510,80 @@ func (in *KubeVirtConfiguration) DeepCopy() *KubeVirtConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeVirtHandlerCanaryStrategy) DeepCopyInto(out *KubeVirtHandlerCanaryStrategy) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ValidationTimeout != nil {
+		in, out := &in.ValidationTimeout, &out.ValidationTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeVirtHandlerCanaryStrategy.
+func (in *KubeVirtHandlerCanaryStrategy) DeepCopy() *KubeVirtHandlerCanaryStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeVirtHandlerCanaryStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeVirtHandlerRolloutStatus) DeepCopyInto(out *KubeVirtHandlerRolloutStatus) {
+	*out = *in
+	if in.CanaryNodes != nil {
+		in, out := &in.CanaryNodes, &out.CanaryNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeVirtHandlerRolloutStatus.
+func (in *KubeVirtHandlerRolloutStatus) DeepCopy() *KubeVirtHandlerRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeVirtHandlerRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeVirtHandlerUpdateStrategy) DeepCopyInto(out *KubeVirtHandlerUpdateStrategy) {
+	*out = *in
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(KubeVirtHandlerCanaryStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeVirtHandlerUpdateStrategy.
+func (in *KubeVirtHandlerUpdateStrategy) DeepCopy() *KubeVirtHandlerUpdateStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeVirtHandlerUpdateStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeVirtList) DeepCopyInto(out *KubeVirtList) {
 	*out = *in
@@ -2157,6 +2662,11 @@ func (in *KubeVirtSelfSignConfiguration) DeepCopy() *KubeVirtSelfSignConfigurati
 func (in *KubeVirtSpec) DeepCopyInto(out *KubeVirtSpec) {
 	*out = *in
 	in.WorkloadUpdateStrategy.DeepCopyInto(&out.WorkloadUpdateStrategy)
+	if in.HandlerUpdateStrategy != nil {
+		in, out := &in.HandlerUpdateStrategy, &out.HandlerUpdateStrategy
+		*out = new(KubeVirtHandlerUpdateStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 	in.CertificateRotationStrategy.DeepCopyInto(&out.CertificateRotationStrategy)
 	in.Configuration.DeepCopyInto(&out.Configuration)
 	if in.Infra != nil {
@@ -2169,6 +2679,11 @@ func (in *KubeVirtSpec) DeepCopyInto(out *KubeVirtSpec) {
 		*out = new(ComponentConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CommonTemplates != nil {
+		in, out := &in.CommonTemplates, &out.CommonTemplates
+		*out = new(KubeVirtCommonTemplates)
+		**out = **in
+	}
 	in.CustomizeComponents.DeepCopyInto(&out.CustomizeComponents)
 	return
 }
@@ -2203,6 +2718,16 @@ func (in *KubeVirtStatus) DeepCopyInto(out *KubeVirtStatus) {
 		*out = make([]GenerationStatus, len(*in))
 		copy(*out, *in)
 	}
+	if in.UnappliedConfiguration != nil {
+		in, out := &in.UnappliedConfiguration, &out.UnappliedConfiguration
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HandlerRollout != nil {
+		in, out := &in.HandlerRollout, &out.HandlerRollout
+		*out = new(KubeVirtHandlerRolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2247,6 +2772,62 @@ func (in *KubeVirtWorkloadUpdateStrategy) DeepCopy() *KubeVirtWorkloadUpdateStra
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LauncherPodMetadata) DeepCopyInto(out *LauncherPodMetadata) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LauncherPodMetadata.
+func (in *LauncherPodMetadata) DeepCopy() *LauncherPodMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(LauncherPodMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LauncherPodMetadataPropagation) DeepCopyInto(out *LauncherPodMetadataPropagation) {
+	*out = *in
+	if in.AllowedLabels != nil {
+		in, out := &in.AllowedLabels, &out.AllowedLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedAnnotations != nil {
+		in, out := &in.AllowedAnnotations, &out.AllowedAnnotations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LauncherPodMetadataPropagation.
+func (in *LauncherPodMetadataPropagation) DeepCopy() *LauncherPodMetadataPropagation {
+	if in == nil {
+		return nil
+	}
+	out := new(LauncherPodMetadataPropagation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LogVerbosity) DeepCopyInto(out *LogVerbosity) {
 	*out = *in
@@ -2289,6 +2870,16 @@ func (in *LunTarget) DeepCopy() *LunTarget {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Machine) DeepCopyInto(out *Machine) {
 	*out = *in
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make([]QEMUOption, len(*in))
+		copy(*out, *in)
+	}
+	if in.Globals != nil {
+		in, out := &in.Globals, &out.Globals
+		*out = make([]QEMUOption, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -2302,6 +2893,126 @@ func (in *Machine) DeepCopy() *Machine {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenancePeriod) DeepCopyInto(out *MaintenancePeriod) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.Duration = in.Duration
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenancePeriod.
+func (in *MaintenancePeriod) DeepCopy() *MaintenancePeriod {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenancePeriod)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MaintenanceWindow) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindowList) DeepCopyInto(out *MaintenanceWindowList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MaintenanceWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindowList.
+func (in *MaintenanceWindowList) DeepCopy() *MaintenanceWindowList {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindowList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MaintenanceWindowList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindowSpec) DeepCopyInto(out *MaintenanceWindowSpec) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = make([]MaintenanceAction, len(*in))
+		copy(*out, *in)
+	}
+	if in.Periods != nil {
+		in, out := &in.Periods, &out.Periods
+		*out = make([]MaintenancePeriod, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindowSpec.
+func (in *MaintenanceWindowSpec) DeepCopy() *MaintenanceWindowSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindowSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MediatedDevicesConfiguration) DeepCopyInto(out *MediatedDevicesConfiguration) {
 	*out = *in
@@ -2366,27 +3077,97 @@ func (in *Memory) DeepCopy() *Memory {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MigrationConfiguration) DeepCopyInto(out *MigrationConfiguration) {
+func (in *MemoryDumpVolumeSource) DeepCopyInto(out *MemoryDumpVolumeSource) {
 	*out = *in
-	if in.NodeDrainTaintKey != nil {
-		in, out := &in.NodeDrainTaintKey, &out.NodeDrainTaintKey
-		*out = new(string)
-		**out = **in
-	}
-	if in.ParallelOutboundMigrationsPerNode != nil {
-		in, out := &in.ParallelOutboundMigrationsPerNode, &out.ParallelOutboundMigrationsPerNode
-		*out = new(uint32)
-		**out = **in
-	}
-	if in.ParallelMigrationsPerCluster != nil {
-		in, out := &in.ParallelMigrationsPerCluster, &out.ParallelMigrationsPerCluster
-		*out = new(uint32)
-		**out = **in
+	out.PersistentVolumeClaimVolumeSource = in.PersistentVolumeClaimVolumeSource
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemoryDumpVolumeSource.
+func (in *MemoryDumpVolumeSource) DeepCopy() *MemoryDumpVolumeSource {
+	if in == nil {
+		return nil
 	}
-	if in.AllowAutoConverge != nil {
-		in, out := &in.AllowAutoConverge, &out.AllowAutoConverge
-		*out = new(bool)
-		**out = **in
+	out := new(MemoryDumpVolumeSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemorySnapshotObjectStorage) DeepCopyInto(out *MemorySnapshotObjectStorage) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemorySnapshotObjectStorage.
+func (in *MemorySnapshotObjectStorage) DeepCopy() *MemorySnapshotObjectStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(MemorySnapshotObjectStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemorySnapshotOptions) DeepCopyInto(out *MemorySnapshotOptions) {
+	*out = *in
+	if in.ObjectStorage != nil {
+		in, out := &in.ObjectStorage, &out.ObjectStorage
+		*out = new(MemorySnapshotObjectStorage)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemorySnapshotOptions.
+func (in *MemorySnapshotOptions) DeepCopy() *MemorySnapshotOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(MemorySnapshotOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemorySnapshotUploadStatus) DeepCopyInto(out *MemorySnapshotUploadStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemorySnapshotUploadStatus.
+func (in *MemorySnapshotUploadStatus) DeepCopy() *MemorySnapshotUploadStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MemorySnapshotUploadStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MigrationConfiguration) DeepCopyInto(out *MigrationConfiguration) {
+	*out = *in
+	if in.NodeDrainTaintKey != nil {
+		in, out := &in.NodeDrainTaintKey, &out.NodeDrainTaintKey
+		*out = new(string)
+		**out = **in
+	}
+	if in.ParallelOutboundMigrationsPerNode != nil {
+		in, out := &in.ParallelOutboundMigrationsPerNode, &out.ParallelOutboundMigrationsPerNode
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.ParallelMigrationsPerCluster != nil {
+		in, out := &in.ParallelMigrationsPerCluster, &out.ParallelMigrationsPerCluster
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.AllowAutoConverge != nil {
+		in, out := &in.AllowAutoConverge, &out.AllowAutoConverge
+		*out = new(bool)
+		**out = **in
 	}
 	if in.BandwidthPerMigration != nil {
 		in, out := &in.BandwidthPerMigration, &out.BandwidthPerMigration
@@ -2418,6 +3199,16 @@ func (in *MigrationConfiguration) DeepCopyInto(out *MigrationConfiguration) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.HostPassthroughPolicy != nil {
+		in, out := &in.HostPassthroughPolicy, &out.HostPassthroughPolicy
+		*out = new(HostPassthroughMigrationPolicy)
+		**out = **in
+	}
+	if in.PreSwitchoverDrainSeconds != nil {
+		in, out := &in.PreSwitchoverDrainSeconds, &out.PreSwitchoverDrainSeconds
+		*out = new(int64)
+		**out = **in
+	}
 	return
 }
 
@@ -2484,6 +3275,27 @@ func (in *NUMAGuestMappingPassthrough) DeepCopy() *NUMAGuestMappingPassthrough {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespacedFeatureGate) DeepCopyInto(out *NamespacedFeatureGate) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespacedFeatureGate.
+func (in *NamespacedFeatureGate) DeepCopy() *NamespacedFeatureGate {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespacedFeatureGate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Network) DeepCopyInto(out *Network) {
 	*out = *in
@@ -2514,6 +3326,18 @@ func (in *NetworkConfiguration) DeepCopyInto(out *NetworkConfiguration) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.NodeBandwidth != nil {
+		in, out := &in.NodeBandwidth, &out.NodeBandwidth
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.NodeBandwidthOverrides != nil {
+		in, out := &in.NodeBandwidthOverrides, &out.NodeBandwidthOverrides
+		*out = make(map[string]resource.Quantity, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
 	return
 }
 
@@ -2527,6 +3351,60 @@ func (in *NetworkConfiguration) DeepCopy() *NetworkConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkDiskAuth) DeepCopyInto(out *NetworkDiskAuth) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkDiskAuth.
+func (in *NetworkDiskAuth) DeepCopy() *NetworkDiskAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkDiskAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkDiskHost) DeepCopyInto(out *NetworkDiskHost) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkDiskHost.
+func (in *NetworkDiskHost) DeepCopy() *NetworkDiskHost {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkDiskHost)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkDiskSource) DeepCopyInto(out *NetworkDiskSource) {
+	*out = *in
+	out.Host = in.Host
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(NetworkDiskAuth)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkDiskSource.
+func (in *NetworkDiskSource) DeepCopy() *NetworkDiskSource {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkDiskSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkSource) DeepCopyInto(out *NetworkSource) {
 	*out = *in
@@ -2701,6 +3579,22 @@ func (in *PersistentVolumeClaimVolumeSource) DeepCopy() *PersistentVolumeClaimVo
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PinnedContainerDiskImage) DeepCopyInto(out *PinnedContainerDiskImage) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PinnedContainerDiskImage.
+func (in *PinnedContainerDiskImage) DeepCopy() *PinnedContainerDiskImage {
+	if in == nil {
+		return nil
+	}
+	out := new(PinnedContainerDiskImage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodNetwork) DeepCopyInto(out *PodNetwork) {
 	*out = *in
@@ -2733,6 +3627,22 @@ func (in *Port) DeepCopy() *Port {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreferredNode) DeepCopyInto(out *PreferredNode) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreferredNode.
+func (in *PreferredNode) DeepCopy() *PreferredNode {
+	if in == nil {
+		return nil
+	}
+	out := new(PreferredNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Probe) DeepCopyInto(out *Probe) {
 	*out = *in
@@ -2750,6 +3660,48 @@ func (in *Probe) DeepCopy() *Probe {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QEMUOption) DeepCopyInto(out *QEMUOption) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QEMUOption.
+func (in *QEMUOption) DeepCopy() *QEMUOption {
+	if in == nil {
+		return nil
+	}
+	out := new(QEMUOption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QEMUOptionsAllowlist) DeepCopyInto(out *QEMUOptionsAllowlist) {
+	*out = *in
+	if in.MachineOptions != nil {
+		in, out := &in.MachineOptions, &out.MachineOptions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Globals != nil {
+		in, out := &in.Globals, &out.Globals
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QEMUOptionsAllowlist.
+func (in *QEMUOptionsAllowlist) DeepCopy() *QEMUOptionsAllowlist {
+	if in == nil {
+		return nil
+	}
+	out := new(QEMUOptionsAllowlist)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *QemuGuestAgentSSHPublicKeyAccessCredentialPropagation) DeepCopyInto(out *QemuGuestAgentSSHPublicKeyAccessCredentialPropagation) {
 	*out = *in
@@ -2955,6 +3907,22 @@ func (in *Rng) DeepCopy() *Rng {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SCSIController) DeepCopyInto(out *SCSIController) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SCSIController.
+func (in *SCSIController) DeepCopy() *SCSIController {
+	if in == nil {
+		return nil
+	}
+	out := new(SCSIController)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SMBiosConfiguration) DeepCopyInto(out *SMBiosConfiguration) {
 	*out = *in
@@ -3037,103 +4005,252 @@ func (in *SSHPublicKeyAccessCredentialSource) DeepCopy() *SSHPublicKeyAccessCred
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecretVolumeSource) DeepCopyInto(out *SecretVolumeSource) {
+func (in *SchedulingFeasibility) DeepCopyInto(out *SchedulingFeasibility) {
 	*out = *in
-	if in.Optional != nil {
-		in, out := &in.Optional, &out.Optional
-		*out = new(bool)
-		**out = **in
+	out.TypeMeta = in.TypeMeta
+	if in.FailedConstraints != nil {
+		in, out := &in.FailedConstraints, &out.FailedConstraints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeFailures != nil {
+		in, out := &in.NodeFailures, &out.NodeFailures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretVolumeSource.
-func (in *SecretVolumeSource) DeepCopy() *SecretVolumeSource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulingFeasibility.
+func (in *SchedulingFeasibility) DeepCopy() *SchedulingFeasibility {
 	if in == nil {
 		return nil
 	}
-	out := new(SecretVolumeSource)
+	out := new(SchedulingFeasibility)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SchedulingFeasibility) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServiceAccountVolumeSource) DeepCopyInto(out *ServiceAccountVolumeSource) {
+func (in *SchedulingFeasibilityOptions) DeepCopyInto(out *SchedulingFeasibilityOptions) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.Spec.DeepCopyInto(&out.Spec)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountVolumeSource.
-func (in *ServiceAccountVolumeSource) DeepCopy() *ServiceAccountVolumeSource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulingFeasibilityOptions.
+func (in *SchedulingFeasibilityOptions) DeepCopy() *SchedulingFeasibilityOptions {
 	if in == nil {
 		return nil
 	}
-	out := new(ServiceAccountVolumeSource)
+	out := new(SchedulingFeasibilityOptions)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *StartOptions) DeepCopyInto(out *StartOptions) {
+func (in *SchedulingReadinessGate) DeepCopyInto(out *SchedulingReadinessGate) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StartOptions.
-func (in *StartOptions) DeepCopy() *StartOptions {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulingReadinessGate.
+func (in *SchedulingReadinessGate) DeepCopy() *SchedulingReadinessGate {
 	if in == nil {
 		return nil
 	}
-	out := new(StartOptions)
+	out := new(SchedulingReadinessGate)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *StopOptions) DeepCopyInto(out *StopOptions) {
+func (in *SecretVolumeSource) DeepCopyInto(out *SecretVolumeSource) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	if in.GracePeriod != nil {
-		in, out := &in.GracePeriod, &out.GracePeriod
-		*out = new(int64)
+	if in.Optional != nil {
+		in, out := &in.Optional, &out.Optional
+		*out = new(bool)
 		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StopOptions.
-func (in *StopOptions) DeepCopy() *StopOptions {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretVolumeSource.
+func (in *SecretVolumeSource) DeepCopy() *SecretVolumeSource {
 	if in == nil {
 		return nil
 	}
-	out := new(StopOptions)
+	out := new(SecretVolumeSource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SyNICTimer) DeepCopyInto(out *SyNICTimer) {
+func (in *SerialChannel) DeepCopyInto(out *SerialChannel) {
 	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
-		**out = **in
-	}
-	if in.Direct != nil {
-		in, out := &in.Direct, &out.Direct
-		*out = new(FeatureState)
-		(*in).DeepCopyInto(*out)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyNICTimer.
-func (in *SyNICTimer) DeepCopy() *SyNICTimer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SerialChannel.
+func (in *SerialChannel) DeepCopy() *SerialChannel {
 	if in == nil {
 		return nil
 	}
-	out := new(SyNICTimer)
+	out := new(SerialChannel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountVolumeSource) DeepCopyInto(out *ServiceAccountVolumeSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountVolumeSource.
+func (in *ServiceAccountVolumeSource) DeepCopy() *ServiceAccountVolumeSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountVolumeSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SetLinkStateOptions) DeepCopyInto(out *SetLinkStateOptions) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SetLinkStateOptions.
+func (in *SetLinkStateOptions) DeepCopy() *SetLinkStateOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(SetLinkStateOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Sidecar) DeepCopyInto(out *Sidecar) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]SidecarVolumeMount, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Sidecar.
+func (in *Sidecar) DeepCopy() *Sidecar {
+	if in == nil {
+		return nil
+	}
+	out := new(Sidecar)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SidecarVolumeMount) DeepCopyInto(out *SidecarVolumeMount) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SidecarVolumeMount.
+func (in *SidecarVolumeMount) DeepCopy() *SidecarVolumeMount {
+	if in == nil {
+		return nil
+	}
+	out := new(SidecarVolumeMount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StartOptions) DeepCopyInto(out *StartOptions) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StartOptions.
+func (in *StartOptions) DeepCopy() *StartOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(StartOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StopOptions) DeepCopyInto(out *StopOptions) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StopOptions.
+func (in *StopOptions) DeepCopy() *StopOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(StopOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyNICTimer) DeepCopyInto(out *SyNICTimer) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Direct != nil {
+		in, out := &in.Direct, &out.Direct
+		*out = new(FeatureState)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyNICTimer.
+func (in *SyNICTimer) DeepCopy() *SyNICTimer {
+	if in == nil {
+		return nil
+	}
+	out := new(SyNICTimer)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -3242,6 +4359,27 @@ func (in *TopologyHints) DeepCopy() *TopologyHints {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UsageReportConfiguration) DeepCopyInto(out *UsageReportConfiguration) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UsageReportConfiguration.
+func (in *UsageReportConfiguration) DeepCopy() *UsageReportConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(UsageReportConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserPasswordAccessCredential) DeepCopyInto(out *UserPasswordAccessCredential) {
 	*out = *in
@@ -3318,6 +4456,102 @@ func (in *VMISelector) DeepCopy() *VMISelector {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValidationPolicy) DeepCopyInto(out *ValidationPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValidationPolicy.
+func (in *ValidationPolicy) DeepCopy() *ValidationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ValidationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ValidationPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValidationPolicyList) DeepCopyInto(out *ValidationPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ValidationPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValidationPolicyList.
+func (in *ValidationPolicyList) DeepCopy() *ValidationPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ValidationPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ValidationPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValidationPolicySpec) DeepCopyInto(out *ValidationPolicySpec) {
+	*out = *in
+	if in.MaxCPUs != nil {
+		in, out := &in.MaxCPUs, &out.MaxCPUs
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.ForbiddenDiskBuses != nil {
+		in, out := &in.ForbiddenDiskBuses, &out.ForbiddenDiskBuses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RequiredLabels != nil {
+		in, out := &in.RequiredLabels, &out.RequiredLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedContainerDiskRegistries != nil {
+		in, out := &in.AllowedContainerDiskRegistries, &out.AllowedContainerDiskRegistries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValidationPolicySpec.
+func (in *ValidationPolicySpec) DeepCopy() *ValidationPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ValidationPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachine) DeepCopyInto(out *VirtualMachine) {
 	*out = *in
@@ -3343,23 +4577,271 @@ func (in *VirtualMachine) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
-	return nil
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineBulkOperation) DeepCopyInto(out *VirtualMachineBulkOperation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineBulkOperation.
+func (in *VirtualMachineBulkOperation) DeepCopy() *VirtualMachineBulkOperation {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineBulkOperation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineBulkOperation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineBulkOperationList) DeepCopyInto(out *VirtualMachineBulkOperationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachineBulkOperation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineBulkOperationList.
+func (in *VirtualMachineBulkOperationList) DeepCopy() *VirtualMachineBulkOperationList {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineBulkOperationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineBulkOperationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineBulkOperationSpec) DeepCopyInto(out *VirtualMachineBulkOperationSpec) {
+	*out = *in
+	if in.VirtualMachines != nil {
+		in, out := &in.VirtualMachines, &out.VirtualMachines
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Extra != nil {
+		in, out := &in.Extra, &out.Extra
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineBulkOperationSpec.
+func (in *VirtualMachineBulkOperationSpec) DeepCopy() *VirtualMachineBulkOperationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineBulkOperationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineBulkOperationStatus) DeepCopyInto(out *VirtualMachineBulkOperationStatus) {
+	*out = *in
+	if in.Applied != nil {
+		in, out := &in.Applied, &out.Applied
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Failures != nil {
+		in, out := &in.Failures, &out.Failures
+		*out = make([]BulkOperationFailure, len(*in))
+		copy(*out, *in)
+	}
+	if in.CompletionTimestamp != nil {
+		in, out := &in.CompletionTimestamp, &out.CompletionTimestamp
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineBulkOperationStatus.
+func (in *VirtualMachineBulkOperationStatus) DeepCopy() *VirtualMachineBulkOperationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineBulkOperationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineCondition) DeepCopyInto(out *VirtualMachineCondition) {
+	*out = *in
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineCondition.
+func (in *VirtualMachineCondition) DeepCopy() *VirtualMachineCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineGuestFailures) DeepCopyInto(out *VirtualMachineGuestFailures) {
+	*out = *in
+	if in.FailureTimestamps != nil {
+		in, out := &in.FailureTimestamps, &out.FailureTimestamps
+		*out = make([]metav1.Time, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineGuestFailures.
+func (in *VirtualMachineGuestFailures) DeepCopy() *VirtualMachineGuestFailures {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineGuestFailures)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImageExport) DeepCopyInto(out *VirtualMachineImageExport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	out.Status = in.Status
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageExport.
+func (in *VirtualMachineImageExport) DeepCopy() *VirtualMachineImageExport {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImageExport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineImageExport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImageExportList) DeepCopyInto(out *VirtualMachineImageExportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachineImageExport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageExportList.
+func (in *VirtualMachineImageExportList) DeepCopy() *VirtualMachineImageExportList {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImageExportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineImageExportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImageExportSpec) DeepCopyInto(out *VirtualMachineImageExportSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageExportSpec.
+func (in *VirtualMachineImageExportSpec) DeepCopy() *VirtualMachineImageExportSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImageExportSpec)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineCondition) DeepCopyInto(out *VirtualMachineCondition) {
+func (in *VirtualMachineImageExportStatus) DeepCopyInto(out *VirtualMachineImageExportStatus) {
 	*out = *in
-	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineCondition.
-func (in *VirtualMachineCondition) DeepCopy() *VirtualMachineCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageExportStatus.
+func (in *VirtualMachineImageExportStatus) DeepCopy() *VirtualMachineImageExportStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineCondition)
+	out := new(VirtualMachineImageExportStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -3515,6 +4997,32 @@ func (in *VirtualMachineInstanceGuestAgentInfo) DeepCopyObject() runtime.Object
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineInstanceGuestAgentStatus) DeepCopyInto(out *VirtualMachineInstanceGuestAgentStatus) {
+	*out = *in
+	if in.SupportedCommands != nil {
+		in, out := &in.SupportedCommands, &out.SupportedCommands
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnavailableFeatures != nil {
+		in, out := &in.UnavailableFeatures, &out.UnavailableFeatures
+		*out = make([]GuestAgentFeature, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceGuestAgentStatus.
+func (in *VirtualMachineInstanceGuestAgentStatus) DeepCopy() *VirtualMachineInstanceGuestAgentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineInstanceGuestAgentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineInstanceGuestOSInfo) DeepCopyInto(out *VirtualMachineInstanceGuestOSInfo) {
 	*out = *in
@@ -3578,6 +5086,22 @@ func (in *VirtualMachineInstanceGuestOSUserList) DeepCopyObject() runtime.Object
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineInstanceHostDeviceStatus) DeepCopyInto(out *VirtualMachineInstanceHostDeviceStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceHostDeviceStatus.
+func (in *VirtualMachineInstanceHostDeviceStatus) DeepCopy() *VirtualMachineInstanceHostDeviceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineInstanceHostDeviceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineInstanceList) DeepCopyInto(out *VirtualMachineInstanceList) {
 	*out = *in
@@ -3639,6 +5163,30 @@ func (in *VirtualMachineInstanceMigration) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineInstanceMigrationBackoff) DeepCopyInto(out *VirtualMachineInstanceMigrationBackoff) {
+	*out = *in
+	if in.LastFailureTimestamp != nil {
+		in, out := &in.LastFailureTimestamp, &out.LastFailureTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.NextAttemptTimestamp != nil {
+		in, out := &in.NextAttemptTimestamp, &out.NextAttemptTimestamp
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceMigrationBackoff.
+func (in *VirtualMachineInstanceMigrationBackoff) DeepCopy() *VirtualMachineInstanceMigrationBackoff {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineInstanceMigrationBackoff)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineInstanceMigrationCondition) DeepCopyInto(out *VirtualMachineInstanceMigrationCondition) {
 	*out = *in
@@ -3768,6 +5316,15 @@ func (in *VirtualMachineInstanceNetworkInterface) DeepCopyInto(out *VirtualMachi
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.LastGuestAgentUpdate != nil {
+		in, out := &in.LastGuestAgentUpdate, &out.LastGuestAgentUpdate
+		*out = (*in).DeepCopy()
+	}
+	if in.BandwidthLimit != nil {
+		in, out := &in.BandwidthLimit, &out.BandwidthLimit
+		*out = new(InterfaceBandwidthLimit)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -3977,6 +5534,11 @@ func (in *VirtualMachineInstanceReplicaSetSpec) DeepCopyInto(out *VirtualMachine
 		*out = new(VirtualMachineInstanceTemplateSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TopologySpread != nil {
+		in, out := &in.TopologySpread, &out.TopologySpread
+		*out = new(VirtualMachineInstanceReplicaSetTopologySpread)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -4013,6 +5575,73 @@ func (in *VirtualMachineInstanceReplicaSetStatus) DeepCopy() *VirtualMachineInst
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineInstanceReplicaSetTopologySpread) DeepCopyInto(out *VirtualMachineInstanceReplicaSetTopologySpread) {
+	*out = *in
+	if in.TopologyKeys != nil {
+		in, out := &in.TopologyKeys, &out.TopologyKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxSkew != nil {
+		in, out := &in.MaxSkew, &out.MaxSkew
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceReplicaSetTopologySpread.
+func (in *VirtualMachineInstanceReplicaSetTopologySpread) DeepCopy() *VirtualMachineInstanceReplicaSetTopologySpread {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineInstanceReplicaSetTopologySpread)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineInstanceResourceOverhead) DeepCopyInto(out *VirtualMachineInstanceResourceOverhead) {
+	*out = *in
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.CPU != nil {
+		in, out := &in.CPU, &out.CPU
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.EmulatorCPU != nil {
+		in, out := &in.EmulatorCPU, &out.EmulatorCPU
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.IOThreadsCPU != nil {
+		in, out := &in.IOThreadsCPU, &out.IOThreadsCPU
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.VirtiofsCPU != nil {
+		in, out := &in.VirtiofsCPU, &out.VirtiofsCPU
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceResourceOverhead.
+func (in *VirtualMachineInstanceResourceOverhead) DeepCopy() *VirtualMachineInstanceResourceOverhead {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineInstanceResourceOverhead)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineInstanceSpec) DeepCopyInto(out *VirtualMachineInstanceSpec) {
 	*out = *in
@@ -4029,6 +5658,23 @@ func (in *VirtualMachineInstanceSpec) DeepCopyInto(out *VirtualMachineInstanceSp
 		*out = new(corev1.Affinity)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RuntimeClassName != nil {
+		in, out := &in.RuntimeClassName, &out.RuntimeClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]corev1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LauncherPodMetadata != nil {
+		in, out := &in.LauncherPodMetadata, &out.LauncherPodMetadata
+		*out = new(LauncherPodMetadata)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Tolerations != nil {
 		in, out := &in.Tolerations, &out.Tolerations
 		*out = make([]corev1.Toleration, len(*in))
@@ -4046,6 +5692,18 @@ func (in *VirtualMachineInstanceSpec) DeepCopyInto(out *VirtualMachineInstanceSp
 		*out = new(StartStrategy)
 		**out = **in
 	}
+	if in.SchedulingReadinessGates != nil {
+		in, out := &in.SchedulingReadinessGates, &out.SchedulingReadinessGates
+		*out = make([]SchedulingReadinessGate, len(*in))
+		copy(*out, *in)
+	}
+	if in.Sidecars != nil {
+		in, out := &in.Sidecars, &out.Sidecars
+		*out = make([]Sidecar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.TerminationGracePeriodSeconds != nil {
 		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
 		*out = new(int64)
@@ -4125,11 +5783,21 @@ func (in *VirtualMachineInstanceStatus) DeepCopyInto(out *VirtualMachineInstance
 		}
 	}
 	out.GuestOSInfo = in.GuestOSInfo
+	if in.GuestAgent != nil {
+		in, out := &in.GuestAgent, &out.GuestAgent
+		*out = new(VirtualMachineInstanceGuestAgentStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.MigrationState != nil {
 		in, out := &in.MigrationState, &out.MigrationState
 		*out = new(VirtualMachineInstanceMigrationState)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MigrationBackoff != nil {
+		in, out := &in.MigrationBackoff, &out.MigrationBackoff
+		*out = new(VirtualMachineInstanceMigrationBackoff)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.QOSClass != nil {
 		in, out := &in.QOSClass, &out.QOSClass
 		*out = new(corev1.PodQOSClass)
@@ -4154,6 +5822,21 @@ func (in *VirtualMachineInstanceStatus) DeepCopyInto(out *VirtualMachineInstance
 		*out = new(TopologyHints)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ResourceOverhead != nil {
+		in, out := &in.ResourceOverhead, &out.ResourceOverhead
+		*out = new(VirtualMachineInstanceResourceOverhead)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CPUAllocationRatio != nil {
+		in, out := &in.CPUAllocationRatio, &out.CPUAllocationRatio
+		*out = new(int)
+		**out = **in
+	}
+	if in.HostDevices != nil {
+		in, out := &in.HostDevices, &out.HostDevices
+		*out = make([]VirtualMachineInstanceHostDeviceStatus, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -4185,6 +5868,40 @@ func (in *VirtualMachineInstanceTemplateSpec) DeepCopy() *VirtualMachineInstance
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineInstanceUsage) DeepCopyInto(out *VirtualMachineInstanceUsage) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+	out.Window = in.Window
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineInstanceUsage.
+func (in *VirtualMachineInstanceUsage) DeepCopy() *VirtualMachineInstanceUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineInstanceUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineInstanceUsage) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineList) DeepCopyInto(out *VirtualMachineList) {
 	*out = *in
@@ -4218,6 +5935,103 @@ func (in *VirtualMachineList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachinePendingChange) DeepCopyInto(out *VirtualMachinePendingChange) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePendingChange.
+func (in *VirtualMachinePendingChange) DeepCopy() *VirtualMachinePendingChange {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachinePendingChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineSchedulingHints) DeepCopyInto(out *VirtualMachineSchedulingHints) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineSchedulingHints.
+func (in *VirtualMachineSchedulingHints) DeepCopy() *VirtualMachineSchedulingHints {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineSchedulingHints)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineSchedulingHints) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineSchedulingHintsList) DeepCopyInto(out *VirtualMachineSchedulingHintsList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachineSchedulingHints, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineSchedulingHintsList.
+func (in *VirtualMachineSchedulingHintsList) DeepCopy() *VirtualMachineSchedulingHintsList {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineSchedulingHintsList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineSchedulingHintsList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineSchedulingHintsSpec) DeepCopyInto(out *VirtualMachineSchedulingHintsSpec) {
+	*out = *in
+	if in.PreferredNodes != nil {
+		in, out := &in.PreferredNodes, &out.PreferredNodes
+		*out = make([]PreferredNode, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineSchedulingHintsSpec.
+func (in *VirtualMachineSchedulingHintsSpec) DeepCopy() *VirtualMachineSchedulingHintsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineSchedulingHintsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineSpec) DeepCopyInto(out *VirtualMachineSpec) {
 	*out = *in
@@ -4231,6 +6045,16 @@ func (in *VirtualMachineSpec) DeepCopyInto(out *VirtualMachineSpec) {
 		*out = new(VirtualMachineRunStrategy)
 		**out = **in
 	}
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ChangeApplyPolicy != nil {
+		in, out := &in.ChangeApplyPolicy, &out.ChangeApplyPolicy
+		*out = new(VirtualMachineChangeApplyPolicy)
+		**out = **in
+	}
 	if in.Template != nil {
 		in, out := &in.Template, &out.Template
 		*out = new(VirtualMachineInstanceTemplateSpec)
@@ -4348,6 +6172,21 @@ func (in *VirtualMachineStatus) DeepCopyInto(out *VirtualMachineStatus) {
 		*out = new(VirtualMachineStartFailure)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.GuestFailures != nil {
+		in, out := &in.GuestFailures, &out.GuestFailures
+		*out = new(VirtualMachineGuestFailures)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PendingChanges != nil {
+		in, out := &in.PendingChanges, &out.PendingChanges
+		*out = make([]VirtualMachinePendingChange, len(*in))
+		copy(*out, *in)
+	}
+	if in.PinnedContainerDiskImages != nil {
+		in, out := &in.PinnedContainerDiskImages, &out.PinnedContainerDiskImages
+		*out = make([]PinnedContainerDiskImage, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -4493,6 +6332,16 @@ func (in *VolumeSource) DeepCopyInto(out *VolumeSource) {
 		*out = new(DownwardMetricsVolumeSource)
 		**out = **in
 	}
+	if in.NetworkDisk != nil {
+		in, out := &in.NetworkDisk, &out.NetworkDisk
+		*out = new(NetworkDiskSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MemoryDump != nil {
+		in, out := &in.MemoryDump, &out.MemoryDump
+		*out = new(MemoryDumpVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
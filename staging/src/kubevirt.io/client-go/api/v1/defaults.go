@@ -1,6 +1,8 @@
 package v1
 
 import (
+	"strings"
+
 	"github.com/pborman/uuid"
 	"k8s.io/apimachinery/pkg/types"
 )
@@ -8,6 +10,16 @@ import (
 var _true = t(true)
 var _false = t(false)
 
+// legacyWindowsVersions don't ship drivers for virtio and e1000e devices
+var legacyWindowsVersions = map[string]bool{
+	"xp":     true,
+	"2003":   true,
+	"vista":  true,
+	"2008":   true,
+	"2008r2": true,
+	"7":      true,
+}
+
 func SetDefaults_HPETTimer(obj *HPETTimer) {
 	if obj.Enabled == nil {
 		obj.Enabled = _true
@@ -127,15 +139,28 @@ func SetDefaults_VirtualMachineInstance(obj *VirtualMachineInstance) {
 	}
 
 	setDefaults_Disk(obj)
+	setDefaults_GuestOSDevices(obj)
 	SetDefaults_Probe(obj.Spec.ReadinessProbe)
 	SetDefaults_Probe(obj.Spec.LivenessProbe)
 }
 
+func isWindowsGuest(obj *VirtualMachineInstance) bool {
+	guestOS := obj.Spec.Domain.GuestOS
+	return guestOS != nil && guestOS.OSFamily == GuestOSFamilyWindows
+}
+
+func isLegacyWindowsGuest(obj *VirtualMachineInstance) bool {
+	return isWindowsGuest(obj) && legacyWindowsVersions[strings.ToLower(obj.Spec.Domain.GuestOS.OSVersion)]
+}
+
 func setDefaults_Disk(obj *VirtualMachineInstance) {
 	// Setting SATA as the default bus since it is typically supported out of the box by
-	// guest operating systems (we support only q35 and therefore IDE is not supported)
-	// TODO: consider making this OS-specific (VIRTIO for linux, SATA for others)
+	// guest operating systems (we support only q35 and therefore IDE is not supported).
+	// Guests which are known to support virtio get virtio instead.
 	bus := "sata"
+	if obj.Spec.Domain.GuestOS != nil && obj.Spec.Domain.GuestOS.OSFamily != "" && !isLegacyWindowsGuest(obj) {
+		bus = "virtio"
+	}
 
 	for i := range obj.Spec.Domain.Devices.Disks {
 		disk := &obj.Spec.Domain.Devices.Disks[i].DiskDevice
@@ -154,6 +179,41 @@ func setDefaults_Disk(obj *VirtualMachineInstance) {
 	}
 }
 
+// setDefaults_GuestOSDevices picks the network interface models, the tablet and the Hyper-V features
+// which fit to the operating system of the guest, if they are not set
+func setDefaults_GuestOSDevices(obj *VirtualMachineInstance) {
+	if obj.Spec.Domain.GuestOS == nil || obj.Spec.Domain.GuestOS.OSFamily == "" {
+		return
+	}
+
+	model := "virtio"
+	if isLegacyWindowsGuest(obj) {
+		model = "e1000"
+	} else if isWindowsGuest(obj) {
+		model = "e1000e"
+	}
+	for i := range obj.Spec.Domain.Devices.Interfaces {
+		iface := &obj.Spec.Domain.Devices.Interfaces[i]
+		if iface.Model == "" && (iface.Bridge != nil || iface.Masquerade != nil) {
+			iface.Model = model
+		}
+	}
+
+	if !isWindowsGuest(obj) {
+		return
+	}
+	if len(obj.Spec.Domain.Devices.Inputs) == 0 {
+		obj.Spec.Domain.Devices.Inputs = []Input{{Name: "tablet", Type: "tablet", Bus: "usb"}}
+	}
+	if obj.Spec.Domain.Features.Hyperv == nil {
+		obj.Spec.Domain.Features.Hyperv = &FeatureHyperv{
+			Relaxed:   &FeatureState{},
+			VAPIC:     &FeatureState{},
+			Spinlocks: &FeatureSpinlocks{Retries: ui32(8191)},
+		}
+	}
+}
+
 func SetDefaults_Probe(probe *Probe) {
 	if probe == nil {
 		return
@@ -2,6 +2,7 @@ package v1
 
 import (
 	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 )
 
@@ -289,6 +290,56 @@ var _ = Describe("Defaults", func() {
 		Expect(*timer.Hyperv.Enabled).To(BeTrue())
 	})
 
+	It("should keep the global device defaults without a guest OS hint", func() {
+		vmi := NewMinimalVMI("testvmi")
+		vmi.Spec.Domain.Devices.Disks = []Disk{{Name: "disk0"}}
+		vmi.Spec.Domain.Devices.Interfaces = []Interface{*DefaultMasqueradeNetworkInterface()}
+		SetObjectDefaults_VirtualMachineInstance(vmi)
+		Expect(vmi.Spec.Domain.Devices.Disks[0].Disk.Bus).To(Equal("sata"))
+		Expect(vmi.Spec.Domain.Devices.Interfaces[0].Model).To(BeEmpty())
+		Expect(vmi.Spec.Domain.Devices.Inputs).To(BeEmpty())
+		Expect(vmi.Spec.Domain.Features.Hyperv).To(BeNil())
+	})
+
+	table.DescribeTable("should pick the device defaults from the guest OS hint", func(guestOS *GuestOS, bus, model string, windows bool) {
+		vmi := NewMinimalVMI("testvmi")
+		vmi.Spec.Domain.GuestOS = guestOS
+		vmi.Spec.Domain.Devices.Disks = []Disk{{Name: "disk0"}}
+		vmi.Spec.Domain.Devices.Interfaces = []Interface{*DefaultMasqueradeNetworkInterface()}
+		SetObjectDefaults_VirtualMachineInstance(vmi)
+		Expect(vmi.Spec.Domain.Devices.Disks[0].Disk.Bus).To(Equal(bus))
+		Expect(vmi.Spec.Domain.Devices.Interfaces[0].Model).To(Equal(model))
+		if windows {
+			Expect(vmi.Spec.Domain.Devices.Inputs).To(Equal([]Input{{Name: "tablet", Type: "tablet", Bus: "usb"}}))
+			Expect(*vmi.Spec.Domain.Features.Hyperv.Relaxed.Enabled).To(BeTrue())
+			Expect(*vmi.Spec.Domain.Features.Hyperv.VAPIC.Enabled).To(BeTrue())
+			Expect(*vmi.Spec.Domain.Features.Hyperv.Spinlocks.Retries).To(Equal(uint32(8191)))
+		} else {
+			Expect(vmi.Spec.Domain.Devices.Inputs).To(BeEmpty())
+			Expect(vmi.Spec.Domain.Features.Hyperv).To(BeNil())
+		}
+	},
+		table.Entry("for linux", &GuestOS{OSFamily: GuestOSFamilyLinux}, "virtio", "virtio", false),
+		table.Entry("for windows", &GuestOS{OSFamily: GuestOSFamilyWindows, OSVersion: "2019"}, "virtio", "e1000e", true),
+		table.Entry("for legacy windows", &GuestOS{OSFamily: GuestOSFamilyWindows, OSVersion: "2008R2"}, "sata", "e1000", true),
+	)
+
+	It("should not override devices which are set explicitly", func() {
+		vmi := NewMinimalVMI("testvmi")
+		vmi.Spec.Domain.GuestOS = &GuestOS{OSFamily: GuestOSFamilyWindows}
+		vmi.Spec.Domain.Devices.Disks = []Disk{{Name: "disk0", DiskDevice: DiskDevice{Disk: &DiskTarget{Bus: "scsi"}}}}
+		iface := DefaultMasqueradeNetworkInterface()
+		iface.Model = "rtl8139"
+		vmi.Spec.Domain.Devices.Interfaces = []Interface{*iface}
+		vmi.Spec.Domain.Devices.Inputs = []Input{{Name: "tablet0", Type: "tablet", Bus: "virtio"}}
+		vmi.Spec.Domain.Features = &Features{Hyperv: &FeatureHyperv{}}
+		SetObjectDefaults_VirtualMachineInstance(vmi)
+		Expect(vmi.Spec.Domain.Devices.Disks[0].Disk.Bus).To(Equal("scsi"))
+		Expect(vmi.Spec.Domain.Devices.Interfaces[0].Model).To(Equal("rtl8139"))
+		Expect(vmi.Spec.Domain.Devices.Inputs).To(HaveLen(1))
+		Expect(vmi.Spec.Domain.Features.Hyperv.Relaxed).To(BeNil())
+	})
+
 	It("should omit IOThreads by default", func() {
 		vmi := &VirtualMachineInstance{}
 		SetObjectDefaults_VirtualMachineInstance(vmi)
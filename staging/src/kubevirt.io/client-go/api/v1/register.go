@@ -80,6 +80,11 @@ var (
 	VirtualMachineGroupVersionKind                   = schema.GroupVersionKind{Group: GroupName, Version: GroupVersion.Version, Kind: "VirtualMachine"}
 	VirtualMachineInstanceMigrationGroupVersionKind  = schema.GroupVersionKind{Group: GroupName, Version: GroupVersion.Version, Kind: "VirtualMachineInstanceMigration"}
 	KubeVirtGroupVersionKind                         = schema.GroupVersionKind{Group: GroupName, Version: GroupVersion.Version, Kind: "KubeVirt"}
+	VirtualMachineSchedulingHintsGroupVersionKind    = schema.GroupVersionKind{Group: GroupName, Version: GroupVersion.Version, Kind: "VirtualMachineSchedulingHints"}
+	VirtualMachineImageExportGroupVersionKind        = schema.GroupVersionKind{Group: GroupName, Version: GroupVersion.Version, Kind: "VirtualMachineImageExport"}
+	VirtualMachineBulkOperationGroupVersionKind      = schema.GroupVersionKind{Group: GroupName, Version: GroupVersion.Version, Kind: "VirtualMachineBulkOperation"}
+	ValidationPolicyGroupVersionKind                 = schema.GroupVersionKind{Group: GroupName, Version: GroupVersion.Version, Kind: "ValidationPolicy"}
+	MaintenanceWindowGroupVersionKind                = schema.GroupVersionKind{Group: GroupName, Version: GroupVersion.Version, Kind: "MaintenanceWindow"}
 )
 
 var (
@@ -127,6 +132,16 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 			&VirtualMachineList{},
 			&KubeVirt{},
 			&KubeVirtList{},
+			&VirtualMachineSchedulingHints{},
+			&VirtualMachineSchedulingHintsList{},
+			&VirtualMachineImageExport{},
+			&VirtualMachineImageExportList{},
+			&VirtualMachineBulkOperation{},
+			&VirtualMachineBulkOperationList{},
+			&ValidationPolicy{},
+			&ValidationPolicyList{},
+			&MaintenanceWindow{},
+			&MaintenanceWindowList{},
 		)
 		metav1.AddToGroupVersion(scheme, groupVersion)
 	}
@@ -22,6 +22,7 @@ package v1
 import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
 
@@ -55,6 +56,10 @@ type HostDisk struct {
 	Capacity resource.Quantity `json:"capacity,omitempty"`
 	// Shared indicate whether the path is shared between nodes
 	Shared *bool `json:"shared,omitempty"`
+	// Preallocation defines how space for the raw disk.img is allocated when it gets created.
+	// Only 'off', 'falloc' and 'full' are supported. Defaults to off.
+	// +optional
+	Preallocation DiskImagePreallocation `json:"preallocation,omitempty"`
 }
 
 // ConfigMapVolumeSource adapts a ConfigMap into a volume.
@@ -121,6 +126,57 @@ type ServiceAccountVolumeSource struct {
 type DownwardMetricsVolumeSource struct {
 }
 
+// NetworkDiskProtocol is the protocol used to access a network disk.
+type NetworkDiskProtocol string
+
+const (
+	NetworkDiskProtocolISCSI NetworkDiskProtocol = "iscsi"
+	NetworkDiskProtocolNBD   NetworkDiskProtocol = "nbd"
+	NetworkDiskProtocolRBD   NetworkDiskProtocol = "rbd"
+)
+
+// NetworkDiskSource represents a disk which is accessed by QEMU directly over the network.
+// NVMe over Fabrics is not supported, since QEMU has no initiator for it.
+// Such disks have to be attached to the node and provided through a PersistentVolumeClaim.
+//
+// +k8s:openapi-gen=true
+type NetworkDiskSource struct {
+	// Protocol used to access the disk. One of: iscsi, nbd, rbd.
+	Protocol NetworkDiskProtocol `json:"protocol"`
+	// Name of the disk on the storage system.
+	// For iscsi it is the target IQN and LUN (<iqn>/<lun>), for rbd the pool and image (<pool>/<image>)
+	// and for nbd the optional export name.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Host serving the disk.
+	Host NetworkDiskHost `json:"host"`
+	// Auth holds the credentials to access the disk. Only supported for iscsi and rbd.
+	// +optional
+	Auth *NetworkDiskAuth `json:"auth,omitempty"`
+}
+
+// NetworkDiskHost is the host serving a network disk.
+//
+// +k8s:openapi-gen=true
+type NetworkDiskHost struct {
+	// Name is the hostname or IP address of the host.
+	Name string `json:"name"`
+	// Port of the host. Defaults to the default port of the protocol.
+	// +optional
+	Port string `json:"port,omitempty"`
+}
+
+// NetworkDiskAuth holds the credentials to access a network disk.
+//
+// +k8s:openapi-gen=true
+type NetworkDiskAuth struct {
+	// Username to authenticate with.
+	Username string `json:"username"`
+	// SecretRef is the name of a secret in the namespace of the vmi, which holds
+	// the CHAP password (iscsi) or the cephx key (rbd) in its "password" key.
+	SecretRef string `json:"secretRef"`
+}
+
 // Represents a Sysprep volume source.
 //
 // +k8s:openapi-gen=true
@@ -216,6 +272,10 @@ type DomainSpec struct {
 	// Chassis specifies the chassis info passed to the domain.
 	// +optional
 	Chassis *Chassis `json:"chassis,omitempty"`
+	// GuestOS describes the operating system of the guest. It is a hint used to pick the defaults of the
+	// disk bus, the network interface model, the tablet and the Hyper-V features, if they are not set.
+	// +optional
+	GuestOS *GuestOS `json:"guestOS,omitempty"`
 }
 
 // Chassis specifies the chassis info passed to the domain.
@@ -229,6 +289,26 @@ type Chassis struct {
 	Sku          string `json:"sku,omitempty"`
 }
 
+// GuestOS describes the operating system of the guest.
+//
+// +k8s:openapi-gen=true
+type GuestOS struct {
+	// OSFamily of the guest operating system.
+	// One of: linux, windows
+	// +optional
+	OSFamily GuestOSFamily `json:"osFamily,omitempty"`
+	// OSVersion of the guest operating system, e.g. 2008 or 10 for windows.
+	// +optional
+	OSVersion string `json:"osVersion,omitempty"`
+}
+
+type GuestOSFamily string
+
+const (
+	GuestOSFamilyLinux   GuestOSFamily = "linux"
+	GuestOSFamilyWindows GuestOSFamily = "windows"
+)
+
 // Represents the firmware blob used to assist in the domain creation process.
 // Used for setting the QEMU BIOS file path for the libvirt domain.
 //
@@ -413,6 +493,26 @@ type Machine struct {
 	// QEMU machine type is the actual chipset of the VirtualMachineInstance.
 	// +optional
 	Type string `json:"type"`
+	// Options are additional QEMU -machine options, for hardware quirks of particular guests.
+	// Only the options allowed in the QEMUOptionsAllowlist of the KubeVirt configuration are accepted.
+	// +optional
+	// +listType=atomic
+	Options []QEMUOption `json:"options,omitempty"`
+	// Globals are QEMU -global options, which set a property of all devices of a driver. Their
+	// names have the form driver.property. Only the globals allowed in the QEMUOptionsAllowlist of
+	// the KubeVirt configuration are accepted.
+	// +optional
+	// +listType=atomic
+	Globals []QEMUOption `json:"globals,omitempty"`
+}
+
+//
+// +k8s:openapi-gen=true
+type QEMUOption struct {
+	// Name of the option.
+	Name string `json:"name"`
+	// Value the option is set to.
+	Value string `json:"value"`
 }
 
 //
@@ -468,6 +568,10 @@ type Devices struct {
 	// Defaults to false.
 	// +optional
 	BlockMultiQueue *bool `json:"blockMultiQueue,omitempty"`
+	// SCSIController configures the controller of the disks with the scsi bus.
+	// If blockMultiQueue is enabled, a virtio-scsi controller gets one queue per vCPU.
+	// +optional
+	SCSIController *SCSIController `json:"scsiController,omitempty"`
 	// If specified, virtual network interfaces configured with a virtio bus will also enable the vhost multiqueue feature for network devices. The number of queues created depends on additional factors of the VirtualMachineInstance, like the number of guest CPUs.
 	// +optional
 	NetworkInterfaceMultiQueue *bool `json:"networkInterfaceMultiqueue,omitempty"`
@@ -475,6 +579,11 @@ type Devices struct {
 	// +optional
 	// +listType=atomic
 	GPUs []GPU `json:"gpus,omitempty"`
+	// Whether to detach the GPUs for a live migration. The GPUs are hot-unplugged and the guest
+	// is paused until the GPUs of the target are hot-plugged. The guest has to support the
+	// hot-unplug of its GPUs. Without it, VMIs with GPUs are not live migratable.
+	// +optional
+	DetachGPUsForMigration *bool `json:"detachGPUsForMigration,omitempty"`
 	// Filesystems describes filesystem which is connected to the vmi.
 	// +optional
 	// +listType=atomic
@@ -486,6 +595,23 @@ type Devices struct {
 	// To configure and access client devices such as redirecting USB
 	// +optional
 	ClientPassthrough *ClientPassthroughDevices `json:"clientPassthrough,omitempty"`
+	// Channels are additional virtio-serial channels of the vmi. Each channel is backed by a unix socket
+	// in the virt-launcher pod, which is shared with the sidecars of the vmi.
+	// +optional
+	// +listType=atomic
+	Channels []SerialChannel `json:"channels,omitempty"`
+}
+
+// SerialChannel is a virtio-serial channel between the guest and the virt-launcher pod. The socket of the channel
+// is created at <channel socket directory>/<name>.sock, where sidecars can connect to it to exchange data with the
+// guest without networking, e.g. to collect logs.
+//
+// +k8s:openapi-gen=true
+type SerialChannel struct {
+	// Name of the channel, unique within the vmi. It names the socket of the channel.
+	Name string `json:"name"`
+	// Target is the name of the virtio-serial port in the guest, e.g. org.example.log.0.
+	Target string `json:"target"`
 }
 
 // Represent a subset of client devices that can be accessed by VMI. At the
@@ -537,6 +663,16 @@ type GPU struct {
 	// Name of the GPU device as exposed by a device plugin
 	Name       string `json:"name"`
 	DeviceName string `json:"deviceName"`
+	// Role labels the device for the guest, e.g. render or compute. It is reported together with
+	// the guest PCI address of the device in the VMI status.
+	// +optional
+	Role string `json:"role,omitempty"`
+	// PCIOrder is an integer value > 0, used to determine the order of the guest PCI addresses of
+	// the GPUs and host devices. Lower values get lower addresses, devices without a PCI order are
+	// placed after the ordered ones.
+	// Each GPU or host device that has a PCI order must have a unique value.
+	// +optional
+	PCIOrder *uint `json:"pciOrder,omitempty"`
 }
 
 //
@@ -545,6 +681,16 @@ type HostDevice struct {
 	Name string `json:"name"`
 	// DeviceName is the resource name of the host device exposed by a device plugin
 	DeviceName string `json:"deviceName"`
+	// Role labels the device for the guest, e.g. render or compute. It is reported together with
+	// the guest PCI address of the device in the VMI status.
+	// +optional
+	Role string `json:"role,omitempty"`
+	// PCIOrder is an integer value > 0, used to determine the order of the guest PCI addresses of
+	// the GPUs and host devices. Lower values get lower addresses, devices without a PCI order are
+	// placed after the ordered ones.
+	// Each GPU or host device that has a PCI order must have a unique value.
+	// +optional
+	PCIOrder *uint `json:"pciOrder,omitempty"`
 }
 
 //
@@ -577,12 +723,28 @@ type Disk struct {
 	// Supported values are: native, default, threads.
 	// +optional
 	IO DriverIO `json:"io,omitempty"`
+	// Discard specifies whether discard requests from the guest are passed to the storage.
+	// Supported values are: unmap, ignore.
+	// Defaults to unmap unless the volume is preallocated.
+	// +optional
+	Discard DriverDiscard `json:"discard,omitempty"`
+	// DetectZeroes specifies whether QEMU detects writes of zeroes and optimizes them.
+	// Supported values are: off, on, unmap.
+	// Defaults to unmap on thin-provisioned block volumes with discard enabled.
+	// +optional
+	DetectZeroes DriverDetectZeroes `json:"detectZeroes,omitempty"`
 	// If specified, disk address and its tag will be provided to the guest via config drive metadata
 	// +optional
 	Tag string `json:"tag,omitempty"`
 	// If specified, the virtual disk will be presented with the given block sizes.
 	// +optional
 	BlockSize *BlockSize `json:"blockSize,omitempty"`
+	// Shareable indicates whether the disk can be shared between multiple VMIs, e.g. for clustered file systems.
+	// A shareable disk must be backed by a block PersistentVolumeClaim with the ReadWriteMany access mode,
+	// it is attached without a host side cache and without an ephemeral overlay.
+	// Defaults to false.
+	// +optional
+	Shareable *bool `json:"shareable,omitempty"`
 }
 
 // CustomBlockSize represents the desired logical and physical block size for a VM disk.
@@ -621,7 +783,7 @@ type DiskDevice struct {
 // +k8s:openapi-gen=true
 type DiskTarget struct {
 	// Bus indicates the type of disk device to emulate.
-	// supported values: virtio, sata, scsi.
+	// supported values: virtio, sata, scsi, usb.
 	Bus string `json:"bus,omitempty"`
 	// ReadOnly.
 	// Defaults to false.
@@ -671,7 +833,7 @@ const (
 // +k8s:openapi-gen=true
 type CDRomTarget struct {
 	// Bus indicates the type of disk device to emulate.
-	// supported values: virtio, sata, scsi.
+	// supported values: virtio, sata, scsi, usb.
 	Bus string `json:"bus,omitempty"`
 	// ReadOnly.
 	// Defaults to true.
@@ -756,6 +918,14 @@ type VolumeSource struct {
 	// DownwardMetrics adds a very small disk to VMIs which contains a limited view of host and guest
 	// metrics. The disk content is compatible with vhostmd (https://github.com/vhostmd/vhostmd) and vm-dump-metrics.
 	DownwardMetrics *DownwardMetricsVolumeSource `json:"downwardMetrics,omitempty"`
+	// NetworkDisk represents a disk which is accessed by QEMU directly over the network (iSCSI, NBD or RBD),
+	// without attaching it to the node or mounting it into the virt-launcher pod.
+	// +optional
+	NetworkDisk *NetworkDiskSource `json:"networkDisk,omitempty"`
+	// MemoryDump represents a PersistentVolumeClaim the memory state of the vmi is saved to.
+	// It is hotplugged into the virt-launcher pod without being attached to the vmi as a disk.
+	// +optional
+	MemoryDump *MemoryDumpVolumeSource `json:"memoryDump,omitempty"`
 }
 
 // HotplugVolumeSource Represents the source of a volume to mount which are capable
@@ -795,6 +965,19 @@ type PersistentVolumeClaimVolumeSource struct {
 	// Hotpluggable indicates whether the volume can be hotplugged and hotunplugged.
 	// +optional
 	Hotpluggable bool `json:"hotpluggable,omitempty"`
+	// Preallocation defines how space for the raw disk.img is allocated when it gets
+	// created on an empty filesystem PVC. Only 'off', 'falloc' and 'full' are supported.
+	// Defaults to off.
+	// +optional
+	Preallocation DiskImagePreallocation `json:"preallocation,omitempty"`
+}
+
+// MemoryDumpVolumeSource represents a reference to a PersistentVolumeClaim in the same namespace
+// the memory state of the vmi is saved to.
+//
+// +k8s:openapi-gen=true
+type MemoryDumpVolumeSource struct {
+	PersistentVolumeClaimVolumeSource `json:",inline"`
 }
 
 //
@@ -805,6 +988,16 @@ type EphemeralVolumeSource struct {
 	// More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims
 	// +optional
 	PersistentVolumeClaim *v1.PersistentVolumeClaimVolumeSource `json:"persistentVolumeClaim,omitempty"`
+	// ImageFormat is the format of the local image the PVC is exposed through.
+	// With qcow2 a copy-on-write overlay on top of the PVC is created, with raw
+	// the PVC content is converted into a standalone local copy.
+	// Defaults to qcow2.
+	// +optional
+	ImageFormat DiskImageFormat `json:"imageFormat,omitempty"`
+	// Preallocation defines how space for the local image is allocated.
+	// Defaults to off.
+	// +optional
+	Preallocation DiskImagePreallocation `json:"preallocation,omitempty"`
 }
 
 // EmptyDisk represents a temporary disk which shares the vmis lifecycle.
@@ -813,8 +1006,38 @@ type EphemeralVolumeSource struct {
 type EmptyDiskSource struct {
 	// Capacity of the sparse disk.
 	Capacity resource.Quantity `json:"capacity"`
+	// ImageFormat is the on-disk format of the created image.
+	// Defaults to qcow2.
+	// +optional
+	ImageFormat DiskImageFormat `json:"imageFormat,omitempty"`
+	// Preallocation defines how space for the created image is allocated.
+	// Defaults to off.
+	// +optional
+	Preallocation DiskImagePreallocation `json:"preallocation,omitempty"`
 }
 
+// DiskImageFormat is the on-disk format of an image created by virt-launcher.
+type DiskImageFormat string
+
+const (
+	DiskImageFormatRaw   DiskImageFormat = "raw"
+	DiskImageFormatQCOW2 DiskImageFormat = "qcow2"
+)
+
+// DiskImagePreallocation is the qemu-img preallocation mode used when virt-launcher creates an image.
+type DiskImagePreallocation string
+
+const (
+	// DiskImagePreallocationOff creates a sparse image.
+	DiskImagePreallocationOff DiskImagePreallocation = "off"
+	// DiskImagePreallocationMetadata only allocates the qcow2 metadata. Only valid for qcow2 images.
+	DiskImagePreallocationMetadata DiskImagePreallocation = "metadata"
+	// DiskImagePreallocationFalloc reserves the space for the whole image without writing to it.
+	DiskImagePreallocationFalloc DiskImagePreallocation = "falloc"
+	// DiskImagePreallocationFull reserves the space for the whole image and fills it with zeroes.
+	DiskImagePreallocationFull DiskImagePreallocation = "full"
+)
+
 // Represents a docker image with an embedded disk.
 //
 // +k8s:openapi-gen=true
@@ -832,8 +1055,26 @@ type ContainerDiskSource struct {
 	// More info: https://kubernetes.io/docs/concepts/containers/images#updating-images
 	// +optional
 	ImagePullPolicy v1.PullPolicy `json:"imagePullPolicy,omitempty"`
+	// ImageDigestPolicy controls whether the image has to be referenced by digest.
+	// With Require, images have to be referenced by digest. With Pin, virt-controller
+	// resolves images referenced by tag to their digest when it starts the VirtualMachine
+	// and records them in its status, so that restarts of the VirtualMachine always boot
+	// the same image. VirtualMachineInstances which are not created by a VirtualMachine
+	// must reference such images by digest.
+	// +optional
+	ImageDigestPolicy ContainerDiskImageDigestPolicy `json:"imageDigestPolicy,omitempty"`
 }
 
+// ContainerDiskImageDigestPolicy defines how the image of a containerDisk is pinned to a digest.
+type ContainerDiskImageDigestPolicy string
+
+const (
+	// ContainerDiskImageDigestPolicyRequire rejects images which are not referenced by digest
+	ContainerDiskImageDigestPolicyRequire ContainerDiskImageDigestPolicy = "Require"
+	// ContainerDiskImageDigestPolicyPin resolves images referenced by tag to their digest when the VirtualMachine starts
+	ContainerDiskImageDigestPolicyPin ContainerDiskImageDigestPolicy = "Pin"
+)
+
 // Exactly one of its members must be set.
 //
 // +k8s:openapi-gen=true
@@ -1230,8 +1471,80 @@ type Interface struct {
 	// If specified, the virtual network interface address and its tag will be provided to the guest via config drive
 	// +optional
 	Tag string `json:"tag,omitempty"`
+	// If specified, the traffic of the interface is mirrored, e.g. for intrusion detection or
+	// troubleshooting captures without tooling in the guest.
+	// Only supported on interfaces with the bridge binding.
+	// +optional
+	Mirror *InterfaceMirror `json:"mirror,omitempty"`
+	// Bandwidth is the throughput the interface is expected to need, in bits per second, for example 10G.
+	// The VMI reserves it from the node bandwidth of the cluster network configuration, nodes without
+	// enough unreserved bandwidth are not considered for scheduling.
+	// +optional
+	Bandwidth *resource.Quantity `json:"bandwidth,omitempty"`
+	// BandwidthLimit shapes the traffic of the interface. It can be changed while the VMI is
+	// running, virt-launcher applies the new limits to the running domain.
+	// Not supported on interfaces with the SR-IOV, slirp or vhostuser binding.
+	// +optional
+	BandwidthLimit *InterfaceBandwidthLimit `json:"bandwidthLimit,omitempty"`
+}
+
+// InterfaceBandwidthLimit limits the average throughput of an interface, as seen from the guest.
+//
+// +k8s:openapi-gen=true
+type InterfaceBandwidthLimit struct {
+	// Inbound is the throughput the guest can receive, in bits per second, for example 100M.
+	// +optional
+	Inbound *resource.Quantity `json:"inbound,omitempty"`
+	// Outbound is the throughput the guest can send, in bits per second, for example 100M.
+	// +optional
+	Outbound *resource.Quantity `json:"outbound,omitempty"`
+}
+
+// InterfaceMirror clones the traffic of an interface to a Multus network or into a capture
+// interface in the virt-launcher pod. The mirror interface of the pod interface ethN or netN
+// is named mirN, sidecars of the VMI can capture the mirrored traffic on it.
+//
+// +k8s:openapi-gen=true
+type InterfaceMirror struct {
+	// Network is the name of the NetworkAttachmentDefinition, optionally prefixed with its namespace,
+	// the traffic is mirrored to. The traffic is mirrored into a capture interface if it is not set.
+	// +optional
+	Network string `json:"network,omitempty"`
+	// Filter limits the mirrored traffic. All traffic is mirrored if it is not set.
+	// +optional
+	Filter *InterfaceMirrorFilter `json:"filter,omitempty"`
+	// Duration limits how long the traffic is mirrored, counted from the creation of the VMI.
+	// The traffic is mirrored for the whole lifetime of the VMI if it is not set.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
 }
 
+// InterfaceMirrorFilter selects the mirrored traffic.
+//
+// +k8s:openapi-gen=true
+type InterfaceMirrorFilter struct {
+	// Direction of the mirrored traffic, as seen from the guest.
+	// One of: ingress, egress, both.
+	// Defaults to both.
+	// +optional
+	Direction InterfaceMirrorDirection `json:"direction,omitempty"`
+	// Protocol limits the mirrored traffic to IPv4 packets of the protocol.
+	// One of: TCP, UDP, ICMP.
+	// +optional
+	Protocol string `json:"protocol,omitempty"`
+	// Port limits the mirrored TCP or UDP traffic to packets from or to the port.
+	// +optional
+	Port int32 `json:"port,omitempty"`
+}
+
+type InterfaceMirrorDirection string
+
+const (
+	InterfaceMirrorIngress InterfaceMirrorDirection = "ingress"
+	InterfaceMirrorEgress  InterfaceMirrorDirection = "egress"
+	InterfaceMirrorBoth    InterfaceMirrorDirection = "both"
+)
+
 // Extra DHCP options to use in the interface.
 //
 // +k8s:openapi-gen=true
@@ -1248,6 +1561,10 @@ type DHCPOptions struct {
 	// If specified will pass extra DHCP options for private use, range: 224-254
 	// +optional
 	PrivateOptions []DHCPPrivateOptions `json:"privateOptions,omitempty"`
+	// If specified will pass the lease time to the VM via DHCP option 051.
+	// Defaults to an hourly renewed lease if the address of the interface can change, to an infinite lease otherwise.
+	// +optional
+	LeaseTime *metav1.Duration `json:"leaseTime,omitempty"`
 }
 
 // DHCPExtraOptions defines Extra DHCP options for a VM.
@@ -1489,6 +1806,26 @@ type PodNetwork struct {
 type Rng struct {
 }
 
+// SCSIControllerModel is the model of the SCSI controller.
+type SCSIControllerModel string
+
+const (
+	// SCSIControllerModelVirtio is the paravirtualized virtio-scsi controller.
+	SCSIControllerModelVirtio SCSIControllerModel = "virtio-scsi"
+	// SCSIControllerModelLSILogic is the emulated LSI Logic controller, for guests without virtio drivers.
+	SCSIControllerModelLSILogic SCSIControllerModel = "lsilogic"
+)
+
+// Represents the SCSI controller of a vmi.
+//
+// +k8s:openapi-gen=true
+type SCSIController struct {
+	// Model of the SCSI controller. One of: virtio-scsi, lsilogic.
+	// Defaults to virtio-scsi.
+	// +optional
+	Model SCSIControllerModel `json:"model,omitempty"`
+}
+
 // Represents the multus cni network.
 //
 // +k8s:openapi-gen=true
@@ -4,11 +4,12 @@ package v1
 
 func (HostDisk) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":         "Represents a disk created on the cluster level\n\n+k8s:openapi-gen=true",
-		"path":     "The path to HostDisk image located on the cluster",
-		"type":     "Contains information if disk.img exists or should be created\nallowed options are 'Disk' and 'DiskOrCreate'",
-		"capacity": "Capacity of the sparse disk\n+optional",
-		"shared":   "Shared indicate whether the path is shared between nodes",
+		"":              "Represents a disk created on the cluster level\n\n+k8s:openapi-gen=true",
+		"path":          "The path to HostDisk image located on the cluster",
+		"type":          "Contains information if disk.img exists or should be created\nallowed options are 'Disk' and 'DiskOrCreate'",
+		"capacity":      "Capacity of the sparse disk\n+optional",
+		"shared":        "Shared indicate whether the path is shared between nodes",
+		"preallocation": "Preallocation defines how space for the raw disk.img is allocated when it gets created.\nOnly 'off', 'falloc' and 'full' are supported. Defaults to off.\n+optional",
 	}
 }
 
@@ -50,6 +51,32 @@ func (DownwardMetricsVolumeSource) SwaggerDoc() map[string]string {
 	}
 }
 
+func (NetworkDiskSource) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":         "NetworkDiskSource represents a disk which is accessed by QEMU directly over the network.\nNVMe over Fabrics is not supported, since QEMU has no initiator for it.\nSuch disks have to be attached to the node and provided through a PersistentVolumeClaim.\n\n+k8s:openapi-gen=true",
+		"protocol": "Protocol used to access the disk. One of: iscsi, nbd, rbd.",
+		"name":     "Name of the disk on the storage system.\nFor iscsi it is the target IQN and LUN (<iqn>/<lun>), for rbd the pool and image (<pool>/<image>)\nand for nbd the optional export name.\n+optional",
+		"host":     "Host serving the disk.",
+		"auth":     "Auth holds the credentials to access the disk. Only supported for iscsi and rbd.\n+optional",
+	}
+}
+
+func (NetworkDiskHost) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":     "NetworkDiskHost is the host serving a network disk.\n\n+k8s:openapi-gen=true",
+		"name": "Name is the hostname or IP address of the host.",
+		"port": "Port of the host. Defaults to the default port of the protocol.\n+optional",
+	}
+}
+
+func (NetworkDiskAuth) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "NetworkDiskAuth holds the credentials to access a network disk.\n\n+k8s:openapi-gen=true",
+		"username":  "Username to authenticate with.",
+		"secretRef": "SecretRef is the name of a secret in the namespace of the vmi, which holds\nthe CHAP password (iscsi) or the cephx key (rbd) in its \"password\" key.",
+	}
+}
+
 func (SysprepSource) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":          "Represents a Sysprep volume source.\n\n+k8s:openapi-gen=true",
@@ -95,6 +122,7 @@ func (DomainSpec) SwaggerDoc() map[string]string {
 		"devices":         "Devices allows adding disks, network interfaces, and others",
 		"ioThreadsPolicy": "Controls whether or not disks will share IOThreads.\nOmitting IOThreadsPolicy disables use of IOThreads.\nOne of: shared, auto\n+optional",
 		"chassis":         "Chassis specifies the chassis info passed to the domain.\n+optional",
+		"guestOS":         "GuestOS describes the operating system of the guest. It is a hint used to pick the defaults of the\ndisk bus, the network interface model, the tablet and the Hyper-V features, if they are not set.\n+optional",
 	}
 }
 
@@ -104,6 +132,14 @@ func (Chassis) SwaggerDoc() map[string]string {
 	}
 }
 
+func (GuestOS) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "GuestOS describes the operating system of the guest.\n\n+k8s:openapi-gen=true",
+		"osFamily":  "OSFamily of the guest operating system.\nOne of: linux, windows\n+optional",
+		"osVersion": "OSVersion of the guest operating system, e.g. 2008 or 10 for windows.\n+optional",
+	}
+}
+
 func (Bootloader) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":     "Represents the firmware blob used to assist in the domain creation process.\nUsed for setting the QEMU BIOS file path for the libvirt domain.\n\n+k8s:openapi-gen=true",
@@ -206,8 +242,18 @@ func (Hugepages) SwaggerDoc() map[string]string {
 
 func (Machine) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":     "+k8s:openapi-gen=true",
-		"type": "QEMU machine type is the actual chipset of the VirtualMachineInstance.\n+optional",
+		"":        "+k8s:openapi-gen=true",
+		"type":    "QEMU machine type is the actual chipset of the VirtualMachineInstance.\n+optional",
+		"options": "Options are additional QEMU -machine options, for hardware quirks of particular guests.\nOnly the options allowed in the QEMUOptionsAllowlist of the KubeVirt configuration are accepted.\n+optional\n+listType=atomic",
+		"globals": "Globals are QEMU -global options, which set a property of all devices of a driver. Their\nnames have the form driver.property. Only the globals allowed in the QEMUOptionsAllowlist of\nthe KubeVirt configuration are accepted.\n+optional\n+listType=atomic",
+	}
+}
+
+func (QEMUOption) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":      "+k8s:openapi-gen=true",
+		"name":  "Name of the option.",
+		"value": "Value the option is set to.",
 	}
 }
 
@@ -236,11 +282,22 @@ func (Devices) SwaggerDoc() map[string]string {
 		"autoattachMemBalloon":       "Whether to attach the Memory balloon device with default period.\nPeriod can be adjusted in virt-config.\nDefaults to true.\n+optional",
 		"rng":                        "Whether to have random number generator from host\n+optional",
 		"blockMultiQueue":            "Whether or not to enable virtio multi-queue for block devices.\nDefaults to false.\n+optional",
+		"scsiController":             "SCSIController configures the controller of the disks with the scsi bus.\nIf blockMultiQueue is enabled, a virtio-scsi controller gets one queue per vCPU.\n+optional",
 		"networkInterfaceMultiqueue": "If specified, virtual network interfaces configured with a virtio bus will also enable the vhost multiqueue feature for network devices. The number of queues created depends on additional factors of the VirtualMachineInstance, like the number of guest CPUs.\n+optional",
 		"gpus":                       "Whether to attach a GPU device to the vmi.\n+optional\n+listType=atomic",
+		"detachGPUsForMigration":     "Whether to detach the GPUs for a live migration. The GPUs are hot-unplugged and the guest\nis paused until the GPUs of the target are hot-plugged. The guest has to support the\nhot-unplug of its GPUs. Without it, VMIs with GPUs are not live migratable.\n+optional",
 		"filesystems":                "Filesystems describes filesystem which is connected to the vmi.\n+optional\n+listType=atomic",
 		"hostDevices":                "Whether to attach a host device to the vmi.\n+optional\n+listType=atomic",
 		"clientPassthrough":          "To configure and access client devices such as redirecting USB\n+optional",
+		"channels":                   "Channels are additional virtio-serial channels of the vmi. Each channel is backed by a unix socket\nin the virt-launcher pod, which is shared with the sidecars of the vmi.\n+optional\n+listType=atomic",
+	}
+}
+
+func (SerialChannel) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":       "SerialChannel is a virtio-serial channel between the guest and the virt-launcher pod. The socket of the channel\nis created at <channel socket directory>/<name>.sock, where sidecars can connect to it to exchange data with the\nguest without networking, e.g. to collect logs.\n\n+k8s:openapi-gen=true",
+		"name":   "Name of the channel, unique within the vmi. It names the socket of the channel.",
+		"target": "Target is the name of the virtio-serial port in the guest, e.g. org.example.log.0.",
 	}
 }
 
@@ -275,8 +332,10 @@ func (FilesystemVirtiofs) SwaggerDoc() map[string]string {
 
 func (GPU) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":     "+k8s:openapi-gen=true",
-		"name": "Name of the GPU device as exposed by a device plugin",
+		"":         "+k8s:openapi-gen=true",
+		"name":     "Name of the GPU device as exposed by a device plugin",
+		"role":     "Role labels the device for the guest, e.g. render or compute. It is reported together with\nthe guest PCI address of the device in the VMI status.\n+optional",
+		"pciOrder": "PCIOrder is an integer value > 0, used to determine the order of the guest PCI addresses of\nthe GPUs and host devices. Lower values get lower addresses, devices without a PCI order are\nplaced after the ordered ones.\nEach GPU or host device that has a PCI order must have a unique value.\n+optional",
 	}
 }
 
@@ -284,6 +343,8 @@ func (HostDevice) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":           "+k8s:openapi-gen=true",
 		"deviceName": "DeviceName is the resource name of the host device exposed by a device plugin",
+		"role":       "Role labels the device for the guest, e.g. render or compute. It is reported together with\nthe guest PCI address of the device in the VMI status.\n+optional",
+		"pciOrder":   "PCIOrder is an integer value > 0, used to determine the order of the guest PCI addresses of\nthe GPUs and host devices. Lower values get lower addresses, devices without a PCI order are\nplaced after the ordered ones.\nEach GPU or host device that has a PCI order must have a unique value.\n+optional",
 	}
 }
 
@@ -296,8 +357,11 @@ func (Disk) SwaggerDoc() map[string]string {
 		"dedicatedIOThread": "dedicatedIOThread indicates this disk should have an exclusive IO Thread.\nEnabling this implies useIOThreads = true.\nDefaults to false.\n+optional",
 		"cache":             "Cache specifies which kvm disk cache mode should be used.\nSupported values are: CacheNone, CacheWriteThrough.\n+optional",
 		"io":                "IO specifies which QEMU disk IO mode should be used.\nSupported values are: native, default, threads.\n+optional",
+		"discard":           "Discard specifies whether discard requests from the guest are passed to the storage.\nSupported values are: unmap, ignore.\nDefaults to unmap unless the volume is preallocated.\n+optional",
+		"detectZeroes":      "DetectZeroes specifies whether QEMU detects writes of zeroes and optimizes them.\nSupported values are: off, on, unmap.\nDefaults to unmap on thin-provisioned block volumes with discard enabled.\n+optional",
 		"tag":               "If specified, disk address and its tag will be provided to the guest via config drive metadata\n+optional",
 		"blockSize":         "If specified, the virtual disk will be presented with the given block sizes.\n+optional",
+		"shareable":         "Shareable indicates whether the disk can be shared between multiple VMIs, e.g. for clustered file systems.\nA shareable disk must be backed by a block PersistentVolumeClaim with the ReadWriteMany access mode,\nit is attached without a host side cache and without an ephemeral overlay.\nDefaults to false.\n+optional",
 	}
 }
 
@@ -326,7 +390,7 @@ func (DiskDevice) SwaggerDoc() map[string]string {
 func (DiskTarget) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":           "+k8s:openapi-gen=true",
-		"bus":        "Bus indicates the type of disk device to emulate.\nsupported values: virtio, sata, scsi.",
+		"bus":        "Bus indicates the type of disk device to emulate.\nsupported values: virtio, sata, scsi, usb.",
 		"readonly":   "ReadOnly.\nDefaults to false.",
 		"pciAddress": "If specified, the virtual disk will be placed on the guests pci address with the specified PCI address. For example: 0000:81:01.10\n+optional",
 	}
@@ -351,7 +415,7 @@ func (FloppyTarget) SwaggerDoc() map[string]string {
 func (CDRomTarget) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":         "+k8s:openapi-gen=true",
-		"bus":      "Bus indicates the type of disk device to emulate.\nsupported values: virtio, sata, scsi.",
+		"bus":      "Bus indicates the type of disk device to emulate.\nsupported values: virtio, sata, scsi, usb.",
 		"readonly": "ReadOnly.\nDefaults to true.",
 		"tray":     "Tray indicates if the tray of the device is open or closed.\nAllowed values are \"open\" and \"closed\".\nDefaults to closed.\n+optional",
 	}
@@ -381,6 +445,8 @@ func (VolumeSource) SwaggerDoc() map[string]string {
 		"downwardAPI":           "DownwardAPI represents downward API about the pod that should populate this volume\n+optional",
 		"serviceAccount":        "ServiceAccountVolumeSource represents a reference to a service account.\nThere can only be one volume of this type!\nMore info: https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/\n+optional",
 		"downwardMetrics":       "DownwardMetrics adds a very small disk to VMIs which contains a limited view of host and guest\nmetrics. The disk content is compatible with vhostmd (https://github.com/vhostmd/vhostmd) and vm-dump-metrics.",
+		"networkDisk":           "NetworkDisk represents a disk which is accessed by QEMU directly over the network (iSCSI, NBD or RBD),\nwithout attaching it to the node or mounting it into the virt-launcher pod.\n+optional",
+		"memoryDump":            "MemoryDump represents a PersistentVolumeClaim the memory state of the vmi is saved to.\nIt is hotplugged into the virt-launcher pod without being attached to the vmi as a disk.\n+optional",
 	}
 }
 
@@ -402,8 +468,15 @@ func (DataVolumeSource) SwaggerDoc() map[string]string {
 
 func (PersistentVolumeClaimVolumeSource) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":             "PersistentVolumeClaimVolumeSource represents a reference to a PersistentVolumeClaim in the same namespace.\nDirectly attached to the vmi via qemu.\nMore info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims\n\n+k8s:openapi-gen=true",
-		"hotpluggable": "Hotpluggable indicates whether the volume can be hotplugged and hotunplugged.\n+optional",
+		"":              "PersistentVolumeClaimVolumeSource represents a reference to a PersistentVolumeClaim in the same namespace.\nDirectly attached to the vmi via qemu.\nMore info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims\n\n+k8s:openapi-gen=true",
+		"hotpluggable":  "Hotpluggable indicates whether the volume can be hotplugged and hotunplugged.\n+optional",
+		"preallocation": "Preallocation defines how space for the raw disk.img is allocated when it gets\ncreated on an empty filesystem PVC. Only 'off', 'falloc' and 'full' are supported.\nDefaults to off.\n+optional",
+	}
+}
+
+func (MemoryDumpVolumeSource) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "MemoryDumpVolumeSource represents a reference to a PersistentVolumeClaim in the same namespace\nthe memory state of the vmi is saved to.\n\n+k8s:openapi-gen=true",
 	}
 }
 
@@ -411,23 +484,28 @@ func (EphemeralVolumeSource) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":                      "+k8s:openapi-gen=true",
 		"persistentVolumeClaim": "PersistentVolumeClaimVolumeSource represents a reference to a PersistentVolumeClaim in the same namespace.\nDirectly attached to the vmi via qemu.\nMore info: https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims\n+optional",
+		"imageFormat":           "ImageFormat is the format of the local image the PVC is exposed through.\nWith qcow2 a copy-on-write overlay on top of the PVC is created, with raw\nthe PVC content is converted into a standalone local copy.\nDefaults to qcow2.\n+optional",
+		"preallocation":         "Preallocation defines how space for the local image is allocated.\nDefaults to off.\n+optional",
 	}
 }
 
 func (EmptyDiskSource) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":         "EmptyDisk represents a temporary disk which shares the vmis lifecycle.\n\n+k8s:openapi-gen=true",
-		"capacity": "Capacity of the sparse disk.",
+		"":              "EmptyDisk represents a temporary disk which shares the vmis lifecycle.\n\n+k8s:openapi-gen=true",
+		"capacity":      "Capacity of the sparse disk.",
+		"imageFormat":   "ImageFormat is the on-disk format of the created image.\nDefaults to qcow2.\n+optional",
+		"preallocation": "Preallocation defines how space for the created image is allocated.\nDefaults to off.\n+optional",
 	}
 }
 
 func (ContainerDiskSource) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":                "Represents a docker image with an embedded disk.\n\n+k8s:openapi-gen=true",
-		"image":           "Image is the name of the image with the embedded disk.",
-		"imagePullSecret": "ImagePullSecret is the name of the Docker registry secret required to pull the image. The secret must already exist.",
-		"path":            "Path defines the path to disk file in the container",
-		"imagePullPolicy": "Image pull policy.\nOne of Always, Never, IfNotPresent.\nDefaults to Always if :latest tag is specified, or IfNotPresent otherwise.\nCannot be updated.\nMore info: https://kubernetes.io/docs/concepts/containers/images#updating-images\n+optional",
+		"":                  "Represents a docker image with an embedded disk.\n\n+k8s:openapi-gen=true",
+		"image":             "Image is the name of the image with the embedded disk.",
+		"imagePullSecret":   "ImagePullSecret is the name of the Docker registry secret required to pull the image. The secret must already exist.",
+		"path":              "Path defines the path to disk file in the container",
+		"imagePullPolicy":   "Image pull policy.\nOne of Always, Never, IfNotPresent.\nDefaults to Always if :latest tag is specified, or IfNotPresent otherwise.\nCannot be updated.\nMore info: https://kubernetes.io/docs/concepts/containers/images#updating-images\n+optional",
+		"imageDigestPolicy": "ImageDigestPolicy controls whether the image has to be referenced by digest.\nWith Require, images have to be referenced by digest. With Pin, virt-controller\nresolves images referenced by tag to their digest when it starts the VirtualMachine\nand records them in its status, so that restarts of the VirtualMachine always boot\nthe same image. VirtualMachineInstances which are not created by a VirtualMachine\nmust reference such images by digest.\n+optional",
 	}
 }
 
@@ -602,15 +680,44 @@ func (I6300ESBWatchdog) SwaggerDoc() map[string]string {
 
 func (Interface) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":            "+k8s:openapi-gen=true",
-		"name":        "Logical name of the interface as well as a reference to the associated networks.\nMust match the Name of a Network.",
-		"model":       "Interface model.\nOne of: e1000, e1000e, ne2k_pci, pcnet, rtl8139, virtio.\nDefaults to virtio.",
-		"ports":       "List of ports to be forwarded to the virtual machine.",
-		"macAddress":  "Interface MAC address. For example: de:ad:00:00:be:af or DE-AD-00-00-BE-AF.",
-		"bootOrder":   "BootOrder is an integer value > 0, used to determine ordering of boot devices.\nLower values take precedence.\nEach interface or disk that has a boot order must have a unique value.\nInterfaces without a boot order are not tried.\n+optional",
-		"pciAddress":  "If specified, the virtual network interface will be placed on the guests pci address with the specified PCI address. For example: 0000:81:01.10\n+optional",
-		"dhcpOptions": "If specified the network interface will pass additional DHCP options to the VMI\n+optional",
-		"tag":         "If specified, the virtual network interface address and its tag will be provided to the guest via config drive\n+optional",
+		"":               "+k8s:openapi-gen=true",
+		"name":           "Logical name of the interface as well as a reference to the associated networks.\nMust match the Name of a Network.",
+		"model":          "Interface model.\nOne of: e1000, e1000e, ne2k_pci, pcnet, rtl8139, virtio.\nDefaults to virtio.",
+		"ports":          "List of ports to be forwarded to the virtual machine.",
+		"macAddress":     "Interface MAC address. For example: de:ad:00:00:be:af or DE-AD-00-00-BE-AF.",
+		"bootOrder":      "BootOrder is an integer value > 0, used to determine ordering of boot devices.\nLower values take precedence.\nEach interface or disk that has a boot order must have a unique value.\nInterfaces without a boot order are not tried.\n+optional",
+		"pciAddress":     "If specified, the virtual network interface will be placed on the guests pci address with the specified PCI address. For example: 0000:81:01.10\n+optional",
+		"dhcpOptions":    "If specified the network interface will pass additional DHCP options to the VMI\n+optional",
+		"tag":            "If specified, the virtual network interface address and its tag will be provided to the guest via config drive\n+optional",
+		"mirror":         "If specified, the traffic of the interface is mirrored, e.g. for intrusion detection or\ntroubleshooting captures without tooling in the guest.\nOnly supported on interfaces with the bridge binding.\n+optional",
+		"bandwidth":      "Bandwidth is the throughput the interface is expected to need, in bits per second, for example 10G.\nThe VMI reserves it from the node bandwidth of the cluster network configuration, nodes without\nenough unreserved bandwidth are not considered for scheduling.\n+optional",
+		"bandwidthLimit": "BandwidthLimit shapes the traffic of the interface. It can be changed while the VMI is\nrunning, virt-launcher applies the new limits to the running domain.\nNot supported on interfaces with the SR-IOV, slirp or vhostuser binding.\n+optional",
+	}
+}
+
+func (InterfaceBandwidthLimit) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":         "InterfaceBandwidthLimit limits the average throughput of an interface, as seen from the guest.",
+		"inbound":  "Inbound is the throughput the guest can receive, in bits per second, for example 100M.\n+optional",
+		"outbound": "Outbound is the throughput the guest can send, in bits per second, for example 100M.\n+optional",
+	}
+}
+
+func (InterfaceMirror) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":         "InterfaceMirror clones the traffic of an interface to a Multus network or into a capture\ninterface in the virt-launcher pod. The mirror interface of the pod interface ethN or netN\nis named mirN, sidecars of the VMI can capture the mirrored traffic on it.\n\n+k8s:openapi-gen=true",
+		"network":  "Network is the name of the NetworkAttachmentDefinition, optionally prefixed with its namespace,\nthe traffic is mirrored to. The traffic is mirrored into a capture interface if it is not set.\n+optional",
+		"filter":   "Filter limits the mirrored traffic. All traffic is mirrored if it is not set.\n+optional",
+		"duration": "Duration limits how long the traffic is mirrored, counted from the creation of the VMI.\nThe traffic is mirrored for the whole lifetime of the VMI if it is not set.\n+optional",
+	}
+}
+
+func (InterfaceMirrorFilter) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "InterfaceMirrorFilter selects the mirrored traffic.\n\n+k8s:openapi-gen=true",
+		"direction": "Direction of the mirrored traffic, as seen from the guest.\nOne of: ingress, egress, both.\nDefaults to both.\n+optional",
+		"protocol":  "Protocol limits the mirrored traffic to IPv4 packets of the protocol.\nOne of: TCP, UDP, ICMP.\n+optional",
+		"port":      "Port limits the mirrored TCP or UDP traffic to packets from or to the port.\n+optional",
 	}
 }
 
@@ -621,6 +728,7 @@ func (DHCPOptions) SwaggerDoc() map[string]string {
 		"tftpServerName": "If specified will pass option 66 to interface's DHCP server\n+optional",
 		"ntpServers":     "If specified will pass the configured NTP server to the VM via DHCP option 042.\n+optional",
 		"privateOptions": "If specified will pass extra DHCP options for private use, range: 224-254\n+optional",
+		"leaseTime":      "If specified will pass the lease time to the VM via DHCP option 051.\nDefaults to an hourly renewed lease if the address of the interface can change, to an infinite lease otherwise.\n+optional",
 	}
 }
 
@@ -796,6 +904,13 @@ func (Rng) SwaggerDoc() map[string]string {
 	}
 }
 
+func (SCSIController) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":      "Represents the SCSI controller of a vmi.\n\n+k8s:openapi-gen=true",
+		"model": "Model of the SCSI controller. One of: virtio-scsi, lsilogic.\nDefaults to virtio-scsi.\n+optional",
+	}
+}
+
 func (MultusNetwork) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":            "Represents the multus cni network.\n\n+k8s:openapi-gen=true",
@@ -109,6 +109,20 @@ type VirtualMachineInstanceSpec struct {
 	// If not specified, the VMI will be dispatched by default scheduler.
 	// +optional
 	SchedulerName string `json:"schedulerName,omitempty"`
+	// If specified, the virt-launcher pod will be run with the referenced RuntimeClass.
+	// If not specified, the default runtime class of the KubeVirt configuration is used.
+	// +optional
+	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
+	// TopologySpreadConstraints describes how the virt-launcher pod ought to spread across topology
+	// domains. All topologySpreadConstraints are ANDed.
+	// +optional
+	// +listType=map
+	// +listMapKey=topologyKey
+	// +listMapKey=whenUnsatisfiable
+	TopologySpreadConstraints []k8sv1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	// LauncherPodMetadata contains additional labels and annotations for the virt-launcher pod.
+	// +optional
+	LauncherPodMetadata *LauncherPodMetadata `json:"launcherPodMetadata,omitempty"`
 	// If toleration is specified, obey all the toleration rules.
 	Tolerations []k8sv1.Toleration `json:"tolerations,omitempty"`
 
@@ -121,6 +135,17 @@ type VirtualMachineInstanceSpec struct {
 	//
 	// +optional
 	StartStrategy *StartStrategy `json:"startStrategy,omitempty"`
+	// SchedulingReadinessGates lists conditions which have to be set to True on the VirtualMachineInstance,
+	// usually by third-party controllers, before the virt-launcher pod is created.
+	// +optional
+	// +listType=atomic
+	SchedulingReadinessGates []SchedulingReadinessGate `json:"schedulingReadinessGates,omitempty"`
+	// Sidecars are user containers which are added to the virt-launcher pod.
+	// They share the lifecycle of the VirtualMachineInstance.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Sidecars []Sidecar `json:"sidecars,omitempty"`
 	// Grace period observed after signalling a VirtualMachineInstance to stop after which the VirtualMachineInstance is force terminated.
 	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
 	// List of volumes that can be mounted by disks belonging to the vmi.
@@ -167,6 +192,57 @@ type VirtualMachineInstanceSpec struct {
 	AccessCredentials []AccessCredential `json:"accessCredentials,omitempty"`
 }
 
+// LauncherPodMetadata contains metadata which is added to the virt-launcher pod.
+//
+// +k8s:openapi-gen=true
+type LauncherPodMetadata struct {
+	// Labels which are added to the virt-launcher pod.
+	// Labels in the kubevirt.io domain are reserved.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations which are added to the virt-launcher pod.
+	// Annotations in the kubevirt.io domain are reserved.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Sidecar is a user container which runs next to the virtual machine in the virt-launcher pod,
+// e.g. a log shipper or a license daemon.
+//
+// +k8s:openapi-gen=true
+type Sidecar struct {
+	// Name of the sidecar container, unique within the VirtualMachineInstance.
+	Name string `json:"name"`
+	// Image of the sidecar container.
+	Image string `json:"image"`
+	// Image pull policy of the sidecar container.
+	// +optional
+	ImagePullPolicy k8sv1.PullPolicy `json:"imagePullPolicy,omitempty"`
+	// Entrypoint array. The ENTRYPOINT of the image is used if this is not provided.
+	// +optional
+	Command []string `json:"command,omitempty"`
+	// Arguments to the entrypoint. The CMD of the image is used if this is not provided.
+	// +optional
+	Args []string `json:"args,omitempty"`
+	// Resources are the cpu and memory requests and limits of the sidecar container.
+	// They are accounted for on top of the resources of the virtual machine.
+	// +optional
+	Resources k8sv1.ResourceRequirements `json:"resources,omitempty"`
+	// VolumeMounts share volumes of the VirtualMachineInstance read-only with the sidecar container.
+	// +optional
+	VolumeMounts []SidecarVolumeMount `json:"volumeMounts,omitempty"`
+}
+
+// SidecarVolumeMount mounts a volume of the VirtualMachineInstance into a sidecar container.
+//
+// +k8s:openapi-gen=true
+type SidecarVolumeMount struct {
+	// Name of the VirtualMachineInstance volume.
+	Name string `json:"name"`
+	// MountPath is the path within the sidecar container at which the volume is mounted.
+	MountPath string `json:"mountPath"`
+}
+
 // VirtualMachineInstancePhaseTransitionTimestamp gives a timestamp in relation to when a phase is set on a vmi
 //
 // +k8s:openapi-gen=true
@@ -177,6 +253,29 @@ type VirtualMachineInstancePhaseTransitionTimestamp struct {
 	PhaseTransitionTimestamp metav1.Time `json:"phaseTransitionTimestamp,omitempty"`
 }
 
+// VirtualMachineInstanceResourceOverhead describes the resources which virt-controller adds
+// to the virt-launcher pod on top of the resources requested for the guest.
+//
+// +k8s:openapi-gen=true
+type VirtualMachineInstanceResourceOverhead struct {
+	// Memory needed by the hypervisor, e.g. for pagetables, virtio rings, iothreads and video RAM.
+	// It includes the additional guest memory overhead ratio of the KubeVirt configuration.
+	// +optional
+	Memory *resource.Quantity `json:"memory,omitempty"`
+	// CPU needed by the hypervisor, e.g. for an isolated emulator thread
+	// +optional
+	CPU *resource.Quantity `json:"cpu,omitempty"`
+	// EmulatorCPU is the part of CPU requested for the emulator thread
+	// +optional
+	EmulatorCPU *resource.Quantity `json:"emulatorCPU,omitempty"`
+	// IOThreadsCPU is the part of CPU requested for the IO threads
+	// +optional
+	IOThreadsCPU *resource.Quantity `json:"ioThreadsCPU,omitempty"`
+	// VirtiofsCPU is the part of CPU requested for the virtiofsd processes
+	// +optional
+	VirtiofsCPU *resource.Quantity `json:"virtiofsCPU,omitempty"`
+}
+
 // +k8s:openapi-gen=true
 type TopologyHints struct {
 	TSCFrequency *int64 `json:"tscFrequency,omitempty"`
@@ -204,10 +303,17 @@ type VirtualMachineInstanceStatus struct {
 	Interfaces []VirtualMachineInstanceNetworkInterface `json:"interfaces,omitempty"`
 	// Guest OS Information
 	GuestOSInfo VirtualMachineInstanceGuestOSInfo `json:"guestOSInfo,omitempty"`
+	// Version and capabilities of the guest agent
+	// +optional
+	GuestAgent *VirtualMachineInstanceGuestAgentStatus `json:"guestAgent,omitempty"`
 	// Represents the status of a live migration
 	MigrationState *VirtualMachineInstanceMigrationState `json:"migrationState,omitempty"`
 	// Represents the method using which the vmi can be migrated: live migration or block migration
 	MigrationMethod VirtualMachineInstanceMigrationMethod `json:"migrationMethod,omitempty"`
+	// MigrationBackoff tracks consecutive failed migrations of the vmi. Migrations created by
+	// KubeVirt itself, e.g. on evacuation, are held back until the backoff expired.
+	// +optional
+	MigrationBackoff *VirtualMachineInstanceMigrationBackoff `json:"migrationBackoff,omitempty"`
 	// The Quality of Service (QOS) classification assigned to the virtual machine instance based on resource requirements
 	// See PodQOSClass type for available QOS classes
 	// More info: https://git.k8s.io/community/contributors/design-proposals/node/resource-qos.md
@@ -239,10 +345,38 @@ type VirtualMachineInstanceStatus struct {
 	// +optional
 	TopologyHints *TopologyHints `json:"topologyHints,omitempty"`
 
+	// ResourceOverhead is the overhead which was added to the resources of the virt-launcher pod
+	// +optional
+	ResourceOverhead *VirtualMachineInstanceResourceOverhead `json:"resourceOverhead,omitempty"`
+
+	// CPUAllocationRatio is the effective ratio of vCPUs to the CPU requested by the virt-launcher pod.
+	// It is not set if the VMI uses dedicated CPUs.
+	// +optional
+	CPUAllocationRatio *int `json:"cpuAllocationRatio,omitempty"`
+
 	//VirtualMachineRevisionName is used to get the vm revision of the vmi when doing
 	// an online vm snapshot
 	// +optional
 	VirtualMachineRevisionName string `json:"virtualMachineRevisionName,omitempty"`
+
+	// HostDevices reports where the GPUs and host devices of the VMI are visible in the guest
+	// +optional
+	// +listType=atomic
+	HostDevices []VirtualMachineInstanceHostDeviceStatus `json:"hostDevices,omitempty"`
+}
+
+// VirtualMachineInstanceHostDeviceStatus reports where a GPU or host device is visible in the guest
+//
+// +k8s:openapi-gen=true
+type VirtualMachineInstanceHostDeviceStatus struct {
+	// Name of the GPU or host device in the VMI spec
+	Name string `json:"name"`
+	// Role of the device as given in the VMI spec
+	// +optional
+	Role string `json:"role,omitempty"`
+	// GuestPCIAddress is the PCI address of the device in the guest, e.g. 0000:05:00.0
+	// +optional
+	GuestPCIAddress string `json:"guestPCIAddress,omitempty"`
 }
 
 // PersistentVolumeClaimInfo contains the relavant information virt-handler needs cached about a PVC
@@ -266,6 +400,11 @@ type PersistentVolumeClaimInfo struct {
 	// Preallocated indicates if the PVC's storage is preallocated or not
 	// +optional
 	Preallocated bool `json:"preallocated,omitempty"`
+
+	// LocalToNode is the hostname of the node the bound PersistentVolume is local to.
+	// VirtualMachineInstances using it are placed on this node and can not be live migrated.
+	// +optional
+	LocalToNode string `json:"localToNode,omitempty"`
 }
 
 // VolumeStatus represents information about the status of volumes attached to the VirtualMachineInstance.
@@ -417,6 +556,30 @@ const (
 	// Reflects whether the QEMU guest agent is connected through the channel
 	VirtualMachineInstanceUnsupportedAgent VirtualMachineInstanceConditionType = "AgentVersionNotSupported"
 
+	// Reflects whether features relying on the QEMU guest agent are unavailable because the agent lacks commands
+	VirtualMachineInstanceAgentDegraded VirtualMachineInstanceConditionType = "AgentDegraded"
+	// Reason means that the guest agent doesn't provide commands KubeVirt requires
+	VirtualMachineInstanceReasonAgentCommandsMissing = "GuestAgentCommandsMissing"
+	// Reason means that the guest agent version is not in the list of supported versions
+	VirtualMachineInstanceReasonAgentVersionNotSupported = "GuestAgentVersionNotSupported"
+	// Reason means that some features relying on the guest agent are unavailable
+	VirtualMachineInstanceReasonAgentFeaturesUnavailable = "GuestAgentFeaturesUnavailable"
+
+	// Reflects whether the configuration of the running domain differs from the VMI
+	VirtualMachineInstanceDriftDetected VirtualMachineInstanceConditionType = "DriftDetected"
+	// Reason means that virt-launcher found fields of the VMI whose configuration differs in the running domain
+	VirtualMachineInstanceReasonDomainDrifted = "DomainDrifted"
+
+	// Reflects whether little of the guest memory is left for applications, as reported by the balloon driver
+	VirtualMachineInstanceGuestMemoryPressure VirtualMachineInstanceConditionType = "GuestMemoryPressure"
+	// Reason means that the share of the guest memory which applications can use without swapping is low
+	VirtualMachineInstanceReasonLowGuestMemory = "LowGuestMemory"
+
+	// Reflects whether the vCPUs of the guest spend much of their time waiting for a host CPU
+	VirtualMachineInstanceGuestCPUSteal VirtualMachineInstanceConditionType = "GuestCPUSteal"
+	// Reason means that the steal time of the vCPUs is high
+	VirtualMachineInstanceReasonHighCPUSteal = "HighCPUSteal"
+
 	// Indicates whether the VMI is live migratable
 	VirtualMachineInstanceIsMigratable VirtualMachineInstanceConditionType = "LiveMigratable"
 	// Reason means that VMI is not live migratioable because of it's disks collection
@@ -429,8 +592,18 @@ const (
 	VirtualMachineInstanceReasonCPUModeNotMigratable = "CPUModeLiveMigratable"
 	// Reason means that VMI is not live migratable because it uses virtiofs
 	VirtualMachineInstanceReasonVirtIOFSNotMigratable = "VirtIOFSNotLiveMigratable"
+	// Reason means that VMI is not live migratable because it uses GPUs which are not detached for the migration
+	VirtualMachineInstanceReasonGPUNotMigratable = "GPUNotLiveMigratable"
 )
 
+// SchedulingReadinessGate refers to a condition on the VirtualMachineInstance which gates the creation of its pod.
+//
+// +k8s:openapi-gen=true
+type SchedulingReadinessGate struct {
+	// ConditionType refers to a condition in the VirtualMachineInstance's condition list with matching type.
+	ConditionType VirtualMachineInstanceConditionType `json:"conditionType"`
+}
+
 const (
 	// PodTerminatingReason indicates on the Ready condition on the VMI if the underlying pod is terminating
 	PodTerminatingReason = "PodTerminating"
@@ -443,8 +616,19 @@ const (
 
 	// GuestNotRunningReason indicates on the Ready condition on the VMI if the underlying guest VM is not running
 	GuestNotRunningReason = "GuestNotRunning"
+
+	// SchedulingGatedReason indicates on the PodScheduled condition on the VMI that the pod creation waits for scheduling readiness gates
+	SchedulingGatedReason = "SchedulingGated"
+
+	// MigrationDrainingReason indicates on the VirtualMachineInstanceNotDraining condition of the virt-launcher pod
+	// that the connections to the VMI are drained before it is migrated
+	MigrationDrainingReason = "MigrationDraining"
 )
 
+// VirtualMachineInstanceNotDraining is the readiness gate of virt-launcher pods whose connections are drained before
+// the VMI is migrated. The pod condition is false while the connections are drained.
+const VirtualMachineInstanceNotDraining k8sv1.PodConditionType = "kubevirt.io/virtual-machine-not-draining"
+
 // +k8s:openapi-gen=true
 type VirtualMachineInstanceMigrationConditionType string
 
@@ -452,6 +636,22 @@ type VirtualMachineInstanceMigrationConditionType string
 const (
 	// VirtualMachineInstanceMigrationAbortRequested indicates that live migration abort has been requested
 	VirtualMachineInstanceMigrationAbortRequested VirtualMachineInstanceMigrationConditionType = "migrationAbortRequested"
+	// VirtualMachineInstanceMigrationStorageCheckFailed indicates that the storage of the VMI can't follow it to another node
+	VirtualMachineInstanceMigrationStorageCheckFailed VirtualMachineInstanceMigrationConditionType = "storageCheckFailed"
+	// VirtualMachineInstanceMigrationAborted indicates that the migration has been aborted and the VMI stayed on the source node
+	VirtualMachineInstanceMigrationAborted VirtualMachineInstanceMigrationConditionType = "migrationAborted"
+	// VirtualMachineInstanceMigrationCPUCheckFailed indicates that the CPU of the target node is not compatible with the VMI
+	VirtualMachineInstanceMigrationCPUCheckFailed VirtualMachineInstanceMigrationConditionType = "cpuCheckFailed"
+)
+
+const (
+	// MigrationCancelledReason indicates on the migrationAbortRequested and migrationAborted conditions that the
+	// migration was cancelled through the cancel subresource or by deleting the migration
+	MigrationCancelledReason = "Cancelled"
+
+	// MigrationAbortedByPolicyReason indicates on the migrationAborted condition that virt-launcher aborted the
+	// migration because it exceeded the configured progress or completion timeouts
+	MigrationAbortedByPolicyReason = "AbortedByPolicy"
 )
 
 //
@@ -478,6 +678,9 @@ type VirtualMachineInstanceMigrationCondition struct {
 	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
 	Reason             string      `json:"reason,omitempty"`
 	Message            string      `json:"message,omitempty"`
+	// ObservedGeneration is the metadata.generation of the object the condition was set for
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
 // The migration phase indicates that the job has completed
@@ -485,6 +688,14 @@ func (m *VirtualMachineInstanceMigration) IsFinal() bool {
 	return m.Status.Phase == MigrationFailed || m.Status.Phase == MigrationSucceeded
 }
 
+func (e *VirtualMachineImageExport) IsFinal() bool {
+	return e.Status.Phase == ImageExportFailed || e.Status.Phase == ImageExportSucceeded
+}
+
+func (o *VirtualMachineBulkOperation) IsFinal() bool {
+	return o.Status.Phase == BulkOperationSucceeded
+}
+
 func (m *VirtualMachineInstanceMigration) IsRunning() bool {
 	switch m.Status.Phase {
 	case MigrationFailed, MigrationPending, MigrationPhaseUnset, MigrationSucceeded:
@@ -522,6 +733,15 @@ type VirtualMachineInstanceNetworkInterface struct {
 	IPs []string `json:"ipAddresses,omitempty"`
 	// The interface name inside the Virtual Machine
 	InterfaceName string `json:"interfaceName,omitempty"`
+	// Time the guest agent data of the interface was last changed. Rapid changes of the
+	// data are debounced, data the guest agent stops reporting is removed.
+	// +optional
+	// +nullable
+	LastGuestAgentUpdate *metav1.Time `json:"lastGuestAgentUpdate,omitempty"`
+	// BandwidthLimit is the limit applied to the interface of the running domain. It differs from
+	// the limit of the spec until virt-launcher applied a change of it.
+	// +optional
+	BandwidthLimit *InterfaceBandwidthLimit `json:"bandwidthLimit,omitempty"`
 }
 
 // +k8s:openapi-gen=true
@@ -542,6 +762,54 @@ type VirtualMachineInstanceGuestOSInfo struct {
 	Machine string `json:"machine,omitempty"`
 	// Guest OS Id
 	ID string `json:"id,omitempty"`
+	// Hostname of the Guest OS
+	Hostname string `json:"hostname,omitempty"`
+	// Timezone of the Guest OS, the zone name and the offset to UTC in seconds
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// VirtualMachineInstanceGuestAgentStatus reports the guest agent running in the VMI and which features it can serve
+//
+// +k8s:openapi-gen=true
+type VirtualMachineInstanceGuestAgentStatus struct {
+	// Version of the guest agent
+	Version string `json:"version,omitempty"`
+	// SupportedCommands lists the commands which are enabled in the guest agent
+	// +listType=set
+	SupportedCommands []string `json:"supportedCommands,omitempty"`
+	// UnavailableFeatures lists the features which can't be used because the guest agent lacks commands they rely on
+	// +listType=set
+	UnavailableFeatures []GuestAgentFeature `json:"unavailableFeatures,omitempty"`
+}
+
+// GuestAgentFeature is a feature which relies on a set of guest agent commands
+type GuestAgentFeature string
+
+const (
+	// GuestAgentFeatureExec is the execution of commands in the guest
+	GuestAgentFeatureExec GuestAgentFeature = "Exec"
+	// GuestAgentFeatureFSFreeze is freezing and thawing the guest filesystems
+	GuestAgentFeatureFSFreeze GuestAgentFeature = "FSFreeze"
+	// GuestAgentFeatureSSHPublicKeys is the propagation of SSH public keys
+	GuestAgentFeatureSSHPublicKeys GuestAgentFeature = "SSHPublicKeys"
+	// GuestAgentFeatureUserPassword is the propagation of user passwords
+	GuestAgentFeatureUserPassword GuestAgentFeature = "UserPassword"
+)
+
+// VirtualMachineInstanceMigrationBackoff represents the backoff of automated migrations after repeated failures
+//
+// +k8s:openapi-gen=true
+type VirtualMachineInstanceMigrationBackoff struct {
+	// The number of consecutive failed migrations
+	FailureCount int32 `json:"failureCount,omitempty"`
+	// The UID of the last failed migration
+	LastFailedMigrationUID types.UID `json:"lastFailedMigrationUid,omitempty"`
+	// The time the last migration failed
+	// +nullable
+	LastFailureTimestamp *metav1.Time `json:"lastFailureTimestamp,omitempty"`
+	// Automated migrations are not created before this time
+	// +nullable
+	NextAttemptTimestamp *metav1.Time `json:"nextAttemptTimestamp,omitempty"`
 }
 
 // +k8s:openapi-gen=true
@@ -578,6 +846,8 @@ type VirtualMachineInstanceMigrationState struct {
 	MigrationUID types.UID `json:"migrationUid,omitempty"`
 	// Lets us know if the vmi is currently running pre or post copy migration
 	Mode MigrationMode `json:"mode,omitempty"`
+	// Indicates that the source aborts the migration once its projected completion time exceeds the completion timeout
+	AbortOnProjectedTimeout bool `json:"abortOnProjectedTimeout,omitempty"`
 }
 
 //
@@ -667,6 +937,10 @@ const (
 	CreatedByLabel string = "kubevirt.io/created-by"
 	// This label is used to indicate that this pod is the target of a migration job.
 	MigrationJobLabel string = "kubevirt.io/migrationJobUID"
+	// This label identifies the common template a VirtualMachineInstancePreset or an example
+	// VirtualMachine deployed by virt-operator belongs to. VirtualMachineInstances carrying it
+	// get the preset of the common template applied.
+	CommonTemplateLabel string = "kubevirt.io/common-template"
 	// This label indicates the migration name that a PDB is protecting.
 	MigrationNameLabel string = "kubevirt.io/migrationName"
 	// This label describes which cluster node runs the virtual machine
@@ -683,6 +957,21 @@ const (
 	// This annotation indicates that a migration is the result of an
 	// automated workload update
 	WorkloadUpdateMigrationAnnotation string = "kubevirt.io/workloadUpdateMigration"
+	// This annotation requests to clear the migration backoff of a VMI, so that
+	// automated migrations are attempted again right away. Used on VirtualMachineInstance.
+	ResetMigrationBackoffAnnotation string = "kubevirt.io/reset-migration-backoff"
+	// This annotation holds the resource overhead, which was added to a
+	// virt-launcher pod. Used on Pod.
+	ResourceOverheadAnnotation string = "kubevirt.io/resource-overhead"
+	// This annotation overrides the CPU allocation ratio of the cluster for all
+	// VMIs in a namespace. Used on Namespace. On virt-launcher pods it holds the
+	// effective CPU allocation ratio. Used on Pod.
+	CPUAllocationRatioAnnotation string = "kubevirt.io/cpu-allocation-ratio"
+	// This annotation names a VirtualMachineInstancePreset of the namespace
+	// which is applied to all virtual machine instances that no preset
+	// selects, so that sizing defaults can be enforced per tenant. Used on
+	// Namespace.
+	DefaultPresetAnnotation string = "kubevirt.io/default-preset"
 	// This label declares whether a particular node is available for
 	// scheduling virtual machine instances on it. Used on Node.
 	NodeSchedulable string = "kubevirt.io/schedulable"
@@ -690,8 +979,30 @@ const (
 	// if a particular node is alive and hence should be available for new
 	// virtual machine instance scheduling. Used on Node.
 	VirtHandlerHeartbeat string = "kubevirt.io/heartbeat"
+	// This annotation puts virt-handler into maintenance mode when set to
+	// "true": the node is labeled unschedulable for new virtual machine
+	// instances, while the ones already running on it keep being managed.
+	// Used on Node.
+	NodeMaintenanceAnnotation string = "kubevirt.io/maintenance"
+	// This annotation is set by virt-handler in maintenance mode and lists
+	// the virtual machine instances which still block a full drain of the
+	// node as comma separated namespace/name pairs. Used on Node.
+	NodeMaintenanceBlockingVMIsAnnotation string = "kubevirt.io/maintenance-blocking-vmis"
+	// This annotation is set by virt-handler and lists the host device
+	// resources which are no longer permitted but still allocated to virtual
+	// machine instances on the node, as comma separated resource names. The
+	// resources are offered no longer and get removed once released. Used on Node.
+	NodePendingHostDeviceRemovalsAnnotation string = "kubevirt.io/pending-host-device-removals"
 	// This label indicates what launcher image a VMI is currently running with.
 	OutdatedLauncherImageLabel string = "kubevirt.io/outdatedLauncherImage"
+	// This label holds the spread group requested by the
+	// VirtualMachineSchedulingHints of a VMI. Launcher pods with the same
+	// value are preferably scheduled to different nodes. Used on Pod.
+	SchedulingSpreadGroupLabel string = "kubevirt.io/scheduling-spread-group"
+	// This annotation skips freezing the guest filesystems while a Velero
+	// backup is taken when set to "true", e.g. for guests without an agent,
+	// where freezing fails the backup. Used on VirtualMachineInstance.
+	SkipBackupFreezeAnnotation string = "kubevirt.io/skip-backup-freeze"
 	// Namespace recommended by Kubernetes for commonly recognized labels
 	AppLabelPrefix = "app.kubernetes.io"
 	// This label is commonly used by 3rd party management tools to identify
@@ -717,6 +1028,9 @@ const (
 	InstallStrategyRegistryAnnotation = "kubevirt.io/install-strategy-registry"
 	// This annotation represents the kubevirt deployment identifier used for an install strategy configmap.
 	InstallStrategyIdentifierAnnotation = "kubevirt.io/install-strategy-identifier"
+	// This annotation can be set on a KubeVirt certificate secret to force the rotation of its certificate.
+	// It is removed again by virt-operator once the certificate is rotated.
+	ForceCertificateRotationAnnotation = "kubevirt.io/force-certificate-rotation"
 	// This annotation shows the enconding used for the manifests in the Install Strategy ConfigMap.
 	InstallStrategyConfigMapEncoding = "kubevirt.io/install-strategy-cm-encoding"
 	// This annotation is a hash of all customizations that live under spec.CustomizeComponents
@@ -768,6 +1082,26 @@ const (
 
 	// This annotation is to keep virt launcher container alive when an VMI encounters a failure for debugging purpose
 	KeepLauncherAfterFailureAnnotation string = "kubevirt.io/keep-launcher-alive-after-failure"
+
+	// This annotation delays the first start of a virtual machine until all of its
+	// DataVolumes and PersistentVolumeClaims are populated, including claims which
+	// the Containerized Data Importer populates, when set to "true".
+	// Used on VirtualMachine.
+	FirstBootWaitForVolumesAnnotation string = "kubevirt.io/first-boot-wait-for-volumes"
+	// This annotation overrides the boot order of the first start of a virtual
+	// machine with a comma separated list of disk names, for example to boot an
+	// installer ISO once and the installed disk afterwards. Used on VirtualMachine.
+	FirstBootOrderAnnotation string = "kubevirt.io/first-boot-order"
+	// This annotation is set to "true" by the virtual machine controller once the
+	// first virtual machine instance of a virtual machine ran, the first boot
+	// annotations don't apply anymore afterwards. Used on VirtualMachine.
+	FirstBootCompletedAnnotation string = "kubevirt.io/first-boot-completed"
+	// This annotation is set by the restore controller to the PersistentVolumeClaim
+	// holding the memory state of the restored snapshot. The next virtual machine
+	// instance resumes from the memory state instead of booting, the annotation is
+	// removed from the virtual machine once it ran. Used on VirtualMachine and
+	// VirtualMachineInstance.
+	RestoreMemoryClaimAnnotation string = "kubevirt.io/restore-memory-claim"
 )
 
 func NewVMI(name string, uid types.UID) *VirtualMachineInstance {
@@ -928,6 +1262,38 @@ type VirtualMachineInstanceReplicaSetSpec struct {
 	// Indicates that the replica set is paused.
 	// +optional
 	Paused bool `json:"paused,omitempty" protobuf:"varint,7,opt,name=paused"`
+
+	// TopologySpread injects topology spread constraints into the created VirtualMachineInstances,
+	// to spread the replicas across the given topology domains.
+	// +optional
+	TopologySpread *VirtualMachineInstanceReplicaSetTopologySpread `json:"topologySpread,omitempty"`
+
+	// ServiceName is the name of the headless service governing the replicas.
+	// The replicas get the stable names <replicaset>-<ordinal> and are reachable by the
+	// DNS names <replicaset>-<ordinal>.<serviceName>.<namespace>.svc, the service must
+	// exist and select the replicas.
+	// +optional
+	ServiceName string `json:"serviceName,omitempty"`
+}
+
+// VirtualMachineInstanceReplicaSetTopologySpread describes how the replicas of a VirtualMachineInstanceReplicaSet
+// are spread across topology domains. Constraints for topology keys which are already part of the template are not injected.
+//
+// +k8s:openapi-gen=true
+type VirtualMachineInstanceReplicaSetTopologySpread struct {
+	// TopologyKeys are the node label keys the replicas are spread across.
+	// Defaults to topology.kubernetes.io/zone.
+	// +optional
+	// +listType=set
+	TopologyKeys []string `json:"topologyKeys,omitempty"`
+	// MaxSkew is the maximum permitted difference of replicas between two topology domains.
+	// Defaults to 1.
+	// +optional
+	MaxSkew *int32 `json:"maxSkew,omitempty"`
+	// WhenUnsatisfiable indicates how to deal with a replica if it doesn't satisfy the spread constraint.
+	// One of DoNotSchedule or ScheduleAnyway. Defaults to ScheduleAnyway.
+	// +optional
+	WhenUnsatisfiable k8sv1.UnsatisfiableConstraintAction `json:"whenUnsatisfiable,omitempty"`
 }
 
 //
@@ -1037,6 +1403,11 @@ type VirtualMachineInstanceMigrationList struct {
 type VirtualMachineInstanceMigrationSpec struct {
 	// The name of the VMI to perform the migration on. VMI must exist in the migration objects namespace
 	VMIName string `json:"vmiName,omitempty" valid:"required"`
+	// AbortOnProjectedTimeout aborts the migration as soon as its projected completion
+	// time, based on the observed transfer rate, exceeds the completion timeout
+	// instead of waiting for the timeout to expire. Ignored when post copy is allowed.
+	// +optional
+	AbortOnProjectedTimeout bool `json:"abortOnProjectedTimeout,omitempty"`
 }
 
 // VirtualMachineInstanceMigration reprents information pertaining to a VMI's migration.
@@ -1123,6 +1494,253 @@ func NewVirtualMachinePreset(name string, selector metav1.LabelSelector) *Virtua
 	}
 }
 
+// VirtualMachineSchedulingHints carries placement hints written by an external
+// placement engine for the VirtualMachineInstance with the same name and namespace.
+// The hints are folded into the affinity of the launcher pod when it is created,
+// already running pods are not affected.
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+// +genclient
+type VirtualMachineSchedulingHints struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// Spec contains the scheduling hints.
+	Spec VirtualMachineSchedulingHintsSpec `json:"spec" valid:"required"`
+}
+
+// VirtualMachineSchedulingHintsList is a list of VirtualMachineSchedulingHints
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+type VirtualMachineSchedulingHintsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachineSchedulingHints `json:"items"`
+}
+
+//
+// +k8s:openapi-gen=true
+type VirtualMachineSchedulingHintsSpec struct {
+	// PreferredNodes is a weighted list of nodes the VirtualMachineInstance
+	// should preferably be scheduled to.
+	// +optional
+	// +listType=atomic
+	PreferredNodes []PreferredNode `json:"preferredNodes,omitempty"`
+	// SpreadGroup places the launcher pod in a group whose members are
+	// preferably scheduled to different nodes.
+	// +optional
+	SpreadGroup string `json:"spreadGroup,omitempty"`
+}
+
+//
+// +k8s:openapi-gen=true
+type PreferredNode struct {
+	// NodeName is the name of the preferred node.
+	NodeName string `json:"nodeName"`
+	// Weight associated with the node, in the range 1-100.
+	Weight int32 `json:"weight"`
+}
+
+// VirtualMachineImageExport publishes the boot volume of a stopped
+// VirtualMachine as a containerDisk image to a registry.
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+// +genclient
+type VirtualMachineImageExport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              VirtualMachineImageExportSpec   `json:"spec" valid:"required"`
+	Status            VirtualMachineImageExportStatus `json:"status,omitempty"`
+}
+
+// VirtualMachineImageExportList is a list of VirtualMachineImageExports
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+type VirtualMachineImageExportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachineImageExport `json:"items"`
+}
+
+//
+// +k8s:openapi-gen=true
+type VirtualMachineImageExportSpec struct {
+	// The name of the VirtualMachine whose boot volume is exported. The VirtualMachine
+	// must exist in the namespace of the export and must be stopped.
+	Source string `json:"source" valid:"required"`
+	// Image is the reference by tag the containerDisk image is pushed to.
+	Image string `json:"image" valid:"required"`
+	// BaseImage is the image the layer with the disk is put on top of. Its layers
+	// are mounted from the base repository instead of being uploaded again, if the
+	// registry supports it. Defaults to an image with only the disk layer.
+	// +optional
+	BaseImage string `json:"baseImage,omitempty"`
+	// PushSecretName is the name of a kubernetes.io/dockerconfigjson Secret in the
+	// namespace of the export with the credentials for the registries.
+	// +optional
+	PushSecretName string `json:"pushSecretName,omitempty"`
+}
+
+//
+// +k8s:openapi-gen=true
+type VirtualMachineImageExportStatus struct {
+	// +optional
+	Phase VirtualMachineImageExportPhase `json:"phase,omitempty"`
+	// Digest is the digest of the manifest of the pushed image.
+	// +optional
+	Digest string `json:"digest,omitempty"`
+	// Message is a human readable reason of a failed export.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// VirtualMachineImageExportPhase is the current phase of a VirtualMachineImageExport.
+//
+// +k8s:openapi-gen=true
+type VirtualMachineImageExportPhase string
+
+// These are the valid image export phases
+const (
+	ImageExportPhaseUnset VirtualMachineImageExportPhase = ""
+	// The export waits for the VirtualMachine to be stopped
+	ImageExportPending VirtualMachineImageExportPhase = "Pending"
+	// The disk is being pushed to the registry
+	ImageExportInProgress VirtualMachineImageExportPhase = "InProgress"
+	// The image was pushed
+	ImageExportSucceeded VirtualMachineImageExportPhase = "Succeeded"
+	// The export failed
+	ImageExportFailed VirtualMachineImageExportPhase = "Failed"
+)
+
+// ValidationPolicy declares additional constraints on VirtualMachineInstances
+// which the validating webhook enforces after the built-in checks. Policies
+// are cluster scoped, a VirtualMachineInstance has to satisfy all of them.
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+// +genclient
+// +genclient:nonNamespaced
+type ValidationPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// Spec contains the constraints of the policy.
+	Spec ValidationPolicySpec `json:"spec" valid:"required"`
+}
+
+// ValidationPolicyList is a list of ValidationPolicies
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+type ValidationPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ValidationPolicy `json:"items"`
+}
+
+//
+// +k8s:openapi-gen=true
+type ValidationPolicySpec struct {
+	// MaxCPUs is the maximum number of vCPUs, counted as sockets*cores*threads,
+	// of a VirtualMachineInstance.
+	// +optional
+	MaxCPUs *uint32 `json:"maxCPUs,omitempty"`
+	// ForbiddenDiskBuses lists the buses which disks, LUNs and CD-ROMs must not use.
+	// +optional
+	// +listType=set
+	ForbiddenDiskBuses []string `json:"forbiddenDiskBuses,omitempty"`
+	// RequiredLabels lists the label keys every VirtualMachineInstance must have.
+	// +optional
+	// +listType=set
+	RequiredLabels []string `json:"requiredLabels,omitempty"`
+	// AllowedContainerDiskRegistries lists the registries, optionally followed by a
+	// repository path, containerDisk images may be pulled from. Any registry is
+	// allowed if empty.
+	// +optional
+	// +listType=set
+	AllowedContainerDiskRegistries []string `json:"allowedContainerDiskRegistries,omitempty"`
+}
+
+// MaintenanceWindow restricts automated actions which disrupt the selected
+// VirtualMachineInstances to recurring periods of time. VirtualMachineInstances
+// which are not selected by any window are not restricted.
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+// +genclient
+// +genclient:nonNamespaced
+type MaintenanceWindow struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// Spec selects the restricted VirtualMachineInstances and contains the periods
+	// in which the actions may run.
+	Spec MaintenanceWindowSpec `json:"spec" valid:"required"`
+}
+
+// MaintenanceWindowList is a list of MaintenanceWindows
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+type MaintenanceWindowList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MaintenanceWindow `json:"items"`
+}
+
+//
+// +k8s:openapi-gen=true
+type MaintenanceWindowSpec struct {
+	// Namespaces lists the namespaces of the selected VirtualMachineInstances.
+	// All namespaces are selected if empty.
+	// +optional
+	// +listType=set
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Selector selects the VirtualMachineInstances by their labels. All
+	// VirtualMachineInstances in the namespaces are selected if not set.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// Actions lists the automated actions which only run during the periods of
+	// the window. All actions are restricted if empty.
+	// +optional
+	// +listType=set
+	Actions []MaintenanceAction `json:"actions,omitempty"`
+	// Periods lists the recurring periods in which the actions may run.
+	// +listType=atomic
+	Periods []MaintenancePeriod `json:"periods"`
+}
+
+// MaintenancePeriod is a period of time which recurs on days of the week.
+// All times are in UTC.
+//
+// +k8s:openapi-gen=true
+type MaintenancePeriod struct {
+	// Days lists the days of the week, like Saturday, the period starts on.
+	// The period starts every day if empty.
+	// +optional
+	// +listType=set
+	Days []string `json:"days,omitempty"`
+	// Start is the time of day, formatted as HH:MM, the period starts at.
+	Start string `json:"start"`
+	// Duration is the length of the period, at most a week.
+	Duration metav1.Duration `json:"duration"`
+}
+
+// MaintenanceAction is an automated action which disrupts VirtualMachineInstances.
+//
+// +k8s:openapi-gen=true
+type MaintenanceAction string
+
+const (
+	// MaintenanceActionEvacuation migrates VirtualMachineInstances away from drained nodes
+	MaintenanceActionEvacuation MaintenanceAction = "Evacuation"
+	// MaintenanceActionWorkloadUpdate migrates or evicts VirtualMachineInstances with an outdated virt-launcher
+	MaintenanceActionWorkloadUpdate MaintenanceAction = "WorkloadUpdate"
+	// MaintenanceActionDescheduling evicts virt-launcher pods from nodes which are not drained, like the descheduler does
+	MaintenanceActionDescheduling MaintenanceAction = "Descheduling"
+)
+
 // VirtualMachine handles the VirtualMachines that are not running
 // or are in a stopped state
 // The VirtualMachine contains the template to create the
@@ -1189,6 +1807,9 @@ const (
 	// VMI will initially be running--and restarted if a failure occurs.
 	// It will not be restarted upon successful completion.
 	RunStrategyRerunOnFailure VirtualMachineRunStrategy = "RerunOnFailure"
+	// VMI will run once and not be restarted upon completion, regardless
+	// if it succeeded or failed.
+	RunStrategyOnce VirtualMachineRunStrategy = "Once"
 )
 
 // VirtualMachineSpec describes how the proper VirtualMachine
@@ -1204,6 +1825,17 @@ type VirtualMachineSpec struct {
 	// mutually exclusive with Running
 	RunStrategy *VirtualMachineRunStrategy `json:"runStrategy,omitempty" optional:"true"`
 
+	// TTLSecondsAfterFinished limits the lifetime of a VirtualMachine with runStrategy Once. The VirtualMachine,
+	// including the DataVolumes of its dataVolumeTemplates, is deleted the given number of seconds after
+	// its VirtualMachineInstance finished. It is kept if unset.
+	// +optional
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+
+	// ChangeApplyPolicy defines how changes of the template are applied to the running VirtualMachineInstance.
+	// Defaults to Manual, which only reports the pending changes in the status.
+	// +optional
+	ChangeApplyPolicy *VirtualMachineChangeApplyPolicy `json:"changeApplyPolicy,omitempty"`
+
 	// Template is the direct specification of VirtualMachineInstance
 	Template *VirtualMachineInstanceTemplateSpec `json:"template"`
 
@@ -1212,6 +1844,46 @@ type VirtualMachineSpec struct {
 	DataVolumeTemplates []DataVolumeTemplateSpec `json:"dataVolumeTemplates,omitempty"`
 }
 
+// VirtualMachineChangeApplyPolicy defines how changes of the template are applied to the running VirtualMachineInstance
+//
+// +k8s:openapi-gen=true
+type VirtualMachineChangeApplyPolicy string
+
+const (
+	// ChangeApplyPolicyManual only reports the pending changes, they are applied by a manual restart of the VM
+	ChangeApplyPolicyManual VirtualMachineChangeApplyPolicy = "Manual"
+	// ChangeApplyPolicyLive hotplugs the changed volumes and migrates the VMI to apply changed scheduling constraints,
+	// changes which require a restart are only reported
+	ChangeApplyPolicyLive VirtualMachineChangeApplyPolicy = "Live"
+	// ChangeApplyPolicyAutomatic applies the changes like ChangeApplyPolicyLive, and restarts the VM to apply
+	// the changes which require a restart
+	ChangeApplyPolicyAutomatic VirtualMachineChangeApplyPolicy = "Automatic"
+)
+
+// VirtualMachineChangeApplyMethod is how a change of the template can be applied to the running VirtualMachineInstance
+//
+// +k8s:openapi-gen=true
+type VirtualMachineChangeApplyMethod string
+
+const (
+	// ChangeApplyMethodHotplug changes are applied by hotplugging or unplugging a volume
+	ChangeApplyMethodHotplug VirtualMachineChangeApplyMethod = "Hotplug"
+	// ChangeApplyMethodMigration changes are applied by live migrating the VMI
+	ChangeApplyMethodMigration VirtualMachineChangeApplyMethod = "Migration"
+	// ChangeApplyMethodRestart changes are only applied by restarting the VM
+	ChangeApplyMethodRestart VirtualMachineChangeApplyMethod = "Restart"
+)
+
+// VirtualMachinePendingChange is a change of the template which isn't applied to the running VirtualMachineInstance yet
+//
+// +k8s:openapi-gen=true
+type VirtualMachinePendingChange struct {
+	// Path is the path of the changed field of the VirtualMachine
+	Path string `json:"path"`
+	// ApplyMethod is how the change can be applied to the running VirtualMachineInstance
+	ApplyMethod VirtualMachineChangeApplyMethod `json:"applyMethod"`
+}
+
 // StateChangeRequestType represents the existing state change requests that are possible
 //
 // +k8s:openapi-gen=true
@@ -1309,10 +1981,56 @@ type VirtualMachineStatus struct {
 	// +nullable
 	// +optional
 	StartFailure *VirtualMachineStartFailure `json:"startFailure,omitempty" optional:"true"`
+
+	// GuestFailures tracks recent failures of VMIs which were running, for the purposes
+	// of detecting guest crash loops
+	// +nullable
+	// +optional
+	GuestFailures *VirtualMachineGuestFailures `json:"guestFailures,omitempty" optional:"true"`
+
+	// PendingChanges are the changes of the template which aren't applied to the running VirtualMachineInstance yet
+	// +listType=atomic
+	// +optional
+	PendingChanges []VirtualMachinePendingChange `json:"pendingChanges,omitempty"`
+
+	// RunOnceStarted is set once the VirtualMachineInstance of a VirtualMachine with runStrategy Once
+	// was created. The VirtualMachineInstance is not started again, even if it gets deleted.
+	// +optional
+	RunOnceStarted bool `json:"runOnceStarted,omitempty"`
+
+	// PinnedContainerDiskImages are the images of the containerDisks with the Pin digest policy,
+	// resolved to their digest when the VirtualMachine was started. Later starts boot the same images
+	// until the image of the containerDisk in the template changes.
+	// +listType=atomic
+	// +optional
+	PinnedContainerDiskImages []PinnedContainerDiskImage `json:"pinnedContainerDiskImages,omitempty"`
 }
 
+// PinnedContainerDiskImage is the image of a containerDisk pinned to its digest
+//
 // +k8s:openapi-gen=true
-type VolumeSnapshotStatus struct {
+type PinnedContainerDiskImage struct {
+	// VolumeName is the name of the containerDisk volume
+	VolumeName string `json:"volumeName"`
+	// Image is the image of the containerDisk in the template
+	Image string `json:"image"`
+	// PinnedImage is the image referenced by the digest it was resolved to
+	PinnedImage string `json:"pinnedImage"`
+}
+
+// VirtualMachineGuestFailures tracks VMIs which failed after they were running
+//
+// +k8s:openapi-gen=true
+type VirtualMachineGuestFailures struct {
+	// FailureTimestamps are the times at which the VMIs failed, within the crash loop detection window
+	// +listType=atomic
+	FailureTimestamps []metav1.Time `json:"failureTimestamps,omitempty"`
+	// LastFailedVMIUID is the UID of the last VMI which was counted as failed
+	LastFailedVMIUID types.UID `json:"lastFailedVMIUID,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+type VolumeSnapshotStatus struct {
 	// Volume name
 	Name string `json:"name"`
 	// True if the volume supports snapshotting
@@ -1353,6 +2071,9 @@ type VirtualMachineCondition struct {
 	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
 	Reason             string      `json:"reason,omitempty"`
 	Message            string      `json:"message,omitempty"`
+	// ObservedGeneration is the metadata.generation of the object the condition was set for
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
 //
@@ -1371,6 +2092,16 @@ const (
 	// VirtualMachinePaused is added in a virtual machine when its vmi
 	// signals with its own condition that it is paused.
 	VirtualMachinePaused VirtualMachineConditionType = "Paused"
+
+	// VirtualMachineRestartRequired is added in a virtual machine when its template changed since
+	// its vmi was started. The changes only take effect once the virtual machine is restarted.
+	VirtualMachineRestartRequired VirtualMachineConditionType = "RestartRequired"
+	// Reason means that the template of the virtual machine changed since the running vmi was started
+	VirtualMachineReasonTemplateChanged = "TemplateChanged"
+
+	// VirtualMachineDriftDetected is copied to the virtual machine from its vmi, it lists the fields whose
+	// configuration in the running domain differs from the vmi until the virtual machine is restarted.
+	VirtualMachineDriftDetected VirtualMachineConditionType = "DriftDetected"
 )
 
 //
@@ -1423,6 +2154,28 @@ const (
 	IODefault DriverIO = "default"
 )
 
+//
+// +k8s:openapi-gen=true
+type DriverDiscard string
+
+//
+// +k8s:openapi-gen=true
+type DriverDetectZeroes string
+
+const (
+	// DiscardUnmap - discard requests from the guest are passed through to the storage, releasing the space.
+	DiscardUnmap DriverDiscard = "unmap"
+	// DiscardIgnore - discard requests from the guest are ignored.
+	DiscardIgnore DriverDiscard = "ignore"
+
+	// DetectZeroesOff - writes of zeroes are passed through as regular writes.
+	DetectZeroesOff DriverDetectZeroes = "off"
+	// DetectZeroesOn - writes of zeroes are converted to efficient zero writes.
+	DetectZeroesOn DriverDetectZeroes = "on"
+	// DetectZeroesUnmap - writes of zeroes are converted to discards. Requires discard to be unmap.
+	DetectZeroesUnmap DriverDetectZeroes = "unmap"
+)
+
 // Handler defines a specific action that should be taken
 // TODO: pass structured data to these actions, and document that data here.
 type Handler struct {
@@ -1586,6 +2339,40 @@ type KubeVirtWorkloadUpdateStrategy struct {
 	BatchEvictionInterval *metav1.Duration `json:"batchEvictionInterval,omitempty"`
 }
 
+//
+// KubeVirtHandlerUpdateStrategy defines how virt-handler is rolled out when KubeVirt is updated
+//
+// +k8s:openapi-gen=true
+type KubeVirtHandlerUpdateStrategy struct {
+	// Canary rolls out virt-handler to a set of canary nodes first. The rollout to the
+	// remaining nodes only proceeds once virt-handler is healthy on all canary nodes.
+	// If the canaries do not become healthy in time, the rollout is paused. A paused
+	// rollout can be resumed by removing the canary strategy.
+	//
+	// +optional
+	Canary *KubeVirtHandlerCanaryStrategy `json:"canary,omitempty"`
+}
+
+//
+// KubeVirtHandlerCanaryStrategy defines the canary nodes and the health validation of a virt-handler canary rollout
+//
+// +k8s:openapi-gen=true
+type KubeVirtHandlerCanaryStrategy struct {
+	// NodeSelector selects the canary nodes among the nodes running virt-handler.
+	// If empty, a single node is used as canary.
+	//
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// ValidationTimeout is the time the updated virt-handler pods on the canary nodes
+	// have to become healthy before the rollout is paused.
+	//
+	// Defaults to 10 minutes
+	//
+	// +optional
+	ValidationTimeout *metav1.Duration `json:"validationTimeout,omitempty"`
+}
+
 //
 // +k8s:openapi-gen=true
 type KubeVirtSpec struct {
@@ -1611,6 +2398,10 @@ type KubeVirtSpec struct {
 	// automated workload updates
 	WorkloadUpdateStrategy KubeVirtWorkloadUpdateStrategy `json:"workloadUpdateStrategy,omitempty"`
 
+	// HandlerUpdateStrategy defines how virt-handler is rolled out on updates
+	// +optional
+	HandlerUpdateStrategy *KubeVirtHandlerUpdateStrategy `json:"handlerUpdateStrategy,omitempty"`
+
 	// Specifies if kubevirt can be deleted if workloads are still present.
 	// This is mainly a precaution to avoid accidental data loss
 	UninstallStrategy KubeVirtUninstallStrategy `json:"uninstallStrategy,omitempty"`
@@ -1639,9 +2430,23 @@ type KubeVirtSpec struct {
 	// +optional
 	Workloads *ComponentConfig `json:"workloads,omitempty"`
 
+	// CommonTemplates deploys curated VirtualMachineInstancePresets and example VirtualMachines
+	// for common operating systems to a namespace. They are versioned with KubeVirt and removed
+	// once the common templates are removed again.
+	// +optional
+	CommonTemplates *KubeVirtCommonTemplates `json:"commonTemplates,omitempty"`
+
 	CustomizeComponents CustomizeComponents `json:"customizeComponents,omitempty"`
 }
 
+// KubeVirtCommonTemplates defines where the common templates are deployed
+//
+// +k8s:openapi-gen=true
+type KubeVirtCommonTemplates struct {
+	// Namespace the common templates are deployed to, it has to exist
+	Namespace string `json:"namespace"`
+}
+
 // +k8s:openapi-gen=true
 type CustomizeComponents struct {
 	// +listType=atomic
@@ -1726,8 +2531,50 @@ type KubeVirtStatus struct {
 	OutdatedVirtualMachineInstanceWorkloads *int                `json:"outdatedVirtualMachineInstanceWorkloads,omitempty" optional:"true"`
 	// +listType=atomic
 	Generations []GenerationStatus `json:"generations,omitempty" optional:"true"`
+	// UnappliedConfiguration lists the configuration settings which only take effect after
+	// a restart of the KubeVirt components, and which are not rolled out yet
+	// +listType=set
+	UnappliedConfiguration []string `json:"unappliedConfiguration,omitempty" optional:"true"`
+	// HandlerRollout reports the progress of a virt-handler canary rollout
+	HandlerRollout *KubeVirtHandlerRolloutStatus `json:"handlerRollout,omitempty" optional:"true"`
+	// CommonTemplatesNamespace is the namespace the common templates are deployed to
+	CommonTemplatesNamespace string `json:"commonTemplatesNamespace,omitempty" optional:"true"`
+}
+
+// KubeVirtHandlerRolloutStatus represents the state of a virt-handler canary rollout
+//
+// +k8s:openapi-gen=true
+type KubeVirtHandlerRolloutStatus struct {
+	Phase KubeVirtHandlerRolloutPhase `json:"phase,omitempty"`
+	// TargetDeploymentID is the deployment the rollout belongs to
+	TargetDeploymentID string `json:"targetDeploymentID,omitempty"`
+	// CanaryNodes are the nodes virt-handler is rolled out to first
+	// +listType=set
+	CanaryNodes []string `json:"canaryNodes,omitempty"`
+	// StartTime is the time the canary rollout started
+	// +optional
+	// +nullable
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	Message   string       `json:"message,omitempty"`
 }
 
+// KubeVirtHandlerRolloutPhase is a label for the phase of a virt-handler canary rollout
+//
+// +k8s:openapi-gen=true
+type KubeVirtHandlerRolloutPhase string
+
+// These are the valid virt-handler canary rollout phases
+const (
+	// virt-handler is rolled out to the canary nodes and validated
+	HandlerRolloutPhaseCanary KubeVirtHandlerRolloutPhase = "Canary"
+	// the canaries are healthy and virt-handler is rolled out to all nodes
+	HandlerRolloutPhaseProceeding KubeVirtHandlerRolloutPhase = "Proceeding"
+	// the canaries did not become healthy in time and the rollout is paused
+	HandlerRolloutPhasePaused KubeVirtHandlerRolloutPhase = "Paused"
+	// virt-handler is rolled out to all nodes, the deployment is not rolled out with canaries again
+	HandlerRolloutPhaseCompleted KubeVirtHandlerRolloutPhase = "Completed"
+)
+
 // KubeVirtPhase is a label for the phase of a KubeVirt deployment at the current time.
 //
 // +k8s:openapi-gen=true
@@ -1827,6 +2674,192 @@ type StopOptions struct {
 	GracePeriod *int64 `json:"gracePeriod,omitempty" protobuf:"varint,1,opt,name=gracePeriod"`
 }
 
+// BulkOperationAction is the action a bulk operation applies to every matching VirtualMachine
+type BulkOperationAction string
+
+const (
+	// BulkStartAction starts all matching VirtualMachines
+	BulkStartAction BulkOperationAction = "start"
+	// BulkStopAction stops all matching VirtualMachines
+	BulkStopAction BulkOperationAction = "stop"
+	// BulkMigrateAction migrates all matching VirtualMachines, e.g. to evacuate them
+	BulkMigrateAction BulkOperationAction = "migrate"
+)
+
+const (
+	// BulkOperationLabel is put on the migrations created by a bulk operation to track their progress
+	BulkOperationLabel string = "kubevirt.io/bulk-operation"
+	// DefaultBulkOperationParallelism is the number of VirtualMachines processed at once if no parallelism was requested
+	DefaultBulkOperationParallelism int32 = 10
+	// MaxBulkOperationParallelism is the highest number of VirtualMachines processed at once, higher requests are clamped
+	MaxBulkOperationParallelism int32 = 50
+)
+
+// BulkOperationOptions are provided to start, stop or migrate all VirtualMachines of a namespace
+// matching a label selector in one call.
+//
+// +k8s:openapi-gen=true
+type BulkOperationOptions struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Action to apply to every matching VirtualMachine, one of start, stop or migrate
+	Action BulkOperationAction `json:"action"`
+	// Selector is the label selector matching the VirtualMachines, e.g. "app=database,tier!=frontend"
+	Selector string `json:"selector"`
+	// Parallelism is the number of VirtualMachines processed at the same time.
+	// Defaults to 10, at most 50.
+	// +optional
+	Parallelism *int32 `json:"parallelism,omitempty"`
+}
+
+// BulkOperation reports the VirtualMachines a bulk operation applies to and how far it progressed
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+type BulkOperation struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Handle identifies the operation when querying its progress, it is the name of
+	// the VirtualMachineBulkOperation recording the operation
+	Handle string `json:"handle"`
+	// Action applied to every matching VirtualMachine
+	Action BulkOperationAction `json:"action"`
+	// Selector matching the VirtualMachines
+	Selector string `json:"selector"`
+	// Total is the number of VirtualMachines matching the selector
+	Total int32 `json:"total"`
+	// Completed is the number of VirtualMachines which reached the state requested by the action
+	Completed int32 `json:"completed"`
+	// Failures lists the VirtualMachines the action could not be applied to
+	// +optional
+	// +listType=atomic
+	Failures []BulkOperationFailure `json:"failures,omitempty"`
+}
+
+// BulkOperationFailure describes why a bulk operation failed for a single VirtualMachine
+//
+// +k8s:openapi-gen=true
+type BulkOperationFailure struct {
+	// Name of the VirtualMachine
+	Name string `json:"name"`
+	// Message describing the failure
+	Message string `json:"message"`
+}
+
+// VirtualMachineBulkOperation records a bulk operation requested through the
+// bulkoperations subresource. virt-controller applies the action to the
+// VirtualMachines and records the outcome in the status.
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+// +genclient
+type VirtualMachineBulkOperation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              VirtualMachineBulkOperationSpec   `json:"spec" valid:"required"`
+	Status            VirtualMachineBulkOperationStatus `json:"status,omitempty"`
+}
+
+// VirtualMachineBulkOperationList is a list of VirtualMachineBulkOperations
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+type VirtualMachineBulkOperationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachineBulkOperation `json:"items"`
+}
+
+//
+// +k8s:openapi-gen=true
+type VirtualMachineBulkOperationSpec struct {
+	// Action to apply to every VirtualMachine, one of start, stop or migrate
+	Action BulkOperationAction `json:"action"`
+	// Selector is the label selector which matched the VirtualMachines
+	Selector string `json:"selector"`
+	// Parallelism is the number of VirtualMachines processed at the same time
+	Parallelism int32 `json:"parallelism"`
+	// VirtualMachines are the names of the VirtualMachines matching the selector
+	// when the operation was requested
+	// +listType=atomic
+	VirtualMachines []string `json:"virtualMachines"`
+	// User who requested the operation. The action is only applied to the
+	// VirtualMachines the user is allowed to apply it to.
+	User string `json:"user"`
+	// Groups of the user who requested the operation
+	// +optional
+	// +listType=atomic
+	Groups []string `json:"groups,omitempty"`
+	// Extra information about the user who requested the operation
+	// +optional
+	Extra map[string][]string `json:"extra,omitempty"`
+}
+
+//
+// +k8s:openapi-gen=true
+type VirtualMachineBulkOperationStatus struct {
+	// +optional
+	Phase VirtualMachineBulkOperationPhase `json:"phase,omitempty"`
+	// Applied lists the VirtualMachines the action was applied to
+	// +optional
+	// +listType=atomic
+	Applied []string `json:"applied,omitempty"`
+	// Failures lists the VirtualMachines the action could not be applied to
+	// +optional
+	// +listType=atomic
+	Failures []BulkOperationFailure `json:"failures,omitempty"`
+	// CompletionTimestamp is the time the action was applied to all VirtualMachines.
+	// The operation is deleted a while after it completed.
+	// +optional
+	// +nullable
+	CompletionTimestamp *metav1.Time `json:"completionTimestamp,omitempty"`
+}
+
+// VirtualMachineBulkOperationPhase is the current phase of a VirtualMachineBulkOperation.
+//
+// +k8s:openapi-gen=true
+type VirtualMachineBulkOperationPhase string
+
+// These are the valid bulk operation phases
+const (
+	BulkOperationPhaseUnset VirtualMachineBulkOperationPhase = ""
+	// The action is being applied to the VirtualMachines
+	BulkOperationRunning VirtualMachineBulkOperationPhase = "Running"
+	// The action was applied to all VirtualMachines
+	BulkOperationSucceeded VirtualMachineBulkOperationPhase = "Succeeded"
+)
+
+// SchedulingFeasibilityOptions are provided to check if a VirtualMachine could currently be scheduled
+// before it gets created.
+//
+// +k8s:openapi-gen=true
+type SchedulingFeasibilityOptions struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Spec of the VirtualMachine to check
+	Spec VirtualMachineSpec `json:"spec"`
+}
+
+// SchedulingFeasibility reports if a VirtualMachine could currently be scheduled and which of
+// its constraints the nodes fail to satisfy, without revealing the nodes themselves
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+type SchedulingFeasibility struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Feasible is true if at least one node can currently run the VirtualMachine
+	Feasible bool `json:"feasible"`
+	// FailedConstraints lists the constraints no node can satisfy, e.g. a missing volume
+	// +optional
+	// +listType=atomic
+	FailedConstraints []string `json:"failedConstraints,omitempty"`
+	// NodeFailures lists the constraints which are not satisfied by some of the nodes
+	// +optional
+	// +listType=atomic
+	NodeFailures []string `json:"nodeFailures,omitempty"`
+}
+
 // VirtualMachineInstanceGuestAgentInfo represents information from the installed guest agent
 //
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -1905,6 +2938,20 @@ type VirtualMachineInstanceFileSystem struct {
 	TotalBytes     int    `json:"totalBytes"`
 }
 
+// VirtualMachineInstanceUsage represents the current CPU and memory usage of a VirtualMachineInstance
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+type VirtualMachineInstanceUsage struct {
+	metav1.TypeMeta `json:",inline"`
+	// Timestamp is the time at which the usage was sampled
+	Timestamp metav1.Time `json:"timestamp"`
+	// Window is the interval over which the CPU usage was calculated
+	Window metav1.Duration `json:"window"`
+	// Usage contains the CPU usage of the guest in cores and its memory usage in bytes
+	Usage k8sv1.ResourceList `json:"usage"`
+}
+
 // AddVolumeOptions is provided when dynamically hot plugging a volume and disk
 // +k8s:openapi-gen=true
 type AddVolumeOptions struct {
@@ -1926,6 +2973,85 @@ type RemoveVolumeOptions struct {
 	Name string `json:"name"`
 }
 
+// MemorySnapshotOptions may be provided when snapshotting the memory of a VMI.
+// The memory state is saved in the background, the request returns the status of the save.
+// +k8s:openapi-gen=true
+type MemorySnapshotOptions struct {
+	// ObjectStorage streams the memory state to an S3 compatible object
+	// instead of saving it to the memory dump volume of the VMI.
+	// +optional
+	ObjectStorage *MemorySnapshotObjectStorage `json:"objectStorage,omitempty"`
+}
+
+// MemorySnapshotObjectStorage is the S3 compatible object the memory state is uploaded to
+// +k8s:openapi-gen=true
+type MemorySnapshotObjectStorage struct {
+	// Endpoint is the URL of the S3 compatible service
+	Endpoint string `json:"endpoint"`
+	// Region of the bucket, defaults to us-east-1
+	// +optional
+	Region string `json:"region,omitempty"`
+	// Bucket the object is uploaded to
+	Bucket string `json:"bucket"`
+	// Key of the object
+	Key string `json:"key"`
+	// AccessKeyID of the credentials used to sign the upload requests.
+	// Only needed to start the upload.
+	// +optional
+	AccessKeyID string `json:"accessKeyId,omitempty"`
+	// SecretAccessKey of the credentials used to sign the upload requests.
+	// Only needed to start the upload.
+	// +optional
+	SecretAccessKey string `json:"secretAccessKey,omitempty"`
+}
+
+// MemorySnapshotUploadStatus is the status of saving the memory state to the memory dump volume or object storage
+// +k8s:openapi-gen=true
+type MemorySnapshotUploadStatus struct {
+	Phase MemorySnapshotUploadPhase `json:"phase"`
+	// MemorySaved is set once libvirt finished saving the memory state,
+	// the guest no longer needs to be frozen while the rest is uploaded.
+	// +optional
+	MemorySaved bool `json:"memorySaved,omitempty"`
+	// Checksum is the SHA-256 of the concatenated SHA-256 digests of the
+	// uploaded parts, hex encoded and followed by the number of parts.
+	// Set once the upload succeeded.
+	// +optional
+	Checksum string `json:"checksum,omitempty"`
+	// Message explains why the upload failed
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// MemorySnapshotUploadPhase is the phase of an upload of the memory state to object storage
+type MemorySnapshotUploadPhase string
+
+const (
+	// MemorySnapshotUploadPending means the upload was not started because no credentials were passed
+	MemorySnapshotUploadPending    MemorySnapshotUploadPhase = "Pending"
+	MemorySnapshotUploadInProgress MemorySnapshotUploadPhase = "InProgress"
+	MemorySnapshotUploadSucceeded  MemorySnapshotUploadPhase = "Succeeded"
+	MemorySnapshotUploadFailed     MemorySnapshotUploadPhase = "Failed"
+)
+
+// SetLinkStateOptions is provided when setting the link state of an interface of a running VMI
+// +k8s:openapi-gen=true
+type SetLinkStateOptions struct {
+	// InterfaceName is the name of the interface in the VMI spec
+	InterfaceName string `json:"interfaceName"`
+	// State is the link state the guest sees on the interface, either up or down.
+	// It is not persisted, the link is up again once the VMI is restarted.
+	State InterfaceLinkState `json:"state"`
+}
+
+// InterfaceLinkState is the administrative link state of an interface
+type InterfaceLinkState string
+
+const (
+	InterfaceLinkStateUp   InterfaceLinkState = "up"
+	InterfaceLinkStateDown InterfaceLinkState = "down"
+)
+
 // +k8s:openapi-gen=true
 type TokenBucketRateLimiter struct {
 	// QPS indicates the maximum QPS to the apiserver from this client.
@@ -1984,6 +3110,192 @@ type KubeVirtConfiguration struct {
 	WebhookConfiguration           *ReloadableComponentConfiguration `json:"webhookConfiguration,omitempty"`
 	ControllerConfiguration        *ReloadableComponentConfiguration `json:"controllerConfiguration,omitempty"`
 	HandlerConfiguration           *ReloadableComponentConfiguration `json:"handlerConfiguration,omitempty"`
+
+	// ImagePullSecrets are added to all virt-launcher pods, to pull the virt-launcher,
+	// containerDisk and kernel boot images. The secrets must exist in the namespace of the VMI.
+	// +listType=atomic
+	// +optional
+	ImagePullSecrets []k8sv1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// ImageRegistryMirrors redirect the virt-launcher, containerDisk, kernel boot and hook sidecar images to mirror registries.
+	// The first mirror whose source matches an image is used.
+	// +listType=atomic
+	// +optional
+	ImageRegistryMirrors []ImageRegistryMirror `json:"imageRegistryMirrors,omitempty"`
+
+	// AdditionalGuestMemoryOverheadRatio is multiplied with the computed memory overhead of
+	// virt-launcher pods, to add a safety margin. It must be a decimal number of at least 1.0.
+	// Defaults to 1.0.
+	// +optional
+	AdditionalGuestMemoryOverheadRatio *string `json:"additionalGuestMemoryOverheadRatio,omitempty"`
+
+	// LauncherPodMetadataPropagation restricts which labels and annotations of VMIs, and of their
+	// launcher pod metadata, are propagated to virt-launcher pods.
+	// If unset, all of them are propagated.
+	// +optional
+	LauncherPodMetadataPropagation *LauncherPodMetadataPropagation `json:"launcherPodMetadataPropagation,omitempty"`
+
+	// NodeShutdownGracePeriodSeconds is how long virt-handler delays the shutdown or reboot of a node,
+	// to live migrate its VMIs or to shut them down gracefully. virt-handler takes a systemd-logind
+	// inhibitor lock for that, the delay is capped by InhibitDelayMaxSec of systemd-logind.
+	// The shutdown of the node is not delayed if unset or 0.
+	// +optional
+	NodeShutdownGracePeriodSeconds *int64 `json:"nodeShutdownGracePeriodSeconds,omitempty"`
+
+	// AuxiliaryThreadsCPURequests adds CPU requests for the threads of VMIs which don't run vCPUs
+	// to their virt-launcher pods, so that these threads don't take CPU time from the vCPUs.
+	// Nothing is added if unset. VMIs with dedicated CPUs are not affected.
+	// +optional
+	AuxiliaryThreadsCPURequests *AuxiliaryThreadsCPURequests `json:"auxiliaryThreadsCPURequests,omitempty"`
+
+	// ContainerDiskPolicy restricts the images containerDisks may use. VMIs and VMs whose
+	// containerDisks violate the policy are rejected on creation. Nothing is restricted if unset.
+	// +optional
+	ContainerDiskPolicy *ContainerDiskPolicy `json:"containerDiskPolicy,omitempty"`
+
+	// AccountingConfiguration attaches labels of VMIs to their metrics and reports the resource
+	// usage of VMIs periodically, to charge tenants back for it.
+	// +optional
+	AccountingConfiguration *AccountingConfiguration `json:"accountingConfiguration,omitempty"`
+
+	// AdmissionLimits are the size limits VMIs and VMs are validated against on creation. They can be
+	// raised to allow for example large inline cloud-init user data.
+	// +optional
+	AdmissionLimits *AdmissionLimits `json:"admissionLimits,omitempty"`
+
+	// QEMUOptionsAllowlist holds the QEMU -machine and -global options VMIs may set. VMIs setting
+	// other options are rejected. No options can be set if unset.
+	// +optional
+	QEMUOptionsAllowlist *QEMUOptionsAllowlist `json:"qemuOptionsAllowlist,omitempty"`
+}
+
+// QEMUOptionsAllowlist holds the names of the QEMU options VMIs may set.
+//
+// +k8s:openapi-gen=true
+type QEMUOptionsAllowlist struct {
+	// MachineOptions are the names of the -machine options VMIs may set.
+	// +listType=atomic
+	// +optional
+	MachineOptions []string `json:"machineOptions,omitempty"`
+	// Globals are the driver.property names of the -global options VMIs may set.
+	// +listType=atomic
+	// +optional
+	Globals []string `json:"globals,omitempty"`
+}
+
+// AdmissionLimits holds the size limits of VMI specs.
+//
+// +k8s:openapi-gen=true
+type AdmissionLimits struct {
+	// CloudInitUserDataMaxBytes is the largest inline cloud-init user data in bytes, larger user data has
+	// to be passed with a UserDataSecretRef. Defaults to 2048.
+	// +optional
+	CloudInitUserDataMaxBytes *int `json:"cloudInitUserDataMaxBytes,omitempty"`
+	// CloudInitNetworkDataMaxBytes is the largest inline cloud-init network data in bytes, larger network data
+	// has to be passed with a NetworkDataSecretRef. Defaults to 2048.
+	// +optional
+	CloudInitNetworkDataMaxBytes *int `json:"cloudInitNetworkDataMaxBytes,omitempty"`
+	// MaxListLength is the largest number of disks, volumes, interfaces, networks and access credentials
+	// a VMI can have. Defaults to 256.
+	// +optional
+	MaxListLength *int `json:"maxListLength,omitempty"`
+}
+
+// AccountingConfiguration holds the options to account the resource usage of VMIs.
+//
+// +k8s:openapi-gen=true
+type AccountingConfiguration struct {
+	// Labels are the label keys of VMIs which are attached to all per-VMI metrics, as metric labels
+	// named accounting_ followed by the sanitized key, and to the usage reports.
+	// VMIs without one of the labels have an empty value for it.
+	// +listType=atomic
+	// +optional
+	Labels []string `json:"labels,omitempty"`
+	// UsageReport enables periodic reports of the vCPU, memory and storage usage of all VMIs.
+	// +optional
+	UsageReport *UsageReportConfiguration `json:"usageReport,omitempty"`
+}
+
+// UsageReportConfiguration configures where and how often the resource usage of VMIs is reported.
+//
+// +k8s:openapi-gen=true
+type UsageReportConfiguration struct {
+	// WebhookURL is the URL the usage reports are posted to as JSON.
+	WebhookURL string `json:"webhookURL"`
+	// Interval is the period each usage report covers. Defaults to 1h.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// ContainerDiskPolicy restricts the provenance of containerDisk images.
+//
+// +k8s:openapi-gen=true
+type ContainerDiskPolicy struct {
+	// AllowedRegistries are the registries, optionally followed by a repository path, which
+	// containerDisk images have to come from, for example "quay.io" or "quay.io/kubevirt".
+	// Images of Docker Hub are matched as "docker.io". All registries are allowed if empty.
+	// +listType=atomic
+	// +optional
+	AllowedRegistries []string `json:"allowedRegistries,omitempty"`
+	// SignatureVerification requires containerDisk images to carry a cosign signature of one of
+	// the given public keys. The images have to be referenced by digest, or be pinned to their
+	// digest with the Pin imageDigestPolicy.
+	// +optional
+	SignatureVerification *ContainerDiskSignatureVerification `json:"signatureVerification,omitempty"`
+}
+
+// ContainerDiskSignatureVerification holds the public keys which containerDisk image signatures
+// are verified with.
+//
+// +k8s:openapi-gen=true
+type ContainerDiskSignatureVerification struct {
+	// PublicKeysConfigMap is the name of a ConfigMap in the namespace of KubeVirt, whose entries
+	// hold PEM encoded ECDSA or RSA public keys.
+	PublicKeysConfigMap string `json:"publicKeysConfigMap"`
+}
+
+// LauncherPodMetadataPropagation holds the allowlists of label and annotation keys which are
+// propagated to virt-launcher pods. An entry is either an exact key, or a key prefix followed by "*".
+//
+// +k8s:openapi-gen=true
+type LauncherPodMetadataPropagation struct {
+	// AllowedLabels are the label keys which are propagated to virt-launcher pods.
+	// If empty, no labels are propagated.
+	// +listType=atomic
+	// +optional
+	AllowedLabels []string `json:"allowedLabels,omitempty"`
+	// AllowedAnnotations are the annotation keys which are propagated to virt-launcher pods.
+	// If empty, no annotations are propagated.
+	// +listType=atomic
+	// +optional
+	AllowedAnnotations []string `json:"allowedAnnotations,omitempty"`
+}
+
+// AuxiliaryThreadsCPURequests holds the CPU which is requested for each of the threads of a VMI
+// which don't run vCPUs.
+//
+// +k8s:openapi-gen=true
+type AuxiliaryThreadsCPURequests struct {
+	// Emulator is requested once per VMI, for the emulator thread of QEMU.
+	// +optional
+	Emulator *resource.Quantity `json:"emulator,omitempty"`
+	// IOThread is requested for each IO thread of a VMI.
+	// +optional
+	IOThread *resource.Quantity `json:"ioThread,omitempty"`
+	// Virtiofs is requested for each filesystem of a VMI, for its virtiofsd process.
+	// +optional
+	Virtiofs *resource.Quantity `json:"virtiofs,omitempty"`
+}
+
+// ImageRegistryMirror redirects images of a registry or repository to a mirror
+//
+// +k8s:openapi-gen=true
+type ImageRegistryMirror struct {
+	// Source is the registry, optionally followed by a repository path, of the images to redirect,
+	// for example "quay.io" or "quay.io/kubevirt"
+	Source string `json:"source"`
+	// Mirror replaces the source in the image references, for example "registry.local:5000/kubevirt"
+	Mirror string `json:"mirror"`
 }
 
 //
@@ -2009,8 +3321,31 @@ type MigrationConfiguration struct {
 	UnsafeMigrationOverride           *bool              `json:"unsafeMigrationOverride,omitempty"`
 	AllowPostCopy                     *bool              `json:"allowPostCopy,omitempty"`
 	DisableTLS                        *bool              `json:"disableTLS,omitempty"`
+	// HostPassthroughPolicy decides whether and where VMIs with the host-passthrough CPU mode are migrated.
+	// One of Allow, CompatibleNodes or Deny. Defaults to Allow.
+	HostPassthroughPolicy *HostPassthroughMigrationPolicy `json:"hostPassthroughPolicy,omitempty"`
+	// PreSwitchoverDrainSeconds drains the connections to a VMI before the switchover of its migration if positive.
+	// Once the migration target pod runs it joins the endpoints of Services, and the virt-launcher pod of the source
+	// is reported as not ready, which removes it from them. The VMI is only handed off to the target after the
+	// connections drained for PreSwitchoverDrainSeconds, the source pod is reported as ready again if the migration fails.
+	// Only applies to VMIs started while it is set. Defaults to 0, which disables draining.
+	PreSwitchoverDrainSeconds *int64 `json:"preSwitchoverDrainSeconds,omitempty"`
 }
 
+// HostPassthroughMigrationPolicy decides how VMIs with the host-passthrough CPU mode are migrated
+type HostPassthroughMigrationPolicy string
+
+const (
+	// HostPassthroughMigrationAllow migrates VMIs with the host-passthrough CPU mode to any node,
+	// the migration fails if the target node lacks the host CPU model or CPU features of the source node
+	HostPassthroughMigrationAllow HostPassthroughMigrationPolicy = "Allow"
+	// HostPassthroughMigrationCompatibleNodes migrates VMIs with the host-passthrough CPU mode only to nodes
+	// with the same host CPU model and at least the CPU features of the source node
+	HostPassthroughMigrationCompatibleNodes HostPassthroughMigrationPolicy = "CompatibleNodes"
+	// HostPassthroughMigrationDeny does not migrate VMIs with the host-passthrough CPU mode
+	HostPassthroughMigrationDeny HostPassthroughMigrationPolicy = "Deny"
+)
+
 // DiskVerification holds container disks verification limits
 // +k8s:openapi-gen=true
 type DiskVerification struct {
@@ -2034,6 +3369,19 @@ type DeveloperConfiguration struct {
 	MinimumClusterTSCFrequency *int64            `json:"minimumClusterTSCFrequency,omitempty"`
 	DiskVerification           *DiskVerification `json:"diskVerification,omitempty"`
 	LogVerbosity               *LogVerbosity     `json:"logVerbosity,omitempty"`
+	// NamespacedFeatureGates enables feature gates only for the VMIs and VMs of the listed namespaces.
+	// +listType=atomic
+	NamespacedFeatureGates []NamespacedFeatureGate `json:"namespacedFeatureGates,omitempty"`
+}
+
+// NamespacedFeatureGate enables a feature gate for a set of namespaces
+// +k8s:openapi-gen=true
+type NamespacedFeatureGate struct {
+	// Name of the feature gate
+	Name string `json:"name"`
+	// Namespaces in which VMIs and VMs are allowed to use the feature
+	// +listType=atomic
+	Namespaces []string `json:"namespaces"`
 }
 
 // LogVerbosity sets log verbosity level of  various components
@@ -2094,6 +3442,13 @@ type NetworkConfiguration struct {
 	NetworkInterface                  string `json:"defaultNetworkInterface,omitempty"`
 	PermitSlirpInterface              *bool  `json:"permitSlirpInterface,omitempty"`
 	PermitBridgeInterfaceOnPodNetwork *bool  `json:"permitBridgeInterfaceOnPodNetwork,omitempty"`
+	// NodeBandwidth is the network bandwidth of every node, in bits per second, which VMIs can reserve
+	// with the bandwidth of their interfaces. Nodes without a bandwidth don't run VMIs reserving any.
+	// +optional
+	NodeBandwidth *resource.Quantity `json:"nodeBandwidth,omitempty"`
+	// NodeBandwidthOverrides overrides the NodeBandwidth of individual nodes, keyed by the node name.
+	// +optional
+	NodeBandwidthOverrides map[string]resource.Quantity `json:"nodeBandwidthOverrides,omitempty"`
 }
 
 // GuestAgentPing configures the guest-agent based ping probe
@@ -24,9 +24,14 @@ func (VirtualMachineInstanceSpec) SwaggerDoc() map[string]string {
 		"nodeSelector":                  "NodeSelector is a selector which must be true for the vmi to fit on a node.\nSelector which must match a node's labels for the vmi to be scheduled on that node.\nMore info: https://kubernetes.io/docs/concepts/configuration/assign-pod-node/\n+optional",
 		"affinity":                      "If affinity is specifies, obey all the affinity rules",
 		"schedulerName":                 "If specified, the VMI will be dispatched by specified scheduler.\nIf not specified, the VMI will be dispatched by default scheduler.\n+optional",
+		"runtimeClassName":              "If specified, the virt-launcher pod will be run with the referenced RuntimeClass.\nIf not specified, the default runtime class of the KubeVirt configuration is used.\n+optional",
+		"topologySpreadConstraints":     "TopologySpreadConstraints describes how the virt-launcher pod ought to spread across topology\ndomains. All topologySpreadConstraints are ANDed.\n+optional\n+listType=map\n+listMapKey=topologyKey\n+listMapKey=whenUnsatisfiable",
+		"launcherPodMetadata":           "LauncherPodMetadata contains additional labels and annotations for the virt-launcher pod.\n+optional",
 		"tolerations":                   "If toleration is specified, obey all the toleration rules.",
 		"evictionStrategy":              "EvictionStrategy can be set to \"LiveMigrate\" if the VirtualMachineInstance should be\nmigrated instead of shut-off in case of a node drain.\n\n+optional",
 		"startStrategy":                 "StartStrategy can be set to \"Paused\" if Virtual Machine should be started in paused state.\n\n+optional",
+		"schedulingReadinessGates":      "SchedulingReadinessGates lists conditions which have to be set to True on the VirtualMachineInstance,\nusually by third-party controllers, before the virt-launcher pod is created.\n+optional\n+listType=atomic",
+		"sidecars":                      "Sidecars are user containers which are added to the virt-launcher pod.\nThey share the lifecycle of the VirtualMachineInstance.\n+optional\n+listType=map\n+listMapKey=name",
 		"terminationGracePeriodSeconds": "Grace period observed after signalling a VirtualMachineInstance to stop after which the VirtualMachineInstance is force terminated.",
 		"volumes":                       "List of volumes that can be mounted by disks belonging to the vmi.",
 		"livenessProbe":                 "Periodic probe of VirtualMachineInstance liveness.\nVirtualmachineInstances will be stopped if the probe fails.\nCannot be updated.\nMore info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes\n+optional",
@@ -40,6 +45,35 @@ func (VirtualMachineInstanceSpec) SwaggerDoc() map[string]string {
 	}
 }
 
+func (LauncherPodMetadata) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "LauncherPodMetadata contains metadata which is added to the virt-launcher pod.\n\n+k8s:openapi-gen=true",
+		"labels":      "Labels which are added to the virt-launcher pod.\nLabels in the kubevirt.io domain are reserved.\n+optional",
+		"annotations": "Annotations which are added to the virt-launcher pod.\nAnnotations in the kubevirt.io domain are reserved.\n+optional",
+	}
+}
+
+func (Sidecar) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                "Sidecar is a user container which runs next to the virtual machine in the virt-launcher pod,\ne.g. a log shipper or a license daemon.\n\n+k8s:openapi-gen=true",
+		"name":            "Name of the sidecar container, unique within the VirtualMachineInstance.",
+		"image":           "Image of the sidecar container.",
+		"imagePullPolicy": "Image pull policy of the sidecar container.\n+optional",
+		"command":         "Entrypoint array. The ENTRYPOINT of the image is used if this is not provided.\n+optional",
+		"args":            "Arguments to the entrypoint. The CMD of the image is used if this is not provided.\n+optional",
+		"resources":       "Resources are the cpu and memory requests and limits of the sidecar container.\nThey are accounted for on top of the resources of the virtual machine.\n+optional",
+		"volumeMounts":    "VolumeMounts share volumes of the VirtualMachineInstance read-only with the sidecar container.\n+optional",
+	}
+}
+
+func (SidecarVolumeMount) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "SidecarVolumeMount mounts a volume of the VirtualMachineInstance into a sidecar container.\n\n+k8s:openapi-gen=true",
+		"name":      "Name of the VirtualMachineInstance volume.",
+		"mountPath": "MountPath is the path within the sidecar container at which the volume is mounted.",
+	}
+}
+
 func (VirtualMachineInstancePhaseTransitionTimestamp) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":                         "VirtualMachineInstancePhaseTransitionTimestamp gives a timestamp in relation to when a phase is set on a vmi\n\n+k8s:openapi-gen=true",
@@ -48,6 +82,17 @@ func (VirtualMachineInstancePhaseTransitionTimestamp) SwaggerDoc() map[string]st
 	}
 }
 
+func (VirtualMachineInstanceResourceOverhead) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":             "VirtualMachineInstanceResourceOverhead describes the resources which virt-controller adds\nto the virt-launcher pod on top of the resources requested for the guest.\n\n+k8s:openapi-gen=true",
+		"memory":       "Memory needed by the hypervisor, e.g. for pagetables, virtio rings, iothreads and video RAM.\nIt includes the additional guest memory overhead ratio of the KubeVirt configuration.\n+optional",
+		"cpu":          "CPU needed by the hypervisor, e.g. for an isolated emulator thread\n+optional",
+		"emulatorCPU":  "EmulatorCPU is the part of CPU requested for the emulator thread\n+optional",
+		"ioThreadsCPU": "IOThreadsCPU is the part of CPU requested for the IO threads\n+optional",
+		"virtiofsCPU":  "VirtiofsCPU is the part of CPU requested for the virtiofsd processes\n+optional",
+	}
+}
+
 func (TopologyHints) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"": "+k8s:openapi-gen=true",
@@ -64,8 +109,10 @@ func (VirtualMachineInstanceStatus) SwaggerDoc() map[string]string {
 		"phaseTransitionTimestamps":     "PhaseTransitionTimestamp is the timestamp of when the last phase change occurred\n+listType=atomic\n+optional",
 		"interfaces":                    "Interfaces represent the details of available network interfaces.",
 		"guestOSInfo":                   "Guest OS Information",
+		"guestAgent":                    "Version and capabilities of the guest agent\n+optional",
 		"migrationState":                "Represents the status of a live migration",
 		"migrationMethod":               "Represents the method using which the vmi can be migrated: live migration or block migration",
+		"migrationBackoff":              "MigrationBackoff tracks consecutive failed migrations of the vmi. Migrations created by\nKubeVirt itself, e.g. on evacuation, are held back until the backoff expired.\n+optional",
 		"qosClass":                      "The Quality of Service (QOS) classification assigned to the virtual machine instance based on resource requirements\nSee PodQOSClass type for available QOS classes\nMore info: https://git.k8s.io/community/contributors/design-proposals/node/resource-qos.md\n+optional",
 		"launcherContainerImageVersion": "LauncherContainerImageVersion indicates what container image is currently active for the vmi.",
 		"evacuationNodeName":            "EvacuationNodeName is used to track the eviction process of a VMI. It stores the name of the node that we want\nto evacuate. It is meant to be used by KubeVirt core components only and can't be set or modified by users.\n+optional",
@@ -73,7 +120,19 @@ func (VirtualMachineInstanceStatus) SwaggerDoc() map[string]string {
 		"volumeStatus":                  "VolumeStatus contains the statuses of all the volumes\n+optional\n+listType=atomic",
 		"fsFreezeStatus":                "FSFreezeStatus is the state of the fs of the guest\nit can be either frozen or thawed\n+optional",
 		"topologyHints":                 "+optional",
+		"resourceOverhead":              "ResourceOverhead is the overhead which was added to the resources of the virt-launcher pod\n+optional",
+		"cpuAllocationRatio":            "CPUAllocationRatio is the effective ratio of vCPUs to the CPU requested by the virt-launcher pod.\nIt is not set if the VMI uses dedicated CPUs.\n+optional",
 		"virtualMachineRevisionName":    "VirtualMachineRevisionName is used to get the vm revision of the vmi when doing\nan online vm snapshot\n+optional",
+		"hostDevices":                   "HostDevices reports where the GPUs and host devices of the VMI are visible in the guest\n+optional\n+listType=atomic",
+	}
+}
+
+func (VirtualMachineInstanceHostDeviceStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                "VirtualMachineInstanceHostDeviceStatus reports where a GPU or host device is visible in the guest\n\n+k8s:openapi-gen=true",
+		"name":            "Name of the GPU or host device in the VMI spec",
+		"role":            "Role of the device as given in the VMI spec\n+optional",
+		"guestPCIAddress": "GuestPCIAddress is the PCI address of the device in the guest, e.g. 0000:05:00.0\n+optional",
 	}
 }
 
@@ -84,6 +143,7 @@ func (PersistentVolumeClaimInfo) SwaggerDoc() map[string]string {
 		"volumeMode":   "VolumeMode defines what type of volume is required by the claim.\nValue of Filesystem is implied when not included in claim spec.\n+optional",
 		"capacity":     "Capacity represents the capacity set on the corresponding PVC spec\n+optional",
 		"preallocated": "Preallocated indicates if the PVC's storage is preallocated or not\n+optional",
+		"localToNode":  "LocalToNode is the hostname of the node the bound PersistentVolume is local to.\nVirtualMachineInstances using it are placed on this node and can not be live migrated.\n+optional",
 	}
 }
 
@@ -109,6 +169,13 @@ func (HotplugVolumeStatus) SwaggerDoc() map[string]string {
 	}
 }
 
+func (SchedulingReadinessGate) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":              "SchedulingReadinessGate refers to a condition on the VirtualMachineInstance which gates the creation of its pod.\n\n+k8s:openapi-gen=true",
+		"conditionType": "ConditionType refers to a condition in the VirtualMachineInstance's condition list with matching type.",
+	}
+}
+
 func (VirtualMachineInstanceCondition) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":                   "+k8s:openapi-gen=true",
@@ -122,17 +189,20 @@ func (VirtualMachineInstanceMigrationCondition) SwaggerDoc() map[string]string {
 		"":                   "+k8s:openapi-gen=true",
 		"lastProbeTime":      "+nullable",
 		"lastTransitionTime": "+nullable",
+		"observedGeneration": "ObservedGeneration is the metadata.generation of the object the condition was set for\n+optional",
 	}
 }
 
 func (VirtualMachineInstanceNetworkInterface) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":              "+k8s:openapi-gen=true",
-		"ipAddress":     "IP address of a Virtual Machine interface. It is always the first item of\nIPs",
-		"mac":           "Hardware address of a Virtual Machine interface",
-		"name":          "Name of the interface, corresponds to name of the network assigned to the interface",
-		"ipAddresses":   "List of all IP addresses of a Virtual Machine interface",
-		"interfaceName": "The interface name inside the Virtual Machine",
+		"":                     "+k8s:openapi-gen=true",
+		"ipAddress":            "IP address of a Virtual Machine interface. It is always the first item of\nIPs",
+		"mac":                  "Hardware address of a Virtual Machine interface",
+		"name":                 "Name of the interface, corresponds to name of the network assigned to the interface",
+		"ipAddresses":          "List of all IP addresses of a Virtual Machine interface",
+		"interfaceName":        "The interface name inside the Virtual Machine",
+		"lastGuestAgentUpdate": "Time the guest agent data of the interface was last changed. Rapid changes of the\ndata are debounced, data the guest agent stops reporting is removed.\n+optional\n+nullable",
+		"bandwidthLimit":       "BandwidthLimit is the limit applied to the interface of the running domain. It differs from\nthe limit of the spec until virt-launcher applied a change of it.\n+optional",
 	}
 }
 
@@ -147,6 +217,27 @@ func (VirtualMachineInstanceGuestOSInfo) SwaggerDoc() map[string]string {
 		"kernelVersion": "Kernel version of the Guest OS",
 		"machine":       "Machine type of the Guest OS",
 		"id":            "Guest OS Id",
+		"hostname":      "Hostname of the Guest OS",
+		"timezone":      "Timezone of the Guest OS, the zone name and the offset to UTC in seconds",
+	}
+}
+
+func (VirtualMachineInstanceGuestAgentStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                    "VirtualMachineInstanceGuestAgentStatus reports the guest agent running in the VMI and which features it can serve\n\n+k8s:openapi-gen=true",
+		"version":             "Version of the guest agent",
+		"supportedCommands":   "SupportedCommands lists the commands which are enabled in the guest agent\n+listType=set",
+		"unavailableFeatures": "UnavailableFeatures lists the features which can't be used because the guest agent lacks commands they rely on\n+listType=set",
+	}
+}
+
+func (VirtualMachineInstanceMigrationBackoff) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                       "VirtualMachineInstanceMigrationBackoff represents the backoff of automated migrations after repeated failures\n\n+k8s:openapi-gen=true",
+		"failureCount":           "The number of consecutive failed migrations",
+		"lastFailedMigrationUid": "The UID of the last failed migration",
+		"lastFailureTimestamp":   "The time the last migration failed\n+nullable",
+		"nextAttemptTimestamp":   "Automated migrations are not created before this time\n+nullable",
 	}
 }
 
@@ -168,6 +259,7 @@ func (VirtualMachineInstanceMigrationState) SwaggerDoc() map[string]string {
 		"abortStatus":                    "Indicates the final status of the live migration abortion",
 		"migrationUid":                   "The VirtualMachineInstanceMigration object associated with this migration",
 		"mode":                           "Lets us know if the vmi is currently running pre or post copy migration",
+		"abortOnProjectedTimeout":        "Indicates that the source aborts the migration once its projected completion time exceeds the completion timeout",
 	}
 }
 
@@ -193,11 +285,22 @@ func (VirtualMachineInstanceReplicaSetList) SwaggerDoc() map[string]string {
 
 func (VirtualMachineInstanceReplicaSetSpec) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":         "+k8s:openapi-gen=true",
-		"replicas": "Number of desired pods. This is a pointer to distinguish between explicit\nzero and not specified. Defaults to 1.\n+optional",
-		"selector": "Label selector for pods. Existing ReplicaSets whose pods are\nselected by this will be the ones affected by this deployment.",
-		"template": "Template describes the pods that will be created.",
-		"paused":   "Indicates that the replica set is paused.\n+optional",
+		"":               "+k8s:openapi-gen=true",
+		"replicas":       "Number of desired pods. This is a pointer to distinguish between explicit\nzero and not specified. Defaults to 1.\n+optional",
+		"selector":       "Label selector for pods. Existing ReplicaSets whose pods are\nselected by this will be the ones affected by this deployment.",
+		"template":       "Template describes the pods that will be created.",
+		"paused":         "Indicates that the replica set is paused.\n+optional",
+		"topologySpread": "TopologySpread injects topology spread constraints into the created VirtualMachineInstances,\nto spread the replicas across the given topology domains.\n+optional",
+		"serviceName":    "ServiceName is the name of the headless service governing the replicas.\nThe replicas get the stable names <replicaset>-<ordinal> and are reachable by the\nDNS names <replicaset>-<ordinal>.<serviceName>.<namespace>.svc, the service must\nexist and select the replicas.\n+optional",
+	}
+}
+
+func (VirtualMachineInstanceReplicaSetTopologySpread) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                  "VirtualMachineInstanceReplicaSetTopologySpread describes how the replicas of a VirtualMachineInstanceReplicaSet\nare spread across topology domains. Constraints for topology keys which are already part of the template are not injected.\n\n+k8s:openapi-gen=true",
+		"topologyKeys":      "TopologyKeys are the node label keys the replicas are spread across.\nDefaults to topology.kubernetes.io/zone.\n+optional\n+listType=set",
+		"maxSkew":           "MaxSkew is the maximum permitted difference of replicas between two topology domains.\nDefaults to 1.\n+optional",
+		"whenUnsatisfiable": "WhenUnsatisfiable indicates how to deal with a replica if it doesn't satisfy the spread constraint.\nOne of DoNotSchedule or ScheduleAnyway. Defaults to ScheduleAnyway.\n+optional",
 	}
 }
 
@@ -254,8 +357,9 @@ func (VirtualMachineInstanceMigrationList) SwaggerDoc() map[string]string {
 
 func (VirtualMachineInstanceMigrationSpec) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":        "+k8s:openapi-gen=true",
-		"vmiName": "The name of the VMI to perform the migration on. VMI must exist in the migration objects namespace",
+		"":                        "+k8s:openapi-gen=true",
+		"vmiName":                 "The name of the VMI to perform the migration on. VMI must exist in the migration objects namespace",
+		"abortOnProjectedTimeout": "AbortOnProjectedTimeout aborts the migration as soon as its projected completion\ntime, based on the observed transfer rate, exceeds the completion timeout\ninstead of waiting for the timeout to expire. Ignored when post copy is allowed.\n+optional",
 	}
 }
 
@@ -286,6 +390,121 @@ func (VirtualMachineInstancePresetSpec) SwaggerDoc() map[string]string {
 	}
 }
 
+func (VirtualMachineSchedulingHints) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":     "VirtualMachineSchedulingHints carries placement hints written by an external\nplacement engine for the VirtualMachineInstance with the same name and namespace.\nThe hints are folded into the affinity of the launcher pod when it is created,\nalready running pods are not affected.\n\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object\n+k8s:openapi-gen=true\n+genclient",
+		"spec": "Spec contains the scheduling hints.",
+	}
+}
+
+func (VirtualMachineSchedulingHintsList) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "VirtualMachineSchedulingHintsList is a list of VirtualMachineSchedulingHints\n\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object\n+k8s:openapi-gen=true",
+	}
+}
+
+func (VirtualMachineSchedulingHintsSpec) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":               "+k8s:openapi-gen=true",
+		"preferredNodes": "PreferredNodes is a weighted list of nodes the VirtualMachineInstance\nshould preferably be scheduled to.\n+optional\n+listType=atomic",
+		"spreadGroup":    "SpreadGroup places the launcher pod in a group whose members are\npreferably scheduled to different nodes.\n+optional",
+	}
+}
+
+func (PreferredNode) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":         "+k8s:openapi-gen=true",
+		"nodeName": "NodeName is the name of the preferred node.",
+		"weight":   "Weight associated with the node, in the range 1-100.",
+	}
+}
+
+func (VirtualMachineImageExport) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "VirtualMachineImageExport publishes the boot volume of a stopped\nVirtualMachine as a containerDisk image to a registry.\n\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object\n+k8s:openapi-gen=true\n+genclient",
+	}
+}
+
+func (VirtualMachineImageExportList) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "VirtualMachineImageExportList is a list of VirtualMachineImageExports\n\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object\n+k8s:openapi-gen=true",
+	}
+}
+
+func (VirtualMachineImageExportSpec) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":               "+k8s:openapi-gen=true",
+		"source":         "The name of the VirtualMachine whose boot volume is exported. The VirtualMachine\nmust exist in the namespace of the export and must be stopped.",
+		"image":          "Image is the reference by tag the containerDisk image is pushed to.",
+		"baseImage":      "BaseImage is the image the layer with the disk is put on top of. Its layers\nare mounted from the base repository instead of being uploaded again, if the\nregistry supports it. Defaults to an image with only the disk layer.\n+optional",
+		"pushSecretName": "PushSecretName is the name of a kubernetes.io/dockerconfigjson Secret in the\nnamespace of the export with the credentials for the registries.\n+optional",
+	}
+}
+
+func (VirtualMachineImageExportStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":        "+k8s:openapi-gen=true",
+		"phase":   "+optional",
+		"digest":  "Digest is the digest of the manifest of the pushed image.\n+optional",
+		"message": "Message is a human readable reason of a failed export.\n+optional",
+	}
+}
+
+func (ValidationPolicy) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":     "ValidationPolicy declares additional constraints on VirtualMachineInstances\nwhich the validating webhook enforces after the built-in checks. Policies\nare cluster scoped, a VirtualMachineInstance has to satisfy all of them.\n\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object\n+k8s:openapi-gen=true\n+genclient\n+genclient:nonNamespaced",
+		"spec": "Spec contains the constraints of the policy.",
+	}
+}
+
+func (ValidationPolicyList) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "ValidationPolicyList is a list of ValidationPolicies\n\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object\n+k8s:openapi-gen=true",
+	}
+}
+
+func (ValidationPolicySpec) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                               "+k8s:openapi-gen=true",
+		"maxCPUs":                        "MaxCPUs is the maximum number of vCPUs, counted as sockets*cores*threads,\nof a VirtualMachineInstance.\n+optional",
+		"forbiddenDiskBuses":             "ForbiddenDiskBuses lists the buses which disks, LUNs and CD-ROMs must not use.\n+optional\n+listType=set",
+		"requiredLabels":                 "RequiredLabels lists the label keys every VirtualMachineInstance must have.\n+optional\n+listType=set",
+		"allowedContainerDiskRegistries": "AllowedContainerDiskRegistries lists the registries, optionally followed by a\nrepository path, containerDisk images may be pulled from. Any registry is\nallowed if empty.\n+optional\n+listType=set",
+	}
+}
+
+func (MaintenanceWindow) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":     "MaintenanceWindow restricts automated actions which disrupt the selected\nVirtualMachineInstances to recurring periods of time. VirtualMachineInstances\nwhich are not selected by any window are not restricted.\n\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object\n+k8s:openapi-gen=true\n+genclient\n+genclient:nonNamespaced",
+		"spec": "Spec selects the restricted VirtualMachineInstances and contains the periods\nin which the actions may run.",
+	}
+}
+
+func (MaintenanceWindowList) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "MaintenanceWindowList is a list of MaintenanceWindows\n\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object\n+k8s:openapi-gen=true",
+	}
+}
+
+func (MaintenanceWindowSpec) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":           "+k8s:openapi-gen=true",
+		"namespaces": "Namespaces lists the namespaces of the selected VirtualMachineInstances.\nAll namespaces are selected if empty.\n+optional\n+listType=set",
+		"selector":   "Selector selects the VirtualMachineInstances by their labels. All\nVirtualMachineInstances in the namespaces are selected if not set.\n+optional",
+		"actions":    "Actions lists the automated actions which only run during the periods of\nthe window. All actions are restricted if empty.\n+optional\n+listType=set",
+		"periods":    "Periods lists the recurring periods in which the actions may run.\n+listType=atomic",
+	}
+}
+
+func (MaintenancePeriod) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":         "MaintenancePeriod is a period of time which recurs on days of the week.\nAll times are in UTC.\n\n+k8s:openapi-gen=true",
+		"days":     "Days lists the days of the week, like Saturday, the period starts on.\nThe period starts every day if empty.\n+optional\n+listType=set",
+		"start":    "Start is the time of day, formatted as HH:MM, the period starts at.",
+		"duration": "Duration is the length of the period, at most a week.",
+	}
+}
+
 func (VirtualMachine) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":       "VirtualMachine handles the VirtualMachines that are not running\nor are in a stopped state\nThe VirtualMachine contains the template to create the\nVirtualMachineInstance. It also mirrors the running state of the created\nVirtualMachineInstance in its status.\n\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object\n+k8s:openapi-gen=true\n+genclient",
@@ -302,11 +521,21 @@ func (VirtualMachineList) SwaggerDoc() map[string]string {
 
 func (VirtualMachineSpec) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":                    "VirtualMachineSpec describes how the proper VirtualMachine\nshould look like\n\n+k8s:openapi-gen=true",
-		"running":             "Running controls whether the associatied VirtualMachineInstance is created or not\nMutually exclusive with RunStrategy",
-		"runStrategy":         "Running state indicates the requested running state of the VirtualMachineInstance\nmutually exclusive with Running",
-		"template":            "Template is the direct specification of VirtualMachineInstance",
-		"dataVolumeTemplates": "dataVolumeTemplates is a list of dataVolumes that the VirtualMachineInstance template can reference.\nDataVolumes in this list are dynamically created for the VirtualMachine and are tied to the VirtualMachine's life-cycle.",
+		"":                        "VirtualMachineSpec describes how the proper VirtualMachine\nshould look like\n\n+k8s:openapi-gen=true",
+		"running":                 "Running controls whether the associatied VirtualMachineInstance is created or not\nMutually exclusive with RunStrategy",
+		"runStrategy":             "Running state indicates the requested running state of the VirtualMachineInstance\nmutually exclusive with Running",
+		"ttlSecondsAfterFinished": "TTLSecondsAfterFinished limits the lifetime of a VirtualMachine with runStrategy Once. The VirtualMachine,\nincluding the DataVolumes of its dataVolumeTemplates, is deleted the given number of seconds after\nits VirtualMachineInstance finished. It is kept if unset.\n+optional",
+		"changeApplyPolicy":       "ChangeApplyPolicy defines how changes of the template are applied to the running VirtualMachineInstance.\nDefaults to Manual, which only reports the pending changes in the status.\n+optional",
+		"template":                "Template is the direct specification of VirtualMachineInstance",
+		"dataVolumeTemplates":     "dataVolumeTemplates is a list of dataVolumes that the VirtualMachineInstance template can reference.\nDataVolumes in this list are dynamically created for the VirtualMachine and are tied to the VirtualMachine's life-cycle.",
+	}
+}
+
+func (VirtualMachinePendingChange) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "VirtualMachinePendingChange is a change of the template which isn't applied to the running VirtualMachineInstance yet\n\n+k8s:openapi-gen=true",
+		"path":        "Path is the path of the changed field of the VirtualMachine",
+		"applyMethod": "ApplyMethod is how the change can be applied to the running VirtualMachineInstance",
 	}
 }
 
@@ -318,17 +547,38 @@ func (VirtualMachineStartFailure) SwaggerDoc() map[string]string {
 
 func (VirtualMachineStatus) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":                       "VirtualMachineStatus represents the status returned by the\ncontroller to describe how the VirtualMachine is doing\n\n+k8s:openapi-gen=true",
-		"snapshotInProgress":     "SnapshotInProgress is the name of the VirtualMachineSnapshot currently executing",
-		"restoreInProgress":      "RestoreInProgress is the name of the VirtualMachineRestore currently executing",
-		"created":                "Created indicates if the virtual machine is created in the cluster",
-		"ready":                  "Ready indicates if the virtual machine is running and ready",
-		"printableStatus":        "PrintableStatus is a human readable, high-level representation of the status of the virtual machine",
-		"conditions":             "Hold the state information of the VirtualMachine and its VirtualMachineInstance",
-		"stateChangeRequests":    "StateChangeRequests indicates a list of actions that should be taken on a VMI\ne.g. stop a specific VMI then start a new one.",
-		"volumeRequests":         "VolumeRequests indicates a list of volumes add or remove from the VMI template and\nhotplug on an active running VMI.\n+listType=atomic",
-		"volumeSnapshotStatuses": "VolumeSnapshotStatuses indicates a list of statuses whether snapshotting is\nsupported by each volume.",
-		"startFailure":           "StartFailure tracks consecutive VMI startup failures for the purposes of\ncrash loop backoffs\n+nullable\n+optional",
+		"":                          "VirtualMachineStatus represents the status returned by the\ncontroller to describe how the VirtualMachine is doing\n\n+k8s:openapi-gen=true",
+		"snapshotInProgress":        "SnapshotInProgress is the name of the VirtualMachineSnapshot currently executing",
+		"restoreInProgress":         "RestoreInProgress is the name of the VirtualMachineRestore currently executing",
+		"created":                   "Created indicates if the virtual machine is created in the cluster",
+		"ready":                     "Ready indicates if the virtual machine is running and ready",
+		"printableStatus":           "PrintableStatus is a human readable, high-level representation of the status of the virtual machine",
+		"conditions":                "Hold the state information of the VirtualMachine and its VirtualMachineInstance",
+		"stateChangeRequests":       "StateChangeRequests indicates a list of actions that should be taken on a VMI\ne.g. stop a specific VMI then start a new one.",
+		"volumeRequests":            "VolumeRequests indicates a list of volumes add or remove from the VMI template and\nhotplug on an active running VMI.\n+listType=atomic",
+		"volumeSnapshotStatuses":    "VolumeSnapshotStatuses indicates a list of statuses whether snapshotting is\nsupported by each volume.",
+		"startFailure":              "StartFailure tracks consecutive VMI startup failures for the purposes of\ncrash loop backoffs\n+nullable\n+optional",
+		"guestFailures":             "GuestFailures tracks recent failures of VMIs which were running, for the purposes\nof detecting guest crash loops\n+nullable\n+optional",
+		"pendingChanges":            "PendingChanges are the changes of the template which aren't applied to the running VirtualMachineInstance yet\n+listType=atomic\n+optional",
+		"runOnceStarted":            "RunOnceStarted is set once the VirtualMachineInstance of a VirtualMachine with runStrategy Once\nwas created. The VirtualMachineInstance is not started again, even if it gets deleted.\n+optional",
+		"pinnedContainerDiskImages": "PinnedContainerDiskImages are the images of the containerDisks with the Pin digest policy,\nresolved to their digest when the VirtualMachine was started. Later starts boot the same images\nuntil the image of the containerDisk in the template changes.\n+listType=atomic\n+optional",
+	}
+}
+
+func (PinnedContainerDiskImage) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "PinnedContainerDiskImage is the image of a containerDisk pinned to its digest\n\n+k8s:openapi-gen=true",
+		"volumeName":  "VolumeName is the name of the containerDisk volume",
+		"image":       "Image is the image of the containerDisk in the template",
+		"pinnedImage": "PinnedImage is the image referenced by the digest it was resolved to",
+	}
+}
+
+func (VirtualMachineGuestFailures) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                  "VirtualMachineGuestFailures tracks VMIs which failed after they were running\n\n+k8s:openapi-gen=true",
+		"failureTimestamps": "FailureTimestamps are the times at which the VMIs failed, within the crash loop detection window\n+listType=atomic",
+		"lastFailedVMIUID":  "LastFailedVMIUID is the UID of the last VMI which was counted as failed",
 	}
 }
 
@@ -363,6 +613,7 @@ func (VirtualMachineCondition) SwaggerDoc() map[string]string {
 		"":                   "VirtualMachineCondition represents the state of VirtualMachine\n\n+k8s:openapi-gen=true",
 		"lastProbeTime":      "+nullable",
 		"lastTransitionTime": "+nullable",
+		"observedGeneration": "ObservedGeneration is the metadata.generation of the object the condition was set for\n+optional",
 	}
 }
 
@@ -433,6 +684,21 @@ func (KubeVirtWorkloadUpdateStrategy) SwaggerDoc() map[string]string {
 	}
 }
 
+func (KubeVirtHandlerUpdateStrategy) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":       "KubeVirtHandlerUpdateStrategy defines how virt-handler is rolled out when KubeVirt is updated\n\n+k8s:openapi-gen=true",
+		"canary": "Canary rolls out virt-handler to a set of canary nodes first. The rollout to the\nremaining nodes only proceeds once virt-handler is healthy on all canary nodes.\nIf the canaries do not become healthy in time, the rollout is paused. A paused\nrollout can be resumed by removing the canary strategy.\n\n+optional",
+	}
+}
+
+func (KubeVirtHandlerCanaryStrategy) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                  "KubeVirtHandlerCanaryStrategy defines the canary nodes and the health validation of a virt-handler canary rollout\n\n+k8s:openapi-gen=true",
+		"nodeSelector":      "NodeSelector selects the canary nodes among the nodes running virt-handler.\nIf empty, a single node is used as canary.\n\n+optional",
+		"validationTimeout": "ValidationTimeout is the time the updated virt-handler pods on the canary nodes\nhave to become healthy before the rollout is paused.\n\nDefaults to 10 minutes\n\n+optional",
+	}
+}
+
 func (KubeVirtSpec) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":                       "+k8s:openapi-gen=true",
@@ -442,12 +708,21 @@ func (KubeVirtSpec) SwaggerDoc() map[string]string {
 		"monitorNamespace":       "The namespace Prometheus is deployed in\nDefaults to openshift-monitor",
 		"monitorAccount":         "The name of the Prometheus service account that needs read-access to KubeVirt endpoints\nDefaults to prometheus-k8s",
 		"workloadUpdateStrategy": "WorkloadUpdateStrategy defines at the cluster level how to handle\nautomated workload updates",
+		"handlerUpdateStrategy":  "HandlerUpdateStrategy defines how virt-handler is rolled out on updates\n+optional",
 		"uninstallStrategy":      "Specifies if kubevirt can be deleted if workloads are still present.\nThis is mainly a precaution to avoid accidental data loss",
 		"productVersion":         "Designate the apps.kubevirt.io/version label for KubeVirt components.\nUseful if KubeVirt is included as part of a product.\nIf ProductVersion is not specified, KubeVirt's version will be used.",
 		"productName":            "Designate the apps.kubevirt.io/part-of label for KubeVirt components.\nUseful if KubeVirt is included as part of a product.\nIf ProductName is not specified, the part-of label will be omitted.",
 		"configuration":          "holds kubevirt configurations.\nsame as the virt-configMap",
 		"infra":                  "selectors and tolerations that should apply to KubeVirt infrastructure components\n+optional",
 		"workloads":              "selectors and tolerations that should apply to KubeVirt workloads\n+optional",
+		"commonTemplates":        "CommonTemplates deploys curated VirtualMachineInstancePresets and example VirtualMachines\nfor common operating systems to a namespace. They are versioned with KubeVirt and removed\nonce the common templates are removed again.\n+optional",
+	}
+}
+
+func (KubeVirtCommonTemplates) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "KubeVirtCommonTemplates defines where the common templates are deployed\n\n+k8s:openapi-gen=true",
+		"namespace": "Namespace the common templates are deployed to, it has to exist",
 	}
 }
 
@@ -487,8 +762,20 @@ func (GenerationStatus) SwaggerDoc() map[string]string {
 
 func (KubeVirtStatus) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":            "KubeVirtStatus represents information pertaining to a KubeVirt deployment.\n\n+k8s:openapi-gen=true",
-		"generations": "+listType=atomic",
+		"":                         "KubeVirtStatus represents information pertaining to a KubeVirt deployment.\n\n+k8s:openapi-gen=true",
+		"generations":              "+listType=atomic",
+		"unappliedConfiguration":   "UnappliedConfiguration lists the configuration settings which only take effect after\na restart of the KubeVirt components, and which are not rolled out yet\n+listType=set",
+		"handlerRollout":           "HandlerRollout reports the progress of a virt-handler canary rollout",
+		"commonTemplatesNamespace": "CommonTemplatesNamespace is the namespace the common templates are deployed to",
+	}
+}
+
+func (KubeVirtHandlerRolloutStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                   "KubeVirtHandlerRolloutStatus represents the state of a virt-handler canary rollout\n\n+k8s:openapi-gen=true",
+		"targetDeploymentID": "TargetDeploymentID is the deployment the rollout belongs to",
+		"canaryNodes":        "CanaryNodes are the nodes virt-handler is rolled out to first\n+listType=set",
+		"startTime":          "StartTime is the time the canary rollout started\n+optional\n+nullable",
 	}
 }
 
@@ -521,6 +808,86 @@ func (StopOptions) SwaggerDoc() map[string]string {
 	}
 }
 
+func (BulkOperationOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "BulkOperationOptions are provided to start, stop or migrate all VirtualMachines of a namespace\nmatching a label selector in one call.\n\n+k8s:openapi-gen=true",
+		"action":      "Action to apply to every matching VirtualMachine, one of start, stop or migrate",
+		"selector":    "Selector is the label selector matching the VirtualMachines, e.g. \"app=database,tier!=frontend\"",
+		"parallelism": "Parallelism is the number of VirtualMachines processed at the same time.\nDefaults to 10, at most 50.\n+optional",
+	}
+}
+
+func (BulkOperation) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "BulkOperation reports the VirtualMachines a bulk operation applies to and how far it progressed\n\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object\n+k8s:openapi-gen=true",
+		"handle":    "Handle identifies the operation when querying its progress, it is the name of\nthe VirtualMachineBulkOperation recording the operation",
+		"action":    "Action applied to every matching VirtualMachine",
+		"selector":  "Selector matching the VirtualMachines",
+		"total":     "Total is the number of VirtualMachines matching the selector",
+		"completed": "Completed is the number of VirtualMachines which reached the state requested by the action",
+		"failures":  "Failures lists the VirtualMachines the action could not be applied to\n+optional\n+listType=atomic",
+	}
+}
+
+func (BulkOperationFailure) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":        "BulkOperationFailure describes why a bulk operation failed for a single VirtualMachine\n\n+k8s:openapi-gen=true",
+		"name":    "Name of the VirtualMachine",
+		"message": "Message describing the failure",
+	}
+}
+
+func (VirtualMachineBulkOperation) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "VirtualMachineBulkOperation records a bulk operation requested through the\nbulkoperations subresource. virt-controller applies the action to the\nVirtualMachines and records the outcome in the status.\n\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object\n+k8s:openapi-gen=true\n+genclient",
+	}
+}
+
+func (VirtualMachineBulkOperationList) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "VirtualMachineBulkOperationList is a list of VirtualMachineBulkOperations\n\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object\n+k8s:openapi-gen=true",
+	}
+}
+
+func (VirtualMachineBulkOperationSpec) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                "+k8s:openapi-gen=true",
+		"action":          "Action to apply to every VirtualMachine, one of start, stop or migrate",
+		"selector":        "Selector is the label selector which matched the VirtualMachines",
+		"parallelism":     "Parallelism is the number of VirtualMachines processed at the same time",
+		"virtualMachines": "VirtualMachines are the names of the VirtualMachines matching the selector\nwhen the operation was requested\n+listType=atomic",
+		"user":            "User who requested the operation. The action is only applied to the\nVirtualMachines the user is allowed to apply it to.",
+		"groups":          "Groups of the user who requested the operation\n+optional\n+listType=atomic",
+		"extra":           "Extra information about the user who requested the operation\n+optional",
+	}
+}
+
+func (VirtualMachineBulkOperationStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                    "+k8s:openapi-gen=true",
+		"phase":               "+optional",
+		"applied":             "Applied lists the VirtualMachines the action was applied to\n+optional\n+listType=atomic",
+		"failures":            "Failures lists the VirtualMachines the action could not be applied to\n+optional\n+listType=atomic",
+		"completionTimestamp": "CompletionTimestamp is the time the action was applied to all VirtualMachines.\nThe operation is deleted a while after it completed.\n+optional\n+nullable",
+	}
+}
+
+func (SchedulingFeasibilityOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":     "SchedulingFeasibilityOptions are provided to check if a VirtualMachine could currently be scheduled\nbefore it gets created.\n\n+k8s:openapi-gen=true",
+		"spec": "Spec of the VirtualMachine to check",
+	}
+}
+
+func (SchedulingFeasibility) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                  "SchedulingFeasibility reports if a VirtualMachine could currently be scheduled and which of\nits constraints the nodes fail to satisfy, without revealing the nodes themselves\n\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object\n+k8s:openapi-gen=true",
+		"feasible":          "Feasible is true if at least one node can currently run the VirtualMachine",
+		"failedConstraints": "FailedConstraints lists the constraints no node can satisfy, e.g. a missing volume\n+optional\n+listType=atomic",
+		"nodeFailures":      "NodeFailures lists the constraints which are not satisfied by some of the nodes\n+optional\n+listType=atomic",
+	}
+}
+
 func (VirtualMachineInstanceGuestAgentInfo) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":                  "VirtualMachineInstanceGuestAgentInfo represents information from the installed guest agent\n\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object\n+k8s:openapi-gen=true",
@@ -571,6 +938,15 @@ func (VirtualMachineInstanceFileSystem) SwaggerDoc() map[string]string {
 	}
 }
 
+func (VirtualMachineInstanceUsage) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "VirtualMachineInstanceUsage represents the current CPU and memory usage of a VirtualMachineInstance\n\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object\n+k8s:openapi-gen=true",
+		"timestamp": "Timestamp is the time at which the usage was sampled",
+		"window":    "Window is the interval over which the CPU usage was calculated",
+		"usage":     "Usage contains the CPU usage of the guest in cores and its memory usage in bytes",
+	}
+}
+
 func (AddVolumeOptions) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":             "AddVolumeOptions is provided when dynamically hot plugging a volume and disk\n+k8s:openapi-gen=true",
@@ -587,6 +963,42 @@ func (RemoveVolumeOptions) SwaggerDoc() map[string]string {
 	}
 }
 
+func (MemorySnapshotOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":              "MemorySnapshotOptions may be provided when snapshotting the memory of a VMI.\nThe memory state is saved in the background, the request returns the status of the save.\n+k8s:openapi-gen=true",
+		"objectStorage": "ObjectStorage streams the memory state to an S3 compatible object\ninstead of saving it to the memory dump volume of the VMI.\n+optional",
+	}
+}
+
+func (MemorySnapshotObjectStorage) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                "MemorySnapshotObjectStorage is the S3 compatible object the memory state is uploaded to\n+k8s:openapi-gen=true",
+		"endpoint":        "Endpoint is the URL of the S3 compatible service",
+		"region":          "Region of the bucket, defaults to us-east-1\n+optional",
+		"bucket":          "Bucket the object is uploaded to",
+		"key":             "Key of the object",
+		"accessKeyId":     "AccessKeyID of the credentials used to sign the upload requests.\nOnly needed to start the upload.\n+optional",
+		"secretAccessKey": "SecretAccessKey of the credentials used to sign the upload requests.\nOnly needed to start the upload.\n+optional",
+	}
+}
+
+func (MemorySnapshotUploadStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "MemorySnapshotUploadStatus is the status of saving the memory state to the memory dump volume or object storage\n+k8s:openapi-gen=true",
+		"memorySaved": "MemorySaved is set once libvirt finished saving the memory state,\nthe guest no longer needs to be frozen while the rest is uploaded.\n+optional",
+		"checksum":    "Checksum is the SHA-256 of the concatenated SHA-256 digests of the\nuploaded parts, hex encoded and followed by the number of parts.\nSet once the upload succeeded.\n+optional",
+		"message":     "Message explains why the upload failed\n+optional",
+	}
+}
+
+func (SetLinkStateOptions) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":              "SetLinkStateOptions is provided when setting the link state of an interface of a running VMI\n+k8s:openapi-gen=true",
+		"interfaceName": "InterfaceName is the name of the interface in the VMI spec",
+		"state":         "State is the link state the guest sees on the interface, either up or down.\nIt is not persisted, the link is up again once the VMI is restarted.",
+	}
+}
+
 func (TokenBucketRateLimiter) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":      "+k8s:openapi-gen=true",
@@ -617,8 +1029,91 @@ func (ReloadableComponentConfiguration) SwaggerDoc() map[string]string {
 
 func (KubeVirtConfiguration) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":                            "KubeVirtConfiguration holds all kubevirt configurations\n+k8s:openapi-gen=true",
-		"supportedGuestAgentVersions": "deprecated",
+		"":                                   "KubeVirtConfiguration holds all kubevirt configurations\n+k8s:openapi-gen=true",
+		"supportedGuestAgentVersions":        "deprecated",
+		"imagePullSecrets":                   "ImagePullSecrets are added to all virt-launcher pods, to pull the virt-launcher,\ncontainerDisk and kernel boot images. The secrets must exist in the namespace of the VMI.\n+listType=atomic\n+optional",
+		"imageRegistryMirrors":               "ImageRegistryMirrors redirect the virt-launcher, containerDisk, kernel boot and hook sidecar images to mirror registries.\nThe first mirror whose source matches an image is used.\n+listType=atomic\n+optional",
+		"additionalGuestMemoryOverheadRatio": "AdditionalGuestMemoryOverheadRatio is multiplied with the computed memory overhead of\nvirt-launcher pods, to add a safety margin. It must be a decimal number of at least 1.0.\nDefaults to 1.0.\n+optional",
+		"launcherPodMetadataPropagation":     "LauncherPodMetadataPropagation restricts which labels and annotations of VMIs, and of their\nlauncher pod metadata, are propagated to virt-launcher pods.\nIf unset, all of them are propagated.\n+optional",
+		"nodeShutdownGracePeriodSeconds":     "NodeShutdownGracePeriodSeconds is how long virt-handler delays the shutdown or reboot of a node,\nto live migrate its VMIs or to shut them down gracefully. virt-handler takes a systemd-logind\ninhibitor lock for that, the delay is capped by InhibitDelayMaxSec of systemd-logind.\nThe shutdown of the node is not delayed if unset or 0.\n+optional",
+		"auxiliaryThreadsCPURequests":        "AuxiliaryThreadsCPURequests adds CPU requests for the threads of VMIs which don't run vCPUs\nto their virt-launcher pods, so that these threads don't take CPU time from the vCPUs.\nNothing is added if unset. VMIs with dedicated CPUs are not affected.\n+optional",
+		"containerDiskPolicy":                "ContainerDiskPolicy restricts the images containerDisks may use. VMIs and VMs whose\ncontainerDisks violate the policy are rejected on creation. Nothing is restricted if unset.\n+optional",
+		"accountingConfiguration":            "AccountingConfiguration attaches labels of VMIs to their metrics and reports the resource\nusage of VMIs periodically, to charge tenants back for it.\n+optional",
+		"admissionLimits":                    "AdmissionLimits are the size limits VMIs and VMs are validated against on creation. They can be\nraised to allow for example large inline cloud-init user data.\n+optional",
+		"qemuOptionsAllowlist":               "QEMUOptionsAllowlist holds the QEMU -machine and -global options VMIs may set. VMIs setting\nother options are rejected. No options can be set if unset.\n+optional",
+	}
+}
+
+func (AdmissionLimits) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                             "AdmissionLimits holds the size limits of VMI specs.\n\n+k8s:openapi-gen=true",
+		"cloudInitUserDataMaxBytes":    "CloudInitUserDataMaxBytes is the largest inline cloud-init user data in bytes, larger user data has\nto be passed with a UserDataSecretRef. Defaults to 2048.\n+optional",
+		"cloudInitNetworkDataMaxBytes": "CloudInitNetworkDataMaxBytes is the largest inline cloud-init network data in bytes, larger network data\nhas to be passed with a NetworkDataSecretRef. Defaults to 2048.\n+optional",
+		"maxListLength":                "MaxListLength is the largest number of disks, volumes, interfaces, networks and access credentials\na VMI can have. Defaults to 256.\n+optional",
+	}
+}
+
+func (QEMUOptionsAllowlist) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":               "QEMUOptionsAllowlist holds the names of the QEMU options VMIs may set.\n\n+k8s:openapi-gen=true",
+		"machineOptions": "MachineOptions are the names of the -machine options VMIs may set.\n+listType=atomic\n+optional",
+		"globals":        "Globals are the driver.property names of the -global options VMIs may set.\n+listType=atomic\n+optional",
+	}
+}
+
+func (AccountingConfiguration) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":            "AccountingConfiguration holds the options to account the resource usage of VMIs.\n\n+k8s:openapi-gen=true",
+		"labels":      "Labels are the label keys of VMIs which are attached to all per-VMI metrics, as metric labels\nnamed accounting_ followed by the sanitized key, and to the usage reports.\nVMIs without one of the labels have an empty value for it.\n+listType=atomic\n+optional",
+		"usageReport": "UsageReport enables periodic reports of the vCPU, memory and storage usage of all VMIs.\n+optional",
+	}
+}
+
+func (UsageReportConfiguration) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":           "UsageReportConfiguration configures where and how often the resource usage of VMIs is reported.\n\n+k8s:openapi-gen=true",
+		"webhookURL": "WebhookURL is the URL the usage reports are posted to as JSON.",
+		"interval":   "Interval is the period each usage report covers. Defaults to 1h.\n+optional",
+	}
+}
+
+func (ContainerDiskPolicy) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                      "ContainerDiskPolicy restricts the provenance of containerDisk images.\n\n+k8s:openapi-gen=true",
+		"allowedRegistries":     "AllowedRegistries are the registries, optionally followed by a repository path, which\ncontainerDisk images have to come from, for example \"quay.io\" or \"quay.io/kubevirt\".\nImages of Docker Hub are matched as \"docker.io\". All registries are allowed if empty.\n+listType=atomic\n+optional",
+		"signatureVerification": "SignatureVerification requires containerDisk images to carry a cosign signature of one of\nthe given public keys. The images have to be referenced by digest, or be pinned to their\ndigest with the Pin imageDigestPolicy.\n+optional",
+	}
+}
+
+func (ContainerDiskSignatureVerification) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                    "ContainerDiskSignatureVerification holds the public keys which containerDisk image signatures\nare verified with.\n\n+k8s:openapi-gen=true",
+		"publicKeysConfigMap": "PublicKeysConfigMap is the name of a ConfigMap in the namespace of KubeVirt, whose entries\nhold PEM encoded ECDSA or RSA public keys.",
+	}
+}
+
+func (LauncherPodMetadataPropagation) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                   "LauncherPodMetadataPropagation holds the allowlists of label and annotation keys which are\npropagated to virt-launcher pods. An entry is either an exact key, or a key prefix followed by \"*\".\n\n+k8s:openapi-gen=true",
+		"allowedLabels":      "AllowedLabels are the label keys which are propagated to virt-launcher pods.\nIf empty, no labels are propagated.\n+listType=atomic\n+optional",
+		"allowedAnnotations": "AllowedAnnotations are the annotation keys which are propagated to virt-launcher pods.\nIf empty, no annotations are propagated.\n+listType=atomic\n+optional",
+	}
+}
+
+func (AuxiliaryThreadsCPURequests) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":         "AuxiliaryThreadsCPURequests holds the CPU which is requested for each of the threads of a VMI\nwhich don't run vCPUs.\n\n+k8s:openapi-gen=true",
+		"emulator": "Emulator is requested once per VMI, for the emulator thread of QEMU.\n+optional",
+		"ioThread": "IOThread is requested for each IO thread of a VMI.\n+optional",
+		"virtiofs": "Virtiofs is requested for each filesystem of a VMI, for its virtiofsd process.\n+optional",
+	}
+}
+
+func (ImageRegistryMirror) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":       "ImageRegistryMirror redirects images of a registry or repository to a mirror\n\n+k8s:openapi-gen=true",
+		"source": "Source is the registry, optionally followed by a repository path, of the images to redirect,\nfor example \"quay.io\" or \"quay.io/kubevirt\"",
+		"mirror": "Mirror replaces the source in the image references, for example \"registry.local:5000/kubevirt\"",
 	}
 }
 
@@ -630,7 +1125,9 @@ func (SMBiosConfiguration) SwaggerDoc() map[string]string {
 
 func (MigrationConfiguration) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"": "MigrationConfiguration holds migration options\n+k8s:openapi-gen=true",
+		"":                          "MigrationConfiguration holds migration options\n+k8s:openapi-gen=true",
+		"hostPassthroughPolicy":     "HostPassthroughPolicy decides whether and where VMIs with the host-passthrough CPU mode are migrated.\nOne of Allow, CompatibleNodes or Deny. Defaults to Allow.",
+		"preSwitchoverDrainSeconds": "PreSwitchoverDrainSeconds drains the connections to a VMI before the switchover of its migration if positive.\nOnce the migration target pod runs it joins the endpoints of Services, and the virt-launcher pod of the source\nis reported as not ready, which removes it from them. The VMI is only handed off to the target after the\nconnections drained for PreSwitchoverDrainSeconds, the source pod is reported as ready again if the migration fails.\nOnly applies to VMIs started while it is set. Defaults to 0, which disables draining.",
 	}
 }
 
@@ -645,6 +1142,15 @@ func (DeveloperConfiguration) SwaggerDoc() map[string]string {
 		"":                           "DeveloperConfiguration holds developer options\n+k8s:openapi-gen=true",
 		"useEmulation":               "UseEmulation can be set to true to allow fallback to software emulation\nin case hardware-assisted emulation is not available.",
 		"minimumClusterTSCFrequency": "Allow overriding the automatically determined minimum TSC frequency of the cluster\nand fixate the minimum to this frequency.",
+		"namespacedFeatureGates":     "NamespacedFeatureGates enables feature gates only for the VMIs and VMs of the listed namespaces.\n+listType=atomic",
+	}
+}
+
+func (NamespacedFeatureGate) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":           "NamespacedFeatureGate enables a feature gate for a set of namespaces\n+k8s:openapi-gen=true",
+		"name":       "Name of the feature gate",
+		"namespaces": "Namespaces in which VMIs and VMs are allowed to use the feature\n+listType=atomic",
 	}
 }
 
@@ -687,7 +1193,9 @@ func (MediatedDevicesConfiguration) SwaggerDoc() map[string]string {
 
 func (NetworkConfiguration) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"": "NetworkConfiguration holds network options\n+k8s:openapi-gen=true",
+		"":                       "NetworkConfiguration holds network options\n+k8s:openapi-gen=true",
+		"nodeBandwidth":          "NodeBandwidth is the network bandwidth of every node, in bits per second, which VMIs can reserve\nwith the bandwidth of their interfaces. Nodes without a bandwidth don't run VMIs reserving any.\n+optional",
+		"nodeBandwidthOverrides": "NodeBandwidthOverrides overrides the NodeBandwidth of individual nodes, keyed by the node name.\n+optional",
 	}
 }
 
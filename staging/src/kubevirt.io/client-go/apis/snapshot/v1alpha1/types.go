@@ -71,6 +71,68 @@ type VirtualMachineSnapshotSpec struct {
 	// Defaults to DefaultFailureDeadline - 5min
 	// +optional
 	FailureDeadline *metav1.Duration `json:"failureDeadline,omitempty"`
+
+	// QuiescePolicy defines whether freezing the guest file systems of an
+	// online vm is required for the snapshot to succeed.
+	// Defaults to BestEffort
+	// +optional
+	QuiescePolicy *QuiescePolicy `json:"quiescePolicy,omitempty"`
+
+	// Memory requests the memory state of an online vm to be saved
+	// alongside the volume snapshots.
+	// +optional
+	Memory *MemorySnapshotSpec `json:"memory,omitempty"`
+
+	// TTL is the time a succeeded snapshot is kept before it is deleted
+	// automatically. Snapshots without a TTL never expire.
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+}
+
+// QuiescePolicy defines how strictly the guest has to be quiesced
+// before the volumes of an online vm are snapshotted
+type QuiescePolicy string
+
+const (
+	// QuiescePolicyBestEffort freezes the guest when possible and
+	// takes a crash consistent snapshot otherwise
+	QuiescePolicyBestEffort QuiescePolicy = "BestEffort"
+
+	// QuiescePolicyRequired fails the snapshot when the guest can't be frozen
+	QuiescePolicyRequired QuiescePolicy = "Required"
+)
+
+// MemorySnapshotSpec configures saving the memory state of an online vm
+type MemorySnapshotSpec struct {
+	// StorageClassName of the PersistentVolumeClaim the memory state is saved to.
+	// Defaults to the default storage class of the cluster
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+
+	// ObjectStorage streams the memory state to an S3 compatible bucket
+	// instead of saving it to a PersistentVolumeClaim.
+	// +optional
+	ObjectStorage *MemoryObjectStorage `json:"objectStorage,omitempty"`
+}
+
+// MemoryObjectStorage is an S3 compatible bucket the memory state is uploaded to
+type MemoryObjectStorage struct {
+	// Endpoint is the URL of the S3 compatible service
+	Endpoint string `json:"endpoint"`
+
+	// Region of the bucket, defaults to us-east-1
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// Bucket the memory state is uploaded to
+	Bucket string `json:"bucket"`
+
+	// SecretRef references a Secret in the namespace of the snapshot
+	// with the accessKeyId and secretAccessKey of the bucket. The user creating
+	// the snapshot has to be allowed to get the Secret, and virt-controller has
+	// to be granted get access to it, e.g. through a RoleBinding in the namespace
+	// of the snapshot
+	SecretRef corev1.LocalObjectReference `json:"secretRef"`
 }
 
 // Indication is a way to indicate the state of the vm when taking the snapshot
@@ -80,6 +142,7 @@ const (
 	VMSnapshotOnlineSnapshotIndication Indication = "Online"
 	VMSnapshotNoGuestAgentIndication   Indication = "NoGuestAgent"
 	VMSnapshotGuestAgentIndication     Indication = "GuestAgent"
+	VMSnapshotMemoryIndication         Indication = "Memory"
 )
 
 // VirtualMachineSnapshotPhase is the current phase of the VirtualMachineSnapshot
@@ -143,6 +206,10 @@ const (
 
 	// ConditionFailure is the "failure" condition type
 	ConditionFailure ConditionType = "Failure"
+
+	// ConditionMemorySnapshotCompleted is the condition type of snapshots
+	// whose memory state has been saved
+	ConditionMemorySnapshotCompleted ConditionType = "MemorySnapshotCompleted"
 )
 
 // Condition defines conditions
@@ -196,6 +263,9 @@ type VirtualMachineSnapshotContentSpec struct {
 
 	// +optional
 	VolumeBackups []VolumeBackup `json:"volumeBackups,omitempty"`
+
+	// +optional
+	MemoryBackup *MemoryBackup `json:"memoryBackup,omitempty"`
 }
 
 // SourceSpec contains the appropriate spec for the resource being snapshotted
@@ -227,6 +297,22 @@ type VolumeBackup struct {
 	VolumeSnapshotName *string `json:"volumeSnapshotName,omitempty"`
 }
 
+// MemoryBackup contains the data needed to locate the saved memory state of a vm
+type MemoryBackup struct {
+	// +optional
+	VolumeName string `json:"volumeName,omitempty"`
+
+	// +optional
+	PersistentVolumeClaim *PersistentVolumeClaim `json:"persistentVolumeClaim,omitempty"`
+
+	// +optional
+	ObjectStorage *MemoryObjectStorage `json:"objectStorage,omitempty"`
+
+	// ObjectKey is the key of the object in the bucket of ObjectStorage
+	// +optional
+	ObjectKey string `json:"objectKey,omitempty"`
+}
+
 // VirtualMachineSnapshotContentStatus is the status for a VirtualMachineSnapshotStatus resource
 type VirtualMachineSnapshotContentStatus struct {
 	// +optional
@@ -241,6 +327,9 @@ type VirtualMachineSnapshotContentStatus struct {
 
 	// +optional
 	VolumeSnapshotStatus []VolumeSnapshotStatus `json:"volumeSnapshotStatus,omitempty"`
+
+	// +optional
+	MemorySnapshotStatus *MemorySnapshotStatus `json:"memorySnapshotStatus,omitempty"`
 }
 
 // VirtualMachineSnapshotContentList is a list of VirtualMachineSnapshot resources
@@ -267,6 +356,23 @@ type VolumeSnapshotStatus struct {
 	Error *Error `json:"error,omitempty"`
 }
 
+// MemorySnapshotStatus is the status of the saved memory state of a vm
+type MemorySnapshotStatus struct {
+	// +optional
+	// +nullable
+	CreationTime *metav1.Time `json:"creationTime,omitempty"`
+
+	// +optional
+	ReadyToUse *bool `json:"readyToUse,omitempty"`
+
+	// +optional
+	Error *Error `json:"error,omitempty"`
+
+	// Checksum of the memory state uploaded to object storage
+	// +optional
+	Checksum *string `json:"checksum,omitempty"`
+}
+
 // VirtualMachineRestore defines the operation of restoring a VM
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -283,9 +389,54 @@ type VirtualMachineRestore struct {
 // VirtualMachineRestoreSpec is the spec for a VirtualMachineRestoreresource
 type VirtualMachineRestoreSpec struct {
 	// initially only VirtualMachine type supported
+	// a VirtualMachine that does not exist is created from the snapshot
 	Target corev1.TypedLocalObjectReference `json:"target"`
 
 	VirtualMachineSnapshotName string `json:"virtualMachineSnapshotName"`
+
+	// +optional
+	StorageClassMappings []StorageClassMapping `json:"storageClassMappings,omitempty"`
+
+	// Identity defines which identifying values of the snapshotted VirtualMachine are preserved
+	// and which are regenerated, e.g. when the snapshot is restored into a new VirtualMachine
+	// +optional
+	Identity *RestoreIdentity `json:"identity,omitempty"`
+}
+
+// IdentityPolicy defines if an identifying value is preserved or regenerated on restore
+type IdentityPolicy string
+
+const (
+	// IdentityPreserve keeps the value of the snapshot
+	IdentityPreserve IdentityPolicy = "Preserve"
+
+	// IdentityRegenerate replaces the value of the snapshot with a new one
+	IdentityRegenerate IdentityPolicy = "Regenerate"
+)
+
+// RestoreIdentity defines the policy for each identifying value of a restored VirtualMachine.
+// Values which are not explicitly set in the snapshot are always generated anew. Unset policies
+// default to Preserve when restoring into the snapshotted VirtualMachine, and to Regenerate when
+// restoring into a VirtualMachine of another name.
+type RestoreIdentity struct {
+	// MACAddresses of the interfaces
+	// +optional
+	MACAddresses IdentityPolicy `json:"macAddresses,omitempty"`
+
+	// FirmwareUUID is the SMBIOS UUID of the firmware
+	// +optional
+	FirmwareUUID IdentityPolicy `json:"firmwareUUID,omitempty"`
+
+	// FirmwareSerial is the SMBIOS serial number of the firmware
+	// +optional
+	FirmwareSerial IdentityPolicy `json:"firmwareSerial,omitempty"`
+}
+
+// StorageClassMapping restores volumes of the source StorageClass to the target StorageClass
+type StorageClassMapping struct {
+	Source string `json:"source"`
+
+	Target string `json:"target"`
 }
 
 // VirtualMachineRestoreStatus is the spec for a VirtualMachineRestoreresource
@@ -14,6 +14,27 @@ func (VirtualMachineSnapshotSpec) SwaggerDoc() map[string]string {
 		"":                "VirtualMachineSnapshotSpec is the spec for a VirtualMachineSnapshot resource",
 		"deletionPolicy":  "+optional",
 		"failureDeadline": "This time represents the number of seconds we permit the vm snapshot\nto take. In case we pass this deadline we mark this snapshot\nas failed.\nDefaults to DefaultFailureDeadline - 5min\n+optional",
+		"quiescePolicy":   "QuiescePolicy defines whether freezing the guest file systems of an\nonline vm is required for the snapshot to succeed.\nDefaults to BestEffort\n+optional",
+		"memory":          "Memory requests the memory state of an online vm to be saved\nalongside the volume snapshots.\n+optional",
+		"ttl":             "TTL is the time a succeeded snapshot is kept before it is deleted\nautomatically. Snapshots without a TTL never expire.\n+optional",
+	}
+}
+
+func (MemorySnapshotSpec) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                 "MemorySnapshotSpec configures saving the memory state of an online vm",
+		"storageClassName": "StorageClassName of the PersistentVolumeClaim the memory state is saved to.\nDefaults to the default storage class of the cluster\n+optional",
+		"objectStorage":    "ObjectStorage streams the memory state to an S3 compatible bucket\ninstead of saving it to a PersistentVolumeClaim.\n+optional",
+	}
+}
+
+func (MemoryObjectStorage) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":          "MemoryObjectStorage is an S3 compatible bucket the memory state is uploaded to",
+		"endpoint":  "Endpoint is the URL of the S3 compatible service",
+		"region":    "Region of the bucket, defaults to us-east-1\n+optional",
+		"bucket":    "Bucket the memory state is uploaded to",
+		"secretRef": "SecretRef references a Secret in the namespace of the snapshot\nwith the accessKeyId and secretAccessKey of the bucket. The user creating\nthe snapshot has to be allowed to get the Secret, and virt-controller has\nto be granted get access to it, e.g. through a RoleBinding in the namespace\nof the snapshot",
 	}
 }
 
@@ -66,6 +87,7 @@ func (VirtualMachineSnapshotContentSpec) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":              "VirtualMachineSnapshotContentSpec is the spec for a VirtualMachineSnapshotContent resource",
 		"volumeBackups": "+optional",
+		"memoryBackup":  "+optional",
 	}
 }
 
@@ -89,6 +111,16 @@ func (VolumeBackup) SwaggerDoc() map[string]string {
 	}
 }
 
+func (MemoryBackup) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":                      "MemoryBackup contains the data needed to locate the saved memory state of a vm",
+		"volumeName":            "+optional",
+		"persistentVolumeClaim": "+optional",
+		"objectStorage":         "+optional",
+		"objectKey":             "ObjectKey is the key of the object in the bucket of ObjectStorage\n+optional",
+	}
+}
+
 func (VirtualMachineSnapshotContentStatus) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":                     "VirtualMachineSnapshotContentStatus is the status for a VirtualMachineSnapshotStatus resource",
@@ -96,6 +128,7 @@ func (VirtualMachineSnapshotContentStatus) SwaggerDoc() map[string]string {
 		"readyToUse":           "+optional",
 		"error":                "+optional",
 		"volumeSnapshotStatus": "+optional",
+		"memorySnapshotStatus": "+optional",
 	}
 }
 
@@ -114,6 +147,16 @@ func (VolumeSnapshotStatus) SwaggerDoc() map[string]string {
 	}
 }
 
+func (MemorySnapshotStatus) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":             "MemorySnapshotStatus is the status of the saved memory state of a vm",
+		"creationTime": "+optional\n+nullable",
+		"readyToUse":   "+optional",
+		"error":        "+optional",
+		"checksum":     "Checksum of the memory state uploaded to object storage\n+optional",
+	}
+}
+
 func (VirtualMachineRestore) SwaggerDoc() map[string]string {
 	return map[string]string{
 		"":       "VirtualMachineRestore defines the operation of restoring a VM\n+genclient\n+k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object",
@@ -123,8 +166,25 @@ func (VirtualMachineRestore) SwaggerDoc() map[string]string {
 
 func (VirtualMachineRestoreSpec) SwaggerDoc() map[string]string {
 	return map[string]string{
-		"":       "VirtualMachineRestoreSpec is the spec for a VirtualMachineRestoreresource",
-		"target": "initially only VirtualMachine type supported",
+		"":                     "VirtualMachineRestoreSpec is the spec for a VirtualMachineRestoreresource",
+		"target":               "initially only VirtualMachine type supported\na VirtualMachine that does not exist is created from the snapshot",
+		"storageClassMappings": "+optional",
+		"identity":             "Identity defines which identifying values of the snapshotted VirtualMachine are preserved\nand which are regenerated, e.g. when the snapshot is restored into a new VirtualMachine\n+optional",
+	}
+}
+
+func (StorageClassMapping) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"": "StorageClassMapping restores volumes of the source StorageClass to the target StorageClass",
+	}
+}
+
+func (RestoreIdentity) SwaggerDoc() map[string]string {
+	return map[string]string{
+		"":               "RestoreIdentity defines the policy for each identifying value of a restored VirtualMachine.\nValues which are not explicitly set in the snapshot are always generated anew. Unset policies\ndefault to Preserve when restoring into the snapshotted VirtualMachine, and to Regenerate when\nrestoring into a VirtualMachine of another name.",
+		"macAddresses":   "MACAddresses of the interfaces\n+optional",
+		"firmwareUUID":   "FirmwareUUID is the SMBIOS UUID of the firmware\n+optional",
+		"firmwareSerial": "FirmwareSerial is the SMBIOS serial number of the firmware\n+optional",
 	}
 }
 
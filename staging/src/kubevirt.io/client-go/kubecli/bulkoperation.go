@@ -0,0 +1,147 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package kubecli
+
+import (
+	"context"
+
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+func (k *kubevirt) VirtualMachineBulkOperation(namespace string) VirtualMachineBulkOperationInterface {
+	return &bulkOperation{
+		restClient: k.restClient,
+		namespace:  namespace,
+		resource:   "virtualmachinebulkoperations",
+	}
+}
+
+type bulkOperation struct {
+	restClient *rest.RESTClient
+	namespace  string
+	resource   string
+}
+
+// Create new VirtualMachineBulkOperation in the cluster to specified namespace
+func (o *bulkOperation) Create(newOperation *v1.VirtualMachineBulkOperation) (*v1.VirtualMachineBulkOperation, error) {
+	result := &v1.VirtualMachineBulkOperation{}
+	err := o.restClient.Post().
+		Resource(o.resource).
+		Namespace(o.namespace).
+		Body(newOperation).
+		Do(context.Background()).
+		Into(result)
+
+	result.SetGroupVersionKind(v1.VirtualMachineBulkOperationGroupVersionKind)
+
+	return result, err
+}
+
+// Get the VirtualMachineBulkOperation from the cluster by its name and namespace
+func (o *bulkOperation) Get(name string, options *k8smetav1.GetOptions) (*v1.VirtualMachineBulkOperation, error) {
+	result := &v1.VirtualMachineBulkOperation{}
+	err := o.restClient.Get().
+		Resource(o.resource).
+		Namespace(o.namespace).
+		Name(name).
+		VersionedParams(options, scheme.ParameterCodec).
+		Do(context.Background()).
+		Into(result)
+
+	result.SetGroupVersionKind(v1.VirtualMachineBulkOperationGroupVersionKind)
+
+	return result, err
+}
+
+// Update the VirtualMachineBulkOperation in the cluster in given namespace
+func (o *bulkOperation) Update(operation *v1.VirtualMachineBulkOperation) (*v1.VirtualMachineBulkOperation, error) {
+	result := &v1.VirtualMachineBulkOperation{}
+	err := o.restClient.Put().
+		Resource(o.resource).
+		Namespace(o.namespace).
+		Name(operation.Name).
+		Body(operation).
+		Do(context.Background()).
+		Into(result)
+
+	result.SetGroupVersionKind(v1.VirtualMachineBulkOperationGroupVersionKind)
+
+	return result, err
+}
+
+// Delete the defined VirtualMachineBulkOperation in the cluster in defined namespace
+func (o *bulkOperation) Delete(name string, options *k8smetav1.DeleteOptions) error {
+	return o.restClient.Delete().
+		Resource(o.resource).
+		Namespace(o.namespace).
+		Name(name).
+		Body(options).
+		Do(context.Background()).
+		Error()
+}
+
+// List all VirtualMachineBulkOperations in given namespace
+func (o *bulkOperation) List(options *k8smetav1.ListOptions) (*v1.VirtualMachineBulkOperationList, error) {
+	list := &v1.VirtualMachineBulkOperationList{}
+	err := o.restClient.Get().
+		Resource(o.resource).
+		Namespace(o.namespace).
+		VersionedParams(options, scheme.ParameterCodec).
+		Do(context.Background()).
+		Into(list)
+
+	for i := range list.Items {
+		list.Items[i].SetGroupVersionKind(v1.VirtualMachineBulkOperationGroupVersionKind)
+	}
+
+	return list, err
+}
+
+func (o *bulkOperation) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.VirtualMachineBulkOperation, err error) {
+	result = &v1.VirtualMachineBulkOperation{}
+	err = o.restClient.Patch(pt).
+		Namespace(o.namespace).
+		Resource(o.resource).
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do(context.Background()).
+		Into(result)
+	return result, err
+}
+
+func (o *bulkOperation) UpdateStatus(operation *v1.VirtualMachineBulkOperation) (result *v1.VirtualMachineBulkOperation, err error) {
+	result = &v1.VirtualMachineBulkOperation{}
+	err = o.restClient.Put().
+		Name(operation.ObjectMeta.Name).
+		Namespace(o.namespace).
+		Resource(o.resource).
+		SubResource("status").
+		Body(operation).
+		Do(context.Background()).
+		Into(result)
+	result.SetGroupVersionKind(v1.VirtualMachineBulkOperationGroupVersionKind)
+	return
+}
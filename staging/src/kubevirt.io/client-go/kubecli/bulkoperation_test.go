@@ -0,0 +1,124 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package kubecli
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	k8sv1 "k8s.io/api/core/v1"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("Kubevirt BulkOperation Client", func() {
+
+	var server *ghttp.Server
+	var client KubevirtClient
+	basePath := "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachinebulkoperations"
+	operationPath := basePath + "/testoperation"
+
+	newOperation := func() *v1.VirtualMachineBulkOperation {
+		return &v1.VirtualMachineBulkOperation{
+			TypeMeta:   k8smetav1.TypeMeta{APIVersion: v1.GroupVersion.String(), Kind: "VirtualMachineBulkOperation"},
+			ObjectMeta: k8smetav1.ObjectMeta{Name: "testoperation", Namespace: k8sv1.NamespaceDefault},
+			Spec:       v1.VirtualMachineBulkOperationSpec{Action: v1.BulkStopAction, Selector: "app=db", Parallelism: 10, VirtualMachines: []string{"testvm"}, User: "alice"},
+		}
+	}
+
+	BeforeEach(func() {
+		var err error
+		server = ghttp.NewServer()
+		client, err = GetKubevirtClientFromFlags(server.URL(), "")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should fetch a BulkOperation", func() {
+		operation := newOperation()
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", operationPath),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, operation),
+		))
+		fetchedOperation, err := client.VirtualMachineBulkOperation(k8sv1.NamespaceDefault).Get("testoperation", &k8smetav1.GetOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetchedOperation).To(Equal(operation))
+	})
+
+	It("should fetch a BulkOperation list", func() {
+		operation := newOperation()
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", basePath),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, &v1.VirtualMachineBulkOperationList{Items: []v1.VirtualMachineBulkOperation{*operation}}),
+		))
+		fetchedList, err := client.VirtualMachineBulkOperation(k8sv1.NamespaceDefault).List(&k8smetav1.ListOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetchedList.Items).To(HaveLen(1))
+		Expect(fetchedList.Items[0]).To(Equal(*operation))
+	})
+
+	It("should create a BulkOperation", func() {
+		operation := newOperation()
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("POST", basePath),
+			ghttp.RespondWithJSONEncoded(http.StatusCreated, operation),
+		))
+		createdOperation, err := client.VirtualMachineBulkOperation(k8sv1.NamespaceDefault).Create(operation)
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(createdOperation).To(Equal(operation))
+	})
+
+	It("should update the status of a BulkOperation", func() {
+		operation := newOperation()
+		operation.Status.Phase = v1.BulkOperationSucceeded
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", operationPath+"/status"),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, operation),
+		))
+		updatedOperation, err := client.VirtualMachineBulkOperation(k8sv1.NamespaceDefault).UpdateStatus(operation)
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updatedOperation).To(Equal(operation))
+	})
+
+	It("should delete a BulkOperation", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("DELETE", operationPath),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, nil),
+		))
+		err := client.VirtualMachineBulkOperation(k8sv1.NamespaceDefault).Delete("testoperation", &k8smetav1.DeleteOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+})
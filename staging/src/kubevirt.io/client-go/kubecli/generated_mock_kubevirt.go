@@ -180,6 +180,26 @@ func (_mr *_MockKubevirtClientRecorder) VirtualMachineRestore(arg0 interface{})
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "VirtualMachineRestore", arg0)
 }
 
+func (_m *MockKubevirtClient) VirtualMachineImageExport(namespace string) VirtualMachineImageExportInterface {
+	ret := _m.ctrl.Call(_m, "VirtualMachineImageExport", namespace)
+	ret0, _ := ret[0].(VirtualMachineImageExportInterface)
+	return ret0
+}
+
+func (_mr *_MockKubevirtClientRecorder) VirtualMachineImageExport(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "VirtualMachineImageExport", arg0)
+}
+
+func (_m *MockKubevirtClient) VirtualMachineBulkOperation(namespace string) VirtualMachineBulkOperationInterface {
+	ret := _m.ctrl.Call(_m, "VirtualMachineBulkOperation", namespace)
+	ret0, _ := ret[0].(VirtualMachineBulkOperationInterface)
+	return ret0
+}
+
+func (_mr *_MockKubevirtClientRecorder) VirtualMachineBulkOperation(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "VirtualMachineBulkOperation", arg0)
+}
+
 func (_m *MockKubevirtClient) ServerVersion() *ServerVersion {
 	ret := _m.ctrl.Call(_m, "ServerVersion")
 	ret0, _ := ret[0].(*ServerVersion)
@@ -977,6 +997,27 @@ func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) Unfreeze(arg0 interface
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "Unfreeze", arg0)
 }
 
+func (_m *MockVirtualMachineInstanceInterface) SnapshotMemory(name string) error {
+	ret := _m.ctrl.Call(_m, "SnapshotMemory", name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) SnapshotMemory(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SnapshotMemory", arg0)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) UploadMemory(name string, options *v117.MemorySnapshotOptions) (*v117.MemorySnapshotUploadStatus, error) {
+	ret := _m.ctrl.Call(_m, "UploadMemory", name, options)
+	ret0, _ := ret[0].(*v117.MemorySnapshotUploadStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) UploadMemory(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "UploadMemory", arg0, arg1)
+}
+
 func (_m *MockVirtualMachineInstanceInterface) GuestOsInfo(name string) (v117.VirtualMachineInstanceGuestAgentInfo, error) {
 	ret := _m.ctrl.Call(_m, "GuestOsInfo", name)
 	ret0, _ := ret[0].(v117.VirtualMachineInstanceGuestAgentInfo)
@@ -1010,6 +1051,28 @@ func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) FilesystemList(arg0 int
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "FilesystemList", arg0)
 }
 
+func (_m *MockVirtualMachineInstanceInterface) Usage(name string) (*v117.VirtualMachineInstanceUsage, error) {
+	ret := _m.ctrl.Call(_m, "Usage", name)
+	ret0, _ := ret[0].(*v117.VirtualMachineInstanceUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) Usage(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Usage", arg0)
+}
+
+func (_m *MockVirtualMachineInstanceInterface) Screenshot(name string) ([]byte, error) {
+	ret := _m.ctrl.Call(_m, "Screenshot", name)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) Screenshot(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Screenshot", arg0)
+}
+
 func (_m *MockVirtualMachineInstanceInterface) AddVolume(name string, addVolumeOptions *v117.AddVolumeOptions) error {
 	ret := _m.ctrl.Call(_m, "AddVolume", name, addVolumeOptions)
 	ret0, _ := ret[0].(error)
@@ -1030,6 +1093,16 @@ func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) RemoveVolume(arg0, arg1
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "RemoveVolume", arg0, arg1)
 }
 
+func (_m *MockVirtualMachineInstanceInterface) SetLinkState(name string, setLinkStateOptions *v117.SetLinkStateOptions) error {
+	ret := _m.ctrl.Call(_m, "SetLinkState", name, setLinkStateOptions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInstanceInterfaceRecorder) SetLinkState(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SetLinkState", arg0, arg1)
+}
+
 // Mock of ReplicaSetInterface interface
 type MockReplicaSetInterface struct {
 	ctrl     *gomock.Controller
@@ -1429,6 +1502,39 @@ func (_mr *_MockVirtualMachineInterfaceRecorder) Migrate(arg0 interface{}) *gomo
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "Migrate", arg0)
 }
 
+func (_m *MockVirtualMachineInterface) BulkOperation(bulkOperationOptions *v117.BulkOperationOptions) (*v117.BulkOperation, error) {
+	ret := _m.ctrl.Call(_m, "BulkOperation", bulkOperationOptions)
+	ret0, _ := ret[0].(*v117.BulkOperation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInterfaceRecorder) BulkOperation(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "BulkOperation", arg0)
+}
+
+func (_m *MockVirtualMachineInterface) BulkOperationProgress(handle string) (*v117.BulkOperation, error) {
+	ret := _m.ctrl.Call(_m, "BulkOperationProgress", handle)
+	ret0, _ := ret[0].(*v117.BulkOperation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInterfaceRecorder) BulkOperationProgress(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "BulkOperationProgress", arg0)
+}
+
+func (_m *MockVirtualMachineInterface) SchedulingFeasibility(schedulingFeasibilityOptions *v117.SchedulingFeasibilityOptions) (*v117.SchedulingFeasibility, error) {
+	ret := _m.ctrl.Call(_m, "SchedulingFeasibility", schedulingFeasibilityOptions)
+	ret0, _ := ret[0].(*v117.SchedulingFeasibility)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineInterfaceRecorder) SchedulingFeasibility(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SchedulingFeasibility", arg0)
+}
+
 func (_m *MockVirtualMachineInterface) AddVolume(name string, addVolumeOptions *v117.AddVolumeOptions) error {
 	ret := _m.ctrl.Call(_m, "AddVolume", name, addVolumeOptions)
 	ret0, _ := ret[0].(error)
@@ -1573,6 +1679,220 @@ func (_mr *_MockVirtualMachineInstanceMigrationInterfaceRecorder) PatchStatus(ar
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "PatchStatus", arg0, arg1, arg2)
 }
 
+func (_m *MockVirtualMachineInstanceMigrationInterface) Cancel(name string) error {
+	ret := _m.ctrl.Call(_m, "Cancel", name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineInstanceMigrationInterfaceRecorder) Cancel(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Cancel", arg0)
+}
+
+// Mock of VirtualMachineImageExportInterface interface
+type MockVirtualMachineImageExportInterface struct {
+	ctrl     *gomock.Controller
+	recorder *_MockVirtualMachineImageExportInterfaceRecorder
+}
+
+// Recorder for MockVirtualMachineImageExportInterface (not exported)
+type _MockVirtualMachineImageExportInterfaceRecorder struct {
+	mock *MockVirtualMachineImageExportInterface
+}
+
+func NewMockVirtualMachineImageExportInterface(ctrl *gomock.Controller) *MockVirtualMachineImageExportInterface {
+	mock := &MockVirtualMachineImageExportInterface{ctrl: ctrl}
+	mock.recorder = &_MockVirtualMachineImageExportInterfaceRecorder{mock}
+	return mock
+}
+
+func (_m *MockVirtualMachineImageExportInterface) EXPECT() *_MockVirtualMachineImageExportInterfaceRecorder {
+	return _m.recorder
+}
+
+func (_m *MockVirtualMachineImageExportInterface) Get(name string, options *v11.GetOptions) (*v117.VirtualMachineImageExport, error) {
+	ret := _m.ctrl.Call(_m, "Get", name, options)
+	ret0, _ := ret[0].(*v117.VirtualMachineImageExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineImageExportInterfaceRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Get", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineImageExportInterface) List(opts *v11.ListOptions) (*v117.VirtualMachineImageExportList, error) {
+	ret := _m.ctrl.Call(_m, "List", opts)
+	ret0, _ := ret[0].(*v117.VirtualMachineImageExportList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineImageExportInterfaceRecorder) List(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "List", arg0)
+}
+
+func (_m *MockVirtualMachineImageExportInterface) Create(_param0 *v117.VirtualMachineImageExport) (*v117.VirtualMachineImageExport, error) {
+	ret := _m.ctrl.Call(_m, "Create", _param0)
+	ret0, _ := ret[0].(*v117.VirtualMachineImageExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineImageExportInterfaceRecorder) Create(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Create", arg0)
+}
+
+func (_m *MockVirtualMachineImageExportInterface) Update(_param0 *v117.VirtualMachineImageExport) (*v117.VirtualMachineImageExport, error) {
+	ret := _m.ctrl.Call(_m, "Update", _param0)
+	ret0, _ := ret[0].(*v117.VirtualMachineImageExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineImageExportInterfaceRecorder) Update(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Update", arg0)
+}
+
+func (_m *MockVirtualMachineImageExportInterface) Delete(name string, options *v11.DeleteOptions) error {
+	ret := _m.ctrl.Call(_m, "Delete", name, options)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineImageExportInterfaceRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Delete", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineImageExportInterface) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (*v117.VirtualMachineImageExport, error) {
+	_s := []interface{}{name, pt, data}
+	for _, _x := range subresources {
+		_s = append(_s, _x)
+	}
+	ret := _m.ctrl.Call(_m, "Patch", _s...)
+	ret0, _ := ret[0].(*v117.VirtualMachineImageExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineImageExportInterfaceRecorder) Patch(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	_s := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Patch", _s...)
+}
+
+func (_m *MockVirtualMachineImageExportInterface) UpdateStatus(_param0 *v117.VirtualMachineImageExport) (*v117.VirtualMachineImageExport, error) {
+	ret := _m.ctrl.Call(_m, "UpdateStatus", _param0)
+	ret0, _ := ret[0].(*v117.VirtualMachineImageExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineImageExportInterfaceRecorder) UpdateStatus(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "UpdateStatus", arg0)
+}
+
+// Mock of VirtualMachineBulkOperationInterface interface
+type MockVirtualMachineBulkOperationInterface struct {
+	ctrl     *gomock.Controller
+	recorder *_MockVirtualMachineBulkOperationInterfaceRecorder
+}
+
+// Recorder for MockVirtualMachineBulkOperationInterface (not exported)
+type _MockVirtualMachineBulkOperationInterfaceRecorder struct {
+	mock *MockVirtualMachineBulkOperationInterface
+}
+
+func NewMockVirtualMachineBulkOperationInterface(ctrl *gomock.Controller) *MockVirtualMachineBulkOperationInterface {
+	mock := &MockVirtualMachineBulkOperationInterface{ctrl: ctrl}
+	mock.recorder = &_MockVirtualMachineBulkOperationInterfaceRecorder{mock}
+	return mock
+}
+
+func (_m *MockVirtualMachineBulkOperationInterface) EXPECT() *_MockVirtualMachineBulkOperationInterfaceRecorder {
+	return _m.recorder
+}
+
+func (_m *MockVirtualMachineBulkOperationInterface) Get(name string, options *v11.GetOptions) (*v117.VirtualMachineBulkOperation, error) {
+	ret := _m.ctrl.Call(_m, "Get", name, options)
+	ret0, _ := ret[0].(*v117.VirtualMachineBulkOperation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineBulkOperationInterfaceRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Get", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineBulkOperationInterface) List(opts *v11.ListOptions) (*v117.VirtualMachineBulkOperationList, error) {
+	ret := _m.ctrl.Call(_m, "List", opts)
+	ret0, _ := ret[0].(*v117.VirtualMachineBulkOperationList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineBulkOperationInterfaceRecorder) List(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "List", arg0)
+}
+
+func (_m *MockVirtualMachineBulkOperationInterface) Create(_param0 *v117.VirtualMachineBulkOperation) (*v117.VirtualMachineBulkOperation, error) {
+	ret := _m.ctrl.Call(_m, "Create", _param0)
+	ret0, _ := ret[0].(*v117.VirtualMachineBulkOperation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineBulkOperationInterfaceRecorder) Create(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Create", arg0)
+}
+
+func (_m *MockVirtualMachineBulkOperationInterface) Update(_param0 *v117.VirtualMachineBulkOperation) (*v117.VirtualMachineBulkOperation, error) {
+	ret := _m.ctrl.Call(_m, "Update", _param0)
+	ret0, _ := ret[0].(*v117.VirtualMachineBulkOperation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineBulkOperationInterfaceRecorder) Update(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Update", arg0)
+}
+
+func (_m *MockVirtualMachineBulkOperationInterface) Delete(name string, options *v11.DeleteOptions) error {
+	ret := _m.ctrl.Call(_m, "Delete", name, options)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVirtualMachineBulkOperationInterfaceRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Delete", arg0, arg1)
+}
+
+func (_m *MockVirtualMachineBulkOperationInterface) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (*v117.VirtualMachineBulkOperation, error) {
+	_s := []interface{}{name, pt, data}
+	for _, _x := range subresources {
+		_s = append(_s, _x)
+	}
+	ret := _m.ctrl.Call(_m, "Patch", _s...)
+	ret0, _ := ret[0].(*v117.VirtualMachineBulkOperation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineBulkOperationInterfaceRecorder) Patch(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	_s := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Patch", _s...)
+}
+
+func (_m *MockVirtualMachineBulkOperationInterface) UpdateStatus(_param0 *v117.VirtualMachineBulkOperation) (*v117.VirtualMachineBulkOperation, error) {
+	ret := _m.ctrl.Call(_m, "UpdateStatus", _param0)
+	ret0, _ := ret[0].(*v117.VirtualMachineBulkOperation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVirtualMachineBulkOperationInterfaceRecorder) UpdateStatus(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "UpdateStatus", arg0)
+}
+
 // Mock of KubeVirtInterface interface
 type MockKubeVirtInterface struct {
 	ctrl     *gomock.Controller
@@ -0,0 +1,147 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package kubecli
+
+import (
+	"context"
+
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+func (k *kubevirt) VirtualMachineImageExport(namespace string) VirtualMachineImageExportInterface {
+	return &imageExport{
+		restClient: k.restClient,
+		namespace:  namespace,
+		resource:   "virtualmachineimageexports",
+	}
+}
+
+type imageExport struct {
+	restClient *rest.RESTClient
+	namespace  string
+	resource   string
+}
+
+// Create new VirtualMachineImageExport in the cluster to specified namespace
+func (o *imageExport) Create(newExport *v1.VirtualMachineImageExport) (*v1.VirtualMachineImageExport, error) {
+	result := &v1.VirtualMachineImageExport{}
+	err := o.restClient.Post().
+		Resource(o.resource).
+		Namespace(o.namespace).
+		Body(newExport).
+		Do(context.Background()).
+		Into(result)
+
+	result.SetGroupVersionKind(v1.VirtualMachineImageExportGroupVersionKind)
+
+	return result, err
+}
+
+// Get the VirtualMachineImageExport from the cluster by its name and namespace
+func (o *imageExport) Get(name string, options *k8smetav1.GetOptions) (*v1.VirtualMachineImageExport, error) {
+	result := &v1.VirtualMachineImageExport{}
+	err := o.restClient.Get().
+		Resource(o.resource).
+		Namespace(o.namespace).
+		Name(name).
+		VersionedParams(options, scheme.ParameterCodec).
+		Do(context.Background()).
+		Into(result)
+
+	result.SetGroupVersionKind(v1.VirtualMachineImageExportGroupVersionKind)
+
+	return result, err
+}
+
+// Update the VirtualMachineImageExport in the cluster in given namespace
+func (o *imageExport) Update(export *v1.VirtualMachineImageExport) (*v1.VirtualMachineImageExport, error) {
+	result := &v1.VirtualMachineImageExport{}
+	err := o.restClient.Put().
+		Resource(o.resource).
+		Namespace(o.namespace).
+		Name(export.Name).
+		Body(export).
+		Do(context.Background()).
+		Into(result)
+
+	result.SetGroupVersionKind(v1.VirtualMachineImageExportGroupVersionKind)
+
+	return result, err
+}
+
+// Delete the defined VirtualMachineImageExport in the cluster in defined namespace
+func (o *imageExport) Delete(name string, options *k8smetav1.DeleteOptions) error {
+	return o.restClient.Delete().
+		Resource(o.resource).
+		Namespace(o.namespace).
+		Name(name).
+		Body(options).
+		Do(context.Background()).
+		Error()
+}
+
+// List all VirtualMachineImageExports in given namespace
+func (o *imageExport) List(options *k8smetav1.ListOptions) (*v1.VirtualMachineImageExportList, error) {
+	list := &v1.VirtualMachineImageExportList{}
+	err := o.restClient.Get().
+		Resource(o.resource).
+		Namespace(o.namespace).
+		VersionedParams(options, scheme.ParameterCodec).
+		Do(context.Background()).
+		Into(list)
+
+	for i := range list.Items {
+		list.Items[i].SetGroupVersionKind(v1.VirtualMachineImageExportGroupVersionKind)
+	}
+
+	return list, err
+}
+
+func (o *imageExport) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.VirtualMachineImageExport, err error) {
+	result = &v1.VirtualMachineImageExport{}
+	err = o.restClient.Patch(pt).
+		Namespace(o.namespace).
+		Resource(o.resource).
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do(context.Background()).
+		Into(result)
+	return result, err
+}
+
+func (o *imageExport) UpdateStatus(export *v1.VirtualMachineImageExport) (result *v1.VirtualMachineImageExport, err error) {
+	result = &v1.VirtualMachineImageExport{}
+	err = o.restClient.Put().
+		Name(export.ObjectMeta.Name).
+		Namespace(o.namespace).
+		Resource(o.resource).
+		SubResource("status").
+		Body(export).
+		Do(context.Background()).
+		Into(result)
+	result.SetGroupVersionKind(v1.VirtualMachineImageExportGroupVersionKind)
+	return
+}
@@ -0,0 +1,124 @@
+/*
+ * This file is part of the KubeVirt project
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * Copyright 2021 Red Hat, Inc.
+ *
+ */
+
+package kubecli
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	k8sv1 "k8s.io/api/core/v1"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("Kubevirt ImageExport Client", func() {
+
+	var server *ghttp.Server
+	var client KubevirtClient
+	basePath := "/apis/kubevirt.io/v1alpha3/namespaces/default/virtualmachineimageexports"
+	exportPath := basePath + "/testexport"
+
+	newExport := func() *v1.VirtualMachineImageExport {
+		return &v1.VirtualMachineImageExport{
+			TypeMeta:   k8smetav1.TypeMeta{APIVersion: v1.GroupVersion.String(), Kind: "VirtualMachineImageExport"},
+			ObjectMeta: k8smetav1.ObjectMeta{Name: "testexport", Namespace: k8sv1.NamespaceDefault},
+			Spec:       v1.VirtualMachineImageExportSpec{Source: "testvm", Image: "registry.example.com/images/testvm:latest"},
+		}
+	}
+
+	BeforeEach(func() {
+		var err error
+		server = ghttp.NewServer()
+		client, err = GetKubevirtClientFromFlags(server.URL(), "")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should fetch an ImageExport", func() {
+		export := newExport()
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", exportPath),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, export),
+		))
+		fetchedExport, err := client.VirtualMachineImageExport(k8sv1.NamespaceDefault).Get("testexport", &k8smetav1.GetOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetchedExport).To(Equal(export))
+	})
+
+	It("should fetch an ImageExport list", func() {
+		export := newExport()
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", basePath),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, &v1.VirtualMachineImageExportList{Items: []v1.VirtualMachineImageExport{*export}}),
+		))
+		fetchedList, err := client.VirtualMachineImageExport(k8sv1.NamespaceDefault).List(&k8smetav1.ListOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetchedList.Items).To(HaveLen(1))
+		Expect(fetchedList.Items[0]).To(Equal(*export))
+	})
+
+	It("should create an ImageExport", func() {
+		export := newExport()
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("POST", basePath),
+			ghttp.RespondWithJSONEncoded(http.StatusCreated, export),
+		))
+		createdExport, err := client.VirtualMachineImageExport(k8sv1.NamespaceDefault).Create(export)
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(createdExport).To(Equal(export))
+	})
+
+	It("should update the status of an ImageExport", func() {
+		export := newExport()
+		export.Status.Phase = v1.ImageExportSucceeded
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", exportPath+"/status"),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, export),
+		))
+		updatedExport, err := client.VirtualMachineImageExport(k8sv1.NamespaceDefault).UpdateStatus(export)
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(updatedExport).To(Equal(export))
+	})
+
+	It("should delete an ImageExport", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("DELETE", exportPath),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, nil),
+		))
+		err := client.VirtualMachineImageExport(k8sv1.NamespaceDefault).Delete("testexport", &k8smetav1.DeleteOptions{})
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+})
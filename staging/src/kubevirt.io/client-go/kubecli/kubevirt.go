@@ -60,6 +60,8 @@ type KubevirtClient interface {
 	VirtualMachineSnapshot(namespace string) vmsnapshotv1alpha1.VirtualMachineSnapshotInterface
 	VirtualMachineSnapshotContent(namespace string) vmsnapshotv1alpha1.VirtualMachineSnapshotContentInterface
 	VirtualMachineRestore(namespace string) vmsnapshotv1alpha1.VirtualMachineRestoreInterface
+	VirtualMachineImageExport(namespace string) VirtualMachineImageExportInterface
+	VirtualMachineBulkOperation(namespace string) VirtualMachineBulkOperationInterface
 	ServerVersion() *ServerVersion
 	GuestfsVersion() *GuestfsVersion
 	RestClient() *rest.RESTClient
@@ -175,11 +177,16 @@ type VirtualMachineInstanceInterface interface {
 	Unpause(name string) error
 	Freeze(name string) error
 	Unfreeze(name string) error
+	SnapshotMemory(name string) error
+	UploadMemory(name string, options *v1.MemorySnapshotOptions) (*v1.MemorySnapshotUploadStatus, error)
 	GuestOsInfo(name string) (v1.VirtualMachineInstanceGuestAgentInfo, error)
 	UserList(name string) (v1.VirtualMachineInstanceGuestOSUserList, error)
 	FilesystemList(name string) (v1.VirtualMachineInstanceFileSystemList, error)
+	Usage(name string) (*v1.VirtualMachineInstanceUsage, error)
+	Screenshot(name string) ([]byte, error)
 	AddVolume(name string, addVolumeOptions *v1.AddVolumeOptions) error
 	RemoveVolume(name string, removeVolumeOptions *v1.RemoveVolumeOptions) error
+	SetLinkState(name string, setLinkStateOptions *v1.SetLinkStateOptions) error
 }
 
 type ReplicaSetInterface interface {
@@ -221,6 +228,9 @@ type VirtualMachineInterface interface {
 	Stop(name string) error
 	ForceStop(name string, graceperiod int) error
 	Migrate(name string) error
+	BulkOperation(bulkOperationOptions *v1.BulkOperationOptions) (*v1.BulkOperation, error)
+	BulkOperationProgress(handle string) (*v1.BulkOperation, error)
+	SchedulingFeasibility(schedulingFeasibilityOptions *v1.SchedulingFeasibilityOptions) (*v1.SchedulingFeasibility, error)
 	AddVolume(name string, addVolumeOptions *v1.AddVolumeOptions) error
 	RemoveVolume(name string, removeVolumeOptions *v1.RemoveVolumeOptions) error
 	PortForward(name string, port int, protocol string) (StreamInterface, error)
@@ -235,6 +245,27 @@ type VirtualMachineInstanceMigrationInterface interface {
 	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.VirtualMachineInstanceMigration, err error)
 	UpdateStatus(*v1.VirtualMachineInstanceMigration) (*v1.VirtualMachineInstanceMigration, error)
 	PatchStatus(name string, pt types.PatchType, data []byte) (result *v1.VirtualMachineInstanceMigration, err error)
+	Cancel(name string) error
+}
+
+type VirtualMachineImageExportInterface interface {
+	Get(name string, options *k8smetav1.GetOptions) (*v1.VirtualMachineImageExport, error)
+	List(opts *k8smetav1.ListOptions) (*v1.VirtualMachineImageExportList, error)
+	Create(*v1.VirtualMachineImageExport) (*v1.VirtualMachineImageExport, error)
+	Update(*v1.VirtualMachineImageExport) (*v1.VirtualMachineImageExport, error)
+	Delete(name string, options *k8smetav1.DeleteOptions) error
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.VirtualMachineImageExport, err error)
+	UpdateStatus(*v1.VirtualMachineImageExport) (*v1.VirtualMachineImageExport, error)
+}
+
+type VirtualMachineBulkOperationInterface interface {
+	Get(name string, options *k8smetav1.GetOptions) (*v1.VirtualMachineBulkOperation, error)
+	List(opts *k8smetav1.ListOptions) (*v1.VirtualMachineBulkOperationList, error)
+	Create(*v1.VirtualMachineBulkOperation) (*v1.VirtualMachineBulkOperation, error)
+	Update(*v1.VirtualMachineBulkOperation) (*v1.VirtualMachineBulkOperation, error)
+	Delete(name string, options *k8smetav1.DeleteOptions) error
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.VirtualMachineBulkOperation, err error)
+	UpdateStatus(*v1.VirtualMachineBulkOperation) (*v1.VirtualMachineBulkOperation, error)
 }
 
 type KubeVirtInterface interface {
@@ -21,6 +21,7 @@ package kubecli
 
 import (
 	"context"
+	"fmt"
 
 	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -30,6 +31,8 @@ import (
 	v1 "kubevirt.io/client-go/api/v1"
 )
 
+const migrationSubresourceURL = "/apis/subresources.kubevirt.io/%s/namespaces/%s/virtualmachineinstancemigrations/%s/%s"
+
 func (k *kubevirt) VirtualMachineInstanceMigration(namespace string) VirtualMachineInstanceMigrationInterface {
 	return &migration{
 		restClient: k.restClient,
@@ -160,3 +163,9 @@ func (v *migration) UpdateStatus(vmi *v1.VirtualMachineInstanceMigration) (resul
 	result.SetGroupVersionKind(v1.VirtualMachineInstanceMigrationGroupVersionKind)
 	return
 }
+
+// Cancel requests the abort of an in-flight VirtualMachineInstanceMigration
+func (v *migration) Cancel(name string) error {
+	uri := fmt.Sprintf(migrationSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "cancel")
+	return v.restClient.Put().RequestURI(uri).Do(context.Background()).Error()
+}
@@ -34,6 +34,8 @@ import (
 )
 
 const vmSubresourceURL = "/apis/subresources.kubevirt.io/%s/namespaces/%s/virtualmachines/%s/%s"
+const bulkOperationsURL = "/apis/subresources.kubevirt.io/%s/namespaces/%s/bulkoperations"
+const schedulingFeasibilityURL = "/apis/subresources.kubevirt.io/%s/namespaces/%s/schedulingfeasibility"
 
 func (k *kubevirt) VirtualMachine(namespace string) VirtualMachineInterface {
 	return &vm{
@@ -213,6 +215,61 @@ func (v *vm) Migrate(name string) error {
 	return v.restClient.Put().RequestURI(uri).Do(context.Background()).Error()
 }
 
+// BulkOperation starts, stops or migrates all VirtualMachines matching the selector of the options
+func (v *vm) BulkOperation(bulkOperationOptions *v1.BulkOperationOptions) (*v1.BulkOperation, error) {
+	uri := fmt.Sprintf(bulkOperationsURL, v1.ApiStorageVersion, v.namespace)
+
+	optsJson, err := json.Marshal(bulkOperationOptions)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := v.restClient.Put().RequestURI(uri).SetHeader("Content-Type", "application/json").Body(optsJson).Do(context.Background()).Raw()
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalBulkOperation(raw)
+}
+
+// BulkOperationProgress returns the progress of the bulk operation identified by handle
+func (v *vm) BulkOperationProgress(handle string) (*v1.BulkOperation, error) {
+	uri := fmt.Sprintf(bulkOperationsURL, v1.ApiStorageVersion, v.namespace) + "/" + handle
+
+	raw, err := v.restClient.Get().RequestURI(uri).Do(context.Background()).Raw()
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalBulkOperation(raw)
+}
+
+func unmarshalBulkOperation(raw []byte) (*v1.BulkOperation, error) {
+	// BulkOperation has no ObjectMeta, so it can't be decoded with Into
+	bulkOperation := &v1.BulkOperation{}
+	if err := json.Unmarshal(raw, bulkOperation); err != nil {
+		return nil, fmt.Errorf("Cannot unmarshal bulk operation response: %v", err)
+	}
+	return bulkOperation, nil
+}
+
+// SchedulingFeasibility checks if any node could currently run the VirtualMachine described by the options
+func (v *vm) SchedulingFeasibility(schedulingFeasibilityOptions *v1.SchedulingFeasibilityOptions) (*v1.SchedulingFeasibility, error) {
+	uri := fmt.Sprintf(schedulingFeasibilityURL, v1.ApiStorageVersion, v.namespace)
+
+	optsJson, err := json.Marshal(schedulingFeasibilityOptions)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := v.restClient.Put().RequestURI(uri).SetHeader("Content-Type", "application/json").Body(optsJson).Do(context.Background()).Raw()
+	if err != nil {
+		return nil, err
+	}
+	// SchedulingFeasibility has no ObjectMeta, so it can't be decoded with Into
+	feasibility := &v1.SchedulingFeasibility{}
+	if err := json.Unmarshal(raw, feasibility); err != nil {
+		return nil, fmt.Errorf("Cannot unmarshal scheduling feasibility response: %v", err)
+	}
+	return feasibility, nil
+}
+
 func (v *vm) AddVolume(name string, addVolumeOptions *v1.AddVolumeOptions) error {
 	uri := fmt.Sprintf(vmSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "addvolume")
 
@@ -187,6 +187,57 @@ var _ = Describe("Kubevirt VirtualMachine Client", func() {
 		Expect(err).ToNot(HaveOccurred())
 	})
 
+	It("should request a bulk operation", func() {
+		bulkPath := fmt.Sprintf("/apis/subresources.kubevirt.io/%s/namespaces/default/bulkoperations", virtv1.SubresourceStorageGroupVersion.Version)
+		opts := &virtv1.BulkOperationOptions{Action: virtv1.BulkStopAction, Selector: "app=db"}
+		bulkOperation := &virtv1.BulkOperation{Handle: "abc", Action: virtv1.BulkStopAction, Selector: "app=db", Total: 2}
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", bulkPath),
+			ghttp.VerifyJSONRepresenting(opts),
+			ghttp.RespondWithJSONEncoded(http.StatusAccepted, bulkOperation),
+		))
+		result, err := client.VirtualMachine(k8sv1.NamespaceDefault).BulkOperation(opts)
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(bulkOperation))
+	})
+
+	It("should fetch the progress of a bulk operation", func() {
+		bulkPath := fmt.Sprintf("/apis/subresources.kubevirt.io/%s/namespaces/default/bulkoperations/abc", virtv1.SubresourceStorageGroupVersion.Version)
+		bulkOperation := &virtv1.BulkOperation{Handle: "abc", Action: virtv1.BulkStopAction, Selector: "app=db", Total: 2, Completed: 1}
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", bulkPath),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, bulkOperation),
+		))
+		result, err := client.VirtualMachine(k8sv1.NamespaceDefault).BulkOperationProgress("abc")
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(bulkOperation))
+	})
+
+	It("should check the scheduling feasibility of a VirtualMachine", func() {
+		feasibilityPath := fmt.Sprintf("/apis/subresources.kubevirt.io/%s/namespaces/default/schedulingfeasibility", virtv1.SubresourceStorageGroupVersion.Version)
+		opts := &virtv1.SchedulingFeasibilityOptions{Spec: virtv1.VirtualMachineSpec{
+			Template: &virtv1.VirtualMachineInstanceTemplateSpec{},
+		}}
+		feasibility := &virtv1.SchedulingFeasibility{
+			Feasible:     true,
+			NodeFailures: []string{"node is cordoned"},
+		}
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", feasibilityPath),
+			ghttp.VerifyJSONRepresenting(opts),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, feasibility),
+		))
+		result, err := client.VirtualMachine(k8sv1.NamespaceDefault).SchedulingFeasibility(opts)
+
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(feasibility))
+	})
+
 	AfterEach(func() {
 		server.Close()
 	})
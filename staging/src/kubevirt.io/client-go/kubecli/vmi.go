@@ -244,6 +244,33 @@ func (v *vmis) Unfreeze(name string) error {
 	return v.restClient.Put().RequestURI(uri).Do(context.Background()).Error()
 }
 
+func (v *vmis) SnapshotMemory(name string) error {
+	log.Log.Infof("Snapshot VMI memory")
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "memorysnapshot")
+	return v.restClient.Put().RequestURI(uri).Do(context.Background()).Error()
+}
+
+func (v *vmis) UploadMemory(name string, options *v1.MemorySnapshotOptions) (*v1.MemorySnapshotUploadStatus, error) {
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "memorysnapshot")
+
+	JSON, err := json.Marshal(options)
+	if err != nil {
+		return nil, err
+	}
+
+	// the status is no runtime.Object, see GuestOsInfo
+	rawStatus, err := v.restClient.Put().RequestURI(uri).Body(JSON).Do(context.Background()).Raw()
+	if err != nil {
+		return nil, err
+	}
+
+	status := &v1.MemorySnapshotUploadStatus{}
+	if err := json.Unmarshal(rawStatus, status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
 func (v *vmis) Pause(name string) error {
 	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "pause")
 	return v.restClient.Put().RequestURI(uri).Do(context.Background()).Error()
@@ -419,6 +446,18 @@ func (v *vmis) FilesystemList(name string) (v1.VirtualMachineInstanceFileSystemL
 	return fsList, err
 }
 
+func (v *vmis) Usage(name string) (*v1.VirtualMachineInstanceUsage, error) {
+	usage := &v1.VirtualMachineInstanceUsage{}
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "usage")
+	err := v.restClient.Get().RequestURI(uri).Do(context.Background()).Into(usage)
+	return usage, err
+}
+
+func (v *vmis) Screenshot(name string) ([]byte, error) {
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "screenshot")
+	return v.restClient.Get().RequestURI(uri).Do(context.Background()).Raw()
+}
+
 func (v *vmis) AddVolume(name string, addVolumeOptions *v1.AddVolumeOptions) error {
 	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "addvolume")
 
@@ -442,3 +481,15 @@ func (v *vmis) RemoveVolume(name string, removeVolumeOptions *v1.RemoveVolumeOpt
 
 	return v.restClient.Put().RequestURI(uri).Body([]byte(JSON)).Do(context.Background()).Error()
 }
+
+func (v *vmis) SetLinkState(name string, setLinkStateOptions *v1.SetLinkStateOptions) error {
+	uri := fmt.Sprintf(vmiSubresourceURL, v1.ApiStorageVersion, v.namespace, name, "setlinkstate")
+
+	JSON, err := json.Marshal(setLinkStateOptions)
+
+	if err != nil {
+		return err
+	}
+
+	return v.restClient.Put().RequestURI(uri).Body([]byte(JSON)).Do(context.Background()).Error()
+}
@@ -20,9 +20,11 @@
 package kubecli
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/websocket"
 	. "github.com/onsi/ginkgo"
@@ -30,6 +32,7 @@ import (
 	"github.com/onsi/gomega/ghttp"
 	k8sv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
@@ -314,6 +317,77 @@ var _ = Describe("Kubevirt VirtualMachineInstance Client", func() {
 		Expect(fetchedInfo).To(Equal(fileSystemList), "fetched info should be the same as passed in")
 	})
 
+	It("should fetch the usage of a VirtualMachineInstance via subresource", func() {
+		usage := &v1.VirtualMachineInstanceUsage{
+			Window: k8smetav1.Duration{Duration: time.Second},
+			Usage: k8sv1.ResourceList{
+				k8sv1.ResourceCPU:    resource.MustParse("500m"),
+				k8sv1.ResourceMemory: resource.MustParse("1Gi"),
+			},
+		}
+
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", subVMPath+"/usage"),
+			ghttp.RespondWithJSONEncoded(http.StatusOK, usage),
+		))
+		fetchedUsage, err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).Usage("testvm")
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fetchedUsage.Window).To(Equal(usage.Window))
+		Expect(fetchedUsage.Usage.Cpu().Equal(resource.MustParse("500m"))).To(BeTrue())
+		Expect(fetchedUsage.Usage.Memory().Equal(resource.MustParse("1Gi"))).To(BeTrue())
+	})
+
+	It("should fetch the screenshot of a VirtualMachineInstance via subresource", func() {
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", subVMPath+"/screenshot"),
+			ghttp.RespondWith(http.StatusOK, []byte("png"), http.Header{"Content-Type": []string{"image/png"}}),
+		))
+		screenshot, err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).Screenshot("testvm")
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(screenshot).To(Equal([]byte("png")))
+	})
+
+	It("should upload the memory of a VirtualMachineInstance via subresource", func() {
+		options := &v1.MemorySnapshotOptions{
+			ObjectStorage: &v1.MemorySnapshotObjectStorage{
+				Endpoint: "https://minio.local",
+				Bucket:   "memory",
+				Key:      "vmsnapshot-memory",
+			},
+		}
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", subVMPath+"/memorysnapshot"),
+			func(w http.ResponseWriter, r *http.Request) {
+				body := &v1.MemorySnapshotOptions{}
+				Expect(json.NewDecoder(r.Body).Decode(body)).To(Succeed())
+				Expect(body).To(Equal(options))
+			},
+			ghttp.RespondWithJSONEncoded(http.StatusOK, v1.MemorySnapshotUploadStatus{Phase: v1.MemorySnapshotUploadInProgress}),
+		))
+		status, err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).UploadMemory("testvm", options)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status.Phase).To(Equal(v1.MemorySnapshotUploadInProgress))
+	})
+
+	It("should set the link state of an interface of a VirtualMachineInstance via subresource", func() {
+		options := &v1.SetLinkStateOptions{InterfaceName: "default", State: v1.InterfaceLinkStateDown}
+		server.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest("PUT", subVMPath+"/setlinkstate"),
+			func(w http.ResponseWriter, r *http.Request) {
+				body := &v1.SetLinkStateOptions{}
+				Expect(json.NewDecoder(r.Body).Decode(body)).To(Succeed())
+				Expect(body).To(Equal(options))
+			},
+			ghttp.RespondWith(http.StatusOK, nil),
+		))
+		err := client.VirtualMachineInstance(k8sv1.NamespaceDefault).SetLinkState("testvm", options)
+
+		Expect(err).ToNot(HaveOccurred())
+	})
+
 	AfterEach(func() {
 		server.Close()
 	})
@@ -1081,17 +1081,7 @@ var _ = Describe("[sig-compute]Configurations", func() {
 			)
 
 			Context("with unsupported page size", func() {
-				It("[test_id:1673]should failed to schedule the pod", func() {
-					nodes, err := virtClient.CoreV1().Nodes().List(context.Background(), metav1.ListOptions{})
-					Expect(err).ToNot(HaveOccurred())
-
-					hugepageType2Mi := kubev1.ResourceName(kubev1.ResourceHugePagesPrefix + "2Mi")
-					for _, node := range nodes.Items {
-						if _, ok := node.Status.Capacity[hugepageType2Mi]; !ok {
-							Skip("No nodes with hugepages support")
-						}
-					}
-
+				It("[test_id:1673]should be rejected on creation", func() {
 					hugepagesVmi.Spec.Domain.Resources.Requests[kubev1.ResourceMemory] = resource.MustParse("66Mi")
 
 					hugepagesVmi.Spec.Domain.Memory = &v1.Memory{
@@ -1100,25 +1090,8 @@ var _ = Describe("[sig-compute]Configurations", func() {
 
 					By("Starting a VM")
 					_, err = virtClient.VirtualMachineInstance(util.NamespaceTestDefault).Create(hugepagesVmi)
-					Expect(err).ToNot(HaveOccurred())
-
-					var vmiCondition v1.VirtualMachineInstanceCondition
-					Eventually(func() bool {
-						vmi, err := virtClient.VirtualMachineInstance(util.NamespaceTestDefault).Get(hugepagesVmi.Name, &metav1.GetOptions{})
-						Expect(err).ToNot(HaveOccurred())
-
-						if len(vmi.Status.Conditions) > 0 {
-							for _, cond := range vmi.Status.Conditions {
-								if cond.Type == v1.VirtualMachineInstanceConditionType(kubev1.PodScheduled) && cond.Status == kubev1.ConditionFalse {
-									vmiCondition = cond
-									return true
-								}
-							}
-						}
-						return false
-					}, 30*time.Second, time.Second).Should(BeTrue())
-					Expect(vmiCondition.Message).To(ContainSubstring("Insufficient hugepages-3Mi"))
-					Expect(vmiCondition.Reason).To(Equal("Unschedulable"))
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("spec.domain.memory.hugepages.pageSize '3Mi' is not supported"))
 				})
 			})
 		})
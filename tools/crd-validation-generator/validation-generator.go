@@ -69,7 +69,9 @@ func generateGoFile(outputDir string, validations map[string]*extv1.CustomResour
 	for _, crdname := range crds {
 		crd := validations[crdname]
 		b, _ := yaml.Marshal(crd)
-		file.WriteString(fmt.Sprintf(variable, crdname, string(b)))
+		// backticks can't be part of a raw string literal, concatenate them as interpreted strings
+		validation := strings.ReplaceAll(string(b), "`", "` + \"`\" + `")
+		file.WriteString(fmt.Sprintf(variable, crdname, validation))
 	}
 	file.WriteString("}\n")
 
@@ -50,6 +50,15 @@ const (
 
 	vmiEvictionBlockerName = "kubevirt_vmi_non_evictable"
 	vmiEvictionBlockerDesc = "Indication for a VirtualMachine that its eviction strategy is set to Live Migration but is not migratable."
+
+	vmiMemoryOverheadName = "kubevirt_vmi_memory_overhead_bytes"
+	vmiMemoryOverheadDesc = "Memory which was added to the virt-launcher pod of the VMI on top of the guest memory."
+
+	vmiCPUOverheadName = "kubevirt_vmi_cpu_overhead_cores"
+	vmiCPUOverheadDesc = "CPU which was added to the virt-launcher pod of the VMI on top of the guest CPUs."
+
+	vmiGuestOSInfoName = "kubevirt_vmi_guest_os_info"
+	vmiGuestOSInfoDesc = "The guest operating system of the VMI as reported by the guest agent."
 )
 
 func main() {
@@ -137,6 +146,18 @@ var (
 			name:        vmiEvictionBlockerName,
 			description: vmiEvictionBlockerDesc,
 		},
+		{
+			name:        vmiMemoryOverheadName,
+			description: vmiMemoryOverheadDesc,
+		},
+		{
+			name:        vmiCPUOverheadName,
+			description: vmiCPUOverheadDesc,
+		},
+		{
+			name:        vmiGuestOSInfoName,
+			description: vmiGuestOSInfoDesc,
+		},
 	}
 )
 